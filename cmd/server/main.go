@@ -15,6 +15,7 @@ import (
 	"github.com/dujiao-next/internal/app"
 	databasemigrations "github.com/dujiao-next/internal/bootstrap/database/migrations"
 	"github.com/dujiao-next/internal/config"
+	"github.com/dujiao-next/internal/i18ncmd"
 	"github.com/dujiao-next/internal/logger"
 	adminapplication "github.com/dujiao-next/internal/modules/identity/admin/application"
 	adminstore "github.com/dujiao-next/internal/modules/identity/admin/infrastructure/gormstore"
@@ -46,6 +47,12 @@ func main() {
 		return
 	}
 
+	// i18n 子命令只检查语言包文件，不连接数据库。
+	if len(os.Args) >= 2 && os.Args[1] == "i18n" {
+		runI18nSubcommand(os.Args[2:])
+		return
+	}
+
 	// 必须排在 config.Load 之前：回滚存在的意义就是新版本起不来，
 	// 而起不来的原因很可能正是配置或数据库本身。
 	if len(os.Args) >= 2 && os.Args[1] == "rollback" {
@@ -380,6 +387,11 @@ func runAdminSubcommand(args []string) {
 	admincmd.Run(args)
 }
 
+func runI18nSubcommand(args []string) {
+	cfg := config.Load()
+	i18ncmd.Run(args, cfg.I18n.LocalesDir)
+}
+
 // resolveDefaultAdminCredentials 解析默认管理员初始化凭据（环境变量优先，其次 config.yml）
 func resolveDefaultAdminCredentials(cfg *config.Config) (string, string) {
 	user := strings.TrimSpace(os.Getenv("DJ_DEFAULT_ADMIN_USERNAME"))
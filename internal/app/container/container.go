@@ -23,6 +23,8 @@ import (
 	productadmin "github.com/dujiao-next/internal/modules/catalog/product/application/admin"
 	productwrite "github.com/dujiao-next/internal/modules/catalog/product/application/write"
 	productgormstore "github.com/dujiao-next/internal/modules/catalog/product/store/gormstore"
+	changesetapp "github.com/dujiao-next/internal/modules/changeset/application"
+	changesetgormstore "github.com/dujiao-next/internal/modules/changeset/infrastructure/gormstore"
 	channelclientapp "github.com/dujiao-next/internal/modules/channelclient/application"
 	channelclientcontract "github.com/dujiao-next/internal/modules/channelclient/contract"
 	complianceapp "github.com/dujiao-next/internal/modules/compliance/application"
@@ -35,6 +37,12 @@ import (
 	downstreamcallbackcontract "github.com/dujiao-next/internal/modules/downstreamcallback/contract"
 	fulfillmentapp "github.com/dujiao-next/internal/modules/fulfillment/application"
 	fulfillmentcontract "github.com/dujiao-next/internal/modules/fulfillment/contract"
+	filesapp "github.com/dujiao-next/internal/modules/fulfillment/files/application"
+	filesgormstore "github.com/dujiao-next/internal/modules/fulfillment/files/infrastructure/gormstore"
+	webhookapp "github.com/dujiao-next/internal/modules/fulfillment/webhook/application"
+	webhookcontract "github.com/dujiao-next/internal/modules/fulfillment/webhook/contract"
+	fxrateapp "github.com/dujiao-next/internal/modules/fxrate/application"
+	fxrategormstore "github.com/dujiao-next/internal/modules/fxrate/infrastructure/gormstore"
 	giftcardapp "github.com/dujiao-next/internal/modules/giftcard/application"
 	giftcardgormstore "github.com/dujiao-next/internal/modules/giftcard/infrastructure/gormstore"
 	admincontract "github.com/dujiao-next/internal/modules/identity/admin/contract"
@@ -43,10 +51,13 @@ import (
 	emailverificationcontract "github.com/dujiao-next/internal/modules/identity/emailverification/contract"
 	externalidentitycontract "github.com/dujiao-next/internal/modules/identity/externalidentity/contract"
 	googleauthapp "github.com/dujiao-next/internal/modules/identity/googleauth/application"
+	oidcauthapp "github.com/dujiao-next/internal/modules/identity/oidcauth/application"
 	telegramauthapp "github.com/dujiao-next/internal/modules/identity/telegramauth/application"
 	usercontract "github.com/dujiao-next/internal/modules/identity/user/contract"
 	userauthapp "github.com/dujiao-next/internal/modules/identity/userauth/application"
 	usertotpapp "github.com/dujiao-next/internal/modules/identity/userauth/totp/application"
+	licenseapp "github.com/dujiao-next/internal/modules/license/application"
+	licensegormstore "github.com/dujiao-next/internal/modules/license/infrastructure/gormstore"
 	memberlevelapp "github.com/dujiao-next/internal/modules/memberlevel/application"
 	memberlevelcontract "github.com/dujiao-next/internal/modules/memberlevel/contract"
 	memberlevelgormstore "github.com/dujiao-next/internal/modules/memberlevel/infrastructure/gormstore"
@@ -57,22 +68,38 @@ import (
 	orderrefund "github.com/dujiao-next/internal/modules/order/application/refund"
 	ordercontract "github.com/dujiao-next/internal/modules/order/contract"
 	orderriskapp "github.com/dujiao-next/internal/modules/orderrisk/application"
+	orderriskcontract "github.com/dujiao-next/internal/modules/orderrisk/contract"
 	paymentapp "github.com/dujiao-next/internal/modules/payment/application"
 	paymentcontract "github.com/dujiao-next/internal/modules/payment/contract"
 	paymentprovider "github.com/dujiao-next/internal/modules/payment/infrastructure/gateway/provider"
+	payoutapp "github.com/dujiao-next/internal/modules/payout/application"
+	payoutgormstore "github.com/dujiao-next/internal/modules/payout/infrastructure/gormstore"
+	preorderapp "github.com/dujiao-next/internal/modules/preorder/application"
+	preordergormstore "github.com/dujiao-next/internal/modules/preorder/infrastructure/gormstore"
 	procurementapp "github.com/dujiao-next/internal/modules/procurement/application"
 	procurementgormstore "github.com/dujiao-next/internal/modules/procurement/infrastructure/gormstore"
 	promotionapp "github.com/dujiao-next/internal/modules/promotion/application"
 	promotiongormstore "github.com/dujiao-next/internal/modules/promotion/infrastructure/gormstore"
 	reconciliationapp "github.com/dujiao-next/internal/modules/reconciliation/application"
 	reconciliationcontract "github.com/dujiao-next/internal/modules/reconciliation/contract"
+	refundpropagationapp "github.com/dujiao-next/internal/modules/refundpropagation/application"
+	refundpropagationgormstore "github.com/dujiao-next/internal/modules/refundpropagation/infrastructure/gormstore"
 	reseller "github.com/dujiao-next/internal/modules/reseller/application"
 	resellergormstore "github.com/dujiao-next/internal/modules/reseller/infrastructure/gormstore"
+	resellerstaffapp "github.com/dujiao-next/internal/modules/reseller/staff/application"
+	resellerstaffcontract "github.com/dujiao-next/internal/modules/reseller/staff/contract"
+	restockapp "github.com/dujiao-next/internal/modules/restock/application"
+	restockgormstore "github.com/dujiao-next/internal/modules/restock/infrastructure/gormstore"
 	settingsapp "github.com/dujiao-next/internal/modules/settings/application"
+	settingsversioning "github.com/dujiao-next/internal/modules/settings/application/versioning"
 	settingscontract "github.com/dujiao-next/internal/modules/settings/contract"
 	siteconnectionapp "github.com/dujiao-next/internal/modules/siteconnection/application"
 	siteconnectioncontract "github.com/dujiao-next/internal/modules/siteconnection/contract"
 	sitemapapp "github.com/dujiao-next/internal/modules/sitemap/application"
+	stockledgerapp "github.com/dujiao-next/internal/modules/stockledger/application"
+	stockledgergormstore "github.com/dujiao-next/internal/modules/stockledger/infrastructure/gormstore"
+	stockthresholdapp "github.com/dujiao-next/internal/modules/stockthreshold/application"
+	stockthresholdgormstore "github.com/dujiao-next/internal/modules/stockthreshold/infrastructure/gormstore"
 	broadcastapp "github.com/dujiao-next/internal/modules/telegram/broadcast/application"
 	broadcastcontract "github.com/dujiao-next/internal/modules/telegram/broadcast/contract"
 	uploadapp "github.com/dujiao-next/internal/modules/upload/application"
@@ -88,46 +115,69 @@ type Container struct {
 	QueueClient *queue.Client
 
 	// Repositories
-	AdminStore             admincontract.Store
-	UserStore              usercontract.Store
-	ExternalIdentityStore  externalidentitycontract.Store
-	EmailVerificationStore emailverificationcontract.Store
-	OrderStore             ordercontract.Store
-	PaymentStore           paymentcontract.Store
-	PaymentChannelStore    paymentcontract.ChannelStore
-	CardSecretRepo         *cardsecretgormstore.Store
-	CardSecretBatchRepo    *cardsecretgormstore.BatchStore
-	GiftCardRepo           *giftcardgormstore.Store
-	FulfillmentStore       fulfillmentcontract.Store
-	ProductRepo            *productgormstore.ProductStore
-	ProductSKURepo         *productgormstore.SKUStore
-	CartRepo               *cartgormstore.Store
-	CouponRepo             *coupongormstore.Store
-	CouponUsageRepo        *coupongormstore.UsageStore
-	PromotionRepo          *promotiongormstore.Store
-	WalletRepo             *walletgormstore.Store
-	CategoryRepo           categorycontract.Repository
-	SettingRepo            settingscontract.Store
-	UserLoginLogRepo       auditlogcontract.UserLoginRepository
-	AuthzAuditLogRepo      auditlogcontract.AuthzRepository
-	NotificationLogRepo    *notificationgormstore.LogStore
-	AdminLoginLogRepo      auditlogcontract.AdminLoginRepository
-	DashboardRepo          dashboardcontract.Repository
-	AffiliateRepo          affiliatecontract.Store
-	ResellerStore          *resellergormstore.Store
-	ApiCredentialRepo      apicredentialcontract.Repository
-	SiteConnectionRepo     siteconnectioncontract.Repository
-	ProductMappingRepo     *mappinggormstore.MappingStore
-	SKUMappingRepo         *mappinggormstore.SKUMappingStore
-	ProcurementOrderRepo   *procurementgormstore.Store
-	DownstreamOrderRefRepo downstreamcallbackcontract.Repository
-	ReconciliationJobRepo  reconciliationcontract.JobRepository
-	ReconciliationItemRepo reconciliationcontract.ItemRepository
-	ChannelClientStore     channelclientcontract.Store
-	TelegramBroadcastRepo  broadcastcontract.Store
-	MemberLevelRepo        memberlevelcontract.LevelRepository
-	MemberLevelPriceRepo   *memberlevelgormstore.PriceStore
-	MemberLevelUserRepo    memberlevelcontract.UserRepository
+	AdminStore               admincontract.Store
+	UserStore                usercontract.Store
+	ExternalIdentityStore    externalidentitycontract.Store
+	OIDCProviderStore        externalidentitycontract.OIDCProviderStore
+	EmailVerificationStore   emailverificationcontract.Store
+	OrderStore               ordercontract.Store
+	PaymentStore             paymentcontract.Store
+	PaymentChannelStore      paymentcontract.ChannelStore
+	OrderRiskSignalStore     orderriskcontract.SignalReader
+	OrderReviewStore         orderriskcontract.ReviewStore
+	CustomerBlacklistStore   orderriskcontract.BlacklistStore
+	CardSecretRepo           *cardsecretgormstore.Store
+	CardSecretBatchRepo      *cardsecretgormstore.BatchStore
+	GiftCardRepo             *giftcardgormstore.Store
+	PayoutRepo               *payoutgormstore.Store
+	FXRateRepo               *fxrategormstore.Store
+	FulfillmentStore         fulfillmentcontract.Store
+	ProductRepo              *productgormstore.ProductStore
+	ProductSKURepo           *productgormstore.SKUStore
+	CartRepo                 *cartgormstore.Store
+	CouponRepo               *coupongormstore.Store
+	CouponUsageRepo          *coupongormstore.UsageStore
+	PromotionRepo            *promotiongormstore.Store
+	WalletRepo               *walletgormstore.Store
+	CategoryRepo             categorycontract.Repository
+	SettingRepo              settingscontract.Store
+	SettingHistoryRepo       settingscontract.HistoryStore
+	UserLoginLogRepo         auditlogcontract.UserLoginRepository
+	AuthzAuditLogRepo        auditlogcontract.AuthzRepository
+	NotificationLogRepo      *notificationgormstore.LogStore
+	AdminLoginLogRepo        auditlogcontract.AdminLoginRepository
+	DashboardRepo            dashboardcontract.Repository
+	AffiliateRepo            affiliatecontract.Store
+	ResellerStore            *resellergormstore.Store
+	ResellerStaffRepo        resellerstaffcontract.Store
+	ApiCredentialRepo        apicredentialcontract.Repository
+	SiteConnectionRepo       siteconnectioncontract.Repository
+	ProductMappingRepo       *mappinggormstore.MappingStore
+	SKUMappingRepo           *mappinggormstore.SKUMappingStore
+	ProcurementOrderRepo     *procurementgormstore.Store
+	DownstreamOrderRefRepo   downstreamcallbackcontract.Repository
+	RefundPropagationRepo    *refundpropagationgormstore.Store
+	FulfillmentWebhookRepo   webhookcontract.Store
+	FulfillmentFileAssetRepo *filesgormstore.AssetStore
+	FulfillmentFileGrantRepo *filesgormstore.GrantStore
+	FulfillmentFileLogRepo   *filesgormstore.DownloadLogStore
+	LicenseTemplateRepo      *licensegormstore.TemplateStore
+	LicenseRepo              *licensegormstore.LicenseStore
+	RestockRepo              *restockgormstore.Store
+	PreorderRepo             *preordergormstore.Store
+	StockLedgerRepo          *stockledgergormstore.Store
+	StockThresholdRepo       *stockthresholdgormstore.Store
+	ChangeSetRepo            *changesetgormstore.Store
+	ReconciliationJobRepo    reconciliationcontract.JobRepository
+	ReconciliationItemRepo   reconciliationcontract.ItemRepository
+	ChannelClientStore       channelclientcontract.Store
+	TelegramBroadcastRepo    broadcastcontract.Store
+	MemberLevelRepo          memberlevelcontract.LevelRepository
+	MemberLevelPriceRepo     *memberlevelgormstore.PriceStore
+	MemberLevelUserRepo      memberlevelcontract.UserRepository
+	MemberLevelPlanRepo      memberlevelcontract.PlanRepository
+	MemberLevelHistoryRepo   memberlevelcontract.HistoryRepository
+	MemberLevelActivity      memberlevelcontract.ActivityReader
 
 	// Services
 	AuthzService                  *authz.Service
@@ -137,6 +187,7 @@ type Container struct {
 	UserAuthService               *userauthapp.Service
 	TelegramAuthService           *telegramauthapp.Service
 	GoogleAuthService             *googleauthapp.Service
+	OIDCAuthService               *oidcauthapp.Service
 	EmailSender                   *notificationsmtp.Service
 	EmailBrandResolver            mailbrand.Resolver
 	CaptchaService                *captchaapp.Service
@@ -150,6 +201,7 @@ type Container struct {
 	ContentMediaService           *contentapp.MediaService
 	CategoryService               *categoryapp.Service
 	SettingService                *settingsapp.Service
+	SettingVersioningService      *settingsversioning.Service
 	SitemapService                *sitemapapp.Service
 	CartService                   *cartapp.Service
 	WalletService                 *walletapp.Service
@@ -161,6 +213,8 @@ type Container struct {
 	PaymentService                *paymentapp.PaymentService
 	CardSecretService             *cardsecretapp.Service
 	GiftCardService               *giftcardapp.Service
+	PayoutService                 *payoutapp.Service
+	FXRateService                 *fxrateapp.Service
 	UserLoginLogService           *auditlogapp.UserLoginService
 	AuthzAuditService             *auditlogapp.AuthzService
 	AdminLoginLogService          *auditlogapp.AdminLoginService
@@ -177,18 +231,30 @@ type Container struct {
 	ResellerAccountingWithdraw    *reseller.AccountingWithdrawService
 	ResellerAccountingLedger      *reseller.AccountingLedgerService
 	ResellerOrderService          *reseller.OrderQueryService
+	ResellerStaffService          *resellerstaffapp.Service
 	ResellerOperationsService     *reseller.OperationsService
 	ApiCredentialService          *apicredentialapp.Service
 	SiteConnectionService         *siteconnectionapp.Service
 	ProductMappingService         *mappingapp.Service
 	ProcurementOrderService       *procurementapp.Service
 	DownstreamCallbackService     *downstreamcallbackapp.Service
+	RefundPropagationService      *refundpropagationapp.Service
+	FulfillmentWebhookService     *webhookapp.Service
+	FulfillmentFileService        *filesapp.Service
+	LicenseService                *licenseapp.Service
+	RestockService                *restockapp.Service
+	PreorderService               *preorderapp.Service
+	StockLedgerService            *stockledgerapp.Service
+	StockThresholdService         *stockthresholdapp.Service
+	ChangeSetService              *changesetapp.Service
 	ReconciliationService         *reconciliationapp.Service
 	ChannelClientService          *channelclientapp.Service
 	TelegramBroadcastService      *broadcastapp.Service
 	MemberLevelService            *memberlevelapp.Service
 	AdProxyService                *adproxyapp.Service
 	OrderRiskControlService       *orderriskapp.Service
+	OrderReviewService            *orderriskapp.ReviewService
+	CustomerBlacklistService      *orderriskapp.BlacklistService
 	ComplianceService             *complianceapp.Service
 
 	PaymentProviderRegistry *paymentprovider.Registry
@@ -11,26 +11,39 @@ import (
 	categorygormstore "github.com/dujiao-next/internal/modules/catalog/category/infrastructure/gormstore"
 	mappinggormstore "github.com/dujiao-next/internal/modules/catalog/mapping/infrastructure/gormstore"
 	productgormstore "github.com/dujiao-next/internal/modules/catalog/product/store/gormstore"
+	changesetgormstore "github.com/dujiao-next/internal/modules/changeset/infrastructure/gormstore"
 	channelclientstore "github.com/dujiao-next/internal/modules/channelclient/infrastructure/gormstore"
 	coupongormstore "github.com/dujiao-next/internal/modules/coupon/infrastructure/gormstore"
 	dashboardgormstore "github.com/dujiao-next/internal/modules/dashboard/infrastructure/gormstore"
 	downstreamcallbackgormstore "github.com/dujiao-next/internal/modules/downstreamcallback/infrastructure/gormstore"
+	filesgormstore "github.com/dujiao-next/internal/modules/fulfillment/files/infrastructure/gormstore"
 	fulfillmentgormstore "github.com/dujiao-next/internal/modules/fulfillment/infrastructure/gormstore"
+	webhookgormstore "github.com/dujiao-next/internal/modules/fulfillment/webhook/infrastructure/gormstore"
+	fxrategormstore "github.com/dujiao-next/internal/modules/fxrate/infrastructure/gormstore"
 	giftcardgormstore "github.com/dujiao-next/internal/modules/giftcard/infrastructure/gormstore"
 	adminstore "github.com/dujiao-next/internal/modules/identity/admin/infrastructure/gormstore"
 	emailverificationstore "github.com/dujiao-next/internal/modules/identity/emailverification/infrastructure/gormstore"
 	externalidentitystore "github.com/dujiao-next/internal/modules/identity/externalidentity/infrastructure/gormstore"
 	userstore "github.com/dujiao-next/internal/modules/identity/user/infrastructure/gormstore"
+	licensegormstore "github.com/dujiao-next/internal/modules/license/infrastructure/gormstore"
 	memberlevelgormstore "github.com/dujiao-next/internal/modules/memberlevel/infrastructure/gormstore"
 	notificationgormstore "github.com/dujiao-next/internal/modules/notification/infrastructure/gormstore"
 	ordergormstore "github.com/dujiao-next/internal/modules/order/infrastructure/gormstore"
+	orderriskgormstore "github.com/dujiao-next/internal/modules/orderrisk/infrastructure/gormstore"
 	paymentgormstore "github.com/dujiao-next/internal/modules/payment/infrastructure/gormstore"
+	payoutgormstore "github.com/dujiao-next/internal/modules/payout/infrastructure/gormstore"
+	preordergormstore "github.com/dujiao-next/internal/modules/preorder/infrastructure/gormstore"
 	procurementgormstore "github.com/dujiao-next/internal/modules/procurement/infrastructure/gormstore"
 	promotiongormstore "github.com/dujiao-next/internal/modules/promotion/infrastructure/gormstore"
 	reconciliationgormstore "github.com/dujiao-next/internal/modules/reconciliation/infrastructure/gormstore"
+	refundpropagationgormstore "github.com/dujiao-next/internal/modules/refundpropagation/infrastructure/gormstore"
 	resellergormstore "github.com/dujiao-next/internal/modules/reseller/infrastructure/gormstore"
+	resellerstaffgormstore "github.com/dujiao-next/internal/modules/reseller/staff/infrastructure/gormstore"
+	restockgormstore "github.com/dujiao-next/internal/modules/restock/infrastructure/gormstore"
 	settingsstore "github.com/dujiao-next/internal/modules/settings/infrastructure/gormstore"
 	siteconnectiongormstore "github.com/dujiao-next/internal/modules/siteconnection/infrastructure/gormstore"
+	stockledgergormstore "github.com/dujiao-next/internal/modules/stockledger/infrastructure/gormstore"
+	stockthresholdgormstore "github.com/dujiao-next/internal/modules/stockthreshold/infrastructure/gormstore"
 	broadcaststore "github.com/dujiao-next/internal/modules/telegram/broadcast/infrastructure/gormstore"
 	walletgormstore "github.com/dujiao-next/internal/modules/wallet/infrastructure/gormstore"
 	"github.com/dujiao-next/internal/platform/database/gormdb"
@@ -40,7 +53,9 @@ func (c *Container) initRepositories() error {
 	db := gormdb.DB
 	c.AdminStore = adminstore.New(db)
 	c.UserStore = userstore.New(db)
-	c.ExternalIdentityStore = externalidentitystore.New(db)
+	externalIdentityStore := externalidentitystore.New(db)
+	c.ExternalIdentityStore = externalIdentityStore
+	c.OIDCProviderStore = externalIdentityStore
 	c.EmailVerificationStore = emailverificationstore.New(db)
 	orderStore := ordergormstore.New(db, c.Config.App.SecretKey)
 	if _, err := orderStore.BackfillGuestCredentialHashes(); err != nil {
@@ -49,9 +64,14 @@ func (c *Container) initRepositories() error {
 	c.OrderStore = orderStore
 	c.PaymentStore = paymentgormstore.New(db, c.Config.App.SecretKey)
 	c.PaymentChannelStore = paymentgormstore.NewChannelStore(db)
+	c.OrderRiskSignalStore = orderriskgormstore.NewSignalStore(db)
+	c.OrderReviewStore = orderriskgormstore.NewReviewStore(db)
+	c.CustomerBlacklistStore = orderriskgormstore.NewBlacklistStore(db)
 	c.CardSecretRepo = cardsecretgormstore.New(db)
 	c.CardSecretBatchRepo = cardsecretgormstore.NewBatch(db)
 	c.GiftCardRepo = giftcardgormstore.New(db)
+	c.PayoutRepo = payoutgormstore.New(db)
+	c.FXRateRepo = fxrategormstore.New(db)
 	c.FulfillmentStore = fulfillmentgormstore.New(db)
 	c.ProductRepo = productgormstore.NewProductStore(db)
 	c.ProductSKURepo = productgormstore.NewSKUStore(db)
@@ -61,7 +81,9 @@ func (c *Container) initRepositories() error {
 	c.PromotionRepo = promotiongormstore.New(db)
 	c.WalletRepo = walletgormstore.New(db)
 	c.CategoryRepo = categorygormstore.NewCategoryStore(db)
-	c.SettingRepo = settingsstore.New(db)
+	settingStore := settingsstore.New(db)
+	c.SettingRepo = settingStore
+	c.SettingHistoryRepo = settingStore
 	c.UserLoginLogRepo = auditloggormstore.NewUserLoginStore(db)
 	c.AuthzAuditLogRepo = auditloggormstore.NewAuthzStore(db)
 	c.NotificationLogRepo = notificationgormstore.NewLogStore(db)
@@ -69,12 +91,25 @@ func (c *Container) initRepositories() error {
 	c.DashboardRepo = dashboardgormstore.New(db)
 	c.AffiliateRepo = affiliategormstore.New(db)
 	c.ResellerStore = resellergormstore.New(db)
+	c.ResellerStaffRepo = resellerstaffgormstore.New(db)
 	c.ApiCredentialRepo = apicredentialgormstore.New(db)
 	c.SiteConnectionRepo = siteconnectiongormstore.New(db)
 	c.ProductMappingRepo = mappinggormstore.NewMappingStore(db)
 	c.SKUMappingRepo = mappinggormstore.NewSKUMappingStore(db)
 	c.ProcurementOrderRepo = procurementgormstore.New(db)
 	c.DownstreamOrderRefRepo = downstreamcallbackgormstore.New(db)
+	c.RefundPropagationRepo = refundpropagationgormstore.New(db)
+	c.FulfillmentWebhookRepo = webhookgormstore.New(db)
+	c.FulfillmentFileAssetRepo = filesgormstore.NewAssetStore(db)
+	c.FulfillmentFileGrantRepo = filesgormstore.NewGrantStore(db)
+	c.FulfillmentFileLogRepo = filesgormstore.NewDownloadLogStore(db)
+	c.LicenseTemplateRepo = licensegormstore.NewTemplateStore(db)
+	c.LicenseRepo = licensegormstore.NewLicenseStore(db)
+	c.RestockRepo = restockgormstore.New(db)
+	c.PreorderRepo = preordergormstore.New(db, c.Config.App.SecretKey)
+	c.StockLedgerRepo = stockledgergormstore.New(db)
+	c.StockThresholdRepo = stockthresholdgormstore.New(db)
+	c.ChangeSetRepo = changesetgormstore.New(db)
 	c.ReconciliationJobRepo = reconciliationgormstore.NewJobStore(db)
 	c.ReconciliationItemRepo = reconciliationgormstore.NewItemStore(db)
 	c.ChannelClientStore = channelclientstore.New(db)
@@ -82,5 +117,8 @@ func (c *Container) initRepositories() error {
 	c.MemberLevelRepo = memberlevelgormstore.NewLevelStore(db)
 	c.MemberLevelPriceRepo = memberlevelgormstore.NewPriceStore(db)
 	c.MemberLevelUserRepo = memberlevelgormstore.NewUserStore(db)
+	c.MemberLevelPlanRepo = memberlevelgormstore.NewPlanStore(db)
+	c.MemberLevelHistoryRepo = memberlevelgormstore.NewHistoryStore(db)
+	c.MemberLevelActivity = memberlevelgormstore.NewActivityStore(db)
 	return nil
 }
@@ -15,6 +15,7 @@ import (
 	couponapp "github.com/dujiao-next/internal/modules/coupon/application"
 	fulfillmentapp "github.com/dujiao-next/internal/modules/fulfillment/application"
 	fulfillmentqueue "github.com/dujiao-next/internal/modules/fulfillment/infrastructure/queueadapter"
+	fxrateapp "github.com/dujiao-next/internal/modules/fxrate/application"
 	giftcardapp "github.com/dujiao-next/internal/modules/giftcard/application"
 	giftcardsettingscurrency "github.com/dujiao-next/internal/modules/giftcard/infrastructure/settingscurrency"
 	memberlevelapp "github.com/dujiao-next/internal/modules/memberlevel/application"
@@ -23,14 +24,17 @@ import (
 	orderqueue "github.com/dujiao-next/internal/modules/order/infrastructure/queueadapter"
 	orderriskapp "github.com/dujiao-next/internal/modules/orderrisk/application"
 	orderrisklimiter "github.com/dujiao-next/internal/modules/orderrisk/infrastructure/redislimiter"
+	payoutapp "github.com/dujiao-next/internal/modules/payout/application"
 	promotionapp "github.com/dujiao-next/internal/modules/promotion/application"
 	sitemapapp "github.com/dujiao-next/internal/modules/sitemap/application"
 	sitemapcontract "github.com/dujiao-next/internal/modules/sitemap/contract"
 	sitemapcache "github.com/dujiao-next/internal/modules/sitemap/infrastructure/cacheadapter"
 	sitemapcatalog "github.com/dujiao-next/internal/modules/sitemap/infrastructure/catalogreader"
 	walletapp "github.com/dujiao-next/internal/modules/wallet/application"
+	walletusertotp "github.com/dujiao-next/internal/modules/wallet/infrastructure/usertotp"
 	"github.com/dujiao-next/internal/platform/database/gormdb"
 	giftcardredeemgormuow "github.com/dujiao-next/internal/workflows/giftcardredeem/infrastructure/gormuow"
+	payoutgormuow "github.com/dujiao-next/internal/workflows/payout/infrastructure/gormuow"
 )
 
 // initApplicationServices 装配内容、购物车、订单、履约和营销用例。
@@ -74,8 +78,14 @@ func (c *Container) initApplicationServices() {
 	}
 	c.SitemapService = sitemapService
 	c.CartService = cartapp.NewService(c.CartRepo, c.ProductRepo, c.ProductSKURepo, c.PromotionRepo, c.SettingService)
+	c.FXRateService = fxrateapp.NewService(fxrateapp.Options{
+		Store:    c.FXRateRepo,
+		Currency: giftcardsettingscurrency.New(c.SettingService),
+	})
 	c.WalletService = walletapp.NewService(walletapp.Options{
 		Repository: c.WalletRepo, Transactions: c.WalletRepo,
+		Recipients: c.UserStore, TwoFactor: walletusertotp.New(c.UserTOTPService), Settings: c.SettingService,
+		Currency: giftcardsettingscurrency.New(c.SettingService),
 	})
 	c.OrderRefundService = orderrefund.New(
 		c.OrderStore,
@@ -85,9 +95,12 @@ func (c *Container) initApplicationServices() {
 		c.WalletService,
 	)
 	c.MemberLevelService = memberlevelapp.NewService(c.MemberLevelRepo, c.MemberLevelPriceRepo, c.MemberLevelUserRepo)
+	c.MemberLevelService.SetMembershipStores(c.MemberLevelPlanRepo, c.MemberLevelHistoryRepo, c.MemberLevelActivity)
 	c.OrderRiskControlService = orderriskapp.NewService(orderriskapp.Options{
 		Settings:    c.SettingService,
 		RateLimiter: orderrisklimiter.New(),
+		Signals:     c.OrderRiskSignalStore,
+		Blacklist:   c.CustomerBlacklistService,
 	})
 	orderQueue := orderqueue.New(c.QueueClient)
 	c.OrderService = orderapp.NewOrderService(orderapp.OrderServiceOptions{
@@ -107,6 +120,7 @@ func (c *Container) initApplicationServices() {
 		ResellerPricingResolver: c.ResellerPricingResolver,
 		ResellerAccounting:      c.ResellerAccountingLedger,
 		RiskControlService:      c.OrderRiskControlService,
+		CurrencyQuoter:          c.FXRateService,
 		ExpireMinutes:           c.Config.Order.PaymentExpireMinutes,
 	})
 	c.FulfillmentService = fulfillmentapp.New(fulfillmentapp.Options{
@@ -131,6 +145,11 @@ func (c *Container) initApplicationServices() {
 		Currency: giftcardsettingscurrency.New(c.SettingService),
 		Redeemer: giftcardredeemgormuow.New(c.GiftCardRepo, c.WalletService),
 	})
+	c.PayoutService = payoutapp.NewService(payoutapp.Options{
+		Store:    c.PayoutRepo,
+		Runner:   payoutgormuow.New(c.PayoutRepo, c.AffiliateService, c.ResellerAccountingWithdraw, c.WalletService),
+		Currency: giftcardsettingscurrency.New(c.SettingService),
+	})
 	c.CouponAdminService = couponapp.NewAdminService(c.CouponRepo)
 	c.PromotionAdminService = promotionapp.NewAdminService(c.PromotionRepo)
 	c.ContentBannerService = contentapp.NewBannerService(
@@ -1,19 +1,25 @@
 package container
 
 import (
+	"time"
+
 	"github.com/dujiao-next/internal/authz"
 	catalogproductbootstrap "github.com/dujiao-next/internal/bootstrap/catalogproduct"
 	mailbrandwiring "github.com/dujiao-next/internal/bootstrap/mailbrand"
 	telegramauthcache "github.com/dujiao-next/internal/bootstrap/telegramauthcache"
 	"github.com/dujiao-next/internal/cache"
+	"github.com/dujiao-next/internal/i18n/locales"
 	"github.com/dujiao-next/internal/logger"
 	affiliateapp "github.com/dujiao-next/internal/modules/affiliate/application"
 	captchaapp "github.com/dujiao-next/internal/modules/captcha/application"
+	captchapowstore "github.com/dujiao-next/internal/modules/captcha/infrastructure/powstore"
 	captchaturnstile "github.com/dujiao-next/internal/modules/captcha/infrastructure/turnstile"
 	complianceapp "github.com/dujiao-next/internal/modules/compliance/application"
 	adminauthapp "github.com/dujiao-next/internal/modules/identity/adminauth/application"
 	admintotpapp "github.com/dujiao-next/internal/modules/identity/adminauth/totp/application"
 	googleauthapp "github.com/dujiao-next/internal/modules/identity/googleauth/application"
+	oidcauthapp "github.com/dujiao-next/internal/modules/identity/oidcauth/application"
+	oidcauthcachestore "github.com/dujiao-next/internal/modules/identity/oidcauth/infrastructure/cachestore"
 	telegramauthapp "github.com/dujiao-next/internal/modules/identity/telegramauth/application"
 	userauthapp "github.com/dujiao-next/internal/modules/identity/userauth/application"
 	userauthcachestore "github.com/dujiao-next/internal/modules/identity/userauth/infrastructure/cachestore"
@@ -21,8 +27,12 @@ import (
 	usertotpapp "github.com/dujiao-next/internal/modules/identity/userauth/totp/application"
 	notificationsmtp "github.com/dujiao-next/internal/modules/notification/infrastructure/smtp"
 	orderapp "github.com/dujiao-next/internal/modules/order/application"
+	orderriskapp "github.com/dujiao-next/internal/modules/orderrisk/application"
 	reseller "github.com/dujiao-next/internal/modules/reseller/application"
+	resellerdomaincheck "github.com/dujiao-next/internal/modules/reseller/infrastructure/domaincheck"
+	resellerstaffapp "github.com/dujiao-next/internal/modules/reseller/staff/application"
 	settingsapp "github.com/dujiao-next/internal/modules/settings/application"
+	settingsversioning "github.com/dujiao-next/internal/modules/settings/application/versioning"
 	settingsmessaging "github.com/dujiao-next/internal/modules/settings/schema/messaging"
 	settingssecurity "github.com/dujiao-next/internal/modules/settings/schema/security"
 	uploadapp "github.com/dujiao-next/internal/modules/upload/application"
@@ -44,11 +54,16 @@ func (c *Container) initPolicyAndSettingServices() {
 	}
 
 	c.SettingService = settingsapp.NewService(c.SettingRepo, c.Config.Order)
+	c.SettingService.SetHistoryStore(c.SettingHistoryRepo)
+	c.SettingVersioningService = settingsversioning.NewService(c.SettingService, c.SettingHistoryRepo)
 	c.EmailBrandResolver = mailbrandwiring.New(c.SettingService, c.ResellerStore)
 	c.ResellerDomainResolver = reseller.NewDomainResolver(c.ResellerStore, c.Config.Reseller)
 	c.ResellerPricingResolver = orderapp.NewResellerPricingResolver(c.ResellerStore)
 	c.ResellerManagementService = reseller.NewManagementService(c.ResellerStore, c.Config.Reseller)
+	c.ResellerManagementService.SetDomainVerifier(resellerdomaincheck.New(c.Config.Reseller.DomainVerification))
+	c.ResellerStaffService = resellerstaffapp.NewService(c.ResellerStaffRepo)
 	c.ResellerSiteConfigService = reseller.NewSiteConfigService(c.ResellerStore)
+	c.ResellerSiteConfigService.SetCategoryLookup(c.CategoryRepo)
 	c.ResellerProductSettingService = reseller.NewProductSettingService(c.ResellerStore, c.ProductRepo)
 	c.ResellerAccountingQuery = reseller.NewAccountingQueryService(c.ResellerStore)
 	c.ResellerAccountingWithdraw = reseller.NewAccountingWithdrawService(c.ResellerStore)
@@ -63,6 +78,13 @@ func (c *Container) initPolicyAndSettingServices() {
 
 // loadRuntimeSettings 用数据库设置覆盖启动配置中的可动态配置项。
 func (c *Container) loadRuntimeSettings() {
+	if loaded, err := locales.LoadDir(c.Config.I18n.LocalesDir); err != nil {
+		logger.Warnw("provider_load_locale_packs_failed", "dir", c.Config.I18n.LocalesDir, "error", err)
+	} else if loaded > 0 {
+		logger.Infow("provider_load_locale_packs", "dir", c.Config.I18n.LocalesDir, "files", loaded)
+	}
+	locales.SetOverrideLoader(c.SettingService.LoadLocaleOverrides, time.Minute)
+
 	smtpSetting, err := c.SettingService.GetSMTPSetting(c.Config.Email)
 	if err != nil {
 		logger.Warnw("provider_load_smtp_setting_failed", "error", err)
@@ -104,16 +126,21 @@ func (c *Container) loadRuntimeSettings() {
 func (c *Container) initIdentityAndCatalogServices() {
 	c.EmailSender = notificationsmtp.New(&c.Config.Email)
 	c.CaptchaService = captchaapp.NewService(c.SettingService, c.Config.Captcha, captchaturnstile.New())
+	c.CaptchaService.SetPoWChallengeStore(captchapowstore.New())
 	c.AuthService = adminauthapp.NewService(c.Config, c.AdminStore)
 	c.TOTPService = admintotpapp.NewService(c.Config, c.AdminStore, cache.Client())
 	c.UserTOTPService = usertotpapp.NewService(c.Config, c.UserStore, cache.Client())
 	c.TelegramAuthService = telegramauthapp.NewService(c.Config.TelegramAuth, telegramauthcache.Options()...)
 	c.GoogleAuthService = googleauthapp.NewService(c.Config.GoogleAuth)
+	c.CustomerBlacklistService = orderriskapp.NewBlacklistService(c.CustomerBlacklistStore)
 	c.UserAuthService = userauthapp.NewService(c.Config, c.UserStore, c.ExternalIdentityStore, c.EmailVerificationStore, c.SettingService, c.EmailSender, c.TelegramAuthService)
 	c.UserAuthService.SetGoogleAuthService(c.GoogleAuthService)
 	c.UserAuthService.SetGoogleRedirectStore(userauthcachestore.NewGoogleRedirectStore())
+	c.OIDCAuthService = oidcauthapp.NewService(c.OIDCProviderStore, oidcauthcachestore.NewStateStore(), c.Config.App.SecretKey)
+	c.UserAuthService.SetOIDCAuthService(c.OIDCAuthService)
 	c.UserAuthService.SetAuthUnitOfWork(userauthgormstore.New(gormdb.DB))
 	c.UserAuthService.SetEmailBrandResolver(c.EmailBrandResolver)
+	c.UserAuthService.SetCustomerBlacklist(c.CustomerBlacklistService)
 	c.UploadService = uploadapp.NewService(uploadapp.Policy{
 		MaxSize:           c.Config.Upload.MaxSize,
 		AllowedTypes:      c.Config.Upload.AllowedTypes,
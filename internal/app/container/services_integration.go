@@ -6,6 +6,8 @@ import (
 	"github.com/dujiao-next/internal/logger"
 	apicredentialapp "github.com/dujiao-next/internal/modules/apicredential/application"
 	auditlogapp "github.com/dujiao-next/internal/modules/auditlog/application"
+	changesetapp "github.com/dujiao-next/internal/modules/changeset/application"
+	changesetgormstore "github.com/dujiao-next/internal/modules/changeset/infrastructure/gormstore"
 	channelclientapp "github.com/dujiao-next/internal/modules/channelclient/application"
 	contentapp "github.com/dujiao-next/internal/modules/content/application"
 	localfilestore "github.com/dujiao-next/internal/modules/content/infrastructure/filestore/local"
@@ -17,10 +19,30 @@ import (
 	downstreamcallbackcredentialreader "github.com/dujiao-next/internal/modules/downstreamcallback/infrastructure/credentialreader"
 	downstreamcallbackorderreader "github.com/dujiao-next/internal/modules/downstreamcallback/infrastructure/orderreader"
 	downstreamcallbackqueue "github.com/dujiao-next/internal/modules/downstreamcallback/infrastructure/queueadapter"
+	filesapp "github.com/dujiao-next/internal/modules/fulfillment/files/application"
+	filesfulfillment "github.com/dujiao-next/internal/modules/fulfillment/files/infrastructure/fulfillmentadapter"
+	filesorderreader "github.com/dujiao-next/internal/modules/fulfillment/files/infrastructure/orderreader"
+	webhookapp "github.com/dujiao-next/internal/modules/fulfillment/webhook/application"
+	webhookcontract "github.com/dujiao-next/internal/modules/fulfillment/webhook/contract"
+	webhookfulfillment "github.com/dujiao-next/internal/modules/fulfillment/webhook/infrastructure/fulfillmentadapter"
+	webhookorderreader "github.com/dujiao-next/internal/modules/fulfillment/webhook/infrastructure/orderreader"
+	webhookproductreader "github.com/dujiao-next/internal/modules/fulfillment/webhook/infrastructure/productreader"
+	webhookqueue "github.com/dujiao-next/internal/modules/fulfillment/webhook/infrastructure/queueadapter"
+	webhookclient "github.com/dujiao-next/internal/modules/fulfillment/webhook/infrastructure/webhookclient"
+	licenseapp "github.com/dujiao-next/internal/modules/license/application"
+	licensefulfillment "github.com/dujiao-next/internal/modules/license/infrastructure/fulfillmentadapter"
+	licenseorderreader "github.com/dujiao-next/internal/modules/license/infrastructure/orderreader"
 	notificationapp "github.com/dujiao-next/internal/modules/notification/application"
 	notificationasyncqueue "github.com/dujiao-next/internal/modules/notification/infrastructure/asyncqueue"
+	orderriskapp "github.com/dujiao-next/internal/modules/orderrisk/application"
+	orderriskrefund "github.com/dujiao-next/internal/modules/orderrisk/infrastructure/refundadapter"
 	paymentapp "github.com/dujiao-next/internal/modules/payment/application"
 	paymentqueue "github.com/dujiao-next/internal/modules/payment/infrastructure/queueadapter"
+	preorderapp "github.com/dujiao-next/internal/modules/preorder/application"
+	preordercontract "github.com/dujiao-next/internal/modules/preorder/contract"
+	preordernotifier "github.com/dujiao-next/internal/modules/preorder/infrastructure/notifier"
+	preorderqueue "github.com/dujiao-next/internal/modules/preorder/infrastructure/queueadapter"
+	preorderrefund "github.com/dujiao-next/internal/modules/preorder/infrastructure/refundadapter"
 	procurementapp "github.com/dujiao-next/internal/modules/procurement/application"
 	procurementmapping "github.com/dujiao-next/internal/modules/procurement/infrastructure/mappingreader"
 	procurementnotification "github.com/dujiao-next/internal/modules/procurement/infrastructure/notificationadapter"
@@ -32,10 +54,22 @@ import (
 	reconciliationprocurement "github.com/dujiao-next/internal/modules/reconciliation/infrastructure/procurementreader"
 	reconciliationqueue "github.com/dujiao-next/internal/modules/reconciliation/infrastructure/queueadapter"
 	reconciliationupstream "github.com/dujiao-next/internal/modules/reconciliation/infrastructure/upstreamreader"
+	refundpropagationapp "github.com/dujiao-next/internal/modules/refundpropagation/application"
+	refundpropagationnotification "github.com/dujiao-next/internal/modules/refundpropagation/infrastructure/notificationadapter"
+	refundpropagationrefund "github.com/dujiao-next/internal/modules/refundpropagation/infrastructure/refundadapter"
+	restockapp "github.com/dujiao-next/internal/modules/restock/application"
+	restockcontract "github.com/dujiao-next/internal/modules/restock/contract"
+	restockcontactreader "github.com/dujiao-next/internal/modules/restock/infrastructure/contactreader"
+	restockmessenger "github.com/dujiao-next/internal/modules/restock/infrastructure/messenger"
+	restockqueue "github.com/dujiao-next/internal/modules/restock/infrastructure/queueadapter"
+	restockstockreader "github.com/dujiao-next/internal/modules/restock/infrastructure/stockreader"
 	siteconnectionapp "github.com/dujiao-next/internal/modules/siteconnection/application"
+	stockledgerapp "github.com/dujiao-next/internal/modules/stockledger/application"
+	stockthresholdapp "github.com/dujiao-next/internal/modules/stockthreshold/application"
 	broadcastapp "github.com/dujiao-next/internal/modules/telegram/broadcast/application"
 	notifyapp "github.com/dujiao-next/internal/modules/telegram/notify/application"
 	notifybotapi "github.com/dujiao-next/internal/modules/telegram/notify/infrastructure/botapi"
+	uploadlocal "github.com/dujiao-next/internal/modules/upload/infrastructure/localstore"
 	"github.com/dujiao-next/internal/platform/database/gormdb"
 )
 
@@ -110,6 +144,96 @@ func (c *Container) initIntegrationServices() {
 		NotificationService:     c.NotificationService,
 		PaymentProviderRegistry: c.PaymentProviderRegistry,
 		ResellerAccounting:      c.ResellerAccountingLedger,
+		RiskAssessor:            c.OrderRiskControlService,
+		CustomerBlacklist:       c.CustomerBlacklistService,
+	})
+	var webhookQueue webhookcontract.DispatchQueue
+	if c.QueueClient != nil {
+		webhookQueue = webhookqueue.New(c.QueueClient)
+	}
+	c.FulfillmentWebhookService = webhookapp.NewService(webhookapp.Options{
+		Deliveries: c.FulfillmentWebhookRepo,
+		Orders:     webhookorderreader.New(c.OrderStore),
+		Endpoints:  webhookproductreader.New(c.ProductRepo),
+		Sender:     webhookclient.New(),
+		Queue:      webhookQueue,
+		Completer:  webhookfulfillment.New(c.FulfillmentService),
+		Notifier:   c.PaymentService,
+		SiteURL: func() string {
+			brand, err := c.SettingService.GetSiteBrand()
+			if err != nil {
+				return ""
+			}
+			return brand.SiteURL
+		},
+	})
+	// 交付文件存放在不对外暴露的 private 目录，仅能通过签名链接下载
+	c.FulfillmentFileService = filesapp.NewService(filesapp.Options{
+		Assets:        c.FulfillmentFileAssetRepo,
+		Grants:        c.FulfillmentFileGrantRepo,
+		Logs:          c.FulfillmentFileLogRepo,
+		Storage:       uploadlocal.New("private"),
+		Orders:        filesorderreader.New(c.OrderStore),
+		Completer:     filesfulfillment.New(c.FulfillmentService),
+		Notifier:      c.PaymentService,
+		SigningSecret: c.Config.App.SecretKey,
+	})
+	// 授权码模板私钥以应用密钥加密存储
+	c.LicenseService = licenseapp.NewService(licenseapp.Options{
+		Templates: c.LicenseTemplateRepo,
+		Licenses:  c.LicenseRepo,
+		Orders:    licenseorderreader.New(c.OrderStore),
+		Completer: licensefulfillment.New(c.FulfillmentService),
+		Notifier:  c.PaymentService,
+		SecretKey: c.Config.App.SecretKey,
+	})
+	// 未启用任务队列时到货检查与投递在进程内异步执行
+	var restockQueue restockcontract.Queue
+	if c.QueueClient.Enabled() {
+		restockQueue = restockqueue.New(c.QueueClient)
+	}
+	c.RestockService = restockapp.NewService(restockapp.Options{
+		Store:     c.RestockRepo,
+		Stock:     restockstockreader.New(c.ProductRepo, c.ProductReadService, c.SKUMappingRepo),
+		Contacts:  restockcontactreader.New(c.UserStore, c.ExternalIdentityStore),
+		Queue:     restockQueue,
+		Messenger: restockmessenger.New(c.EmailSender, telegramNotifyService),
+		Brands:    c.EmailBrandResolver,
+	})
+	// 未启用任务队列时预售分配在进程内异步执行
+	var preorderQueue preordercontract.Queue
+	if c.QueueClient.Enabled() {
+		preorderQueue = preorderqueue.New(c.QueueClient)
+	}
+	c.PreorderService = preorderapp.NewService(preorderapp.Options{
+		Store:    c.PreorderRepo,
+		Products: c.ProductRepo,
+		Cards:    c.CardSecretRepo,
+		Orders:   c.OrderStore,
+		Wallets:  c.WalletService,
+		Refunder: preorderrefund.New(c.OrderRefundService, c.QueueClient),
+		Notifier: preordernotifier.New(c.OrderStore, c.NotificationService),
+		Queue:    preorderQueue,
+	})
+	c.StockLedgerService = stockledgerapp.NewService(c.StockLedgerRepo)
+	c.StockThresholdService = stockthresholdapp.NewService(c.StockThresholdRepo)
+	c.ChangeSetService = changesetapp.NewService(changesetapp.Options{
+		Store:   c.ChangeSetRepo,
+		Catalog: changesetgormstore.NewCatalog(gormdb.DB, c.CategoryRepo),
+	})
+	c.OrderReviewService = orderriskapp.NewReviewService(orderriskapp.ReviewOptions{
+		Store:    c.OrderReviewStore,
+		Settings: c.SettingService,
+		Releaser: c.PaymentService,
+		Refunder: orderriskrefund.New(c.OrderRefundService, c.QueueClient),
+		Notifier: c.NotificationService,
+	})
+	c.RefundPropagationService = refundpropagationapp.NewService(refundpropagationapp.Options{
+		Store:       c.RefundPropagationRepo,
+		Connections: c.SiteConnectionRepo,
+		Orders:      c.OrderStore,
+		Refunder:    refundpropagationrefund.New(c.OrderRefundService, c.QueueClient),
+		Notifier:    refundpropagationnotification.New(c.NotificationService),
 	})
 	c.ProcurementOrderService = procurementapp.NewService(procurementapp.Options{
 		Repository:         c.ProcurementOrderRepo,
@@ -122,6 +246,7 @@ func (c *Container) initIntegrationServices() {
 		DownstreamCallback: c.DownstreamCallbackService,
 		BotNotifier:        c.FulfillmentService,
 		Notifications:      procurementnotification.New(c.NotificationService),
+		RefundObserver:     c.RefundPropagationService,
 	})
 	c.ReconciliationService = reconciliationapp.NewService(reconciliationapp.Options{
 		Jobs: c.ReconciliationJobRepo, Items: c.ReconciliationItemRepo,
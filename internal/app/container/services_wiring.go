@@ -4,8 +4,19 @@ package container
 func (c *Container) wireServiceDependencies() {
 	c.UserAuthService.SetMemberLevelService(c.MemberLevelService)
 	c.OrderRefundService.SetResellerAccounting(c.ResellerAccountingLedger)
+	c.OrderRefundService.SetFileGrantRevoker(c.FulfillmentFileService)
+	c.OrderRefundService.SetLicenseRevoker(c.LicenseService)
+	c.OrderRefundService.SetMembershipRevoker(c.MemberLevelService)
 	c.PaymentService.SetMemberLevelService(c.MemberLevelService)
 	c.PaymentService.SetProcurementService(c.ProcurementOrderService)
 	c.PaymentService.SetDownstreamCallbackService(c.DownstreamCallbackService)
+	c.PaymentService.SetWebhookFulfillmentService(c.FulfillmentWebhookService)
+	c.PaymentService.SetReviewQueue(c.OrderReviewService)
 	c.FulfillmentService.SetDownstreamCallbackService(c.DownstreamCallbackService)
+	// 库存增加先满足预售排队，剩余库存再由预售服务转交到货提醒
+	c.PreorderService.SetRestockTrigger(c.RestockService)
+	c.CardSecretService.SetRestockTrigger(c.PreorderService)
+	c.ProductWriteService.SetRestockTrigger(c.PreorderService)
+	c.OrderService.SetPreorderGate(c.PreorderService)
+	c.PaymentService.SetPreorderTrigger(c.PreorderService)
 }
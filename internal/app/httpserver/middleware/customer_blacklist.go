@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"errors"
+
+	"github.com/dujiao-next/internal/i18n"
+	"github.com/dujiao-next/internal/logger"
+	orderriskcontract "github.com/dujiao-next/internal/modules/orderrisk/contract"
+	"github.com/dujiao-next/internal/platform/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CustomerBlacklistIP 按请求 IP 拦截命中客户黑名单 IP 段的注册与登录请求；
+// 账户、邮箱与 Telegram 维度由认证服务在业务层校验。黑名单查询失败时放行，避免阻断全部登录。
+func CustomerBlacklistIP(checker orderriskcontract.CustomerBlacklist) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if checker == nil {
+			c.Next()
+			return
+		}
+		err := checker.CheckCustomer(orderriskcontract.BlacklistSubject{ClientIP: c.ClientIP()})
+		if errors.Is(err, orderriskcontract.ErrCustomerBlacklisted) {
+			response.Forbidden(c, i18n.T(i18n.ResolveLocale(c), "error.customer_blacklisted"))
+			c.Abort()
+			return
+		}
+		if err != nil {
+			logger.Warnw("customer_blacklist_ip_check_failed", "client_ip", c.ClientIP(), "error", err)
+		}
+		c.Next()
+	}
+}
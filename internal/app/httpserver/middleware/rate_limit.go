@@ -218,6 +218,8 @@ func RateLimitMiddleware(client *redis.Client, rule RateLimitRule, keyFunc RateL
 			return
 		}
 
+		// 供下游按请求频率调整策略（如工作量证明验证码难度）。
+		c.Set("rate_limit_count", count)
 		c.Next()
 	}
 }
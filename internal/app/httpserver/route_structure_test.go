@@ -49,6 +49,7 @@ func TestRouteDomainFilesPreserveTrustBoundaries(t *testing.T) {
 				`categoryhttp.RegisterPublicRoutes(public, publicCategoryHandler)`,
 				`contenttransport.RegisterPublicRoutes(public, publicContentHandler)`,
 				`captchatransport.RegisterPublicRoutes(public,`,
+				`captchatransport.RegisterPublicPoWRoutes(public,`,
 				`affiliatetransport.RegisterPublicRoutes(public, affiliateHandler)`,
 				`affiliatetransport.RegisterUserRoutes(user, affiliateHandler)`,
 				`user.Use(middleware.UserJWTAuthMiddleware(`,
@@ -152,6 +153,7 @@ func TestRouteDomainFilesPreserveTrustBoundaries(t *testing.T) {
 				`settingstransport.RegisterAdminOrderEmailTemplateRoutes(authorized,`,
 				`settingstransport.RegisterAdminAffiliateRoutes(authorized,`,
 				`settingstransport.RegisterAdminTelegramBotRoutes(authorized,`,
+				`settingstransport.RegisterAdminLocaleRoutes(authorized,`,
 				`uploadtransport.RegisterAdminRoutes(authorized,`,
 				`broadcasthttp.RegisterAdminRoutes(authorized,`,
 				`channelclienthttp.RegisterAdminRoutes(authorized,`,
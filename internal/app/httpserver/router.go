@@ -40,11 +40,13 @@ import (
 	contenttransport "github.com/dujiao-next/internal/modules/content/transport/http"
 	coupontransport "github.com/dujiao-next/internal/modules/coupon/transport/http"
 	dashboardtransport "github.com/dujiao-next/internal/modules/dashboard/transport/http"
+	fulfillmentwebhooktransport "github.com/dujiao-next/internal/modules/fulfillment/webhook/transport/http"
 	giftcardtransport "github.com/dujiao-next/internal/modules/giftcard/transport/http"
 	memberleveltransport "github.com/dujiao-next/internal/modules/memberlevel/transport/http"
 	notificationtransport "github.com/dujiao-next/internal/modules/notification/transport/http"
 	procurementtransport "github.com/dujiao-next/internal/modules/procurement/transport/http"
 	promotiontransport "github.com/dujiao-next/internal/modules/promotion/transport/http"
+	resellerusertransport "github.com/dujiao-next/internal/modules/reseller/transport/http/user"
 	settingstransport "github.com/dujiao-next/internal/modules/settings/transport/http"
 	sitemapbrand "github.com/dujiao-next/internal/modules/sitemap/infrastructure/settingsbrand"
 	sitemaptransport "github.com/dujiao-next/internal/modules/sitemap/transport/http"
@@ -74,6 +76,7 @@ func SetupRouter(cfg *config.Config, c *container.Container) *gin.Engine {
 	adminUserHandler := adminuserwiring.NewHandler(c)
 	adminAuthzHandler := adminauthzwiring.NewHandler(c)
 	adminFulfillmentHandler := fulfillmentwiring.NewAdminHandler(c)
+	fulfillmentWebhookCallbackHandler := fulfillmentwiring.NewWebhookCallbackHandler(c)
 	orderHandlers := orderwiring.New(c)
 	adminOrderHandler := orderHandlers.Admin
 	adminOrderRefundHandler := orderHandlers.AdminRefund
@@ -105,6 +108,7 @@ func SetupRouter(cfg *config.Config, c *container.Container) *gin.Engine {
 		MemberLevels: c.MemberLevelService,
 		Mappings:     c.ProductMappingRepo,
 		SKUMappings:  c.SKUMappingRepo,
+		Thresholds:   c.StockThresholdService,
 		RelatedPosts: c.ContentPostService,
 	})
 	publicCategoryHandler := categoryhttp.NewPublicHandler(c.CategoryService)
@@ -127,10 +131,12 @@ func SetupRouter(cfg *config.Config, c *container.Container) *gin.Engine {
 	userTelegramOIDCHandler := userAuthHandlers.TelegramOIDC
 	userTelegramHandler := userAuthHandlers.Telegram
 	userGoogleHandler := userAuthHandlers.Google
+	userOIDCHandler := userAuthHandlers.OIDC
 	walletHandlers := walletbootstrap.New(c)
 	userWalletHandler := walletHandlers.User
 	adminWalletHandler := walletHandlers.Admin
 	channelWalletHandler := walletHandlers.Channel
+	walletFundsHandler := walletHandlers.Funds
 	channelMemberLevelHandler := memberleveltransport.NewChannelHandler(c.MemberLevelService)
 	adminApiCredentialHandler := apicredentialtransport.NewAdminHandler(c.ApiCredentialService)
 	userApiCredentialHandler := apicredentialtransport.NewUserHandler(c.ApiCredentialService)
@@ -205,6 +211,13 @@ func SetupRouter(cfg *config.Config, c *container.Container) *gin.Engine {
 		BlockSeconds:  300,
 		MessageKey:    "error.rate_limited",
 	}
+	captchaPoWRule := middleware.RateLimitRule{
+		Prefix:        fmt.Sprintf("%s:rate:captcha_pow", redisPrefix),
+		WindowSeconds: 60,
+		MaxRequests:   60,
+		BlockSeconds:  120,
+		MessageKey:    "error.rate_limited",
+	}
 	upstreamAPIRule := middleware.RateLimitRule{
 		Prefix:        fmt.Sprintf("%s:rate:upstream_api", redisPrefix),
 		WindowSeconds: 60,
@@ -226,12 +239,16 @@ func SetupRouter(cfg *config.Config, c *container.Container) *gin.Engine {
 	// SEO 资源（动态生成）。
 	sitemaptransport.RegisterRoutes(r, sitemaptransport.NewHandler(c.SitemapService, sitemapbrand.New(c.SettingService)))
 
+	// 分销自定义域名 HTTP 校验挑战。
+	resellerusertransport.RegisterDomainChallengeRoutes(r, userResellerHandler)
+
 	apiV1 := r.Group("/api/v1")
-	registerStorefrontRoutes(apiV1, cfg, c, publicContentHandler, publicCatalogHandler, publicCategoryHandler, userResellerHandler, userResellerProductSettingHandler, userResellerFinanceHandler, userResellerOrderHandler, userApiCredentialHandler, userAuditLogHandler, userGiftCardHandler, publicMemberLevelHandler, userProfileHandler, userEmailHandler, userPasswordHandler, userVerifyHandler, userTelegramOIDCHandler, userTelegramHandler, userGoogleHandler, userLoginHandler, user2FAHandler, publicConfigHandler, userCartHandler, userOrderHandler, guestOrderHandler, orderPreviewHandler, orderCreateHandler, paymentLatestHandler, paymentWriteHandler, userWalletHandler, redisClient, loginRule, guestReadRule, guestWriteRule)
+	registerStorefrontRoutes(apiV1, cfg, c, publicContentHandler, publicCatalogHandler, publicCategoryHandler, userResellerHandler, userResellerProductSettingHandler, userResellerFinanceHandler, userResellerOrderHandler, userApiCredentialHandler, userAuditLogHandler, userGiftCardHandler, publicMemberLevelHandler, userProfileHandler, userEmailHandler, userPasswordHandler, userVerifyHandler, userTelegramOIDCHandler, userTelegramHandler, userGoogleHandler, userOIDCHandler, userLoginHandler, user2FAHandler, publicConfigHandler, userCartHandler, userOrderHandler, guestOrderHandler, orderPreviewHandler, orderCreateHandler, paymentLatestHandler, paymentWriteHandler, userWalletHandler, walletFundsHandler, redisClient, loginRule, guestReadRule, guestWriteRule, captchaPoWRule)
 	registerUpstreamRoutes(apiV1, c, upstreamHandler, redisClient, upstreamAPIRule)
 	registerChannelRoutes(apiV1, c, channelHandler, channelMemberLevelHandler, channelGiftCardHandler, channelAffiliateHandler, channelTelegramBotHandler, channelWalletHandler)
 	registerPaymentCallbackRoutes(apiV1, paymentCallbackHandler, paymentWebhookHandler)
-	registerAdminRoutes(r, apiV1, cfg, c, adminLoginHandler, admin2FAHandler, adminUser2FAHandler, adminUserHandler, adminAuthzHandler, adminFulfillmentHandler, adminOrderHandler, adminOrderRefundHandler, adminContentHandler, adminDashboardHandler, adminMemberLevelHandler, adminApiCredentialHandler, adminAuditLogHandler, adminCardSecretHandler, adminCatalogCategoryHandler, adminCatalogProductHandler, adminCatalogProductMappingHandler, adminCouponHandler, adminGiftCardHandler, adminPromotionHandler, adminNotificationHandler, adminProcurementHandler, adminResellerManagementHandler, adminResellerProfileDetailHandler, adminResellerSiteConfigHandler, adminResellerProductSettingHandler, adminResellerOperationsHandler, adminResellerFinanceHandler, adminSettingsHandler, adminWalletHandler, adminPaymentHandler, adminPaymentChannelHandler, redisClient, adminLoginRule)
+	fulfillmentwebhooktransport.RegisterCallbackRoutes(apiV1, fulfillmentWebhookCallbackHandler)
+	registerAdminRoutes(r, apiV1, cfg, c, adminLoginHandler, admin2FAHandler, adminUser2FAHandler, adminUserHandler, adminAuthzHandler, adminFulfillmentHandler, adminOrderHandler, adminOrderRefundHandler, adminContentHandler, adminDashboardHandler, adminMemberLevelHandler, adminApiCredentialHandler, adminAuditLogHandler, adminCardSecretHandler, adminCatalogCategoryHandler, adminCatalogProductHandler, adminCatalogProductMappingHandler, adminCouponHandler, adminGiftCardHandler, adminPromotionHandler, adminNotificationHandler, adminProcurementHandler, adminResellerManagementHandler, adminResellerProfileDetailHandler, adminResellerSiteConfigHandler, adminResellerProductSettingHandler, adminResellerOperationsHandler, adminResellerFinanceHandler, adminSettingsHandler, adminWalletHandler, walletFundsHandler, adminPaymentHandler, adminPaymentChannelHandler, redisClient, adminLoginRule)
 
 	// 健康检查
 	r.GET("/health", func(c *gin.Context) {
@@ -4,6 +4,7 @@ import (
 	"github.com/dujiao-next/internal/app/container"
 	"github.com/dujiao-next/internal/app/httpserver/middleware"
 	affiliatebootstrap "github.com/dujiao-next/internal/bootstrap/affiliate"
+	fulfillmentwiring "github.com/dujiao-next/internal/bootstrap/fulfillment"
 	settingsbootstrap "github.com/dujiao-next/internal/bootstrap/settingshttp"
 	"github.com/dujiao-next/internal/config"
 	adproxytransport "github.com/dujiao-next/internal/modules/adproxy/transport/http"
@@ -14,26 +15,39 @@ import (
 	categoryhttp "github.com/dujiao-next/internal/modules/catalog/category/transport/http"
 	mappinghttp "github.com/dujiao-next/internal/modules/catalog/mapping/transport/http"
 	producthttp "github.com/dujiao-next/internal/modules/catalog/product/transport/http"
+	changesettransport "github.com/dujiao-next/internal/modules/changeset/transport/http"
 	channelclienthttp "github.com/dujiao-next/internal/modules/channelclient/transport/http"
 	compliancetransport "github.com/dujiao-next/internal/modules/compliance/transport/http"
 	contenttransport "github.com/dujiao-next/internal/modules/content/transport/http"
 	coupontransport "github.com/dujiao-next/internal/modules/coupon/transport/http"
 	dashboardtransport "github.com/dujiao-next/internal/modules/dashboard/transport/http"
+	downstreamcallbacktransport "github.com/dujiao-next/internal/modules/downstreamcallback/transport/http"
+	fulfillmentfilestransport "github.com/dujiao-next/internal/modules/fulfillment/files/transport/http"
 	fulfillmenttransport "github.com/dujiao-next/internal/modules/fulfillment/transport/http"
+	fxratetransport "github.com/dujiao-next/internal/modules/fxrate/transport/http"
 	giftcardtransport "github.com/dujiao-next/internal/modules/giftcard/transport/http"
 	adminauthtransport "github.com/dujiao-next/internal/modules/identity/adminauth/transport/http"
 	adminauthztransport "github.com/dujiao-next/internal/modules/identity/adminauthorization/transport/http"
+	oidcauthtransport "github.com/dujiao-next/internal/modules/identity/oidcauth/transport/http"
 	adminusertransport "github.com/dujiao-next/internal/modules/identity/user/transport/http/admin"
+	licensetransport "github.com/dujiao-next/internal/modules/license/transport/http"
 	memberleveltransport "github.com/dujiao-next/internal/modules/memberlevel/transport/http"
 	notificationtransport "github.com/dujiao-next/internal/modules/notification/transport/http"
 	ordertransport "github.com/dujiao-next/internal/modules/order/transport/http"
+	orderrisktransport "github.com/dujiao-next/internal/modules/orderrisk/transport/http"
 	paymenttransport "github.com/dujiao-next/internal/modules/payment/transport/http"
+	payouttransport "github.com/dujiao-next/internal/modules/payout/transport/http"
+	preordertransport "github.com/dujiao-next/internal/modules/preorder/transport/http"
 	procurementtransport "github.com/dujiao-next/internal/modules/procurement/transport/http"
 	promotiontransport "github.com/dujiao-next/internal/modules/promotion/transport/http"
 	reconciliationtransport "github.com/dujiao-next/internal/modules/reconciliation/transport/http"
+	refundpropagationtransport "github.com/dujiao-next/internal/modules/refundpropagation/transport/http"
 	resellertransport "github.com/dujiao-next/internal/modules/reseller/transport/http/admin"
+	restocktransport "github.com/dujiao-next/internal/modules/restock/transport/http"
 	settingstransport "github.com/dujiao-next/internal/modules/settings/transport/http"
 	siteconnectiontransport "github.com/dujiao-next/internal/modules/siteconnection/transport/http"
+	stockledgertransport "github.com/dujiao-next/internal/modules/stockledger/transport/http"
+	stockthresholdtransport "github.com/dujiao-next/internal/modules/stockthreshold/transport/http"
 	broadcasthttp "github.com/dujiao-next/internal/modules/telegram/broadcast/transport/http"
 	uploadtransport "github.com/dujiao-next/internal/modules/upload/transport/http"
 	wallettransport "github.com/dujiao-next/internal/modules/wallet/transport/http"
@@ -79,6 +93,7 @@ func registerAdminRoutes(
 	adminResellerFinanceHandler *resellertransport.AdminFinanceHandler,
 	adminSettingsHandler *settingstransport.AdminHandler,
 	adminWalletHandler *wallettransport.AdminHandler,
+	walletFundsHandler *wallettransport.FundsHandler,
 	adminPaymentHandler *paymenttransport.AdminHandler,
 	adminPaymentChannelHandler *paymenttransport.AdminChannelHandler,
 	redisClient *redis.Client,
@@ -112,6 +127,7 @@ func registerAdminRoutes(
 
 	// 设置管理
 	settingstransport.RegisterAdminRoutes(authorized, adminSettingsHandler)
+	settingstransport.RegisterAdminHistoryRoutes(authorized, settingstransport.NewHistoryHandler(c.SettingVersioningService))
 	settingstransport.RegisterAdminSMTPRoutes(authorized, settingsbootstrap.NewSMTPHandler(c, cfg))
 	settingstransport.RegisterAdminCaptchaRoutes(authorized, settingsbootstrap.NewCaptchaHandler(c, cfg))
 	settingstransport.RegisterAdminTelegramAuthRoutes(authorized, settingsbootstrap.NewTelegramAuthHandler(c, cfg))
@@ -120,6 +136,7 @@ func registerAdminRoutes(
 	settingstransport.RegisterAdminOrderEmailTemplateRoutes(authorized, settingstransport.NewOrderEmailTemplateHandler(c.SettingService))
 	settingstransport.RegisterAdminAffiliateRoutes(authorized, settingstransport.NewAffiliateHandler(c.SettingService))
 	settingstransport.RegisterAdminTelegramBotRoutes(authorized, settingstransport.NewTelegramBotHandler(c.SettingService))
+	settingstransport.RegisterAdminLocaleRoutes(authorized, settingstransport.NewLocaleHandler(c.SettingService))
 	adminauthtransport.RegisterAdminPasswordRoutes(authorized, adminLoginHandler)
 
 	// 系统信息与版本检测
@@ -131,6 +148,7 @@ func registerAdminRoutes(
 	adminAffiliateHandler := affiliatebootstrap.NewAdminHandler(c)
 	affiliatetransport.RegisterAdminRoutes(authorized, adminAffiliateHandler)
 	affiliatetransport.RegisterAdminFinanceRoutes(paymentProtected, adminAffiliateHandler)
+	affiliatetransport.RegisterAdminCommissionRuleRoutes(authorized, adminAffiliateHandler)
 	resellertransport.RegisterOperationsOverviewRoutes(authorized, adminResellerOperationsHandler)
 	resellertransport.RegisterManagementRoutes(authorized, adminResellerManagementHandler)
 	resellertransport.RegisterProfileDetailRoutes(authorized, adminResellerProfileDetailHandler)
@@ -138,6 +156,8 @@ func registerAdminRoutes(
 	resellertransport.RegisterProductSettingRoutes(authorized, adminResellerProductSettingHandler)
 	resellertransport.RegisterOperationsFinanceRoutes(paymentProtected, adminResellerOperationsHandler)
 	resellertransport.RegisterFinanceRoutes(paymentProtected, adminResellerFinanceHandler)
+	payouttransport.RegisterAdminRoutes(paymentProtected, payouttransport.NewAdminHandler(c.PayoutService))
+	fxratetransport.RegisterAdminRoutes(paymentProtected, fxratetransport.NewAdminHandler(c.FXRateService))
 
 	// 权限管理
 	adminauthztransport.RegisterAdminRoutes(authorized, adminAuthzHandler)
@@ -153,7 +173,16 @@ func registerAdminRoutes(
 	ordertransport.RegisterAdminRoutes(authorized, adminOrderHandler)
 	ordertransport.RegisterAdminRefundWriteRoutes(authorized, adminOrderRefundHandler)
 	ordertransport.RegisterAdminRefundRoutes(authorized, adminOrderRefundHandler)
+	orderrisktransport.RegisterAdminRoutes(authorized, orderrisktransport.NewAdminHandler(c.OrderReviewService))
+	orderrisktransport.RegisterAdminBlacklistRoutes(authorized, orderrisktransport.NewBlacklistHandler(c.CustomerBlacklistService))
 	fulfillmenttransport.RegisterAdminRoutes(authorized, adminFulfillmentHandler)
+	fulfillmentfilestransport.RegisterAdminRoutes(authorized, fulfillmentwiring.NewFileAdminHandler(c))
+	licensetransport.RegisterAdminRoutes(authorized, licensetransport.NewAdminHandler(c.LicenseService))
+	restocktransport.RegisterAdminRoutes(authorized, restocktransport.NewAdminHandler(c.RestockService))
+	preordertransport.RegisterAdminRoutes(authorized, preordertransport.NewAdminHandler(c.PreorderService))
+	stockledgertransport.RegisterAdminRoutes(authorized, stockledgertransport.NewAdminHandler(c.StockLedgerService))
+	stockthresholdtransport.RegisterAdminRoutes(authorized, stockthresholdtransport.NewAdminHandler(c.StockThresholdService))
+	changesettransport.RegisterAdminRoutes(authorized, changesettransport.NewAdminHandler(c.ChangeSetService))
 	cardsecrettransport.RegisterAdminRoutes(authorized, adminCardSecretHandler)
 	giftcardtransport.RegisterAdminRoutes(authorized, adminGiftCardHandler)
 
@@ -171,10 +200,15 @@ func registerAdminRoutes(
 	// 用户管理
 	adminusertransport.RegisterAdminRoutes(authorized, adminUserHandler)
 	wallettransport.RegisterAdminRoutes(paymentProtected, adminWalletHandler)
+	wallettransport.RegisterAdminFundsRoutes(paymentProtected, walletFundsHandler)
 	adminauthtransport.RegisterAdminUser2FARoutes(authorized, adminUser2FAHandler)
 
+	// 通用 OIDC 登录提供方
+	oidcauthtransport.RegisterAdminRoutes(authorized, oidcauthtransport.NewAdminHandler(c.OIDCAuthService))
+
 	// API 凭证审核管理
 	apicredentialtransport.RegisterAdminRoutes(authorized, adminApiCredentialHandler)
+	downstreamcallbacktransport.RegisterAdminRoutes(authorized, downstreamcallbacktransport.NewAdminHandler(c.DownstreamCallbackService))
 
 	// 站点对接连接管理
 	siteconnectiontransport.RegisterAdminRoutes(authorized, siteconnectiontransport.NewAdminHandler(
@@ -187,6 +221,7 @@ func registerAdminRoutes(
 
 	// 采购单管理
 	procurementtransport.RegisterAdminRoutes(authorized, adminProcurementHandler)
+	refundpropagationtransport.RegisterAdminRoutes(authorized, refundpropagationtransport.NewAdminHandler(c.RefundPropagationService))
 
 	// 对账管理
 	reconciliationtransport.RegisterAdminRoutes(paymentProtected, reconciliationtransport.NewAdminHandler(c.ReconciliationService))
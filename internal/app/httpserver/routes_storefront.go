@@ -4,6 +4,8 @@ import (
 	"github.com/dujiao-next/internal/app/container"
 	"github.com/dujiao-next/internal/app/httpserver/middleware"
 	affiliatebootstrap "github.com/dujiao-next/internal/bootstrap/affiliate"
+	fulfillmentwiring "github.com/dujiao-next/internal/bootstrap/fulfillment"
+	resellerbootstrap "github.com/dujiao-next/internal/bootstrap/reseller"
 	"github.com/dujiao-next/internal/config"
 	affiliatetransport "github.com/dujiao-next/internal/modules/affiliate/transport/http"
 	apicredentialtransport "github.com/dujiao-next/internal/modules/apicredential/transport/http"
@@ -13,13 +15,19 @@ import (
 	categoryhttp "github.com/dujiao-next/internal/modules/catalog/category/transport/http"
 	producthttp "github.com/dujiao-next/internal/modules/catalog/product/transport/http"
 	contenttransport "github.com/dujiao-next/internal/modules/content/transport/http"
+	fulfillmentfilestransport "github.com/dujiao-next/internal/modules/fulfillment/files/transport/http"
+	fxratetransport "github.com/dujiao-next/internal/modules/fxrate/transport/http"
 	giftcardtransport "github.com/dujiao-next/internal/modules/giftcard/transport/http"
 	userauthtransport "github.com/dujiao-next/internal/modules/identity/userauth/transport/http"
+	licensetransport "github.com/dujiao-next/internal/modules/license/transport/http"
 	memberleveltransport "github.com/dujiao-next/internal/modules/memberlevel/transport/http"
 	ordertransport "github.com/dujiao-next/internal/modules/order/transport/http"
 	paymenttransport "github.com/dujiao-next/internal/modules/payment/transport/http"
 	paymentcallbacktransport "github.com/dujiao-next/internal/modules/payment/transport/http/callback"
+	preordertransport "github.com/dujiao-next/internal/modules/preorder/transport/http"
+	resellerstafftransport "github.com/dujiao-next/internal/modules/reseller/staff/transport/http"
 	resellertransport "github.com/dujiao-next/internal/modules/reseller/transport/http/user"
+	restocktransport "github.com/dujiao-next/internal/modules/restock/transport/http"
 	publicconfigtransport "github.com/dujiao-next/internal/modules/settings/transport/http/public"
 	wallettransport "github.com/dujiao-next/internal/modules/wallet/transport/http"
 
@@ -49,6 +57,7 @@ func registerStorefrontRoutes(
 	userTelegramOIDCHandler *userauthtransport.UserTelegramOIDCHandler,
 	userTelegramHandler *userauthtransport.UserTelegramHandler,
 	userGoogleHandler *userauthtransport.UserGoogleHandler,
+	userOIDCHandler *userauthtransport.UserOIDCHandler,
 	userLoginHandler *userauthtransport.UserLoginHandler,
 	user2FAHandler *userauthtransport.User2FAHandler,
 	publicConfigHandler *publicconfigtransport.Handler,
@@ -60,14 +69,23 @@ func registerStorefrontRoutes(
 	paymentLatestHandler *paymenttransport.LatestHandler,
 	paymentWriteHandler *paymenttransport.WriteHandler,
 	userWalletHandler *wallettransport.UserHandler,
+	walletFundsHandler *wallettransport.FundsHandler,
 	redisClient *redis.Client,
 	loginRule middleware.RateLimitRule,
 	guestReadRule middleware.RateLimitRule,
 	guestWriteRule middleware.RateLimitRule,
+	captchaPoWRule middleware.RateLimitRule,
 ) {
 	storefront := apiV1.Group("")
 	storefront.Use(middleware.ResellerTenantMiddleware(c.ResellerDomainResolver))
 	affiliateHandler := affiliatebootstrap.NewStorefrontHandler(c)
+	resellerStaffHandler := resellerbootstrap.NewStaffHandler(c)
+	fileFulfillmentHandler := fulfillmentwiring.NewFileHandler(c)
+
+	// 文件交付签名下载（鉴权由链接签名完成）
+	fulfillmentfilestransport.RegisterDownloadRoutes(storefront, fileFulfillmentHandler)
+	restockHandler := restocktransport.NewHandler(c.RestockService)
+	preorderHandler := preordertransport.NewHandler(c.PreorderService)
 
 	// 公开接口
 	public := storefront.Group("/public")
@@ -76,9 +94,15 @@ func registerStorefrontRoutes(
 		producthttp.RegisterPublicRoutes(public, publicCatalogHandler)
 		categoryhttp.RegisterPublicRoutes(public, publicCategoryHandler)
 		contenttransport.RegisterPublicRoutes(public, publicContentHandler)
-		captchatransport.RegisterPublicRoutes(public, captchatransport.NewPublicHandler(c.CaptchaService))
+		captchaHandler := captchatransport.NewPublicHandler(c.CaptchaService, c.CaptchaService)
+		captchatransport.RegisterPublicRoutes(public, captchaHandler)
+		captchatransport.RegisterPublicPoWRoutes(public, captchaHandler, middleware.RateLimitMiddleware(redisClient, captchaPoWRule, middleware.KeyByIP))
 		affiliatetransport.RegisterPublicRoutes(public, affiliateHandler)
 		memberleveltransport.RegisterPublicRoutes(public, publicMemberLevelHandler)
+		fxratetransport.RegisterPublicRoutes(public, fxratetransport.NewPublicHandler(c.FXRateService))
+		licensetransport.RegisterPublicRoutes(public, licensetransport.NewHandler(c.LicenseService), middleware.RateLimitMiddleware(redisClient, guestReadRule, middleware.KeyByIP))
+		restocktransport.RegisterPublicRoutes(public, restockHandler, middleware.RateLimitMiddleware(redisClient, guestReadRule, middleware.KeyByIP))
+		preordertransport.RegisterPublicRoutes(public, preorderHandler)
 	}
 
 	// 游客接口
@@ -88,6 +112,8 @@ func registerStorefrontRoutes(
 	{
 		ordertransport.RegisterGuestPreviewRoute(guestRead, orderPreviewHandler)
 		ordertransport.RegisterGuestReadRoutes(guestRead, guestOrderHandler)
+		fulfillmentfilestransport.RegisterGuestRoutes(guestRead, fileFulfillmentHandler)
+		preordertransport.RegisterGuestRoutes(guestRead, preorderHandler)
 		paymenttransport.RegisterGuestLatestRoute(guestRead, paymentLatestHandler)
 	}
 	guestWrite := guest.Group("")
@@ -96,10 +122,11 @@ func registerStorefrontRoutes(
 		ordertransport.RegisterGuestCreateRoute(guestWrite, orderCreateHandler)
 		ordertransport.RegisterGuestCreateAndPayRoute(guestWrite, orderCreateHandler)
 		paymenttransport.RegisterGuestWriteRoutes(guestWrite, paymentWriteHandler)
+		restocktransport.RegisterGuestRoutes(guestWrite, restockHandler)
 	}
 
 	// 用户认证接口
-	auth := storefront.Group("/auth")
+	auth := storefront.Group("/auth", middleware.CustomerBlacklistIP(c.CustomerBlacklistService))
 	{
 		userauthtransport.RegisterUserVerifyAuthRoutes(auth, userVerifyHandler)
 		userauthtransport.RegisterUserRegisterAuthRoutes(auth, userLoginHandler)
@@ -108,6 +135,7 @@ func registerStorefrontRoutes(
 		userauthtransport.RegisterUserTelegramAuthRoutes(auth, userTelegramHandler, middleware.RateLimitMiddleware(redisClient, loginRule, middleware.KeyByIP))
 		userauthtransport.RegisterUserTelegramOIDCAuthRoutes(auth, userTelegramOIDCHandler, middleware.RateLimitMiddleware(redisClient, loginRule, middleware.KeyByIP))
 		userauthtransport.RegisterUserGoogleAuthRoutes(auth, userGoogleHandler, middleware.RateLimitMiddleware(redisClient, loginRule, middleware.KeyByIP))
+		userauthtransport.RegisterUserOIDCAuthRoutes(auth, userOIDCHandler, middleware.RateLimitMiddleware(redisClient, loginRule, middleware.KeyByIP))
 		userauthtransport.RegisterUserPasswordAuthRoutes(auth, userPasswordHandler)
 	}
 
@@ -125,6 +153,7 @@ func registerStorefrontRoutes(
 			userGoogleHandler,
 			middleware.RateLimitMiddleware(redisClient, loginRule, middleware.KeyByUserIDAndIP),
 		)
+		userauthtransport.RegisterUserOIDCRoutes(user, userOIDCHandler)
 		userauthtransport.RegisterUserEmailRoutes(user, userEmailHandler)
 		userauthtransport.RegisterUser2FARoutes(user, user2FAHandler)
 		carttransport.RegisterUserRoutes(user, userCartHandler)
@@ -133,16 +162,22 @@ func registerStorefrontRoutes(
 		ordertransport.RegisterUserPreviewRoute(user, orderPreviewHandler)
 		ordertransport.RegisterUserPaymentChannelsRoute(user, userOrderHandler)
 		ordertransport.RegisterUserReadRoutes(user, userOrderHandler)
+		fulfillmentfilestransport.RegisterUserRoutes(user, fileFulfillmentHandler)
 		ordertransport.RegisterUserCancelRoute(user, userOrderHandler)
 		paymenttransport.RegisterUserWriteRoutes(user, paymentWriteHandler)
 		paymenttransport.RegisterUserLatestRoute(user, paymentLatestHandler)
 		wallettransport.RegisterUserRoutes(user, userWalletHandler)
+		wallettransport.RegisterUserFundsRoutes(user, walletFundsHandler)
 		giftcardtransport.RegisterUserRoutes(user, userGiftCardHandler)
 		affiliatetransport.RegisterUserRoutes(user, affiliateHandler)
+		restocktransport.RegisterUserRoutes(user, restockHandler)
+		preordertransport.RegisterUserRoutes(user, preorderHandler)
 
 		resellerConsole := user.Group("/reseller")
 		resellerConsole.Use(middleware.RequireMainTenantForResellerConsole())
+		resellerConsole.Use(resellerStaffHandler.ConsoleAccess(resellerConsole.BasePath()))
 		{
+			resellerstafftransport.RegisterConsoleRoutes(resellerConsole, resellerStaffHandler)
 			resellertransport.RegisterUserConsoleRoutes(resellerConsole, userResellerHandler)
 			resellertransport.RegisterUserProductSettingRoutes(resellerConsole, userResellerProductSettingHandler)
 			resellertransport.RegisterUserFinanceRoutes(resellerConsole, userResellerFinanceHandler)
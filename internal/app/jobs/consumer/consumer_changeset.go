@@ -0,0 +1,24 @@
+package consumer
+
+import (
+	"context"
+
+	"github.com/dujiao-next/internal/logger"
+
+	"github.com/hibiken/asynq"
+)
+
+// handleProductChangeSetRun 执行到期的商品变更集生效与回滚。
+func (c *Consumer) handleProductChangeSetRun(_ context.Context, _ *asynq.Task) error {
+	if c == nil || c.ChangeSetService == nil {
+		logger.Debugw("worker_product_change_set_run_skip_nil")
+		return nil
+	}
+	result, err := c.ChangeSetService.RunDue()
+	if err != nil {
+		logger.Warnw("worker_product_change_set_run_failed", "error", err)
+		return err
+	}
+	logger.Debugw("worker_product_change_set_run_done", "applied", result.Applied, "reverted", result.Reverted, "failed", result.Failed)
+	return nil
+}
@@ -44,10 +44,23 @@ func (c *Consumer) Register(mux *asynq.ServeMux) {
 	mux.HandleFunc(queue.TaskNotificationDispatch, withPanicRecovery(queue.TaskNotificationDispatch, c.handleNotificationDispatch))
 	mux.HandleFunc(queue.TaskAffiliateConfirmCommissions, withPanicRecovery(queue.TaskAffiliateConfirmCommissions, c.handleAffiliateConfirmCommissions))
 	mux.HandleFunc(queue.TaskResellerConfirmLedger, withPanicRecovery(queue.TaskResellerConfirmLedger, c.handleResellerConfirmLedger))
+	mux.HandleFunc(queue.TaskOrderReviewSLACheck, withPanicRecovery(queue.TaskOrderReviewSLACheck, c.handleOrderReviewSLACheck))
+	mux.HandleFunc(queue.TaskMemberLevelEvaluate, withPanicRecovery(queue.TaskMemberLevelEvaluate, c.handleMemberLevelEvaluate))
+	mux.HandleFunc(queue.TaskResellerDomainRecheck, withPanicRecovery(queue.TaskResellerDomainRecheck, c.handleResellerDomainRecheck))
 	mux.HandleFunc(queue.TaskUpstreamSyncStock, withPanicRecovery(queue.TaskUpstreamSyncStock, c.handleUpstreamSyncStock))
 	mux.HandleFunc(queue.TaskProcurementSubmit, withPanicRecovery(queue.TaskProcurementSubmit, c.handleProcurementSubmit))
 	mux.HandleFunc(queue.TaskProcurementPollStatus, withPanicRecovery(queue.TaskProcurementPollStatus, c.handleProcurementPollStatus))
 	mux.HandleFunc(queue.TaskProcurementSyncAccepted, withPanicRecovery(queue.TaskProcurementSyncAccepted, c.handleProcurementSyncAccepted))
+	mux.HandleFunc(queue.TaskProcurementSyncRefunds, withPanicRecovery(queue.TaskProcurementSyncRefunds, c.handleProcurementSyncRefunds))
+	mux.HandleFunc(queue.TaskFulfillmentWebhookDispatch, withPanicRecovery(queue.TaskFulfillmentWebhookDispatch, c.handleFulfillmentWebhookDispatch))
+	mux.HandleFunc(queue.TaskFulfillmentFileDeliver, withPanicRecovery(queue.TaskFulfillmentFileDeliver, c.handleFulfillmentFileDeliver))
+	mux.HandleFunc(queue.TaskFulfillmentLicenseDeliver, withPanicRecovery(queue.TaskFulfillmentLicenseDeliver, c.handleFulfillmentLicenseDeliver))
+	mux.HandleFunc(queue.TaskRestockCheck, withPanicRecovery(queue.TaskRestockCheck, c.handleRestockCheck))
+	mux.HandleFunc(queue.TaskRestockDeliver, withPanicRecovery(queue.TaskRestockDeliver, c.handleRestockDeliver))
+	mux.HandleFunc(queue.TaskPreorderAllocate, withPanicRecovery(queue.TaskPreorderAllocate, c.handlePreorderAllocate))
+	mux.HandleFunc(queue.TaskPreorderSweep, withPanicRecovery(queue.TaskPreorderSweep, c.handlePreorderSweep))
+	mux.HandleFunc(queue.TaskStockLedgerCheck, withPanicRecovery(queue.TaskStockLedgerCheck, c.handleStockLedgerCheck))
+	mux.HandleFunc(queue.TaskProductChangeSetRun, withPanicRecovery(queue.TaskProductChangeSetRun, c.handleProductChangeSetRun))
 	mux.HandleFunc(queue.TaskDownstreamCallback, withPanicRecovery(queue.TaskDownstreamCallback, c.handleDownstreamCallback))
 	mux.HandleFunc(queue.TaskReconciliationRun, withPanicRecovery(queue.TaskReconciliationRun, c.handleReconciliationRun))
 	mux.HandleFunc(queue.TaskBotNotify, withPanicRecovery(queue.TaskBotNotify, c.handleBotNotify))
@@ -70,6 +70,61 @@ func (c *Consumer) handleResellerConfirmLedger(_ context.Context, _ *asynq.Task)
 	return nil
 }
 
+// handleOrderReviewSLACheck 为超过复核时限的待复核订单发送超时告警。
+func (c *Consumer) handleOrderReviewSLACheck(_ context.Context, _ *asynq.Task) error {
+	if c == nil || c.OrderReviewService == nil {
+		logger.Debugw("worker_order_review_sla_skip_nil", "consumer_nil", c == nil)
+		return nil
+	}
+	alerted, err := c.OrderReviewService.AlertOverdue()
+	if err != nil {
+		logger.Warnw("worker_order_review_sla_failed", "error", err)
+		return err
+	}
+	logger.Debugw("worker_order_review_sla_ok", "alerted", alerted)
+	return nil
+}
+
+// handleMemberLevelEvaluate 处理到期会员等级的续期/降级，并按统计窗口重新评估等级。
+func (c *Consumer) handleMemberLevelEvaluate(_ context.Context, _ *asynq.Task) error {
+	if c == nil || c.MemberLevelService == nil {
+		logger.Debugw("worker_member_level_evaluate_skip_nil", "consumer_nil", c == nil)
+		return nil
+	}
+	result, err := c.MemberLevelService.EvaluateMemberships(time.Now())
+	if err != nil {
+		logger.Warnw("worker_member_level_evaluate_failed", "error", err)
+		return err
+	}
+	logger.Debugw("worker_member_level_evaluate_ok",
+		"checked", result.Checked,
+		"upgraded", result.Upgraded,
+		"renewed", result.Renewed,
+		"downgraded", result.Downgraded,
+	)
+	return nil
+}
+
+// handleResellerDomainRecheck 周期复查分销自定义域名的 DNS TXT / HTTP 校验状态。
+func (c *Consumer) handleResellerDomainRecheck(ctx context.Context, _ *asynq.Task) error {
+	if c == nil || c.ResellerManagementService == nil {
+		logger.Debugw("worker_reseller_domain_recheck_skip_nil", "consumer_nil", c == nil)
+		return nil
+	}
+	result, err := c.ResellerManagementService.RecheckDomains(ctx, time.Now())
+	if err != nil {
+		logger.Warnw("worker_reseller_domain_recheck_failed", "error", err)
+		return err
+	}
+	logger.Debugw("worker_reseller_domain_recheck_ok",
+		"checked", result.Checked,
+		"verified", result.Verified,
+		"failed", result.Failed,
+		"suspended", result.Suspended,
+	)
+	return nil
+}
+
 // handleReconciliationRun 处理对账任务执行。
 func (c *Consumer) handleReconciliationRun(ctx context.Context, task *asynq.Task) error {
 	if c == nil || task == nil || c.ReconciliationService == nil {
@@ -11,6 +11,9 @@ import (
 
 	fulfillmentapp "github.com/dujiao-next/internal/modules/fulfillment/application"
 	fulfillmentdomain "github.com/dujiao-next/internal/modules/fulfillment/domain"
+	filescontract "github.com/dujiao-next/internal/modules/fulfillment/files/contract"
+	webhookcontract "github.com/dujiao-next/internal/modules/fulfillment/webhook/contract"
+	licensecontract "github.com/dujiao-next/internal/modules/license/contract"
 	orderapp "github.com/dujiao-next/internal/modules/order/application"
 	orderdomain "github.com/dujiao-next/internal/modules/order/domain"
 
@@ -272,6 +275,95 @@ func (c *Consumer) handleOrderAutoFulfill(_ context.Context, task *asynq.Task) e
 	return nil
 }
 
+// handleFulfillmentWebhookDispatch 处理 webhook 交付推送任务，重试由交付服务自行调度。
+func (c *Consumer) handleFulfillmentWebhookDispatch(ctx context.Context, task *asynq.Task) error {
+	if c == nil || task == nil || c.FulfillmentWebhookService == nil {
+		logger.Debugw("worker_fulfillment_webhook_skip_nil")
+		return nil
+	}
+	var payload queue.FulfillmentWebhookDispatchPayload
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		logger.Warnw("worker_fulfillment_webhook_unmarshal_failed", "error", err)
+		return err
+	}
+	if payload.DeliveryID == 0 {
+		return nil
+	}
+	if err := c.FulfillmentWebhookService.Dispatch(ctx, payload.DeliveryID); err != nil {
+		if errors.Is(err, webhookcontract.ErrDeliveryNotFound) {
+			logger.Debugw("worker_fulfillment_webhook_skip_not_found", "delivery_id", payload.DeliveryID)
+			return nil
+		}
+		logger.Warnw("worker_fulfillment_webhook_failed", "delivery_id", payload.DeliveryID, "error", err)
+		return err
+	}
+	return nil
+}
+
+// handleFulfillmentFileDeliver 处理文件交付任务，失败交由队列重试，重试耗尽后转人工交付提醒。
+func (c *Consumer) handleFulfillmentFileDeliver(ctx context.Context, task *asynq.Task) error {
+	if c == nil || task == nil || c.FulfillmentFileService == nil {
+		logger.Debugw("worker_fulfillment_file_skip_nil")
+		return nil
+	}
+	var payload queue.FulfillmentOrderDeliverPayload
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		logger.Warnw("worker_fulfillment_file_unmarshal_failed", "error", err)
+		return err
+	}
+	if payload.OrderID == 0 {
+		return nil
+	}
+	if err := c.FulfillmentFileService.DeliverForOrder(payload.OrderID); err != nil {
+		if errors.Is(err, filescontract.ErrOrderNotFound) || errors.Is(err, filescontract.ErrNotFileOrder) {
+			logger.Debugw("worker_fulfillment_file_skip", "order_id", payload.OrderID, "error", err)
+			return nil
+		}
+		logger.Warnw("worker_fulfillment_file_failed", "order_id", payload.OrderID, "error", err)
+		c.notifyManualFulfillmentOnFinalRetry(ctx, payload.OrderID)
+		return err
+	}
+	return nil
+}
+
+// handleFulfillmentLicenseDeliver 处理授权码交付任务，失败交由队列重试，重试耗尽后转人工交付提醒。
+func (c *Consumer) handleFulfillmentLicenseDeliver(ctx context.Context, task *asynq.Task) error {
+	if c == nil || task == nil || c.LicenseService == nil {
+		logger.Debugw("worker_fulfillment_license_skip_nil")
+		return nil
+	}
+	var payload queue.FulfillmentOrderDeliverPayload
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		logger.Warnw("worker_fulfillment_license_unmarshal_failed", "error", err)
+		return err
+	}
+	if payload.OrderID == 0 {
+		return nil
+	}
+	if err := c.LicenseService.DeliverForOrder(payload.OrderID); err != nil {
+		if errors.Is(err, licensecontract.ErrOrderNotFound) || errors.Is(err, licensecontract.ErrNotLicenseOrder) {
+			logger.Debugw("worker_fulfillment_license_skip", "order_id", payload.OrderID, "error", err)
+			return nil
+		}
+		logger.Warnw("worker_fulfillment_license_failed", "order_id", payload.OrderID, "error", err)
+		c.notifyManualFulfillmentOnFinalRetry(ctx, payload.OrderID)
+		return err
+	}
+	return nil
+}
+
+// notifyManualFulfillmentOnFinalRetry 自动交付任务最后一次重试仍失败时发送待人工交付提醒。
+func (c *Consumer) notifyManualFulfillmentOnFinalRetry(ctx context.Context, orderID uint) {
+	retryCount, _ := asynq.GetRetryCount(ctx)
+	maxRetry, _ := asynq.GetMaxRetry(ctx)
+	if retryCount < maxRetry || c.PaymentService == nil {
+		return
+	}
+	if err := c.PaymentService.NotifyManualFulfillmentPending(orderID); err != nil {
+		logger.Warnw("worker_fulfillment_manual_fallback_failed", "order_id", orderID, "error", err)
+	}
+}
+
 // handleOrderTimeoutCancel 处理超时未支付订单自动取消任务。
 func (c *Consumer) handleOrderTimeoutCancel(_ context.Context, task *asynq.Task) error {
 	if c == nil || task == nil {
@@ -0,0 +1,45 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/dujiao-next/internal/logger"
+	"github.com/dujiao-next/internal/queue"
+
+	"github.com/hibiken/asynq"
+)
+
+// handlePreorderAllocate 处理预售排队分配任务，按支付先后为排队记录分配新到库存。
+func (c *Consumer) handlePreorderAllocate(_ context.Context, task *asynq.Task) error {
+	if c == nil || task == nil || c.PreorderService == nil {
+		logger.Debugw("worker_preorder_allocate_skip_nil")
+		return nil
+	}
+	var payload queue.PreorderAllocatePayload
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		logger.Warnw("worker_preorder_allocate_unmarshal_failed", "error", err)
+		return err
+	}
+	if payload.ProductID == 0 {
+		return nil
+	}
+	if err := c.PreorderService.Allocate(payload.ProductID); err != nil {
+		logger.Warnw("worker_preorder_allocate_failed", "product_id", payload.ProductID, "error", err)
+		return err
+	}
+	return nil
+}
+
+// handlePreorderSweep 巡检逾期预售并退款，同时为仍在排队的商品补做分配。
+func (c *Consumer) handlePreorderSweep(_ context.Context, _ *asynq.Task) error {
+	if c == nil || c.PreorderService == nil {
+		logger.Debugw("worker_preorder_sweep_skip_nil")
+		return nil
+	}
+	if err := c.PreorderService.Sweep(); err != nil {
+		logger.Warnw("worker_preorder_sweep_failed", "error", err)
+		return err
+	}
+	return nil
+}
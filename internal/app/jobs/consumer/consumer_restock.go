@@ -0,0 +1,53 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/dujiao-next/internal/logger"
+	"github.com/dujiao-next/internal/queue"
+
+	"github.com/hibiken/asynq"
+)
+
+// handleRestockCheck 处理到货检查任务，按订阅顺序挑选本批需要提醒的订阅。
+func (c *Consumer) handleRestockCheck(_ context.Context, task *asynq.Task) error {
+	if c == nil || task == nil || c.RestockService == nil {
+		logger.Debugw("worker_restock_check_skip_nil")
+		return nil
+	}
+	var payload queue.RestockCheckPayload
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		logger.Warnw("worker_restock_check_unmarshal_failed", "error", err)
+		return err
+	}
+	if payload.ProductID == 0 {
+		return nil
+	}
+	if err := c.RestockService.CheckProduct(payload.ProductID); err != nil {
+		logger.Warnw("worker_restock_check_failed", "product_id", payload.ProductID, "error", err)
+		return err
+	}
+	return nil
+}
+
+// handleRestockDeliver 处理单个订阅的到货提醒投递任务。
+func (c *Consumer) handleRestockDeliver(ctx context.Context, task *asynq.Task) error {
+	if c == nil || task == nil || c.RestockService == nil {
+		logger.Debugw("worker_restock_deliver_skip_nil")
+		return nil
+	}
+	var payload queue.RestockDeliverPayload
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		logger.Warnw("worker_restock_deliver_unmarshal_failed", "error", err)
+		return err
+	}
+	if payload.SubscriptionID == 0 {
+		return nil
+	}
+	if err := c.RestockService.Deliver(ctx, payload.SubscriptionID); err != nil {
+		logger.Warnw("worker_restock_deliver_failed", "subscription_id", payload.SubscriptionID, "error", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package consumer
+
+import (
+	"context"
+
+	"github.com/dujiao-next/internal/logger"
+
+	"github.com/hibiken/asynq"
+)
+
+// handleStockLedgerCheck 比对库存计数与台账流水，记录偏差。
+func (c *Consumer) handleStockLedgerCheck(_ context.Context, _ *asynq.Task) error {
+	if c == nil || c.StockLedgerService == nil {
+		logger.Debugw("worker_stock_ledger_check_skip_nil")
+		return nil
+	}
+	result, err := c.StockLedgerService.Check()
+	if err != nil {
+		logger.Warnw("worker_stock_ledger_check_failed", "error", err)
+		return err
+	}
+	logger.Debugw("worker_stock_ledger_check_done", "checked", result.Checked, "drifted", result.Drifted, "resolved", result.Resolved)
+	return nil
+}
@@ -21,6 +21,12 @@ func (c *Consumer) handleUpstreamSyncStock(_ context.Context, _ *asynq.Task) err
 		logger.Warnw("worker_upstream_sync_stock_failed", "error", err)
 		return err
 	}
+	// 上游库存刷新后为仍有待通知订阅的商品调度到货检查
+	if c.RestockService != nil {
+		if err := c.RestockService.TriggerPendingChecks(); err != nil {
+			logger.Warnw("worker_upstream_sync_stock_restock_trigger_failed", "error", err)
+		}
+	}
 	return nil
 }
 
@@ -82,6 +88,16 @@ func (c *Consumer) handleProcurementSyncAccepted(_ context.Context, _ *asynq.Tas
 	return nil
 }
 
+// handleProcurementSyncRefunds 处理已交付采购单的上游退款巡检任务。
+func (c *Consumer) handleProcurementSyncRefunds(_ context.Context, _ *asynq.Task) error {
+	if c == nil || c.ProcurementOrderService == nil {
+		logger.Debugw("worker_procurement_sync_refunds_skip_nil")
+		return nil
+	}
+	c.ProcurementOrderService.SyncUpstreamRefunds()
+	return nil
+}
+
 // handleDownstreamCallback 处理下游回调发送任务。
 func (c *Consumer) handleDownstreamCallback(ctx context.Context, task *asynq.Task) error {
 	if c == nil || task == nil || c.DownstreamCallbackService == nil {
@@ -96,7 +112,7 @@ func (c *Consumer) handleDownstreamCallback(ctx context.Context, task *asynq.Tas
 	if payload.DownstreamOrderRefID == 0 {
 		return nil
 	}
-	if err := c.DownstreamCallbackService.SendCallback(ctx, payload.DownstreamOrderRefID); err != nil {
+	if err := c.DownstreamCallbackService.SendCallback(ctx, payload.DownstreamOrderRefID, payload.Trigger); err != nil {
 		logger.Warnw("worker_downstream_callback_failed",
 			"ref_id", payload.DownstreamOrderRefID,
 			"error", err,
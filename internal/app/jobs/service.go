@@ -70,6 +70,60 @@ func registerPeriodicTasks(scheduler *asynq.Scheduler, consumer *jobconsumer.Con
 			logger.Infow("scheduler_register_reseller_confirm_ledger_ok", "entry_id", entryID)
 		}
 	}
+	if consumer.OrderReviewService != nil {
+		task := queue.NewOrderReviewSLACheckTask()
+		entryID, err := scheduler.Register("@every 5m", task, asynq.Queue(queue.DefaultQueue))
+		if err != nil {
+			logger.Warnw("scheduler_register_order_review_sla_failed", "error", err)
+		} else {
+			logger.Infow("scheduler_register_order_review_sla_ok", "entry_id", entryID)
+		}
+	}
+	if consumer.PreorderService != nil {
+		task := queue.NewPreorderSweepTask()
+		entryID, err := scheduler.Register("@every 5m", task, asynq.Queue(queue.DefaultQueue))
+		if err != nil {
+			logger.Warnw("scheduler_register_preorder_sweep_failed", "error", err)
+		} else {
+			logger.Infow("scheduler_register_preorder_sweep_ok", "entry_id", entryID)
+		}
+	}
+	if consumer.StockLedgerService != nil {
+		task := queue.NewStockLedgerCheckTask()
+		entryID, err := scheduler.Register("@every 1h", task, asynq.Queue(queue.DefaultQueue))
+		if err != nil {
+			logger.Warnw("scheduler_register_stock_ledger_check_failed", "error", err)
+		} else {
+			logger.Infow("scheduler_register_stock_ledger_check_ok", "entry_id", entryID)
+		}
+	}
+	if consumer.ChangeSetService != nil {
+		task := queue.NewProductChangeSetRunTask()
+		entryID, err := scheduler.Register("@every 1m", task, asynq.Queue(queue.DefaultQueue))
+		if err != nil {
+			logger.Warnw("scheduler_register_product_change_set_run_failed", "error", err)
+		} else {
+			logger.Infow("scheduler_register_product_change_set_run_ok", "entry_id", entryID)
+		}
+	}
+	if consumer.MemberLevelService != nil {
+		task := queue.NewMemberLevelEvaluateTask()
+		entryID, err := scheduler.Register("@every 30m", task, asynq.Queue(queue.DefaultQueue))
+		if err != nil {
+			logger.Warnw("scheduler_register_member_level_evaluate_failed", "error", err)
+		} else {
+			logger.Infow("scheduler_register_member_level_evaluate_ok", "entry_id", entryID)
+		}
+	}
+	if consumer.ResellerManagementService != nil {
+		task := queue.NewResellerDomainRecheckTask()
+		entryID, err := scheduler.Register("@every 30m", task, asynq.Queue(queue.DefaultQueue))
+		if err != nil {
+			logger.Warnw("scheduler_register_reseller_domain_recheck_failed", "error", err)
+		} else {
+			logger.Infow("scheduler_register_reseller_domain_recheck_ok", "entry_id", entryID)
+		}
+	}
 	if consumer.ProductMappingService != nil {
 		fallbackInterval := "5m"
 		if cfg != nil && cfg.UpstreamSyncInterval != "" {
@@ -114,6 +168,13 @@ func registerPeriodicTasks(scheduler *asynq.Scheduler, consumer *jobconsumer.Con
 		} else {
 			logger.Infow("scheduler_register_procurement_sync_accepted_ok", "entry_id", entryID)
 		}
+		refundTask := queue.NewProcurementSyncRefundsTask()
+		refundEntryID, err := scheduler.Register("@every 30m", refundTask, asynq.Queue(queue.DefaultQueue))
+		if err != nil {
+			logger.Warnw("scheduler_register_procurement_sync_refunds_failed", "error", err)
+		} else {
+			logger.Infow("scheduler_register_procurement_sync_refunds_ok", "entry_id", refundEntryID)
+		}
 	}
 }
 
@@ -19,7 +19,7 @@ func TestAffiliateHTTPLivesInTransport(t *testing.T) {
 	})
 	assertFileDeclaresFunctions(t, filepath.Join(transportRoot, "routes.go"), []string{
 		"RegisterPublicRoutes", "RegisterUserRoutes",
-		"RegisterAdminRoutes", "RegisterAdminFinanceRoutes",
+		"RegisterAdminRoutes", "RegisterAdminFinanceRoutes", "RegisterAdminCommissionRuleRoutes",
 		"RegisterChannelRoutes",
 	})
 	assertFileDeclaresTypes(t, filepath.Join(transportRoot, "handler.go"), []string{
@@ -17,7 +17,8 @@ func TestAffiliateApplicationOwnsUseCasesAndContracts(t *testing.T) {
 	assertFileDeclaresFunctions(t, filepath.Join(applicationRoot, "profile.go"), []string{"UpdateAffiliateProfileStatus", "BatchUpdateAffiliateProfileStatus", "OpenAffiliate"})
 	assertFileDeclaresFunctions(t, filepath.Join(applicationRoot, "query.go"), []string{"GetUserDashboard", "ListUserCommissions", "ListUserWithdraws", "ListAdminUsers", "ListAdminCommissions", "ListAdminWithdraws"})
 	assertFileDeclaresFunctions(t, filepath.Join(applicationRoot, "withdraw.go"), []string{"ApplyWithdraw", "ReviewWithdraw"})
-	assertDirectoryGoFileBudget(t, applicationRoot, 8)
+	assertFileDeclaresFunctions(t, filepath.Join(applicationRoot, "commission_rule.go"), []string{"ListCommissionRules", "CreateCommissionRule", "UpdateCommissionRule", "DeleteCommissionRule", "ListCommissionTiers", "ReplaceCommissionTiers"})
+	assertDirectoryGoFileBudget(t, applicationRoot, 9)
 
 	transportRoot := filepath.Join(moduleRoot, "transport", "http")
 	assertFileDeclaresTypes(t, filepath.Join(transportRoot, "handler.go"), []string{"Handler"})
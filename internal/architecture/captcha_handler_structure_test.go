@@ -12,25 +12,29 @@ func TestPublicCaptchaHTTPLivesInTransport(t *testing.T) {
 	applicationRoot := filepath.Join(moduleRoot, "application")
 	contractRoot := filepath.Join(moduleRoot, "contract")
 	turnstileRoot := filepath.Join(moduleRoot, "infrastructure", "turnstile")
+	powStoreRoot := filepath.Join(moduleRoot, "infrastructure", "powstore")
 	transportRoot := filepath.Join(moduleRoot, "transport", "http")
 
 	assertFileDeclaresTypes(t, filepath.Join(applicationRoot, "service.go"), []string{"Service"})
 	assertFileDeclaresFunctions(t, filepath.Join(applicationRoot, "service.go"), []string{"NewService"})
-	assertFileDeclaresTypes(t, filepath.Join(contractRoot, "ports.go"), []string{"SettingReader", "TurnstileVerifier"})
-	assertFileDeclaresTypes(t, filepath.Join(contractRoot, "types.go"), []string{"VerifyPayload", "ImageChallenge"})
+	assertFileDeclaresFunctions(t, filepath.Join(applicationRoot, "pow.go"), []string{"GeneratePoWChallenge", "SetPoWChallengeStore"})
+	assertFileDeclaresTypes(t, filepath.Join(contractRoot, "ports.go"), []string{"SettingReader", "TurnstileVerifier", "PoWChallengeStore"})
+	assertFileDeclaresTypes(t, filepath.Join(contractRoot, "types.go"), []string{"VerifyPayload", "ImageChallenge", "PoWChallenge", "PoWChallengeRecord"})
+	assertFileDeclaresTypes(t, filepath.Join(powStoreRoot, "store.go"), []string{"Store"})
 	assertFileDeclaresTypes(t, filepath.Join(turnstileRoot, "client.go"), []string{"Client"})
 	assertFileDeclaresFunctions(t, filepath.Join(turnstileRoot, "client.go"), []string{"New"})
-	assertFileDeclaresFunctions(t, filepath.Join(transportRoot, "routes.go"), []string{"RegisterPublicRoutes"})
+	assertFileDeclaresFunctions(t, filepath.Join(transportRoot, "routes.go"), []string{"RegisterPublicRoutes", "RegisterPublicPoWRoutes"})
 	assertFileDeclaresTypes(t, filepath.Join(transportRoot, "public_handler.go"), []string{
-		"PublicHandler", "ImageChallengeGenerator",
+		"PublicHandler", "ImageChallengeGenerator", "PoWChallengeGenerator",
 	})
 	production, total := countDirectGoFiles(t, moduleRoot)
 	if production != 0 || total != 0 {
 		t.Fatalf("captcha module root must remain structural only, got production=%d total=%d", production, total)
 	}
-	assertDirectoryGoFileBudget(t, applicationRoot, 2)
+	assertDirectoryGoFileBudget(t, applicationRoot, 3)
 	assertDirectoryGoFileBudget(t, contractRoot, 4)
 	assertDirectoryGoFileBudget(t, turnstileRoot, 3)
+	assertDirectoryGoFileBudget(t, powStoreRoot, 1)
 	assertDirectoryGoFileBudget(t, transportRoot, 6)
 	assertProductionImportsAbsent(t, applicationRoot, "net/http")
 	assertProductionImportsAbsent(t, applicationRoot, "net/url")
@@ -40,7 +40,7 @@ func TestCardSecretUsesCompleteVerticalLayout(t *testing.T) {
 	assertFileDeclaresTypes(t, filepath.Join(transportRoot, "admin_handler.go"), []string{"AdminHandler", "Service"})
 
 	expected := map[string][]string{
-		"service.go": {"NewService", "resolveCardSecretSKU", "normalizeCardSecretIDs"},
+		"service.go": {"NewService", "SetRestockTrigger", "resolveCardSecretSKU", "normalizeCardSecretIDs"},
 		"import.go": {
 			"CreateCardSecretBatch", "ImportCardSecretCSV", "shouldDeduplicateCardSecrets",
 			"normalizeSecrets", "parseCSVSecrets", "generateBatchNo",
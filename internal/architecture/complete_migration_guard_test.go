@@ -17,7 +17,7 @@ type packageFileBudget struct {
 // Test-only architecture assertions intentionally share one package so they can
 // reuse AST helpers. Production packages have no file-budget exceptions.
 var packageFileBudgetOverrides = map[string]packageFileBudget{
-	"internal/architecture": {production: 0, total: 51},
+	"internal/architecture": {production: 0, total: 53},
 }
 
 // completedMigrationPaths are deleted compatibility-free entry points. Once a
@@ -576,11 +576,12 @@ func TestAffiliateModuleOwnsDomainAndPersistence(t *testing.T) {
 	assertFileDeclaresTypes(t, filepath.Join(domainRoot, "click.go"), []string{"Click"})
 	assertFileDeclaresTypes(t, filepath.Join(domainRoot, "commission.go"), []string{"Commission"})
 	assertFileDeclaresTypes(t, filepath.Join(domainRoot, "withdraw_request.go"), []string{"WithdrawRequest"})
-	assertDirectoryGoFileBudget(t, domainRoot, 5)
+	assertFileDeclaresTypes(t, filepath.Join(domainRoot, "commission_rule.go"), []string{"CommissionRule", "CommissionTier"})
+	assertDirectoryGoFileBudget(t, domainRoot, 6)
 
 	storeRoot := filepath.Join(repositoryRoot, "internal", "modules", "affiliate", "infrastructure", "gormstore")
 	assertFileDeclaresTypes(t, filepath.Join(storeRoot, "store.go"), []string{"Store"})
-	assertDirectoryGoFileBudget(t, storeRoot, 2)
+	assertDirectoryGoFileBudget(t, storeRoot, 3)
 
 	moduleRoot := filepath.Join(repositoryRoot, "internal", "modules", "affiliate")
 	production, total := countDirectGoFiles(t, moduleRoot)
@@ -0,0 +1,40 @@
+package architecture
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestFXRateImplementationLivesInBoundedContextDirectories(t *testing.T) {
+	repositoryRoot := findRepositoryRoot(t)
+	moduleRoot := filepath.Join(repositoryRoot, "internal", "modules", "fxrate")
+	domainRoot := filepath.Join(moduleRoot, "domain")
+	contractRoot := filepath.Join(moduleRoot, "contract")
+	applicationRoot := filepath.Join(moduleRoot, "application")
+	storeRoot := filepath.Join(moduleRoot, "infrastructure", "gormstore")
+	integrationTestRoot := filepath.Join(moduleRoot, "integrationtest")
+	transportRoot := filepath.Join(moduleRoot, "transport", "http")
+
+	assertFileDeclaresTypes(t, filepath.Join(domainRoot, "rate.go"), []string{"Rate"})
+	assertFileDeclaresTypes(t, filepath.Join(contractRoot, "ports.go"), []string{"Store", "CurrencyProvider"})
+	assertFileDeclaresFunctions(t, filepath.Join(applicationRoot, "service.go"), []string{"NewService", "BaseCurrency"})
+	assertFileDeclaresFunctions(t, filepath.Join(applicationRoot, "rate.go"), []string{
+		"ListRates", "UpsertRate", "DeleteRate", "DisplayCurrencies", "Quote", "ToBase",
+	})
+	assertFileDeclaresFunctions(t, filepath.Join(applicationRoot, "import.go"), []string{"ImportRates"})
+	assertFileDeclaresTypes(t, filepath.Join(storeRoot, "store.go"), []string{"Store"})
+	assertFileDeclaresFunctions(t, filepath.Join(transportRoot, "routes.go"), []string{
+		"RegisterAdminRoutes", "RegisterPublicRoutes",
+	})
+
+	production, total := countDirectGoFiles(t, moduleRoot)
+	if production != 0 || total != 0 {
+		t.Fatalf("fxrate module root must remain structural only, got production=%d total=%d", production, total)
+	}
+	assertDirectoryGoFileBudget(t, domainRoot, 1)
+	assertDirectoryGoFileBudget(t, contractRoot, 2)
+	assertDirectoryGoFileBudget(t, applicationRoot, 4)
+	assertDirectoryGoFileBudget(t, storeRoot, 1)
+	assertDirectoryGoFileBudget(t, integrationTestRoot, 1)
+	assertDirectoryGoFileBudget(t, transportRoot, 2)
+}
@@ -83,7 +83,7 @@ func TestOrderAdminHTTPLivesInTransport(t *testing.T) {
 		"NewPreviewHandler", "PreviewOrder", "PreviewGuestOrder",
 	})
 	assertFileDeclaresTypes(t, filepath.Join(transportRoot, "create_handler.go"), []string{
-		"CreateHandler", "OrderCreateService", "GuestCreateCaptcha", "OrderPaymentCreator",
+		"CreateHandler", "OrderCreateService", "OrderCreateCaptcha", "OrderPaymentCreator",
 	})
 	assertFileDeclaresFunctions(t, filepath.Join(transportRoot, "create_handler.go"), []string{
 		"NewCreateHandler", "CreateOrder", "CreateGuestOrder", "CreateOrderAndPay", "CreateGuestOrderAndPay",
@@ -13,32 +13,60 @@ func TestOrderRiskUsesCompleteVerticalLayout(t *testing.T) {
 	contractRoot := filepath.Join(moduleRoot, "contract")
 	domainRoot := filepath.Join(moduleRoot, "domain")
 	limiterRoot := filepath.Join(moduleRoot, "infrastructure", "redislimiter")
+	signalStoreRoot := filepath.Join(moduleRoot, "infrastructure", "gormstore")
+	refundAdapterRoot := filepath.Join(moduleRoot, "infrastructure", "refundadapter")
+	transportRoot := filepath.Join(moduleRoot, "transport", "http")
 
 	production, total := countDirectGoFiles(t, moduleRoot)
 	if production != 0 || total != 0 {
 		t.Fatalf("order risk module root must remain structural only, got production=%d total=%d", production, total)
 	}
-	assertDirectoryGoFileBudget(t, applicationRoot, 2)
+	assertDirectoryGoFileBudget(t, applicationRoot, 5)
 	assertDirectoryGoFileBudget(t, contractRoot, 4)
-	assertDirectoryGoFileBudget(t, domainRoot, 1)
+	assertDirectoryGoFileBudget(t, domainRoot, 5)
 	assertDirectoryGoFileBudget(t, limiterRoot, 1)
+	assertDirectoryGoFileBudget(t, signalStoreRoot, 3)
+	assertDirectoryGoFileBudget(t, refundAdapterRoot, 1)
+	assertDirectoryGoFileBudget(t, transportRoot, 3)
 
 	assertFileDeclaresTypes(t, filepath.Join(applicationRoot, "service.go"), []string{"Options", "Service"})
 	assertFileDeclaresFunctions(t, filepath.Join(applicationRoot, "service.go"), []string{"NewService"})
-	assertFileDeclaresTypes(t, filepath.Join(contractRoot, "types.go"), []string{"CheckInput"})
+	assertFileDeclaresFunctions(t, filepath.Join(applicationRoot, "scoring.go"), []string{"decideRisk"})
+	assertFileDeclaresTypes(t, filepath.Join(applicationRoot, "review.go"), []string{"ReviewOptions", "ReviewService"})
+	assertFileDeclaresFunctions(t, filepath.Join(applicationRoot, "review.go"), []string{"NewReviewService"})
+	assertFileDeclaresTypes(t, filepath.Join(applicationRoot, "blacklist.go"), []string{"BlacklistService"})
+	assertFileDeclaresFunctions(t, filepath.Join(applicationRoot, "blacklist.go"), []string{"NewBlacklistService"})
+	assertFileDeclaresTypes(t, filepath.Join(contractRoot, "types.go"), []string{"CheckInput", "AssessInput", "Assessment", "SignalScope"})
 	assertFileDeclaresTypes(t, filepath.Join(contractRoot, "ports.go"), []string{
-		"SettingReader", "PendingOrderGate", "RateLimiter", "Controller",
+		"SettingReader", "PendingOrderGate", "RateLimiter", "SignalReader", "Controller",
+		"ReviewStore", "HeldOrderReleaser", "HeldOrderRefunder", "ReviewNotifier",
+		"BlacklistStore", "CustomerBlacklist",
 	})
 	assertFileDeclaresTypes(t, filepath.Join(contractRoot, "errors.go"), []string{"RateLimitedError"})
 	assertFileDeclaresFunctions(t, filepath.Join(contractRoot, "errors.go"), []string{"GetRetryAfter"})
 	assertFileDeclaresTypes(t, filepath.Join(domainRoot, "lock_key.go"), []string{"LockKey"})
+	assertFileDeclaresTypes(t, filepath.Join(domainRoot, "review.go"), []string{"ReviewLog"})
+	assertFileDeclaresTypes(t, filepath.Join(domainRoot, "blacklist.go"), []string{"BlacklistEntry"})
+	assertFileDeclaresFunctions(t, filepath.Join(domainRoot, "decision.go"), []string{"MoreSevereDecision"})
+	assertFileDeclaresFunctions(t, filepath.Join(domainRoot, "email_domain.go"), []string{"EmailDomain", "IsDisposableDomain"})
 	assertFileDeclaresTypes(t, filepath.Join(limiterRoot, "limiter.go"), []string{"Limiter"})
 	assertFileDeclaresFunctions(t, filepath.Join(limiterRoot, "limiter.go"), []string{"New"})
+	assertFileDeclaresTypes(t, filepath.Join(signalStoreRoot, "signal_store.go"), []string{"SignalStore"})
+	assertFileDeclaresFunctions(t, filepath.Join(signalStoreRoot, "signal_store.go"), []string{"NewSignalStore"})
+	assertFileDeclaresTypes(t, filepath.Join(signalStoreRoot, "review_store.go"), []string{"ReviewStore"})
+	assertFileDeclaresFunctions(t, filepath.Join(signalStoreRoot, "review_store.go"), []string{"NewReviewStore"})
+	assertFileDeclaresTypes(t, filepath.Join(signalStoreRoot, "blacklist_store.go"), []string{"BlacklistStore"})
+	assertFileDeclaresFunctions(t, filepath.Join(signalStoreRoot, "blacklist_store.go"), []string{"NewBlacklistStore"})
+	assertFileDeclaresTypes(t, filepath.Join(refundAdapterRoot, "refunder.go"), []string{"Refunder"})
+	assertFileDeclaresTypes(t, filepath.Join(transportRoot, "admin_handler.go"), []string{"ReviewService", "AdminHandler"})
+	assertFileDeclaresTypes(t, filepath.Join(transportRoot, "blacklist_handler.go"), []string{"BlacklistService", "BlacklistHandler"})
+	assertFileDeclaresFunctions(t, filepath.Join(transportRoot, "routes.go"), []string{"RegisterAdminRoutes", "RegisterAdminBlacklistRoutes"})
 
 	assertProductionImportsAbsent(t, applicationRoot, moduleImportPath+"/internal/cache")
 	assertProductionImportsAbsent(t, applicationRoot, "github.com/redis/go-redis")
 	assertProductionImportsAbsent(t, contractRoot, moduleImportPath+"/internal/cache")
 	assertProductionImportsAbsent(t, contractRoot, "github.com/redis/go-redis")
+	assertProductionImportsAbsent(t, applicationRoot, "gorm.io/gorm")
 
 	for _, relativePath := range []string{
 		"internal/modules/orderrisk/service.go",
@@ -16,7 +16,8 @@ func TestPaymentCallbackImplementationIsSplitByResponsibility(t *testing.T) {
 			"HandleCallback", "updateCallbackMeta", "applyPaymentUpdate",
 			"mergeProviderPayload", "markOrderPaid", "validateCallbackPaymentFacts",
 			"updateCallbackMetaWithRepo", "canAdoptVerifiedLegacyDujiaoPayCurrency",
-			"adoptVerifiedLegacyDujiaoPayCurrency",
+			"adoptVerifiedLegacyDujiaoPayCurrency", "holdOrderForReviewIfNeeded", "resolveReviewHoldReason",
+			"resolvePayerBlacklistHold",
 		},
 		"payment_service_callback_wallet.go": {
 			"handleWalletRechargeCallback", "applyWalletRechargePaymentUpdate", "canApplyWalletRechargeCallback",
@@ -27,6 +28,8 @@ func TestPaymentCallbackImplementationIsSplitByResponsibility(t *testing.T) {
 			"enqueueOrderPaidNotificationAsync", "enqueueWalletRechargeSuccessAsync",
 			"enqueueOrderPaidBotNotifyAsync", "enqueueWalletRechargeBotNotifyAsync",
 			"hasManualFulfillmentItems", "enqueueManualFulfillmentPendingAsync",
+			"hasWebhookFulfillmentItems", "enqueueWebhookFulfillmentAsync", "hasFileFulfillmentItems", "enqueueFileFulfillmentAsync", "hasLicenseFulfillmentItems", "enqueueLicenseFulfillmentAsync", "NotifyManualFulfillmentPending",
+			"enqueueFulfillmentAsync", "enqueuePreorderAllocationAsync", "enqueueReviewHoldAsync", "ReleaseHeldOrder", "collectPurchasedItems", "grantPurchasedMembership",
 		},
 		"payment_service_notification_payload.go": {
 			"buildOrderNotificationPayload", "buildWalletRechargeNotificationPayload",
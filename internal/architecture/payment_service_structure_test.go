@@ -13,11 +13,11 @@ func TestPaymentServiceImplementationIsSplitByResponsibility(t *testing.T) {
 	serviceDirectory := filepath.Join(repositoryRoot, "internal", "modules", "payment", "application")
 	expected := map[string][]string{
 		"payment_service.go": {
-			"SetProcurementService", "SetDownstreamCallbackService", "SetMemberLevelService",
+			"SetProcurementService", "SetDownstreamCallbackService", "SetWebhookFulfillmentService", "SetPreorderTrigger", "SetMemberLevelService", "SetReviewQueue",
 			"NewPaymentService", "ListPayments", "GetPayment", "ListChannels", "GetChannel",
 			"paymentLogger",
 		},
-		"payment_service_create.go": {"hasProviderResult", "CreatePayment"},
+		"payment_service_create.go": {"hasProviderResult", "CreatePayment", "reassessOrderRisk", "checkCustomerBlacklist"},
 		"payment_service_recharge.go": {
 			"CreateWalletRechargePayment", "generateWalletRechargeNo",
 			"ExpireWalletRechargePayment", "canExpireWalletRechargePayment",
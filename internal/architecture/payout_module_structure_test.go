@@ -0,0 +1,47 @@
+package architecture
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestPayoutImplementationLivesInBoundedContextDirectories(t *testing.T) {
+	repositoryRoot := findRepositoryRoot(t)
+	moduleRoot := filepath.Join(repositoryRoot, "internal", "modules", "payout")
+	domainRoot := filepath.Join(moduleRoot, "domain")
+	contractRoot := filepath.Join(moduleRoot, "contract")
+	applicationRoot := filepath.Join(moduleRoot, "application")
+	storeRoot := filepath.Join(moduleRoot, "infrastructure", "gormstore")
+	payoutTransactionRoot := filepath.Join(
+		repositoryRoot,
+		"internal", "workflows", "payout", "infrastructure", "gormuow",
+	)
+	integrationTestRoot := filepath.Join(moduleRoot, "integrationtest")
+	transportRoot := filepath.Join(moduleRoot, "transport", "http")
+
+	assertFileDeclaresTypes(t, filepath.Join(domainRoot, "batch.go"), []string{"Batch", "BatchItem"})
+	assertFileDeclaresTypes(t, filepath.Join(contractRoot, "ports.go"), []string{
+		"BatchListFilter", "Store", "WithdrawCandidate", "WalletCreditInput",
+		"Transaction", "TransactionRunner", "CurrencyProvider",
+	})
+	assertFileDeclaresFunctions(t, filepath.Join(applicationRoot, "service.go"), []string{"NewService"})
+	assertFileDeclaresFunctions(t, filepath.Join(applicationRoot, "batch.go"), []string{
+		"ListBatches", "GetBatch", "CreateBatch", "MarkBatchPaid", "CancelBatch",
+	})
+	assertFileDeclaresFunctions(t, filepath.Join(applicationRoot, "export.go"), []string{"ExportBatchFile"})
+	assertFileDeclaresTypes(t, filepath.Join(storeRoot, "store.go"), []string{"Store"})
+	assertFileDeclaresTypes(t, filepath.Join(payoutTransactionRoot, "runner.go"), []string{"Runner"})
+	assertFileDeclaresFunctions(t, filepath.Join(transportRoot, "routes.go"), []string{"RegisterAdminRoutes"})
+
+	production, total := countDirectGoFiles(t, moduleRoot)
+	if production != 0 || total != 0 {
+		t.Fatalf("payout module root must remain structural only, got production=%d total=%d", production, total)
+	}
+	assertDirectoryGoFileBudget(t, domainRoot, 1)
+	assertDirectoryGoFileBudget(t, contractRoot, 2)
+	assertDirectoryGoFileBudget(t, applicationRoot, 4)
+	assertDirectoryGoFileBudget(t, storeRoot, 1)
+	assertDirectoryGoFileBudget(t, payoutTransactionRoot, 1)
+	assertDirectoryGoFileBudget(t, integrationTestRoot, 1)
+	assertDirectoryGoFileBudget(t, transportRoot, 2)
+}
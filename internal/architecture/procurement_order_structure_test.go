@@ -65,7 +65,10 @@ func TestProcurementUsesCompleteVerticalLayout(t *testing.T) {
 			"isRetryableErrorCode", "parseRetryIntervals",
 		},
 		"callback.go": {"HandleUpstreamCallback", "createUpstreamFulfillment"},
-		"poll.go":     {"PollUpstreamStatus", "requeuePoll", "SyncAcceptedOrders", "mapProcurementUpstreamStatus"},
+		"poll.go": {
+			"PollUpstreamStatus", "requeuePoll", "SyncAcceptedOrders", "SyncUpstreamRefunds", "observeUpstreamRefund",
+			"mapProcurementUpstreamStatus",
+		},
 		"query.go": {
 			"GetByID", "GetByLocalOrderNo", "List", "StatsByStatus", "FillParentOrderNo", "fillParentOrderNos",
 			"applyProcurementLocalRefundedAmountFallback", "shouldSyncUpstreamRefundStatus",
@@ -23,7 +23,7 @@ func TestUpstreamHandlerImplementationIsSplitByResource(t *testing.T) {
 			"ListCategories", "ListProducts", "GetProduct", "applyUpstreamStockToProducts",
 			"resolveEffectiveFulfillmentTypes", "toUpstreamProductWithMemberPrice", "computeSKUStock",
 		},
-		"upstream_order.go":    {"CreateOrder", "GetOrder", "CancelOrder", "mapOrderErrorToResponse"},
+		"upstream_order.go":    {"CreateOrder", "GetOrder", "CancelOrder", "ResendCallback", "mapOrderErrorToResponse"},
 		"upstream_callback.go": {"HandleCallback", "Read", "mapCallbackStatus", "validateCallbackURL"},
 	}
 
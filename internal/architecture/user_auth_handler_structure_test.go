@@ -51,6 +51,25 @@ func TestGoogleAuthLivesInIdentityModule(t *testing.T) {
 	assertDirectoryGoFileBudget(t, applicationRoot, 3)
 }
 
+func TestOIDCAuthLivesInIdentityModule(t *testing.T) {
+	repositoryRoot := findRepositoryRoot(t)
+	moduleRoot := filepath.Join(repositoryRoot, "internal", "modules", "identity", "oidcauth")
+	applicationRoot := filepath.Join(moduleRoot, "application")
+
+	assertFileDeclaresTypes(t, filepath.Join(applicationRoot, "service.go"), []string{
+		"Service", "State", "StateStore", "VerifiedIdentity", "PublicProvider", "Option",
+	})
+	assertFileDeclaresFunctions(t, filepath.Join(applicationRoot, "service.go"), []string{
+		"NewService", "WithHTTPClient", "WithClock", "Start", "Complete",
+	})
+	assertFileDeclaresTypes(t, filepath.Join(applicationRoot, "admin.go"), []string{
+		"ClaimMapping", "ProviderInput", "AdminProvider",
+	})
+	assertDirectoryGoFileBudget(t, moduleRoot, 0)
+	assertDirectoryGoFileBudget(t, applicationRoot, 5)
+	assertDirectoryGoFileBudget(t, filepath.Join(moduleRoot, "infrastructure", "cachestore"), 1)
+}
+
 func TestEmailVerificationLivesInIdentityModule(t *testing.T) {
 	repositoryRoot := findRepositoryRoot(t)
 	moduleRoot := filepath.Join(repositoryRoot, "internal", "modules", "identity", "emailverification")
@@ -164,6 +183,8 @@ func TestUserProfileHTTPLivesInTransport(t *testing.T) {
 		"RegisterUserTelegramRoutes",
 		"RegisterUserGoogleAuthRoutes",
 		"RegisterUserGoogleRoutes",
+		"RegisterUserOIDCAuthRoutes",
+		"RegisterUserOIDCRoutes",
 	})
 	assertFileDeclaresTypes(t, filepath.Join(transportRoot, "user_profile_handler.go"), []string{
 		"UserProfileService", "UserProfileHandler", "UserProfileUpdateRequest",
@@ -210,6 +231,13 @@ func TestUserProfileHTTPLivesInTransport(t *testing.T) {
 	assertFileDeclaresFunctions(t, filepath.Join(transportRoot, "user_google_handler.go"), []string{
 		"NewUserGoogleHandler", "UserGoogleLogin", "GetMyGoogleBinding", "BindMyGoogle", "UnbindMyGoogle",
 	})
+	assertFileDeclaresTypes(t, filepath.Join(transportRoot, "user_oidc_handler.go"), []string{
+		"UserOIDCService", "UserOIDCHandler", "OIDCProviderView", "OIDCBindingResult",
+	})
+	assertFileDeclaresFunctions(t, filepath.Join(transportRoot, "user_oidc_handler.go"), []string{
+		"NewUserOIDCHandler", "ListOIDCProviders", "StartOIDCLogin", "OIDCLoginCallback",
+		"GetMyOIDCBindings", "StartOIDCBind", "OIDCBindCallback", "UnbindMyOIDC", "respondOIDCError",
+	})
 	assertFileDeclaresTypes(t, filepath.Join(transportRoot, "user_login_handler.go"), []string{
 		"UserLoginSettings", "UserLoginAuth", "UserLoginHandler", "UserRegisterRequest", "UserLoginRequest",
 	})
@@ -225,12 +253,12 @@ func TestUserProfileHTTPLivesInTransport(t *testing.T) {
 		"DisableUser2FA", "RegenerateUser2FARecoveryCodes", "VerifyUser2FA",
 	})
 	assertFileDeclaresTypes(t, filepath.Join(presenterRoot, "user.go"), []string{
-		"UserProfileResp", "TelegramBindingResp", "GoogleBindingResp", "UserAuthBriefResp",
+		"UserProfileResp", "TelegramBindingResp", "GoogleBindingResp", "OIDCBindingResp", "UserAuthBriefResp",
 	})
 	assertFileDeclaresFunctions(t, filepath.Join(presenterRoot, "user.go"), []string{
-		"NewUserProfileResp", "NewTelegramBindingResp", "NewGoogleBindingResp", "NewUserAuthBriefResp",
+		"NewUserProfileResp", "NewTelegramBindingResp", "NewGoogleBindingResp", "NewOIDCBindingResp", "NewUserAuthBriefResp",
 	})
-	assertDirectoryGoFileBudget(t, transportRoot, 13)
+	assertDirectoryGoFileBudget(t, transportRoot, 14)
 	assertDirectoryGoFileBudget(t, presenterRoot, 2)
 
 	for _, legacy := range []string{
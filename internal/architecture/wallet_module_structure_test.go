@@ -24,15 +24,20 @@ func TestWalletOwnsCompleteVerticalSlice(t *testing.T) {
 
 	assertFileDeclaresTypes(t, filepath.Join(contractRoot, "ports.go"), []string{
 		"Repository", "Transaction", "UnitOfWork", "UseCase",
+		"RecipientDirectory", "TwoFactorVerifier", "FundsSettingsReader",
 	})
 	assertFileDeclaresTypes(t, filepath.Join(contractRoot, "types.go"), []string{
 		"AccountListFilter", "TransactionListFilter", "RechargeListFilter",
-		"RechargeInput", "AdjustBalanceInput", "CreditInput",
+		"RechargeInput", "AdjustBalanceInput", "CreditInput", "DebitInput",
 		"OrderBalanceInput", "OrderReleaseInput",
+		"TransferListFilter", "WithdrawListFilter", "TransferInput",
+		"WithdrawApplyInput", "WithdrawReviewInput",
 	})
 	assertFileDeclaresTypes(t, filepath.Join(domainRoot, "account.go"), []string{"Account"})
 	assertFileDeclaresTypes(t, filepath.Join(domainRoot, "transaction.go"), []string{"Transaction"})
 	assertFileDeclaresTypes(t, filepath.Join(domainRoot, "recharge_order.go"), []string{"RechargeOrder"})
+	assertFileDeclaresTypes(t, filepath.Join(domainRoot, "transfer.go"), []string{"Transfer"})
+	assertFileDeclaresTypes(t, filepath.Join(domainRoot, "withdraw_request.go"), []string{"WithdrawRequest"})
 
 	assertFileDeclaresTypes(t, filepath.Join(applicationRoot, "service.go"), []string{"Options", "Service"})
 	assertFileDeclaresFunctions(t, filepath.Join(applicationRoot, "service.go"), []string{"NewService"})
@@ -45,7 +50,7 @@ func TestWalletOwnsCompleteVerticalSlice(t *testing.T) {
 		"Recharge", "AdminAdjustBalance",
 	})
 	assertFileDeclaresFunctions(t, filepath.Join(applicationRoot, "credit.go"), []string{
-		"CreditInTransaction",
+		"CreditInTransaction", "DebitInTransaction",
 	})
 	assertFileDeclaresFunctions(t, filepath.Join(applicationRoot, "order_balance.go"), []string{
 		"ApplyOrderBalance", "ReleaseOrderBalance",
@@ -53,6 +58,12 @@ func TestWalletOwnsCompleteVerticalSlice(t *testing.T) {
 	assertFileDeclaresFunctions(t, filepath.Join(applicationRoot, "recharge.go"), []string{
 		"ApplyRechargePayment",
 	})
+	assertFileDeclaresFunctions(t, filepath.Join(applicationRoot, "transfer.go"), []string{
+		"Transfer", "ListTransfers",
+	})
+	assertFileDeclaresFunctions(t, filepath.Join(applicationRoot, "withdraw.go"), []string{
+		"ApplyWithdraw", "ListWithdrawRequests", "RejectWithdraw", "PayWithdraw",
+	})
 
 	assertFileDeclaresTypes(t, filepath.Join(storeRoot, "store.go"), []string{"Store"})
 	assertFileDeclaresFunctions(t, filepath.Join(storeRoot, "store.go"), []string{
@@ -61,6 +72,7 @@ func TestWalletOwnsCompleteVerticalSlice(t *testing.T) {
 
 	assertFileDeclaresFunctions(t, filepath.Join(transportRoot, "routes.go"), []string{
 		"RegisterUserRoutes", "RegisterAdminRoutes", "RegisterChannelRoutes",
+		"RegisterUserFundsRoutes", "RegisterAdminFundsRoutes",
 	})
 	assertFileDeclaresTypes(t, filepath.Join(transportRoot, "user_handler.go"), []string{
 		"WalletService", "PaymentService", "UserReader", "SiteCurrencyReader", "UserHandler",
@@ -72,6 +84,10 @@ func TestWalletOwnsCompleteVerticalSlice(t *testing.T) {
 	assertFileDeclaresTypes(t, filepath.Join(transportRoot, "channel_handler.go"), []string{
 		"ChannelUserProvisioner", "ChannelHandler",
 	})
+	assertFileDeclaresTypes(t, filepath.Join(transportRoot, "funds_handler.go"), []string{
+		"FundsService", "FundsHandler", "TransferInput", "WithdrawApplyInput",
+		"WithdrawListFilter", "WithdrawReviewInput",
+	})
 	assertFileDeclaresTypes(t, filepath.Join(presenterRoot, "wallet.go"), []string{
 		"WalletAccountResp", "WalletTransactionResp", "WalletRechargeResp",
 		"WalletRechargePaymentPayload",
@@ -83,11 +99,11 @@ func TestWalletOwnsCompleteVerticalSlice(t *testing.T) {
 	assertFileDeclaresTypes(t, filepath.Join(bootstrapRoot, "handlers.go"), []string{"Handlers"})
 	assertFileDeclaresFunctions(t, filepath.Join(bootstrapRoot, "handlers.go"), []string{"New"})
 
-	assertDirectoryGoFileBudget(t, applicationRoot, 7)
+	assertDirectoryGoFileBudget(t, applicationRoot, 9)
 	assertDirectoryGoFileBudget(t, contractRoot, 4)
-	assertDirectoryGoFileBudget(t, domainRoot, 4)
+	assertDirectoryGoFileBudget(t, domainRoot, 6)
 	assertDirectoryGoFileBudget(t, storeRoot, 2)
-	assertDirectoryGoFileBudget(t, transportRoot, 6)
+	assertDirectoryGoFileBudget(t, transportRoot, 7)
 	assertDirectoryGoFileBudget(t, presenterRoot, 2)
 	assertDirectoryGoFileBudget(t, bootstrapRoot, 3)
 
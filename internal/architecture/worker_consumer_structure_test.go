@@ -23,6 +23,7 @@ func TestWorkerConsumerIsSplitByJobDomain(t *testing.T) {
 		"handleOrderStatusEmail":            "consumer_order.go",
 		"handleOrderAutoFulfill":            "consumer_order.go",
 		"handleOrderTimeoutCancel":          "consumer_order.go",
+		"handleFulfillmentWebhookDispatch":  "consumer_order.go",
 		"handleWalletRechargeExpire":        "consumer_order.go",
 		"buildOrderInstructionsEmailText":   "consumer_order.go",
 		"localizedInstructionsText":         "consumer_order.go",
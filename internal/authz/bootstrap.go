@@ -23,6 +23,7 @@ func BuiltinRoleSeeds() []RoleSeed {
 				{Object: "/admin/dashboard/trends", Action: "GET"},
 				{Object: "/admin/dashboard/rankings", Action: "GET"},
 				{Object: "/admin/dashboard/inventory-alerts", Action: "GET"},
+				{Object: "/admin/dashboard/inventory-forecast", Action: "GET"},
 				{Object: "/admin/compliance/status", Action: "GET"},
 				{Object: "/admin/authz/me", Action: "GET"},
 				{Object: "/admin/2fa/status", Action: "GET"},
@@ -43,6 +44,8 @@ func BuiltinRoleSeeds() []RoleSeed {
 				{Object: "/admin/products", Action: "*"},
 				{Object: "/admin/products/:id", Action: "*"},
 				{Object: "/admin/products/:id/wholesale-prices", Action: "PATCH"},
+				{Object: "/admin/products/:id/files", Action: "*"},
+				{Object: "/admin/products/:id/files/:file_id", Action: "DELETE"},
 				{Object: "/admin/categories", Action: "*"},
 				{Object: "/admin/categories/:id", Action: "*"},
 				{Object: "/admin/categories/:id/active", Action: "PATCH"},
@@ -66,6 +69,25 @@ func BuiltinRoleSeeds() []RoleSeed {
 				{Object: "/admin/card-secrets/stats", Action: "GET"},
 				{Object: "/admin/card-secrets/batches", Action: "GET"},
 				{Object: "/admin/card-secrets/template", Action: "GET"},
+				{Object: "/admin/license-templates", Action: "*"},
+				{Object: "/admin/license-templates/:id", Action: "PUT"},
+				{Object: "/admin/licenses", Action: "GET"},
+				{Object: "/admin/licenses/:id/revoke", Action: "POST"},
+				{Object: "/admin/restock-subscriptions", Action: "GET"},
+				{Object: "/admin/restock-subscriptions/demand", Action: "GET"},
+				{Object: "/admin/preorder-policies", Action: "*"},
+				{Object: "/admin/backorders", Action: "GET"},
+				{Object: "/admin/stock-movements", Action: "GET"},
+				{Object: "/admin/stock-movements/sku", Action: "GET"},
+				{Object: "/admin/stock-drifts", Action: "GET"},
+				{Object: "/admin/stock-drifts/check", Action: "POST"},
+				{Object: "/admin/stock-thresholds", Action: "*"},
+				{Object: "/admin/stock-thresholds/:id", Action: "DELETE"},
+				{Object: "/admin/product-change-sets", Action: "*"},
+				{Object: "/admin/product-change-sets/preview", Action: "POST"},
+				{Object: "/admin/product-change-sets/:id", Action: "GET"},
+				{Object: "/admin/product-change-sets/:id/preview", Action: "GET"},
+				{Object: "/admin/product-change-sets/:id/cancel", Action: "POST"},
 				{Object: "/admin/gift-cards", Action: "*"},
 				{Object: "/admin/gift-cards/:id", Action: "*"},
 				{Object: "/admin/gift-cards/generate", Action: "POST"},
@@ -83,6 +105,9 @@ func BuiltinRoleSeeds() []RoleSeed {
 				{Object: "/admin/member-levels", Action: "*"},
 				{Object: "/admin/member-levels/:id", Action: "*"},
 				{Object: "/admin/member-levels/backfill", Action: "POST"},
+				{Object: "/admin/member-levels/evaluate", Action: "POST"},
+				{Object: "/admin/member-level-plans", Action: "*"},
+				{Object: "/admin/member-level-plans/:id", Action: "*"},
 				{Object: "/admin/member-level-prices", Action: "*"},
 				{Object: "/admin/member-level-prices/batch", Action: "POST"},
 				{Object: "/admin/member-level-prices/:id", Action: "DELETE"},
@@ -101,6 +126,14 @@ func BuiltinRoleSeeds() []RoleSeed {
 				{Object: "/admin/orders", Action: "GET"},
 				{Object: "/admin/orders/:id", Action: "GET"},
 				{Object: "/admin/orders/:id/fulfillment/download", Action: "GET"},
+				{Object: "/admin/orders/:id/fulfillment/files", Action: "GET"},
+				{Object: "/admin/licenses", Action: "GET"},
+				{Object: "/admin/restock-subscriptions", Action: "GET"},
+				{Object: "/admin/restock-subscriptions/demand", Action: "GET"},
+				{Object: "/admin/backorders", Action: "GET"},
+				{Object: "/admin/order-reviews", Action: "GET"},
+				{Object: "/admin/order-reviews/:id", Action: "GET"},
+				{Object: "/admin/customer-blacklist", Action: "GET"},
 				{Object: "/admin/order-refunds", Action: "GET"},
 				{Object: "/admin/order-refunds/:id", Action: "GET"},
 				{Object: "/admin/fulfillments", Action: "POST"},
@@ -112,8 +145,11 @@ func BuiltinRoleSeeds() []RoleSeed {
 				{Object: "/admin/users/:id/wallet", Action: "GET"},
 				{Object: "/admin/users/:id/wallet/transactions", Action: "GET"},
 				{Object: "/admin/users/:id/member-level", Action: "PUT"},
+				{Object: "/admin/users/:id/member-level-histories", Action: "GET"},
 				{Object: "/admin/users/:id/oauth/telegram", Action: "DELETE"},
 				{Object: "/admin/users/:id/oauth/google", Action: "DELETE"},
+				{Object: "/admin/oidc-providers", Action: "*"},
+				{Object: "/admin/oidc-providers/:id", Action: "*"},
 				{Object: "/admin/users/:id/2fa", Action: "DELETE"}, // 客服协助用户重置丢失 TOTP+恢复码 的 2FA
 				{Object: "/admin/user-login-logs", Action: "GET"},
 				{Object: "/admin/wallet/recharges", Action: "GET"},
@@ -151,11 +187,17 @@ func BuiltinRoleSeeds() []RoleSeed {
 				{Object: "/admin/reconciliation/jobs", Action: "GET"},
 				{Object: "/admin/reconciliation/jobs/:id", Action: "GET"},
 				{Object: "/admin/reconciliation/items/:id/resolve", Action: "PUT"},
+				{Object: "/admin/refund-propagations", Action: "GET"},
+				{Object: "/admin/refund-propagations/:id/approve", Action: "POST"},
+				{Object: "/admin/refund-propagations/:id/reject", Action: "POST"},
 				{Object: "/admin/api-credentials", Action: "*"},
 				{Object: "/admin/api-credentials/:id", Action: "*"},
 				{Object: "/admin/api-credentials/:id/approve", Action: "POST"},
 				{Object: "/admin/api-credentials/:id/reject", Action: "POST"},
 				{Object: "/admin/api-credentials/:id/status", Action: "PUT"},
+				{Object: "/admin/downstream-callbacks", Action: "GET"},
+				{Object: "/admin/downstream-callbacks/attempts", Action: "GET"},
+				{Object: "/admin/downstream-callbacks/:id/resend", Action: "POST"},
 				{Object: "/admin/upstream-products", Action: "GET"},
 				{Object: "/admin/upstream-categories", Action: "GET"},
 				{Object: "/admin/resellers/operations/overview", Action: "GET"},
@@ -168,9 +210,12 @@ func BuiltinRoleSeeds() []RoleSeed {
 				{Object: "/admin/resellers/domains", Action: "GET"},
 				{Object: "/admin/resellers/domains/:id/approve", Action: "POST"},
 				{Object: "/admin/resellers/domains/:id/set-primary", Action: "POST"},
+				{Object: "/admin/resellers/domains/:id/verify", Action: "POST"},
+				{Object: "/admin/resellers/domains/:id/verification-attempts", Action: "GET"},
 				{Object: "/admin/resellers/site-configs", Action: "GET"},
 				{Object: "/admin/resellers/site-configs/:reseller_id", Action: "GET"},
 				{Object: "/admin/resellers/site-configs/:reseller_id", Action: "PUT"},
+				{Object: "/admin/resellers/site-configs/:reseller_id/preview", Action: "POST"},
 				{Object: "/admin/resellers/site-configs/:reseller_id/reset", Action: "POST"},
 				{Object: "/admin/resellers/product-settings", Action: "GET"},
 				{Object: "/admin/resellers/product-settings/:reseller_id/:product_id", Action: "GET"},
@@ -195,24 +240,50 @@ func BuiltinRoleSeeds() []RoleSeed {
 				{Object: "/admin/orders/:id", Action: "PATCH"},
 				{Object: "/admin/orders/:id/refund-to-wallet", Action: "POST"},
 				{Object: "/admin/orders/:id/manual-refund", Action: "POST"},
+				{Object: "/admin/order-reviews", Action: "GET"},
+				{Object: "/admin/order-reviews/:id", Action: "GET"},
+				{Object: "/admin/order-reviews/:id/approve", Action: "POST"},
+				{Object: "/admin/order-reviews/:id/reject", Action: "POST"},
+				{Object: "/admin/customer-blacklist", Action: "GET"},
+				{Object: "/admin/customer-blacklist", Action: "POST"},
+				{Object: "/admin/customer-blacklist/import", Action: "POST"},
+				{Object: "/admin/customer-blacklist/:id", Action: "DELETE"},
+				{Object: "/admin/orders/:id/blacklist-customer", Action: "POST"},
 				{Object: "/admin/order-refunds", Action: "GET"},
 				{Object: "/admin/order-refunds/:id", Action: "GET"},
 				{Object: "/admin/affiliates/commissions", Action: "GET"},
 				{Object: "/admin/affiliates/withdraws", Action: "GET"},
+				{Object: "/admin/affiliates/withdraws/:id/approve", Action: "POST"},
 				{Object: "/admin/affiliates/withdraws/:id/reject", Action: "POST"},
 				{Object: "/admin/affiliates/withdraws/:id/pay", Action: "POST"},
+				{Object: "/admin/affiliates/commission-rules", Action: "*"},
+				{Object: "/admin/affiliates/commission-rules/:id", Action: "*"},
+				{Object: "/admin/affiliates/commission-tiers", Action: "*"},
 				{Object: "/admin/resellers/operations/finance", Action: "GET"},
 				{Object: "/admin/resellers/ledger-entries", Action: "GET"},
 				{Object: "/admin/resellers/balance-accounts", Action: "GET"},
 				{Object: "/admin/resellers/withdraws", Action: "GET"},
+				{Object: "/admin/resellers/withdraws/:id/approve", Action: "POST"},
 				{Object: "/admin/resellers/withdraws/:id/reject", Action: "POST"},
 				{Object: "/admin/resellers/withdraws/:id/pay", Action: "POST"},
+				{Object: "/admin/payout-batches", Action: "*"},
+				{Object: "/admin/payout-batches/:id", Action: "GET"},
+				{Object: "/admin/payout-batches/:id/export", Action: "GET"},
+				{Object: "/admin/payout-batches/:id/mark-paid", Action: "POST"},
+				{Object: "/admin/payout-batches/:id/cancel", Action: "POST"},
 				{Object: "/admin/gift-cards", Action: "GET"},
 				{Object: "/admin/gift-cards/export", Action: "POST"},
 				{Object: "/admin/wallet/recharges", Action: "GET"},
 				{Object: "/admin/users/:id/wallet", Action: "GET"},
 				{Object: "/admin/users/:id/wallet/transactions", Action: "GET"},
 				{Object: "/admin/users/:id/wallet/adjust", Action: "POST"},
+				{Object: "/admin/wallet/withdraws", Action: "GET"},
+				{Object: "/admin/wallet/withdraws/:id/reject", Action: "POST"},
+				{Object: "/admin/wallet/withdraws/:id/pay", Action: "POST"},
+				{Object: "/admin/fx-rates", Action: "GET"},
+				{Object: "/admin/fx-rates", Action: "POST"},
+				{Object: "/admin/fx-rates/:id", Action: "DELETE"},
+				{Object: "/admin/fx-rates/import", Action: "POST"},
 			},
 			Immutable: true,
 		},
@@ -239,6 +310,13 @@ func BuiltinRoleSeeds() []RoleSeed {
 				{Object: "/admin/settings/affiliate", Action: "*"},
 				{Object: "/admin/settings/telegram-bot", Action: "*"},
 				{Object: "/admin/settings/telegram-bot/runtime-status", Action: "GET"},
+				{Object: "/admin/settings/versions", Action: "GET"},
+				{Object: "/admin/settings/versions/:id", Action: "GET"},
+				{Object: "/admin/settings/versions/:id/rollback", Action: "POST"},
+				{Object: "/admin/settings/export", Action: "POST"},
+				{Object: "/admin/settings/import", Action: "POST"},
+				{Object: "/admin/settings/locales", Action: "GET"},
+				{Object: "/admin/settings/locales", Action: "PUT"},
 				// 权限管理（仅 system_admin 可操作）
 				{Object: "/admin/authz/me", Action: "GET"},
 				{Object: "/admin/authz/roles", Action: "*"},
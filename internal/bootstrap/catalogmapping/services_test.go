@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	mappingdomain "github.com/dujiao-next/internal/modules/catalog/mapping/domain"
+	stockledgerdomain "github.com/dujiao-next/internal/modules/stockledger/domain"
 
 	siteconnectiondomain "github.com/dujiao-next/internal/modules/siteconnection/domain"
 
@@ -103,6 +104,7 @@ func TestImportUpstreamProductRollbackWhenSKUMappingCreateFails(t *testing.T) {
 		t.Fatalf("open sqlite failed: %v", err)
 	}
 	if err := db.AutoMigrate(
+		&stockledgerdomain.Movement{},
 		&categorydomain.Category{},
 		&productdomain.Product{},
 		&productdomain.ProductSKU{},
@@ -216,6 +218,7 @@ func setupMappingWithUpstreamHandler(t *testing.T, dsn string, handler http.Hand
 		t.Fatalf("open sqlite failed: %v", err)
 	}
 	if err := db.AutoMigrate(
+		&stockledgerdomain.Movement{},
 		&categorydomain.Category{},
 		&productdomain.Product{},
 		&productdomain.ProductSKU{},
@@ -780,6 +783,7 @@ func TestImportUpstreamProductRejectsInactive(t *testing.T) {
 		t.Fatalf("open sqlite failed: %v", err)
 	}
 	if err := db.AutoMigrate(
+		&stockledgerdomain.Movement{},
 		&categorydomain.Category{},
 		&productdomain.Product{},
 		&productdomain.ProductSKU{},
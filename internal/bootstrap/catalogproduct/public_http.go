@@ -1,6 +1,7 @@
 package catalogproductbootstrap
 
 import (
+	domaincatalog "github.com/dujiao-next/internal/modules/catalog"
 	productapplication "github.com/dujiao-next/internal/modules/catalog/product/application"
 	productdomain "github.com/dujiao-next/internal/modules/catalog/product/domain"
 	producthttp "github.com/dujiao-next/internal/modules/catalog/product/transport/http"
@@ -18,6 +19,7 @@ type PublicHTTPDependencies struct {
 	MemberLevels producthttp.MemberLevelPricing
 	Mappings     producthttp.LocalProductMappingReader
 	SKUMappings  producthttp.SKUMappingLookup
+	Thresholds   domaincatalog.StockThresholdSource
 	RelatedPosts producthttp.RelatedPostReader
 }
 
@@ -59,6 +61,7 @@ func NewPublicHTTP(dependencies PublicHTTPDependencies) *producthttp.PublicHandl
 		dependencies.MemberLevels,
 		dependencies.Mappings,
 		dependencies.SKUMappings,
+		dependencies.Thresholds,
 		dependencies.RelatedPosts,
 	)
 }
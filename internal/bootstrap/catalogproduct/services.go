@@ -15,6 +15,7 @@ import (
 	productapplication "github.com/dujiao-next/internal/modules/catalog/product/application"
 	productadmin "github.com/dujiao-next/internal/modules/catalog/product/application/admin"
 	productwrite "github.com/dujiao-next/internal/modules/catalog/product/application/write"
+	stockledgergormstore "github.com/dujiao-next/internal/modules/stockledger/infrastructure/gormstore"
 
 	"gorm.io/gorm"
 )
@@ -118,6 +119,7 @@ func (unit *productWriteUnitOfWork) WithinTransaction(fn func(repositories produ
 			Products:    unit.products.BindTx(tx),
 			SKUs:        skus,
 			CardSecrets: cardSecrets,
+			StockLedger: stockledgergormstore.NewRecorder(tx),
 		})
 	})
 }
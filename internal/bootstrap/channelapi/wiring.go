@@ -36,6 +36,7 @@ func NewHandler(c *container.Container) *channeltransport.Handler {
 		UserAuthService: identityAdapter{auth: c.UserAuthService}, MemberLevelService: c.MemberLevelService,
 		SettingService: c.SettingService, OrderService: orderAdapter{orders: c.OrderService},
 		PaymentService: paymentAdapter{payments: c.PaymentService}, PaymentStore: c.PaymentStore,
+		StockThresholds: c.StockThresholdService,
 	})
 }
 
@@ -193,6 +194,8 @@ func mapError(err error) error {
 		{orderriskcontract.ErrProductQuantityLimit, channeltransport.ErrRiskProductQuantityLimit},
 		{orderriskcontract.ErrPendingProductQuantityLimit, channeltransport.ErrRiskPendingProductLimit},
 		{orderriskcontract.ErrOrderRateLimited, channeltransport.ErrRiskOrderRateLimited},
+		{orderriskcontract.ErrOrderBlocked, channeltransport.ErrRiskOrderBlocked},
+		{orderriskcontract.ErrCustomerBlacklisted, channeltransport.ErrRiskCustomerBlacklisted},
 		{orderapp.ErrProductSKURequired, channeltransport.ErrProductSKURequired},
 		{orderapp.ErrProductSKUInvalid, channeltransport.ErrProductSKUInvalid},
 		{orderapp.ErrInvalidOrderItem, channeltransport.ErrInvalidOrderItem},
@@ -16,6 +16,8 @@ import (
 	paymentdomain "github.com/dujiao-next/internal/modules/payment/domain"
 	procurementdomain "github.com/dujiao-next/internal/modules/procurement/domain"
 	settingsstore "github.com/dujiao-next/internal/modules/settings/infrastructure/gormstore"
+	stockledgerdomain "github.com/dujiao-next/internal/modules/stockledger/domain"
+	stockledgergormstore "github.com/dujiao-next/internal/modules/stockledger/infrastructure/gormstore"
 	"github.com/dujiao-next/internal/platform/database/gormdb"
 	"github.com/dujiao-next/internal/shared/jsonmap"
 	"gorm.io/gorm"
@@ -28,6 +30,7 @@ const (
 	paymentProviderBepusdtRenameMigrationSettingKey = "migration/payment_provider_bepusdt_rename_v1"
 	paymentChannelBepusdtConfigMigrationSettingKey  = "migration/payment_channel_bepusdt_config_v2"
 	orderItemOriginalPriceMigrationKey              = "migration/order_item_original_price_v1"
+	stockLedgerOpeningMigrationKey                  = "migration/stock_ledger_opening_v1"
 	manualStockUnlimitedValue                       = -1
 	cartProductForeignKeyConstraint                 = "fk_cart_items_product"
 	cartSKUForeignKeyConstraint                     = "fk_cart_items_sku"
@@ -254,6 +257,43 @@ func ensureOrderItemOriginalPriceMigration() error {
 	})
 }
 
+// ensureStockLedgerOpeningMigration 台账启用时按现有计数写入期初流水；已有流水时不再补写，避免重复入账。
+func ensureStockLedgerOpeningMigration() error {
+	if gormdb.DB == nil {
+		return errors.New("database is not initialized")
+	}
+
+	var marker settingsstore.SettingRecord
+	if err := gormdb.DB.First(&marker, "key = ?", stockLedgerOpeningMigrationKey).Error; err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+	} else if migrationDone(marker.ValueJSON) {
+		return nil
+	}
+
+	return gormdb.DB.Transaction(func(tx *gorm.DB) error {
+		var existing int64
+		if err := tx.Model(&stockledgerdomain.Movement{}).Count(&existing).Error; err != nil {
+			return err
+		}
+		if existing == 0 {
+			if err := stockledgergormstore.RecordOpeningBalances(tx); err != nil {
+				return err
+			}
+		}
+
+		marker := settingsstore.SettingRecord{
+			Key: stockLedgerOpeningMigrationKey,
+			ValueJSON: jsonmap.JSON{
+				"done":        true,
+				"migrated_at": time.Now().UTC().Format(time.RFC3339),
+			},
+		}
+		return tx.Save(&marker).Error
+	})
+}
+
 // migrateCartSKUUniqueIndex 迁移购物车唯一索引为 user_id + product_id + sku_id 维度。
 func migrateCartSKUUniqueIndex() error {
 	migrator := gormdb.DB.Migrator()
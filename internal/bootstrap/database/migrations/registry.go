@@ -10,28 +10,40 @@ import (
 	categorydomain "github.com/dujiao-next/internal/modules/catalog/category/domain"
 	mappingdomain "github.com/dujiao-next/internal/modules/catalog/mapping/domain"
 	productdomain "github.com/dujiao-next/internal/modules/catalog/product/domain"
+	changesetdomain "github.com/dujiao-next/internal/modules/changeset/domain"
 	channelclientdomain "github.com/dujiao-next/internal/modules/channelclient/domain"
 	contentdomain "github.com/dujiao-next/internal/modules/content/domain"
 	coupondomain "github.com/dujiao-next/internal/modules/coupon/domain"
 	downstreamcallbackdomain "github.com/dujiao-next/internal/modules/downstreamcallback/domain"
 	fulfillmentdomain "github.com/dujiao-next/internal/modules/fulfillment/domain"
+	fulfillmentfilesdomain "github.com/dujiao-next/internal/modules/fulfillment/files/domain"
+	fulfillmentwebhookdomain "github.com/dujiao-next/internal/modules/fulfillment/webhook/domain"
+	fxratedomain "github.com/dujiao-next/internal/modules/fxrate/domain"
 	giftcarddomain "github.com/dujiao-next/internal/modules/giftcard/domain"
 	admindomain "github.com/dujiao-next/internal/modules/identity/admin/domain"
 	emailverificationdomain "github.com/dujiao-next/internal/modules/identity/emailverification/domain"
 	externalidentitydomain "github.com/dujiao-next/internal/modules/identity/externalidentity/domain"
 	userdomain "github.com/dujiao-next/internal/modules/identity/user/domain"
+	licensedomain "github.com/dujiao-next/internal/modules/license/domain"
 	memberleveldomain "github.com/dujiao-next/internal/modules/memberlevel/domain"
 	notificationdomain "github.com/dujiao-next/internal/modules/notification/domain"
 	orderdomain "github.com/dujiao-next/internal/modules/order/domain"
 	orderriskcontract "github.com/dujiao-next/internal/modules/orderrisk/contract"
 	orderriskdomain "github.com/dujiao-next/internal/modules/orderrisk/domain"
 	paymentdomain "github.com/dujiao-next/internal/modules/payment/domain"
+	payoutdomain "github.com/dujiao-next/internal/modules/payout/domain"
+	preorderdomain "github.com/dujiao-next/internal/modules/preorder/domain"
 	procurementdomain "github.com/dujiao-next/internal/modules/procurement/domain"
 	promotiondomain "github.com/dujiao-next/internal/modules/promotion/domain"
 	reconciliationdomain "github.com/dujiao-next/internal/modules/reconciliation/domain"
+	refundpropagationdomain "github.com/dujiao-next/internal/modules/refundpropagation/domain"
 	resellerstore "github.com/dujiao-next/internal/modules/reseller/infrastructure/gormstore"
+	resellerstaffdomain "github.com/dujiao-next/internal/modules/reseller/staff/domain"
+	restockdomain "github.com/dujiao-next/internal/modules/restock/domain"
 	settingsstore "github.com/dujiao-next/internal/modules/settings/infrastructure/gormstore"
 	siteconnectiondomain "github.com/dujiao-next/internal/modules/siteconnection/domain"
+	stockledgerdomain "github.com/dujiao-next/internal/modules/stockledger/domain"
+	stockthresholddomain "github.com/dujiao-next/internal/modules/stockthreshold/domain"
 	broadcastdomain "github.com/dujiao-next/internal/modules/telegram/broadcast/domain"
 	walletdomain "github.com/dujiao-next/internal/modules/wallet/domain"
 	"github.com/dujiao-next/internal/platform/database/gormdb"
@@ -46,13 +58,20 @@ func AutoMigrate() error {
 		&admindomain.Admin{},
 		&userdomain.User{},
 		&externalidentitydomain.Identity{},
+		&externalidentitydomain.OIDCProvider{},
 		&affiliatedomain.Profile{},
 		&affiliatedomain.Click{},
 		&affiliatedomain.Commission{},
 		&affiliatedomain.WithdrawRequest{},
+		&affiliatedomain.CommissionRule{},
+		&affiliatedomain.CommissionTier{},
 		&walletdomain.Account{},
 		&walletdomain.Transaction{},
 		&walletdomain.RechargeOrder{},
+		&walletdomain.Transfer{},
+		&walletdomain.WithdrawRequest{},
+		&walletdomain.CurrencyBalance{},
+		&fxratedomain.Rate{},
 		&auditlogdomain.UserLoginLog{},
 		&auditlogdomain.AuthzAuditLog{},
 		&notificationdomain.NotificationLog{},
@@ -62,6 +81,8 @@ func AutoMigrate() error {
 		&orderdomain.OrderItem{},
 		&orderdomain.OrderRefundRecord{},
 		&orderriskdomain.LockKey{},
+		&orderriskdomain.ReviewLog{},
+		&orderriskdomain.BlacklistEntry{},
 		&cartdomain.Item{},
 		&paymentdomain.PaymentChannel{},
 		&paymentdomain.Payment{},
@@ -69,6 +90,8 @@ func AutoMigrate() error {
 		&cardsecretdomain.Batch{},
 		&giftcarddomain.GiftCard{},
 		&giftcarddomain.GiftCardBatch{},
+		&payoutdomain.Batch{},
+		&payoutdomain.BatchItem{},
 		&fulfillmentdomain.Fulfillment{},
 		&coupondomain.Coupon{},
 		&coupondomain.CouponUsage{},
@@ -81,18 +104,38 @@ func AutoMigrate() error {
 		&contentdomain.PostCategory{},
 		&contentdomain.Banner{},
 		&settingsstore.SettingRecord{},
+		&settingsstore.SettingVersionRecord{},
 		&apicredentialdomain.ApiCredential{},
 		&siteconnectiondomain.Connection{},
 		&mappingdomain.Mapping{},
 		&mappingdomain.SKUMapping{},
 		&procurementdomain.Order{},
 		&downstreamcallbackdomain.OrderRef{},
+		&downstreamcallbackdomain.Attempt{},
+		&fulfillmentwebhookdomain.Delivery{},
+		&fulfillmentfilesdomain.Asset{},
+		&fulfillmentfilesdomain.Grant{},
+		&fulfillmentfilesdomain.DownloadLog{},
+		&licensedomain.Template{},
+		&licensedomain.License{},
+		&restockdomain.Subscription{},
+		&preorderdomain.Policy{},
+		&preorderdomain.Backorder{},
+		&stockledgerdomain.Movement{},
+		&stockledgerdomain.Drift{},
+		&stockthresholddomain.Threshold{},
+		&changesetdomain.ChangeSet{},
+		&changesetdomain.Item{},
+		&changesetdomain.Event{},
 		&reconciliationdomain.Job{},
 		&reconciliationdomain.Item{},
+		&refundpropagationdomain.Propagation{},
 		&channelclientdomain.Client{},
 		&broadcastdomain.Broadcast{},
 		&memberleveldomain.MemberLevel{},
 		&memberleveldomain.MemberLevelPrice{},
+		&memberleveldomain.MemberLevelPlan{},
+		&memberleveldomain.MemberLevelHistory{},
 		&contentdomain.Media{},
 	); err != nil {
 		return err
@@ -107,6 +150,9 @@ func AutoMigrate() error {
 	if err := resellerstore.Migrate(db); err != nil {
 		return err
 	}
+	if err := db.AutoMigrate(&resellerstaffdomain.Member{}, &resellerstaffdomain.ActivityLog{}); err != nil {
+		return err
+	}
 	if err := migrateCartSKUUniqueIndex(); err != nil {
 		return err
 	}
@@ -128,6 +174,9 @@ func AutoMigrate() error {
 	if err := ensureOrderItemOriginalPriceMigration(); err != nil {
 		return err
 	}
+	if err := ensureStockLedgerOpeningMigration(); err != nil {
+		return err
+	}
 	if err := ensureCartForeignKeyConstraints(); err != nil {
 		return err
 	}
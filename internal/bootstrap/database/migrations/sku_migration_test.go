@@ -411,6 +411,7 @@ func TestAutoMigrateOwnsResellerSchemaAndCrossModuleConstraints(t *testing.T) {
 	for _, table := range []string{
 		"reseller_profiles",
 		"reseller_domains",
+		"reseller_domain_verification_attempts",
 		"reseller_site_configs",
 		"reseller_product_settings",
 		"reseller_order_snapshots",
@@ -418,6 +419,8 @@ func TestAutoMigrateOwnsResellerSchemaAndCrossModuleConstraints(t *testing.T) {
 		"reseller_withdraw_requests",
 		"reseller_balance_accounts",
 		"reseller_related_accounts",
+		"reseller_staff_members",
+		"reseller_activity_logs",
 	} {
 		if !db.Migrator().HasTable(table) {
 			t.Errorf("central AutoMigrate did not create reseller table %s", table)
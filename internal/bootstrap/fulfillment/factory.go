@@ -2,7 +2,9 @@ package fulfillmentwiring
 
 import (
 	"github.com/dujiao-next/internal/app/container"
+	fulfillmentfilestransport "github.com/dujiao-next/internal/modules/fulfillment/files/transport/http"
 	fulfillmenttransport "github.com/dujiao-next/internal/modules/fulfillment/transport/http"
+	fulfillmentwebhooktransport "github.com/dujiao-next/internal/modules/fulfillment/webhook/transport/http"
 )
 
 func NewAdminHandler(c *container.Container) *fulfillmenttransport.AdminHandler {
@@ -11,3 +13,18 @@ func NewAdminHandler(c *container.Container) *fulfillmenttransport.AdminHandler
 		fulfillmentAdminOrderAdapter{orders: c.OrderService},
 	)
 }
+
+// NewWebhookCallbackHandler 创建发码服务异步回调处理器。
+func NewWebhookCallbackHandler(c *container.Container) *fulfillmentwebhooktransport.Handler {
+	return fulfillmentwebhooktransport.NewHandler(c.FulfillmentWebhookService)
+}
+
+// NewFileHandler 创建前台文件交付处理器（下载链接与签名下载）。
+func NewFileHandler(c *container.Container) *fulfillmentfilestransport.Handler {
+	return fulfillmentfilestransport.NewHandler(c.FulfillmentFileService, fileOrderLookupAdapter{orders: c.OrderService})
+}
+
+// NewFileAdminHandler 创建后台交付文件管理处理器。
+func NewFileAdminHandler(c *container.Container) *fulfillmentfilestransport.AdminHandler {
+	return fulfillmentfilestransport.NewAdminHandler(c.FulfillmentFileService, fileOrderLookupAdapter{orders: c.OrderService})
+}
@@ -0,0 +1,56 @@
+package fulfillmentwiring
+
+import (
+	"errors"
+	"fmt"
+
+	fulfillmentfilestransport "github.com/dujiao-next/internal/modules/fulfillment/files/transport/http"
+	orderapp "github.com/dujiao-next/internal/modules/order/application"
+	orderdomain "github.com/dujiao-next/internal/modules/order/domain"
+	resellercontract "github.com/dujiao-next/internal/modules/reseller/contract"
+)
+
+// fileOrderLookupAdapter 将订单查询结果展开为订单及子订单 ID。
+type fileOrderLookupAdapter struct {
+	orders *orderapp.OrderService
+}
+
+func (a fileOrderLookupAdapter) UserOrderIDs(tenant resellercontract.TenantContext, orderNo string, userID uint) ([]uint, error) {
+	order, err := a.orders.GetAnyOrderByUserOrderNoForTenant(tenant, orderNo, userID)
+	return collectFileOrderIDs(order, mapFileOrderLookupError(err))
+}
+
+func (a fileOrderLookupAdapter) GuestOrderIDs(tenant resellercontract.TenantContext, orderNo, email, password string) ([]uint, error) {
+	order, err := a.orders.GetAnyOrderByGuestOrderNoForTenant(tenant, orderNo, email, password)
+	return collectFileOrderIDs(order, mapFileOrderLookupError(err))
+}
+
+func (a fileOrderLookupAdapter) AdminOrderIDs(orderID uint) ([]uint, error) {
+	order, err := a.orders.GetOrderForAdmin(orderID)
+	return collectFileOrderIDs(order, mapFileOrderLookupError(err))
+}
+
+func collectFileOrderIDs(order *orderdomain.Order, err error) ([]uint, error) {
+	if err != nil {
+		return nil, err
+	}
+	if order == nil {
+		return nil, fulfillmentfilestransport.ErrOrderNotFound
+	}
+	ids := make([]uint, 0, len(order.Children)+1)
+	ids = append(ids, order.ID)
+	for _, child := range order.Children {
+		ids = append(ids, child.ID)
+	}
+	return ids, nil
+}
+
+func mapFileOrderLookupError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, orderapp.ErrOrderNotFound) || errors.Is(err, orderapp.ErrGuestOrderNotFound) {
+		return fmt.Errorf("%w: %v", fulfillmentfilestransport.ErrOrderNotFound, err)
+	}
+	return err
+}
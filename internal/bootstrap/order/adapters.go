@@ -319,11 +319,14 @@ func (a orderCreateAdapter) CreateOrder(input ordertransport.CreateOrderInput) (
 		UserID:              input.UserID,
 		Tenant:              input.Tenant,
 		Items:               mapServiceOrderItems(input.Items),
+		Currency:            input.Currency,
 		CouponCode:          input.CouponCode,
 		AffiliateCode:       input.AffiliateCode,
 		AffiliateVisitorKey: input.AffiliateVisitorKey,
 		ClientIP:            input.ClientIP,
 		ManualFormData:      input.ManualFormData,
+		DeviceID:            input.DeviceID,
+		RiskChallengePassed: input.RiskChallengePassed,
 	})
 	return order, mapOrderTransportError(err)
 }
@@ -335,26 +338,36 @@ func (a orderCreateAdapter) CreateGuestOrder(input ordertransport.CreateGuestOrd
 		Locale:              input.Locale,
 		Tenant:              input.Tenant,
 		Items:               mapServiceOrderItems(input.Items),
+		Currency:            input.Currency,
 		CouponCode:          input.CouponCode,
 		AffiliateCode:       input.AffiliateCode,
 		AffiliateVisitorKey: input.AffiliateVisitorKey,
 		ClientIP:            input.ClientIP,
 		ManualFormData:      input.ManualFormData,
+		DeviceID:            input.DeviceID,
+		RiskChallengePassed: input.RiskChallengePassed,
 	})
 	return order, mapOrderTransportError(err)
 }
 
-type orderGuestCreateCaptchaAdapter struct {
+type orderCreateCaptchaAdapter struct {
 	captcha *captchaapp.Service
 }
 
-func (a orderGuestCreateCaptchaAdapter) VerifyGuestCreateOrder(payload captchahttp.CaptchaPayloadRequest, clientIP string) error {
+func (a orderCreateCaptchaAdapter) VerifyGuestCreateOrder(payload captchahttp.CaptchaPayloadRequest, clientIP string) error {
 	if a.captcha == nil {
 		return nil
 	}
 	return mapOrderTransportError(a.captcha.Verify(constants.CaptchaSceneGuestCreateOrder, payload.ToCaptchaPayload(), clientIP))
 }
 
+func (a orderCreateCaptchaAdapter) VerifyOrderRiskChallenge(payload captchahttp.CaptchaPayloadRequest, clientIP string) error {
+	if a.captcha == nil {
+		return nil
+	}
+	return mapOrderTransportError(a.captcha.Verify(constants.CaptchaSceneOrderRisk, payload.ToCaptchaPayload(), clientIP))
+}
+
 type orderPaymentCreatorAdapter struct {
 	payments *paymentapp.PaymentService
 }
@@ -391,6 +404,7 @@ func (a orderPreviewAdapter) PreviewOrder(input ordertransport.CreateOrderInput)
 		UserID:              input.UserID,
 		Tenant:              input.Tenant,
 		Items:               mapServiceOrderItems(input.Items),
+		Currency:            input.Currency,
 		CouponCode:          input.CouponCode,
 		AffiliateCode:       input.AffiliateCode,
 		AffiliateVisitorKey: input.AffiliateVisitorKey,
@@ -410,6 +424,7 @@ func (a orderPreviewAdapter) PreviewGuestOrder(input ordertransport.CreateGuestO
 		Locale:              input.Locale,
 		Tenant:              input.Tenant,
 		Items:               mapServiceOrderItems(input.Items),
+		Currency:            input.Currency,
 		CouponCode:          input.CouponCode,
 		AffiliateCode:       input.AffiliateCode,
 		AffiliateVisitorKey: input.AffiliateVisitorKey,
@@ -457,10 +472,15 @@ func mapOrderPreview(preview *orderapp.OrderPreview) *ordertransport.OrderPrevie
 			PromotionDiscount:  item.PromotionDiscount,
 			WholesaleDiscount:  item.WholesaleDiscount,
 			FulfillmentType:    item.FulfillmentType,
+			IsPreorder:         item.IsPreorder,
+			PreorderBalance:    item.PreorderBalance,
+			PreorderExpectedAt: item.PreorderExpectedAt,
 		})
 	}
 	return &ordertransport.OrderPreview{
 		Currency:                preview.Currency,
+		BaseCurrency:            preview.BaseCurrency,
+		ExchangeRate:            preview.ExchangeRate,
 		OriginalAmount:          preview.OriginalAmount,
 		MemberDiscountAmount:    preview.MemberDiscountAmount,
 		DiscountAmount:          preview.DiscountAmount,
@@ -498,7 +518,11 @@ func mapOrderTransportError(err error) error {
 		{orderapp.ErrInvalidEmail, ordertransport.ErrInvalidEmail},
 		{orderapp.ErrProductPurchaseNotAllowed, ordertransport.ErrProductPurchaseNotAllowed},
 		{orderapp.ErrManualStockInsufficient, ordertransport.ErrManualStockInsufficient},
+		{orderapp.ErrPreorderUnavailable, ordertransport.ErrPreorderUnavailable},
+		{orderapp.ErrPreorderLimitReached, ordertransport.ErrPreorderLimitReached},
+		{orderapp.ErrPreorderLoginRequired, ordertransport.ErrPreorderLoginRequired},
 		{orderapp.ErrOrderCurrencyMismatch, ordertransport.ErrOrderCurrencyMismatch},
+		{orderapp.ErrOrderCurrencyUnsupported, ordertransport.ErrOrderCurrencyUnsupported},
 		{orderapp.ErrProductNotAvailable, ordertransport.ErrProductNotAvailable},
 		{orderapp.ErrResellerCouponNotAllowed, ordertransport.ErrResellerCouponNotAllowed},
 		{orderapp.ErrQueueUnavailable, ordertransport.ErrQueueUnavailable},
@@ -508,6 +532,9 @@ func mapOrderTransportError(err error) error {
 		{orderriskcontract.ErrProductQuantityLimit, ordertransport.ErrRiskProductQuantityLimit},
 		{orderriskcontract.ErrPendingProductQuantityLimit, ordertransport.ErrRiskPendingProductLimit},
 		{orderriskcontract.ErrOrderRateLimited, ordertransport.ErrRiskOrderRateLimited},
+		{orderriskcontract.ErrChallengeRequired, ordertransport.ErrRiskChallengeRequired},
+		{orderriskcontract.ErrOrderBlocked, ordertransport.ErrRiskOrderBlocked},
+		{orderriskcontract.ErrCustomerBlacklisted, ordertransport.ErrRiskCustomerBlacklisted},
 	} {
 		if errors.Is(err, mapping.source) {
 			return fmt.Errorf("%w: %v", mapping.target, err)
@@ -44,7 +44,7 @@ func New(c *container.Container) Handlers {
 		Create: ordertransport.NewCreateHandler(
 			orderCreateAdapter{orders: c.OrderService},
 			orderUserPaymentChannelAdapter{payments: c.PaymentService},
-			orderGuestCreateCaptchaAdapter{captcha: c.CaptchaService},
+			orderCreateCaptchaAdapter{captcha: c.CaptchaService},
 			orderPaymentCreatorAdapter{payments: c.PaymentService},
 		),
 	}
@@ -12,6 +12,7 @@ import (
 
 	orderapp "github.com/dujiao-next/internal/modules/order/application"
 	orderdomain "github.com/dujiao-next/internal/modules/order/domain"
+	orderriskcontract "github.com/dujiao-next/internal/modules/orderrisk/contract"
 
 	paymenttransport "github.com/dujiao-next/internal/modules/payment/transport/http"
 	reseller "github.com/dujiao-next/internal/modules/reseller/contract"
@@ -106,6 +107,8 @@ func mapTransportError(err error) error {
 		{orderapp.ErrOrderNotFound, paymenttransport.ErrOrderNotFound},
 		{orderapp.ErrGuestOrderNotFound, paymenttransport.ErrGuestOrderNotFound},
 		{orderapp.ErrOrderStatusInvalid, paymenttransport.ErrOrderStatusInvalid},
+		{orderriskcontract.ErrOrderBlocked, paymenttransport.ErrRiskOrderBlocked},
+		{orderriskcontract.ErrCustomerBlacklisted, paymenttransport.ErrRiskCustomerBlacklisted},
 		{paymentapp.ErrPaymentInvalid, paymenttransport.ErrPaymentInvalid},
 		{paymentapp.ErrPaymentNotFound, paymenttransport.ErrPaymentNotFound},
 		{paymentapp.ErrPaymentChannelNotFound, paymenttransport.ErrPaymentChannelNotFound},
@@ -2,6 +2,7 @@ package resellerbootstrap
 
 import (
 	"github.com/dujiao-next/internal/app/container"
+	staffhttp "github.com/dujiao-next/internal/modules/reseller/staff/transport/http"
 	adminhttp "github.com/dujiao-next/internal/modules/reseller/transport/http/admin"
 	userhttp "github.com/dujiao-next/internal/modules/reseller/transport/http/user"
 )
@@ -19,6 +20,11 @@ type Handlers struct {
 	AdminFinance        *adminhttp.AdminFinanceHandler
 }
 
+// NewStaffHandler 创建分销商员工管理与控制台访问控制处理器。
+func NewStaffHandler(c *container.Container) *staffhttp.Handler {
+	return staffhttp.NewHandler(c.ResellerStaffService)
+}
+
 func New(c *container.Container) Handlers {
 	return Handlers{
 		User: userhttp.NewUserHandler(
@@ -17,8 +17,8 @@ func (a settingsCaptchaAdapter) GetCaptchaSetting() (settingssecurity.CaptchaSet
 	return a.settings.GetCaptchaSetting(a.cfg.Captcha)
 }
 
-func (a settingsCaptchaAdapter) PatchCaptchaSetting(patch settingssecurity.CaptchaSettingPatch) (settingssecurity.CaptchaSetting, error) {
-	return a.settings.PatchCaptchaSetting(a.cfg.Captcha, patch)
+func (a settingsCaptchaAdapter) PatchCaptchaSetting(patch settingssecurity.CaptchaSettingPatch, adminID uint) (settingssecurity.CaptchaSetting, error) {
+	return a.settings.PatchCaptchaSetting(a.cfg.Captcha, patch, adminID)
 }
 
 func (a settingsCaptchaAdapter) ApplyRuntime(setting settingssecurity.CaptchaSetting) {
@@ -20,8 +20,8 @@ func (a settingsGoogleAuthAdapter) GetGoogleAuthSetting() (settingssecurity.Goog
 	return a.settings.GetGoogleAuthSetting(a.cfg.GoogleAuth)
 }
 
-func (a settingsGoogleAuthAdapter) PatchGoogleAuthSetting(patch settingssecurity.GoogleAuthSettingPatch) (settingssecurity.GoogleAuthSetting, error) {
-	return a.settings.PatchGoogleAuthSetting(a.cfg.GoogleAuth, patch)
+func (a settingsGoogleAuthAdapter) PatchGoogleAuthSetting(patch settingssecurity.GoogleAuthSettingPatch, adminID uint) (settingssecurity.GoogleAuthSetting, error) {
+	return a.settings.PatchGoogleAuthSetting(a.cfg.GoogleAuth, patch, adminID)
 }
 
 func (a settingsGoogleAuthAdapter) ApplyRuntime(setting settingssecurity.GoogleAuthSetting) {
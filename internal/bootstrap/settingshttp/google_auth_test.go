@@ -90,7 +90,7 @@ func TestSettingsGoogleAuthAdapterKeepsStartupFallbackImmutable(t *testing.T) {
 			if _, err := adapter.PatchGoogleAuthSetting(settingssecurity.GoogleAuthSettingPatch{
 				Enabled:  &enabled,
 				ClientID: &clientID,
-			}); err != nil {
+			}, 1); err != nil {
 				t.Errorf("patch setting: %v", err)
 			}
 		}()
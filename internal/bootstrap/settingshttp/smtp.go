@@ -22,8 +22,8 @@ func (a settingsSMTPAdapter) GetSMTPSetting() (settingsmessaging.SMTPSetting, er
 	return a.settings.GetSMTPSetting(a.cfg.Email)
 }
 
-func (a settingsSMTPAdapter) PatchSMTPSetting(patch settingsmessaging.SMTPSettingPatch) (settingsmessaging.SMTPSetting, error) {
-	return a.settings.PatchSMTPSetting(a.cfg.Email, patch)
+func (a settingsSMTPAdapter) PatchSMTPSetting(patch settingsmessaging.SMTPSettingPatch, adminID uint) (settingsmessaging.SMTPSetting, error) {
+	return a.settings.PatchSMTPSetting(a.cfg.Email, patch, adminID)
 }
 
 func (a settingsSMTPAdapter) ApplyRuntime(setting settingsmessaging.SMTPSetting) {
@@ -17,8 +17,8 @@ func (a settingsTelegramAuthAdapter) GetTelegramAuthSetting() (settingssecurity.
 	return a.settings.GetTelegramAuthSetting(a.cfg.TelegramAuth)
 }
 
-func (a settingsTelegramAuthAdapter) PatchTelegramAuthSetting(patch settingssecurity.TelegramAuthSettingPatch) (settingssecurity.TelegramAuthSetting, error) {
-	return a.settings.PatchTelegramAuthSetting(a.cfg.TelegramAuth, patch)
+func (a settingsTelegramAuthAdapter) PatchTelegramAuthSetting(patch settingssecurity.TelegramAuthSettingPatch, adminID uint) (settingssecurity.TelegramAuthSetting, error) {
+	return a.settings.PatchTelegramAuthSetting(a.cfg.TelegramAuth, patch, adminID)
 }
 
 func (a settingsTelegramAuthAdapter) ApplyRuntime(setting settingssecurity.TelegramAuthSetting) {
@@ -1,6 +1,7 @@
 package upstreamwiring
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"time"
@@ -16,6 +17,9 @@ import (
 	productapplication "github.com/dujiao-next/internal/modules/catalog/product/application"
 	productcontract "github.com/dujiao-next/internal/modules/catalog/product/contract"
 	"github.com/dujiao-next/internal/modules/catalog/product/manualform"
+	downstreamcallbackapp "github.com/dujiao-next/internal/modules/downstreamcallback/application"
+	downstreamcallbackcontract "github.com/dujiao-next/internal/modules/downstreamcallback/contract"
+	downstreamcallbackdomain "github.com/dujiao-next/internal/modules/downstreamcallback/domain"
 	upstreamtransport "github.com/dujiao-next/internal/modules/upstreamapi/transport/http"
 	walletcontract "github.com/dujiao-next/internal/modules/wallet/contract"
 	"github.com/dujiao-next/internal/shared/jsonmap"
@@ -39,8 +43,10 @@ func NewHandler(c *container.Container) *upstreamtransport.Handler {
 		Payments:          paymentServiceAdapter{payments: c.PaymentService},
 		Procurements:      c.ProcurementOrderService,
 		DownstreamRefs:    c.DownstreamOrderRefRepo,
+		Callbacks:         callbackServiceAdapter{callbacks: c.DownstreamCallbackService},
 		Connections:       c.SiteConnectionRepo,
 		ConnectionSecrets: c.SiteConnectionService,
+		StockThresholds:   c.StockThresholdService,
 	})
 }
 
@@ -110,6 +116,23 @@ func (a paymentServiceAdapter) CreatePayment(input upstreamtransport.CreatePayme
 	return &upstreamtransport.CreatePaymentResult{OrderPaid: result.OrderPaid}, nil
 }
 
+type callbackServiceAdapter struct {
+	callbacks *downstreamcallbackapp.Service
+}
+
+func (a callbackServiceAdapter) RequestResend(ctx context.Context, credentialID, orderID uint) (*downstreamcallbackdomain.OrderRef, error) {
+	ref, err := a.callbacks.RequestResend(ctx, credentialID, orderID)
+	switch {
+	case errors.Is(err, downstreamcallbackcontract.ErrRefNotFound):
+		return nil, fmt.Errorf("%w: %v", upstreamtransport.ErrOrderNotFound, err)
+	case errors.Is(err, downstreamcallbackcontract.ErrCallbackURLMissing):
+		return nil, fmt.Errorf("%w: %v", upstreamtransport.ErrCallbackURLMissing, err)
+	case errors.Is(err, downstreamcallbackcontract.ErrResendTooFrequent):
+		return nil, fmt.Errorf("%w: %v", upstreamtransport.ErrCallbackTooFrequent, err)
+	}
+	return ref, err
+}
+
 func mapOrderError(err error) error {
 	if err == nil {
 		return nil
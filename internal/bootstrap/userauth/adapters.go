@@ -20,6 +20,7 @@ import (
 	auditlogapp "github.com/dujiao-next/internal/modules/auditlog/application"
 	externalidentitydomain "github.com/dujiao-next/internal/modules/identity/externalidentity/domain"
 	googleauthapp "github.com/dujiao-next/internal/modules/identity/googleauth/application"
+	oidcauthapp "github.com/dujiao-next/internal/modules/identity/oidcauth/application"
 	telegramauthapp "github.com/dujiao-next/internal/modules/identity/telegramauth/application"
 	userauthapp "github.com/dujiao-next/internal/modules/identity/userauth/application"
 	"github.com/dujiao-next/internal/modules/identity/userauth/challenge"
@@ -398,6 +399,74 @@ func (a userTelegramOIDCTransportAdapter) BindTelegramOIDC(ctx context.Context,
 	return identity, mapUserAuthTransportError(err)
 }
 
+// userOIDCTransportAdapter 将通用 OIDC 服务适配为 transport 端口。
+type userOIDCTransportAdapter struct {
+	auth *userauthapp.Service
+	oidc *oidcauthapp.Service
+}
+
+func (a userOIDCTransportAdapter) ListOIDCProviders() ([]userauthtransport.OIDCProviderView, error) {
+	providers, err := a.oidc.ListEnabledProviders()
+	if err != nil {
+		return nil, mapUserAuthTransportError(err)
+	}
+	views := make([]userauthtransport.OIDCProviderView, 0, len(providers))
+	for _, provider := range providers {
+		views = append(views, userauthtransport.OIDCProviderView{
+			Slug:        provider.Slug,
+			DisplayName: provider.DisplayName,
+			IconURL:     provider.IconURL,
+		})
+	}
+	return views, nil
+}
+
+func (a userOIDCTransportAdapter) StartOIDC(ctx context.Context, slug, intent string, userID uint) (string, error) {
+	authURL, err := a.auth.StartOIDC(ctx, slug, intent, userID)
+	return authURL, mapUserAuthTransportError(err)
+}
+
+func (a userOIDCTransportAdapter) LoginWithOIDC(ctx context.Context, slug, code, state string) (*userauthtransport.AuthLoginResult, error) {
+	result, err := a.auth.LoginWithOIDC(ctx, slug, code, state)
+	if err != nil {
+		return nil, mapUserAuthTransportError(err)
+	}
+	return toUserAuthTransportLoginResult(result), nil
+}
+
+func (a userOIDCTransportAdapter) BindOIDC(ctx context.Context, userID uint, slug, code, state string) (*userauthtransport.OIDCBindingResult, error) {
+	binding, err := a.auth.BindOIDC(ctx, userID, slug, code, state)
+	if err != nil {
+		return nil, mapUserAuthTransportError(err)
+	}
+	result := toOIDCTransportBindingResult(*binding)
+	return &result, nil
+}
+
+func (a userOIDCTransportAdapter) ListOIDCBindings(userID uint) ([]userauthtransport.OIDCBindingResult, error) {
+	bindings, err := a.auth.ListOIDCBindings(userID)
+	if err != nil {
+		return nil, mapUserAuthTransportError(err)
+	}
+	results := make([]userauthtransport.OIDCBindingResult, 0, len(bindings))
+	for _, binding := range bindings {
+		results = append(results, toOIDCTransportBindingResult(binding))
+	}
+	return results, nil
+}
+
+func (a userOIDCTransportAdapter) UnbindOIDC(userID uint, slug string) error {
+	return mapUserAuthTransportError(a.auth.UnbindOIDC(userID, slug))
+}
+
+func toOIDCTransportBindingResult(binding userauthapp.OIDCBinding) userauthtransport.OIDCBindingResult {
+	return userauthtransport.OIDCBindingResult{
+		ProviderSlug: binding.ProviderSlug,
+		Identity:     binding.Identity,
+		CanUnbind:    binding.CanUnbind,
+	}
+}
+
 // userLoginTransportAdapter 将设置/认证服务适配为注册登录 transport 端口。
 type userLoginTransportAdapter struct {
 	auth     *userauthapp.Service
@@ -663,6 +732,18 @@ func mapUserAuthTransportError(err error) error {
 		{googleauthapp.ErrGoogleCredentialExpired, userauthtransport.ErrGoogleCredentialExpired},
 		{googleauthapp.ErrGoogleEmailUnverified, userauthtransport.ErrGoogleEmailUnverified},
 		{googleauthapp.ErrGoogleJWKSUnavailable, userauthtransport.ErrGoogleJWKSUnavailable},
+		{oidcauthapp.ErrOIDCProviderNotFound, userauthtransport.ErrOIDCProviderNotFound},
+		{oidcauthapp.ErrOIDCProviderDisabled, userauthtransport.ErrOIDCProviderDisabled},
+		{oidcauthapp.ErrOIDCProviderInvalid, userauthtransport.ErrOIDCProviderInvalid},
+		{oidcauthapp.ErrOIDCDiscoveryUnavailable, userauthtransport.ErrOIDCDiscoveryUnavailable},
+		{oidcauthapp.ErrOIDCStateInvalid, userauthtransport.ErrOIDCStateInvalid},
+		{oidcauthapp.ErrOIDCTokenExchange, userauthtransport.ErrOIDCTokenExchange},
+		{oidcauthapp.ErrOIDCUserInfoUnavailable, userauthtransport.ErrOIDCTokenExchange},
+		{oidcauthapp.ErrOIDCIDTokenInvalid, userauthtransport.ErrOIDCIDTokenInvalid},
+		{oidcauthapp.ErrOIDCClaimsInvalid, userauthtransport.ErrOIDCClaimsInvalid},
+		{userauthapp.ErrOIDCAutoLinkForbidden, userauthtransport.ErrOIDCAutoLinkForbidden},
+		{userauthapp.ErrOIDCEmailRequired, userauthtransport.ErrOIDCEmailRequired},
+		{userauthapp.ErrOIDCUnbindLocked, userauthtransport.ErrOIDCUnbindLocked},
 		{userauthapp.ErrUserOAuthIdentityExists, userauthtransport.ErrUserOAuthIdentityExists},
 		{userauthapp.ErrUserOAuthAlreadyBound, userauthtransport.ErrUserOAuthAlreadyBound},
 		{userauthapp.ErrUserOAuthNotBound, userauthtransport.ErrUserOAuthNotBound},
@@ -675,6 +756,7 @@ func mapUserAuthTransportError(err error) error {
 		{userauthapp.ErrGoogleRedirectUserMismatch, userauthtransport.ErrGoogleRedirectUserMismatch},
 		{userauthapp.ErrGoogleRedirectFlowInvalid, userauthtransport.ErrGoogleRedirectFlowInvalid},
 		{userauthapp.ErrUserDisabled, userauthtransport.ErrUserDisabled},
+		{userauthapp.ErrCustomerBlacklisted, userauthtransport.ErrCustomerBlacklisted},
 		{userauthapp.ErrRegistrationDisabled, userauthtransport.ErrRegistrationDisabled},
 		{userauthapp.ErrAgreementRequired, userauthtransport.ErrAgreementRequired},
 		{userauthapp.ErrInvalidCredentials, userauthtransport.ErrInvalidCredentials},
@@ -17,6 +17,7 @@ type Handlers struct {
 	TelegramOIDC *userauthtransport.UserTelegramOIDCHandler
 	Telegram     *userauthtransport.UserTelegramHandler
 	Google       *userauthtransport.UserGoogleHandler
+	OIDC         *userauthtransport.UserOIDCHandler
 }
 
 // New assembles user authentication transports at the application boundary.
@@ -65,5 +66,9 @@ func New(c *container.Container) Handlers {
 			userGoogleTransportAdapter{auth: c.UserAuthService},
 			recorder,
 		),
+		OIDC: userauthtransport.NewUserOIDCHandler(
+			userOIDCTransportAdapter{auth: c.UserAuthService, oidc: c.OIDCAuthService},
+			recorder,
+		),
 	}
 }
@@ -30,6 +30,11 @@ func (a walletTransportAdapter) GetAccount(userID uint) (*walletdomain.Account,
 	return account, mapWalletTransportError(err)
 }
 
+func (a walletTransportAdapter) ListCurrencyBalances(userID uint) ([]walletdomain.CurrencyBalance, error) {
+	balances, err := a.wallets.ListCurrencyBalances(userID)
+	return balances, mapWalletTransportError(err)
+}
+
 func (a walletTransportAdapter) ListTransactions(userID uint, page, pageSize int) ([]walletdomain.Transaction, int64, error) {
 	transactions, total, err := a.wallets.ListTransactions(walletcontract.TransactionListFilter{
 		Page: page, PageSize: pageSize, UserID: userID,
@@ -123,6 +128,56 @@ func (a walletTransportAdapter) CapturePayment(input wallettransport.CapturePaym
 	return payment, mapWalletTransportError(err)
 }
 
+// walletFundsAdapter bridges transfer and withdraw use cases to the wallet HTTP surface.
+type walletFundsAdapter struct {
+	wallets *walletapp.Service
+}
+
+func (a walletFundsAdapter) Transfer(input wallettransport.TransferInput) (*walletdomain.Transfer, error) {
+	transfer, err := a.wallets.Transfer(walletcontract.TransferInput{
+		FromUserID: input.FromUserID, Recipient: input.Recipient, Amount: input.Amount,
+		Currency: input.Currency, Remark: input.Remark, TwoFactorCode: input.TwoFactorCode,
+	})
+	return transfer, mapWalletTransportError(err)
+}
+
+func (a walletFundsAdapter) ListTransfers(userID uint, page, pageSize int) ([]walletdomain.Transfer, int64, error) {
+	transfers, total, err := a.wallets.ListTransfers(walletcontract.TransferListFilter{
+		Page: page, PageSize: pageSize, UserID: userID,
+	})
+	return transfers, total, mapWalletTransportError(err)
+}
+
+func (a walletFundsAdapter) ApplyWithdraw(input wallettransport.WithdrawApplyInput) (*walletdomain.WithdrawRequest, error) {
+	request, err := a.wallets.ApplyWithdraw(walletcontract.WithdrawApplyInput{
+		UserID: input.UserID, Amount: input.Amount, Currency: input.Currency,
+		Channel: input.Channel, Account: input.Account,
+	})
+	return request, mapWalletTransportError(err)
+}
+
+func (a walletFundsAdapter) ListWithdraws(filter wallettransport.WithdrawListFilter) ([]walletdomain.WithdrawRequest, int64, error) {
+	requests, total, err := a.wallets.ListWithdrawRequests(walletcontract.WithdrawListFilter{
+		Page: filter.Page, PageSize: filter.PageSize, UserID: filter.UserID,
+		Status: filter.Status, WithdrawNo: filter.WithdrawNo,
+	})
+	return requests, total, mapWalletTransportError(err)
+}
+
+func (a walletFundsAdapter) RejectWithdraw(input wallettransport.WithdrawReviewInput) (*walletdomain.WithdrawRequest, error) {
+	request, err := a.wallets.RejectWithdraw(walletcontract.WithdrawReviewInput{
+		WithdrawID: input.WithdrawID, AdminID: input.AdminID, Reason: input.Reason,
+	})
+	return request, mapWalletTransportError(err)
+}
+
+func (a walletFundsAdapter) PayWithdraw(input wallettransport.WithdrawReviewInput) (*walletdomain.WithdrawRequest, error) {
+	request, err := a.wallets.PayWithdraw(walletcontract.WithdrawReviewInput{
+		WithdrawID: input.WithdrawID, AdminID: input.AdminID, PayoutReference: input.PayoutReference,
+	})
+	return request, mapWalletTransportError(err)
+}
+
 func mapWalletTransportError(err error) error {
 	if err == nil {
 		return nil
@@ -151,6 +206,19 @@ func mapWalletTransportError(err error) error {
 		{walletcontract.ErrOnlyPaymentRequired, wallettransport.ErrWalletOnlyPaymentRequired},
 		{paymentapp.ErrPaymentStatusInvalid, wallettransport.ErrPaymentStatusInvalid},
 		{paymentapp.ErrPaymentAmountMismatch, wallettransport.ErrPaymentAmountMismatch},
+		{walletcontract.ErrAmountBelowMinimum, wallettransport.ErrAmountBelowMinimum},
+		{walletcontract.ErrTransferDisabled, wallettransport.ErrTransferDisabled},
+		{walletcontract.ErrTransferRecipientNotFound, wallettransport.ErrTransferRecipientNotFound},
+		{walletcontract.ErrTransferToSelf, wallettransport.ErrTransferToSelf},
+		{walletcontract.ErrTransferLimitExceeded, wallettransport.ErrTransferLimitExceeded},
+		{walletcontract.ErrTwoFactorRequired, wallettransport.ErrTwoFactorRequired},
+		{walletcontract.ErrTwoFactorInvalid, wallettransport.ErrTwoFactorInvalid},
+		{walletcontract.ErrWithdrawDisabled, wallettransport.ErrWithdrawDisabled},
+		{walletcontract.ErrWithdrawChannelInvalid, wallettransport.ErrWithdrawChannelInvalid},
+		{walletcontract.ErrWithdrawLimitExceeded, wallettransport.ErrWithdrawLimitExceeded},
+		{walletcontract.ErrWithdrawNotFound, wallettransport.ErrWithdrawNotFound},
+		{walletcontract.ErrWithdrawStatusInvalid, wallettransport.ErrWithdrawStatusInvalid},
+		{walletcontract.ErrWithdrawExceedsRecharged, wallettransport.ErrWithdrawExceedsRecharged},
 	} {
 		if errors.Is(err, mapping.source) {
 			return fmt.Errorf("%w: %v", mapping.target, err)
@@ -10,6 +10,7 @@ type Handlers struct {
 	User    *wallettransport.UserHandler
 	Admin   *wallettransport.AdminHandler
 	Channel *wallettransport.ChannelHandler
+	Funds   *wallettransport.FundsHandler
 }
 
 func New(c *container.Container) Handlers {
@@ -27,5 +28,6 @@ func New(c *container.Container) Handlers {
 			channeluserwiring.NewSimpleProvisioner(c.UserAuthService),
 			c.SettingService,
 		),
+		Funds: wallettransport.NewFundsHandler(walletFundsAdapter{wallets: c.WalletService}, c.SettingService),
 	}
 }
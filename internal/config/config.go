@@ -33,6 +33,7 @@ type Config struct {
 	Captcha      CaptchaConfig      `mapstructure:"captcha"`
 	Web          WebConfig          `mapstructure:"web"`
 	Reseller     ResellerConfig     `mapstructure:"reseller"`
+	I18n         I18nConfig         `mapstructure:"i18n"`
 }
 
 // AppConfig 应用级配置
@@ -173,6 +174,7 @@ type CaptchaConfig struct {
 	Scenes    CaptchaSceneConfig     `mapstructure:"scenes"`
 	Image     CaptchaImageConfig     `mapstructure:"image"`
 	Turnstile CaptchaTurnstileConfig `mapstructure:"turnstile"`
+	PoW       CaptchaPoWConfig       `mapstructure:"pow"`
 }
 
 // CaptchaSceneConfig 验证码场景开关
@@ -203,6 +205,14 @@ type CaptchaTurnstileConfig struct {
 	TimeoutMS int    `mapstructure:"timeout_ms"`
 }
 
+// CaptchaPoWConfig 工作量证明验证码配置
+type CaptchaPoWConfig struct {
+	BaseDifficulty int `mapstructure:"base_difficulty"`
+	MaxDifficulty  int `mapstructure:"max_difficulty"`
+	RateStep       int `mapstructure:"rate_step"`
+	ExpireSeconds  int `mapstructure:"expire_seconds"`
+}
+
 // UploadConfig 文件上传配置
 type UploadConfig struct {
 	MaxSize           int64    `mapstructure:"max_size"`
@@ -232,6 +242,8 @@ var (
 		"Cache-Control",
 		"X-Requested-With",
 		"X-CSRF-Token",
+		"X-Device-ID",
+		"X-Reseller-ID",
 	}
 )
 
@@ -291,6 +303,11 @@ type WebConfig struct {
 	AdminPath string `mapstructure:"admin_path"`
 }
 
+// I18nConfig 语言包配置
+type I18nConfig struct {
+	LocalesDir string `mapstructure:"locales_dir"` // 外部语言包目录（*.json / *.yaml），与内置语言包按键合并；目录不存在时忽略
+}
+
 // ResellerConfig 分销商模式配置。
 type ResellerConfig struct {
 	Enabled              bool     `mapstructure:"enabled"`
@@ -300,6 +317,16 @@ type ResellerConfig struct {
 	SelfApplyEnabled     bool     `mapstructure:"self_apply_enabled"`
 	// SettlementConfirmDays 分销利润入账后转为可提现的确认天数（0 表示即时到账）。
 	SettlementConfirmDays int `mapstructure:"settlement_confirm_days"`
+	// DomainVerification 自定义域名 DNS TXT / HTTP 自动校验配置。
+	DomainVerification ResellerDomainVerificationConfig `mapstructure:"domain_verification"`
+}
+
+// ResellerDomainVerificationConfig 分销自定义域名自动校验配置。
+type ResellerDomainVerificationConfig struct {
+	DNSServer            string `mapstructure:"dns_server"`             // 指定 DNS 服务器 host:port；为空使用系统解析器
+	TimeoutSeconds       int    `mapstructure:"timeout_seconds"`        // 单次 DNS/HTTP 校验超时
+	RecheckIntervalHours int    `mapstructure:"recheck_interval_hours"` // 已验证域名的周期复查间隔
+	SuspendAfterFailures int    `mapstructure:"suspend_after_failures"` // 连续复查失败多少次后暂停域名
 }
 
 // Load 从 config.yml 加载配置
@@ -421,6 +448,10 @@ func Load() *Config {
 	viper.SetDefault("captcha.turnstile.secret_key", "")
 	viper.SetDefault("captcha.turnstile.verify_url", "https://challenges.cloudflare.com/turnstile/v0/siteverify")
 	viper.SetDefault("captcha.turnstile.timeout_ms", 2000)
+	viper.SetDefault("captcha.pow.base_difficulty", 18)
+	viper.SetDefault("captcha.pow.max_difficulty", 24)
+	viper.SetDefault("captcha.pow.rate_step", 5)
+	viper.SetDefault("captcha.pow.expire_seconds", 120)
 	viper.SetDefault("web.admin_path", "/admin")
 	viper.SetDefault("reseller.enabled", false)
 	viper.SetDefault("reseller.main_hosts", []string{"localhost", "127.0.0.1", "::1"})
@@ -428,6 +459,11 @@ func Load() *Config {
 	viper.SetDefault("reseller.subdomain_base", "")
 	viper.SetDefault("reseller.self_apply_enabled", true)
 	viper.SetDefault("reseller.settlement_confirm_days", 7)
+	viper.SetDefault("reseller.domain_verification.dns_server", "")
+	viper.SetDefault("reseller.domain_verification.timeout_seconds", 10)
+	viper.SetDefault("reseller.domain_verification.recheck_interval_hours", 24)
+	viper.SetDefault("reseller.domain_verification.suspend_after_failures", 3)
+	viper.SetDefault("i18n.locales_dir", "locales")
 
 	// 环境变量支持
 	viper.AutomaticEnv()                                   // 自动读取环境变量
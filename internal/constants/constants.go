@@ -4,6 +4,7 @@ package constants
 const (
 	OrderStatusPendingPayment     = "pending_payment"
 	OrderStatusPaid               = "paid"
+	OrderStatusHeldForReview      = "held_for_review" // 已支付但待人工复核，暂停交付与采购
 	OrderStatusFulfilling         = "fulfilling"
 	OrderStatusPartiallyDelivered = "partially_delivered"
 	OrderStatusPartiallyRefunded  = "partially_refunded"
@@ -13,6 +14,13 @@ const (
 	OrderStatusRefunded           = "refunded"
 )
 
+// 订单人工复核挂起来源
+const (
+	OrderReviewHoldRiskScore      = "risk_score"
+	OrderReviewHoldProductSetting = "product_setting"
+	OrderReviewHoldBlacklist      = "blacklist" // 支付回调中的付款人身份命中客户黑名单
+)
+
 // 订单退款常量
 
 const (
@@ -25,6 +33,9 @@ const (
 	FulfillmentTypeAuto        = "auto"
 	FulfillmentTypeManual      = "manual"
 	FulfillmentTypeUpstream    = "upstream"
+	FulfillmentTypeWebhook     = "webhook"
+	FulfillmentTypeFile        = "file"
+	FulfillmentTypeLicense     = "license"
 	FulfillmentStatusPending   = "pending"
 	FulfillmentStatusDelivered = "delivered"
 )
@@ -106,12 +117,19 @@ const (
 
 // 钱包交易类型常量
 const (
-	WalletTxnTypeRecharge    = "recharge"
-	WalletTxnTypeOrderPay    = "order_pay"
-	WalletTxnTypeOrderRefund = "order_refund"
-	WalletTxnTypeAdminAdjust = "admin_adjust"
-	WalletTxnTypeAdminRefund = "admin_refund"
-	WalletTxnTypeGiftCard    = "gift_card_redeem"
+	WalletTxnTypeRecharge        = "recharge"
+	WalletTxnTypeOrderPay        = "order_pay"
+	WalletTxnTypeOrderRefund     = "order_refund"
+	WalletTxnTypeAdminAdjust     = "admin_adjust"
+	WalletTxnTypeAdminRefund     = "admin_refund"
+	WalletTxnTypeGiftCard        = "gift_card_redeem"
+	WalletTxnTypePayout          = "withdraw_payout" // 提现打款批次结算入钱包
+	WalletTxnTypeTransferOut     = "transfer_out"
+	WalletTxnTypeTransferIn      = "transfer_in"
+	WalletTxnTypeTransferFee     = "transfer_fee"
+	WalletTxnTypeWithdrawFreeze  = "withdraw_freeze"  // 余额提现申请冻结
+	WalletTxnTypeWithdrawRelease = "withdraw_release" // 提现驳回解冻退回
+	WalletTxnTypePreorderBalance = "preorder_balance" // 预售尾款余额支付
 )
 
 // 钱包交易方向常量
@@ -120,6 +138,13 @@ const (
 	WalletTxnDirectionOut = "out"
 )
 
+// 钱包余额提现状态常量
+const (
+	WalletWithdrawStatusPending  = "pending"
+	WalletWithdrawStatusPaid     = "paid"
+	WalletWithdrawStatusRejected = "rejected"
+)
+
 // 钱包充值状态常量
 const (
 	WalletRechargeStatusPending = "pending"
@@ -144,20 +169,23 @@ const (
 
 // 推广返利佣金类型常量
 const (
-	AffiliateCommissionTypeOrder = "order"
+	AffiliateCommissionTypeOrder       = "order"
+	AffiliateCommissionTypeSecondLevel = "order_l2" // 二级推广人分成
 )
 
 // 推广返利提现状态常量
 const (
 	AffiliateWithdrawStatusPendingReview = "pending_review"
+	AffiliateWithdrawStatusApproved      = "approved" // 审核通过，待打款批次出款
 	AffiliateWithdrawStatusRejected      = "rejected"
 	AffiliateWithdrawStatusPaid          = "paid"
 )
 
 // 推广返利提现审核动作常量
 const (
-	AffiliateWithdrawActionReject = "reject"
-	AffiliateWithdrawActionPay    = "pay"
+	AffiliateWithdrawActionApprove = "approve"
+	AffiliateWithdrawActionReject  = "reject"
+	AffiliateWithdrawActionPay     = "pay"
 )
 
 // 易支付回调常量
@@ -288,12 +316,15 @@ const (
 	LoginLogFailReasonInvalidCredentials   = "invalid_credentials"
 	LoginLogFailReasonEmailNotVerified     = "email_not_verified"
 	LoginLogFailReasonUserDisabled         = "user_disabled"
+	LoginLogFailReasonCustomerBlacklisted  = "customer_blacklisted"
 	LoginLogFailReasonTelegramInvalid      = "telegram_invalid"
 	LoginLogFailReasonTelegramExpired      = "telegram_expired"
 	LoginLogFailReasonTelegramReplayed     = "telegram_replayed"
 	LoginLogFailReasonTelegramConfig       = "telegram_config_invalid"
 	LoginLogFailReasonGoogleInvalid        = "google_invalid"
 	LoginLogFailReasonGoogleConfig         = "google_config_invalid"
+	LoginLogFailReasonOIDCInvalid          = "oidc_invalid"
+	LoginLogFailReasonOIDCConfig           = "oidc_config_invalid"
 	LoginLogFailReasonInternalError        = "internal_error"
 	LoginLogFailReasonInvalidTOTPCode      = "invalid_totp_code"
 	LoginLogFailReasonInvalidRecoveryCode  = "invalid_recovery_code"
@@ -308,6 +339,7 @@ const (
 	LoginLogSourceWeb      = "web"
 	LoginLogSourceTelegram = "telegram"
 	LoginLogSourceGoogle   = "google"
+	LoginLogSourceOIDC     = "oidc"
 )
 
 // 验证码用途常量
@@ -324,6 +356,7 @@ const (
 	CaptchaProviderNone      = "none"
 	CaptchaProviderImage     = "image"
 	CaptchaProviderTurnstile = "turnstile"
+	CaptchaProviderPoW       = "pow"
 )
 
 // 验证码校验场景常量
@@ -333,6 +366,7 @@ const (
 	CaptchaSceneResetSendCode    = "reset_send_code"
 	CaptchaSceneGuestCreateOrder = "guest_create_order"
 	CaptchaSceneGiftCardRedeem   = "gift_card_redeem"
+	CaptchaSceneOrderRisk        = "order_risk" // 风险评分挑战，验证码服务商启用后即生效
 )
 
 // 通知中心事件常量
@@ -350,6 +384,8 @@ const (
 	NotificationAlertTypeLowStockProducts   = "low_stock_products"
 	NotificationAlertTypePendingOrders      = "pending_payment_orders"
 	NotificationAlertTypePaymentsFailed     = "payments_failed"
+	NotificationAlertTypeOrderReviewPending = "order_review_pending"
+	NotificationAlertTypeOrderReviewOverdue = "order_review_overdue"
 )
 
 // 队列常量
@@ -365,12 +401,25 @@ const (
 	TaskProcurementSubmit           = "procurement:submit"
 	TaskProcurementPollStatus       = "procurement:poll_status"
 	TaskProcurementSyncAccepted     = "procurement:sync_accepted"
+	TaskProcurementSyncRefunds      = "procurement:sync_refunds"
 	TaskUpstreamSyncProducts        = "upstream:sync_products"
 	TaskUpstreamSyncStock           = "upstream:sync_stock"
 	TaskReconciliationRun           = "reconciliation:run"
 	TaskDownstreamCallback          = "downstream:callback"
 	TaskBotNotify                   = "bot:notify"
 	TaskTelegramBroadcast           = "telegram:broadcast"
+	TaskOrderReviewSLACheck         = "order:review_sla_check"
+	TaskMemberLevelEvaluate         = "member_level:evaluate"
+	TaskResellerDomainRecheck       = "reseller:domain_recheck"
+	TaskFulfillmentWebhookDispatch  = "fulfillment:webhook_dispatch"
+	TaskFulfillmentFileDeliver      = "fulfillment:file_deliver"
+	TaskFulfillmentLicenseDeliver   = "fulfillment:license_deliver"
+	TaskRestockCheck                = "restock:check"
+	TaskRestockDeliver              = "restock:deliver"
+	TaskPreorderAllocate            = "preorder:allocate"
+	TaskPreorderSweep               = "preorder:sweep"
+	TaskStockLedgerCheck            = "stock_ledger:check"
+	TaskProductChangeSetRun         = "product_change_set:run"
 )
 
 // Telegram Bot 群发常量
@@ -409,6 +458,19 @@ const (
 	ConnectionProtocolDujiaoNext = "dujiao-next"
 )
 
+// 上游退款联动策略常量
+const (
+	RefundPropagationOff    = "off"
+	RefundPropagationAuto   = "auto"
+	RefundPropagationReview = "review"
+)
+
+// 上游退款联动的本地退款去向常量（original 写入手动退款记录，由管理员按原支付渠道退回）
+const (
+	RefundPropagationTargetWallet   = "wallet"
+	RefundPropagationTargetOriginal = "original"
+)
+
 // API 凭证状态常量
 const (
 	ApiCredentialStatusPendingReview = "pending_review"
@@ -457,6 +519,8 @@ const (
 
 	SettingKeyNavConfig = "nav_config"
 
+	SettingKeyLocaleConfig = "locale_config"
+
 	SettingKeyWalletConfig        = "wallet_config"
 	SettingFieldWalletOnlyPayment = "wallet_only_payment"
 
@@ -511,17 +575,15 @@ const (
 	LocaleEnUS = "en-US"
 )
 
-// 支持的站点语言顺序（含回退顺序）
-var SupportedLocales = []string{LocaleZhCN, LocaleZhTW, LocaleEnUS}
-
 // 通知业务类型常量
 const (
-	NotificationBizTypeOrder           = "order"
-	NotificationBizTypeWalletRecharge  = "wallet_recharge"
-	NotificationBizTypeDashboardAlert  = "dashboard_alert"
-	NotificationBizTypePaymentCallback = "payment_callback"
-	NotificationBizTypeProcurement     = "procurement"
-	NotificationBizTypeReconciliation  = "reconciliation"
+	NotificationBizTypeOrder             = "order"
+	NotificationBizTypeWalletRecharge    = "wallet_recharge"
+	NotificationBizTypeDashboardAlert    = "dashboard_alert"
+	NotificationBizTypePaymentCallback   = "payment_callback"
+	NotificationBizTypeProcurement       = "procurement"
+	NotificationBizTypeReconciliation    = "reconciliation"
+	NotificationBizTypeRefundPropagation = "refund_propagation"
 )
 
 // 对账差异类型常量
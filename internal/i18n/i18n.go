@@ -0,0 +1,62 @@
+package i18n
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dujiao-next/internal/constants"
+	"github.com/dujiao-next/internal/i18n/locales"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	LocaleZH = constants.LocaleZhCN
+	LocaleTW = constants.LocaleZhTW
+	LocaleEN = constants.LocaleEnUS
+)
+
+// ResolveLocale 根据请求解析语言
+func ResolveLocale(c *gin.Context) string {
+	if c == nil {
+		return LocaleZH
+	}
+	if lang := strings.TrimSpace(c.Query("lang")); lang != "" {
+		return normalizeLocale(lang)
+	}
+	if lang := strings.TrimSpace(c.GetHeader("X-Lang")); lang != "" {
+		return normalizeLocale(lang)
+	}
+	accept := strings.TrimSpace(c.GetHeader("Accept-Language"))
+	if accept == "" {
+		return LocaleZH
+	}
+	parts := strings.Split(accept, ",")
+	if len(parts) == 0 {
+		return LocaleZH
+	}
+	return normalizeLocale(parts[0])
+}
+
+// T 获取翻译文本，沿语言包回退链查找，全部缺失时返回 key 本身
+func T(locale, key string) string {
+	if locale == "" {
+		locale = LocaleZH
+	}
+	if msg, ok := locales.Lookup(locale, key); ok {
+		return msg
+	}
+	return key
+}
+
+// Sprintf 获取带参数的翻译文本
+func Sprintf(locale, key string, args ...interface{}) string {
+	return fmt.Sprintf(T(locale, key), args...)
+}
+
+// normalizeLocale 把请求语言匹配到已启用的语言包，无法匹配时使用简体中文
+func normalizeLocale(locale string) string {
+	if matched := locales.Match(locale); matched != "" {
+		return matched
+	}
+	return LocaleZH
+}
@@ -0,0 +1,394 @@
+package locales
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dujiao-next/internal/constants"
+)
+
+// Default 是兜底语言：任何回退链最终都会落到这里，且不可被禁用。
+const Default = constants.LocaleZhCN
+
+// builtinOrder 决定内置语言在列表中的顺序，其余语言按标签字母序排在后面。
+var builtinOrder = []string{constants.LocaleZhCN, constants.LocaleZhTW, constants.LocaleEnUS}
+
+// aliasLocales 处理不能靠语言前缀推断的地区/书写系统别名。
+var aliasLocales = map[string]string{
+	"zh-HK":   constants.LocaleZhTW,
+	"zh-MO":   constants.LocaleZhTW,
+	"zh-Hant": constants.LocaleZhTW,
+	"zh-Hans": constants.LocaleZhCN,
+	"zh-SG":   constants.LocaleZhCN,
+}
+
+// Overrides 是后台维护的语言配置：禁用的语言与按语言覆盖的消息文本。
+type Overrides struct {
+	Disabled []string
+	Messages map[string]map[string]string
+}
+
+// OverrideLoader 读取当前后台语言配置，由 settings 模块在启动时注册。
+type OverrideLoader func() (Overrides, error)
+
+// PackInfo 是已安装语言包的概要，供后台展示与缺词检查。
+type PackInfo struct {
+	Locale        string   `json:"locale"`
+	Name          string   `json:"name"`
+	Fallback      []string `json:"fallback"`
+	Sources       []string `json:"sources"`
+	Enabled       bool     `json:"enabled"`
+	MessageCount  int      `json:"message_count"`
+	MissingCount  int      `json:"missing_count"`
+	OverrideCount int      `json:"override_count"`
+}
+
+type installedPack struct {
+	name     string
+	fallback []string
+	sources  []string
+	messages map[string]string
+}
+
+// Catalog 保存已安装的语言包、禁用列表与后台覆盖文本。
+type Catalog struct {
+	mu        sync.RWMutex
+	packs     map[string]*installedPack
+	order     []string
+	disabled  map[string]struct{}
+	overrides map[string]map[string]string
+
+	loader     OverrideLoader
+	loaderTTL  time.Duration
+	expires    time.Time
+	refreshing atomic.Bool
+}
+
+// NewCatalog 创建空语言目录。
+func NewCatalog() *Catalog {
+	return &Catalog{
+		packs:     map[string]*installedPack{},
+		disabled:  map[string]struct{}{},
+		overrides: map[string]map[string]string{},
+	}
+}
+
+// Install 安装语言包；同一语言再次安装时逐键覆盖，用于外部文件补充或修改内置文本。
+func (c *Catalog) Install(pack Pack) error {
+	normalized, err := NormalizePack(pack)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	existing, ok := c.packs[normalized.Locale]
+	if !ok {
+		existing = &installedPack{name: normalized.Name, messages: map[string]string{}}
+		c.packs[normalized.Locale] = existing
+		c.order = sortLocales(append(c.order, normalized.Locale))
+	} else if normalized.Name != normalized.Locale {
+		existing.name = normalized.Name
+	}
+	if len(normalized.Fallback) > 0 {
+		existing.fallback = normalized.Fallback
+	}
+	if normalized.Source != "" {
+		existing.sources = append(existing.sources, normalized.Source)
+	}
+	for key, text := range normalized.Messages {
+		existing.messages[key] = text
+	}
+	return nil
+}
+
+// Apply 替换后台语言配置；未安装的语言与兜底语言的禁用项会被忽略。
+func (c *Catalog) Apply(overrides Overrides) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.applyLocked(overrides)
+}
+
+func (c *Catalog) applyLocked(overrides Overrides) {
+	disabled := make(map[string]struct{}, len(overrides.Disabled))
+	for _, raw := range overrides.Disabled {
+		locale, ok := Canonical(raw)
+		if !ok || locale == Default {
+			continue
+		}
+		disabled[locale] = struct{}{}
+	}
+	messages := make(map[string]map[string]string, len(overrides.Messages))
+	for raw, bundle := range overrides.Messages {
+		locale, ok := Canonical(raw)
+		if !ok || len(bundle) == 0 {
+			continue
+		}
+		copied := make(map[string]string, len(bundle))
+		for key, text := range bundle {
+			copied[key] = text
+		}
+		messages[locale] = copied
+	}
+	c.disabled = disabled
+	c.overrides = messages
+}
+
+// SetOverrideLoader 注册后台配置加载器；配置按 ttl 懒刷新，多进程部署下各进程最迟 ttl 后一致。
+func (c *Catalog) SetOverrideLoader(loader OverrideLoader, ttl time.Duration) {
+	c.mu.Lock()
+	c.loader = loader
+	c.loaderTTL = ttl
+	c.expires = time.Time{}
+	c.mu.Unlock()
+}
+
+// InvalidateOverrides 让下一次查询重新加载后台配置。
+func (c *Catalog) InvalidateOverrides() {
+	c.mu.Lock()
+	c.expires = time.Time{}
+	c.mu.Unlock()
+}
+
+// refresh 在配置过期时调用加载器；并发或重入（加载器内部再次查询语言）时直接沿用旧配置。
+func (c *Catalog) refresh() {
+	c.mu.RLock()
+	loader, stale := c.loader, time.Now().After(c.expires)
+	c.mu.RUnlock()
+	if loader == nil || !stale {
+		return
+	}
+	if !c.refreshing.CompareAndSwap(false, true) {
+		return
+	}
+	defer c.refreshing.Store(false)
+
+	overrides, err := loader()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.expires = time.Now().Add(c.loaderTTL)
+	if err == nil {
+		c.applyLocked(overrides)
+	}
+}
+
+// Installed 返回全部已安装语言（含已禁用），不触发后台配置刷新。
+func (c *Catalog) Installed() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return append([]string(nil), c.order...)
+}
+
+// IsInstalled 判断语言是否已安装。
+func (c *Catalog) IsInstalled(locale string) bool {
+	locale, ok := Canonical(locale)
+	if !ok {
+		return false
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	_, exists := c.packs[locale]
+	return exists
+}
+
+// Enabled 返回当前启用的语言列表，兜底语言总在第一位。
+func (c *Catalog) Enabled() []string {
+	c.refresh()
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	result := make([]string, 0, len(c.order))
+	for _, locale := range c.order {
+		if _, off := c.disabled[locale]; !off {
+			result = append(result, locale)
+		}
+	}
+	return result
+}
+
+// IsEnabled 判断语言标签（按规范形式比较）是否已启用。
+func (c *Catalog) IsEnabled(locale string) bool {
+	locale, ok := Canonical(locale)
+	if !ok {
+		return false
+	}
+	c.refresh()
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.enabledLocked(locale)
+}
+
+func (c *Catalog) enabledLocked(locale string) bool {
+	if _, exists := c.packs[locale]; !exists {
+		return false
+	}
+	_, off := c.disabled[locale]
+	return !off
+}
+
+// Match 把请求中的语言标签（可带 q 权重）匹配到已启用语言：先精确匹配与别名，
+// 再逐级去掉子标签，最后按主语言匹配第一个启用的语言。无法匹配时返回空串。
+func (c *Catalog) Match(tag string) string {
+	if index := strings.IndexByte(tag, ';'); index >= 0 {
+		tag = tag[:index]
+	}
+	locale, ok := Canonical(tag)
+	if !ok {
+		return ""
+	}
+	c.refresh()
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for candidate := locale; candidate != ""; candidate = trimSubtag(candidate) {
+		if c.enabledLocked(candidate) {
+			return candidate
+		}
+		if alias, exists := aliasLocales[candidate]; exists && c.enabledLocked(alias) {
+			return alias
+		}
+	}
+	language := primaryLanguage(locale)
+	for _, candidate := range c.order {
+		if primaryLanguage(candidate) == language && c.enabledLocked(candidate) {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// Chain 返回语言的查找顺序：自身、语言包声明的回退（递归展开）、兜底语言。
+func (c *Catalog) Chain(locale string) []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.chainLocked(locale)
+}
+
+func (c *Catalog) chainLocked(locale string) []string {
+	chain := make([]string, 0, 4)
+	seen := map[string]struct{}{}
+	var walk func(string)
+	walk = func(current string) {
+		if _, visited := seen[current]; visited {
+			return
+		}
+		seen[current] = struct{}{}
+		pack, exists := c.packs[current]
+		if !exists {
+			return
+		}
+		chain = append(chain, current)
+		for _, next := range pack.fallback {
+			walk(next)
+		}
+	}
+	if canonical, ok := Canonical(locale); ok {
+		walk(canonical)
+	}
+	walk(Default)
+	return chain
+}
+
+// Lookup 沿回退链查找消息；每一级先看后台覆盖再看语言包。
+func (c *Catalog) Lookup(locale, key string) (string, bool) {
+	c.refresh()
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, current := range c.chainLocked(locale) {
+		if text, ok := c.overrides[current][key]; ok {
+			return text, true
+		}
+		if text, ok := c.packs[current].messages[key]; ok {
+			return text, true
+		}
+	}
+	return "", false
+}
+
+// HasKey 判断任一已安装语言包是否定义了该消息键。
+func (c *Catalog) HasKey(key string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, pack := range c.packs {
+		if _, ok := pack.messages[key]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// MissingKeys 返回 reference 语言包中存在而 locale 语言包自身缺失的键（不计回退）。
+func (c *Catalog) MissingKeys(reference, locale string) []string {
+	reference, _ = Canonical(reference)
+	locale, _ = Canonical(locale)
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.missingLocked(reference, locale)
+}
+
+func (c *Catalog) missingLocked(reference, locale string) []string {
+	base, ok := c.packs[reference]
+	if !ok {
+		return nil
+	}
+	target := c.packs[locale]
+	missing := make([]string, 0)
+	for key := range base.messages {
+		if target != nil {
+			if _, exists := target.messages[key]; exists {
+				continue
+			}
+		}
+		missing = append(missing, key)
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// Packs 返回已安装语言包概要，缺词数以兜底语言为参照。
+func (c *Catalog) Packs() []PackInfo {
+	c.refresh()
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	result := make([]PackInfo, 0, len(c.order))
+	for _, locale := range c.order {
+		pack := c.packs[locale]
+		result = append(result, PackInfo{
+			Locale:        locale,
+			Name:          pack.name,
+			Fallback:      append([]string{}, pack.fallback...),
+			Sources:       append([]string{}, pack.sources...),
+			Enabled:       c.enabledLocked(locale),
+			MessageCount:  len(pack.messages),
+			MissingCount:  len(c.missingLocked(Default, locale)),
+			OverrideCount: len(c.overrides[locale]),
+		})
+	}
+	return result
+}
+
+func trimSubtag(tag string) string {
+	if index := strings.LastIndexByte(tag, '-'); index > 0 {
+		return tag[:index]
+	}
+	return ""
+}
+
+func sortLocales(locales []string) []string {
+	rank := func(locale string) int {
+		for index, builtin := range builtinOrder {
+			if builtin == locale {
+				return index
+			}
+		}
+		return len(builtinOrder)
+	}
+	sort.SliceStable(locales, func(i, j int) bool {
+		left, right := rank(locales[i]), rank(locales[j])
+		if left != right {
+			return left < right
+		}
+		return locales[i] < locales[j]
+	})
+	return locales
+}
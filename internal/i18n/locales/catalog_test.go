@@ -0,0 +1,140 @@
+package locales
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func newTestCatalog(t *testing.T, packs ...Pack) *Catalog {
+	t.Helper()
+	catalog := NewCatalog()
+	for _, pack := range packs {
+		if err := catalog.Install(pack); err != nil {
+			t.Fatalf("install %s: %v", pack.Locale, err)
+		}
+	}
+	return catalog
+}
+
+func basePacks() []Pack {
+	return []Pack{
+		{Locale: "zh-CN", Messages: map[string]string{"greeting": "你好", "farewell": "再见"}},
+		{Locale: "zh-TW", Messages: map[string]string{"greeting": "你好", "farewell": "再見"}},
+		{Locale: "en-US", Messages: map[string]string{"greeting": "Hello", "farewell": "Bye"}},
+		{Locale: "ja_jp", Fallback: []string{"en-US"}, Messages: map[string]string{"greeting": "こんにちは"}},
+	}
+}
+
+func TestCatalogMatch(t *testing.T) {
+	catalog := newTestCatalog(t, basePacks()...)
+	cases := map[string]string{
+		"zh-CN":         "zh-CN",
+		"zh-hk":         "zh-TW",
+		"zh-Hant-TW":    "zh-TW",
+		"zh":            "zh-CN",
+		"en-GB;q=0.8":   "en-US",
+		"ja":            "ja-JP",
+		"fr-FR":         "",
+		"not a locale!": "",
+	}
+	for tag, want := range cases {
+		if got := catalog.Match(tag); got != want {
+			t.Errorf("Match(%q) = %q, want %q", tag, got, want)
+		}
+	}
+	if got := catalog.Enabled(); !reflect.DeepEqual(got, []string{"zh-CN", "zh-TW", "en-US", "ja-JP"}) {
+		t.Fatalf("unexpected enabled order: %v", got)
+	}
+}
+
+func TestCatalogLookupFollowsFallbackChain(t *testing.T) {
+	catalog := newTestCatalog(t, basePacks()...)
+	if chain := catalog.Chain("ja-JP"); !reflect.DeepEqual(chain, []string{"ja-JP", "en-US", "zh-CN"}) {
+		t.Fatalf("unexpected chain: %v", chain)
+	}
+	if text, _ := catalog.Lookup("ja-JP", "greeting"); text != "こんにちは" {
+		t.Fatalf("expected own message, got %q", text)
+	}
+	if text, _ := catalog.Lookup("ja-JP", "farewell"); text != "Bye" {
+		t.Fatalf("expected en-US fallback, got %q", text)
+	}
+	if _, ok := catalog.Lookup("ja-JP", "missing"); ok {
+		t.Fatal("unknown key must not resolve")
+	}
+	if missing := catalog.MissingKeys("zh-CN", "ja-JP"); !reflect.DeepEqual(missing, []string{"farewell"}) {
+		t.Fatalf("unexpected missing keys: %v", missing)
+	}
+}
+
+func TestCatalogInstallMergesAndOverridesApply(t *testing.T) {
+	catalog := newTestCatalog(t, basePacks()...)
+	if err := catalog.Install(Pack{Locale: "en-US", Messages: map[string]string{"farewell": "Goodbye"}}); err != nil {
+		t.Fatalf("merge install: %v", err)
+	}
+	if text, _ := catalog.Lookup("en-US", "greeting"); text != "Hello" {
+		t.Fatalf("merge must keep existing keys, got %q", text)
+	}
+	if text, _ := catalog.Lookup("en-US", "farewell"); text != "Goodbye" {
+		t.Fatalf("merge must replace key, got %q", text)
+	}
+
+	loads := 0
+	catalog.SetOverrideLoader(func() (Overrides, error) {
+		loads++
+		return Overrides{
+			Disabled: []string{"ja-JP", "zh-CN"},
+			Messages: map[string]map[string]string{"en-US": {"greeting": "Hi"}},
+		}, nil
+	}, time.Hour)
+	if text, _ := catalog.Lookup("en-US", "greeting"); text != "Hi" {
+		t.Fatalf("override must win, got %q", text)
+	}
+	if catalog.IsEnabled("ja-JP") || !catalog.IsEnabled("zh-CN") {
+		t.Fatal("ja-JP should be disabled and the default locale must stay enabled")
+	}
+	if got := catalog.Match("ja"); got != "" {
+		t.Fatalf("disabled locale must not match, got %q", got)
+	}
+	_ = catalog.Enabled()
+	if loads != 1 {
+		t.Fatalf("loader should be cached within ttl, loads=%d", loads)
+	}
+	catalog.InvalidateOverrides()
+	_ = catalog.Enabled()
+	if loads != 2 {
+		t.Fatalf("invalidate should force reload, loads=%d", loads)
+	}
+}
+
+func TestParsePack(t *testing.T) {
+	pack, err := ParsePack("vi.yaml", []byte("locale: vi-vn\nname: Tiếng Việt\nfallback: [en-US]\nmessages:\n  greeting: Xin chào\n"))
+	if err != nil {
+		t.Fatalf("parse yaml: %v", err)
+	}
+	if pack.Locale != "vi-VN" || pack.Messages["greeting"] != "Xin chào" || !reflect.DeepEqual(pack.Fallback, []string{"en-US"}) {
+		t.Fatalf("unexpected pack: %+v", pack)
+	}
+	if _, err := ParsePack("vi.txt", nil); !errors.Is(err, ErrPackFormatUnknown) {
+		t.Fatalf("expected unknown format, got %v", err)
+	}
+	if _, err := ParsePack("bad.json", []byte(`{"locale":"??","messages":{}}`)); !errors.Is(err, ErrPackInvalid) {
+		t.Fatalf("expected invalid locale, got %v", err)
+	}
+	if _, err := ParsePack("bad.json", []byte(`{"locale":"fr-FR","messages":{"bad key":"x"}}`)); !errors.Is(err, ErrPackInvalid) {
+		t.Fatalf("expected invalid key, got %v", err)
+	}
+}
+
+func TestEmbeddedPacksCoverDefaultLocale(t *testing.T) {
+	catalog := NewCatalog()
+	if err := LoadEmbedded(catalog); err != nil {
+		t.Fatalf("load embedded: %v", err)
+	}
+	for _, locale := range builtinOrder {
+		if missing := catalog.MissingKeys(Default, locale); len(missing) > 0 {
+			t.Errorf("builtin locale %s is missing %d keys, e.g. %s", locale, len(missing), missing[0])
+		}
+	}
+}
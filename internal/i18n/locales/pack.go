@@ -0,0 +1,140 @@
+package locales
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	ErrPackInvalid       = errors.New("locale pack invalid")
+	ErrPackFormatUnknown = errors.New("locale pack format unknown")
+)
+
+var localeTagPattern = regexp.MustCompile(`^[A-Za-z]{2,3}(-[A-Za-z0-9]{2,8}){0,2}$`)
+
+const (
+	maxPackFallbacks  = 4
+	maxMessageKeyLen  = 128
+	maxMessageTextLen = 4000
+)
+
+// Pack 是一份语言包：locale 为 BCP 47 标签，fallback 为缺词时依次回退的语言。
+// 文件格式为 JSON 或 YAML，字段与 JSON 标签一致。
+type Pack struct {
+	Locale   string            `json:"locale" yaml:"locale"`
+	Name     string            `json:"name" yaml:"name"`
+	Fallback []string          `json:"fallback,omitempty" yaml:"fallback,omitempty"`
+	Messages map[string]string `json:"messages" yaml:"messages"`
+	// Source 记录语言包来源（embedded:<file> 或外部文件路径），不参与序列化。
+	Source string `json:"-" yaml:"-"`
+}
+
+// ParsePack 按文件扩展名解析语言包并完成规范化与校验。
+func ParsePack(filename string, data []byte) (Pack, error) {
+	var pack Pack
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".json":
+		if err := json.Unmarshal(data, &pack); err != nil {
+			return Pack{}, fmt.Errorf("%w: %s: %v", ErrPackInvalid, filename, err)
+		}
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(data, &pack); err != nil {
+			return Pack{}, fmt.Errorf("%w: %s: %v", ErrPackInvalid, filename, err)
+		}
+	default:
+		return Pack{}, fmt.Errorf("%w: %s", ErrPackFormatUnknown, filename)
+	}
+	pack.Source = filename
+	normalized, err := NormalizePack(pack)
+	if err != nil {
+		return Pack{}, fmt.Errorf("%s: %w", filename, err)
+	}
+	return normalized, nil
+}
+
+// NormalizePack 规范化语言标签与回退链，并校验消息键与文本长度。
+func NormalizePack(pack Pack) (Pack, error) {
+	locale, ok := Canonical(pack.Locale)
+	if !ok {
+		return Pack{}, fmt.Errorf("%w: locale %q", ErrPackInvalid, pack.Locale)
+	}
+	pack.Locale = locale
+	pack.Name = strings.TrimSpace(pack.Name)
+	if pack.Name == "" {
+		pack.Name = locale
+	}
+
+	fallback := make([]string, 0, len(pack.Fallback))
+	seen := map[string]struct{}{locale: {}}
+	for _, raw := range pack.Fallback {
+		tag, ok := Canonical(raw)
+		if !ok {
+			return Pack{}, fmt.Errorf("%w: fallback %q", ErrPackInvalid, raw)
+		}
+		if _, exists := seen[tag]; exists {
+			continue
+		}
+		seen[tag] = struct{}{}
+		fallback = append(fallback, tag)
+	}
+	if len(fallback) > maxPackFallbacks {
+		return Pack{}, fmt.Errorf("%w: too many fallbacks", ErrPackInvalid)
+	}
+	pack.Fallback = fallback
+
+	messages := make(map[string]string, len(pack.Messages))
+	for key, text := range pack.Messages {
+		key = strings.TrimSpace(key)
+		if err := ValidateMessage(key, text); err != nil {
+			return Pack{}, err
+		}
+		messages[key] = text
+	}
+	pack.Messages = messages
+	return pack, nil
+}
+
+// ValidateMessage 校验单条消息的键与文本长度，语言包与后台覆盖共用。
+func ValidateMessage(key, text string) error {
+	if key == "" || len(key) > maxMessageKeyLen || strings.ContainsAny(key, " \t\r\n") {
+		return fmt.Errorf("%w: message key %q", ErrPackInvalid, key)
+	}
+	if len(text) > maxMessageTextLen {
+		return fmt.Errorf("%w: message %q too long", ErrPackInvalid, key)
+	}
+	return nil
+}
+
+// Canonical 把语言标签规范为 xx-YY 形式：语言小写、地区大写、书写系统首字母大写。
+func Canonical(tag string) (string, bool) {
+	tag = strings.ReplaceAll(strings.TrimSpace(tag), "_", "-")
+	if !localeTagPattern.MatchString(tag) {
+		return "", false
+	}
+	parts := strings.Split(tag, "-")
+	parts[0] = strings.ToLower(parts[0])
+	for i := 1; i < len(parts); i++ {
+		switch len(parts[i]) {
+		case 2:
+			parts[i] = strings.ToUpper(parts[i])
+		case 4:
+			parts[i] = strings.ToUpper(parts[i][:1]) + strings.ToLower(parts[i][1:])
+		default:
+			parts[i] = strings.ToLower(parts[i])
+		}
+	}
+	return strings.Join(parts, "-"), true
+}
+
+func primaryLanguage(tag string) string {
+	if index := strings.IndexByte(tag, '-'); index > 0 {
+		return tag[:index]
+	}
+	return tag
+}
@@ -0,0 +1,138 @@
+package locales
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+//go:embed packs/*.json
+var embeddedPacks embed.FS
+
+// shared 是进程级语言目录，初始化时装入内置语言包。
+var shared = mustEmbeddedCatalog()
+
+func mustEmbeddedCatalog() *Catalog {
+	catalog := NewCatalog()
+	if err := LoadEmbedded(catalog); err != nil {
+		panic(fmt.Sprintf("load embedded locale packs: %v", err))
+	}
+	return catalog
+}
+
+// LoadEmbedded 把内置语言包装入目录。
+func LoadEmbedded(catalog *Catalog) error {
+	entries, err := fs.ReadDir(embeddedPacks, "packs")
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		data, err := embeddedPacks.ReadFile(path.Join("packs", entry.Name()))
+		if err != nil {
+			return err
+		}
+		pack, err := ParsePack(entry.Name(), data)
+		if err != nil {
+			return err
+		}
+		pack.Source = "embedded:" + entry.Name()
+		if err := catalog.Install(pack); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadDirInto 从外部目录读取 *.json / *.yaml / *.yml 语言包并装入目录，返回装入的文件数。
+// 目录不存在时视为未配置外部语言包；与已安装语言相同的包逐键覆盖内置文本。
+func LoadDirInto(catalog *Catalog, dir string) (int, error) {
+	dir = strings.TrimSpace(dir)
+	if dir == "" {
+		return 0, nil
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		switch strings.ToLower(filepath.Ext(entry.Name())) {
+		case ".json", ".yaml", ".yml":
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	// 先全部解析再安装，任一文件有误时不留下只装了一半的语言包。
+	packs := make([]Pack, 0, len(names))
+	for _, name := range names {
+		filename := filepath.Join(dir, name)
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			return 0, err
+		}
+		pack, err := ParsePack(filename, data)
+		if err != nil {
+			return 0, err
+		}
+		packs = append(packs, pack)
+	}
+	for _, pack := range packs {
+		if err := catalog.Install(pack); err != nil {
+			return 0, err
+		}
+	}
+	return len(packs), nil
+}
+
+// LoadDir 把外部语言包装入进程级目录。
+func LoadDir(dir string) (int, error) { return LoadDirInto(shared, dir) }
+
+// SetOverrideLoader 为进程级目录注册后台配置加载器。
+func SetOverrideLoader(loader OverrideLoader, ttl time.Duration) {
+	shared.SetOverrideLoader(loader, ttl)
+}
+
+// InvalidateOverrides 让进程级目录在下一次查询时重新加载后台配置。
+func InvalidateOverrides() { shared.InvalidateOverrides() }
+
+// Installed 返回已安装语言（含已禁用）。
+func Installed() []string { return shared.Installed() }
+
+// IsInstalled 判断语言是否已安装。
+func IsInstalled(locale string) bool { return shared.IsInstalled(locale) }
+
+// Enabled 返回当前启用的语言列表。
+func Enabled() []string { return shared.Enabled() }
+
+// IsEnabled 判断语言是否已启用。
+func IsEnabled(locale string) bool { return shared.IsEnabled(locale) }
+
+// Match 把请求语言标签匹配到已启用语言，无法匹配时返回空串。
+func Match(tag string) string { return shared.Match(tag) }
+
+// Lookup 沿回退链查找消息。
+func Lookup(locale, key string) (string, bool) { return shared.Lookup(locale, key) }
+
+// HasKey 判断消息键是否在任一语言包中定义。
+func HasKey(key string) bool { return shared.HasKey(key) }
+
+// MissingKeys 返回 locale 相对 reference 缺失的键。
+func MissingKeys(reference, locale string) []string { return shared.MissingKeys(reference, locale) }
+
+// Packs 返回已安装语言包概要。
+func Packs() []PackInfo { return shared.Packs() }
+
+// Chain 返回语言的回退查找顺序。
+func Chain(locale string) []string { return shared.Chain(locale) }
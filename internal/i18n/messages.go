@@ -1,1148 +0,0 @@
-package i18n
-
-import (
-	"fmt"
-	"strings"
-
-	"github.com/dujiao-next/internal/constants"
-	"github.com/gin-gonic/gin"
-)
-
-const (
-	LocaleZH = constants.LocaleZhCN
-	LocaleTW = constants.LocaleZhTW
-	LocaleEN = constants.LocaleEnUS
-)
-
-var messages = map[string]map[string]string{
-	LocaleZH: {
-		"error.jwt_secret_missing":                       "JWT secret 未配置",
-		"error.auth_header_missing":                      "缺少 Authorization header",
-		"error.auth_header_invalid":                      "Authorization header 格式错误",
-		"error.token_invalid":                            "无效的 token",
-		"error.token_revoked":                            "登录状态已失效，请重新登录",
-		"error.bad_request":                              "请求参数错误",
-		"error.request_too_large":                        "请求内容过大",
-		"error.authz_builtin_role_immutable":             "系统内置角色由程序托管，不允许修改权限或删除",
-		"error.wallet_adjust_remark_required":            "余额调整必须填写备注",
-		"error.reseller_subdomain_base_missing":          "分销系统二级域名基础域名未配置，请先配置 reseller.subdomain_base",
-		"error.internal_error":                           "服务器内部错误",
-		"error.email_invalid":                            "邮箱格式不正确",
-		"error.verify_purpose_invalid":                   "验证码用途无效",
-		"error.email_exists":                             "邮箱已注册",
-		"error.profile_empty":                            "请至少填写一项资料",
-		"error.email_change_invalid":                     "更换邮箱参数不合法",
-		"error.email_change_exists":                      "新邮箱已被注册",
-		"error.user_not_found":                           "用户不存在",
-		"error.verify_code_too_frequent":                 "发送过于频繁，请稍后重试",
-		"error.email_service_not_configured":             "邮箱服务未配置",
-		"error.email_recipient_not_found":                "收件邮箱不存在，请检查后重试",
-		"error.send_verify_code_failed":                  "发送验证码失败",
-		"error.notification_send_failed":                 "通知发送失败",
-		"error.verify_code_invalid":                      "验证码错误",
-		"error.verify_code_expired":                      "验证码已过期",
-		"error.verify_code_attempts_exceeded":            "验证码尝试次数过多",
-		"error.password_weak":                            "密码强度不足",
-		"error.password_min_length":                      "密码长度至少 %d 位",
-		"error.password_require_upper":                   "需包含大写字母",
-		"error.password_require_lower":                   "需包含小写字母",
-		"error.password_require_number":                  "需包含数字",
-		"error.password_require_special":                 "需包含特殊字符",
-		"error.register_failed":                          "注册失败",
-		"error.registration_disabled":                    "注册功能已关闭",
-		"error.email_domain_not_allowed":                 "当前邮箱后缀不允许注册",
-		"error.email_verification_disabled":              "邮箱验证功能已关闭",
-		"error.password_reset_disabled":                  "密码重置功能已关闭，请联系管理员修改密码",
-		"error.agreement_required":                       "请先同意隐私政策和服务条款",
-		"error.login_too_many":                           "登录尝试过多，请在 %d 秒后重试",
-		"error.rate_limited":                             "请求过于频繁，请在 %d 秒后重试",
-		"error.rate_limit_unavailable":                   "限流服务不可用",
-		"error.login_invalid":                            "邮箱或密码错误",
-		"error.email_not_verified":                       "邮箱未验证",
-		"error.user_disabled":                            "账号已禁用",
-		"error.login_failed":                             "登录失败",
-		"error.telegram_auth_disabled":                   "Telegram 登录未启用",
-		"error.telegram_auth_config_invalid":             "Telegram 登录配置不合法",
-		"error.telegram_auth_payload_invalid":            "Telegram 登录参数不合法",
-		"error.telegram_auth_signature_invalid":          "Telegram 登录签名校验失败",
-		"error.telegram_auth_expired":                    "Telegram 登录已过期，请重试",
-		"error.telegram_auth_replayed":                   "Telegram 登录请求已失效，请重试",
-		"error.telegram_oidc_state_invalid":              "Telegram 登录会话已失效，请重试",
-		"error.telegram_oidc_token_exchange_failed":      "Telegram 授权码换取令牌失败，请重试",
-		"error.telegram_oidc_id_token_invalid":           "Telegram 身份令牌校验失败",
-		"error.telegram_bind_conflict":                   "该 Telegram 账号已绑定其他用户",
-		"error.telegram_already_bound":                   "当前账号已绑定其他 Telegram 账号",
-		"error.telegram_not_bound":                       "当前账号未绑定 Telegram",
-		"error.telegram_unbind_requires_email":           "请先设置本地密码或绑定其他可用登录方式，再解绑 Telegram",
-		"error.google_auth_disabled":                     "Google 登录未启用",
-		"error.google_auth_config_invalid":               "Google 登录配置不合法",
-		"error.google_credential_invalid":                "Google 身份凭证无效，请重试",
-		"error.google_credential_expired":                "Google 身份凭证已过期，请重试",
-		"error.google_email_unverified":                  "Google 邮箱尚未验证",
-		"error.google_service_unavailable":               "Google 登录服务暂时不可用，请稍后重试",
-		"error.google_auto_link_forbidden":               "请先使用原账号登录，再绑定此 Google 账号",
-		"error.google_bind_conflict":                     "该 Google 账号已绑定其他用户",
-		"error.google_already_bound":                     "当前账号已绑定其他 Google 账号",
-		"error.google_not_bound":                         "当前账号未绑定 Google",
-		"error.google_unbind_locked":                     "请先设置本地密码或绑定其他可用登录方式，再解绑 Google",
-		"error.google_redirect_session_expired":          "Google 登录会话已失效，请重试",
-		"error.google_redirect_context_mismatch":         "Google 登录会话与当前站点或账号不匹配，请重试",
-		"error.captcha_required":                         "请先完成验证码",
-		"error.captcha_invalid":                          "验证码错误或已失效",
-		"error.captcha_verify_failed":                    "验证码校验失败",
-		"error.captcha_config_invalid":                   "验证码配置不合法",
-		"error.captcha_unavailable":                      "验证码服务不可用",
-		"error.captcha_generate_failed":                  "验证码生成失败",
-		"error.user_update_failed":                       "更新用户资料失败",
-		"error.reset_failed":                             "重置密码失败",
-		"error.email_change_failed":                      "更换邮箱失败",
-		"error.unauthorized":                             "未授权",
-		"error.forbidden":                                "无权限访问",
-		"error.internal_server_error":                    "服务器内部错误",
-		"error.user_id_invalid":                          "无效的用户ID",
-		"error.user_id_type_invalid":                     "系统错误: ID类型异常",
-		"error.admin_id_invalid":                         "无效的管理员ID",
-		"error.admin_id_type_invalid":                    "系统错误: 管理员ID类型异常",
-		"error.admin_username_invalid":                   "管理员账号格式不合法",
-		"error.admin_username_exists":                    "管理员账号已存在",
-		"error.admin_create_failed":                      "创建管理员失败",
-		"error.admin_update_failed":                      "更新管理员失败",
-		"error.admin_delete_failed":                      "删除管理员失败",
-		"error.admin_delete_self_forbidden":              "不允许删除当前登录管理员",
-		"error.admin_delete_last_forbidden":              "至少保留一个管理员账号",
-		"error.admin_delete_protected":                   "默认超级管理员不允许删除",
-		"error.product_price_invalid":                    "商品价格或币种不合法",
-		"error.wholesale_price_invalid":                  "批发价配置不合法",
-		"error.product_purchase_invalid":                 "商品购买身份不合法",
-		"error.product_category_invalid":                 "当前分类不可直接挂载商品，请选择末级分类",
-		"error.product_purchase_not_allowed":             "当前商品仅限会员购买",
-		"error.product_max_purchase_exceeded":            "超出当前商品单次购买数量上限",
-		"error.product_min_purchase_not_met":             "未达到当前商品单次购买数量下限",
-		"error.product_purchase_limit_invalid":           "单次购买数量下限不能大于上限",
-		"error.user_fetch_failed":                        "获取用户信息失败",
-		"error.user_login_log_fetch_failed":              "获取登录日志失败",
-		"error.dashboard_fetch_failed":                   "获取仪表盘数据失败",
-		"error.update_check_failed":                      "检测更新失败，请稍后再试",
-		"error.update_check_rate_limited":                "GitHub 请求次数已超限，请稍后再试",
-		"error.update_not_supported":                     "当前部署方式不支持一键升级",
-		"error.update_in_progress":                       "升级任务正在执行中，请稍后再试",
-		"error.update_already_latest":                    "当前已是最新版本，无需升级",
-		"error.update_failed":                            "升级失败，请查看服务器日志",
-		"error.update_no_backup":                         "没有可回滚的备份版本",
-		"error.update_rollback_unsafe":                   "数据库迁移已经开始，或升级记录缺失／损坏而无法确认是否迁移过；数据库结构可能已改变，回滚存在不兼容风险。请先备份数据库，确认要继续请再次提交并勾选强制回滚",
-		"error.update_rollback_failed":                   "回滚失败，请查看服务器日志",
-		"error.restart_not_supported":                    "当前进程未被 systemd 托管，重启后无法自动拉起，请手动重启服务",
-		"error.config_fetch_failed":                      "获取配置失败",
-		"error.product_fetch_failed":                     "获取商品失败",
-		"error.product_not_found":                        "商品不存在",
-		"error.product_sku_has_card_secret_stock":        "该 SKU 仍有关联卡密库存，不能直接停用或删除",
-		"error.post_fetch_failed":                        "获取文章失败",
-		"error.post_not_found":                           "文章不存在",
-		"error.banner_fetch_failed":                      "获取 Banner 失败",
-		"error.banner_not_found":                         "Banner 不存在",
-		"error.banner_invalid":                           "Banner 参数不合法",
-		"error.banner_create_failed":                     "创建 Banner 失败",
-		"error.banner_update_failed":                     "更新 Banner 失败",
-		"error.banner_delete_failed":                     "删除 Banner 失败",
-		"error.category_fetch_failed":                    "获取分类失败",
-		"error.category_not_found":                       "分类不存在",
-		"error.admin_login_invalid":                      "用户名或密码错误",
-		"error.password_old_invalid":                     "旧密码错误",
-		"error.save_failed":                              "保存失败",
-		"error.slug_exists":                              "Slug 已存在",
-		"error.slug_used":                                "Slug 已被其他资源使用",
-		"error.product_create_failed":                    "创建商品失败",
-		"error.product_update_failed":                    "更新商品失败",
-		"error.product_delete_failed":                    "删除商品失败",
-		"error.product_has_stock":                        "该商品仍有可用或预占的卡密库存，无法删除",
-		"error.product_has_order_record":                 "该商品已有成交记录，无法删除",
-		"error.post_type_invalid":                        "文章类型不合法",
-		"error.post_category_invalid":                    "当前文章分类不可直接挂载文章，请选择有效的末级分类",
-		"error.post_notice_category_unsupported":         "公告不支持设置文章分类",
-		"error.post_create_failed":                       "创建文章失败",
-		"error.post_update_failed":                       "更新文章失败",
-		"error.post_delete_failed":                       "删除文章失败",
-		"error.category_create_failed":                   "创建分类失败",
-		"error.category_parent_invalid":                  "父分类不合法，仅支持最多两级分类",
-		"error.category_update_failed":                   "更新分类失败",
-		"error.category_delete_failed":                   "删除分类失败",
-		"error.category_in_use":                          "该分类存在子分类或商品，无法删除",
-		"error.post_category_fetch_failed":               "获取文章分类失败",
-		"error.post_category_create_failed":              "创建文章分类失败",
-		"error.post_category_update_failed":              "更新文章分类失败",
-		"error.post_category_delete_failed":              "删除文章分类失败",
-		"error.post_category_not_found":                  "文章分类不存在",
-		"error.post_category_in_use":                     "该分类存在子分类或文章，无法删除",
-		"error.settings_fetch_failed":                    "获取设置失败",
-		"error.settings_save_failed":                     "保存设置失败",
-		"error.file_missing":                             "未上传文件",
-		"error.upload_failed":                            "文件上传失败",
-		"error.order_item_invalid":                       "订单项不合法",
-		"error.order_amount_invalid":                     "订单金额不合法",
-		"error.order_currency_mismatch":                  "订单币种不一致",
-		"error.order_not_found":                          "订单不存在",
-		"error.risk_ip_blacklisted":                      "当前网络已被限制下单，请联系客服",
-		"error.risk_client_ip_unavailable":               "无法识别当前网络，请稍后重试或联系客服",
-		"error.risk_too_many_pending_orders":             "当前网络或账号的待支付订单过多，请先完成支付或取消已有订单",
-		"error.risk_product_quantity_limit":              "本次订单中的商品数量超过当前身份允许的上限",
-		"error.risk_pending_product_quantity_limit":      "当前网络对该商品的待支付占用已达上限，请先完成支付或等待订单取消",
-		"error.risk_order_rate_limited":                  "下单过于频繁，请稍后再试",
-		"error.order_create_failed":                      "创建订单失败",
-		"error.order_fetch_failed":                       "获取订单失败",
-		"error.order_status_invalid":                     "订单状态不合法",
-		"error.order_refund_expired":                     "已超过订单最大可退款时间",
-		"error.order_cancel_not_allowed":                 "当前状态不允许取消订单",
-		"error.order_update_failed":                      "更新订单失败",
-		"error.guest_email_required":                     "游客邮箱不能为空",
-		"error.guest_password_required":                  "订单密码不能为空",
-		"error.guest_order_not_found":                    "未找到匹配的游客订单",
-		"error.guest_coupon_not_allowed":                 "游客订单暂不支持优惠券",
-		"error.product_not_available":                    "商品不可用或已下架",
-		"error.reseller_product_not_listed":              "该商品暂不在当前分销站销售",
-		"error.reseller_price_invalid":                   "分销商品价格配置不合法",
-		"error.reseller_markup_exceeded":                 "分销商品加价超过允许范围",
-		"error.reseller_withdraw_amount_invalid":         "提现金额不合法",
-		"error.reseller_withdraw_currency_unavailable":   "该币种暂不支持提现",
-		"error.reseller_withdraw_insufficient":           "可提现余额不足",
-		"error.reseller_balance_frozen":                  "提现账户已被冻结，暂时无法提现",
-		"error.reseller_settlement_unavailable":          "当前结算状态暂不可提现",
-		"error.reseller_profile_inactive":                "分销商资格未激活，暂时无法提现",
-		"error.reseller_domain_invalid":                  "域名格式无效，请填写正确的域名",
-		"error.reseller_domain_main_host_not_allowed":    "不能使用主站域名作为分销域名",
-		"error.reseller_domain_conflict":                 "该域名已被占用，请更换其他域名",
-		"error.reseller_site_config_invalid":             "站点配置不合法，请检查后重试",
-		"error.reseller_support_telegram_invalid":        "Telegram 链接格式不正确，请使用 https://telegram.me/ 或 https://t.me/ 开头的链接",
-		"error.reseller_support_whatsapp_invalid":        "WhatsApp 链接格式不正确，请使用 https://wa.me/ 开头的链接",
-		"error.reseller_support_email_invalid":           "客服邮箱格式不正确，请检查后重试",
-		"error.reseller_support_url_invalid":             "客服链接格式不正确，请使用 https:// 开头的完整链接",
-		"error.reseller_image_invalid":                   "图片地址无效，请重新上传或填写以 https:// 开头的完整链接",
-		"error.reseller_link_invalid":                    "链接地址格式不正确，请使用 https:// 开头的完整链接",
-		"error.reseller_coupon_not_allowed":              "分销站订单暂不支持主站优惠券",
-		"error.coupon_invalid":                           "优惠券不合法",
-		"error.coupon_not_found":                         "优惠券不存在",
-		"error.coupon_inactive":                          "优惠券未启用",
-		"error.coupon_not_started":                       "优惠券未开始",
-		"error.coupon_expired":                           "优惠券已过期",
-		"error.coupon_usage_limit":                       "优惠券已用完",
-		"error.coupon_per_user_limit":                    "已达到优惠券使用上限",
-		"error.coupon_min_amount":                        "未满足优惠券使用门槛",
-		"error.coupon_scope_invalid":                     "优惠券不适用于该商品",
-		"error.coupon_payment_role_not_allowed":          "当前付款角色不可使用该优惠券",
-		"error.coupon_payment_role_guest_only":           "该优惠券限游客使用",
-		"error.coupon_payment_role_member_only":          "该优惠券限会员使用",
-		"error.coupon_member_level_not_allowed":          "当前会员等级不可使用该优惠券",
-		"error.coupon_wholesale_disabled":                "该优惠券不能参与批发价商品购买",
-		"error.member_level_sort_order_used":             "该排序权重已被其他启用会员等级使用",
-		"error.promotion_invalid":                        "活动价规则不合法",
-		"error.coupon_create_failed":                     "创建优惠券失败",
-		"error.coupon_fetch_failed":                      "获取优惠券失败",
-		"error.coupon_update_failed":                     "更新优惠券失败",
-		"error.coupon_delete_failed":                     "删除优惠券失败",
-		"error.promotion_not_found":                      "活动价不存在",
-		"error.promotion_create_failed":                  "创建活动价失败",
-		"error.promotion_fetch_failed":                   "获取活动价失败",
-		"error.promotion_update_failed":                  "更新活动价失败",
-		"error.promotion_delete_failed":                  "删除活动价失败",
-		"error.fulfillment_invalid":                      "交付信息不合法",
-		"error.fulfillment_exists":                       "交付记录已存在",
-		"error.fulfillment_create_failed":                "创建交付失败",
-		"error.payment_invalid":                          "支付请求不合法",
-		"error.payment_not_found":                        "支付记录不存在",
-		"error.payment_create_failed":                    "创建支付失败",
-		"error.payment_update_failed":                    "更新支付失败",
-		"error.payment_status_invalid":                   "支付状态不合法",
-		"error.payment_amount_mismatch":                  "支付金额不匹配",
-		"error.payment_currency_mismatch":                "支付币种不匹配",
-		"error.payment_channel_not_found":                "支付渠道不存在",
-		"error.payment_channel_inactive":                 "支付渠道已停用",
-		"error.payment_channel_not_allowed_for_product":  "该商品不支持此支付渠道",
-		"error.payment_channel_not_allowed_for_recharge": "钱包充值不支持此支付渠道",
-		"error.wallet_only_payment_required":             "当前仅支持钱包余额支付，请先充值",
-		"error.payment_provider_not_supported":           "支付渠道暂未支持",
-		"error.payment_channel_config_invalid":           "支付渠道配置不完整",
-		"error.payment_gateway_request_failed":           "支付网关请求失败",
-		"error.payment_gateway_response_invalid":         "支付网关响应异常",
-		"error.payment_fetch_failed":                     "获取支付记录失败",
-		"error.payment_export_failed":                    "导出支付记录失败",
-		"error.payment_callback_failed":                  "支付回调处理失败",
-		"error.payment_channel_invalid":                  "支付渠道参数不合法",
-		"error.payment_channel_create_failed":            "创建支付渠道失败",
-		"error.payment_channel_update_failed":            "更新支付渠道失败",
-		"error.payment_channel_delete_failed":            "删除支付渠道失败",
-		"error.payment_channel_fetch_failed":             "获取支付渠道失败",
-		"error.wechatpay_key_test_unsupported":           "该渠道不支持微信支付公钥测试",
-		"error.wechatpay_key_test_config_invalid":        "请先保存完整的微信支付公钥配置，并选择微信支付公钥或兼容验签模式",
-		"error.wechatpay_key_test_request_failed":        "无法连接微信支付安全测试接口，请稍后重试",
-		"error.wechatpay_key_test_response_invalid":      "微信支付未接受请求，或公钥应答验签、回显内容校验失败",
-		"error.wechatpay_key_test_failed":                "微信支付公钥测试失败",
-		"error.card_secret_invalid":                      "卡密参数不合法",
-		"error.card_secret_insufficient":                 "卡密库存不足",
-		"error.manual_stock_insufficient":                "人工库存不足",
-		"error.manual_form_schema_invalid":               "人工交付表单配置不合法",
-		"error.manual_form_required_missing":             "请填写完整的人工交付信息",
-		"error.manual_form_field_invalid":                "人工交付表单字段值不合法",
-		"error.manual_form_type_invalid":                 "人工交付表单字段类型不正确",
-		"error.manual_form_option_invalid":               "人工交付表单选项不合法",
-		"error.manual_stock_invalid":                     "人工库存参数不合法",
-		"error.card_secret_create_failed":                "批量录入卡密失败",
-		"error.card_secret_fetch_failed":                 "获取卡密失败",
-		"error.card_secret_batch_create_failed":          "创建卡密批次失败",
-		"error.card_secret_batch_fetch_failed":           "获取卡密批次失败",
-		"error.card_secret_import_failed":                "导入卡密失败",
-		"error.card_secret_stats_failed":                 "获取卡密统计失败",
-		"error.card_secret_update_failed":                "更新卡密失败",
-		"error.card_secret_delete_failed":                "删除卡密失败",
-		"error.card_secret_not_found":                    "卡密不存在",
-		"error.gift_card_invalid":                        "礼品卡参数不合法",
-		"error.gift_card_not_found":                      "礼品卡不存在",
-		"error.gift_card_expired":                        "礼品卡已过期",
-		"error.gift_card_disabled":                       "礼品卡已禁用",
-		"error.gift_card_redeemed":                       "礼品卡已兑换",
-		"error.gift_card_create_failed":                  "礼品卡生成失败",
-		"error.gift_card_fetch_failed":                   "获取礼品卡失败",
-		"error.gift_card_update_failed":                  "更新礼品卡失败",
-		"error.gift_card_delete_failed":                  "删除礼品卡失败",
-		"error.gift_card_redeem_failed":                  "兑换礼品卡失败",
-		"error.api_credential_fetch_failed":              "获取 API 凭证失败",
-		"error.api_credential_apply_failed":              "申请 API 凭证失败",
-		"error.api_credential_not_found":                 "API 凭证不存在",
-		"error.api_credential_regenerate_failed":         "重新生成 API 凭证失败",
-		"error.api_credential_update_failed":             "更新 API 凭证失败",
-		"error.api_credential_approve_failed":            "审核通过 API 凭证失败",
-		"error.api_credential_reject_failed":             "拒绝 API 凭证失败",
-		"error.api_credential_delete_failed":             "删除 API 凭证失败",
-		"error.api_credential_not_approved":              "API 凭证尚未审核通过",
-		"error.queue_unavailable":                        "队列服务不可用，请稍后重试",
-		"order.status.pending_payment":                   "待支付",
-		"order.status.paid":                              "已支付",
-		"order.status.fulfilling":                        "处理中",
-		"order.status.partially_delivered":               "部分交付",
-		"order.status.partially_refunded":                "部分退款",
-		"order.status.delivered":                         "已交付",
-		"order.status.completed":                         "已完成",
-		"order.status.canceled":                          "已取消",
-		"order.status.refunded":                          "已退款",
-		"email.order_status.subject":                     "订单状态更新：%s",
-		"email.order_status.body":                        "订单号：%s\n状态：%s\n金额：%s %s\n\n感谢您的购买。\n\n%s 的网址：%s",
-		"email.order_status.body_paid":                   "订单号：%s\n状态：%s\n金额：%s %s\n\n我们已收到您的付款，将尽快完成交付。\n\n%s 的网址：%s",
-		"email.order_status.body_refunded":               "订单号：%s\n状态：%s\n退款金额：%s %s\n退款原因：%s\n\n订单已退款，如有疑问请联系管理员。\n\n%s 的网址：%s",
-		"email.order_status.body_partially_refunded":     "订单号：%s\n状态：%s\n退款金额：%s %s\n退款原因：%s\n\n订单已部分退款，如有疑问请联系管理员。\n\n%s 的网址：%s",
-		"email.order_status.body_delivered_simple":       "订单号：%s\n状态：%s\n金额：%s %s\n\n交付已完成，感谢您的购买。\n\n%s 的网址：%s",
-		"email.order_status.body_delivered":              "订单号：%s\n状态：%s\n金额：%s %s\n\n交付内容：\n%s\n\n感谢您的购买。\n\n%s 的网址：%s",
-		"email.order_status.guest_tip":                   "游客订单可使用下单邮箱与订单密码在网站查询订单详情。",
-		"email.order_status.fulfillment_attachment_tip":  "交付内容较多，已作为附件发送，请查看邮件附件获取完整交付内容。",
-
-		// 通用校验规则
-		"validation.rule.required": "不能为空",
-		"validation.rule.min":      "最小值为 %s",
-		"validation.rule.max":      "最大值为 %s",
-		"validation.rule.len":      "长度必须为 %s",
-		"validation.rule.email":    "格式不正确",
-		"validation.rule.url":      "必须是有效的 URL",
-		"validation.rule.oneof":    "必须是以下值之一: %s",
-		"validation.rule.gt":       "必须大于 %s",
-		"validation.rule.gte":      "必须大于或等于 %s",
-		"validation.rule.lt":       "必须小于 %s",
-		"validation.rule.lte":      "必须小于或等于 %s",
-		"validation.rule.numeric":  "必须是数字",
-		"validation.rule.alphanum": "只能包含字母和数字",
-
-		// 商品映射
-		"error.mapping_fetch_failed":             "获取商品映射失败",
-		"error.mapping_not_found":                "商品映射不存在",
-		"error.mapping_already_exists":           "该上游商品已存在映射",
-		"error.mapping_import_failed":            "导入上游商品失败",
-		"error.mapping_sync_failed":              "同步商品映射失败",
-		"error.mapping_update_failed":            "更新商品映射失败",
-		"error.mapping_delete_failed":            "删除商品映射失败",
-		"error.connection_not_found":             "站点连接不存在",
-		"error.upstream_product_not_found":       "上游商品不存在",
-		"error.upstream_products_fetch_failed":   "获取上游商品列表失败",
-		"error.upstream_categories_fetch_failed": "获取上游分类列表失败",
-		"error.category_import_failed":           "按分类导入商品失败",
-		"error.totp_already_enabled":             "已启用两步验证，无需重复绑定",
-		"error.totp_not_enabled":                 "尚未启用两步验证",
-		"error.totp_pending_expired":             "绑定流程已超时，请重新发起",
-		"error.totp_code_invalid":                "动态验证码错误",
-		"error.totp_code_required":               "请提供动态验证码或恢复码",
-		"error.recovery_code_invalid":            "恢复码错误或已使用",
-		"error.totp_too_many_attempts":           "失败次数过多，请稍后重试",
-		"error.totp_challenge_invalid":           "登录会话已失效，请重新输入密码",
-		"error.totp_cannot_reset_self":           "无法通过此入口重置自己的 2FA，请使用 admin-tool CLI",
-		"error.invalid_upstream_status":          "无效的上游状态参数",
-		"error.invalid_product_status":           "无效的商品状态参数",
-	},
-	LocaleTW: {
-		"error.jwt_secret_missing":                       "JWT secret 未配置",
-		"error.auth_header_missing":                      "缺少 Authorization header",
-		"error.auth_header_invalid":                      "Authorization header 格式錯誤",
-		"error.token_invalid":                            "無效的 token",
-		"error.token_revoked":                            "登入狀態已失效，請重新登入",
-		"error.bad_request":                              "請求參數錯誤",
-		"error.request_too_large":                        "請求內容過大",
-		"error.authz_builtin_role_immutable":             "系統內建角色由程式託管，不允許修改權限或刪除",
-		"error.wallet_adjust_remark_required":            "餘額調整必須填寫備註",
-		"error.reseller_subdomain_base_missing":          "分銷系統二級域名基礎域名未配置，請先配置 reseller.subdomain_base",
-		"error.internal_error":                           "伺服器內部錯誤",
-		"error.email_invalid":                            "郵箱格式不正確",
-		"error.verify_purpose_invalid":                   "驗證碼用途無效",
-		"error.email_exists":                             "郵箱已註冊",
-		"error.profile_empty":                            "請至少填寫一項資料",
-		"error.email_change_invalid":                     "更換郵箱參數不合法",
-		"error.email_change_exists":                      "新郵箱已被註冊",
-		"error.user_not_found":                           "用戶不存在",
-		"error.verify_code_too_frequent":                 "發送過於頻繁，請稍後重試",
-		"error.email_service_not_configured":             "郵箱服務未配置",
-		"error.email_recipient_not_found":                "收件郵箱不存在，請檢查後重試",
-		"error.send_verify_code_failed":                  "發送驗證碼失敗",
-		"error.notification_send_failed":                 "通知發送失敗",
-		"error.verify_code_invalid":                      "驗證碼錯誤",
-		"error.verify_code_expired":                      "驗證碼已過期",
-		"error.verify_code_attempts_exceeded":            "驗證碼嘗試次數過多",
-		"error.password_weak":                            "密碼強度不足",
-		"error.password_min_length":                      "密碼長度至少 %d 位",
-		"error.password_require_upper":                   "需包含大寫字母",
-		"error.password_require_lower":                   "需包含小寫字母",
-		"error.password_require_number":                  "需包含數字",
-		"error.password_require_special":                 "需包含特殊字符",
-		"error.register_failed":                          "註冊失敗",
-		"error.registration_disabled":                    "註冊功能已關閉",
-		"error.email_domain_not_allowed":                 "目前信箱後綴不允許註冊",
-		"error.email_verification_disabled":              "郵箱驗證功能已關閉",
-		"error.password_reset_disabled":                  "密碼重置功能已關閉，請聯繫管理員修改密碼",
-		"error.agreement_required":                       "請先同意隱私政策與服務條款",
-		"error.login_too_many":                           "登入嘗試過多，請在 %d 秒後重試",
-		"error.rate_limited":                             "請求過於頻繁，請在 %d 秒後重試",
-		"error.rate_limit_unavailable":                   "限流服務不可用",
-		"error.login_invalid":                            "郵箱或密碼錯誤",
-		"error.email_not_verified":                       "郵箱未驗證",
-		"error.user_disabled":                            "帳號已禁用",
-		"error.login_failed":                             "登入失敗",
-		"error.telegram_auth_disabled":                   "Telegram 登入未啟用",
-		"error.telegram_auth_config_invalid":             "Telegram 登入配置不合法",
-		"error.telegram_auth_payload_invalid":            "Telegram 登入參數不合法",
-		"error.telegram_auth_signature_invalid":          "Telegram 登入簽名校驗失敗",
-		"error.telegram_auth_expired":                    "Telegram 登入已過期，請重試",
-		"error.telegram_auth_replayed":                   "Telegram 登入請求已失效，請重試",
-		"error.telegram_oidc_state_invalid":              "Telegram 登入工作階段已失效，請重試",
-		"error.telegram_oidc_token_exchange_failed":      "Telegram 授權碼換取權杖失敗，請重試",
-		"error.telegram_oidc_id_token_invalid":           "Telegram 身分權杖驗證失敗",
-		"error.telegram_bind_conflict":                   "該 Telegram 帳號已綁定其他用戶",
-		"error.telegram_already_bound":                   "當前帳號已綁定其他 Telegram 帳號",
-		"error.telegram_not_bound":                       "當前帳號未綁定 Telegram",
-		"error.telegram_unbind_requires_email":           "請先設定本機密碼或綁定其他可用登入方式，再解除綁定 Telegram",
-		"error.google_auth_disabled":                     "Google 登入未啟用",
-		"error.google_auth_config_invalid":               "Google 登入配置不合法",
-		"error.google_credential_invalid":                "Google 身分憑證無效，請重試",
-		"error.google_credential_expired":                "Google 身分憑證已過期，請重試",
-		"error.google_email_unverified":                  "Google 信箱尚未驗證",
-		"error.google_service_unavailable":               "Google 登入服務暫時不可用，請稍後重試",
-		"error.google_auto_link_forbidden":               "請先使用原帳號登入，再綁定此 Google 帳號",
-		"error.google_bind_conflict":                     "該 Google 帳號已綁定其他用戶",
-		"error.google_already_bound":                     "目前帳號已綁定其他 Google 帳號",
-		"error.google_not_bound":                         "目前帳號未綁定 Google",
-		"error.google_unbind_locked":                     "請先設定本機密碼或綁定其他可用登入方式，再解除綁定 Google",
-		"error.google_redirect_session_expired":          "Google 登入工作階段已失效，請重試",
-		"error.google_redirect_context_mismatch":         "Google 登入工作階段與目前站點或帳號不符，請重試",
-		"error.captcha_required":                         "請先完成驗證碼",
-		"error.captcha_invalid":                          "驗證碼錯誤或已失效",
-		"error.captcha_verify_failed":                    "驗證碼校驗失敗",
-		"error.captcha_config_invalid":                   "驗證碼配置不合法",
-		"error.captcha_unavailable":                      "驗證碼服務不可用",
-		"error.captcha_generate_failed":                  "驗證碼生成失敗",
-		"error.user_update_failed":                       "更新用戶資料失敗",
-		"error.reset_failed":                             "重置密碼失敗",
-		"error.email_change_failed":                      "更換郵箱失敗",
-		"error.unauthorized":                             "未授權",
-		"error.forbidden":                                "無權限存取",
-		"error.internal_server_error":                    "伺服器內部錯誤",
-		"error.user_id_invalid":                          "無效的用戶ID",
-		"error.user_id_type_invalid":                     "系統錯誤: ID類型異常",
-		"error.admin_id_invalid":                         "無效的管理員ID",
-		"error.admin_id_type_invalid":                    "系統錯誤: 管理員ID類型異常",
-		"error.admin_username_invalid":                   "管理員帳號格式不合法",
-		"error.admin_username_exists":                    "管理員帳號已存在",
-		"error.admin_create_failed":                      "建立管理員失敗",
-		"error.admin_update_failed":                      "更新管理員失敗",
-		"error.admin_delete_failed":                      "刪除管理員失敗",
-		"error.admin_delete_self_forbidden":              "不允許刪除當前登入管理員",
-		"error.admin_delete_last_forbidden":              "至少保留一個管理員帳號",
-		"error.admin_delete_protected":                   "預設超級管理員不允許刪除",
-		"error.product_price_invalid":                    "商品價格或幣種不合法",
-		"error.wholesale_price_invalid":                  "批發價配置不合法",
-		"error.product_purchase_invalid":                 "商品購買身份不合法",
-		"error.product_category_invalid":                 "當前分類不可直接掛載商品，請選擇末級分類",
-		"error.product_purchase_not_allowed":             "當前商品僅限會員購買",
-		"error.product_max_purchase_exceeded":            "超出當前商品單次購買數量上限",
-		"error.product_min_purchase_not_met":             "未達到當前商品單次購買數量下限",
-		"error.product_purchase_limit_invalid":           "單次購買數量下限不能大於上限",
-		"error.user_fetch_failed":                        "獲取用戶信息失敗",
-		"error.user_login_log_fetch_failed":              "獲取登入日誌失敗",
-		"error.dashboard_fetch_failed":                   "獲取儀表板數據失敗",
-		"error.update_check_failed":                      "檢測更新失敗，請稍後再試",
-		"error.update_check_rate_limited":                "GitHub 請求次數已超限，請稍後再試",
-		"error.update_not_supported":                     "當前部署方式不支援一鍵升級",
-		"error.update_in_progress":                       "升級任務正在執行中，請稍後再試",
-		"error.update_already_latest":                    "當前已是最新版本，無需升級",
-		"error.update_failed":                            "升級失敗，請查看伺服器日誌",
-		"error.update_no_backup":                         "沒有可回滾的備份版本",
-		"error.update_rollback_unsafe":                   "資料庫遷移已經開始，或升級記錄缺失／損壞而無法確認是否遷移過；資料庫結構可能已改變，回滾存在不相容風險。請先備份資料庫，確認要繼續請再次提交並勾選強制回滾",
-		"error.update_rollback_failed":                   "回滾失敗，請查看伺服器日誌",
-		"error.restart_not_supported":                    "當前進程未被 systemd 託管，重啟後無法自動拉起，請手動重啟服務",
-		"error.config_fetch_failed":                      "獲取配置失敗",
-		"error.product_fetch_failed":                     "獲取商品失敗",
-		"error.product_not_found":                        "商品不存在",
-		"error.product_sku_has_card_secret_stock":        "該 SKU 仍有關聯卡密庫存，不能直接停用或刪除",
-		"error.post_fetch_failed":                        "獲取文章失敗",
-		"error.post_not_found":                           "文章不存在",
-		"error.banner_fetch_failed":                      "獲取 Banner 失敗",
-		"error.banner_not_found":                         "Banner 不存在",
-		"error.banner_invalid":                           "Banner 參數不合法",
-		"error.banner_create_failed":                     "建立 Banner 失敗",
-		"error.banner_update_failed":                     "更新 Banner 失敗",
-		"error.banner_delete_failed":                     "刪除 Banner 失敗",
-		"error.category_fetch_failed":                    "獲取分類失敗",
-		"error.category_not_found":                       "分類不存在",
-		"error.admin_login_invalid":                      "用戶名或密碼錯誤",
-		"error.password_old_invalid":                     "舊密碼錯誤",
-		"error.save_failed":                              "保存失敗",
-		"error.slug_exists":                              "Slug 已存在",
-		"error.slug_used":                                "Slug 已被其他資源使用",
-		"error.product_create_failed":                    "建立商品失敗",
-		"error.product_update_failed":                    "更新商品失敗",
-		"error.product_delete_failed":                    "刪除商品失敗",
-		"error.product_has_stock":                        "該商品仍有可用或預佔的卡密庫存，無法刪除",
-		"error.product_has_order_record":                 "該商品已有成交記錄，無法刪除",
-		"error.post_type_invalid":                        "文章類型不合法",
-		"error.post_category_invalid":                    "當前文章分類不可直接掛載文章，請選擇有效的末級分類",
-		"error.post_notice_category_unsupported":         "公告不支援設定文章分類",
-		"error.post_create_failed":                       "建立文章失敗",
-		"error.post_update_failed":                       "更新文章失敗",
-		"error.post_delete_failed":                       "刪除文章失敗",
-		"error.category_create_failed":                   "建立分類失敗",
-		"error.category_parent_invalid":                  "父分類不合法，僅支援最多兩級分類",
-		"error.category_update_failed":                   "更新分類失敗",
-		"error.category_delete_failed":                   "刪除分類失敗",
-		"error.category_in_use":                          "該分類存在子分類或商品，無法刪除",
-		"error.post_category_fetch_failed":               "獲取文章分類失敗",
-		"error.post_category_create_failed":              "建立文章分類失敗",
-		"error.post_category_update_failed":              "更新文章分類失敗",
-		"error.post_category_delete_failed":              "刪除文章分類失敗",
-		"error.post_category_not_found":                  "文章分類不存在",
-		"error.post_category_in_use":                     "該分類存在子分類或文章，無法刪除",
-		"error.settings_fetch_failed":                    "獲取設定失敗",
-		"error.settings_save_failed":                     "保存設定失敗",
-		"error.file_missing":                             "未上傳文件",
-		"error.upload_failed":                            "文件上傳失敗",
-		"error.order_item_invalid":                       "訂單項不合法",
-		"error.order_amount_invalid":                     "訂單金額不合法",
-		"error.order_currency_mismatch":                  "訂單幣種不一致",
-		"error.order_not_found":                          "訂單不存在",
-		"error.risk_ip_blacklisted":                      "當前網絡已被限制下單，請聯繫客服",
-		"error.risk_client_ip_unavailable":               "無法識別當前網絡，請稍後重試或聯繫客服",
-		"error.risk_too_many_pending_orders":             "當前網絡或賬號的待支付訂單過多，請先完成支付或取消已有訂單",
-		"error.risk_product_quantity_limit":              "本次訂單中的商品數量超過當前身份允許的上限",
-		"error.risk_pending_product_quantity_limit":      "當前網絡對該商品的待支付佔用已達上限，請先完成支付或等待訂單取消",
-		"error.risk_order_rate_limited":                  "下單過於頻繁，請稍後再試",
-		"error.order_create_failed":                      "建立訂單失敗",
-		"error.order_fetch_failed":                       "獲取訂單失敗",
-		"error.order_status_invalid":                     "訂單狀態不合法",
-		"error.order_refund_expired":                     "已超過訂單最大可退款時間",
-		"error.order_cancel_not_allowed":                 "當前狀態不允許取消訂單",
-		"error.order_update_failed":                      "更新訂單失敗",
-		"error.guest_email_required":                     "遊客郵箱不能為空",
-		"error.guest_password_required":                  "訂單密碼不能為空",
-		"error.guest_order_not_found":                    "未找到匹配的遊客訂單",
-		"error.guest_coupon_not_allowed":                 "遊客訂單暫不支持優惠券",
-		"error.product_not_available":                    "商品不可用或已下架",
-		"error.reseller_product_not_listed":              "該商品暫不在當前分銷站銷售",
-		"error.reseller_price_invalid":                   "分銷商品價格配置不合法",
-		"error.reseller_markup_exceeded":                 "分銷商品加價超過允許範圍",
-		"error.reseller_withdraw_amount_invalid":         "提現金額不合法",
-		"error.reseller_withdraw_currency_unavailable":   "該幣種暫不支援提現",
-		"error.reseller_withdraw_insufficient":           "可提現餘額不足",
-		"error.reseller_balance_frozen":                  "提現帳戶已被凍結，暫時無法提現",
-		"error.reseller_settlement_unavailable":          "目前結算狀態暫不可提現",
-		"error.reseller_profile_inactive":                "分銷商資格未啟用，暫時無法提現",
-		"error.reseller_domain_invalid":                  "網域格式無效，請填寫正確的網域",
-		"error.reseller_domain_main_host_not_allowed":    "不能使用主站網域作為分銷網域",
-		"error.reseller_domain_conflict":                 "該網域已被佔用，請更換其他網域",
-		"error.reseller_site_config_invalid":             "站點配置不合法，請檢查後重試",
-		"error.reseller_support_telegram_invalid":        "Telegram 連結格式不正確，請使用 https://telegram.me/ 或 https://t.me/ 開頭的連結",
-		"error.reseller_support_whatsapp_invalid":        "WhatsApp 連結格式不正確，請使用 https://wa.me/ 開頭的連結",
-		"error.reseller_support_email_invalid":           "客服信箱格式不正確，請檢查後重試",
-		"error.reseller_support_url_invalid":             "客服連結格式不正確，請使用 https:// 開頭的完整連結",
-		"error.reseller_image_invalid":                   "圖片地址無效，請重新上傳或填寫以 https:// 開頭的完整連結",
-		"error.reseller_link_invalid":                    "連結地址格式不正確，請使用 https:// 開頭的完整連結",
-		"error.reseller_coupon_not_allowed":              "分銷站訂單暫不支持主站優惠券",
-		"error.coupon_invalid":                           "優惠券不合法",
-		"error.coupon_not_found":                         "優惠券不存在",
-		"error.coupon_inactive":                          "優惠券未啟用",
-		"error.coupon_not_started":                       "優惠券未開始",
-		"error.coupon_expired":                           "優惠券已過期",
-		"error.coupon_usage_limit":                       "優惠券已用完",
-		"error.coupon_per_user_limit":                    "已達到優惠券使用上限",
-		"error.coupon_min_amount":                        "未滿足優惠券使用門檻",
-		"error.coupon_scope_invalid":                     "優惠券不適用於該商品",
-		"error.coupon_payment_role_not_allowed":          "當前付款角色不可使用該優惠券",
-		"error.coupon_payment_role_guest_only":           "該優惠券限游客使用",
-		"error.coupon_payment_role_member_only":          "該優惠券限會員使用",
-		"error.coupon_member_level_not_allowed":          "當前會員等級不可使用該優惠券",
-		"error.coupon_wholesale_disabled":                "該優惠券不能參與批發價商品購買",
-		"error.member_level_sort_order_used":             "該排序權重已被其他啟用會員等級使用",
-		"error.promotion_invalid":                        "活動價規則不合法",
-		"error.coupon_create_failed":                     "建立優惠券失敗",
-		"error.coupon_fetch_failed":                      "獲取優惠券失敗",
-		"error.coupon_update_failed":                     "更新優惠券失敗",
-		"error.coupon_delete_failed":                     "刪除優惠券失敗",
-		"error.promotion_not_found":                      "活動價不存在",
-		"error.promotion_create_failed":                  "建立活動價失敗",
-		"error.promotion_fetch_failed":                   "獲取活動價失敗",
-		"error.promotion_update_failed":                  "更新活動價失敗",
-		"error.promotion_delete_failed":                  "刪除活動價失敗",
-		"error.fulfillment_invalid":                      "交付資訊不合法",
-		"error.fulfillment_exists":                       "交付記錄已存在",
-		"error.fulfillment_create_failed":                "建立交付失敗",
-		"error.payment_invalid":                          "支付請求不合法",
-		"error.payment_not_found":                        "支付記錄不存在",
-		"error.payment_create_failed":                    "建立支付失敗",
-		"error.payment_update_failed":                    "更新支付失敗",
-		"error.payment_status_invalid":                   "支付狀態不合法",
-		"error.payment_amount_mismatch":                  "支付金額不匹配",
-		"error.payment_currency_mismatch":                "支付幣種不匹配",
-		"error.payment_channel_not_found":                "支付渠道不存在",
-		"error.payment_channel_inactive":                 "支付渠道已停用",
-		"error.payment_channel_not_allowed_for_product":  "該商品不支援此支付渠道",
-		"error.payment_channel_not_allowed_for_recharge": "錢包儲值不支援此支付渠道",
-		"error.wallet_only_payment_required":             "目前僅支援錢包餘額支付，請先儲值",
-		"error.payment_provider_not_supported":           "支付渠道暫未支援",
-		"error.payment_channel_config_invalid":           "支付渠道設定不完整",
-		"error.payment_gateway_request_failed":           "支付網關請求失敗",
-		"error.payment_gateway_response_invalid":         "支付網關回應異常",
-		"error.payment_fetch_failed":                     "獲取支付記錄失敗",
-		"error.payment_export_failed":                    "導出支付記錄失敗",
-		"error.payment_callback_failed":                  "支付回調處理失敗",
-		"error.payment_channel_invalid":                  "支付渠道參數不合法",
-		"error.payment_channel_create_failed":            "建立支付渠道失敗",
-		"error.payment_channel_update_failed":            "更新支付渠道失敗",
-		"error.payment_channel_delete_failed":            "刪除支付渠道失敗",
-		"error.payment_channel_fetch_failed":             "獲取支付渠道失敗",
-		"error.wechatpay_key_test_unsupported":           "該渠道不支援微信支付公鑰測試",
-		"error.wechatpay_key_test_config_invalid":        "請先儲存完整的微信支付公鑰設定，並選擇微信支付公鑰或相容驗簽模式",
-		"error.wechatpay_key_test_request_failed":        "無法連線微信支付安全測試介面，請稍後重試",
-		"error.wechatpay_key_test_response_invalid":      "微信支付未接受請求，或公鑰回應驗簽、回顯內容校驗失敗",
-		"error.wechatpay_key_test_failed":                "微信支付公鑰測試失敗",
-		"error.card_secret_invalid":                      "卡密參數不合法",
-		"error.card_secret_insufficient":                 "卡密庫存不足",
-		"error.manual_stock_insufficient":                "人工庫存不足",
-		"error.manual_form_schema_invalid":               "人工交付表單配置不合法",
-		"error.manual_form_required_missing":             "請填寫完整的人工交付資訊",
-		"error.manual_form_field_invalid":                "人工交付表單欄位值不合法",
-		"error.manual_form_type_invalid":                 "人工交付表單欄位類型不正確",
-		"error.manual_form_option_invalid":               "人工交付表單選項不合法",
-		"error.manual_stock_invalid":                     "人工庫存參數不合法",
-		"error.card_secret_create_failed":                "批量錄入卡密失敗",
-		"error.card_secret_fetch_failed":                 "獲取卡密失敗",
-		"error.card_secret_batch_create_failed":          "建立卡密批次失敗",
-		"error.card_secret_batch_fetch_failed":           "獲取卡密批次失敗",
-		"error.card_secret_import_failed":                "導入卡密失敗",
-		"error.card_secret_stats_failed":                 "獲取卡密統計失敗",
-		"error.card_secret_update_failed":                "更新卡密失敗",
-		"error.card_secret_delete_failed":                "刪除卡密失敗",
-		"error.card_secret_not_found":                    "卡密不存在",
-		"error.gift_card_invalid":                        "禮品卡參數不合法",
-		"error.gift_card_not_found":                      "禮品卡不存在",
-		"error.gift_card_expired":                        "禮品卡已過期",
-		"error.gift_card_disabled":                       "禮品卡已禁用",
-		"error.gift_card_redeemed":                       "禮品卡已兌換",
-		"error.gift_card_create_failed":                  "禮品卡生成失敗",
-		"error.gift_card_fetch_failed":                   "獲取禮品卡失敗",
-		"error.gift_card_update_failed":                  "更新禮品卡失敗",
-		"error.gift_card_delete_failed":                  "刪除禮品卡失敗",
-		"error.gift_card_redeem_failed":                  "兌換禮品卡失敗",
-		"error.api_credential_fetch_failed":              "獲取 API 憑證失敗",
-		"error.api_credential_apply_failed":              "申請 API 憑證失敗",
-		"error.api_credential_not_found":                 "API 憑證不存在",
-		"error.api_credential_regenerate_failed":         "重新生成 API 憑證失敗",
-		"error.api_credential_update_failed":             "更新 API 憑證失敗",
-		"error.api_credential_approve_failed":            "審核通過 API 憑證失敗",
-		"error.api_credential_reject_failed":             "拒絕 API 憑證失敗",
-		"error.api_credential_delete_failed":             "刪除 API 憑證失敗",
-		"error.api_credential_not_approved":              "API 憑證尚未審核通過",
-		"error.queue_unavailable":                        "隊列服務不可用，請稍後重試",
-		"order.status.pending_payment":                   "待支付",
-		"order.status.paid":                              "已支付",
-		"order.status.fulfilling":                        "處理中",
-		"order.status.partially_delivered":               "部分交付",
-		"order.status.partially_refunded":                "部分退款",
-		"order.status.delivered":                         "已交付",
-		"order.status.completed":                         "已完成",
-		"order.status.canceled":                          "已取消",
-		"order.status.refunded":                          "已退款",
-		"email.order_status.subject":                     "訂單狀態更新：%s",
-		"email.order_status.body":                        "訂單號：%s\n狀態：%s\n金額：%s %s\n\n感謝您的購買。\n\n%s 的網址：%s",
-		"email.order_status.body_paid":                   "訂單號：%s\n狀態：%s\n金額：%s %s\n\n已收到付款，將盡快完成交付。\n\n%s 的網址：%s",
-		"email.order_status.body_refunded":               "訂單號：%s\n狀態：%s\n退款金額：%s %s\n退款原因：%s\n\n訂單已退款，如有疑問請聯絡管理員。\n\n%s 的網址：%s",
-		"email.order_status.body_partially_refunded":     "訂單號：%s\n狀態：%s\n退款金額：%s %s\n退款原因：%s\n\n訂單已部分退款，如有疑問請聯絡管理員。\n\n%s 的網址：%s",
-		"email.order_status.body_delivered_simple":       "訂單號：%s\n狀態：%s\n金額：%s %s\n\n交付已完成，感謝您的購買。\n\n%s 的網址：%s",
-		"email.order_status.body_delivered":              "訂單號：%s\n狀態：%s\n金額：%s %s\n\n交付內容：\n%s\n\n感謝您的購買。\n\n%s 的網址：%s",
-		"email.order_status.guest_tip":                   "遊客訂單可使用下單信箱與訂單密碼在網站查詢訂單詳情。",
-		"email.order_status.fulfillment_attachment_tip":  "交付內容較多，已作為附件發送，請查看郵件附件獲取完整交付內容。",
-
-		// 通用校驗規則
-		"validation.rule.required": "不能為空",
-		"validation.rule.min":      "最小值為 %s",
-		"validation.rule.max":      "最大值為 %s",
-		"validation.rule.len":      "長度必須為 %s",
-		"validation.rule.email":    "格式不正確",
-		"validation.rule.url":      "必須是有效的 URL",
-		"validation.rule.oneof":    "必須是以下值之一: %s",
-		"validation.rule.gt":       "必須大於 %s",
-		"validation.rule.gte":      "必須大於或等於 %s",
-		"validation.rule.lt":       "必須小於 %s",
-		"validation.rule.lte":      "必須小於或等於 %s",
-		"validation.rule.numeric":  "必須是數字",
-		"validation.rule.alphanum": "只能包含字母和數字",
-
-		// 商品映射
-		"error.mapping_fetch_failed":             "獲取商品映射失敗",
-		"error.mapping_not_found":                "商品映射不存在",
-		"error.mapping_already_exists":           "該上游商品已存在映射",
-		"error.mapping_import_failed":            "導入上游商品失敗",
-		"error.mapping_sync_failed":              "同步商品映射失敗",
-		"error.mapping_update_failed":            "更新商品映射失敗",
-		"error.mapping_delete_failed":            "刪除商品映射失敗",
-		"error.connection_not_found":             "站點連接不存在",
-		"error.upstream_product_not_found":       "上游商品不存在",
-		"error.upstream_products_fetch_failed":   "獲取上游商品列表失敗",
-		"error.upstream_categories_fetch_failed": "獲取上游分類列表失敗",
-		"error.category_import_failed":           "按分類匯入商品失敗",
-		"error.totp_already_enabled":             "已啟用兩步驗證，無需重複綁定",
-		"error.totp_not_enabled":                 "尚未啟用兩步驗證",
-		"error.totp_pending_expired":             "綁定流程已逾時，請重新發起",
-		"error.totp_code_invalid":                "動態驗證碼錯誤",
-		"error.totp_code_required":               "請提供動態驗證碼或恢復碼",
-		"error.recovery_code_invalid":            "恢復碼錯誤或已使用",
-		"error.totp_too_many_attempts":           "失敗次數過多，請稍後重試",
-		"error.totp_challenge_invalid":           "登入工作階段已失效，請重新輸入密碼",
-		"error.totp_cannot_reset_self":           "無法透過此入口重設自己的 2FA，請使用 admin-tool CLI",
-		"error.invalid_upstream_status":          "無效的上游狀態參數",
-		"error.invalid_product_status":           "無效的商品狀態參數",
-	},
-	LocaleEN: {
-		"error.jwt_secret_missing":                       "JWT secret is not configured",
-		"error.auth_header_missing":                      "Missing Authorization header",
-		"error.auth_header_invalid":                      "Invalid Authorization header format",
-		"error.token_invalid":                            "Invalid token",
-		"error.token_revoked":                            "Session expired, please sign in again",
-		"error.bad_request":                              "Invalid request parameters",
-		"error.request_too_large":                        "Request body is too large",
-		"error.authz_builtin_role_immutable":             "Built-in roles are managed by the system and cannot be modified or deleted",
-		"error.wallet_adjust_remark_required":            "A remark is required for wallet balance adjustments",
-		"error.reseller_subdomain_base_missing":          "Reseller subdomain base is not configured. Configure reseller.subdomain_base first",
-		"error.internal_error":                           "Internal server error",
-		"error.email_invalid":                            "Invalid email format",
-		"error.verify_purpose_invalid":                   "Invalid verification purpose",
-		"error.email_exists":                             "Email already registered",
-		"error.profile_empty":                            "Please provide at least one profile field",
-		"error.email_change_invalid":                     "Invalid email change request",
-		"error.email_change_exists":                      "New email is already registered",
-		"error.user_not_found":                           "User not found",
-		"error.verify_code_too_frequent":                 "Too many requests, please try again later",
-		"error.email_service_not_configured":             "Email service is not configured",
-		"error.email_recipient_not_found":                "Recipient email address does not exist",
-		"error.send_verify_code_failed":                  "Failed to send verification code",
-		"error.notification_send_failed":                 "Failed to send notification",
-		"error.verify_code_invalid":                      "Invalid verification code",
-		"error.verify_code_expired":                      "Verification code expired",
-		"error.verify_code_attempts_exceeded":            "Too many verification attempts",
-		"error.password_weak":                            "Password is too weak",
-		"error.password_min_length":                      "Password must be at least %d characters",
-		"error.password_require_upper":                   "Password must include an uppercase letter",
-		"error.password_require_lower":                   "Password must include a lowercase letter",
-		"error.password_require_number":                  "Password must include a number",
-		"error.password_require_special":                 "Password must include a special character",
-		"error.register_failed":                          "Registration failed",
-		"error.registration_disabled":                    "Registration is disabled",
-		"error.email_domain_not_allowed":                 "This email domain is not allowed for registration",
-		"error.email_verification_disabled":              "Email verification is disabled",
-		"error.password_reset_disabled":                  "Password reset is disabled, please contact the administrator",
-		"error.agreement_required":                       "Please agree to the Privacy Policy and Terms of Service first",
-		"error.login_too_many":                           "Too many login attempts, retry in %d seconds",
-		"error.rate_limited":                             "Too many requests, retry in %d seconds",
-		"error.rate_limit_unavailable":                   "Rate limit service unavailable",
-		"error.login_invalid":                            "Invalid email or password",
-		"error.email_not_verified":                       "Email not verified",
-		"error.user_disabled":                            "Account disabled",
-		"error.login_failed":                             "Login failed",
-		"error.telegram_auth_disabled":                   "Telegram login is disabled",
-		"error.telegram_auth_config_invalid":             "Telegram login configuration is invalid",
-		"error.telegram_auth_payload_invalid":            "Telegram login payload is invalid",
-		"error.telegram_auth_signature_invalid":          "Telegram login signature verification failed",
-		"error.telegram_auth_expired":                    "Telegram login has expired, please try again",
-		"error.telegram_auth_replayed":                   "Telegram login request has already been used",
-		"error.telegram_oidc_state_invalid":              "Telegram login session expired, please try again.",
-		"error.telegram_oidc_token_exchange_failed":      "Failed to exchange Telegram authorization code.",
-		"error.telegram_oidc_id_token_invalid":           "Telegram ID token verification failed.",
-		"error.telegram_bind_conflict":                   "This Telegram account is already bound to another user",
-		"error.telegram_already_bound":                   "Current account is already bound to another Telegram account",
-		"error.telegram_not_bound":                       "Current account is not bound to Telegram",
-		"error.telegram_unbind_requires_email":           "Set a local password or bind another usable login method before unbinding Telegram",
-		"error.google_auth_disabled":                     "Google login is disabled",
-		"error.google_auth_config_invalid":               "Google login configuration is invalid",
-		"error.google_credential_invalid":                "Google credential is invalid, please try again",
-		"error.google_credential_expired":                "Google credential has expired, please try again",
-		"error.google_email_unverified":                  "Google email is not verified",
-		"error.google_service_unavailable":               "Google login is temporarily unavailable, please try again later",
-		"error.google_auto_link_forbidden":               "Sign in to the existing account first, then bind this Google account",
-		"error.google_bind_conflict":                     "This Google account is already bound to another user",
-		"error.google_already_bound":                     "Current account is already bound to another Google account",
-		"error.google_not_bound":                         "Current account is not bound to Google",
-		"error.google_unbind_locked":                     "Set a local password or bind another usable login method before unbinding Google",
-		"error.google_redirect_session_expired":          "Google sign-in session has expired, please try again",
-		"error.google_redirect_context_mismatch":         "Google sign-in session does not match the current site or account, please try again",
-		"error.captcha_required":                         "Please complete captcha verification",
-		"error.captcha_invalid":                          "Captcha is invalid or expired",
-		"error.captcha_verify_failed":                    "Failed to verify captcha",
-		"error.captcha_config_invalid":                   "Captcha configuration is invalid",
-		"error.captcha_unavailable":                      "Captcha service is unavailable",
-		"error.captcha_generate_failed":                  "Failed to generate captcha",
-		"error.user_update_failed":                       "Failed to update user profile",
-		"error.reset_failed":                             "Password reset failed",
-		"error.email_change_failed":                      "Failed to change email",
-		"error.unauthorized":                             "Unauthorized",
-		"error.forbidden":                                "Access denied",
-		"error.internal_server_error":                    "Internal server error",
-		"error.user_id_invalid":                          "Invalid user ID",
-		"error.user_id_type_invalid":                     "System error: invalid ID type",
-		"error.admin_id_invalid":                         "Invalid admin ID",
-		"error.admin_id_type_invalid":                    "System error: invalid admin ID type",
-		"error.admin_username_invalid":                   "Invalid admin username format",
-		"error.admin_username_exists":                    "Admin username already exists",
-		"error.admin_create_failed":                      "Failed to create admin",
-		"error.admin_update_failed":                      "Failed to update admin",
-		"error.admin_delete_failed":                      "Failed to delete admin",
-		"error.admin_delete_self_forbidden":              "Cannot delete current logged-in admin",
-		"error.admin_delete_last_forbidden":              "At least one admin must be kept",
-		"error.admin_delete_protected":                   "Default super admin cannot be deleted",
-		"error.product_price_invalid":                    "Invalid product price or currency",
-		"error.wholesale_price_invalid":                  "Invalid wholesale price configuration",
-		"error.product_purchase_invalid":                 "Invalid product purchase type",
-		"error.product_category_invalid":                 "This category cannot be assigned products directly; choose a leaf category",
-		"error.product_purchase_not_allowed":             "This product requires member purchase",
-		"error.product_max_purchase_exceeded":            "Purchase quantity exceeds the per-order limit for this product",
-		"error.product_min_purchase_not_met":             "Purchase quantity is below the per-order minimum for this product",
-		"error.product_purchase_limit_invalid":           "Minimum purchase quantity must not exceed the maximum",
-		"error.user_fetch_failed":                        "Failed to fetch user",
-		"error.user_login_log_fetch_failed":              "Failed to fetch login logs",
-		"error.dashboard_fetch_failed":                   "Failed to fetch dashboard data",
-		"error.update_check_failed":                      "Failed to check for updates, please try again later",
-		"error.update_check_rate_limited":                "GitHub API rate limit exceeded, please try again later",
-		"error.update_not_supported":                     "One-click upgrade is not supported for the current deployment",
-		"error.update_in_progress":                       "An upgrade is already running, please try again later",
-		"error.update_already_latest":                    "Already on the latest version, no upgrade needed",
-		"error.update_failed":                            "Upgrade failed, please check the server logs",
-		"error.update_no_backup":                         "No backup version available to roll back to",
-		"error.update_rollback_unsafe":                   "Database migration has already started, or the upgrade record is missing/corrupted so migration cannot be ruled out; the schema may have changed and rolling back risks incompatibility. Back up your database first, then resubmit with force enabled to continue",
-		"error.update_rollback_failed":                   "Rollback failed, please check the server logs",
-		"error.restart_not_supported":                    "This process is not managed by systemd and would not be restarted automatically; please restart the service manually",
-		"error.config_fetch_failed":                      "Failed to fetch configuration",
-		"error.product_fetch_failed":                     "Failed to fetch products",
-		"error.product_not_found":                        "Product not found",
-		"error.product_sku_has_card_secret_stock":        "This SKU still has linked card secret stock and cannot be disabled or removed directly",
-		"error.post_fetch_failed":                        "Failed to fetch posts",
-		"error.post_not_found":                           "Post not found",
-		"error.banner_fetch_failed":                      "Failed to fetch banners",
-		"error.banner_not_found":                         "Banner not found",
-		"error.banner_invalid":                           "Invalid banner payload",
-		"error.banner_create_failed":                     "Failed to create banner",
-		"error.banner_update_failed":                     "Failed to update banner",
-		"error.banner_delete_failed":                     "Failed to delete banner",
-		"error.category_fetch_failed":                    "Failed to fetch categories",
-		"error.category_not_found":                       "Category not found",
-		"error.admin_login_invalid":                      "Invalid username or password",
-		"error.password_old_invalid":                     "Incorrect old password",
-		"error.save_failed":                              "Save failed",
-		"error.slug_exists":                              "Slug already exists",
-		"error.slug_used":                                "Slug is already used by another resource",
-		"error.product_create_failed":                    "Failed to create product",
-		"error.product_update_failed":                    "Failed to update product",
-		"error.product_delete_failed":                    "Failed to delete product",
-		"error.product_has_stock":                        "This product still has available or reserved card secret stock and cannot be deleted",
-		"error.product_has_order_record":                 "This product has order records and cannot be deleted",
-		"error.post_type_invalid":                        "Invalid post type",
-		"error.post_category_invalid":                    "This post category cannot be assigned directly; choose a valid leaf category",
-		"error.post_notice_category_unsupported":         "Notice posts do not support categories",
-		"error.post_create_failed":                       "Failed to create post",
-		"error.post_update_failed":                       "Failed to update post",
-		"error.post_delete_failed":                       "Failed to delete post",
-		"error.category_create_failed":                   "Failed to create category",
-		"error.category_parent_invalid":                  "Invalid parent category, only two levels are supported",
-		"error.category_update_failed":                   "Failed to update category",
-		"error.category_delete_failed":                   "Failed to delete category",
-		"error.category_in_use":                          "Category has child categories or products and cannot be deleted",
-		"error.post_category_fetch_failed":               "Failed to fetch post categories",
-		"error.post_category_create_failed":              "Failed to create post category",
-		"error.post_category_update_failed":              "Failed to update post category",
-		"error.post_category_delete_failed":              "Failed to delete post category",
-		"error.post_category_not_found":                  "Post category not found",
-		"error.post_category_in_use":                     "Category has child categories or posts and cannot be deleted",
-		"error.settings_fetch_failed":                    "Failed to fetch settings",
-		"error.settings_save_failed":                     "Failed to save settings",
-		"error.file_missing":                             "No file uploaded",
-		"error.upload_failed":                            "File upload failed",
-		"error.order_item_invalid":                       "Invalid order item",
-		"error.order_amount_invalid":                     "Invalid order amount",
-		"error.order_currency_mismatch":                  "Order currency mismatch",
-		"error.order_not_found":                          "Order not found",
-		"error.risk_ip_blacklisted":                      "Orders from the current network have been restricted. Please contact support",
-		"error.risk_client_ip_unavailable":               "Unable to identify the current network. Please try again later or contact support",
-		"error.risk_too_many_pending_orders":             "The current network or account has too many pending orders. Please pay or cancel an existing order first",
-		"error.risk_product_quantity_limit":              "A product quantity in this order exceeds the limit for the current buyer type",
-		"error.risk_pending_product_quantity_limit":      "This network has reached the pending inventory limit for that product. Please pay or wait for an order to be canceled",
-		"error.risk_order_rate_limited":                  "Ordering too frequently, please try again later",
-		"error.order_create_failed":                      "Failed to create order",
-		"error.order_fetch_failed":                       "Failed to fetch order",
-		"error.order_status_invalid":                     "Invalid order status",
-		"error.order_refund_expired":                     "Order exceeded the maximum refundable period",
-		"error.order_cancel_not_allowed":                 "Order cannot be canceled in current status",
-		"error.order_update_failed":                      "Failed to update order",
-		"error.guest_email_required":                     "Guest email is required",
-		"error.guest_password_required":                  "Order password is required",
-		"error.guest_order_not_found":                    "Guest order not found",
-		"error.guest_coupon_not_allowed":                 "Guest orders do not support coupons yet",
-		"error.product_not_available":                    "Product is not available",
-		"error.reseller_product_not_listed":              "This product is not available on the current reseller site",
-		"error.reseller_price_invalid":                   "Invalid reseller product price configuration",
-		"error.reseller_markup_exceeded":                 "Reseller markup exceeds the allowed range",
-		"error.reseller_withdraw_amount_invalid":         "Invalid withdrawal amount",
-		"error.reseller_withdraw_currency_unavailable":   "This currency is not available for withdrawal",
-		"error.reseller_withdraw_insufficient":           "Insufficient withdrawable balance",
-		"error.reseller_balance_frozen":                  "Withdrawal account is frozen and temporarily unavailable",
-		"error.reseller_settlement_unavailable":          "Withdrawals are unavailable for your current settlement status",
-		"error.reseller_profile_inactive":                "Reseller account is not active; withdrawals are unavailable",
-		"error.reseller_domain_invalid":                  "Invalid domain format; please enter a valid domain",
-		"error.reseller_domain_main_host_not_allowed":    "The main site domain cannot be used as a reseller domain",
-		"error.reseller_domain_conflict":                 "This domain is already taken; please use another one",
-		"error.reseller_site_config_invalid":             "Invalid site configuration; please review and try again",
-		"error.reseller_support_telegram_invalid":        "Invalid Telegram link; it must start with https://telegram.me/ or https://t.me/",
-		"error.reseller_support_whatsapp_invalid":        "Invalid WhatsApp link; it must start with https://wa.me/",
-		"error.reseller_support_email_invalid":           "Invalid support email address, please check and try again",
-		"error.reseller_support_url_invalid":             "Invalid support URL; please use a full link starting with https://",
-		"error.reseller_image_invalid":                   "Invalid image address; re-upload or use a full link starting with https://",
-		"error.reseller_link_invalid":                    "Invalid link address; please use a full link starting with https://",
-		"error.reseller_coupon_not_allowed":              "Main-site coupons are not available on reseller orders",
-		"error.coupon_invalid":                           "Invalid coupon",
-		"error.coupon_not_found":                         "Coupon not found",
-		"error.coupon_inactive":                          "Coupon is inactive",
-		"error.coupon_not_started":                       "Coupon is not started",
-		"error.coupon_expired":                           "Coupon expired",
-		"error.coupon_usage_limit":                       "Coupon usage limit reached",
-		"error.coupon_per_user_limit":                    "Coupon usage limit per user reached",
-		"error.coupon_min_amount":                        "Coupon minimum amount not met",
-		"error.coupon_scope_invalid":                     "Coupon is not applicable to this product",
-		"error.coupon_payment_role_not_allowed":          "This coupon is not available for your payment role",
-		"error.coupon_payment_role_guest_only":           "This coupon is only for guest users",
-		"error.coupon_payment_role_member_only":          "This coupon is only for member users",
-		"error.coupon_member_level_not_allowed":          "This coupon is not available for your member level",
-		"error.coupon_wholesale_disabled":                "This coupon cannot be used for products with wholesale pricing",
-		"error.member_level_sort_order_used":             "This sort order is already used by another active member level",
-		"error.promotion_invalid":                        "Invalid promotion rule",
-		"error.coupon_create_failed":                     "Failed to create coupon",
-		"error.coupon_fetch_failed":                      "Failed to fetch coupons",
-		"error.coupon_update_failed":                     "Failed to update coupon",
-		"error.coupon_delete_failed":                     "Failed to delete coupon",
-		"error.promotion_not_found":                      "Promotion not found",
-		"error.promotion_create_failed":                  "Failed to create promotion",
-		"error.promotion_fetch_failed":                   "Failed to fetch promotions",
-		"error.promotion_update_failed":                  "Failed to update promotion",
-		"error.promotion_delete_failed":                  "Failed to delete promotion",
-		"error.fulfillment_invalid":                      "Invalid fulfillment data",
-		"error.fulfillment_exists":                       "Fulfillment already exists",
-		"error.fulfillment_create_failed":                "Failed to create fulfillment",
-		"error.payment_invalid":                          "Invalid payment request",
-		"error.payment_not_found":                        "Payment not found",
-		"error.payment_create_failed":                    "Failed to create payment",
-		"error.payment_update_failed":                    "Failed to update payment",
-		"error.payment_status_invalid":                   "Invalid payment status",
-		"error.payment_amount_mismatch":                  "Payment amount mismatch",
-		"error.payment_currency_mismatch":                "Payment currency mismatch",
-		"error.payment_channel_not_found":                "Payment channel not found",
-		"error.payment_channel_inactive":                 "Payment channel is inactive",
-		"error.payment_channel_not_allowed_for_product":  "This payment channel is not available for this product",
-		"error.payment_channel_not_allowed_for_recharge": "This payment channel is not available for wallet recharge",
-		"error.wallet_only_payment_required":             "Only wallet balance payment is accepted, please recharge first",
-		"error.payment_provider_not_supported":           "Payment provider is not supported",
-		"error.payment_channel_config_invalid":           "Payment channel config is invalid",
-		"error.payment_gateway_request_failed":           "Payment gateway request failed",
-		"error.payment_gateway_response_invalid":         "Payment gateway response invalid",
-		"error.payment_fetch_failed":                     "Failed to fetch payments",
-		"error.payment_export_failed":                    "Failed to export payments",
-		"error.payment_callback_failed":                  "Failed to handle payment callback",
-		"error.payment_channel_invalid":                  "Invalid payment channel parameters",
-		"error.payment_channel_create_failed":            "Failed to create payment channel",
-		"error.payment_channel_update_failed":            "Failed to update payment channel",
-		"error.payment_channel_delete_failed":            "Failed to delete payment channel",
-		"error.payment_channel_fetch_failed":             "Failed to fetch payment channels",
-		"error.wechatpay_key_test_unsupported":           "This channel does not support the WeChat Pay public key test",
-		"error.wechatpay_key_test_config_invalid":        "Save a complete WeChat Pay public key configuration and select public-key or combined verification mode first",
-		"error.wechatpay_key_test_request_failed":        "Could not reach the WeChat Pay security test API; please try again",
-		"error.wechatpay_key_test_response_invalid":      "WeChat Pay rejected the request, response verification failed, or the echo message did not match",
-		"error.wechatpay_key_test_failed":                "WeChat Pay public key test failed",
-		"error.card_secret_invalid":                      "Invalid card secret data",
-		"error.card_secret_insufficient":                 "Insufficient card secret inventory",
-		"error.manual_stock_insufficient":                "Insufficient manual inventory",
-		"error.manual_form_schema_invalid":               "Manual fulfillment form schema is invalid",
-		"error.manual_form_required_missing":             "Please complete required manual fulfillment fields",
-		"error.manual_form_field_invalid":                "Manual fulfillment field value is invalid",
-		"error.manual_form_type_invalid":                 "Manual fulfillment field type is invalid",
-		"error.manual_form_option_invalid":               "Manual fulfillment field option is invalid",
-		"error.manual_stock_invalid":                     "Invalid manual inventory value",
-		"error.card_secret_create_failed":                "Failed to import card secrets",
-		"error.card_secret_fetch_failed":                 "Failed to fetch card secrets",
-		"error.card_secret_batch_create_failed":          "Failed to create card secret batch",
-		"error.card_secret_batch_fetch_failed":           "Failed to fetch card secret batches",
-		"error.card_secret_import_failed":                "Failed to import card secrets",
-		"error.card_secret_stats_failed":                 "Failed to fetch card secret stats",
-		"error.card_secret_update_failed":                "Failed to update card secret",
-		"error.card_secret_delete_failed":                "Failed to delete card secret",
-		"error.card_secret_not_found":                    "Card secret not found",
-		"error.gift_card_invalid":                        "Invalid gift card parameters",
-		"error.gift_card_not_found":                      "Gift card not found",
-		"error.gift_card_expired":                        "Gift card expired",
-		"error.gift_card_disabled":                       "Gift card is disabled",
-		"error.gift_card_redeemed":                       "Gift card has been redeemed",
-		"error.gift_card_create_failed":                  "Failed to generate gift cards",
-		"error.gift_card_fetch_failed":                   "Failed to fetch gift cards",
-		"error.gift_card_update_failed":                  "Failed to update gift card",
-		"error.gift_card_delete_failed":                  "Failed to delete gift card",
-		"error.gift_card_redeem_failed":                  "Failed to redeem gift card",
-		"error.api_credential_fetch_failed":              "Failed to fetch API credential",
-		"error.api_credential_apply_failed":              "Failed to apply for API credential",
-		"error.api_credential_not_found":                 "API credential not found",
-		"error.api_credential_regenerate_failed":         "Failed to regenerate API credential",
-		"error.api_credential_update_failed":             "Failed to update API credential",
-		"error.api_credential_approve_failed":            "Failed to approve API credential",
-		"error.api_credential_reject_failed":             "Failed to reject API credential",
-		"error.api_credential_delete_failed":             "Failed to delete API credential",
-		"error.api_credential_not_approved":              "API credential is not approved",
-		"error.queue_unavailable":                        "Queue service unavailable, please try again later",
-		"order.status.pending_payment":                   "Pending Payment",
-		"order.status.paid":                              "Paid",
-		"order.status.fulfilling":                        "Processing",
-		"order.status.partially_delivered":               "Partially delivered",
-		"order.status.partially_refunded":                "Partially refunded",
-		"order.status.delivered":                         "Delivered",
-		"order.status.completed":                         "Completed",
-		"order.status.canceled":                          "Canceled",
-		"order.status.refunded":                          "Refunded",
-		"email.order_status.subject":                     "Order status updated: %s",
-		"email.order_status.body":                        "Order No: %s\nStatus: %s\nAmount: %s %s\n\nThank you for your purchase.\n\n%s's Site URL: %s",
-		"email.order_status.body_paid":                   "Order No: %s\nStatus: %s\nAmount: %s %s\n\nWe have received your payment and will deliver soon.\n\n%s's Site URL: %s",
-		"email.order_status.body_refunded":               "Order No: %s\nStatus: %s\nRefund Amount: %s %s\nReason for refund: %s\n\nThe order has been refunded. Please contact admin if needed.\n\n%s's Site URL: %s",
-		"email.order_status.body_partially_refunded":     "Order No: %s\nStatus: %s\nRefund Amount: %s %s\nReason for refund: %s\n\nThe order has been partially refunded. Please contact admin if needed.\n\n%s's Site URL: %s",
-		"email.order_status.body_delivered_simple":       "Order No: %s\nStatus: %s\nAmount: %s %s\n\nDelivery completed. Thank you for your purchase.\n\n%s's Site URL: %s",
-		"email.order_status.body_delivered":              "Order No: %s\nStatus: %s\nAmount: %s %s\n\nDelivery content:\n%s\n\nThank you for your purchase.\n\n%s's Site URL: %s",
-		"email.order_status.guest_tip":                   "Guest orders can be queried on the site using the checkout email and order password.",
-		"email.order_status.fulfillment_attachment_tip":  "The delivery content is included as an attachment. Please check the email attachment for the full content.",
-
-		// Validation rules
-		"validation.rule.required": "is required",
-		"validation.rule.min":      "minimum is %s",
-		"validation.rule.max":      "maximum is %s",
-		"validation.rule.len":      "length must be %s",
-		"validation.rule.email":    "invalid format",
-		"validation.rule.url":      "must be a valid URL",
-		"validation.rule.oneof":    "must be one of: %s",
-		"validation.rule.gt":       "must be greater than %s",
-		"validation.rule.gte":      "must be greater than or equal to %s",
-		"validation.rule.lt":       "must be less than %s",
-		"validation.rule.lte":      "must be less than or equal to %s",
-		"validation.rule.numeric":  "must be numeric",
-		"validation.rule.alphanum": "must be alphanumeric",
-
-		// Product mappings
-		"error.mapping_fetch_failed":             "Failed to fetch product mapping",
-		"error.mapping_not_found":                "Product mapping not found",
-		"error.mapping_already_exists":           "Mapping already exists for this upstream product",
-		"error.mapping_import_failed":            "Failed to import upstream product",
-		"error.mapping_sync_failed":              "Failed to sync product mapping",
-		"error.mapping_update_failed":            "Failed to update product mapping",
-		"error.mapping_delete_failed":            "Failed to delete product mapping",
-		"error.connection_not_found":             "Site connection not found",
-		"error.upstream_product_not_found":       "Upstream product not found",
-		"error.upstream_products_fetch_failed":   "Failed to fetch upstream products",
-		"error.upstream_categories_fetch_failed": "Failed to fetch upstream categories",
-		"error.category_import_failed":           "Failed to import products by category",
-		"error.totp_already_enabled":             "Two-factor authentication is already enabled",
-		"error.totp_not_enabled":                 "Two-factor authentication is not enabled",
-		"error.totp_pending_expired":             "2FA setup expired, please start over",
-		"error.totp_code_invalid":                "Invalid one-time code",
-		"error.totp_code_required":               "A one-time code or recovery code is required",
-		"error.recovery_code_invalid":            "Invalid or already-used recovery code",
-		"error.totp_too_many_attempts":           "Too many failed attempts, please try again later",
-		"error.totp_challenge_invalid":           "Login session expired, please re-enter your password",
-		"error.totp_cannot_reset_self":           "You cannot reset your own 2FA from this endpoint; use admin-tool CLI",
-		"error.invalid_upstream_status":          "Invalid upstream status parameter",
-		"error.invalid_product_status":           "Invalid product status parameter",
-	},
-}
-
-// ResolveLocale 根据请求解析语言
-func ResolveLocale(c *gin.Context) string {
-	if c == nil {
-		return LocaleZH
-	}
-	if lang := strings.TrimSpace(c.Query("lang")); lang != "" {
-		return normalizeLocale(lang)
-	}
-	if lang := strings.TrimSpace(c.GetHeader("X-Lang")); lang != "" {
-		return normalizeLocale(lang)
-	}
-	accept := strings.TrimSpace(c.GetHeader("Accept-Language"))
-	if accept == "" {
-		return LocaleZH
-	}
-	parts := strings.Split(accept, ",")
-	if len(parts) == 0 {
-		return LocaleZH
-	}
-	return normalizeLocale(parts[0])
-}
-
-// T 获取翻译文本
-func T(locale, key string) string {
-	if locale == "" {
-		locale = LocaleZH
-	}
-	if bundle, ok := messages[locale]; ok {
-		if msg, ok := bundle[key]; ok {
-			return msg
-		}
-	}
-	if bundle, ok := messages[LocaleZH]; ok {
-		if msg, ok := bundle[key]; ok {
-			return msg
-		}
-	}
-	return key
-}
-
-// Sprintf 获取带参数的翻译文本
-func Sprintf(locale, key string, args ...interface{}) string {
-	return fmt.Sprintf(T(locale, key), args...)
-}
-
-func normalizeLocale(locale string) string {
-	l := strings.ToLower(strings.TrimSpace(locale))
-	switch {
-	case strings.HasPrefix(l, "zh-tw"), strings.HasPrefix(l, "zh-hk"), strings.HasPrefix(l, "zh-mo"):
-		return LocaleTW
-	case strings.HasPrefix(l, "zh"):
-		return LocaleZH
-	case strings.HasPrefix(l, "en"):
-		return LocaleEN
-	default:
-		return LocaleZH
-	}
-}
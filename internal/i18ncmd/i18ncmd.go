@@ -0,0 +1,118 @@
+// Package i18ncmd 提供 i18n 子命令实现：检查内置与外部语言包相对参照语言缺失的键。
+//
+// 入口由 cmd/server/main.go 在检测到 "i18n" 子命令时调用 Run(args, defaultDir)，
+// 不连接数据库，也不读取后台覆盖配置，只检查语言包文件本身。
+package i18ncmd
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/dujiao-next/internal/i18n/locales"
+)
+
+// Usage 打印 i18n 子命令帮助文档。
+func Usage() {
+	fmt.Fprintln(os.Stderr, `i18n 子命令: 语言包工具
+
+用法:
+  dujiao-api i18n check [--dir <path>] [--reference zh-CN] [--locale ja-JP] [--strict]
+                                                          列出各语言相对参照语言缺失的键
+                                                          --strict 时存在缺失即以退出码 1 结束`)
+}
+
+// Run 分发 i18n 子命令。args 是去掉 "i18n" 之后的参数（os.Args[2:]），
+// defaultDir 为配置中的外部语言包目录。
+func Run(args []string, defaultDir string) {
+	if len(args) < 1 {
+		Usage()
+		os.Exit(1)
+	}
+	switch args[0] {
+	case "check":
+		os.Exit(runCheck(args[1:], defaultDir, os.Stdout))
+	default:
+		Usage()
+		os.Exit(1)
+	}
+}
+
+func runCheck(args []string, defaultDir string, out io.Writer) int {
+	fs := flag.NewFlagSet("i18n check", flag.ContinueOnError)
+	dir := fs.String("dir", defaultDir, "外部语言包目录")
+	reference := fs.String("reference", locales.Default, "参照语言")
+	only := fs.String("locale", "", "只检查指定语言（逗号分隔）")
+	strict := fs.Bool("strict", false, "存在缺失键时以退出码 1 结束")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	catalog := locales.NewCatalog()
+	if err := locales.LoadEmbedded(catalog); err != nil {
+		fmt.Fprintf(os.Stderr, "load embedded packs: %v\n", err)
+		return 1
+	}
+	if _, err := locales.LoadDirInto(catalog, *dir); err != nil {
+		fmt.Fprintf(os.Stderr, "load %s: %v\n", *dir, err)
+		return 1
+	}
+	missing, err := Check(catalog, *reference, splitLocales(*only), out)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	if *strict && missing > 0 {
+		return 1
+	}
+	return 0
+}
+
+// Check 把各语言缺失的键写入 out，返回缺失总数；reference 未安装时返回错误。
+func Check(catalog *locales.Catalog, reference string, only []string, out io.Writer) (int, error) {
+	reference, ok := locales.Canonical(reference)
+	if !ok || !catalog.IsInstalled(reference) {
+		return 0, fmt.Errorf("reference locale %q is not installed", reference)
+	}
+	targets := catalog.Installed()
+	if len(only) > 0 {
+		targets = targets[:0]
+		for _, raw := range only {
+			locale, ok := locales.Canonical(raw)
+			if !ok || !catalog.IsInstalled(locale) {
+				return 0, fmt.Errorf("locale %q is not installed", raw)
+			}
+			targets = append(targets, locale)
+		}
+	}
+
+	total := 0
+	for _, locale := range targets {
+		if locale == reference {
+			continue
+		}
+		keys := catalog.MissingKeys(reference, locale)
+		total += len(keys)
+		if len(keys) == 0 {
+			fmt.Fprintf(out, "%s: ok\n", locale)
+			continue
+		}
+		fmt.Fprintf(out, "%s: %d missing (falls back to %s)\n", locale, len(keys), strings.Join(catalog.Chain(locale)[1:], " > "))
+		for _, key := range keys {
+			fmt.Fprintf(out, "  %s\n", key)
+		}
+	}
+	return total, nil
+}
+
+func splitLocales(raw string) []string {
+	result := make([]string, 0)
+	for _, part := range strings.Split(raw, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			result = append(result, part)
+		}
+	}
+	return result
+}
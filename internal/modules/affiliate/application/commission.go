@@ -11,6 +11,7 @@ import (
 
 	affiliatecontract "github.com/dujiao-next/internal/modules/affiliate/contract"
 	affiliatedomain "github.com/dujiao-next/internal/modules/affiliate/domain"
+	settingsintegration "github.com/dujiao-next/internal/modules/settings/schema/integration"
 
 	"github.com/dujiao-next/internal/constants"
 	"github.com/dujiao-next/internal/shared/money"
@@ -18,7 +19,7 @@ import (
 	"github.com/shopspring/decimal"
 )
 
-// HandleOrderPaid 处理订单支付成功后的佣金生成
+// HandleOrderPaid 处理订单支付成功后的佣金生成：直推佣金按规则逐项计算，开启二级分成时为上级推广人另记一条佣金。
 func (s *Service) HandleOrderPaid(orderID uint) error {
 	if orderID == 0 || s.repo == nil || s.orderRepo == nil {
 		return nil
@@ -27,7 +28,7 @@ func (s *Service) HandleOrderPaid(orderID uint) error {
 	if err != nil {
 		return err
 	}
-	if !setting.Enabled || setting.CommissionRate <= 0 {
+	if !setting.Enabled {
 		return nil
 	}
 
@@ -52,32 +53,69 @@ func (s *Service) HandleOrderPaid(orderID uint) error {
 		return nil
 	}
 
-	commissionType := constants.AffiliateCommissionTypeOrder
-	existing, err := s.repo.GetCommissionByOrderAndProfile(order.ID, profile.ID, commissionType)
+	paidAt := time.Now()
+	if order.PaidAt != nil {
+		paidAt = *order.PaidAt
+	}
+	baseAmount, commissionAmount, err := s.calculateOrderCommission(order, profile, setting.CommissionRate, paidAt)
 	if err != nil {
 		return err
 	}
-	if existing != nil {
+	if baseAmount.LessThanOrEqual(decimal.Zero) {
 		return nil
 	}
+	// 逐项规则下记录加权后的实际比例
+	rate := commissionAmount.Mul(decimal.NewFromInt(100)).Div(baseAmount).Round(2)
+	if err := s.createOrderCommission(order.ID, profile.ID, constants.AffiliateCommissionTypeOrder, baseAmount, rate, commissionAmount, setting, paidAt); err != nil {
+		return err
+	}
+	return s.createSecondLevelCommission(order, profile, baseAmount, setting, paidAt)
+}
 
-	baseAmount, err := s.calculateCommissionBaseAmount(order)
+func (s *Service) createSecondLevelCommission(
+	order *orderdomain.Order,
+	profile *affiliatedomain.Profile,
+	baseAmount decimal.Decimal,
+	setting settingsintegration.AffiliateSetting,
+	paidAt time.Time,
+) error {
+	if setting.SecondLevelRate <= 0 || profile.ParentProfileID == nil || *profile.ParentProfileID == profile.ID {
+		return nil
+	}
+	parent, err := s.repo.GetProfileByID(*profile.ParentProfileID)
 	if err != nil {
 		return err
 	}
-	if baseAmount.LessThanOrEqual(decimal.Zero) {
+	if parent == nil || strings.TrimSpace(parent.Status) != constants.AffiliateProfileStatusActive {
 		return nil
 	}
-	rate := decimal.NewFromFloat(setting.CommissionRate).Round(2)
-	commissionAmount := baseAmount.Mul(rate).Div(decimal.NewFromInt(100)).Round(2)
-	if commissionAmount.LessThanOrEqual(decimal.Zero) {
+	if order.UserID > 0 && parent.UserID == order.UserID {
 		return nil
 	}
+	rate := decimal.NewFromFloat(setting.SecondLevelRate).Round(2)
+	amount := baseAmount.Mul(rate).Div(decimal.NewFromInt(100)).Round(2)
+	return s.createOrderCommission(order.ID, parent.ID, constants.AffiliateCommissionTypeSecondLevel, baseAmount, rate, amount, setting, paidAt)
+}
 
-	paidAt := time.Now()
-	if order.PaidAt != nil {
-		paidAt = *order.PaidAt
+// createOrderCommission 幂等地创建一条订单佣金，金额为 0 时跳过。
+func (s *Service) createOrderCommission(
+	orderID, profileID uint,
+	commissionType string,
+	baseAmount, rate, commissionAmount decimal.Decimal,
+	setting settingsintegration.AffiliateSetting,
+	paidAt time.Time,
+) error {
+	if commissionAmount.LessThanOrEqual(decimal.Zero) {
+		return nil
+	}
+	existing, err := s.repo.GetCommissionByOrderAndProfile(orderID, profileID, commissionType)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return nil
 	}
+
 	status := constants.AffiliateCommissionStatusPendingConfirm
 	var confirmAt *time.Time
 	var availableAt *time.Time
@@ -90,8 +128,8 @@ func (s *Service) HandleOrderPaid(orderID uint) error {
 	}
 
 	commission := &affiliatedomain.Commission{
-		AffiliateProfileID: profile.ID,
-		OrderID:            order.ID,
+		AffiliateProfileID: profileID,
+		OrderID:            orderID,
 		CommissionType:     commissionType,
 		BaseAmount:         money.FromDecimal(baseAmount),
 		RatePercent:        money.FromDecimal(rate),
@@ -264,21 +302,33 @@ func (s *Service) resolveAffiliateProfileForOrder(order *orderdomain.Order) (*af
 	return nil, nil
 }
 
-func (s *Service) calculateCommissionBaseAmount(order *orderdomain.Order) (decimal.Decimal, error) {
+// calculateOrderCommission 汇总订单中参与返利商品的可返利金额（折算为基准币种），并按商品/分类/推广用户规则逐项计算佣金。
+func (s *Service) calculateOrderCommission(order *orderdomain.Order, profile *affiliatedomain.Profile, globalRate float64, paidAt time.Time) (decimal.Decimal, decimal.Decimal, error) {
 	if order == nil || s.productRepo == nil {
-		return decimal.Zero, nil
+		return decimal.Zero, decimal.Zero, nil
 	}
 	productIDs := collectAffiliateProductIDs(order)
 	if len(productIDs) == 0 {
-		return decimal.Zero, nil
+		return decimal.Zero, decimal.Zero, nil
 	}
 	products, err := s.productRepo.ListByIDs(productIDs)
 	if err != nil {
-		return decimal.Zero, err
+		return decimal.Zero, decimal.Zero, err
 	}
 	productMap := make(map[uint]productdomain.Product, len(products))
+	enabled := make([]productdomain.Product, 0, len(products))
 	for _, product := range products {
 		productMap[product.ID] = product
+		if product.IsAffiliateEnabled {
+			enabled = append(enabled, product)
+		}
+	}
+	if len(enabled) == 0 {
+		return decimal.Zero, decimal.Zero, nil
+	}
+	resolver, err := s.buildCommissionRateResolver(profile, enabled, globalRate, paidAt)
+	if err != nil {
+		return decimal.Zero, decimal.Zero, err
 	}
 
 	targetOrders := order.Children
@@ -286,8 +336,10 @@ func (s *Service) calculateCommissionBaseAmount(order *orderdomain.Order) (decim
 		targetOrders = []orderdomain.Order{*order}
 	}
 
-	total := decimal.Zero
+	totalBase := decimal.Zero
+	totalCommission := decimal.Zero
 	for _, current := range targetOrders {
+		exchangeRate := orderExchangeRate(&current, order)
 		for _, item := range current.Items {
 			product, ok := productMap[item.ProductID]
 			if !ok || !product.IsAffiliateEnabled {
@@ -297,10 +349,23 @@ func (s *Service) calculateCommissionBaseAmount(order *orderdomain.Order) (decim
 			if payable.LessThan(decimal.Zero) {
 				payable = decimal.Zero
 			}
-			total = total.Add(payable).Round(2)
+			// 佣金以基准币种入账，订单币种金额按下单汇率快照折算
+			payable = payable.Div(exchangeRate).Round(2)
+			totalBase = totalBase.Add(payable).Round(2)
+			totalCommission = totalCommission.Add(payable.Mul(resolver.rateFor(product)).Div(decimal.NewFromInt(100))).Round(2)
+		}
+	}
+	return totalBase, totalCommission, nil
+}
+
+// orderExchangeRate 返回订单下单时的汇率快照（1 基准币种 = rate 订单币种），子订单缺失时取父订单，均缺失按 1 处理。
+func orderExchangeRate(order, parent *orderdomain.Order) decimal.Decimal {
+	for _, current := range []*orderdomain.Order{order, parent} {
+		if current != nil && current.ExchangeRate.GreaterThan(decimal.Zero) {
+			return current.ExchangeRate
 		}
 	}
-	return total, nil
+	return decimal.NewFromInt(1)
 }
 
 func collectAffiliateProductIDs(order *orderdomain.Order) []uint {
@@ -0,0 +1,236 @@
+package application
+
+import (
+	"strings"
+	"time"
+
+	affiliatedomain "github.com/dujiao-next/internal/modules/affiliate/domain"
+	productdomain "github.com/dujiao-next/internal/modules/catalog/product/domain"
+	"github.com/dujiao-next/internal/shared/money"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	commissionRuleRemarkMaxLen = 255
+	commissionTiersMaxSize     = 20
+)
+
+var commissionRatePercentMax = decimal.NewFromInt(100)
+
+// ListCommissionRules 管理端佣金规则列表
+func (s *Service) ListCommissionRules(scope string) ([]affiliatedomain.CommissionRule, error) {
+	if s.repo == nil {
+		return []affiliatedomain.CommissionRule{}, nil
+	}
+	scope = strings.TrimSpace(scope)
+	if scope != "" && !affiliatedomain.IsCommissionRuleScope(scope) {
+		return nil, ErrCommissionRuleInvalid
+	}
+	return s.repo.ListCommissionRules(scope)
+}
+
+// CreateCommissionRule 管理端新增佣金规则
+func (s *Service) CreateCommissionRule(input CommissionRuleInput) (*affiliatedomain.CommissionRule, error) {
+	if s.repo == nil {
+		return nil, ErrNotFound
+	}
+	scope := strings.TrimSpace(input.Scope)
+	if !affiliatedomain.IsCommissionRuleScope(scope) || input.TargetID == 0 {
+		return nil, ErrCommissionRuleInvalid
+	}
+	rate, ok := normalizeCommissionRatePercent(input.RatePercent)
+	if !ok {
+		return nil, ErrCommissionRuleInvalid
+	}
+	existing, err := s.repo.GetCommissionRuleByTarget(scope, input.TargetID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrCommissionRuleExists
+	}
+	now := time.Now()
+	rule := &affiliatedomain.CommissionRule{
+		Scope:       scope,
+		TargetID:    input.TargetID,
+		RatePercent: money.FromDecimal(rate),
+		Remark:      truncateCommissionRuleRemark(input.Remark),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := s.repo.CreateCommissionRule(rule); err != nil {
+		if isUniqueViolation(err) {
+			return nil, ErrCommissionRuleExists
+		}
+		return nil, err
+	}
+	return rule, nil
+}
+
+// UpdateCommissionRule 管理端更新佣金规则比例与备注
+func (s *Service) UpdateCommissionRule(id uint, input CommissionRuleInput) (*affiliatedomain.CommissionRule, error) {
+	if s.repo == nil {
+		return nil, ErrNotFound
+	}
+	rate, ok := normalizeCommissionRatePercent(input.RatePercent)
+	if !ok {
+		return nil, ErrCommissionRuleInvalid
+	}
+	rule, err := s.repo.GetCommissionRuleByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if rule == nil {
+		return nil, ErrNotFound
+	}
+	rule.RatePercent = money.FromDecimal(rate)
+	rule.Remark = truncateCommissionRuleRemark(input.Remark)
+	rule.UpdatedAt = time.Now()
+	if err := s.repo.UpdateCommissionRule(rule); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// DeleteCommissionRule 管理端删除佣金规则
+func (s *Service) DeleteCommissionRule(id uint) error {
+	if s.repo == nil {
+		return ErrNotFound
+	}
+	rule, err := s.repo.GetCommissionRuleByID(id)
+	if err != nil {
+		return err
+	}
+	if rule == nil {
+		return ErrNotFound
+	}
+	return s.repo.DeleteCommissionRule(rule.ID)
+}
+
+// ListCommissionTiers 管理端业绩阶梯列表
+func (s *Service) ListCommissionTiers() ([]affiliatedomain.CommissionTier, error) {
+	if s.repo == nil {
+		return []affiliatedomain.CommissionTier{}, nil
+	}
+	return s.repo.ListCommissionTiers()
+}
+
+// ReplaceCommissionTiers 管理端整体保存业绩阶梯，门槛不可重复
+func (s *Service) ReplaceCommissionTiers(inputs []CommissionTierInput) ([]affiliatedomain.CommissionTier, error) {
+	if s.repo == nil {
+		return nil, ErrNotFound
+	}
+	if len(inputs) > commissionTiersMaxSize {
+		return nil, ErrCommissionTierInvalid
+	}
+	now := time.Now()
+	tiers := make([]affiliatedomain.CommissionTier, 0, len(inputs))
+	seen := make(map[string]struct{}, len(inputs))
+	for _, input := range inputs {
+		threshold := input.MinMonthlyGMV.Round(2)
+		rate, ok := normalizeCommissionRatePercent(input.RatePercent)
+		if !ok || threshold.LessThan(decimal.Zero) {
+			return nil, ErrCommissionTierInvalid
+		}
+		key := threshold.StringFixed(2)
+		if _, exists := seen[key]; exists {
+			return nil, ErrCommissionTierInvalid
+		}
+		seen[key] = struct{}{}
+		tiers = append(tiers, affiliatedomain.CommissionTier{
+			MinMonthlyGMV: money.FromDecimal(threshold),
+			RatePercent:   money.FromDecimal(rate),
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		})
+	}
+	if err := s.repo.ReplaceCommissionTiers(tiers); err != nil {
+		return nil, err
+	}
+	return s.repo.ListCommissionTiers()
+}
+
+// commissionRateResolver 按 商品 > 分类 > 推广用户默认比例 解析订单项佣金比例。
+type commissionRateResolver struct {
+	product     map[uint]decimal.Decimal
+	category    map[uint]decimal.Decimal
+	defaultRate decimal.Decimal
+}
+
+func (r commissionRateResolver) rateFor(product productdomain.Product) decimal.Decimal {
+	if rate, ok := r.product[product.ID]; ok {
+		return rate
+	}
+	if rate, ok := r.category[product.CategoryID]; ok {
+		return rate
+	}
+	return r.defaultRate
+}
+
+// buildCommissionRateResolver 加载订单涉及的规则；推广用户默认比例取 推广用户规则 > 当月业绩阶梯 > 全局比例。
+func (s *Service) buildCommissionRateResolver(profile *affiliatedomain.Profile, products []productdomain.Product, globalRate float64, paidAt time.Time) (commissionRateResolver, error) {
+	resolver := commissionRateResolver{
+		product:     make(map[uint]decimal.Decimal),
+		category:    make(map[uint]decimal.Decimal),
+		defaultRate: decimal.NewFromFloat(globalRate).Round(2),
+	}
+	productIDs := make([]uint, 0, len(products))
+	categoryIDs := make([]uint, 0, len(products))
+	for _, product := range products {
+		productIDs = append(productIDs, product.ID)
+		if product.CategoryID > 0 {
+			categoryIDs = append(categoryIDs, product.CategoryID)
+		}
+	}
+	rules, err := s.repo.ListCommissionRulesForTargets(productIDs, categoryIDs, profile.ID)
+	if err != nil {
+		return resolver, err
+	}
+	affiliateOverride := false
+	for _, rule := range rules {
+		rate := rule.RatePercent.Decimal.Round(2)
+		switch rule.Scope {
+		case affiliatedomain.CommissionRuleScopeProduct:
+			resolver.product[rule.TargetID] = rate
+		case affiliatedomain.CommissionRuleScopeCategory:
+			resolver.category[rule.TargetID] = rate
+		case affiliatedomain.CommissionRuleScopeAffiliate:
+			resolver.defaultRate = rate
+			affiliateOverride = true
+		}
+	}
+	if affiliateOverride {
+		return resolver, nil
+	}
+
+	tiers, err := s.repo.ListCommissionTiers()
+	if err != nil || len(tiers) == 0 {
+		return resolver, err
+	}
+	monthStart := time.Date(paidAt.Year(), paidAt.Month(), 1, 0, 0, 0, 0, paidAt.Location())
+	gmv, err := s.repo.SumReferredGMVSince(profile.ID, monthStart)
+	if err != nil {
+		return resolver, err
+	}
+	if tier, ok := affiliatedomain.MatchCommissionTier(tiers, gmv); ok {
+		resolver.defaultRate = tier.RatePercent.Decimal.Round(2)
+	}
+	return resolver, nil
+}
+
+func normalizeCommissionRatePercent(rate decimal.Decimal) (decimal.Decimal, bool) {
+	rate = rate.Round(2)
+	if rate.LessThan(decimal.Zero) || rate.GreaterThan(commissionRatePercentMax) {
+		return decimal.Zero, false
+	}
+	return rate, true
+}
+
+func truncateCommissionRuleRemark(raw string) string {
+	remark := strings.TrimSpace(raw)
+	if runes := []rune(remark); len(runes) > commissionRuleRemarkMaxLen {
+		return string(runes[:commissionRuleRemarkMaxLen])
+	}
+	return remark
+}
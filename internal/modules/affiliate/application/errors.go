@@ -12,6 +12,9 @@ var (
 	ErrWithdrawChannelInvalid = errors.New("affiliate withdraw channel invalid")
 	ErrWithdrawInsufficient   = errors.New("affiliate withdraw insufficient")
 	ErrWithdrawStatusInvalid  = errors.New("affiliate withdraw status invalid")
+	ErrCommissionRuleInvalid  = errors.New("affiliate commission rule invalid")
+	ErrCommissionRuleExists   = errors.New("affiliate commission rule already exists")
+	ErrCommissionTierInvalid  = errors.New("affiliate commission tier invalid")
 	// ErrUserDisabled 开通推广时目标用户已禁用（与用户域共用同一文案哨兵）。
 	ErrUserDisabled = errors.New("user disabled")
 )
@@ -88,6 +88,12 @@ func (s *Service) OpenAffiliate(userID uint) (*affiliatedomain.Profile, error) {
 		return existing, nil
 	}
 
+	// 用户最近一笔已支付推广订单的推广人成为其上级，用于二级分成
+	parentProfileID, err := s.repo.GetReferrerProfileIDByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
 	const maxRetry = 8
 	for i := 0; i < maxRetry; i++ {
 		code, genErr := generateAffiliateCode()
@@ -95,9 +101,10 @@ func (s *Service) OpenAffiliate(userID uint) (*affiliatedomain.Profile, error) {
 			return nil, genErr
 		}
 		profile := &affiliatedomain.Profile{
-			UserID:        userID,
-			AffiliateCode: code,
-			Status:        constants.AffiliateProfileStatusActive,
+			UserID:          userID,
+			AffiliateCode:   code,
+			Status:          constants.AffiliateProfileStatusActive,
+			ParentProfileID: parentProfileID,
 		}
 		if err := s.repo.CreateProfile(profile); err != nil {
 			if isUniqueViolation(err) {
@@ -24,6 +24,20 @@ type WithdrawApplyInput struct {
 	Account string
 }
 
+// CommissionRuleInput 佣金规则输入；更新时忽略 Scope 与 TargetID。
+type CommissionRuleInput struct {
+	Scope       string
+	TargetID    uint
+	RatePercent decimal.Decimal
+	Remark      string
+}
+
+// CommissionTierInput 业绩阶梯输入。
+type CommissionTierInput struct {
+	MinMonthlyGMV decimal.Decimal
+	RatePercent   decimal.Decimal
+}
+
 // Dashboard 推广用户中心数据。
 type Dashboard struct {
 	Opened              bool         `json:"opened"`
@@ -81,3 +95,9 @@ type AdminWithdrawListFilter struct {
 	Status             string
 	Keyword            string
 }
+
+// PayoutWithdraw 打款批次锁定的提现申请及收款用户
+type PayoutWithdraw struct {
+	Request affiliatedomain.WithdrawRequest
+	UserID  uint
+}
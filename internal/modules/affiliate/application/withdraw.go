@@ -138,13 +138,15 @@ func (s *Service) ApplyWithdraw(userID uint, input WithdrawApplyInput) (*affilia
 	return s.repo.GetWithdrawByID(createdID)
 }
 
-// ReviewWithdraw 管理端审核提现申请
+// ReviewWithdraw 管理端审核提现申请：审核通过后可单笔标记打款，或加入打款批次出款
 func (s *Service) ReviewWithdraw(adminID, withdrawID uint, action, rejectReason string) (*affiliatedomain.WithdrawRequest, error) {
 	if withdrawID == 0 || s.repo == nil {
 		return nil, ErrNotFound
 	}
 	act := strings.ToLower(strings.TrimSpace(action))
-	if act != constants.AffiliateWithdrawActionReject && act != constants.AffiliateWithdrawActionPay {
+	if act != constants.AffiliateWithdrawActionApprove &&
+		act != constants.AffiliateWithdrawActionReject &&
+		act != constants.AffiliateWithdrawActionPay {
 		return nil, ErrWithdrawStatusInvalid
 	}
 	rejectReason = strings.TrimSpace(rejectReason)
@@ -157,43 +159,42 @@ func (s *Service) ReviewWithdraw(adminID, withdrawID uint, action, rejectReason
 		if req == nil {
 			return ErrNotFound
 		}
-		if req.Status != constants.AffiliateWithdrawStatusPendingReview {
+		if !withdrawReviewable(req, act) {
 			return ErrWithdrawStatusInvalid
 		}
 
-		commissions, err := repoTx.ListCommissionsByWithdrawIDForUpdate(withdrawID)
-		if err != nil {
-			return err
-		}
-		ids := make([]uint, 0, len(commissions))
-		for _, commission := range commissions {
-			ids = append(ids, commission.ID)
-		}
-
 		now := time.Now()
-		req.ProcessedBy = &adminID
-		req.ProcessedAt = &now
-		req.UpdatedAt = now
-		if act == constants.AffiliateWithdrawActionReject {
+		switch act {
+		case constants.AffiliateWithdrawActionApprove:
+			req.Status = constants.AffiliateWithdrawStatusApproved
+			req.ProcessedBy = &adminID
+			req.ProcessedAt = &now
+			req.UpdatedAt = now
+			return repoTx.UpdateWithdraw(req)
+		case constants.AffiliateWithdrawActionReject:
+			commissions, err := repoTx.ListCommissionsByWithdrawIDForUpdate(withdrawID)
+			if err != nil {
+				return err
+			}
+			ids := make([]uint, 0, len(commissions))
+			for _, commission := range commissions {
+				ids = append(ids, commission.ID)
+			}
 			req.Status = constants.AffiliateWithdrawStatusRejected
 			req.RejectReason = rejectReason
+			req.ProcessedBy = &adminID
+			req.ProcessedAt = &now
+			req.UpdatedAt = now
 			if err := repoTx.BatchUpdateCommissions(ids, map[string]interface{}{
 				"withdraw_request_id": nil,
 				"updated_at":          now,
 			}); err != nil {
 				return err
 			}
-		} else {
-			req.Status = constants.AffiliateWithdrawStatusPaid
-			req.RejectReason = ""
-			if err := repoTx.BatchUpdateCommissions(ids, map[string]interface{}{
-				"status":     constants.AffiliateCommissionStatusWithdrawn,
-				"updated_at": now,
-			}); err != nil {
-				return err
-			}
+			return repoTx.UpdateWithdraw(req)
+		default:
+			return markWithdrawPaid(repoTx, req, adminID, "", now)
 		}
-		return repoTx.UpdateWithdraw(req)
 	})
 	if err != nil {
 		return nil, err
@@ -201,6 +202,112 @@ func (s *Service) ReviewWithdraw(adminID, withdrawID uint, action, rejectReason
 	return s.repo.GetWithdrawByID(withdrawID)
 }
 
+// LockApprovedWithdrawsForPayout 在调用方事务内锁定已审核且未加入打款批次的提现申请
+func (s *Service) LockApprovedWithdrawsForPayout(repoTx affiliatecontract.Store, ids []uint) ([]PayoutWithdraw, error) {
+	if repoTx == nil {
+		return nil, ErrNotFound
+	}
+	rows := make([]PayoutWithdraw, 0, len(ids))
+	for _, id := range ids {
+		req, err := repoTx.GetWithdrawByIDForUpdate(id)
+		if err != nil {
+			return nil, err
+		}
+		if req == nil {
+			return nil, ErrNotFound
+		}
+		if req.Status != constants.AffiliateWithdrawStatusApproved || req.PayoutBatchID != nil {
+			return nil, ErrWithdrawStatusInvalid
+		}
+		profile, err := repoTx.GetProfileByID(req.AffiliateProfileID)
+		if err != nil {
+			return nil, err
+		}
+		if profile == nil {
+			return nil, ErrNotFound
+		}
+		rows = append(rows, PayoutWithdraw{Request: *req, UserID: profile.UserID})
+	}
+	return rows, nil
+}
+
+// AssignWithdrawPayoutBatch 在调用方事务内绑定打款批次；batchID 为 0 时解除绑定
+func (s *Service) AssignWithdrawPayoutBatch(repoTx affiliatecontract.Store, withdrawID, batchID uint) error {
+	if repoTx == nil {
+		return ErrNotFound
+	}
+	req, err := repoTx.GetWithdrawByIDForUpdate(withdrawID)
+	if err != nil {
+		return err
+	}
+	if req == nil {
+		return ErrNotFound
+	}
+	if req.Status != constants.AffiliateWithdrawStatusApproved {
+		return ErrWithdrawStatusInvalid
+	}
+	req.PayoutBatchID = nil
+	if batchID != 0 {
+		req.PayoutBatchID = &batchID
+	}
+	req.UpdatedAt = time.Now()
+	return repoTx.UpdateWithdraw(req)
+}
+
+// SettleWithdrawPayout 在调用方事务内按打款批次结清提现申请，并记录打款流水号
+func (s *Service) SettleWithdrawPayout(repoTx affiliatecontract.Store, adminID, withdrawID, batchID uint, reference string) error {
+	if repoTx == nil {
+		return ErrNotFound
+	}
+	req, err := repoTx.GetWithdrawByIDForUpdate(withdrawID)
+	if err != nil {
+		return err
+	}
+	if req == nil {
+		return ErrNotFound
+	}
+	if req.Status != constants.AffiliateWithdrawStatusApproved || req.PayoutBatchID == nil || *req.PayoutBatchID != batchID {
+		return ErrWithdrawStatusInvalid
+	}
+	return markWithdrawPaid(repoTx, req, adminID, strings.TrimSpace(reference), time.Now())
+}
+
+// withdrawReviewable 已加入打款批次的申请只能随批次结清或在批次取消后处理
+func withdrawReviewable(req *affiliatedomain.WithdrawRequest, act string) bool {
+	if act == constants.AffiliateWithdrawActionApprove {
+		return req.Status == constants.AffiliateWithdrawStatusPendingReview
+	}
+	if req.PayoutBatchID != nil {
+		return false
+	}
+	return req.Status == constants.AffiliateWithdrawStatusPendingReview ||
+		req.Status == constants.AffiliateWithdrawStatusApproved
+}
+
+func markWithdrawPaid(repoTx affiliatecontract.Store, req *affiliatedomain.WithdrawRequest, adminID uint, reference string, now time.Time) error {
+	commissions, err := repoTx.ListCommissionsByWithdrawIDForUpdate(req.ID)
+	if err != nil {
+		return err
+	}
+	ids := make([]uint, 0, len(commissions))
+	for _, commission := range commissions {
+		ids = append(ids, commission.ID)
+	}
+	if err := repoTx.BatchUpdateCommissions(ids, map[string]interface{}{
+		"status":     constants.AffiliateCommissionStatusWithdrawn,
+		"updated_at": now,
+	}); err != nil {
+		return err
+	}
+	req.Status = constants.AffiliateWithdrawStatusPaid
+	req.RejectReason = ""
+	req.PayoutReference = reference
+	req.ProcessedBy = &adminID
+	req.ProcessedAt = &now
+	req.UpdatedAt = now
+	return repoTx.UpdateWithdraw(req)
+}
+
 func containsWithdrawChannel(channels []string, channel string) bool {
 	target := strings.ToLower(strings.TrimSpace(channel))
 	if target == "" {
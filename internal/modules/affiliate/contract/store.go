@@ -95,6 +95,19 @@ type Store interface {
 	ListAvailableCommissionsForUpdate(profileID uint) ([]domain.Commission, error)
 	BatchUpdateCommissions(ids []uint, updates map[string]interface{}) error
 
+	SumReferredGMVSince(profileID uint, since time.Time) (decimal.Decimal, error)
+	GetReferrerProfileIDByUser(userID uint) (*uint, error)
+
+	ListCommissionRules(scope string) ([]domain.CommissionRule, error)
+	ListCommissionRulesForTargets(productIDs, categoryIDs []uint, profileID uint) ([]domain.CommissionRule, error)
+	GetCommissionRuleByID(id uint) (*domain.CommissionRule, error)
+	GetCommissionRuleByTarget(scope string, targetID uint) (*domain.CommissionRule, error)
+	CreateCommissionRule(rule *domain.CommissionRule) error
+	UpdateCommissionRule(rule *domain.CommissionRule) error
+	DeleteCommissionRule(id uint) error
+	ListCommissionTiers() ([]domain.CommissionTier, error)
+	ReplaceCommissionTiers(tiers []domain.CommissionTier) error
+
 	CreateWithdraw(request *domain.WithdrawRequest) error
 	UpdateWithdraw(request *domain.WithdrawRequest) error
 	GetWithdrawByID(id uint) (*domain.WithdrawRequest, error)
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"sort"
+	"time"
+
+	"github.com/dujiao-next/internal/shared/money"
+
+	"github.com/shopspring/decimal"
+)
+
+// 佣金规则作用域；同一订单项按 商品 > 分类 > 推广用户 的顺序取第一条命中的规则。
+const (
+	CommissionRuleScopeProduct   = "product"
+	CommissionRuleScopeCategory  = "category"
+	CommissionRuleScopeAffiliate = "affiliate"
+)
+
+// CommissionRule 佣金比例覆盖规则
+type CommissionRule struct {
+	ID          uint         `gorm:"primarykey" json:"id"`                                                                    // 主键
+	Scope       string       `gorm:"type:varchar(20);not null;uniqueIndex:idx_affiliate_commission_rule_target" json:"scope"` // 作用域
+	TargetID    uint         `gorm:"not null;uniqueIndex:idx_affiliate_commission_rule_target" json:"target_id"`              // 商品/分类/推广档案ID
+	RatePercent money.Amount `gorm:"type:decimal(10,2);not null;default:0" json:"rate_percent"`                               // 佣金比例（百分比）
+	Remark      string       `gorm:"type:varchar(255);not null;default:''" json:"remark"`                                     // 备注
+	CreatedAt   time.Time    `gorm:"index" json:"created_at"`                                                                 // 创建时间
+	UpdatedAt   time.Time    `gorm:"index" json:"updated_at"`                                                                 // 更新时间
+}
+
+// TableName 指定表名
+func (CommissionRule) TableName() string {
+	return "affiliate_commission_rules"
+}
+
+// CommissionTier 按推广用户当月推广业绩（GMV）阶梯提升默认佣金比例
+type CommissionTier struct {
+	ID            uint         `gorm:"primarykey" json:"id"`                                                     // 主键
+	MinMonthlyGMV money.Amount `gorm:"type:decimal(20,2);not null;default:0;uniqueIndex" json:"min_monthly_gmv"` // 当月业绩门槛
+	RatePercent   money.Amount `gorm:"type:decimal(10,2);not null;default:0" json:"rate_percent"`                // 佣金比例（百分比）
+	CreatedAt     time.Time    `gorm:"index" json:"created_at"`                                                  // 创建时间
+	UpdatedAt     time.Time    `gorm:"index" json:"updated_at"`                                                  // 更新时间
+}
+
+// TableName 指定表名
+func (CommissionTier) TableName() string {
+	return "affiliate_commission_tiers"
+}
+
+// IsCommissionRuleScope 判断是否为受支持的规则作用域。
+func IsCommissionRuleScope(scope string) bool {
+	switch scope {
+	case CommissionRuleScopeProduct, CommissionRuleScopeCategory, CommissionRuleScopeAffiliate:
+		return true
+	default:
+		return false
+	}
+}
+
+// MatchCommissionTier 返回业绩达到的最高阶梯；未达到任何门槛时返回 false。
+func MatchCommissionTier(tiers []CommissionTier, monthlyGMV decimal.Decimal) (CommissionTier, bool) {
+	sorted := append([]CommissionTier(nil), tiers...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].MinMonthlyGMV.Decimal.GreaterThan(sorted[j].MinMonthlyGMV.Decimal)
+	})
+	for _, tier := range sorted {
+		if monthlyGMV.GreaterThanOrEqual(tier.MinMonthlyGMV.Decimal) {
+			return tier, true
+		}
+	}
+	return CommissionTier{}, false
+}
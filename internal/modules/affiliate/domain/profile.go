@@ -21,13 +21,14 @@ func NormalizeCode(raw string) string {
 
 // Profile 推广返利用户档案
 type Profile struct {
-	ID            uint       `gorm:"primarykey" json:"id"`                              // 主键
-	UserID        uint       `gorm:"not null;uniqueIndex" json:"user_id"`               // 用户ID
-	AffiliateCode string     `gorm:"type:varchar(32);not null;uniqueIndex" json:"code"` // 联盟短ID
-	Status        string     `gorm:"type:varchar(20);not null;index" json:"status"`     // 状态
-	CreatedAt     time.Time  `gorm:"index" json:"created_at"`                           // 创建时间
-	UpdatedAt     time.Time  `gorm:"index" json:"updated_at"`                           // 更新时间
-	DeletedAt     *time.Time `gorm:"index" json:"-"`                                    // 软删除时间
+	ID              uint       `gorm:"primarykey" json:"id"`                              // 主键
+	UserID          uint       `gorm:"not null;uniqueIndex" json:"user_id"`               // 用户ID
+	AffiliateCode   string     `gorm:"type:varchar(32);not null;uniqueIndex" json:"code"` // 联盟短ID
+	Status          string     `gorm:"type:varchar(20);not null;index" json:"status"`     // 状态
+	ParentProfileID *uint      `gorm:"index" json:"parent_profile_id,omitempty"`          // 上级推广档案ID（二级分成）
+	CreatedAt       time.Time  `gorm:"index" json:"created_at"`                           // 创建时间
+	UpdatedAt       time.Time  `gorm:"index" json:"updated_at"`                           // 更新时间
+	DeletedAt       *time.Time `gorm:"index" json:"-"`                                    // 软删除时间
 
 	User userdomain.User `gorm:"foreignKey:UserID" json:"user,omitempty"` // 用户信息
 }
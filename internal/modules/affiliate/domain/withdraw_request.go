@@ -18,6 +18,8 @@ type WithdrawRequest struct {
 	RejectReason       string       `gorm:"type:varchar(255)" json:"reject_reason"`              // 拒绝原因
 	ProcessedBy        *uint        `gorm:"index" json:"processed_by,omitempty"`                 // 审核管理员ID
 	ProcessedAt        *time.Time   `gorm:"index" json:"processed_at,omitempty"`                 // 审核时间
+	PayoutBatchID      *uint        `gorm:"index" json:"payout_batch_id,omitempty"`              // 打款批次ID
+	PayoutReference    string       `gorm:"type:varchar(128)" json:"payout_reference,omitempty"` // 打款流水号/钱包入账引用
 	CreatedAt          time.Time    `gorm:"index" json:"created_at"`                             // 创建时间
 	UpdatedAt          time.Time    `gorm:"index" json:"updated_at"`                             // 更新时间
 	DeletedAt          *time.Time   `gorm:"index" json:"-"`                                      // 软删除时间
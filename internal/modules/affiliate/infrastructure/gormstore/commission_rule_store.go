@@ -0,0 +1,154 @@
+package gormstore
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/dujiao-next/internal/constants"
+	affiliatedomain "github.com/dujiao-next/internal/modules/affiliate/domain"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// SumReferredGMVSince 汇总推广用户自指定时间以来直推订单的有效佣金基数；base_amount 已按下单汇率折算为基准币种
+func (r *Store) SumReferredGMVSince(profileID uint, since time.Time) (decimal.Decimal, error) {
+	if profileID == 0 {
+		return decimal.Zero, nil
+	}
+	var row struct {
+		Total decimal.Decimal `gorm:"column:total"`
+	}
+	if err := r.db.Model(&affiliatedomain.Commission{}).
+		Select("COALESCE(SUM(base_amount), 0) AS total").
+		Where("affiliate_profile_id = ? AND commission_type = ? AND status <> ? AND created_at >= ? AND deleted_at IS NULL",
+			profileID, constants.AffiliateCommissionTypeOrder, constants.AffiliateCommissionStatusRejected, since).
+		Scan(&row).Error; err != nil {
+		return decimal.Zero, err
+	}
+	return row.Total.Round(2), nil
+}
+
+// GetReferrerProfileIDByUser 查询用户最近一笔已支付推广订单的推广档案，作为其上级推广人
+func (r *Store) GetReferrerProfileIDByUser(userID uint) (*uint, error) {
+	if userID == 0 {
+		return nil, nil
+	}
+	var ids []uint
+	if err := r.db.Table("orders").
+		Where("user_id = ? AND affiliate_profile_id IS NOT NULL AND paid_at IS NOT NULL AND deleted_at IS NULL", userID).
+		Order("id desc").
+		Limit(1).
+		Pluck("affiliate_profile_id", &ids).Error; err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 || ids[0] == 0 {
+		return nil, nil
+	}
+	return &ids[0], nil
+}
+
+// ListCommissionRules 查询佣金规则，scope 为空时返回全部
+func (r *Store) ListCommissionRules(scope string) ([]affiliatedomain.CommissionRule, error) {
+	query := r.db.Model(&affiliatedomain.CommissionRule{})
+	if value := strings.TrimSpace(scope); value != "" {
+		query = query.Where("scope = ?", value)
+	}
+	var rows []affiliatedomain.CommissionRule
+	if err := query.Order("scope asc, target_id asc").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// ListCommissionRulesForTargets 查询订单佣金计算涉及的商品、分类与推广用户规则
+func (r *Store) ListCommissionRulesForTargets(productIDs, categoryIDs []uint, profileID uint) ([]affiliatedomain.CommissionRule, error) {
+	conditions := r.db.Where("scope = ? AND target_id = ?", affiliatedomain.CommissionRuleScopeAffiliate, profileID)
+	if len(productIDs) > 0 {
+		conditions = conditions.Or("scope = ? AND target_id IN ?", affiliatedomain.CommissionRuleScopeProduct, productIDs)
+	}
+	if len(categoryIDs) > 0 {
+		conditions = conditions.Or("scope = ? AND target_id IN ?", affiliatedomain.CommissionRuleScopeCategory, categoryIDs)
+	}
+	var rows []affiliatedomain.CommissionRule
+	if err := r.db.Model(&affiliatedomain.CommissionRule{}).Where(conditions).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// GetCommissionRuleByID 按ID获取佣金规则
+func (r *Store) GetCommissionRuleByID(id uint) (*affiliatedomain.CommissionRule, error) {
+	if id == 0 {
+		return nil, nil
+	}
+	var rule affiliatedomain.CommissionRule
+	if err := r.db.First(&rule, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// GetCommissionRuleByTarget 按作用域与目标获取佣金规则
+func (r *Store) GetCommissionRuleByTarget(scope string, targetID uint) (*affiliatedomain.CommissionRule, error) {
+	var rule affiliatedomain.CommissionRule
+	if err := r.db.Where("scope = ? AND target_id = ?", scope, targetID).First(&rule).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// CreateCommissionRule 创建佣金规则
+func (r *Store) CreateCommissionRule(rule *affiliatedomain.CommissionRule) error {
+	return r.db.Create(rule).Error
+}
+
+// UpdateCommissionRule 更新佣金规则
+func (r *Store) UpdateCommissionRule(rule *affiliatedomain.CommissionRule) error {
+	if rule == nil || rule.ID == 0 {
+		return nil
+	}
+	return r.db.Model(&affiliatedomain.CommissionRule{}).
+		Where("id = ?", rule.ID).
+		Updates(map[string]interface{}{
+			"rate_percent": rule.RatePercent,
+			"remark":       rule.Remark,
+			"updated_at":   rule.UpdatedAt,
+		}).Error
+}
+
+// DeleteCommissionRule 删除佣金规则
+func (r *Store) DeleteCommissionRule(id uint) error {
+	if id == 0 {
+		return nil
+	}
+	return r.db.Delete(&affiliatedomain.CommissionRule{}, id).Error
+}
+
+// ListCommissionTiers 按门槛升序查询业绩阶梯
+func (r *Store) ListCommissionTiers() ([]affiliatedomain.CommissionTier, error) {
+	var rows []affiliatedomain.CommissionTier
+	if err := r.db.Order("min_monthly_gmv asc").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// ReplaceCommissionTiers 整体替换业绩阶梯
+func (r *Store) ReplaceCommissionTiers(tiers []affiliatedomain.CommissionTier) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("1 = 1").Delete(&affiliatedomain.CommissionTier{}).Error; err != nil {
+			return err
+		}
+		if len(tiers) == 0 {
+			return nil
+		}
+		return tx.Create(&tiers).Error
+	})
+}
@@ -363,7 +363,8 @@ func (r *Store) CountValidOrdersByProfile(profileID uint) (int64, error) {
 	}
 	var total int64
 	if err := r.db.Model(&affiliatedomain.Commission{}).
-		Where("affiliate_profile_id = ? AND status <> ? AND deleted_at IS NULL", profileID, constants.AffiliateCommissionStatusRejected).
+		Where("affiliate_profile_id = ? AND commission_type = ? AND status <> ? AND deleted_at IS NULL",
+			profileID, constants.AffiliateCommissionTypeOrder, constants.AffiliateCommissionStatusRejected).
 		Distinct("order_id").
 		Count(&total).Error; err != nil {
 		return 0, err
@@ -544,7 +545,8 @@ func (r *Store) GetProfileStatsBatch(profileIDs []uint) (map[uint]affiliatecontr
 	}
 	if err := r.db.Model(&affiliatedomain.Commission{}).
 		Select("affiliate_profile_id, COUNT(DISTINCT order_id) AS total").
-		Where("affiliate_profile_id IN ? AND status <> ? AND deleted_at IS NULL", profileIDs, constants.AffiliateCommissionStatusRejected).
+		Where("affiliate_profile_id IN ? AND commission_type = ? AND status <> ? AND deleted_at IS NULL",
+			profileIDs, constants.AffiliateCommissionTypeOrder, constants.AffiliateCommissionStatusRejected).
 		Group("affiliate_profile_id").
 		Scan(&validRows).Error; err != nil {
 		return nil, err
@@ -124,7 +124,7 @@ func setupChannelAffiliateHandlerTest(t *testing.T) (*gorm.DB, *httptest.Server)
 		ConfirmDays:       7,
 		MinWithdrawAmount: 10,
 		WithdrawChannels:  []string{"alipay", "wechat", "bank"},
-	}); err != nil {
+	}, 1); err != nil {
 		t.Fatalf("enable affiliate setting failed: %v", err)
 	}
 
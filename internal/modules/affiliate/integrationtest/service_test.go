@@ -14,6 +14,11 @@ import (
 
 	userdomain "github.com/dujiao-next/internal/modules/identity/user/domain"
 
+	productdomain "github.com/dujiao-next/internal/modules/catalog/product/domain"
+	orderdomain "github.com/dujiao-next/internal/modules/order/domain"
+	"github.com/dujiao-next/internal/shared/money"
+	"github.com/shopspring/decimal"
+
 	"github.com/dujiao-next/internal/testkit/memorysettings"
 
 	settingsapp "github.com/dujiao-next/internal/modules/settings/application"
@@ -152,7 +157,7 @@ func setupAffiliateServiceTest(t *testing.T) (*affiliateapp.Service, *gorm.DB, a
 	if _, err := settingSvc.UpdateAffiliateSetting(settingsintegration.AffiliateSetting{
 		Enabled:        true,
 		CommissionRate: 20,
-	}); err != nil {
+	}, 1); err != nil {
 		t.Fatalf("init affiliate setting failed: %v", err)
 	}
 
@@ -206,3 +211,217 @@ func createAffiliateTestClick(t *testing.T, db *gorm.DB, profileID uint, visitor
 		t.Fatalf("create affiliate click failed: %v", err)
 	}
 }
+
+type commissionOrderReaderStub struct {
+	orders map[uint]*orderdomain.Order
+}
+
+func (s commissionOrderReaderStub) GetByID(id uint) (*orderdomain.Order, error) {
+	return s.orders[id], nil
+}
+
+type commissionProductReaderStub struct {
+	products []productdomain.Product
+}
+
+func (s commissionProductReaderStub) ListByIDs([]uint) ([]productdomain.Product, error) {
+	return s.products, nil
+}
+
+func TestHandleOrderPaidAppliesRulesTiersAndSecondLevel(t *testing.T) {
+	dsn := fmt.Sprintf("file:affiliate_rules_%d?mode=memory&cache=shared", time.Now().UnixNano())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite failed: %v", err)
+	}
+	if err := db.AutoMigrate(
+		&userdomain.User{}, &orderdomain.Order{}, &affiliatedomain.Profile{}, &affiliatedomain.Commission{},
+		&affiliatedomain.CommissionRule{}, &affiliatedomain.CommissionTier{},
+	); err != nil {
+		t.Fatalf("auto migrate failed: %v", err)
+	}
+	settingSvc := settingsapp.NewService(memorysettings.New())
+	if _, err := settingSvc.UpdateAffiliateSetting(settingsintegration.AffiliateSetting{
+		Enabled:         true,
+		CommissionRate:  10,
+		SecondLevelRate: 2,
+	}, 1); err != nil {
+		t.Fatalf("init affiliate setting failed: %v", err)
+	}
+
+	parentUser := createAffiliateTestUser(t, db, "affiliate-parent@example.com")
+	childUser := createAffiliateTestUser(t, db, "affiliate-child@example.com")
+	parent := createAffiliateTestProfile(t, db, parentUser.ID, "AFFPAR01", constants.AffiliateProfileStatusActive)
+
+	// 子推广人曾通过上级推广链接下单，开通推广后自动挂到上级名下
+	paidAt := time.Now()
+	referredOrder := orderdomain.Order{OrderNo: "REF-1", UserID: childUser.ID, AffiliateProfileID: &parent.ID, Status: constants.OrderStatusCompleted, PaidAt: &paidAt}
+	if err := db.Create(&referredOrder).Error; err != nil {
+		t.Fatalf("create referred order failed: %v", err)
+	}
+
+	orders := map[uint]*orderdomain.Order{}
+	products := []productdomain.Product{
+		{ID: 1, CategoryID: 7, IsAffiliateEnabled: true},
+		{ID: 2, CategoryID: 8, IsAffiliateEnabled: true},
+		{ID: 3, CategoryID: 9, IsAffiliateEnabled: true},
+		{ID: 4, CategoryID: 9, IsAffiliateEnabled: false},
+	}
+	store := affiliategormstore.New(db)
+	svc := affiliateapp.NewService(store, userstore.New(db), commissionOrderReaderStub{orders: orders}, commissionProductReaderStub{products: products}, settingSvc)
+
+	child, err := svc.OpenAffiliate(childUser.ID)
+	if err != nil {
+		t.Fatalf("open child affiliate failed: %v", err)
+	}
+	if child.ParentProfileID == nil || *child.ParentProfileID != parent.ID {
+		t.Fatalf("expected child parent %d, got %+v", parent.ID, child.ParentProfileID)
+	}
+
+	if _, err := svc.CreateCommissionRule(affiliateapp.CommissionRuleInput{Scope: affiliatedomain.CommissionRuleScopeProduct, TargetID: 1, RatePercent: decimal.NewFromInt(30)}); err != nil {
+		t.Fatalf("create product rule failed: %v", err)
+	}
+	if _, err := svc.CreateCommissionRule(affiliateapp.CommissionRuleInput{Scope: affiliatedomain.CommissionRuleScopeCategory, TargetID: 8, RatePercent: decimal.NewFromInt(20)}); err != nil {
+		t.Fatalf("create category rule failed: %v", err)
+	}
+	if _, err := svc.CreateCommissionRule(affiliateapp.CommissionRuleInput{Scope: affiliatedomain.CommissionRuleScopeCategory, TargetID: 8, RatePercent: decimal.NewFromInt(5)}); err != affiliateapp.ErrCommissionRuleExists {
+		t.Fatalf("expected duplicate rule error, got %v", err)
+	}
+	if _, err := svc.ReplaceCommissionTiers([]affiliateapp.CommissionTierInput{
+		{MinMonthlyGMV: decimal.NewFromInt(100), RatePercent: decimal.NewFromInt(15)},
+	}); err != nil {
+		t.Fatalf("replace tiers failed: %v", err)
+	}
+
+	newOrder := func(id uint, no string) *orderdomain.Order {
+		order := &orderdomain.Order{
+			ID: id, OrderNo: no, UserID: 0, AffiliateProfileID: &child.ID, PaidAt: &paidAt,
+			TotalAmount: money.FromDecimal(decimal.NewFromInt(400)),
+			Items: []orderdomain.OrderItem{
+				{ProductID: 1, TotalPrice: money.FromDecimal(decimal.NewFromInt(100))},
+				{ProductID: 2, TotalPrice: money.FromDecimal(decimal.NewFromInt(100))},
+				{ProductID: 3, TotalPrice: money.FromDecimal(decimal.NewFromInt(100))},
+				{ProductID: 4, TotalPrice: money.FromDecimal(decimal.NewFromInt(100))},
+			},
+		}
+		orders[id] = order
+		return order
+	}
+
+	// 首单：商品规则 30%、分类规则 20%、其余按全局 10%，不参与返利商品不计入
+	first := newOrder(101, "A-101")
+	if err := svc.HandleOrderPaid(first.ID); err != nil {
+		t.Fatalf("handle first order failed: %v", err)
+	}
+	if err := svc.HandleOrderPaid(first.ID); err != nil {
+		t.Fatalf("handle first order again failed: %v", err)
+	}
+	assertOrderCommission(t, store, first.ID, child.ID, constants.AffiliateCommissionTypeOrder, "300.00", "60.00", "20.00")
+	assertOrderCommission(t, store, first.ID, parent.ID, constants.AffiliateCommissionTypeSecondLevel, "300.00", "6.00", "2.00")
+
+	// 当月业绩达到 100 后默认比例升至阶梯 15%
+	second := newOrder(102, "A-102")
+	if err := svc.HandleOrderPaid(second.ID); err != nil {
+		t.Fatalf("handle second order failed: %v", err)
+	}
+	assertOrderCommission(t, store, second.ID, child.ID, constants.AffiliateCommissionTypeOrder, "300.00", "65.00", "21.67")
+
+	// 推广用户专属比例优先于阶梯
+	if _, err := svc.CreateCommissionRule(affiliateapp.CommissionRuleInput{Scope: affiliatedomain.CommissionRuleScopeAffiliate, TargetID: child.ID, RatePercent: decimal.NewFromInt(25)}); err != nil {
+		t.Fatalf("create affiliate rule failed: %v", err)
+	}
+	third := newOrder(103, "A-103")
+	if err := svc.HandleOrderPaid(third.ID); err != nil {
+		t.Fatalf("handle third order failed: %v", err)
+	}
+	assertOrderCommission(t, store, third.ID, child.ID, constants.AffiliateCommissionTypeOrder, "300.00", "75.00", "25.00")
+
+	// 取消订单时直推与二级佣金一并失效
+	if err := svc.HandleOrderCanceled(first.ID, ""); err != nil {
+		t.Fatalf("cancel first order failed: %v", err)
+	}
+	rows, err := store.ListCommissionsByOrder(first.ID, []string{constants.AffiliateCommissionStatusPendingConfirm, constants.AffiliateCommissionStatusAvailable})
+	if err != nil || len(rows) != 0 {
+		t.Fatalf("expected all commissions of canceled order rejected, got %d err=%v", len(rows), err)
+	}
+}
+
+func TestHandleOrderPaidConvertsOrderCurrencyToBase(t *testing.T) {
+	dsn := fmt.Sprintf("file:affiliate_currency_%d?mode=memory&cache=shared", time.Now().UnixNano())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite failed: %v", err)
+	}
+	if err := db.AutoMigrate(
+		&userdomain.User{}, &orderdomain.Order{}, &affiliatedomain.Profile{}, &affiliatedomain.Commission{},
+		&affiliatedomain.CommissionRule{}, &affiliatedomain.CommissionTier{},
+	); err != nil {
+		t.Fatalf("auto migrate failed: %v", err)
+	}
+	settingSvc := settingsapp.NewService(memorysettings.New())
+	if _, err := settingSvc.UpdateAffiliateSetting(settingsintegration.AffiliateSetting{
+		Enabled:        true,
+		CommissionRate: 10,
+	}, 1); err != nil {
+		t.Fatalf("init affiliate setting failed: %v", err)
+	}
+
+	user := createAffiliateTestUser(t, db, "affiliate-jpy@example.com")
+	profile := createAffiliateTestProfile(t, db, user.ID, "AFFJPY01", constants.AffiliateProfileStatusActive)
+	orders := map[uint]*orderdomain.Order{}
+	products := []productdomain.Product{{ID: 1, CategoryID: 7, IsAffiliateEnabled: true}}
+	store := affiliategormstore.New(db)
+	svc := affiliateapp.NewService(store, userstore.New(db), commissionOrderReaderStub{orders: orders}, commissionProductReaderStub{products: products}, settingSvc)
+	if _, err := svc.ReplaceCommissionTiers([]affiliateapp.CommissionTierInput{
+		{MinMonthlyGMV: decimal.NewFromInt(100), RatePercent: decimal.NewFromInt(15)},
+	}); err != nil {
+		t.Fatalf("replace tiers failed: %v", err)
+	}
+
+	paidAt := time.Now()
+	newOrder := func(id uint, no string) *orderdomain.Order {
+		order := &orderdomain.Order{
+			ID: id, OrderNo: no, AffiliateProfileID: &profile.ID, PaidAt: &paidAt,
+			Currency: "JPY", ExchangeRate: decimal.NewFromInt(20),
+			TotalAmount: money.FromDecimal(decimal.NewFromInt(1000)),
+			Items: []orderdomain.OrderItem{
+				{ProductID: 1, TotalPrice: money.FromDecimal(decimal.NewFromInt(1000))},
+			},
+		}
+		orders[id] = order
+		return order
+	}
+
+	// 1000 JPY 按汇率 20 折算为基准币种 50，佣金按全局 10% 计 5
+	first := newOrder(201, "J-201")
+	if err := svc.HandleOrderPaid(first.ID); err != nil {
+		t.Fatalf("handle first order failed: %v", err)
+	}
+	assertOrderCommission(t, store, first.ID, profile.ID, constants.AffiliateCommissionTypeOrder, "50.00", "5.00", "10.00")
+
+	// 当月业绩按基准币种累计为 50，未达到阶梯门槛 100
+	second := newOrder(202, "J-202")
+	if err := svc.HandleOrderPaid(second.ID); err != nil {
+		t.Fatalf("handle second order failed: %v", err)
+	}
+	assertOrderCommission(t, store, second.ID, profile.ID, constants.AffiliateCommissionTypeOrder, "50.00", "5.00", "10.00")
+
+	// 累计达到 100 后才升至阶梯 15%
+	third := newOrder(203, "J-203")
+	if err := svc.HandleOrderPaid(third.ID); err != nil {
+		t.Fatalf("handle third order failed: %v", err)
+	}
+	assertOrderCommission(t, store, third.ID, profile.ID, constants.AffiliateCommissionTypeOrder, "50.00", "7.50", "15.00")
+}
+
+func assertOrderCommission(t *testing.T, store affiliatecontract.Store, orderID, profileID uint, commissionType, base, amount, rate string) {
+	t.Helper()
+	row, err := store.GetCommissionByOrderAndProfile(orderID, profileID, commissionType)
+	if err != nil || row == nil {
+		t.Fatalf("expected %s commission for order %d profile %d, got %+v err=%v", commissionType, orderID, profileID, row, err)
+	}
+	if row.BaseAmount.Decimal.StringFixed(2) != base || row.CommissionAmount.Decimal.StringFixed(2) != amount || row.RatePercent.Decimal.StringFixed(2) != rate {
+		t.Fatalf("unexpected %s commission base=%s amount=%s rate=%s", commissionType,
+			row.BaseAmount.Decimal.StringFixed(2), row.CommissionAmount.Decimal.StringFixed(2), row.RatePercent.Decimal.StringFixed(2))
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/dujiao-next/internal/platform/http/response"
 
 	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
 )
 
 // AdminService 是后台推广返利管理端口。
@@ -22,6 +23,12 @@ type AdminService interface {
 	UpdateAffiliateProfileStatus(profileID uint, status string) (*affiliatedomain.Profile, error)
 	BatchUpdateAffiliateProfileStatus(profileIDs []uint, status string) (int64, error)
 	ReviewWithdraw(adminID, withdrawID uint, action, reason string) (*affiliatedomain.WithdrawRequest, error)
+	ListCommissionRules(scope string) ([]affiliatedomain.CommissionRule, error)
+	CreateCommissionRule(input affiliateapp.CommissionRuleInput) (*affiliatedomain.CommissionRule, error)
+	UpdateCommissionRule(id uint, input affiliateapp.CommissionRuleInput) (*affiliatedomain.CommissionRule, error)
+	DeleteCommissionRule(id uint) error
+	ListCommissionTiers() ([]affiliatedomain.CommissionTier, error)
+	ReplaceCommissionTiers(inputs []affiliateapp.CommissionTierInput) ([]affiliatedomain.CommissionTier, error)
 }
 
 type profileStatusRequest struct {
@@ -37,6 +44,22 @@ type reviewWithdrawRequest struct {
 	Reason string `json:"reason"`
 }
 
+type commissionRuleRequest struct {
+	Scope       string          `json:"scope"`
+	TargetID    uint            `json:"target_id"`
+	RatePercent decimal.Decimal `json:"rate_percent" binding:"required"`
+	Remark      string          `json:"remark"`
+}
+
+type commissionTierRequest struct {
+	MinMonthlyGMV decimal.Decimal `json:"min_monthly_gmv"`
+	RatePercent   decimal.Decimal `json:"rate_percent"`
+}
+
+type commissionTiersRequest struct {
+	Tiers []commissionTierRequest `json:"tiers"`
+}
+
 // AdminHandler 处理后台推广返利管理请求。
 type AdminHandler struct {
 	svc AdminService
@@ -161,6 +184,32 @@ func (h *AdminHandler) BatchUpdateAffiliateUserStatus(c *gin.Context) {
 	response.Success(c, gin.H{"updated": updated})
 }
 
+// ApproveAffiliateWithdraw 审核通过提现申请，等待单笔打款或加入打款批次
+func (h *AdminHandler) ApproveAffiliateWithdraw(c *gin.Context) {
+	adminID, ok := ginutil.GetAdminID(c)
+	if !ok {
+		return
+	}
+	id, err := ginutil.ParseParamUint(c, "id")
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", nil)
+		return
+	}
+	row, err := h.svc.ReviewWithdraw(adminID, id, constants.AffiliateWithdrawActionApprove, "")
+	if err != nil {
+		switch {
+		case errors.Is(err, affiliateapp.ErrNotFound):
+			ginutil.RespondError(c, response.CodeNotFound, "error.bad_request", nil)
+		case errors.Is(err, affiliateapp.ErrWithdrawStatusInvalid):
+			ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", nil)
+		default:
+			ginutil.RespondError(c, response.CodeInternal, "error.save_failed", err)
+		}
+		return
+	}
+	response.Success(c, row)
+}
+
 // RejectAffiliateWithdraw 拒绝提现申请
 func (h *AdminHandler) RejectAffiliateWithdraw(c *gin.Context) {
 	adminID, ok := ginutil.GetAdminID(c)
@@ -218,3 +267,115 @@ func (h *AdminHandler) PayAffiliateWithdraw(c *gin.Context) {
 	}
 	response.Success(c, row)
 }
+
+// ListCommissionRules 管理端佣金规则列表
+func (h *AdminHandler) ListCommissionRules(c *gin.Context) {
+	rows, err := h.svc.ListCommissionRules(strings.TrimSpace(c.Query("scope")))
+	if err != nil {
+		respondCommissionRuleError(c, err)
+		return
+	}
+	response.Success(c, rows)
+}
+
+// CreateCommissionRule 管理端新增佣金规则
+func (h *AdminHandler) CreateCommissionRule(c *gin.Context) {
+	var req commissionRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ginutil.RespondBindError(c, err)
+		return
+	}
+	row, err := h.svc.CreateCommissionRule(affiliateapp.CommissionRuleInput{
+		Scope:       req.Scope,
+		TargetID:    req.TargetID,
+		RatePercent: req.RatePercent,
+		Remark:      req.Remark,
+	})
+	if err != nil {
+		respondCommissionRuleError(c, err)
+		return
+	}
+	response.Success(c, row)
+}
+
+// UpdateCommissionRule 管理端更新佣金规则
+func (h *AdminHandler) UpdateCommissionRule(c *gin.Context) {
+	id, err := ginutil.ParseParamUint(c, "id")
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", nil)
+		return
+	}
+	var req commissionRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ginutil.RespondBindError(c, err)
+		return
+	}
+	row, err := h.svc.UpdateCommissionRule(id, affiliateapp.CommissionRuleInput{
+		RatePercent: req.RatePercent,
+		Remark:      req.Remark,
+	})
+	if err != nil {
+		respondCommissionRuleError(c, err)
+		return
+	}
+	response.Success(c, row)
+}
+
+// DeleteCommissionRule 管理端删除佣金规则
+func (h *AdminHandler) DeleteCommissionRule(c *gin.Context) {
+	id, err := ginutil.ParseParamUint(c, "id")
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", nil)
+		return
+	}
+	if err := h.svc.DeleteCommissionRule(id); err != nil {
+		respondCommissionRuleError(c, err)
+		return
+	}
+	response.Success(c, nil)
+}
+
+// ListCommissionTiers 管理端业绩阶梯列表
+func (h *AdminHandler) ListCommissionTiers(c *gin.Context) {
+	rows, err := h.svc.ListCommissionTiers()
+	if err != nil {
+		ginutil.RespondError(c, response.CodeInternal, "error.settings_fetch_failed", err)
+		return
+	}
+	response.Success(c, rows)
+}
+
+// ReplaceCommissionTiers 管理端整体保存业绩阶梯
+func (h *AdminHandler) ReplaceCommissionTiers(c *gin.Context) {
+	var req commissionTiersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ginutil.RespondBindError(c, err)
+		return
+	}
+	inputs := make([]affiliateapp.CommissionTierInput, 0, len(req.Tiers))
+	for _, tier := range req.Tiers {
+		inputs = append(inputs, affiliateapp.CommissionTierInput{
+			MinMonthlyGMV: tier.MinMonthlyGMV,
+			RatePercent:   tier.RatePercent,
+		})
+	}
+	rows, err := h.svc.ReplaceCommissionTiers(inputs)
+	if err != nil {
+		respondCommissionRuleError(c, err)
+		return
+	}
+	response.Success(c, rows)
+}
+
+func respondCommissionRuleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, affiliateapp.ErrNotFound):
+		ginutil.RespondError(c, response.CodeNotFound, "error.bad_request", nil)
+	case errors.Is(err, affiliateapp.ErrCommissionRuleInvalid), errors.Is(err, affiliateapp.ErrCommissionTierInvalid):
+		ginutil.RespondError(c, response.CodeBadRequest, "error.affiliate_commission_rule_invalid", nil)
+	case errors.Is(err, affiliateapp.ErrCommissionRuleExists):
+		ginutil.RespondError(c, response.CodeBadRequest, "error.affiliate_commission_rule_exists", nil)
+	default:
+		ginutil.RespondError(c, response.CodeInternal, "error.save_failed", err)
+	}
+}
@@ -27,10 +27,21 @@ func RegisterAdminRoutes(admin gin.IRoutes, handler *AdminHandler) {
 func RegisterAdminFinanceRoutes(admin gin.IRoutes, handler *AdminHandler) {
 	admin.GET("/affiliates/commissions", handler.ListAffiliateCommissions)
 	admin.GET("/affiliates/withdraws", handler.ListAffiliateWithdraws)
+	admin.POST("/affiliates/withdraws/:id/approve", handler.ApproveAffiliateWithdraw)
 	admin.POST("/affiliates/withdraws/:id/reject", handler.RejectAffiliateWithdraw)
 	admin.POST("/affiliates/withdraws/:id/pay", handler.PayAffiliateWithdraw)
 }
 
+// RegisterAdminCommissionRuleRoutes 注册后台佣金规则与业绩阶梯路由。
+func RegisterAdminCommissionRuleRoutes(admin gin.IRoutes, handler *AdminHandler) {
+	admin.GET("/affiliates/commission-rules", handler.ListCommissionRules)
+	admin.POST("/affiliates/commission-rules", handler.CreateCommissionRule)
+	admin.PUT("/affiliates/commission-rules/:id", handler.UpdateCommissionRule)
+	admin.DELETE("/affiliates/commission-rules/:id", handler.DeleteCommissionRule)
+	admin.GET("/affiliates/commission-tiers", handler.ListCommissionTiers)
+	admin.PUT("/affiliates/commission-tiers", handler.ReplaceCommissionTiers)
+}
+
 // RegisterChannelRoutes 注册渠道推广返利路由。
 func RegisterChannelRoutes(channel gin.IRoutes, handler *ChannelHandler) {
 	channel.POST("/affiliate/click", handler.TrackAffiliateClick)
@@ -0,0 +1,131 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/bits"
+	"strings"
+	"time"
+
+	"github.com/dujiao-next/internal/constants"
+	"github.com/dujiao-next/internal/modules/captcha/contract"
+	settingssecurity "github.com/dujiao-next/internal/modules/settings/schema/security"
+)
+
+// PoWAlgorithm 是下发给客户端的哈希算法标识。
+const PoWAlgorithm = "SHA-256"
+
+const powNonceMaxLength = 64
+
+// SetPoWChallengeStore 注入工作量证明挑战存储（Redis）。
+func (s *Service) SetPoWChallengeStore(store contract.PoWChallengeStore) {
+	if s == nil {
+		return
+	}
+	s.powStore = store
+}
+
+// GeneratePoWChallenge 生成工作量证明挑战。
+// requestCount 为限流器在当前窗口内对该 IP 的计数，请求越频繁难度越高。
+func (s *Service) GeneratePoWChallenge(ctx context.Context, scene string, requestCount int64) (*contract.PoWChallenge, error) {
+	setting, err := s.getSetting()
+	if err != nil {
+		return nil, err
+	}
+	scene = strings.ToLower(strings.TrimSpace(scene))
+	if setting.Provider != constants.CaptchaProviderPoW || !setting.IsSceneEnabled(scene) || s.powStore == nil {
+		return nil, contract.ErrConfigInvalid
+	}
+
+	challengeID, err := randomHex(16)
+	if err != nil {
+		return nil, err
+	}
+	challenge, err := randomHex(16)
+	if err != nil {
+		return nil, err
+	}
+	difficulty := powDifficulty(setting.PoW, requestCount)
+	ttl := time.Duration(setting.PoW.ExpireSeconds) * time.Second
+	record := contract.PoWChallengeRecord{
+		Challenge:  challenge,
+		Scene:      scene,
+		Difficulty: difficulty,
+	}
+	if err := s.powStore.Put(ctx, challengeID, record, ttl); err != nil {
+		return nil, err
+	}
+	return &contract.PoWChallenge{
+		ChallengeID: challengeID,
+		Challenge:   challenge,
+		Algorithm:   PoWAlgorithm,
+		Difficulty:  difficulty,
+		ExpiresAt:   time.Now().Add(ttl),
+	}, nil
+}
+
+// verifyPoW 校验工作量证明；挑战无论成功与否都会被消费，防止重放与针对同一挑战的多次尝试。
+func (s *Service) verifyPoW(scene string, payload contract.VerifyPayload) error {
+	challengeID := strings.TrimSpace(payload.PoWChallengeID)
+	nonce := strings.TrimSpace(payload.PoWNonce)
+	if challengeID == "" || nonce == "" {
+		return contract.ErrRequired
+	}
+	if s.powStore == nil {
+		return contract.ErrVerifyFailed
+	}
+	record, err := s.powStore.Take(context.Background(), challengeID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", contract.ErrVerifyFailed, err)
+	}
+	if record == nil || record.Scene != strings.ToLower(strings.TrimSpace(scene)) {
+		return contract.ErrInvalid
+	}
+	if len(nonce) > powNonceMaxLength || !powSolved(record.Challenge, nonce, record.Difficulty) {
+		return contract.ErrInvalid
+	}
+	return nil
+}
+
+// powDifficulty 每 RateStep 次请求提高 1 比特难度，最高不超过 MaxDifficulty。
+func powDifficulty(setting settingssecurity.CaptchaPoWSetting, requestCount int64) int {
+	difficulty := setting.BaseDifficulty
+	if setting.RateStep > 0 && requestCount > 0 {
+		difficulty += int((requestCount - 1) / int64(setting.RateStep))
+	}
+	if difficulty > setting.MaxDifficulty {
+		difficulty = setting.MaxDifficulty
+	}
+	if difficulty < setting.BaseDifficulty {
+		difficulty = setting.BaseDifficulty
+	}
+	return difficulty
+}
+
+func powSolved(challenge, nonce string, difficulty int) bool {
+	sum := sha256.Sum256([]byte(challenge + ":" + nonce))
+	return leadingZeroBits(sum[:]) >= difficulty
+}
+
+func leadingZeroBits(digest []byte) int {
+	count := 0
+	for _, b := range digest {
+		if b == 0 {
+			count += 8
+			continue
+		}
+		return count + bits.LeadingZeros8(b)
+	}
+	return count
+}
+
+func randomHex(size int) (string, error) {
+	buf := make([]byte, size)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -17,15 +17,16 @@ import (
 // Service 验证码服务
 // 负责统一读取配置、生成挑战与执行校验
 // 按场景开关决定是否需要验证码
-// 对图片验证码与 Turnstile 进行统一封装
+// 对图片验证码、Turnstile 与工作量证明进行统一封装
 // 外部仅需要调用 Verify(scene, payload, clientIP)
-// 以及图片模式下调用 GenerateImageChallenge
+// 以及图片模式下调用 GenerateImageChallenge、工作量证明模式下调用 GeneratePoWChallenge
 //
 //nolint:govet
 type Service struct {
 	settingService contract.SettingReader
 	defaultConfig  config.CaptchaConfig
 	turnstile      contract.TurnstileVerifier
+	powStore       contract.PoWChallengeStore
 	cacheTTL       time.Duration
 
 	mu            sync.RWMutex
@@ -143,6 +144,8 @@ func (s *Service) Verify(scene string, payload contract.VerifyPayload, clientIP
 			return contract.ErrVerifyFailed
 		}
 		return s.turnstile.Verify(setting.Turnstile, token, strings.TrimSpace(clientIP))
+	case constants.CaptchaProviderPoW:
+		return s.verifyPoW(scene, payload)
 	case constants.CaptchaProviderNone:
 		return contract.ErrConfigInvalid
 	default:
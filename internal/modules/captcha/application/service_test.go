@@ -1,7 +1,12 @@
 package application
 
 import (
+	"context"
+	"crypto/sha256"
+	"strconv"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/dujiao-next/internal/config"
 	"github.com/dujiao-next/internal/constants"
@@ -74,3 +79,120 @@ func TestVerifySkipsDisabledScene(t *testing.T) {
 		t.Fatalf("disabled scene must skip captcha, got %v", err)
 	}
 }
+
+type powStoreStub struct {
+	mu      sync.Mutex
+	records map[string]contract.PoWChallengeRecord
+}
+
+func (s *powStoreStub) Put(_ context.Context, id string, record contract.PoWChallengeRecord, _ time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.records == nil {
+		s.records = map[string]contract.PoWChallengeRecord{}
+	}
+	s.records[id] = record
+	return nil
+}
+
+func (s *powStoreStub) Take(_ context.Context, id string) (*contract.PoWChallengeRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	record, ok := s.records[id]
+	if !ok {
+		return nil, nil
+	}
+	delete(s.records, id)
+	return &record, nil
+}
+
+func newPoWService(t *testing.T) *Service {
+	t.Helper()
+	setting := settingssecurity.NormalizeCaptchaSetting(settingssecurity.CaptchaSetting{
+		Provider: constants.CaptchaProviderPoW,
+		Scenes:   settingssecurity.CaptchaSceneSetting{Login: true, GuestCreateOrder: true},
+		PoW:      settingssecurity.CaptchaPoWSetting{BaseDifficulty: 8, MaxDifficulty: 10, RateStep: 2, ExpireSeconds: 60},
+	})
+	service := NewService(settingReaderStub{setting: setting}, config.CaptchaConfig{}, nil)
+	service.SetPoWChallengeStore(&powStoreStub{})
+	return service
+}
+
+func solvePoW(t *testing.T, challenge *contract.PoWChallenge) string {
+	t.Helper()
+	for i := 0; i < 1<<22; i++ {
+		nonce := strconv.Itoa(i)
+		sum := sha256.Sum256([]byte(challenge.Challenge + ":" + nonce))
+		if leadingZeroBits(sum[:]) >= challenge.Difficulty {
+			return nonce
+		}
+	}
+	t.Fatalf("no nonce found for difficulty %d", challenge.Difficulty)
+	return ""
+}
+
+func TestVerifyPoWAcceptsSolutionOnce(t *testing.T) {
+	service := newPoWService(t)
+	challenge, err := service.GeneratePoWChallenge(context.Background(), constants.CaptchaSceneLogin, 1)
+	if err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+	payload := contract.VerifyPayload{PoWChallengeID: challenge.ChallengeID, PoWNonce: solvePoW(t, challenge)}
+	if err := service.Verify(constants.CaptchaSceneLogin, payload, ""); err != nil {
+		t.Fatalf("valid solution rejected: %v", err)
+	}
+	if err := service.Verify(constants.CaptchaSceneLogin, payload, ""); err != contract.ErrInvalid {
+		t.Fatalf("replayed solution error got %v want ErrInvalid", err)
+	}
+}
+
+func TestVerifyPoWRejectsOtherSceneAndBurnsChallenge(t *testing.T) {
+	service := newPoWService(t)
+	challenge, err := service.GeneratePoWChallenge(context.Background(), constants.CaptchaSceneLogin, 1)
+	if err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+	payload := contract.VerifyPayload{PoWChallengeID: challenge.ChallengeID, PoWNonce: solvePoW(t, challenge)}
+	if err := service.Verify(constants.CaptchaSceneGuestCreateOrder, payload, ""); err != contract.ErrInvalid {
+		t.Fatalf("cross-scene solution error got %v want ErrInvalid", err)
+	}
+	if err := service.Verify(constants.CaptchaSceneLogin, payload, ""); err != contract.ErrInvalid {
+		t.Fatalf("challenge must be consumed by the first attempt, got %v", err)
+	}
+}
+
+func TestVerifyPoWRequiresPayload(t *testing.T) {
+	service := newPoWService(t)
+	if err := service.Verify(constants.CaptchaSceneLogin, contract.VerifyPayload{}, ""); err != contract.ErrRequired {
+		t.Fatalf("empty pow payload error got %v want ErrRequired", err)
+	}
+}
+
+func TestGeneratePoWChallengeScalesDifficultyWithRequestRate(t *testing.T) {
+	service := newPoWService(t)
+	for _, tc := range []struct {
+		count int64
+		want  int
+	}{
+		{count: 0, want: 8},
+		{count: 2, want: 8},
+		{count: 3, want: 9},
+		{count: 5, want: 10},
+		{count: 100, want: 10},
+	} {
+		challenge, err := service.GeneratePoWChallenge(context.Background(), constants.CaptchaSceneLogin, tc.count)
+		if err != nil {
+			t.Fatalf("generate failed: %v", err)
+		}
+		if challenge.Difficulty != tc.want {
+			t.Fatalf("count %d difficulty got %d want %d", tc.count, challenge.Difficulty, tc.want)
+		}
+	}
+}
+
+func TestGeneratePoWChallengeRejectsDisabledScene(t *testing.T) {
+	service := newPoWService(t)
+	if _, err := service.GeneratePoWChallenge(context.Background(), constants.CaptchaSceneGiftCardRedeem, 1); err != contract.ErrConfigInvalid {
+		t.Fatalf("disabled scene error got %v want ErrConfigInvalid", err)
+	}
+}
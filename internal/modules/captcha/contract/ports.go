@@ -1,6 +1,9 @@
 package contract
 
 import (
+	"context"
+	"time"
+
 	"github.com/dujiao-next/internal/config"
 	settingssecurity "github.com/dujiao-next/internal/modules/settings/schema/security"
 )
@@ -14,3 +17,9 @@ type SettingReader interface {
 type TurnstileVerifier interface {
 	Verify(cfg settingssecurity.CaptchaTurnstileSetting, token, clientIP string) error
 }
+
+// PoWChallengeStore 保存工作量证明挑战；Take 必须原子地读取并删除，保证每个挑战只能校验一次。
+type PoWChallengeStore interface {
+	Put(ctx context.Context, challengeID string, record PoWChallengeRecord, ttl time.Duration) error
+	Take(ctx context.Context, challengeID string) (*PoWChallengeRecord, error)
+}
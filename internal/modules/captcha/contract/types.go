@@ -1,10 +1,14 @@
 package contract
 
+import "time"
+
 // VerifyPayload 是验证码校验输入。
 type VerifyPayload struct {
 	CaptchaID      string
 	CaptchaCode    string
 	TurnstileToken string
+	PoWChallengeID string
+	PoWNonce       string
 }
 
 // ImageChallenge 是图片验证码挑战。
@@ -12,3 +16,20 @@ type ImageChallenge struct {
 	CaptchaID   string
 	ImageBase64 string
 }
+
+// PoWChallenge 是下发给客户端的工作量证明挑战。
+// 客户端需找到 nonce 使 SHA-256(challenge + ":" + nonce) 的前导零比特数不少于 Difficulty。
+type PoWChallenge struct {
+	ChallengeID string
+	Challenge   string
+	Algorithm   string
+	Difficulty  int
+	ExpiresAt   time.Time
+}
+
+// PoWChallengeRecord 是服务端保存的挑战状态。
+type PoWChallengeRecord struct {
+	Challenge  string `json:"challenge"`
+	Scene      string `json:"scene"`
+	Difficulty int    `json:"difficulty"`
+}
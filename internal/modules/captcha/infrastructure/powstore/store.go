@@ -0,0 +1,43 @@
+package powstore
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/dujiao-next/internal/cache"
+	"github.com/dujiao-next/internal/modules/captcha/contract"
+)
+
+const challengeKeyPrefix = "captcha:pow:"
+
+const maxChallengeIDLength = 64
+
+var errInvalidChallengeID = errors.New("invalid pow challenge id")
+
+// Store 在 Redis 中保存工作量证明挑战；Take 使用 GETDEL，同一挑战只能被校验一次。
+type Store struct{}
+
+var _ contract.PoWChallengeStore = Store{}
+
+// New 创建工作量证明挑战存储。
+func New() Store { return Store{} }
+
+func (Store) Put(ctx context.Context, challengeID string, record contract.PoWChallengeRecord, ttl time.Duration) error {
+	if challengeID == "" || len(challengeID) > maxChallengeIDLength {
+		return errInvalidChallengeID
+	}
+	return cache.SetJSONRequired(ctx, challengeKeyPrefix+challengeID, record, ttl)
+}
+
+func (Store) Take(ctx context.Context, challengeID string) (*contract.PoWChallengeRecord, error) {
+	if challengeID == "" || len(challengeID) > maxChallengeIDLength {
+		return nil, nil
+	}
+	var record contract.PoWChallengeRecord
+	found, err := cache.GetDelJSONRequired(ctx, challengeKeyPrefix+challengeID, &record)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &record, nil
+}
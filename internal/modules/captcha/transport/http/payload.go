@@ -11,6 +11,8 @@ type CaptchaPayloadRequest struct {
 	CaptchaID      string `json:"captcha_id"`
 	CaptchaCode    string `json:"captcha_code"`
 	TurnstileToken string `json:"turnstile_token"`
+	PoWChallengeID string `json:"pow_challenge_id"`
+	PoWNonce       string `json:"pow_nonce"`
 }
 
 // ToCaptchaPayload 转换为验证码模块载荷。
@@ -19,5 +21,7 @@ func (r CaptchaPayloadRequest) ToCaptchaPayload() captchacontract.VerifyPayload
 		CaptchaID:      strings.TrimSpace(r.CaptchaID),
 		CaptchaCode:    strings.TrimSpace(r.CaptchaCode),
 		TurnstileToken: strings.TrimSpace(r.TurnstileToken),
+		PoWChallengeID: strings.TrimSpace(r.PoWChallengeID),
+		PoWNonce:       strings.TrimSpace(r.PoWNonce),
 	}
 }
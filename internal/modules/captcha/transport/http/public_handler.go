@@ -1,6 +1,7 @@
 package captchahttp
 
 import (
+	"context"
 	"errors"
 
 	captchacontract "github.com/dujiao-next/internal/modules/captcha/contract"
@@ -15,13 +16,19 @@ type ImageChallengeGenerator interface {
 	GenerateImageChallenge() (*captchacontract.ImageChallenge, error)
 }
 
+// PoWChallengeGenerator 生成工作量证明挑战。
+type PoWChallengeGenerator interface {
+	GeneratePoWChallenge(ctx context.Context, scene string, requestCount int64) (*captchacontract.PoWChallenge, error)
+}
+
 // PublicHandler 处理公开验证码请求。
 type PublicHandler struct {
 	generator ImageChallengeGenerator
+	pow       PoWChallengeGenerator
 }
 
-func NewPublicHandler(generator ImageChallengeGenerator) *PublicHandler {
-	return &PublicHandler{generator: generator}
+func NewPublicHandler(generator ImageChallengeGenerator, pow PoWChallengeGenerator) *PublicHandler {
+	return &PublicHandler{generator: generator, pow: pow}
 }
 
 // GetImageCaptcha 获取图片验证码挑战。
@@ -47,3 +54,28 @@ func (h *PublicHandler) GetImageCaptcha(c *gin.Context) {
 		"image_base64": challenge.ImageBase64,
 	})
 }
+
+// GetPoWChallenge 获取工作量证明挑战；难度随限流窗口内的请求次数提升。
+func (h *PublicHandler) GetPoWChallenge(c *gin.Context) {
+	if h == nil || h.pow == nil {
+		ginutil.RespondError(c, response.CodeInternal, "error.captcha_unavailable", captchacontract.ErrConfigInvalid)
+		return
+	}
+	challenge, err := h.pow.GeneratePoWChallenge(c.Request.Context(), c.Query("scene"), ginutil.GetRateLimitCount(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, captchacontract.ErrConfigInvalid):
+			ginutil.RespondError(c, response.CodeBadRequest, "error.captcha_unavailable", nil)
+		default:
+			ginutil.RespondError(c, response.CodeInternal, "error.captcha_generate_failed", err)
+		}
+		return
+	}
+	response.Success(c, gin.H{
+		"challenge_id": challenge.ChallengeID,
+		"challenge":    challenge.Challenge,
+		"algorithm":    challenge.Algorithm,
+		"difficulty":   challenge.Difficulty,
+		"expires_at":   challenge.ExpiresAt,
+	})
+}
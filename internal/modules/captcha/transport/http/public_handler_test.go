@@ -27,7 +27,7 @@ func TestGetImageCaptchaSuccess(t *testing.T) {
 	handler := NewPublicHandler(fakeGenerator{challenge: &captchacontract.ImageChallenge{
 		CaptchaID:   "id-1",
 		ImageBase64: "data:image/png;base64,abc",
-	}})
+	}}, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -50,7 +50,7 @@ func TestGetImageCaptchaSuccess(t *testing.T) {
 
 func TestGetImageCaptchaUnavailableWhenConfigInvalid(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	handler := NewPublicHandler(fakeGenerator{err: captchacontract.ErrConfigInvalid})
+	handler := NewPublicHandler(fakeGenerator{err: captchacontract.ErrConfigInvalid}, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -69,7 +69,7 @@ func TestGetImageCaptchaUnavailableWhenConfigInvalid(t *testing.T) {
 
 func TestGetImageCaptchaUnavailableWithoutGenerator(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	handler := NewPublicHandler(nil)
+	handler := NewPublicHandler(nil, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -88,7 +88,7 @@ func TestGetImageCaptchaUnavailableWithoutGenerator(t *testing.T) {
 
 func TestGetImageCaptchaMapsUnknownErrors(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	handler := NewPublicHandler(fakeGenerator{err: errors.New("boom")})
+	handler := NewPublicHandler(fakeGenerator{err: errors.New("boom")}, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
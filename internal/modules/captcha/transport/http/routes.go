@@ -6,3 +6,8 @@ import "github.com/gin-gonic/gin"
 func RegisterPublicRoutes(public gin.IRoutes, handler *PublicHandler) {
 	public.GET("/captcha/image", handler.GetImageCaptcha)
 }
+
+// RegisterPublicPoWRoutes 注册工作量证明挑战路由；rateLimit 的计数同时用于调整难度。
+func RegisterPublicPoWRoutes(public gin.IRoutes, handler *PublicHandler, rateLimit gin.HandlerFunc) {
+	public.GET("/captcha/pow", rateLimit, handler.GetPoWChallenge)
+}
@@ -21,6 +21,7 @@ type ExportAvailableCardSecretInput struct {
 	Limit             int
 	Format            string
 	DeleteAfterExport bool
+	AdminID           uint
 }
 
 // ExportAvailableCardSecretResult 可用卡密出库导出结果。
@@ -89,12 +90,12 @@ func (s *Service) ExportAvailableCardSecrets(input ExportAvailableCardSecretInpu
 
 		var affected int64
 		if input.DeleteAfterExport {
-			affected, err = secretRepo.BatchDeleteByIDs(ids)
+			affected, err = secretRepo.BatchDeleteByIDs(ids, input.AdminID)
 			if err != nil {
 				return ErrDeleteFailed
 			}
 		} else {
-			affected, err = secretRepo.BatchUpdateStatus(ids, cardsecretdomain.StatusUsed, time.Now())
+			affected, err = secretRepo.BatchUpdateStatus(ids, cardsecretdomain.StatusUsed, time.Now(), input.AdminID)
 			if err != nil {
 				return ErrUpdateFailed
 			}
@@ -111,6 +111,9 @@ func (s *Service) CreateCardSecretBatch(input CreateCardSecretBatchInput) (*card
 		}
 		return nil, 0, ErrCreateFailed
 	}
+	if s.restockTrigger != nil {
+		s.restockTrigger.TriggerCheck(batch.ProductID)
+	}
 	return batch, batch.TotalCount, nil
 }
 
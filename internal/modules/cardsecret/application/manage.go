@@ -71,7 +71,7 @@ func (s *Service) hasListFilter(input ListCardSecretInput) bool {
 }
 
 // BatchUpdateCardSecretStatus 批量更新卡密状态
-func (s *Service) BatchUpdateCardSecretStatus(ids []uint, batchID uint, filter ListCardSecretInput, status string) (int64, error) {
+func (s *Service) BatchUpdateCardSecretStatus(ids []uint, batchID uint, filter ListCardSecretInput, status string, adminID uint) (int64, error) {
 	normalizedStatus := strings.TrimSpace(status)
 	switch normalizedStatus {
 	case cardsecretdomain.StatusAvailable, cardsecretdomain.StatusReserved, cardsecretdomain.StatusUsed:
@@ -82,7 +82,7 @@ func (s *Service) BatchUpdateCardSecretStatus(ids []uint, batchID uint, filter L
 	if err != nil {
 		return 0, err
 	}
-	rows, err := s.secretRepo.BatchUpdateStatus(normalizedIDs, normalizedStatus, time.Now())
+	rows, err := s.secretRepo.BatchUpdateStatus(normalizedIDs, normalizedStatus, time.Now(), adminID)
 	if err != nil {
 		return 0, ErrUpdateFailed
 	}
@@ -90,12 +90,12 @@ func (s *Service) BatchUpdateCardSecretStatus(ids []uint, batchID uint, filter L
 }
 
 // BatchDeleteCardSecrets 批量删除卡密
-func (s *Service) BatchDeleteCardSecrets(ids []uint, batchID uint, filter ListCardSecretInput) (int64, error) {
+func (s *Service) BatchDeleteCardSecrets(ids []uint, batchID uint, filter ListCardSecretInput, adminID uint) (int64, error) {
 	normalizedIDs, err := s.resolveBatchTargetCardSecretIDs(ids, batchID, filter)
 	if err != nil {
 		return 0, err
 	}
-	rows, err := s.secretRepo.BatchDeleteByIDs(normalizedIDs)
+	rows, err := s.secretRepo.BatchDeleteByIDs(normalizedIDs, adminID)
 	if err != nil {
 		return 0, ErrDeleteFailed
 	}
@@ -103,7 +103,7 @@ func (s *Service) BatchDeleteCardSecrets(ids []uint, batchID uint, filter ListCa
 }
 
 // UpdateCardSecret 更新卡密
-func (s *Service) UpdateCardSecret(id uint, secret, status string) (*cardsecretdomain.Secret, error) {
+func (s *Service) UpdateCardSecret(id uint, secret, status string, adminID uint) (*cardsecretdomain.Secret, error) {
 	if id == 0 {
 		return nil, ErrInvalid
 	}
@@ -128,7 +128,7 @@ func (s *Service) UpdateCardSecret(id uint, secret, status string) (*cardsecretd
 		}
 	}
 	item.UpdatedAt = time.Now()
-	if err := s.secretRepo.Update(item); err != nil {
+	if err := s.secretRepo.Update(item, adminID); err != nil {
 		return nil, ErrUpdateFailed
 	}
 	return item, nil
@@ -18,6 +18,11 @@ type ServiceOptions struct {
 	ProductSKUs  cardsecretcontract.ProductSKURepository
 }
 
+// RestockTrigger 在卡密入库后触发到货提醒检查。
+type RestockTrigger interface {
+	TriggerCheck(productID uint)
+}
+
 // Service 卡密库存服务。
 type Service struct {
 	secretRepo     cardsecretcontract.Repository
@@ -25,6 +30,7 @@ type Service struct {
 	transactions   cardsecretcontract.UnitOfWork
 	productRepo    cardsecretcontract.ProductRepository
 	productSKURepo cardsecretcontract.ProductSKURepository
+	restockTrigger RestockTrigger
 }
 
 func NewService(options ServiceOptions) *Service {
@@ -37,6 +43,11 @@ func NewService(options ServiceOptions) *Service {
 	}
 }
 
+// SetRestockTrigger 注入到货提醒触发器（容器装配时调用）。
+func (s *Service) SetRestockTrigger(trigger RestockTrigger) {
+	s.restockTrigger = trigger
+}
+
 func (s *Service) resolveCardSecretSKU(productID, rawSKUID uint) (*productdomain.ProductSKU, error) {
 	if productID == 0 || s.productSKURepo == nil {
 		return nil, ErrProductSKUInvalid
@@ -20,9 +20,9 @@ type Repository interface {
 	ListAvailableByProductForUpdate(productID, skuID uint, limit int) ([]cardsecretdomain.Secret, error)
 	ListAvailableByProductBatchForUpdate(productID, skuID, batchID uint, limit int) ([]cardsecretdomain.Secret, error)
 	GetByID(id uint) (*cardsecretdomain.Secret, error)
-	Update(secret *cardsecretdomain.Secret) error
-	BatchUpdateStatus(ids []uint, status string, updatedAt time.Time) (int64, error)
-	BatchDeleteByIDs(ids []uint) (int64, error)
+	Update(secret *cardsecretdomain.Secret, adminID uint) error
+	BatchUpdateStatus(ids []uint, status string, updatedAt time.Time, adminID uint) (int64, error)
+	BatchDeleteByIDs(ids []uint, adminID uint) (int64, error)
 	CountByProduct(productID, skuID uint) (int64, int64, int64, error)
 	CountAvailable(productID, skuID uint) (int64, error)
 	CountAvailableByProductIDs(productIDs []uint) (map[uint]int64, error)
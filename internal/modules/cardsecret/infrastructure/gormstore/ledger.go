@@ -0,0 +1,103 @@
+package gormstore
+
+import (
+	cardsecretdomain "github.com/dujiao-next/internal/modules/cardsecret/domain"
+	stockledgerdomain "github.com/dujiao-next/internal/modules/stockledger/domain"
+	stockledgergormstore "github.com/dujiao-next/internal/modules/stockledger/infrastructure/gormstore"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type secretState struct {
+	ID        uint
+	ProductID uint
+	SKUID     uint `gorm:"column:sku_id"`
+	Status    string
+}
+
+// lockSecretStates 锁定即将变更的卡密并读取变更前的状态，用于计算台账增量。
+func lockSecretStates(tx *gorm.DB, query string, args ...interface{}) ([]secretState, error) {
+	var states []secretState
+	err := tx.Model(&cardsecretdomain.Secret{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id, product_id, sku_id, status").
+		Where(query, args...).
+		Scan(&states).Error
+	return states, err
+}
+
+// recordSecretTransition 记录卡密状态迁移：原状态计数减一、新状态计数加一；toStatus 为空表示删除。
+func recordSecretTransition(tx *gorm.DB, states []secretState, toStatus string, base stockledgerdomain.Movement) error {
+	set := stockledgergormstore.NewMovementSet(withCardSecretSource(base))
+	for _, state := range states {
+		if state.Status == toStatus {
+			continue
+		}
+		set.Add(state.ProductID, state.SKUID, secretBucket(state.Status), -1)
+		if toStatus != "" {
+			set.Add(state.ProductID, state.SKUID, secretBucket(toStatus), 1)
+		}
+	}
+	return set.Record(tx)
+}
+
+// recordSecretCreation 记录新增卡密：带批次的为导入，其余为订单直接写入的交付记录。
+func recordSecretCreation(tx *gorm.DB, items []cardsecretdomain.Secret) error {
+	sets := make([]*stockledgergormstore.MovementSet, 0, 1)
+	byRef := make(map[stockledgerdomain.Movement]*stockledgergormstore.MovementSet)
+	for _, item := range items {
+		base := stockledgerdomain.Movement{Kind: stockledgerdomain.KindImport}
+		switch {
+		case item.BatchID != nil && *item.BatchID > 0:
+			base.RefType = stockledgerdomain.RefTypeBatch
+			base.RefID = *item.BatchID
+		case item.OrderID != nil && *item.OrderID > 0:
+			base.Kind = stockledgerdomain.KindConsume
+			if item.Status == cardsecretdomain.StatusReserved {
+				base.Kind = stockledgerdomain.KindLock
+			}
+			base.RefType = stockledgerdomain.RefTypeOrder
+			base.RefID = *item.OrderID
+		}
+		set, ok := byRef[base]
+		if !ok {
+			set = stockledgergormstore.NewMovementSet(withCardSecretSource(base))
+			byRef[base] = set
+			sets = append(sets, set)
+		}
+		set.Add(item.ProductID, item.SKUID, secretBucket(item.Status), 1)
+	}
+	for _, set := range sets {
+		if err := set.Record(tx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func withCardSecretSource(base stockledgerdomain.Movement) stockledgerdomain.Movement {
+	base.Source = stockledgerdomain.SourceCardSecret
+	return base
+}
+
+func secretBucket(status string) string {
+	switch status {
+	case cardsecretdomain.StatusAvailable:
+		return stockledgerdomain.BucketAvailable
+	case cardsecretdomain.StatusReserved:
+		return stockledgerdomain.BucketLocked
+	case cardsecretdomain.StatusUsed:
+		return stockledgerdomain.BucketSold
+	default:
+		return ""
+	}
+}
+
+func adminMovement(kind string, adminID uint) stockledgerdomain.Movement {
+	return stockledgerdomain.Movement{Kind: kind, RefType: stockledgerdomain.RefTypeAdmin, RefID: adminID}
+}
+
+func orderMovement(kind string, orderID uint) stockledgerdomain.Movement {
+	return stockledgerdomain.Movement{Kind: kind, RefType: stockledgerdomain.RefTypeOrder, RefID: orderID}
+}
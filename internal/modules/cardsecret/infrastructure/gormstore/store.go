@@ -7,6 +7,7 @@ import (
 
 	cardsecretcontract "github.com/dujiao-next/internal/modules/cardsecret/contract"
 	cardsecretdomain "github.com/dujiao-next/internal/modules/cardsecret/domain"
+	stockledgerdomain "github.com/dujiao-next/internal/modules/stockledger/domain"
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -46,7 +47,12 @@ func (r *Store) CreateBatch(items []cardsecretdomain.Secret) error {
 	if len(items) == 0 {
 		return nil
 	}
-	return r.db.CreateInBatches(&items, 200).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.CreateInBatches(&items, 200).Error; err != nil {
+			return err
+		}
+		return recordSecretCreation(tx, items)
+	})
 }
 
 func (r *Store) buildListQuery(filter cardsecretcontract.ListFilter) *gorm.DB {
@@ -234,38 +240,74 @@ func (r *Store) GetByID(id uint) (*cardsecretdomain.Secret, error) {
 	return &secret, nil
 }
 
-// Update 更新卡密
-func (r *Store) Update(secret *cardsecretdomain.Secret) error {
-	return r.db.Save(secret).Error
+// Update 更新卡密，状态变化记为后台调整流水
+func (r *Store) Update(secret *cardsecretdomain.Secret, adminID uint) error {
+	if secret == nil {
+		return errors.New("card secret is nil")
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		states, err := lockSecretStates(tx, "id = ? AND deleted_at IS NULL", secret.ID)
+		if err != nil {
+			return err
+		}
+		if err := tx.Save(secret).Error; err != nil {
+			return err
+		}
+		return recordSecretTransition(tx, states, secret.Status, adminMovement(stockledgerdomain.KindAdjust, adminID))
+	})
 }
 
 // BatchUpdateStatus 批量更新卡密状态
-func (r *Store) BatchUpdateStatus(ids []uint, status string, updatedAt time.Time) (int64, error) {
+func (r *Store) BatchUpdateStatus(ids []uint, status string, updatedAt time.Time, adminID uint) (int64, error) {
 	if len(ids) == 0 {
 		return 0, nil
 	}
 	if updatedAt.IsZero() {
 		updatedAt = time.Now()
 	}
-	result := r.db.Model(&cardsecretdomain.Secret{}).
-		Where("id IN ? AND deleted_at IS NULL", ids).
-		Updates(map[string]interface{}{
-			"status":     status,
-			"updated_at": updatedAt,
-		})
-	return result.RowsAffected, result.Error
+	var affected int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		states, err := lockSecretStates(tx, "id IN ? AND deleted_at IS NULL", ids)
+		if err != nil {
+			return err
+		}
+		result := tx.Model(&cardsecretdomain.Secret{}).
+			Where("id IN ? AND deleted_at IS NULL", ids).
+			Updates(map[string]interface{}{
+				"status":     status,
+				"updated_at": updatedAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		affected = result.RowsAffected
+		return recordSecretTransition(tx, states, status, adminMovement(stockledgerdomain.KindAdjust, adminID))
+	})
+	return affected, err
 }
 
 // BatchDeleteByIDs 批量删除卡密
-func (r *Store) BatchDeleteByIDs(ids []uint) (int64, error) {
+func (r *Store) BatchDeleteByIDs(ids []uint, adminID uint) (int64, error) {
 	if len(ids) == 0 {
 		return 0, nil
 	}
 	now := time.Now()
-	result := r.db.Model(&cardsecretdomain.Secret{}).
-		Where("id IN ? AND deleted_at IS NULL", ids).
-		Updates(map[string]interface{}{"deleted_at": now, "updated_at": now})
-	return result.RowsAffected, result.Error
+	var affected int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		states, err := lockSecretStates(tx, "id IN ? AND deleted_at IS NULL", ids)
+		if err != nil {
+			return err
+		}
+		result := tx.Model(&cardsecretdomain.Secret{}).
+			Where("id IN ? AND deleted_at IS NULL", ids).
+			Updates(map[string]interface{}{"deleted_at": now, "updated_at": now})
+		if result.Error != nil {
+			return result.Error
+		}
+		affected = result.RowsAffected
+		return recordSecretTransition(tx, states, "", adminMovement(stockledgerdomain.KindDelete, adminID))
+	})
+	return affected, err
 }
 
 // DeleteByProduct 删除指定商品下的所有卡密
@@ -274,9 +316,22 @@ func (r *Store) DeleteByProduct(productID uint) error {
 		return errors.New("invalid product id")
 	}
 	now := time.Now()
-	return r.db.Model(&cardsecretdomain.Secret{}).
-		Where("product_id = ? AND deleted_at IS NULL", productID).
-		Updates(map[string]interface{}{"deleted_at": now, "updated_at": now}).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		states, err := lockSecretStates(tx, "product_id = ? AND deleted_at IS NULL", productID)
+		if err != nil {
+			return err
+		}
+		if err := tx.Model(&cardsecretdomain.Secret{}).
+			Where("product_id = ? AND deleted_at IS NULL", productID).
+			Updates(map[string]interface{}{"deleted_at": now, "updated_at": now}).Error; err != nil {
+			return err
+		}
+		return recordSecretTransition(tx, states, "", stockledgerdomain.Movement{
+			Kind:    stockledgerdomain.KindDelete,
+			RefType: stockledgerdomain.RefTypeProduct,
+			RefID:   productID,
+		})
+	})
 }
 
 // CountByProduct 统计库存数量（总/可用/已用）
@@ -399,15 +454,28 @@ func (r *Store) Reserve(ids []uint, orderID uint, reservedAt time.Time) (int64,
 	if len(ids) == 0 || orderID == 0 {
 		return 0, nil
 	}
-	result := r.db.Model(&cardsecretdomain.Secret{}).
-		Where("id IN ? AND status = ? AND deleted_at IS NULL", ids, cardsecretdomain.StatusAvailable).
-		Updates(map[string]interface{}{
-			"status":      cardsecretdomain.StatusReserved,
-			"order_id":    orderID,
-			"reserved_at": reservedAt,
-			"updated_at":  reservedAt,
-		})
-	return result.RowsAffected, result.Error
+	var affected int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		condition := "id IN ? AND status = ? AND deleted_at IS NULL"
+		states, err := lockSecretStates(tx, condition, ids, cardsecretdomain.StatusAvailable)
+		if err != nil {
+			return err
+		}
+		result := tx.Model(&cardsecretdomain.Secret{}).
+			Where(condition, ids, cardsecretdomain.StatusAvailable).
+			Updates(map[string]interface{}{
+				"status":      cardsecretdomain.StatusReserved,
+				"order_id":    orderID,
+				"reserved_at": reservedAt,
+				"updated_at":  reservedAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		affected = result.RowsAffected
+		return recordSecretTransition(tx, states, cardsecretdomain.StatusReserved, orderMovement(stockledgerdomain.KindLock, orderID))
+	})
+	return affected, err
 }
 
 // ReleaseByOrder 释放占用库存
@@ -416,15 +484,28 @@ func (r *Store) ReleaseByOrder(orderID uint) (int64, error) {
 		return 0, nil
 	}
 	now := time.Now()
-	result := r.db.Model(&cardsecretdomain.Secret{}).
-		Where("order_id = ? AND status = ? AND deleted_at IS NULL", orderID, cardsecretdomain.StatusReserved).
-		Updates(map[string]interface{}{
-			"status":      cardsecretdomain.StatusAvailable,
-			"order_id":    nil,
-			"reserved_at": nil,
-			"updated_at":  now,
-		})
-	return result.RowsAffected, result.Error
+	var affected int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		condition := "order_id = ? AND status = ? AND deleted_at IS NULL"
+		states, err := lockSecretStates(tx, condition, orderID, cardsecretdomain.StatusReserved)
+		if err != nil {
+			return err
+		}
+		result := tx.Model(&cardsecretdomain.Secret{}).
+			Where(condition, orderID, cardsecretdomain.StatusReserved).
+			Updates(map[string]interface{}{
+				"status":      cardsecretdomain.StatusAvailable,
+				"order_id":    nil,
+				"reserved_at": nil,
+				"updated_at":  now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		affected = result.RowsAffected
+		return recordSecretTransition(tx, states, cardsecretdomain.StatusAvailable, orderMovement(stockledgerdomain.KindRelease, orderID))
+	})
+	return affected, err
 }
 
 // MarkUsed 标记卡密已使用
@@ -432,14 +513,28 @@ func (r *Store) MarkUsed(ids []uint, orderID uint, usedAt time.Time) (int64, err
 	if len(ids) == 0 || orderID == 0 {
 		return 0, nil
 	}
-	result := r.db.Model(&cardsecretdomain.Secret{}).
-		Where("id IN ? AND status IN ? AND (order_id IS NULL OR order_id = ?) AND deleted_at IS NULL", ids, []string{cardsecretdomain.StatusAvailable, cardsecretdomain.StatusReserved}, orderID).
-		Updates(map[string]interface{}{
-			"status":      cardsecretdomain.StatusUsed,
-			"order_id":    orderID,
-			"used_at":     usedAt,
-			"reserved_at": nil,
-			"updated_at":  usedAt,
-		})
-	return result.RowsAffected, result.Error
+	var affected int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		condition := "id IN ? AND status IN ? AND (order_id IS NULL OR order_id = ?) AND deleted_at IS NULL"
+		statuses := []string{cardsecretdomain.StatusAvailable, cardsecretdomain.StatusReserved}
+		states, err := lockSecretStates(tx, condition, ids, statuses, orderID)
+		if err != nil {
+			return err
+		}
+		result := tx.Model(&cardsecretdomain.Secret{}).
+			Where(condition, ids, statuses, orderID).
+			Updates(map[string]interface{}{
+				"status":      cardsecretdomain.StatusUsed,
+				"order_id":    orderID,
+				"used_at":     usedAt,
+				"reserved_at": nil,
+				"updated_at":  usedAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		affected = result.RowsAffected
+		return recordSecretTransition(tx, states, cardsecretdomain.StatusUsed, orderMovement(stockledgerdomain.KindConsume, orderID))
+	})
+	return affected, err
 }
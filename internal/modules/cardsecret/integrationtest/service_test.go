@@ -12,6 +12,7 @@ import (
 	productcontract "github.com/dujiao-next/internal/modules/catalog/product/contract"
 	productdomain "github.com/dujiao-next/internal/modules/catalog/product/domain"
 	productgormstore "github.com/dujiao-next/internal/modules/catalog/product/store/gormstore"
+	stockledgerdomain "github.com/dujiao-next/internal/modules/stockledger/domain"
 
 	"github.com/dujiao-next/internal/constants"
 	cardsecretapp "github.com/dujiao-next/internal/modules/cardsecret/application"
@@ -65,6 +66,7 @@ func setupCardSecretServiceTestDB(t *testing.T) *gorm.DB {
 		t.Fatalf("open sqlite failed: %v", err)
 	}
 	if err := db.AutoMigrate(
+		&stockledgerdomain.Movement{},
 		&productdomain.Product{},
 		&productdomain.ProductSKU{},
 		&cardsecretdomain.Batch{},
@@ -451,7 +453,7 @@ func TestCardSecretServiceSupportsBatchTargetOperations(t *testing.T) {
 		}
 	}
 
-	affected, err := svc.BatchUpdateCardSecretStatus(nil, batchA.ID, ListCardSecretInput{}, cardsecretdomain.StatusUsed)
+	affected, err := svc.BatchUpdateCardSecretStatus(nil, batchA.ID, ListCardSecretInput{}, cardsecretdomain.StatusUsed, 1)
 	if err != nil {
 		t.Fatalf("batch update status by batch id failed: %v", err)
 	}
@@ -500,7 +502,7 @@ func TestCardSecretServiceSupportsBatchTargetOperations(t *testing.T) {
 		t.Fatalf("exported content should not contain batch B secret: %s", exported)
 	}
 
-	deleted, err := svc.BatchDeleteCardSecrets(nil, batchB.ID, ListCardSecretInput{})
+	deleted, err := svc.BatchDeleteCardSecrets(nil, batchB.ID, ListCardSecretInput{}, 1)
 	if err != nil {
 		t.Fatalf("delete batch B secrets failed: %v", err)
 	}
@@ -713,13 +715,13 @@ func TestCardSecretServiceListBatchesReturnsRealtimeCounts(t *testing.T) {
 	if err != nil {
 		t.Fatalf("list batch A ids failed: %v", err)
 	}
-	if _, err := svc.BatchUpdateCardSecretStatus(rows[:1], 0, ListCardSecretInput{}, cardsecretdomain.StatusReserved); err != nil {
+	if _, err := svc.BatchUpdateCardSecretStatus(rows[:1], 0, ListCardSecretInput{}, cardsecretdomain.StatusReserved, 1); err != nil {
 		t.Fatalf("mark batch A reserved failed: %v", err)
 	}
-	if _, err := svc.BatchUpdateCardSecretStatus(rows[1:], 0, ListCardSecretInput{}, cardsecretdomain.StatusUsed); err != nil {
+	if _, err := svc.BatchUpdateCardSecretStatus(rows[1:], 0, ListCardSecretInput{}, cardsecretdomain.StatusUsed, 1); err != nil {
 		t.Fatalf("mark batch A used failed: %v", err)
 	}
-	if _, err := svc.BatchDeleteCardSecrets(nil, batchB.ID, ListCardSecretInput{}); err != nil {
+	if _, err := svc.BatchDeleteCardSecrets(nil, batchB.ID, ListCardSecretInput{}, 1); err != nil {
 		t.Fatalf("delete batch B failed: %v", err)
 	}
 
@@ -19,9 +19,9 @@ type Service interface {
 	CreateCardSecretBatch(cardsecretapp.CreateCardSecretBatchInput) (*cardsecretdomain.Batch, int, error)
 	ImportCardSecretCSV(cardsecretapp.ImportCardSecretCSVInput) (*cardsecretdomain.Batch, int, error)
 	ListCardSecrets(cardsecretapp.ListCardSecretInput) ([]cardsecretdomain.Secret, int64, error)
-	UpdateCardSecret(id uint, secret, status string) (*cardsecretdomain.Secret, error)
-	BatchUpdateCardSecretStatus(ids []uint, batchID uint, filter cardsecretapp.ListCardSecretInput, status string) (int64, error)
-	BatchDeleteCardSecrets(ids []uint, batchID uint, filter cardsecretapp.ListCardSecretInput) (int64, error)
+	UpdateCardSecret(id uint, secret, status string, adminID uint) (*cardsecretdomain.Secret, error)
+	BatchUpdateCardSecretStatus(ids []uint, batchID uint, filter cardsecretapp.ListCardSecretInput, status string, adminID uint) (int64, error)
+	BatchDeleteCardSecrets(ids []uint, batchID uint, filter cardsecretapp.ListCardSecretInput, adminID uint) (int64, error)
 	ExportCardSecrets(ids []uint, batchID uint, filter cardsecretapp.ListCardSecretInput, format string) ([]byte, string, error)
 	ExportAvailableCardSecrets(cardsecretapp.ExportAvailableCardSecretInput) (*cardsecretapp.ExportAvailableCardSecretResult, error)
 	GetStats(productID, skuID uint) (*cardsecretapp.CardSecretStats, error)
@@ -293,6 +293,10 @@ func (h *AdminHandler) GetCardSecrets(c *gin.Context) {
 
 // UpdateCardSecret 更新卡密
 func (h *AdminHandler) UpdateCardSecret(c *gin.Context) {
+	adminID, ok := ginutil.GetAdminID(c)
+	if !ok {
+		return
+	}
 	rawID, err := ginutil.ParseParamUint(c, "id")
 	if err != nil {
 		ginutil.RespondError(c, response.CodeBadRequest, "error.card_secret_invalid", nil)
@@ -318,7 +322,7 @@ func (h *AdminHandler) UpdateCardSecret(c *gin.Context) {
 		return
 	}
 
-	item, err := h.service.UpdateCardSecret(rawID, secret, status)
+	item, err := h.service.UpdateCardSecret(rawID, secret, status, adminID)
 	if err != nil {
 		switch {
 		case errors.Is(err, cardsecretapp.ErrNotFound):
@@ -338,13 +342,17 @@ func (h *AdminHandler) UpdateCardSecret(c *gin.Context) {
 
 // BatchUpdateCardSecretStatus 批量更新卡密状态
 func (h *AdminHandler) BatchUpdateCardSecretStatus(c *gin.Context) {
+	adminID, ok := ginutil.GetAdminID(c)
+	if !ok {
+		return
+	}
 	var req BatchUpdateCardSecretStatusRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		ginutil.RespondBindError(c, err)
 		return
 	}
 
-	rows, err := h.service.BatchUpdateCardSecretStatus(req.IDs, req.BatchID, buildCardSecretListInput(req.Filter), req.Status)
+	rows, err := h.service.BatchUpdateCardSecretStatus(req.IDs, req.BatchID, buildCardSecretListInput(req.Filter), req.Status, adminID)
 	if err != nil {
 		switch {
 		case errors.Is(err, cardsecretapp.ErrInvalid):
@@ -366,13 +374,17 @@ func (h *AdminHandler) BatchUpdateCardSecretStatus(c *gin.Context) {
 
 // BatchDeleteCardSecrets 批量删除卡密
 func (h *AdminHandler) BatchDeleteCardSecrets(c *gin.Context) {
+	adminID, ok := ginutil.GetAdminID(c)
+	if !ok {
+		return
+	}
 	var req BatchDeleteCardSecretRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		ginutil.RespondBindError(c, err)
 		return
 	}
 
-	rows, err := h.service.BatchDeleteCardSecrets(req.IDs, req.BatchID, buildCardSecretListInput(req.Filter))
+	rows, err := h.service.BatchDeleteCardSecrets(req.IDs, req.BatchID, buildCardSecretListInput(req.Filter), adminID)
 	if err != nil {
 		switch {
 		case errors.Is(err, cardsecretapp.ErrInvalid):
@@ -422,6 +434,10 @@ func (h *AdminHandler) ExportCardSecrets(c *gin.Context) {
 
 // ExportAvailableCardSecrets 从可用库存中导出卡密并出库
 func (h *AdminHandler) ExportAvailableCardSecrets(c *gin.Context) {
+	adminID, ok := ginutil.GetAdminID(c)
+	if !ok {
+		return
+	}
 	var req ExportAvailableCardSecretRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		ginutil.RespondBindError(c, err)
@@ -435,6 +451,7 @@ func (h *AdminHandler) ExportAvailableCardSecrets(c *gin.Context) {
 		Limit:             req.Limit,
 		Format:            req.Format,
 		DeleteAfterExport: req.DeleteAfterExport,
+		AdminID:           adminID,
 	})
 	if err != nil {
 		switch {
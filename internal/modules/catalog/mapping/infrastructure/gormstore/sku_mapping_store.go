@@ -6,6 +6,8 @@ import (
 
 	mappingcontract "github.com/dujiao-next/internal/modules/catalog/mapping/contract"
 	mappingdomain "github.com/dujiao-next/internal/modules/catalog/mapping/domain"
+	stockledgerdomain "github.com/dujiao-next/internal/modules/stockledger/domain"
+	stockledgergormstore "github.com/dujiao-next/internal/modules/stockledger/infrastructure/gormstore"
 
 	"gorm.io/gorm"
 )
@@ -65,11 +67,72 @@ func (r *SKUMappingStore) ListByProductMappingIDs(productMappingIDs []uint) ([]m
 }
 
 func (r *SKUMappingStore) Create(mapping *mappingdomain.SKUMapping) error {
-	return r.db.Create(mapping).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(mapping).Error; err != nil {
+			return err
+		}
+		return recordUpstreamStock(tx, mapping, 0)
+	})
 }
 
+// Update 保存映射，上游库存缓存变化时追加上游同步流水。
 func (r *SKUMappingStore) Update(mapping *mappingdomain.SKUMapping) error {
-	return r.db.Save(mapping).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var previous []int
+		if mapping.ID > 0 {
+			if err := tx.Model(&mappingdomain.SKUMapping{}).
+				Where("id = ?", mapping.ID).
+				Limit(1).
+				Pluck("upstream_stock", &previous).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Save(mapping).Error; err != nil {
+			return err
+		}
+		if len(previous) == 0 {
+			return recordUpstreamStock(tx, mapping, 0)
+		}
+		return recordUpstreamStock(tx, mapping, previous[0])
+	})
+}
+
+// recordUpstreamStock 上游库存仅记录可售增量，无限库存（负数）按 0 计并在备注中标记。
+func recordUpstreamStock(tx *gorm.DB, mapping *mappingdomain.SKUMapping, previous int) error {
+	delta := effectiveUpstreamStock(mapping.UpstreamStock) - effectiveUpstreamStock(previous)
+	if delta == 0 {
+		return nil
+	}
+	var productIDs []uint
+	if err := tx.Model(&mappingdomain.Mapping{}).
+		Where("id = ?", mapping.ProductMappingID).
+		Limit(1).
+		Pluck("local_product_id", &productIDs).Error; err != nil {
+		return err
+	}
+	if len(productIDs) == 0 {
+		return nil
+	}
+	movement := stockledgerdomain.Movement{
+		Source:         stockledgerdomain.SourceUpstream,
+		ProductID:      productIDs[0],
+		SKUID:          mapping.LocalSKUID,
+		Kind:           stockledgerdomain.KindUpstreamSync,
+		AvailableDelta: delta,
+		RefType:        stockledgerdomain.RefTypeMapping,
+		RefID:          mapping.ProductMappingID,
+	}
+	if mapping.UpstreamStock < 0 {
+		movement.Remark = "unlimited"
+	}
+	return stockledgergormstore.Record(tx, movement)
+}
+
+func effectiveUpstreamStock(stock int) int {
+	if stock < 0 {
+		return 0
+	}
+	return stock
 }
 
 func (r *SKUMappingStore) DeleteByProductMapping(productMappingID uint) error {
@@ -36,6 +36,10 @@ func (s *WriteService) Create(input CreateProductInput) (*productdomain.Product,
 	if input.IsAffiliateEnabled != nil {
 		isAffiliateEnabled = *input.IsAffiliateEnabled
 	}
+	requireManualReview := false
+	if input.RequireManualReview != nil {
+		requireManualReview = *input.RequireManualReview
+	}
 	purchaseType := productdomain.NormalizePurchaseType(input.PurchaseType)
 	if purchaseType == "" {
 		return nil, productcontract.ErrProductPurchaseInvalid
@@ -44,6 +48,10 @@ func (s *WriteService) Create(input CreateProductInput) (*productdomain.Product,
 	if fulfillmentType == "" {
 		return nil, productcontract.ErrFulfillmentInvalid
 	}
+	webhookURL, webhookSecret, err := resolveWebhookEndpoint(fulfillmentType, input.WebhookURL, input.WebhookSecret, "")
+	if err != nil {
+		return nil, err
+	}
 
 	priceAmount := input.PriceAmount.Round(2)
 	if len(input.SKUs) == 0 && priceAmount.LessThanOrEqual(decimal.Zero) {
@@ -115,11 +123,14 @@ func (s *WriteService) Create(input CreateProductInput) (*productdomain.Product,
 		MaxPurchaseQuantity:  maxPurchaseQuantity,
 		StockDisplayMode:     stockDisplayMode,
 		FulfillmentType:      fulfillmentType,
+		WebhookURL:           webhookURL,
+		WebhookSecret:        webhookSecret,
 		ManualStockTotal:     manualStockTotal,
 		ManualStockLocked:    0,
 		ManualStockSold:      0,
 		PaymentChannelIDs:    productdomain.EncodePaymentChannelIDs(paymentChannelIDs),
 		IsAffiliateEnabled:   isAffiliateEnabled,
+		RequireManualReview:  requireManualReview,
 		IsActive:             isActive,
 		SortOrder:            input.SortOrder,
 	}
@@ -145,6 +156,9 @@ func (s *WriteService) Create(input CreateProductInput) (*productdomain.Product,
 		} else if err := s.syncSingleProductSKU(skuRepo, product.ID, priceAmount, costPriceAmount, manualStockTotal, true); err != nil {
 			return err
 		}
+		if err := recordManualStockAdjustments(repositories.StockLedger, skuRepo, product.ID, nil, input.OperatorAdminID); err != nil {
+			return err
+		}
 		if input.WholesalePrices != nil {
 			var skus []productdomain.ProductSKU
 			if skuRepo != nil {
@@ -1,9 +1,14 @@
 package productwrite
 
 import (
+	"strings"
+
 	paymentdomain "github.com/dujiao-next/internal/modules/payment/domain"
 
+	"github.com/dujiao-next/internal/constants"
+	productcontract "github.com/dujiao-next/internal/modules/catalog/product/contract"
 	productdomain "github.com/dujiao-next/internal/modules/catalog/product/domain"
+	stockledgerdomain "github.com/dujiao-next/internal/modules/stockledger/domain"
 
 	"github.com/shopspring/decimal"
 )
@@ -41,11 +46,17 @@ type CardSecretStockRepository interface {
 	CountByProduct(productID, skuID uint) (int64, int64, int64, error)
 }
 
+// StockLedgerRecorder 在商品写事务内追加库存流水。
+type StockLedgerRecorder interface {
+	Record(movements ...stockledgerdomain.Movement) error
+}
+
 // TransactionRepositories 是一次商品写事务内绑定的仓储集合。
 type TransactionRepositories struct {
 	Products    ProductRepository
 	SKUs        SKURepository
 	CardSecrets CardSecretStockRepository
+	StockLedger StockLedgerRecorder
 }
 
 // UnitOfWork 隐藏具体数据库和事务对象，不向 Application 暴露 GORM。
@@ -53,6 +64,11 @@ type UnitOfWork interface {
 	WithinTransaction(fn func(repositories TransactionRepositories) error) error
 }
 
+// RestockTrigger 在商品库存被手动调整后触发到货提醒检查。
+type RestockTrigger interface {
+	TriggerCheck(productID uint)
+}
+
 // Options 描述商品写入应用服务依赖。
 type Options struct {
 	Products        ProductRepository
@@ -69,6 +85,7 @@ type WriteService struct {
 	categories      CategoryRepository
 	paymentChannels PaymentChannelStoresitory
 	transactions    UnitOfWork
+	restockTrigger  RestockTrigger
 }
 
 // NewWriteService 创建商品写入应用服务。
@@ -82,6 +99,11 @@ func NewWriteService(options Options) *WriteService {
 	}
 }
 
+// SetRestockTrigger 注入到货提醒触发器（容器装配时调用）。
+func (s *WriteService) SetRestockTrigger(trigger RestockTrigger) {
+	s.restockTrigger = trigger
+}
+
 // CreateProductInput 创建或完整更新商品的输入。
 type CreateProductInput struct {
 	CategoryID           uint
@@ -103,12 +125,18 @@ type CreateProductInput struct {
 	MaxPurchaseQuantity *int
 	StockDisplayMode    string
 	FulfillmentType     string
+	// WebhookSecret 留空表示更新时保留原密钥。
+	WebhookURL          string
+	WebhookSecret       string
 	ManualStockTotal    *int
 	SKUs                []ProductSKUInput
 	PaymentChannelIDs   []uint
 	IsAffiliateEnabled  *bool
+	RequireManualReview *bool
 	IsActive            *bool
 	SortOrder           int
+	// OperatorAdminID 记入手动库存调整流水
+	OperatorAdminID uint
 }
 
 // ProductSKUInput 描述商品 SKU 的完整写入值。
@@ -123,6 +151,25 @@ type ProductSKUInput struct {
 	SortOrder        int
 }
 
+// resolveWebhookEndpoint 校验 webhook 交付地址与密钥；非 webhook 类型清空配置。
+func resolveWebhookEndpoint(fulfillmentType, rawURL, rawSecret, currentSecret string) (string, string, error) {
+	if fulfillmentType != constants.FulfillmentTypeWebhook {
+		return "", "", nil
+	}
+	webhookURL := productdomain.NormalizeWebhookURL(rawURL)
+	if webhookURL == "" {
+		return "", "", productcontract.ErrProductWebhookInvalid
+	}
+	secret := strings.TrimSpace(rawSecret)
+	if secret == "" {
+		secret = currentSecret
+	}
+	if len(secret) < productdomain.WebhookSecretMinLength || len(secret) > productdomain.WebhookSecretMaxLength {
+		return "", "", productcontract.ErrProductWebhookInvalid
+	}
+	return webhookURL, secret, nil
+}
+
 func (s *WriteService) filterAvailablePaymentChannelIDs(ids []uint) ([]uint, error) {
 	if len(ids) == 0 {
 		return nil, nil
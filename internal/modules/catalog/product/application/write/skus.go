@@ -7,6 +7,7 @@ import (
 
 	"github.com/dujiao-next/internal/constants"
 	productcontract "github.com/dujiao-next/internal/modules/catalog/product/contract"
+	stockledgerdomain "github.com/dujiao-next/internal/modules/stockledger/domain"
 	"github.com/dujiao-next/internal/shared/jsonmap"
 	"github.com/dujiao-next/internal/shared/money"
 
@@ -360,3 +361,66 @@ func (s *WriteService) ensureAutoSKUCardSecretStockSafe(
 	}
 	return nil
 }
+
+// snapshotManualStock 在写入 SKU 前记录手动库存计数，供写入后生成调整流水。
+func snapshotManualStock(ledger StockLedgerRecorder, skuRepo SKURepository, productID uint) ([]productdomain.ProductSKU, error) {
+	if ledger == nil || skuRepo == nil || productID == 0 {
+		return nil, nil
+	}
+	return skuRepo.ListByProduct(productID, false)
+}
+
+// recordManualStockAdjustments 比对写入前后的 SKU 手动库存，生成后台调整与删除流水。
+// 无限库存不计入台账，切换为无限库存时扣回原可售数量，切回限量时按新数量入账。
+func recordManualStockAdjustments(ledger StockLedgerRecorder, skuRepo SKURepository, productID uint, before []productdomain.ProductSKU, adminID uint) error {
+	if ledger == nil || skuRepo == nil || productID == 0 {
+		return nil
+	}
+	after, err := skuRepo.ListByProduct(productID, false)
+	if err != nil {
+		return err
+	}
+	previous := make(map[uint]productdomain.ProductSKU, len(before))
+	for _, sku := range before {
+		previous[sku.ID] = sku
+	}
+	movements := make([]stockledgerdomain.Movement, 0, len(after))
+	newMovement := func(skuID uint, kind string) stockledgerdomain.Movement {
+		return stockledgerdomain.Movement{
+			Source:    stockledgerdomain.SourceManual,
+			ProductID: productID,
+			SKUID:     skuID,
+			Kind:      kind,
+			RefType:   stockledgerdomain.RefTypeAdmin,
+			RefID:     adminID,
+		}
+	}
+	for _, sku := range after {
+		prev, existed := previous[sku.ID]
+		delete(previous, sku.ID)
+		movement := newMovement(sku.ID, stockledgerdomain.KindAdjust)
+		movement.AvailableDelta = ledgerManualStock(sku.ManualStockTotal)
+		if existed {
+			movement.AvailableDelta -= ledgerManualStock(prev.ManualStockTotal)
+		}
+		movements = append(movements, movement)
+	}
+	for _, sku := range before {
+		if _, removed := previous[sku.ID]; !removed {
+			continue
+		}
+		movement := newMovement(sku.ID, stockledgerdomain.KindDelete)
+		movement.AvailableDelta = -ledgerManualStock(sku.ManualStockTotal)
+		movement.LockedDelta = -sku.ManualStockLocked
+		movement.SoldDelta = -sku.ManualStockSold
+		movements = append(movements, movement)
+	}
+	return ledger.Record(movements...)
+}
+
+func ledgerManualStock(total int) int {
+	if total == constants.ManualStockUnlimited {
+		return 0
+	}
+	return total
+}
@@ -65,6 +65,9 @@ func (s *WriteService) Update(id string, input CreateProductInput) (*productdoma
 	if input.IsAffiliateEnabled != nil {
 		product.IsAffiliateEnabled = *input.IsAffiliateEnabled
 	}
+	if input.RequireManualReview != nil {
+		product.RequireManualReview = *input.RequireManualReview
+	}
 	rawPurchaseType := strings.TrimSpace(input.PurchaseType)
 	if rawPurchaseType == "" {
 		rawPurchaseType = product.PurchaseType
@@ -101,6 +104,10 @@ func (s *WriteService) Update(id string, input CreateProductInput) (*productdoma
 		fulfillmentType = constants.FulfillmentTypeUpstream
 	}
 	product.FulfillmentType = fulfillmentType
+	product.WebhookURL, product.WebhookSecret, err = resolveWebhookEndpoint(fulfillmentType, input.WebhookURL, input.WebhookSecret, product.WebhookSecret)
+	if err != nil {
+		return nil, err
+	}
 	if fulfillmentType == constants.FulfillmentTypeManual {
 		normalizedSchemaJSON, err := manualform.NormalizeSchema(jsonmap.JSON(input.ManualFormSchemaJSON))
 		if err != nil {
@@ -149,6 +156,10 @@ func (s *WriteService) Update(id string, input CreateProductInput) (*productdoma
 		productRepo := repositories.Products
 		skuRepo := repositories.SKUs
 		cardSecretRepo := repositories.CardSecrets
+		stockBefore, err := snapshotManualStock(repositories.StockLedger, skuRepo, product.ID)
+		if err != nil {
+			return err
+		}
 		if len(normalizedSKUs) > 0 {
 			if err := s.applyProductSKUsWithStockGuard(skuRepo, cardSecretRepo, product.ID, fulfillmentType, normalizedSKUs); err != nil {
 				return err
@@ -156,6 +167,9 @@ func (s *WriteService) Update(id string, input CreateProductInput) (*productdoma
 		} else if err := s.syncSingleProductSKU(skuRepo, product.ID, priceAmount, product.CostPriceAmount.Decimal, product.ManualStockTotal, true); err != nil {
 			return err
 		}
+		if err := recordManualStockAdjustments(repositories.StockLedger, skuRepo, product.ID, stockBefore, input.OperatorAdminID); err != nil {
+			return err
+		}
 		// 仅当请求显式携带批发价字段时才覆盖，省略字段（nil）保留原有配置，
 		// 避免不关心批发价的局部更新静默清空已配阶梯。
 		if input.WholesalePrices != nil {
@@ -180,5 +194,8 @@ func (s *WriteService) Update(id string, input CreateProductInput) (*productdoma
 	}); err != nil {
 		return nil, err
 	}
+	if s.restockTrigger != nil {
+		s.restockTrigger.TriggerCheck(product.ID)
+	}
 	return s.products.GetByID(id)
 }
@@ -13,6 +13,7 @@ var (
 	ErrProductStockDisplayInvalid   = errors.New("product stock display invalid")
 	ErrFulfillmentInvalid           = errors.New("fulfillment invalid")
 	ErrManualStockInvalid           = errors.New("manual stock invalid")
+	ErrProductWebhookInvalid        = errors.New("product webhook invalid")
 	ErrProductSKUInvalid            = errors.New("product sku invalid")
 	ErrProductSKUHasCardSecretStock = errors.New("product sku has card secret stock")
 	ErrProductHasStock              = errors.New("product has stock")
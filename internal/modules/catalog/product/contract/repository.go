@@ -34,9 +34,9 @@ type Repository interface {
 	Update(item *productdomain.Product) error
 	Delete(id string) error
 	CountBySlug(slug string, excludeID *string) (int64, error)
-	ReserveManualStock(productID uint, quantity int) (int64, error)
-	ReleaseManualStock(productID uint, quantity int) (int64, error)
-	ConsumeManualStock(productID uint, quantity int) (int64, error)
+	ReserveManualStock(productID uint, quantity int, orderID uint) (int64, error)
+	ReleaseManualStock(productID uint, quantity int, orderID uint) (int64, error)
+	ConsumeManualStock(productID uint, quantity int, orderID uint) (int64, error)
 	QuickUpdate(id string, fields map[string]interface{}) error
 }
 
@@ -52,7 +52,7 @@ type SKURepository interface {
 	Delete(id uint) error
 	DeleteByProduct(productID uint) error
 	PurgeSoftDeletedByProductAndCode(productID uint, skuCode string) error
-	ReserveManualStock(skuID uint, quantity int) (int64, error)
-	ReleaseManualStock(skuID uint, quantity int) (int64, error)
-	ConsumeManualStock(skuID uint, quantity int) (int64, error)
+	ReserveManualStock(skuID uint, quantity int, orderID uint) (int64, error)
+	ReleaseManualStock(skuID uint, quantity int, orderID uint) (int64, error)
+	ConsumeManualStock(skuID uint, quantity int, orderID uint) (int64, error)
 }
@@ -83,6 +83,15 @@ func TestProductConfigurationNormalizersKeepDefaultsAndRejectUnknownValues(t *te
 	if got := NormalizeFulfillmentType(constants.FulfillmentTypeUpstream); got != constants.FulfillmentTypeUpstream {
 		t.Fatalf("upstream fulfillment type want %q got %q", constants.FulfillmentTypeUpstream, got)
 	}
+	if got := NormalizeFulfillmentType(" Webhook "); got != constants.FulfillmentTypeWebhook {
+		t.Fatalf("webhook fulfillment type want %q got %q", constants.FulfillmentTypeWebhook, got)
+	}
+	if got := NormalizeFulfillmentType("file"); got != constants.FulfillmentTypeFile {
+		t.Fatalf("file fulfillment type want %q got %q", constants.FulfillmentTypeFile, got)
+	}
+	if got := NormalizeFulfillmentType("license"); got != constants.FulfillmentTypeLicense {
+		t.Fatalf("license fulfillment type want %q got %q", constants.FulfillmentTypeLicense, got)
+	}
 	if got := NormalizeFulfillmentType("invalid"); got != "" {
 		t.Fatalf("invalid fulfillment type must be rejected, got %q", got)
 	}
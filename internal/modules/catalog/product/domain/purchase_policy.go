@@ -2,6 +2,7 @@ package productdomain
 
 import (
 	"errors"
+	"net/url"
 	"strconv"
 	"strings"
 
@@ -10,6 +11,13 @@ import (
 	"github.com/dujiao-next/internal/constants"
 )
 
+// webhook 交付配置长度约束。
+const (
+	WebhookURLMaxLength    = 500
+	WebhookSecretMinLength = 16
+	WebhookSecretMaxLength = 128
+)
+
 var (
 	ErrPurchaseQuantityInvalid = errors.New("invalid order item")
 	ErrMaxPurchaseExceeded     = errors.New("product max purchase exceeded")
@@ -46,11 +54,34 @@ func NormalizeFulfillmentType(raw string) string {
 		return constants.FulfillmentTypeAuto
 	case constants.FulfillmentTypeUpstream:
 		return constants.FulfillmentTypeUpstream
+	case constants.FulfillmentTypeWebhook:
+		return constants.FulfillmentTypeWebhook
+	case constants.FulfillmentTypeFile:
+		return constants.FulfillmentTypeFile
+	case constants.FulfillmentTypeLicense:
+		return constants.FulfillmentTypeLicense
 	default:
 		return ""
 	}
 }
 
+// NormalizeWebhookURL 归一化 webhook 交付地址，仅接受带主机名的 http(s) 绝对地址，非法时返回空串。
+func NormalizeWebhookURL(raw string) string {
+	value := strings.TrimSpace(raw)
+	if value == "" || len(value) > WebhookURLMaxLength {
+		return ""
+	}
+	parsed, err := url.Parse(value)
+	if err != nil || parsed.Host == "" {
+		return ""
+	}
+	scheme := strings.ToLower(parsed.Scheme)
+	if scheme != "http" && scheme != "https" {
+		return ""
+	}
+	return value
+}
+
 // NormalizeStockDisplayMode 归一化商品库存展示模式。
 func NormalizeStockDisplayMode(raw string) string {
 	value := strings.ToLower(strings.TrimSpace(raw))
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	paymentgormstore "github.com/dujiao-next/internal/modules/payment/infrastructure/gormstore"
+	stockledgerdomain "github.com/dujiao-next/internal/modules/stockledger/domain"
 
 	paymentdomain "github.com/dujiao-next/internal/modules/payment/domain"
 
@@ -55,6 +56,7 @@ func newProductServiceForResellerPublicTest(t *testing.T) (catalogproductbootstr
 		t.Fatalf("open sqlite failed: %v", err)
 	}
 	if err := db.AutoMigrate(
+		&stockledgerdomain.Movement{},
 		&userdomain.User{},
 		&categorydomain.Category{},
 		&productdomain.Product{},
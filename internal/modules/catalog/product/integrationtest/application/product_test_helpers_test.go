@@ -6,6 +6,7 @@ import (
 	"time"
 
 	paymentgormstore "github.com/dujiao-next/internal/modules/payment/infrastructure/gormstore"
+	stockledgerdomain "github.com/dujiao-next/internal/modules/stockledger/domain"
 
 	paymentdomain "github.com/dujiao-next/internal/modules/payment/domain"
 
@@ -42,7 +43,7 @@ func newAutoStockProductService(t *testing.T) (catalogproductbootstrap.Services,
 	if err != nil {
 		t.Fatalf("open sqlite failed: %v", err)
 	}
-	if err := db.AutoMigrate(&cardsecretdomain.Secret{}); err != nil {
+	if err := db.AutoMigrate(&cardsecretdomain.Secret{}, &stockledgerdomain.Movement{}); err != nil {
 		t.Fatalf("auto migrate card secret failed: %v", err)
 	}
 	secretRepo := cardsecretgormstore.New(db)
@@ -72,7 +73,7 @@ func newProductServiceForTest(t *testing.T) (catalogproductbootstrap.Services, *
 	if err != nil {
 		t.Fatalf("open sqlite failed: %v", err)
 	}
-	if err := db.AutoMigrate(&categorydomain.Category{}, &productdomain.Product{}, &productdomain.ProductSKU{}, &cardsecretdomain.Secret{}, &cardsecretdomain.Batch{}, &memberleveldomain.MemberLevelPrice{}, &cartdomain.Item{}, &mappingdomain.Mapping{}, &mappingdomain.SKUMapping{}, &orderdomain.Order{}, &orderdomain.OrderItem{}, &paymentdomain.PaymentChannel{}); err != nil {
+	if err := db.AutoMigrate(&categorydomain.Category{}, &productdomain.Product{}, &productdomain.ProductSKU{}, &cardsecretdomain.Secret{}, &stockledgerdomain.Movement{}, &cardsecretdomain.Batch{}, &memberleveldomain.MemberLevelPrice{}, &cartdomain.Item{}, &mappingdomain.Mapping{}, &mappingdomain.SKUMapping{}, &orderdomain.Order{}, &orderdomain.OrderItem{}, &paymentdomain.PaymentChannel{}); err != nil {
 		t.Fatalf("auto migrate product service tables failed: %v", err)
 	}
 
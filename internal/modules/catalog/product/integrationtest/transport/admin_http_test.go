@@ -34,6 +34,7 @@ import (
 	productgormstore "github.com/dujiao-next/internal/modules/catalog/product/store/gormstore"
 	producthttp "github.com/dujiao-next/internal/modules/catalog/product/transport/http"
 	memberlevelgormstore "github.com/dujiao-next/internal/modules/memberlevel/infrastructure/gormstore"
+	stockledgergormstore "github.com/dujiao-next/internal/modules/stockledger/infrastructure/gormstore"
 	"github.com/dujiao-next/internal/shared/jsonmap"
 	"github.com/dujiao-next/internal/shared/money"
 	"github.com/gin-gonic/gin"
@@ -63,6 +64,7 @@ func (unit *productWriteUoW) WithinTransaction(fn func(productwrite.TransactionR
 			Products:    unit.products.BindTx(tx),
 			SKUs:        unit.skus.BindTx(tx),
 			CardSecrets: unit.cardSecrets.BindTx(tx),
+			StockLedger: stockledgergormstore.NewRecorder(tx),
 		})
 	})
 }
@@ -9,6 +9,7 @@ import (
 
 	productcontract "github.com/dujiao-next/internal/modules/catalog/product/contract"
 	productdomain "github.com/dujiao-next/internal/modules/catalog/product/domain"
+	stockledgerdomain "github.com/dujiao-next/internal/modules/stockledger/domain"
 
 	"github.com/dujiao-next/internal/persistence/gormutil"
 
@@ -282,16 +283,16 @@ func (r *ProductStore) CountBySlug(slug string, excludeID *string) (int64, error
 }
 
 // ReserveManualStock 预占手动库存
-func (r *ProductStore) ReserveManualStock(productID uint, quantity int) (int64, error) {
-	return gormutil.ReserveManualStock(r.db, &productdomain.Product{}, productID, quantity)
+func (r *ProductStore) ReserveManualStock(productID uint, quantity int, orderID uint) (int64, error) {
+	return applyManualStock(r.db, &productdomain.Product{}, false, productID, quantity, orderID, stockledgerdomain.KindLock, gormutil.ReserveManualStock)
 }
 
 // ReleaseManualStock 释放手动库存占用
-func (r *ProductStore) ReleaseManualStock(productID uint, quantity int) (int64, error) {
-	return gormutil.ReleaseManualStock(r.db, &productdomain.Product{}, productID, quantity)
+func (r *ProductStore) ReleaseManualStock(productID uint, quantity int, orderID uint) (int64, error) {
+	return applyManualStock(r.db, &productdomain.Product{}, false, productID, quantity, orderID, stockledgerdomain.KindRelease, gormutil.ReleaseManualStock)
 }
 
 // ConsumeManualStock 消耗手动库存（支付成功后占用转已售）
-func (r *ProductStore) ConsumeManualStock(productID uint, quantity int) (int64, error) {
-	return gormutil.ConsumeManualStock(r.db, &productdomain.Product{}, productID, quantity)
+func (r *ProductStore) ConsumeManualStock(productID uint, quantity int, orderID uint) (int64, error) {
+	return applyManualStock(r.db, &productdomain.Product{}, false, productID, quantity, orderID, stockledgerdomain.KindConsume, gormutil.ConsumeManualStock)
 }
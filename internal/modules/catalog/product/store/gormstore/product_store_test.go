@@ -13,6 +13,7 @@ import (
 
 	productcontract "github.com/dujiao-next/internal/modules/catalog/product/contract"
 	productdomain "github.com/dujiao-next/internal/modules/catalog/product/domain"
+	stockledgerdomain "github.com/dujiao-next/internal/modules/stockledger/domain"
 
 	categorydomain "github.com/dujiao-next/internal/modules/catalog/category/domain"
 
@@ -40,6 +41,7 @@ func setupProductStoreTest(t *testing.T) (*ProductStore, *gorm.DB) {
 		&siteconnectiondomain.Connection{},
 		&mappingdomain.Mapping{},
 		&mappingdomain.SKUMapping{},
+		&stockledgerdomain.Movement{},
 	); err != nil {
 		t.Fatalf("migrate product/sku/card_secret/mappings failed: %v", err)
 	}
@@ -135,7 +137,7 @@ func TestManualStockReserveReleaseConsumeLifecycle(t *testing.T) {
 	repo, db := setupProductStoreTest(t)
 	product := createManualProduct(t, repo, "manual-stock-lifecycle", 10, 0, 0)
 
-	affected, err := repo.ReserveManualStock(product.ID, 3)
+	affected, err := repo.ReserveManualStock(product.ID, 3, 0)
 	if err != nil {
 		t.Fatalf("reserve stock failed: %v", err)
 	}
@@ -143,7 +145,7 @@ func TestManualStockReserveReleaseConsumeLifecycle(t *testing.T) {
 		t.Fatalf("reserve affected want 1 got %d", affected)
 	}
 
-	affected, err = repo.ConsumeManualStock(product.ID, 2)
+	affected, err = repo.ConsumeManualStock(product.ID, 2, 0)
 	if err != nil {
 		t.Fatalf("consume stock failed: %v", err)
 	}
@@ -151,7 +153,7 @@ func TestManualStockReserveReleaseConsumeLifecycle(t *testing.T) {
 		t.Fatalf("consume affected want 1 got %d", affected)
 	}
 
-	affected, err = repo.ReleaseManualStock(product.ID, 1)
+	affected, err = repo.ReleaseManualStock(product.ID, 1, 0)
 	if err != nil {
 		t.Fatalf("release stock failed: %v", err)
 	}
@@ -173,7 +175,7 @@ func TestManualStockReserveReleaseConsumeLifecycle(t *testing.T) {
 		t.Fatalf("sold want 2 got %d", got.ManualStockSold)
 	}
 
-	affected, err = repo.ReserveManualStock(product.ID, 9)
+	affected, err = repo.ReserveManualStock(product.ID, 9, 0)
 	if err != nil {
 		t.Fatalf("reserve over available failed: %v", err)
 	}
@@ -181,7 +183,7 @@ func TestManualStockReserveReleaseConsumeLifecycle(t *testing.T) {
 		t.Fatalf("reserve over available affected want 0 got %d", affected)
 	}
 
-	affected, err = repo.ReserveManualStock(product.ID, 8)
+	affected, err = repo.ReserveManualStock(product.ID, 8, 0)
 	if err != nil {
 		t.Fatalf("reserve exact available failed: %v", err)
 	}
@@ -194,7 +196,7 @@ func TestManualStockConsumeWithLegacyUnreservedOrder(t *testing.T) {
 	repo, db := setupProductStoreTest(t)
 	product := createManualProduct(t, repo, "manual-stock-legacy", 5, 0, 1)
 
-	affected, err := repo.ConsumeManualStock(product.ID, 2)
+	affected, err := repo.ConsumeManualStock(product.ID, 2, 0)
 	if err != nil {
 		t.Fatalf("consume stock failed: %v", err)
 	}
@@ -221,7 +223,7 @@ func TestManualStockUnlimitedDoesNotReserve(t *testing.T) {
 	repo, _ := setupProductStoreTest(t)
 	product := createManualProduct(t, repo, "manual-stock-unlimited", constants.ManualStockUnlimited, 0, 0)
 
-	affected, err := repo.ReserveManualStock(product.ID, 1)
+	affected, err := repo.ReserveManualStock(product.ID, 1, 0)
 	if err != nil {
 		t.Fatalf("reserve unlimited stock failed: %v", err)
 	}
@@ -229,7 +231,7 @@ func TestManualStockUnlimitedDoesNotReserve(t *testing.T) {
 		t.Fatalf("reserve unlimited affected want 0 got %d", affected)
 	}
 
-	affected, err = repo.ConsumeManualStock(product.ID, 1)
+	affected, err = repo.ConsumeManualStock(product.ID, 1, 0)
 	if err != nil {
 		t.Fatalf("consume unlimited stock failed: %v", err)
 	}
@@ -279,9 +281,9 @@ func TestProductStoreSoftDeleteHidesProductAndRejectsStockMutations(t *testing.T
 	}
 
 	for operation, mutate := range map[string]func() (int64, error){
-		"reserve": func() (int64, error) { return repo.ReserveManualStock(product.ID, 1) },
-		"release": func() (int64, error) { return repo.ReleaseManualStock(product.ID, 1) },
-		"consume": func() (int64, error) { return repo.ConsumeManualStock(product.ID, 1) },
+		"reserve": func() (int64, error) { return repo.ReserveManualStock(product.ID, 1, 0) },
+		"release": func() (int64, error) { return repo.ReleaseManualStock(product.ID, 1, 0) },
+		"consume": func() (int64, error) { return repo.ConsumeManualStock(product.ID, 1, 0) },
 	} {
 		affected, err := mutate()
 		if err != nil || affected != 0 {
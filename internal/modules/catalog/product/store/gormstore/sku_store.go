@@ -7,10 +7,13 @@ import (
 
 	productcontract "github.com/dujiao-next/internal/modules/catalog/product/contract"
 	productdomain "github.com/dujiao-next/internal/modules/catalog/product/domain"
+	stockledgerdomain "github.com/dujiao-next/internal/modules/stockledger/domain"
+	stockledgergormstore "github.com/dujiao-next/internal/modules/stockledger/infrastructure/gormstore"
 
 	"github.com/dujiao-next/internal/persistence/gormutil"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // SKUStore 是 Catalog Product SKU 端口的 GORM 实现。
@@ -145,16 +148,87 @@ func (r *SKUStore) DeleteByProduct(productID uint) error {
 }
 
 // ReserveManualStock 预占手动库存
-func (r *SKUStore) ReserveManualStock(skuID uint, quantity int) (int64, error) {
-	return gormutil.ReserveManualStock(r.db, &productdomain.ProductSKU{}, skuID, quantity)
+func (r *SKUStore) ReserveManualStock(skuID uint, quantity int, orderID uint) (int64, error) {
+	return applyManualStock(r.db, &productdomain.ProductSKU{}, true, skuID, quantity, orderID, stockledgerdomain.KindLock, gormutil.ReserveManualStock)
 }
 
 // ReleaseManualStock 释放手动库存占用
-func (r *SKUStore) ReleaseManualStock(skuID uint, quantity int) (int64, error) {
-	return gormutil.ReleaseManualStock(r.db, &productdomain.ProductSKU{}, skuID, quantity)
+func (r *SKUStore) ReleaseManualStock(skuID uint, quantity int, orderID uint) (int64, error) {
+	return applyManualStock(r.db, &productdomain.ProductSKU{}, true, skuID, quantity, orderID, stockledgerdomain.KindRelease, gormutil.ReleaseManualStock)
 }
 
 // ConsumeManualStock 消耗手动库存（支付成功后占用转已售）
-func (r *SKUStore) ConsumeManualStock(skuID uint, quantity int) (int64, error) {
-	return gormutil.ConsumeManualStock(r.db, &productdomain.ProductSKU{}, skuID, quantity)
+func (r *SKUStore) ConsumeManualStock(skuID uint, quantity int, orderID uint) (int64, error) {
+	return applyManualStock(r.db, &productdomain.ProductSKU{}, true, skuID, quantity, orderID, stockledgerdomain.KindConsume, gormutil.ConsumeManualStock)
+}
+
+type manualStockOp func(db *gorm.DB, model interface{}, id uint, quantity int) (int64, error)
+
+type manualStockRow struct {
+	ProductID         uint
+	ManualStockLocked int
+}
+
+// applyManualStock 在事务内锁定计数行、执行库存变更并追加台账流水，计数与流水同时提交。
+// 商品级（无规格）库存以 SKUID 0 记录。
+func applyManualStock(db *gorm.DB, model interface{}, skuLevel bool, id uint, quantity int, orderID uint, kind string, op manualStockOp) (int64, error) {
+	var affected int64
+	err := db.Transaction(func(tx *gorm.DB) error {
+		columns := "id AS product_id, manual_stock_locked"
+		if skuLevel {
+			columns = "product_id, manual_stock_locked"
+		}
+		var rows []manualStockRow
+		if err := tx.Model(model).Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select(columns).
+			Where("id = ? AND deleted_at IS NULL", id).
+			Limit(1).
+			Scan(&rows).Error; err != nil {
+			return err
+		}
+		result, err := op(tx, model, id, quantity)
+		if err != nil {
+			return err
+		}
+		affected = result
+		if affected != 1 || len(rows) == 0 {
+			return nil
+		}
+		movement := stockledgerdomain.Movement{
+			Source:    stockledgerdomain.SourceManual,
+			ProductID: rows[0].ProductID,
+			Kind:      kind,
+			RefType:   stockledgerdomain.RefTypeOrder,
+			RefID:     orderID,
+		}
+		if skuLevel {
+			movement.SKUID = id
+		}
+		applyManualStockDeltas(&movement, kind, quantity, rows[0].ManualStockLocked)
+		return stockledgergormstore.Record(tx, movement)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return affected, nil
+}
+
+// applyManualStockDeltas 与 gormutil 中的计数更新保持一致；消耗时占用不足的部分直接从可售扣除。
+func applyManualStockDeltas(movement *stockledgerdomain.Movement, kind string, quantity, lockedBefore int) {
+	switch kind {
+	case stockledgerdomain.KindLock:
+		movement.AvailableDelta = -quantity
+		movement.LockedDelta = quantity
+	case stockledgerdomain.KindRelease:
+		movement.AvailableDelta = quantity
+		movement.LockedDelta = -quantity
+	case stockledgerdomain.KindConsume:
+		movement.SoldDelta = quantity
+		if lockedBefore >= quantity {
+			movement.LockedDelta = -quantity
+			return
+		}
+		movement.AvailableDelta = -(quantity - lockedBefore)
+		movement.LockedDelta = -lockedBefore
+	}
 }
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	productdomain "github.com/dujiao-next/internal/modules/catalog/product/domain"
+	stockledgerdomain "github.com/dujiao-next/internal/modules/stockledger/domain"
 
 	"github.com/dujiao-next/internal/constants"
 	"github.com/dujiao-next/internal/shared/jsonmap"
@@ -22,7 +23,7 @@ func setupSKUStoreTest(t *testing.T) (*SKUStore, *gorm.DB) {
 	if err != nil {
 		t.Fatalf("open sqlite failed: %v", err)
 	}
-	if err := db.AutoMigrate(&productdomain.ProductSKU{}); err != nil {
+	if err := db.AutoMigrate(&productdomain.ProductSKU{}, &stockledgerdomain.Movement{}); err != nil {
 		t.Fatalf("migrate product sku failed: %v", err)
 	}
 	return NewSKUStore(db), db
@@ -89,11 +90,11 @@ func TestProductSKUManualStockLifecycleMatchesProductSemantics(t *testing.T) {
 		}
 	}
 
-	affected, err := repo.ReserveManualStock(sku.ID, 3)
+	affected, err := repo.ReserveManualStock(sku.ID, 3, 7)
 	assertAffected("reserve", affected, err)
-	affected, err = repo.ConsumeManualStock(sku.ID, 2)
+	affected, err = repo.ConsumeManualStock(sku.ID, 2, 7)
 	assertAffected("consume", affected, err)
-	affected, err = repo.ReleaseManualStock(sku.ID, 1)
+	affected, err = repo.ReleaseManualStock(sku.ID, 1, 7)
 	assertAffected("release", affected, err)
 
 	var reloaded productdomain.ProductSKU
@@ -103,6 +104,26 @@ func TestProductSKUManualStockLifecycleMatchesProductSemantics(t *testing.T) {
 	if reloaded.ManualStockTotal != 8 || reloaded.ManualStockLocked != 0 || reloaded.ManualStockSold != 2 {
 		t.Fatalf("unexpected stock lifecycle result: %#v", reloaded)
 	}
+	var movements []stockledgerdomain.Movement
+	if err := db.Order("id ASC").Find(&movements).Error; err != nil {
+		t.Fatalf("load stock movements: %v", err)
+	}
+	if len(movements) != 3 {
+		t.Fatalf("expected 3 stock movements, got %d", len(movements))
+	}
+	wantKinds := []string{stockledgerdomain.KindLock, stockledgerdomain.KindConsume, stockledgerdomain.KindRelease}
+	var available, locked, sold int
+	for i, movement := range movements {
+		if movement.Kind != wantKinds[i] || movement.SKUID != sku.ID || movement.ProductID != 1 || movement.RefType != stockledgerdomain.RefTypeOrder || movement.RefID != 7 {
+			t.Fatalf("unexpected stock movement %d: %#v", i, movement)
+		}
+		available += movement.AvailableDelta
+		locked += movement.LockedDelta
+		sold += movement.SoldDelta
+	}
+	if available != -2 || locked != 0 || sold != 2 {
+		t.Fatalf("stock movement deltas mismatch: available=%d locked=%d sold=%d", available, locked, sold)
+	}
 
 	unlimited := &productdomain.ProductSKU{
 		ProductID:        1,
@@ -114,10 +135,10 @@ func TestProductSKUManualStockLifecycleMatchesProductSemantics(t *testing.T) {
 	if err := repo.Create(unlimited); err != nil {
 		t.Fatalf("create unlimited sku: %v", err)
 	}
-	if affected, err := repo.ReserveManualStock(unlimited.ID, 1); err != nil || affected != 0 {
+	if affected, err := repo.ReserveManualStock(unlimited.ID, 1, 0); err != nil || affected != 0 {
 		t.Fatalf("unlimited reserve should be no-op, affected=%d err=%v", affected, err)
 	}
-	if affected, err := repo.ConsumeManualStock(unlimited.ID, 1); err != nil || affected != 0 {
+	if affected, err := repo.ConsumeManualStock(unlimited.ID, 1, 0); err != nil || affected != 0 {
 		t.Fatalf("unlimited consume should be no-op, affected=%d err=%v", affected, err)
 	}
 }
@@ -161,9 +182,9 @@ func TestSKUStoreDeleteByProductHidesRowsAndRejectsStockMutations(t *testing.T)
 	}
 
 	for operation, mutate := range map[string]func() (int64, error){
-		"reserve": func() (int64, error) { return repo.ReserveManualStock(sku.ID, 1) },
-		"release": func() (int64, error) { return repo.ReleaseManualStock(sku.ID, 1) },
-		"consume": func() (int64, error) { return repo.ConsumeManualStock(sku.ID, 1) },
+		"reserve": func() (int64, error) { return repo.ReserveManualStock(sku.ID, 1, 0) },
+		"release": func() (int64, error) { return repo.ReleaseManualStock(sku.ID, 1, 0) },
+		"consume": func() (int64, error) { return repo.ConsumeManualStock(sku.ID, 1, 0) },
 	} {
 		affected, err := mutate()
 		if err != nil || affected != 0 {
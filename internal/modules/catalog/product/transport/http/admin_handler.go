@@ -223,10 +223,13 @@ type CreateProductRequest struct {
 	MaxPurchaseQuantity *int                     `json:"max_purchase_quantity"`
 	StockDisplayMode    string                   `json:"stock_display_mode"`
 	FulfillmentType     string                   `json:"fulfillment_type"`
+	WebhookURL          string                   `json:"webhook_url"`
+	WebhookSecret       string                   `json:"webhook_secret"`
 	ManualStockTotal    *int                     `json:"manual_stock_total"`
 	SKUs                []ProductSKURequest      `json:"skus"`
 	PaymentChannelIDs   []uint                   `json:"payment_channel_ids"`
 	IsAffiliateEnabled  *bool                    `json:"is_affiliate_enabled"`
+	RequireManualReview *bool                    `json:"require_manual_review"`
 	IsActive            *bool                    `json:"is_active"`
 	SortOrder           int                      `json:"sort_order"`
 }
@@ -296,12 +299,16 @@ func (h *AdminProductHandler) CreateProduct(c *gin.Context) {
 		MaxPurchaseQuantity:  req.MaxPurchaseQuantity,
 		StockDisplayMode:     req.StockDisplayMode,
 		FulfillmentType:      req.FulfillmentType,
+		WebhookURL:           req.WebhookURL,
+		WebhookSecret:        req.WebhookSecret,
 		ManualStockTotal:     req.ManualStockTotal,
 		SKUs:                 toProductSKUInputs(req.SKUs),
 		PaymentChannelIDs:    req.PaymentChannelIDs,
 		IsAffiliateEnabled:   req.IsAffiliateEnabled,
+		RequireManualReview:  req.RequireManualReview,
 		IsActive:             req.IsActive,
 		SortOrder:            req.SortOrder,
+		OperatorAdminID:      c.GetUint("admin_id"),
 	})
 	if err != nil {
 		if errors.Is(err, productcontract.ErrSlugExists) {
@@ -324,6 +331,10 @@ func (h *AdminProductHandler) CreateProduct(c *gin.Context) {
 			ginutil.RespondError(c, response.CodeBadRequest, "error.fulfillment_invalid", nil)
 			return
 		}
+		if errors.Is(err, productcontract.ErrProductWebhookInvalid) {
+			ginutil.RespondError(c, response.CodeBadRequest, "error.product_webhook_invalid", nil)
+			return
+		}
 		if errors.Is(err, manualform.ErrSchemaInvalid) {
 			ginutil.RespondError(c, response.CodeBadRequest, "error.manual_form_schema_invalid", nil)
 			return
@@ -388,12 +399,16 @@ func (h *AdminProductHandler) UpdateProduct(c *gin.Context) {
 		MaxPurchaseQuantity:  req.MaxPurchaseQuantity,
 		StockDisplayMode:     req.StockDisplayMode,
 		FulfillmentType:      req.FulfillmentType,
+		WebhookURL:           req.WebhookURL,
+		WebhookSecret:        req.WebhookSecret,
 		ManualStockTotal:     req.ManualStockTotal,
 		SKUs:                 toProductSKUInputs(req.SKUs),
 		PaymentChannelIDs:    req.PaymentChannelIDs,
 		IsAffiliateEnabled:   req.IsAffiliateEnabled,
+		RequireManualReview:  req.RequireManualReview,
 		IsActive:             req.IsActive,
 		SortOrder:            req.SortOrder,
+		OperatorAdminID:      c.GetUint("admin_id"),
 	})
 	if err != nil {
 		if errors.Is(err, productcontract.ErrNotFound) {
@@ -420,6 +435,10 @@ func (h *AdminProductHandler) UpdateProduct(c *gin.Context) {
 			ginutil.RespondError(c, response.CodeBadRequest, "error.fulfillment_invalid", nil)
 			return
 		}
+		if errors.Is(err, productcontract.ErrProductWebhookInvalid) {
+			ginutil.RespondError(c, response.CodeBadRequest, "error.product_webhook_invalid", nil)
+			return
+		}
 		if errors.Is(err, manualform.ErrSchemaInvalid) {
 			ginutil.RespondError(c, response.CodeBadRequest, "error.manual_form_schema_invalid", nil)
 			return
@@ -9,6 +9,7 @@ import (
 
 	memberleveldomain "github.com/dujiao-next/internal/modules/memberlevel/domain"
 
+	domaincatalog "github.com/dujiao-next/internal/modules/catalog"
 	mappingdomain "github.com/dujiao-next/internal/modules/catalog/mapping/domain"
 
 	productcontract "github.com/dujiao-next/internal/modules/catalog/product/contract"
@@ -70,6 +71,7 @@ type PublicHandler struct {
 	memberLevels MemberLevelPricing
 	mappings     LocalProductMappingReader
 	skuMappings  SKUMappingLookup
+	thresholds   domaincatalog.StockThresholdSource
 	relatedPosts RelatedPostReader
 }
 
@@ -81,6 +83,7 @@ func NewPublicHandler(
 	memberLevels MemberLevelPricing,
 	mappings LocalProductMappingReader,
 	skuMappings SKUMappingLookup,
+	thresholds domaincatalog.StockThresholdSource,
 	relatedPosts RelatedPostReader,
 ) *PublicHandler {
 	if products == nil || relatedPosts == nil {
@@ -93,6 +96,7 @@ func NewPublicHandler(
 		memberLevels: memberLevels,
 		mappings:     mappings,
 		skuMappings:  skuMappings,
+		thresholds:   thresholds,
 		relatedPosts: relatedPosts,
 	}
 }
@@ -26,6 +26,7 @@ func (h *PublicHandler) decorateProductStock(product *productdomain.Product, ite
 	item.AutoStockAvailable = 0
 	item.StockStatus = stockStatus
 	item.IsSoldOut = false
+	item.StockThresholds = domaincatalog.LoadStockThresholds(h.thresholds)
 
 	fulfillmentType := strings.TrimSpace(product.FulfillmentType)
 	if fulfillmentType == "" {
@@ -38,6 +39,15 @@ func (h *PublicHandler) decorateProductStock(product *productdomain.Product, ite
 		return
 	}
 
+	// webhook / 文件 / 授权码类型：即时生成或重复授权下载，视为无限库存
+	if fulfillmentType == constants.FulfillmentTypeWebhook || fulfillmentType == constants.FulfillmentTypeFile ||
+		fulfillmentType == constants.FulfillmentTypeLicense {
+		item.ManualStockAvailable = constants.ManualStockUnlimited
+		item.StockStatus = constants.ProductStockStatusUnlimited
+		item.IsSoldOut = false
+		return
+	}
+
 	if fulfillmentType == constants.FulfillmentTypeManual {
 		hasActiveSKU := false
 		hasUnlimitedSKU := false
@@ -75,7 +85,7 @@ func (h *PublicHandler) decorateProductStock(product *productdomain.Product, ite
 			}
 		}
 		item.ManualStockAvailable = manualAvailable
-		item.StockStatus = domaincatalog.StorefrontStockPolicy().Status(int64(manualAvailable))
+		item.StockStatus = item.stockPolicy(0).Status(int64(manualAvailable))
 		item.IsSoldOut = item.StockStatus == constants.ProductStockStatusOutOfStock
 		return
 	}
@@ -98,7 +108,7 @@ func (h *PublicHandler) decorateProductStock(product *productdomain.Product, ite
 	item.AutoStockLocked = autoLocked
 	item.AutoStockSold = autoSold
 
-	item.StockStatus = domaincatalog.StorefrontStockPolicy().Status(autoAvailable)
+	item.StockStatus = item.stockPolicy(0).Status(autoAvailable)
 	item.IsSoldOut = item.StockStatus == constants.ProductStockStatusOutOfStock
 }
 
@@ -195,6 +205,6 @@ func (h *PublicHandler) decorateUpstreamStock(product *productdomain.Product, it
 		item.ManualStockAvailable = totalStock
 	}
 
-	item.StockStatus = domaincatalog.StorefrontStockPolicy().Status(int64(totalStock))
+	item.StockStatus = item.stockPolicy(0).Status(int64(totalStock))
 	item.IsSoldOut = item.StockStatus == constants.ProductStockStatusOutOfStock
 }
@@ -15,6 +15,7 @@ import (
 	categorypresenter "github.com/dujiao-next/internal/modules/catalog/category/transport/presenter"
 	promotioncontract "github.com/dujiao-next/internal/modules/promotion/contract"
 	reseller "github.com/dujiao-next/internal/modules/reseller/contract"
+	stockthresholddomain "github.com/dujiao-next/internal/modules/stockthreshold/domain"
 	"github.com/dujiao-next/internal/shared/money"
 )
 
@@ -39,18 +40,19 @@ type publicProductView struct {
 	AutoStockAvailable   int64
 	StockStatus          string
 	IsSoldOut            bool
+	StockThresholds      *stockthresholddomain.Set
 }
 
 // toProductResp 将内部计算结构转换为公共 DTO
 func (v *publicProductView) toProductResp() productpresenter.Product {
 	mode := domaincatalog.NormalizeStockDisplayMode(v.Product.StockDisplayMode)
 	productQuantity := v.productStockQuantity()
-	productDisplay := domaincatalog.StorefrontStockPolicy().Display(mode, v.StockStatus, productQuantity)
+	productDisplay := v.stockPolicy(0).Display(mode, v.StockStatus, productQuantity)
 
 	skus := make([]productpresenter.SKU, 0, len(v.PublicSKUs))
 	for _, sv := range v.PublicSKUs {
 		skuStatus, skuQuantity := v.skuStockState(sv)
-		skuDisplay := domaincatalog.StorefrontStockPolicy().Display(mode, skuStatus, skuQuantity)
+		skuDisplay := v.stockPolicy(sv.ID).Display(mode, skuStatus, skuQuantity)
 		skus = append(skus, productpresenter.SKU{
 			ID:                   sv.ID,
 			SKUCode:              sv.SKUCode,
@@ -125,10 +127,15 @@ func (v *publicProductView) skuStockState(sv publicSKUView) (string, int64) {
 		fulfillmentType = constants.FulfillmentTypeManual
 	}
 	quantity := domaincatalog.StockQuantity(fulfillmentType, sv.AutoStockAvailable, sv.ManualStockTotal)
-	status := domaincatalog.StorefrontStockPolicy().Status(quantity)
+	status := v.stockPolicy(sv.ID).Status(quantity)
 	return status, quantity
 }
 
+// stockPolicy 按商品分类、商品与规格解析店面库存策略；skuID 为 0 时按商品级解析。
+func (v *publicProductView) stockPolicy(skuID uint) domaincatalog.StockPolicy {
+	return domaincatalog.StorefrontStockPolicy(v.StockThresholds, v.Product.CategoryID, v.Product.ID, skuID)
+}
+
 func isResellerDisplayHiddenError(err error) bool {
 	return errors.Is(err, productcontract.ErrResellerProductNotListed) ||
 		errors.Is(err, reseller.ErrPriceBelowBase) ||
@@ -4,27 +4,52 @@ import (
 	"strings"
 
 	"github.com/dujiao-next/internal/constants"
+	stockthresholddomain "github.com/dujiao-next/internal/modules/stockthreshold/domain"
 )
 
+// 未配置分类、商品或规格级阈值覆盖时使用的默认低库存阈值。
 const (
 	StorefrontLowStockThreshold int64 = 5
 	UpstreamLowStockThreshold   int64 = 20
 )
 
+// StockThresholdSource 提供后台配置的逐级低库存阈值覆盖。
+type StockThresholdSource interface {
+	CurrentSet() (*stockthresholddomain.Set, error)
+}
+
+// LoadStockThresholds 读取阈值覆盖；来源为空或读取失败时返回 nil，库存策略回退默认阈值。
+func LoadStockThresholds(source StockThresholdSource) *stockthresholddomain.Set {
+	if source == nil {
+		return nil
+	}
+	set, err := source.CurrentSet()
+	if err != nil {
+		return nil
+	}
+	return set
+}
+
 // StockPolicy 描述特定消费上下文如何把可用数量映射为库存状态。
 // Public 与 Channel 属于店面消费上下文；Upstream API 保留更宽的低库存预警窗口。
 type StockPolicy struct {
 	LowStockThreshold int64
 }
 
-// StorefrontStockPolicy 返回 Public/Channel 使用的库存策略值。
-func StorefrontStockPolicy() StockPolicy {
-	return StockPolicy{LowStockThreshold: StorefrontLowStockThreshold}
+// StorefrontStockPolicy 返回 Public/Channel 使用的库存策略值；skuID 为 0 时按商品级解析阈值。
+func StorefrontStockPolicy(thresholds *stockthresholddomain.Set, categoryID, productID, skuID uint) StockPolicy {
+	return resolveStockPolicy(thresholds, StorefrontLowStockThreshold, categoryID, productID, skuID)
+}
+
+// UpstreamStockPolicy 返回 Upstream API 使用的库存策略值；skuID 为 0 时按商品级解析阈值。
+func UpstreamStockPolicy(thresholds *stockthresholddomain.Set, categoryID, productID, skuID uint) StockPolicy {
+	return resolveStockPolicy(thresholds, UpstreamLowStockThreshold, categoryID, productID, skuID)
 }
 
-// UpstreamStockPolicy 返回 Upstream API 使用的库存策略值。
-func UpstreamStockPolicy() StockPolicy {
-	return StockPolicy{LowStockThreshold: UpstreamLowStockThreshold}
+// resolveStockPolicy 按 规格 > 商品 > 分类 > 上下文默认 的顺序解析低库存阈值。
+func resolveStockPolicy(thresholds *stockthresholddomain.Set, fallback int64, categoryID, productID, skuID uint) StockPolicy {
+	values := thresholds.Resolve(stockthresholddomain.Values{LowStockQuantity: fallback}, categoryID, productID, skuID)
+	return StockPolicy{LowStockThreshold: values.LowStockQuantity}
 }
 
 // Status 根据可用数量计算库存状态。负数表示无限库存。
@@ -137,8 +162,12 @@ func StockRange(quantity int64) (string, *int, *int) {
 
 // StockQuantity 按交付类型选择已经计算完成的可用库存。
 func StockQuantity(fulfillmentType string, autoStockAvailable int64, manualStockAvailable int) int64 {
-	if strings.TrimSpace(fulfillmentType) == constants.FulfillmentTypeAuto {
+	switch strings.TrimSpace(fulfillmentType) {
+	case constants.FulfillmentTypeAuto:
 		return autoStockAvailable
+	case constants.FulfillmentTypeWebhook, constants.FulfillmentTypeFile, constants.FulfillmentTypeLicense:
+		// webhook 商品由发码服务即时生成、文件商品可重复授权下载、授权码按模板签发，均不受本地库存约束。
+		return int64(constants.ManualStockUnlimited)
 	}
 	return int64(manualStockAvailable)
 }
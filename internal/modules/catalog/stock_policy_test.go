@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/dujiao-next/internal/constants"
+	stockthresholddomain "github.com/dujiao-next/internal/modules/stockthreshold/domain"
 )
 
 func TestStockPolicyStatusKeepsContextThresholdsExplicit(t *testing.T) {
@@ -13,12 +14,12 @@ func TestStockPolicyStatusKeepsContextThresholdsExplicit(t *testing.T) {
 		quantity int64
 		want     string
 	}{
-		{name: "storefront unlimited", policy: StorefrontStockPolicy(), quantity: -1, want: constants.ProductStockStatusUnlimited},
-		{name: "storefront out", policy: StorefrontStockPolicy(), quantity: 0, want: constants.ProductStockStatusOutOfStock},
-		{name: "storefront low boundary", policy: StorefrontStockPolicy(), quantity: 5, want: constants.ProductStockStatusLowStock},
-		{name: "storefront in stock", policy: StorefrontStockPolicy(), quantity: 6, want: constants.ProductStockStatusInStock},
-		{name: "upstream low boundary", policy: UpstreamStockPolicy(), quantity: 20, want: constants.ProductStockStatusLowStock},
-		{name: "upstream in stock", policy: UpstreamStockPolicy(), quantity: 21, want: constants.ProductStockStatusInStock},
+		{name: "storefront unlimited", policy: StorefrontStockPolicy(nil, 0, 0, 0), quantity: -1, want: constants.ProductStockStatusUnlimited},
+		{name: "storefront out", policy: StorefrontStockPolicy(nil, 0, 0, 0), quantity: 0, want: constants.ProductStockStatusOutOfStock},
+		{name: "storefront low boundary", policy: StorefrontStockPolicy(nil, 0, 0, 0), quantity: 5, want: constants.ProductStockStatusLowStock},
+		{name: "storefront in stock", policy: StorefrontStockPolicy(nil, 0, 0, 0), quantity: 6, want: constants.ProductStockStatusInStock},
+		{name: "upstream low boundary", policy: UpstreamStockPolicy(nil, 0, 0, 0), quantity: 20, want: constants.ProductStockStatusLowStock},
+		{name: "upstream in stock", policy: UpstreamStockPolicy(nil, 0, 0, 0), quantity: 21, want: constants.ProductStockStatusInStock},
 	}
 
 	for _, test := range tests {
@@ -30,8 +31,40 @@ func TestStockPolicyStatusKeepsContextThresholdsExplicit(t *testing.T) {
 	}
 }
 
+func TestStockPolicyResolvesThresholdOverrides(t *testing.T) {
+	thresholds := stockthresholddomain.NewSet([]stockthresholddomain.Threshold{
+		{Scope: stockthresholddomain.ScopeCategory, TargetID: 1, LowStockQuantity: 30},
+		{Scope: stockthresholddomain.ScopeProduct, TargetID: 10, LowStockQuantity: 12},
+		{Scope: stockthresholddomain.ScopeSKU, TargetID: 100, LowStockQuantity: 2},
+		{Scope: stockthresholddomain.ScopeProduct, TargetID: 11, AlertDays: 7},
+	}, map[uint]uint{2: 1})
+
+	tests := []struct {
+		name   string
+		policy StockPolicy
+		want   int64
+	}{
+		{name: "storefront sku override", policy: StorefrontStockPolicy(thresholds, 2, 10, 100), want: 2},
+		{name: "storefront product override", policy: StorefrontStockPolicy(thresholds, 2, 10, 101), want: 12},
+		{name: "storefront parent category override", policy: StorefrontStockPolicy(thresholds, 2, 12, 0), want: 30},
+		{name: "storefront alert-only override keeps default", policy: StorefrontStockPolicy(thresholds, 3, 11, 0), want: StorefrontLowStockThreshold},
+		{name: "upstream product override", policy: UpstreamStockPolicy(thresholds, 3, 10, 0), want: 12},
+		{name: "upstream default", policy: UpstreamStockPolicy(thresholds, 3, 13, 0), want: UpstreamLowStockThreshold},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.policy.LowStockThreshold != test.want {
+				t.Fatalf("LowStockThreshold want %d got %d", test.want, test.policy.LowStockThreshold)
+			}
+		})
+	}
+	if got := StorefrontStockPolicy(thresholds, 2, 10, 0).Status(12); got != constants.ProductStockStatusLowStock {
+		t.Fatalf("product override should classify 12 as low_stock, got %q", got)
+	}
+}
+
 func TestStorefrontDisplayModesAndRanges(t *testing.T) {
-	rangeDisplay := StorefrontStockPolicy().Display(constants.ProductStockDisplayRange, "", 42)
+	rangeDisplay := StorefrontStockPolicy(nil, 0, 0, 0).Display(constants.ProductStockDisplayRange, "", 42)
 	if rangeDisplay.Mode != constants.ProductStockDisplayRange || rangeDisplay.Display != constants.ProductStockDisplayRange21To50 {
 		t.Fatalf("range display mismatch: %#v", rangeDisplay)
 	}
@@ -42,17 +75,17 @@ func TestStorefrontDisplayModesAndRanges(t *testing.T) {
 		t.Fatal("range mode must hide exact quantity")
 	}
 
-	statusDisplay := StorefrontStockPolicy().Display(constants.ProductStockDisplayStatus, "", 5)
+	statusDisplay := StorefrontStockPolicy(nil, 0, 0, 0).Display(constants.ProductStockDisplayStatus, "", 5)
 	if statusDisplay.Display != constants.ProductStockStatusLowStock || !statusDisplay.QuantityHidden {
 		t.Fatalf("status display mismatch: %#v", statusDisplay)
 	}
 
-	hiddenDisplay := StorefrontStockPolicy().Display(constants.ProductStockDisplayHidden, "", 6)
+	hiddenDisplay := StorefrontStockPolicy(nil, 0, 0, 0).Display(constants.ProductStockDisplayHidden, "", 6)
 	if hiddenDisplay.Display != constants.ProductStockDisplayHidden || !hiddenDisplay.QuantityHidden {
 		t.Fatalf("hidden display mismatch: %#v", hiddenDisplay)
 	}
 
-	exactDisplay := StorefrontStockPolicy().Display(constants.ProductStockDisplayExact, "", 6)
+	exactDisplay := StorefrontStockPolicy(nil, 0, 0, 0).Display(constants.ProductStockDisplayExact, "", 6)
 	if exactDisplay.Display != constants.ProductStockDisplayExact || exactDisplay.QuantityHidden {
 		t.Fatalf("exact display mismatch: %#v", exactDisplay)
 	}
@@ -0,0 +1,328 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dujiao-next/internal/logger"
+	changesetcontract "github.com/dujiao-next/internal/modules/changeset/contract"
+	changesetdomain "github.com/dujiao-next/internal/modules/changeset/domain"
+	"github.com/dujiao-next/internal/shared/money"
+
+	"github.com/shopspring/decimal"
+)
+
+// Options 描述变更集服务依赖。
+type Options struct {
+	Store   changesetcontract.Store
+	Catalog changesetcontract.Catalog
+	Now     func() time.Time
+}
+
+// Service 编排商品变更集的排期、预览、取消以及到期生效与回滚。
+type Service struct {
+	store   changesetcontract.Store
+	catalog changesetcontract.Catalog
+	now     func() time.Time
+}
+
+func NewService(options Options) *Service {
+	if options.Store == nil || options.Catalog == nil {
+		panic("change set service: required dependency is nil")
+	}
+	now := options.Now
+	if now == nil {
+		now = time.Now
+	}
+	return &Service{store: options.Store, catalog: options.Catalog, now: now}
+}
+
+// Create 校验并保存变更集，等待定时任务在生效时间执行。
+func (s *Service) Create(input changesetcontract.CreateInput) (*changesetdomain.ChangeSet, error) {
+	set, err := s.build(input)
+	if err != nil {
+		return nil, err
+	}
+	if !set.ApplyAt.After(s.now()) {
+		return nil, changesetcontract.ErrInvalid
+	}
+	if err := s.catalog.Validate(set.Items); err != nil {
+		return nil, err
+	}
+	if err := s.store.Create(set); err != nil {
+		return nil, err
+	}
+	s.recordEvent(set.ID, changesetdomain.EventCreated, input.OperatorID, fmt.Sprintf("排期 %d 项变更", len(set.Items)))
+	return set, nil
+}
+
+// Preview 不保存变更集，返回各项当前值与生效后将写入的值。
+func (s *Service) Preview(input changesetcontract.CreateInput) ([]changesetcontract.PreviewItem, error) {
+	set, err := s.build(input)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.catalog.Validate(set.Items); err != nil {
+		return nil, err
+	}
+	return s.preview(set.Items, false)
+}
+
+// PreviewByID 预览已保存变更集的下一步：待生效时对比目标值，已生效时对比回滚将恢复的原值。
+func (s *Service) PreviewByID(id uint) ([]changesetcontract.PreviewItem, error) {
+	set, err := s.get(id)
+	if err != nil {
+		return nil, err
+	}
+	switch set.Status {
+	case changesetdomain.StatusScheduled:
+		return s.preview(set.Items, false)
+	case changesetdomain.StatusApplied:
+		if set.RevertAt == nil {
+			return nil, changesetcontract.ErrStatusInvalid
+		}
+		return s.preview(set.Items, true)
+	default:
+		return nil, changesetcontract.ErrStatusInvalid
+	}
+}
+
+// Get 返回变更集详情及审计记录。
+func (s *Service) Get(id uint) (*changesetcontract.Detail, error) {
+	set, err := s.get(id)
+	if err != nil {
+		return nil, err
+	}
+	events, err := s.store.ListEvents(id)
+	if err != nil {
+		return nil, err
+	}
+	return &changesetcontract.Detail{ChangeSet: set, Events: events}, nil
+}
+
+// List 后台查询变更集。
+func (s *Service) List(filter changesetcontract.ListFilter) ([]changesetdomain.ChangeSet, int64, error) {
+	filter.Status = strings.TrimSpace(filter.Status)
+	return s.store.List(filter)
+}
+
+// Cancel 取消尚未生效的变更集。
+func (s *Service) Cancel(id, adminID uint) (*changesetdomain.ChangeSet, error) {
+	set, err := s.get(id)
+	if err != nil {
+		return nil, err
+	}
+	claimed, err := s.store.Claim(id, changesetdomain.StatusScheduled, changesetdomain.StatusCanceled)
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		return nil, changesetcontract.ErrStatusInvalid
+	}
+	now := s.now()
+	set.Status = changesetdomain.StatusCanceled
+	set.CanceledAt = &now
+	if err := s.store.Update(set); err != nil {
+		return nil, err
+	}
+	s.recordEvent(set.ID, changesetdomain.EventCanceled, adminID, "")
+	return set, nil
+}
+
+// RunDue 由定时任务调用：先恢复执行中断的变更集，再依次执行到期生效与到期回滚的变更集。
+func (s *Service) RunDue() (*changesetcontract.RunResult, error) {
+	result := &changesetcontract.RunResult{}
+	now := s.now()
+	if err := s.recoverStale(now); err != nil {
+		return nil, err
+	}
+	due, err := s.store.ListDueApply(now, changesetdomain.RunBatchSize)
+	if err != nil {
+		return nil, err
+	}
+	for i := range due {
+		if s.execute(&due[i], false) {
+			result.Applied++
+		} else if due[i].Status == changesetdomain.StatusFailed {
+			result.Failed++
+		}
+	}
+	due, err = s.store.ListDueRevert(now, changesetdomain.RunBatchSize)
+	if err != nil {
+		return nil, err
+	}
+	for i := range due {
+		if s.execute(&due[i], true) {
+			result.Reverted++
+		} else if due[i].Status == changesetdomain.StatusFailed {
+			result.Failed++
+		}
+	}
+	return result, nil
+}
+
+// execute 认领并执行一次生效或回滚，返回是否成功；认领失败说明已被其他进程处理。
+func (s *Service) execute(set *changesetdomain.ChangeSet, revert bool) bool {
+	from, to := changesetdomain.StatusScheduled, changesetdomain.StatusApplying
+	if revert {
+		from, to = changesetdomain.StatusApplied, changesetdomain.StatusReverting
+	}
+	claimed, err := s.store.Claim(set.ID, from, to)
+	if err != nil || !claimed {
+		if err != nil {
+			logger.Warnw("change_set_claim_failed", "change_set_id", set.ID, "error", err)
+		}
+		return false
+	}
+	set.Status = to
+
+	// 结果状态与商品写入在同一事务提交，失败时商品字段保持执行前的值
+	now := s.now()
+	action := changesetdomain.EventApplied
+	next := *set
+	if revert {
+		next.Status = changesetdomain.StatusReverted
+		next.RevertedAt = &now
+		action = changesetdomain.EventReverted
+	} else {
+		next.Status = changesetdomain.StatusApplied
+		next.AppliedAt = &now
+	}
+	writeErr := s.catalog.Execute(&next, revert)
+	if errors.Is(writeErr, changesetcontract.ErrStatusInvalid) {
+		// 已被中断恢复流程重置，交由下一轮执行
+		logger.Warnw("change_set_execute_superseded", "change_set_id", set.ID, "revert", revert)
+		return false
+	}
+	if writeErr != nil {
+		set.Status = changesetdomain.StatusFailed
+		set.ErrorMessage = writeErr.Error()
+		if err := s.store.Update(set); err != nil {
+			logger.Warnw("change_set_update_failed", "change_set_id", set.ID, "status", set.Status, "error", err)
+		}
+		s.recordEvent(set.ID, changesetdomain.EventFailed, 0, set.ErrorMessage)
+		logger.Warnw("change_set_execute_failed", "change_set_id", set.ID, "revert", revert, "error", writeErr)
+		return false
+	}
+	*set = next
+	s.recordEvent(set.ID, action, 0, "")
+	return true
+}
+
+// recoverStale 认领后进程中断的变更集未提交任何商品写入，恢复为执行前状态后重新执行。
+func (s *Service) recoverStale(now time.Time) error {
+	stale, err := s.store.ListStale(now.Add(-changesetdomain.StaleClaimTimeout), changesetdomain.RunBatchSize)
+	if err != nil {
+		return err
+	}
+	for _, set := range stale {
+		to := changesetdomain.StatusScheduled
+		if set.Status == changesetdomain.StatusReverting {
+			to = changesetdomain.StatusApplied
+		}
+		recovered, err := s.store.Claim(set.ID, set.Status, to)
+		if err != nil {
+			return err
+		}
+		if recovered {
+			s.recordEvent(set.ID, changesetdomain.EventRecovered, 0, fmt.Sprintf("%s 中断，恢复为 %s", set.Status, to))
+		}
+	}
+	return nil
+}
+
+func (s *Service) get(id uint) (*changesetdomain.ChangeSet, error) {
+	set, err := s.store.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if set == nil {
+		return nil, changesetcontract.ErrNotFound
+	}
+	return set, nil
+}
+
+func (s *Service) preview(items []changesetdomain.Item, revert bool) ([]changesetcontract.PreviewItem, error) {
+	current, err := s.catalog.Read(items)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]changesetcontract.PreviewItem, 0, len(items))
+	for i, item := range items {
+		next := item.Target
+		if revert {
+			next = item.Previous
+		}
+		result = append(result, changesetcontract.PreviewItem{Item: item, Current: current[i], Next: next})
+	}
+	return result, nil
+}
+
+// build 规范化输入：商品级变更不可改价，规格级变更仅可改价，同一目标不可重复出现。
+func (s *Service) build(input changesetcontract.CreateInput) (*changesetdomain.ChangeSet, error) {
+	name := strings.TrimSpace(input.Name)
+	if name == "" || input.ApplyAt.IsZero() || len(input.Items) == 0 || len(input.Items) > changesetdomain.MaxItems {
+		return nil, changesetcontract.ErrInvalid
+	}
+	if input.RevertAt != nil && !input.RevertAt.After(input.ApplyAt) {
+		return nil, changesetcontract.ErrInvalid
+	}
+	items := make([]changesetdomain.Item, 0, len(input.Items))
+	seen := make(map[string]struct{}, len(input.Items))
+	for _, raw := range input.Items {
+		item := changesetdomain.Item{
+			TargetType: strings.TrimSpace(raw.TargetType),
+			ProductID:  raw.ProductID,
+			SKUID:      raw.SKUID,
+			Target:     raw.Values,
+		}
+		if item.ProductID == 0 {
+			return nil, changesetcontract.ErrInvalid
+		}
+		switch item.TargetType {
+		case changesetdomain.TargetProduct:
+			value := item.Target
+			if item.SKUID != 0 || value.PriceAmount != nil ||
+				(value.IsActive == nil && value.CategoryID == nil && value.SortOrder == nil) {
+				return nil, changesetcontract.ErrInvalid
+			}
+		case changesetdomain.TargetSKU:
+			value := item.Target
+			if item.SKUID == 0 || value.PriceAmount == nil || value.IsActive != nil || value.CategoryID != nil || value.SortOrder != nil {
+				return nil, changesetcontract.ErrInvalid
+			}
+			price := value.PriceAmount.Decimal.Round(2)
+			if price.LessThanOrEqual(decimal.Zero) {
+				return nil, changesetcontract.ErrInvalid
+			}
+			rounded := money.FromDecimal(price)
+			item.Target.PriceAmount = &rounded
+		default:
+			return nil, changesetcontract.ErrInvalid
+		}
+		key := fmt.Sprintf("%s:%d:%d", item.TargetType, item.ProductID, item.SKUID)
+		if _, ok := seen[key]; ok {
+			return nil, changesetcontract.ErrInvalid
+		}
+		seen[key] = struct{}{}
+		items = append(items, item)
+	}
+	return &changesetdomain.ChangeSet{
+		Name:      name,
+		Remark:    strings.TrimSpace(input.Remark),
+		Status:    changesetdomain.StatusScheduled,
+		ApplyAt:   input.ApplyAt,
+		RevertAt:  input.RevertAt,
+		CreatedBy: input.OperatorID,
+		Items:     items,
+	}, nil
+}
+
+func (s *Service) recordEvent(changeSetID uint, action string, operatorID uint, message string) {
+	event := &changesetdomain.Event{ChangeSetID: changeSetID, Action: action, OperatorID: operatorID, Message: message}
+	if err := s.store.CreateEvent(event); err != nil {
+		logger.Warnw("change_set_event_record_failed", "change_set_id", changeSetID, "action", action, "error", err)
+	}
+}
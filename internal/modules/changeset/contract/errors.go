@@ -0,0 +1,10 @@
+package contract
+
+import "errors"
+
+var (
+	ErrNotFound       = errors.New("change set not found")
+	ErrInvalid        = errors.New("change set invalid")
+	ErrStatusInvalid  = errors.New("change set status invalid")
+	ErrTargetNotFound = errors.New("change set target not found")
+)
@@ -0,0 +1,39 @@
+package contract
+
+import (
+	"time"
+
+	changesetdomain "github.com/dujiao-next/internal/modules/changeset/domain"
+)
+
+// Store 持久化变更集、变更项与审计记录。
+type Store interface {
+	// Create 同时写入变更集及其变更项
+	Create(set *changesetdomain.ChangeSet) error
+	// Update 仅更新变更集自身字段
+	Update(set *changesetdomain.ChangeSet) error
+	// GetByID 返回变更集及其变更项
+	GetByID(id uint) (*changesetdomain.ChangeSet, error)
+	List(filter ListFilter) ([]changesetdomain.ChangeSet, int64, error)
+	// ListDueApply 返回生效时间已到的待生效变更集
+	ListDueApply(now time.Time, limit int) ([]changesetdomain.ChangeSet, error)
+	// ListDueRevert 返回回滚时间已到的已生效变更集
+	ListDueRevert(now time.Time, limit int) ([]changesetdomain.ChangeSet, error)
+	// ListStale 返回认领时间早于 before 仍处于执行中状态的变更集
+	ListStale(before time.Time, limit int) ([]changesetdomain.ChangeSet, error)
+	// Claim 仅当变更集处于 from 状态时改为 to，返回是否认领成功
+	Claim(id uint, from, to string) (bool, error)
+	CreateEvent(event *changesetdomain.Event) error
+	ListEvents(changeSetID uint) ([]changesetdomain.Event, error)
+}
+
+// Catalog 读写商品与规格上可定时变更的字段。
+type Catalog interface {
+	// Validate 校验变更项指向的商品、规格与分类存在且取值可用
+	Validate(items []changesetdomain.Item) error
+	// Read 按 items 顺序返回各项涉及字段的当前值
+	Read(items []changesetdomain.Item) ([]changesetdomain.Values, error)
+	// Execute 在单个事务中写入目标值（回滚时写入原值）并同步商品展示价，同时保存变更项原值与
+	// set 上的状态字段；变更集已不处于执行中状态时返回 ErrStatusInvalid 且不写入任何数据
+	Execute(set *changesetdomain.ChangeSet, revert bool) error
+}
@@ -0,0 +1,52 @@
+package contract
+
+import (
+	"time"
+
+	changesetdomain "github.com/dujiao-next/internal/modules/changeset/domain"
+)
+
+// CreateInput 新建变更集；RevertAt 为空表示生效后不回滚。
+type CreateInput struct {
+	Name       string
+	Remark     string
+	ApplyAt    time.Time
+	RevertAt   *time.Time
+	Items      []ItemInput
+	OperatorID uint
+}
+
+// ItemInput 变更项输入：商品级可设置 is_active、category_id、sort_order，规格级仅可设置 price_amount。
+type ItemInput struct {
+	TargetType string
+	ProductID  uint
+	SKUID      uint
+	Values     changesetdomain.Values
+}
+
+// ListFilter 后台变更集筛选条件。
+type ListFilter struct {
+	Status   string
+	Page     int
+	PageSize int
+}
+
+// PreviewItem 变更项预览：Current 为当前值，Next 为下一步（生效或回滚）将写入的值。
+type PreviewItem struct {
+	changesetdomain.Item
+	Current changesetdomain.Values `json:"current"`
+	Next    changesetdomain.Values `json:"next"`
+}
+
+// Detail 变更集详情及审计记录。
+type Detail struct {
+	*changesetdomain.ChangeSet
+	Events []changesetdomain.Event `json:"events"`
+}
+
+// RunResult 一次定时执行的结果统计。
+type RunResult struct {
+	Applied  int `json:"applied"`
+	Reverted int `json:"reverted"`
+	Failed   int `json:"failed"`
+}
@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/dujiao-next/internal/shared/money"
+)
+
+const (
+	// StatusScheduled 等待到达生效时间
+	StatusScheduled = "scheduled"
+	// StatusApplying/StatusReverting 已被定时任务认领，执行结果与状态在同一事务提交
+	StatusApplying = "applying"
+	// StatusApplied 已生效；设置了回滚时间的等待到期回滚
+	StatusApplied   = "applied"
+	StatusReverting = "reverting"
+	StatusReverted  = "reverted"
+	StatusCanceled  = "canceled"
+	// StatusFailed 生效或回滚失败，原因见 ErrorMessage，已写入的字段随事务回滚
+	StatusFailed = "failed"
+)
+
+const (
+	// TargetProduct 商品级变更：上架状态、分类、排序
+	TargetProduct = "product"
+	// TargetSKU 规格级变更：售价
+	TargetSKU = "sku"
+)
+
+const (
+	EventCreated  = "created"
+	EventCanceled = "canceled"
+	EventApplied  = "applied"
+	EventReverted = "reverted"
+	EventFailed   = "failed"
+	// EventRecovered 执行中断的变更集恢复为执行前状态等待重试
+	EventRecovered = "recovered"
+)
+
+const (
+	// MaxItems 单个变更集允许的变更项数量
+	MaxItems = 200
+	// RunBatchSize 单次定时任务处理的到期变更集数量
+	RunBatchSize = 50
+	// StaleClaimTimeout 认领后超过该时长仍处于执行中，视为进程中断
+	StaleClaimTimeout = 10 * time.Minute
+)
+
+// Values 变更项涉及的字段取值，nil 表示不涉及该字段。
+type Values struct {
+	PriceAmount *money.Amount `gorm:"type:decimal(20,2)" json:"price_amount,omitempty"`
+	IsActive    *bool         `json:"is_active,omitempty"`
+	CategoryID  *uint         `json:"category_id,omitempty"`
+	SortOrder   *int          `json:"sort_order,omitempty"`
+}
+
+// ChangeSet 定时生效的商品变更集：到达 ApplyAt 时写入各项目标值，设置 RevertAt 时到期恢复变更前的值。
+type ChangeSet struct {
+	ID           uint       `gorm:"primarykey" json:"id"`
+	Name         string     `gorm:"type:varchar(120);not null" json:"name"`
+	Remark       string     `gorm:"type:varchar(255);not null;default:''" json:"remark"`
+	Status       string     `gorm:"type:varchar(20);not null;index" json:"status"`
+	ApplyAt      time.Time  `gorm:"not null;index" json:"apply_at"`
+	RevertAt     *time.Time `gorm:"index" json:"revert_at,omitempty"`
+	AppliedAt    *time.Time `json:"applied_at,omitempty"`
+	RevertedAt   *time.Time `json:"reverted_at,omitempty"`
+	CanceledAt   *time.Time `json:"canceled_at,omitempty"`
+	CreatedBy    uint       `gorm:"not null;default:0" json:"created_by"`
+	ErrorMessage string     `gorm:"type:text" json:"error_message,omitempty"`
+	CreatedAt    time.Time  `gorm:"index" json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+
+	Items []Item `gorm:"foreignKey:ChangeSetID" json:"items,omitempty"`
+}
+
+func (ChangeSet) TableName() string { return "product_change_sets" }
+
+// Item 变更集中的单个变更项；Previous 在生效时记录被覆盖的原值，用于到期回滚。
+type Item struct {
+	ID          uint   `gorm:"primarykey" json:"id"`
+	ChangeSetID uint   `gorm:"not null;index" json:"change_set_id"`
+	TargetType  string `gorm:"type:varchar(20);not null" json:"target_type"`
+	ProductID   uint   `gorm:"not null;index" json:"product_id"`
+	SKUID       uint   `gorm:"column:sku_id;not null;default:0" json:"sku_id,omitempty"`
+	Target      Values `gorm:"embedded;embeddedPrefix:target_" json:"target"`
+	Previous    Values `gorm:"embedded;embeddedPrefix:previous_" json:"previous"`
+}
+
+func (Item) TableName() string { return "product_change_set_items" }
+
+// Event 变更集审计记录；OperatorID 为 0 表示由定时任务执行。
+type Event struct {
+	ID          uint      `gorm:"primarykey" json:"id"`
+	ChangeSetID uint      `gorm:"not null;index" json:"change_set_id"`
+	Action      string    `gorm:"type:varchar(20);not null" json:"action"`
+	OperatorID  uint      `gorm:"not null;default:0" json:"operator_id"`
+	Message     string    `gorm:"type:text" json:"message,omitempty"`
+	CreatedAt   time.Time `gorm:"index" json:"created_at"`
+}
+
+func (Event) TableName() string { return "product_change_set_events" }
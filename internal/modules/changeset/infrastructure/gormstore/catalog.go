@@ -0,0 +1,276 @@
+package gormstore
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	productdomain "github.com/dujiao-next/internal/modules/catalog/product/domain"
+	changesetcontract "github.com/dujiao-next/internal/modules/changeset/contract"
+	changesetdomain "github.com/dujiao-next/internal/modules/changeset/domain"
+	"github.com/dujiao-next/internal/shared/money"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Catalog 直接按列更新商品与规格，避免整行保存覆盖并发变化的库存计数。
+type Catalog struct {
+	db         *gorm.DB
+	categories productdomain.CategoryAssignmentRepository
+}
+
+var _ changesetcontract.Catalog = (*Catalog)(nil)
+
+func NewCatalog(db *gorm.DB, categories productdomain.CategoryAssignmentRepository) *Catalog {
+	if db == nil || categories == nil {
+		panic("change set catalog: required dependency is nil")
+	}
+	return &Catalog{db: db, categories: categories}
+}
+
+func (c *Catalog) Validate(items []changesetdomain.Item) error {
+	return c.validate(items, itemTargets(items))
+}
+
+func (c *Catalog) Read(items []changesetdomain.Item) ([]changesetdomain.Values, error) {
+	return readValues(c.db, items, itemTargets(items), false)
+}
+
+func (c *Catalog) Execute(set *changesetdomain.ChangeSet, revert bool) error {
+	items := set.Items
+	values := make([]changesetdomain.Values, 0, len(items))
+	for _, item := range items {
+		if revert {
+			values = append(values, item.Previous)
+		} else {
+			values = append(values, item.Target)
+		}
+	}
+	// 分类校验在事务外完成，避免 SQLite 读后写自锁
+	if err := c.validate(items, values); err != nil {
+		return err
+	}
+	return c.db.Transaction(func(tx *gorm.DB) error {
+		previous, err := readValues(tx, items, values, true)
+		if err != nil {
+			return err
+		}
+		repriced := make(map[uint]struct{})
+		now := time.Now()
+		for i, item := range items {
+			fields := map[string]interface{}{"updated_at": now}
+			value := values[i]
+			if item.TargetType == changesetdomain.TargetSKU {
+				if value.PriceAmount == nil {
+					continue
+				}
+				fields["price_amount"] = money.FromDecimal(value.PriceAmount.Decimal.Round(2))
+				if err := tx.Model(&productdomain.ProductSKU{}).Where("id = ?", item.SKUID).Updates(fields).Error; err != nil {
+					return err
+				}
+				repriced[item.ProductID] = struct{}{}
+				continue
+			}
+			if value.IsActive != nil {
+				fields["is_active"] = *value.IsActive
+			}
+			if value.CategoryID != nil {
+				fields["category_id"] = *value.CategoryID
+			}
+			if value.SortOrder != nil {
+				fields["sort_order"] = *value.SortOrder
+			}
+			if err := tx.Model(&productdomain.Product{}).Where("id = ?", item.ProductID).Updates(fields).Error; err != nil {
+				return err
+			}
+		}
+		for productID := range repriced {
+			if err := syncProductPrice(tx, productID, now); err != nil {
+				return err
+			}
+		}
+		if !revert {
+			for i := range items {
+				items[i].Previous = previous[i]
+				if err := tx.Save(&items[i]).Error; err != nil {
+					return err
+				}
+			}
+		}
+		return finishExecution(tx, set, revert, now)
+	})
+}
+
+// finishExecution 仅当变更集仍处于执行中状态时提交结果状态，否则回滚整个事务。
+func finishExecution(tx *gorm.DB, set *changesetdomain.ChangeSet, revert bool, now time.Time) error {
+	from := changesetdomain.StatusApplying
+	if revert {
+		from = changesetdomain.StatusReverting
+	}
+	result := tx.Model(&changesetdomain.ChangeSet{}).
+		Where("id = ? AND status = ?", set.ID, from).
+		Updates(map[string]interface{}{
+			"status":        set.Status,
+			"applied_at":    set.AppliedAt,
+			"reverted_at":   set.RevertedAt,
+			"error_message": set.ErrorMessage,
+			"updated_at":    now,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected != 1 {
+		return changesetcontract.ErrStatusInvalid
+	}
+	return nil
+}
+
+// validate 校验目标存在；商品上架或改分类时要求分类为启用的末级分类。
+func (c *Catalog) validate(items []changesetdomain.Item, values []changesetdomain.Values) error {
+	for i, item := range items {
+		if item.TargetType == changesetdomain.TargetSKU {
+			var count int64
+			if err := c.db.Model(&productdomain.ProductSKU{}).
+				Where("id = ? AND product_id = ? AND deleted_at IS NULL", item.SKUID, item.ProductID).
+				Count(&count).Error; err != nil {
+				return err
+			}
+			if count == 0 {
+				return changesetcontract.ErrTargetNotFound
+			}
+			continue
+		}
+		product, err := loadProduct(c.db, item.ProductID, false)
+		if err != nil {
+			return err
+		}
+		value := values[i]
+		categoryID := product.CategoryID
+		if value.CategoryID != nil {
+			categoryID = *value.CategoryID
+			if err := productdomain.ValidateCategoryAssignment(c.categories, categoryID, product.CategoryID, changesetcontract.ErrInvalid); err != nil {
+				return err
+			}
+		}
+		active := product.IsActive
+		if value.IsActive != nil {
+			active = *value.IsActive
+		}
+		if active && (categoryID != product.CategoryID || !product.IsActive) {
+			if err := c.validateActiveCategory(categoryID); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (c *Catalog) validateActiveCategory(categoryID uint) error {
+	if categoryID == 0 {
+		return changesetcontract.ErrInvalid
+	}
+	idText := strconv.FormatUint(uint64(categoryID), 10)
+	category, err := c.categories.GetByID(idText)
+	if err != nil {
+		return err
+	}
+	if category == nil || !category.IsActive {
+		return changesetcontract.ErrInvalid
+	}
+	children, err := c.categories.CountChildren(idText)
+	if err != nil {
+		return err
+	}
+	if children > 0 {
+		return changesetcontract.ErrInvalid
+	}
+	return nil
+}
+
+// readValues 按 fields 中非空的字段读取各项当前值。
+func readValues(db *gorm.DB, items []changesetdomain.Item, fields []changesetdomain.Values, forUpdate bool) ([]changesetdomain.Values, error) {
+	result := make([]changesetdomain.Values, len(items))
+	for i, item := range items {
+		field := fields[i]
+		if item.TargetType == changesetdomain.TargetSKU {
+			query := db.Where("id = ? AND product_id = ? AND deleted_at IS NULL", item.SKUID, item.ProductID)
+			if forUpdate {
+				query = query.Clauses(clause.Locking{Strength: "UPDATE"})
+			}
+			var sku productdomain.ProductSKU
+			if err := query.First(&sku).Error; err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return nil, changesetcontract.ErrTargetNotFound
+				}
+				return nil, err
+			}
+			if field.PriceAmount != nil {
+				price := sku.PriceAmount
+				result[i].PriceAmount = &price
+			}
+			continue
+		}
+		product, err := loadProduct(db, item.ProductID, forUpdate)
+		if err != nil {
+			return nil, err
+		}
+		if field.IsActive != nil {
+			active := product.IsActive
+			result[i].IsActive = &active
+		}
+		if field.CategoryID != nil {
+			categoryID := product.CategoryID
+			result[i].CategoryID = &categoryID
+		}
+		if field.SortOrder != nil {
+			sortOrder := product.SortOrder
+			result[i].SortOrder = &sortOrder
+		}
+	}
+	return result, nil
+}
+
+func loadProduct(db *gorm.DB, productID uint, forUpdate bool) (*productdomain.Product, error) {
+	query := db.Select("id, category_id, is_active, sort_order").Where("id = ? AND deleted_at IS NULL", productID)
+	if forUpdate {
+		query = query.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
+	var product productdomain.Product
+	if err := query.First(&product).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, changesetcontract.ErrTargetNotFound
+		}
+		return nil, err
+	}
+	return &product, nil
+}
+
+// syncProductPrice 与商品编辑保持一致：商品展示价取启用规格的最低售价。
+func syncProductPrice(tx *gorm.DB, productID uint, now time.Time) error {
+	var skus []productdomain.ProductSKU
+	if err := tx.Select("price_amount").
+		Where("product_id = ? AND is_active = ? AND deleted_at IS NULL", productID, true).
+		Find(&skus).Error; err != nil {
+		return err
+	}
+	if len(skus) == 0 {
+		return nil
+	}
+	minPrice := skus[0].PriceAmount.Decimal
+	for _, sku := range skus[1:] {
+		if sku.PriceAmount.Decimal.LessThan(minPrice) {
+			minPrice = sku.PriceAmount.Decimal
+		}
+	}
+	return tx.Model(&productdomain.Product{}).Where("id = ?", productID).
+		Updates(map[string]interface{}{"price_amount": money.FromDecimal(minPrice.Round(2)), "updated_at": now}).Error
+}
+
+func itemTargets(items []changesetdomain.Item) []changesetdomain.Values {
+	values := make([]changesetdomain.Values, 0, len(items))
+	for _, item := range items {
+		values = append(values, item.Target)
+	}
+	return values
+}
@@ -0,0 +1,116 @@
+package gormstore
+
+import (
+	"errors"
+	"time"
+
+	changesetcontract "github.com/dujiao-next/internal/modules/changeset/contract"
+	changesetdomain "github.com/dujiao-next/internal/modules/changeset/domain"
+
+	"gorm.io/gorm"
+)
+
+// Store 是商品变更集的 GORM 仓储。
+type Store struct {
+	db *gorm.DB
+}
+
+var _ changesetcontract.Store = (*Store)(nil)
+
+func New(db *gorm.DB) *Store {
+	if db == nil {
+		panic("change set store: db is nil")
+	}
+	return &Store{db: db}
+}
+
+func (s *Store) Create(set *changesetdomain.ChangeSet) error {
+	return s.db.Create(set).Error
+}
+
+func (s *Store) Update(set *changesetdomain.ChangeSet) error {
+	return s.db.Omit("Items").Save(set).Error
+}
+
+func (s *Store) GetByID(id uint) (*changesetdomain.ChangeSet, error) {
+	var set changesetdomain.ChangeSet
+	if err := s.db.Preload("Items", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id ASC")
+	}).First(&set, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &set, nil
+}
+
+func (s *Store) List(filter changesetcontract.ListFilter) ([]changesetdomain.ChangeSet, int64, error) {
+	query := s.db.Model(&changesetdomain.ChangeSet{})
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if filter.PageSize > 0 {
+		page := filter.Page
+		if page < 1 {
+			page = 1
+		}
+		query = query.Offset((page - 1) * filter.PageSize).Limit(filter.PageSize)
+	}
+	var sets []changesetdomain.ChangeSet
+	if err := query.Order("apply_at DESC, id DESC").Find(&sets).Error; err != nil {
+		return nil, 0, err
+	}
+	return sets, total, nil
+}
+
+func (s *Store) ListDueApply(now time.Time, limit int) ([]changesetdomain.ChangeSet, error) {
+	var sets []changesetdomain.ChangeSet
+	err := s.db.Preload("Items", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id ASC")
+	}).Where("status = ? AND apply_at <= ?", changesetdomain.StatusScheduled, now).
+		Order("apply_at ASC, id ASC").Limit(limit).Find(&sets).Error
+	return sets, err
+}
+
+func (s *Store) ListDueRevert(now time.Time, limit int) ([]changesetdomain.ChangeSet, error) {
+	var sets []changesetdomain.ChangeSet
+	err := s.db.Preload("Items", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id ASC")
+	}).Where("status = ? AND revert_at IS NOT NULL AND revert_at <= ?", changesetdomain.StatusApplied, now).
+		Order("revert_at ASC, id ASC").Limit(limit).Find(&sets).Error
+	return sets, err
+}
+
+func (s *Store) ListStale(before time.Time, limit int) ([]changesetdomain.ChangeSet, error) {
+	var sets []changesetdomain.ChangeSet
+	err := s.db.Where("status IN ? AND updated_at <= ?", []string{changesetdomain.StatusApplying, changesetdomain.StatusReverting}, before).
+		Order("updated_at ASC, id ASC").Limit(limit).Find(&sets).Error
+	return sets, err
+}
+
+func (s *Store) Claim(id uint, from, to string) (bool, error) {
+	result := s.db.Model(&changesetdomain.ChangeSet{}).
+		Where("id = ? AND status = ?", id, from).
+		Updates(map[string]interface{}{"status": to, "updated_at": time.Now()})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (s *Store) CreateEvent(event *changesetdomain.Event) error {
+	return s.db.Create(event).Error
+}
+
+func (s *Store) ListEvents(changeSetID uint) ([]changesetdomain.Event, error) {
+	var events []changesetdomain.Event
+	if err := s.db.Where("change_set_id = ?", changeSetID).Order("id ASC").Find(&events).Error; err != nil {
+		return nil, err
+	}
+	return events, nil
+}
@@ -0,0 +1,391 @@
+package integrationtest
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	categorydomain "github.com/dujiao-next/internal/modules/catalog/category/domain"
+	categorygormstore "github.com/dujiao-next/internal/modules/catalog/category/infrastructure/gormstore"
+	productdomain "github.com/dujiao-next/internal/modules/catalog/product/domain"
+	changesetapp "github.com/dujiao-next/internal/modules/changeset/application"
+	changesetcontract "github.com/dujiao-next/internal/modules/changeset/contract"
+	changesetdomain "github.com/dujiao-next/internal/modules/changeset/domain"
+	changesetgormstore "github.com/dujiao-next/internal/modules/changeset/infrastructure/gormstore"
+	"github.com/dujiao-next/internal/shared/jsonmap"
+	"github.com/dujiao-next/internal/shared/money"
+
+	"github.com/glebarez/sqlite"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+type fixture struct {
+	db      *gorm.DB
+	now     time.Time
+	store   *claimHookStore
+	service *changesetapp.Service
+}
+
+// claimHookStore 在认领成功后执行 afterClaim，用于模拟认领与执行之间的并发变化。
+type claimHookStore struct {
+	*changesetgormstore.Store
+	afterClaim func(id uint)
+}
+
+func (s *claimHookStore) Claim(id uint, from, to string) (bool, error) {
+	claimed, err := s.Store.Claim(id, from, to)
+	if claimed && s.afterClaim != nil && to == changesetdomain.StatusApplying {
+		s.afterClaim(id)
+	}
+	return claimed, err
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+	dsn := fmt.Sprintf("file:change_set_%d?mode=memory&cache=shared", time.Now().UnixNano())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite failed: %v", err)
+	}
+	if err := db.AutoMigrate(
+		&categorydomain.Category{},
+		&productdomain.Product{},
+		&productdomain.ProductSKU{},
+		&changesetdomain.ChangeSet{},
+		&changesetdomain.Item{},
+		&changesetdomain.Event{},
+	); err != nil {
+		t.Fatalf("migrate failed: %v", err)
+	}
+	f := &fixture{
+		db:    db,
+		now:   time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC),
+		store: &claimHookStore{Store: changesetgormstore.New(db)},
+	}
+	f.service = changesetapp.NewService(changesetapp.Options{
+		Store:   f.store,
+		Catalog: changesetgormstore.NewCatalog(db, categorygormstore.NewCategoryStore(db)),
+		Now:     func() time.Time { return f.now },
+	})
+	return f
+}
+
+func (f *fixture) createCategory(t *testing.T, slug string) categorydomain.Category {
+	t.Helper()
+	category := categorydomain.Category{Slug: slug, NameJSON: jsonmap.JSON{"zh-CN": slug}, IsActive: true}
+	if err := f.db.Create(&category).Error; err != nil {
+		t.Fatalf("create category: %v", err)
+	}
+	return category
+}
+
+// createProduct 创建已下架商品及两个规格，展示价取最低规格价。
+func (f *fixture) createProduct(t *testing.T, categoryID uint, slug string) (productdomain.Product, []productdomain.ProductSKU) {
+	t.Helper()
+	product := productdomain.Product{
+		CategoryID:  categoryID,
+		Slug:        slug,
+		TitleJSON:   jsonmap.JSON{"zh-CN": slug},
+		PriceAmount: amount(10),
+		SortOrder:   1,
+	}
+	if err := f.db.Create(&product).Error; err != nil {
+		t.Fatalf("create product: %v", err)
+	}
+	if err := f.db.Model(&product).Update("is_active", false).Error; err != nil {
+		t.Fatalf("deactivate product: %v", err)
+	}
+	skus := []productdomain.ProductSKU{
+		{ProductID: product.ID, SKUCode: "A", PriceAmount: amount(10), IsActive: true},
+		{ProductID: product.ID, SKUCode: "B", PriceAmount: amount(20), IsActive: true},
+	}
+	if err := f.db.Create(&skus).Error; err != nil {
+		t.Fatalf("create skus: %v", err)
+	}
+	return product, skus
+}
+
+func (f *fixture) reload(t *testing.T, productID, skuID uint) (productdomain.Product, productdomain.ProductSKU) {
+	t.Helper()
+	var product productdomain.Product
+	if err := f.db.First(&product, productID).Error; err != nil {
+		t.Fatalf("reload product: %v", err)
+	}
+	var sku productdomain.ProductSKU
+	if err := f.db.First(&sku, skuID).Error; err != nil {
+		t.Fatalf("reload sku: %v", err)
+	}
+	return product, sku
+}
+
+func (f *fixture) run(t *testing.T) *changesetcontract.RunResult {
+	t.Helper()
+	result, err := f.service.RunDue()
+	if err != nil {
+		t.Fatalf("run due: %v", err)
+	}
+	return result
+}
+
+func amount(value int64) money.Amount {
+	return money.FromDecimal(decimal.NewFromInt(value))
+}
+
+func launchInput(productID, skuID uint, applyAt time.Time, revertAt *time.Time) changesetcontract.CreateInput {
+	price := amount(5)
+	active := true
+	sort := 99
+	return changesetcontract.CreateInput{
+		Name:       "launch",
+		ApplyAt:    applyAt,
+		RevertAt:   revertAt,
+		OperatorID: 7,
+		Items: []changesetcontract.ItemInput{
+			{TargetType: changesetdomain.TargetProduct, ProductID: productID, Values: changesetdomain.Values{IsActive: &active, SortOrder: &sort}},
+			{TargetType: changesetdomain.TargetSKU, ProductID: productID, SKUID: skuID, Values: changesetdomain.Values{PriceAmount: &price}},
+		},
+	}
+}
+
+func TestChangeSetAppliesAndRevertsOnSchedule(t *testing.T) {
+	f := newFixture(t)
+	category := f.createCategory(t, "games")
+	product, skus := f.createProduct(t, category.ID, "game-a")
+	applyAt := f.now.Add(time.Hour)
+	revertAt := f.now.Add(2 * time.Hour)
+
+	set, err := f.service.Create(launchInput(product.ID, skus[0].ID, applyAt, &revertAt))
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if result := f.run(t); result.Applied != 0 {
+		t.Fatalf("change set should wait until apply time, got %+v", result)
+	}
+
+	f.now = applyAt
+	if result := f.run(t); result.Applied != 1 || result.Failed != 0 {
+		t.Fatalf("expected one applied change set, got %+v", result)
+	}
+	gotProduct, gotSKU := f.reload(t, product.ID, skus[0].ID)
+	if !gotProduct.IsActive || gotProduct.SortOrder != 99 || !gotSKU.PriceAmount.Decimal.Equal(decimal.NewFromInt(5)) {
+		t.Fatalf("targets not applied: product=%+v sku=%+v", gotProduct, gotSKU)
+	}
+	if !gotProduct.PriceAmount.Decimal.Equal(decimal.NewFromInt(5)) {
+		t.Fatalf("product price should follow cheapest sku, got %s", gotProduct.PriceAmount.String())
+	}
+
+	preview, err := f.service.PreviewByID(set.ID)
+	if err != nil {
+		t.Fatalf("preview applied: %v", err)
+	}
+	if len(preview) != 2 || preview[1].Next.PriceAmount == nil || !preview[1].Next.PriceAmount.Decimal.Equal(decimal.NewFromInt(10)) {
+		t.Fatalf("revert preview should restore previous price, got %+v", preview)
+	}
+
+	f.now = revertAt
+	if result := f.run(t); result.Reverted != 1 {
+		t.Fatalf("expected one reverted change set, got %+v", result)
+	}
+	gotProduct, gotSKU = f.reload(t, product.ID, skus[0].ID)
+	if gotProduct.IsActive || gotProduct.SortOrder != 1 || !gotSKU.PriceAmount.Decimal.Equal(decimal.NewFromInt(10)) ||
+		!gotProduct.PriceAmount.Decimal.Equal(decimal.NewFromInt(10)) {
+		t.Fatalf("values not reverted: product=%+v sku=%+v", gotProduct, gotSKU)
+	}
+
+	detail, err := f.service.Get(set.ID)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if detail.Status != changesetdomain.StatusReverted || detail.AppliedAt == nil || detail.RevertedAt == nil {
+		t.Fatalf("unexpected change set state: %+v", detail.ChangeSet)
+	}
+	actions := make([]string, 0, len(detail.Events))
+	for _, event := range detail.Events {
+		actions = append(actions, event.Action)
+	}
+	if len(actions) != 3 {
+		t.Fatalf("expected three audit events, got %v", actions)
+	}
+	if result := f.run(t); result.Applied != 0 || result.Reverted != 0 {
+		t.Fatalf("finished change set must not run again, got %+v", result)
+	}
+}
+
+func TestChangeSetPreviewDoesNotWrite(t *testing.T) {
+	f := newFixture(t)
+	category := f.createCategory(t, "games")
+	product, skus := f.createProduct(t, category.ID, "game-a")
+
+	preview, err := f.service.Preview(launchInput(product.ID, skus[1].ID, f.now.Add(time.Hour), nil))
+	if err != nil {
+		t.Fatalf("preview: %v", err)
+	}
+	if len(preview) != 2 {
+		t.Fatalf("expected two preview items, got %d", len(preview))
+	}
+	if preview[0].Current.IsActive == nil || *preview[0].Current.IsActive || *preview[0].Next.IsActive != true {
+		t.Fatalf("unexpected product preview: %+v", preview[0])
+	}
+	if preview[1].Current.PriceAmount == nil || !preview[1].Current.PriceAmount.Decimal.Equal(decimal.NewFromInt(20)) {
+		t.Fatalf("unexpected sku preview: %+v", preview[1])
+	}
+	if _, total, _ := f.service.List(changesetcontract.ListFilter{}); total != 0 {
+		t.Fatalf("preview must not persist change sets, got %d", total)
+	}
+}
+
+func TestChangeSetCancelBeforeApply(t *testing.T) {
+	f := newFixture(t)
+	category := f.createCategory(t, "games")
+	product, skus := f.createProduct(t, category.ID, "game-a")
+	set, err := f.service.Create(launchInput(product.ID, skus[0].ID, f.now.Add(time.Hour), nil))
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	canceled, err := f.service.Cancel(set.ID, 8)
+	if err != nil {
+		t.Fatalf("cancel: %v", err)
+	}
+	if canceled.Status != changesetdomain.StatusCanceled || canceled.CanceledAt == nil {
+		t.Fatalf("unexpected canceled change set: %+v", canceled)
+	}
+	if _, err := f.service.Cancel(set.ID, 8); !errors.Is(err, changesetcontract.ErrStatusInvalid) {
+		t.Fatalf("second cancel should fail with status invalid, got %v", err)
+	}
+
+	f.now = f.now.Add(2 * time.Hour)
+	if result := f.run(t); result.Applied != 0 {
+		t.Fatalf("canceled change set must not apply, got %+v", result)
+	}
+	gotProduct, _ := f.reload(t, product.ID, skus[0].ID)
+	if gotProduct.IsActive {
+		t.Fatalf("canceled change set must not activate product")
+	}
+}
+
+func TestChangeSetRejectsInvalidInput(t *testing.T) {
+	f := newFixture(t)
+	category := f.createCategory(t, "games")
+	product, skus := f.createProduct(t, category.ID, "game-a")
+	other, otherSKUs := f.createProduct(t, category.ID, "game-b")
+	applyAt := f.now.Add(time.Hour)
+	revertAt := f.now.Add(30 * time.Minute)
+
+	cases := map[string]struct {
+		input changesetcontract.CreateInput
+		want  error
+	}{
+		"apply in past":        {launchInput(product.ID, skus[0].ID, f.now.Add(-time.Minute), nil), changesetcontract.ErrInvalid},
+		"revert before apply":  {launchInput(product.ID, skus[0].ID, applyAt, &revertAt), changesetcontract.ErrInvalid},
+		"sku of other product": {launchInput(product.ID, otherSKUs[0].ID, applyAt, nil), changesetcontract.ErrTargetNotFound},
+	}
+	duplicate := launchInput(other.ID, otherSKUs[0].ID, applyAt, nil)
+	duplicate.Items = append(duplicate.Items, duplicate.Items[1])
+	cases["duplicate target"] = struct {
+		input changesetcontract.CreateInput
+		want  error
+	}{duplicate, changesetcontract.ErrInvalid}
+
+	inactive := categorydomain.Category{Slug: "hidden", NameJSON: jsonmap.JSON{"zh-CN": "hidden"}, IsActive: true}
+	if err := f.db.Create(&inactive).Error; err != nil {
+		t.Fatalf("create category: %v", err)
+	}
+	if err := f.db.Model(&inactive).Update("is_active", false).Error; err != nil {
+		t.Fatalf("deactivate category: %v", err)
+	}
+	moveToInactive := launchInput(product.ID, skus[0].ID, applyAt, nil)
+	moveToInactive.Items[0].Values.CategoryID = &inactive.ID
+	cases["activate into inactive category"] = struct {
+		input changesetcontract.CreateInput
+		want  error
+	}{moveToInactive, changesetcontract.ErrInvalid}
+
+	for name, tc := range cases {
+		if _, err := f.service.Create(tc.input); !errors.Is(err, tc.want) {
+			t.Fatalf("%s: want %v, got %v", name, tc.want, err)
+		}
+	}
+	if _, total, _ := f.service.List(changesetcontract.ListFilter{}); total != 0 {
+		t.Fatalf("invalid change sets must not persist, got %d", total)
+	}
+}
+
+func TestChangeSetRecoversInterruptedRun(t *testing.T) {
+	f := newFixture(t)
+	category := f.createCategory(t, "games")
+	product, skus := f.createProduct(t, category.ID, "game-a")
+	applyAt := f.now.Add(time.Hour)
+	set, err := f.service.Create(launchInput(product.ID, skus[0].ID, applyAt, nil))
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	// 模拟认领后进程退出：状态停留在 applying 且没有任何商品写入
+	if err := f.db.Model(&changesetdomain.ChangeSet{}).Where("id = ?", set.ID).Updates(map[string]interface{}{
+		"status":     changesetdomain.StatusApplying,
+		"updated_at": applyAt,
+	}).Error; err != nil {
+		t.Fatalf("prepare interrupted run: %v", err)
+	}
+
+	f.now = applyAt.Add(time.Minute)
+	if result := f.run(t); result.Applied != 0 {
+		t.Fatalf("recently claimed change set must not be recovered, got %+v", result)
+	}
+	f.now = applyAt.Add(changesetdomain.StaleClaimTimeout + time.Minute)
+	if result := f.run(t); result.Applied != 1 {
+		t.Fatalf("expected recovered change set to apply, got %+v", result)
+	}
+	gotProduct, gotSKU := f.reload(t, product.ID, skus[0].ID)
+	if !gotProduct.IsActive || !gotSKU.PriceAmount.Decimal.Equal(decimal.NewFromInt(5)) {
+		t.Fatalf("recovered change set not applied: product=%+v sku=%+v", gotProduct, gotSKU)
+	}
+	detail, err := f.service.Get(set.ID)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if len(detail.Events) != 3 || detail.Events[1].Action != changesetdomain.EventRecovered {
+		t.Fatalf("expected recovered event before applied, got %+v", detail.Events)
+	}
+}
+
+func TestChangeSetExecutionRollsBackWhenClaimIsLost(t *testing.T) {
+	f := newFixture(t)
+	category := f.createCategory(t, "games")
+	product, skus := f.createProduct(t, category.ID, "game-a")
+	applyAt := f.now.Add(time.Hour)
+	set, err := f.service.Create(launchInput(product.ID, skus[0].ID, applyAt, nil))
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	// 认领后被恢复流程重置，执行结果无法提交时商品写入须一并回滚
+	f.store.afterClaim = func(id uint) {
+		if err := f.db.Model(&changesetdomain.ChangeSet{}).Where("id = ?", id).
+			Update("status", changesetdomain.StatusScheduled).Error; err != nil {
+			t.Fatalf("reset claim: %v", err)
+		}
+	}
+
+	f.now = applyAt
+	if result := f.run(t); result.Applied != 0 || result.Failed != 0 {
+		t.Fatalf("superseded run must neither apply nor fail, got %+v", result)
+	}
+	gotProduct, gotSKU := f.reload(t, product.ID, skus[0].ID)
+	if gotProduct.IsActive || gotProduct.SortOrder != 1 || !gotSKU.PriceAmount.Decimal.Equal(decimal.NewFromInt(10)) {
+		t.Fatalf("catalog writes must roll back: product=%+v sku=%+v", gotProduct, gotSKU)
+	}
+	detail, err := f.service.Get(set.ID)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if detail.Status != changesetdomain.StatusScheduled || detail.Items[0].Previous.IsActive != nil {
+		t.Fatalf("change set must stay scheduled without saved previous values, got %+v", detail.ChangeSet)
+	}
+
+	f.store.afterClaim = nil
+	if result := f.run(t); result.Applied != 1 {
+		t.Fatalf("expected retry to apply, got %+v", result)
+	}
+}
@@ -0,0 +1,197 @@
+package changesethttp
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	changesetcontract "github.com/dujiao-next/internal/modules/changeset/contract"
+	changesetdomain "github.com/dujiao-next/internal/modules/changeset/domain"
+	"github.com/dujiao-next/internal/platform/http/ginutil"
+	"github.com/dujiao-next/internal/platform/http/response"
+	"github.com/dujiao-next/internal/shared/money"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminService 是后台商品变更集排期、预览与取消所需的最小用例接口。
+type AdminService interface {
+	List(filter changesetcontract.ListFilter) ([]changesetdomain.ChangeSet, int64, error)
+	Get(id uint) (*changesetcontract.Detail, error)
+	Create(input changesetcontract.CreateInput) (*changesetdomain.ChangeSet, error)
+	Preview(input changesetcontract.CreateInput) ([]changesetcontract.PreviewItem, error)
+	PreviewByID(id uint) ([]changesetcontract.PreviewItem, error)
+	Cancel(id, adminID uint) (*changesetdomain.ChangeSet, error)
+}
+
+// AdminHandler 处理后台商品变更集请求。
+type AdminHandler struct {
+	service AdminService
+}
+
+func NewAdminHandler(service AdminService) *AdminHandler {
+	if service == nil {
+		panic("change set admin handler: required dependency is nil")
+	}
+	return &AdminHandler{service: service}
+}
+
+// ChangeSetRequest 变更集排期请求；revert_at 为空表示生效后不回滚
+type ChangeSetRequest struct {
+	Name     string        `json:"name" binding:"required,max=120"`
+	Remark   string        `json:"remark" binding:"max=255"`
+	ApplyAt  time.Time     `json:"apply_at" binding:"required"`
+	RevertAt *time.Time    `json:"revert_at"`
+	Items    []ItemRequest `json:"items" binding:"required,min=1,dive"`
+}
+
+// ItemRequest 变更项；target_type 为 product 时可设置 is_active、category_id、sort_order，为 sku 时仅可设置 price_amount
+type ItemRequest struct {
+	TargetType  string        `json:"target_type" binding:"required,oneof=product sku"`
+	ProductID   uint          `json:"product_id" binding:"required"`
+	SKUID       uint          `json:"sku_id"`
+	PriceAmount *money.Amount `json:"price_amount"`
+	IsActive    *bool         `json:"is_active"`
+	CategoryID  *uint         `json:"category_id"`
+	SortOrder   *int          `json:"sort_order"`
+}
+
+func (r ChangeSetRequest) toInput(operatorID uint) changesetcontract.CreateInput {
+	items := make([]changesetcontract.ItemInput, 0, len(r.Items))
+	for _, item := range r.Items {
+		items = append(items, changesetcontract.ItemInput{
+			TargetType: item.TargetType,
+			ProductID:  item.ProductID,
+			SKUID:      item.SKUID,
+			Values: changesetdomain.Values{
+				PriceAmount: item.PriceAmount,
+				IsActive:    item.IsActive,
+				CategoryID:  item.CategoryID,
+				SortOrder:   item.SortOrder,
+			},
+		})
+	}
+	return changesetcontract.CreateInput{
+		Name:       r.Name,
+		Remark:     r.Remark,
+		ApplyAt:    r.ApplyAt,
+		RevertAt:   r.RevertAt,
+		Items:      items,
+		OperatorID: operatorID,
+	}
+}
+
+// List 获取商品变更集（支持 status 筛选）
+func (h *AdminHandler) List(c *gin.Context) {
+	page, pageSize := ginutil.ParsePagination(c)
+	sets, total, err := h.service.List(changesetcontract.ListFilter{
+		Status:   strings.TrimSpace(c.Query("status")),
+		Page:     page,
+		PageSize: pageSize,
+	})
+	if err != nil {
+		ginutil.RespondError(c, response.CodeInternal, "error.change_set_fetch_failed", err)
+		return
+	}
+	response.SuccessWithPage(c, sets, response.BuildPagination(page, pageSize, total))
+}
+
+// Get 获取变更集详情及审计记录
+func (h *AdminHandler) Get(c *gin.Context) {
+	id, err := ginutil.ParseParamUint(c, "id")
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", nil)
+		return
+	}
+	detail, err := h.service.Get(id)
+	if err != nil {
+		respondError(c, err, "error.change_set_fetch_failed")
+		return
+	}
+	response.Success(c, detail)
+}
+
+// Create 排期新的变更集
+func (h *AdminHandler) Create(c *gin.Context) {
+	adminID, ok := ginutil.GetAdminID(c)
+	if !ok {
+		ginutil.RespondError(c, response.CodeUnauthorized, "error.unauthorized", nil)
+		return
+	}
+	var req ChangeSetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ginutil.RespondBindError(c, err)
+		return
+	}
+	set, err := h.service.Create(req.toInput(adminID))
+	if err != nil {
+		respondError(c, err, "error.change_set_save_failed")
+		return
+	}
+	response.Success(c, set)
+}
+
+// Preview 预览尚未保存的变更集
+func (h *AdminHandler) Preview(c *gin.Context) {
+	var req ChangeSetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ginutil.RespondBindError(c, err)
+		return
+	}
+	items, err := h.service.Preview(req.toInput(0))
+	if err != nil {
+		respondError(c, err, "error.change_set_fetch_failed")
+		return
+	}
+	response.Success(c, items)
+}
+
+// PreviewByID 预览变更集下一步（生效或回滚）将带来的变化
+func (h *AdminHandler) PreviewByID(c *gin.Context) {
+	id, err := ginutil.ParseParamUint(c, "id")
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", nil)
+		return
+	}
+	items, err := h.service.PreviewByID(id)
+	if err != nil {
+		respondError(c, err, "error.change_set_fetch_failed")
+		return
+	}
+	response.Success(c, items)
+}
+
+// Cancel 取消尚未生效的变更集
+func (h *AdminHandler) Cancel(c *gin.Context) {
+	id, err := ginutil.ParseParamUint(c, "id")
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", nil)
+		return
+	}
+	adminID, ok := ginutil.GetAdminID(c)
+	if !ok {
+		ginutil.RespondError(c, response.CodeUnauthorized, "error.unauthorized", nil)
+		return
+	}
+	set, err := h.service.Cancel(id, adminID)
+	if err != nil {
+		respondError(c, err, "error.change_set_save_failed")
+		return
+	}
+	response.Success(c, set)
+}
+
+func respondError(c *gin.Context, err error, fallbackKey string) {
+	switch {
+	case errors.Is(err, changesetcontract.ErrNotFound):
+		ginutil.RespondError(c, response.CodeNotFound, "error.change_set_not_found", nil)
+	case errors.Is(err, changesetcontract.ErrInvalid):
+		ginutil.RespondError(c, response.CodeBadRequest, "error.change_set_invalid", nil)
+	case errors.Is(err, changesetcontract.ErrTargetNotFound):
+		ginutil.RespondError(c, response.CodeBadRequest, "error.change_set_target_not_found", nil)
+	case errors.Is(err, changesetcontract.ErrStatusInvalid):
+		ginutil.RespondError(c, response.CodeBadRequest, "error.change_set_status_invalid", nil)
+	default:
+		ginutil.RespondError(c, response.CodeInternal, fallbackKey, err)
+	}
+}
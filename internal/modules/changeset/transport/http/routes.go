@@ -0,0 +1,16 @@
+package changesethttp
+
+import "github.com/gin-gonic/gin"
+
+// RegisterAdminRoutes 注册后台商品变更集路由。
+func RegisterAdminRoutes(authorized gin.IRoutes, handler *AdminHandler) {
+	if authorized == nil || handler == nil {
+		panic("change set admin routes: required dependency is nil")
+	}
+	authorized.GET("/product-change-sets", handler.List)
+	authorized.POST("/product-change-sets", handler.Create)
+	authorized.POST("/product-change-sets/preview", handler.Preview)
+	authorized.GET("/product-change-sets/:id", handler.Get)
+	authorized.GET("/product-change-sets/:id/preview", handler.PreviewByID)
+	authorized.POST("/product-change-sets/:id/cancel", handler.Cancel)
+}
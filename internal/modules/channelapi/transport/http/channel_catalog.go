@@ -186,6 +186,7 @@ func (h *Handler) GetProducts(c *gin.Context) {
 		CategoryName        string                  `json:"category_name"`
 	}
 
+	thresholds := domaincatalog.LoadStockThresholds(h.StockThresholds)
 	items := make([]productItem, 0, len(products))
 	for _, p := range products {
 		title := resolveLocalizedJSON(p.TitleJSON, locale, defaultLocale)
@@ -204,8 +205,9 @@ func (h *Handler) GetProducts(c *gin.Context) {
 		}
 
 		stockCount := domaincatalog.StockQuantity(ft, p.AutoStockAvailable, p.ManualStockTotal)
-		stockStatus := domaincatalog.StorefrontStockPolicy().Status(stockCount)
-		stockDisplay := domaincatalog.StorefrontStockPolicy().Display(p.StockDisplayMode, stockStatus, stockCount)
+		stockPolicy := domaincatalog.StorefrontStockPolicy(thresholds, p.CategoryID, p.ID, 0)
+		stockStatus := stockPolicy.Status(stockCount)
+		stockDisplay := stockPolicy.Display(p.StockDisplayMode, stockStatus, stockCount)
 		item := productItem{
 			ID:                  p.ID,
 			Title:               title,
@@ -316,6 +318,7 @@ func (h *Handler) GetProductDetail(c *gin.Context) {
 		StockQuantityHidden bool   `json:"stock_quantity_hidden"`
 	}
 
+	thresholds := domaincatalog.LoadStockThresholds(h.StockThresholds)
 	skus := make([]skuItem, 0, len(product.SKUs))
 	for _, sku := range product.SKUs {
 		if !sku.IsActive {
@@ -323,8 +326,9 @@ func (h *Handler) GetProductDetail(c *gin.Context) {
 		}
 		specValues := resolveLocalizedJSON(sku.SpecValuesJSON, locale, defaultLocale)
 		stockCount := domaincatalog.StockQuantity(effectiveFT, sku.AutoStockAvailable, sku.ManualStockTotal)
-		stockStatus := domaincatalog.StorefrontStockPolicy().Status(stockCount)
-		stockDisplay := domaincatalog.StorefrontStockPolicy().Display(product.StockDisplayMode, stockStatus, stockCount)
+		stockPolicy := domaincatalog.StorefrontStockPolicy(thresholds, product.CategoryID, product.ID, sku.ID)
+		stockStatus := stockPolicy.Status(stockCount)
+		stockDisplay := stockPolicy.Display(product.StockDisplayMode, stockStatus, stockCount)
 		si := skuItem{
 			ID:                  sku.ID,
 			SKUCode:             sku.SKUCode,
@@ -357,8 +361,9 @@ func (h *Handler) GetProductDetail(c *gin.Context) {
 	}
 
 	stockCount := domaincatalog.StockQuantity(effectiveFT, product.AutoStockAvailable, product.ManualStockTotal)
-	stockStatus := domaincatalog.StorefrontStockPolicy().Status(stockCount)
-	stockDisplay := domaincatalog.StorefrontStockPolicy().Display(product.StockDisplayMode, stockStatus, stockCount)
+	stockPolicy := domaincatalog.StorefrontStockPolicy(thresholds, product.CategoryID, product.ID, 0)
+	stockStatus := stockPolicy.Status(stockCount)
+	stockDisplay := stockPolicy.Display(product.StockDisplayMode, stockStatus, stockCount)
 	respondChannelSuccess(c, gin.H{
 		"id":                    product.ID,
 		"title":                 title,
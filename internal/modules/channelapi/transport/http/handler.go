@@ -9,6 +9,7 @@ import (
 
 	orderdomain "github.com/dujiao-next/internal/modules/order/domain"
 
+	domaincatalog "github.com/dujiao-next/internal/modules/catalog"
 	mappingdomain "github.com/dujiao-next/internal/modules/catalog/mapping/domain"
 
 	productdomain "github.com/dujiao-next/internal/modules/catalog/product/domain"
@@ -31,6 +32,8 @@ var (
 	ErrRiskProductQuantityLimit      = errors.New("risk product quantity limit")
 	ErrRiskPendingProductLimit       = errors.New("risk pending product quantity limit")
 	ErrRiskOrderRateLimited          = errors.New("order rate limited")
+	ErrRiskOrderBlocked              = errors.New("risk order blocked")
+	ErrRiskCustomerBlacklisted       = errors.New("risk customer blacklisted")
 	ErrProductSKURequired            = errors.New("product sku required")
 	ErrProductSKUInvalid             = errors.New("product sku invalid")
 	ErrInvalidOrderItem              = errors.New("invalid order item")
@@ -220,6 +223,7 @@ type Dependencies struct {
 	OrderService       Orders
 	PaymentService     Payments
 	PaymentStore       PaymentStoresitory
+	StockThresholds    domaincatalog.StockThresholdSource
 }
 
 type Handler struct {
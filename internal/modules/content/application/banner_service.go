@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/dujiao-next/internal/constants"
+	"github.com/dujiao-next/internal/i18n/locales"
 	"github.com/dujiao-next/internal/modules/content/contract"
 	"github.com/dujiao-next/internal/modules/content/domain"
 	"github.com/dujiao-next/internal/shared/jsonmap"
@@ -224,7 +225,7 @@ func normalizeBannerLinkType(raw string) string {
 
 func normalizeMultiLangJSON(raw map[string]interface{}) jsonmap.JSON {
 	result := jsonmap.JSON{}
-	for _, key := range constants.SupportedLocales {
+	for _, key := range locales.Enabled() {
 		value, exists := raw[key]
 		if !exists {
 			result[key] = ""
@@ -5,12 +5,10 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/dujiao-next/internal/constants"
+	"github.com/dujiao-next/internal/i18n/locales"
 	"gorm.io/gorm"
 )
 
-var localizedJSONSearchKeys = append([]string(nil), constants.SupportedLocales...)
-
 func withContext(db *gorm.DB, ctx context.Context) *gorm.DB {
 	if ctx == nil {
 		return db
@@ -48,7 +46,7 @@ func buildLocalizedLikeCondition(db *gorm.DB, plainColumns, jsonColumns []string
 }
 
 func buildLocalizedLikeConditionByDialect(dialect string, plainColumns, jsonColumns []string) (string, int) {
-	parts := make([]string, 0, len(plainColumns)+len(jsonColumns)*len(localizedJSONSearchKeys))
+	parts := make([]string, 0, len(plainColumns)+len(jsonColumns)*len(locales.Enabled()))
 	argCount := 0
 	operator := likeOperatorByDialect(dialect)
 
@@ -66,7 +64,7 @@ func buildLocalizedLikeConditionByDialect(dialect string, plainColumns, jsonColu
 		if trimmed == "" {
 			continue
 		}
-		for _, key := range localizedJSONSearchKeys {
+		for _, key := range locales.Enabled() {
 			parts = append(parts, fmt.Sprintf("%s %s ?", jsonTextExprByDialect(dialect, trimmed, key), operator))
 			argCount++
 		}
@@ -12,6 +12,7 @@ import (
 	categorydomain "github.com/dujiao-next/internal/modules/catalog/category/domain"
 
 	"github.com/dujiao-next/internal/constants"
+	"github.com/dujiao-next/internal/i18n/locales"
 	contentcontract "github.com/dujiao-next/internal/modules/content/contract"
 	contentdomain "github.com/dujiao-next/internal/modules/content/domain"
 	"github.com/dujiao-next/internal/shared/jsonmap"
@@ -311,12 +312,13 @@ func TestStoresPropagateCancelledContext(t *testing.T) {
 }
 
 func TestLocalizedSearchDialectExpressions(t *testing.T) {
+	wantArgs := 1 + len(locales.Enabled())
 	postgres, postgresArgs := buildLocalizedLikeConditionByDialect("postgres", []string{"slug"}, []string{"title_json"})
-	if postgresArgs != 4 || !strings.Contains(postgres, "ILIKE") || !strings.Contains(postgres, "title_json::jsonb ->> 'zh-CN'") {
+	if postgresArgs != wantArgs || !strings.Contains(postgres, "ILIKE") || !strings.Contains(postgres, "title_json::jsonb ->> 'zh-CN'") {
 		t.Fatalf("postgres localized search expression mismatch args=%d sql=%s", postgresArgs, postgres)
 	}
 	sqliteCondition, sqliteArgs := buildLocalizedLikeConditionByDialect("sqlite", []string{"slug"}, []string{"title_json"})
-	if sqliteArgs != 4 || !strings.Contains(sqliteCondition, " LIKE ") || !strings.Contains(sqliteCondition, `json_extract(title_json, '$."zh-CN"')`) {
+	if sqliteArgs != wantArgs || !strings.Contains(sqliteCondition, " LIKE ") || !strings.Contains(sqliteCondition, `json_extract(title_json, '$."zh-CN"')`) {
 		t.Fatalf("sqlite localized search expression mismatch args=%d sql=%s", sqliteArgs, sqliteCondition)
 	}
 }
@@ -40,7 +40,7 @@ func (s *Service) GetOverview(ctx context.Context, input reportingdomain.Query)
 
 	setting := s.loadSetting()
 
-	cacheKey := fmt.Sprintf("dashboard:overview:%s:%d:%d:%s:%d:%d:%d:%d",
+	cacheKey := fmt.Sprintf("dashboard:overview:%s:%d:%d:%s:%d:%d:%d:%d:%d:%d",
 		window.Range,
 		window.StartAt.Unix(),
 		window.EndAt.Unix(),
@@ -49,6 +49,8 @@ func (s *Service) GetOverview(ctx context.Context, input reportingdomain.Query)
 		setting.Alert.OutOfStockProductsThreshold,
 		setting.Alert.PendingPaymentOrdersThreshold,
 		setting.Alert.PaymentsFailedThreshold,
+		setting.Alert.ForecastAlertDays,
+		setting.Alert.ForecastWindowDays,
 	)
 	if !input.ForceRefresh {
 		var cached OverviewResponse
@@ -66,7 +68,7 @@ func (s *Service) GetOverview(ctx context.Context, input reportingdomain.Query)
 	if err != nil {
 		return nil, err
 	}
-	stockStats, err := s.repo.GetStockStats(setting.Alert.LowStockThreshold)
+	stockStats, err := s.repo.GetStockStats(inventoryPolicy(setting.Alert))
 	if err != nil {
 		return nil, err
 	}
@@ -148,11 +150,28 @@ func (s *Service) LoadDashboardAlertSetting() settingsstorefront.DashboardAlertS
 }
 
 // GetInventoryAlertItems 获取库存异常明细
-func (s *Service) GetInventoryAlertItems(_ context.Context, lowStockThreshold int64) ([]dashboardcontract.InventoryAlertRow, error) {
+func (s *Service) GetInventoryAlertItems(_ context.Context, alert settingsstorefront.DashboardAlertSetting) ([]dashboardcontract.InventoryAlertRow, error) {
 	if s == nil || s.repo == nil {
 		return []dashboardcontract.InventoryAlertRow{}, nil
 	}
-	return s.repo.GetInventoryAlertItems(lowStockThreshold)
+	return s.repo.GetInventoryAlertItems(inventoryPolicy(alert))
+}
+
+// GetInventoryForecast 获取全部有限库存条目的销量预测
+func (s *Service) GetInventoryForecast(_ context.Context, alert settingsstorefront.DashboardAlertSetting) ([]dashboardcontract.InventoryAlertRow, error) {
+	if s == nil || s.repo == nil {
+		return []dashboardcontract.InventoryAlertRow{}, nil
+	}
+	return s.repo.GetInventoryForecastItems(inventoryPolicy(alert))
+}
+
+func inventoryPolicy(alert settingsstorefront.DashboardAlertSetting) dashboardcontract.InventoryPolicy {
+	return dashboardcontract.InventoryPolicy{
+		LowStockThreshold:  alert.LowStockThreshold,
+		ForecastAlertDays:  int(alert.ForecastAlertDays),
+		ForecastWindowDays: int(alert.ForecastWindowDays),
+		Now:                time.Now(),
+	}
 }
 
 // GetPaymentOrderAlertCounts 获取支付订单告警计数
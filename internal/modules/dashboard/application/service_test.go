@@ -30,11 +30,15 @@ func (s dashboardServiceRepoStub) GetPaymentTrends(startAt, endAt time.Time) ([]
 	return []dashboardcontract.PaymentTrendRow{}, nil
 }
 
-func (s dashboardServiceRepoStub) GetStockStats(lowStockThreshold int64) (dashboardcontract.StockStatsRow, error) {
+func (s dashboardServiceRepoStub) GetStockStats(policy dashboardcontract.InventoryPolicy) (dashboardcontract.StockStatsRow, error) {
 	return s.stock, nil
 }
 
-func (s dashboardServiceRepoStub) GetInventoryAlertItems(lowStockThreshold int64) ([]dashboardcontract.InventoryAlertRow, error) {
+func (s dashboardServiceRepoStub) GetInventoryAlertItems(policy dashboardcontract.InventoryPolicy) ([]dashboardcontract.InventoryAlertRow, error) {
+	return []dashboardcontract.InventoryAlertRow{}, nil
+}
+
+func (s dashboardServiceRepoStub) GetInventoryForecastItems(policy dashboardcontract.InventoryPolicy) ([]dashboardcontract.InventoryAlertRow, error) {
 	return []dashboardcontract.InventoryAlertRow{}, nil
 }
 
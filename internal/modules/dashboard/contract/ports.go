@@ -15,8 +15,10 @@ type Repository interface {
 	GetPaymentTrends(startAt, endAt time.Time) ([]PaymentTrendRow, error)
 	GetProfitOverview(startAt, endAt time.Time) (ProfitOverviewRow, error)
 	GetProfitTrends(startAt, endAt time.Time) ([]ProfitTrendRow, error)
-	GetStockStats(lowStockThreshold int64) (StockStatsRow, error)
-	GetInventoryAlertItems(lowStockThreshold int64) ([]InventoryAlertRow, error)
+	GetStockStats(policy InventoryPolicy) (StockStatsRow, error)
+	GetInventoryAlertItems(policy InventoryPolicy) ([]InventoryAlertRow, error)
+	// GetInventoryForecastItems 返回全部有限库存条目及其销量预测，不论是否触发告警
+	GetInventoryForecastItems(policy InventoryPolicy) ([]InventoryAlertRow, error)
 	GetTopProducts(startAt, endAt time.Time, limit int) ([]ProductRankingRow, error)
 	GetTopChannels(startAt, endAt time.Time, limit int) ([]ChannelRankingRow, error)
 	GetTotalUserBalance() (float64, error)
@@ -80,6 +82,14 @@ type StockStatsRow struct {
 	ManualAvailableUnits int64
 }
 
+// InventoryPolicy 是库存告警的全局默认阈值与销量预测窗口；分类、商品、规格可逐级覆盖阈值。
+type InventoryPolicy struct {
+	LowStockThreshold  int64
+	ForecastAlertDays  int
+	ForecastWindowDays int
+	Now                time.Time
+}
+
 type InventoryAlertRow struct {
 	ProductID         uint
 	SKUID             uint
@@ -89,6 +99,11 @@ type InventoryAlertRow struct {
 	FulfillmentType   string
 	AlertType         string
 	AvailableStock    int64
+	LowStockThreshold int64
+	AlertDays         int
+	// DailySales 为预测窗口内的日均销量；DaysLeft 为按该速度可售天数，窗口内无销量时为 nil
+	DailySales float64
+	DaysLeft   *float64
 }
 
 type ProductRankingRow struct {
@@ -23,9 +23,10 @@ func (r *Store) GetTopChannels(startAt, endAt time.Time, limit int) ([]dashboard
 			payments.channel_type as channel_type,
 			SUM(CASE WHEN payments.status = 'success' THEN 1 ELSE 0 END) as success_count,
 			SUM(CASE WHEN payments.status = 'failed' THEN 1 ELSE 0 END) as failed_count,
-			COALESCE(SUM(CASE WHEN payments.status = 'success' THEN payments.amount ELSE 0 END), 0) as success_amount
+			COALESCE(SUM(CASE WHEN payments.status = 'success' THEN `+baseAmountExpr("payments.amount", "orders.exchange_rate")+` ELSE 0 END), 0) as success_amount
 		`).
 		Joins("LEFT JOIN payment_channels ON payment_channels.id = payments.channel_id").
+		Joins("LEFT JOIN orders ON orders.id = payments.order_id").
 		Where("payments.deleted_at IS NULL").
 		Where("payments.created_at >= ? AND payments.created_at < ? AND payments.provider_type <> ?", startAt, endAt, constants.PaymentProviderWallet).
 		Group("payments.channel_id, payment_channels.name, payments.provider_type, payments.channel_type").
@@ -0,0 +1,140 @@
+package gormstore
+
+import (
+	"math"
+	"time"
+
+	categorydomain "github.com/dujiao-next/internal/modules/catalog/category/domain"
+	orderdomain "github.com/dujiao-next/internal/modules/order/domain"
+	stockthresholddomain "github.com/dujiao-next/internal/modules/stockthreshold/domain"
+
+	"github.com/dujiao-next/internal/constants"
+	dashboard "github.com/dujiao-next/internal/modules/dashboard/contract"
+)
+
+// inventoryAssessor 结合逐级阈值覆盖与预测窗口内的销量评估库存条目。
+type inventoryAssessor struct {
+	fallback     stockthresholddomain.Values
+	windowDays   int
+	thresholds   *stockthresholddomain.Set
+	productSales map[uint]int64
+	skuSales     map[uint]int64
+}
+
+func (r *Store) loadInventoryAssessor(policy dashboard.InventoryPolicy) (*inventoryAssessor, error) {
+	assessor := &inventoryAssessor{
+		fallback: stockthresholddomain.Values{
+			LowStockQuantity: policy.LowStockThreshold,
+			AlertDays:        policy.ForecastAlertDays,
+		},
+		windowDays:   policy.ForecastWindowDays,
+		productSales: make(map[uint]int64),
+		skuSales:     make(map[uint]int64),
+	}
+
+	var thresholds []stockthresholddomain.Threshold
+	if err := r.db.Find(&thresholds).Error; err != nil {
+		return nil, err
+	}
+	parents := make(map[uint]uint)
+	if hasCategoryThreshold(thresholds) {
+		var categories []categorydomain.Category
+		if err := r.db.Select("id, parent_id").Where("deleted_at IS NULL").Find(&categories).Error; err != nil {
+			return nil, err
+		}
+		for _, category := range categories {
+			parents[category.ID] = category.ParentID
+		}
+	}
+	assessor.thresholds = stockthresholddomain.NewSet(thresholds, parents)
+
+	if assessor.windowDays <= 0 {
+		return assessor, nil
+	}
+	now := policy.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	type salesRow struct {
+		ProductID uint
+		SKUID     uint `gorm:"column:sku_id"`
+		Quantity  int64
+	}
+	rows := make([]salesRow, 0)
+	if err := r.db.Model(&orderdomain.OrderItem{}).
+		Select("order_items.product_id as product_id, order_items.sku_id as sku_id, COALESCE(SUM(order_items.quantity), 0) as quantity").
+		Joins("JOIN orders ON orders.id = order_items.order_id").
+		Where("order_items.deleted_at IS NULL AND orders.deleted_at IS NULL AND orders.created_at >= ? AND orders.created_at < ? AND orders.status IN ?",
+			now.AddDate(0, 0, -assessor.windowDays), now, paidOrderStatuses()).
+		Group("order_items.product_id, order_items.sku_id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		assessor.productSales[row.ProductID] += row.Quantity
+		if row.SKUID > 0 {
+			assessor.skuSales[row.SKUID] += row.Quantity
+		}
+	}
+	return assessor, nil
+}
+
+// assess 填充条目的生效阈值与销量预测并判定告警类型；SKUID 为 0 的条目按商品级汇总销量。
+func (a *inventoryAssessor) assess(row *dashboard.InventoryAlertRow, categoryID uint) {
+	values := a.thresholds.Resolve(a.fallback, categoryID, row.ProductID, row.SKUID)
+	row.LowStockThreshold = values.LowStockQuantity
+	row.AlertDays = values.AlertDays
+	row.DailySales = 0
+	row.DaysLeft = nil
+
+	sold := a.productSales[row.ProductID]
+	if row.SKUID > 0 {
+		sold = a.skuSales[row.SKUID]
+	}
+	var daysLeft float64
+	if sold > 0 && a.windowDays > 0 {
+		available := row.AvailableStock
+		if available < 0 {
+			available = 0
+		}
+		dailySales := float64(sold) / float64(a.windowDays)
+		daysLeft = float64(available) / dailySales
+		row.DailySales = math.Round(dailySales*100) / 100
+		rounded := math.Round(daysLeft*10) / 10
+		row.DaysLeft = &rounded
+	}
+	row.AlertType = classifyInventoryAlertType(row.AvailableStock, values, row.DaysLeft != nil, daysLeft)
+}
+
+// alertType 评估统计口径下的单个条目，仅返回告警类型。
+func (a *inventoryAssessor) alertType(productID, categoryID, skuID uint, available int64) string {
+	row := dashboard.InventoryAlertRow{ProductID: productID, SKUID: skuID, AvailableStock: available}
+	a.assess(&row, categoryID)
+	return row.AlertType
+}
+
+// classifyInventoryAlertType 有近期销量时按预测可售天数判定低库存，否则回退到数量阈值。
+func classifyInventoryAlertType(available int64, values stockthresholddomain.Values, hasForecast bool, daysLeft float64) string {
+	switch {
+	case available <= 0:
+		return constants.NotificationAlertTypeOutOfStockProducts
+	case hasForecast:
+		if daysLeft <= float64(values.AlertDays) {
+			return constants.NotificationAlertTypeLowStockProducts
+		}
+		return ""
+	case available <= values.LowStockQuantity:
+		return constants.NotificationAlertTypeLowStockProducts
+	default:
+		return ""
+	}
+}
+
+func hasCategoryThreshold(items []stockthresholddomain.Threshold) bool {
+	for _, item := range items {
+		if item.Scope == stockthresholddomain.ScopeCategory {
+			return true
+		}
+	}
+	return false
+}
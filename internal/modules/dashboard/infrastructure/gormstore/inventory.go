@@ -1,6 +1,7 @@
 package gormstore
 
 import (
+	"sort"
 	"strings"
 
 	cardsecretdomain "github.com/dujiao-next/internal/modules/cardsecret/domain"
@@ -40,8 +41,12 @@ func resolveDashboardManualAvailableStock(product productdomain.Product) (int64,
 }
 
 // GetStockStats 获取库存总览统计
-func (r *Store) GetStockStats(lowStockThreshold int64) (dashboard.StockStatsRow, error) {
+func (r *Store) GetStockStats(policy dashboard.InventoryPolicy) (dashboard.StockStatsRow, error) {
 	result := dashboard.StockStatsRow{}
+	assessor, err := r.loadInventoryAssessor(policy)
+	if err != nil {
+		return result, err
+	}
 
 	products := make([]productdomain.Product, 0)
 	if err := r.db.
@@ -53,10 +58,12 @@ func (r *Store) GetStockStats(lowStockThreshold int64) (dashboard.StockStatsRow,
 		return result, err
 	}
 
+	productCategories := make(map[uint]uint, len(products))
 	autoProductIDs := make([]uint, 0)
 	allActiveSKUIDs := make([]uint, 0)
 	autoProductActiveSKUs := make(map[uint][]uint) // product_id -> active sku_ids
 	for _, product := range products {
+		productCategories[product.ID] = product.CategoryID
 		fulfillmentType := strings.TrimSpace(product.FulfillmentType)
 		if fulfillmentType == constants.FulfillmentTypeAuto {
 			autoProductIDs = append(autoProductIDs, product.ID)
@@ -76,7 +83,7 @@ func (r *Store) GetStockStats(lowStockThreshold int64) (dashboard.StockStatsRow,
 			continue
 		}
 		result.ManualAvailableUnits += available
-		switch classifyInventoryAlertType(available, lowStockThreshold) {
+		switch assessor.alertType(product.ID, product.CategoryID, 0, available) {
 		case constants.NotificationAlertTypeOutOfStockProducts:
 			result.OutOfStockProducts += 1
 		case constants.NotificationAlertTypeLowStockProducts:
@@ -92,7 +99,7 @@ func (r *Store) GetStockStats(lowStockThreshold int64) (dashboard.StockStatsRow,
 			if skuAvail < 0 {
 				skuAvail = 0
 			}
-			switch classifyInventoryAlertType(skuAvail, lowStockThreshold) {
+			switch assessor.alertType(product.ID, product.CategoryID, sku.ID, skuAvail) {
 			case constants.NotificationAlertTypeOutOfStockProducts:
 				result.OutOfStockSKUs += 1
 			case constants.NotificationAlertTypeLowStockProducts:
@@ -139,7 +146,7 @@ func (r *Store) GetStockStats(lowStockThreshold int64) (dashboard.StockStatsRow,
 	// 商品级别统计
 	for _, productID := range autoProductIDs {
 		available := productAvailableMap[productID]
-		switch classifyInventoryAlertType(available, lowStockThreshold) {
+		switch assessor.alertType(productID, productCategories[productID], 0, available) {
 		case constants.NotificationAlertTypeOutOfStockProducts:
 			result.OutOfStockProducts += 1
 		case constants.NotificationAlertTypeLowStockProducts:
@@ -162,7 +169,7 @@ func (r *Store) GetStockStats(lowStockThreshold int64) (dashboard.StockStatsRow,
 			if skuID == legacyTargetSKUID && skuMap != nil {
 				skuAvail += skuMap[0]
 			}
-			switch classifyInventoryAlertType(skuAvail, lowStockThreshold) {
+			switch assessor.alertType(productID, productCategories[productID], skuID, skuAvail) {
 			case constants.NotificationAlertTypeOutOfStockProducts:
 				result.OutOfStockSKUs += 1
 			case constants.NotificationAlertTypeLowStockProducts:
@@ -175,7 +182,45 @@ func (r *Store) GetStockStats(lowStockThreshold int64) (dashboard.StockStatsRow,
 }
 
 // GetInventoryAlertItems 获取库存异常明细
-func (r *Store) GetInventoryAlertItems(lowStockThreshold int64) ([]dashboard.InventoryAlertRow, error) {
+func (r *Store) GetInventoryAlertItems(policy dashboard.InventoryPolicy) ([]dashboard.InventoryAlertRow, error) {
+	rows, err := r.collectInventoryRows(policy)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]dashboard.InventoryAlertRow, 0)
+	for _, row := range rows {
+		if row.AlertType != "" {
+			result = append(result, row)
+		}
+	}
+	return result, nil
+}
+
+// GetInventoryForecastItems 获取全部有限库存条目的销量预测，按可售天数升序，无销量的排在最后
+func (r *Store) GetInventoryForecastItems(policy dashboard.InventoryPolicy) ([]dashboard.InventoryAlertRow, error) {
+	rows, err := r.collectInventoryRows(policy)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		left, right := rows[i].DaysLeft, rows[j].DaysLeft
+		switch {
+		case left != nil && right != nil && *left != *right:
+			return *left < *right
+		case (left == nil) != (right == nil):
+			return left != nil
+		default:
+			return rows[i].AvailableStock < rows[j].AvailableStock
+		}
+	})
+	return rows, nil
+}
+
+func (r *Store) collectInventoryRows(policy dashboard.InventoryPolicy) ([]dashboard.InventoryAlertRow, error) {
+	assessor, err := r.loadInventoryAssessor(policy)
+	if err != nil {
+		return nil, err
+	}
 	products := make([]productdomain.Product, 0)
 	if err := r.db.
 		Preload("SKUs", func(db *gorm.DB) *gorm.DB {
@@ -221,15 +266,15 @@ func (r *Store) GetInventoryAlertItems(lowStockThreshold int64) ([]dashboard.Inv
 	for _, product := range products {
 		switch strings.TrimSpace(product.FulfillmentType) {
 		case constants.FulfillmentTypeAuto:
-			result = append(result, collectAutoInventoryAlertRows(product, autoAvailableMap[product.ID], lowStockThreshold)...)
+			result = append(result, collectAutoInventoryRows(product, autoAvailableMap[product.ID], assessor)...)
 		case constants.FulfillmentTypeManual:
-			result = append(result, collectManualInventoryAlertRows(product, lowStockThreshold)...)
+			result = append(result, collectManualInventoryRows(product, assessor)...)
 		}
 	}
 	return result, nil
 }
 
-func collectManualInventoryAlertRows(product productdomain.Product, lowStockThreshold int64) []dashboard.InventoryAlertRow {
+func collectManualInventoryRows(product productdomain.Product, assessor *inventoryAssessor) []dashboard.InventoryAlertRow {
 	result := make([]dashboard.InventoryAlertRow, 0)
 	activeSKUs := activeProductSKUs(product.SKUs)
 	if len(activeSKUs) == 0 {
@@ -240,16 +285,14 @@ func collectManualInventoryAlertRows(product productdomain.Product, lowStockThre
 		if available < 0 {
 			available = 0
 		}
-		if alertType := classifyInventoryAlertType(available, lowStockThreshold); alertType != "" {
-			result = append(result, dashboard.InventoryAlertRow{
-				ProductID:        product.ID,
-				ProductTitleJSON: product.TitleJSON,
-				FulfillmentType:  constants.FulfillmentTypeManual,
-				AlertType:        alertType,
-				AvailableStock:   available,
-			})
+		row := dashboard.InventoryAlertRow{
+			ProductID:        product.ID,
+			ProductTitleJSON: product.TitleJSON,
+			FulfillmentType:  constants.FulfillmentTypeManual,
+			AvailableStock:   available,
 		}
-		return result
+		assessor.assess(&row, product.CategoryID)
+		return append(result, row)
 	}
 
 	for _, sku := range activeSKUs {
@@ -260,23 +303,22 @@ func collectManualInventoryAlertRows(product productdomain.Product, lowStockThre
 		if available < 0 {
 			available = 0
 		}
-		if alertType := classifyInventoryAlertType(available, lowStockThreshold); alertType != "" {
-			result = append(result, dashboard.InventoryAlertRow{
-				ProductID:         product.ID,
-				SKUID:             sku.ID,
-				ProductTitleJSON:  product.TitleJSON,
-				SKUCode:           strings.TrimSpace(sku.SKUCode),
-				SKUSpecValuesJSON: sku.SpecValuesJSON,
-				FulfillmentType:   constants.FulfillmentTypeManual,
-				AlertType:         alertType,
-				AvailableStock:    available,
-			})
-		}
+		row := dashboard.InventoryAlertRow{
+			ProductID:         product.ID,
+			SKUID:             sku.ID,
+			ProductTitleJSON:  product.TitleJSON,
+			SKUCode:           strings.TrimSpace(sku.SKUCode),
+			SKUSpecValuesJSON: sku.SpecValuesJSON,
+			FulfillmentType:   constants.FulfillmentTypeManual,
+			AvailableStock:    available,
+		}
+		assessor.assess(&row, product.CategoryID)
+		result = append(result, row)
 	}
 	return result
 }
 
-func collectAutoInventoryAlertRows(product productdomain.Product, availableMap map[uint]int64, lowStockThreshold int64) []dashboard.InventoryAlertRow {
+func collectAutoInventoryRows(product productdomain.Product, availableMap map[uint]int64, assessor *inventoryAssessor) []dashboard.InventoryAlertRow {
 	result := make([]dashboard.InventoryAlertRow, 0)
 	activeSKUs := activeProductSKUs(product.SKUs)
 	totalAvailable := int64(0)
@@ -295,17 +337,15 @@ func collectAutoInventoryAlertRows(product productdomain.Product, availableMap m
 		}
 		legacyInactiveAvailable += total
 	}
+	productRow := dashboard.InventoryAlertRow{
+		ProductID:        product.ID,
+		ProductTitleJSON: product.TitleJSON,
+		FulfillmentType:  constants.FulfillmentTypeAuto,
+		AvailableStock:   totalAvailable,
+	}
 	if len(activeSKUs) == 0 {
-		if alertType := classifyInventoryAlertType(totalAvailable, lowStockThreshold); alertType != "" {
-			result = append(result, dashboard.InventoryAlertRow{
-				ProductID:        product.ID,
-				ProductTitleJSON: product.TitleJSON,
-				FulfillmentType:  constants.FulfillmentTypeAuto,
-				AlertType:        alertType,
-				AvailableStock:   totalAvailable,
-			})
-		}
-		return result
+		assessor.assess(&productRow, product.CategoryID)
+		return append(result, productRow)
 	}
 
 	legacyTargetIdx := resolveDashboardLegacyStockTargetSKUIndex(activeSKUs)
@@ -321,32 +361,25 @@ func collectAutoInventoryAlertRows(product productdomain.Product, availableMap m
 		if available > 0 {
 			hasPositiveActive = true
 		}
-		if alertType := classifyInventoryAlertType(available, lowStockThreshold); alertType != "" {
-			result = append(result, dashboard.InventoryAlertRow{
-				ProductID:         product.ID,
-				SKUID:             sku.ID,
-				ProductTitleJSON:  product.TitleJSON,
-				SKUCode:           strings.TrimSpace(sku.SKUCode),
-				SKUSpecValuesJSON: sku.SpecValuesJSON,
-				FulfillmentType:   constants.FulfillmentTypeAuto,
-				AlertType:         alertType,
-				AvailableStock:    available,
-			})
-		}
+		row := dashboard.InventoryAlertRow{
+			ProductID:         product.ID,
+			SKUID:             sku.ID,
+			ProductTitleJSON:  product.TitleJSON,
+			SKUCode:           strings.TrimSpace(sku.SKUCode),
+			SKUSpecValuesJSON: sku.SpecValuesJSON,
+			FulfillmentType:   constants.FulfillmentTypeAuto,
+			AvailableStock:    available,
+		}
+		assessor.assess(&row, product.CategoryID)
+		result = append(result, row)
 	}
 	if hasPositiveActive || legacyInactiveAvailable <= 0 {
 		return result
 	}
-	if fallbackType := classifyInventoryAlertType(totalAvailable, lowStockThreshold); fallbackType != "" {
-		return []dashboard.InventoryAlertRow{
-			{
-				ProductID:        product.ID,
-				ProductTitleJSON: product.TitleJSON,
-				FulfillmentType:  constants.FulfillmentTypeAuto,
-				AlertType:        fallbackType,
-				AvailableStock:   totalAvailable,
-			},
-		}
+	// 库存只挂在已停用规格上时，按商品级汇总判断，避免逐个规格误报缺货
+	assessor.assess(&productRow, product.CategoryID)
+	if productRow.AlertType != "" {
+		return []dashboard.InventoryAlertRow{productRow}
 	}
 	return result
 }
@@ -362,17 +395,6 @@ func activeProductSKUs(items []productdomain.ProductSKU) []productdomain.Product
 	return result
 }
 
-func classifyInventoryAlertType(available int64, lowStockThreshold int64) string {
-	switch {
-	case available <= 0:
-		return constants.NotificationAlertTypeOutOfStockProducts
-	case available <= lowStockThreshold:
-		return constants.NotificationAlertTypeLowStockProducts
-	default:
-		return ""
-	}
-}
-
 func resolveDashboardLegacyStockTargetSKUIndex(skus []productdomain.ProductSKU) int {
 	if len(skus) == 0 {
 		return -1
@@ -3,15 +3,22 @@ package gormstore
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	cardsecretdomain "github.com/dujiao-next/internal/modules/cardsecret/domain"
+	categorydomain "github.com/dujiao-next/internal/modules/catalog/category/domain"
 	productdomain "github.com/dujiao-next/internal/modules/catalog/product/domain"
 
+	orderdomain "github.com/dujiao-next/internal/modules/order/domain"
+	stockthresholddomain "github.com/dujiao-next/internal/modules/stockthreshold/domain"
+
 	"github.com/dujiao-next/internal/constants"
+	dashboard "github.com/dujiao-next/internal/modules/dashboard/contract"
 	"github.com/dujiao-next/internal/shared/jsonmap"
 	"github.com/dujiao-next/internal/shared/money"
 
 	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
 )
 
 func TestGetStockStatsUsesActiveManualSKUs(t *testing.T) {
@@ -85,7 +92,7 @@ func TestGetStockStatsUsesActiveManualSKUs(t *testing.T) {
 		t.Fatalf("create fallback product failed: %v", err)
 	}
 
-	stats, err := repo.GetStockStats(5)
+	stats, err := repo.GetStockStats(testInventoryPolicy())
 	if err != nil {
 		t.Fatalf("get stock stats failed: %v", err)
 	}
@@ -156,7 +163,7 @@ func TestGetInventoryAlertItemsFallsBackToProductLevelWhenOnlyInactiveAutoSKUHas
 		}
 	}
 
-	rows, err := repo.GetInventoryAlertItems(5)
+	rows, err := repo.GetInventoryAlertItems(testInventoryPolicy())
 	if err != nil {
 		t.Fatalf("get inventory alert items failed: %v", err)
 	}
@@ -173,3 +180,121 @@ func TestGetInventoryAlertItemsFallsBackToProductLevelWhenOnlyInactiveAutoSKUHas
 		t.Fatalf("fallback row alert type want low_stock_products got %s", rows[0].AlertType)
 	}
 }
+
+func TestInventoryForecastUsesSalesVelocityAndThresholdOverrides(t *testing.T) {
+	repo, db := setupDashboardRepositoryTest(t)
+	parent := createDashboardCategory(t, db, "dashboard-forecast-parent")
+	child := &categorydomain.Category{ParentID: parent.ID, Slug: "dashboard-forecast-child", NameJSON: jsonmap.JSON{"zh-CN": "子分类"}}
+	if err := db.Create(child).Error; err != nil {
+		t.Fatalf("create child category failed: %v", err)
+	}
+	product := &productdomain.Product{
+		CategoryID:      child.ID,
+		Slug:            "dashboard-forecast",
+		TitleJSON:       jsonmap.JSON{"zh-CN": "预测商品"},
+		PriceAmount:     money.FromDecimal(decimal.NewFromInt(10)),
+		PurchaseType:    constants.ProductPurchaseMember,
+		FulfillmentType: constants.FulfillmentTypeManual,
+		IsActive:        true,
+	}
+	if err := db.Create(product).Error; err != nil {
+		t.Fatalf("create product failed: %v", err)
+	}
+	fast := &productdomain.ProductSKU{ProductID: product.ID, SKUCode: "FAST", PriceAmount: money.FromDecimal(decimal.NewFromInt(10)), ManualStockTotal: 10, IsActive: true}
+	slow := &productdomain.ProductSKU{ProductID: product.ID, SKUCode: "SLOW", PriceAmount: money.FromDecimal(decimal.NewFromInt(10)), ManualStockTotal: 3, IsActive: true}
+	for _, sku := range []*productdomain.ProductSKU{fast, slow} {
+		if err := db.Create(sku).Error; err != nil {
+			t.Fatalf("create sku failed: %v", err)
+		}
+	}
+
+	now := time.Date(2026, 3, 20, 12, 0, 0, 0, time.UTC)
+	// FAST 在 14 天窗口内售出 14 件（日均 1 件），窗口外与未支付订单不计入
+	createDashboardForecastSale(t, db, product.ID, fast.ID, "FORECAST-1", constants.OrderStatusCompleted, 9, now.AddDate(0, 0, -3))
+	createDashboardForecastSale(t, db, product.ID, fast.ID, "FORECAST-2", constants.OrderStatusPaid, 5, now.AddDate(0, 0, -10))
+	createDashboardForecastSale(t, db, product.ID, fast.ID, "FORECAST-3", constants.OrderStatusCompleted, 50, now.AddDate(0, 0, -20))
+	createDashboardForecastSale(t, db, product.ID, fast.ID, "FORECAST-4", constants.OrderStatusPendingPayment, 50, now.AddDate(0, 0, -1))
+
+	policy := dashboard.InventoryPolicy{LowStockThreshold: 5, ForecastAlertDays: 7, ForecastWindowDays: 14, Now: now}
+	rows, err := repo.GetInventoryAlertItems(policy)
+	if err != nil {
+		t.Fatalf("get inventory alert items failed: %v", err)
+	}
+	// FAST 约可售 10 天，未到 7 天告警线；SLOW 无销量，回退数量阈值 3 <= 5
+	if len(rows) != 1 || rows[0].SKUID != slow.ID || rows[0].DaysLeft != nil {
+		t.Fatalf("unexpected default alert rows: %+v", rows)
+	}
+
+	thresholds := []stockthresholddomain.Threshold{
+		{Scope: stockthresholddomain.ScopeCategory, TargetID: parent.ID, AlertDays: 14},
+		{Scope: stockthresholddomain.ScopeProduct, TargetID: product.ID, LowStockQuantity: 2},
+	}
+	if err := db.Create(&thresholds).Error; err != nil {
+		t.Fatalf("create thresholds failed: %v", err)
+	}
+	rows, err = repo.GetInventoryAlertItems(policy)
+	if err != nil {
+		t.Fatalf("get inventory alert items failed: %v", err)
+	}
+	if len(rows) != 1 || rows[0].SKUID != fast.ID || rows[0].AlertDays != 14 || rows[0].LowStockThreshold != 2 {
+		t.Fatalf("override alert rows mismatch: %+v", rows)
+	}
+	if rows[0].DaysLeft == nil || *rows[0].DaysLeft != 10 || rows[0].DailySales != 1 {
+		t.Fatalf("forecast mismatch: daily=%v days_left=%v", rows[0].DailySales, rows[0].DaysLeft)
+	}
+
+	forecast, err := repo.GetInventoryForecastItems(policy)
+	if err != nil {
+		t.Fatalf("get inventory forecast failed: %v", err)
+	}
+	if len(forecast) != 2 || forecast[0].SKUID != fast.ID || forecast[1].SKUID != slow.ID || forecast[1].AlertType != "" {
+		t.Fatalf("forecast rows mismatch: %+v", forecast)
+	}
+
+	stats, err := repo.GetStockStats(policy)
+	if err != nil {
+		t.Fatalf("get stock stats failed: %v", err)
+	}
+	// 商品级汇总 13 件、日均 1 件，同样低于分类的 14 天告警线
+	if stats.LowStockSKUs != 1 || stats.LowStockProducts != 1 {
+		t.Fatalf("stock stats mismatch: %+v", stats)
+	}
+}
+
+func testInventoryPolicy() dashboard.InventoryPolicy {
+	return dashboard.InventoryPolicy{LowStockThreshold: 5, ForecastAlertDays: 7, ForecastWindowDays: 14, Now: time.Now()}
+}
+
+func createDashboardForecastSale(t *testing.T, db *gorm.DB, productID, skuID uint, orderNo, status string, quantity int, createdAt time.Time) {
+	t.Helper()
+	order := &orderdomain.Order{
+		OrderNo:        orderNo,
+		UserID:         1,
+		Status:         status,
+		Currency:       "CNY",
+		OriginalAmount: money.FromDecimal(decimal.NewFromInt(10)),
+		DiscountAmount: money.FromDecimal(decimal.Zero),
+		TotalAmount:    money.FromDecimal(decimal.NewFromInt(10)),
+		CreatedAt:      createdAt,
+		UpdatedAt:      createdAt,
+	}
+	if err := db.Create(order).Error; err != nil {
+		t.Fatalf("create order failed: %v", err)
+	}
+	item := &orderdomain.OrderItem{
+		OrderID:         order.ID,
+		ProductID:       productID,
+		SKUID:           skuID,
+		TitleJSON:       jsonmap.JSON{"zh-CN": "预测商品"},
+		UnitPrice:       money.FromDecimal(decimal.NewFromInt(10)),
+		Quantity:        quantity,
+		TotalPrice:      money.FromDecimal(decimal.NewFromInt(10)),
+		CouponDiscount:  money.FromDecimal(decimal.Zero),
+		FulfillmentType: constants.FulfillmentTypeManual,
+		CreatedAt:       createdAt,
+		UpdatedAt:       createdAt,
+	}
+	if err := db.Create(item).Error; err != nil {
+		t.Fatalf("create order item failed: %v", err)
+	}
+}
@@ -24,6 +24,7 @@ func (r *Store) GetOverview(startAt, endAt time.Time) (dashboard.OverviewRow, er
 	paidIn := quotedStatusList(paidOrderStatuses())
 	processingStatuses := []string{
 		constants.OrderStatusPaid,
+		constants.OrderStatusHeldForReview,
 		constants.OrderStatusFulfilling,
 		constants.OrderStatusPartiallyDelivered,
 		constants.OrderStatusDelivered,
@@ -44,8 +45,8 @@ func (r *Store) GetOverview(startAt, endAt time.Time) (dashboard.OverviewRow, er
 		COALESCE(SUM(CASE WHEN status = '%s' THEN 1 ELSE 0 END), 0) as completed_orders,
 		COALESCE(SUM(CASE WHEN status = '%s' THEN 1 ELSE 0 END), 0) as pending_payment_orders,
 		COALESCE(SUM(CASE WHEN status IN (%s) THEN 1 ELSE 0 END), 0) as processing_orders,
-		COALESCE(SUM(CASE WHEN status IN (%s) THEN total_amount ELSE 0 END), 0) as gmv_paid
-	`, paidIn, constants.OrderStatusCompleted, constants.OrderStatusPendingPayment, processingIn, paidIn)
+		COALESCE(SUM(CASE WHEN status IN (%s) THEN %s ELSE 0 END), 0) as gmv_paid
+	`, paidIn, constants.OrderStatusCompleted, constants.OrderStatusPendingPayment, processingIn, paidIn, baseAmountExpr("total_amount", "exchange_rate"))
 
 	if err := r.db.Model(&orderdomain.Order{}).
 		Select(orderSelectSQL).
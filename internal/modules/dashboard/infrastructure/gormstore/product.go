@@ -25,9 +25,9 @@ func (r *Store) GetTopProducts(startAt, endAt time.Time, limit int) ([]dashboard
 			%s as title,
 			COUNT(DISTINCT order_items.order_id) as paid_orders,
 			COALESCE(SUM(order_items.quantity), 0) as quantity,
-			COALESCE(SUM(order_items.total_price - order_items.coupon_discount), 0) as paid_amount,
+			COALESCE(SUM(%s), 0) as paid_amount,
 			COALESCE(SUM(CASE WHEN order_items.cost_price > 0 THEN order_items.cost_price * order_items.quantity ELSE 0 END), 0) as total_cost
-		`, titleExpr)).
+		`, titleExpr, baseAmountExpr("order_items.total_price - order_items.coupon_discount", "orders.exchange_rate"))).
 		Joins("JOIN orders ON orders.id = order_items.order_id").
 		Joins("LEFT JOIN product_skus ON product_skus.id = order_items.sku_id AND product_skus.deleted_at IS NULL").
 		Where("order_items.deleted_at IS NULL AND orders.deleted_at IS NULL AND orders.created_at >= ? AND orders.created_at < ? AND orders.status IN ?", startAt, endAt, paidOrderStatuses()).
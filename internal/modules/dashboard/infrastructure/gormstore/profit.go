@@ -12,6 +12,12 @@ import (
 	dashboard "github.com/dujiao-next/internal/modules/dashboard/contract"
 )
 
+// 成本价始终为基准币种快照，收入与退款需按订单汇率折算后才能相减。
+var (
+	itemRevenueBaseExpr = baseAmountExpr("order_items.total_price - order_items.coupon_discount", "orders.exchange_rate")
+	refundBaseExpr      = baseAmountExpr("order_refund_records.amount", "orders.exchange_rate")
+)
+
 func profitOrderStatuses() []string {
 	statuses := append([]string{}, paidOrderStatuses()...)
 	return append(statuses, constants.OrderStatusRefunded)
@@ -21,10 +27,10 @@ func profitOrderStatuses() []string {
 func (r *Store) GetProfitOverview(startAt, endAt time.Time) (dashboard.ProfitOverviewRow, error) {
 	result := dashboard.ProfitOverviewRow{}
 	if err := r.db.Model(&orderdomain.OrderItem{}).
-		Select(`
-			COALESCE(SUM(order_items.total_price - order_items.coupon_discount), 0) as total_revenue,
+		Select(fmt.Sprintf(`
+			COALESCE(SUM(%s), 0) as total_revenue,
 			COALESCE(SUM(order_items.cost_price * order_items.quantity), 0) as total_cost
-		`).
+		`, itemRevenueBaseExpr)).
 		Joins("JOIN orders ON orders.id = order_items.order_id").
 		Where("order_items.deleted_at IS NULL AND orders.deleted_at IS NULL AND order_items.cost_price > 0 AND orders.created_at >= ? AND orders.created_at < ? AND orders.status IN ?", startAt, endAt, profitOrderStatuses()).
 		Scan(&result).Error; err != nil {
@@ -33,8 +39,9 @@ func (r *Store) GetProfitOverview(startAt, endAt time.Time) (dashboard.ProfitOve
 
 	var refundedAmount float64
 	if err := r.db.Model(&orderdomain.OrderRefundRecord{}).
-		Select("COALESCE(SUM(amount), 0)").
-		Where("deleted_at IS NULL AND created_at >= ? AND created_at < ?", startAt, endAt).
+		Select(fmt.Sprintf("COALESCE(SUM(%s), 0)", refundBaseExpr)).
+		Joins("LEFT JOIN orders ON orders.id = order_refund_records.order_id").
+		Where("order_refund_records.deleted_at IS NULL AND order_refund_records.created_at >= ? AND order_refund_records.created_at < ?", startAt, endAt).
 		Scan(&refundedAmount).Error; err != nil {
 		return result, err
 	}
@@ -49,9 +56,9 @@ func (r *Store) GetProfitTrends(startAt, endAt time.Time) ([]dashboard.ProfitTre
 	rows := make([]dashboard.ProfitTrendRow, 0)
 	if err := r.db.Model(&orderdomain.OrderItem{}).Select(fmt.Sprintf(`
 		%s as day,
-		COALESCE(SUM(order_items.total_price - order_items.coupon_discount), 0) as revenue,
+		COALESCE(SUM(%s), 0) as revenue,
 		COALESCE(SUM(order_items.cost_price * order_items.quantity), 0) as cost
-	`, orderDayExpr)).
+	`, orderDayExpr, itemRevenueBaseExpr)).
 		Joins("JOIN orders ON orders.id = order_items.order_id").
 		Where("order_items.deleted_at IS NULL AND orders.deleted_at IS NULL AND order_items.cost_price > 0 AND orders.created_at >= ? AND orders.created_at < ? AND orders.status IN ?", startAt, endAt, profitOrderStatuses()).
 		Group(orderDayExpr).
@@ -64,13 +71,14 @@ func (r *Store) GetProfitTrends(startAt, endAt time.Time) ([]dashboard.ProfitTre
 		RefundAmount float64 `gorm:"column:refund_amount"`
 	}
 	refundRows := make([]refundTrendRow, 0)
-	refundDayExpr := dateGroupExpr(r.db, "created_at", startAt.Location(), startAt)
+	refundDayExpr := dateGroupExpr(r.db, "order_refund_records.created_at", startAt.Location(), startAt)
 	if err := r.db.Model(&orderdomain.OrderRefundRecord{}).
 		Select(fmt.Sprintf(`
 			%s as day,
-			COALESCE(SUM(amount), 0) as refund_amount
-		`, refundDayExpr)).
-		Where("deleted_at IS NULL AND created_at >= ? AND created_at < ?", startAt, endAt).
+			COALESCE(SUM(%s), 0) as refund_amount
+		`, refundDayExpr, refundBaseExpr)).
+		Joins("LEFT JOIN orders ON orders.id = order_refund_records.order_id").
+		Where("order_refund_records.deleted_at IS NULL AND order_refund_records.created_at >= ? AND order_refund_records.created_at < ?", startAt, endAt).
 		Group(refundDayExpr).
 		Scan(&refundRows).Error; err != nil {
 		return nil, err
@@ -5,7 +5,7 @@ import (
 	"strings"
 	"time"
 
-	"github.com/dujiao-next/internal/constants"
+	"github.com/dujiao-next/internal/i18n/locales"
 
 	"gorm.io/gorm"
 )
@@ -22,8 +22,8 @@ func dialectName(db *gorm.DB) string {
 }
 
 func localizedJSONCoalesceExpr(db *gorm.DB, column string) string {
-	parts := make([]string, 0, len(constants.SupportedLocales)+1)
-	for _, key := range constants.SupportedLocales {
+	parts := make([]string, 0, len(locales.Enabled())+1)
+	for _, key := range locales.Enabled() {
 		switch dialectName(db) {
 		case "postgres", "postgresql":
 			parts = append(parts, fmt.Sprintf("(%s::jsonb ->> '%s')", column, key))
@@ -62,6 +62,11 @@ func dateGroupExpr(db *gorm.DB, column string, loc *time.Location, refTime time.
 	}
 }
 
+// baseAmountExpr 按订单下单汇率快照把订单币种金额折算回站点基准币种；历史订单汇率为 1。
+func baseAmountExpr(amountExpr, rateColumn string) string {
+	return fmt.Sprintf("(%s) / COALESCE(NULLIF(%s, 0), 1)", amountExpr, rateColumn)
+}
+
 // quotedStatusList only accepts internal status constants, never user input.
 func quotedStatusList(statuses []string) string {
 	parts := make([]string, len(statuses))
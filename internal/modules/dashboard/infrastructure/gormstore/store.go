@@ -23,6 +23,7 @@ func New(db *gorm.DB) *Store {
 func paidOrderStatuses() []string {
 	return []string{
 		constants.OrderStatusPaid,
+		constants.OrderStatusHeldForReview,
 		constants.OrderStatusFulfilling,
 		constants.OrderStatusPartiallyDelivered,
 		constants.OrderStatusPartiallyRefunded,
@@ -35,17 +35,19 @@ func (r *Store) GetOrderTrends(startAt, endAt time.Time) ([]dashboard.OrderTrend
 
 // GetPaymentTrends 获取支付趋势
 func (r *Store) GetPaymentTrends(startAt, endAt time.Time) ([]dashboard.PaymentTrendRow, error) {
-	dayExpr := dateGroupExpr(r.db, "created_at", startAt.Location(), startAt)
+	dayExpr := dateGroupExpr(r.db, "payments.created_at", startAt.Location(), startAt)
 
 	rows := make([]dashboard.PaymentTrendRow, 0)
 	selectSQL := fmt.Sprintf(`
 		%s as day,
-		COALESCE(SUM(CASE WHEN status = '%s' THEN 1 ELSE 0 END), 0) as payments_success,
-		COALESCE(SUM(CASE WHEN status = '%s' THEN 1 ELSE 0 END), 0) as payments_failed,
-		COALESCE(SUM(CASE WHEN status = '%s' THEN amount ELSE 0 END), 0) as gmv_paid
-	`, dayExpr, constants.PaymentStatusSuccess, constants.PaymentStatusFailed, constants.PaymentStatusSuccess)
+		COALESCE(SUM(CASE WHEN payments.status = '%s' THEN 1 ELSE 0 END), 0) as payments_success,
+		COALESCE(SUM(CASE WHEN payments.status = '%s' THEN 1 ELSE 0 END), 0) as payments_failed,
+		COALESCE(SUM(CASE WHEN payments.status = '%s' THEN %s ELSE 0 END), 0) as gmv_paid
+	`, dayExpr, constants.PaymentStatusSuccess, constants.PaymentStatusFailed, constants.PaymentStatusSuccess,
+		baseAmountExpr("payments.amount", "orders.exchange_rate"))
 
 	if err := onlinePaymentBase(r.db, startAt, endAt).
+		Joins("LEFT JOIN orders ON orders.id = payments.order_id").
 		Select(selectSQL).
 		Group(dayExpr).
 		Order("day ASC").
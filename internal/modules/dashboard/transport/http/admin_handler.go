@@ -21,7 +21,8 @@ type Reader interface {
 	GetTrends(ctx context.Context, input reportingdomain.Query) (*dashboardapp.TrendResponse, error)
 	GetRankings(ctx context.Context, input reportingdomain.Query) (*dashboardapp.RankingsResponse, error)
 	LoadDashboardAlertSetting() settingsstorefront.DashboardAlertSetting
-	GetInventoryAlertItems(ctx context.Context, lowStockThreshold int64) ([]dashboardcontract.InventoryAlertRow, error)
+	GetInventoryAlertItems(ctx context.Context, alert settingsstorefront.DashboardAlertSetting) ([]dashboardcontract.InventoryAlertRow, error)
+	GetInventoryForecast(ctx context.Context, alert settingsstorefront.DashboardAlertSetting) ([]dashboardcontract.InventoryAlertRow, error)
 }
 
 type AdminHandler struct {
@@ -89,7 +90,22 @@ func (h *AdminHandler) GetInventoryAlerts(c *gin.Context) {
 		return
 	}
 	setting := h.reader.LoadDashboardAlertSetting()
-	items, err := h.reader.GetInventoryAlertItems(c.Request.Context(), setting.LowStockThreshold)
+	items, err := h.reader.GetInventoryAlertItems(c.Request.Context(), setting)
+	if err != nil {
+		respondFetchError(c, err)
+		return
+	}
+	response.Success(c, mapInventoryAlerts(items))
+}
+
+// GetInventoryForecast 返回按销量预测可售天数排序的库存列表
+func (h *AdminHandler) GetInventoryForecast(c *gin.Context) {
+	if h == nil || h.reader == nil {
+		respondFetchError(c, nil)
+		return
+	}
+	setting := h.reader.LoadDashboardAlertSetting()
+	items, err := h.reader.GetInventoryForecast(c.Request.Context(), setting)
 	if err != nil {
 		respondFetchError(c, err)
 		return
@@ -3,27 +3,35 @@ package dashboardhttp
 import dashboardcontract "github.com/dujiao-next/internal/modules/dashboard/contract"
 
 type inventoryAlertResponse struct {
-	ProductID       uint                   `json:"product_id"`
-	SKUID           uint                   `json:"sku_id,omitempty"`
-	ProductTitle    map[string]interface{} `json:"product_title"`
-	SKUCode         string                 `json:"sku_code,omitempty"`
-	SKUSpecValues   map[string]interface{} `json:"sku_spec_values,omitempty"`
-	FulfillmentType string                 `json:"fulfillment_type"`
-	AlertType       string                 `json:"alert_type"`
-	AvailableStock  int64                  `json:"available_stock"`
+	ProductID         uint                   `json:"product_id"`
+	SKUID             uint                   `json:"sku_id,omitempty"`
+	ProductTitle      map[string]interface{} `json:"product_title"`
+	SKUCode           string                 `json:"sku_code,omitempty"`
+	SKUSpecValues     map[string]interface{} `json:"sku_spec_values,omitempty"`
+	FulfillmentType   string                 `json:"fulfillment_type"`
+	AlertType         string                 `json:"alert_type"`
+	AvailableStock    int64                  `json:"available_stock"`
+	LowStockThreshold int64                  `json:"low_stock_threshold"`
+	AlertDays         int                    `json:"alert_days"`
+	DailySales        float64                `json:"daily_sales"`
+	DaysLeft          *float64               `json:"days_left"`
 }
 
 func mapInventoryAlerts(items []dashboardcontract.InventoryAlertRow) []inventoryAlertResponse {
 	result := make([]inventoryAlertResponse, 0, len(items))
 	for _, item := range items {
 		row := inventoryAlertResponse{
-			ProductID:       item.ProductID,
-			SKUID:           item.SKUID,
-			ProductTitle:    item.ProductTitleJSON,
-			SKUCode:         item.SKUCode,
-			FulfillmentType: item.FulfillmentType,
-			AlertType:       item.AlertType,
-			AvailableStock:  item.AvailableStock,
+			ProductID:         item.ProductID,
+			SKUID:             item.SKUID,
+			ProductTitle:      item.ProductTitleJSON,
+			SKUCode:           item.SKUCode,
+			FulfillmentType:   item.FulfillmentType,
+			AlertType:         item.AlertType,
+			AvailableStock:    item.AvailableStock,
+			LowStockThreshold: item.LowStockThreshold,
+			AlertDays:         item.AlertDays,
+			DailySales:        item.DailySales,
+			DaysLeft:          item.DaysLeft,
 		}
 		if item.SKUSpecValuesJSON != nil {
 			row.SKUSpecValues = item.SKUSpecValuesJSON
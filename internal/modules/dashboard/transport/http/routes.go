@@ -7,4 +7,5 @@ func RegisterAdminRoutes(admin gin.IRoutes, handler *AdminHandler) {
 	admin.GET("/dashboard/trends", handler.GetTrends)
 	admin.GET("/dashboard/rankings", handler.GetRankings)
 	admin.GET("/dashboard/inventory-alerts", handler.GetInventoryAlerts)
+	admin.GET("/dashboard/inventory-forecast", handler.GetInventoryForecast)
 }
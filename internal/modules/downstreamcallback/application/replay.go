@@ -0,0 +1,96 @@
+package application
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/dujiao-next/internal/logger"
+	downstreamcontract "github.com/dujiao-next/internal/modules/downstreamcallback/contract"
+	downstreamdomain "github.com/dujiao-next/internal/modules/downstreamcallback/domain"
+)
+
+// downstreamResendCooldown 下游自助重发的最小间隔，防止对方循环触发。
+const downstreamResendCooldown = 60 * time.Second
+
+// ListRefs 后台查询回调引用。
+func (s *Service) ListRefs(filter downstreamcontract.RefAdminFilter) ([]downstreamdomain.OrderRef, int64, error) {
+	filter.CallbackStatus = strings.TrimSpace(filter.CallbackStatus)
+	filter.DownstreamOrderNo = strings.TrimSpace(filter.DownstreamOrderNo)
+	return s.references.ListRefs(filter)
+}
+
+// ListAttempts 后台查询回调投递记录。
+func (s *Service) ListAttempts(filter downstreamcontract.AttemptListFilter) ([]downstreamdomain.Attempt, int64, error) {
+	filter.Trigger = strings.TrimSpace(filter.Trigger)
+	return s.references.ListAttempts(filter)
+}
+
+// ResendNow 管理员立即重发：重置重试计数后同步投递一次，失败时沿用常规退避重试。
+func (s *Service) ResendNow(ctx context.Context, refID, adminID uint) (*downstreamcontract.ResendResult, error) {
+	ref, err := s.references.GetByID(refID)
+	if err != nil {
+		return nil, err
+	}
+	if ref == nil {
+		return nil, downstreamcontract.ErrRefNotFound
+	}
+	if strings.TrimSpace(ref.CallbackURL) == "" {
+		return nil, downstreamcontract.ErrCallbackURLMissing
+	}
+	resetForResend(ref)
+	attempt, err := s.deliver(ctx, ref, downstreamdomain.TriggerAdmin, adminID)
+	if err != nil {
+		return nil, err
+	}
+	logger.Infow("downstream_callback_admin_resend", "ref_id", ref.ID, "admin_id", adminID, "status", ref.CallbackStatus)
+	return &downstreamcontract.ResendResult{Ref: ref, Attempt: attempt}, nil
+}
+
+// RequestResend 下游站点为自己的订单请求重发回调，只能操作本凭证创建的引用。
+func (s *Service) RequestResend(ctx context.Context, credentialID, orderID uint) (*downstreamdomain.OrderRef, error) {
+	ref, err := s.references.GetByOrderID(orderID)
+	if err != nil {
+		return nil, err
+	}
+	if ref == nil || ref.ApiCredentialID != credentialID {
+		return nil, downstreamcontract.ErrRefNotFound
+	}
+	if strings.TrimSpace(ref.CallbackURL) == "" {
+		return nil, downstreamcontract.ErrCallbackURLMissing
+	}
+
+	now := s.now()
+	latest, err := s.references.GetLatestAttempt(ref.ID)
+	if err != nil {
+		return nil, err
+	}
+	if latest != nil && now.Sub(latest.AttemptedAt) < downstreamResendCooldown {
+		return nil, downstreamcontract.ErrResendTooFrequent
+	}
+	// 已排队但尚未投递的重发同样计入冷却
+	if ref.CallbackStatus == downstreamdomain.StatusPending && now.Sub(ref.UpdatedAt) < downstreamResendCooldown {
+		return nil, downstreamcontract.ErrResendTooFrequent
+	}
+
+	resetForResend(ref)
+	if s.queue == nil {
+		if _, err := s.deliver(ctx, ref, downstreamdomain.TriggerDownstream, 0); err != nil {
+			return nil, err
+		}
+		return ref, nil
+	}
+	if err := s.references.Update(ref); err != nil {
+		return nil, err
+	}
+	if err := s.queue.EnqueueCallback(ref.ID, downstreamdomain.TriggerDownstream, 0); err != nil {
+		return nil, err
+	}
+	logger.Infow("downstream_callback_downstream_resend", "ref_id", ref.ID, "credential_id", credentialID)
+	return ref, nil
+}
+
+func resetForResend(ref *downstreamdomain.OrderRef) {
+	ref.CallbackStatus = downstreamdomain.StatusPending
+	ref.CallbackRetryCount = 0
+}
@@ -119,13 +119,13 @@ func (s *Service) EnqueueCallback(orderID uint) {
 		ref.CallbackRetryCount = 0
 		_ = s.references.Update(ref)
 	}
-	if err := s.queue.EnqueueCallback(ref.ID, 0); err != nil {
+	if err := s.queue.EnqueueCallback(ref.ID, downstreamdomain.TriggerAuto, 0); err != nil {
 		logger.Warnw("downstream_enqueue_callback_failed", "order_id", orderID, "ref_id", ref.ID, "error", err)
 	}
 }
 
-// SendCallback 读取最新订单状态并执行一次下游回调。
-func (s *Service) SendCallback(ctx context.Context, refID uint) error {
+// SendCallback 读取最新订单状态并执行一次下游回调；trigger 为空按自动投递记录。
+func (s *Service) SendCallback(ctx context.Context, refID uint, trigger string) error {
 	ref, err := s.references.GetByID(refID)
 	if err != nil {
 		return err
@@ -133,19 +133,28 @@ func (s *Service) SendCallback(ctx context.Context, refID uint) error {
 	if ref == nil {
 		return downstreamcontract.ErrRefNotFound
 	}
+	if strings.TrimSpace(trigger) == "" {
+		trigger = downstreamdomain.TriggerAuto
+	}
+	_, err = s.deliver(ctx, ref, trigger, 0)
+	return err
+}
+
+// deliver 执行一次投递并写入投递记录；未真正发起投递时返回的记录为 nil。
+func (s *Service) deliver(ctx context.Context, ref *downstreamdomain.OrderRef, trigger string, operatorID uint) (*downstreamdomain.Attempt, error) {
 	if strings.TrimSpace(ref.CallbackURL) == "" {
-		return nil
+		return nil, nil
 	}
 
 	order, err := s.orders.GetByID(ref.OrderID)
 	if err != nil || order == nil {
 		logger.Warnw("downstream_callback_order_not_found", "ref_id", ref.ID, "order_id", ref.OrderID)
-		return err
+		return nil, err
 	}
 	credential, err := s.credentials.GetByID(ref.ApiCredentialID)
 	if err != nil || credential == nil {
 		logger.Warnw("downstream_callback_credential_not_found", "ref_id", ref.ID, "credential_id", ref.ApiCredentialID)
-		return fmt.Errorf("credential not found for ref %d", ref.ID)
+		return nil, fmt.Errorf("credential not found for ref %d", ref.ID)
 	}
 
 	event := "order.status_changed"
@@ -170,21 +179,68 @@ func (s *Service) SendCallback(ctx context.Context, refID uint) error {
 		"callback_url", ref.CallbackURL,
 		"event", event,
 		"status", order.Status,
+		"trigger", trigger,
 		"has_fulfillment", fulfillment != nil,
 	)
-	if err := s.deliverer.Send(ctx, downstreamcontract.DeliveryRequest{
+	result, sendErr := s.deliverer.Send(ctx, downstreamcontract.DeliveryRequest{
 		URL:       ref.CallbackURL,
 		APIKey:    credential.APIKey,
 		APISecret: credential.APISecret,
 		Payload:   payload,
-	}); err != nil {
-		logger.Warnw("downstream_callback_http_error", "ref_id", ref.ID, "callback_url", ref.CallbackURL, "error", err)
-		return s.handleCallbackFailure(ref, now, err)
+	})
+	attempt := s.recordAttempt(ref, trigger, operatorID, payload, result, sendErr, now)
+	if sendErr != nil {
+		logger.Warnw("downstream_callback_http_error", "ref_id", ref.ID, "callback_url", ref.CallbackURL, "error", sendErr)
+		return attempt, s.handleCallbackFailure(ref, now, sendErr)
 	}
 
 	ref.CallbackStatus = downstreamdomain.StatusSent
 	ref.LastCallbackAt = &now
-	return s.references.Update(ref)
+	return attempt, s.references.Update(ref)
+}
+
+// recordAttempt 写入投递记录；记录失败只告警，不影响回调状态推进。
+func (s *Service) recordAttempt(
+	ref *downstreamdomain.OrderRef,
+	trigger string,
+	operatorID uint,
+	payload downstreamcontract.CallbackPayload,
+	result downstreamcontract.DeliveryResult,
+	sendErr error,
+	attemptedAt time.Time,
+) *downstreamdomain.Attempt {
+	attempt := &downstreamdomain.Attempt{
+		RefID:           ref.ID,
+		OrderID:         ref.OrderID,
+		ApiCredentialID: ref.ApiCredentialID,
+		Trigger:         trigger,
+		OperatorID:      operatorID,
+		Event:           payload.Event,
+		OrderStatus:     payload.Status,
+		CallbackURL:     ref.CallbackURL,
+		RequestBodyHash: result.RequestBodyHash,
+		ResponseStatus:  result.StatusCode,
+		ResponseExcerpt: result.ResponseBody,
+		Success:         sendErr == nil,
+		LatencyMs:       result.Latency.Milliseconds(),
+		AttemptedAt:     attemptedAt,
+	}
+	if sendErr != nil {
+		attempt.Error = truncateError(sendErr.Error())
+	}
+	if err := s.references.CreateAttempt(attempt); err != nil {
+		logger.Warnw("downstream_callback_attempt_record_failed", "ref_id", ref.ID, "error", err)
+	}
+	return attempt
+}
+
+func truncateError(message string) string {
+	const limit = 1000
+	runes := []rune(message)
+	if len(runes) <= limit {
+		return message
+	}
+	return string(runes[:limit])
 }
 
 func deliveredFulfillment(order *downstreamcontract.OrderSnapshot) *downstreamcontract.Fulfillment {
@@ -224,7 +280,7 @@ func (s *Service) handleCallbackFailure(ref *downstreamdomain.OrderRef, now time
 		if index >= len(callbackRetryDelays) {
 			index = len(callbackRetryDelays) - 1
 		}
-		if err := s.queue.EnqueueCallback(ref.ID, callbackRetryDelays[index]); err != nil {
+		if err := s.queue.EnqueueCallback(ref.ID, downstreamdomain.TriggerAuto, callbackRetryDelays[index]); err != nil {
 			logger.Warnw("downstream_callback_requeue_failed", "ref_id", ref.ID, "error", err)
 		}
 	}
@@ -3,6 +3,8 @@ package contract
 import "errors"
 
 var (
-	ErrRefNotFound = errors.New("downstream order ref not found")
-	ErrInvalidRef  = errors.New("invalid downstream order ref")
+	ErrRefNotFound        = errors.New("downstream order ref not found")
+	ErrInvalidRef         = errors.New("invalid downstream order ref")
+	ErrCallbackURLMissing = errors.New("downstream callback url missing")
+	ErrResendTooFrequent  = errors.New("downstream callback resend too frequent")
 )
@@ -16,6 +16,12 @@ type Repository interface {
 	Update(ref *downstreamdomain.OrderRef) error
 	ListPendingCallbacks(limit int) ([]downstreamdomain.OrderRef, error)
 	ListByCredentialID(credentialID uint, filter RefListFilter) ([]downstreamdomain.OrderRef, int64, error)
+	// ListRefs 后台按状态、凭证、订单筛选回调引用
+	ListRefs(filter RefAdminFilter) ([]downstreamdomain.OrderRef, int64, error)
+
+	CreateAttempt(attempt *downstreamdomain.Attempt) error
+	ListAttempts(filter AttemptListFilter) ([]downstreamdomain.Attempt, int64, error)
+	GetLatestAttempt(refID uint) (*downstreamdomain.Attempt, error)
 }
 
 // OrderReader 返回回调编排所需的订单投影。
@@ -28,12 +34,12 @@ type CredentialReader interface {
 	GetByID(id uint) (*Credential, error)
 }
 
-// CallbackQueue 负责立即或延迟投递回调任务。
+// CallbackQueue 负责立即或延迟投递回调任务，trigger 随任务透传到投递记录。
 type CallbackQueue interface {
-	EnqueueCallback(refID uint, delay time.Duration) error
+	EnqueueCallback(refID uint, trigger string, delay time.Duration) error
 }
 
-// Deliverer 执行签名后的下游 HTTP 回调。
+// Deliverer 执行签名后的下游 HTTP 回调；无论成功与否都返回已获取的投递结果。
 type Deliverer interface {
-	Send(ctx context.Context, request DeliveryRequest) error
+	Send(ctx context.Context, request DeliveryRequest) (DeliveryResult, error)
 }
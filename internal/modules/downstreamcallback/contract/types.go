@@ -3,6 +3,7 @@ package contract
 import (
 	"time"
 
+	downstreamdomain "github.com/dujiao-next/internal/modules/downstreamcallback/domain"
 	"github.com/dujiao-next/internal/shared/jsonmap"
 )
 
@@ -13,6 +14,27 @@ type RefListFilter struct {
 	PageSize       int
 }
 
+// RefAdminFilter 描述后台回调引用列表的筛选条件。
+type RefAdminFilter struct {
+	CallbackStatus    string
+	ApiCredentialID   uint
+	OrderID           uint
+	DownstreamOrderNo string
+	Page              int
+	PageSize          int
+}
+
+// AttemptListFilter 描述回调投递记录的筛选条件；Success 为 nil 表示不限。
+type AttemptListFilter struct {
+	RefID           uint
+	OrderID         uint
+	ApiCredentialID uint
+	Trigger         string
+	Success         *bool
+	Page            int
+	PageSize        int
+}
+
 // Fulfillment 是回调协议需要的交付快照。
 type Fulfillment struct {
 	Type         string       `json:"type"`
@@ -57,3 +79,17 @@ type DeliveryRequest struct {
 	APISecret string
 	Payload   CallbackPayload
 }
+
+// DeliveryResult 是一次 HTTP 回调的可观测结果。
+type DeliveryResult struct {
+	RequestBodyHash string
+	StatusCode      int
+	ResponseBody    string
+	Latency         time.Duration
+}
+
+// ResendResult 是立即重发后的引用状态与本次投递记录。
+type ResendResult struct {
+	Ref     *downstreamdomain.OrderRef `json:"ref"`
+	Attempt *downstreamdomain.Attempt  `json:"attempt"`
+}
@@ -0,0 +1,37 @@
+package domain
+
+import "time"
+
+const (
+	TriggerAuto       = "auto"
+	TriggerAdmin      = "admin"
+	TriggerDownstream = "downstream"
+)
+
+// ResponseExcerptLimit 回调响应体截断保存的最大字节数。
+const ResponseExcerptLimit = 1024
+
+// Attempt 记录一次下游回调投递，请求体只保存摘要，避免交付内容落库。
+type Attempt struct {
+	ID              uint      `gorm:"primarykey" json:"id"`
+	RefID           uint      `gorm:"index;not null" json:"ref_id"`
+	OrderID         uint      `gorm:"index;not null" json:"order_id"`
+	ApiCredentialID uint      `gorm:"index;not null" json:"api_credential_id"`
+	Trigger         string    `gorm:"column:trigger_source;type:varchar(20);not null;index" json:"trigger"`
+	OperatorID      uint      `gorm:"not null;default:0" json:"operator_id,omitempty"`
+	Event           string    `gorm:"type:varchar(40)" json:"event"`
+	OrderStatus     string    `gorm:"type:varchar(32)" json:"order_status"`
+	CallbackURL     string    `gorm:"type:varchar(500)" json:"callback_url"`
+	RequestBodyHash string    `gorm:"type:varchar(64)" json:"request_body_hash"`
+	ResponseStatus  int       `gorm:"not null;default:0" json:"response_status"`
+	ResponseExcerpt string    `gorm:"type:text" json:"response_excerpt"`
+	Error           string    `gorm:"type:varchar(1000)" json:"error,omitempty"`
+	Success         bool      `gorm:"not null;default:false;index" json:"success"`
+	LatencyMs       int64     `gorm:"not null;default:0" json:"latency_ms"`
+	AttemptedAt     time.Time `gorm:"index;not null" json:"attempted_at"`
+}
+
+// TableName 指定表名
+func (Attempt) TableName() string {
+	return "downstream_callback_attempts"
+}
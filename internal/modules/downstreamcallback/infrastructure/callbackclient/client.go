@@ -3,14 +3,18 @@ package callbackclient
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	downstreamcontract "github.com/dujiao-next/internal/modules/downstreamcallback/contract"
+	downstreamdomain "github.com/dujiao-next/internal/modules/downstreamcallback/domain"
 	"github.com/dujiao-next/internal/upstream"
 )
 
@@ -34,37 +38,58 @@ func NewWithHTTPClient(client *http.Client) *Client {
 	return &Client{httpClient: client}
 }
 
-func (c *Client) Send(ctx context.Context, request downstreamcontract.DeliveryRequest) error {
+// Send 投递回调；请求已发出时，即使失败也返回请求摘要、响应状态、响应摘录与耗时。
+func (c *Client) Send(ctx context.Context, request downstreamcontract.DeliveryRequest) (downstreamcontract.DeliveryResult, error) {
+	var result downstreamcontract.DeliveryResult
 	body, err := json.Marshal(request.Payload)
 	if err != nil {
-		return err
+		return result, err
 	}
+	digest := sha256.Sum256(body)
+	result.RequestBodyHash = hex.EncodeToString(digest[:])
 	signature := upstream.Sign(request.APISecret, http.MethodPost, signaturePath, request.Payload.Timestamp, body)
 	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, request.URL, bytes.NewReader(body))
 	if err != nil {
-		return err
+		return result, err
 	}
 	httpRequest.Header.Set("Content-Type", "application/json")
 	httpRequest.Header.Set(upstream.HeaderApiKey, request.APIKey)
 	httpRequest.Header.Set(upstream.HeaderTimestamp, fmt.Sprintf("%d", request.Payload.Timestamp))
 	httpRequest.Header.Set(upstream.HeaderSignature, signature)
 
+	startedAt := time.Now()
 	response, err := c.httpClient.Do(httpRequest)
 	if err != nil {
-		return err
+		result.Latency = time.Since(startedAt)
+		return result, err
 	}
 	defer response.Body.Close()
 	body, readErr := io.ReadAll(io.LimitReader(response.Body, 4096))
+	result.Latency = time.Since(startedAt)
+	result.StatusCode = response.StatusCode
+	result.ResponseBody = excerpt(body)
 	if readErr != nil {
-		return readErr
+		return result, readErr
 	}
 	if response.StatusCode == http.StatusOK {
-		var result struct {
+		var ack struct {
 			OK bool `json:"ok"`
 		}
-		if json.Unmarshal(body, &result) == nil && result.OK {
-			return nil
+		if json.Unmarshal(body, &ack) == nil && ack.OK {
+			return result, nil
 		}
 	}
-	return fmt.Errorf("callback returned %d: %s", response.StatusCode, strings.TrimSpace(string(body)))
+	return result, fmt.Errorf("callback returned %d: %s", response.StatusCode, strings.TrimSpace(string(body)))
+}
+
+// excerpt 截断响应体，避免截断落在多字节字符中间。
+func excerpt(body []byte) string {
+	if len(body) <= downstreamdomain.ResponseExcerptLimit {
+		return string(body)
+	}
+	cut := downstreamdomain.ResponseExcerptLimit
+	for cut > 0 && !utf8.RuneStart(body[cut]) {
+		cut--
+	}
+	return string(body[:cut])
 }
@@ -43,7 +43,7 @@ func TestClientSendsSignedCallback(t *testing.T) {
 	t.Cleanup(server.Close)
 
 	client := New()
-	err := client.Send(context.Background(), downstreamcontract.DeliveryRequest{
+	result, err := client.Send(context.Background(), downstreamcontract.DeliveryRequest{
 		URL:       server.URL,
 		APIKey:    "downstream-key",
 		APISecret: secret,
@@ -56,6 +56,9 @@ func TestClientSendsSignedCallback(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Send() error = %v", err)
 	}
+	if result.StatusCode != http.StatusOK || result.ResponseBody != `{"ok":true}` || len(result.RequestBodyHash) != 64 {
+		t.Fatalf("unexpected delivery result: %#v", result)
+	}
 }
 
 func TestClientRejectsNonSuccessContract(t *testing.T) {
@@ -65,11 +68,14 @@ func TestClientRejectsNonSuccessContract(t *testing.T) {
 	}))
 	t.Cleanup(server.Close)
 
-	err := New().Send(context.Background(), downstreamcontract.DeliveryRequest{
+	result, err := New().Send(context.Background(), downstreamcontract.DeliveryRequest{
 		URL:     server.URL,
 		Payload: downstreamcontract.CallbackPayload{Timestamp: 1_700_000_000},
 	})
 	if err == nil {
 		t.Fatal("Send() should reject non-success response")
 	}
+	if result.StatusCode != http.StatusBadGateway || result.ResponseBody != "upstream unavailable" {
+		t.Fatalf("failed delivery should keep response details: %#v", result)
+	}
 }
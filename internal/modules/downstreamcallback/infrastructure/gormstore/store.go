@@ -100,3 +100,83 @@ func (s *Store) ListByCredentialID(credentialID uint, filter downstreamcontract.
 	}
 	return refs, total, nil
 }
+
+func (s *Store) ListRefs(filter downstreamcontract.RefAdminFilter) ([]downstreamdomain.OrderRef, int64, error) {
+	var refs []downstreamdomain.OrderRef
+	var total int64
+
+	query := s.db.Model(&downstreamdomain.OrderRef{})
+	if filter.CallbackStatus != "" {
+		query = query.Where("callback_status = ?", filter.CallbackStatus)
+	}
+	if filter.ApiCredentialID > 0 {
+		query = query.Where("api_credential_id = ?", filter.ApiCredentialID)
+	}
+	if filter.OrderID > 0 {
+		query = query.Where("order_id = ?", filter.OrderID)
+	}
+	if filter.DownstreamOrderNo != "" {
+		query = query.Where("downstream_order_no = ?", filter.DownstreamOrderNo)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	query = query.Order("updated_at DESC, id DESC")
+	if filter.Page > 0 && filter.PageSize > 0 {
+		query = query.Offset((filter.Page - 1) * filter.PageSize).Limit(filter.PageSize)
+	}
+	if err := query.Find(&refs).Error; err != nil {
+		return nil, 0, err
+	}
+	return refs, total, nil
+}
+
+func (s *Store) CreateAttempt(attempt *downstreamdomain.Attempt) error {
+	return s.db.Create(attempt).Error
+}
+
+func (s *Store) ListAttempts(filter downstreamcontract.AttemptListFilter) ([]downstreamdomain.Attempt, int64, error) {
+	var attempts []downstreamdomain.Attempt
+	var total int64
+
+	query := s.db.Model(&downstreamdomain.Attempt{})
+	if filter.RefID > 0 {
+		query = query.Where("ref_id = ?", filter.RefID)
+	}
+	if filter.OrderID > 0 {
+		query = query.Where("order_id = ?", filter.OrderID)
+	}
+	if filter.ApiCredentialID > 0 {
+		query = query.Where("api_credential_id = ?", filter.ApiCredentialID)
+	}
+	if filter.Trigger != "" {
+		query = query.Where("trigger_source = ?", filter.Trigger)
+	}
+	if filter.Success != nil {
+		query = query.Where("success = ?", *filter.Success)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	query = query.Order("id DESC")
+	if filter.Page > 0 && filter.PageSize > 0 {
+		query = query.Offset((filter.Page - 1) * filter.PageSize).Limit(filter.PageSize)
+	}
+	if err := query.Find(&attempts).Error; err != nil {
+		return nil, 0, err
+	}
+	return attempts, total, nil
+}
+
+func (s *Store) GetLatestAttempt(refID uint) (*downstreamdomain.Attempt, error) {
+	var attempt downstreamdomain.Attempt
+	if err := s.db.Where("ref_id = ?", refID).Order("id DESC").First(&attempt).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &attempt, nil
+}
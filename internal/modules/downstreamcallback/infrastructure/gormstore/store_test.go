@@ -19,7 +19,7 @@ func openTestStore(t *testing.T) *Store {
 	if err != nil {
 		t.Fatalf("open sqlite: %v", err)
 	}
-	if err := db.AutoMigrate(&downstreamdomain.OrderRef{}); err != nil {
+	if err := db.AutoMigrate(&downstreamdomain.OrderRef{}, &downstreamdomain.Attempt{}); err != nil {
 		t.Fatalf("migrate order refs: %v", err)
 	}
 	return New(db)
@@ -82,3 +82,35 @@ func TestStoreFiltersPendingAndCredentialLists(t *testing.T) {
 		t.Fatalf("credential list mismatch: total=%d refs=%#v", total, listed)
 	}
 }
+
+func TestStoreListsAttemptsNewestFirst(t *testing.T) {
+	store := openTestStore(t)
+	base := time.Unix(1_700_000_000, 0).UTC()
+	for i, attempt := range []downstreamdomain.Attempt{
+		{RefID: 1, OrderID: 8, ApiCredentialID: 5, Trigger: downstreamdomain.TriggerAuto, ResponseStatus: 502},
+		{RefID: 1, OrderID: 8, ApiCredentialID: 5, Trigger: downstreamdomain.TriggerAdmin, ResponseStatus: 200, Success: true},
+		{RefID: 2, OrderID: 9, ApiCredentialID: 6, Trigger: downstreamdomain.TriggerAuto, ResponseStatus: 200, Success: true},
+	} {
+		attempt.AttemptedAt = base.Add(time.Duration(i) * time.Minute)
+		if err := store.CreateAttempt(&attempt); err != nil {
+			t.Fatalf("create attempt: %v", err)
+		}
+	}
+
+	latest, err := store.GetLatestAttempt(1)
+	if err != nil || latest == nil || latest.Trigger != downstreamdomain.TriggerAdmin {
+		t.Fatalf("latest attempt = %#v err=%v", latest, err)
+	}
+	success := true
+	attempts, total, err := store.ListAttempts(downstreamcontract.AttemptListFilter{Success: &success, Page: 1, PageSize: 10})
+	if err != nil || total != 2 || attempts[0].RefID != 2 {
+		t.Fatalf("successful attempts = %#v total=%d err=%v", attempts, total, err)
+	}
+	attempts, total, err = store.ListAttempts(downstreamcontract.AttemptListFilter{RefID: 1, Trigger: downstreamdomain.TriggerAuto})
+	if err != nil || total != 1 || attempts[0].ResponseStatus != 502 {
+		t.Fatalf("filtered attempts = %#v total=%d err=%v", attempts, total, err)
+	}
+	if missing, err := store.GetLatestAttempt(404); err != nil || missing != nil {
+		t.Fatalf("missing latest attempt = %#v err=%v", missing, err)
+	}
+}
@@ -23,12 +23,13 @@ func New(client *queue.Client) *Adapter {
 	return &Adapter{client: client}
 }
 
-func (a *Adapter) EnqueueCallback(refID uint, delay time.Duration) error {
+func (a *Adapter) EnqueueCallback(refID uint, trigger string, delay time.Duration) error {
 	options := make([]asynq.Option, 0, 1)
 	if delay > 0 {
 		options = append(options, asynq.ProcessIn(delay))
 	}
 	return a.client.EnqueueDownstreamCallback(queue.DownstreamCallbackPayload{
 		DownstreamOrderRefID: refID,
+		Trigger:              trigger,
 	}, options...)
 }
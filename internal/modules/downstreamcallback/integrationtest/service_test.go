@@ -16,6 +16,7 @@ type refRepositoryStub struct {
 	byOrderID map[uint]*downstreamdomain.OrderRef
 	created   *downstreamdomain.OrderRef
 	updated   []*downstreamdomain.OrderRef
+	attempts  []*downstreamdomain.Attempt
 }
 
 func (r *refRepositoryStub) GetByID(id uint) (*downstreamdomain.OrderRef, error) {
@@ -48,6 +49,29 @@ func (r *refRepositoryStub) ListByCredentialID(uint, downstreamcontract.RefListF
 	return nil, 0, nil
 }
 
+func (r *refRepositoryStub) ListRefs(downstreamcontract.RefAdminFilter) ([]downstreamdomain.OrderRef, int64, error) {
+	return nil, 0, nil
+}
+
+func (r *refRepositoryStub) CreateAttempt(attempt *downstreamdomain.Attempt) error {
+	attempt.ID = uint(len(r.attempts) + 1)
+	r.attempts = append(r.attempts, attempt)
+	return nil
+}
+
+func (r *refRepositoryStub) ListAttempts(downstreamcontract.AttemptListFilter) ([]downstreamdomain.Attempt, int64, error) {
+	return nil, 0, nil
+}
+
+func (r *refRepositoryStub) GetLatestAttempt(refID uint) (*downstreamdomain.Attempt, error) {
+	for i := len(r.attempts) - 1; i >= 0; i-- {
+		if r.attempts[i].RefID == refID {
+			return r.attempts[i], nil
+		}
+	}
+	return nil, nil
+}
+
 type orderReaderStub struct {
 	orders map[uint]*downstreamcontract.OrderSnapshot
 }
@@ -65,29 +89,31 @@ func (r credentialReaderStub) GetByID(id uint) (*downstreamcontract.Credential,
 }
 
 type queuedCallback struct {
-	refID uint
-	delay time.Duration
+	refID   uint
+	trigger string
+	delay   time.Duration
 }
 
 type callbackQueueStub struct {
 	callbacks []queuedCallback
 }
 
-func (q *callbackQueueStub) EnqueueCallback(refID uint, delay time.Duration) error {
-	q.callbacks = append(q.callbacks, queuedCallback{refID: refID, delay: delay})
+func (q *callbackQueueStub) EnqueueCallback(refID uint, trigger string, delay time.Duration) error {
+	q.callbacks = append(q.callbacks, queuedCallback{refID: refID, trigger: trigger, delay: delay})
 	return nil
 }
 
 type delivererStub struct {
 	request downstreamcontract.DeliveryRequest
+	result  downstreamcontract.DeliveryResult
 	err     error
 	calls   int
 }
 
-func (d *delivererStub) Send(_ context.Context, request downstreamcontract.DeliveryRequest) error {
+func (d *delivererStub) Send(_ context.Context, request downstreamcontract.DeliveryRequest) (downstreamcontract.DeliveryResult, error) {
 	d.calls++
 	d.request = request
-	return d.err
+	return d.result, d.err
 }
 
 func newService(references downstreamcontract.Repository, orders downstreamcontract.OrderReader, credentials downstreamcontract.CredentialReader, queue downstreamcontract.CallbackQueue, deliverer downstreamcontract.Deliverer) *downstreamapp.Service {
@@ -167,7 +193,7 @@ func TestSendCallbackBuildsPayloadAndPersistsSentStatus(t *testing.T) {
 	deliverer := &delivererStub{}
 	service := newService(references, orders, credentials, nil, deliverer)
 
-	if err := service.SendCallback(context.Background(), ref.ID); err != nil {
+	if err := service.SendCallback(context.Background(), ref.ID, ""); err != nil {
 		t.Fatalf("SendCallback() error = %v", err)
 	}
 	if deliverer.calls != 1 || deliverer.request.Payload.Event != "order.fulfilled" {
@@ -182,6 +208,9 @@ func TestSendCallbackBuildsPayloadAndPersistsSentStatus(t *testing.T) {
 	if len(references.updated) != 1 {
 		t.Fatalf("updates = %d, want 1", len(references.updated))
 	}
+	if len(references.attempts) != 1 || !references.attempts[0].Success || references.attempts[0].Trigger != downstreamdomain.TriggerAuto {
+		t.Fatalf("attempt log mismatch: %#v", references.attempts)
+	}
 }
 
 func TestSendCallbackFailureSchedulesBackoffAndPersistsRetry(t *testing.T) {
@@ -190,19 +219,96 @@ func TestSendCallbackFailureSchedulesBackoffAndPersistsRetry(t *testing.T) {
 	orders := orderReaderStub{orders: map[uint]*downstreamcontract.OrderSnapshot{8: {ID: 8, Status: "paid"}}}
 	credentials := credentialReaderStub{credentials: map[uint]*downstreamcontract.Credential{5: {ID: 5}}}
 	queue := &callbackQueueStub{}
-	service := newService(references, orders, credentials, queue, &delivererStub{err: errors.New("network unavailable")})
+	deliverer := &delivererStub{
+		err:    errors.New("callback returned 502: bad gateway"),
+		result: downstreamcontract.DeliveryResult{RequestBodyHash: "hash", StatusCode: 502, ResponseBody: "bad gateway", Latency: 250 * time.Millisecond},
+	}
+	service := newService(references, orders, credentials, queue, deliverer)
 
-	if err := service.SendCallback(context.Background(), ref.ID); err != nil {
+	if err := service.SendCallback(context.Background(), ref.ID, downstreamdomain.TriggerDownstream); err != nil {
 		t.Fatalf("SendCallback() should persist retry state, got %v", err)
 	}
 	if ref.CallbackRetryCount != 1 || len(queue.callbacks) != 1 || queue.callbacks[0].delay != 30*time.Second {
 		t.Fatalf("retry state mismatch: ref=%#v queue=%#v", ref, queue.callbacks)
 	}
+	if queue.callbacks[0].trigger != downstreamdomain.TriggerAuto {
+		t.Fatalf("retries should be queued as auto, got %q", queue.callbacks[0].trigger)
+	}
+	if len(references.attempts) != 1 {
+		t.Fatalf("attempts = %d, want 1", len(references.attempts))
+	}
+	attempt := references.attempts[0]
+	if attempt.Success || attempt.Trigger != downstreamdomain.TriggerDownstream || attempt.ResponseStatus != 502 ||
+		attempt.ResponseExcerpt != "bad gateway" || attempt.LatencyMs != 250 || attempt.RequestBodyHash != "hash" || attempt.Error == "" {
+		t.Fatalf("failed attempt mismatch: %#v", attempt)
+	}
+}
+
+func newResendFixture(queue downstreamcontract.CallbackQueue, deliverer *delivererStub) (*refRepositoryStub, *downstreamdomain.OrderRef, *downstreamapp.Service) {
+	ref := &downstreamdomain.OrderRef{
+		ID:                 3,
+		OrderID:            8,
+		ApiCredentialID:    5,
+		CallbackURL:        "https://callback.example.test",
+		CallbackStatus:     downstreamdomain.StatusFailed,
+		CallbackRetryCount: 5,
+		UpdatedAt:          time.Unix(1_699_990_000, 0).UTC(),
+	}
+	references := &refRepositoryStub{
+		byID:      map[uint]*downstreamdomain.OrderRef{ref.ID: ref},
+		byOrderID: map[uint]*downstreamdomain.OrderRef{ref.OrderID: ref},
+	}
+	orders := orderReaderStub{orders: map[uint]*downstreamcontract.OrderSnapshot{8: {ID: 8, Status: "completed"}}}
+	credentials := credentialReaderStub{credentials: map[uint]*downstreamcontract.Credential{5: {ID: 5}}}
+	return references, ref, newService(references, orders, credentials, queue, deliverer)
+}
+
+func TestResendNowDeliversSynchronouslyAndRecordsOperator(t *testing.T) {
+	deliverer := &delivererStub{result: downstreamcontract.DeliveryResult{StatusCode: 200}}
+	references, ref, service := newResendFixture(&callbackQueueStub{}, deliverer)
+
+	result, err := service.ResendNow(context.Background(), ref.ID, 77)
+	if err != nil {
+		t.Fatalf("ResendNow() error = %v", err)
+	}
+	if deliverer.calls != 1 || ref.CallbackStatus != downstreamdomain.StatusSent || ref.CallbackRetryCount != 0 {
+		t.Fatalf("resend state mismatch: calls=%d ref=%#v", deliverer.calls, ref)
+	}
+	if result.Attempt == nil || result.Attempt.Trigger != downstreamdomain.TriggerAdmin || result.Attempt.OperatorID != 77 || len(references.attempts) != 1 {
+		t.Fatalf("admin attempt mismatch: %#v", result.Attempt)
+	}
+	if _, err := service.ResendNow(context.Background(), 404, 77); !errors.Is(err, downstreamcontract.ErrRefNotFound) {
+		t.Fatalf("missing ref error = %v", err)
+	}
+}
+
+func TestRequestResendEnforcesOwnershipAndCooldown(t *testing.T) {
+	queue := &callbackQueueStub{}
+	references, ref, service := newResendFixture(queue, &delivererStub{})
+
+	if _, err := service.RequestResend(context.Background(), 6, ref.OrderID); !errors.Is(err, downstreamcontract.ErrRefNotFound) {
+		t.Fatalf("foreign credential error = %v, want ErrRefNotFound", err)
+	}
+	references.attempts = append(references.attempts, &downstreamdomain.Attempt{RefID: ref.ID, AttemptedAt: time.Unix(1_699_999_970, 0).UTC()})
+	if _, err := service.RequestResend(context.Background(), 5, ref.OrderID); !errors.Is(err, downstreamcontract.ErrResendTooFrequent) {
+		t.Fatalf("cooldown error = %v, want ErrResendTooFrequent", err)
+	}
+
+	references.attempts[0].AttemptedAt = time.Unix(1_699_999_000, 0).UTC()
+	if _, err := service.RequestResend(context.Background(), 5, ref.OrderID); err != nil {
+		t.Fatalf("RequestResend() error = %v", err)
+	}
+	if len(queue.callbacks) != 1 || queue.callbacks[0].trigger != downstreamdomain.TriggerDownstream {
+		t.Fatalf("queued callbacks = %#v", queue.callbacks)
+	}
+	if ref.CallbackStatus != downstreamdomain.StatusPending || ref.CallbackRetryCount != 0 {
+		t.Fatalf("ref was not reset: %#v", ref)
+	}
 }
 
 func TestSendCallbackReturnsStableNotFoundSentinel(t *testing.T) {
 	service := newService(&refRepositoryStub{}, orderReaderStub{}, credentialReaderStub{}, nil, &delivererStub{})
-	if err := service.SendCallback(context.Background(), 404); !errors.Is(err, downstreamcontract.ErrRefNotFound) {
+	if err := service.SendCallback(context.Background(), 404, ""); !errors.Is(err, downstreamcontract.ErrRefNotFound) {
 		t.Fatalf("error = %v, want ErrRefNotFound", err)
 	}
 }
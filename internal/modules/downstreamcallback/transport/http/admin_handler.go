@@ -0,0 +1,127 @@
+package downstreamcallbackhttp
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	downstreamcontract "github.com/dujiao-next/internal/modules/downstreamcallback/contract"
+	downstreamdomain "github.com/dujiao-next/internal/modules/downstreamcallback/domain"
+	"github.com/dujiao-next/internal/platform/http/ginutil"
+	"github.com/dujiao-next/internal/platform/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminService 是后台下游回调查询与重发所需的最小用例接口。
+type AdminService interface {
+	ListRefs(filter downstreamcontract.RefAdminFilter) ([]downstreamdomain.OrderRef, int64, error)
+	ListAttempts(filter downstreamcontract.AttemptListFilter) ([]downstreamdomain.Attempt, int64, error)
+	ResendNow(ctx context.Context, refID, adminID uint) (*downstreamcontract.ResendResult, error)
+}
+
+// AdminHandler 处理后台下游回调请求。
+type AdminHandler struct {
+	service AdminService
+}
+
+func NewAdminHandler(service AdminService) *AdminHandler {
+	if service == nil {
+		panic("downstream callback admin handler: required dependency is nil")
+	}
+	return &AdminHandler{service: service}
+}
+
+// ListRefs 获取下游回调引用（支持 callback_status、api_credential_id、order_id、downstream_order_no 筛选）
+func (h *AdminHandler) ListRefs(c *gin.Context) {
+	credentialID, err := ginutil.ParseQueryUint(c.Query("api_credential_id"), false)
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", nil)
+		return
+	}
+	orderID, err := ginutil.ParseQueryUint(c.Query("order_id"), false)
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", nil)
+		return
+	}
+	page, pageSize := ginutil.ParsePagination(c)
+	refs, total, err := h.service.ListRefs(downstreamcontract.RefAdminFilter{
+		CallbackStatus:    strings.TrimSpace(c.Query("callback_status")),
+		ApiCredentialID:   credentialID,
+		OrderID:           orderID,
+		DownstreamOrderNo: strings.TrimSpace(c.Query("downstream_order_no")),
+		Page:              page,
+		PageSize:          pageSize,
+	})
+	if err != nil {
+		ginutil.RespondError(c, response.CodeInternal, "error.downstream_callback_fetch_failed", err)
+		return
+	}
+	response.SuccessWithPage(c, refs, response.BuildPagination(page, pageSize, total))
+}
+
+// ListAttempts 获取回调投递记录（支持 ref_id、order_id、api_credential_id、trigger、success 筛选）
+func (h *AdminHandler) ListAttempts(c *gin.Context) {
+	refID, err := ginutil.ParseQueryUint(c.Query("ref_id"), false)
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", nil)
+		return
+	}
+	orderID, err := ginutil.ParseQueryUint(c.Query("order_id"), false)
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", nil)
+		return
+	}
+	credentialID, err := ginutil.ParseQueryUint(c.Query("api_credential_id"), false)
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", nil)
+		return
+	}
+	success, err := ginutil.ParseQueryBoolPtr(c, "success")
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", nil)
+		return
+	}
+	page, pageSize := ginutil.ParsePagination(c)
+	attempts, total, err := h.service.ListAttempts(downstreamcontract.AttemptListFilter{
+		RefID:           refID,
+		OrderID:         orderID,
+		ApiCredentialID: credentialID,
+		Trigger:         strings.TrimSpace(c.Query("trigger")),
+		Success:         success,
+		Page:            page,
+		PageSize:        pageSize,
+	})
+	if err != nil {
+		ginutil.RespondError(c, response.CodeInternal, "error.downstream_callback_fetch_failed", err)
+		return
+	}
+	response.SuccessWithPage(c, attempts, response.BuildPagination(page, pageSize, total))
+}
+
+// Resend 立即重发一次回调，返回最新引用状态与本次投递记录
+func (h *AdminHandler) Resend(c *gin.Context) {
+	refID, err := ginutil.ParseParamUint(c, "id")
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", nil)
+		return
+	}
+	adminID, ok := ginutil.GetAdminID(c)
+	if !ok {
+		ginutil.RespondError(c, response.CodeUnauthorized, "error.unauthorized", nil)
+		return
+	}
+	result, err := h.service.ResendNow(c.Request.Context(), refID, adminID)
+	if err != nil {
+		switch {
+		case errors.Is(err, downstreamcontract.ErrRefNotFound):
+			ginutil.RespondError(c, response.CodeNotFound, "error.downstream_callback_not_found", nil)
+		case errors.Is(err, downstreamcontract.ErrCallbackURLMissing):
+			ginutil.RespondError(c, response.CodeBadRequest, "error.downstream_callback_url_missing", nil)
+		default:
+			ginutil.RespondError(c, response.CodeInternal, "error.downstream_callback_resend_failed", err)
+		}
+		return
+	}
+	response.Success(c, result)
+}
@@ -0,0 +1,13 @@
+package downstreamcallbackhttp
+
+import "github.com/gin-gonic/gin"
+
+// RegisterAdminRoutes 注册后台下游回调查询与重发路由。
+func RegisterAdminRoutes(authorized gin.IRoutes, handler *AdminHandler) {
+	if authorized == nil || handler == nil {
+		panic("downstream callback admin routes: required dependency is nil")
+	}
+	authorized.GET("/downstream-callbacks", handler.ListRefs)
+	authorized.GET("/downstream-callbacks/attempts", handler.ListAttempts)
+	authorized.POST("/downstream-callbacks/:id/resend", handler.Resend)
+}
@@ -22,7 +22,7 @@ import (
 	"github.com/dujiao-next/internal/shared/jsonmap"
 )
 
-// Service 编排人工交付与自动交付。
+// Service 编排人工交付、自动交付、webhook 交付、文件交付与授权码交付。
 type Service struct {
 	orderStore            ordercontract.Store
 	fulfillmentRepo       fulfillmentcontract.Store
@@ -158,52 +158,7 @@ func (s *Service) CreateManual(input CreateManualInput) (*fulfillmentdomain.Fulf
 		}
 		return nil, ErrFulfillmentCreateFailed
 	}
-	if s.orderQueue != nil {
-		if order.ParentID != nil {
-			status, syncErr := orderapp.SyncParentStatus(s.orderStore, *order.ParentID, now)
-			if syncErr != nil {
-				logger.Warnw("fulfillment_sync_parent_status_failed",
-					"order_id", order.ID,
-					"parent_order_id", *order.ParentID,
-					"target_status", constants.OrderStatusDelivered,
-					"error", syncErr,
-				)
-			} else {
-				if status == "" {
-					status = constants.OrderStatusDelivered
-				}
-				if status != constants.OrderStatusCanceled {
-					if _, err := orderapp.EnqueueStatusEmailTaskIfEligible(s.orderStore, s.orderQueue, s.settingService, s.defaultEmailConfig, *order.ParentID, status); err != nil {
-						logger.Warnw("fulfillment_enqueue_status_email_failed",
-							"order_id", order.ID,
-							"target_order_id", *order.ParentID,
-							"status", status,
-							"error", err,
-						)
-					}
-				}
-			}
-		} else {
-			if _, err := orderapp.EnqueueStatusEmailTaskIfEligible(s.orderStore, s.orderQueue, s.settingService, s.defaultEmailConfig, input.OrderID, constants.OrderStatusDelivered); err != nil {
-				logger.Warnw("fulfillment_enqueue_status_email_failed",
-					"order_id", order.ID,
-					"target_order_id", input.OrderID,
-					"status", constants.OrderStatusDelivered,
-					"error", err,
-				)
-			}
-		}
-	}
-	// Telegram 通知：交付完成后推送给用户
-	notifyOrderID := input.OrderID
-	if order.ParentID != nil {
-		notifyOrderID = *order.ParentID
-	}
-	go s.NotifyBotOrderFulfilled(order.UserID, notifyOrderID)
-	// B 侧：人工交付完成后触发下游回调
-	if s.downstreamCallbackSvc != nil {
-		s.downstreamCallbackSvc.EnqueueCallback(input.OrderID)
-	}
+	s.afterFulfilled(order, constants.OrderStatusDelivered, now)
 	return created, nil
 }
 
@@ -336,6 +291,146 @@ func (s *Service) CreateAuto(orderID uint) (*fulfillmentdomain.Fulfillment, erro
 			return nil, ErrFulfillmentCreateFailed
 		}
 	}
+	s.afterFulfilled(order, constants.OrderStatusCompleted, now)
+	return fulfillment, nil
+}
+
+// CreateWebhook 写入 webhook 发码服务返回的交付内容，订单直接完成。
+func (s *Service) CreateWebhook(orderID uint, payload string, deliveryData jsonmap.JSON) (*fulfillmentdomain.Fulfillment, error) {
+	return s.createExternal(orderID, constants.FulfillmentTypeWebhook, payload, deliveryData, nil)
+}
+
+// CreateFile 写入文件交付说明（下载授权由文件交付模块管理），订单直接完成。
+func (s *Service) CreateFile(orderID uint, payload string, deliveryData jsonmap.JSON) (*fulfillmentdomain.Fulfillment, error) {
+	return s.createExternal(orderID, constants.FulfillmentTypeFile, payload, deliveryData, nil)
+}
+
+// CreateLicense 写入按模板签发的授权码，并在同一事务中记为已售卡密，便于导出、替换与审计。
+func (s *Service) CreateLicense(orderID uint, secrets []cardsecretdomain.Secret) (*fulfillmentdomain.Fulfillment, error) {
+	if len(secrets) == 0 {
+		return nil, ErrFulfillmentInvalid
+	}
+	lines := make([]string, 0, len(secrets))
+	for _, secret := range secrets {
+		value := strings.TrimSpace(secret.Secret)
+		if value == "" || secret.ProductID == 0 {
+			return nil, ErrFulfillmentInvalid
+		}
+		lines = append(lines, value)
+	}
+	return s.createExternal(orderID, constants.FulfillmentTypeLicense, strings.Join(lines, "\n"), nil, secrets)
+}
+
+// createExternal 由外部交付来源写入交付记录，要求订单商品均为指定交付类型。
+// secrets 非空时一并写入已售卡密记录，且必须对应订单中的商品。
+func (s *Service) createExternal(orderID uint, fulfillmentType string, payload string, deliveryData jsonmap.JSON, secrets []cardsecretdomain.Secret) (*fulfillmentdomain.Fulfillment, error) {
+	if orderID == 0 {
+		return nil, ErrFulfillmentInvalid
+	}
+	payload = strings.TrimSpace(payload)
+	deliveryData = normalizeManualDeliveryData(deliveryData)
+	if payload == "" && len(deliveryData) == 0 {
+		return nil, ErrFulfillmentInvalid
+	}
+	if payload == "" {
+		payload = buildManualDeliveryPayload(deliveryData)
+	}
+
+	order, err := s.orderStore.GetByID(orderID)
+	if err != nil {
+		return nil, ErrOrderFetchFailed
+	}
+	if order == nil {
+		return nil, ErrOrderNotFound
+	}
+	if order.ParentID == nil && len(order.Children) > 0 {
+		return nil, ErrFulfillmentInvalid
+	}
+	if order.Status != constants.OrderStatusPaid && order.Status != constants.OrderStatusFulfilling {
+		return nil, ErrOrderStatusInvalid
+	}
+	if len(order.Items) == 0 {
+		return nil, ErrFulfillmentInvalid
+	}
+	itemKeys := make(map[string]struct{}, len(order.Items))
+	for _, item := range order.Items {
+		if strings.TrimSpace(item.FulfillmentType) != fulfillmentType {
+			return nil, ErrFulfillmentInvalid
+		}
+		itemKeys[orderdomain.ItemKey(item.ProductID, item.SKUID)] = struct{}{}
+	}
+	for _, secret := range secrets {
+		if _, ok := itemKeys[orderdomain.ItemKey(secret.ProductID, secret.SKUID)]; !ok {
+			return nil, ErrFulfillmentInvalid
+		}
+	}
+
+	now := time.Now()
+	var created *fulfillmentdomain.Fulfillment
+	err = s.orderStore.WithinTransaction(func(tx ordercontract.Transaction) error {
+		if _, found, err := tx.Fulfillments().FindByOrderIDForUpdate(orderID); err != nil {
+			return err
+		} else if found {
+			return ErrFulfillmentExists
+		}
+		if len(secrets) > 0 {
+			records := make([]cardsecretdomain.Secret, 0, len(secrets))
+			for _, secret := range secrets {
+				usedAt := now
+				recordOrderID := orderID
+				records = append(records, cardsecretdomain.Secret{
+					ProductID: secret.ProductID,
+					SKUID:     secret.SKUID,
+					Secret:    strings.TrimSpace(secret.Secret),
+					Status:    cardsecretdomain.StatusUsed,
+					OrderID:   &recordOrderID,
+					UsedAt:    &usedAt,
+					CreatedAt: now,
+					UpdatedAt: now,
+				})
+			}
+			if err := tx.CardSecrets().CreateBatch(records); err != nil {
+				return ErrFulfillmentCreateFailed
+			}
+		}
+		fulfillment := &fulfillmentdomain.Fulfillment{
+			OrderID:       orderID,
+			Type:          fulfillmentType,
+			Status:        constants.FulfillmentStatusDelivered,
+			Payload:       payload,
+			LogisticsJSON: deliveryData,
+			DeliveredAt:   &now,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		}
+		if err := tx.Fulfillments().Create(fulfillment); err != nil {
+			return ErrFulfillmentCreateFailed
+		}
+		if err := tx.Orders().UpdateFields(orderID, map[string]interface{}{
+			"status":     constants.OrderStatusCompleted,
+			"updated_at": now,
+		}); err != nil {
+			return ErrOrderUpdateFailed
+		}
+		created = fulfillment
+		return nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrFulfillmentExists):
+			return nil, ErrFulfillmentExists
+		case errors.Is(err, ErrOrderUpdateFailed):
+			return nil, ErrOrderUpdateFailed
+		default:
+			return nil, ErrFulfillmentCreateFailed
+		}
+	}
+	s.afterFulfilled(order, constants.OrderStatusCompleted, now)
+	return created, nil
+}
+
+// afterFulfilled 交付完成后的收尾：同步父订单状态、状态邮件、Telegram 通知与下游回调。
+func (s *Service) afterFulfilled(order *orderdomain.Order, targetStatus string, now time.Time) {
 	if s.orderQueue != nil {
 		if order.ParentID != nil {
 			status, syncErr := orderapp.SyncParentStatus(s.orderStore, *order.ParentID, now)
@@ -343,12 +438,12 @@ func (s *Service) CreateAuto(orderID uint) (*fulfillmentdomain.Fulfillment, erro
 				logger.Warnw("fulfillment_sync_parent_status_failed",
 					"order_id", order.ID,
 					"parent_order_id", *order.ParentID,
-					"target_status", constants.OrderStatusCompleted,
+					"target_status", targetStatus,
 					"error", syncErr,
 				)
 			} else {
 				if status == "" {
-					status = constants.OrderStatusCompleted
+					status = targetStatus
 				}
 				if status != constants.OrderStatusCanceled {
 					if _, err := orderapp.EnqueueStatusEmailTaskIfEligible(s.orderStore, s.orderQueue, s.settingService, s.defaultEmailConfig, *order.ParentID, status); err != nil {
@@ -362,27 +457,26 @@ func (s *Service) CreateAuto(orderID uint) (*fulfillmentdomain.Fulfillment, erro
 				}
 			}
 		} else {
-			if _, err := orderapp.EnqueueStatusEmailTaskIfEligible(s.orderStore, s.orderQueue, s.settingService, s.defaultEmailConfig, orderID, constants.OrderStatusCompleted); err != nil {
+			if _, err := orderapp.EnqueueStatusEmailTaskIfEligible(s.orderStore, s.orderQueue, s.settingService, s.defaultEmailConfig, order.ID, targetStatus); err != nil {
 				logger.Warnw("fulfillment_enqueue_status_email_failed",
 					"order_id", order.ID,
-					"target_order_id", orderID,
-					"status", constants.OrderStatusCompleted,
+					"target_order_id", order.ID,
+					"status", targetStatus,
 					"error", err,
 				)
 			}
 		}
 	}
 	// Telegram 通知：交付完成后推送给用户
-	notifyOrderID := orderID
+	notifyOrderID := order.ID
 	if order.ParentID != nil {
 		notifyOrderID = *order.ParentID
 	}
 	go s.NotifyBotOrderFulfilled(order.UserID, notifyOrderID)
-	// B 侧：自动交付完成后触发下游回调
+	// B 侧：交付完成后触发下游回调
 	if s.downstreamCallbackSvc != nil {
-		s.downstreamCallbackSvc.EnqueueCallback(orderID)
+		s.downstreamCallbackSvc.EnqueueCallback(order.ID)
 	}
-	return fulfillment, nil
 }
 
 // NotifyBotOrderFulfilled 查找用户 Telegram 绑定并入队通知任务。
@@ -0,0 +1,85 @@
+package application
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	filescontract "github.com/dujiao-next/internal/modules/fulfillment/files/contract"
+	filesdomain "github.com/dujiao-next/internal/modules/fulfillment/files/domain"
+	uploadcontract "github.com/dujiao-next/internal/modules/upload/contract"
+
+	"github.com/google/uuid"
+)
+
+// ListAssets 列出商品的交付文件。
+func (s *Service) ListAssets(productID uint) ([]filesdomain.Asset, error) {
+	if productID == 0 {
+		return nil, filescontract.ErrAssetInvalid
+	}
+	return s.assets.ListByProduct(productID)
+}
+
+// UploadAsset 写入私有存储并登记交付文件。
+func (s *Service) UploadAsset(input filescontract.AssetUploadInput) (*filesdomain.Asset, error) {
+	filename := strings.TrimSpace(filepath.Base(strings.ReplaceAll(input.Filename, "\\", "/")))
+	if input.ProductID == 0 || input.Source == nil || filename == "" || filename == "." || filename == "/" {
+		return nil, filescontract.ErrAssetInvalid
+	}
+	if len([]rune(filename)) > 255 {
+		return nil, filescontract.ErrAssetInvalid
+	}
+	if input.Size <= 0 {
+		return nil, filescontract.ErrAssetInvalid
+	}
+	if input.Size > filesdomain.MaxAssetSize {
+		return nil, filescontract.ErrAssetTooLarge
+	}
+	if input.MaxDownloads < 0 || input.MaxDownloads > filesdomain.MaxDownloadsLimit ||
+		input.ValidDays < 0 || input.ValidDays > filesdomain.ValidDaysLimit {
+		return nil, filescontract.ErrAssetInvalid
+	}
+
+	now := s.now()
+	storedName := uuid.New().String() + strings.ToLower(filepath.Ext(filename))
+	year := now.Format("2006")
+	month := now.Format("01")
+	if _, err := s.storage.Save(uploadcontract.StoreInput{
+		Source:   input.Source,
+		Scene:    filesdomain.StorageScene,
+		Year:     year,
+		Month:    month,
+		Filename: storedName,
+	}); err != nil {
+		return nil, err
+	}
+	asset := &filesdomain.Asset{
+		ProductID:    input.ProductID,
+		SKUID:        input.SKUID,
+		Filename:     filename,
+		StorageKey:   fmt.Sprintf("%s/%s/%s/%s", filesdomain.StorageScene, year, month, storedName),
+		MimeType:     strings.TrimSpace(input.MimeType),
+		Size:         input.Size,
+		MaxDownloads: input.MaxDownloads,
+		ValidDays:    input.ValidDays,
+		SortOrder:    input.SortOrder,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if err := s.assets.Create(asset); err != nil {
+		return nil, err
+	}
+	return asset, nil
+}
+
+// DeleteAsset 下架交付文件；已发放的授权仍可在有效期内下载。
+func (s *Service) DeleteAsset(productID, assetID uint) error {
+	asset, err := s.assets.GetByID(assetID)
+	if err != nil {
+		return err
+	}
+	if asset == nil || asset.DeletedAt != nil || asset.ProductID != productID {
+		return filescontract.ErrAssetNotFound
+	}
+	return s.assets.SoftDelete(asset.ID, s.now())
+}
@@ -0,0 +1,217 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dujiao-next/internal/constants"
+	"github.com/dujiao-next/internal/logger"
+	filescontract "github.com/dujiao-next/internal/modules/fulfillment/files/contract"
+	filesdomain "github.com/dujiao-next/internal/modules/fulfillment/files/domain"
+)
+
+const (
+	// linkTTL 签名下载链接有效期，授权本身的有效期由文件配置决定
+	linkTTL          = 15 * time.Minute
+	downloadPath     = "/api/v1/fulfillment/files/%d/download?expires=%d&sig=%s"
+	maxOrderLogs     = 200
+	maxUserAgentSize = 255
+)
+
+// ListLinks 为订单（含子订单）的下载授权签发短期链接。
+func (s *Service) ListLinks(orderIDs []uint) ([]filescontract.DownloadLink, error) {
+	if len(orderIDs) == 0 {
+		return []filescontract.DownloadLink{}, nil
+	}
+	grants, err := s.grants.ListByOrderIDs(orderIDs)
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	linkExpires := now.Add(linkTTL)
+	links := make([]filescontract.DownloadLink, 0, len(grants))
+	for i := range grants {
+		grant := &grants[i]
+		link := filescontract.DownloadLink{
+			GrantID:            grant.ID,
+			OrderID:            grant.OrderID,
+			Filename:           grant.Filename,
+			ExpiresAt:          grant.ExpiresAt,
+			MaxDownloads:       grant.MaxDownloads,
+			RemainingDownloads: grant.RemainingDownloads(),
+			Revoked:            grant.RevokedAt != nil,
+		}
+		if asset, err := s.assets.GetByID(grant.AssetID); err == nil && asset != nil {
+			link.Size = asset.Size
+		}
+		if grant.IsUsable(now) && link.RemainingDownloads > 0 {
+			link.URL = fmt.Sprintf(downloadPath, grant.ID, linkExpires.Unix(), filesdomain.SignLink(s.signingSecret, grant.ID, linkExpires.Unix()))
+			link.LinkExpiresAt = &linkExpires
+		}
+		links = append(links, link)
+	}
+	return links, nil
+}
+
+// ListOrderActivity 管理端查看订单的下载授权与下载记录。
+func (s *Service) ListOrderActivity(orderIDs []uint) ([]filesdomain.Grant, []filesdomain.DownloadLog, error) {
+	if len(orderIDs) == 0 {
+		return []filesdomain.Grant{}, []filesdomain.DownloadLog{}, nil
+	}
+	grants, err := s.grants.ListByOrderIDs(orderIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	logs, err := s.logs.ListByOrderIDs(orderIDs, maxOrderLogs)
+	if err != nil {
+		return nil, nil, err
+	}
+	return grants, logs, nil
+}
+
+// Download 校验签名链接与授权状态后打开文件。
+// 仅紧接在一次计数下载之后（链接有效期内）的续传 Range 不重复计数，其余请求均计入次数。
+func (s *Service) Download(request filescontract.DownloadRequest) (*filescontract.DownloadFile, error) {
+	now := s.now()
+	grant, err := s.grants.GetByID(request.GrantID)
+	if err != nil {
+		return nil, err
+	}
+	if grant == nil {
+		return nil, filescontract.ErrGrantNotFound
+	}
+	entry := &filesdomain.DownloadLog{
+		GrantID:   grant.ID,
+		OrderID:   grant.OrderID,
+		Range:     truncate(strings.TrimSpace(request.Range), 100),
+		ClientIP:  truncate(strings.TrimSpace(request.ClientIP), 64),
+		UserAgent: truncate(strings.TrimSpace(request.UserAgent), maxUserAgentSize),
+		CreatedAt: now,
+	}
+	file, err := s.authorizeDownload(grant, request, entry, now)
+	if err != nil {
+		s.recordLog(entry, downloadResult(err))
+		return nil, err
+	}
+	s.recordLog(entry, filesdomain.DownloadResultSuccess)
+	return file, nil
+}
+
+func (s *Service) authorizeDownload(grant *filesdomain.Grant, request filescontract.DownloadRequest, entry *filesdomain.DownloadLog, now time.Time) (*filescontract.DownloadFile, error) {
+	if !filesdomain.VerifyLink(s.signingSecret, strings.TrimSpace(request.Signature), grant.ID, request.Expires) {
+		return nil, filescontract.ErrLinkInvalid
+	}
+	if now.Unix() > request.Expires {
+		return nil, filescontract.ErrLinkExpired
+	}
+	if grant.RevokedAt == nil {
+		// 管理员直接把订单改为已退款时不会经过退款服务，下载前补做吊销
+		order, err := s.orders.GetByID(grant.OrderID)
+		if err != nil {
+			return nil, err
+		}
+		if order != nil && order.Status == constants.OrderStatusRefunded {
+			if err := s.RevokeForOrder(grant.OrderID); err != nil {
+				return nil, err
+			}
+			return nil, filescontract.ErrGrantRevoked
+		}
+	}
+	if grant.RevokedAt != nil {
+		return nil, filescontract.ErrGrantRevoked
+	}
+	if !now.Before(grant.ExpiresAt) {
+		return nil, filescontract.ErrGrantExpired
+	}
+	asset, err := s.assets.GetByID(grant.AssetID)
+	if err != nil {
+		return nil, err
+	}
+	if asset == nil {
+		return nil, filescontract.ErrFileMissing
+	}
+
+	resume := isResumeRange(request.Range)
+	if resume && grant.DownloadCount == 0 {
+		// 续传必须建立在一次已计数的下载之上
+		return nil, filescontract.ErrDownloadsExhausted
+	}
+	if !resume || !resumesRecentDownload(grant, now) {
+		counted, err := s.grants.IncrementDownloadCount(grant.ID, now)
+		if err != nil {
+			return nil, err
+		}
+		if !counted {
+			return nil, filescontract.ErrDownloadsExhausted
+		}
+		entry.Counted = true
+	}
+
+	content, err := s.storage.Open(asset.StorageKey)
+	if err != nil {
+		logger.Warnw("fulfillment_file_open_failed", "grant_id", grant.ID, "asset_id", asset.ID, "error", err)
+		return nil, filescontract.ErrFileMissing
+	}
+	return &filescontract.DownloadFile{
+		Filename: asset.Filename,
+		MimeType: asset.MimeType,
+		ModTime:  asset.CreatedAt,
+		Content:  content,
+	}, nil
+}
+
+func (s *Service) recordLog(entry *filesdomain.DownloadLog, result string) {
+	entry.Result = result
+	if err := s.logs.Create(entry); err != nil {
+		logger.Warnw("fulfillment_file_download_log_failed", "grant_id", entry.GrantID, "error", err)
+	}
+}
+
+// isResumeRange 判断是否为从中间偏移继续的单段 Range；
+// 后缀 Range（bytes=-N）、多段 Range 或从 0 开始的请求可能覆盖整个文件，不视为续传。
+func isResumeRange(rangeHeader string) bool {
+	value := strings.ReplaceAll(strings.TrimSpace(rangeHeader), " ", "")
+	spec, ok := strings.CutPrefix(value, "bytes=")
+	if !ok || strings.Contains(spec, ",") {
+		return false
+	}
+	start, _, ok := strings.Cut(spec, "-")
+	if !ok || start == "" {
+		return false
+	}
+	offset, err := strconv.ParseInt(start, 10, 64)
+	return err == nil && offset > 0
+}
+
+// resumesRecentDownload 续传须在最近一次计数下载后的链接有效期内。
+func resumesRecentDownload(grant *filesdomain.Grant, now time.Time) bool {
+	return grant.LastCountedAt != nil && !now.After(grant.LastCountedAt.Add(linkTTL))
+}
+
+func downloadResult(err error) string {
+	switch {
+	case errors.Is(err, filescontract.ErrLinkInvalid):
+		return filesdomain.DownloadResultInvalidSignature
+	case errors.Is(err, filescontract.ErrLinkExpired):
+		return filesdomain.DownloadResultLinkExpired
+	case errors.Is(err, filescontract.ErrGrantExpired):
+		return filesdomain.DownloadResultGrantExpired
+	case errors.Is(err, filescontract.ErrGrantRevoked):
+		return filesdomain.DownloadResultRevoked
+	case errors.Is(err, filescontract.ErrDownloadsExhausted):
+		return filesdomain.DownloadResultExhausted
+	default:
+		return filesdomain.DownloadResultFileMissing
+	}
+}
+
+func truncate(value string, limit int) string {
+	runes := []rune(value)
+	if len(runes) <= limit {
+		return value
+	}
+	return string(runes[:limit])
+}
@@ -0,0 +1,222 @@
+package application
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/dujiao-next/internal/constants"
+	"github.com/dujiao-next/internal/logger"
+	filescontract "github.com/dujiao-next/internal/modules/fulfillment/files/contract"
+	filesdomain "github.com/dujiao-next/internal/modules/fulfillment/files/domain"
+	"github.com/dujiao-next/internal/shared/jsonmap"
+)
+
+// Options 声明文件交付应用服务的全部端口。
+type Options struct {
+	Assets    filescontract.AssetStore
+	Grants    filescontract.GrantStore
+	Logs      filescontract.DownloadLogStore
+	Storage   filescontract.Storage
+	Orders    filescontract.OrderReader
+	Completer filescontract.Completer
+	Notifier  filescontract.FallbackNotifier
+	// SigningSecret 下载链接签名密钥
+	SigningSecret string
+	Now           func() time.Time
+}
+
+// Service 管理交付文件、下载授权与签名下载。
+type Service struct {
+	assets        filescontract.AssetStore
+	grants        filescontract.GrantStore
+	logs          filescontract.DownloadLogStore
+	storage       filescontract.Storage
+	orders        filescontract.OrderReader
+	completer     filescontract.Completer
+	notifier      filescontract.FallbackNotifier
+	signingSecret string
+	now           func() time.Time
+}
+
+// NewService 创建文件交付应用服务。
+func NewService(options Options) *Service {
+	if options.Assets == nil {
+		panic("file fulfillment service: assets are nil")
+	}
+	if options.Grants == nil {
+		panic("file fulfillment service: grants are nil")
+	}
+	if options.Logs == nil {
+		panic("file fulfillment service: logs are nil")
+	}
+	if options.Storage == nil {
+		panic("file fulfillment service: storage is nil")
+	}
+	if options.Orders == nil {
+		panic("file fulfillment service: orders are nil")
+	}
+	if options.Completer == nil {
+		panic("file fulfillment service: completer is nil")
+	}
+	if strings.TrimSpace(options.SigningSecret) == "" {
+		panic("file fulfillment service: signing secret is empty")
+	}
+	if options.Now == nil {
+		options.Now = time.Now
+	}
+	return &Service{
+		assets:        options.Assets,
+		grants:        options.Grants,
+		logs:          options.Logs,
+		storage:       options.Storage,
+		orders:        options.Orders,
+		completer:     options.Completer,
+		notifier:      options.Notifier,
+		signingSecret: options.SigningSecret,
+		now:           options.Now,
+	}
+}
+
+// DeliverForOrder 为已支付的文件订单发放下载授权并完成交付，重复调用幂等。
+// 商品未配置交付文件时订单保持交付中，并提醒管理员人工交付。
+func (s *Service) DeliverForOrder(orderID uint) error {
+	order, err := s.orders.GetByID(orderID)
+	if err != nil {
+		return err
+	}
+	if order == nil {
+		return filescontract.ErrOrderNotFound
+	}
+	if !hasFileItems(order) {
+		return filescontract.ErrNotFileOrder
+	}
+	if order.Status != constants.OrderStatusPaid && order.Status != constants.OrderStatusFulfilling {
+		return nil
+	}
+
+	grants, err := s.grants.ListByOrderIDs([]uint{order.ID})
+	if err != nil {
+		return err
+	}
+	if len(grants) == 0 {
+		grants, err = s.buildGrants(order)
+		if err != nil {
+			return err
+		}
+		if len(grants) == 0 {
+			logger.Warnw("fulfillment_file_assets_missing", "order_id", order.ID)
+			if s.notifier != nil {
+				if err := s.notifier.NotifyManualFulfillmentPending(order.ID); err != nil {
+					logger.Warnw("fulfillment_file_notify_fallback_failed", "order_id", order.ID, "error", err)
+				}
+			}
+			return nil
+		}
+		if err := s.grants.CreateBatch(grants); err != nil {
+			return err
+		}
+	}
+
+	payload, deliveryData := buildDeliveryContent(grants)
+	if err := s.completer.CompleteFile(order.ID, payload, deliveryData); err != nil {
+		if errors.Is(err, filescontract.ErrAlreadyFulfilled) || errors.Is(err, filescontract.ErrOrderNotPending) {
+			return nil
+		}
+		return err
+	}
+	logger.Infow("fulfillment_file_delivered", "order_id", order.ID, "grants", len(grants))
+	return nil
+}
+
+// RevokeForOrder 退款时作废订单（含子订单）尚有效的下载授权，已签出的链接随之失效。
+func (s *Service) RevokeForOrder(orderID uint) error {
+	if orderID == 0 {
+		return nil
+	}
+	revoked, err := s.grants.RevokeByOrder(orderID, filesdomain.RevokeReasonRefunded, s.now())
+	if err != nil {
+		return err
+	}
+	if revoked > 0 {
+		logger.Infow("fulfillment_file_grants_revoked", "order_id", orderID, "count", revoked)
+	}
+	return nil
+}
+
+// buildGrants 为每个文件订单项匹配交付文件，任一订单项缺少文件时不发放授权。
+func (s *Service) buildGrants(order *filescontract.OrderSnapshot) ([]filesdomain.Grant, error) {
+	now := s.now()
+	rootOrderID := order.ID
+	if order.ParentID != nil && *order.ParentID > 0 {
+		rootOrderID = *order.ParentID
+	}
+	assetsByProduct := make(map[uint][]filesdomain.Asset)
+	seen := make(map[uint]struct{})
+	grants := make([]filesdomain.Grant, 0)
+	for _, item := range order.Items {
+		if strings.TrimSpace(item.FulfillmentType) != constants.FulfillmentTypeFile {
+			continue
+		}
+		assets, ok := assetsByProduct[item.ProductID]
+		if !ok {
+			listed, err := s.assets.ListByProduct(item.ProductID)
+			if err != nil {
+				return nil, err
+			}
+			assets = listed
+			assetsByProduct[item.ProductID] = assets
+		}
+		matched := 0
+		for i := range assets {
+			asset := &assets[i]
+			if !asset.AppliesTo(item.ProductID, item.SKUID) {
+				continue
+			}
+			matched++
+			if _, exists := seen[asset.ID]; exists {
+				continue
+			}
+			seen[asset.ID] = struct{}{}
+			grants = append(grants, filesdomain.Grant{
+				OrderID:      order.ID,
+				RootOrderID:  rootOrderID,
+				AssetID:      asset.ID,
+				Filename:     asset.Filename,
+				MaxDownloads: asset.EffectiveMaxDownloads(),
+				ExpiresAt:    now.AddDate(0, 0, asset.EffectiveValidDays()),
+				CreatedAt:    now,
+				UpdatedAt:    now,
+			})
+		}
+		if matched == 0 {
+			return nil, nil
+		}
+	}
+	return grants, nil
+}
+
+func hasFileItems(order *filescontract.OrderSnapshot) bool {
+	for _, item := range order.Items {
+		if strings.TrimSpace(item.FulfillmentType) == constants.FulfillmentTypeFile {
+			return true
+		}
+	}
+	return false
+}
+
+// buildDeliveryContent 交付内容只记录文件清单，下载链接需在订单详情中按需签发。
+func buildDeliveryContent(grants []filesdomain.Grant) (string, jsonmap.JSON) {
+	names := make([]string, 0, len(grants))
+	files := make([]interface{}, 0, len(grants))
+	for _, grant := range grants {
+		names = append(names, grant.Filename)
+		files = append(files, map[string]interface{}{
+			"grant_id":      grant.ID,
+			"filename":      grant.Filename,
+			"max_downloads": grant.MaxDownloads,
+			"expires_at":    grant.ExpiresAt.Format(time.RFC3339),
+		})
+	}
+	return strings.Join(names, "\n"), jsonmap.JSON{"files": files}
+}
@@ -0,0 +1,20 @@
+package contract
+
+import "errors"
+
+var (
+	ErrAssetNotFound      = errors.New("file asset not found")
+	ErrAssetInvalid       = errors.New("file asset invalid")
+	ErrAssetTooLarge      = errors.New("file asset too large")
+	ErrOrderNotFound      = errors.New("file order not found")
+	ErrNotFileOrder       = errors.New("order has no file item")
+	ErrAlreadyFulfilled   = errors.New("order already fulfilled")
+	ErrOrderNotPending    = errors.New("order not awaiting fulfillment")
+	ErrGrantNotFound      = errors.New("file grant not found")
+	ErrLinkInvalid        = errors.New("download link invalid")
+	ErrLinkExpired        = errors.New("download link expired")
+	ErrGrantExpired       = errors.New("file grant expired")
+	ErrGrantRevoked       = errors.New("file grant revoked")
+	ErrDownloadsExhausted = errors.New("file downloads exhausted")
+	ErrFileMissing        = errors.New("file content missing")
+)
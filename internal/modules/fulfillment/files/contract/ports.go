@@ -0,0 +1,57 @@
+package contract
+
+import (
+	"time"
+
+	filesdomain "github.com/dujiao-next/internal/modules/fulfillment/files/domain"
+	uploadcontract "github.com/dujiao-next/internal/modules/upload/contract"
+	"github.com/dujiao-next/internal/shared/jsonmap"
+)
+
+// AssetStore 持久化商品交付文件。
+type AssetStore interface {
+	// GetByID 包含已删除文件，已发放的授权仍可下载。
+	GetByID(id uint) (*filesdomain.Asset, error)
+	ListByProduct(productID uint) ([]filesdomain.Asset, error)
+	Create(asset *filesdomain.Asset) error
+	SoftDelete(id uint, at time.Time) error
+}
+
+// GrantStore 持久化订单下载授权。
+type GrantStore interface {
+	GetByID(id uint) (*filesdomain.Grant, error)
+	ListByOrderIDs(orderIDs []uint) ([]filesdomain.Grant, error)
+	CreateBatch(grants []filesdomain.Grant) error
+	// IncrementDownloadCount 仅在未达上限时计数，返回是否计数成功。
+	IncrementDownloadCount(id uint, at time.Time) (bool, error)
+	// RevokeByOrder 吊销订单（含以其为父订单的子订单）下全部未吊销授权。
+	RevokeByOrder(orderID uint, reason string, at time.Time) (int64, error)
+}
+
+// DownloadLogStore 记录下载尝试。
+type DownloadLogStore interface {
+	Create(log *filesdomain.DownloadLog) error
+	ListByOrderIDs(orderIDs []uint, limit int) ([]filesdomain.DownloadLog, error)
+}
+
+// Storage 写入与读取私有交付文件。
+type Storage interface {
+	uploadcontract.Store
+	uploadcontract.Opener
+}
+
+// OrderReader 返回文件交付所需的订单投影。
+type OrderReader interface {
+	GetByID(id uint) (*OrderSnapshot, error)
+}
+
+// Completer 写入文件交付记录并推进订单状态；
+// 订单已交付时返回 ErrAlreadyFulfilled，状态不允许交付时返回 ErrOrderNotPending。
+type Completer interface {
+	CompleteFile(orderID uint, payload string, deliveryData jsonmap.JSON) error
+}
+
+// FallbackNotifier 商品未配置交付文件时提醒管理员人工交付。
+type FallbackNotifier interface {
+	NotifyManualFulfillmentPending(orderID uint) error
+}
@@ -0,0 +1,66 @@
+package contract
+
+import (
+	"io"
+	"time"
+)
+
+// OrderItem 是文件交付关心的订单项投影。
+type OrderItem struct {
+	ProductID       uint
+	SKUID           uint
+	FulfillmentType string
+}
+
+// OrderSnapshot 是文件交付关心的订单投影。
+type OrderSnapshot struct {
+	ID       uint
+	ParentID *uint
+	Status   string
+	Items    []OrderItem
+}
+
+// AssetUploadInput 管理端上传交付文件。
+type AssetUploadInput struct {
+	ProductID    uint
+	SKUID        uint
+	Filename     string
+	MimeType     string
+	Size         int64
+	Source       io.Reader
+	MaxDownloads int
+	ValidDays    int
+	SortOrder    int
+}
+
+// DownloadLink 买家可见的下载授权与签名链接；授权不可用时 URL 为空。
+type DownloadLink struct {
+	GrantID            uint       `json:"grant_id"`
+	OrderID            uint       `json:"order_id"`
+	Filename           string     `json:"filename"`
+	Size               int64      `json:"size"`
+	URL                string     `json:"url,omitempty"`
+	LinkExpiresAt      *time.Time `json:"link_expires_at,omitempty"`
+	ExpiresAt          time.Time  `json:"expires_at"`
+	MaxDownloads       int        `json:"max_downloads"`
+	RemainingDownloads int        `json:"remaining_downloads"`
+	Revoked            bool       `json:"revoked"`
+}
+
+// DownloadRequest 是一次签名下载请求。
+type DownloadRequest struct {
+	GrantID   uint
+	Expires   int64
+	Signature string
+	Range     string
+	ClientIP  string
+	UserAgent string
+}
+
+// DownloadFile 校验通过后返回的文件内容，调用方负责关闭 Content。
+type DownloadFile struct {
+	Filename string
+	MimeType string
+	ModTime  time.Time
+	Content  io.ReadSeekCloser
+}
@@ -0,0 +1,61 @@
+package domain
+
+import "time"
+
+const (
+	// DefaultMaxDownloads 单个下载授权默认可下载次数
+	DefaultMaxDownloads = 5
+	// MaxDownloadsLimit 下载次数配置上限
+	MaxDownloadsLimit = 100
+	// DefaultValidDays 下载授权默认有效天数
+	DefaultValidDays = 7
+	// ValidDaysLimit 有效天数配置上限
+	ValidDaysLimit = 365
+	// MaxAssetSize 交付文件大小上限（字节）
+	MaxAssetSize = 1 << 30
+	// StorageScene 交付文件在私有存储中的目录
+	StorageScene = "fulfillment"
+)
+
+// Asset 商品或 SKU 绑定的交付文件；SKUID 为 0 时对商品全部 SKU 生效。
+type Asset struct {
+	ID           uint       `gorm:"primarykey" json:"id"`
+	ProductID    uint       `gorm:"index;not null" json:"product_id"`
+	SKUID        uint       `gorm:"column:sku_id;index;not null;default:0" json:"sku_id"`
+	Filename     string     `gorm:"type:varchar(255);not null" json:"filename"`
+	StorageKey   string     `gorm:"type:varchar(255);not null" json:"-"`
+	MimeType     string     `gorm:"type:varchar(120)" json:"mime_type"`
+	Size         int64      `gorm:"not null;default:0" json:"size"`
+	MaxDownloads int        `gorm:"not null;default:0" json:"max_downloads"`
+	ValidDays    int        `gorm:"not null;default:0" json:"valid_days"`
+	SortOrder    int        `gorm:"not null;default:0" json:"sort_order"`
+	CreatedAt    time.Time  `gorm:"index" json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+	DeletedAt    *time.Time `gorm:"index" json:"-"`
+}
+
+// TableName 指定表名
+func (Asset) TableName() string {
+	return "fulfillment_file_assets"
+}
+
+// AppliesTo 判断文件是否适用于指定 SKU。
+func (a *Asset) AppliesTo(productID, skuID uint) bool {
+	return a.ProductID == productID && (a.SKUID == 0 || a.SKUID == skuID)
+}
+
+// EffectiveMaxDownloads 返回生效的下载次数上限。
+func (a *Asset) EffectiveMaxDownloads() int {
+	if a.MaxDownloads <= 0 {
+		return DefaultMaxDownloads
+	}
+	return a.MaxDownloads
+}
+
+// EffectiveValidDays 返回生效的授权有效天数。
+func (a *Asset) EffectiveValidDays() int {
+	if a.ValidDays <= 0 {
+		return DefaultValidDays
+	}
+	return a.ValidDays
+}
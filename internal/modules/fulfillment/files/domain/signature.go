@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+)
+
+// SignLink 生成下载链接签名
+// signString = "{grant_id}.{expires}"
+func SignLink(secret string, grantID uint, expires int64) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatUint(uint64(grantID), 10)))
+	mac.Write([]byte("."))
+	mac.Write([]byte(strconv.FormatInt(expires, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyLink 验证下载链接签名
+func VerifyLink(secret, signature string, grantID uint, expires int64) bool {
+	if secret == "" || signature == "" {
+		return false
+	}
+	return hmac.Equal([]byte(SignLink(secret, grantID, expires)), []byte(signature))
+}
@@ -0,0 +1,43 @@
+package fulfillmentadapter
+
+import (
+	"errors"
+
+	fulfillmentapp "github.com/dujiao-next/internal/modules/fulfillment/application"
+	fulfillmentdomain "github.com/dujiao-next/internal/modules/fulfillment/domain"
+	filescontract "github.com/dujiao-next/internal/modules/fulfillment/files/contract"
+	"github.com/dujiao-next/internal/shared/jsonmap"
+)
+
+// Creator 是交付上下文写入文件交付的最小端口。
+type Creator interface {
+	CreateFile(orderID uint, payload string, deliveryData jsonmap.JSON) (*fulfillmentdomain.Fulfillment, error)
+}
+
+// Adapter 将交付用例错误映射为文件交付合同错误。
+type Adapter struct {
+	creator Creator
+}
+
+var _ filescontract.Completer = (*Adapter)(nil)
+
+func New(creator Creator) *Adapter {
+	if creator == nil {
+		panic("file fulfillment completer: creator is nil")
+	}
+	return &Adapter{creator: creator}
+}
+
+func (a *Adapter) CompleteFile(orderID uint, payload string, deliveryData jsonmap.JSON) error {
+	_, err := a.creator.CreateFile(orderID, payload, deliveryData)
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, fulfillmentapp.ErrFulfillmentExists):
+		return filescontract.ErrAlreadyFulfilled
+	case errors.Is(err, fulfillmentapp.ErrOrderStatusInvalid):
+		return filescontract.ErrOrderNotPending
+	default:
+		return err
+	}
+}
@@ -0,0 +1,63 @@
+package gormstore
+
+import (
+	"errors"
+	"time"
+
+	filescontract "github.com/dujiao-next/internal/modules/fulfillment/files/contract"
+	filesdomain "github.com/dujiao-next/internal/modules/fulfillment/files/domain"
+
+	"gorm.io/gorm"
+)
+
+// AssetStore 是交付文件的 GORM 仓储。
+type AssetStore struct {
+	db *gorm.DB
+}
+
+var _ filescontract.AssetStore = (*AssetStore)(nil)
+
+// NewAssetStore 创建交付文件仓储。
+func NewAssetStore(db *gorm.DB) *AssetStore {
+	if db == nil {
+		panic("file asset store: db is nil")
+	}
+	return &AssetStore{db: db}
+}
+
+// GetByID 包含已下架文件，保证已发放的授权仍可下载。
+func (s *AssetStore) GetByID(id uint) (*filesdomain.Asset, error) {
+	if id == 0 {
+		return nil, nil
+	}
+	var asset filesdomain.Asset
+	if err := s.db.Where("id = ?", id).First(&asset).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &asset, nil
+}
+
+func (s *AssetStore) ListByProduct(productID uint) ([]filesdomain.Asset, error) {
+	var assets []filesdomain.Asset
+	if err := s.db.Where("product_id = ? AND deleted_at IS NULL", productID).
+		Order("sort_order DESC, id ASC").Find(&assets).Error; err != nil {
+		return nil, err
+	}
+	return assets, nil
+}
+
+func (s *AssetStore) Create(asset *filesdomain.Asset) error {
+	if asset == nil {
+		return errors.New("file asset is nil")
+	}
+	return s.db.Create(asset).Error
+}
+
+func (s *AssetStore) SoftDelete(id uint, at time.Time) error {
+	return s.db.Model(&filesdomain.Asset{}).
+		Where("id = ? AND deleted_at IS NULL", id).
+		Updates(map[string]interface{}{"deleted_at": at, "updated_at": at}).Error
+}
@@ -0,0 +1,47 @@
+package gormstore
+
+import (
+	"errors"
+
+	filescontract "github.com/dujiao-next/internal/modules/fulfillment/files/contract"
+	filesdomain "github.com/dujiao-next/internal/modules/fulfillment/files/domain"
+
+	"gorm.io/gorm"
+)
+
+// DownloadLogStore 是下载记录的 GORM 仓储。
+type DownloadLogStore struct {
+	db *gorm.DB
+}
+
+var _ filescontract.DownloadLogStore = (*DownloadLogStore)(nil)
+
+// NewDownloadLogStore 创建下载记录仓储。
+func NewDownloadLogStore(db *gorm.DB) *DownloadLogStore {
+	if db == nil {
+		panic("file download log store: db is nil")
+	}
+	return &DownloadLogStore{db: db}
+}
+
+func (s *DownloadLogStore) Create(log *filesdomain.DownloadLog) error {
+	if log == nil {
+		return errors.New("file download log is nil")
+	}
+	return s.db.Create(log).Error
+}
+
+func (s *DownloadLogStore) ListByOrderIDs(orderIDs []uint, limit int) ([]filesdomain.DownloadLog, error) {
+	if len(orderIDs) == 0 {
+		return []filesdomain.DownloadLog{}, nil
+	}
+	var logs []filesdomain.DownloadLog
+	query := s.db.Where("order_id IN ?", orderIDs).Order("id DESC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if err := query.Find(&logs).Error; err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
@@ -0,0 +1,85 @@
+package gormstore
+
+import (
+	"errors"
+	"time"
+
+	filescontract "github.com/dujiao-next/internal/modules/fulfillment/files/contract"
+	filesdomain "github.com/dujiao-next/internal/modules/fulfillment/files/domain"
+
+	"gorm.io/gorm"
+)
+
+// GrantStore 是下载授权的 GORM 仓储。
+type GrantStore struct {
+	db *gorm.DB
+}
+
+var _ filescontract.GrantStore = (*GrantStore)(nil)
+
+// NewGrantStore 创建下载授权仓储。
+func NewGrantStore(db *gorm.DB) *GrantStore {
+	if db == nil {
+		panic("file grant store: db is nil")
+	}
+	return &GrantStore{db: db}
+}
+
+func (s *GrantStore) GetByID(id uint) (*filesdomain.Grant, error) {
+	if id == 0 {
+		return nil, nil
+	}
+	var grant filesdomain.Grant
+	if err := s.db.Where("id = ?", id).First(&grant).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &grant, nil
+}
+
+func (s *GrantStore) ListByOrderIDs(orderIDs []uint) ([]filesdomain.Grant, error) {
+	if len(orderIDs) == 0 {
+		return []filesdomain.Grant{}, nil
+	}
+	var grants []filesdomain.Grant
+	if err := s.db.Where("order_id IN ?", orderIDs).Order("order_id ASC, id ASC").Find(&grants).Error; err != nil {
+		return nil, err
+	}
+	return grants, nil
+}
+
+func (s *GrantStore) CreateBatch(grants []filesdomain.Grant) error {
+	if len(grants) == 0 {
+		return nil
+	}
+	return s.db.Create(&grants).Error
+}
+
+// IncrementDownloadCount 条件更新计数并记录计数时间，并发下载不会超过次数上限。
+func (s *GrantStore) IncrementDownloadCount(id uint, at time.Time) (bool, error) {
+	result := s.db.Model(&filesdomain.Grant{}).
+		Where("id = ? AND revoked_at IS NULL AND download_count < max_downloads", id).
+		Updates(map[string]interface{}{
+			"download_count":  gorm.Expr("download_count + 1"),
+			"last_counted_at": at,
+			"updated_at":      at,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// RevokeByOrder 按订单或父订单吊销全部未吊销授权。
+func (s *GrantStore) RevokeByOrder(orderID uint, reason string, at time.Time) (int64, error) {
+	result := s.db.Model(&filesdomain.Grant{}).
+		Where("(order_id = ? OR root_order_id = ?) AND revoked_at IS NULL", orderID, orderID).
+		Updates(map[string]interface{}{
+			"revoked_at":    at,
+			"revoke_reason": reason,
+			"updated_at":    at,
+		})
+	return result.RowsAffected, result.Error
+}
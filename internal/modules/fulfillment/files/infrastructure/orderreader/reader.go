@@ -0,0 +1,46 @@
+package orderreader
+
+import (
+	filescontract "github.com/dujiao-next/internal/modules/fulfillment/files/contract"
+	orderdomain "github.com/dujiao-next/internal/modules/order/domain"
+)
+
+// Source 是订单上下文暴露给防腐适配器的最小读取端口。
+type Source interface {
+	GetByID(id uint) (*orderdomain.Order, error)
+}
+
+// Reader 将订单持久化模型投影为文件交付读模型。
+type Reader struct {
+	source Source
+}
+
+var _ filescontract.OrderReader = (*Reader)(nil)
+
+func New(source Source) *Reader {
+	if source == nil {
+		panic("file fulfillment order reader: source is nil")
+	}
+	return &Reader{source: source}
+}
+
+func (r *Reader) GetByID(id uint) (*filescontract.OrderSnapshot, error) {
+	order, err := r.source.GetByID(id)
+	if err != nil || order == nil {
+		return nil, err
+	}
+	snapshot := &filescontract.OrderSnapshot{
+		ID:       order.ID,
+		ParentID: order.ParentID,
+		Status:   order.Status,
+		Items:    make([]filescontract.OrderItem, 0, len(order.Items)),
+	}
+	for _, item := range order.Items {
+		snapshot.Items = append(snapshot.Items, filescontract.OrderItem{
+			ProductID:       item.ProductID,
+			SKUID:           item.SKUID,
+			FulfillmentType: item.FulfillmentType,
+		})
+	}
+	return snapshot, nil
+}
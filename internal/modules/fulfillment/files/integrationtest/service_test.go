@@ -0,0 +1,380 @@
+package integrationtest
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dujiao-next/internal/constants"
+	filesapp "github.com/dujiao-next/internal/modules/fulfillment/files/application"
+	filescontract "github.com/dujiao-next/internal/modules/fulfillment/files/contract"
+	filesdomain "github.com/dujiao-next/internal/modules/fulfillment/files/domain"
+	filesgormstore "github.com/dujiao-next/internal/modules/fulfillment/files/infrastructure/gormstore"
+	uploadlocal "github.com/dujiao-next/internal/modules/upload/infrastructure/localstore"
+	"github.com/dujiao-next/internal/shared/jsonmap"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+)
+
+const testSecret = "file-delivery-secret"
+
+type orderReaderStub struct {
+	orders map[uint]*filescontract.OrderSnapshot
+}
+
+func (r *orderReaderStub) GetByID(id uint) (*filescontract.OrderSnapshot, error) {
+	return r.orders[id], nil
+}
+
+type completerStub struct {
+	orders  *orderReaderStub
+	payload string
+	calls   int
+}
+
+func (c *completerStub) CompleteFile(orderID uint, payload string, _ jsonmap.JSON) error {
+	order := c.orders.orders[orderID]
+	if order.Status == constants.OrderStatusCompleted {
+		return filescontract.ErrAlreadyFulfilled
+	}
+	c.calls++
+	c.payload = payload
+	order.Status = constants.OrderStatusCompleted
+	return nil
+}
+
+type notifierStub struct {
+	orderIDs []uint
+}
+
+func (n *notifierStub) NotifyManualFulfillmentPending(orderID uint) error {
+	n.orderIDs = append(n.orderIDs, orderID)
+	return nil
+}
+
+type fixture struct {
+	service   *filesapp.Service
+	db        *gorm.DB
+	orders    *orderReaderStub
+	completer *completerStub
+	notifier  *notifierStub
+	now       time.Time
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+	dsn := fmt.Sprintf("file:fulfillment_files_%d?mode=memory&cache=shared", time.Now().UnixNano())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite failed: %v", err)
+	}
+	if err := db.AutoMigrate(&filesdomain.Asset{}, &filesdomain.Grant{}, &filesdomain.DownloadLog{}); err != nil {
+		t.Fatalf("auto migrate failed: %v", err)
+	}
+	f := &fixture{
+		db:       db,
+		orders:   &orderReaderStub{orders: map[uint]*filescontract.OrderSnapshot{}},
+		notifier: &notifierStub{},
+		now:      time.Now().UTC().Truncate(time.Second),
+	}
+	f.completer = &completerStub{orders: f.orders}
+	f.service = filesapp.NewService(filesapp.Options{
+		Assets:        filesgormstore.NewAssetStore(db),
+		Grants:        filesgormstore.NewGrantStore(db),
+		Logs:          filesgormstore.NewDownloadLogStore(db),
+		Storage:       uploadlocal.New(t.TempDir()),
+		Orders:        f.orders,
+		Completer:     f.completer,
+		Notifier:      f.notifier,
+		SigningSecret: testSecret,
+		Now:           func() time.Time { return f.now },
+	})
+	return f
+}
+
+func (f *fixture) upload(t *testing.T, productID, skuID uint, filename, content string, maxDownloads int) *filesdomain.Asset {
+	t.Helper()
+	asset, err := f.service.UploadAsset(filescontract.AssetUploadInput{
+		ProductID:    productID,
+		SKUID:        skuID,
+		Filename:     filename,
+		MimeType:     "application/octet-stream",
+		Size:         int64(len(content)),
+		Source:       strings.NewReader(content),
+		MaxDownloads: maxDownloads,
+	})
+	if err != nil {
+		t.Fatalf("upload asset failed: %v", err)
+	}
+	return asset
+}
+
+func (f *fixture) paidOrder(id uint, parentID *uint, productID, skuID uint) *filescontract.OrderSnapshot {
+	order := &filescontract.OrderSnapshot{
+		ID:       id,
+		ParentID: parentID,
+		Status:   constants.OrderStatusPaid,
+		Items: []filescontract.OrderItem{
+			{ProductID: productID, SKUID: skuID, FulfillmentType: constants.FulfillmentTypeFile},
+		},
+	}
+	f.orders.orders[id] = order
+	return order
+}
+
+func parseLink(t *testing.T, link filescontract.DownloadLink) filescontract.DownloadRequest {
+	t.Helper()
+	parsed, err := url.Parse(link.URL)
+	if err != nil || link.URL == "" {
+		t.Fatalf("invalid link url %q: %v", link.URL, err)
+	}
+	expires, err := strconv.ParseInt(parsed.Query().Get("expires"), 10, 64)
+	if err != nil {
+		t.Fatalf("invalid expires in %q", link.URL)
+	}
+	return filescontract.DownloadRequest{
+		GrantID:   link.GrantID,
+		Expires:   expires,
+		Signature: parsed.Query().Get("sig"),
+	}
+}
+
+func readAll(t *testing.T, file *filescontract.DownloadFile) string {
+	t.Helper()
+	defer file.Content.Close()
+	body, err := io.ReadAll(file.Content)
+	if err != nil {
+		t.Fatalf("read file failed: %v", err)
+	}
+	return string(body)
+}
+
+func TestDeliverForOrderGrantsMatchingFilesOnce(t *testing.T) {
+	f := newFixture(t)
+	f.upload(t, 10, 0, "manual.pdf", "product-wide", 0)
+	f.upload(t, 10, 2, "pro-license.txt", "sku-two", 3)
+	f.upload(t, 10, 3, "lite-license.txt", "sku-three", 0)
+	f.paidOrder(100, nil, 10, 2)
+
+	if err := f.service.DeliverForOrder(100); err != nil {
+		t.Fatalf("deliver failed: %v", err)
+	}
+	if err := f.service.DeliverForOrder(100); err != nil {
+		t.Fatalf("repeat deliver failed: %v", err)
+	}
+	if f.completer.calls != 1 || !strings.Contains(f.completer.payload, "pro-license.txt") {
+		t.Fatalf("unexpected completion calls=%d payload=%q", f.completer.calls, f.completer.payload)
+	}
+	links, err := f.service.ListLinks([]uint{100})
+	if err != nil {
+		t.Fatalf("list links failed: %v", err)
+	}
+	if len(links) != 2 {
+		t.Fatalf("expected product-wide and sku file grants, got %+v", links)
+	}
+	for _, link := range links {
+		if link.Filename == "lite-license.txt" {
+			t.Fatalf("grant created for another sku: %+v", link)
+		}
+		if link.Filename == "manual.pdf" && link.MaxDownloads != filesdomain.DefaultMaxDownloads {
+			t.Fatalf("default max downloads not applied: %+v", link)
+		}
+		if !link.ExpiresAt.Equal(f.now.AddDate(0, 0, filesdomain.DefaultValidDays)) {
+			t.Fatalf("unexpected grant expiry: %+v", link)
+		}
+	}
+}
+
+func TestDeliverForOrderWithoutFilesFallsBackToManual(t *testing.T) {
+	f := newFixture(t)
+	f.paidOrder(100, nil, 10, 0)
+
+	if err := f.service.DeliverForOrder(100); err != nil {
+		t.Fatalf("deliver failed: %v", err)
+	}
+	if f.completer.calls != 0 || len(f.notifier.orderIDs) != 1 {
+		t.Fatalf("expected manual fallback, completions=%d notified=%v", f.completer.calls, f.notifier.orderIDs)
+	}
+}
+
+func TestDownloadEnforcesSignatureLimitAndResume(t *testing.T) {
+	f := newFixture(t)
+	f.upload(t, 10, 0, "book.epub", "0123456789", 1)
+	f.paidOrder(100, nil, 10, 0)
+	if err := f.service.DeliverForOrder(100); err != nil {
+		t.Fatalf("deliver failed: %v", err)
+	}
+	links, err := f.service.ListLinks([]uint{100})
+	if err != nil || len(links) != 1 {
+		t.Fatalf("list links failed: %v %+v", err, links)
+	}
+	request := parseLink(t, links[0])
+
+	tampered := request
+	tampered.GrantID++
+	if _, err := f.service.Download(tampered); !errors.Is(err, filescontract.ErrGrantNotFound) {
+		t.Fatalf("expected unknown grant, got %v", err)
+	}
+	forged := request
+	forged.Expires += 60
+	if _, err := f.service.Download(forged); !errors.Is(err, filescontract.ErrLinkInvalid) {
+		t.Fatalf("expected invalid signature, got %v", err)
+	}
+	resume := request
+	resume.Range = "bytes=5-"
+	if _, err := f.service.Download(resume); !errors.Is(err, filescontract.ErrDownloadsExhausted) {
+		t.Fatalf("resume before a counted download must fail, got %v", err)
+	}
+
+	file, err := f.service.Download(request)
+	if err != nil {
+		t.Fatalf("download failed: %v", err)
+	}
+	if body := readAll(t, file); body != "0123456789" || file.Filename != "book.epub" {
+		t.Fatalf("unexpected file %q %q", file.Filename, body)
+	}
+	file, err = f.service.Download(resume)
+	if err != nil {
+		t.Fatalf("resume download failed: %v", err)
+	}
+	readAll(t, file)
+	if _, err := f.service.Download(request); !errors.Is(err, filescontract.ErrDownloadsExhausted) {
+		t.Fatalf("expected exhausted downloads, got %v", err)
+	}
+
+	f.now = f.now.Add(time.Hour)
+	if _, err := f.service.Download(resume); !errors.Is(err, filescontract.ErrLinkExpired) {
+		t.Fatalf("expected expired link, got %v", err)
+	}
+
+	grants, logs, err := f.service.ListOrderActivity([]uint{100})
+	if err != nil {
+		t.Fatalf("list activity failed: %v", err)
+	}
+	if grants[0].DownloadCount != 1 {
+		t.Fatalf("resume must not be counted, got %d", grants[0].DownloadCount)
+	}
+	results := make([]string, 0, len(logs))
+	for _, log := range logs {
+		results = append(results, log.Result)
+	}
+	want := []string{
+		filesdomain.DownloadResultLinkExpired,
+		filesdomain.DownloadResultExhausted,
+		filesdomain.DownloadResultSuccess,
+		filesdomain.DownloadResultSuccess,
+		filesdomain.DownloadResultExhausted,
+		filesdomain.DownloadResultInvalidSignature,
+	}
+	if strings.Join(results, ",") != strings.Join(want, ",") {
+		t.Fatalf("unexpected download log %v", results)
+	}
+}
+
+func TestDownloadCountsRangesThatDoNotResumeRecentDownload(t *testing.T) {
+	f := newFixture(t)
+	f.upload(t, 10, 0, "book.epub", "0123456789", 3)
+	f.paidOrder(100, nil, 10, 0)
+	if err := f.service.DeliverForOrder(100); err != nil {
+		t.Fatalf("deliver failed: %v", err)
+	}
+	nextRequest := func(rangeHeader string) filescontract.DownloadRequest {
+		links, err := f.service.ListLinks([]uint{100})
+		if err != nil || len(links) != 1 {
+			t.Fatalf("list links failed: %v %+v", err, links)
+		}
+		request := parseLink(t, links[0])
+		request.Range = rangeHeader
+		return request
+	}
+	download := func(request filescontract.DownloadRequest) {
+		t.Helper()
+		file, err := f.service.Download(request)
+		if err != nil {
+			t.Fatalf("download %q failed: %v", request.Range, err)
+		}
+		readAll(t, file)
+	}
+	countAfter := func(want int) {
+		t.Helper()
+		grants, _, err := f.service.ListOrderActivity([]uint{100})
+		if err != nil {
+			t.Fatalf("list activity failed: %v", err)
+		}
+		if grants[0].DownloadCount != want {
+			t.Fatalf("expected %d counted downloads, got %d", want, grants[0].DownloadCount)
+		}
+	}
+
+	// 后缀 Range 可取回整个文件，必须计数
+	download(nextRequest("bytes=-999999999"))
+	countAfter(1)
+	// 紧随计数下载的续传不重复计数
+	download(nextRequest("bytes=1-"))
+	countAfter(1)
+	// 多段 Range 可拼出整个文件，必须计数
+	download(nextRequest("bytes=1-4,0-"))
+	countAfter(2)
+
+	// 超过链接有效期后的续传视为新的下载
+	f.now = f.now.Add(time.Hour)
+	request := nextRequest("bytes=1-")
+	download(request)
+	countAfter(3)
+	download(request)
+	countAfter(3)
+
+	f.now = f.now.Add(time.Hour)
+	if links, _ := f.service.ListLinks([]uint{100}); links[0].URL != "" {
+		t.Fatalf("exhausted grant must not issue links, got %q", links[0].URL)
+	}
+}
+
+func TestRefundRevokesChildOrderGrants(t *testing.T) {
+	f := newFixture(t)
+	f.upload(t, 10, 0, "course.zip", "lesson", 0)
+	parentID := uint(100)
+	f.paidOrder(101, &parentID, 10, 0)
+	if err := f.service.DeliverForOrder(101); err != nil {
+		t.Fatalf("deliver failed: %v", err)
+	}
+	links, _ := f.service.ListLinks([]uint{100, 101})
+	request := parseLink(t, links[0])
+
+	if err := f.service.RevokeForOrder(parentID); err != nil {
+		t.Fatalf("revoke failed: %v", err)
+	}
+	if _, err := f.service.Download(request); !errors.Is(err, filescontract.ErrGrantRevoked) {
+		t.Fatalf("expected revoked grant, got %v", err)
+	}
+	links, _ = f.service.ListLinks([]uint{100, 101})
+	if len(links) != 1 || !links[0].Revoked || links[0].URL != "" {
+		t.Fatalf("revoked grant must not expose a link: %+v", links)
+	}
+}
+
+func TestDownloadRevokesGrantWhenOrderRefundedElsewhere(t *testing.T) {
+	f := newFixture(t)
+	f.upload(t, 10, 0, "font.otf", "glyphs", 0)
+	order := f.paidOrder(100, nil, 10, 0)
+	if err := f.service.DeliverForOrder(100); err != nil {
+		t.Fatalf("deliver failed: %v", err)
+	}
+	links, _ := f.service.ListLinks([]uint{100})
+	request := parseLink(t, links[0])
+
+	order.Status = constants.OrderStatusRefunded
+	if _, err := f.service.Download(request); !errors.Is(err, filescontract.ErrGrantRevoked) {
+		t.Fatalf("expected revoked grant, got %v", err)
+	}
+	grants, _, _ := f.service.ListOrderActivity([]uint{100})
+	if grants[0].RevokedAt == nil || grants[0].RevokeReason != filesdomain.RevokeReasonRefunded {
+		t.Fatalf("grant not persisted as revoked: %+v", grants[0])
+	}
+}
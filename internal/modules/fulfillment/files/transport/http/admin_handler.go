@@ -0,0 +1,179 @@
+package fileshttp
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	filescontract "github.com/dujiao-next/internal/modules/fulfillment/files/contract"
+	filesdomain "github.com/dujiao-next/internal/modules/fulfillment/files/domain"
+	"github.com/dujiao-next/internal/platform/http/ginutil"
+	"github.com/dujiao-next/internal/platform/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxFormOverheadBytes multipart 表单除文件外的额外字节上限
+const maxFormOverheadBytes = 1 << 20
+
+// AdminService 是管理端文件交付所需的最小用例接口。
+type AdminService interface {
+	ListAssets(productID uint) ([]filesdomain.Asset, error)
+	UploadAsset(input filescontract.AssetUploadInput) (*filesdomain.Asset, error)
+	DeleteAsset(productID, assetID uint) error
+	ListOrderActivity(orderIDs []uint) ([]filesdomain.Grant, []filesdomain.DownloadLog, error)
+}
+
+// AdminOrderLookup 解析管理端订单及其子订单 ID。
+type AdminOrderLookup interface {
+	AdminOrderIDs(orderID uint) ([]uint, error)
+}
+
+// AdminHandler 处理后台交付文件管理请求。
+type AdminHandler struct {
+	service AdminService
+	orders  AdminOrderLookup
+}
+
+func NewAdminHandler(service AdminService, orders AdminOrderLookup) *AdminHandler {
+	if service == nil || orders == nil {
+		panic("file fulfillment admin handler: required dependency is nil")
+	}
+	return &AdminHandler{service: service, orders: orders}
+}
+
+// ListProductFiles 获取商品交付文件
+func (h *AdminHandler) ListProductFiles(c *gin.Context) {
+	productID, err := ginutil.ParseParamUint(c, "id")
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", nil)
+		return
+	}
+	assets, err := h.service.ListAssets(productID)
+	if err != nil {
+		ginutil.RespondError(c, response.CodeInternal, "error.fulfillment_file_fetch_failed", err)
+		return
+	}
+	response.Success(c, assets)
+}
+
+// UploadProductFile 上传商品交付文件（multipart：file、sku_id、max_downloads、valid_days、sort_order）
+func (h *AdminHandler) UploadProductFile(c *gin.Context) {
+	productID, err := ginutil.ParseParamUint(c, "id")
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", nil)
+		return
+	}
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, filesdomain.MaxAssetSize+maxFormOverheadBytes)
+	header, err := c.FormFile("file")
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.file_missing", nil)
+		return
+	}
+	skuID, errSKU := parseFormUint(c, "sku_id")
+	maxDownloads, errMax := parseFormInt(c, "max_downloads")
+	validDays, errDays := parseFormInt(c, "valid_days")
+	sortOrder, errSort := parseFormInt(c, "sort_order")
+	if errSKU != nil || errMax != nil || errDays != nil || errSort != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.fulfillment_file_invalid", nil)
+		return
+	}
+	source, err := header.Open()
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.file_missing", nil)
+		return
+	}
+	defer source.Close()
+
+	asset, err := h.service.UploadAsset(filescontract.AssetUploadInput{
+		ProductID:    productID,
+		SKUID:        skuID,
+		Filename:     header.Filename,
+		MimeType:     header.Header.Get("Content-Type"),
+		Size:         header.Size,
+		Source:       source,
+		MaxDownloads: maxDownloads,
+		ValidDays:    validDays,
+		SortOrder:    sortOrder,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, filescontract.ErrAssetTooLarge):
+			ginutil.RespondError(c, response.CodeBadRequest, "error.fulfillment_file_too_large", nil)
+		case errors.Is(err, filescontract.ErrAssetInvalid):
+			ginutil.RespondError(c, response.CodeBadRequest, "error.fulfillment_file_invalid", nil)
+		default:
+			ginutil.RespondError(c, response.CodeInternal, "error.upload_failed", err)
+		}
+		return
+	}
+	response.Success(c, asset)
+}
+
+// DeleteProductFile 下架商品交付文件，已发放的下载授权不受影响
+func (h *AdminHandler) DeleteProductFile(c *gin.Context) {
+	productID, err := ginutil.ParseParamUint(c, "id")
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", nil)
+		return
+	}
+	assetID, err := ginutil.ParseParamUint(c, "file_id")
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", nil)
+		return
+	}
+	if err := h.service.DeleteAsset(productID, assetID); err != nil {
+		if errors.Is(err, filescontract.ErrAssetNotFound) {
+			ginutil.RespondError(c, response.CodeNotFound, "error.fulfillment_file_not_found", nil)
+			return
+		}
+		ginutil.RespondError(c, response.CodeInternal, "error.fulfillment_file_fetch_failed", err)
+		return
+	}
+	response.Success(c, nil)
+}
+
+// GetOrderFiles 获取订单的下载授权与下载记录
+func (h *AdminHandler) GetOrderFiles(c *gin.Context) {
+	orderID, err := ginutil.ParseParamUint(c, "id")
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", nil)
+		return
+	}
+	orderIDs, err := h.orders.AdminOrderIDs(orderID)
+	if err != nil {
+		if errors.Is(err, ErrOrderNotFound) {
+			ginutil.RespondError(c, response.CodeNotFound, "error.order_not_found", nil)
+			return
+		}
+		ginutil.RespondError(c, response.CodeInternal, "error.order_fetch_failed", err)
+		return
+	}
+	grants, logs, err := h.service.ListOrderActivity(orderIDs)
+	if err != nil {
+		ginutil.RespondError(c, response.CodeInternal, "error.fulfillment_file_fetch_failed", err)
+		return
+	}
+	response.Success(c, gin.H{"grants": grants, "download_logs": logs})
+}
+
+func parseFormUint(c *gin.Context, key string) (uint, error) {
+	raw := strings.TrimSpace(c.PostForm(key))
+	if raw == "" {
+		return 0, nil
+	}
+	value, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return uint(value), nil
+}
+
+func parseFormInt(c *gin.Context, key string) (int, error) {
+	raw := strings.TrimSpace(c.PostForm(key))
+	if raw == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(raw)
+}
@@ -0,0 +1,181 @@
+package fileshttp
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/dujiao-next/internal/logger"
+	filescontract "github.com/dujiao-next/internal/modules/fulfillment/files/contract"
+	reseller "github.com/dujiao-next/internal/modules/reseller/contract"
+	"github.com/dujiao-next/internal/platform/http/ginutil"
+	"github.com/dujiao-next/internal/platform/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+var ErrOrderNotFound = errors.New("order not found")
+
+// Service 是前台文件交付所需的最小用例接口。
+type Service interface {
+	ListLinks(orderIDs []uint) ([]filescontract.DownloadLink, error)
+	Download(request filescontract.DownloadRequest) (*filescontract.DownloadFile, error)
+}
+
+// OrderLookup 按订单号解析当前买家可见的订单 ID（含子订单）。
+type OrderLookup interface {
+	UserOrderIDs(tenant reseller.TenantContext, orderNo string, userID uint) ([]uint, error)
+	GuestOrderIDs(tenant reseller.TenantContext, orderNo, email, password string) ([]uint, error)
+}
+
+// Handler 处理前台文件交付请求。
+type Handler struct {
+	service Service
+	orders  OrderLookup
+}
+
+func NewHandler(service Service, orders OrderLookup) *Handler {
+	if service == nil || orders == nil {
+		panic("file fulfillment handler: required dependency is nil")
+	}
+	return &Handler{service: service, orders: orders}
+}
+
+// ListUserFiles 登录用户获取订单文件下载链接
+func (h *Handler) ListUserFiles(c *gin.Context) {
+	uid, ok := ginutil.GetUserID(c)
+	if !ok {
+		return
+	}
+	orderNo := strings.TrimSpace(c.Param("order_no"))
+	if orderNo == "" {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.order_item_invalid", nil)
+		return
+	}
+	orderIDs, err := h.orders.UserOrderIDs(tenantFromRequest(c), orderNo, uid)
+	if err != nil {
+		if errors.Is(err, ErrOrderNotFound) {
+			ginutil.RespondError(c, response.CodeNotFound, "error.order_not_found", nil)
+			return
+		}
+		ginutil.RespondError(c, response.CodeInternal, "error.order_fetch_failed", err)
+		return
+	}
+	h.respondLinks(c, orderIDs)
+}
+
+// ListGuestFiles 游客获取订单文件下载链接
+func (h *Handler) ListGuestFiles(c *gin.Context) {
+	email, password, ok := ginutil.GetGuestCredentials(c)
+	if !ok || email == "" || password == "" {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.guest_email_required", nil)
+		return
+	}
+	orderNo := strings.TrimSpace(c.Param("order_no"))
+	if orderNo == "" {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.order_item_invalid", nil)
+		return
+	}
+	orderIDs, err := h.orders.GuestOrderIDs(tenantFromRequest(c), orderNo, email, password)
+	if err != nil {
+		if errors.Is(err, ErrOrderNotFound) {
+			ginutil.RespondError(c, response.CodeNotFound, "error.guest_order_not_found", nil)
+			return
+		}
+		ginutil.RespondError(c, response.CodeInternal, "error.order_fetch_failed", err)
+		return
+	}
+	h.respondLinks(c, orderIDs)
+}
+
+func (h *Handler) respondLinks(c *gin.Context, orderIDs []uint) {
+	links, err := h.service.ListLinks(orderIDs)
+	if err != nil {
+		ginutil.RespondError(c, response.CodeInternal, "error.fulfillment_file_fetch_failed", err)
+		return
+	}
+	response.Success(c, links)
+}
+
+// Download GET /api/v1/fulfillment/files/:grant_id/download
+// 鉴权由签名链接完成，支持 Range 断点续传。
+func (h *Handler) Download(c *gin.Context) {
+	grantID, err := ginutil.ParseParamUint(c, "grant_id")
+	if err != nil {
+		ginutil.RespondError(c, response.CodeNotFound, "error.fulfillment_file_link_invalid", nil)
+		return
+	}
+	expires, err := strconv.ParseInt(strings.TrimSpace(c.Query("expires")), 10, 64)
+	if err != nil {
+		ginutil.RespondError(c, response.CodeForbidden, "error.fulfillment_file_link_invalid", nil)
+		return
+	}
+	file, err := h.service.Download(filescontract.DownloadRequest{
+		GrantID:   grantID,
+		Expires:   expires,
+		Signature: c.Query("sig"),
+		Range:     c.GetHeader("Range"),
+		ClientIP:  c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	})
+	if err != nil {
+		respondDownloadError(c, err)
+		return
+	}
+	defer file.Content.Close()
+
+	if file.MimeType != "" {
+		c.Header("Content-Type", file.MimeType)
+	}
+	c.Header("Content-Disposition", contentDisposition(file.Filename))
+	c.Header("Cache-Control", "private, no-store")
+	http.ServeContent(c.Writer, c.Request, file.Filename, file.ModTime, file.Content)
+}
+
+func respondDownloadError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, filescontract.ErrGrantNotFound):
+		ginutil.RespondError(c, response.CodeNotFound, "error.fulfillment_file_link_invalid", nil)
+	case errors.Is(err, filescontract.ErrLinkInvalid):
+		ginutil.RespondError(c, response.CodeForbidden, "error.fulfillment_file_link_invalid", nil)
+	case errors.Is(err, filescontract.ErrLinkExpired):
+		ginutil.RespondError(c, response.CodeForbidden, "error.fulfillment_file_link_expired", nil)
+	case errors.Is(err, filescontract.ErrGrantExpired):
+		ginutil.RespondError(c, response.CodeForbidden, "error.fulfillment_file_grant_expired", nil)
+	case errors.Is(err, filescontract.ErrGrantRevoked):
+		ginutil.RespondError(c, response.CodeForbidden, "error.fulfillment_file_revoked", nil)
+	case errors.Is(err, filescontract.ErrDownloadsExhausted):
+		ginutil.RespondError(c, response.CodeForbidden, "error.fulfillment_file_downloads_exhausted", nil)
+	case errors.Is(err, filescontract.ErrFileMissing):
+		ginutil.RespondError(c, response.CodeNotFound, "error.fulfillment_file_missing", nil)
+	default:
+		logger.Errorw("fulfillment_file_download_failed", "error", err)
+		ginutil.RespondError(c, response.CodeInternal, "error.fulfillment_file_fetch_failed", err)
+	}
+}
+
+// contentDisposition 同时提供 ASCII 回退与 RFC 5987 编码的文件名。
+func contentDisposition(filename string) string {
+	fallback := strings.Map(func(r rune) rune {
+		if r < 0x20 || r > 0x7e || r == '"' || r == '\\' {
+			return '_'
+		}
+		return r
+	}, filename)
+	if value := mime.FormatMediaType("attachment", map[string]string{"filename": fallback}); value != "" {
+		return value + "; filename*=UTF-8''" + url.PathEscape(filename)
+	}
+	return "attachment; filename*=UTF-8''" + url.PathEscape(filename)
+}
+
+func tenantFromRequest(c *gin.Context) reseller.TenantContext {
+	if c != nil && c.Request != nil {
+		if tenant, ok := reseller.TenantFromContext(c.Request.Context()); ok {
+			return tenant
+		}
+	}
+	return reseller.MainTenantContext("")
+}
@@ -0,0 +1,38 @@
+package fileshttp
+
+import "github.com/gin-gonic/gin"
+
+// RegisterUserRoutes 注册登录用户订单文件下载链接路由。
+func RegisterUserRoutes(user gin.IRoutes, handler *Handler) {
+	if user == nil || handler == nil {
+		panic("file fulfillment user routes: required dependency is nil")
+	}
+	user.GET("/orders/:order_no/fulfillment/files", handler.ListUserFiles)
+}
+
+// RegisterGuestRoutes 注册游客订单文件下载链接路由。
+func RegisterGuestRoutes(guest gin.IRoutes, handler *Handler) {
+	if guest == nil || handler == nil {
+		panic("file fulfillment guest routes: required dependency is nil")
+	}
+	guest.GET("/orders/:order_no/fulfillment/files", handler.ListGuestFiles)
+}
+
+// RegisterDownloadRoutes 注册签名下载路由，鉴权由链接签名完成。
+func RegisterDownloadRoutes(public gin.IRoutes, handler *Handler) {
+	if public == nil || handler == nil {
+		panic("file fulfillment download routes: required dependency is nil")
+	}
+	public.GET("/fulfillment/files/:grant_id/download", handler.Download)
+}
+
+// RegisterAdminRoutes 注册后台交付文件管理路由。
+func RegisterAdminRoutes(authorized gin.IRoutes, handler *AdminHandler) {
+	if authorized == nil || handler == nil {
+		panic("file fulfillment admin routes: required dependency is nil")
+	}
+	authorized.GET("/products/:id/files", handler.ListProductFiles)
+	authorized.POST("/products/:id/files", handler.UploadProductFile)
+	authorized.DELETE("/products/:id/files/:file_id", handler.DeleteProductFile)
+	authorized.GET("/orders/:id/fulfillment/files", handler.GetOrderFiles)
+}
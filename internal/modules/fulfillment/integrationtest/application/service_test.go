@@ -11,6 +11,7 @@ import (
 	fulfillmentgormstore "github.com/dujiao-next/internal/modules/fulfillment/infrastructure/gormstore"
 	orderdomain "github.com/dujiao-next/internal/modules/order/domain"
 	ordergormstore "github.com/dujiao-next/internal/modules/order/infrastructure/gormstore"
+	stockledgerdomain "github.com/dujiao-next/internal/modules/stockledger/domain"
 
 	"github.com/dujiao-next/internal/constants"
 	cardsecretdomain "github.com/dujiao-next/internal/modules/cardsecret/domain"
@@ -33,6 +34,7 @@ func setupFulfillmentServiceTestDB(t *testing.T) *gorm.DB {
 		t.Fatalf("open sqlite failed: %v", err)
 	}
 	if err := db.AutoMigrate(
+		&stockledgerdomain.Movement{},
 		&orderdomain.Order{},
 		&orderdomain.OrderItem{},
 		&fulfillmentdomain.Fulfillment{},
@@ -150,3 +152,123 @@ func TestCreateAutoFulfillmentRespectsSKUBoundary(t *testing.T) {
 		t.Fatalf("order status want completed got %s", orderAfter.Status)
 	}
 }
+
+func TestCreateWebhookFulfillmentCompletesOrder(t *testing.T) {
+	db := setupFulfillmentServiceTestDB(t)
+	now := time.Now()
+
+	order := &orderdomain.Order{
+		OrderNo:          "FULFILL-WEBHOOK-001",
+		UserID:           1,
+		Status:           constants.OrderStatusFulfilling,
+		Currency:         "CNY",
+		OriginalAmount:   money.FromDecimal(decimal.NewFromInt(10)),
+		TotalAmount:      money.FromDecimal(decimal.NewFromInt(10)),
+		OnlinePaidAmount: money.FromDecimal(decimal.NewFromInt(10)),
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+	if err := db.Create(order).Error; err != nil {
+		t.Fatalf("create order failed: %v", err)
+	}
+	orderItem := &orderdomain.OrderItem{
+		OrderID:         order.ID,
+		ProductID:       200,
+		TitleJSON:       jsonmap.JSON{"zh-CN": "接口商品"},
+		UnitPrice:       money.FromDecimal(decimal.NewFromInt(10)),
+		Quantity:        1,
+		TotalPrice:      money.FromDecimal(decimal.NewFromInt(10)),
+		FulfillmentType: constants.FulfillmentTypeWebhook,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	if err := db.Create(orderItem).Error; err != nil {
+		t.Fatalf("create order item failed: %v", err)
+	}
+
+	svc := New(Options{
+		OrderStore:       ordergormstore.New(db, "test-guest-credential-secret-with-32-bytes"),
+		FulfillmentStore: fulfillmentgormstore.New(db),
+	})
+
+	result, err := svc.CreateWebhook(order.ID, "WEBHOOK-CODE-001", nil)
+	if err != nil {
+		t.Fatalf("create webhook fulfillment failed: %v", err)
+	}
+	if result.Type != constants.FulfillmentTypeWebhook || result.Payload != "WEBHOOK-CODE-001" {
+		t.Fatalf("unexpected fulfillment: %#v", result)
+	}
+	if _, err := svc.CreateWebhook(order.ID, "WEBHOOK-CODE-002", nil); err == nil {
+		t.Fatalf("second webhook fulfillment should fail")
+	}
+
+	var orderAfter orderdomain.Order
+	if err := db.First(&orderAfter, order.ID).Error; err != nil {
+		t.Fatalf("query order failed: %v", err)
+	}
+	if orderAfter.Status != constants.OrderStatusCompleted {
+		t.Fatalf("order status want completed got %s", orderAfter.Status)
+	}
+}
+
+func TestCreateLicenseFulfillmentRecordsCardSecrets(t *testing.T) {
+	db := setupFulfillmentServiceTestDB(t)
+	now := time.Now()
+
+	order := &orderdomain.Order{
+		OrderNo:          "FULFILL-LICENSE-001",
+		UserID:           1,
+		Status:           constants.OrderStatusFulfilling,
+		Currency:         "CNY",
+		OriginalAmount:   money.FromDecimal(decimal.NewFromInt(20)),
+		TotalAmount:      money.FromDecimal(decimal.NewFromInt(20)),
+		OnlinePaidAmount: money.FromDecimal(decimal.NewFromInt(20)),
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+	if err := db.Create(order).Error; err != nil {
+		t.Fatalf("create order failed: %v", err)
+	}
+	orderItem := &orderdomain.OrderItem{
+		OrderID:         order.ID,
+		ProductID:       300,
+		SKUID:           31,
+		TitleJSON:       jsonmap.JSON{"zh-CN": "授权码商品"},
+		UnitPrice:       money.FromDecimal(decimal.NewFromInt(10)),
+		Quantity:        2,
+		TotalPrice:      money.FromDecimal(decimal.NewFromInt(20)),
+		FulfillmentType: constants.FulfillmentTypeLicense,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	if err := db.Create(orderItem).Error; err != nil {
+		t.Fatalf("create order item failed: %v", err)
+	}
+
+	svc := New(Options{
+		OrderStore:       ordergormstore.New(db, "test-guest-credential-secret-with-32-bytes"),
+		FulfillmentStore: fulfillmentgormstore.New(db),
+	})
+
+	if _, err := svc.CreateLicense(order.ID, []cardsecretdomain.Secret{{ProductID: 999, SKUID: 31, Secret: "LIC-OTHER"}}); err == nil {
+		t.Fatalf("license for another product should fail")
+	}
+	result, err := svc.CreateLicense(order.ID, []cardsecretdomain.Secret{
+		{ProductID: 300, SKUID: 31, Secret: "LIC-AAAA"},
+		{ProductID: 300, SKUID: 31, Secret: "LIC-BBBB"},
+	})
+	if err != nil {
+		t.Fatalf("create license fulfillment failed: %v", err)
+	}
+	if result.Type != constants.FulfillmentTypeLicense || result.Payload != "LIC-AAAA\nLIC-BBBB" {
+		t.Fatalf("unexpected fulfillment: %#v", result)
+	}
+
+	var secrets []cardsecretdomain.Secret
+	if err := db.Where("order_id = ?", order.ID).Order("id ASC").Find(&secrets).Error; err != nil {
+		t.Fatalf("query card secrets failed: %v", err)
+	}
+	if len(secrets) != 2 || secrets[0].Status != cardsecretdomain.StatusUsed || secrets[0].SKUID != 31 || secrets[1].Secret != "LIC-BBBB" {
+		t.Fatalf("unexpected card secrets: %#v", secrets)
+	}
+}
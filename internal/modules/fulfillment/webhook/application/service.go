@@ -0,0 +1,420 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dujiao-next/internal/constants"
+	"github.com/dujiao-next/internal/logger"
+	webhookcontract "github.com/dujiao-next/internal/modules/fulfillment/webhook/contract"
+	webhookdomain "github.com/dujiao-next/internal/modules/fulfillment/webhook/domain"
+)
+
+const (
+	maxDispatchAttempts = 5
+	callbackWaitWindow  = 24 * time.Hour
+	callbackPathPrefix  = "/api/v1/fulfillment/webhook/callback/"
+	maxLastErrorLength  = 500
+)
+
+var dispatchRetryDelays = []time.Duration{
+	30 * time.Second,
+	60 * time.Second,
+	120 * time.Second,
+	300 * time.Second,
+}
+
+// Options 声明 webhook 交付应用服务的全部端口。
+type Options struct {
+	Deliveries webhookcontract.Store
+	Orders     webhookcontract.OrderReader
+	Endpoints  webhookcontract.EndpointReader
+	Sender     webhookcontract.Sender
+	Queue      webhookcontract.DispatchQueue
+	Completer  webhookcontract.Completer
+	Notifier   webhookcontract.FallbackNotifier
+	// SiteURL 返回站点对外地址，用于拼接异步回调地址；为空时不下发 callback_url。
+	SiteURL func() string
+	Now     func() time.Time
+}
+
+// Service 编排 webhook 发码请求、重试、异步回调与人工兜底。
+type Service struct {
+	deliveries webhookcontract.Store
+	orders     webhookcontract.OrderReader
+	endpoints  webhookcontract.EndpointReader
+	sender     webhookcontract.Sender
+	queue      webhookcontract.DispatchQueue
+	completer  webhookcontract.Completer
+	notifier   webhookcontract.FallbackNotifier
+	siteURL    func() string
+	now        func() time.Time
+}
+
+// NewService 创建 webhook 交付应用服务。
+func NewService(options Options) *Service {
+	if options.Deliveries == nil {
+		panic("webhook fulfillment service: deliveries are nil")
+	}
+	if options.Orders == nil {
+		panic("webhook fulfillment service: orders are nil")
+	}
+	if options.Endpoints == nil {
+		panic("webhook fulfillment service: endpoints are nil")
+	}
+	if options.Sender == nil {
+		panic("webhook fulfillment service: sender is nil")
+	}
+	if options.Completer == nil {
+		panic("webhook fulfillment service: completer is nil")
+	}
+	if options.SiteURL == nil {
+		options.SiteURL = func() string { return "" }
+	}
+	if options.Now == nil {
+		options.Now = time.Now
+	}
+	return &Service{
+		deliveries: options.Deliveries,
+		orders:     options.Orders,
+		endpoints:  options.Endpoints,
+		sender:     options.Sender,
+		queue:      options.Queue,
+		completer:  options.Completer,
+		notifier:   options.Notifier,
+		siteURL:    options.SiteURL,
+		now:        options.Now,
+	}
+}
+
+// StartForOrder 为已支付的 webhook 订单创建交付记录并触发首次发码请求，重复调用幂等。
+func (s *Service) StartForOrder(orderID uint) error {
+	existing, err := s.deliveries.GetByOrderID(orderID)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return nil
+	}
+	order, err := s.orders.GetByID(orderID)
+	if err != nil {
+		return err
+	}
+	if order == nil {
+		return webhookcontract.ErrOrderNotFound
+	}
+	productID := webhookProductID(order)
+	if productID == 0 {
+		return webhookcontract.ErrNotWebhookOrder
+	}
+	token, err := newCallbackToken()
+	if err != nil {
+		return err
+	}
+	delivery := &webhookdomain.Delivery{
+		OrderID:       order.ID,
+		ProductID:     productID,
+		Status:        webhookdomain.StatusPending,
+		CallbackToken: token,
+	}
+	if err := s.deliveries.Create(delivery); err != nil {
+		return err
+	}
+	if s.queue == nil {
+		return s.Dispatch(context.Background(), delivery.ID)
+	}
+	return s.queue.EnqueueDispatch(delivery.ID, 0)
+}
+
+// GetByOrderID 查询订单的 webhook 交付记录。
+func (s *Service) GetByOrderID(orderID uint) (*webhookdomain.Delivery, error) {
+	return s.deliveries.GetByOrderID(orderID)
+}
+
+// Dispatch 执行一次发码请求；等待回调的记录在截止时间到达后转入人工交付。
+func (s *Service) Dispatch(ctx context.Context, deliveryID uint) error {
+	delivery, err := s.deliveries.GetByID(deliveryID)
+	if err != nil {
+		return err
+	}
+	if delivery == nil {
+		return webhookcontract.ErrDeliveryNotFound
+	}
+	now := s.now()
+	switch delivery.Status {
+	case webhookdomain.StatusDelivered, webhookdomain.StatusClosed, webhookdomain.StatusManualFallback:
+		return nil
+	case webhookdomain.StatusAwaitingCallback:
+		if delivery.CallbackDeadline != nil && now.Before(*delivery.CallbackDeadline) {
+			return nil
+		}
+		return s.fallback(delivery, "callback timeout")
+	}
+
+	order, err := s.orders.GetByID(delivery.OrderID)
+	if err != nil {
+		return err
+	}
+	if order == nil || (order.Status != constants.OrderStatusPaid && order.Status != constants.OrderStatusFulfilling) {
+		return s.close(delivery, "order not awaiting fulfillment")
+	}
+	endpoint, err := s.endpoints.GetByProductID(delivery.ProductID)
+	if err != nil {
+		return err
+	}
+	if endpoint == nil || strings.TrimSpace(endpoint.URL) == "" || strings.TrimSpace(endpoint.Secret) == "" {
+		return s.fallback(delivery, "webhook endpoint not configured")
+	}
+
+	delivery.Attempts++
+	delivery.LastAttemptAt = &now
+	payload := webhookcontract.DispatchPayload{
+		Event:       webhookcontract.EventFulfillmentRequested,
+		DeliveryID:  delivery.ID,
+		OrderID:     order.ID,
+		OrderNo:     order.OrderNo,
+		UserID:      order.UserID,
+		Currency:    order.Currency,
+		TotalAmount: order.TotalAmount,
+		Items:       order.Items,
+		Attempt:     delivery.Attempts,
+		CallbackURL: s.callbackURL(delivery.CallbackToken),
+		Timestamp:   now.Unix(),
+	}
+	logger.Infow("fulfillment_webhook_dispatching",
+		"delivery_id", delivery.ID,
+		"order_id", order.ID,
+		"url", endpoint.URL,
+		"attempt", delivery.Attempts,
+	)
+	response, err := s.sender.Send(ctx, webhookcontract.SendRequest{
+		URL:     endpoint.URL,
+		Secret:  endpoint.Secret,
+		Payload: payload,
+	})
+	if err != nil {
+		delivery.LastHTTPStatus = 0
+		return s.handleFailure(delivery, err.Error())
+	}
+	delivery.LastHTTPStatus = response.StatusCode
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return s.handleFailure(delivery, fmt.Sprintf("endpoint returned %d: %s", response.StatusCode, strings.TrimSpace(string(response.Body))))
+	}
+	result, err := parseDeliveryResult(response.Body)
+	if err != nil {
+		return s.handleFailure(delivery, "invalid response: "+err.Error())
+	}
+	if hasDeliveryContent(result) {
+		if err := s.complete(delivery, result); err != nil && !errors.Is(err, webhookcontract.ErrAlreadyFulfilled) && !errors.Is(err, webhookcontract.ErrOrderNotPending) {
+			return s.handleFailure(delivery, "complete fulfillment failed: "+err.Error())
+		}
+		return nil
+	}
+	if strings.EqualFold(strings.TrimSpace(result.Status), webhookcontract.ResultStatusAccepted) {
+		return s.awaitCallback(delivery, now)
+	}
+	return s.handleFailure(delivery, "response carries no delivery")
+}
+
+// HandleCallback 校验签名后写入发码服务的异步交付结果。
+// 转入人工交付后仍接受迟到的回调，只要订单尚未被人工交付。
+func (s *Service) HandleCallback(input webhookcontract.CallbackInput) error {
+	token := strings.TrimSpace(input.Token)
+	if token == "" {
+		return webhookcontract.ErrDeliveryNotFound
+	}
+	delivery, err := s.deliveries.GetByCallbackToken(token)
+	if err != nil {
+		return err
+	}
+	if delivery == nil {
+		return webhookcontract.ErrDeliveryNotFound
+	}
+	endpoint, err := s.endpoints.GetByProductID(delivery.ProductID)
+	if err != nil {
+		return err
+	}
+	if endpoint == nil || strings.TrimSpace(endpoint.Secret) == "" {
+		return webhookcontract.ErrSignatureInvalid
+	}
+	timestamp, err := strconv.ParseInt(strings.TrimSpace(input.Timestamp), 10, 64)
+	if err != nil {
+		return webhookcontract.ErrSignatureInvalid
+	}
+	skew := s.now().Unix() - timestamp
+	if skew > webhookdomain.MaxTimestampSkew || skew < -webhookdomain.MaxTimestampSkew {
+		return webhookcontract.ErrSignatureInvalid
+	}
+	if !webhookdomain.Verify(endpoint.Secret, strings.TrimSpace(input.Signature), timestamp, input.Body) {
+		return webhookcontract.ErrSignatureInvalid
+	}
+
+	switch delivery.Status {
+	case webhookdomain.StatusDelivered:
+		return nil
+	case webhookdomain.StatusClosed:
+		return webhookcontract.ErrDeliveryClosed
+	}
+	result, err := parseDeliveryResult(input.Body)
+	if err != nil || !hasDeliveryContent(result) {
+		return webhookcontract.ErrDeliveryInvalid
+	}
+	if err := s.complete(delivery, result); err != nil {
+		if errors.Is(err, webhookcontract.ErrAlreadyFulfilled) || errors.Is(err, webhookcontract.ErrOrderNotPending) {
+			return webhookcontract.ErrDeliveryClosed
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *Service) complete(delivery *webhookdomain.Delivery, result *webhookcontract.DeliveryResult) error {
+	if err := s.completer.CompleteWebhook(delivery.OrderID, result.Payload, result.DeliveryData); err != nil {
+		if errors.Is(err, webhookcontract.ErrAlreadyFulfilled) || errors.Is(err, webhookcontract.ErrOrderNotPending) {
+			// 同步响应与异步回调可能并发到达，以先写入者为准
+			latest, getErr := s.deliveries.GetByID(delivery.ID)
+			if getErr == nil && latest != nil && latest.Status == webhookdomain.StatusDelivered {
+				return nil
+			}
+			if closeErr := s.close(delivery, err.Error()); closeErr != nil {
+				return closeErr
+			}
+		}
+		return err
+	}
+	now := s.now()
+	delivery.Status = webhookdomain.StatusDelivered
+	delivery.DeliveredAt = &now
+	delivery.CallbackDeadline = nil
+	delivery.LastError = ""
+	logger.Infow("fulfillment_webhook_delivered",
+		"delivery_id", delivery.ID,
+		"order_id", delivery.OrderID,
+		"attempts", delivery.Attempts,
+	)
+	return s.deliveries.Update(delivery)
+}
+
+func (s *Service) awaitCallback(delivery *webhookdomain.Delivery, now time.Time) error {
+	if s.queue == nil {
+		return s.fallback(delivery, "async delivery requires queue")
+	}
+	deadline := now.Add(callbackWaitWindow)
+	delivery.Status = webhookdomain.StatusAwaitingCallback
+	delivery.CallbackDeadline = &deadline
+	delivery.LastError = ""
+	if err := s.deliveries.Update(delivery); err != nil {
+		return err
+	}
+	// 截止时间到达后再次调度，仍未回调则转入人工交付
+	if err := s.queue.EnqueueDispatch(delivery.ID, callbackWaitWindow); err != nil {
+		logger.Warnw("fulfillment_webhook_enqueue_deadline_failed", "delivery_id", delivery.ID, "error", err)
+	}
+	return nil
+}
+
+func (s *Service) handleFailure(delivery *webhookdomain.Delivery, reason string) error {
+	logger.Warnw("fulfillment_webhook_attempt_failed",
+		"delivery_id", delivery.ID,
+		"order_id", delivery.OrderID,
+		"attempt", delivery.Attempts,
+		"http_status", delivery.LastHTTPStatus,
+		"error", reason,
+	)
+	if delivery.Attempts >= maxDispatchAttempts || s.queue == nil {
+		return s.fallback(delivery, reason)
+	}
+	delivery.LastError = truncateError(reason)
+	if err := s.deliveries.Update(delivery); err != nil {
+		return err
+	}
+	index := delivery.Attempts - 1
+	if index >= len(dispatchRetryDelays) {
+		index = len(dispatchRetryDelays) - 1
+	}
+	if err := s.queue.EnqueueDispatch(delivery.ID, dispatchRetryDelays[index]); err != nil {
+		logger.Warnw("fulfillment_webhook_requeue_failed", "delivery_id", delivery.ID, "error", err)
+	}
+	return nil
+}
+
+// fallback 转入人工交付：订单保持交付中，由管理员通过人工交付完成。
+func (s *Service) fallback(delivery *webhookdomain.Delivery, reason string) error {
+	delivery.Status = webhookdomain.StatusManualFallback
+	delivery.CallbackDeadline = nil
+	delivery.LastError = truncateError(reason)
+	if err := s.deliveries.Update(delivery); err != nil {
+		return err
+	}
+	logger.Warnw("fulfillment_webhook_manual_fallback",
+		"delivery_id", delivery.ID,
+		"order_id", delivery.OrderID,
+		"attempts", delivery.Attempts,
+		"reason", reason,
+	)
+	if s.notifier != nil {
+		if err := s.notifier.NotifyManualFulfillmentPending(delivery.OrderID); err != nil {
+			logger.Warnw("fulfillment_webhook_notify_fallback_failed", "order_id", delivery.OrderID, "error", err)
+		}
+	}
+	return nil
+}
+
+func (s *Service) close(delivery *webhookdomain.Delivery, reason string) error {
+	delivery.Status = webhookdomain.StatusClosed
+	delivery.CallbackDeadline = nil
+	delivery.LastError = truncateError(reason)
+	return s.deliveries.Update(delivery)
+}
+
+func (s *Service) callbackURL(token string) string {
+	base := strings.TrimRight(strings.TrimSpace(s.siteURL()), "/")
+	if base == "" || token == "" {
+		return ""
+	}
+	return base + callbackPathPrefix + token
+}
+
+func webhookProductID(order *webhookcontract.OrderSnapshot) uint {
+	for _, item := range order.Items {
+		if strings.TrimSpace(item.FulfillmentType) == constants.FulfillmentTypeWebhook && item.ProductID > 0 {
+			return item.ProductID
+		}
+	}
+	return 0
+}
+
+func parseDeliveryResult(body []byte) (*webhookcontract.DeliveryResult, error) {
+	var result webhookcontract.DeliveryResult
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func hasDeliveryContent(result *webhookcontract.DeliveryResult) bool {
+	return result != nil && (strings.TrimSpace(result.Payload) != "" || len(result.DeliveryData) > 0)
+}
+
+func newCallbackToken() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func truncateError(reason string) string {
+	runes := []rune(strings.TrimSpace(reason))
+	if len(runes) <= maxLastErrorLength {
+		return string(runes)
+	}
+	return string(runes[:maxLastErrorLength])
+}
@@ -0,0 +1,14 @@
+package contract
+
+import "errors"
+
+var (
+	ErrDeliveryNotFound = errors.New("webhook delivery not found")
+	ErrDeliveryClosed   = errors.New("webhook delivery closed")
+	ErrDeliveryInvalid  = errors.New("webhook delivery invalid")
+	ErrSignatureInvalid = errors.New("webhook signature invalid")
+	ErrNotWebhookOrder  = errors.New("order has no webhook item")
+	ErrOrderNotFound    = errors.New("webhook order not found")
+	ErrAlreadyFulfilled = errors.New("order already fulfilled")
+	ErrOrderNotPending  = errors.New("order not awaiting fulfillment")
+)
@@ -0,0 +1,49 @@
+package contract
+
+import (
+	"context"
+	"time"
+
+	webhookdomain "github.com/dujiao-next/internal/modules/fulfillment/webhook/domain"
+	"github.com/dujiao-next/internal/shared/jsonmap"
+)
+
+// Store 持久化 webhook 交付记录。
+type Store interface {
+	GetByID(id uint) (*webhookdomain.Delivery, error)
+	GetByOrderID(orderID uint) (*webhookdomain.Delivery, error)
+	GetByCallbackToken(token string) (*webhookdomain.Delivery, error)
+	Create(delivery *webhookdomain.Delivery) error
+	Update(delivery *webhookdomain.Delivery) error
+}
+
+// OrderReader 返回发码请求所需的订单投影。
+type OrderReader interface {
+	GetByID(id uint) (*OrderSnapshot, error)
+}
+
+// EndpointReader 读取商品配置的 webhook 地址与密钥。
+type EndpointReader interface {
+	GetByProductID(productID uint) (*Endpoint, error)
+}
+
+// Sender 执行签名后的 webhook HTTP 请求。
+type Sender interface {
+	Send(ctx context.Context, request SendRequest) (*SendResponse, error)
+}
+
+// DispatchQueue 负责立即或延迟投递调度任务。
+type DispatchQueue interface {
+	EnqueueDispatch(deliveryID uint, delay time.Duration) error
+}
+
+// Completer 将发码结果写入交付记录并推进订单状态。
+// 订单已存在交付记录时返回 ErrAlreadyFulfilled，订单不在待交付状态时返回 ErrOrderNotPending。
+type Completer interface {
+	CompleteWebhook(orderID uint, payload string, deliveryData jsonmap.JSON) error
+}
+
+// FallbackNotifier 在转入人工交付时发送待人工交付提醒。
+type FallbackNotifier interface {
+	NotifyManualFulfillmentPending(orderID uint) error
+}
@@ -0,0 +1,81 @@
+package contract
+
+import "github.com/dujiao-next/internal/shared/jsonmap"
+
+const (
+	// EventFulfillmentRequested 发码请求事件
+	EventFulfillmentRequested = "fulfillment.requested"
+	// ResultStatusAccepted 发码服务已受理，稍后通过回调交付
+	ResultStatusAccepted = "accepted"
+)
+
+// OrderItem 是发码请求需要的商品行投影。
+type OrderItem struct {
+	ProductID       uint         `json:"product_id"`
+	SKUID           uint         `json:"sku_id"`
+	SKUCode         string       `json:"sku_code,omitempty"`
+	Title           jsonmap.JSON `json:"title"`
+	Quantity        int          `json:"quantity"`
+	UnitPrice       string       `json:"unit_price"`
+	FulfillmentType string       `json:"-"`
+}
+
+// OrderSnapshot 是 webhook 交付读取订单所需的最小投影。
+type OrderSnapshot struct {
+	ID          uint
+	OrderNo     string
+	UserID      uint
+	Status      string
+	Currency    string
+	TotalAmount string
+	Items       []OrderItem
+}
+
+// Endpoint 是商品级 webhook 配置。
+type Endpoint struct {
+	URL    string
+	Secret string
+}
+
+// DispatchPayload 是发送给发码服务的稳定 JSON 合同。
+type DispatchPayload struct {
+	Event       string      `json:"event"`
+	DeliveryID  uint        `json:"delivery_id"`
+	OrderID     uint        `json:"order_id"`
+	OrderNo     string      `json:"order_no"`
+	UserID      uint        `json:"user_id,omitempty"`
+	Currency    string      `json:"currency"`
+	TotalAmount string      `json:"total_amount"`
+	Items       []OrderItem `json:"items"`
+	Attempt     int         `json:"attempt"`
+	CallbackURL string      `json:"callback_url,omitempty"`
+	Timestamp   int64       `json:"timestamp"`
+}
+
+// SendRequest 包含一次签名 webhook 请求所需的数据。
+type SendRequest struct {
+	URL     string
+	Secret  string
+	Payload DispatchPayload
+}
+
+// SendResponse 是发码服务的原始响应。
+type SendResponse struct {
+	StatusCode int
+	Body       []byte
+}
+
+// DeliveryResult 是同步响应与异步回调共用的交付结果。
+type DeliveryResult struct {
+	Status       string       `json:"status"`
+	Payload      string       `json:"payload"`
+	DeliveryData jsonmap.JSON `json:"delivery_data"`
+}
+
+// CallbackInput 是异步回调请求的原始输入。
+type CallbackInput struct {
+	Token     string
+	Timestamp string
+	Signature string
+	Body      []byte
+}
@@ -0,0 +1,38 @@
+package domain
+
+import "time"
+
+const (
+	StatusPending          = "pending"
+	StatusAwaitingCallback = "awaiting_callback"
+	StatusDelivered        = "delivered"
+	StatusManualFallback   = "manual_fallback"
+	StatusClosed           = "closed"
+)
+
+// Delivery 记录单个（子）订单的 webhook 交付调度状态。
+type Delivery struct {
+	ID               uint       `gorm:"primarykey" json:"id"`
+	OrderID          uint       `gorm:"uniqueIndex;not null" json:"order_id"`
+	ProductID        uint       `gorm:"index;not null" json:"product_id"`
+	Status           string     `gorm:"type:varchar(20);not null;default:'pending';index" json:"status"`
+	Attempts         int        `gorm:"not null;default:0" json:"attempts"`
+	CallbackToken    string     `gorm:"type:varchar(64);uniqueIndex;not null" json:"-"`
+	CallbackDeadline *time.Time `json:"callback_deadline,omitempty"`
+	LastHTTPStatus   int        `gorm:"not null;default:0" json:"last_http_status"`
+	LastError        string     `gorm:"type:varchar(500)" json:"last_error"`
+	LastAttemptAt    *time.Time `json:"last_attempt_at,omitempty"`
+	DeliveredAt      *time.Time `json:"delivered_at,omitempty"`
+	CreatedAt        time.Time  `gorm:"index" json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+// TableName 指定表名
+func (Delivery) TableName() string {
+	return "fulfillment_webhook_deliveries"
+}
+
+// IsFinal 已交付或已关闭的记录不再调度。
+func (d *Delivery) IsFinal() bool {
+	return d.Status == StatusDelivered || d.Status == StatusClosed
+}
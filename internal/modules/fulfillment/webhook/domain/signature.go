@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+)
+
+const (
+	// HeaderTimestamp webhook 请求与回调的时间戳 header
+	HeaderTimestamp = "Dujiao-Next-Webhook-Timestamp"
+	// HeaderSignature webhook 请求与回调的签名 header
+	HeaderSignature = "Dujiao-Next-Webhook-Signature"
+	// MaxTimestampSkew 回调时间戳允许的最大偏差（秒）
+	MaxTimestampSkew = 300
+)
+
+// Sign 使用商品密钥生成 HMAC-SHA256 签名
+// signString = "{timestamp}.{body}"
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify 验证签名
+func Verify(secret, signature string, timestamp int64, body []byte) bool {
+	if secret == "" || signature == "" {
+		return false
+	}
+	expected := Sign(secret, timestamp, body)
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
@@ -0,0 +1,45 @@
+package fulfillmentadapter
+
+import (
+	"errors"
+
+	fulfillmentapp "github.com/dujiao-next/internal/modules/fulfillment/application"
+	fulfillmentdomain "github.com/dujiao-next/internal/modules/fulfillment/domain"
+	webhookcontract "github.com/dujiao-next/internal/modules/fulfillment/webhook/contract"
+	"github.com/dujiao-next/internal/shared/jsonmap"
+)
+
+// Creator 是交付上下文写入 webhook 交付的最小端口。
+type Creator interface {
+	CreateWebhook(orderID uint, payload string, deliveryData jsonmap.JSON) (*fulfillmentdomain.Fulfillment, error)
+}
+
+// Adapter 将交付用例错误映射为 webhook 合同错误。
+type Adapter struct {
+	creator Creator
+}
+
+var _ webhookcontract.Completer = (*Adapter)(nil)
+
+func New(creator Creator) *Adapter {
+	if creator == nil {
+		panic("webhook fulfillment completer: creator is nil")
+	}
+	return &Adapter{creator: creator}
+}
+
+func (a *Adapter) CompleteWebhook(orderID uint, payload string, deliveryData jsonmap.JSON) error {
+	_, err := a.creator.CreateWebhook(orderID, payload, deliveryData)
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, fulfillmentapp.ErrFulfillmentExists):
+		return webhookcontract.ErrAlreadyFulfilled
+	case errors.Is(err, fulfillmentapp.ErrOrderStatusInvalid):
+		return webhookcontract.ErrOrderNotPending
+	case errors.Is(err, fulfillmentapp.ErrFulfillmentInvalid):
+		return webhookcontract.ErrDeliveryInvalid
+	default:
+		return err
+	}
+}
@@ -0,0 +1,71 @@
+package gormstore
+
+import (
+	"errors"
+
+	webhookcontract "github.com/dujiao-next/internal/modules/fulfillment/webhook/contract"
+	webhookdomain "github.com/dujiao-next/internal/modules/fulfillment/webhook/domain"
+
+	"gorm.io/gorm"
+)
+
+// Store 是 webhook 交付记录的 GORM 仓储。
+type Store struct {
+	db *gorm.DB
+}
+
+var _ webhookcontract.Store = (*Store)(nil)
+
+// New 创建 webhook 交付记录仓储。
+func New(db *gorm.DB) *Store {
+	if db == nil {
+		panic("webhook delivery store: db is nil")
+	}
+	return &Store{db: db}
+}
+
+func (s *Store) GetByID(id uint) (*webhookdomain.Delivery, error) {
+	if id == 0 {
+		return nil, nil
+	}
+	return s.first(s.db.Where("id = ?", id))
+}
+
+func (s *Store) GetByOrderID(orderID uint) (*webhookdomain.Delivery, error) {
+	if orderID == 0 {
+		return nil, nil
+	}
+	return s.first(s.db.Where("order_id = ?", orderID))
+}
+
+func (s *Store) GetByCallbackToken(token string) (*webhookdomain.Delivery, error) {
+	if token == "" {
+		return nil, nil
+	}
+	return s.first(s.db.Where("callback_token = ?", token))
+}
+
+func (s *Store) first(query *gorm.DB) (*webhookdomain.Delivery, error) {
+	var delivery webhookdomain.Delivery
+	if err := query.First(&delivery).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func (s *Store) Create(delivery *webhookdomain.Delivery) error {
+	if delivery == nil {
+		return errors.New("webhook delivery is nil")
+	}
+	return s.db.Create(delivery).Error
+}
+
+func (s *Store) Update(delivery *webhookdomain.Delivery) error {
+	if delivery == nil || delivery.ID == 0 {
+		return errors.New("invalid webhook delivery")
+	}
+	return s.db.Save(delivery).Error
+}
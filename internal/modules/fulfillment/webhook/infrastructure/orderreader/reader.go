@@ -0,0 +1,56 @@
+package orderreader
+
+import (
+	"strings"
+
+	webhookcontract "github.com/dujiao-next/internal/modules/fulfillment/webhook/contract"
+	orderdomain "github.com/dujiao-next/internal/modules/order/domain"
+)
+
+// Source 是订单上下文暴露给防腐适配器的最小读取端口。
+type Source interface {
+	GetByID(id uint) (*orderdomain.Order, error)
+}
+
+// Reader 将订单持久化模型投影为 webhook 交付读模型。
+type Reader struct {
+	source Source
+}
+
+var _ webhookcontract.OrderReader = (*Reader)(nil)
+
+func New(source Source) *Reader {
+	if source == nil {
+		panic("webhook fulfillment order reader: source is nil")
+	}
+	return &Reader{source: source}
+}
+
+func (r *Reader) GetByID(id uint) (*webhookcontract.OrderSnapshot, error) {
+	order, err := r.source.GetByID(id)
+	if err != nil || order == nil {
+		return nil, err
+	}
+	snapshot := &webhookcontract.OrderSnapshot{
+		ID:          order.ID,
+		OrderNo:     order.OrderNo,
+		UserID:      order.UserID,
+		Status:      order.Status,
+		Currency:    order.Currency,
+		TotalAmount: order.TotalAmount.StringFixed(2),
+		Items:       make([]webhookcontract.OrderItem, 0, len(order.Items)),
+	}
+	for _, item := range order.Items {
+		skuCode, _ := item.SKUSnapshotJSON["sku_code"].(string)
+		snapshot.Items = append(snapshot.Items, webhookcontract.OrderItem{
+			ProductID:       item.ProductID,
+			SKUID:           item.SKUID,
+			SKUCode:         strings.TrimSpace(skuCode),
+			Title:           item.TitleJSON,
+			Quantity:        item.Quantity,
+			UnitPrice:       item.UnitPrice.StringFixed(2),
+			FulfillmentType: item.FulfillmentType,
+		})
+	}
+	return snapshot, nil
+}
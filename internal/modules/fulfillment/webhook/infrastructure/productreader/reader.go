@@ -0,0 +1,38 @@
+package productreader
+
+import (
+	"strconv"
+
+	productdomain "github.com/dujiao-next/internal/modules/catalog/product/domain"
+	webhookcontract "github.com/dujiao-next/internal/modules/fulfillment/webhook/contract"
+)
+
+// Source 是商品上下文暴露给防腐适配器的最小读取端口。
+type Source interface {
+	GetByID(id string) (*productdomain.Product, error)
+}
+
+// Reader 读取商品上配置的 webhook 地址与密钥。
+type Reader struct {
+	source Source
+}
+
+var _ webhookcontract.EndpointReader = (*Reader)(nil)
+
+func New(source Source) *Reader {
+	if source == nil {
+		panic("webhook fulfillment product reader: source is nil")
+	}
+	return &Reader{source: source}
+}
+
+func (r *Reader) GetByProductID(productID uint) (*webhookcontract.Endpoint, error) {
+	if productID == 0 {
+		return nil, nil
+	}
+	product, err := r.source.GetByID(strconv.FormatUint(uint64(productID), 10))
+	if err != nil || product == nil {
+		return nil, err
+	}
+	return &webhookcontract.Endpoint{URL: product.WebhookURL, Secret: product.WebhookSecret}, nil
+}
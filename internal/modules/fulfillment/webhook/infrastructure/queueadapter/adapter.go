@@ -0,0 +1,34 @@
+package queueadapter
+
+import (
+	"time"
+
+	webhookcontract "github.com/dujiao-next/internal/modules/fulfillment/webhook/contract"
+	"github.com/dujiao-next/internal/queue"
+
+	"github.com/hibiken/asynq"
+)
+
+// Adapter 将 webhook 调度端口映射到全局任务客户端。
+type Adapter struct {
+	client *queue.Client
+}
+
+var _ webhookcontract.DispatchQueue = (*Adapter)(nil)
+
+func New(client *queue.Client) *Adapter {
+	if client == nil {
+		panic("webhook fulfillment queue adapter: client is nil")
+	}
+	return &Adapter{client: client}
+}
+
+func (a *Adapter) EnqueueDispatch(deliveryID uint, delay time.Duration) error {
+	options := make([]asynq.Option, 0, 1)
+	if delay > 0 {
+		options = append(options, asynq.ProcessIn(delay))
+	}
+	return a.client.EnqueueFulfillmentWebhookDispatch(queue.FulfillmentWebhookDispatchPayload{
+		DeliveryID: deliveryID,
+	}, options...)
+}
@@ -0,0 +1,60 @@
+package webhookclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	webhookcontract "github.com/dujiao-next/internal/modules/fulfillment/webhook/contract"
+	webhookdomain "github.com/dujiao-next/internal/modules/fulfillment/webhook/domain"
+)
+
+// maxResponseBytes 发码服务响应体上限
+const maxResponseBytes = 1 << 20
+
+// Client 向发码服务发送签名的 webhook 请求。
+type Client struct {
+	httpClient *http.Client
+}
+
+var _ webhookcontract.Sender = (*Client)(nil)
+
+func New() *Client {
+	return NewWithHTTPClient(&http.Client{Timeout: 15 * time.Second})
+}
+
+func NewWithHTTPClient(client *http.Client) *Client {
+	if client == nil {
+		panic("webhook fulfillment client: http client is nil")
+	}
+	return &Client{httpClient: client}
+}
+
+func (c *Client) Send(ctx context.Context, request webhookcontract.SendRequest) (*webhookcontract.SendResponse, error) {
+	body, err := json.Marshal(request.Payload)
+	if err != nil {
+		return nil, err
+	}
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, request.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+	httpRequest.Header.Set(webhookdomain.HeaderTimestamp, strconv.FormatInt(request.Payload.Timestamp, 10))
+	httpRequest.Header.Set(webhookdomain.HeaderSignature, webhookdomain.Sign(request.Secret, request.Payload.Timestamp, body))
+
+	response, err := c.httpClient.Do(httpRequest)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	responseBody, err := io.ReadAll(io.LimitReader(response.Body, maxResponseBytes))
+	if err != nil {
+		return nil, err
+	}
+	return &webhookcontract.SendResponse{StatusCode: response.StatusCode, Body: responseBody}, nil
+}
@@ -0,0 +1,354 @@
+package integrationtest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/dujiao-next/internal/constants"
+	webhookapp "github.com/dujiao-next/internal/modules/fulfillment/webhook/application"
+	webhookcontract "github.com/dujiao-next/internal/modules/fulfillment/webhook/contract"
+	webhookdomain "github.com/dujiao-next/internal/modules/fulfillment/webhook/domain"
+	"github.com/dujiao-next/internal/modules/fulfillment/webhook/infrastructure/webhookclient"
+	"github.com/dujiao-next/internal/shared/jsonmap"
+)
+
+const testSecret = "webhook-secret-0123456789"
+
+var testNow = time.Unix(1_700_000_000, 0).UTC()
+
+type deliveryStoreStub struct {
+	nextID     uint
+	deliveries map[uint]*webhookdomain.Delivery
+}
+
+func newDeliveryStore() *deliveryStoreStub {
+	return &deliveryStoreStub{deliveries: map[uint]*webhookdomain.Delivery{}}
+}
+
+func (s *deliveryStoreStub) GetByID(id uint) (*webhookdomain.Delivery, error) {
+	return s.deliveries[id], nil
+}
+
+func (s *deliveryStoreStub) GetByOrderID(orderID uint) (*webhookdomain.Delivery, error) {
+	for _, delivery := range s.deliveries {
+		if delivery.OrderID == orderID {
+			return delivery, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *deliveryStoreStub) GetByCallbackToken(token string) (*webhookdomain.Delivery, error) {
+	for _, delivery := range s.deliveries {
+		if delivery.CallbackToken == token {
+			return delivery, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *deliveryStoreStub) Create(delivery *webhookdomain.Delivery) error {
+	s.nextID++
+	delivery.ID = s.nextID
+	s.deliveries[delivery.ID] = delivery
+	return nil
+}
+
+func (s *deliveryStoreStub) Update(delivery *webhookdomain.Delivery) error {
+	s.deliveries[delivery.ID] = delivery
+	return nil
+}
+
+type orderReaderStub struct {
+	order *webhookcontract.OrderSnapshot
+}
+
+func (r *orderReaderStub) GetByID(id uint) (*webhookcontract.OrderSnapshot, error) {
+	if r.order == nil || r.order.ID != id {
+		return nil, nil
+	}
+	return r.order, nil
+}
+
+type endpointReaderStub struct {
+	endpoint *webhookcontract.Endpoint
+}
+
+func (r endpointReaderStub) GetByProductID(uint) (*webhookcontract.Endpoint, error) {
+	return r.endpoint, nil
+}
+
+type senderStub struct {
+	statusCode int
+	body       string
+	err        error
+	requests   []webhookcontract.SendRequest
+}
+
+func (s *senderStub) Send(_ context.Context, request webhookcontract.SendRequest) (*webhookcontract.SendResponse, error) {
+	s.requests = append(s.requests, request)
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &webhookcontract.SendResponse{StatusCode: s.statusCode, Body: []byte(s.body)}, nil
+}
+
+type queuedDispatch struct {
+	deliveryID uint
+	delay      time.Duration
+}
+
+type dispatchQueueStub struct {
+	dispatches []queuedDispatch
+}
+
+func (q *dispatchQueueStub) EnqueueDispatch(deliveryID uint, delay time.Duration) error {
+	q.dispatches = append(q.dispatches, queuedDispatch{deliveryID: deliveryID, delay: delay})
+	return nil
+}
+
+type completerStub struct {
+	order   *webhookcontract.OrderSnapshot
+	payload string
+	calls   int
+}
+
+func (c *completerStub) CompleteWebhook(orderID uint, payload string, _ jsonmap.JSON) error {
+	if c.order.Status == constants.OrderStatusCompleted {
+		return webhookcontract.ErrAlreadyFulfilled
+	}
+	c.calls++
+	c.payload = payload
+	c.order.Status = constants.OrderStatusCompleted
+	return nil
+}
+
+type notifierStub struct {
+	orderIDs []uint
+}
+
+func (n *notifierStub) NotifyManualFulfillmentPending(orderID uint) error {
+	n.orderIDs = append(n.orderIDs, orderID)
+	return nil
+}
+
+type fixture struct {
+	service   *webhookapp.Service
+	store     *deliveryStoreStub
+	order     *webhookcontract.OrderSnapshot
+	sender    *senderStub
+	queue     *dispatchQueueStub
+	completer *completerStub
+	notifier  *notifierStub
+}
+
+func newFixture(sender *senderStub) *fixture {
+	order := &webhookcontract.OrderSnapshot{
+		ID:          7,
+		OrderNo:     "DJ-WEBHOOK-001",
+		UserID:      3,
+		Status:      constants.OrderStatusFulfilling,
+		Currency:    "CNY",
+		TotalAmount: "10.00",
+		Items: []webhookcontract.OrderItem{{
+			ProductID:       11,
+			SKUID:           12,
+			Quantity:        1,
+			UnitPrice:       "10.00",
+			FulfillmentType: constants.FulfillmentTypeWebhook,
+		}},
+	}
+	f := &fixture{
+		store:     newDeliveryStore(),
+		order:     order,
+		sender:    sender,
+		queue:     &dispatchQueueStub{},
+		completer: &completerStub{order: order},
+		notifier:  &notifierStub{},
+	}
+	f.service = webhookapp.NewService(webhookapp.Options{
+		Deliveries: f.store,
+		Orders:     &orderReaderStub{order: order},
+		Endpoints:  endpointReaderStub{endpoint: &webhookcontract.Endpoint{URL: "https://codes.example.test/issue", Secret: testSecret}},
+		Sender:     sender,
+		Queue:      f.queue,
+		Completer:  f.completer,
+		Notifier:   f.notifier,
+		SiteURL:    func() string { return "https://shop.example.test/" },
+		Now:        func() time.Time { return testNow },
+	})
+	return f
+}
+
+func (f *fixture) start(t *testing.T) *webhookdomain.Delivery {
+	t.Helper()
+	if err := f.service.StartForOrder(f.order.ID); err != nil {
+		t.Fatalf("StartForOrder() error = %v", err)
+	}
+	delivery, _ := f.store.GetByOrderID(f.order.ID)
+	if delivery == nil {
+		t.Fatal("delivery was not created")
+	}
+	if len(f.queue.dispatches) != 1 || f.queue.dispatches[0].delay != 0 {
+		t.Fatalf("initial dispatch = %#v", f.queue.dispatches)
+	}
+	return delivery
+}
+
+func signedCallback(token string, body []byte, secret string) webhookcontract.CallbackInput {
+	return webhookcontract.CallbackInput{
+		Token:     token,
+		Timestamp: strconv.FormatInt(testNow.Unix(), 10),
+		Signature: webhookdomain.Sign(secret, testNow.Unix(), body),
+		Body:      body,
+	}
+}
+
+func TestDispatchCompletesOrderWithSynchronousDelivery(t *testing.T) {
+	f := newFixture(&senderStub{statusCode: http.StatusOK, body: `{"payload":"CODE-001"}`})
+	delivery := f.start(t)
+
+	if err := f.service.Dispatch(context.Background(), delivery.ID); err != nil {
+		t.Fatalf("Dispatch() error = %v", err)
+	}
+	if delivery.Status != webhookdomain.StatusDelivered || delivery.DeliveredAt == nil || delivery.Attempts != 1 {
+		t.Fatalf("delivery = %#v", delivery)
+	}
+	if f.completer.calls != 1 || f.completer.payload != "CODE-001" || f.order.Status != constants.OrderStatusCompleted {
+		t.Fatalf("completer calls = %d payload = %q order status = %s", f.completer.calls, f.completer.payload, f.order.Status)
+	}
+	payload := f.sender.requests[0].Payload
+	if payload.Event != webhookcontract.EventFulfillmentRequested || payload.OrderNo != f.order.OrderNo {
+		t.Fatalf("payload = %#v", payload)
+	}
+	if payload.CallbackURL != "https://shop.example.test/api/v1/fulfillment/webhook/callback/"+delivery.CallbackToken {
+		t.Fatalf("callback url = %q", payload.CallbackURL)
+	}
+
+	// 重复调度不再发送请求
+	if err := f.service.Dispatch(context.Background(), delivery.ID); err != nil {
+		t.Fatalf("repeat Dispatch() error = %v", err)
+	}
+	if len(f.sender.requests) != 1 {
+		t.Fatalf("requests = %d, want 1", len(f.sender.requests))
+	}
+}
+
+func TestAcceptedDeliveryCompletesThroughSignedCallback(t *testing.T) {
+	f := newFixture(&senderStub{statusCode: http.StatusAccepted, body: `{"status":"accepted"}`})
+	delivery := f.start(t)
+
+	if err := f.service.Dispatch(context.Background(), delivery.ID); err != nil {
+		t.Fatalf("Dispatch() error = %v", err)
+	}
+	if delivery.Status != webhookdomain.StatusAwaitingCallback || delivery.CallbackDeadline == nil {
+		t.Fatalf("delivery = %#v", delivery)
+	}
+	if last := f.queue.dispatches[len(f.queue.dispatches)-1]; last.delay != 24*time.Hour {
+		t.Fatalf("deadline dispatch = %#v", last)
+	}
+
+	body := []byte(`{"payload":"CODE-ASYNC","delivery_data":{"account":"demo"}}`)
+	err := f.service.HandleCallback(signedCallback(delivery.CallbackToken, body, "wrong-secret-0123456789"))
+	if !errors.Is(err, webhookcontract.ErrSignatureInvalid) {
+		t.Fatalf("HandleCallback(bad signature) error = %v", err)
+	}
+	if f.completer.calls != 0 {
+		t.Fatalf("bad signature must not complete the order")
+	}
+	if err := f.service.HandleCallback(signedCallback("unknown-token", body, testSecret)); !errors.Is(err, webhookcontract.ErrDeliveryNotFound) {
+		t.Fatalf("HandleCallback(unknown token) error = %v", err)
+	}
+
+	if err := f.service.HandleCallback(signedCallback(delivery.CallbackToken, body, testSecret)); err != nil {
+		t.Fatalf("HandleCallback() error = %v", err)
+	}
+	if delivery.Status != webhookdomain.StatusDelivered || f.completer.payload != "CODE-ASYNC" {
+		t.Fatalf("delivery = %#v payload = %q", delivery, f.completer.payload)
+	}
+	// 重复回调幂等
+	if err := f.service.HandleCallback(signedCallback(delivery.CallbackToken, body, testSecret)); err != nil {
+		t.Fatalf("repeat HandleCallback() error = %v", err)
+	}
+	if f.completer.calls != 1 {
+		t.Fatalf("completer calls = %d, want 1", f.completer.calls)
+	}
+}
+
+func TestRepeatedFailuresFallBackToManualFulfillment(t *testing.T) {
+	f := newFixture(&senderStub{statusCode: http.StatusBadGateway, body: "upstream down"})
+	delivery := f.start(t)
+
+	for attempt := 1; attempt <= 5; attempt++ {
+		if err := f.service.Dispatch(context.Background(), delivery.ID); err != nil {
+			t.Fatalf("Dispatch(attempt %d) error = %v", attempt, err)
+		}
+	}
+	if delivery.Status != webhookdomain.StatusManualFallback || delivery.Attempts != 5 || delivery.LastHTTPStatus != http.StatusBadGateway {
+		t.Fatalf("delivery = %#v", delivery)
+	}
+	wantDelays := []time.Duration{0, 30 * time.Second, 60 * time.Second, 120 * time.Second, 300 * time.Second}
+	if len(f.queue.dispatches) != len(wantDelays) {
+		t.Fatalf("dispatches = %#v", f.queue.dispatches)
+	}
+	for i, want := range wantDelays {
+		if f.queue.dispatches[i].delay != want {
+			t.Fatalf("dispatch %d delay = %s, want %s", i, f.queue.dispatches[i].delay, want)
+		}
+	}
+	if len(f.notifier.orderIDs) != 1 || f.notifier.orderIDs[0] != f.order.ID {
+		t.Fatalf("notified orders = %#v", f.notifier.orderIDs)
+	}
+	if f.order.Status != constants.OrderStatusFulfilling {
+		t.Fatalf("order status = %s, want fulfilling", f.order.Status)
+	}
+
+	// 转入人工后不再自动重试
+	if err := f.service.Dispatch(context.Background(), delivery.ID); err != nil {
+		t.Fatalf("Dispatch() after fallback error = %v", err)
+	}
+	if len(f.sender.requests) != 5 {
+		t.Fatalf("requests = %d, want 5", len(f.sender.requests))
+	}
+}
+
+func TestWebhookClientSignsRequestBody(t *testing.T) {
+	var received webhookcontract.DispatchPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		timestamp, err := strconv.ParseInt(r.Header.Get(webhookdomain.HeaderTimestamp), 10, 64)
+		if err != nil || !webhookdomain.Verify(testSecret, r.Header.Get(webhookdomain.HeaderSignature), timestamp, body) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.Unmarshal(body, &received)
+		_, _ = w.Write([]byte(`{"payload":"CODE-HTTP"}`))
+	}))
+	defer server.Close()
+
+	response, err := webhookclient.New().Send(context.Background(), webhookcontract.SendRequest{
+		URL:    server.URL,
+		Secret: testSecret,
+		Payload: webhookcontract.DispatchPayload{
+			Event:     webhookcontract.EventFulfillmentRequested,
+			OrderNo:   "DJ-WEBHOOK-HTTP",
+			Timestamp: testNow.Unix(),
+		},
+	})
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if response.StatusCode != http.StatusOK || string(response.Body) != `{"payload":"CODE-HTTP"}` {
+		t.Fatalf("response = %d %s", response.StatusCode, response.Body)
+	}
+	if received.OrderNo != "DJ-WEBHOOK-HTTP" {
+		t.Fatalf("received payload = %#v", received)
+	}
+}
@@ -0,0 +1,64 @@
+package webhookhttp
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/dujiao-next/internal/logger"
+	webhookcontract "github.com/dujiao-next/internal/modules/fulfillment/webhook/contract"
+	webhookdomain "github.com/dujiao-next/internal/modules/fulfillment/webhook/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxCallbackBodyBytes 异步回调请求体上限
+const maxCallbackBodyBytes = 1 << 20
+
+// Service 是 webhook 回调端点所需的最小用例接口。
+type Service interface {
+	HandleCallback(input webhookcontract.CallbackInput) error
+}
+
+// Handler 接收发码服务的异步交付回调。
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	if service == nil {
+		panic("webhook fulfillment handler: service is nil")
+	}
+	return &Handler{service: service}
+}
+
+// HandleCallback POST /api/v1/fulfillment/webhook/callback/:token
+// 以 HTTP 状态码区分结果：2xx 表示已受理，4xx 表示无需重试，5xx 可重试。
+func (h *Handler) HandleCallback(c *gin.Context) {
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxCallbackBodyBytes))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"ok": false, "message": "failed to read request body"})
+		return
+	}
+	err = h.service.HandleCallback(webhookcontract.CallbackInput{
+		Token:     c.Param("token"),
+		Timestamp: c.GetHeader(webhookdomain.HeaderTimestamp),
+		Signature: c.GetHeader(webhookdomain.HeaderSignature),
+		Body:      body,
+	})
+	switch {
+	case err == nil:
+		c.JSON(http.StatusOK, gin.H{"ok": true})
+	case errors.Is(err, webhookcontract.ErrDeliveryNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"ok": false, "message": "delivery not found"})
+	case errors.Is(err, webhookcontract.ErrSignatureInvalid):
+		c.JSON(http.StatusUnauthorized, gin.H{"ok": false, "message": "invalid signature"})
+	case errors.Is(err, webhookcontract.ErrDeliveryInvalid):
+		c.JSON(http.StatusBadRequest, gin.H{"ok": false, "message": "invalid delivery"})
+	case errors.Is(err, webhookcontract.ErrDeliveryClosed):
+		c.JSON(http.StatusConflict, gin.H{"ok": false, "message": "delivery closed"})
+	default:
+		logger.Errorw("fulfillment_webhook_callback_failed", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"ok": false, "message": "internal error"})
+	}
+}
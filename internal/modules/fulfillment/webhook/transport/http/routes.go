@@ -0,0 +1,11 @@
+package webhookhttp
+
+import "github.com/gin-gonic/gin"
+
+// RegisterCallbackRoutes 注册发码服务异步回调路由，鉴权由请求签名完成。
+func RegisterCallbackRoutes(apiV1 gin.IRoutes, handler *Handler) {
+	if apiV1 == nil || handler == nil {
+		panic("webhook fulfillment routes: required dependency is nil")
+	}
+	apiV1.POST("/fulfillment/webhook/callback/:token", handler.HandleCallback)
+}
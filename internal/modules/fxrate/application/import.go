@@ -0,0 +1,147 @@
+package application
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	fxratecontract "github.com/dujiao-next/internal/modules/fxrate/contract"
+	fxratedomain "github.com/dujiao-next/internal/modules/fxrate/domain"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	importFormatCSV  = "csv"
+	importFormatJSON = "json"
+	importMaxRows    = 500
+)
+
+type importedRate struct {
+	Currency string
+	Rate     decimal.Decimal
+}
+
+// ImportRates 从汇率文件批量导入；整份文件校验通过后才写入，新币种默认启用。
+func (s *Service) ImportRates(input ImportRatesInput) (int, error) {
+	if input.Reader == nil {
+		return 0, fxratecontract.ErrImportInvalid
+	}
+	var (
+		rows []importedRate
+		err  error
+	)
+	switch strings.ToLower(strings.TrimSpace(input.Format)) {
+	case importFormatJSON:
+		rows, err = s.parseJSONRates(input.Reader)
+	case importFormatCSV, "":
+		rows, err = parseCSVRates(input.Reader)
+	default:
+		return 0, fxratecontract.ErrImportInvalid
+	}
+	if err != nil {
+		return 0, err
+	}
+	if len(rows) == 0 || len(rows) > importMaxRows {
+		return 0, fxratecontract.ErrImportInvalid
+	}
+
+	now := time.Now()
+	var updatedBy *uint
+	if input.AdminID != 0 {
+		adminID := input.AdminID
+		updatedBy = &adminID
+	}
+	seen := make(map[string]struct{}, len(rows))
+	rates := make([]fxratedomain.Rate, 0, len(rows))
+	for idx, row := range rows {
+		currency, err := s.normalizeQuoteCurrency(row.Currency)
+		if err != nil {
+			return 0, fmt.Errorf("%w: row %d currency %q", fxratecontract.ErrImportInvalid, idx+1, row.Currency)
+		}
+		if row.Rate.LessThanOrEqual(decimal.Zero) {
+			return 0, fmt.Errorf("%w: row %d rate must be positive", fxratecontract.ErrImportInvalid, idx+1)
+		}
+		if _, ok := seen[currency]; ok {
+			return 0, fmt.Errorf("%w: duplicate currency %s", fxratecontract.ErrImportInvalid, currency)
+		}
+		seen[currency] = struct{}{}
+		rates = append(rates, fxratedomain.Rate{
+			Currency:  currency,
+			Rate:      row.Rate,
+			Enabled:   true,
+			Source:    fxratedomain.SourceImport,
+			UpdatedBy: updatedBy,
+			CreatedAt: now,
+			UpdatedAt: now,
+		})
+	}
+	if err := s.store.UpsertBatch(rates); err != nil {
+		return 0, err
+	}
+	return len(rates), nil
+}
+
+// parseCSVRates 解析 currency,rate 两列，首行为表头时自动跳过。
+func parseCSVRates(reader io.Reader) ([]importedRate, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.TrimLeadingSpace = true
+	csvReader.FieldsPerRecord = -1
+	var rows []importedRate
+	first := true
+	for {
+		record, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", fxratecontract.ErrImportInvalid, err)
+		}
+		if len(record) == 0 || (len(record) == 1 && strings.TrimSpace(record[0]) == "") {
+			continue
+		}
+		if len(record) < 2 {
+			return nil, fmt.Errorf("%w: expected currency,rate", fxratecontract.ErrImportInvalid)
+		}
+		currency := strings.TrimSpace(strings.TrimPrefix(record[0], "\ufeff"))
+		rawRate := strings.TrimSpace(record[1])
+		if first {
+			first = false
+			if strings.EqualFold(currency, "currency") {
+				continue
+			}
+		}
+		rate, err := decimal.NewFromString(rawRate)
+		if err != nil {
+			return nil, fmt.Errorf("%w: rate %q", fxratecontract.ErrImportInvalid, rawRate)
+		}
+		rows = append(rows, importedRate{Currency: currency, Rate: rate})
+	}
+	return rows, nil
+}
+
+// parseJSONRates 解析 {"base":"CNY","rates":{"USD":0.14}}；base 缺省视为站点币种。
+func (s *Service) parseJSONRates(reader io.Reader) ([]importedRate, error) {
+	var payload struct {
+		Base  string                     `json:"base"`
+		Rates map[string]decimal.Decimal `json:"rates"`
+	}
+	if err := json.NewDecoder(reader).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("%w: %v", fxratecontract.ErrImportInvalid, err)
+	}
+	if base := fxratedomain.NormalizeCurrency(payload.Base); base != "" && base != s.BaseCurrency() {
+		return nil, fxratecontract.ErrImportBaseMismatch
+	}
+	rows := make([]importedRate, 0, len(payload.Rates))
+	for currency, rate := range payload.Rates {
+		if fxratedomain.NormalizeCurrency(currency) == s.BaseCurrency() {
+			continue
+		}
+		rows = append(rows, importedRate{Currency: currency, Rate: rate})
+	}
+	return rows, nil
+}
@@ -0,0 +1,112 @@
+package application
+
+import (
+	"time"
+
+	fxratecontract "github.com/dujiao-next/internal/modules/fxrate/contract"
+	fxratedomain "github.com/dujiao-next/internal/modules/fxrate/domain"
+
+	"github.com/shopspring/decimal"
+)
+
+// ListRates 后台汇率列表。
+func (s *Service) ListRates() ([]fxratedomain.Rate, error) {
+	return s.store.List()
+}
+
+// UpsertRate 手工维护单个币种汇率；基准币种本身不入表。
+func (s *Service) UpsertRate(input UpsertRateInput) (*fxratedomain.Rate, error) {
+	currency, err := s.normalizeQuoteCurrency(input.Currency)
+	if err != nil {
+		return nil, err
+	}
+	if input.Rate.LessThanOrEqual(decimal.Zero) {
+		return nil, fxratecontract.ErrRateInvalid
+	}
+	rate, err := s.store.GetByCurrency(currency)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if rate == nil {
+		rate = &fxratedomain.Rate{Currency: currency, Enabled: true, CreatedAt: now}
+	}
+	rate.Rate = input.Rate
+	rate.Source = fxratedomain.SourceManual
+	if input.Enabled != nil {
+		rate.Enabled = *input.Enabled
+	}
+	if input.AdminID != 0 {
+		adminID := input.AdminID
+		rate.UpdatedBy = &adminID
+	}
+	rate.UpdatedAt = now
+	if err := s.store.Save(rate); err != nil {
+		return nil, err
+	}
+	return rate, nil
+}
+
+// DeleteRate 删除币种汇率；已下单订单保留各自的汇率快照，不受影响。
+func (s *Service) DeleteRate(id uint) error {
+	rate, err := s.store.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if rate == nil {
+		return fxratecontract.ErrRateNotFound
+	}
+	return s.store.Delete(id)
+}
+
+// DisplayCurrencies 前台可选币种：基准币种在首位，其后为已启用的汇率币种。
+func (s *Service) DisplayCurrencies() ([]DisplayCurrency, error) {
+	base := s.BaseCurrency()
+	rates, err := s.store.ListEnabled()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]DisplayCurrency, 0, len(rates)+1)
+	result = append(result, DisplayCurrency{Currency: base, Rate: decimal.NewFromInt(1), IsBase: true})
+	for _, rate := range rates {
+		if rate.Currency == base || rate.Rate.LessThanOrEqual(decimal.Zero) {
+			continue
+		}
+		result = append(result, DisplayCurrency{Currency: rate.Currency, Rate: rate.Rate})
+	}
+	return result, nil
+}
+
+// Quote 返回下单币种相对基准币种的汇率；空币种或基准币种返回 1。
+func (s *Service) Quote(currency string) (string, decimal.Decimal, error) {
+	base := s.BaseCurrency()
+	normalized := fxratedomain.NormalizeCurrency(currency)
+	if normalized == "" || normalized == base {
+		return base, decimal.NewFromInt(1), nil
+	}
+	rate, err := s.store.GetByCurrency(normalized)
+	if err != nil {
+		return "", decimal.Zero, err
+	}
+	if rate == nil || !rate.Enabled || rate.Rate.LessThanOrEqual(decimal.Zero) {
+		return "", decimal.Zero, fxratecontract.ErrCurrencyUnsupported
+	}
+	return base, rate.Rate, nil
+}
+
+// ToBase 按当前汇率把指定币种金额折算为基准币种金额。
+func (s *Service) ToBase(amount decimal.Decimal, currency string) (decimal.Decimal, error) {
+	_, rate, err := s.Quote(currency)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return fxratedomain.ToBase(amount, rate), nil
+}
+
+func (s *Service) normalizeQuoteCurrency(raw string) (string, error) {
+	currency := fxratedomain.NormalizeCurrency(raw)
+	if !fxratedomain.IsCurrencyCode(currency) || currency == s.BaseCurrency() {
+		return "", fxratecontract.ErrCurrencyInvalid
+	}
+	return currency, nil
+}
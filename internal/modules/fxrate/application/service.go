@@ -0,0 +1,40 @@
+package application
+
+import (
+	"github.com/dujiao-next/internal/constants"
+	fxratecontract "github.com/dujiao-next/internal/modules/fxrate/contract"
+	fxratedomain "github.com/dujiao-next/internal/modules/fxrate/domain"
+)
+
+// Service 汇率表与多币种报价用例。
+type Service struct {
+	store    fxratecontract.Store
+	currency fxratecontract.CurrencyProvider
+}
+
+// Options 组装汇率用例依赖。
+type Options struct {
+	Store    fxratecontract.Store
+	Currency fxratecontract.CurrencyProvider
+}
+
+func NewService(opts Options) *Service {
+	if opts.Store == nil {
+		panic("fxrate service: store is nil")
+	}
+	return &Service{
+		store:    opts.Store,
+		currency: opts.Currency,
+	}
+}
+
+// BaseCurrency 返回站点基准币种，所有汇率均相对该币种。
+func (s *Service) BaseCurrency() string {
+	if s.currency == nil {
+		return constants.SiteCurrencyDefault
+	}
+	if base := fxratedomain.NormalizeCurrency(s.currency.SiteCurrency()); base != "" {
+		return base
+	}
+	return constants.SiteCurrencyDefault
+}
@@ -0,0 +1,29 @@
+package application
+
+import (
+	"io"
+
+	"github.com/shopspring/decimal"
+)
+
+// UpsertRateInput 后台新增/修改单个币种汇率。
+type UpsertRateInput struct {
+	Currency string
+	Rate     decimal.Decimal
+	Enabled  *bool
+	AdminID  uint
+}
+
+// ImportRatesInput 从汇率文件批量导入（csv: currency,rate；json: {"base":"CNY","rates":{"USD":0.14}}）。
+type ImportRatesInput struct {
+	Reader  io.Reader
+	Format  string
+	AdminID uint
+}
+
+// DisplayCurrency 前台可选的展示币种。
+type DisplayCurrency struct {
+	Currency string          `json:"currency"`
+	Rate     decimal.Decimal `json:"rate"`
+	IsBase   bool            `json:"is_base"`
+}
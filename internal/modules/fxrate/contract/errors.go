@@ -0,0 +1,12 @@
+package contract
+
+import "errors"
+
+var (
+	ErrCurrencyInvalid     = errors.New("fx currency invalid")
+	ErrRateInvalid         = errors.New("fx rate invalid")
+	ErrRateNotFound        = errors.New("fx rate not found")
+	ErrCurrencyUnsupported = errors.New("fx currency unsupported")
+	ErrImportInvalid       = errors.New("fx rate import invalid")
+	ErrImportBaseMismatch  = errors.New("fx rate import base currency mismatch")
+)
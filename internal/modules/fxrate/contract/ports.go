@@ -0,0 +1,22 @@
+package contract
+
+import (
+	fxratedomain "github.com/dujiao-next/internal/modules/fxrate/domain"
+)
+
+// Store 是汇率表持久化端口。
+type Store interface {
+	List() ([]fxratedomain.Rate, error)
+	ListEnabled() ([]fxratedomain.Rate, error)
+	GetByID(id uint) (*fxratedomain.Rate, error)
+	GetByCurrency(currency string) (*fxratedomain.Rate, error)
+	Save(rate *fxratedomain.Rate) error
+	Delete(id uint) error
+	// UpsertBatch 按币种批量写入汇率，已存在的币种只更新汇率与来源。
+	UpsertBatch(rates []fxratedomain.Rate) error
+}
+
+// CurrencyProvider 是站点基准币种读取端口。
+type CurrencyProvider interface {
+	SiteCurrency() string
+}
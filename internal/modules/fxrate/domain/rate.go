@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// 汇率来源
+const (
+	SourceManual = "manual" // 后台手工维护
+	SourceImport = "import" // 汇率文件导入
+)
+
+// Rate 站点汇率：1 单位基准币种（站点币种）= Rate 单位 Currency。
+type Rate struct {
+	ID        uint            `gorm:"primarykey" json:"id"`                                  // 主键
+	Currency  string          `gorm:"type:varchar(16);uniqueIndex;not null" json:"currency"` // 目标币种（ISO 4217 大写）
+	Rate      decimal.Decimal `gorm:"type:decimal(20,8);not null" json:"rate"`               // 相对基准币种的汇率
+	Enabled   bool            `gorm:"not null;default:true;index" json:"enabled"`            // 是否允许前台选择为展示/下单币种
+	Source    string          `gorm:"type:varchar(16);not null" json:"source"`               // 汇率来源
+	UpdatedBy *uint           `gorm:"index" json:"updated_by,omitempty"`                     // 最后修改管理员ID（导入时同样记录）
+	CreatedAt time.Time       `gorm:"index" json:"created_at"`                               // 创建时间
+	UpdatedAt time.Time       `gorm:"index" json:"updated_at"`                               // 更新时间
+}
+
+// TableName 指定表名
+func (Rate) TableName() string {
+	return "fx_rates"
+}
+
+// NormalizeCurrency 统一币种代码为去空白大写形式。
+func NormalizeCurrency(currency string) string {
+	return strings.ToUpper(strings.TrimSpace(currency))
+}
+
+// IsCurrencyCode 判断是否为 3 位字母币种代码。
+func IsCurrencyCode(currency string) bool {
+	if len(currency) != 3 {
+		return false
+	}
+	for _, r := range currency {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// FromBase 基准币种金额换算为目标币种金额（保留 2 位小数）。
+func FromBase(amount, rate decimal.Decimal) decimal.Decimal {
+	return amount.Mul(rate).Round(2)
+}
+
+// ToBase 目标币种金额折算回基准币种金额（保留 2 位小数）；汇率非法时原样返回。
+func ToBase(amount, rate decimal.Decimal) decimal.Decimal {
+	if rate.LessThanOrEqual(decimal.Zero) {
+		return amount.Round(2)
+	}
+	return amount.Div(rate).Round(2)
+}
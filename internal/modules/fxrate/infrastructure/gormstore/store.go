@@ -0,0 +1,106 @@
+package gormstore
+
+import (
+	"errors"
+
+	fxratedomain "github.com/dujiao-next/internal/modules/fxrate/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Store 是汇率表仓储端口的 GORM 实现。
+type Store struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) *Store {
+	return &Store{db: db}
+}
+
+// List 全部汇率，按币种排序
+func (r *Store) List() ([]fxratedomain.Rate, error) {
+	var rates []fxratedomain.Rate
+	if err := r.db.Order("currency asc").Find(&rates).Error; err != nil {
+		return nil, err
+	}
+	return rates, nil
+}
+
+// ListEnabled 前台可选的汇率
+func (r *Store) ListEnabled() ([]fxratedomain.Rate, error) {
+	var rates []fxratedomain.Rate
+	if err := r.db.Where("enabled = ?", true).Order("currency asc").Find(&rates).Error; err != nil {
+		return nil, err
+	}
+	return rates, nil
+}
+
+func (r *Store) GetByID(id uint) (*fxratedomain.Rate, error) {
+	if id == 0 {
+		return nil, nil
+	}
+	var rate fxratedomain.Rate
+	if err := r.db.First(&rate, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &rate, nil
+}
+
+func (r *Store) GetByCurrency(currency string) (*fxratedomain.Rate, error) {
+	if currency == "" {
+		return nil, nil
+	}
+	var rate fxratedomain.Rate
+	if err := r.db.Where("currency = ?", currency).First(&rate).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &rate, nil
+}
+
+func (r *Store) Save(rate *fxratedomain.Rate) error {
+	if rate == nil {
+		return errors.New("fx rate is nil")
+	}
+	if rate.ID != 0 {
+		return r.db.Save(rate).Error
+	}
+	requestedEnabled := rate.Enabled
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(rate).Error; err != nil {
+			return err
+		}
+		if requestedEnabled {
+			return nil
+		}
+		// Enabled 带有 default:true，Create 会把 false 当零值回填为 true，这里显式改回。
+		if err := tx.Model(rate).UpdateColumn("enabled", false).Error; err != nil {
+			return err
+		}
+		rate.Enabled = false
+		return nil
+	})
+}
+
+func (r *Store) Delete(id uint) error {
+	return r.db.Delete(&fxratedomain.Rate{}, id).Error
+}
+
+// UpsertBatch 按币种唯一键批量写入；已存在的币种保留启用状态，只覆盖汇率与来源。
+func (r *Store) UpsertBatch(rates []fxratedomain.Rate) error {
+	if len(rates) == 0 {
+		return nil
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "currency"}},
+			DoUpdates: clause.AssignmentColumns([]string{"rate", "source", "updated_by", "updated_at"}),
+		}).Create(&rates).Error
+	})
+}
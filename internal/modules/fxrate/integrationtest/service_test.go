@@ -0,0 +1,101 @@
+package integrationtest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	fxrateapp "github.com/dujiao-next/internal/modules/fxrate/application"
+	fxratecontract "github.com/dujiao-next/internal/modules/fxrate/contract"
+	fxratedomain "github.com/dujiao-next/internal/modules/fxrate/domain"
+	fxrategormstore "github.com/dujiao-next/internal/modules/fxrate/infrastructure/gormstore"
+
+	"github.com/glebarez/sqlite"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+type fxTestCurrency struct{}
+
+func (fxTestCurrency) SiteCurrency() string { return "CNY" }
+
+func setupFXRateTest(t *testing.T) *fxrateapp.Service {
+	t.Helper()
+	dsn := fmt.Sprintf("file:fxrate_service_test_%d?mode=memory&cache=shared", time.Now().UnixNano())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite failed: %v", err)
+	}
+	if err := db.AutoMigrate(&fxratedomain.Rate{}); err != nil {
+		t.Fatalf("auto migrate failed: %v", err)
+	}
+	return fxrateapp.NewService(fxrateapp.Options{Store: fxrategormstore.New(db), Currency: fxTestCurrency{}})
+}
+
+func TestFXRateQuoteUsesEnabledRatesOnly(t *testing.T) {
+	service := setupFXRateTest(t)
+	if _, err := service.UpsertRate(fxrateapp.UpsertRateInput{Currency: "usd", Rate: decimal.RequireFromString("0.14"), AdminID: 1}); err != nil {
+		t.Fatalf("upsert usd: %v", err)
+	}
+	disabled := false
+	if _, err := service.UpsertRate(fxrateapp.UpsertRateInput{Currency: "EUR", Rate: decimal.RequireFromString("0.13"), Enabled: &disabled}); err != nil {
+		t.Fatalf("upsert eur: %v", err)
+	}
+	if _, err := service.UpsertRate(fxrateapp.UpsertRateInput{Currency: "USDT", Rate: decimal.NewFromInt(1)}); !errors.Is(err, fxratecontract.ErrCurrencyInvalid) {
+		t.Fatalf("expected invalid currency, got %v", err)
+	}
+	if _, err := service.UpsertRate(fxrateapp.UpsertRateInput{Currency: "JPY", Rate: decimal.Zero}); !errors.Is(err, fxratecontract.ErrRateInvalid) {
+		t.Fatalf("expected invalid rate, got %v", err)
+	}
+
+	base, rate, err := service.Quote("usd")
+	if err != nil || base != "CNY" || !rate.Equal(decimal.RequireFromString("0.14")) {
+		t.Fatalf("unexpected usd quote: %s %s %v", base, rate, err)
+	}
+	if _, rate, err := service.Quote(""); err != nil || !rate.Equal(decimal.NewFromInt(1)) {
+		t.Fatalf("empty currency should quote base, got %s %v", rate, err)
+	}
+	if _, _, err := service.Quote("EUR"); !errors.Is(err, fxratecontract.ErrCurrencyUnsupported) {
+		t.Fatalf("disabled rate must be unsupported, got %v", err)
+	}
+
+	currencies, err := service.DisplayCurrencies()
+	if err != nil {
+		t.Fatalf("display currencies: %v", err)
+	}
+	if len(currencies) != 2 || currencies[0].Currency != "CNY" || !currencies[0].IsBase || currencies[1].Currency != "USD" {
+		t.Fatalf("unexpected display currencies: %+v", currencies)
+	}
+}
+
+func TestFXRateImportCSVAndJSON(t *testing.T) {
+	service := setupFXRateTest(t)
+	csv := "\ufeffcurrency,rate\nUSD,0.14\nhkd,1.09\n"
+	count, err := service.ImportRates(fxrateapp.ImportRatesInput{Reader: strings.NewReader(csv), Format: "csv", AdminID: 1})
+	if err != nil || count != 2 {
+		t.Fatalf("import csv: count=%d err=%v", count, err)
+	}
+	json := `{"base":"CNY","rates":{"USD":0.15,"EUR":0.13}}`
+	count, err = service.ImportRates(fxrateapp.ImportRatesInput{Reader: strings.NewReader(json), Format: "json", AdminID: 1})
+	if err != nil || count != 2 {
+		t.Fatalf("import json: count=%d err=%v", count, err)
+	}
+	rates, err := service.ListRates()
+	if err != nil || len(rates) != 3 {
+		t.Fatalf("expected 3 rates, got %d err=%v", len(rates), err)
+	}
+	if _, rate, _ := service.Quote("USD"); !rate.Equal(decimal.RequireFromString("0.15")) {
+		t.Fatalf("json import should overwrite usd, got %s", rate)
+	}
+
+	mismatch := `{"base":"USD","rates":{"EUR":0.9}}`
+	if _, err := service.ImportRates(fxrateapp.ImportRatesInput{Reader: strings.NewReader(mismatch), Format: "json"}); !errors.Is(err, fxratecontract.ErrImportBaseMismatch) {
+		t.Fatalf("expected base mismatch, got %v", err)
+	}
+	duplicated := "USD,0.1\nusd,0.2\n"
+	if _, err := service.ImportRates(fxrateapp.ImportRatesInput{Reader: strings.NewReader(duplicated), Format: "csv"}); !errors.Is(err, fxratecontract.ErrImportInvalid) {
+		t.Fatalf("expected duplicated import to fail, got %v", err)
+	}
+}
@@ -0,0 +1,172 @@
+package fxratehttp
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+
+	fxrateapp "github.com/dujiao-next/internal/modules/fxrate/application"
+	fxratecontract "github.com/dujiao-next/internal/modules/fxrate/contract"
+	fxratedomain "github.com/dujiao-next/internal/modules/fxrate/domain"
+	"github.com/dujiao-next/internal/platform/http/ginutil"
+	"github.com/dujiao-next/internal/platform/http/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
+)
+
+// AdminService 是后台汇率表管理端口。
+type AdminService interface {
+	BaseCurrency() string
+	ListRates() ([]fxratedomain.Rate, error)
+	UpsertRate(input fxrateapp.UpsertRateInput) (*fxratedomain.Rate, error)
+	DeleteRate(id uint) error
+	ImportRates(input fxrateapp.ImportRatesInput) (int, error)
+}
+
+// PublicService 是前台可选币种读取端口。
+type PublicService interface {
+	DisplayCurrencies() ([]fxrateapp.DisplayCurrency, error)
+}
+
+// AdminHandler 处理后台汇率表请求。
+type AdminHandler struct {
+	rates AdminService
+}
+
+func NewAdminHandler(rates AdminService) *AdminHandler {
+	if rates == nil {
+		panic("fxrate admin handler: rates is nil")
+	}
+	return &AdminHandler{rates: rates}
+}
+
+// PublicHandler 处理前台币种列表请求。
+type PublicHandler struct {
+	rates PublicService
+}
+
+func NewPublicHandler(rates PublicService) *PublicHandler {
+	if rates == nil {
+		panic("fxrate public handler: rates is nil")
+	}
+	return &PublicHandler{rates: rates}
+}
+
+type upsertRateRequest struct {
+	Currency string          `json:"currency" binding:"required"`
+	Rate     decimal.Decimal `json:"rate" binding:"required"`
+	Enabled  *bool           `json:"enabled"`
+}
+
+func respondFXRateError(c *gin.Context, err error, fallbackKey string) {
+	switch {
+	case errors.Is(err, fxratecontract.ErrCurrencyInvalid):
+		ginutil.RespondError(c, response.CodeBadRequest, "error.fx_currency_invalid", nil)
+	case errors.Is(err, fxratecontract.ErrRateInvalid):
+		ginutil.RespondError(c, response.CodeBadRequest, "error.fx_rate_invalid", nil)
+	case errors.Is(err, fxratecontract.ErrRateNotFound):
+		ginutil.RespondError(c, response.CodeNotFound, "error.fx_rate_not_found", nil)
+	case errors.Is(err, fxratecontract.ErrImportBaseMismatch):
+		ginutil.RespondError(c, response.CodeBadRequest, "error.fx_rate_import_base_mismatch", nil)
+	case errors.Is(err, fxratecontract.ErrImportInvalid):
+		ginutil.RespondError(c, response.CodeBadRequest, "error.fx_rate_import_invalid", err)
+	default:
+		ginutil.RespondError(c, response.CodeInternal, fallbackKey, err)
+	}
+}
+
+// ListRates 汇率列表（附带基准币种）
+func (h *AdminHandler) ListRates(c *gin.Context) {
+	rates, err := h.rates.ListRates()
+	if err != nil {
+		respondFXRateError(c, err, "error.fx_rate_fetch_failed")
+		return
+	}
+	response.Success(c, gin.H{
+		"base_currency": h.rates.BaseCurrency(),
+		"items":         rates,
+	})
+}
+
+// UpsertRate 新增或修改币种汇率
+func (h *AdminHandler) UpsertRate(c *gin.Context) {
+	adminID, ok := ginutil.GetAdminID(c)
+	if !ok {
+		return
+	}
+	var req upsertRateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ginutil.RespondBindError(c, err)
+		return
+	}
+	rate, err := h.rates.UpsertRate(fxrateapp.UpsertRateInput{
+		Currency: req.Currency,
+		Rate:     req.Rate,
+		Enabled:  req.Enabled,
+		AdminID:  adminID,
+	})
+	if err != nil {
+		respondFXRateError(c, err, "error.fx_rate_save_failed")
+		return
+	}
+	response.Success(c, rate)
+}
+
+// DeleteRate 删除币种汇率
+func (h *AdminHandler) DeleteRate(c *gin.Context) {
+	id, err := ginutil.ParseParamUint(c, "id")
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", nil)
+		return
+	}
+	if err := h.rates.DeleteRate(id); err != nil {
+		respondFXRateError(c, err, "error.fx_rate_save_failed")
+		return
+	}
+	response.Success(c, nil)
+}
+
+// ImportRates 上传汇率文件（csv 或 json）批量导入
+func (h *AdminHandler) ImportRates(c *gin.Context) {
+	adminID, ok := ginutil.GetAdminID(c)
+	if !ok {
+		return
+	}
+	file, err := c.FormFile("file")
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.fx_rate_import_invalid", nil)
+		return
+	}
+	format := strings.TrimSpace(c.PostForm("format"))
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(file.Filename)), ".")
+	}
+	reader, err := file.Open()
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.fx_rate_import_invalid", nil)
+		return
+	}
+	defer reader.Close()
+
+	imported, err := h.rates.ImportRates(fxrateapp.ImportRatesInput{
+		Reader:  reader,
+		Format:  format,
+		AdminID: adminID,
+	})
+	if err != nil {
+		respondFXRateError(c, err, "error.fx_rate_save_failed")
+		return
+	}
+	response.Success(c, gin.H{"imported": imported})
+}
+
+// ListCurrencies 前台可选展示/下单币种
+func (h *PublicHandler) ListCurrencies(c *gin.Context) {
+	currencies, err := h.rates.DisplayCurrencies()
+	if err != nil {
+		ginutil.RespondError(c, response.CodeInternal, "error.fx_rate_fetch_failed", err)
+		return
+	}
+	response.Success(c, currencies)
+}
@@ -0,0 +1,14 @@
+package fxratehttp
+
+import "github.com/gin-gonic/gin"
+
+func RegisterAdminRoutes(admin gin.IRoutes, handler *AdminHandler) {
+	admin.GET("/fx-rates", handler.ListRates)
+	admin.POST("/fx-rates", handler.UpsertRate)
+	admin.DELETE("/fx-rates/:id", handler.DeleteRate)
+	admin.POST("/fx-rates/import", handler.ImportRates)
+}
+
+func RegisterPublicRoutes(public gin.IRoutes, handler *PublicHandler) {
+	public.GET("/currencies", handler.ListCurrencies)
+}
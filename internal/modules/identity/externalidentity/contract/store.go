@@ -37,3 +37,13 @@ type Store interface {
 	Update(*externalidentitydomain.Identity) error
 	DeleteByID(id uint) error
 }
+
+// OIDCProviderStore 通用 OIDC/OAuth2 提供方配置存储。
+type OIDCProviderStore interface {
+	ListProviders(enabledOnly bool) ([]externalidentitydomain.OIDCProvider, error)
+	GetProviderByID(id uint) (*externalidentitydomain.OIDCProvider, error)
+	GetProviderBySlug(slug string) (*externalidentitydomain.OIDCProvider, error)
+	CreateProvider(*externalidentitydomain.OIDCProvider) error
+	UpdateProvider(*externalidentitydomain.OIDCProvider) error
+	DeleteProvider(id uint) error
+}
@@ -0,0 +1,74 @@
+package externalidentitydomain
+
+import (
+	"strings"
+	"time"
+)
+
+// OIDCProviderKeyPrefix 通用 OIDC/OAuth2 提供方在身份绑定表中的 provider 前缀。
+const OIDCProviderKeyPrefix = "oidc:"
+
+// OIDC 提供方协议。
+const (
+	OIDCProtocolOIDC   = "oidc"   // 标准 OIDC：discovery + id_token
+	OIDCProtocolOAuth2 = "oauth2" // 纯 OAuth2：手动端点 + userinfo
+)
+
+// OIDC 令牌端点客户端认证方式。
+const (
+	OIDCTokenAuthBasic = "client_secret_basic"
+	OIDCTokenAuthPost  = "client_secret_post"
+)
+
+// OIDCProvider 通用 OIDC/OAuth2 登录提供方配置
+// 说明：每条记录对应一个可登录的外部身份源（Keycloak、Authentik、Microsoft、GitHub 等）。
+type OIDCProvider struct {
+	ID                    uint      `gorm:"primarykey" json:"id"`                                   // 主键
+	Slug                  string    `gorm:"type:varchar(24);uniqueIndex;not null" json:"slug"`      // 唯一标识（用于回调路径与绑定 provider）
+	DisplayName           string    `gorm:"type:varchar(64);not null" json:"display_name"`          // 展示名称
+	IconURL               string    `gorm:"type:text" json:"icon_url"`                              // 按钮图标
+	Protocol              string    `gorm:"type:varchar(16);not null;default:oidc" json:"protocol"` // 协议 oidc/oauth2
+	DiscoveryURL          string    `gorm:"type:text" json:"discovery_url"`                         // Discovery 地址（可只填 issuer）
+	AuthorizationEndpoint string    `gorm:"type:text" json:"authorization_endpoint"`                // 授权端点（为空时取 discovery）
+	TokenEndpoint         string    `gorm:"type:text" json:"token_endpoint"`                        // 令牌端点（为空时取 discovery）
+	UserInfoEndpoint      string    `gorm:"type:text" json:"userinfo_endpoint"`                     // 用户信息端点（为空时取 discovery）
+	JWKSURI               string    `gorm:"column:jwks_uri;type:text" json:"jwks_uri"`              // JWKS 地址（为空时取 discovery）
+	ClientID              string    `gorm:"type:varchar(255);not null" json:"client_id"`            // 客户端 ID
+	ClientSecret          string    `gorm:"type:text" json:"-"`                                     // 客户端密钥（加密存储）
+	TokenAuthMethod       string    `gorm:"type:varchar(32)" json:"token_auth_method"`              // 令牌端点认证方式
+	Scopes                string    `gorm:"type:varchar(255)" json:"scopes"`                        // 请求 scope，空格分隔
+	RedirectURI           string    `gorm:"type:text;not null" json:"redirect_uri"`                 // 前端回调地址
+	ClaimMappingJSON      string    `gorm:"type:text" json:"-"`                                     // 声明映射 JSON
+	UsePKCE               bool      `gorm:"not null" json:"use_pkce"`                               // 是否启用 PKCE
+	TrustEmail            bool      `gorm:"not null;default:false" json:"trust_email"`              // 是否信任已验证邮箱并自动关联已有账号
+	AllowRegistration     bool      `gorm:"not null" json:"allow_registration"`                     // 是否允许首次登录自动注册
+	Enabled               bool      `gorm:"index;not null;default:false" json:"enabled"`            // 是否启用
+	SortOrder             int       `gorm:"not null;default:0" json:"sort_order"`                   // 排序
+	CreatedAt             time.Time `gorm:"index" json:"created_at"`                                // 创建时间
+	UpdatedAt             time.Time `gorm:"index" json:"updated_at"`                                // 更新时间
+}
+
+// TableName 指定表名
+func (OIDCProvider) TableName() string {
+	return "oidc_providers"
+}
+
+// ProviderKey 返回身份绑定表使用的 provider 值。
+func (p OIDCProvider) ProviderKey() string {
+	return OIDCProviderKey(p.Slug)
+}
+
+// OIDCProviderKey 根据 slug 生成身份绑定表 provider 值。
+func OIDCProviderKey(slug string) string {
+	return OIDCProviderKeyPrefix + strings.ToLower(strings.TrimSpace(slug))
+}
+
+// OIDCProviderSlugFromKey 从身份绑定 provider 值中解析 slug，非 OIDC 提供方返回 false。
+func OIDCProviderSlugFromKey(provider string) (string, bool) {
+	provider = strings.ToLower(strings.TrimSpace(provider))
+	if !strings.HasPrefix(provider, OIDCProviderKeyPrefix) {
+		return "", false
+	}
+	slug := strings.TrimPrefix(provider, OIDCProviderKeyPrefix)
+	return slug, slug != ""
+}
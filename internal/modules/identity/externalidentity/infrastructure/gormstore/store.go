@@ -148,3 +148,71 @@ func (r *Store) DeleteByID(id uint) error {
 	}
 	return r.db.Delete(&externalidentitydomain.Identity{}, id).Error
 }
+
+// ListProviders 查询 OIDC 提供方配置列表。
+func (r *Store) ListProviders(enabledOnly bool) ([]externalidentitydomain.OIDCProvider, error) {
+	query := r.db.Model(&externalidentitydomain.OIDCProvider{})
+	if enabledOnly {
+		query = query.Where("enabled = ?", true)
+	}
+	var providers []externalidentitydomain.OIDCProvider
+	if err := query.Order("sort_order DESC, id ASC").Find(&providers).Error; err != nil {
+		return nil, err
+	}
+	return providers, nil
+}
+
+// GetProviderByID 按 ID 查询 OIDC 提供方。
+func (r *Store) GetProviderByID(id uint) (*externalidentitydomain.OIDCProvider, error) {
+	if id == 0 {
+		return nil, nil
+	}
+	var provider externalidentitydomain.OIDCProvider
+	if err := r.db.First(&provider, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &provider, nil
+}
+
+// GetProviderBySlug 按 slug 查询 OIDC 提供方。
+func (r *Store) GetProviderBySlug(slug string) (*externalidentitydomain.OIDCProvider, error) {
+	slug = strings.ToLower(strings.TrimSpace(slug))
+	if slug == "" {
+		return nil, nil
+	}
+	var provider externalidentitydomain.OIDCProvider
+	if err := r.db.Where("slug = ?", slug).First(&provider).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &provider, nil
+}
+
+// CreateProvider 创建 OIDC 提供方。
+func (r *Store) CreateProvider(provider *externalidentitydomain.OIDCProvider) error {
+	if provider == nil {
+		return nil
+	}
+	return r.db.Create(provider).Error
+}
+
+// UpdateProvider 更新 OIDC 提供方。
+func (r *Store) UpdateProvider(provider *externalidentitydomain.OIDCProvider) error {
+	if provider == nil {
+		return nil
+	}
+	return r.db.Save(provider).Error
+}
+
+// DeleteProvider 删除 OIDC 提供方。
+func (r *Store) DeleteProvider(id uint) error {
+	if id == 0 {
+		return nil
+	}
+	return r.db.Delete(&externalidentitydomain.OIDCProvider{}, id).Error
+}
@@ -0,0 +1,293 @@
+package application
+
+import (
+	"encoding/json"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/dujiao-next/internal/crypto"
+	externalidentitydomain "github.com/dujiao-next/internal/modules/identity/externalidentity/domain"
+)
+
+var providerSlugPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{1,23}$`)
+
+// reservedProviderSlugs 与内置登录方式冲突的 slug。
+var reservedProviderSlugs = map[string]struct{}{
+	"google":   {},
+	"telegram": {},
+	"password": {},
+}
+
+// ClaimMapping maps provider claims onto the local identity fields. Paths
+// may be dotted to reach nested objects.
+type ClaimMapping struct {
+	Subject             string `json:"subject"`
+	Email               string `json:"email"`
+	EmailVerified       string `json:"email_verified"`
+	Name                string `json:"name"`
+	Username            string `json:"username"`
+	Picture             string `json:"picture"`
+	AssumeEmailVerified bool   `json:"assume_email_verified"`
+}
+
+// DefaultClaimMapping returns the standard OIDC claim names.
+func DefaultClaimMapping() ClaimMapping {
+	return ClaimMapping{
+		Subject:       "sub",
+		Email:         "email",
+		EmailVerified: "email_verified",
+		Name:          "name",
+		Username:      "preferred_username",
+		Picture:       "picture",
+	}
+}
+
+// ParseClaimMapping decodes a stored mapping, filling blanks with defaults.
+func ParseClaimMapping(raw string) ClaimMapping {
+	mapping := DefaultClaimMapping()
+	if strings.TrimSpace(raw) == "" {
+		return mapping
+	}
+	var stored ClaimMapping
+	if err := json.Unmarshal([]byte(raw), &stored); err != nil {
+		return mapping
+	}
+	return mergeClaimMapping(mapping, stored)
+}
+
+func mergeClaimMapping(base, override ClaimMapping) ClaimMapping {
+	base.Subject = firstNonEmpty(override.Subject, base.Subject)
+	base.Email = firstNonEmpty(override.Email, base.Email)
+	base.EmailVerified = firstNonEmpty(override.EmailVerified, base.EmailVerified)
+	base.Name = firstNonEmpty(override.Name, base.Name)
+	base.Username = firstNonEmpty(override.Username, base.Username)
+	base.Picture = firstNonEmpty(override.Picture, base.Picture)
+	base.AssumeEmailVerified = override.AssumeEmailVerified
+	return base
+}
+
+// ProviderInput is the admin create/update payload. An empty ClientSecret on
+// update keeps the stored secret.
+type ProviderInput struct {
+	Slug                  string        `json:"slug"`
+	DisplayName           string        `json:"display_name"`
+	IconURL               string        `json:"icon_url"`
+	Protocol              string        `json:"protocol"`
+	DiscoveryURL          string        `json:"discovery_url"`
+	AuthorizationEndpoint string        `json:"authorization_endpoint"`
+	TokenEndpoint         string        `json:"token_endpoint"`
+	UserInfoEndpoint      string        `json:"userinfo_endpoint"`
+	JWKSURI               string        `json:"jwks_uri"`
+	ClientID              string        `json:"client_id"`
+	ClientSecret          string        `json:"client_secret"`
+	TokenAuthMethod       string        `json:"token_auth_method"`
+	Scopes                string        `json:"scopes"`
+	RedirectURI           string        `json:"redirect_uri"`
+	ClaimMapping          *ClaimMapping `json:"claim_mapping"`
+	UsePKCE               bool          `json:"use_pkce"`
+	TrustEmail            bool          `json:"trust_email"`
+	AllowRegistration     bool          `json:"allow_registration"`
+	Enabled               bool          `json:"enabled"`
+	SortOrder             int           `json:"sort_order"`
+}
+
+// AdminProvider is the admin view of a provider; the client secret is never
+// returned, only whether one is stored.
+type AdminProvider struct {
+	externalidentitydomain.OIDCProvider
+	ClaimMapping    ClaimMapping `json:"claim_mapping"`
+	HasClientSecret bool         `json:"has_client_secret"`
+}
+
+// ListProviders 列出全部 OIDC 提供方。
+func (s *Service) ListProviders() ([]AdminProvider, error) {
+	providers, err := s.store.ListProviders(false)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]AdminProvider, 0, len(providers))
+	for _, provider := range providers {
+		items = append(items, toAdminProvider(provider))
+	}
+	return items, nil
+}
+
+// GetProvider 获取单个 OIDC 提供方。
+func (s *Service) GetProvider(id uint) (*AdminProvider, error) {
+	provider, err := s.store.GetProviderByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if provider == nil {
+		return nil, ErrOIDCProviderNotFound
+	}
+	view := toAdminProvider(*provider)
+	return &view, nil
+}
+
+// CreateProvider 创建 OIDC 提供方。
+func (s *Service) CreateProvider(input ProviderInput) (*AdminProvider, error) {
+	provider := &externalidentitydomain.OIDCProvider{Slug: strings.ToLower(strings.TrimSpace(input.Slug))}
+	if !providerSlugPattern.MatchString(provider.Slug) {
+		return nil, ErrOIDCProviderInvalid
+	}
+	if _, reserved := reservedProviderSlugs[provider.Slug]; reserved {
+		return nil, ErrOIDCProviderInvalid
+	}
+	existing, err := s.store.GetProviderBySlug(provider.Slug)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrOIDCProviderExists
+	}
+	if err := s.applyProviderInput(provider, input); err != nil {
+		return nil, err
+	}
+	if err := s.store.CreateProvider(provider); err != nil {
+		return nil, err
+	}
+	view := toAdminProvider(*provider)
+	return &view, nil
+}
+
+// UpdateProvider 更新 OIDC 提供方；slug 决定已有绑定的 provider 值，不允许修改。
+func (s *Service) UpdateProvider(id uint, input ProviderInput) (*AdminProvider, error) {
+	provider, err := s.store.GetProviderByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if provider == nil {
+		return nil, ErrOIDCProviderNotFound
+	}
+	if slug := strings.ToLower(strings.TrimSpace(input.Slug)); slug != "" && slug != provider.Slug {
+		return nil, ErrOIDCProviderInvalid
+	}
+	if err := s.applyProviderInput(provider, input); err != nil {
+		return nil, err
+	}
+	if err := s.store.UpdateProvider(provider); err != nil {
+		return nil, err
+	}
+	view := toAdminProvider(*provider)
+	return &view, nil
+}
+
+// DeleteProvider 删除 OIDC 提供方；已有绑定保留但不再可用于登录。
+func (s *Service) DeleteProvider(id uint) error {
+	provider, err := s.store.GetProviderByID(id)
+	if err != nil {
+		return err
+	}
+	if provider == nil {
+		return ErrOIDCProviderNotFound
+	}
+	return s.store.DeleteProvider(id)
+}
+
+func (s *Service) applyProviderInput(provider *externalidentitydomain.OIDCProvider, input ProviderInput) error {
+	protocol := strings.ToLower(strings.TrimSpace(input.Protocol))
+	if protocol == "" {
+		protocol = externalidentitydomain.OIDCProtocolOIDC
+	}
+	tokenAuth := strings.TrimSpace(input.TokenAuthMethod)
+	if tokenAuth == "" {
+		tokenAuth = externalidentitydomain.OIDCTokenAuthBasic
+	}
+	next := *provider
+	next.DisplayName = strings.TrimSpace(input.DisplayName)
+	next.IconURL = strings.TrimSpace(input.IconURL)
+	next.Protocol = protocol
+	next.DiscoveryURL = strings.TrimSpace(input.DiscoveryURL)
+	next.AuthorizationEndpoint = strings.TrimSpace(input.AuthorizationEndpoint)
+	next.TokenEndpoint = strings.TrimSpace(input.TokenEndpoint)
+	next.UserInfoEndpoint = strings.TrimSpace(input.UserInfoEndpoint)
+	next.JWKSURI = strings.TrimSpace(input.JWKSURI)
+	next.ClientID = strings.TrimSpace(input.ClientID)
+	next.TokenAuthMethod = tokenAuth
+	next.Scopes = strings.Join(strings.Fields(input.Scopes), " ")
+	next.RedirectURI = strings.TrimSpace(input.RedirectURI)
+	next.UsePKCE = input.UsePKCE
+	next.TrustEmail = input.TrustEmail
+	next.AllowRegistration = input.AllowRegistration
+	next.Enabled = input.Enabled
+	next.SortOrder = input.SortOrder
+	if input.ClaimMapping != nil {
+		encoded, err := json.Marshal(mergeClaimMapping(DefaultClaimMapping(), *input.ClaimMapping))
+		if err != nil {
+			return err
+		}
+		next.ClaimMappingJSON = string(encoded)
+	}
+	if secret := strings.TrimSpace(input.ClientSecret); secret != "" {
+		encrypted, err := crypto.Encrypt(s.encryptKey, secret)
+		if err != nil {
+			return err
+		}
+		next.ClientSecret = encrypted
+	}
+	if err := validateProvider(&next); err != nil {
+		return err
+	}
+	*provider = next
+	return nil
+}
+
+func validateProvider(provider *externalidentitydomain.OIDCProvider) error {
+	if provider.DisplayName == "" || len(provider.DisplayName) > 64 || provider.ClientID == "" {
+		return ErrOIDCProviderInvalid
+	}
+	if provider.TokenAuthMethod != externalidentitydomain.OIDCTokenAuthBasic &&
+		provider.TokenAuthMethod != externalidentitydomain.OIDCTokenAuthPost {
+		return ErrOIDCProviderInvalid
+	}
+	if !validAbsoluteURL(provider.RedirectURI, true) {
+		return ErrOIDCProviderInvalid
+	}
+	for _, endpoint := range []string{
+		provider.DiscoveryURL,
+		provider.AuthorizationEndpoint,
+		provider.TokenEndpoint,
+		provider.UserInfoEndpoint,
+		provider.JWKSURI,
+		provider.IconURL,
+	} {
+		if !validAbsoluteURL(endpoint, false) {
+			return ErrOIDCProviderInvalid
+		}
+	}
+	switch provider.Protocol {
+	case externalidentitydomain.OIDCProtocolOIDC:
+		// id_token 的 issuer 校验依赖 discovery 文档。
+		if provider.DiscoveryURL == "" {
+			return ErrOIDCProviderInvalid
+		}
+	case externalidentitydomain.OIDCProtocolOAuth2:
+		if provider.AuthorizationEndpoint == "" || provider.TokenEndpoint == "" || provider.UserInfoEndpoint == "" {
+			return ErrOIDCProviderInvalid
+		}
+	default:
+		return ErrOIDCProviderInvalid
+	}
+	return nil
+}
+
+func validAbsoluteURL(raw string, required bool) bool {
+	if raw == "" {
+		return !required
+	}
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Host == "" {
+		return false
+	}
+	return parsed.Scheme == "https" || parsed.Scheme == "http"
+}
+
+func toAdminProvider(provider externalidentitydomain.OIDCProvider) AdminProvider {
+	return AdminProvider{
+		OIDCProvider:    provider,
+		ClaimMapping:    ParseClaimMapping(provider.ClaimMappingJSON),
+		HasClientSecret: strings.TrimSpace(provider.ClientSecret) != "",
+	}
+}
@@ -0,0 +1,16 @@
+package application
+
+import "errors"
+
+var (
+	ErrOIDCProviderNotFound     = errors.New("oidc provider not found")
+	ErrOIDCProviderDisabled     = errors.New("oidc provider disabled")
+	ErrOIDCProviderInvalid      = errors.New("oidc provider config invalid")
+	ErrOIDCProviderExists       = errors.New("oidc provider slug exists")
+	ErrOIDCDiscoveryUnavailable = errors.New("oidc discovery unavailable")
+	ErrOIDCStateInvalid         = errors.New("oidc state invalid")
+	ErrOIDCTokenExchange        = errors.New("oidc token exchange failed")
+	ErrOIDCIDTokenInvalid       = errors.New("oidc id token invalid")
+	ErrOIDCUserInfoUnavailable  = errors.New("oidc userinfo unavailable")
+	ErrOIDCClaimsInvalid        = errors.New("oidc claims invalid")
+)
@@ -0,0 +1,515 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	externalidentitydomain "github.com/dujiao-next/internal/modules/identity/externalidentity/domain"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const wellKnownConfigurationPath = "/.well-known/openid-configuration"
+
+var idTokenSigningMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}
+
+type providerEndpoints struct {
+	Issuer        string
+	Authorization string
+	Token         string
+	UserInfo      string
+	JWKS          string
+}
+
+type discoveryDocument struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserInfoEndpoint      string `json:"userinfo_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type cachedDiscovery struct {
+	doc   discoveryDocument
+	until time.Time
+}
+
+type cachedJWKS struct {
+	keys          map[string]crypto.PublicKey
+	until         time.Time
+	lastRefreshed time.Time
+}
+
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	IDToken     string `json:"id_token"`
+	Error       string `json:"error"`
+}
+
+// resolveEndpoints merges discovery metadata with the endpoint overrides
+// stored on the provider.
+func (s *Service) resolveEndpoints(ctx context.Context, provider *externalidentitydomain.OIDCProvider) (providerEndpoints, error) {
+	endpoints := providerEndpoints{
+		Authorization: strings.TrimSpace(provider.AuthorizationEndpoint),
+		Token:         strings.TrimSpace(provider.TokenEndpoint),
+		UserInfo:      strings.TrimSpace(provider.UserInfoEndpoint),
+		JWKS:          strings.TrimSpace(provider.JWKSURI),
+	}
+	if discoveryURL := discoveryEndpoint(provider.DiscoveryURL); discoveryURL != "" {
+		doc, err := s.loadDiscovery(ctx, discoveryURL)
+		if err != nil {
+			return providerEndpoints{}, err
+		}
+		endpoints.Issuer = doc.Issuer
+		endpoints.Authorization = firstNonEmpty(endpoints.Authorization, doc.AuthorizationEndpoint)
+		endpoints.Token = firstNonEmpty(endpoints.Token, doc.TokenEndpoint)
+		endpoints.UserInfo = firstNonEmpty(endpoints.UserInfo, doc.UserInfoEndpoint)
+		endpoints.JWKS = firstNonEmpty(endpoints.JWKS, doc.JWKSURI)
+	}
+	if endpoints.Authorization == "" || endpoints.Token == "" {
+		return providerEndpoints{}, ErrOIDCProviderInvalid
+	}
+	switch provider.Protocol {
+	case externalidentitydomain.OIDCProtocolOIDC:
+		if endpoints.Issuer == "" || endpoints.JWKS == "" {
+			return providerEndpoints{}, ErrOIDCProviderInvalid
+		}
+	case externalidentitydomain.OIDCProtocolOAuth2:
+		if endpoints.UserInfo == "" {
+			return providerEndpoints{}, ErrOIDCProviderInvalid
+		}
+	default:
+		return providerEndpoints{}, ErrOIDCProviderInvalid
+	}
+	return endpoints, nil
+}
+
+// discoveryEndpoint accepts either an issuer or a full discovery URL.
+func discoveryEndpoint(raw string) string {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return ""
+	}
+	if strings.Contains(raw, "/.well-known/") {
+		return raw
+	}
+	return strings.TrimRight(raw, "/") + wellKnownConfigurationPath
+}
+
+func (s *Service) loadDiscovery(ctx context.Context, endpoint string) (discoveryDocument, error) {
+	s.cacheMu.Lock()
+	cached, ok := s.discovery[endpoint]
+	s.cacheMu.Unlock()
+	if ok && s.now().Before(cached.until) {
+		return cached.doc, nil
+	}
+	var doc discoveryDocument
+	if err := s.getJSON(ctx, endpoint, "", &doc); err != nil {
+		return discoveryDocument{}, fmt.Errorf("%w: %v", ErrOIDCDiscoveryUnavailable, err)
+	}
+	if strings.TrimSpace(doc.Issuer) == "" {
+		return discoveryDocument{}, fmt.Errorf("%w: issuer missing", ErrOIDCDiscoveryUnavailable)
+	}
+	s.cacheMu.Lock()
+	s.discovery[endpoint] = cachedDiscovery{doc: doc, until: s.now().Add(discoveryCacheTTL)}
+	s.cacheMu.Unlock()
+	return doc, nil
+}
+
+func (s *Service) exchangeCode(
+	ctx context.Context,
+	provider *externalidentitydomain.OIDCProvider,
+	endpoints providerEndpoints,
+	secret, code, verifier string,
+) (*tokenResponse, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", provider.RedirectURI)
+	if verifier != "" {
+		form.Set("code_verifier", verifier)
+	}
+	basicAuth := provider.TokenAuthMethod != externalidentitydomain.OIDCTokenAuthPost && secret != ""
+	if !basicAuth {
+		form.Set("client_id", provider.ClientID)
+		if secret != "" {
+			form.Set("client_secret", secret)
+		}
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoints.Token, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if basicAuth {
+		req.SetBasicAuth(url.QueryEscape(provider.ClientID), url.QueryEscape(secret))
+	}
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrOIDCTokenExchange, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrOIDCTokenExchange, err)
+	}
+	if resp.StatusCode/100 != 2 {
+		return nil, fmt.Errorf("%w: http %d", ErrOIDCTokenExchange, resp.StatusCode)
+	}
+	var token tokenResponse
+	if err := json.Unmarshal(body, &token); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrOIDCTokenExchange, err)
+	}
+	if token.Error != "" {
+		return nil, fmt.Errorf("%w: %s", ErrOIDCTokenExchange, token.Error)
+	}
+	if provider.Protocol == externalidentitydomain.OIDCProtocolOIDC && strings.TrimSpace(token.IDToken) == "" {
+		return nil, fmt.Errorf("%w: id_token missing", ErrOIDCTokenExchange)
+	}
+	if provider.Protocol == externalidentitydomain.OIDCProtocolOAuth2 && strings.TrimSpace(token.AccessToken) == "" {
+		return nil, fmt.Errorf("%w: access_token missing", ErrOIDCTokenExchange)
+	}
+	return &token, nil
+}
+
+// collectClaims returns the verified ID token claims (OIDC) or the userinfo
+// document (OAuth2). For OIDC providers userinfo only fills claims the ID
+// token left out, and is skipped when it reports a different subject.
+func (s *Service) collectClaims(
+	ctx context.Context,
+	provider *externalidentitydomain.OIDCProvider,
+	endpoints providerEndpoints,
+	token *tokenResponse,
+	nonce string,
+) (map[string]any, error) {
+	if provider.Protocol == externalidentitydomain.OIDCProtocolOAuth2 {
+		claims := map[string]any{}
+		if err := s.getJSON(ctx, endpoints.UserInfo, token.AccessToken, &claims); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrOIDCUserInfoUnavailable, err)
+		}
+		return claims, nil
+	}
+
+	claims, err := s.verifyIDToken(ctx, provider, endpoints, token.IDToken, nonce)
+	if err != nil {
+		return nil, err
+	}
+	if endpoints.UserInfo == "" || strings.TrimSpace(token.AccessToken) == "" {
+		return claims, nil
+	}
+	userInfo := map[string]any{}
+	if err := s.getJSON(ctx, endpoints.UserInfo, token.AccessToken, &userInfo); err != nil {
+		// userinfo 仅用于补全声明，失败不影响已验证的 id_token。
+		return claims, nil
+	}
+	if sub, _ := claimString(userInfo, "sub"); sub != "" {
+		if idSub, _ := claimString(claims, "sub"); idSub != sub {
+			return claims, nil
+		}
+	}
+	for key, value := range userInfo {
+		if _, exists := claims[key]; !exists {
+			claims[key] = value
+		}
+	}
+	return claims, nil
+}
+
+func (s *Service) verifyIDToken(
+	ctx context.Context,
+	provider *externalidentitydomain.OIDCProvider,
+	endpoints providerEndpoints,
+	rawToken, nonce string,
+) (map[string]any, error) {
+	claims := jwt.MapClaims{}
+	parser := jwt.NewParser(
+		jwt.WithValidMethods(idTokenSigningMethods),
+		jwt.WithIssuer(endpoints.Issuer),
+		jwt.WithAudience(provider.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(tokenClockSkew),
+		jwt.WithTimeFunc(s.now),
+		jwt.WithJSONNumber(),
+	)
+	_, err := parser.ParseWithClaims(rawToken, claims, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		return s.jwksKey(ctx, endpoints.JWKS, kid)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrOIDCIDTokenInvalid, err)
+	}
+	if nonce != "" {
+		if got, _ := claimString(claims, "nonce"); got != nonce {
+			return nil, fmt.Errorf("%w: nonce mismatch", ErrOIDCIDTokenInvalid)
+		}
+	}
+	return map[string]any(claims), nil
+}
+
+func (s *Service) jwksKey(ctx context.Context, endpoint, kid string) (crypto.PublicKey, error) {
+	s.cacheMu.Lock()
+	cached, ok := s.jwks[endpoint]
+	s.cacheMu.Unlock()
+	now := s.now()
+	if ok && now.Before(cached.until) {
+		if key := pickJWK(cached.keys, kid); key != nil {
+			return key, nil
+		}
+		// 未知 kid 可能是密钥轮换，冷却时间内不重复拉取。
+		if now.Sub(cached.lastRefreshed) < jwksForcedRefreshWait {
+			return nil, fmt.Errorf("kid %q not found in JWKS", kid)
+		}
+	}
+	raw := map[string]json.RawMessage{}
+	if err := s.getJSON(ctx, endpoint, "", &raw); err != nil {
+		return nil, err
+	}
+	keys, err := parseJWKS(raw["keys"])
+	if err != nil {
+		return nil, err
+	}
+	s.cacheMu.Lock()
+	s.jwks[endpoint] = cachedJWKS{keys: keys, until: now.Add(jwksCacheTTL), lastRefreshed: now}
+	s.cacheMu.Unlock()
+	if key := pickJWK(keys, kid); key != nil {
+		return key, nil
+	}
+	return nil, fmt.Errorf("kid %q not found in JWKS", kid)
+}
+
+func pickJWK(keys map[string]crypto.PublicKey, kid string) crypto.PublicKey {
+	if kid != "" {
+		return keys[kid]
+	}
+	if len(keys) == 1 {
+		for _, key := range keys {
+			return key
+		}
+	}
+	return nil
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func parseJWKS(raw json.RawMessage) (map[string]crypto.PublicKey, error) {
+	var set []jsonWebKey
+	if err := json.Unmarshal(raw, &set); err != nil {
+		return nil, fmt.Errorf("decode jwks: %w", err)
+	}
+	keys := map[string]crypto.PublicKey{}
+	for index, jwk := range set {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := parseJWK(jwk)
+		if err != nil {
+			continue
+		}
+		kid := jwk.Kid
+		if kid == "" {
+			kid = "#" + strconv.Itoa(index)
+		}
+		keys[kid] = key
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no usable signing keys in JWKS")
+	}
+	return keys, nil
+}
+
+func parseJWK(jwk jsonWebKey) (crypto.PublicKey, error) {
+	switch strings.ToUpper(jwk.Kty) {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(jwk.N, "="))
+		if err != nil || len(n) == 0 {
+			return nil, errors.New("invalid rsa modulus")
+		}
+		e, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(jwk.E, "="))
+		if err != nil || len(e) == 0 || len(e) > 4 {
+			return nil, errors.New("invalid rsa exponent")
+		}
+		exponent := int(new(big.Int).SetBytes(e).Int64())
+		if exponent < 3 {
+			return nil, errors.New("invalid rsa exponent")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: exponent}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch jwk.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, errors.New("unsupported ec curve")
+		}
+		size := (curve.Params().BitSize + 7) / 8
+		x, errX := base64.RawURLEncoding.DecodeString(strings.TrimRight(jwk.X, "="))
+		y, errY := base64.RawURLEncoding.DecodeString(strings.TrimRight(jwk.Y, "="))
+		if errX != nil || errY != nil || len(x) > size || len(y) > size {
+			return nil, errors.New("invalid ec point")
+		}
+		point := make([]byte, 1+2*size)
+		point[0] = 4
+		copy(point[1+size-len(x):1+size], x)
+		copy(point[1+2*size-len(y):], y)
+		return ecdsa.ParseUncompressedPublicKey(curve, point)
+	default:
+		return nil, errors.New("unsupported key type")
+	}
+}
+
+func (s *Service) getJSON(ctx context.Context, endpoint, bearer string, target any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if bearer != "" {
+		req.Header.Set("Authorization", "Bearer "+bearer)
+	}
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("http %d", resp.StatusCode)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	return decoder.Decode(target)
+}
+
+// mapIdentity applies the provider claim mapping to the collected claims.
+func mapIdentity(provider *externalidentitydomain.OIDCProvider, claims map[string]any) (*VerifiedIdentity, error) {
+	mapping := ParseClaimMapping(provider.ClaimMappingJSON)
+	subject, _ := claimString(claims, mapping.Subject)
+	if subject == "" || len(subject) > 255 {
+		return nil, fmt.Errorf("%w: subject missing", ErrOIDCClaimsInvalid)
+	}
+	identity := &VerifiedIdentity{
+		ProviderKey:       provider.ProviderKey(),
+		ProviderSlug:      provider.Slug,
+		ProviderName:      provider.DisplayName,
+		Subject:           subject,
+		TrustEmail:        provider.TrustEmail,
+		AllowRegistration: provider.AllowRegistration,
+	}
+	if email, _ := claimString(claims, mapping.Email); email != "" {
+		if parsed, err := mail.ParseAddress(email); err == nil && parsed.Address == email {
+			identity.Email = strings.ToLower(email)
+			identity.EmailVerified = mapping.AssumeEmailVerified || claimBool(claims, mapping.EmailVerified)
+		}
+	}
+	identity.Name, _ = claimString(claims, mapping.Name)
+	identity.Username, _ = claimString(claims, mapping.Username)
+	if picture, _ := claimString(claims, mapping.Picture); len(picture) <= 2048 {
+		if parsed, err := url.Parse(picture); err == nil && (parsed.Scheme == "https" || parsed.Scheme == "http") {
+			identity.Picture = picture
+		}
+	}
+	if len(identity.Username) > 128 {
+		identity.Username = identity.Username[:128]
+	}
+	return identity, nil
+}
+
+// lookupClaim resolves a dotted claim path such as "profile.email".
+func lookupClaim(claims map[string]any, path string) (any, bool) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return nil, false
+	}
+	if value, ok := claims[path]; ok {
+		return value, true
+	}
+	var current any = claims
+	for _, part := range strings.Split(path, ".") {
+		object, ok := current.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if current, ok = object[part]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+func claimString(claims map[string]any, path string) (string, bool) {
+	value, ok := lookupClaim(claims, path)
+	if !ok {
+		return "", false
+	}
+	switch typed := value.(type) {
+	case string:
+		return strings.TrimSpace(typed), true
+	case json.Number:
+		return typed.String(), true
+	case float64:
+		return strconv.FormatFloat(typed, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(typed), true
+	default:
+		return "", false
+	}
+}
+
+func claimBool(claims map[string]any, path string) bool {
+	value, ok := lookupClaim(claims, path)
+	if !ok {
+		return false
+	}
+	switch typed := value.(type) {
+	case bool:
+		return typed
+	case string:
+		parsed, err := strconv.ParseBool(strings.TrimSpace(typed))
+		return err == nil && parsed
+	default:
+		return false
+	}
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if trimmed := strings.TrimSpace(value); trimmed != "" {
+			return trimmed
+		}
+	}
+	return ""
+}
@@ -0,0 +1,321 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dujiao-next/internal/crypto"
+	externalidentitycontract "github.com/dujiao-next/internal/modules/identity/externalidentity/contract"
+	externalidentitydomain "github.com/dujiao-next/internal/modules/identity/externalidentity/domain"
+)
+
+const (
+	IntentLogin = "login"
+	IntentBind  = "bind"
+
+	defaultHTTPTimeout    = 10 * time.Second
+	stateTTL              = 10 * time.Minute
+	discoveryCacheTTL     = time.Hour
+	jwksCacheTTL          = 10 * time.Minute
+	jwksForcedRefreshWait = 30 * time.Second
+	maxResponseBytes      = 1 << 20
+	tokenClockSkew        = time.Minute
+)
+
+// State is the short-lived authorization request state kept between the
+// start and callback legs of a login or bind flow.
+type State struct {
+	Slug         string `json:"s"`
+	CodeVerifier string `json:"v,omitempty"`
+	Nonce        string `json:"n,omitempty"`
+	Intent       string `json:"i"`
+	UserID       uint   `json:"u,omitempty"`
+}
+
+// StateStore persists authorization state. Take must be one-shot.
+type StateStore interface {
+	Put(ctx context.Context, state string, value State, ttl time.Duration) error
+	Take(ctx context.Context, state string) (*State, error)
+}
+
+// VerifiedIdentity is the normalized identity produced by a completed
+// authorization-code exchange.
+type VerifiedIdentity struct {
+	ProviderKey       string
+	ProviderSlug      string
+	ProviderName      string
+	Subject           string
+	Email             string
+	EmailVerified     bool
+	Name              string
+	Username          string
+	Picture           string
+	TrustEmail        bool
+	AllowRegistration bool
+	AuthAt            time.Time
+}
+
+// PublicProvider is the storefront view of an enabled provider.
+type PublicProvider struct {
+	Slug        string `json:"slug"`
+	DisplayName string `json:"display_name"`
+	IconURL     string `json:"icon_url"`
+}
+
+// Option customizes the OIDC service.
+type Option func(*Service)
+
+// WithHTTPClient injects the HTTP client used for discovery, JWKS, token and
+// userinfo requests.
+func WithHTTPClient(client *http.Client) Option {
+	return func(service *Service) {
+		if client != nil {
+			service.httpClient = client
+		}
+	}
+}
+
+// WithClock injects the service clock. Intended for deterministic tests.
+func WithClock(clock func() time.Time) Option {
+	return func(service *Service) {
+		if clock != nil {
+			service.now = clock
+		}
+	}
+}
+
+// Service runs authorization-code flows against admin-configured OIDC and
+// OAuth2 providers and manages their configuration.
+type Service struct {
+	store      externalidentitycontract.OIDCProviderStore
+	states     StateStore
+	encryptKey []byte
+	httpClient *http.Client
+	now        func() time.Time
+
+	cacheMu   sync.Mutex
+	discovery map[string]cachedDiscovery
+	jwks      map[string]cachedJWKS
+}
+
+// NewService creates the generic OIDC service.
+func NewService(
+	store externalidentitycontract.OIDCProviderStore,
+	states StateStore,
+	appSecretKey string,
+	opts ...Option,
+) *Service {
+	service := &Service{
+		store:      store,
+		states:     states,
+		encryptKey: crypto.DeriveKey(appSecretKey),
+		httpClient: &http.Client{Timeout: defaultHTTPTimeout},
+		now:        time.Now,
+		discovery:  map[string]cachedDiscovery{},
+		jwks:       map[string]cachedJWKS{},
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(service)
+		}
+	}
+	return service
+}
+
+// ListEnabledProviders returns the providers shown on the storefront login page.
+func (s *Service) ListEnabledProviders() ([]PublicProvider, error) {
+	if s == nil || s.store == nil {
+		return []PublicProvider{}, nil
+	}
+	providers, err := s.store.ListProviders(true)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]PublicProvider, 0, len(providers))
+	for _, provider := range providers {
+		items = append(items, PublicProvider{
+			Slug:        provider.Slug,
+			DisplayName: provider.DisplayName,
+			IconURL:     provider.IconURL,
+		})
+	}
+	return items, nil
+}
+
+// IsProviderEnabled reports whether an identity bound to providerKey can
+// still be used to sign in.
+func (s *Service) IsProviderEnabled(providerKey string) bool {
+	if s == nil || s.store == nil {
+		return false
+	}
+	slug, ok := externalidentitydomain.OIDCProviderSlugFromKey(providerKey)
+	if !ok {
+		return false
+	}
+	provider, err := s.store.GetProviderBySlug(slug)
+	return err == nil && provider != nil && provider.Enabled
+}
+
+// Start stores a fresh state and returns the provider authorization URL.
+func (s *Service) Start(ctx context.Context, slug, intent string, userID uint) (string, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if intent != IntentLogin && intent != IntentBind {
+		return "", ErrOIDCStateInvalid
+	}
+	if intent == IntentBind && userID == 0 {
+		return "", ErrOIDCStateInvalid
+	}
+	provider, err := s.enabledProvider(slug)
+	if err != nil {
+		return "", err
+	}
+	if s.states == nil {
+		return "", ErrOIDCProviderInvalid
+	}
+	endpoints, err := s.resolveEndpoints(ctx, provider)
+	if err != nil {
+		return "", err
+	}
+	authURL, err := url.Parse(endpoints.Authorization)
+	if err != nil || authURL.Host == "" {
+		return "", ErrOIDCProviderInvalid
+	}
+
+	stateValue, err := randomToken(32)
+	if err != nil {
+		return "", err
+	}
+	record := State{Slug: provider.Slug, Intent: intent, UserID: userID}
+	query := authURL.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", provider.ClientID)
+	query.Set("redirect_uri", provider.RedirectURI)
+	query.Set("state", stateValue)
+	if scopes := providerScopes(provider); scopes != "" {
+		query.Set("scope", scopes)
+	}
+	if provider.Protocol == externalidentitydomain.OIDCProtocolOIDC {
+		if record.Nonce, err = randomToken(16); err != nil {
+			return "", err
+		}
+		query.Set("nonce", record.Nonce)
+	}
+	if provider.UsePKCE {
+		if record.CodeVerifier, err = randomToken(48); err != nil {
+			return "", err
+		}
+		sum := sha256.Sum256([]byte(record.CodeVerifier))
+		query.Set("code_challenge", base64.RawURLEncoding.EncodeToString(sum[:]))
+		query.Set("code_challenge_method", "S256")
+	}
+	if err := s.states.Put(ctx, stateValue, record, stateTTL); err != nil {
+		return "", err
+	}
+	authURL.RawQuery = query.Encode()
+	return authURL.String(), nil
+}
+
+// Complete consumes the state, exchanges the authorization code and returns
+// the verified identity together with the original request state.
+func (s *Service) Complete(ctx context.Context, slug, code, state string) (*VerifiedIdentity, *State, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	slug = strings.ToLower(strings.TrimSpace(slug))
+	code = strings.TrimSpace(code)
+	state = strings.TrimSpace(state)
+	if slug == "" || code == "" || state == "" || s.states == nil {
+		return nil, nil, ErrOIDCStateInvalid
+	}
+	record, err := s.states.Take(ctx, state)
+	if err != nil {
+		return nil, nil, err
+	}
+	if record == nil || record.Slug != slug {
+		return nil, nil, ErrOIDCStateInvalid
+	}
+	provider, err := s.enabledProvider(slug)
+	if err != nil {
+		return nil, nil, err
+	}
+	endpoints, err := s.resolveEndpoints(ctx, provider)
+	if err != nil {
+		return nil, nil, err
+	}
+	secret, err := s.decryptSecret(provider)
+	if err != nil {
+		return nil, nil, ErrOIDCProviderInvalid
+	}
+	token, err := s.exchangeCode(ctx, provider, endpoints, secret, code, record.CodeVerifier)
+	if err != nil {
+		return nil, nil, err
+	}
+	claims, err := s.collectClaims(ctx, provider, endpoints, token, record.Nonce)
+	if err != nil {
+		return nil, nil, err
+	}
+	identity, err := mapIdentity(provider, claims)
+	if err != nil {
+		return nil, nil, err
+	}
+	identity.AuthAt = s.now()
+	return identity, record, nil
+}
+
+func (s *Service) enabledProvider(slug string) (*externalidentitydomain.OIDCProvider, error) {
+	if s == nil || s.store == nil {
+		return nil, ErrOIDCProviderNotFound
+	}
+	provider, err := s.store.GetProviderBySlug(slug)
+	if err != nil {
+		return nil, err
+	}
+	if provider == nil {
+		return nil, ErrOIDCProviderNotFound
+	}
+	if !provider.Enabled {
+		return nil, ErrOIDCProviderDisabled
+	}
+	return provider, nil
+}
+
+func (s *Service) decryptSecret(provider *externalidentitydomain.OIDCProvider) (string, error) {
+	if strings.TrimSpace(provider.ClientSecret) == "" {
+		return "", nil
+	}
+	return crypto.Decrypt(s.encryptKey, provider.ClientSecret)
+}
+
+func providerScopes(provider *externalidentitydomain.OIDCProvider) string {
+	scopes := strings.Fields(provider.Scopes)
+	if provider.Protocol != externalidentitydomain.OIDCProtocolOIDC {
+		return strings.Join(scopes, " ")
+	}
+	if len(scopes) == 0 {
+		return "openid email profile"
+	}
+	for _, scope := range scopes {
+		if scope == "openid" {
+			return strings.Join(scopes, " ")
+		}
+	}
+	return strings.Join(append([]string{"openid"}, scopes...), " ")
+}
+
+func randomToken(size int) (string, error) {
+	buf := make([]byte, size)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generate oidc random token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
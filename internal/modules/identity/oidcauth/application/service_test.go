@@ -0,0 +1,408 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	externalidentitydomain "github.com/dujiao-next/internal/modules/identity/externalidentity/domain"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type memoryProviderStore struct {
+	mu        sync.Mutex
+	providers []externalidentitydomain.OIDCProvider
+}
+
+func (m *memoryProviderStore) ListProviders(enabledOnly bool) ([]externalidentitydomain.OIDCProvider, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	items := []externalidentitydomain.OIDCProvider{}
+	for _, provider := range m.providers {
+		if !enabledOnly || provider.Enabled {
+			items = append(items, provider)
+		}
+	}
+	return items, nil
+}
+
+func (m *memoryProviderStore) GetProviderByID(id uint) (*externalidentitydomain.OIDCProvider, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, provider := range m.providers {
+		if provider.ID == id {
+			copied := provider
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryProviderStore) GetProviderBySlug(slug string) (*externalidentitydomain.OIDCProvider, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, provider := range m.providers {
+		if provider.Slug == slug {
+			copied := provider
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryProviderStore) CreateProvider(provider *externalidentitydomain.OIDCProvider) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	provider.ID = uint(len(m.providers) + 1)
+	m.providers = append(m.providers, *provider)
+	return nil
+}
+
+func (m *memoryProviderStore) UpdateProvider(provider *externalidentitydomain.OIDCProvider) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for index := range m.providers {
+		if m.providers[index].ID == provider.ID {
+			m.providers[index] = *provider
+		}
+	}
+	return nil
+}
+
+func (m *memoryProviderStore) DeleteProvider(id uint) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for index := range m.providers {
+		if m.providers[index].ID == id {
+			m.providers = append(m.providers[:index], m.providers[index+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+type memoryStateStore struct {
+	mu     sync.Mutex
+	states map[string]State
+}
+
+func (m *memoryStateStore) Put(_ context.Context, state string, value State, _ time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.states == nil {
+		m.states = map[string]State{}
+	}
+	m.states[state] = value
+	return nil
+}
+
+func (m *memoryStateStore) Take(_ context.Context, state string) (*State, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	value, ok := m.states[state]
+	if !ok {
+		return nil, nil
+	}
+	delete(m.states, state)
+	return &value, nil
+}
+
+// mockIssuer is a minimal local OIDC issuer: discovery, JWKS, token and
+// userinfo endpoints backed by an in-memory RSA key.
+type mockIssuer struct {
+	t        *testing.T
+	server   *httptest.Server
+	key      *rsa.PrivateKey
+	clientID string
+	secret   string
+	audience string
+	claims   map[string]any
+	userInfo map[string]any
+
+	mu       sync.Mutex
+	pending  map[string]url.Values
+	lastForm url.Values
+}
+
+func newMockIssuer(t *testing.T) *mockIssuer {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate rsa key: %v", err)
+	}
+	issuer := &mockIssuer{t: t, key: key, clientID: "shop", secret: "s3cret", pending: map[string]url.Values{}}
+	issuer.audience = issuer.clientID
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, map[string]string{
+			"issuer":                 issuer.server.URL,
+			"authorization_endpoint": issuer.server.URL + "/authorize",
+			"token_endpoint":         issuer.server.URL + "/token",
+			"userinfo_endpoint":      issuer.server.URL + "/userinfo",
+			"jwks_uri":               issuer.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, map[string]any{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "k1",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", issuer.handleToken)
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer access-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		writeJSON(w, issuer.userInfo)
+	})
+	issuer.server = httptest.NewServer(mux)
+	t.Cleanup(issuer.server.Close)
+	return issuer
+}
+
+// authorize simulates the browser leg: the issuer remembers the request
+// parameters and hands back a code.
+func (m *mockIssuer) authorize(authURL string) (code, state string) {
+	m.t.Helper()
+	parsed, err := url.Parse(authURL)
+	if err != nil {
+		m.t.Fatalf("parse auth url: %v", err)
+	}
+	query := parsed.Query()
+	if query.Get("client_id") != m.clientID || query.Get("response_type") != "code" {
+		m.t.Fatalf("unexpected authorize query: %v", query)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	code = "code-" + query.Get("state")[:8]
+	m.pending[code] = query
+	return code, query.Get("state")
+}
+
+func (m *mockIssuer) handleToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	user, pass, ok := r.BasicAuth()
+	if !ok || user != m.clientID || pass != m.secret {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	m.mu.Lock()
+	m.lastForm = r.PostForm
+	request, found := m.pending[r.PostForm.Get("code")]
+	delete(m.pending, r.PostForm.Get("code"))
+	m.mu.Unlock()
+	if !found {
+		writeJSON(w, map[string]string{"error": "invalid_grant"})
+		return
+	}
+	if challenge := request.Get("code_challenge"); challenge != "" {
+		sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+		if base64.RawURLEncoding.EncodeToString(sum[:]) != challenge {
+			writeJSON(w, map[string]string{"error": "invalid_grant"})
+			return
+		}
+	}
+	claims := jwt.MapClaims{
+		"iss":   m.server.URL,
+		"aud":   m.audience,
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"iat":   time.Now().Unix(),
+		"nonce": request.Get("nonce"),
+	}
+	for key, value := range m.claims {
+		claims[key] = value
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = "k1"
+	signed, err := token.SignedString(m.key)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, map[string]string{"access_token": "access-token", "token_type": "Bearer", "id_token": signed})
+}
+
+func writeJSON(w http.ResponseWriter, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+func newTestService(t *testing.T, issuer *mockIssuer, input ProviderInput) *Service {
+	t.Helper()
+	service := NewService(&memoryProviderStore{}, &memoryStateStore{}, "app-secret", WithHTTPClient(issuer.server.Client()))
+	if input.Slug == "" {
+		input.Slug = "keycloak"
+	}
+	input.DisplayName = "Keycloak"
+	input.ClientID = issuer.clientID
+	input.ClientSecret = issuer.secret
+	input.RedirectURI = "https://shop.example.com/auth/oidc/keycloak/callback"
+	input.Enabled = true
+	if input.Protocol == "" {
+		input.DiscoveryURL = issuer.server.URL
+	}
+	if _, err := service.CreateProvider(input); err != nil {
+		t.Fatalf("create provider: %v", err)
+	}
+	return service
+}
+
+func TestCompleteVerifiesIDTokenFromMockIssuer(t *testing.T) {
+	issuer := newMockIssuer(t)
+	issuer.claims = map[string]any{"sub": "user-1", "email": "Alice@Example.com", "email_verified": true, "name": "Alice"}
+	issuer.userInfo = map[string]any{"sub": "user-1", "picture": "https://cdn.example.com/a.png"}
+	service := newTestService(t, issuer, ProviderInput{UsePKCE: true, TrustEmail: true, AllowRegistration: true})
+
+	authURL, err := service.Start(context.Background(), "keycloak", IntentBind, 7)
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	if !strings.HasPrefix(authURL, issuer.server.URL+"/authorize?") || !strings.Contains(authURL, "scope=openid+email+profile") {
+		t.Fatalf("unexpected auth url %q", authURL)
+	}
+	code, state := issuer.authorize(authURL)
+
+	identity, record, err := service.Complete(context.Background(), "keycloak", code, state)
+	if err != nil {
+		t.Fatalf("complete: %v", err)
+	}
+	if record.Intent != IntentBind || record.UserID != 7 {
+		t.Fatalf("state = %+v", record)
+	}
+	if identity.ProviderKey != "oidc:keycloak" || identity.Subject != "user-1" {
+		t.Fatalf("identity = %+v", identity)
+	}
+	if identity.Email != "alice@example.com" || !identity.EmailVerified || !identity.TrustEmail {
+		t.Fatalf("email fields = %+v", identity)
+	}
+	if identity.Picture != "https://cdn.example.com/a.png" {
+		t.Fatalf("userinfo claims were not merged: %+v", identity)
+	}
+	if issuer.lastForm.Get("code_verifier") == "" || issuer.lastForm.Get("client_secret") != "" {
+		t.Fatalf("token request form = %v", issuer.lastForm)
+	}
+
+	if _, _, err := service.Complete(context.Background(), "keycloak", code, state); !errors.Is(err, ErrOIDCStateInvalid) {
+		t.Fatalf("replayed state err = %v, want ErrOIDCStateInvalid", err)
+	}
+}
+
+func TestCompleteRejectsTokenForOtherAudience(t *testing.T) {
+	issuer := newMockIssuer(t)
+	issuer.audience = "someone-else"
+	issuer.claims = map[string]any{"sub": "user-1"}
+	service := newTestService(t, issuer, ProviderInput{})
+
+	authURL, err := service.Start(context.Background(), "keycloak", IntentLogin, 0)
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	code, state := issuer.authorize(authURL)
+	if _, _, err := service.Complete(context.Background(), "keycloak", code, state); !errors.Is(err, ErrOIDCIDTokenInvalid) {
+		t.Fatalf("err = %v, want ErrOIDCIDTokenInvalid", err)
+	}
+}
+
+func TestCompleteRejectsStateFromAnotherProvider(t *testing.T) {
+	issuer := newMockIssuer(t)
+	issuer.claims = map[string]any{"sub": "user-1"}
+	service := newTestService(t, issuer, ProviderInput{})
+
+	authURL, err := service.Start(context.Background(), "keycloak", IntentLogin, 0)
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	code, state := issuer.authorize(authURL)
+	if _, _, err := service.Complete(context.Background(), "authentik", code, state); !errors.Is(err, ErrOIDCStateInvalid) {
+		t.Fatalf("err = %v, want ErrOIDCStateInvalid", err)
+	}
+}
+
+func TestCompleteOAuth2MapsUserInfoClaims(t *testing.T) {
+	issuer := newMockIssuer(t)
+	issuer.userInfo = map[string]any{
+		"id":      12345,
+		"login":   "octocat",
+		"profile": map[string]any{"mail": "octo@example.com"},
+	}
+	service := newTestService(t, issuer, ProviderInput{
+		Slug:                  "github",
+		Protocol:              externalidentitydomain.OIDCProtocolOAuth2,
+		AuthorizationEndpoint: issuer.server.URL + "/authorize",
+		TokenEndpoint:         issuer.server.URL + "/token",
+		UserInfoEndpoint:      issuer.server.URL + "/userinfo",
+		Scopes:                "read:user user:email",
+		ClaimMapping: &ClaimMapping{
+			Subject:             "id",
+			Email:               "profile.mail",
+			Username:            "login",
+			AssumeEmailVerified: true,
+		},
+	})
+
+	authURL, err := service.Start(context.Background(), "github", IntentLogin, 0)
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	if strings.Contains(authURL, "nonce=") || strings.Contains(authURL, "openid") {
+		t.Fatalf("oauth2 auth url must not carry OIDC parameters: %q", authURL)
+	}
+	code, state := issuer.authorize(authURL)
+	identity, _, err := service.Complete(context.Background(), "github", code, state)
+	if err != nil {
+		t.Fatalf("complete: %v", err)
+	}
+	if identity.ProviderKey != "oidc:github" || identity.Subject != "12345" || identity.Username != "octocat" {
+		t.Fatalf("identity = %+v", identity)
+	}
+	if identity.Email != "octo@example.com" || !identity.EmailVerified {
+		t.Fatalf("email fields = %+v", identity)
+	}
+}
+
+func TestCreateProviderValidatesSlug(t *testing.T) {
+	issuer := newMockIssuer(t)
+	service := newTestService(t, issuer, ProviderInput{})
+	base := ProviderInput{
+		DisplayName:  "Other",
+		ClientID:     "id",
+		RedirectURI:  "https://shop.example.com/cb",
+		DiscoveryURL: issuer.server.URL,
+	}
+	for _, slug := range []string{"google", "Bad Slug", "x"} {
+		input := base
+		input.Slug = slug
+		if _, err := service.CreateProvider(input); !errors.Is(err, ErrOIDCProviderInvalid) {
+			t.Fatalf("slug %q err = %v, want ErrOIDCProviderInvalid", slug, err)
+		}
+	}
+	input := base
+	input.Slug = "keycloak"
+	if _, err := service.CreateProvider(input); !errors.Is(err, ErrOIDCProviderExists) {
+		t.Fatalf("duplicate slug err = %v, want ErrOIDCProviderExists", err)
+	}
+	providers, err := service.ListProviders()
+	if err != nil || len(providers) != 1 || !providers[0].HasClientSecret || providers[0].ClientSecret == issuer.secret {
+		t.Fatalf("providers = %+v, err = %v", providers, err)
+	}
+}
@@ -0,0 +1,42 @@
+package cachestore
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/dujiao-next/internal/cache"
+	oidcauthapp "github.com/dujiao-next/internal/modules/identity/oidcauth/application"
+)
+
+const oidcStateKeyPrefix = "oidc:state:"
+
+const maxStateLength = 128
+
+var errInvalidOIDCState = errors.New("invalid oidc state")
+
+// StateStore persists OIDC authorization state in Redis; Take is one-shot.
+type StateStore struct{}
+
+func NewStateStore() *StateStore {
+	return &StateStore{}
+}
+
+func (*StateStore) Put(ctx context.Context, state string, value oidcauthapp.State, ttl time.Duration) error {
+	if state == "" || len(state) > maxStateLength {
+		return errInvalidOIDCState
+	}
+	return cache.SetJSONRequired(ctx, oidcStateKeyPrefix+state, value, ttl)
+}
+
+func (*StateStore) Take(ctx context.Context, state string) (*oidcauthapp.State, error) {
+	if state == "" || len(state) > maxStateLength {
+		return nil, nil
+	}
+	var value oidcauthapp.State
+	found, err := cache.GetDelJSONRequired(ctx, oidcStateKeyPrefix+state, &value)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &value, nil
+}
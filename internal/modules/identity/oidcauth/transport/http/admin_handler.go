@@ -0,0 +1,119 @@
+package oidcauthhttp
+
+import (
+	"errors"
+
+	oidcauthapp "github.com/dujiao-next/internal/modules/identity/oidcauth/application"
+	"github.com/dujiao-next/internal/platform/http/ginutil"
+	"github.com/dujiao-next/internal/platform/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminService 是后台 OIDC 提供方配置端口。
+type AdminService interface {
+	ListProviders() ([]oidcauthapp.AdminProvider, error)
+	GetProvider(id uint) (*oidcauthapp.AdminProvider, error)
+	CreateProvider(input oidcauthapp.ProviderInput) (*oidcauthapp.AdminProvider, error)
+	UpdateProvider(id uint, input oidcauthapp.ProviderInput) (*oidcauthapp.AdminProvider, error)
+	DeleteProvider(id uint) error
+}
+
+// AdminHandler 处理后台 OIDC 提供方配置请求。
+type AdminHandler struct {
+	providers AdminService
+}
+
+func NewAdminHandler(providers AdminService) *AdminHandler {
+	if providers == nil {
+		panic("oidc provider admin handler: providers is nil")
+	}
+	return &AdminHandler{providers: providers}
+}
+
+func respondProviderError(c *gin.Context, err error, fallbackKey string) {
+	switch {
+	case errors.Is(err, oidcauthapp.ErrOIDCProviderNotFound):
+		ginutil.RespondError(c, response.CodeNotFound, "error.oidc_provider_not_found", nil)
+	case errors.Is(err, oidcauthapp.ErrOIDCProviderExists):
+		ginutil.RespondError(c, response.CodeBadRequest, "error.oidc_provider_exists", nil)
+	case errors.Is(err, oidcauthapp.ErrOIDCProviderInvalid):
+		ginutil.RespondError(c, response.CodeBadRequest, "error.oidc_provider_config_invalid", nil)
+	default:
+		ginutil.RespondError(c, response.CodeInternal, fallbackKey, err)
+	}
+}
+
+// ListProviders 获取 OIDC 提供方列表
+func (h *AdminHandler) ListProviders(c *gin.Context) {
+	providers, err := h.providers.ListProviders()
+	if err != nil {
+		respondProviderError(c, err, "error.config_fetch_failed")
+		return
+	}
+	response.Success(c, providers)
+}
+
+// GetProvider 获取 OIDC 提供方详情
+func (h *AdminHandler) GetProvider(c *gin.Context) {
+	id, err := ginutil.ParseParamUint(c, "id")
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", err)
+		return
+	}
+	provider, err := h.providers.GetProvider(id)
+	if err != nil {
+		respondProviderError(c, err, "error.config_fetch_failed")
+		return
+	}
+	response.Success(c, provider)
+}
+
+// CreateProvider 创建 OIDC 提供方
+func (h *AdminHandler) CreateProvider(c *gin.Context) {
+	var input oidcauthapp.ProviderInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		ginutil.RespondBindError(c, err)
+		return
+	}
+	provider, err := h.providers.CreateProvider(input)
+	if err != nil {
+		respondProviderError(c, err, "error.save_failed")
+		return
+	}
+	response.Success(c, provider)
+}
+
+// UpdateProvider 更新 OIDC 提供方
+func (h *AdminHandler) UpdateProvider(c *gin.Context) {
+	id, err := ginutil.ParseParamUint(c, "id")
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", err)
+		return
+	}
+	var input oidcauthapp.ProviderInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		ginutil.RespondBindError(c, err)
+		return
+	}
+	provider, err := h.providers.UpdateProvider(id, input)
+	if err != nil {
+		respondProviderError(c, err, "error.save_failed")
+		return
+	}
+	response.Success(c, provider)
+}
+
+// DeleteProvider 删除 OIDC 提供方
+func (h *AdminHandler) DeleteProvider(c *gin.Context) {
+	id, err := ginutil.ParseParamUint(c, "id")
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", err)
+		return
+	}
+	if err := h.providers.DeleteProvider(id); err != nil {
+		respondProviderError(c, err, "error.delete_failed")
+		return
+	}
+	response.Success(c, nil)
+}
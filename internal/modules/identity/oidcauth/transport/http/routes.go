@@ -0,0 +1,15 @@
+package oidcauthhttp
+
+import "github.com/gin-gonic/gin"
+
+// RegisterAdminRoutes 注册后台通用 OIDC 提供方配置路由。
+func RegisterAdminRoutes(admin gin.IRoutes, handler *AdminHandler) {
+	if admin == nil || handler == nil {
+		panic("oidc provider admin routes: required dependency is nil")
+	}
+	admin.GET("/oidc-providers", handler.ListProviders)
+	admin.GET("/oidc-providers/:id", handler.GetProvider)
+	admin.POST("/oidc-providers", handler.CreateProvider)
+	admin.PUT("/oidc-providers/:id", handler.UpdateProvider)
+	admin.DELETE("/oidc-providers/:id", handler.DeleteProvider)
+}
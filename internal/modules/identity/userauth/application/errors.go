@@ -28,11 +28,16 @@ var (
 	ErrGoogleRedirectTenantMismatch = errors.New("google redirect tenant mismatch")
 	ErrGoogleRedirectUserMismatch   = errors.New("google redirect user mismatch")
 	ErrGoogleRedirectFlowInvalid    = errors.New("google redirect flow invalid")
+	ErrOIDCAutoLinkForbidden        = errors.New("oidc email auto link forbidden")
+	ErrOIDCEmailRequired            = errors.New("oidc verified email required")
+	ErrOIDCUnbindLocked             = errors.New("oidc unbind would lock account")
 	ErrProfileEmpty                 = errors.New("profile empty")
 	ErrEmailChangeInvalid           = errors.New("email change invalid")
 	ErrEmailChangeExists            = errors.New("email change exists")
 	ErrRegistrationDisabled         = errors.New("registration disabled")
+	ErrCustomerBlacklisted          = errors.New("customer blacklisted")
 )
 
 var errExternalIdentityUnbindLocked = errors.New("external identity unbind would lock account")
 var errGoogleLoginMappingChanged = errors.New("google identity mapping changed during login")
+var errOIDCLoginMappingChanged = errors.New("oidc identity mapping changed during login")
@@ -15,6 +15,7 @@ import (
 	externalidentitydomain "github.com/dujiao-next/internal/modules/identity/externalidentity/domain"
 	googleauthapp "github.com/dujiao-next/internal/modules/identity/googleauth/application"
 	userdomain "github.com/dujiao-next/internal/modules/identity/user/domain"
+	orderriskcontract "github.com/dujiao-next/internal/modules/orderrisk/contract"
 	settingsapp "github.com/dujiao-next/internal/modules/settings/application"
 
 	"golang.org/x/crypto/bcrypt"
@@ -573,6 +574,9 @@ func (s *Service) isUsableExternalIdentity(identity *externalidentitydomain.Iden
 			strings.TrimSpace(username) != "" &&
 			(mode == "widget" || mode == "oidc")
 	default:
+		if _, ok := externalidentitydomain.OIDCProviderSlugFromKey(identity.Provider); ok {
+			return s.oidcAuthService != nil && s.oidcAuthService.IsProviderEnabled(identity.Provider)
+		}
 		// Unknown providers are not assumed to be a usable recovery method.
 		return false
 	}
@@ -582,6 +586,9 @@ func (s *Service) completeExternalLogin(user *userdomain.User, source string) (*
 	if user == nil {
 		return nil, ErrNotFound
 	}
+	if err := s.checkCustomerBlacklist(orderriskcontract.BlacklistSubject{UserID: user.ID, Email: user.Email}); err != nil {
+		return nil, err
+	}
 	if user.TOTPEnabledAt != nil {
 		challengeToken, jti, expiresAt, err := s.IssueUserChallengeTokenForSource(user.ID, false, source)
 		if err != nil {
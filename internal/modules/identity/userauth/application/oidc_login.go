@@ -0,0 +1,382 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/dujiao-next/internal/constants"
+	externalidentitydomain "github.com/dujiao-next/internal/modules/identity/externalidentity/domain"
+	oidcauthapp "github.com/dujiao-next/internal/modules/identity/oidcauth/application"
+	userdomain "github.com/dujiao-next/internal/modules/identity/user/domain"
+	settingsapp "github.com/dujiao-next/internal/modules/settings/application"
+)
+
+const maxOIDCIdentityUsernameRunes = 128
+
+// OIDCBinding is the account-safe view of one generic OIDC binding.
+type OIDCBinding struct {
+	ProviderSlug string
+	Identity     *externalidentitydomain.Identity
+	CanUnbind    bool
+}
+
+// StartOIDC returns the authorization URL of an enabled generic provider.
+func (s *Service) StartOIDC(ctx context.Context, slug, intent string, userID uint) (string, error) {
+	if s == nil || s.oidcAuthService == nil {
+		return "", oidcauthapp.ErrOIDCProviderInvalid
+	}
+	return s.oidcAuthService.Start(ctx, slug, intent, userID)
+}
+
+// LoginWithOIDC exchanges the callback code and completes the normal JWT/2FA
+// login flow.
+func (s *Service) LoginWithOIDC(ctx context.Context, slug, code, state string) (*UserLoginResult, error) {
+	if s == nil || s.oidcAuthService == nil {
+		return nil, oidcauthapp.ErrOIDCProviderInvalid
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	verified, record, err := s.oidcAuthService.Complete(ctx, slug, code, state)
+	if err != nil {
+		return nil, err
+	}
+	if record.Intent != oidcauthapp.IntentLogin {
+		return nil, oidcauthapp.ErrOIDCStateInvalid
+	}
+	return s.LoginVerifiedOIDC(ctx, verified)
+}
+
+// LoginVerifiedOIDC resolves or provisions the local account for a verified
+// provider identity. Linking to an existing email account requires both a
+// verified email claim and a provider that is trusted for email ownership.
+func (s *Service) LoginVerifiedOIDC(ctx context.Context, verified *oidcauthapp.VerifiedIdentity) (*UserLoginResult, error) {
+	if s == nil || s.userOAuthIdentityRepo == nil || s.authUnitOfWork == nil {
+		return nil, oidcauthapp.ErrOIDCProviderInvalid
+	}
+	if verified == nil || strings.TrimSpace(verified.Subject) == "" || strings.TrimSpace(verified.ProviderKey) == "" {
+		return nil, oidcauthapp.ErrOIDCClaimsInvalid
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	for attempt := 0; attempt < 2; attempt++ {
+		identityBefore, err := s.userOAuthIdentityRepo.GetByProviderUserID(verified.ProviderKey, verified.Subject)
+		if err != nil {
+			return nil, err
+		}
+		var registration googleRegistrationSnapshot
+		if identityBefore == nil {
+			if verified.Email == "" {
+				return nil, ErrOIDCEmailRequired
+			}
+			existing, lookupErr := s.userRepo.GetByEmail(verified.Email)
+			if lookupErr != nil {
+				return nil, lookupErr
+			}
+			if existing == nil {
+				if registration, err = s.loadGoogleRegistrationSnapshot(); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		user, createdUser, txErr := s.loginOIDCTransaction(ctx, verified, identityBefore, registration)
+		if txErr != nil {
+			if attempt == 0 && errors.Is(txErr, errOIDCLoginMappingChanged) {
+				continue
+			}
+			// 并发首次登录可能已写入同一身份，重试一次走已绑定分支。
+			occupied, occupiedErr := s.userOAuthIdentityRepo.GetByProviderUserID(verified.ProviderKey, verified.Subject)
+			if attempt == 0 && occupiedErr == nil && occupied != nil {
+				continue
+			}
+			return nil, txErr
+		}
+		if createdUser && s.memberLevelSvc != nil {
+			_ = s.memberLevelSvc.AssignDefaultLevel(user.ID)
+			if refreshed, refreshErr := s.userRepo.GetByID(user.ID); refreshErr == nil && refreshed != nil {
+				user = refreshed
+			}
+		}
+		return s.completeExternalLogin(user, constants.LoginLogSourceOIDC)
+	}
+	return nil, errOIDCLoginMappingChanged
+}
+
+func (s *Service) loginOIDCTransaction(
+	ctx context.Context,
+	verified *oidcauthapp.VerifiedIdentity,
+	identityBefore *externalidentitydomain.Identity,
+	registration googleRegistrationSnapshot,
+) (resolvedUser *userdomain.User, createdUser bool, resultErr error) {
+	resultErr = s.authUnitOfWork.WithinTransaction(ctx, func(tx AuthTransaction) error {
+		if identityBefore != nil {
+			user, err := activeTransactionUser(tx, identityBefore.UserID)
+			if err != nil {
+				return err
+			}
+			identity, err := tx.GetIdentityByProviderUserID(verified.ProviderKey, verified.Subject)
+			if err != nil {
+				return err
+			}
+			if identity == nil || identity.UserID != user.ID {
+				return errOIDCLoginMappingChanged
+			}
+			resolvedUser = user
+			if applyOIDCIdentity(verified, identity) {
+				identity.UpdatedAt = time.Now()
+				return tx.UpdateIdentity(identity)
+			}
+			return nil
+		}
+
+		// 与 Google 登录保持一致的加锁顺序：先用户，后身份。
+		user, err := tx.GetUserByEmail(verified.Email)
+		if err != nil {
+			return err
+		}
+		identity, err := tx.GetIdentityByProviderUserID(verified.ProviderKey, verified.Subject)
+		if err != nil {
+			return err
+		}
+		if identity != nil {
+			return errOIDCLoginMappingChanged
+		}
+
+		if user != nil {
+			if !verified.TrustEmail || !verified.EmailVerified {
+				return ErrOIDCAutoLinkForbidden
+			}
+			if strings.ToLower(strings.TrimSpace(user.Status)) != constants.UserStatusActive {
+				return ErrUserDisabled
+			}
+			if user.EmailVerifiedAt == nil {
+				now := time.Now()
+				user.EmailVerifiedAt = &now
+				user.UpdatedAt = now
+				if err = tx.UpdateUser(user); err != nil {
+					return err
+				}
+			}
+		} else {
+			if !verified.EmailVerified {
+				return ErrOIDCEmailRequired
+			}
+			if !verified.AllowRegistration || !registration.Enabled {
+				return ErrRegistrationDisabled
+			}
+			if err = settingsapp.CheckRegistrationEmailDomainAllowed(verified.Email, registration.EmailDomain); err != nil {
+				return err
+			}
+			if user, err = newOIDCUser(verified); err != nil {
+				return err
+			}
+			if err = tx.CreateUser(user); err != nil {
+				return err
+			}
+			createdUser = true
+		}
+
+		current, err := tx.GetIdentityByUserProvider(user.ID, verified.ProviderKey)
+		if err != nil {
+			return err
+		}
+		if current != nil && current.ProviderUserID != verified.Subject {
+			return ErrUserOAuthAlreadyBound
+		}
+		if current == nil {
+			if err = tx.CreateIdentity(newOIDCIdentity(user.ID, verified)); err != nil {
+				return err
+			}
+		} else if applyOIDCIdentity(verified, current) {
+			current.UpdatedAt = time.Now()
+			if err = tx.UpdateIdentity(current); err != nil {
+				return err
+			}
+		}
+		resolvedUser = user
+		return nil
+	})
+	return resolvedUser, createdUser, resultErr
+}
+
+// BindOIDC exchanges the callback code and binds the identity to the
+// authenticated user that started the flow.
+func (s *Service) BindOIDC(ctx context.Context, userID uint, slug, code, state string) (*OIDCBinding, error) {
+	if userID == 0 {
+		return nil, ErrNotFound
+	}
+	if s == nil || s.oidcAuthService == nil || s.userOAuthIdentityRepo == nil || s.authUnitOfWork == nil {
+		return nil, oidcauthapp.ErrOIDCProviderInvalid
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	verified, record, err := s.oidcAuthService.Complete(ctx, slug, code, state)
+	if err != nil {
+		return nil, err
+	}
+	if record.Intent != oidcauthapp.IntentBind || record.UserID != userID {
+		return nil, oidcauthapp.ErrOIDCStateInvalid
+	}
+
+	var user *userdomain.User
+	var current *externalidentitydomain.Identity
+	err = s.authUnitOfWork.WithinTransaction(ctx, func(tx AuthTransaction) error {
+		var txErr error
+		if user, txErr = activeTransactionUser(tx, userID); txErr != nil {
+			return txErr
+		}
+		occupied, txErr := tx.GetIdentityByProviderUserID(verified.ProviderKey, verified.Subject)
+		if txErr != nil {
+			return txErr
+		}
+		if occupied != nil && occupied.UserID != userID {
+			return ErrUserOAuthIdentityExists
+		}
+		if current, txErr = tx.GetIdentityByUserProvider(userID, verified.ProviderKey); txErr != nil {
+			return txErr
+		}
+		if current != nil && current.ProviderUserID != verified.Subject {
+			return ErrUserOAuthAlreadyBound
+		}
+		if current == nil {
+			current = newOIDCIdentity(userID, verified)
+			return tx.CreateIdentity(current)
+		}
+		if applyOIDCIdentity(verified, current) {
+			current.UpdatedAt = time.Now()
+			return tx.UpdateIdentity(current)
+		}
+		return nil
+	})
+	if err != nil {
+		// Translate database uniqueness races into stable application errors.
+		occupied, occupiedErr := s.userOAuthIdentityRepo.GetByProviderUserID(verified.ProviderKey, verified.Subject)
+		if occupiedErr == nil && occupied != nil && occupied.UserID != userID {
+			return nil, ErrUserOAuthIdentityExists
+		}
+		return nil, err
+	}
+	return s.buildOIDCBinding(user, current)
+}
+
+// ListOIDCBindings returns the generic OIDC identities bound to the user.
+func (s *Service) ListOIDCBindings(userID uint) ([]OIDCBinding, error) {
+	if userID == 0 {
+		return nil, ErrNotFound
+	}
+	if s == nil || s.userOAuthIdentityRepo == nil {
+		return nil, oidcauthapp.ErrOIDCProviderInvalid
+	}
+	user, err := s.getActiveUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	identities, err := s.userOAuthIdentityRepo.ListByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	bindings := make([]OIDCBinding, 0, len(identities))
+	for index := range identities {
+		if _, ok := externalidentitydomain.OIDCProviderSlugFromKey(identities[index].Provider); !ok {
+			continue
+		}
+		binding, err := s.buildOIDCBinding(user, &identities[index])
+		if err != nil {
+			return nil, err
+		}
+		bindings = append(bindings, *binding)
+	}
+	return bindings, nil
+}
+
+// UnbindOIDC removes a generic OIDC binding only when another login method
+// remains usable.
+func (s *Service) UnbindOIDC(userID uint, slug string) error {
+	if strings.TrimSpace(slug) == "" {
+		return ErrUserOAuthNotBound
+	}
+	if err := s.unbindExternalIdentity(userID, externalidentitydomain.OIDCProviderKey(slug)); err != nil {
+		if err == errExternalIdentityUnbindLocked {
+			return ErrOIDCUnbindLocked
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *Service) buildOIDCBinding(user *userdomain.User, identity *externalidentitydomain.Identity) (*OIDCBinding, error) {
+	slug, _ := externalidentitydomain.OIDCProviderSlugFromKey(identity.Provider)
+	canUnbind, err := s.canUnbindExternalIdentity(user, identity)
+	if err != nil {
+		return nil, err
+	}
+	return &OIDCBinding{ProviderSlug: slug, Identity: identity, CanUnbind: canUnbind}, nil
+}
+
+func newOIDCUser(verified *oidcauthapp.VerifiedIdentity) (*userdomain.User, error) {
+	passwordHash, err := generateGooglePlaceholderPassword()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	displayName := truncateRunes(strings.TrimSpace(verified.Name), maxGoogleDisplayNameRunes)
+	if displayName == "" {
+		displayName = resolveNicknameFromEmail(verified.Email)
+	}
+	return &userdomain.User{
+		Email:                 verified.Email,
+		PasswordHash:          passwordHash,
+		PasswordSetupRequired: true,
+		DisplayName:           displayName,
+		Status:                constants.UserStatusActive,
+		EmailVerifiedAt:       &now,
+		CreatedAt:             now,
+		UpdatedAt:             now,
+	}, nil
+}
+
+func newOIDCIdentity(userID uint, verified *oidcauthapp.VerifiedIdentity) *externalidentitydomain.Identity {
+	now := time.Now()
+	identity := &externalidentitydomain.Identity{
+		UserID:         userID,
+		Provider:       verified.ProviderKey,
+		ProviderUserID: verified.Subject,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	applyOIDCIdentity(verified, identity)
+	return identity
+}
+
+func applyOIDCIdentity(verified *oidcauthapp.VerifiedIdentity, identity *externalidentitydomain.Identity) bool {
+	username := strings.TrimSpace(verified.Username)
+	if username == "" {
+		username = verified.Email
+	}
+	username = truncateRunes(username, maxOIDCIdentityUsernameRunes)
+	picture := normalizeGoogleIdentityPicture(verified.Picture)
+	authAt := verified.AuthAt
+	if authAt.IsZero() {
+		authAt = time.Now()
+	}
+	changed := false
+	if identity.Username != username {
+		identity.Username = username
+		changed = true
+	}
+	if identity.AvatarURL != picture {
+		identity.AvatarURL = picture
+		changed = true
+	}
+	if identity.AuthAt == nil || !identity.AuthAt.Equal(authAt) {
+		identity.AuthAt = &authAt
+		changed = true
+	}
+	return changed
+}
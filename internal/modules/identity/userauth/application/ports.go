@@ -7,6 +7,7 @@ import (
 	externalidentitydomain "github.com/dujiao-next/internal/modules/identity/externalidentity/domain"
 	googleauthapp "github.com/dujiao-next/internal/modules/identity/googleauth/application"
 	userdomain "github.com/dujiao-next/internal/modules/identity/user/domain"
+	orderriskcontract "github.com/dujiao-next/internal/modules/orderrisk/contract"
 	"github.com/dujiao-next/internal/shared/mailbrand"
 )
 
@@ -16,6 +17,13 @@ type VerificationEmailSender interface {
 	SendVerifyCode(toEmail, code, purpose, locale string, brand mailbrand.Brand) error
 }
 
+// CustomerBlacklist rejects blacklisted customers at registration and every
+// login completion path. Implementations return
+// orderriskcontract.ErrCustomerBlacklisted on a hit.
+type CustomerBlacklist interface {
+	CheckCustomer(subject orderriskcontract.BlacklistSubject) error
+}
+
 // AuthTransaction is the persistence surface required by cross-aggregate
 // authentication mutations. Implementations must execute every method on the
 // same database transaction.
@@ -26,7 +26,9 @@ import (
 	externalidentitycontract "github.com/dujiao-next/internal/modules/identity/externalidentity/contract"
 	googleauthapp "github.com/dujiao-next/internal/modules/identity/googleauth/application"
 	"github.com/dujiao-next/internal/modules/identity/jwttoken"
+	oidcauthapp "github.com/dujiao-next/internal/modules/identity/oidcauth/application"
 	"github.com/dujiao-next/internal/modules/identity/userauth/challenge"
+	orderriskcontract "github.com/dujiao-next/internal/modules/orderrisk/contract"
 	"github.com/dujiao-next/internal/shared/mailbrand"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -46,8 +48,10 @@ type Service struct {
 	telegramAuthService   *telegramauthapp.Service
 	googleAuthService     *googleauthapp.Service
 	googleRedirectStore   GoogleRedirectStore
+	oidcAuthService       *oidcauthapp.Service
 	memberLevelSvc        MemberLevelAssigner
 	authUnitOfWork        AuthUnitOfWork
+	customerBlacklist     CustomerBlacklist
 }
 
 type MemberLevelAssigner interface {
@@ -70,6 +74,11 @@ func (s *Service) SetGoogleRedirectStore(store GoogleRedirectStore) {
 	s.googleRedirectStore = store
 }
 
+// SetOIDCAuthService injects the admin-configured generic OIDC/OAuth2 providers.
+func (s *Service) SetOIDCAuthService(service *oidcauthapp.Service) {
+	s.oidcAuthService = service
+}
+
 // SetAuthUnitOfWork injects the transaction boundary shared by user accounts
 // and external identities.
 func (s *Service) SetAuthUnitOfWork(unitOfWork AuthUnitOfWork) {
@@ -82,6 +91,12 @@ func (s *Service) SetEmailBrandResolver(resolver mailbrand.Resolver) {
 	s.emailBrandResolver = resolver
 }
 
+// SetCustomerBlacklist injects the customer blacklist enforced at
+// registration and login.
+func (s *Service) SetCustomerBlacklist(blacklist CustomerBlacklist) {
+	s.customerBlacklist = blacklist
+}
+
 // NewService 创建用户认证服务
 func NewService(
 	cfg *config.Config,
@@ -270,6 +285,9 @@ func (s *Service) Register(email, password, code string, agreementAccepted bool,
 	if err := s.checkRegistrationEmailDomain(normalized); err != nil {
 		return nil, "", time.Time{}, err
 	}
+	if err := s.checkCustomerBlacklist(orderriskcontract.BlacklistSubject{Email: normalized}); err != nil {
+		return nil, "", time.Time{}, err
+	}
 	if err := passwordpolicy.Validate(s.cfg.Security.PasswordPolicy.ValidationPolicy(), password); err != nil {
 		return nil, "", time.Time{}, err
 	}
@@ -351,6 +369,9 @@ func (s *Service) LoginStep1(email, password string, rememberMe bool) (*UserLogi
 	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
 		return nil, ErrInvalidCredentials
 	}
+	if err := s.checkCustomerBlacklist(orderriskcontract.BlacklistSubject{UserID: user.ID, Email: user.Email}); err != nil {
+		return nil, err
+	}
 
 	if user.TOTPEnabledAt != nil {
 		challenge, jti, expiresAt, err := s.IssueUserChallengeToken(user.ID, rememberMe)
@@ -427,6 +448,8 @@ func normalizeLoginSource(source string) string {
 		return constants.LoginLogSourceGoogle
 	case constants.LoginLogSourceTelegram:
 		return constants.LoginLogSourceTelegram
+	case constants.LoginLogSourceOIDC:
+		return constants.LoginLogSourceOIDC
 	default:
 		return constants.LoginLogSourceWeb
 	}
@@ -460,6 +483,9 @@ func (s *Service) CompleteLoginAfter2FA(userID uint, rememberMe bool) (*UserLogi
 	if user == nil {
 		return nil, ErrNotFound
 	}
+	if err := s.checkCustomerBlacklist(orderriskcontract.BlacklistSubject{UserID: user.ID, Email: user.Email}); err != nil {
+		return nil, err
+	}
 	expireHours := resolveUserJWTExpireHours(s.cfg.UserJWT)
 	if rememberMe {
 		expireHours = resolveRememberMeExpireHours(s.cfg.UserJWT)
@@ -477,6 +503,18 @@ func (s *Service) CompleteLoginAfter2FA(userID uint, rememberMe bool) (*UserLogi
 	return &UserLoginResult{RequiresTOTP: false, User: user, Token: token, ExpiresAt: expiresAt}, nil
 }
 
+// checkCustomerBlacklist 校验客户身份；提供账户 ID 时，账户邮箱与已绑定的 Telegram ID 由黑名单服务补充。
+func (s *Service) checkCustomerBlacklist(subject orderriskcontract.BlacklistSubject) error {
+	if s.customerBlacklist == nil {
+		return nil
+	}
+	err := s.customerBlacklist.CheckCustomer(subject)
+	if errors.Is(err, orderriskcontract.ErrCustomerBlacklisted) {
+		return ErrCustomerBlacklisted
+	}
+	return err
+}
+
 func (s *Service) verifyCode(email, purpose, code string) (*emailverificationdomain.Code, error) {
 	record, err := s.codeRepo.GetLatest(email, purpose)
 	if err != nil {
@@ -9,6 +9,7 @@ import (
 	"github.com/dujiao-next/internal/constants"
 	externalidentitydomain "github.com/dujiao-next/internal/modules/identity/externalidentity/domain"
 	telegramauthapp "github.com/dujiao-next/internal/modules/identity/telegramauth/application"
+	orderriskcontract "github.com/dujiao-next/internal/modules/orderrisk/contract"
 )
 
 // LoginWithTelegramInput Telegram 登录输入
@@ -58,6 +59,12 @@ func (s *Service) LoginWithTelegramMiniApp(input LoginWithTelegramMiniAppInput)
 // LoginVerifiedTelegram completes a login after a trusted Telegram verifier
 // has authenticated and normalized the upstream identity.
 func (s *Service) LoginVerifiedTelegram(verified *telegramauthapp.IdentityVerified) (*UserLoginResult, error) {
+	// 先按 Telegram ID 拦截，避免为已拉黑的 Telegram 账号自动建号
+	if verified != nil {
+		if err := s.checkCustomerBlacklist(orderriskcontract.BlacklistSubject{TelegramIDs: []string{verified.ProviderUserID}}); err != nil {
+			return nil, err
+		}
+	}
 	identity, err := s.getTelegramIdentityByVerifiedID(verified)
 	if err != nil {
 		return nil, err
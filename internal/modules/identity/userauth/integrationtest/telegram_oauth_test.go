@@ -356,7 +356,7 @@ func TestTelegramMiniAppLoginReturnsRegistrationDisabledWhenCreatingNewUser(t *t
 // 必须被分配默认会员等级，且不会被后续 Update(Save) 用零值覆盖（issue #197）。
 func TestLoginWithTelegramAssignsDefaultMemberLevel(t *testing.T) {
 	svc, _, db := setupTelegramOAuthTestService(t)
-	if err := db.AutoMigrate(&memberleveldomain.MemberLevel{}); err != nil {
+	if err := db.AutoMigrate(&memberleveldomain.MemberLevel{}, &memberleveldomain.MemberLevelHistory{}); err != nil {
 		t.Fatalf("auto migrate member level failed: %v", err)
 	}
 
@@ -376,7 +376,7 @@ func TestLoginWithTelegramAssignsDefaultMemberLevel(t *testing.T) {
 	svc.SetMemberLevelService(memberlevelapp.NewService(
 		memberlevelgormstore.NewLevelStore(db),
 		nil,
-		userstore.New(db),
+		memberlevelgormstore.NewUserStore(db),
 	))
 
 	res, err := svc.LoginVerifiedTelegram(&telegramauthapp.IdentityVerified{
@@ -140,3 +140,24 @@ func RegisterUserGoogleRoutes(user gin.IRoutes, handler *UserGoogleHandler, bind
 	user.POST("/me/google/redirect/exchange", handler.ExchangeGoogleRedirectBind)
 	user.DELETE("/me/google/unbind", handler.UnbindMyGoogle)
 }
+
+// RegisterUserOIDCAuthRoutes 注册公开的通用 OIDC 登录端点（需附带限流中间件）。
+func RegisterUserOIDCAuthRoutes(auth gin.IRoutes, handler *UserOIDCHandler, rateLimit gin.HandlerFunc) {
+	if auth == nil || handler == nil || rateLimit == nil {
+		panic("user oidc auth routes: required dependency is nil")
+	}
+	auth.GET("/oidc/providers", handler.ListOIDCProviders)
+	auth.GET("/oidc/:provider/start", rateLimit, handler.StartOIDCLogin)
+	auth.POST("/oidc/:provider/callback", rateLimit, handler.OIDCLoginCallback)
+}
+
+// RegisterUserOIDCRoutes 注册登录态通用 OIDC 绑定端点。
+func RegisterUserOIDCRoutes(user gin.IRoutes, handler *UserOIDCHandler) {
+	if user == nil || handler == nil {
+		panic("user oidc routes: required dependency is nil")
+	}
+	user.GET("/me/oidc", handler.GetMyOIDCBindings)
+	user.GET("/me/oidc/:provider/start", handler.StartOIDCBind)
+	user.POST("/me/oidc/:provider/callback", handler.OIDCBindCallback)
+	user.DELETE("/me/oidc/:provider", handler.UnbindMyOIDC)
+}
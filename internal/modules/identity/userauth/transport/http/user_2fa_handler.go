@@ -331,6 +331,11 @@ func (h *User2FAHandler) VerifyUser2FA(c *gin.Context) {
 	}
 	loginRes, err := h.auth.CompleteLoginAfter2FA(claims.UserID, claims.RememberMe)
 	if err != nil {
+		if errors.Is(err, ErrCustomerBlacklisted) {
+			h.recordLogin(c, "", claims.UserID, constants.LoginLogStatusFailed, constants.LoginLogFailReasonCustomerBlacklisted, resolvedChallengeLoginSource(claims))
+			ginutil.RespondError(c, response.CodeForbidden, "error.customer_blacklisted", nil)
+			return
+		}
 		ginutil.RespondError(c, response.CodeInternal, "error.login_failed", err)
 		return
 	}
@@ -352,6 +357,8 @@ func resolvedChallengeLoginSource(claims *UserChallengeClaims) string {
 		return constants.LoginLogSourceGoogle
 	case constants.LoginLogSourceTelegram:
 		return constants.LoginLogSourceTelegram
+	case constants.LoginLogSourceOIDC:
+		return constants.LoginLogSourceOIDC
 	default:
 		return constants.LoginLogSourceWeb
 	}
@@ -143,6 +143,7 @@ var googleLoginErrorRules = []googleLoginErrorRule{
 	{target: ErrUserOAuthAlreadyBound, code: response.CodeBadRequest, key: "error.google_already_bound", failReason: constants.LoginLogFailReasonGoogleInvalid},
 	{target: ErrEmailDomainNotAllowed, code: response.CodeBadRequest, key: "error.email_domain_not_allowed", failReason: constants.LoginLogFailReasonGoogleInvalid},
 	{target: ErrUserDisabled, code: response.CodeUnauthorized, key: "error.user_disabled", failReason: constants.LoginLogFailReasonUserDisabled},
+	{target: ErrCustomerBlacklisted, code: response.CodeForbidden, key: "error.customer_blacklisted", failReason: constants.LoginLogFailReasonCustomerBlacklisted},
 	{target: ErrRegistrationDisabled, code: response.CodeForbidden, key: "error.registration_disabled", failReason: constants.LoginLogFailReasonBadRequest},
 }
 
@@ -122,6 +122,8 @@ func (h *UserLoginHandler) UserRegister(c *gin.Context) {
 			ginutil.RespondError(c, response.CodeBadRequest, "error.verify_code_attempts_exceeded", nil)
 		case errors.Is(err, ErrAgreementRequired):
 			ginutil.RespondError(c, response.CodeBadRequest, "error.agreement_required", nil)
+		case errors.Is(err, ErrCustomerBlacklisted):
+			ginutil.RespondError(c, response.CodeForbidden, "error.customer_blacklisted", nil)
 		case errors.Is(err, ErrWeakPassword):
 			respondWeakPassword(c, err)
 		default:
@@ -184,6 +186,9 @@ func (h *UserLoginHandler) UserLogin(c *gin.Context) {
 		case errors.Is(err, ErrUserDisabled):
 			h.recordLogin(c, req.Email, 0, constants.LoginLogStatusFailed, constants.LoginLogFailReasonUserDisabled, constants.LoginLogSourceWeb)
 			ginutil.RespondError(c, response.CodeUnauthorized, "error.user_disabled", nil)
+		case errors.Is(err, ErrCustomerBlacklisted):
+			h.recordLogin(c, req.Email, 0, constants.LoginLogStatusFailed, constants.LoginLogFailReasonCustomerBlacklisted, constants.LoginLogSourceWeb)
+			ginutil.RespondError(c, response.CodeForbidden, "error.customer_blacklisted", nil)
 		default:
 			h.recordLogin(c, req.Email, 0, constants.LoginLogStatusFailed, constants.LoginLogFailReasonInternalError, constants.LoginLogSourceWeb)
 			ginutil.RespondError(c, response.CodeInternal, "error.login_failed", err)
@@ -0,0 +1,255 @@
+package userauthhttp
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dujiao-next/internal/constants"
+	externalidentitydomain "github.com/dujiao-next/internal/modules/identity/externalidentity/domain"
+	userpresenter "github.com/dujiao-next/internal/modules/identity/userauth/transport/presenter"
+	"github.com/dujiao-next/internal/platform/http/ginutil"
+	"github.com/dujiao-next/internal/platform/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	ErrOIDCProviderNotFound     = errors.New("oidc provider not found")
+	ErrOIDCProviderDisabled     = errors.New("oidc provider disabled")
+	ErrOIDCProviderInvalid      = errors.New("oidc provider config invalid")
+	ErrOIDCDiscoveryUnavailable = errors.New("oidc discovery unavailable")
+	ErrOIDCStateInvalid         = errors.New("oidc state invalid")
+	ErrOIDCTokenExchange        = errors.New("oidc token exchange failed")
+	ErrOIDCIDTokenInvalid       = errors.New("oidc id token invalid")
+	ErrOIDCClaimsInvalid        = errors.New("oidc claims invalid")
+	ErrOIDCAutoLinkForbidden    = errors.New("oidc email auto link forbidden")
+	ErrOIDCEmailRequired        = errors.New("oidc verified email required")
+	ErrOIDCUnbindLocked         = errors.New("oidc unbind would lock account")
+)
+
+// OIDCProviderView 是店铺登录页展示的 OIDC 提供方。
+type OIDCProviderView struct {
+	Slug        string `json:"slug"`
+	DisplayName string `json:"display_name"`
+	IconURL     string `json:"icon_url"`
+}
+
+// OIDCBindingResult 是 transport 层 OIDC 绑定视图。
+type OIDCBindingResult struct {
+	ProviderSlug string
+	Identity     *externalidentitydomain.Identity
+	CanUnbind    bool
+}
+
+// UserOIDCService 是通用 OIDC 登录与绑定端点所需的最小端口。
+type UserOIDCService interface {
+	ListOIDCProviders() ([]OIDCProviderView, error)
+	StartOIDC(ctx context.Context, slug, intent string, userID uint) (string, error)
+	LoginWithOIDC(ctx context.Context, slug, code, state string) (*AuthLoginResult, error)
+	BindOIDC(ctx context.Context, userID uint, slug, code, state string) (*OIDCBindingResult, error)
+	ListOIDCBindings(userID uint) ([]OIDCBindingResult, error)
+	UnbindOIDC(userID uint, slug string) error
+}
+
+// UserOIDCHandler 处理通用 OIDC/OAuth2 登录与绑定 HTTP 请求。
+type UserOIDCHandler struct {
+	service  UserOIDCService
+	recorder LoginRecorder
+}
+
+func NewUserOIDCHandler(service UserOIDCService, recorder LoginRecorder) *UserOIDCHandler {
+	if service == nil {
+		panic("user oidc handler: service is nil")
+	}
+	return &UserOIDCHandler{service: service, recorder: recorder}
+}
+
+type oidcCallbackRequest struct {
+	Code  string `json:"code" binding:"required"`
+	State string `json:"state" binding:"required"`
+}
+
+type oidcErrorRule struct {
+	target     error
+	code       int
+	key        string
+	failReason string
+	logErr     bool
+}
+
+var oidcErrorRules = []oidcErrorRule{
+	{target: ErrOIDCProviderNotFound, code: response.CodeNotFound, key: "error.oidc_provider_not_found", failReason: constants.LoginLogFailReasonOIDCConfig},
+	{target: ErrOIDCProviderDisabled, code: response.CodeBadRequest, key: "error.oidc_provider_disabled", failReason: constants.LoginLogFailReasonOIDCConfig},
+	{target: ErrOIDCProviderInvalid, code: response.CodeInternal, key: "error.oidc_provider_config_invalid", failReason: constants.LoginLogFailReasonOIDCConfig, logErr: true},
+	{target: ErrOIDCDiscoveryUnavailable, code: response.CodeInternal, key: "error.oidc_service_unavailable", failReason: constants.LoginLogFailReasonOIDCConfig, logErr: true},
+	{target: ErrOIDCStateInvalid, code: response.CodeBadRequest, key: "error.oidc_state_invalid", failReason: constants.LoginLogFailReasonOIDCInvalid},
+	{target: ErrOIDCTokenExchange, code: response.CodeBadRequest, key: "error.oidc_token_exchange_failed", failReason: constants.LoginLogFailReasonOIDCInvalid, logErr: true},
+	{target: ErrOIDCIDTokenInvalid, code: response.CodeBadRequest, key: "error.oidc_id_token_invalid", failReason: constants.LoginLogFailReasonOIDCInvalid},
+	{target: ErrOIDCClaimsInvalid, code: response.CodeBadRequest, key: "error.oidc_claims_invalid", failReason: constants.LoginLogFailReasonOIDCInvalid},
+	{target: ErrOIDCEmailRequired, code: response.CodeBadRequest, key: "error.oidc_email_required", failReason: constants.LoginLogFailReasonOIDCInvalid},
+	{target: ErrOIDCAutoLinkForbidden, code: response.CodeForbidden, key: "error.oidc_auto_link_forbidden", failReason: constants.LoginLogFailReasonOIDCInvalid},
+	{target: ErrOIDCUnbindLocked, code: response.CodeBadRequest, key: "error.oidc_unbind_locked"},
+	{target: ErrUserOAuthIdentityExists, code: response.CodeBadRequest, key: "error.oidc_bind_conflict", failReason: constants.LoginLogFailReasonOIDCInvalid},
+	{target: ErrUserOAuthAlreadyBound, code: response.CodeBadRequest, key: "error.oidc_already_bound", failReason: constants.LoginLogFailReasonOIDCInvalid},
+	{target: ErrUserOAuthNotBound, code: response.CodeBadRequest, key: "error.oidc_not_bound"},
+	{target: ErrEmailDomainNotAllowed, code: response.CodeBadRequest, key: "error.email_domain_not_allowed", failReason: constants.LoginLogFailReasonOIDCInvalid},
+	{target: ErrUserDisabled, code: response.CodeUnauthorized, key: "error.user_disabled", failReason: constants.LoginLogFailReasonUserDisabled},
+	{target: ErrCustomerBlacklisted, code: response.CodeForbidden, key: "error.customer_blacklisted", failReason: constants.LoginLogFailReasonCustomerBlacklisted},
+	{target: ErrRegistrationDisabled, code: response.CodeForbidden, key: "error.registration_disabled", failReason: constants.LoginLogFailReasonBadRequest},
+}
+
+func matchOIDCErrorRule(err error) (oidcErrorRule, bool) {
+	for _, rule := range oidcErrorRules {
+		if errors.Is(err, rule.target) {
+			return rule, true
+		}
+	}
+	return oidcErrorRule{}, false
+}
+
+func respondOIDCError(c *gin.Context, err error, fallbackKey string) {
+	rule, ok := matchOIDCErrorRule(err)
+	if !ok {
+		ginutil.RespondError(c, response.CodeInternal, fallbackKey, err)
+		return
+	}
+	var cause error
+	if rule.logErr {
+		cause = err
+	}
+	ginutil.RespondError(c, rule.code, rule.key, cause)
+}
+
+func (h *UserOIDCHandler) recordLogin(c *gin.Context, email string, userID uint, status, failReason string) {
+	if h == nil || h.recorder == nil || c == nil {
+		return
+	}
+	requestID := ""
+	if rid, ok := c.Get("request_id"); ok {
+		requestID, _ = rid.(string)
+	}
+	h.recorder.Record(email, userID, status, failReason, constants.LoginLogSourceOIDC, c.ClientIP(), c.GetHeader("User-Agent"), requestID)
+}
+
+// ListOIDCProviders 返回已启用的通用 OIDC 提供方。
+func (h *UserOIDCHandler) ListOIDCProviders(c *gin.Context) {
+	providers, err := h.service.ListOIDCProviders()
+	if err != nil {
+		ginutil.RespondError(c, response.CodeInternal, "error.config_fetch_failed", err)
+		return
+	}
+	response.Success(c, providers)
+}
+
+// StartOIDCLogin 返回指定提供方的授权 URL（登录流程）。
+func (h *UserOIDCHandler) StartOIDCLogin(c *gin.Context) {
+	authURL, err := h.service.StartOIDC(c.Request.Context(), c.Param("provider"), "login", 0)
+	if err != nil {
+		respondOIDCError(c, err, "error.login_failed")
+		return
+	}
+	response.Success(c, gin.H{"auth_url": authURL})
+}
+
+// OIDCLoginCallback 处理通用 OIDC 回调（登录）。
+func (h *UserOIDCHandler) OIDCLoginCallback(c *gin.Context) {
+	var req oidcCallbackRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.recordLogin(c, "", 0, constants.LoginLogStatusFailed, constants.LoginLogFailReasonBadRequest)
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", err)
+		return
+	}
+	res, err := h.service.LoginWithOIDC(c.Request.Context(), c.Param("provider"), req.Code, req.State)
+	if err == nil && (res == nil || res.User == nil) {
+		err = errors.New("oidc login returned an empty result")
+	}
+	if err != nil {
+		failReason := constants.LoginLogFailReasonInternalError
+		if rule, ok := matchOIDCErrorRule(err); ok && rule.failReason != "" {
+			failReason = rule.failReason
+		}
+		h.recordLogin(c, "", 0, constants.LoginLogStatusFailed, failReason)
+		respondOIDCError(c, err, "error.login_failed")
+		return
+	}
+	if res.RequiresTOTP {
+		h.recordLogin(c, res.User.Email, res.User.ID, constants.LoginLogStatusSuccess, constants.LoginLogPasswordOK2FAPending)
+		response.Success(c, gin.H{
+			"requires_totp":        true,
+			"challenge_token":      res.ChallengeToken,
+			"challenge_expires_at": res.ChallengeExpiresAt.Format("2006-01-02T15:04:05Z07:00"),
+		})
+		return
+	}
+	h.recordLogin(c, res.User.Email, res.User.ID, constants.LoginLogStatusSuccess, "")
+	response.Success(c, gin.H{
+		"requires_totp": false,
+		"user":          userpresenter.NewUserAuthBriefResp(res.User),
+		"token":         res.Token,
+		"expires_at":    res.ExpiresAt.Format("2006-01-02T15:04:05Z07:00"),
+	})
+}
+
+// GetMyOIDCBindings 返回当前用户的通用 OIDC 绑定列表。
+func (h *UserOIDCHandler) GetMyOIDCBindings(c *gin.Context) {
+	uid, ok := ginutil.GetUserID(c)
+	if !ok {
+		return
+	}
+	bindings, err := h.service.ListOIDCBindings(uid)
+	if err != nil {
+		respondOIDCError(c, err, "error.user_fetch_failed")
+		return
+	}
+	items := make([]userpresenter.OIDCBindingResp, 0, len(bindings))
+	for _, binding := range bindings {
+		items = append(items, userpresenter.NewOIDCBindingResp(binding.ProviderSlug, binding.Identity, binding.CanUnbind))
+	}
+	response.Success(c, items)
+}
+
+// StartOIDCBind 返回指定提供方的授权 URL（绑定流程，需登录）。
+func (h *UserOIDCHandler) StartOIDCBind(c *gin.Context) {
+	uid, ok := ginutil.GetUserID(c)
+	if !ok {
+		return
+	}
+	authURL, err := h.service.StartOIDC(c.Request.Context(), c.Param("provider"), "bind", uid)
+	if err != nil {
+		respondOIDCError(c, err, "error.user_update_failed")
+		return
+	}
+	response.Success(c, gin.H{"auth_url": authURL})
+}
+
+// OIDCBindCallback 处理通用 OIDC 回调（绑定，需登录）。
+func (h *UserOIDCHandler) OIDCBindCallback(c *gin.Context) {
+	uid, ok := ginutil.GetUserID(c)
+	if !ok {
+		return
+	}
+	var req oidcCallbackRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ginutil.RespondBindError(c, err)
+		return
+	}
+	binding, err := h.service.BindOIDC(c.Request.Context(), uid, c.Param("provider"), req.Code, req.State)
+	if err != nil {
+		respondOIDCError(c, err, "error.user_update_failed")
+		return
+	}
+	response.Success(c, userpresenter.NewOIDCBindingResp(binding.ProviderSlug, binding.Identity, binding.CanUnbind))
+}
+
+// UnbindMyOIDC 解绑指定的通用 OIDC 提供方。
+func (h *UserOIDCHandler) UnbindMyOIDC(c *gin.Context) {
+	uid, ok := ginutil.GetUserID(c)
+	if !ok {
+		return
+	}
+	if err := h.service.UnbindOIDC(uid, c.Param("provider")); err != nil {
+		respondOIDCError(c, err, "error.user_update_failed")
+		return
+	}
+	response.Success(c, nil)
+}
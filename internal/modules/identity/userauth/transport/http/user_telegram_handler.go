@@ -119,6 +119,7 @@ var telegramLoginErrorRules = []telegramLoginErrorRule{
 	{target: ErrTelegramAuthExpired, code: response.CodeBadRequest, key: "error.telegram_auth_expired", failReason: constants.LoginLogFailReasonTelegramExpired},
 	{target: ErrTelegramAuthReplay, code: response.CodeBadRequest, key: "error.telegram_auth_replayed", failReason: constants.LoginLogFailReasonTelegramReplayed},
 	{target: ErrUserDisabled, code: response.CodeUnauthorized, key: "error.user_disabled", failReason: constants.LoginLogFailReasonUserDisabled},
+	{target: ErrCustomerBlacklisted, code: response.CodeForbidden, key: "error.customer_blacklisted", failReason: constants.LoginLogFailReasonCustomerBlacklisted},
 	{target: ErrRegistrationDisabled, code: response.CodeForbidden, key: "error.registration_disabled", failReason: constants.LoginLogFailReasonBadRequest},
 }
 
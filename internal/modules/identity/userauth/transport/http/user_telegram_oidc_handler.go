@@ -28,6 +28,7 @@ var (
 	ErrUserOAuthIdentityExists    = errors.New("user oauth identity exists")
 	ErrUserOAuthAlreadyBound      = errors.New("user oauth already bound")
 	ErrUserDisabled               = errors.New("user disabled")
+	ErrCustomerBlacklisted        = errors.New("customer blacklisted")
 	ErrRegistrationDisabled       = errors.New("registration disabled")
 )
 
@@ -95,6 +96,8 @@ func respondTelegramOIDCError(c *gin.Context, err error) {
 		ginutil.RespondError(c, response.CodeBadRequest, "error.telegram_already_bound", nil)
 	case errors.Is(err, ErrUserDisabled):
 		ginutil.RespondError(c, response.CodeUnauthorized, "error.user_disabled", nil)
+	case errors.Is(err, ErrCustomerBlacklisted):
+		ginutil.RespondError(c, response.CodeForbidden, "error.customer_blacklisted", nil)
 	case errors.Is(err, ErrRegistrationDisabled):
 		ginutil.RespondError(c, response.CodeForbidden, "error.registration_disabled", nil)
 	default:
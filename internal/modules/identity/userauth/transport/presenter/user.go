@@ -17,6 +17,7 @@ type UserProfileResp struct {
 	EmailVerifiedAt    *time.Time   `json:"email_verified_at"`
 	Locale             string       `json:"locale"`
 	MemberLevelID      uint         `json:"member_level_id"`
+	MemberLevelExpires *time.Time   `json:"member_level_expires_at"`
 	TotalRecharged     money.Amount `json:"total_recharged"`
 	TotalSpent         money.Amount `json:"total_spent"`
 	EmailChangeMode    string       `json:"email_change_mode,omitempty"`
@@ -35,13 +36,14 @@ func NewUserProfileResp(user *userdomain.User, emailMode, passwordMode string) U
 		EmailVerifiedAt:    user.EmailVerifiedAt,
 		Locale:             user.Locale,
 		MemberLevelID:      user.MemberLevelID,
+		MemberLevelExpires: user.MemberLevelExpiresAt,
 		TotalRecharged:     user.TotalRecharged,
 		TotalSpent:         user.TotalSpent,
 		EmailChangeMode:    emailMode,
 		PasswordChangeMode: passwordMode,
 	}
 	// 排除：PasswordHash、PasswordSetupRequired、Status、TokenVersion、TokenInvalidBefore、
-	// LastLoginAt、CreatedAt、UpdatedAt、DeletedAt
+	// MemberLevelSource、LastLoginAt、CreatedAt、UpdatedAt、DeletedAt
 }
 
 // TelegramBindingResp Telegram 绑定状态响应
@@ -86,6 +88,28 @@ func NewGoogleBindingResp(identity *externalidentitydomain.Identity, email, disp
 	}
 }
 
+// OIDCBindingResp 通用 OIDC 绑定状态响应。
+type OIDCBindingResp struct {
+	ProviderSlug   string     `json:"provider_slug"`
+	ProviderUserID string     `json:"provider_user_id"`
+	Username       string     `json:"username,omitempty"`
+	AvatarURL      string     `json:"avatar_url,omitempty"`
+	AuthAt         *time.Time `json:"auth_at,omitempty"`
+	CanUnbind      bool       `json:"can_unbind"`
+}
+
+// NewOIDCBindingResp 从外部身份领域实体构造通用 OIDC 绑定响应。
+func NewOIDCBindingResp(slug string, identity *externalidentitydomain.Identity, canUnbind bool) OIDCBindingResp {
+	resp := OIDCBindingResp{ProviderSlug: slug, CanUnbind: canUnbind}
+	if identity != nil {
+		resp.ProviderUserID = identity.ProviderUserID
+		resp.Username = identity.Username
+		resp.AvatarURL = identity.AvatarURL
+		resp.AuthAt = identity.AuthAt
+	}
+	return resp
+}
+
 // NewTelegramBindingResp 从外部身份领域实体构造响应。
 func NewTelegramBindingResp(identity *externalidentitydomain.Identity, canUnbind ...bool) TelegramBindingResp {
 	resolvedCanUnbind := len(canUnbind) > 0 && canUnbind[0]
@@ -0,0 +1,274 @@
+package application
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/dujiao-next/internal/constants"
+	"github.com/dujiao-next/internal/crypto"
+	"github.com/dujiao-next/internal/logger"
+	licensecontract "github.com/dujiao-next/internal/modules/license/contract"
+	licensedomain "github.com/dujiao-next/internal/modules/license/domain"
+)
+
+// Options 声明授权码应用服务的全部端口。
+type Options struct {
+	Templates licensecontract.TemplateStore
+	Licenses  licensecontract.LicenseStore
+	Orders    licensecontract.OrderReader
+	Completer licensecontract.Completer
+	Notifier  licensecontract.FallbackNotifier
+	// SecretKey 用于加密模板私钥
+	SecretKey string
+	Now       func() time.Time
+}
+
+// Service 管理授权码模板、签发、吊销与公开校验。
+type Service struct {
+	templates licensecontract.TemplateStore
+	licenses  licensecontract.LicenseStore
+	orders    licensecontract.OrderReader
+	completer licensecontract.Completer
+	notifier  licensecontract.FallbackNotifier
+	encKey    []byte
+	now       func() time.Time
+}
+
+// NewService 创建授权码应用服务。
+func NewService(options Options) *Service {
+	if options.Templates == nil {
+		panic("license service: templates are nil")
+	}
+	if options.Licenses == nil {
+		panic("license service: licenses are nil")
+	}
+	if options.Orders == nil {
+		panic("license service: orders are nil")
+	}
+	if options.Completer == nil {
+		panic("license service: completer is nil")
+	}
+	if strings.TrimSpace(options.SecretKey) == "" {
+		panic("license service: secret key is empty")
+	}
+	if options.Now == nil {
+		options.Now = time.Now
+	}
+	return &Service{
+		templates: options.Templates,
+		licenses:  options.Licenses,
+		orders:    options.Orders,
+		completer: options.Completer,
+		notifier:  options.Notifier,
+		encKey:    crypto.DeriveKey(options.SecretKey),
+		now:       options.Now,
+	}
+}
+
+// DeliverForOrder 为已支付的授权码订单签发授权码并完成交付，重复调用幂等。
+// 任一订单项缺少启用的模板时订单保持交付中，并提醒管理员人工交付。
+func (s *Service) DeliverForOrder(orderID uint) error {
+	order, err := s.orders.GetByID(orderID)
+	if err != nil {
+		return err
+	}
+	if order == nil {
+		return licensecontract.ErrOrderNotFound
+	}
+	if !hasLicenseItems(order) {
+		return licensecontract.ErrNotLicenseOrder
+	}
+	if order.Status != constants.OrderStatusPaid && order.Status != constants.OrderStatusFulfilling {
+		return nil
+	}
+
+	issued, err := s.licenses.ListByOrderIDs([]uint{order.ID})
+	if err != nil {
+		return err
+	}
+	if len(issued) == 0 {
+		issued, err = s.issue(order)
+		if err != nil {
+			return err
+		}
+		if len(issued) == 0 {
+			logger.Warnw("license_template_missing", "order_id", order.ID)
+			if s.notifier != nil {
+				if err := s.notifier.NotifyManualFulfillmentPending(order.ID); err != nil {
+					logger.Warnw("license_notify_fallback_failed", "order_id", order.ID, "error", err)
+				}
+			}
+			return nil
+		}
+		if err := s.licenses.CreateBatch(issued); err != nil {
+			return err
+		}
+	}
+
+	keys := make([]licensecontract.IssuedKey, 0, len(issued))
+	for _, license := range issued {
+		keys = append(keys, licensecontract.IssuedKey{ProductID: license.ProductID, SKUID: license.SKUID, Key: license.Key})
+	}
+	if err := s.completer.CompleteLicense(order.ID, keys); err != nil {
+		if errors.Is(err, licensecontract.ErrAlreadyFulfilled) || errors.Is(err, licensecontract.ErrOrderNotPending) {
+			return nil
+		}
+		return err
+	}
+	logger.Infow("license_keys_delivered", "order_id", order.ID, "count", len(keys))
+	return nil
+}
+
+// RevokeForOrder 由退款服务调用，吊销订单及其子订单签发的授权码，之后校验返回 revoked。
+func (s *Service) RevokeForOrder(orderID uint) error {
+	if orderID == 0 {
+		return nil
+	}
+	revoked, err := s.licenses.RevokeByOrder(orderID, licensedomain.RevokeReasonRefunded, s.now())
+	if err != nil {
+		return err
+	}
+	if revoked > 0 {
+		logger.Infow("license_keys_revoked", "order_id", orderID, "count", revoked)
+	}
+	return nil
+}
+
+// RevokeLicense 管理员手动吊销单个授权码。
+func (s *Service) RevokeLicense(id uint) (*licensedomain.License, error) {
+	license, err := s.licenses.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if license == nil {
+		return nil, licensecontract.ErrLicenseNotFound
+	}
+	if _, err := s.licenses.Revoke(license.ID, licensedomain.RevokeReasonManual, s.now()); err != nil {
+		return nil, err
+	}
+	return s.licenses.GetByID(license.ID)
+}
+
+// ListLicenses 管理端查询已签发授权码。
+func (s *Service) ListLicenses(filter licensecontract.ListFilter) ([]licensedomain.License, int64, error) {
+	filter.Serial = strings.ToLower(strings.TrimSpace(filter.Serial))
+	return s.licenses.List(filter)
+}
+
+// issue 为每个授权码订单项按数量签发，任一订单项缺少模板时不签发。
+func (s *Service) issue(order *licensecontract.OrderSnapshot) ([]licensedomain.License, error) {
+	now := s.now()
+	rootOrderID := order.ID
+	if order.ParentID != nil && *order.ParentID > 0 {
+		rootOrderID = *order.ParentID
+	}
+	licenses := make([]licensedomain.License, 0)
+	for _, item := range order.Items {
+		if strings.TrimSpace(item.FulfillmentType) != constants.FulfillmentTypeLicense {
+			continue
+		}
+		template, err := s.resolveTemplate(item.ProductID, item.SKUID)
+		if err != nil {
+			return nil, err
+		}
+		if template == nil {
+			return nil, nil
+		}
+		privateKey, err := s.decryptPrivateKey(template)
+		if err != nil {
+			return nil, err
+		}
+		var expiresAt *time.Time
+		if template.ValidDays > 0 {
+			value := now.AddDate(0, 0, template.ValidDays)
+			expiresAt = &value
+		}
+		for i := 0; i < item.Quantity; i++ {
+			serial, err := newSerial()
+			if err != nil {
+				return nil, err
+			}
+			claims := licensedomain.Claims{
+				Serial:    serial,
+				ProductID: item.ProductID,
+				SKUID:     item.SKUID,
+				OrderNo:   order.OrderNo,
+				Seats:     template.Seats,
+			}
+			if expiresAt != nil {
+				claims.ExpiresAt = expiresAt.Unix()
+			}
+			key, err := licensedomain.Issue(privateKey, claims, template.Prefix, template.GroupSize)
+			if err != nil {
+				return nil, err
+			}
+			licenses = append(licenses, licensedomain.License{
+				Serial:      serial,
+				TemplateID:  template.ID,
+				ProductID:   item.ProductID,
+				SKUID:       item.SKUID,
+				OrderID:     order.ID,
+				RootOrderID: rootOrderID,
+				OrderNo:     order.OrderNo,
+				Key:         key,
+				Seats:       template.Seats,
+				ExpiresAt:   expiresAt,
+				CreatedAt:   now,
+				UpdatedAt:   now,
+			})
+		}
+	}
+	return licenses, nil
+}
+
+// resolveTemplate SKU 模板优先，其次商品级模板；停用的模板视为缺失。
+func (s *Service) resolveTemplate(productID, skuID uint) (*licensedomain.Template, error) {
+	scopes := []uint{skuID}
+	if skuID != 0 {
+		scopes = append(scopes, 0)
+	}
+	for _, scope := range scopes {
+		template, err := s.templates.GetByScope(productID, scope)
+		if err != nil {
+			return nil, err
+		}
+		if template != nil && template.IsActive {
+			return template, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *Service) decryptPrivateKey(template *licensedomain.Template) (ed25519.PrivateKey, error) {
+	encoded, err := crypto.Decrypt(s.encKey, template.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	seed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(seed) != ed25519.SeedSize {
+		return nil, errors.New("license template private key corrupted")
+	}
+	return ed25519.NewKeyFromSeed(seed), nil
+}
+
+func newSerial() (string, error) {
+	buffer := make([]byte, 8)
+	if _, err := rand.Read(buffer); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buffer), nil
+}
+
+func hasLicenseItems(order *licensecontract.OrderSnapshot) bool {
+	for _, item := range order.Items {
+		if strings.TrimSpace(item.FulfillmentType) == constants.FulfillmentTypeLicense {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,106 @@
+package application
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"strings"
+
+	"github.com/dujiao-next/internal/crypto"
+	licensecontract "github.com/dujiao-next/internal/modules/license/contract"
+	licensedomain "github.com/dujiao-next/internal/modules/license/domain"
+)
+
+// ListTemplates 列出商品的授权码模板。
+func (s *Service) ListTemplates(productID uint) ([]licensedomain.Template, error) {
+	if productID == 0 {
+		return nil, licensecontract.ErrTemplateInvalid
+	}
+	return s.templates.ListByProduct(productID)
+}
+
+// CreateTemplate 创建模板并生成独立的 Ed25519 密钥对。
+func (s *Service) CreateTemplate(input licensecontract.TemplateInput) (*licensedomain.Template, error) {
+	if input.ProductID == 0 {
+		return nil, licensecontract.ErrTemplateInvalid
+	}
+	template := &licensedomain.Template{ProductID: input.ProductID, SKUID: input.SKUID}
+	if err := applyTemplateInput(template, input); err != nil {
+		return nil, err
+	}
+	existing, err := s.templates.GetByScope(input.ProductID, input.SKUID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, licensecontract.ErrTemplateExists
+	}
+
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	encrypted, err := crypto.Encrypt(s.encKey, base64.StdEncoding.EncodeToString(privateKey.Seed()))
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	template.PublicKey = base64.StdEncoding.EncodeToString(publicKey)
+	template.PrivateKey = encrypted
+	template.CreatedAt = now
+	template.UpdatedAt = now
+	if err := s.templates.Create(template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// UpdateTemplate 更新模板格式与有效期，密钥对与适用范围不可变更。
+func (s *Service) UpdateTemplate(id uint, input licensecontract.TemplateInput) (*licensedomain.Template, error) {
+	template, err := s.templates.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if template == nil {
+		return nil, licensecontract.ErrTemplateNotFound
+	}
+	if err := applyTemplateInput(template, input); err != nil {
+		return nil, err
+	}
+	template.UpdatedAt = s.now()
+	if err := s.templates.Update(template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+func applyTemplateInput(template *licensedomain.Template, input licensecontract.TemplateInput) error {
+	prefix := strings.ToUpper(strings.TrimSpace(input.Prefix))
+	if len(prefix) > licensedomain.MaxPrefixLength {
+		return licensecontract.ErrTemplateInvalid
+	}
+	for _, r := range prefix {
+		if (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return licensecontract.ErrTemplateInvalid
+		}
+	}
+	groupSize := input.GroupSize
+	if groupSize == 0 {
+		groupSize = licensedomain.DefaultGroupSize
+	}
+	seats := input.Seats
+	if seats == 0 {
+		seats = licensedomain.DefaultSeats
+	}
+	if groupSize < licensedomain.MinGroupSize || groupSize > licensedomain.MaxGroupSize ||
+		seats < 1 || seats > licensedomain.MaxSeats ||
+		input.ValidDays < 0 || input.ValidDays > licensedomain.MaxValidDays {
+		return licensecontract.ErrTemplateInvalid
+	}
+	template.Prefix = prefix
+	template.GroupSize = groupSize
+	template.Seats = seats
+	template.ValidDays = input.ValidDays
+	template.IsActive = input.IsActive
+	return nil
+}
@@ -0,0 +1,74 @@
+package application
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"time"
+
+	"github.com/dujiao-next/internal/constants"
+	"github.com/dujiao-next/internal/logger"
+	licensecontract "github.com/dujiao-next/internal/modules/license/contract"
+	licensedomain "github.com/dujiao-next/internal/modules/license/domain"
+)
+
+// Verify 公开校验授权码：签名、签发记录、吊销与有效期。
+// 无法识别的授权码统一返回 invalid，不区分失败原因。
+func (s *Service) Verify(key string) (licensecontract.VerifyResult, error) {
+	invalid := licensecontract.VerifyResult{Status: licensedomain.VerifyStatusInvalid}
+	claims, _, _, err := licensedomain.Decode(key)
+	if err != nil {
+		return invalid, nil
+	}
+	license, err := s.licenses.GetBySerial(claims.Serial)
+	if err != nil {
+		return invalid, err
+	}
+	if license == nil || license.ProductID != claims.ProductID || license.SKUID != claims.SKUID || license.OrderNo != claims.OrderNo {
+		return invalid, nil
+	}
+	template, err := s.templates.GetByID(license.TemplateID)
+	if err != nil {
+		return invalid, err
+	}
+	if template == nil {
+		return invalid, nil
+	}
+	publicKey, err := base64.StdEncoding.DecodeString(template.PublicKey)
+	if err != nil {
+		return invalid, nil
+	}
+	if _, err := licensedomain.Verify(ed25519.PublicKey(publicKey), key); err != nil {
+		return invalid, nil
+	}
+
+	now := s.now()
+	if license.RevokedAt == nil {
+		s.revokeIfOrderRefunded(license, now)
+	}
+	status := license.Status(now)
+	return licensecontract.VerifyResult{
+		Valid:     status == licensedomain.VerifyStatusActive,
+		Status:    status,
+		Serial:    license.Serial,
+		ProductID: license.ProductID,
+		SKUID:     license.SKUID,
+		OrderNo:   license.OrderNo,
+		Seats:     license.Seats,
+		ExpiresAt: license.ExpiresAt,
+		RevokedAt: license.RevokedAt,
+	}, nil
+}
+
+// revokeIfOrderRefunded 客户端校验时发现订单已被直接改为退款，补记吊销后按 revoked 返回。
+func (s *Service) revokeIfOrderRefunded(license *licensedomain.License, now time.Time) {
+	order, err := s.orders.GetByID(license.OrderID)
+	if err != nil || order == nil || order.Status != constants.OrderStatusRefunded {
+		return
+	}
+	if _, err := s.licenses.Revoke(license.ID, licensedomain.RevokeReasonRefunded, now); err != nil {
+		logger.Warnw("license_lazy_revoke_failed", "license_id", license.ID, "error", err)
+		return
+	}
+	license.RevokedAt = &now
+	license.RevokeReason = licensedomain.RevokeReasonRefunded
+}
@@ -0,0 +1,14 @@
+package contract
+
+import "errors"
+
+var (
+	ErrTemplateNotFound = errors.New("license template not found")
+	ErrTemplateInvalid  = errors.New("license template invalid")
+	ErrTemplateExists   = errors.New("license template exists")
+	ErrLicenseNotFound  = errors.New("license not found")
+	ErrOrderNotFound    = errors.New("order not found")
+	ErrNotLicenseOrder  = errors.New("order has no license items")
+	ErrAlreadyFulfilled = errors.New("order already fulfilled")
+	ErrOrderNotPending  = errors.New("order not pending fulfillment")
+)
@@ -0,0 +1,44 @@
+package contract
+
+import (
+	"time"
+
+	licensedomain "github.com/dujiao-next/internal/modules/license/domain"
+)
+
+// TemplateStore 持久化授权码模板。
+type TemplateStore interface {
+	GetByID(id uint) (*licensedomain.Template, error)
+	GetByScope(productID, skuID uint) (*licensedomain.Template, error)
+	ListByProduct(productID uint) ([]licensedomain.Template, error)
+	Create(template *licensedomain.Template) error
+	Update(template *licensedomain.Template) error
+}
+
+// LicenseStore 持久化已签发授权码。
+type LicenseStore interface {
+	GetByID(id uint) (*licensedomain.License, error)
+	GetBySerial(serial string) (*licensedomain.License, error)
+	ListByOrderIDs(orderIDs []uint) ([]licensedomain.License, error)
+	List(filter ListFilter) ([]licensedomain.License, int64, error)
+	CreateBatch(licenses []licensedomain.License) error
+	// Revoke 仅吊销尚未吊销的授权码，返回是否发生变更
+	Revoke(id uint, reason string, at time.Time) (bool, error)
+	// RevokeByOrder 吊销订单或以其为父订单的全部授权码
+	RevokeByOrder(orderID uint, reason string, at time.Time) (int64, error)
+}
+
+// OrderReader 读取签发所需的订单快照。
+type OrderReader interface {
+	GetByID(id uint) (*OrderSnapshot, error)
+}
+
+// Completer 将签发的授权码写入交付记录与卡密台账并完成订单。
+type Completer interface {
+	CompleteLicense(orderID uint, keys []IssuedKey) error
+}
+
+// FallbackNotifier 缺少模板时转人工交付提醒。
+type FallbackNotifier interface {
+	NotifyManualFulfillmentPending(orderID uint) error
+}
@@ -0,0 +1,60 @@
+package contract
+
+import "time"
+
+// OrderItem 签发所需的订单项快照。
+type OrderItem struct {
+	ProductID       uint
+	SKUID           uint
+	Quantity        int
+	FulfillmentType string
+}
+
+// OrderSnapshot 签发所需的订单快照。
+type OrderSnapshot struct {
+	ID       uint
+	ParentID *uint
+	OrderNo  string
+	Status   string
+	Items    []OrderItem
+}
+
+// IssuedKey 交付给买家的单个授权码。
+type IssuedKey struct {
+	ProductID uint
+	SKUID     uint
+	Key       string
+}
+
+// TemplateInput 模板创建与更新输入；SKUID 仅在创建时生效。
+type TemplateInput struct {
+	ProductID uint
+	SKUID     uint
+	Prefix    string
+	GroupSize int
+	ValidDays int
+	Seats     int
+	IsActive  bool
+}
+
+// ListFilter 已签发授权码筛选条件。
+type ListFilter struct {
+	ProductID uint
+	OrderID   uint
+	Serial    string
+	Page      int
+	PageSize  int
+}
+
+// VerifyResult 公开校验结果；Valid 仅在签名有效、未吊销且未过期时为 true。
+type VerifyResult struct {
+	Valid     bool       `json:"valid"`
+	Status    string     `json:"status"`
+	Serial    string     `json:"serial,omitempty"`
+	ProductID uint       `json:"product_id,omitempty"`
+	SKUID     uint       `json:"sku_id,omitempty"`
+	OrderNo   string     `json:"order_no,omitempty"`
+	Seats     int        `json:"seats,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+}
@@ -0,0 +1,174 @@
+package domain
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"io"
+	"strings"
+)
+
+// keyVersion 授权码载荷版本，变更编码时递增
+const keyVersion byte = 1
+
+const (
+	serialSize     = 8
+	maxOrderNoSize = 64
+)
+
+var (
+	ErrKeyMalformed = errors.New("license key malformed")
+	ErrKeySignature = errors.New("license key signature invalid")
+)
+
+var keyEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// Claims 授权码载荷；ExpiresAt 为 Unix 秒，0 表示永久有效。
+type Claims struct {
+	Serial    string
+	ProductID uint
+	SKUID     uint
+	OrderNo   string
+	ExpiresAt int64
+	Seats     int
+}
+
+// MarshalBinary 紧凑编码：版本 | 8 字节序列号 | varint 字段 | 订单号。
+func (c Claims) MarshalBinary() ([]byte, error) {
+	serial, err := hex.DecodeString(c.Serial)
+	if err != nil || len(serial) != serialSize {
+		return nil, ErrKeyMalformed
+	}
+	if len(c.OrderNo) > maxOrderNoSize || c.Seats < 0 || c.ExpiresAt < 0 {
+		return nil, ErrKeyMalformed
+	}
+	buffer := make([]byte, 0, 1+serialSize+4*binary.MaxVarintLen64+len(c.OrderNo))
+	buffer = append(buffer, keyVersion)
+	buffer = append(buffer, serial...)
+	buffer = binary.AppendUvarint(buffer, uint64(c.ProductID))
+	buffer = binary.AppendUvarint(buffer, uint64(c.SKUID))
+	buffer = binary.AppendUvarint(buffer, uint64(c.ExpiresAt))
+	buffer = binary.AppendUvarint(buffer, uint64(c.Seats))
+	buffer = binary.AppendUvarint(buffer, uint64(len(c.OrderNo)))
+	buffer = append(buffer, c.OrderNo...)
+	return buffer, nil
+}
+
+// UnmarshalClaims 严格解析载荷，多余或缺失字节均视为格式错误。
+func UnmarshalClaims(payload []byte) (Claims, error) {
+	reader := bytes.NewReader(payload)
+	version, err := reader.ReadByte()
+	if err != nil || version != keyVersion {
+		return Claims{}, ErrKeyMalformed
+	}
+	serial := make([]byte, serialSize)
+	if _, err := io.ReadFull(reader, serial); err != nil {
+		return Claims{}, ErrKeyMalformed
+	}
+	var fields [5]uint64
+	for i := range fields {
+		value, err := binary.ReadUvarint(reader)
+		if err != nil {
+			return Claims{}, ErrKeyMalformed
+		}
+		fields[i] = value
+	}
+	if fields[4] > maxOrderNoSize || uint64(reader.Len()) != fields[4] {
+		return Claims{}, ErrKeyMalformed
+	}
+	orderNo := make([]byte, fields[4])
+	_, _ = reader.Read(orderNo)
+	return Claims{
+		Serial:    hex.EncodeToString(serial),
+		ProductID: uint(fields[0]),
+		SKUID:     uint(fields[1]),
+		ExpiresAt: int64(fields[2]),
+		Seats:     int(fields[3]),
+		OrderNo:   string(orderNo),
+	}, nil
+}
+
+// Issue 签名载荷并按模板格式渲染为分组授权码。
+func Issue(privateKey ed25519.PrivateKey, claims Claims, prefix string, groupSize int) (string, error) {
+	payload, err := claims.MarshalBinary()
+	if err != nil {
+		return "", err
+	}
+	signature := ed25519.Sign(privateKey, payload)
+	encoded := keyEncoding.EncodeToString(append(payload, signature...))
+	return renderGroups(encoded, prefix, groupSize), nil
+}
+
+// Decode 解析授权码载荷但不校验签名，用于定位签发模板。
+// 前缀段可省略；客户端误录的 0/1/8 会按 O/I/B 纠正。
+func Decode(key string) (Claims, []byte, []byte, error) {
+	groups := strings.FieldsFunc(normalizeKey(key), func(r rune) bool { return r == '-' })
+	if len(groups) == 0 {
+		return Claims{}, nil, nil, ErrKeyMalformed
+	}
+	for _, candidate := range []string{strings.Join(groups, ""), strings.Join(groups[1:], "")} {
+		raw, err := keyEncoding.DecodeString(candidate)
+		if err != nil || len(raw) <= ed25519.SignatureSize {
+			continue
+		}
+		payload := raw[:len(raw)-ed25519.SignatureSize]
+		claims, err := UnmarshalClaims(payload)
+		if err != nil {
+			continue
+		}
+		return claims, payload, raw[len(raw)-ed25519.SignatureSize:], nil
+	}
+	return Claims{}, nil, nil, ErrKeyMalformed
+}
+
+// Verify 使用模板公钥校验授权码签名。
+func Verify(publicKey ed25519.PublicKey, key string) (Claims, error) {
+	claims, payload, signature, err := Decode(key)
+	if err != nil {
+		return Claims{}, err
+	}
+	if len(publicKey) != ed25519.PublicKeySize || !ed25519.Verify(publicKey, payload, signature) {
+		return Claims{}, ErrKeySignature
+	}
+	return claims, nil
+}
+
+func renderGroups(encoded, prefix string, groupSize int) string {
+	if groupSize < MinGroupSize || groupSize > MaxGroupSize {
+		groupSize = DefaultGroupSize
+	}
+	parts := make([]string, 0, len(encoded)/groupSize+2)
+	if prefix = strings.TrimSpace(prefix); prefix != "" {
+		parts = append(parts, prefix)
+	}
+	for start := 0; start < len(encoded); start += groupSize {
+		end := start + groupSize
+		if end > len(encoded) {
+			end = len(encoded)
+		}
+		parts = append(parts, encoded[start:end])
+	}
+	return strings.Join(parts, "-")
+}
+
+func normalizeKey(key string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == ' ' || r == '\t' || r == '\r' || r == '\n':
+			return -1
+		case r == '0':
+			return 'O'
+		case r == '1':
+			return 'I'
+		case r == '8':
+			return 'B'
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		default:
+			return r
+		}
+	}, key)
+}
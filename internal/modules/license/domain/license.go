@@ -0,0 +1,53 @@
+package domain
+
+import "time"
+
+// RevokeReasonRefunded 订单退款导致的吊销
+const RevokeReasonRefunded = "order_refunded"
+
+// RevokeReasonManual 管理员手动吊销
+const RevokeReasonManual = "manual"
+
+const (
+	VerifyStatusActive  = "active"
+	VerifyStatusExpired = "expired"
+	VerifyStatusRevoked = "revoked"
+	VerifyStatusInvalid = "invalid"
+)
+
+// License 已签发的授权码，交付内容同时以已售卡密记录留存。
+// RootOrderID 指向签发时的父订单（单独下单时即本订单），父订单退款会吊销其下签发的全部授权码。
+type License struct {
+	ID           uint       `gorm:"primarykey" json:"id"`
+	Serial       string     `gorm:"type:varchar(16);uniqueIndex;not null" json:"serial"`
+	TemplateID   uint       `gorm:"index;not null" json:"template_id"`
+	ProductID    uint       `gorm:"index;not null" json:"product_id"`
+	SKUID        uint       `gorm:"column:sku_id;not null;default:0" json:"sku_id"`
+	OrderID      uint       `gorm:"index;not null" json:"order_id"`
+	RootOrderID  uint       `gorm:"index;not null" json:"root_order_id"`
+	OrderNo      string     `gorm:"type:varchar(64);not null" json:"order_no"`
+	Key          string     `gorm:"type:text;not null" json:"key"`
+	Seats        int        `gorm:"not null" json:"seats"`
+	ExpiresAt    *time.Time `json:"expires_at"`
+	RevokedAt    *time.Time `json:"revoked_at,omitempty"`
+	RevokeReason string     `gorm:"type:varchar(40)" json:"revoke_reason,omitempty"`
+	CreatedAt    time.Time  `gorm:"index" json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// TableName 指定表名
+func (License) TableName() string {
+	return "license_keys"
+}
+
+// Status 计算授权码在指定时间的校验状态。
+func (l *License) Status(now time.Time) string {
+	switch {
+	case l.RevokedAt != nil:
+		return VerifyStatusRevoked
+	case l.ExpiresAt != nil && !now.Before(*l.ExpiresAt):
+		return VerifyStatusExpired
+	default:
+		return VerifyStatusActive
+	}
+}
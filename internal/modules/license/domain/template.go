@@ -0,0 +1,40 @@
+package domain
+
+import "time"
+
+const (
+	DefaultGroupSize = 5
+	MinGroupSize     = 4
+	MaxGroupSize     = 8
+	MaxPrefixLength  = 12
+	DefaultSeats     = 1
+	MaxSeats         = 10000
+	MaxValidDays     = 3650
+)
+
+// Template SKU 授权码模板；SKUID 为 0 时对商品全部 SKU 生效，SKU 模板优先。
+// 每个模板持有独立的 Ed25519 密钥对，私钥加密存储，公钥供客户端离线校验。
+type Template struct {
+	ID         uint      `gorm:"primarykey" json:"id"`
+	ProductID  uint      `gorm:"uniqueIndex:idx_license_template_scope;not null" json:"product_id"`
+	SKUID      uint      `gorm:"column:sku_id;uniqueIndex:idx_license_template_scope;not null;default:0" json:"sku_id"`
+	Prefix     string    `gorm:"type:varchar(12)" json:"prefix"`
+	GroupSize  int       `gorm:"not null;default:5" json:"group_size"`
+	ValidDays  int       `gorm:"not null;default:0" json:"valid_days"` // 0 表示永久有效
+	Seats      int       `gorm:"not null;default:1" json:"seats"`
+	PublicKey  string    `gorm:"type:varchar(64);not null" json:"public_key"`
+	PrivateKey string    `gorm:"type:text;not null" json:"-"`
+	IsActive   bool      `gorm:"not null;default:true" json:"is_active"`
+	CreatedAt  time.Time `gorm:"index" json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// TableName 指定表名
+func (Template) TableName() string {
+	return "license_templates"
+}
+
+// AppliesTo 模板是否适用于指定商品 SKU。
+func (t *Template) AppliesTo(productID, skuID uint) bool {
+	return t.ProductID == productID && (t.SKUID == 0 || t.SKUID == skuID)
+}
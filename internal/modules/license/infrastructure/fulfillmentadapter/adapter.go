@@ -0,0 +1,51 @@
+package fulfillmentadapter
+
+import (
+	"errors"
+
+	cardsecretdomain "github.com/dujiao-next/internal/modules/cardsecret/domain"
+	fulfillmentapp "github.com/dujiao-next/internal/modules/fulfillment/application"
+	fulfillmentdomain "github.com/dujiao-next/internal/modules/fulfillment/domain"
+	licensecontract "github.com/dujiao-next/internal/modules/license/contract"
+)
+
+// Creator 是交付上下文写入授权码交付的最小端口。
+type Creator interface {
+	CreateLicense(orderID uint, secrets []cardsecretdomain.Secret) (*fulfillmentdomain.Fulfillment, error)
+}
+
+// Adapter 将授权码映射为卡密台账记录，并将交付用例错误映射为授权码合同错误。
+type Adapter struct {
+	creator Creator
+}
+
+var _ licensecontract.Completer = (*Adapter)(nil)
+
+func New(creator Creator) *Adapter {
+	if creator == nil {
+		panic("license fulfillment completer: creator is nil")
+	}
+	return &Adapter{creator: creator}
+}
+
+func (a *Adapter) CompleteLicense(orderID uint, keys []licensecontract.IssuedKey) error {
+	secrets := make([]cardsecretdomain.Secret, 0, len(keys))
+	for _, key := range keys {
+		secrets = append(secrets, cardsecretdomain.Secret{
+			ProductID: key.ProductID,
+			SKUID:     key.SKUID,
+			Secret:    key.Key,
+		})
+	}
+	_, err := a.creator.CreateLicense(orderID, secrets)
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, fulfillmentapp.ErrFulfillmentExists):
+		return licensecontract.ErrAlreadyFulfilled
+	case errors.Is(err, fulfillmentapp.ErrOrderStatusInvalid):
+		return licensecontract.ErrOrderNotPending
+	default:
+		return err
+	}
+}
@@ -0,0 +1,121 @@
+package gormstore
+
+import (
+	"errors"
+	"time"
+
+	licensecontract "github.com/dujiao-next/internal/modules/license/contract"
+	licensedomain "github.com/dujiao-next/internal/modules/license/domain"
+
+	"gorm.io/gorm"
+)
+
+// LicenseStore 是已签发授权码的 GORM 仓储。
+type LicenseStore struct {
+	db *gorm.DB
+}
+
+var _ licensecontract.LicenseStore = (*LicenseStore)(nil)
+
+// NewLicenseStore 创建已签发授权码仓储。
+func NewLicenseStore(db *gorm.DB) *LicenseStore {
+	if db == nil {
+		panic("license store: db is nil")
+	}
+	return &LicenseStore{db: db}
+}
+
+func (s *LicenseStore) GetByID(id uint) (*licensedomain.License, error) {
+	if id == 0 {
+		return nil, nil
+	}
+	return s.first(s.db.Where("id = ?", id))
+}
+
+func (s *LicenseStore) GetBySerial(serial string) (*licensedomain.License, error) {
+	if serial == "" {
+		return nil, nil
+	}
+	return s.first(s.db.Where("serial = ?", serial))
+}
+
+func (s *LicenseStore) first(query *gorm.DB) (*licensedomain.License, error) {
+	var license licensedomain.License
+	if err := query.First(&license).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &license, nil
+}
+
+func (s *LicenseStore) ListByOrderIDs(orderIDs []uint) ([]licensedomain.License, error) {
+	if len(orderIDs) == 0 {
+		return []licensedomain.License{}, nil
+	}
+	var licenses []licensedomain.License
+	if err := s.db.Where("order_id IN ?", orderIDs).Order("id ASC").Find(&licenses).Error; err != nil {
+		return nil, err
+	}
+	return licenses, nil
+}
+
+func (s *LicenseStore) List(filter licensecontract.ListFilter) ([]licensedomain.License, int64, error) {
+	query := s.db.Model(&licensedomain.License{})
+	if filter.ProductID > 0 {
+		query = query.Where("product_id = ?", filter.ProductID)
+	}
+	if filter.OrderID > 0 {
+		query = query.Where("order_id = ? OR root_order_id = ?", filter.OrderID, filter.OrderID)
+	}
+	if filter.Serial != "" {
+		query = query.Where("serial = ?", filter.Serial)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if filter.PageSize > 0 {
+		page := filter.Page
+		if page < 1 {
+			page = 1
+		}
+		query = query.Offset((page - 1) * filter.PageSize).Limit(filter.PageSize)
+	}
+	var licenses []licensedomain.License
+	if err := query.Order("id DESC").Find(&licenses).Error; err != nil {
+		return nil, 0, err
+	}
+	return licenses, total, nil
+}
+
+func (s *LicenseStore) CreateBatch(licenses []licensedomain.License) error {
+	if len(licenses) == 0 {
+		return nil
+	}
+	return s.db.Create(&licenses).Error
+}
+
+func (s *LicenseStore) Revoke(id uint, reason string, at time.Time) (bool, error) {
+	result := s.db.Model(&licensedomain.License{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Updates(revokeUpdates(reason, at))
+	return result.RowsAffected > 0, result.Error
+}
+
+func (s *LicenseStore) RevokeByOrder(orderID uint, reason string, at time.Time) (int64, error) {
+	result := s.db.Model(&licensedomain.License{}).
+		Where("(order_id = ? OR root_order_id = ?) AND revoked_at IS NULL", orderID, orderID).
+		Updates(revokeUpdates(reason, at))
+	return result.RowsAffected, result.Error
+}
+
+func revokeUpdates(reason string, at time.Time) map[string]interface{} {
+	return map[string]interface{}{
+		"revoked_at":    at,
+		"revoke_reason": reason,
+		"updated_at":    at,
+	}
+}
@@ -0,0 +1,79 @@
+package gormstore
+
+import (
+	"errors"
+
+	licensecontract "github.com/dujiao-next/internal/modules/license/contract"
+	licensedomain "github.com/dujiao-next/internal/modules/license/domain"
+
+	"gorm.io/gorm"
+)
+
+// TemplateStore 是授权码模板的 GORM 仓储。
+type TemplateStore struct {
+	db *gorm.DB
+}
+
+var _ licensecontract.TemplateStore = (*TemplateStore)(nil)
+
+// NewTemplateStore 创建授权码模板仓储。
+func NewTemplateStore(db *gorm.DB) *TemplateStore {
+	if db == nil {
+		panic("license template store: db is nil")
+	}
+	return &TemplateStore{db: db}
+}
+
+func (s *TemplateStore) GetByID(id uint) (*licensedomain.Template, error) {
+	if id == 0 {
+		return nil, nil
+	}
+	return s.first(s.db.Where("id = ?", id))
+}
+
+func (s *TemplateStore) GetByScope(productID, skuID uint) (*licensedomain.Template, error) {
+	if productID == 0 {
+		return nil, nil
+	}
+	return s.first(s.db.Where("product_id = ? AND sku_id = ?", productID, skuID))
+}
+
+func (s *TemplateStore) first(query *gorm.DB) (*licensedomain.Template, error) {
+	var template licensedomain.Template
+	if err := query.First(&template).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &template, nil
+}
+
+func (s *TemplateStore) ListByProduct(productID uint) ([]licensedomain.Template, error) {
+	var templates []licensedomain.Template
+	if err := s.db.Where("product_id = ?", productID).Order("sku_id ASC").Find(&templates).Error; err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+func (s *TemplateStore) Create(template *licensedomain.Template) error {
+	if template == nil {
+		return errors.New("license template is nil")
+	}
+	return s.db.Create(template).Error
+}
+
+func (s *TemplateStore) Update(template *licensedomain.Template) error {
+	if template == nil || template.ID == 0 {
+		return errors.New("invalid license template")
+	}
+	return s.db.Model(&licensedomain.Template{}).Where("id = ?", template.ID).Updates(map[string]interface{}{
+		"prefix":     template.Prefix,
+		"group_size": template.GroupSize,
+		"valid_days": template.ValidDays,
+		"seats":      template.Seats,
+		"is_active":  template.IsActive,
+		"updated_at": template.UpdatedAt,
+	}).Error
+}
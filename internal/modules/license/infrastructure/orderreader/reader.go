@@ -0,0 +1,48 @@
+package orderreader
+
+import (
+	licensecontract "github.com/dujiao-next/internal/modules/license/contract"
+	orderdomain "github.com/dujiao-next/internal/modules/order/domain"
+)
+
+// Source 是订单上下文暴露给防腐适配器的最小读取端口。
+type Source interface {
+	GetByID(id uint) (*orderdomain.Order, error)
+}
+
+// Reader 将订单持久化模型投影为授权码签发读模型。
+type Reader struct {
+	source Source
+}
+
+var _ licensecontract.OrderReader = (*Reader)(nil)
+
+func New(source Source) *Reader {
+	if source == nil {
+		panic("license order reader: source is nil")
+	}
+	return &Reader{source: source}
+}
+
+func (r *Reader) GetByID(id uint) (*licensecontract.OrderSnapshot, error) {
+	order, err := r.source.GetByID(id)
+	if err != nil || order == nil {
+		return nil, err
+	}
+	snapshot := &licensecontract.OrderSnapshot{
+		ID:       order.ID,
+		ParentID: order.ParentID,
+		OrderNo:  order.OrderNo,
+		Status:   order.Status,
+		Items:    make([]licensecontract.OrderItem, 0, len(order.Items)),
+	}
+	for _, item := range order.Items {
+		snapshot.Items = append(snapshot.Items, licensecontract.OrderItem{
+			ProductID:       item.ProductID,
+			SKUID:           item.SKUID,
+			Quantity:        item.Quantity,
+			FulfillmentType: item.FulfillmentType,
+		})
+	}
+	return snapshot, nil
+}
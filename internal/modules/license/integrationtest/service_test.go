@@ -0,0 +1,273 @@
+package integrationtest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dujiao-next/internal/constants"
+	licenseapp "github.com/dujiao-next/internal/modules/license/application"
+	licensecontract "github.com/dujiao-next/internal/modules/license/contract"
+	licensedomain "github.com/dujiao-next/internal/modules/license/domain"
+	licensegormstore "github.com/dujiao-next/internal/modules/license/infrastructure/gormstore"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+)
+
+type orderReaderStub struct {
+	orders map[uint]*licensecontract.OrderSnapshot
+}
+
+func (r *orderReaderStub) GetByID(id uint) (*licensecontract.OrderSnapshot, error) {
+	return r.orders[id], nil
+}
+
+type completerStub struct {
+	orders *orderReaderStub
+	keys   []licensecontract.IssuedKey
+	calls  int
+}
+
+func (c *completerStub) CompleteLicense(orderID uint, keys []licensecontract.IssuedKey) error {
+	order := c.orders.orders[orderID]
+	if order.Status == constants.OrderStatusCompleted {
+		return licensecontract.ErrAlreadyFulfilled
+	}
+	c.calls++
+	c.keys = keys
+	order.Status = constants.OrderStatusCompleted
+	return nil
+}
+
+type notifierStub struct {
+	orderIDs []uint
+}
+
+func (n *notifierStub) NotifyManualFulfillmentPending(orderID uint) error {
+	n.orderIDs = append(n.orderIDs, orderID)
+	return nil
+}
+
+type fixture struct {
+	service   *licenseapp.Service
+	orders    *orderReaderStub
+	completer *completerStub
+	notifier  *notifierStub
+	now       time.Time
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+	dsn := fmt.Sprintf("file:license_%d?mode=memory&cache=shared", time.Now().UnixNano())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite failed: %v", err)
+	}
+	if err := db.AutoMigrate(&licensedomain.Template{}, &licensedomain.License{}); err != nil {
+		t.Fatalf("auto migrate failed: %v", err)
+	}
+	f := &fixture{
+		orders:   &orderReaderStub{orders: map[uint]*licensecontract.OrderSnapshot{}},
+		notifier: &notifierStub{},
+		now:      time.Now().UTC().Truncate(time.Second),
+	}
+	f.completer = &completerStub{orders: f.orders}
+	f.service = licenseapp.NewService(licenseapp.Options{
+		Templates: licensegormstore.NewTemplateStore(db),
+		Licenses:  licensegormstore.NewLicenseStore(db),
+		Orders:    f.orders,
+		Completer: f.completer,
+		Notifier:  f.notifier,
+		SecretKey: "license-secret",
+		Now:       func() time.Time { return f.now },
+	})
+	return f
+}
+
+func (f *fixture) paidOrder(id, productID, skuID uint, quantity int) *licensecontract.OrderSnapshot {
+	order := &licensecontract.OrderSnapshot{
+		ID:      id,
+		OrderNo: fmt.Sprintf("DJ%06d", id),
+		Status:  constants.OrderStatusPaid,
+		Items: []licensecontract.OrderItem{
+			{ProductID: productID, SKUID: skuID, Quantity: quantity, FulfillmentType: constants.FulfillmentTypeLicense},
+		},
+	}
+	f.orders.orders[id] = order
+	return order
+}
+
+func (f *fixture) deliver(t *testing.T, orderID uint) []licensecontract.IssuedKey {
+	t.Helper()
+	if err := f.service.DeliverForOrder(orderID); err != nil {
+		t.Fatalf("deliver failed: %v", err)
+	}
+	return f.completer.keys
+}
+
+func (f *fixture) verify(t *testing.T, key string) licensecontract.VerifyResult {
+	t.Helper()
+	result, err := f.service.Verify(key)
+	if err != nil {
+		t.Fatalf("verify failed: %v", err)
+	}
+	return result
+}
+
+func TestDeliverIssuesSignedKeysPerQuantity(t *testing.T) {
+	f := newFixture(t)
+	if _, err := f.service.CreateTemplate(licensecontract.TemplateInput{ProductID: 1, Prefix: "APP", GroupSize: 5, ValidDays: 30, Seats: 3, IsActive: true}); err != nil {
+		t.Fatalf("create template failed: %v", err)
+	}
+	f.paidOrder(10, 1, 2, 2)
+
+	keys := f.deliver(t, 10)
+	if len(keys) != 2 || keys[0].Key == keys[1].Key {
+		t.Fatalf("expected two distinct keys, got %+v", keys)
+	}
+	for _, issued := range keys {
+		if !strings.HasPrefix(issued.Key, "APP-") {
+			t.Fatalf("expected prefixed key, got %q", issued.Key)
+		}
+		for _, group := range strings.Split(strings.TrimPrefix(issued.Key, "APP-"), "-") {
+			if len(group) > 5 {
+				t.Fatalf("expected groups of at most 5 chars, got %q", issued.Key)
+			}
+		}
+		result := f.verify(t, strings.ToLower(issued.Key))
+		if !result.Valid || result.Status != licensedomain.VerifyStatusActive {
+			t.Fatalf("expected active key, got %+v", result)
+		}
+		if result.ProductID != 1 || result.SKUID != 2 || result.OrderNo != "DJ000010" || result.Seats != 3 {
+			t.Fatalf("unexpected claims: %+v", result)
+		}
+		if result.ExpiresAt == nil || !result.ExpiresAt.Equal(f.now.AddDate(0, 0, 30)) {
+			t.Fatalf("unexpected expiry: %+v", result.ExpiresAt)
+		}
+	}
+
+	// 重复投递不会重新签发
+	f.orders.orders[10].Status = constants.OrderStatusFulfilling
+	again := f.deliver(t, 10)
+	if again[0].Key != keys[0].Key || f.completer.calls != 2 {
+		t.Fatalf("expected reused keys on redelivery, got %+v", again)
+	}
+	_, total, err := f.service.ListLicenses(licensecontract.ListFilter{OrderID: 10})
+	if err != nil || total != 2 {
+		t.Fatalf("expected 2 licenses recorded, got %d err=%v", total, err)
+	}
+}
+
+func TestVerifyRejectsTamperedAndUnknownKeys(t *testing.T) {
+	f := newFixture(t)
+	if _, err := f.service.CreateTemplate(licensecontract.TemplateInput{ProductID: 1, SKUID: 2, IsActive: true}); err != nil {
+		t.Fatalf("create template failed: %v", err)
+	}
+	f.paidOrder(11, 1, 2, 1)
+	key := f.deliver(t, 11)[0].Key
+
+	runes := []rune(key)
+	middle := len(runes) / 2
+	if runes[middle] == '-' {
+		middle++
+	}
+	if runes[middle] == 'A' {
+		runes[middle] = 'B'
+	} else {
+		runes[middle] = 'A'
+	}
+	for _, candidate := range []string{string(runes), "NOT-A-KEY", ""} {
+		result := f.verify(t, candidate)
+		if result.Valid || result.Status != licensedomain.VerifyStatusInvalid || result.Serial != "" {
+			t.Fatalf("expected invalid result for %q, got %+v", candidate, result)
+		}
+	}
+}
+
+func TestRefundRevokesKeys(t *testing.T) {
+	f := newFixture(t)
+	if _, err := f.service.CreateTemplate(licensecontract.TemplateInput{ProductID: 1, IsActive: true}); err != nil {
+		t.Fatalf("create template failed: %v", err)
+	}
+	f.paidOrder(12, 1, 0, 1)
+	first := f.deliver(t, 12)[0].Key
+	f.paidOrder(13, 1, 0, 1)
+	second := f.deliver(t, 13)[0].Key
+
+	if err := f.service.RevokeForOrder(12); err != nil {
+		t.Fatalf("revoke failed: %v", err)
+	}
+	result := f.verify(t, first)
+	if result.Valid || result.Status != licensedomain.VerifyStatusRevoked || result.RevokedAt == nil {
+		t.Fatalf("expected revoked key, got %+v", result)
+	}
+
+	// 订单被直接改为已退款时，下一次校验补记吊销
+	f.orders.orders[13].Status = constants.OrderStatusRefunded
+	result = f.verify(t, second)
+	if result.Valid || result.Status != licensedomain.VerifyStatusRevoked {
+		t.Fatalf("expected lazily revoked key, got %+v", result)
+	}
+}
+
+func TestExpiredKeyIsNotValid(t *testing.T) {
+	f := newFixture(t)
+	if _, err := f.service.CreateTemplate(licensecontract.TemplateInput{ProductID: 1, ValidDays: 1, IsActive: true}); err != nil {
+		t.Fatalf("create template failed: %v", err)
+	}
+	f.paidOrder(14, 1, 0, 1)
+	key := f.deliver(t, 14)[0].Key
+
+	f.now = f.now.Add(48 * time.Hour)
+	result := f.verify(t, key)
+	if result.Valid || result.Status != licensedomain.VerifyStatusExpired {
+		t.Fatalf("expected expired key, got %+v", result)
+	}
+}
+
+func TestMissingTemplateFallsBackToManual(t *testing.T) {
+	f := newFixture(t)
+	template, err := f.service.CreateTemplate(licensecontract.TemplateInput{ProductID: 1, IsActive: true})
+	if err != nil {
+		t.Fatalf("create template failed: %v", err)
+	}
+	if _, err := f.service.UpdateTemplate(template.ID, licensecontract.TemplateInput{IsActive: false}); err != nil {
+		t.Fatalf("disable template failed: %v", err)
+	}
+	f.paidOrder(15, 1, 0, 1)
+
+	if err := f.service.DeliverForOrder(15); err != nil {
+		t.Fatalf("deliver failed: %v", err)
+	}
+	if f.completer.calls != 0 || len(f.notifier.orderIDs) != 1 || f.notifier.orderIDs[0] != 15 {
+		t.Fatalf("expected manual fallback, calls=%d notified=%v", f.completer.calls, f.notifier.orderIDs)
+	}
+}
+
+func TestCreateTemplateValidatesInput(t *testing.T) {
+	f := newFixture(t)
+	invalid := []licensecontract.TemplateInput{
+		{ProductID: 0},
+		{ProductID: 1, Prefix: "bad-prefix"},
+		{ProductID: 1, GroupSize: 3},
+		{ProductID: 1, Seats: licensedomain.MaxSeats + 1},
+		{ProductID: 1, ValidDays: -1},
+	}
+	for _, input := range invalid {
+		if _, err := f.service.CreateTemplate(input); err == nil {
+			t.Fatalf("expected invalid template error for %+v", input)
+		}
+	}
+	template, err := f.service.CreateTemplate(licensecontract.TemplateInput{ProductID: 1, Prefix: "app"})
+	if err != nil {
+		t.Fatalf("create template failed: %v", err)
+	}
+	if template.Prefix != "APP" || template.GroupSize != licensedomain.DefaultGroupSize || template.PublicKey == "" {
+		t.Fatalf("unexpected template: %+v", template)
+	}
+	if _, err := f.service.CreateTemplate(licensecontract.TemplateInput{ProductID: 1}); err != licensecontract.ErrTemplateExists {
+		t.Fatalf("expected template exists, got %v", err)
+	}
+}
@@ -0,0 +1,166 @@
+package licensehttp
+
+import (
+	"errors"
+	"strings"
+
+	licensecontract "github.com/dujiao-next/internal/modules/license/contract"
+	licensedomain "github.com/dujiao-next/internal/modules/license/domain"
+	"github.com/dujiao-next/internal/platform/http/ginutil"
+	"github.com/dujiao-next/internal/platform/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminService 是管理端授权码所需的最小用例接口。
+type AdminService interface {
+	ListTemplates(productID uint) ([]licensedomain.Template, error)
+	CreateTemplate(input licensecontract.TemplateInput) (*licensedomain.Template, error)
+	UpdateTemplate(id uint, input licensecontract.TemplateInput) (*licensedomain.Template, error)
+	ListLicenses(filter licensecontract.ListFilter) ([]licensedomain.License, int64, error)
+	RevokeLicense(id uint) (*licensedomain.License, error)
+}
+
+// AdminHandler 处理后台授权码模板与签发记录请求。
+type AdminHandler struct {
+	service AdminService
+}
+
+func NewAdminHandler(service AdminService) *AdminHandler {
+	if service == nil {
+		panic("license admin handler: required dependency is nil")
+	}
+	return &AdminHandler{service: service}
+}
+
+// TemplateRequest 授权码模板创建/更新请求；product_id、sku_id 仅创建时生效
+type TemplateRequest struct {
+	ProductID uint   `json:"product_id"`
+	SKUID     uint   `json:"sku_id"`
+	Prefix    string `json:"prefix"`
+	GroupSize int    `json:"group_size"`
+	ValidDays int    `json:"valid_days"`
+	Seats     int    `json:"seats"`
+	IsActive  *bool  `json:"is_active"`
+}
+
+func (r TemplateRequest) toInput() licensecontract.TemplateInput {
+	active := true
+	if r.IsActive != nil {
+		active = *r.IsActive
+	}
+	return licensecontract.TemplateInput{
+		ProductID: r.ProductID,
+		SKUID:     r.SKUID,
+		Prefix:    r.Prefix,
+		GroupSize: r.GroupSize,
+		ValidDays: r.ValidDays,
+		Seats:     r.Seats,
+		IsActive:  active,
+	}
+}
+
+// ListTemplates 获取商品的授权码模板
+func (h *AdminHandler) ListTemplates(c *gin.Context) {
+	productID, err := ginutil.ParseQueryUint(c.Query("product_id"), true)
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.license_template_invalid", nil)
+		return
+	}
+	templates, err := h.service.ListTemplates(productID)
+	if err != nil {
+		ginutil.RespondError(c, response.CodeInternal, "error.license_fetch_failed", err)
+		return
+	}
+	response.Success(c, templates)
+}
+
+// CreateTemplate 创建授权码模板并生成签名密钥对
+func (h *AdminHandler) CreateTemplate(c *gin.Context) {
+	var req TemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ginutil.RespondBindError(c, err)
+		return
+	}
+	template, err := h.service.CreateTemplate(req.toInput())
+	if err != nil {
+		respondTemplateError(c, err)
+		return
+	}
+	response.Success(c, template)
+}
+
+// UpdateTemplate 更新授权码格式与有效期，签名密钥不变
+func (h *AdminHandler) UpdateTemplate(c *gin.Context) {
+	id, err := ginutil.ParseParamUint(c, "id")
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", nil)
+		return
+	}
+	var req TemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ginutil.RespondBindError(c, err)
+		return
+	}
+	template, err := h.service.UpdateTemplate(id, req.toInput())
+	if err != nil {
+		respondTemplateError(c, err)
+		return
+	}
+	response.Success(c, template)
+}
+
+// ListLicenses 获取已签发授权码（支持 product_id、order_id、serial 筛选）
+func (h *AdminHandler) ListLicenses(c *gin.Context) {
+	productID, errProduct := ginutil.ParseQueryUint(c.Query("product_id"), false)
+	orderID, errOrder := ginutil.ParseQueryUint(c.Query("order_id"), false)
+	if errProduct != nil || errOrder != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", nil)
+		return
+	}
+	page, pageSize := ginutil.ParsePagination(c)
+	licenses, total, err := h.service.ListLicenses(licensecontract.ListFilter{
+		ProductID: productID,
+		OrderID:   orderID,
+		Serial:    strings.TrimSpace(c.Query("serial")),
+		Page:      page,
+		PageSize:  pageSize,
+	})
+	if err != nil {
+		ginutil.RespondError(c, response.CodeInternal, "error.license_fetch_failed", err)
+		return
+	}
+	response.SuccessWithPage(c, licenses, response.BuildPagination(page, pageSize, total))
+}
+
+// RevokeLicense 手动吊销授权码
+func (h *AdminHandler) RevokeLicense(c *gin.Context) {
+	id, err := ginutil.ParseParamUint(c, "id")
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", nil)
+		return
+	}
+	license, err := h.service.RevokeLicense(id)
+	if err != nil {
+		if errors.Is(err, licensecontract.ErrLicenseNotFound) {
+			ginutil.RespondError(c, response.CodeNotFound, "error.license_not_found", nil)
+			return
+		}
+		ginutil.RespondError(c, response.CodeInternal, "error.license_fetch_failed", err)
+		return
+	}
+	response.Success(c, license)
+}
+
+func respondTemplateError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, licensecontract.ErrTemplateInvalid):
+		ginutil.RespondError(c, response.CodeBadRequest, "error.license_template_invalid", nil)
+	case errors.Is(err, licensecontract.ErrTemplateExists):
+		ginutil.RespondError(c, response.CodeBadRequest, "error.license_template_exists", nil)
+	case errors.Is(err, licensecontract.ErrTemplateNotFound):
+		ginutil.RespondError(c, response.CodeNotFound, "error.license_template_not_found", nil)
+	default:
+		ginutil.RespondError(c, response.CodeInternal, "error.license_fetch_failed", err)
+	}
+}
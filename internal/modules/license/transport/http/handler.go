@@ -0,0 +1,56 @@
+package licensehttp
+
+import (
+	"strings"
+
+	licensecontract "github.com/dujiao-next/internal/modules/license/contract"
+	"github.com/dujiao-next/internal/platform/http/ginutil"
+	"github.com/dujiao-next/internal/platform/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxKeyLength 公开校验接受的授权码最大长度
+const maxKeyLength = 256
+
+// VerifyService 是公开校验所需的最小用例接口。
+type VerifyService interface {
+	Verify(key string) (licensecontract.VerifyResult, error)
+}
+
+// Handler 处理授权码公开校验请求。
+type Handler struct {
+	service VerifyService
+}
+
+func NewHandler(service VerifyService) *Handler {
+	if service == nil {
+		panic("license handler: required dependency is nil")
+	}
+	return &Handler{service: service}
+}
+
+// VerifyRequest 授权码校验请求
+type VerifyRequest struct {
+	Key string `json:"key" binding:"required"`
+}
+
+// Verify 校验授权码签名、有效期与吊销状态
+func (h *Handler) Verify(c *gin.Context) {
+	var req VerifyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ginutil.RespondBindError(c, err)
+		return
+	}
+	key := strings.TrimSpace(req.Key)
+	if key == "" || len(key) > maxKeyLength {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", nil)
+		return
+	}
+	result, err := h.service.Verify(key)
+	if err != nil {
+		ginutil.RespondError(c, response.CodeInternal, "error.license_fetch_failed", err)
+		return
+	}
+	response.Success(c, result)
+}
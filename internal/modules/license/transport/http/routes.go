@@ -0,0 +1,23 @@
+package licensehttp
+
+import "github.com/gin-gonic/gin"
+
+// RegisterPublicRoutes 注册授权码公开校验路由，按 IP 限流。
+func RegisterPublicRoutes(public gin.IRoutes, handler *Handler, rateLimit gin.HandlerFunc) {
+	if public == nil || handler == nil || rateLimit == nil {
+		panic("license public routes: required dependency is nil")
+	}
+	public.POST("/licenses/verify", rateLimit, handler.Verify)
+}
+
+// RegisterAdminRoutes 注册后台授权码模板与签发记录路由。
+func RegisterAdminRoutes(authorized gin.IRoutes, handler *AdminHandler) {
+	if authorized == nil || handler == nil {
+		panic("license admin routes: required dependency is nil")
+	}
+	authorized.GET("/license-templates", handler.ListTemplates)
+	authorized.POST("/license-templates", handler.CreateTemplate)
+	authorized.PUT("/license-templates/:id", handler.UpdateTemplate)
+	authorized.GET("/licenses", handler.ListLicenses)
+	authorized.POST("/licenses/:id/revoke", handler.RevokeLicense)
+}
@@ -0,0 +1,484 @@
+package application
+
+import (
+	"time"
+
+	userdomain "github.com/dujiao-next/internal/modules/identity/user/domain"
+	memberlevelcontract "github.com/dujiao-next/internal/modules/memberlevel/contract"
+	memberleveldomain "github.com/dujiao-next/internal/modules/memberlevel/domain"
+)
+
+const (
+	membershipEvaluateBatchSize = 200
+	maxPlanDurationDays         = 3650
+	maxGrantDays                = 36500
+	maxOrderPurchaseGrants      = 50
+)
+
+// SetMembershipStores 设置付费等级方案、等级历史与窗口统计端口。
+// 未设置统计端口时，按窗口统计的等级回退为累计金额判断。
+func (s *Service) SetMembershipStores(
+	planRepo memberlevelcontract.PlanRepository,
+	historyRepo memberlevelcontract.HistoryRepository,
+	activity memberlevelcontract.ActivityReader,
+) {
+	s.planRepo = planRepo
+	s.historyRepo = historyRepo
+	s.activity = activity
+}
+
+// --- 等级历史 ---
+
+// ListHistory 查询用户等级变更历史
+func (s *Service) ListHistory(filter memberlevelcontract.HistoryFilter) ([]memberleveldomain.MemberLevelHistory, int64, error) {
+	if s.historyRepo == nil {
+		return []memberleveldomain.MemberLevelHistory{}, 0, nil
+	}
+	return s.historyRepo.List(filter)
+}
+
+// --- 付费等级方案 ---
+
+func (s *Service) ListPlans(filter memberlevelcontract.PlanFilter) ([]memberleveldomain.MemberLevelPlan, int64, error) {
+	if s.planRepo == nil {
+		return []memberleveldomain.MemberLevelPlan{}, 0, nil
+	}
+	return s.planRepo.List(filter)
+}
+
+func (s *Service) CreatePlan(plan *memberleveldomain.MemberLevelPlan) error {
+	if err := s.validatePlan(plan); err != nil {
+		return err
+	}
+	return s.planRepo.Create(plan)
+}
+
+func (s *Service) UpdatePlan(plan *memberleveldomain.MemberLevelPlan) error {
+	if plan == nil || plan.ID == 0 || s.planRepo == nil {
+		return memberlevelcontract.ErrPlanNotFound
+	}
+	existing, err := s.planRepo.GetByID(plan.ID)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return memberlevelcontract.ErrPlanNotFound
+	}
+	if err := s.validatePlan(plan); err != nil {
+		return err
+	}
+	plan.CreatedAt = existing.CreatedAt
+	return s.planRepo.Update(plan)
+}
+
+func (s *Service) DeletePlan(id uint) error {
+	if s.planRepo == nil {
+		return memberlevelcontract.ErrPlanNotFound
+	}
+	existing, err := s.planRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return memberlevelcontract.ErrPlanNotFound
+	}
+	return s.planRepo.Delete(id)
+}
+
+// validatePlan 校验方案等级存在、天数合法，且同一商品/SKU 只对应一个方案
+func (s *Service) validatePlan(plan *memberleveldomain.MemberLevelPlan) error {
+	if s.planRepo == nil {
+		return memberlevelcontract.ErrPlanInvalid
+	}
+	if plan == nil || plan.ProductID == 0 || plan.MemberLevelID == 0 ||
+		plan.DurationDays <= 0 || plan.DurationDays > maxPlanDurationDays {
+		return memberlevelcontract.ErrPlanInvalid
+	}
+	level, err := s.levelRepo.GetByID(plan.MemberLevelID)
+	if err != nil {
+		return err
+	}
+	if level == nil {
+		return memberlevelcontract.ErrNotFound
+	}
+	if level.IsDefault {
+		return memberlevelcontract.ErrPlanInvalid
+	}
+	existing, err := s.planRepo.GetByProductAndSKU(plan.ProductID, plan.SKUID)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.ID != plan.ID {
+		return memberlevelcontract.ErrPlanExists
+	}
+	return nil
+}
+
+// OnMembershipPurchased 订单支付成功后按付费等级方案授予等级；同一订单只授予一次
+func (s *Service) OnMembershipPurchased(userID, orderID uint, items []memberlevelcontract.PurchasedItem) error {
+	if userID == 0 || orderID == 0 || len(items) == 0 || s.planRepo == nil {
+		return nil
+	}
+	grants := make(map[uint]int)
+	order := make([]uint, 0, len(items))
+	for _, item := range items {
+		plan, err := s.resolvePlan(item.ProductID, item.SKUID)
+		if err != nil {
+			return err
+		}
+		if plan == nil {
+			continue
+		}
+		quantity := item.Quantity
+		if quantity <= 0 {
+			quantity = 1
+		}
+		if _, seen := grants[plan.MemberLevelID]; !seen {
+			order = append(order, plan.MemberLevelID)
+		}
+		grants[plan.MemberLevelID] += plan.DurationDays * quantity
+	}
+	if len(grants) == 0 {
+		return nil
+	}
+	if s.historyRepo != nil {
+		_, granted, err := s.historyRepo.List(memberlevelcontract.HistoryFilter{
+			OrderID: orderID, Reason: memberleveldomain.LevelChangeReasonPurchase, Page: 1, PageSize: 1,
+		})
+		if err != nil {
+			return err
+		}
+		if granted > 0 {
+			return nil
+		}
+	}
+	for _, levelID := range order {
+		if err := s.grantPurchasedLevel(userID, orderID, levelID, grants[levelID], time.Now()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolvePlan 优先匹配 SKU 级方案，再匹配商品级方案
+func (s *Service) resolvePlan(productID, skuID uint) (*memberleveldomain.MemberLevelPlan, error) {
+	if productID == 0 {
+		return nil, nil
+	}
+	if skuID > 0 {
+		plan, err := s.planRepo.GetByProductAndSKU(productID, skuID)
+		if err != nil {
+			return nil, err
+		}
+		if plan != nil {
+			if !plan.IsActive {
+				return nil, nil
+			}
+			return plan, nil
+		}
+	}
+	plan, err := s.planRepo.GetByProductAndSKU(productID, 0)
+	if err != nil || plan == nil || !plan.IsActive {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// grantPurchasedLevel 授予付费等级：同等级顺延到期时间，低等级直接切换，
+// 当前等级更高时保留当前等级，仅记录历史便于客服核对。
+func (s *Service) grantPurchasedLevel(userID, orderID, levelID uint, days int, now time.Time) error {
+	if days > maxGrantDays {
+		days = maxGrantDays
+	}
+	level, err := s.levelRepo.GetByID(levelID)
+	if err != nil {
+		return err
+	}
+	if level == nil {
+		return memberlevelcontract.ErrNotFound
+	}
+	const maxAttempts = 3
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		user, err := s.userRepo.GetByID(userID)
+		if err != nil {
+			return err
+		}
+		if user == nil {
+			return memberlevelcontract.ErrUserNotFound
+		}
+		change := memberlevelcontract.LevelChange{
+			UserID:      user.ID,
+			FromLevelID: user.MemberLevelID,
+			ToLevelID:   level.ID,
+			Source:      memberleveldomain.LevelSourcePurchase,
+			Reason:      memberleveldomain.LevelChangeReasonPurchase,
+			OrderID:     orderID,
+			Days:        days,
+		}
+		base := now
+		if user.MemberLevelID == level.ID && user.MemberLevelExpiresAt != nil && user.MemberLevelExpiresAt.After(now) {
+			base = *user.MemberLevelExpiresAt
+		}
+		expiresAt := base.AddDate(0, 0, days)
+		change.ExpiresAt = &expiresAt
+
+		if user.MemberLevelID != level.ID {
+			current, err := s.levelRepo.GetByID(user.MemberLevelID)
+			if err != nil {
+				return err
+			}
+			if current != nil && current.IsActive && current.SortOrder > level.SortOrder && !membershipExpired(user, now) {
+				change.ToLevelID = user.MemberLevelID
+				change.ExpiresAt = user.MemberLevelExpiresAt
+				change.Source = user.MemberLevelSource
+				change.Days = 0
+				change.Note = "purchased level is lower than current level"
+			}
+		}
+		applied, err := s.userRepo.ChangeMemberLevel(change)
+		if err != nil || applied {
+			return err
+		}
+	}
+	return memberlevelcontract.ErrLevelConflict
+}
+
+// RevokePurchasedForOrder 付费订单全额退款后回收授予的天数；回收后已到期的降到仍满足的最高等级或默认等级。
+// 同一订单只回收一次，用户等级在购买后已被其他来源变更时只记录历史不改动等级。
+func (s *Service) RevokePurchasedForOrder(orderID uint) error {
+	if orderID == 0 || s.historyRepo == nil {
+		return nil
+	}
+	_, revoked, err := s.historyRepo.List(memberlevelcontract.HistoryFilter{
+		OrderID: orderID, Reason: memberleveldomain.LevelChangeReasonRevoke, Page: 1, PageSize: 1,
+	})
+	if err != nil || revoked > 0 {
+		return err
+	}
+	grants, _, err := s.historyRepo.List(memberlevelcontract.HistoryFilter{
+		OrderID: orderID, Reason: memberleveldomain.LevelChangeReasonPurchase, Page: 1, PageSize: maxOrderPurchaseGrants,
+	})
+	if err != nil {
+		return err
+	}
+	for i := range grants {
+		if grants[i].Days <= 0 {
+			continue
+		}
+		if err := s.revokePurchasedLevel(&grants[i], time.Now()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Service) revokePurchasedLevel(grant *memberleveldomain.MemberLevelHistory, now time.Time) error {
+	const maxAttempts = 3
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		user, err := s.userRepo.GetByID(grant.UserID)
+		if err != nil {
+			return err
+		}
+		if user == nil {
+			return memberlevelcontract.ErrUserNotFound
+		}
+		change := memberlevelcontract.LevelChange{
+			UserID:      user.ID,
+			FromLevelID: user.MemberLevelID,
+			ToLevelID:   user.MemberLevelID,
+			ExpiresAt:   user.MemberLevelExpiresAt,
+			Source:      user.MemberLevelSource,
+			Reason:      memberleveldomain.LevelChangeReasonRevoke,
+			OrderID:     grant.OrderID,
+			Days:        grant.Days,
+		}
+		if user.MemberLevelID == grant.ToLevelID && user.MemberLevelSource == memberleveldomain.LevelSourcePurchase && user.MemberLevelExpiresAt != nil {
+			expiresAt := user.MemberLevelExpiresAt.AddDate(0, 0, -grant.Days)
+			change.ExpiresAt = &expiresAt
+			if !expiresAt.After(now) {
+				if err := s.applyRevokeFallback(&change, user, now); err != nil {
+					return err
+				}
+			}
+		} else {
+			change.Days = 0
+			change.Note = "level changed since purchase"
+		}
+		applied, err := s.userRepo.ChangeMemberLevel(change)
+		if err != nil || applied {
+			return err
+		}
+	}
+	return memberlevelcontract.ErrLevelConflict
+}
+
+// applyRevokeFallback 回收后等级已到期，按当前统计降到仍满足的最高等级或默认等级。
+func (s *Service) applyRevokeFallback(change *memberlevelcontract.LevelChange, user *userdomain.User, now time.Time) error {
+	levels, err := s.levelRepo.ListAllActive()
+	if err != nil {
+		return err
+	}
+	defaultLevel, err := s.levelRepo.GetDefault()
+	if err != nil {
+		return err
+	}
+	qualified, err := highestQualifiedLevel(s.newProgressMeter(user, now), levels)
+	if err != nil {
+		return err
+	}
+	change.ExpiresAt = nil
+	applyFallbackLevel(change, qualified, defaultLevel, now)
+	return nil
+}
+
+// --- 周期评估 ---
+
+// MembershipEvaluation 周期评估结果
+type MembershipEvaluation struct {
+	Checked    int `json:"checked"`
+	Upgraded   int `json:"upgraded"`
+	Renewed    int `json:"renewed"`
+	Downgraded int `json:"downgraded"`
+}
+
+// EvaluateMemberships 处理到期等级与按统计窗口评估的等级：仍达标则续期，
+// 达到更高等级则升级，否则降到仍满足的最高等级或默认等级。
+func (s *Service) EvaluateMemberships(now time.Time) (MembershipEvaluation, error) {
+	result := MembershipEvaluation{}
+	levels, err := s.levelRepo.ListAllActive()
+	if err != nil {
+		return result, err
+	}
+	defaultLevel, err := s.levelRepo.GetDefault()
+	if err != nil {
+		return result, err
+	}
+	windowLevelIDs := make([]uint, 0)
+	for _, level := range levels {
+		if level.EvaluationWindowDays > 0 {
+			windowLevelIDs = append(windowLevelIDs, level.ID)
+		}
+	}
+
+	var afterID uint
+	for {
+		users, err := s.userRepo.ListMembershipDue(now, windowLevelIDs, afterID, membershipEvaluateBatchSize)
+		if err != nil {
+			return result, err
+		}
+		for i := range users {
+			user := &users[i]
+			afterID = user.ID
+			result.Checked++
+			reason, err := s.evaluateMembership(user, levels, defaultLevel, now)
+			if err != nil {
+				return result, err
+			}
+			switch reason {
+			case memberleveldomain.LevelChangeReasonUpgrade:
+				result.Upgraded++
+			case memberleveldomain.LevelChangeReasonRenew:
+				result.Renewed++
+			case memberleveldomain.LevelChangeReasonExpire, memberleveldomain.LevelChangeReasonDowngrade:
+				result.Downgraded++
+			}
+		}
+		if len(users) < membershipEvaluateBatchSize {
+			return result, nil
+		}
+	}
+}
+
+// evaluateMembership 评估单个用户，返回实际生效的变更原因（未变更返回空串）
+func (s *Service) evaluateMembership(
+	user *userdomain.User,
+	levels []memberleveldomain.MemberLevel,
+	defaultLevel *memberleveldomain.MemberLevel,
+	now time.Time,
+) (string, error) {
+	var current *memberleveldomain.MemberLevel
+	for i := range levels {
+		if levels[i].ID == user.MemberLevelID {
+			current = &levels[i]
+			break
+		}
+	}
+	meter := s.newProgressMeter(user, now)
+	qualified, err := highestQualifiedLevel(meter, levels)
+	if err != nil {
+		return "", err
+	}
+
+	change := memberlevelcontract.LevelChange{UserID: user.ID, FromLevelID: user.MemberLevelID}
+	expired := membershipExpired(user, now)
+	switch {
+	case qualified != nil && (current == nil || qualified.SortOrder > current.SortOrder):
+		change.ToLevelID = qualified.ID
+		change.ExpiresAt = levelExpiry(qualified, now)
+		change.Source = memberleveldomain.LevelSourceThreshold
+		change.Reason = memberleveldomain.LevelChangeReasonUpgrade
+	case expired && qualified != nil && current != nil && qualified.ID == current.ID:
+		change.ToLevelID = current.ID
+		change.ExpiresAt = levelExpiry(current, now)
+		change.Source = memberleveldomain.LevelSourceThreshold
+		change.Reason = memberleveldomain.LevelChangeReasonRenew
+	case expired:
+		applyFallbackLevel(&change, qualified, defaultLevel, now)
+		change.Reason = memberleveldomain.LevelChangeReasonExpire
+	case current != nil && current.EvaluationWindowDays > 0 && !current.IsDefault &&
+		user.MemberLevelExpiresAt == nil && windowDowngradable(user.MemberLevelSource):
+		meets, err := meter.meets(current)
+		if err != nil || meets {
+			return "", err
+		}
+		applyFallbackLevel(&change, qualified, defaultLevel, now)
+		change.Reason = memberleveldomain.LevelChangeReasonDowngrade
+	default:
+		return "", nil
+	}
+
+	applied, err := s.userRepo.ChangeMemberLevel(change)
+	if err != nil || !applied {
+		return "", err
+	}
+	return change.Reason, nil
+}
+
+// highestQualifiedLevel 返回满足条件的最高等级，levels 需按 sort_order 降序
+func highestQualifiedLevel(meter *progressMeter, levels []memberleveldomain.MemberLevel) (*memberleveldomain.MemberLevel, error) {
+	for i := range levels {
+		meets, err := meter.meets(&levels[i])
+		if err != nil {
+			return nil, err
+		}
+		if meets {
+			return &levels[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// applyFallbackLevel 降级到仍满足的最高等级，否则回到默认等级
+func applyFallbackLevel(change *memberlevelcontract.LevelChange, qualified, defaultLevel *memberleveldomain.MemberLevel, now time.Time) {
+	switch {
+	case qualified != nil:
+		change.ToLevelID = qualified.ID
+		change.ExpiresAt = levelExpiry(qualified, now)
+		change.Source = memberleveldomain.LevelSourceThreshold
+	case defaultLevel != nil:
+		change.ToLevelID = defaultLevel.ID
+		change.Source = memberleveldomain.LevelSourceDefault
+	default:
+		change.ToLevelID = 0
+		change.Source = ""
+	}
+}
+
+// windowDowngradable 付费与手动设置的长期等级不随统计窗口降级
+func windowDowngradable(source string) bool {
+	return source != memberleveldomain.LevelSourcePurchase && source != memberleveldomain.LevelSourceAdmin
+}
+
+func membershipExpired(user *userdomain.User, now time.Time) bool {
+	return user != nil && user.MemberLevelExpiresAt != nil && !user.MemberLevelExpiresAt.After(now)
+}
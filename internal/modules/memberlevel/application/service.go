@@ -1,6 +1,9 @@
 package application
 
 import (
+	"strings"
+	"time"
+
 	userdomain "github.com/dujiao-next/internal/modules/identity/user/domain"
 	memberlevelcontract "github.com/dujiao-next/internal/modules/memberlevel/contract"
 	memberleveldomain "github.com/dujiao-next/internal/modules/memberlevel/domain"
@@ -10,9 +13,12 @@ import (
 
 // Service 会员等级服务
 type Service struct {
-	levelRepo memberlevelcontract.LevelRepository
-	priceRepo memberlevelcontract.PriceRepository
-	userRepo  memberlevelcontract.UserRepository
+	levelRepo   memberlevelcontract.LevelRepository
+	priceRepo   memberlevelcontract.PriceRepository
+	userRepo    memberlevelcontract.UserRepository
+	planRepo    memberlevelcontract.PlanRepository
+	historyRepo memberlevelcontract.HistoryRepository
+	activity    memberlevelcontract.ActivityReader
 }
 
 // NewService 创建会员等级服务
@@ -195,7 +201,7 @@ func (s *Service) ResolveMemberPriceForProducts(levelID uint, productIDs []uint)
 
 // --- 等级升级 ---
 
-// CheckAndUpgrade 检查用户是否满足升级条件，只升不降
+// CheckAndUpgrade 检查用户是否满足升级条件，只升不降；降级与到期由 EvaluateMemberships 周期处理
 func (s *Service) CheckAndUpgrade(userID uint) error {
 	const maxUpgradeAttempts = 3
 	for attempt := 0; attempt < maxUpgradeAttempts; attempt++ {
@@ -212,22 +218,30 @@ func (s *Service) CheckAndUpgrade(userID uint) error {
 			return nil
 		}
 
-		target, err := s.findUpgradeTarget(user, levels)
+		now := time.Now()
+		target, err := s.findUpgradeTarget(user, levels, now)
 		if err != nil || target == nil {
 			return err
 		}
-		affected, err := s.userRepo.UpdateMemberLevelIfCurrent(user.ID, user.MemberLevelID, target.ID)
+		applied, err := s.userRepo.ChangeMemberLevel(memberlevelcontract.LevelChange{
+			UserID:      user.ID,
+			FromLevelID: user.MemberLevelID,
+			ToLevelID:   target.ID,
+			ExpiresAt:   levelExpiry(target, now),
+			Source:      memberleveldomain.LevelSourceThreshold,
+			Reason:      memberleveldomain.LevelChangeReasonUpgrade,
+		})
 		if err != nil {
 			return err
 		}
-		if affected > 0 {
+		if applied {
 			return nil
 		}
 	}
 	return nil
 }
 
-func (s *Service) findUpgradeTarget(user *userdomain.User, levels []memberleveldomain.MemberLevel) (*memberleveldomain.MemberLevel, error) {
+func (s *Service) findUpgradeTarget(user *userdomain.User, levels []memberleveldomain.MemberLevel, now time.Time) (*memberleveldomain.MemberLevel, error) {
 	if user == nil {
 		return nil, nil
 	}
@@ -235,6 +249,7 @@ func (s *Service) findUpgradeTarget(user *userdomain.User, levels []memberleveld
 	if err != nil || !ok {
 		return nil, err
 	}
+	meter := s.newProgressMeter(user, now)
 	for i := range levels {
 		level := &levels[i]
 		if level.ID == user.MemberLevelID {
@@ -243,7 +258,11 @@ func (s *Service) findUpgradeTarget(user *userdomain.User, levels []memberleveld
 		if level.SortOrder <= currentSortOrder {
 			continue
 		}
-		if s.meetsThreshold(user, level) {
+		meets, err := meter.meets(level)
+		if err != nil {
+			return nil, err
+		}
+		if meets {
 			return level, nil
 		}
 	}
@@ -266,20 +285,66 @@ func (s *Service) resolveCurrentSortOrder(levelID uint, activeLevels []memberlev
 	return level.SortOrder, true, nil
 }
 
-// meetsThreshold 判断用户是否满足等级阈值（充值累计 OR 消费累计）
-func (s *Service) meetsThreshold(user *userdomain.User, level *memberleveldomain.MemberLevel) bool {
+// progressMeter 计算用户在各统计窗口内的充值/消费金额，同一窗口只查询一次
+type progressMeter struct {
+	activity memberlevelcontract.ActivityReader
+	user     *userdomain.User
+	now      time.Time
+	windows  map[int][2]decimal.Decimal
+}
+
+func (s *Service) newProgressMeter(user *userdomain.User, now time.Time) *progressMeter {
+	return &progressMeter{activity: s.activity, user: user, now: now, windows: map[int][2]decimal.Decimal{}}
+}
+
+// totals 返回窗口内的充值与消费金额；窗口为 0 或未配置统计端口时使用累计金额
+func (m *progressMeter) totals(windowDays int) (decimal.Decimal, decimal.Decimal, error) {
+	if windowDays <= 0 || m.activity == nil {
+		return m.user.TotalRecharged.Decimal, m.user.TotalSpent.Decimal, nil
+	}
+	if cached, ok := m.windows[windowDays]; ok {
+		return cached[0], cached[1], nil
+	}
+	since := m.now.AddDate(0, 0, -windowDays)
+	recharged, err := m.activity.SumRechargedSince(m.user.ID, since)
+	if err != nil {
+		return decimal.Zero, decimal.Zero, err
+	}
+	spent, err := m.activity.SumSpentSince(m.user.ID, since)
+	if err != nil {
+		return decimal.Zero, decimal.Zero, err
+	}
+	m.windows[windowDays] = [2]decimal.Decimal{recharged, spent}
+	return recharged, spent, nil
+}
+
+// meets 判断用户是否满足等级阈值（充值 OR 消费，按等级的统计窗口计算）
+func (m *progressMeter) meets(level *memberleveldomain.MemberLevel) (bool, error) {
 	rechargeThreshold := level.RechargeThreshold.Decimal
 	spendThreshold := level.SpendThreshold.Decimal
-
-	if rechargeThreshold.GreaterThan(decimal.Zero) &&
-		user.TotalRecharged.Decimal.GreaterThanOrEqual(rechargeThreshold) {
-		return true
+	if !rechargeThreshold.GreaterThan(decimal.Zero) && !spendThreshold.GreaterThan(decimal.Zero) {
+		return false, nil
+	}
+	recharged, spent, err := m.totals(level.EvaluationWindowDays)
+	if err != nil {
+		return false, err
+	}
+	if rechargeThreshold.GreaterThan(decimal.Zero) && recharged.GreaterThanOrEqual(rechargeThreshold) {
+		return true, nil
+	}
+	if spendThreshold.GreaterThan(decimal.Zero) && spent.GreaterThanOrEqual(spendThreshold) {
+		return true, nil
 	}
-	if spendThreshold.GreaterThan(decimal.Zero) &&
-		user.TotalSpent.Decimal.GreaterThanOrEqual(spendThreshold) {
-		return true
+	return false, nil
+}
+
+// levelExpiry 按等级有效期计算达标获得后的到期时间，0 表示长期
+func levelExpiry(level *memberleveldomain.MemberLevel, now time.Time) *time.Time {
+	if level == nil || level.ValidityDays <= 0 {
+		return nil
 	}
-	return false
+	expiresAt := now.AddDate(0, 0, level.ValidityDays)
+	return &expiresAt
 }
 
 // OnRechargeCompleted 充值到账后触发
@@ -314,24 +379,31 @@ func (s *Service) AssignDefaultLevel(userID uint) error {
 	if err != nil || user == nil {
 		return err
 	}
-	if user.MemberLevelID == 0 {
-		user.MemberLevelID = defaultLevel.ID
-		return s.userRepo.Update(user)
+	if user.MemberLevelID != 0 {
+		return nil
 	}
-	return nil
+	_, err = s.userRepo.ChangeMemberLevel(memberlevelcontract.LevelChange{
+		UserID:    user.ID,
+		ToLevelID: defaultLevel.ID,
+		Source:    memberleveldomain.LevelSourceDefault,
+		Reason:    memberleveldomain.LevelChangeReasonDefault,
+	})
+	return err
 }
 
-// SetUserLevel 管理员手动设置用户等级
-func (s *Service) SetUserLevel(userID, levelID uint) error {
-	user, err := s.userRepo.GetByID(userID)
-	if err != nil {
-		return err
-	}
-	if user == nil {
-		return memberlevelcontract.ErrUserNotFound
-	}
-	if levelID > 0 {
-		level, err := s.levelRepo.GetByID(levelID)
+// SetUserLevelInput 管理员手动设置用户等级输入
+type SetUserLevelInput struct {
+	UserID    uint
+	LevelID   uint
+	AdminID   uint
+	ExpiresAt *time.Time // 为空表示长期
+	Note      string
+}
+
+// SetUserLevel 管理员手动设置用户等级，并记录等级历史
+func (s *Service) SetUserLevel(input SetUserLevelInput) error {
+	if input.LevelID > 0 {
+		level, err := s.levelRepo.GetByID(input.LevelID)
 		if err != nil {
 			return err
 		}
@@ -339,8 +411,30 @@ func (s *Service) SetUserLevel(userID, levelID uint) error {
 			return memberlevelcontract.ErrNotFound
 		}
 	}
-	user.MemberLevelID = levelID
-	return s.userRepo.Update(user)
+	const maxAttempts = 3
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		user, err := s.userRepo.GetByID(input.UserID)
+		if err != nil {
+			return err
+		}
+		if user == nil {
+			return memberlevelcontract.ErrUserNotFound
+		}
+		applied, err := s.userRepo.ChangeMemberLevel(memberlevelcontract.LevelChange{
+			UserID:      user.ID,
+			FromLevelID: user.MemberLevelID,
+			ToLevelID:   input.LevelID,
+			ExpiresAt:   input.ExpiresAt,
+			Source:      memberleveldomain.LevelSourceAdmin,
+			Reason:      memberleveldomain.LevelChangeReasonAdmin,
+			AdminID:     input.AdminID,
+			Note:        strings.TrimSpace(input.Note),
+		})
+		if err != nil || applied {
+			return err
+		}
+	}
+	return memberlevelcontract.ErrLevelConflict
 }
 
 // BackfillDefaultLevel 为所有未分配等级的老用户批量分配默认等级，返回影响行数
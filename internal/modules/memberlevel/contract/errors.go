@@ -8,4 +8,8 @@ var (
 	ErrSortOrderUsed = errors.New("member_level_sort_order_used")
 	ErrDeleteDefault = errors.New("member_level_cannot_delete_default")
 	ErrUserNotFound  = errors.New("user_not_found")
+	ErrPlanNotFound  = errors.New("member_level_plan_not_found")
+	ErrPlanExists    = errors.New("member_level_plan_exists")
+	ErrPlanInvalid   = errors.New("member_level_plan_invalid")
+	ErrLevelConflict = errors.New("member_level_change_conflict")
 )
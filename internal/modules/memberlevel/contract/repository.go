@@ -1,6 +1,8 @@
 package contract
 
 import (
+	"time"
+
 	userdomain "github.com/dujiao-next/internal/modules/identity/user/domain"
 	memberleveldomain "github.com/dujiao-next/internal/modules/memberlevel/domain"
 	"github.com/shopspring/decimal"
@@ -41,6 +43,64 @@ type UserRepository interface {
 	Update(user *userdomain.User) error
 	IncrementTotalRecharged(userID uint, amount decimal.Decimal) error
 	IncrementTotalSpent(userID uint, amount decimal.Decimal) error
-	UpdateMemberLevelIfCurrent(userID, currentLevelID, nextLevelID uint) (int64, error)
+	// ChangeMemberLevel 仅在用户当前等级仍为 FromLevelID 时切换等级并写入历史，返回是否生效。
+	ChangeMemberLevel(change LevelChange) (bool, error)
 	AssignDefaultMemberLevel(defaultLevelID uint) (int64, error)
+	// ListMembershipDue 按 ID 升序分页列出等级已到期或处于窗口统计等级的用户。
+	ListMembershipDue(now time.Time, windowLevelIDs []uint, afterID uint, limit int) ([]userdomain.User, error)
+}
+
+// LevelChange 描述一次用户等级变更；FromLevelID 用于并发保护。
+type LevelChange struct {
+	UserID      uint
+	FromLevelID uint
+	ToLevelID   uint
+	ExpiresAt   *time.Time
+	Source      string
+	Reason      string
+	OrderID     uint
+	AdminID     uint
+	Days        int
+	Note        string
+}
+
+type HistoryFilter struct {
+	UserID   uint
+	OrderID  uint
+	Reason   string
+	Page     int
+	PageSize int
+}
+
+type HistoryRepository interface {
+	List(filter HistoryFilter) ([]memberleveldomain.MemberLevelHistory, int64, error)
+}
+
+type PlanFilter struct {
+	MemberLevelID uint
+	ProductID     uint
+	Page          int
+	PageSize      int
+}
+
+type PlanRepository interface {
+	GetByID(id uint) (*memberleveldomain.MemberLevelPlan, error)
+	GetByProductAndSKU(productID, skuID uint) (*memberleveldomain.MemberLevelPlan, error)
+	List(filter PlanFilter) ([]memberleveldomain.MemberLevelPlan, int64, error)
+	Create(plan *memberleveldomain.MemberLevelPlan) error
+	Update(plan *memberleveldomain.MemberLevelPlan) error
+	Delete(id uint) error
+}
+
+// ActivityReader 统计用户在窗口期内的已支付消费与已到账充值。
+type ActivityReader interface {
+	SumSpentSince(userID uint, since time.Time) (decimal.Decimal, error)
+	SumRechargedSince(userID uint, since time.Time) (decimal.Decimal, error)
+}
+
+// PurchasedItem 是已支付订单中的一行商品，用于匹配付费等级方案。
+type PurchasedItem struct {
+	ProductID uint
+	SKUID     uint
+	Quantity  int
 }
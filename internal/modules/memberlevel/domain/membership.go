@@ -0,0 +1,59 @@
+package domain
+
+import "time"
+
+// 用户当前等级的来源，决定周期评估时是否允许按阈值降级。
+const (
+	LevelSourceDefault   = "default"   // 默认等级
+	LevelSourceThreshold = "threshold" // 按阈值达标获得
+	LevelSourcePurchase  = "purchase"  // 购买付费等级获得
+	LevelSourceAdmin     = "admin"     // 管理员手动设置
+)
+
+// 等级变更原因，记录在等级历史中。
+const (
+	LevelChangeReasonDefault   = "default"   // 分配默认等级
+	LevelChangeReasonUpgrade   = "upgrade"   // 达标升级
+	LevelChangeReasonRenew     = "renew"     // 到期后仍达标续期
+	LevelChangeReasonExpire    = "expire"    // 到期且不再达标降级
+	LevelChangeReasonDowngrade = "downgrade" // 统计窗口内不再达标降级
+	LevelChangeReasonPurchase  = "purchase"  // 购买付费等级
+	LevelChangeReasonAdmin     = "admin"     // 管理员手动设置
+	LevelChangeReasonRevoke    = "revoke"    // 付费订单退款回收
+)
+
+// MemberLevelHistory 用户等级变更历史
+type MemberLevelHistory struct {
+	ID          uint       `gorm:"primarykey" json:"id"`
+	UserID      uint       `gorm:"index:idx_member_level_history_user,priority:1;not null" json:"user_id"`
+	FromLevelID uint       `gorm:"not null;default:0" json:"from_level_id"`
+	ToLevelID   uint       `gorm:"not null;default:0" json:"to_level_id"`
+	Reason      string     `gorm:"type:varchar(20);index;not null" json:"reason"`
+	ExpiresAt   *time.Time `json:"expires_at"`                               // 变更后的到期时间（NULL=长期）
+	OrderID     uint       `gorm:"index;not null;default:0" json:"order_id"` // 购买付费等级的订单
+	AdminID     uint       `gorm:"not null;default:0" json:"admin_id"`       // 手动设置的管理员
+	Days        int        `gorm:"not null;default:0" json:"days"`           // 购买授予或退款回收的天数
+	Note        string     `gorm:"type:varchar(255);default:''" json:"note"`
+	CreatedAt   time.Time  `gorm:"index:idx_member_level_history_user,priority:2" json:"created_at"`
+}
+
+func (MemberLevelHistory) TableName() string {
+	return "member_level_histories"
+}
+
+// MemberLevelPlan 付费等级方案：购买指定商品/SKU 后授予等级 N 天
+type MemberLevelPlan struct {
+	ID            uint       `gorm:"primarykey" json:"id"`
+	MemberLevelID uint       `gorm:"index;not null" json:"member_level_id"`
+	ProductID     uint       `gorm:"index:idx_member_level_plan_item;not null" json:"product_id"`
+	SKUID         uint       `gorm:"column:sku_id;index:idx_member_level_plan_item;not null;default:0" json:"sku_id"` // 0=商品下任意 SKU
+	DurationDays  int        `gorm:"not null" json:"duration_days"`                                                   // 每件授予天数
+	IsActive      bool       `gorm:"not null;default:true" json:"is_active"`
+	CreatedAt     time.Time  `gorm:"index" json:"created_at"`
+	UpdatedAt     time.Time  `gorm:"index" json:"updated_at"`
+	DeletedAt     *time.Time `gorm:"index" json:"-"`
+}
+
+func (MemberLevelPlan) TableName() string {
+	return "member_level_plans"
+}
@@ -0,0 +1,154 @@
+package gormstore
+
+import (
+	"errors"
+	"time"
+
+	"github.com/dujiao-next/internal/constants"
+	memberlevelcontract "github.com/dujiao-next/internal/modules/memberlevel/contract"
+	memberleveldomain "github.com/dujiao-next/internal/modules/memberlevel/domain"
+	orderdomain "github.com/dujiao-next/internal/modules/order/domain"
+	walletdomain "github.com/dujiao-next/internal/modules/wallet/domain"
+
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// HistoryStore 读取用户等级变更历史；写入由 UserStore.ChangeMemberLevel 在事务内完成。
+type HistoryStore struct {
+	db *gorm.DB
+}
+
+func NewHistoryStore(db *gorm.DB) *HistoryStore {
+	return &HistoryStore{db: db}
+}
+
+func (r *HistoryStore) List(filter memberlevelcontract.HistoryFilter) ([]memberleveldomain.MemberLevelHistory, int64, error) {
+	query := r.db.Model(&memberleveldomain.MemberLevelHistory{})
+	if filter.UserID > 0 {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+	if filter.OrderID > 0 {
+		query = query.Where("order_id = ?", filter.OrderID)
+	}
+	if filter.Reason != "" {
+		query = query.Where("reason = ?", filter.Reason)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if filter.Page > 0 && filter.PageSize > 0 {
+		query = query.Offset((filter.Page - 1) * filter.PageSize).Limit(filter.PageSize)
+	}
+	var histories []memberleveldomain.MemberLevelHistory
+	if err := query.Order("created_at desc, id desc").Find(&histories).Error; err != nil {
+		return nil, 0, err
+	}
+	return histories, total, nil
+}
+
+// PlanStore 持久化付费等级方案。
+type PlanStore struct {
+	db *gorm.DB
+}
+
+func NewPlanStore(db *gorm.DB) *PlanStore {
+	return &PlanStore{db: db}
+}
+
+func (r *PlanStore) GetByID(id uint) (*memberleveldomain.MemberLevelPlan, error) {
+	var plan memberleveldomain.MemberLevelPlan
+	if err := r.db.Where("deleted_at IS NULL").First(&plan, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &plan, nil
+}
+
+func (r *PlanStore) GetByProductAndSKU(productID, skuID uint) (*memberleveldomain.MemberLevelPlan, error) {
+	var plan memberleveldomain.MemberLevelPlan
+	if err := r.db.Where("deleted_at IS NULL AND product_id = ? AND sku_id = ?", productID, skuID).First(&plan).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &plan, nil
+}
+
+func (r *PlanStore) List(filter memberlevelcontract.PlanFilter) ([]memberleveldomain.MemberLevelPlan, int64, error) {
+	query := r.db.Model(&memberleveldomain.MemberLevelPlan{}).Where("deleted_at IS NULL")
+	if filter.MemberLevelID > 0 {
+		query = query.Where("member_level_id = ?", filter.MemberLevelID)
+	}
+	if filter.ProductID > 0 {
+		query = query.Where("product_id = ?", filter.ProductID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if filter.Page > 0 && filter.PageSize > 0 {
+		query = query.Offset((filter.Page - 1) * filter.PageSize).Limit(filter.PageSize)
+	}
+	var plans []memberleveldomain.MemberLevelPlan
+	if err := query.Order("product_id asc, sku_id asc, id asc").Find(&plans).Error; err != nil {
+		return nil, 0, err
+	}
+	return plans, total, nil
+}
+
+func (r *PlanStore) Create(plan *memberleveldomain.MemberLevelPlan) error {
+	return r.db.Create(plan).Error
+}
+
+func (r *PlanStore) Update(plan *memberleveldomain.MemberLevelPlan) error {
+	return r.db.Save(plan).Error
+}
+
+func (r *PlanStore) Delete(id uint) error {
+	return r.db.Model(&memberleveldomain.MemberLevelPlan{}).
+		Where("id = ? AND deleted_at IS NULL", id).
+		Update("deleted_at", time.Now()).Error
+}
+
+// ActivityStore 从订单与充值记录统计窗口期金额。
+type ActivityStore struct {
+	db *gorm.DB
+}
+
+func NewActivityStore(db *gorm.DB) *ActivityStore {
+	return &ActivityStore{db: db}
+}
+
+// SumSpentSince 统计窗口内已支付且未全额退款/取消的主订单实付金额。
+func (r *ActivityStore) SumSpentSince(userID uint, since time.Time) (decimal.Decimal, error) {
+	var total decimal.Decimal
+	err := r.db.Model(&orderdomain.Order{}).
+		Select("COALESCE(SUM(total_amount), 0)").
+		Where("user_id = ? AND parent_id IS NULL AND paid_at IS NOT NULL AND paid_at >= ?", userID, since).
+		Where("status NOT IN ?", []string{constants.OrderStatusPendingPayment, constants.OrderStatusCanceled, constants.OrderStatusRefunded}).
+		Row().Scan(&total)
+	return total, err
+}
+
+// SumRechargedSince 统计窗口内已到账的钱包充值金额。
+func (r *ActivityStore) SumRechargedSince(userID uint, since time.Time) (decimal.Decimal, error) {
+	var total decimal.Decimal
+	err := r.db.Model(&walletdomain.RechargeOrder{}).
+		Select("COALESCE(SUM(amount), 0)").
+		Where("user_id = ? AND status = ? AND paid_at IS NOT NULL AND paid_at >= ?", userID, constants.WalletRechargeStatusSuccess, since).
+		Row().Scan(&total)
+	return total, err
+}
+
+var (
+	_ memberlevelcontract.HistoryRepository = (*HistoryStore)(nil)
+	_ memberlevelcontract.PlanRepository    = (*PlanStore)(nil)
+	_ memberlevelcontract.ActivityReader    = (*ActivityStore)(nil)
+)
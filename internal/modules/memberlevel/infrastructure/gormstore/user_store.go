@@ -7,6 +7,7 @@ import (
 	userdomain "github.com/dujiao-next/internal/modules/identity/user/domain"
 
 	memberlevelcontract "github.com/dujiao-next/internal/modules/memberlevel/contract"
+	memberleveldomain "github.com/dujiao-next/internal/modules/memberlevel/domain"
 	"github.com/dujiao-next/internal/shared/money"
 	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
@@ -67,17 +68,44 @@ func (r *UserStore) incrementMoneyColumn(userID uint, column string, amount deci
 		}).Error
 }
 
-func (r *UserStore) UpdateMemberLevelIfCurrent(userID, currentLevelID, nextLevelID uint) (int64, error) {
-	if userID == 0 || currentLevelID == nextLevelID {
-		return 0, nil
+// ChangeMemberLevel 在同一事务内按当前等级条件切换用户等级并写入等级历史。
+func (r *UserStore) ChangeMemberLevel(change memberlevelcontract.LevelChange) (bool, error) {
+	if change.UserID == 0 {
+		return false, nil
 	}
-	result := r.db.Model(&userdomain.User{}).
-		Where("id = ? AND member_level_id = ? AND deleted_at IS NULL", userID, currentLevelID).
-		Updates(map[string]interface{}{
-			"member_level_id": nextLevelID,
-			"updated_at":      time.Now(),
-		})
-	return result.RowsAffected, result.Error
+	applied := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&userdomain.User{}).
+			Where("id = ? AND member_level_id = ? AND deleted_at IS NULL", change.UserID, change.FromLevelID).
+			Updates(map[string]interface{}{
+				"member_level_id":         change.ToLevelID,
+				"member_level_expires_at": change.ExpiresAt,
+				"member_level_source":     change.Source,
+				"updated_at":              time.Now(),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		applied = true
+		return tx.Create(&memberleveldomain.MemberLevelHistory{
+			UserID:      change.UserID,
+			FromLevelID: change.FromLevelID,
+			ToLevelID:   change.ToLevelID,
+			Reason:      change.Reason,
+			ExpiresAt:   change.ExpiresAt,
+			OrderID:     change.OrderID,
+			AdminID:     change.AdminID,
+			Days:        change.Days,
+			Note:        change.Note,
+		}).Error
+	})
+	if err != nil {
+		return false, err
+	}
+	return applied, nil
 }
 
 func (r *UserStore) AssignDefaultMemberLevel(defaultLevelID uint) (int64, error) {
@@ -93,4 +121,22 @@ func (r *UserStore) AssignDefaultMemberLevel(defaultLevelID uint) (int64, error)
 	return result.RowsAffected, result.Error
 }
 
+// ListMembershipDue 列出等级已到期、或当前等级按统计窗口评估的用户。
+func (r *UserStore) ListMembershipDue(now time.Time, windowLevelIDs []uint, afterID uint, limit int) ([]userdomain.User, error) {
+	if limit <= 0 {
+		limit = 200
+	}
+	query := r.db.Where("deleted_at IS NULL AND id > ?", afterID)
+	if len(windowLevelIDs) > 0 {
+		query = query.Where("((member_level_expires_at IS NOT NULL AND member_level_expires_at <= ?) OR member_level_id IN ?)", now, windowLevelIDs)
+	} else {
+		query = query.Where("member_level_expires_at IS NOT NULL AND member_level_expires_at <= ?", now)
+	}
+	var users []userdomain.User
+	if err := query.Order("id asc").Limit(limit).Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
 var _ memberlevelcontract.UserRepository = (*UserStore)(nil)
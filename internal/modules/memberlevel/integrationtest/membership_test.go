@@ -0,0 +1,249 @@
+package integrationtest
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dujiao-next/internal/constants"
+	userdomain "github.com/dujiao-next/internal/modules/identity/user/domain"
+	memberlevelapp "github.com/dujiao-next/internal/modules/memberlevel/application"
+	memberlevelcontract "github.com/dujiao-next/internal/modules/memberlevel/contract"
+	memberleveldomain "github.com/dujiao-next/internal/modules/memberlevel/domain"
+	"github.com/dujiao-next/internal/modules/memberlevel/infrastructure/gormstore"
+	orderdomain "github.com/dujiao-next/internal/modules/order/domain"
+	walletdomain "github.com/dujiao-next/internal/modules/wallet/domain"
+	"github.com/dujiao-next/internal/shared/money"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+func newMembershipServiceForTest(t *testing.T) (*memberlevelapp.Service, *gorm.DB) {
+	t.Helper()
+
+	svc, db := newMemberLevelServiceForTest(t)
+	if err := db.AutoMigrate(&memberleveldomain.MemberLevelPlan{}, &orderdomain.Order{}, &walletdomain.RechargeOrder{}); err != nil {
+		t.Fatalf("auto migrate membership tables failed: %v", err)
+	}
+	svc.SetMembershipStores(gormstore.NewPlanStore(db), gormstore.NewHistoryStore(db), gormstore.NewActivityStore(db))
+	return svc, db
+}
+
+func createPaidOrderFixture(t *testing.T, db *gorm.DB, userID uint, amount string, paidAt time.Time) {
+	t.Helper()
+
+	order := orderdomain.Order{
+		OrderNo:     fmt.Sprintf("DJ%d", time.Now().UnixNano()),
+		UserID:      userID,
+		Status:      constants.OrderStatusCompleted,
+		Currency:    "CNY",
+		TotalAmount: money.FromDecimal(decimal.RequireFromString(amount)),
+		PaidAt:      &paidAt,
+	}
+	if err := db.Create(&order).Error; err != nil {
+		t.Fatalf("create order fixture failed: %v", err)
+	}
+}
+
+func reloadUser(t *testing.T, db *gorm.DB, userID uint) userdomain.User {
+	t.Helper()
+
+	var user userdomain.User
+	if err := db.First(&user, userID).Error; err != nil {
+		t.Fatalf("fetch user failed: %v", err)
+	}
+	return user
+}
+
+func listUserHistories(t *testing.T, svc *memberlevelapp.Service, userID uint) []memberleveldomain.MemberLevelHistory {
+	t.Helper()
+
+	rows, _, err := svc.ListHistory(memberlevelcontract.HistoryFilter{UserID: userID, Page: 1, PageSize: 20})
+	if err != nil {
+		t.Fatalf("list histories failed: %v", err)
+	}
+	return rows
+}
+
+func TestMembershipPurchaseGrantsAndExtendsLevelOncePerOrder(t *testing.T) {
+	svc, db := newMembershipServiceForTest(t)
+	defaultLevel := createMemberLevelFixture(t, db, "default", 0, "0", true)
+	vip := createMemberLevelFixture(t, db, "vip", 10, "100000", false)
+	user := createUserFixture(t, db, "purchase@example.com", defaultLevel.ID)
+
+	if err := svc.CreatePlan(&memberleveldomain.MemberLevelPlan{
+		MemberLevelID: vip.ID, ProductID: 7, DurationDays: 30, IsActive: true,
+	}); err != nil {
+		t.Fatalf("create plan failed: %v", err)
+	}
+	if err := svc.CreatePlan(&memberleveldomain.MemberLevelPlan{
+		MemberLevelID: vip.ID, ProductID: 7, DurationDays: 60, IsActive: true,
+	}); err != memberlevelcontract.ErrPlanExists {
+		t.Fatalf("expected ErrPlanExists, got %v", err)
+	}
+
+	items := []memberlevelcontract.PurchasedItem{{ProductID: 7, SKUID: 3, Quantity: 2}}
+	if err := svc.OnMembershipPurchased(user.ID, 101, items); err != nil {
+		t.Fatalf("first purchase failed: %v", err)
+	}
+	first := reloadUser(t, db, user.ID)
+	if first.MemberLevelID != vip.ID || first.MemberLevelSource != memberleveldomain.LevelSourcePurchase {
+		t.Fatalf("expected purchased vip level, got level=%d source=%s", first.MemberLevelID, first.MemberLevelSource)
+	}
+	if first.MemberLevelExpiresAt == nil || time.Until(*first.MemberLevelExpiresAt) < 59*24*time.Hour {
+		t.Fatalf("expected about 60 days validity, got %v", first.MemberLevelExpiresAt)
+	}
+
+	// 同一订单重复回调不应再次顺延
+	if err := svc.OnMembershipPurchased(user.ID, 101, items); err != nil {
+		t.Fatalf("repeat purchase failed: %v", err)
+	}
+	if again := reloadUser(t, db, user.ID); !again.MemberLevelExpiresAt.Equal(*first.MemberLevelExpiresAt) {
+		t.Fatalf("expected expiry unchanged on duplicate order, got %v", again.MemberLevelExpiresAt)
+	}
+
+	if err := svc.OnMembershipPurchased(user.ID, 102, []memberlevelcontract.PurchasedItem{{ProductID: 7, Quantity: 1}}); err != nil {
+		t.Fatalf("renew purchase failed: %v", err)
+	}
+	renewed := reloadUser(t, db, user.ID)
+	if got := renewed.MemberLevelExpiresAt.Sub(*first.MemberLevelExpiresAt); got < 30*24*time.Hour-time.Minute {
+		t.Fatalf("expected expiry extended by 30 days, got %v", got)
+	}
+	if histories := listUserHistories(t, svc, user.ID); len(histories) != 2 {
+		t.Fatalf("expected 2 purchase histories, got %d", len(histories))
+	}
+}
+
+func TestRevokePurchasedForOrderShortensThenDropsLevel(t *testing.T) {
+	svc, db := newMembershipServiceForTest(t)
+	defaultLevel := createMemberLevelFixture(t, db, "default", 0, "0", true)
+	vip := createMemberLevelFixture(t, db, "vip", 10, "100000", false)
+	user := createUserFixture(t, db, "revoke@example.com", defaultLevel.ID)
+	if err := svc.CreatePlan(&memberleveldomain.MemberLevelPlan{
+		MemberLevelID: vip.ID, ProductID: 7, DurationDays: 30, IsActive: true,
+	}); err != nil {
+		t.Fatalf("create plan failed: %v", err)
+	}
+	items := []memberlevelcontract.PurchasedItem{{ProductID: 7, Quantity: 1}}
+	if err := svc.OnMembershipPurchased(user.ID, 201, items); err != nil {
+		t.Fatalf("first purchase failed: %v", err)
+	}
+	first := reloadUser(t, db, user.ID)
+	if err := svc.OnMembershipPurchased(user.ID, 202, items); err != nil {
+		t.Fatalf("second purchase failed: %v", err)
+	}
+
+	// 回收第二笔订单只扣减其授予的天数
+	if err := svc.RevokePurchasedForOrder(202); err != nil {
+		t.Fatalf("revoke second order failed: %v", err)
+	}
+	shortened := reloadUser(t, db, user.ID)
+	if shortened.MemberLevelID != vip.ID || shortened.MemberLevelExpiresAt.Sub(*first.MemberLevelExpiresAt).Abs() > time.Minute {
+		t.Fatalf("expected vip until first expiry, got level=%d expires=%v", shortened.MemberLevelID, shortened.MemberLevelExpiresAt)
+	}
+	if err := svc.RevokePurchasedForOrder(202); err != nil {
+		t.Fatalf("repeat revoke failed: %v", err)
+	}
+	if again := reloadUser(t, db, user.ID); !again.MemberLevelExpiresAt.Equal(*shortened.MemberLevelExpiresAt) {
+		t.Fatalf("repeat revoke must not shorten again, got %v", again.MemberLevelExpiresAt)
+	}
+
+	// 回收全部购买天数后回到默认等级
+	if err := svc.RevokePurchasedForOrder(201); err != nil {
+		t.Fatalf("revoke first order failed: %v", err)
+	}
+	dropped := reloadUser(t, db, user.ID)
+	if dropped.MemberLevelID != defaultLevel.ID || dropped.MemberLevelExpiresAt != nil {
+		t.Fatalf("expected default level, got level=%d expires=%v", dropped.MemberLevelID, dropped.MemberLevelExpiresAt)
+	}
+	revokes, _, err := svc.ListHistory(memberlevelcontract.HistoryFilter{
+		UserID: user.ID, Reason: memberleveldomain.LevelChangeReasonRevoke, Page: 1, PageSize: 20,
+	})
+	if err != nil || len(revokes) != 2 {
+		t.Fatalf("expected two revoke histories, got %d err=%v", len(revokes), err)
+	}
+	for _, row := range revokes {
+		if row.Days != 30 {
+			t.Fatalf("expected 30 revoked days, got %+v", row)
+		}
+	}
+
+	// 未授予等级的订单回收为空操作
+	if err := svc.RevokePurchasedForOrder(999); err != nil {
+		t.Fatalf("revoke unknown order failed: %v", err)
+	}
+}
+
+func TestEvaluateMembershipsExpiresPurchasedLevelToDefault(t *testing.T) {
+	svc, db := newMembershipServiceForTest(t)
+	defaultLevel := createMemberLevelFixture(t, db, "default", 0, "0", true)
+	vip := createMemberLevelFixture(t, db, "vip", 10, "100000", false)
+	user := createUserFixture(t, db, "expire@example.com", vip.ID)
+	expiredAt := time.Now().Add(-time.Hour)
+	if err := db.Model(&userdomain.User{}).Where("id = ?", user.ID).Updates(map[string]interface{}{
+		"member_level_expires_at": expiredAt,
+		"member_level_source":     memberleveldomain.LevelSourcePurchase,
+	}).Error; err != nil {
+		t.Fatalf("prepare expired membership failed: %v", err)
+	}
+
+	result, err := svc.EvaluateMemberships(time.Now())
+	if err != nil {
+		t.Fatalf("evaluate failed: %v", err)
+	}
+	if result.Checked != 1 || result.Downgraded != 1 {
+		t.Fatalf("unexpected evaluation result: %+v", result)
+	}
+	updated := reloadUser(t, db, user.ID)
+	if updated.MemberLevelID != defaultLevel.ID || updated.MemberLevelExpiresAt != nil {
+		t.Fatalf("expected default level without expiry, got level=%d expires=%v", updated.MemberLevelID, updated.MemberLevelExpiresAt)
+	}
+	histories := listUserHistories(t, svc, user.ID)
+	if len(histories) != 1 || histories[0].Reason != memberleveldomain.LevelChangeReasonExpire || histories[0].FromLevelID != vip.ID {
+		t.Fatalf("expected one expire history from vip, got %+v", histories)
+	}
+}
+
+func TestEvaluateMembershipsRenewsAndDowngradesByWindow(t *testing.T) {
+	svc, db := newMembershipServiceForTest(t)
+	defaultLevel := createMemberLevelFixture(t, db, "default", 0, "0", true)
+	vip := createMemberLevelFixture(t, db, "vip", 10, "100", false)
+	if err := db.Model(&memberleveldomain.MemberLevel{}).Where("id = ?", vip.ID).Updates(map[string]interface{}{
+		"evaluation_window_days": 30,
+		"validity_days":          30,
+	}).Error; err != nil {
+		t.Fatalf("prepare window level failed: %v", err)
+	}
+	now := time.Now()
+
+	active := createUserFixture(t, db, "active@example.com", vip.ID)
+	createPaidOrderFixture(t, db, active.ID, "150", now.AddDate(0, 0, -3))
+	if err := db.Model(&userdomain.User{}).Where("id = ?", active.ID).
+		Update("member_level_expires_at", now.Add(-time.Minute)).Error; err != nil {
+		t.Fatalf("prepare expiring membership failed: %v", err)
+	}
+
+	lapsed := createUserFixture(t, db, "lapsed@example.com", vip.ID)
+	createPaidOrderFixture(t, db, lapsed.ID, "500", now.AddDate(0, 0, -60))
+
+	result, err := svc.EvaluateMemberships(now)
+	if err != nil {
+		t.Fatalf("evaluate failed: %v", err)
+	}
+	if result.Renewed != 1 || result.Downgraded != 1 {
+		t.Fatalf("unexpected evaluation result: %+v", result)
+	}
+
+	renewed := reloadUser(t, db, active.ID)
+	if renewed.MemberLevelID != vip.ID || renewed.MemberLevelExpiresAt == nil || !renewed.MemberLevelExpiresAt.After(now) {
+		t.Fatalf("expected vip renewed into the future, got level=%d expires=%v", renewed.MemberLevelID, renewed.MemberLevelExpiresAt)
+	}
+	downgraded := reloadUser(t, db, lapsed.ID)
+	if downgraded.MemberLevelID != defaultLevel.ID {
+		t.Fatalf("expected lapsed user downgraded to default, got %d", downgraded.MemberLevelID)
+	}
+	histories := listUserHistories(t, svc, lapsed.ID)
+	if len(histories) != 1 || histories[0].Reason != memberleveldomain.LevelChangeReasonDowngrade {
+		t.Fatalf("expected one downgrade history, got %+v", histories)
+	}
+}
@@ -25,7 +25,7 @@ func newMemberLevelServiceForTest(t *testing.T) (*memberlevelapp.Service, *gorm.
 	if err != nil {
 		t.Fatalf("open sqlite failed: %v", err)
 	}
-	if err := db.AutoMigrate(&userdomain.User{}, &memberleveldomain.MemberLevel{}, &memberleveldomain.MemberLevelPrice{}); err != nil {
+	if err := db.AutoMigrate(&userdomain.User{}, &memberleveldomain.MemberLevel{}, &memberleveldomain.MemberLevelPrice{}, &memberleveldomain.MemberLevelHistory{}); err != nil {
 		t.Fatalf("auto migrate failed: %v", err)
 	}
 
@@ -169,8 +169,12 @@ func (r *concurrentUserRepository) IncrementTotalSpent(userID uint, amount decim
 	return nil
 }
 
-func (r *concurrentUserRepository) UpdateMemberLevelIfCurrent(userID, currentLevelID, nextLevelID uint) (int64, error) {
-	return r.base.UpdateMemberLevelIfCurrent(userID, currentLevelID, nextLevelID)
+func (r *concurrentUserRepository) ChangeMemberLevel(change memberlevelcontract.LevelChange) (bool, error) {
+	return r.base.ChangeMemberLevel(change)
+}
+
+func (r *concurrentUserRepository) ListMembershipDue(now time.Time, windowLevelIDs []uint, afterID uint, limit int) ([]userdomain.User, error) {
+	return r.base.ListMembershipDue(now, windowLevelIDs, afterID, limit)
 }
 
 func (r *concurrentUserRepository) AssignDefaultMemberLevel(defaultLevelID uint) (int64, error) {
@@ -2,7 +2,9 @@ package memberlevelhttp
 
 import (
 	"errors"
+	"time"
 
+	memberlevelapp "github.com/dujiao-next/internal/modules/memberlevel/application"
 	memberlevelcontract "github.com/dujiao-next/internal/modules/memberlevel/contract"
 	memberleveldomain "github.com/dujiao-next/internal/modules/memberlevel/domain"
 	"github.com/dujiao-next/internal/platform/http/ginutil"
@@ -23,8 +25,14 @@ type AdminService interface {
 	GetLevelPricesByProduct(productID uint) ([]memberleveldomain.MemberLevelPrice, error)
 	BatchUpsertLevelPrices(prices []memberleveldomain.MemberLevelPrice) error
 	DeleteLevelPrice(id uint) error
-	SetUserLevel(userID, levelID uint) error
+	SetUserLevel(input memberlevelapp.SetUserLevelInput) error
 	BackfillDefaultLevel() (int64, error)
+	ListHistory(filter memberlevelcontract.HistoryFilter) ([]memberleveldomain.MemberLevelHistory, int64, error)
+	ListPlans(filter memberlevelcontract.PlanFilter) ([]memberleveldomain.MemberLevelPlan, int64, error)
+	CreatePlan(plan *memberleveldomain.MemberLevelPlan) error
+	UpdatePlan(plan *memberleveldomain.MemberLevelPlan) error
+	DeletePlan(id uint) error
+	EvaluateMemberships(now time.Time) (memberlevelapp.MembershipEvaluation, error)
 }
 
 type AdminHandler struct {
@@ -43,9 +51,13 @@ type CreateMemberLevelRequest struct {
 	DiscountRate      float64      `json:"discount_rate"`
 	RechargeThreshold float64      `json:"recharge_threshold"`
 	SpendThreshold    float64      `json:"spend_threshold"`
-	IsDefault         bool         `json:"is_default"`
-	SortOrder         int          `json:"sort_order"`
-	IsActive          *bool        `json:"is_active"`
+	// EvaluationWindowDays 阈值统计窗口（天），0=累计金额
+	EvaluationWindowDays int `json:"evaluation_window_days" binding:"min=0,max=3650"`
+	// ValidityDays 达标获得后的有效期（天），0=长期
+	ValidityDays int   `json:"validity_days" binding:"min=0,max=3650"`
+	IsDefault    bool  `json:"is_default"`
+	SortOrder    int   `json:"sort_order"`
+	IsActive     *bool `json:"is_active"`
 }
 
 // GetAdminMemberLevels 获取会员等级列表
@@ -86,15 +98,17 @@ func (h *AdminHandler) CreateMemberLevel(c *gin.Context) {
 	}
 
 	level := &memberleveldomain.MemberLevel{
-		NameJSON:          req.NameJSON,
-		Slug:              req.Slug,
-		Icon:              req.Icon,
-		DiscountRate:      money.FromDecimal(decimal.NewFromFloat(req.DiscountRate)),
-		RechargeThreshold: money.FromDecimal(decimal.NewFromFloat(req.RechargeThreshold)),
-		SpendThreshold:    money.FromDecimal(decimal.NewFromFloat(req.SpendThreshold)),
-		IsDefault:         req.IsDefault,
-		SortOrder:         req.SortOrder,
-		IsActive:          isActive,
+		NameJSON:             req.NameJSON,
+		Slug:                 req.Slug,
+		Icon:                 req.Icon,
+		DiscountRate:         money.FromDecimal(decimal.NewFromFloat(req.DiscountRate)),
+		RechargeThreshold:    money.FromDecimal(decimal.NewFromFloat(req.RechargeThreshold)),
+		SpendThreshold:       money.FromDecimal(decimal.NewFromFloat(req.SpendThreshold)),
+		EvaluationWindowDays: req.EvaluationWindowDays,
+		ValidityDays:         req.ValidityDays,
+		IsDefault:            req.IsDefault,
+		SortOrder:            req.SortOrder,
+		IsActive:             isActive,
 	}
 
 	if err := h.service.CreateLevel(level); err != nil {
@@ -142,6 +156,8 @@ func (h *AdminHandler) UpdateMemberLevel(c *gin.Context) {
 	existing.DiscountRate = money.FromDecimal(decimal.NewFromFloat(req.DiscountRate))
 	existing.RechargeThreshold = money.FromDecimal(decimal.NewFromFloat(req.RechargeThreshold))
 	existing.SpendThreshold = money.FromDecimal(decimal.NewFromFloat(req.SpendThreshold))
+	existing.EvaluationWindowDays = req.EvaluationWindowDays
+	existing.ValidityDays = req.ValidityDays
 	existing.IsDefault = req.IsDefault
 	existing.SortOrder = req.SortOrder
 	if req.IsActive != nil {
@@ -262,7 +278,9 @@ func (h *AdminHandler) DeleteMemberLevelPrice(c *gin.Context) {
 
 // SetUserMemberLevelRequest 手动设置用户等级请求
 type SetUserMemberLevelRequest struct {
-	MemberLevelID uint `json:"member_level_id"`
+	MemberLevelID uint       `json:"member_level_id"`
+	ExpiresAt     *time.Time `json:"expires_at"` // 为空表示长期
+	Note          string     `json:"note" binding:"max=255"`
 }
 
 // SetUserMemberLevel 手动设置用户等级
@@ -279,10 +297,27 @@ func (h *AdminHandler) SetUserMemberLevel(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.SetUserLevel(userID, req.MemberLevelID); err != nil {
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.member_level_expiry_invalid", nil)
+		return
+	}
+	adminID, ok := ginutil.GetAdminID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.SetUserLevel(memberlevelapp.SetUserLevelInput{
+		UserID:    userID,
+		LevelID:   req.MemberLevelID,
+		AdminID:   adminID,
+		ExpiresAt: req.ExpiresAt,
+		Note:      req.Note,
+	}); err != nil {
 		switch {
 		case errors.Is(err, memberlevelcontract.ErrNotFound):
 			ginutil.RespondError(c, response.CodeNotFound, "error.member_level_not_found", nil)
+		case errors.Is(err, memberlevelcontract.ErrUserNotFound):
+			ginutil.RespondError(c, response.CodeNotFound, "error.user_not_found", nil)
 		default:
 			ginutil.RespondError(c, response.CodeInternal, "error.user_member_level_update_failed", err)
 		}
@@ -0,0 +1,158 @@
+package memberlevelhttp
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	memberlevelcontract "github.com/dujiao-next/internal/modules/memberlevel/contract"
+	memberleveldomain "github.com/dujiao-next/internal/modules/memberlevel/domain"
+	"github.com/dujiao-next/internal/platform/http/ginutil"
+	"github.com/dujiao-next/internal/platform/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MemberLevelPlanRequest 创建/更新付费等级方案请求
+type MemberLevelPlanRequest struct {
+	MemberLevelID uint  `json:"member_level_id" binding:"required"`
+	ProductID     uint  `json:"product_id" binding:"required"`
+	SKUID         uint  `json:"sku_id"`
+	DurationDays  int   `json:"duration_days" binding:"required,min=1,max=3650"`
+	IsActive      *bool `json:"is_active"`
+}
+
+// GetUserMemberLevelHistories 获取用户等级变更历史
+func (h *AdminHandler) GetUserMemberLevelHistories(c *gin.Context) {
+	userID, err := ginutil.ParseParamUint(c, "id")
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", err)
+		return
+	}
+	page, pageSize := ginutil.ParsePaginationWithKeys(c, "page", "page_size", 20)
+
+	histories, total, err := h.service.ListHistory(memberlevelcontract.HistoryFilter{
+		UserID:   userID,
+		Reason:   strings.TrimSpace(c.Query("reason")),
+		Page:     page,
+		PageSize: pageSize,
+	})
+	if err != nil {
+		ginutil.RespondError(c, response.CodeInternal, "error.member_level_history_fetch_failed", err)
+		return
+	}
+	response.SuccessWithPage(c, histories, response.BuildPagination(page, pageSize, total))
+}
+
+// GetMemberLevelPlans 获取付费等级方案列表
+func (h *AdminHandler) GetMemberLevelPlans(c *gin.Context) {
+	page, pageSize := ginutil.ParsePaginationWithKeys(c, "page", "page_size", 50)
+	levelID, err := ginutil.ParseQueryUint(c.Query("member_level_id"), false)
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", err)
+		return
+	}
+	productID, err := ginutil.ParseQueryUint(c.Query("product_id"), false)
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", err)
+		return
+	}
+
+	plans, total, err := h.service.ListPlans(memberlevelcontract.PlanFilter{
+		MemberLevelID: levelID,
+		ProductID:     productID,
+		Page:          page,
+		PageSize:      pageSize,
+	})
+	if err != nil {
+		ginutil.RespondError(c, response.CodeInternal, "error.member_level_plan_fetch_failed", err)
+		return
+	}
+	response.SuccessWithPage(c, plans, response.BuildPagination(page, pageSize, total))
+}
+
+// CreateMemberLevelPlan 创建付费等级方案
+func (h *AdminHandler) CreateMemberLevelPlan(c *gin.Context) {
+	var req MemberLevelPlanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ginutil.RespondBindError(c, err)
+		return
+	}
+	plan := &memberleveldomain.MemberLevelPlan{IsActive: true}
+	applyPlanRequest(plan, req)
+	if err := h.service.CreatePlan(plan); err != nil {
+		respondPlanError(c, err, "error.member_level_plan_save_failed")
+		return
+	}
+	response.Success(c, plan)
+}
+
+// UpdateMemberLevelPlan 更新付费等级方案
+func (h *AdminHandler) UpdateMemberLevelPlan(c *gin.Context) {
+	planID, err := ginutil.ParseParamUint(c, "id")
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", err)
+		return
+	}
+	var req MemberLevelPlanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ginutil.RespondBindError(c, err)
+		return
+	}
+	plan := &memberleveldomain.MemberLevelPlan{ID: planID, IsActive: true}
+	applyPlanRequest(plan, req)
+	if err := h.service.UpdatePlan(plan); err != nil {
+		respondPlanError(c, err, "error.member_level_plan_save_failed")
+		return
+	}
+	response.Success(c, plan)
+}
+
+// DeleteMemberLevelPlan 删除付费等级方案
+func (h *AdminHandler) DeleteMemberLevelPlan(c *gin.Context) {
+	planID, err := ginutil.ParseParamUint(c, "id")
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", err)
+		return
+	}
+	if err := h.service.DeletePlan(planID); err != nil {
+		respondPlanError(c, err, "error.member_level_plan_delete_failed")
+		return
+	}
+	response.Success(c, gin.H{"deleted": true})
+}
+
+// EvaluateMemberLevels POST /admin/member-levels/evaluate — 立即执行一次到期与窗口评估
+func (h *AdminHandler) EvaluateMemberLevels(c *gin.Context) {
+	result, err := h.service.EvaluateMemberships(time.Now())
+	if err != nil {
+		ginutil.RespondError(c, response.CodeInternal, "error.member_level_evaluate_failed", err)
+		return
+	}
+	response.Success(c, result)
+}
+
+func applyPlanRequest(plan *memberleveldomain.MemberLevelPlan, req MemberLevelPlanRequest) {
+	plan.MemberLevelID = req.MemberLevelID
+	plan.ProductID = req.ProductID
+	plan.SKUID = req.SKUID
+	plan.DurationDays = req.DurationDays
+	if req.IsActive != nil {
+		plan.IsActive = *req.IsActive
+	}
+}
+
+func respondPlanError(c *gin.Context, err error, fallbackKey string) {
+	switch {
+	case errors.Is(err, memberlevelcontract.ErrPlanNotFound):
+		ginutil.RespondError(c, response.CodeNotFound, "error.member_level_plan_not_found", nil)
+	case errors.Is(err, memberlevelcontract.ErrNotFound):
+		ginutil.RespondError(c, response.CodeNotFound, "error.member_level_not_found", nil)
+	case errors.Is(err, memberlevelcontract.ErrPlanExists):
+		ginutil.RespondError(c, response.CodeBadRequest, "error.member_level_plan_exists", nil)
+	case errors.Is(err, memberlevelcontract.ErrPlanInvalid):
+		ginutil.RespondError(c, response.CodeBadRequest, "error.member_level_plan_invalid", nil)
+	default:
+		ginutil.RespondError(c, response.CodeInternal, fallbackKey, err)
+	}
+}
@@ -11,7 +11,13 @@ func RegisterAdminRoutes(admin gin.IRoutes, handler *AdminHandler) {
 	admin.POST("/member-level-prices/batch", handler.BatchUpsertMemberLevelPrices)
 	admin.DELETE("/member-level-prices/:id", handler.DeleteMemberLevelPrice)
 	admin.POST("/member-levels/backfill", handler.BackfillMemberLevels)
+	admin.POST("/member-levels/evaluate", handler.EvaluateMemberLevels)
+	admin.GET("/member-level-plans", handler.GetMemberLevelPlans)
+	admin.POST("/member-level-plans", handler.CreateMemberLevelPlan)
+	admin.PUT("/member-level-plans/:id", handler.UpdateMemberLevelPlan)
+	admin.DELETE("/member-level-plans/:id", handler.DeleteMemberLevelPlan)
 	admin.PUT("/users/:id/member-level", handler.SetUserMemberLevel)
+	admin.GET("/users/:id/member-level-histories", handler.GetUserMemberLevelHistories)
 }
 
 func RegisterPublicRoutes(public gin.IRoutes, handler *PublicHandler) {
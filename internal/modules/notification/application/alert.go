@@ -21,7 +21,7 @@ func (s *Service) dispatchExceptionAlertCheck(ctx context.Context, setting setti
 	}
 
 	var firstErr error
-	inventoryAlerts, err := s.dashboardSvc.GetInventoryAlertItems(ctx, dashboardSetting.Alert.LowStockThreshold)
+	inventoryAlerts, err := s.dashboardSvc.GetInventoryAlertItems(ctx, dashboardSetting.Alert)
 	if err != nil {
 		return err
 	}
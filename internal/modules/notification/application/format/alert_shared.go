@@ -39,6 +39,8 @@ func alertTypeLabelByType(locale, alertType string) string {
 		constants.NotificationAlertTypeLowStockProducts:   {"低库存商品", "低庫存商品", "Low Stock"},
 		constants.NotificationAlertTypePendingOrders:      {"待支付订单", "待支付訂單", "Pending Payment"},
 		constants.NotificationAlertTypePaymentsFailed:     {"支付失败", "支付失敗", "Payment Failed"},
+		constants.NotificationAlertTypeOrderReviewPending: {"订单待复核", "訂單待複核", "Order Review Pending"},
+		constants.NotificationAlertTypeOrderReviewOverdue: {"订单复核超时", "訂單複核逾時", "Order Review Overdue"},
 	}
 	value, ok := values[alertType]
 	if !ok {
@@ -54,6 +56,43 @@ func alertTypeLabelByType(locale, alertType string) string {
 	}
 }
 
+// ApplyOrderReviewAlertVariables 按通知语言补全订单复核告警的类型名称与详情文案。
+// 复核服务只写入 alert_type_key 与订单事实，文案在分发时按语言渲染。
+func ApplyOrderReviewAlertVariables(variables map[string]interface{}, locale string) {
+	if len(variables) == 0 {
+		return
+	}
+	alertType := toString(variables["alert_type_key"])
+	if alertType != constants.NotificationAlertTypeOrderReviewPending && alertType != constants.NotificationAlertTypeOrderReviewOverdue {
+		return
+	}
+	variables["alert_type"] = alertTypeLabelByType(locale, alertType)
+	variables["alert_type_label"] = variables["alert_type"]
+	orderNo := toString(variables["order_no"])
+	if alertType == constants.NotificationAlertTypeOrderReviewOverdue {
+		held := toString(variables["alert_value"])
+		sla := toString(variables["alert_threshold"])
+		variables["message"] = localizedNotificationText(
+			locale,
+			fmt.Sprintf("订单 %s 已待复核 %s 分钟，超过复核时限 %s 分钟，请尽快处理。", orderNo, held, sla),
+			fmt.Sprintf("訂單 %s 已待複核 %s 分鐘，超過複核時限 %s 分鐘，請盡快處理。", orderNo, held, sla),
+			fmt.Sprintf("Order %s has been waiting for review for %s minutes, exceeding the %s-minute SLA.", orderNo, held, sla),
+		)
+		return
+	}
+	source := toString(variables["hold_source"])
+	sourceLabel := localizedNotificationText(locale, "商品设置", "商品設定", "product setting")
+	if source == constants.OrderReviewHoldRiskScore {
+		sourceLabel = localizedNotificationText(locale, "风险评分", "風險評分", "risk score")
+	}
+	variables["message"] = localizedNotificationText(
+		locale,
+		fmt.Sprintf("订单 %s 已支付并因%s进入人工复核，交付与采购已暂停，请在后台复核队列处理。", orderNo, sourceLabel),
+		fmt.Sprintf("訂單 %s 已支付並因%s進入人工複核，交付與採購已暫停，請在後台複核佇列處理。", orderNo, sourceLabel),
+		fmt.Sprintf("Order %s was paid and held for manual review by %s; fulfillment and procurement are paused until it is reviewed.", orderNo, sourceLabel),
+	)
+}
+
 func NormalizeInventoryAlertTypeKey(value string) string {
 	normalized := strings.ToLower(strings.TrimSpace(value))
 	inventoryAlertTypes := []string{
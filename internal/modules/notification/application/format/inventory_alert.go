@@ -151,12 +151,21 @@ func buildInventoryAlertSummary(locale string, rows []dashboard.InventoryAlertRo
 		if fulfillmentLabel != "" {
 			line += " [" + fulfillmentLabel + "]"
 		}
-		line += localizedNotificationText(
-			locale,
-			fmt.Sprintf(" 剩余 %d（%s）", row.AvailableStock, statusLabel),
-			fmt.Sprintf(" 剩餘 %d（%s）", row.AvailableStock, statusLabel),
-			fmt.Sprintf(" | Remaining %d (%s)", row.AvailableStock, statusLabel),
-		)
+		if row.DaysLeft != nil && row.AvailableStock > 0 {
+			line += localizedNotificationText(
+				locale,
+				fmt.Sprintf(" 剩余 %d，按近期销量约可售 %.1f 天（%s）", row.AvailableStock, *row.DaysLeft, statusLabel),
+				fmt.Sprintf(" 剩餘 %d，按近期銷量約可售 %.1f 天（%s）", row.AvailableStock, *row.DaysLeft, statusLabel),
+				fmt.Sprintf(" | Remaining %d, about %.1f days at recent sales (%s)", row.AvailableStock, *row.DaysLeft, statusLabel),
+			)
+		} else {
+			line += localizedNotificationText(
+				locale,
+				fmt.Sprintf(" 剩余 %d（%s）", row.AvailableStock, statusLabel),
+				fmt.Sprintf(" 剩餘 %d（%s）", row.AvailableStock, statusLabel),
+				fmt.Sprintf(" | Remaining %d (%s)", row.AvailableStock, statusLabel),
+			)
+		}
 		lines = append(lines, line)
 	}
 	return strings.Join(lines, "\n")
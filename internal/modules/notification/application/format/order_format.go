@@ -31,7 +31,7 @@ func BuildOrderItemSummaries(items []orderdomain.OrderItem, locale string) (stri
 		allLines = append(allLines, line)
 
 		switch NormalizeFulfillmentType(item.FulfillmentType) {
-		case constants.FulfillmentTypeAuto:
+		case constants.FulfillmentTypeAuto, constants.FulfillmentTypeWebhook, constants.FulfillmentTypeFile, constants.FulfillmentTypeLicense:
 			counts.Auto++
 		case constants.FulfillmentTypeUpstream:
 			counts.Upstream++
@@ -97,6 +97,12 @@ func notificationFulfillmentLabel(locale, fulfillmentType string) string {
 		return localizedNotificationText(locale, "自动交付", "自動交付", "Auto")
 	case constants.FulfillmentTypeUpstream:
 		return localizedNotificationText(locale, "上游交付", "上游交付", "Upstream")
+	case constants.FulfillmentTypeWebhook:
+		return localizedNotificationText(locale, "接口交付", "介面交付", "Webhook")
+	case constants.FulfillmentTypeFile:
+		return localizedNotificationText(locale, "文件交付", "檔案交付", "File")
+	case constants.FulfillmentTypeLicense:
+		return localizedNotificationText(locale, "授权码", "授權碼", "License key")
 	default:
 		return localizedNotificationText(locale, "人工交付", "人工交付", "Manual")
 	}
@@ -108,6 +114,12 @@ func NormalizeFulfillmentType(fulfillmentType string) string {
 		return constants.FulfillmentTypeAuto
 	case constants.FulfillmentTypeUpstream:
 		return constants.FulfillmentTypeUpstream
+	case constants.FulfillmentTypeWebhook:
+		return constants.FulfillmentTypeWebhook
+	case constants.FulfillmentTypeFile:
+		return constants.FulfillmentTypeFile
+	case constants.FulfillmentTypeLicense:
+		return constants.FulfillmentTypeLicense
 	default:
 		return constants.FulfillmentTypeManual
 	}
@@ -149,7 +161,7 @@ func resolveNotificationLocalizedJSON(value jsonmap.JSON, locale, defaultLocale
 }
 
 func localizedNotificationText(locale, zhCN, zhTW, enUS string) string {
-	switch normalizeNotificationLocale(locale) {
+	switch builtinNotificationLocale(locale) {
 	case constants.LocaleZhTW:
 		return zhTW
 	case constants.LocaleEnUS:
@@ -89,6 +89,10 @@ func normalizeNotificationLocale(locale string) string {
 	return settingsmessaging.NormalizeNotificationLocale(locale)
 }
 
+func builtinNotificationLocale(locale string) string {
+	return settingsmessaging.ResolveBuiltinLocale(locale)
+}
+
 func normalizeNotificationInventoryAlertInterval(seconds int) int {
 	return settingsmessaging.NormalizeNotificationInventoryAlertInterval(seconds)
 }
@@ -41,13 +41,21 @@ type OrderStatusEmailInput struct {
 	MailBrand         mailbrand.Brand
 }
 
+// RestockEmailInput carries the product facts required to render a back-in-stock email.
+type RestockEmailInput struct {
+	ProductTitle   string
+	ProductURL     string
+	UnsubscribeURL string
+	MailBrand      mailbrand.Brand
+}
+
 type DispatchQueue interface {
 	EnqueueNotificationDispatch(payload queue.NotificationDispatchPayload, maxRetry int) error
 }
 
 type DashboardAlertReader interface {
 	LoadDashboardAlertSetting() settingsstorefront.DashboardAlertSetting
-	GetInventoryAlertItems(ctx context.Context, lowStockThreshold int64) ([]dashboardcontract.InventoryAlertRow, error)
+	GetInventoryAlertItems(ctx context.Context, alert settingsstorefront.DashboardAlertSetting) ([]dashboardcontract.InventoryAlertRow, error)
 	GetPaymentOrderAlertCounts(ctx context.Context, startAt, endAt time.Time) (dashboardcontract.PaymentOrderAlertCountsRow, error)
 }
 
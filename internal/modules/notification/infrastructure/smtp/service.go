@@ -171,6 +171,20 @@ func buildOrderStatusContentFromTemplate(input notificationcontract.OrderStatusE
 	return subject, body
 }
 
+// SendRestockEmail 发送到货提醒
+func (s *Service) SendRestockEmail(toEmail string, input notificationcontract.RestockEmailInput, locale string) error {
+	subject, body := buildRestockContent(input, locale)
+	return s.sendTextEmail(toEmail, subject, body, input.MailBrand)
+}
+
+func buildRestockContent(input notificationcontract.RestockEmailInput, locale string) (string, string) {
+	normalized := normalizeLocale(locale)
+	title := strings.TrimSpace(input.ProductTitle)
+	subject := i18n.Sprintf(normalized, "email.restock.subject", title)
+	body := i18n.Sprintf(normalized, "email.restock.body", title, strings.TrimSpace(input.ProductURL), strings.TrimSpace(input.UnsubscribeURL))
+	return subject, body
+}
+
 // SendCustomEmail 发送测试邮件或自定义邮件
 func (s *Service) SendCustomEmail(toEmail, subject, body string) error {
 	subject = strings.TrimSpace(subject)
@@ -16,7 +16,7 @@ import (
 
 type SettingsService interface {
 	GetNotificationCenterSetting() (settingsmessaging.NotificationCenterSetting, error)
-	PatchNotificationCenterSetting(settingsmessaging.NotificationCenterSettingPatch) (settingsmessaging.NotificationCenterSetting, error)
+	PatchNotificationCenterSetting(patch settingsmessaging.NotificationCenterSettingPatch, adminID uint) (settingsmessaging.NotificationCenterSetting, error)
 }
 
 type LogService interface {
@@ -58,7 +58,11 @@ func (h *AdminHandler) UpdateNotificationCenterSettings(c *gin.Context) {
 		return
 	}
 
-	setting, err := h.settings.PatchNotificationCenterSetting(req)
+	adminID, ok := ginutil.GetAdminID(c)
+	if !ok {
+		return
+	}
+	setting, err := h.settings.PatchNotificationCenterSetting(req, adminID)
 	if err != nil {
 		switch {
 		case errors.Is(err, contract.ErrConfigInvalid):
@@ -1,6 +1,12 @@
 package application
 
-import "github.com/shopspring/decimal"
+import (
+	"strings"
+
+	"github.com/dujiao-next/internal/shared/money"
+
+	"github.com/shopspring/decimal"
+)
 
 func normalizeOrderAmount(amount decimal.Decimal) decimal.Decimal {
 	normalized := amount.Round(2)
@@ -9,3 +15,82 @@ func normalizeOrderAmount(amount decimal.Decimal) decimal.Decimal {
 	}
 	return normalized
 }
+
+// OrderCurrencyQuoter 是下单币种报价端口：返回站点基准币种与 1 基准币种兑下单币种的汇率。
+type OrderCurrencyQuoter interface {
+	Quote(currency string) (string, decimal.Decimal, error)
+}
+
+// applyOrderCurrency 把以基准币种计算好的订单金额按汇率换算为下单币种，并记录汇率快照。
+// 成本价仍保留基准币种，便于利润报表直接按基准币种核算；分销站订单只接受基准币种。
+func (s *OrderService) applyOrderCurrency(result *orderBuildResult, requested string, resellerOrder bool) error {
+	base := result.Currency
+	result.BaseCurrency = base
+	result.ExchangeRate = decimal.NewFromInt(1)
+	currency := strings.ToUpper(strings.TrimSpace(requested))
+	if currency == "" || currency == base {
+		return nil
+	}
+	if resellerOrder || s.currencyQuoter == nil {
+		return ErrOrderCurrencyUnsupported
+	}
+	quotedBase, rate, err := s.currencyQuoter.Quote(currency)
+	if err != nil {
+		return err
+	}
+	if quotedBase != base || rate.LessThanOrEqual(decimal.Zero) {
+		return ErrOrderCurrencyUnsupported
+	}
+
+	convert := func(amount decimal.Decimal) decimal.Decimal {
+		return amount.Mul(rate).Round(2)
+	}
+	convertAmount := func(amount money.Amount) money.Amount {
+		return money.FromDecimal(convert(amount.Decimal))
+	}
+	originalAmount := decimal.Zero
+	memberDiscount := decimal.Zero
+	promotionDiscount := decimal.Zero
+	wholesaleDiscount := decimal.Zero
+	couponDiscount := decimal.Zero
+	totalAmount := decimal.Zero
+	for i := range result.Plans {
+		plan := &result.Plans[i]
+		plan.TotalAmount = convert(plan.TotalAmount)
+		plan.MemberDiscount = convert(plan.MemberDiscount)
+		plan.PromotionDiscount = convert(plan.PromotionDiscount)
+		plan.WholesaleDiscount = convert(plan.WholesaleDiscount)
+		plan.CouponDiscount = convert(plan.CouponDiscount)
+		plan.Currency = currency
+
+		item := &plan.Item
+		item.OriginalUnitPrice = convertAmount(item.OriginalUnitPrice)
+		item.UnitPrice = convertAmount(item.UnitPrice)
+		item.OriginalTotalPrice = convertAmount(item.OriginalTotalPrice)
+		item.TotalPrice = money.FromDecimal(plan.TotalAmount)
+		item.MemberDiscount = money.FromDecimal(plan.MemberDiscount)
+		item.PromotionDiscount = money.FromDecimal(plan.PromotionDiscount)
+		item.WholesaleDiscount = money.FromDecimal(plan.WholesaleDiscount)
+		item.CouponDiscount = money.FromDecimal(plan.CouponDiscount)
+
+		originalAmount = originalAmount.Add(item.OriginalTotalPrice.Decimal)
+		memberDiscount = memberDiscount.Add(plan.MemberDiscount)
+		promotionDiscount = promotionDiscount.Add(plan.PromotionDiscount)
+		wholesaleDiscount = wholesaleDiscount.Add(plan.WholesaleDiscount)
+		couponDiscount = couponDiscount.Add(plan.CouponDiscount)
+		totalAmount = totalAmount.Add(normalizeOrderAmount(plan.TotalAmount.Sub(plan.CouponDiscount)))
+	}
+	if totalAmount.LessThanOrEqual(decimal.Zero) {
+		return ErrInvalidOrderAmount
+	}
+
+	result.OriginalAmount = originalAmount.Round(2)
+	result.MemberDiscountAmount = memberDiscount.Round(2)
+	result.PromotionDiscountAmount = promotionDiscount.Round(2)
+	result.WholesaleDiscountAmount = wholesaleDiscount.Round(2)
+	result.DiscountAmount = couponDiscount.Round(2)
+	result.TotalAmount = totalAmount.Round(2)
+	result.Currency = currency
+	result.ExchangeRate = rate
+	return nil
+}
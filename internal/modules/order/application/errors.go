@@ -5,6 +5,7 @@ import (
 
 	productcontract "github.com/dujiao-next/internal/modules/catalog/product/contract"
 	productdomain "github.com/dujiao-next/internal/modules/catalog/product/domain"
+	fxratecontract "github.com/dujiao-next/internal/modules/fxrate/contract"
 	resellercontract "github.com/dujiao-next/internal/modules/reseller/contract"
 )
 
@@ -13,6 +14,7 @@ var (
 	ErrInvalidOrderItem           = productdomain.ErrPurchaseQuantityInvalid
 	ErrInvalidOrderAmount         = errors.New("invalid order amount")
 	ErrOrderCurrencyMismatch      = errors.New("order currency mismatch")
+	ErrOrderCurrencyUnsupported   = fxratecontract.ErrCurrencyUnsupported
 	ErrOrderNotFound              = resellercontract.ErrOrderNotFound
 	ErrOrderCreateFailed          = errors.New("order create failed")
 	ErrOrderFetchFailed           = errors.New("order fetch failed")
@@ -34,6 +36,9 @@ var (
 	ErrRefundRecordCreateFailed   = errors.New("refund record create failed")
 	ErrCardSecretInsufficient     = errors.New("card secret insufficient")
 	ErrManualStockInsufficient    = errors.New("manual stock insufficient")
+	ErrPreorderUnavailable        = errors.New("preorder unavailable")
+	ErrPreorderLimitReached       = errors.New("preorder limit reached")
+	ErrPreorderLoginRequired      = errors.New("preorder login required")
 	ErrQueueUnavailable           = errors.New("queue unavailable")
 	ErrResellerProductNotListed   = productcontract.ErrResellerProductNotListed
 	ErrResellerCouponNotAllowed   = errors.New("reseller coupon not allowed")
@@ -41,11 +41,11 @@ func summarizeManualStockItems(items []orderdomain.OrderItem) manualStockSummary
 }
 
 func releaseManualStockByItems(productRepo productcontract.Repository, productSKURepo productcontract.SKURepository, items []orderdomain.OrderItem) error {
-	var skuOp func(uint, int) (int64, error)
+	var skuOp func(uint, int, uint) (int64, error)
 	if productSKURepo != nil {
 		skuOp = productSKURepo.ReleaseManualStock
 	}
-	var productOp func(uint, int) (int64, error)
+	var productOp func(uint, int, uint) (int64, error)
 	if productRepo != nil {
 		productOp = productRepo.ReleaseManualStock
 	}
@@ -53,11 +53,11 @@ func releaseManualStockByItems(productRepo productcontract.Repository, productSK
 }
 
 func ConsumeManualStockByItems(productRepo productcontract.Repository, productSKURepo productcontract.SKURepository, items []orderdomain.OrderItem) error {
-	var skuOp func(uint, int) (int64, error)
+	var skuOp func(uint, int, uint) (int64, error)
 	if productSKURepo != nil {
 		skuOp = productSKURepo.ConsumeManualStock
 	}
-	var productOp func(uint, int) (int64, error)
+	var productOp func(uint, int, uint) (int64, error)
 	if productRepo != nil {
 		productOp = productRepo.ConsumeManualStock
 	}
@@ -68,8 +68,34 @@ func applyManualStockByItems(
 	productRepo productcontract.Repository,
 	productSKURepo productcontract.SKURepository,
 	items []orderdomain.OrderItem,
-	updateSKU func(uint, int) (int64, error),
-	updateProduct func(uint, int) (int64, error),
+	updateSKU func(uint, int, uint) (int64, error),
+	updateProduct func(uint, int, uint) (int64, error),
+	requireAffected bool,
+) error {
+	// 按订单分组执行，库存流水可关联到具体订单
+	orderIDs := make([]uint, 0, 1)
+	byOrder := make(map[uint][]orderdomain.OrderItem)
+	for _, item := range items {
+		if _, ok := byOrder[item.OrderID]; !ok {
+			orderIDs = append(orderIDs, item.OrderID)
+		}
+		byOrder[item.OrderID] = append(byOrder[item.OrderID], item)
+	}
+	for _, orderID := range orderIDs {
+		if err := applyOrderManualStock(productRepo, productSKURepo, orderID, byOrder[orderID], updateSKU, updateProduct, requireAffected); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func applyOrderManualStock(
+	productRepo productcontract.Repository,
+	productSKURepo productcontract.SKURepository,
+	orderID uint,
+	items []orderdomain.OrderItem,
+	updateSKU func(uint, int, uint) (int64, error),
+	updateProduct func(uint, int, uint) (int64, error),
 	requireAffected bool,
 ) error {
 	summary := summarizeManualStockItems(items)
@@ -82,7 +108,7 @@ func applyManualStockByItems(
 			if sku == nil || sku.ManualStockTotal == constants.ManualStockUnlimited {
 				continue
 			}
-			affected, err := updateSKU(skuID, quantity)
+			affected, err := updateSKU(skuID, quantity, orderID)
 			if err != nil {
 				return err
 			}
@@ -107,7 +133,7 @@ func applyManualStockByItems(
 		if product == nil || product.ManualStockTotal == constants.ManualStockUnlimited {
 			continue
 		}
-		affected, err := updateProduct(productID, quantity)
+		affected, err := updateProduct(productID, quantity, orderID)
 		if err != nil {
 			return err
 		}
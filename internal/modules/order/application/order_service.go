@@ -1,6 +1,8 @@
 package application
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"strings"
 	"time"
@@ -14,6 +16,7 @@ import (
 	ordercontract "github.com/dujiao-next/internal/modules/order/contract"
 	orderdomain "github.com/dujiao-next/internal/modules/order/domain"
 	orderriskcontract "github.com/dujiao-next/internal/modules/orderrisk/contract"
+	preorderdomain "github.com/dujiao-next/internal/modules/preorder/domain"
 	promotioncontract "github.com/dujiao-next/internal/modules/promotion/contract"
 	resellercontract "github.com/dujiao-next/internal/modules/reseller/contract"
 	settingsapp "github.com/dujiao-next/internal/modules/settings/application"
@@ -50,6 +53,8 @@ type OrderService struct {
 	resellerAccounting      resellerAccountingTransactions
 	riskControlSvc          orderriskcontract.Controller
 	productMappingService   upstreamStockEnsurer
+	currencyQuoter          OrderCurrencyQuoter
+	preorderGate            PreorderGate
 	expireMinutes           int
 }
 
@@ -100,6 +105,7 @@ type OrderServiceOptions struct {
 	ResellerAccounting      resellerAccountingTransactions
 	RiskControlService      orderriskcontract.Controller
 	ProductMappingService   upstreamStockEnsurer
+	CurrencyQuoter          OrderCurrencyQuoter
 	ExpireMinutes           int
 }
 
@@ -132,6 +138,7 @@ func NewOrderService(opts OrderServiceOptions) *OrderService {
 		resellerAccounting:      opts.ResellerAccounting,
 		riskControlSvc:          opts.RiskControlService,
 		productMappingService:   opts.ProductMappingService,
+		currencyQuoter:          opts.CurrencyQuoter,
 		expireMinutes:           opts.ExpireMinutes,
 	}
 }
@@ -141,13 +148,16 @@ type CreateOrderInput struct {
 	UserID              uint
 	Tenant              resellercontract.TenantContext
 	Items               []CreateOrderItem
+	Currency            string // 下单币种，为空使用站点基准币种
 	CouponCode          string
 	AffiliateCode       string
 	AffiliateVisitorKey string
 	ClientIP            string
 	ManualFormData      map[string]jsonmap.JSON
-	SkipRiskControl     bool // 完全跳过风控（下游订单）
-	SkipIPRiskControl   bool // 跳过 IP 维度风控（渠道/Bot 订单）
+	DeviceID            string // 客户端设备标识，仅以摘要参与风险评分
+	RiskChallengePassed bool   // 本次请求已通过风险评分验证码
+	SkipRiskControl     bool   // 完全跳过风控（下游订单）
+	SkipIPRiskControl   bool   // 跳过 IP 维度风控（渠道/Bot 订单）
 }
 
 // CreateGuestOrderInput 游客创建订单输入
@@ -157,11 +167,14 @@ type CreateGuestOrderInput struct {
 	Locale              string
 	Tenant              resellercontract.TenantContext
 	Items               []CreateOrderItem
+	Currency            string // 下单币种，为空使用站点基准币种
 	CouponCode          string
 	AffiliateCode       string
 	AffiliateVisitorKey string
 	ClientIP            string
 	ManualFormData      map[string]jsonmap.JSON
+	DeviceID            string
+	RiskChallengePassed bool
 }
 
 // CreateOrderItem 创建订单项输入
@@ -183,6 +196,9 @@ type childOrderPlan struct {
 	WholesaleDiscount decimal.Decimal
 	CouponDiscount    decimal.Decimal
 	Currency          string
+	// Preorder 非空表示该规格缺货转为预售，PreorderBalance 为定金预售待补的尾款
+	Preorder        *preorderdomain.Policy
+	PreorderBalance decimal.Decimal
 }
 
 var allowedTransitions = map[string]map[string]bool{
@@ -191,12 +207,19 @@ var allowedTransitions = map[string]map[string]bool{
 		constants.OrderStatusCanceled: true,
 	},
 	constants.OrderStatusPaid: {
+		constants.OrderStatusHeldForReview:      true,
 		constants.OrderStatusFulfilling:         true,
 		constants.OrderStatusPartiallyDelivered: true,
 		constants.OrderStatusDelivered:          true,
 		constants.OrderStatusPartiallyRefunded:  true,
 		constants.OrderStatusRefunded:           true,
 	},
+	constants.OrderStatusHeldForReview: {
+		constants.OrderStatusPaid:              true,
+		constants.OrderStatusFulfilling:        true,
+		constants.OrderStatusPartiallyRefunded: true,
+		constants.OrderStatusRefunded:          true,
+	},
 	constants.OrderStatusFulfilling: {
 		constants.OrderStatusPartiallyDelivered: true,
 		constants.OrderStatusDelivered:          true,
@@ -232,11 +255,14 @@ func (s *OrderService) CreateOrder(input CreateOrderInput) (*orderdomain.Order,
 		UserID:              input.UserID,
 		Tenant:              input.Tenant,
 		Items:               input.Items,
+		Currency:            input.Currency,
 		CouponCode:          input.CouponCode,
 		AffiliateCode:       input.AffiliateCode,
 		AffiliateVisitorKey: input.AffiliateVisitorKey,
 		ClientIP:            input.ClientIP,
 		ManualFormData:      input.ManualFormData,
+		DeviceHash:          hashDeviceID(input.DeviceID),
+		RiskChallengePassed: input.RiskChallengePassed,
 		SkipRiskControl:     input.SkipRiskControl,
 		SkipIPRiskControl:   input.SkipIPRiskControl,
 	})
@@ -260,12 +286,15 @@ func (s *OrderService) CreateGuestOrder(input CreateGuestOrderInput) (*orderdoma
 		GuestLocale:         locale,
 		Tenant:              input.Tenant,
 		Items:               input.Items,
+		Currency:            input.Currency,
 		CouponCode:          input.CouponCode,
 		AffiliateCode:       input.AffiliateCode,
 		AffiliateVisitorKey: input.AffiliateVisitorKey,
 		ClientIP:            input.ClientIP,
 		IsGuest:             true,
 		ManualFormData:      input.ManualFormData,
+		DeviceHash:          hashDeviceID(input.DeviceID),
+		RiskChallengePassed: input.RiskChallengePassed,
 	})
 }
 
@@ -276,6 +305,7 @@ type orderCreateParams struct {
 	GuestLocale              string
 	Tenant                   resellercontract.TenantContext
 	Items                    []CreateOrderItem
+	Currency                 string
 	CouponCode               string
 	AffiliateCode            string
 	AffiliateVisitorKey      string
@@ -283,6 +313,9 @@ type orderCreateParams struct {
 	RiskIP                   string
 	RiskPaymentExpireMinutes int
 	RiskCheckResult          orderriskcontract.CheckResult
+	RiskAssessment           orderriskcontract.Assessment
+	RiskChallengePassed      bool
+	DeviceHash               string
 	IsGuest                  bool
 	ManualFormData           map[string]jsonmap.JSON
 	SkipManualFormCheck      bool
@@ -293,6 +326,8 @@ type orderCreateParams struct {
 // OrderPreview 订单金额预览
 type OrderPreview struct {
 	Currency                string             `json:"currency"`
+	BaseCurrency            string             `json:"base_currency"`
+	ExchangeRate            decimal.Decimal    `json:"exchange_rate"`
 	OriginalAmount          money.Amount       `json:"original_amount"`
 	MemberDiscountAmount    money.Amount       `json:"member_discount_amount"`
 	DiscountAmount          money.Amount       `json:"discount_amount"`
@@ -319,6 +354,9 @@ type OrderPreviewItem struct {
 	PromotionDiscount  money.Amount      `json:"promotion_discount_amount"`
 	WholesaleDiscount  money.Amount      `json:"wholesale_discount_amount"`
 	FulfillmentType    string            `json:"fulfillment_type"`
+	IsPreorder         bool              `json:"is_preorder"`
+	PreorderBalance    money.Amount      `json:"preorder_balance_amount"`
+	PreorderExpectedAt *time.Time        `json:"preorder_expected_at,omitempty"`
 }
 
 type orderBuildResult struct {
@@ -331,6 +369,8 @@ type orderBuildResult struct {
 	DiscountAmount          decimal.Decimal
 	TotalAmount             decimal.Decimal
 	Currency                string
+	BaseCurrency            string
+	ExchangeRate            decimal.Decimal
 	OrderPromotionID        *uint
 	MemberLevelID           *uint
 	AppliedCoupon           *coupondomain.Coupon
@@ -345,6 +385,7 @@ func (s *OrderService) PreviewOrder(input CreateOrderInput) (*OrderPreview, erro
 		UserID:              input.UserID,
 		Tenant:              input.Tenant,
 		Items:               input.Items,
+		Currency:            input.Currency,
 		CouponCode:          input.CouponCode,
 		AffiliateCode:       input.AffiliateCode,
 		AffiliateVisitorKey: input.AffiliateVisitorKey,
@@ -366,6 +407,7 @@ func (s *OrderService) PreviewGuestOrder(input CreateGuestOrderInput) (*OrderPre
 		GuestLocale:         input.Locale,
 		Tenant:              input.Tenant,
 		Items:               input.Items,
+		Currency:            input.Currency,
 		CouponCode:          input.CouponCode,
 		AffiliateCode:       input.AffiliateCode,
 		AffiliateVisitorKey: input.AffiliateVisitorKey,
@@ -392,9 +434,18 @@ func (s *OrderService) previewOrder(input orderCreateParams) (*OrderPreview, err
 	} else if isResellerOrderContext(input.Tenant) {
 		return nil, ErrResellerProductNotListed
 	}
+	if err := s.applyOrderCurrency(result, input.Currency, isResellerOrderContext(input.Tenant)); err != nil {
+		return nil, err
+	}
+	applyPreorderDeposits(result)
 	items := make([]OrderPreviewItem, 0, len(result.Plans))
 	for _, plan := range result.Plans {
 		item := plan.Item
+		var preorderExpectedAt *time.Time
+		if plan.Preorder != nil {
+			expectedAt := plan.Preorder.ExpectedAt
+			preorderExpectedAt = &expectedAt
+		}
 		items = append(items, OrderPreviewItem{
 			ProductID:          item.ProductID,
 			SKUID:              item.SKUID,
@@ -411,10 +462,15 @@ func (s *OrderService) previewOrder(input orderCreateParams) (*OrderPreview, err
 			PromotionDiscount:  item.PromotionDiscount,
 			WholesaleDiscount:  item.WholesaleDiscount,
 			FulfillmentType:    item.FulfillmentType,
+			IsPreorder:         plan.Preorder != nil,
+			PreorderBalance:    money.FromDecimal(plan.PreorderBalance),
+			PreorderExpectedAt: preorderExpectedAt,
 		})
 	}
 	return &OrderPreview{
 		Currency:                result.Currency,
+		BaseCurrency:            result.BaseCurrency,
+		ExchangeRate:            result.ExchangeRate,
 		OriginalAmount:          money.FromDecimal(result.OriginalAmount),
 		MemberDiscountAmount:    money.FromDecimal(result.MemberDiscountAmount),
 		DiscountAmount:          money.FromDecimal(result.DiscountAmount),
@@ -433,6 +489,9 @@ func (s *OrderService) createOrder(input orderCreateParams) (*orderdomain.Order,
 	if err := s.checkOrderRisk(&input, true); err != nil {
 		return nil, err
 	}
+	if err := s.assessOrderRisk(&input); err != nil {
+		return nil, err
+	}
 
 	result, err := s.buildOrderResult(input)
 	if err != nil {
@@ -447,6 +506,10 @@ func (s *OrderService) createOrder(input orderCreateParams) (*orderdomain.Order,
 	} else if isResellerOrderContext(input.Tenant) {
 		return nil, ErrResellerProductNotListed
 	}
+	if err := s.applyOrderCurrency(result, input.Currency, pricingCtx != nil || isResellerOrderContext(input.Tenant)); err != nil {
+		return nil, err
+	}
+	applyPreorderDeposits(result)
 
 	// 仅允许钱包余额支付时，在创建订单（锁库存）前预校验余额是否充足
 	if s.settingService != nil && s.settingService.GetWalletOnlyPayment() {
@@ -457,11 +520,11 @@ func (s *OrderService) createOrder(input orderCreateParams) (*orderdomain.Order,
 		if s.walletService == nil {
 			return nil, walletcontract.ErrOnlyPaymentRequired
 		}
-		account, accErr := s.walletService.GetAccount(input.UserID)
+		balance, accErr := s.walletService.GetAvailableBalance(input.UserID, result.Currency)
 		if accErr != nil {
 			return nil, walletcontract.ErrOnlyPaymentRequired
 		}
-		if account.Balance.Decimal.LessThan(result.TotalAmount) {
+		if balance.Decimal.LessThan(result.TotalAmount) {
 			return nil, walletcontract.ErrInsufficientBalance
 		}
 	}
@@ -508,6 +571,8 @@ func (s *OrderService) createOrder(input orderCreateParams) (*orderdomain.Order,
 		GuestLocale:             input.GuestLocale,
 		Status:                  constants.OrderStatusPendingPayment,
 		Currency:                result.Currency,
+		BaseCurrency:            result.BaseCurrency,
+		ExchangeRate:            result.ExchangeRate,
 		OriginalAmount:          money.FromDecimal(result.OriginalAmount),
 		MemberDiscountAmount:    money.FromDecimal(result.MemberDiscountAmount),
 		DiscountAmount:          money.FromDecimal(result.DiscountAmount),
@@ -525,6 +590,10 @@ func (s *OrderService) createOrder(input orderCreateParams) (*orderdomain.Order,
 		ExpiresAt:               &expiresAt,
 		ClientIP:                strings.TrimSpace(input.ClientIP),
 		RiskIP:                  input.RiskIP,
+		DeviceHash:              input.DeviceHash,
+		RiskScore:               input.RiskAssessment.Score,
+		RiskDecision:            input.RiskAssessment.Decision,
+		RiskReasons:             input.RiskAssessment.Reasons,
 		CreatedAt:               now,
 		UpdatedAt:               now,
 	}
@@ -566,14 +635,16 @@ func (s *OrderService) createOrder(input orderCreateParams) (*orderdomain.Order,
 				GuestLocale:             order.GuestLocale,
 				Status:                  constants.OrderStatusPendingPayment,
 				Currency:                plan.Currency,
+				BaseCurrency:            order.BaseCurrency,
+				ExchangeRate:            order.ExchangeRate,
 				OriginalAmount:          money.FromDecimal(plan.TotalAmount),
 				MemberDiscountAmount:    money.FromDecimal(plan.MemberDiscount),
 				DiscountAmount:          money.FromDecimal(plan.CouponDiscount),
 				PromotionDiscountAmount: money.FromDecimal(plan.PromotionDiscount),
 				WholesaleDiscountAmount: money.FromDecimal(plan.WholesaleDiscount),
-				TotalAmount:             money.FromDecimal(normalizeOrderAmount(plan.TotalAmount.Sub(plan.CouponDiscount))),
+				TotalAmount:             money.FromDecimal(planPayableAmount(plan)),
 				WalletPaidAmount:        money.FromDecimal(decimal.Zero),
-				OnlinePaidAmount:        money.FromDecimal(normalizeOrderAmount(plan.TotalAmount.Sub(plan.CouponDiscount))),
+				OnlinePaidAmount:        money.FromDecimal(planPayableAmount(plan)),
 				RefundedAmount:          money.FromDecimal(decimal.Zero),
 				CouponID:                nil,
 				PromotionID:             plan.Item.PromotionID,
@@ -582,6 +653,11 @@ func (s *OrderService) createOrder(input orderCreateParams) (*orderdomain.Order,
 				ExpiresAt:               &expiresAt,
 				ClientIP:                order.ClientIP,
 				RiskIP:                  order.RiskIP,
+				DeviceHash:              order.DeviceHash,
+				RiskScore:               order.RiskScore,
+				RiskDecision:            order.RiskDecision,
+				RiskReasons:             order.RiskReasons,
+				IsPreorder:              plan.Preorder != nil,
 				CreatedAt:               now,
 				UpdatedAt:               now,
 			}
@@ -606,6 +682,13 @@ func (s *OrderService) createOrder(input orderCreateParams) (*orderdomain.Order,
 				}
 			}
 
+			if plan.Preorder != nil {
+				// 预售子订单不预占库存，到货后按排队先后分配
+				if err := reservePreorderSlot(tx.Preorders(), plan, order, childOrder, now); err != nil {
+					return err
+				}
+				continue
+			}
 			if strings.TrimSpace(plan.Item.FulfillmentType) == constants.FulfillmentTypeAuto {
 				secretRepo := tx.CardSecrets()
 				rows, err := secretRepo.ListAvailableByProductForUpdate(plan.Item.ProductID, plan.Item.SKUID, plan.Item.Quantity)
@@ -630,7 +713,7 @@ func (s *OrderService) createOrder(input orderCreateParams) (*orderdomain.Order,
 			if strings.TrimSpace(plan.Item.FulfillmentType) == constants.FulfillmentTypeManual &&
 				plan.SKU != nil &&
 				productdomain.ShouldEnforceManualSKUStock(plan.Product, plan.SKU) {
-				affected, err := productSKURepo.ReserveManualStock(plan.Item.SKUID, plan.Item.Quantity)
+				affected, err := productSKURepo.ReserveManualStock(plan.Item.SKUID, plan.Item.Quantity, childOrder.ID)
 				if err != nil {
 					return err
 				}
@@ -683,6 +766,9 @@ func (s *OrderService) createOrder(input orderCreateParams) (*orderdomain.Order,
 		if errors.Is(err, ErrManualStockInsufficient) {
 			return nil, ErrManualStockInsufficient
 		}
+		if errors.Is(err, ErrPreorderUnavailable) || errors.Is(err, ErrPreorderLimitReached) {
+			return nil, err
+		}
 		return nil, ErrOrderCreateFailed
 	}
 
@@ -743,6 +829,47 @@ func (s *OrderService) checkOrderRisk(input *orderCreateParams, consumeRateLimit
 	return nil
 }
 
+// assessOrderRisk 在创建订单前执行风险评分；预览不评分，避免未提交的订单消耗速率信号。
+func (s *OrderService) assessOrderRisk(input *orderCreateParams) error {
+	input.RiskAssessment = orderriskcontract.Assessment{}
+	if s.riskControlSvc == nil || input.SkipRiskControl {
+		return nil
+	}
+	// 渠道/Bot 订单无法展示验证码，challenge 处置对其视为已通过。
+	challengePassed := input.RiskChallengePassed || input.SkipIPRiskControl
+	assessment, err := s.riskControlSvc.Assess(orderriskcontract.AssessInput{
+		Stage:           orderriskcontract.StageOrder,
+		UserID:          input.UserID,
+		GuestEmail:      input.GuestEmail,
+		RiskIP:          input.RiskIP,
+		DeviceHash:      input.DeviceHash,
+		ChallengePassed: challengePassed,
+	})
+	if err != nil {
+		if errors.Is(err, orderriskcontract.ErrOrderBlocked) {
+			logger.Warnw("order_risk_blocked",
+				"user_id", input.UserID,
+				"risk_ip", input.RiskIP,
+				"score", assessment.Score,
+				"reasons", assessment.Reasons,
+			)
+		}
+		return err
+	}
+	input.RiskAssessment = assessment
+	return nil
+}
+
+// hashDeviceID 仅保存设备标识摘要，避免原始指纹落库。
+func hashDeviceID(deviceID string) string {
+	deviceID = strings.TrimSpace(deviceID)
+	if deviceID == "" || len(deviceID) > 256 {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(deviceID))
+	return hex.EncodeToString(sum[:])
+}
+
 func buildRiskCheckInput(input orderCreateParams, consumeRateLimit bool) orderriskcontract.CheckInput {
 	items := make([]orderriskcontract.OrderItem, 0, len(input.Items))
 	for _, item := range input.Items {
@@ -750,6 +877,7 @@ func buildRiskCheckInput(input orderCreateParams, consumeRateLimit bool) orderri
 	}
 	return orderriskcontract.CheckInput{
 		UserID:           input.UserID,
+		GuestEmail:       input.GuestEmail,
 		ClientIP:         input.ClientIP,
 		RiskIP:           input.RiskIP,
 		IsGuest:          input.IsGuest,
@@ -48,6 +48,13 @@ func (s *OrderService) cancelOrderWithChildren(order *orderdomain.Order, rollbac
 		}
 		if len(order.Children) > 0 {
 			for _, child := range order.Children {
+				if child.IsPreorder {
+					// 预售子订单未预占库存，仅释放预售名额
+					if _, err := tx.Preorders().CancelAwaitingPayment(child.ID, now); err != nil {
+						return err
+					}
+					continue
+				}
 				if err := releaseManualStockByItems(productRepo, productSKURepo, child.Items); err != nil {
 					return err
 				}
@@ -162,6 +169,10 @@ func (s *OrderService) UpdateOrderStatus(orderID uint, targetStatus string) (*or
 	if target == constants.OrderStatusPaid {
 		return nil, ErrOrderStatusInvalid
 	}
+	// 待复核订单只能经复核队列放行或驳回，避免绕过审核直接进入交付。
+	if target == constants.OrderStatusHeldForReview || order.Status == constants.OrderStatusHeldForReview {
+		return nil, ErrOrderStatusInvalid
+	}
 	isParent := order.ParentID == nil && len(order.Children) > 0
 	if isParent {
 		switch target {
@@ -372,7 +383,11 @@ func (s *OrderService) cancelSingleOrderInTx(tx ordercontract.Transaction, order
 	if _, err := secretRepo.ReleaseByOrder(order.ID); err != nil {
 		return err
 	}
-	if err := releaseManualStockByItems(productRepo, productSKURepo, order.Items); err != nil {
+	if order.IsPreorder {
+		if _, err := tx.Preorders().CancelAwaitingPayment(order.ID, time.Now()); err != nil {
+			return err
+		}
+	} else if err := releaseManualStockByItems(productRepo, productSKURepo, order.Items); err != nil {
 		return err
 	}
 	if s.walletService != nil {
@@ -7,7 +7,9 @@ import (
 	"strings"
 	"time"
 
+	ordercontract "github.com/dujiao-next/internal/modules/order/contract"
 	orderdomain "github.com/dujiao-next/internal/modules/order/domain"
+	preorderdomain "github.com/dujiao-next/internal/modules/preorder/domain"
 
 	"github.com/dujiao-next/internal/constants"
 	productdomain "github.com/dujiao-next/internal/modules/catalog/product/domain"
@@ -190,10 +192,16 @@ func (s *OrderService) buildOrderResult(input orderCreateParams) (*orderBuildRes
 		if fulfillmentType == "" {
 			fulfillmentType = constants.FulfillmentTypeManual
 		}
-		if fulfillmentType != constants.FulfillmentTypeManual && fulfillmentType != constants.FulfillmentTypeAuto && fulfillmentType != constants.FulfillmentTypeUpstream {
+		if fulfillmentType != constants.FulfillmentTypeManual && fulfillmentType != constants.FulfillmentTypeAuto &&
+			fulfillmentType != constants.FulfillmentTypeUpstream && fulfillmentType != constants.FulfillmentTypeWebhook &&
+			fulfillmentType != constants.FulfillmentTypeFile && fulfillmentType != constants.FulfillmentTypeLicense {
 			return nil, ErrFulfillmentInvalid
 		}
-		if fulfillmentType == constants.FulfillmentTypeManual &&
+		preorder, err := s.resolvePreorderPolicy(input, product, sku, fulfillmentType, item.Quantity)
+		if err != nil {
+			return nil, err
+		}
+		if preorder == nil && fulfillmentType == constants.FulfillmentTypeManual &&
 			productdomain.ShouldEnforceManualSKUStock(product, sku) &&
 			productdomain.ManualSKUAvailable(sku) < item.Quantity {
 			return nil, ErrManualStockInsufficient
@@ -270,6 +278,7 @@ func (s *OrderService) buildOrderResult(input orderCreateParams) (*orderBuildRes
 			PromotionDiscount: promotionDiscount,
 			WholesaleDiscount: wholesaleDiscount,
 			Currency:          productCurrency,
+			Preorder:          preorder,
 		})
 	}
 	if currency == "" {
@@ -525,3 +534,94 @@ func FillOrdersItemsFromChildren(orders []orderdomain.Order) {
 		FillOrderItemsFromChildren(&orders[i])
 	}
 }
+
+// PreorderGate 判断缺货规格是否接受预售；返回 nil 表示按普通缺货处理。
+type PreorderGate interface {
+	ResolvePreorder(product *productdomain.Product, sku *productdomain.ProductSKU, quantity int) (*preorderdomain.Policy, error)
+}
+
+// SetPreorderGate 注入预售判定（容器装配时调用）。
+func (s *OrderService) SetPreorderGate(gate PreorderGate) {
+	if s == nil {
+		return
+	}
+	s.preorderGate = gate
+}
+
+// resolvePreorderPolicy 仅主站的自动/人工交付规格可转为预售；定金预售需登录以便用余额补尾款。
+func (s *OrderService) resolvePreorderPolicy(input orderCreateParams, product *productdomain.Product, sku *productdomain.ProductSKU, fulfillmentType string, quantity int) (*preorderdomain.Policy, error) {
+	if s.preorderGate == nil || isResellerOrderContext(input.Tenant) || sku == nil {
+		return nil, nil
+	}
+	if fulfillmentType != constants.FulfillmentTypeAuto && fulfillmentType != constants.FulfillmentTypeManual {
+		return nil, nil
+	}
+	policy, err := s.preorderGate.ResolvePreorder(product, sku, quantity)
+	if err != nil || policy == nil {
+		return nil, err
+	}
+	if policy.IsDeposit() && input.IsGuest {
+		return nil, ErrPreorderLoginRequired
+	}
+	return policy, nil
+}
+
+// applyPreorderDeposits 定金预售的子订单先只收定金，尾款在库存到位后另行补齐。
+// 在币种换算之后调用，使定金与尾款均以下单币种计。
+func applyPreorderDeposits(result *orderBuildResult) {
+	if result == nil {
+		return
+	}
+	for i := range result.Plans {
+		plan := &result.Plans[i]
+		if plan.Preorder == nil {
+			continue
+		}
+		_, balance := plan.Preorder.SplitDeposit(normalizeOrderAmount(plan.TotalAmount.Sub(plan.CouponDiscount)))
+		plan.PreorderBalance = balance
+		result.TotalAmount = normalizeOrderAmount(result.TotalAmount.Sub(balance))
+	}
+}
+
+// planPayableAmount 子订单本次应付金额（定金预售扣除尾款）。
+func planPayableAmount(plan childOrderPlan) decimal.Decimal {
+	return normalizeOrderAmount(plan.TotalAmount.Sub(plan.CouponDiscount).Sub(plan.PreorderBalance))
+}
+
+// reservePreorderSlot 在下单事务内锁定预售设置并校验名额，代替库存预占写入排队记录。
+func reservePreorderSlot(store ordercontract.PreorderStore, plan childOrderPlan, parent, child *orderdomain.Order, now time.Time) error {
+	policy, err := store.GetPolicyForUpdate(plan.Item.SKUID)
+	if err != nil {
+		return err
+	}
+	if policy == nil || !policy.IsOpen(now) || policy.PaymentMode != plan.Preorder.PaymentMode {
+		return ErrPreorderUnavailable
+	}
+	if policy.MaxOutstanding > 0 {
+		outstanding, err := store.CountOutstanding(plan.Item.SKUID)
+		if err != nil {
+			return err
+		}
+		if outstanding+int64(plan.Item.Quantity) > int64(policy.MaxOutstanding) {
+			return ErrPreorderLimitReached
+		}
+	}
+	return store.CreateBackorder(&preorderdomain.Backorder{
+		OrderID:         child.ID,
+		ParentOrderID:   parent.ID,
+		OrderNo:         child.OrderNo,
+		UserID:          child.UserID,
+		ProductID:       plan.Item.ProductID,
+		SKUID:           plan.Item.SKUID,
+		Quantity:        plan.Item.Quantity,
+		FulfillmentType: strings.TrimSpace(plan.Item.FulfillmentType),
+		PaymentMode:     policy.PaymentMode,
+		DepositAmount:   child.TotalAmount,
+		BalanceAmount:   money.FromDecimal(plan.PreorderBalance),
+		Currency:        child.Currency,
+		Status:          preorderdomain.StatusAwaitingPayment,
+		ExpectedAt:      policy.ExpectedAt,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	})
+}
@@ -50,6 +50,7 @@ func CalcParentStatus(children []orderdomain.Order, currentStatus string) string
 	var paidCount int
 	var pendingCount int
 	var fulfillingCount int
+	var heldCount int
 	for _, child := range children {
 		switch strings.ToLower(strings.TrimSpace(child.Status)) {
 		case constants.OrderStatusCanceled:
@@ -66,6 +67,8 @@ func CalcParentStatus(children []orderdomain.Order, currentStatus string) string
 			paidCount++
 		case constants.OrderStatusFulfilling:
 			fulfillingCount++
+		case constants.OrderStatusHeldForReview:
+			heldCount++
 		case constants.OrderStatusPendingPayment:
 			pendingCount++
 		}
@@ -88,6 +91,9 @@ func CalcParentStatus(children []orderdomain.Order, currentStatus string) string
 	if deliveredCount+completedCount > 0 {
 		return constants.OrderStatusPartiallyDelivered
 	}
+	if heldCount > 0 {
+		return constants.OrderStatusHeldForReview
+	}
 	if fulfillingCount > 0 {
 		return constants.OrderStatusFulfilling
 	}
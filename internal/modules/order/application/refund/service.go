@@ -18,6 +18,7 @@ import (
 	walletcontract "github.com/dujiao-next/internal/modules/wallet/contract"
 
 	"github.com/dujiao-next/internal/constants"
+	"github.com/dujiao-next/internal/logger"
 	"github.com/dujiao-next/internal/shared/money"
 
 	"github.com/shopspring/decimal"
@@ -28,6 +29,8 @@ type AdminManualRefundInput struct {
 	OrderID uint
 	Amount  money.Amount
 	Remark  string
+	// IgnoreRefundWindow 系统发起的退款（如上游退款联动）不受售后退款期限限制
+	IgnoreRefundWindow bool
 }
 
 // AdminOrderRefundListQuery 管理端退款记录列表查询条件（原始输入）。
@@ -63,6 +66,19 @@ type Service struct {
 	settingService     *settingsapp.Service
 	resellerAccounting resellerAccountingTransactions
 	wallets            *walletapp.Service
+	fileGrants         deliveryRevoker
+	licenses           deliveryRevoker
+	memberships        membershipRevoker
+}
+
+// deliveryRevoker 在订单全额退款后吊销已交付的下载授权或授权码。
+type deliveryRevoker interface {
+	RevokeForOrder(orderID uint) error
+}
+
+// membershipRevoker 在订单全额退款后回收购买授予的付费会员等级。
+type membershipRevoker interface {
+	RevokePurchasedForOrder(orderID uint) error
 }
 
 type affiliateRefundProcessor interface {
@@ -135,6 +151,43 @@ func (s *Service) SetResellerAccounting(accounting resellerAccountingTransaction
 	s.resellerAccounting = accounting
 }
 
+// SetFileGrantRevoker 设置文件下载授权吊销器（解决循环依赖）
+func (s *Service) SetFileGrantRevoker(revoker deliveryRevoker) {
+	s.fileGrants = revoker
+}
+
+// SetLicenseRevoker 设置授权码吊销器（解决循环依赖）
+func (s *Service) SetLicenseRevoker(revoker deliveryRevoker) {
+	s.licenses = revoker
+}
+
+// SetMembershipRevoker 设置付费会员等级回收器（解决循环依赖）
+func (s *Service) SetMembershipRevoker(revoker membershipRevoker) {
+	s.memberships = revoker
+}
+
+// revokeDeliveriesIfRefunded 全额退款后吊销交付内容并回收付费等级；失败仅记录日志，下载与授权码校验时仍会按订单状态兜底拦截。
+func (s *Service) revokeDeliveriesIfRefunded(order *orderdomain.Order) {
+	if order == nil || order.Status != constants.OrderStatusRefunded {
+		return
+	}
+	if s.fileGrants != nil {
+		if err := s.fileGrants.RevokeForOrder(order.ID); err != nil {
+			logger.Warnw("order_refund_revoke_file_grants_failed", "order_id", order.ID, "error", err)
+		}
+	}
+	if s.licenses != nil {
+		if err := s.licenses.RevokeForOrder(order.ID); err != nil {
+			logger.Warnw("order_refund_revoke_licenses_failed", "order_id", order.ID, "error", err)
+		}
+	}
+	if s.memberships != nil {
+		if err := s.memberships.RevokePurchasedForOrder(order.ID); err != nil {
+			logger.Warnw("order_refund_revoke_membership_failed", "order_id", order.ID, "error", err)
+		}
+	}
+}
+
 // ParseRefundAmount 解析并校验退款金额。
 func (s *Service) ParseRefundAmount(raw string) (money.Amount, error) {
 	parsed, err := decimal.NewFromString(strings.TrimSpace(raw))
@@ -279,7 +332,7 @@ func (s *Service) AdminManualRefund(input AdminManualRefundInput) (*orderdomain.
 		if order.PaidAt == nil {
 			return ErrOrderStatusInvalid
 		}
-		if settingsapp.IsOrderRefundWindowExpired(order.CreatedAt, order.PaidAt, cfg.MaxRefundDays, time.Now()) {
+		if !input.IgnoreRefundWindow && settingsapp.IsOrderRefundWindowExpired(order.CreatedAt, order.PaidAt, cfg.MaxRefundDays, time.Now()) {
 			return ErrOrderRefundExpired
 		}
 		if order.TotalAmount.Decimal.LessThanOrEqual(decimal.Zero) {
@@ -353,6 +406,7 @@ func (s *Service) AdminManualRefund(input AdminManualRefundInput) (*orderdomain.
 	if order == nil {
 		return nil, nil, ErrOrderNotFound
 	}
+	s.revokeDeliveriesIfRefunded(order)
 	return order, createdRecord, nil
 }
 
@@ -25,6 +25,8 @@ type AdminRefundToWalletInput struct {
 	OrderID uint
 	Amount  money.Amount
 	Remark  string
+	// IgnoreRefundWindow 系统发起的退款（如预售逾期）不受售后退款期限限制
+	IgnoreRefundWindow bool
 }
 
 // AdminRefundToWallet executes the order refund workflow and delegates only
@@ -81,7 +83,7 @@ func (s *Service) AdminRefundToWallet(
 			return ErrOrderStatusInvalid
 		}
 		now := time.Now()
-		if settingsapp.IsOrderRefundWindowExpired(order.CreatedAt, order.PaidAt, config.MaxRefundDays, now) {
+		if !input.IgnoreRefundWindow && settingsapp.IsOrderRefundWindowExpired(order.CreatedAt, order.PaidAt, config.MaxRefundDays, now) {
 			return ErrOrderRefundExpired
 		}
 		if order.TotalAmount.Decimal.LessThanOrEqual(decimal.Zero) {
@@ -184,5 +186,6 @@ func (s *Service) AdminRefundToWallet(
 	if order == nil {
 		return nil, nil, nil, ErrOrderNotFound
 	}
+	s.revokeDeliveriesIfRefunded(order)
 	return order, transactionResult, refundRecordResult, nil
 }
@@ -9,6 +9,7 @@ import (
 	couponcontract "github.com/dujiao-next/internal/modules/coupon/contract"
 	fulfillmentcontract "github.com/dujiao-next/internal/modules/fulfillment/contract"
 	orderdomain "github.com/dujiao-next/internal/modules/order/domain"
+	preorderdomain "github.com/dujiao-next/internal/modules/preorder/domain"
 	resellercontract "github.com/dujiao-next/internal/modules/reseller/contract"
 	resellerdomain "github.com/dujiao-next/internal/modules/reseller/domain"
 	walletcontract "github.com/dujiao-next/internal/modules/wallet/contract"
@@ -67,6 +68,18 @@ type ResellerOrderStore interface {
 	CreateOrderSnapshot(snapshot *resellerdomain.OrderSnapshot) error
 }
 
+// PreorderStore 是下单、支付与取消时维护预售排队记录的最小端口。
+type PreorderStore interface {
+	GetPolicyForUpdate(skuID uint) (*preorderdomain.Policy, error)
+	// CountOutstanding 统计规格占用预售名额的件数
+	CountOutstanding(skuID uint) (int64, error)
+	CreateBackorder(backorder *preorderdomain.Backorder) error
+	// MarkQueued 将待支付记录置为排队，返回实际变更的记录数
+	MarkQueued(orderID uint, at time.Time) (int64, error)
+	// CancelAwaitingPayment 取消尚未支付的记录，释放预售名额
+	CancelAwaitingPayment(orderID uint, at time.Time) (int64, error)
+}
+
 // Transaction 是已打开事务的订单工作单元。
 // 每个方法只暴露相应领域端口，保证事务原子性同时隔离 GORM。
 type Transaction interface {
@@ -80,6 +93,7 @@ type Transaction interface {
 	Wallets() walletcontract.Transaction
 	Affiliates() affiliatecontract.Store
 	ResellerOrders() ResellerOrderStore
+	Preorders() PreorderStore
 	ResellerAccounting() resellercontract.AccountingLedgerStore
 	ExpirePendingPaymentsByOrderIDs(orderIDs []uint, expiredAt time.Time) (int64, error)
 }
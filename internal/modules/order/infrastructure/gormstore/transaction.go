@@ -1,6 +1,7 @@
 package gormstore
 
 import (
+	"errors"
 	"strings"
 	"time"
 
@@ -17,6 +18,7 @@ import (
 	fulfillmentcontract "github.com/dujiao-next/internal/modules/fulfillment/contract"
 	fulfillmentgormstore "github.com/dujiao-next/internal/modules/fulfillment/infrastructure/gormstore"
 	ordercontract "github.com/dujiao-next/internal/modules/order/contract"
+	preorderdomain "github.com/dujiao-next/internal/modules/preorder/domain"
 	resellercontract "github.com/dujiao-next/internal/modules/reseller/contract"
 	resellergormstore "github.com/dujiao-next/internal/modules/reseller/infrastructure/gormstore"
 	walletcontract "github.com/dujiao-next/internal/modules/wallet/contract"
@@ -25,6 +27,7 @@ import (
 	"github.com/dujiao-next/internal/constants"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type transaction struct {
@@ -94,6 +97,10 @@ func (tx transaction) ResellerOrders() ordercontract.ResellerOrderStore {
 	return resellergormstore.New(tx.db)
 }
 
+func (tx transaction) Preorders() ordercontract.PreorderStore {
+	return preorderStore{db: tx.db}
+}
+
 func (tx transaction) ResellerAccounting() resellercontract.AccountingLedgerStore {
 	return resellergormstore.New(tx.db)
 }
@@ -120,3 +127,61 @@ func (s *Store) WithinTransaction(fn func(ordercontract.Transaction) error) erro
 		return fn(useTransaction(tx, s.guestCredentialSecret))
 	})
 }
+
+// preorderStore 在订单事务内维护预售排队记录，预售模块的完整存储见 preorder gormstore。
+type preorderStore struct {
+	db *gorm.DB
+}
+
+var _ ordercontract.PreorderStore = preorderStore{}
+
+func (s preorderStore) GetPolicyForUpdate(skuID uint) (*preorderdomain.Policy, error) {
+	if skuID == 0 {
+		return nil, nil
+	}
+	var policy preorderdomain.Policy
+	if err := s.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("sku_id = ?", skuID).
+		First(&policy).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &policy, nil
+}
+
+func (s preorderStore) CountOutstanding(skuID uint) (int64, error) {
+	var total int64
+	err := s.db.Model(&preorderdomain.Backorder{}).
+		Where("sku_id = ? AND status IN ?", skuID, preorderdomain.OutstandingStatuses).
+		Select("COALESCE(SUM(quantity), 0)").
+		Scan(&total).Error
+	return total, err
+}
+
+func (s preorderStore) CreateBackorder(backorder *preorderdomain.Backorder) error {
+	return s.db.Create(backorder).Error
+}
+
+func (s preorderStore) MarkQueued(orderID uint, at time.Time) (int64, error) {
+	result := s.db.Model(&preorderdomain.Backorder{}).
+		Where("order_id = ? AND status = ?", orderID, preorderdomain.StatusAwaitingPayment).
+		Updates(map[string]interface{}{
+			"status":     preorderdomain.StatusQueued,
+			"queued_at":  at,
+			"updated_at": at,
+		})
+	return result.RowsAffected, result.Error
+}
+
+func (s preorderStore) CancelAwaitingPayment(orderID uint, at time.Time) (int64, error) {
+	result := s.db.Model(&preorderdomain.Backorder{}).
+		Where("order_id = ? AND status = ?", orderID, preorderdomain.StatusAwaitingPayment).
+		Updates(map[string]interface{}{
+			"status":     preorderdomain.StatusCanceled,
+			"closed_at":  at,
+			"updated_at": at,
+		})
+	return result.RowsAffected, result.Error
+}
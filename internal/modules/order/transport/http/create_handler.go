@@ -29,9 +29,10 @@ type OrderCreateService interface {
 	CreateGuestOrder(input CreateGuestOrderInput) (*orderdomain.Order, error)
 }
 
-// GuestCreateCaptcha 游客下单验证码端口。
-type GuestCreateCaptcha interface {
+// OrderCreateCaptcha 下单验证码端口：游客下单场景与风险评分挑战场景。
+type OrderCreateCaptcha interface {
 	VerifyGuestCreateOrder(payload captchahttp.CaptchaPayloadRequest, clientIP string) error
+	VerifyOrderRiskChallenge(payload captchahttp.CaptchaPayloadRequest, clientIP string) error
 }
 
 // CreatePaymentInput 创建支付输入。
@@ -59,13 +60,15 @@ type OrderPaymentCreator interface {
 
 // CreateOrderAndPayRequest 创建订单并发起支付请求
 type CreateOrderAndPayRequest struct {
-	Items               []OrderItemRequest      `json:"items" binding:"required"`
-	CouponCode          string                  `json:"coupon_code"`
-	AffiliateCode       string                  `json:"affiliate_code"`
-	AffiliateVisitorKey string                  `json:"affiliate_visitor_key"`
-	ManualFormData      map[string]jsonmap.JSON `json:"manual_form_data"`
-	ChannelID           uint                    `json:"channel_id"`
-	UseBalance          bool                    `json:"use_balance"`
+	Items               []OrderItemRequest                `json:"items" binding:"required"`
+	Currency            string                            `json:"currency"`
+	CouponCode          string                            `json:"coupon_code"`
+	AffiliateCode       string                            `json:"affiliate_code"`
+	AffiliateVisitorKey string                            `json:"affiliate_visitor_key"`
+	ManualFormData      map[string]jsonmap.JSON           `json:"manual_form_data"`
+	ChannelID           uint                              `json:"channel_id"`
+	UseBalance          bool                              `json:"use_balance"`
+	RiskCaptchaPayload  captchahttp.CaptchaPayloadRequest `json:"risk_captcha_payload"`
 }
 
 // CreateGuestOrderAndPayRequest 游客创建订单并发起支付请求
@@ -73,11 +76,13 @@ type CreateGuestOrderAndPayRequest struct {
 	Email               string                            `json:"email" binding:"required"`
 	OrderPassword       string                            `json:"order_password" binding:"required"`
 	Items               []OrderItemRequest                `json:"items" binding:"required"`
+	Currency            string                            `json:"currency"`
 	CouponCode          string                            `json:"coupon_code"`
 	AffiliateCode       string                            `json:"affiliate_code"`
 	AffiliateVisitorKey string                            `json:"affiliate_visitor_key"`
 	ManualFormData      map[string]jsonmap.JSON           `json:"manual_form_data"`
 	CaptchaPayload      captchahttp.CaptchaPayloadRequest `json:"captcha_payload"`
+	RiskCaptchaPayload  captchahttp.CaptchaPayloadRequest `json:"risk_captcha_payload"`
 	ChannelID           uint                              `json:"channel_id"`
 }
 
@@ -85,11 +90,11 @@ type CreateGuestOrderAndPayRequest struct {
 type CreateHandler struct {
 	orders   OrderCreateService
 	payments PaymentChannelPolicy
-	captcha  GuestCreateCaptcha
+	captcha  OrderCreateCaptcha
 	pay      OrderPaymentCreator
 }
 
-func NewCreateHandler(orders OrderCreateService, payments PaymentChannelPolicy, captcha GuestCreateCaptcha, pay OrderPaymentCreator) *CreateHandler {
+func NewCreateHandler(orders OrderCreateService, payments PaymentChannelPolicy, captcha OrderCreateCaptcha, pay OrderPaymentCreator) *CreateHandler {
 	if orders == nil {
 		panic("order create handler: orders is nil")
 	}
@@ -108,16 +113,23 @@ func (h *CreateHandler) CreateOrder(c *gin.Context) {
 		ginutil.RespondBindError(c, err)
 		return
 	}
+	challengePassed, ok := h.verifyRiskChallenge(c, req.RiskCaptchaPayload)
+	if !ok {
+		return
+	}
 
 	order, err := h.orders.CreateOrder(CreateOrderInput{
 		UserID:              uid,
 		Tenant:              tenantFromRequest(c),
 		Items:               mapOrderItems(req.Items),
+		Currency:            req.Currency,
 		CouponCode:          req.CouponCode,
 		AffiliateCode:       req.AffiliateCode,
 		AffiliateVisitorKey: req.AffiliateVisitorKey,
 		ClientIP:            c.ClientIP(),
 		ManualFormData:      req.ManualFormData,
+		DeviceID:            deviceIDFromRequest(c),
+		RiskChallengePassed: challengePassed,
 	})
 	if err != nil {
 		respondUserOrderCreateError(c, err)
@@ -139,6 +151,10 @@ func (h *CreateHandler) CreateGuestOrder(c *gin.Context) {
 	if !h.verifyGuestCreateCaptcha(c, req.CaptchaPayload) {
 		return
 	}
+	challengePassed, ok := h.verifyRiskChallenge(c, req.RiskCaptchaPayload)
+	if !ok {
+		return
+	}
 
 	order, err := h.orders.CreateGuestOrder(CreateGuestOrderInput{
 		Email:               req.Email,
@@ -146,11 +162,14 @@ func (h *CreateHandler) CreateGuestOrder(c *gin.Context) {
 		Locale:              i18n.ResolveLocale(c),
 		Tenant:              tenantFromRequest(c),
 		Items:               mapOrderItems(req.Items),
+		Currency:            req.Currency,
 		CouponCode:          req.CouponCode,
 		AffiliateCode:       req.AffiliateCode,
 		AffiliateVisitorKey: req.AffiliateVisitorKey,
 		ClientIP:            c.ClientIP(),
 		ManualFormData:      req.ManualFormData,
+		DeviceID:            deviceIDFromRequest(c),
+		RiskChallengePassed: challengePassed,
 	})
 	if err != nil {
 		respondGuestOrderCreateError(c, err)
@@ -173,16 +192,23 @@ func (h *CreateHandler) CreateOrderAndPay(c *gin.Context) {
 		ginutil.RespondBindError(c, err)
 		return
 	}
+	challengePassed, ok := h.verifyRiskChallenge(c, req.RiskCaptchaPayload)
+	if !ok {
+		return
+	}
 
 	order, err := h.orders.CreateOrder(CreateOrderInput{
 		UserID:              uid,
 		Tenant:              tenantFromRequest(c),
 		Items:               mapOrderItems(req.Items),
+		Currency:            req.Currency,
 		CouponCode:          req.CouponCode,
 		AffiliateCode:       req.AffiliateCode,
 		AffiliateVisitorKey: req.AffiliateVisitorKey,
 		ClientIP:            c.ClientIP(),
 		ManualFormData:      req.ManualFormData,
+		DeviceID:            deviceIDFromRequest(c),
+		RiskChallengePassed: challengePassed,
 	})
 	if err != nil {
 		respondUserOrderCreateError(c, err)
@@ -213,6 +239,10 @@ func (h *CreateHandler) CreateGuestOrderAndPay(c *gin.Context) {
 	if !h.verifyGuestCreateCaptcha(c, req.CaptchaPayload) {
 		return
 	}
+	challengePassed, ok := h.verifyRiskChallenge(c, req.RiskCaptchaPayload)
+	if !ok {
+		return
+	}
 
 	order, err := h.orders.CreateGuestOrder(CreateGuestOrderInput{
 		Email:               req.Email,
@@ -220,11 +250,14 @@ func (h *CreateHandler) CreateGuestOrderAndPay(c *gin.Context) {
 		Locale:              i18n.ResolveLocale(c),
 		Tenant:              tenantFromRequest(c),
 		Items:               mapOrderItems(req.Items),
+		Currency:            req.Currency,
 		CouponCode:          req.CouponCode,
 		AffiliateCode:       req.AffiliateCode,
 		AffiliateVisitorKey: req.AffiliateVisitorKey,
 		ClientIP:            c.ClientIP(),
 		ManualFormData:      req.ManualFormData,
+		DeviceID:            deviceIDFromRequest(c),
+		RiskChallengePassed: challengePassed,
 	})
 	if err != nil {
 		respondGuestOrderCreateError(c, err)
@@ -249,21 +282,47 @@ func (h *CreateHandler) verifyGuestCreateCaptcha(c *gin.Context, payload captcha
 		return true
 	}
 	if captchaErr := h.captcha.VerifyGuestCreateOrder(payload, c.ClientIP()); captchaErr != nil {
-		switch {
-		case errors.Is(captchaErr, captcha.ErrRequired):
-			ginutil.RespondError(c, response.CodeBadRequest, "error.captcha_required", nil)
-		case errors.Is(captchaErr, captcha.ErrInvalid):
-			ginutil.RespondError(c, response.CodeBadRequest, "error.captcha_invalid", nil)
-		case errors.Is(captchaErr, captcha.ErrConfigInvalid):
-			ginutil.RespondError(c, response.CodeInternal, "error.captcha_config_invalid", captchaErr)
-		default:
-			ginutil.RespondError(c, response.CodeInternal, "error.captcha_verify_failed", captchaErr)
-		}
+		respondCaptchaError(c, captchaErr)
 		return false
 	}
 	return true
 }
 
+// verifyRiskChallenge 校验风险评分挑战验证码。未提交验证码不是错误：
+// 返回 passed=false，由风险评分决定是否要求挑战；验证码场景未启用时视为已通过。
+func (h *CreateHandler) verifyRiskChallenge(c *gin.Context, payload captchahttp.CaptchaPayloadRequest) (passed bool, ok bool) {
+	if h.captcha == nil {
+		return true, true
+	}
+	captchaErr := h.captcha.VerifyOrderRiskChallenge(payload, c.ClientIP())
+	if captchaErr == nil {
+		return true, true
+	}
+	if errors.Is(captchaErr, captcha.ErrRequired) {
+		return false, true
+	}
+	respondCaptchaError(c, captchaErr)
+	return false, false
+}
+
+func respondCaptchaError(c *gin.Context, captchaErr error) {
+	switch {
+	case errors.Is(captchaErr, captcha.ErrRequired):
+		ginutil.RespondError(c, response.CodeBadRequest, "error.captcha_required", nil)
+	case errors.Is(captchaErr, captcha.ErrInvalid):
+		ginutil.RespondError(c, response.CodeBadRequest, "error.captcha_invalid", nil)
+	case errors.Is(captchaErr, captcha.ErrConfigInvalid):
+		ginutil.RespondError(c, response.CodeInternal, "error.captcha_config_invalid", captchaErr)
+	default:
+		ginutil.RespondError(c, response.CodeInternal, "error.captcha_verify_failed", captchaErr)
+	}
+}
+
+// deviceIDFromRequest 读取前端生成的设备标识，仅用于风险评分的设备维度统计。
+func deviceIDFromRequest(c *gin.Context) string {
+	return strings.TrimSpace(c.GetHeader("X-Device-ID"))
+}
+
 func (h *CreateHandler) respondCreateAndPay(c *gin.Context, order *orderdomain.Order, orderResp orderpresenter.OrderDetail, channelID uint, useBalance bool) {
 	if h.pay == nil {
 		response.Success(c, gin.H{
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"time"
 
 	productcontract "github.com/dujiao-next/internal/modules/catalog/product/contract"
 
@@ -22,6 +23,7 @@ import (
 	"github.com/dujiao-next/internal/shared/money"
 
 	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
 )
 
 var (
@@ -33,7 +35,11 @@ var (
 	ErrProductPurchaseNotAllowed = errors.New("product purchase not allowed")
 	ErrGuestCouponNotAllowed     = errors.New("guest coupon not allowed")
 	ErrManualStockInsufficient   = errors.New("manual stock insufficient")
+	ErrPreorderUnavailable       = errors.New("preorder unavailable")
+	ErrPreorderLimitReached      = errors.New("preorder limit reached")
+	ErrPreorderLoginRequired     = errors.New("preorder login required")
 	ErrOrderCurrencyMismatch     = errors.New("order currency mismatch")
+	ErrOrderCurrencyUnsupported  = errors.New("order currency unsupported")
 	ErrProductNotAvailable       = errors.New("product not available")
 	ErrResellerCouponNotAllowed  = errors.New("reseller coupon not allowed")
 	ErrQueueUnavailable          = errors.New("queue unavailable")
@@ -43,6 +49,9 @@ var (
 	ErrRiskProductQuantityLimit  = errors.New("risk: product quantity limit")
 	ErrRiskPendingProductLimit   = errors.New("risk: pending product quantity limit")
 	ErrRiskOrderRateLimited      = errors.New("risk: order rate limited")
+	ErrRiskChallengeRequired     = errors.New("risk: challenge required")
+	ErrRiskOrderBlocked          = errors.New("risk: order blocked")
+	ErrRiskCustomerBlacklisted   = errors.New("risk: customer blacklisted")
 )
 
 type riskRateLimitedError struct {
@@ -85,11 +94,13 @@ type OrderItemRequest struct {
 
 // CreateOrderRequest 用户订单预览/创建请求体（preview 使用）。
 type CreateOrderRequest struct {
-	Items               []OrderItemRequest      `json:"items" binding:"required"`
-	CouponCode          string                  `json:"coupon_code"`
-	AffiliateCode       string                  `json:"affiliate_code"`
-	AffiliateVisitorKey string                  `json:"affiliate_visitor_key"`
-	ManualFormData      map[string]jsonmap.JSON `json:"manual_form_data"`
+	Items               []OrderItemRequest                `json:"items" binding:"required"`
+	Currency            string                            `json:"currency"`
+	CouponCode          string                            `json:"coupon_code"`
+	AffiliateCode       string                            `json:"affiliate_code"`
+	AffiliateVisitorKey string                            `json:"affiliate_visitor_key"`
+	ManualFormData      map[string]jsonmap.JSON           `json:"manual_form_data"`
+	RiskCaptchaPayload  captchahttp.CaptchaPayloadRequest `json:"risk_captcha_payload"`
 }
 
 // CreateGuestOrderRequest 游客订单预览请求体。
@@ -97,11 +108,13 @@ type CreateGuestOrderRequest struct {
 	Email               string                            `json:"email" binding:"required"`
 	OrderPassword       string                            `json:"order_password" binding:"required"`
 	Items               []OrderItemRequest                `json:"items" binding:"required"`
+	Currency            string                            `json:"currency"`
 	CouponCode          string                            `json:"coupon_code"`
 	AffiliateCode       string                            `json:"affiliate_code"`
 	AffiliateVisitorKey string                            `json:"affiliate_visitor_key"`
 	ManualFormData      map[string]jsonmap.JSON           `json:"manual_form_data"`
 	CaptchaPayload      captchahttp.CaptchaPayloadRequest `json:"captcha_payload"`
+	RiskCaptchaPayload  captchahttp.CaptchaPayloadRequest `json:"risk_captcha_payload"`
 }
 
 // CreateOrderItem 创建/预览订单项。
@@ -117,11 +130,14 @@ type CreateOrderInput struct {
 	UserID              uint
 	Tenant              resellermodule.TenantContext
 	Items               []CreateOrderItem
+	Currency            string
 	CouponCode          string
 	AffiliateCode       string
 	AffiliateVisitorKey string
 	ClientIP            string
 	ManualFormData      map[string]jsonmap.JSON
+	DeviceID            string
+	RiskChallengePassed bool
 }
 
 // CreateGuestOrderInput 游客订单预览输入。
@@ -131,16 +147,21 @@ type CreateGuestOrderInput struct {
 	Locale              string
 	Tenant              resellermodule.TenantContext
 	Items               []CreateOrderItem
+	Currency            string
 	CouponCode          string
 	AffiliateCode       string
 	AffiliateVisitorKey string
 	ClientIP            string
 	ManualFormData      map[string]jsonmap.JSON
+	DeviceID            string
+	RiskChallengePassed bool
 }
 
 // OrderPreview 订单金额预览。
 type OrderPreview struct {
 	Currency                string             `json:"currency"`
+	BaseCurrency            string             `json:"base_currency"`
+	ExchangeRate            decimal.Decimal    `json:"exchange_rate"`
 	OriginalAmount          money.Amount       `json:"original_amount"`
 	MemberDiscountAmount    money.Amount       `json:"member_discount_amount"`
 	DiscountAmount          money.Amount       `json:"discount_amount"`
@@ -167,6 +188,9 @@ type OrderPreviewItem struct {
 	PromotionDiscount  money.Amount      `json:"promotion_discount_amount"`
 	WholesaleDiscount  money.Amount      `json:"wholesale_discount_amount"`
 	FulfillmentType    string            `json:"fulfillment_type"`
+	IsPreorder         bool              `json:"is_preorder"`
+	PreorderBalance    money.Amount      `json:"preorder_balance_amount"`
+	PreorderExpectedAt *time.Time        `json:"preorder_expected_at,omitempty"`
 }
 
 // OrderPreviewService 订单金额预览端口。
@@ -204,6 +228,7 @@ func (h *PreviewHandler) PreviewOrder(c *gin.Context) {
 		UserID:              uid,
 		Tenant:              tenantFromRequest(c),
 		Items:               mapOrderItems(req.Items),
+		Currency:            req.Currency,
 		CouponCode:          req.CouponCode,
 		AffiliateCode:       req.AffiliateCode,
 		AffiliateVisitorKey: req.AffiliateVisitorKey,
@@ -231,6 +256,7 @@ func (h *PreviewHandler) PreviewGuestOrder(c *gin.Context) {
 		Locale:              i18n.ResolveLocale(c),
 		Tenant:              tenantFromRequest(c),
 		Items:               mapOrderItems(req.Items),
+		Currency:            req.Currency,
 		CouponCode:          req.CouponCode,
 		AffiliateCode:       req.AffiliateCode,
 		AffiliateVisitorKey: req.AffiliateVisitorKey,
@@ -324,6 +350,9 @@ var orderRiskControlErrorRules = []mappedError{
 	{target: ErrRiskProductQuantityLimit, code: response.CodeBadRequest, key: "error.risk_product_quantity_limit"},
 	{target: ErrRiskPendingProductLimit, code: response.CodeTooManyRequests, key: "error.risk_pending_product_quantity_limit"},
 	{target: ErrRiskOrderRateLimited, code: response.CodeTooManyRequests, key: "error.risk_order_rate_limited"},
+	{target: ErrRiskChallengeRequired, code: response.CodeBadRequest, key: "error.risk_challenge_required"},
+	{target: ErrRiskOrderBlocked, code: response.CodeForbidden, key: "error.risk_order_blocked"},
+	{target: ErrRiskCustomerBlacklisted, code: response.CodeForbidden, key: "error.customer_blacklisted"},
 }
 
 var guestOrderCreateExtraErrorRules = []mappedError{
@@ -339,8 +368,12 @@ var userOrderCommonErrorRules = []mappedError{
 	{target: productdomain.ErrMaxPurchaseExceeded, code: response.CodeBadRequest, key: "error.product_max_purchase_exceeded"},
 	{target: productdomain.ErrMinPurchaseNotMet, code: response.CodeBadRequest, key: "error.product_min_purchase_not_met"},
 	{target: ErrManualStockInsufficient, code: response.CodeBadRequest, key: "error.manual_stock_insufficient"},
+	{target: ErrPreorderUnavailable, code: response.CodeBadRequest, key: "error.preorder_unavailable"},
+	{target: ErrPreorderLimitReached, code: response.CodeBadRequest, key: "error.preorder_limit_reached"},
+	{target: ErrPreorderLoginRequired, code: response.CodeBadRequest, key: "error.preorder_login_required"},
 	{target: cardsecretapp.ErrInsufficient, code: response.CodeBadRequest, key: "error.card_secret_insufficient"},
 	{target: ErrOrderCurrencyMismatch, code: response.CodeBadRequest, key: "error.order_currency_mismatch"},
+	{target: ErrOrderCurrencyUnsupported, code: response.CodeBadRequest, key: "error.order_currency_unsupported"},
 	{target: productcontract.ErrProductPriceInvalid, code: response.CodeBadRequest, key: "error.product_price_invalid"},
 	{target: ErrProductNotAvailable, code: response.CodeBadRequest, key: "error.product_not_available"},
 	{target: productcontract.ErrResellerProductNotListed, code: response.CodeBadRequest, key: "error.reseller_product_not_listed"},
@@ -387,8 +420,12 @@ var guestOrderCommonErrorRules = []mappedError{
 	{target: productdomain.ErrPurchaseQuantityInvalid, code: response.CodeBadRequest, key: "error.order_item_invalid"},
 	{target: ErrInvalidOrderAmount, code: response.CodeBadRequest, key: "error.order_amount_invalid"},
 	{target: ErrManualStockInsufficient, code: response.CodeBadRequest, key: "error.manual_stock_insufficient"},
+	{target: ErrPreorderUnavailable, code: response.CodeBadRequest, key: "error.preorder_unavailable"},
+	{target: ErrPreorderLimitReached, code: response.CodeBadRequest, key: "error.preorder_limit_reached"},
+	{target: ErrPreorderLoginRequired, code: response.CodeBadRequest, key: "error.preorder_login_required"},
 	{target: cardsecretapp.ErrInsufficient, code: response.CodeBadRequest, key: "error.card_secret_insufficient"},
 	{target: ErrOrderCurrencyMismatch, code: response.CodeBadRequest, key: "error.order_currency_mismatch"},
+	{target: ErrOrderCurrencyUnsupported, code: response.CodeBadRequest, key: "error.order_currency_unsupported"},
 	{target: productcontract.ErrProductPriceInvalid, code: response.CodeBadRequest, key: "error.product_price_invalid"},
 	{target: ErrProductNotAvailable, code: response.CodeBadRequest, key: "error.product_not_available"},
 	{target: productcontract.ErrResellerProductNotListed, code: response.CodeBadRequest, key: "error.reseller_product_not_listed"},
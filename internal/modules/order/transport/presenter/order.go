@@ -10,6 +10,8 @@ import (
 	"github.com/dujiao-next/internal/shared/jsonmap"
 	"github.com/dujiao-next/internal/shared/jsonslice"
 	"github.com/dujiao-next/internal/shared/money"
+
+	"github.com/shopspring/decimal"
 )
 
 // OrderSummary 订单列表响应（精简字段）
@@ -68,6 +70,8 @@ type OrderDetail struct {
 	GuestLocale              string            `json:"guest_locale,omitempty"`
 	Status                   string            `json:"status"`
 	Currency                 string            `json:"currency"`
+	BaseCurrency             string            `json:"base_currency,omitempty"`
+	ExchangeRate             *decimal.Decimal  `json:"exchange_rate,omitempty"`
 	OriginalAmount           money.Amount      `json:"original_amount"`
 	DiscountAmount           money.Amount      `json:"discount_amount"`
 	MemberDiscountAmount     money.Amount      `json:"member_discount_amount"`
@@ -120,6 +124,11 @@ func NewOrderDetail(o *orderdomain.Order) OrderDetail {
 		CanceledAt:              o.CanceledAt,
 		CreatedAt:               o.CreatedAt,
 	}
+	if o.BaseCurrency != "" && o.BaseCurrency != o.Currency {
+		rate := o.ExchangeRate
+		d.BaseCurrency = o.BaseCurrency
+		d.ExchangeRate = &rate
+	}
 	paid := o.PaidAt != nil
 	for _, item := range o.Items {
 		resp := newOrderItemResp(&item)
@@ -0,0 +1,289 @@
+package application
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dujiao-next/internal/logger"
+	orderriskcontract "github.com/dujiao-next/internal/modules/orderrisk/contract"
+	orderriskdomain "github.com/dujiao-next/internal/modules/orderrisk/domain"
+)
+
+// blacklistImportMaxValues 单次批量导入的最大条目数。
+const blacklistImportMaxValues = 5000
+
+// BlacklistService 管理客户黑名单，并为登录、下单与支付入口提供统一的命中判断。
+type BlacklistService struct {
+	store orderriskcontract.BlacklistStore
+	now   func() time.Time
+}
+
+var _ orderriskcontract.CustomerBlacklist = (*BlacklistService)(nil)
+
+func NewBlacklistService(store orderriskcontract.BlacklistStore) *BlacklistService {
+	return &BlacklistService{store: store, now: time.Now}
+}
+
+func (s *BlacklistService) List(filter orderriskcontract.BlacklistFilter) ([]orderriskdomain.BlacklistEntry, int64, error) {
+	return s.store.ListEntries(filter, s.now())
+}
+
+// Create 新增条目；同类型同值已存在时更新原因与有效期。
+func (s *BlacklistService) Create(input orderriskcontract.BlacklistEntryInput) (*orderriskdomain.BlacklistEntry, error) {
+	entry, err := buildBlacklistEntry(input.Type, input.Value, input.Reason, input.ExpiresAt, input.Operator)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.store.UpsertEntry(entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// Import 批量导入同一类型的条目，非法值记入结果而不中断导入。
+func (s *BlacklistService) Import(input orderriskcontract.BlacklistImportInput) (*orderriskcontract.BlacklistImportResult, error) {
+	if !orderriskdomain.IsBlacklistType(input.Type) {
+		return nil, orderriskcontract.ErrBlacklistTypeInvalid
+	}
+	if len(input.Values) > blacklistImportMaxValues {
+		return nil, orderriskcontract.ErrBlacklistValueInvalid
+	}
+	result := &orderriskcontract.BlacklistImportResult{Invalid: []string{}}
+	seen := make(map[string]struct{}, len(input.Values))
+	for _, raw := range input.Values {
+		if strings.TrimSpace(raw) == "" {
+			continue
+		}
+		entry, err := buildBlacklistEntry(input.Type, raw, input.Reason, input.ExpiresAt, input.Operator)
+		if err != nil {
+			result.Invalid = append(result.Invalid, strings.TrimSpace(raw))
+			continue
+		}
+		if _, ok := seen[entry.Value]; ok {
+			continue
+		}
+		seen[entry.Value] = struct{}{}
+		created, err := s.store.UpsertEntry(entry)
+		if err != nil {
+			return nil, err
+		}
+		if created {
+			result.Created++
+		} else {
+			result.Updated++
+		}
+	}
+	return result, nil
+}
+
+func (s *BlacklistService) Delete(id uint) error {
+	entry, err := s.store.GetEntry(id)
+	if err != nil {
+		return err
+	}
+	if entry == nil {
+		return orderriskcontract.ErrBlacklistEntryNotFound
+	}
+	return s.store.DeleteEntry(entry.ID)
+}
+
+// BlacklistOrderCustomer 拉黑订单客户在订单上可识别的身份：会员 ID、邮箱、下单 IP、
+// 已绑定的 Telegram ID 与成功支付的付款人身份。
+func (s *BlacklistService) BlacklistOrderCustomer(input orderriskcontract.BlacklistOrderCustomerInput) ([]orderriskdomain.BlacklistEntry, error) {
+	if input.OrderID == 0 {
+		return nil, orderriskcontract.ErrBlacklistOrderNotFound
+	}
+	order, err := s.store.GetOrder(input.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	if order == nil {
+		return nil, orderriskcontract.ErrBlacklistOrderNotFound
+	}
+	wanted := make(map[string]bool, len(input.Types))
+	for _, entryType := range input.Types {
+		entryType = strings.TrimSpace(entryType)
+		if !orderriskdomain.IsBlacklistType(entryType) {
+			return nil, orderriskcontract.ErrBlacklistTypeInvalid
+		}
+		wanted[entryType] = true
+	}
+	include := func(entryType string) bool {
+		return len(wanted) == 0 || wanted[entryType]
+	}
+
+	type candidate struct{ entryType, value string }
+	var candidates []candidate
+	email := order.GuestEmail
+	if order.UserID != 0 {
+		candidates = append(candidates, candidate{orderriskdomain.BlacklistTypeUserID, strconv.FormatUint(uint64(order.UserID), 10)})
+		identity, err := s.store.GetCustomerIdentity(order.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if identity != nil {
+			email = identity.Email
+			for _, telegramID := range identity.TelegramIDs {
+				candidates = append(candidates, candidate{orderriskdomain.BlacklistTypeTelegramID, telegramID})
+			}
+		}
+	}
+	candidates = append(candidates,
+		candidate{orderriskdomain.BlacklistTypeEmail, email},
+		candidate{orderriskdomain.BlacklistTypeIPRange, order.ClientIP},
+	)
+	if include(orderriskdomain.BlacklistTypePayer) {
+		payments, err := s.store.ListOrderSuccessPayments(order.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, payment := range payments {
+			for _, identity := range orderriskdomain.PayerIdentitiesFromPayload(payment.ProviderType, payment.ChannelType, payment.Payload) {
+				candidates = append(candidates, candidate{orderriskdomain.BlacklistTypePayer, identity})
+			}
+		}
+	}
+
+	reason := strings.TrimSpace(input.Reason)
+	if reason == "" {
+		reason = "订单 " + order.OrderNo + " 拉黑"
+	}
+	entries := make([]orderriskdomain.BlacklistEntry, 0, len(candidates))
+	seen := make(map[string]struct{}, len(candidates))
+	for _, item := range candidates {
+		if !include(item.entryType) {
+			continue
+		}
+		// Telegram 自动建号的占位邮箱等无法规范化的值直接跳过
+		entry, err := buildBlacklistEntry(item.entryType, item.value, reason, input.ExpiresAt, input.Operator)
+		if err != nil {
+			continue
+		}
+		key := entry.Type + "\x00" + entry.Value
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		entry.SourceOrderID = order.ID
+		if _, err := s.store.UpsertEntry(entry); err != nil {
+			return nil, err
+		}
+		entries = append(entries, *entry)
+	}
+	return entries, nil
+}
+
+// CheckCustomer 命中任一未过期条目时返回 ErrCustomerBlacklisted。
+func (s *BlacklistService) CheckCustomer(subject orderriskcontract.BlacklistSubject) error {
+	entry, err := s.Match(subject)
+	if err != nil {
+		return err
+	}
+	if entry != nil {
+		logger.Warnw("customer_blacklist_hit",
+			"entry_id", entry.ID,
+			"type", entry.Type,
+			"user_id", subject.UserID,
+			"client_ip", subject.ClientIP,
+		)
+		return orderriskcontract.ErrCustomerBlacklisted
+	}
+	return nil
+}
+
+// Match 返回主体命中的第一条未过期条目，未命中时返回 nil。
+func (s *BlacklistService) Match(subject orderriskcontract.BlacklistSubject) (*orderriskdomain.BlacklistEntry, error) {
+	if s == nil || s.store == nil {
+		return nil, nil
+	}
+	now := s.now()
+	emails := []string{subject.Email}
+	telegramIDs := append([]string{}, subject.TelegramIDs...)
+	var userIDs []string
+	if subject.UserID != 0 {
+		userIDs = append(userIDs, strconv.FormatUint(uint64(subject.UserID), 10))
+		identity, err := s.store.GetCustomerIdentity(subject.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if identity != nil {
+			emails = append(emails, identity.Email)
+			telegramIDs = append(telegramIDs, identity.TelegramIDs...)
+		}
+	}
+	domains := make([]string, 0, len(emails))
+	for _, email := range emails {
+		domains = append(domains, orderriskdomain.EmailDomain(email))
+	}
+
+	for _, group := range []struct {
+		entryType string
+		values    []string
+	}{
+		{orderriskdomain.BlacklistTypeUserID, userIDs},
+		{orderriskdomain.BlacklistTypeEmail, emails},
+		{orderriskdomain.BlacklistTypeEmailDomain, domains},
+		{orderriskdomain.BlacklistTypeTelegramID, telegramIDs},
+		{orderriskdomain.BlacklistTypePayer, subject.PayerIdentities},
+	} {
+		values := normalizeBlacklistValues(group.entryType, group.values)
+		if len(values) == 0 {
+			continue
+		}
+		entry, err := s.store.FindActive(group.entryType, values, now)
+		if err != nil || entry != nil {
+			return entry, err
+		}
+	}
+
+	if strings.TrimSpace(subject.ClientIP) == "" {
+		return nil, nil
+	}
+	ranges, err := s.store.ListActiveByType(orderriskdomain.BlacklistTypeIPRange, now)
+	if err != nil {
+		return nil, err
+	}
+	for i := range ranges {
+		if orderriskdomain.IPRangeContains(ranges[i].Value, subject.ClientIP) {
+			return &ranges[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func buildBlacklistEntry(entryType, rawValue, reason string, expiresAt *time.Time, operator orderriskcontract.BlacklistOperator) (*orderriskdomain.BlacklistEntry, error) {
+	entryType = strings.TrimSpace(entryType)
+	if !orderriskdomain.IsBlacklistType(entryType) {
+		return nil, orderriskcontract.ErrBlacklistTypeInvalid
+	}
+	value, ok := orderriskdomain.NormalizeBlacklistValue(entryType, rawValue)
+	if !ok {
+		return nil, orderriskcontract.ErrBlacklistValueInvalid
+	}
+	return &orderriskdomain.BlacklistEntry{
+		Type:              entryType,
+		Value:             value,
+		Reason:            strings.TrimSpace(reason),
+		ExpiresAt:         expiresAt,
+		CreatedByAdminID:  operator.AdminID,
+		CreatedByUsername: strings.TrimSpace(operator.Username),
+	}, nil
+}
+
+func normalizeBlacklistValues(entryType string, raw []string) []string {
+	values := make([]string, 0, len(raw))
+	seen := make(map[string]struct{}, len(raw))
+	for _, item := range raw {
+		value, ok := orderriskdomain.NormalizeBlacklistValue(entryType, item)
+		if !ok {
+			continue
+		}
+		if _, exists := seen[value]; exists {
+			continue
+		}
+		seen[value] = struct{}{}
+		values = append(values, value)
+	}
+	return values
+}
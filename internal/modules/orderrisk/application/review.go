@@ -0,0 +1,271 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dujiao-next/internal/constants"
+	"github.com/dujiao-next/internal/logger"
+	notificationcontract "github.com/dujiao-next/internal/modules/notification/contract"
+	orderdomain "github.com/dujiao-next/internal/modules/order/domain"
+	orderriskcontract "github.com/dujiao-next/internal/modules/orderrisk/contract"
+	orderriskdomain "github.com/dujiao-next/internal/modules/orderrisk/domain"
+	"github.com/dujiao-next/internal/shared/jsonmap"
+	"github.com/dujiao-next/internal/shared/money"
+)
+
+var errReviewNotConfigured = errors.New("order review service not configured")
+
+// reviewSLABatchSize 单轮超时巡检最多告警的订单数，剩余订单留给下一轮。
+const reviewSLABatchSize = 100
+
+// ReviewOptions 声明人工复核队列的全部端口。
+type ReviewOptions struct {
+	Store    orderriskcontract.ReviewStore
+	Settings orderriskcontract.SettingReader
+	Releaser orderriskcontract.HeldOrderReleaser
+	Refunder orderriskcontract.HeldOrderRefunder
+	Notifier orderriskcontract.ReviewNotifier
+}
+
+// ReviewService 编排待复核订单的入队审计、放行、驳回退款与超时告警。
+type ReviewService struct {
+	store    orderriskcontract.ReviewStore
+	settings orderriskcontract.SettingReader
+	releaser orderriskcontract.HeldOrderReleaser
+	refunder orderriskcontract.HeldOrderRefunder
+	notifier orderriskcontract.ReviewNotifier
+	now      func() time.Time
+}
+
+func NewReviewService(options ReviewOptions) *ReviewService {
+	return &ReviewService{
+		store:    options.Store,
+		settings: options.Settings,
+		releaser: options.Releaser,
+		refunder: options.Refunder,
+		notifier: options.Notifier,
+		now:      time.Now,
+	}
+}
+
+// ListQueue 返回待复核父订单，按挂起时间先后排列。
+func (s *ReviewService) ListQueue(filter orderriskcontract.ReviewQueueFilter) ([]orderdomain.Order, int64, error) {
+	return s.store.ListHeldOrders(filter)
+}
+
+// GetReview 返回订单与复核审计记录；已处理的订单仍可查看历史。
+func (s *ReviewService) GetReview(orderID uint) (*orderriskcontract.ReviewDetail, error) {
+	order, err := s.loadOrder(orderID)
+	if err != nil {
+		return nil, err
+	}
+	logs, err := s.store.ListLogs(order.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &orderriskcontract.ReviewDetail{Order: order, Logs: logs}, nil
+}
+
+// EnqueueHeldOrder 由支付服务在订单挂起后调用：写入挂起审计并发送待复核告警。
+func (s *ReviewService) EnqueueHeldOrder(order *orderdomain.Order) error {
+	if s == nil || order == nil {
+		return nil
+	}
+	if err := s.store.CreateLog(&orderriskdomain.ReviewLog{
+		OrderID: order.ID,
+		OrderNo: order.OrderNo,
+		Action:  orderriskdomain.ReviewActionHold,
+		Reason:  order.ReviewHoldReason,
+		DetailJSON: jsonmap.JSON{
+			"risk_score":    order.RiskScore,
+			"risk_decision": order.RiskDecision,
+			"risk_reasons":  []string(order.RiskReasons),
+		},
+	}); err != nil {
+		return err
+	}
+	s.notify(order, constants.NotificationAlertTypeOrderReviewPending, jsonmap.JSON{})
+	return nil
+}
+
+// Approve 放行待复核订单，恢复交付与采购。
+func (s *ReviewService) Approve(input orderriskcontract.ReviewDecisionInput) (*orderdomain.Order, error) {
+	order, err := s.loadHeldOrder(input.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	if s.releaser == nil {
+		return nil, errReviewNotConfigured
+	}
+	released, err := s.releaser.ReleaseHeldOrder(order.ID)
+	if err != nil {
+		return nil, err
+	}
+	s.writeDecisionLog(order, orderriskdomain.ReviewActionApprove, input, jsonmap.JSON{"status": released.Status})
+	return released, nil
+}
+
+// Reject 驳回待复核订单并经订单退款流程全额退款；游客订单只能手动退款。
+func (s *ReviewService) Reject(input orderriskcontract.ReviewDecisionInput) (*orderdomain.Order, error) {
+	order, err := s.loadHeldOrder(input.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	mode, err := resolveReviewRefundMode(order, input.RefundMode)
+	if err != nil {
+		return nil, err
+	}
+	if s.refunder == nil {
+		return nil, errReviewNotConfigured
+	}
+	remark := strings.TrimSpace(input.Reason)
+	if remark == "" {
+		remark = "人工复核驳回"
+	}
+	if err := s.refunder.RefundHeldOrder(orderriskcontract.HeldOrderRefundInput{
+		OrderID: order.ID,
+		Amount:  money.FromDecimal(order.TotalAmount.Decimal.Sub(order.RefundedAmount.Decimal).Round(2)),
+		Mode:    mode,
+		Remark:  remark,
+	}); err != nil {
+		return nil, err
+	}
+	s.writeDecisionLog(order, orderriskdomain.ReviewActionReject, input, jsonmap.JSON{"refund_mode": mode})
+	return s.loadOrder(order.ID)
+}
+
+// AlertOverdue 为超过复核时限且尚未告警的订单发送超时告警，每个订单只告警一次。
+func (s *ReviewService) AlertOverdue() (int, error) {
+	if s == nil || s.store == nil || s.settings == nil {
+		return 0, nil
+	}
+	cfg, err := s.settings.GetOrderRiskControlConfig()
+	if err != nil {
+		return 0, err
+	}
+	slaMinutes := cfg.Review.SLAMinutes
+	if slaMinutes <= 0 {
+		return 0, nil
+	}
+	now := s.now()
+	orders, err := s.store.ListOverdueUnalerted(now.Add(-time.Duration(slaMinutes)*time.Minute), reviewSLABatchSize)
+	if err != nil {
+		return 0, err
+	}
+	if len(orders) == 0 {
+		return 0, nil
+	}
+	ids := make([]uint, 0, len(orders))
+	for i := range orders {
+		ids = append(ids, orders[i].ID)
+	}
+	// 先标记再发送，避免告警发送失败后每轮重复轰炸
+	if err := s.store.MarkReviewAlerted(ids, now); err != nil {
+		return 0, err
+	}
+	for i := range orders {
+		order := &orders[i]
+		heldMinutes := 0
+		if order.HeldAt != nil {
+			heldMinutes = int(now.Sub(*order.HeldAt) / time.Minute)
+		}
+		s.notify(order, constants.NotificationAlertTypeOrderReviewOverdue, jsonmap.JSON{
+			"alert_value":     fmt.Sprintf("%d", heldMinutes),
+			"alert_threshold": fmt.Sprintf("%d", slaMinutes),
+		})
+		if err := s.store.CreateLog(&orderriskdomain.ReviewLog{
+			OrderID:    order.ID,
+			OrderNo:    order.OrderNo,
+			Action:     orderriskdomain.ReviewActionSLAAlert,
+			DetailJSON: jsonmap.JSON{"held_minutes": heldMinutes, "sla_minutes": slaMinutes},
+		}); err != nil {
+			logger.Warnw("order_review_sla_log_failed", "order_id", order.ID, "error", err)
+		}
+	}
+	return len(orders), nil
+}
+
+func (s *ReviewService) loadOrder(orderID uint) (*orderdomain.Order, error) {
+	if orderID == 0 {
+		return nil, orderriskcontract.ErrReviewOrderNotFound
+	}
+	order, err := s.store.GetOrder(orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order == nil || order.ParentID != nil {
+		return nil, orderriskcontract.ErrReviewOrderNotFound
+	}
+	return order, nil
+}
+
+func (s *ReviewService) loadHeldOrder(orderID uint) (*orderdomain.Order, error) {
+	order, err := s.loadOrder(orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != constants.OrderStatusHeldForReview {
+		return nil, orderriskcontract.ErrReviewOrderNotHeld
+	}
+	return order, nil
+}
+
+func resolveReviewRefundMode(order *orderdomain.Order, raw string) (string, error) {
+	mode := strings.ToLower(strings.TrimSpace(raw))
+	switch mode {
+	case "":
+		if order.UserID == 0 {
+			return orderriskdomain.ReviewRefundManual, nil
+		}
+		return orderriskdomain.ReviewRefundWallet, nil
+	case orderriskdomain.ReviewRefundWallet:
+		if order.UserID == 0 {
+			return "", orderriskcontract.ErrReviewRefundModeInvalid
+		}
+		return mode, nil
+	case orderriskdomain.ReviewRefundManual:
+		return mode, nil
+	default:
+		return "", orderriskcontract.ErrReviewRefundModeInvalid
+	}
+}
+
+// writeDecisionLog 记录人工决策；决策已生效，审计写入失败只记日志不回滚。
+func (s *ReviewService) writeDecisionLog(order *orderdomain.Order, action string, input orderriskcontract.ReviewDecisionInput, detail jsonmap.JSON) {
+	detail["hold_source"] = order.ReviewHoldReason
+	detail["risk_score"] = order.RiskScore
+	if err := s.store.CreateLog(&orderriskdomain.ReviewLog{
+		OrderID:          order.ID,
+		OrderNo:          order.OrderNo,
+		Action:           action,
+		OperatorAdminID:  input.OperatorAdminID,
+		OperatorUsername: strings.TrimSpace(input.OperatorUsername),
+		Reason:           strings.TrimSpace(input.Reason),
+		DetailJSON:       detail,
+	}); err != nil {
+		logger.Warnw("order_review_log_failed", "order_id", order.ID, "action", action, "error", err)
+	}
+}
+
+func (s *ReviewService) notify(order *orderdomain.Order, alertType string, data jsonmap.JSON) {
+	if s.notifier == nil || order == nil {
+		return
+	}
+	data["alert_type_key"] = alertType
+	data["alert_level"] = "warning"
+	data["order_id"] = order.ID
+	data["order_no"] = order.OrderNo
+	data["hold_source"] = order.ReviewHoldReason
+	data["risk_score"] = order.RiskScore
+	if err := s.notifier.Enqueue(notificationcontract.EnqueueInput{
+		EventType: constants.NotificationEventExceptionAlert,
+		BizType:   constants.NotificationBizTypeOrder,
+		BizID:     order.ID,
+		Data:      data,
+	}); err != nil {
+		logger.Warnw("order_review_notify_failed", "order_id", order.ID, "alert_type", alertType, "error", err)
+	}
+}
@@ -0,0 +1,168 @@
+package application
+
+import (
+	"strings"
+	"time"
+
+	"github.com/dujiao-next/internal/logger"
+	orderriskcontract "github.com/dujiao-next/internal/modules/orderrisk/contract"
+	orderriskdomain "github.com/dujiao-next/internal/modules/orderrisk/domain"
+	settingssecurity "github.com/dujiao-next/internal/modules/settings/schema/security"
+)
+
+// 评分原因标识，写入订单 risk_reasons 供后台复核。
+const (
+	ReasonDisposableEmail    = "disposable_email"
+	ReasonSuspiciousDomain   = "suspicious_email_domain"
+	ReasonNewAccount         = "new_account"
+	ReasonIPVelocity         = "ip_velocity"
+	ReasonEmailVelocity      = "email_velocity"
+	ReasonDeviceVelocity     = "device_velocity"
+	ReasonFailedPayments     = "failed_payments"
+	ReasonGuestEmailMismatch = "guest_email_mismatch"
+)
+
+// Assess 按配置的信号权重计算风险分，并给出 allow/challenge/hold/block 处置。
+// block 始终返回 ErrOrderBlocked；challenge 仅在下单阶段且未通过验证码时返回 ErrChallengeRequired，
+// hold 由调用方记录在订单上。单个信号读取失败只记录日志并跳过，避免风控依赖故障阻断下单。
+func (s *Service) Assess(input orderriskcontract.AssessInput) (orderriskcontract.Assessment, error) {
+	assessment := orderriskcontract.Assessment{Decision: orderriskdomain.DecisionAllow, Reasons: []string{}}
+	if s == nil || s.settings == nil {
+		return assessment, nil
+	}
+	cfg, err := s.settings.GetOrderRiskControlConfig()
+	if err != nil {
+		logger.Warnw("risk_scoring_get_config_error", "error", err)
+		return assessment, nil
+	}
+	if !cfg.Enabled || !cfg.Scoring.Enabled {
+		return assessment, nil
+	}
+
+	s.scoreSignals(input, cfg.Scoring, &assessment)
+	assessment.Decision = decideRisk(assessment.Score, cfg.Scoring)
+	switch assessment.Decision {
+	case orderriskdomain.DecisionBlock:
+		return assessment, orderriskcontract.ErrOrderBlocked
+	case orderriskdomain.DecisionChallenge:
+		if input.Stage != orderriskcontract.StagePayment && !input.ChallengePassed {
+			return assessment, orderriskcontract.ErrChallengeRequired
+		}
+	}
+	return assessment, nil
+}
+
+func (s *Service) scoreSignals(input orderriskcontract.AssessInput, policy settingssecurity.OrderRiskScoringPolicy, assessment *orderriskcontract.Assessment) {
+	add := func(reason string, rule settingssecurity.OrderRiskScoreRule) {
+		assessment.Score += rule.Weight
+		assessment.Reasons = append(assessment.Reasons, reason)
+	}
+	now := s.currentTime()
+	isGuest := input.UserID == 0
+	email := strings.TrimSpace(input.GuestEmail)
+
+	if !isGuest && s.signals != nil {
+		user, err := s.signals.GetUserSignal(input.UserID)
+		if err != nil {
+			logger.Warnw("risk_scoring_user_signal_error", "user_id", input.UserID, "error", err)
+		} else if user != nil {
+			email = user.Email
+			rule := policy.NewAccount
+			if rule.Weight > 0 && rule.Threshold > 0 && !user.CreatedAt.IsZero() &&
+				now.Sub(user.CreatedAt) < time.Duration(rule.Threshold)*time.Hour {
+				add(ReasonNewAccount, rule)
+			}
+		}
+	}
+
+	domain := orderriskdomain.EmailDomain(email)
+	if policy.DisposableEmail.Weight > 0 && orderriskdomain.IsDisposableDomain(domain, policy.DisposableDomains) {
+		add(ReasonDisposableEmail, policy.DisposableEmail)
+	}
+	if policy.SuspiciousDomain.Weight > 0 && orderriskdomain.MatchDomainList(domain, policy.SuspiciousDomains) {
+		add(ReasonSuspiciousDomain, policy.SuspiciousDomain)
+	}
+	if s.signals == nil {
+		return
+	}
+
+	velocitySince := now.Add(-time.Duration(policy.VelocityWindowMinutes) * time.Minute)
+	historySince := now.AddDate(0, 0, -policy.HistoryWindowDays)
+	identity := orderriskcontract.SignalScope{ExcludeOrderID: input.OrderID}
+	if isGuest {
+		identity.Email = email
+	} else {
+		identity.UserID = input.UserID
+	}
+	hasIdentity := identity.UserID > 0 || identity.Email != ""
+
+	countRule := func(reason string, rule settingssecurity.OrderRiskScoreRule, enabled bool, count func() (int64, error)) {
+		if !enabled || rule.Weight <= 0 || rule.Threshold <= 0 {
+			return
+		}
+		value, err := count()
+		if err != nil {
+			logger.Warnw("risk_scoring_signal_error", "signal", reason, "error", err)
+			return
+		}
+		if value >= int64(rule.Threshold) {
+			add(reason, rule)
+		}
+	}
+
+	countRule(ReasonIPVelocity, policy.IPVelocity, input.RiskIP != "", func() (int64, error) {
+		return s.signals.CountRecentOrders(orderriskcontract.SignalScope{RiskIP: input.RiskIP, ExcludeOrderID: input.OrderID, Since: velocitySince})
+	})
+	countRule(ReasonEmailVelocity, policy.EmailVelocity, hasIdentity, func() (int64, error) {
+		scope := identity
+		scope.Since = velocitySince
+		return s.signals.CountRecentOrders(scope)
+	})
+	countRule(ReasonDeviceVelocity, policy.DeviceVelocity, input.DeviceHash != "", func() (int64, error) {
+		return s.signals.CountRecentOrders(orderriskcontract.SignalScope{DeviceHash: input.DeviceHash, ExcludeOrderID: input.OrderID, Since: velocitySince})
+	})
+	countRule(ReasonFailedPayments, policy.FailedPayments, hasIdentity, func() (int64, error) {
+		scope := identity
+		scope.Since = historySince
+		return s.signals.CountRecentFailedPayments(scope)
+	})
+	countRule(ReasonGuestEmailMismatch, policy.GuestEmailMismatch, isGuest && email != "" && (input.RiskIP != "" || input.DeviceHash != ""), func() (int64, error) {
+		var maximum int64
+		for _, scope := range []orderriskcontract.SignalScope{
+			{Email: email, RiskIP: input.RiskIP, ExcludeOrderID: input.OrderID, Since: historySince},
+			{Email: email, DeviceHash: input.DeviceHash, ExcludeOrderID: input.OrderID, Since: historySince},
+		} {
+			if scope.RiskIP == "" && scope.DeviceHash == "" {
+				continue
+			}
+			count, err := s.signals.CountOtherGuestEmails(scope)
+			if err != nil {
+				return 0, err
+			}
+			if count > maximum {
+				maximum = count
+			}
+		}
+		return maximum, nil
+	})
+}
+
+func decideRisk(score int, policy settingssecurity.OrderRiskScoringPolicy) string {
+	switch {
+	case policy.BlockScore > 0 && score >= policy.BlockScore:
+		return orderriskdomain.DecisionBlock
+	case policy.HoldScore > 0 && score >= policy.HoldScore:
+		return orderriskdomain.DecisionHold
+	case policy.ChallengeScore > 0 && score >= policy.ChallengeScore:
+		return orderriskdomain.DecisionChallenge
+	default:
+		return orderriskdomain.DecisionAllow
+	}
+}
+
+func (s *Service) currentTime() time.Time {
+	if s.now != nil {
+		return s.now()
+	}
+	return time.Now()
+}
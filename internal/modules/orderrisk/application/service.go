@@ -7,6 +7,7 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/dujiao-next/internal/logger"
 	orderriskcontract "github.com/dujiao-next/internal/modules/orderrisk/contract"
@@ -23,12 +24,17 @@ type parsedIPBlacklist struct {
 type Options struct {
 	Settings    orderriskcontract.SettingReader
 	RateLimiter orderriskcontract.RateLimiter
+	Signals     orderriskcontract.SignalReader
+	Blacklist   orderriskcontract.CustomerBlacklist
 }
 
-// Service 编排身份分流、黑名单、商品数量、待支付库存配额和下单频率检查。
+// Service 编排身份分流、黑名单、商品数量、待支付库存配额、下单频率检查与风险评分。
 type Service struct {
 	settings    orderriskcontract.SettingReader
 	rateLimiter orderriskcontract.RateLimiter
+	signals     orderriskcontract.SignalReader
+	blacklist   orderriskcontract.CustomerBlacklist
+	now         func() time.Time
 
 	mu              sync.RWMutex
 	cachedBlacklist *parsedIPBlacklist
@@ -37,7 +43,13 @@ type Service struct {
 var _ orderriskcontract.Controller = (*Service)(nil)
 
 func NewService(options Options) *Service {
-	return &Service{settings: options.Settings, rateLimiter: options.RateLimiter}
+	return &Service{
+		settings:    options.Settings,
+		rateLimiter: options.RateLimiter,
+		signals:     options.Signals,
+		blacklist:   options.Blacklist,
+		now:         time.Now,
+	}
 }
 
 // CheckOrderAllowed 在订单事务外读取配置并执行前置风控，返回后续事务应复用的配置与规范化 IP。
@@ -46,7 +58,20 @@ func (s *Service) CheckOrderAllowed(input orderriskcontract.CheckInput) (orderri
 		RiskIP:         orderriskcontract.NormalizeRiskIP(input.ClientIP),
 		ConfigSnapshot: settingssecurity.DefaultOrderRiskControlConfig(),
 	}
-	if s == nil || s.settings == nil {
+	if s == nil {
+		return result, nil
+	}
+	// 客户黑名单独立于风控开关，始终生效；渠道/Bot 订单不校验 IP 维度
+	if s.blacklist != nil {
+		subject := orderriskcontract.BlacklistSubject{UserID: input.UserID, Email: input.GuestEmail}
+		if !input.SkipIPCheck {
+			subject.ClientIP = input.ClientIP
+		}
+		if err := s.blacklist.CheckCustomer(subject); err != nil {
+			return result, err
+		}
+	}
+	if s.settings == nil {
 		return result, nil
 	}
 	cfg, err := s.settings.GetOrderRiskControlConfig()
@@ -4,20 +4,28 @@ import (
 	"errors"
 	"path/filepath"
 	"reflect"
+	"strconv"
 	"testing"
 	"time"
 
 	"github.com/dujiao-next/internal/constants"
+	externalidentitydomain "github.com/dujiao-next/internal/modules/identity/externalidentity/domain"
+	userdomain "github.com/dujiao-next/internal/modules/identity/user/domain"
+	notificationcontract "github.com/dujiao-next/internal/modules/notification/contract"
 	ordercontract "github.com/dujiao-next/internal/modules/order/contract"
 	orderdomain "github.com/dujiao-next/internal/modules/order/domain"
 	orderstore "github.com/dujiao-next/internal/modules/order/infrastructure/gormstore"
 	orderriskcontract "github.com/dujiao-next/internal/modules/orderrisk/contract"
 	orderriskdomain "github.com/dujiao-next/internal/modules/orderrisk/domain"
+	orderriskgormstore "github.com/dujiao-next/internal/modules/orderrisk/infrastructure/gormstore"
+	paymentdomain "github.com/dujiao-next/internal/modules/payment/domain"
 	settingsapp "github.com/dujiao-next/internal/modules/settings/application"
 	settingsstore "github.com/dujiao-next/internal/modules/settings/infrastructure/gormstore"
 	settingssecurity "github.com/dujiao-next/internal/modules/settings/schema/security"
+	"github.com/dujiao-next/internal/shared/money"
 
 	"github.com/glebarez/sqlite"
+	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
 )
 
@@ -358,3 +366,476 @@ func TestCheckOrderAllowed_GuestReturnsExpiryOverride(t *testing.T) {
 		t.Fatalf("expected guest expiry 8, got %d", result.PaymentExpireMinutes)
 	}
 }
+
+type signalReaderStub struct {
+	user          *orderriskcontract.UserSignal
+	orders        map[string]int64
+	failed        int64
+	otherEmails   int64
+	orderScopes   []orderriskcontract.SignalScope
+	orderCountErr error
+}
+
+func (s *signalReaderStub) GetUserSignal(uint) (*orderriskcontract.UserSignal, error) {
+	return s.user, nil
+}
+
+func (s *signalReaderStub) CountRecentOrders(scope orderriskcontract.SignalScope) (int64, error) {
+	s.orderScopes = append(s.orderScopes, scope)
+	if s.orderCountErr != nil {
+		return 0, s.orderCountErr
+	}
+	switch {
+	case scope.RiskIP != "":
+		return s.orders["ip"], nil
+	case scope.DeviceHash != "":
+		return s.orders["device"], nil
+	default:
+		return s.orders["identity"], nil
+	}
+}
+
+func (s *signalReaderStub) CountRecentFailedPayments(orderriskcontract.SignalScope) (int64, error) {
+	return s.failed, nil
+}
+
+func (s *signalReaderStub) CountOtherGuestEmails(orderriskcontract.SignalScope) (int64, error) {
+	return s.otherEmails, nil
+}
+
+func scoringConfig() settingssecurity.OrderRiskControlConfig {
+	cfg := testConfig()
+	cfg.Scoring.Enabled = true
+	return cfg
+}
+
+func TestAssess_DisabledScoringAllows(t *testing.T) {
+	signals := &signalReaderStub{orders: map[string]int64{"ip": 100}}
+	svc := NewService(Options{Settings: settingReaderStub{config: testConfig()}, Signals: signals})
+	assessment, err := svc.Assess(orderriskcontract.AssessInput{Stage: orderriskcontract.StageOrder, GuestEmail: "a@mailinator.com", RiskIP: "1.2.3.4"})
+	if err != nil || assessment.Decision != orderriskdomain.DecisionAllow || assessment.Score != 0 {
+		t.Fatalf("expected allow while scoring disabled, got %+v err=%v", assessment, err)
+	}
+	if len(signals.orderScopes) != 0 {
+		t.Fatalf("disabled scoring must not read signals, got %d reads", len(signals.orderScopes))
+	}
+}
+
+func TestAssess_DisposableEmailRequiresChallengeUntilPassed(t *testing.T) {
+	cfg := scoringConfig()
+	cfg.Scoring.DisposableDomains = []string{"burner.test"}
+	svc := NewService(Options{Settings: settingReaderStub{config: cfg}})
+	input := orderriskcontract.AssessInput{Stage: orderriskcontract.StageOrder, GuestEmail: "buyer@mx.burner.test"}
+
+	assessment, err := svc.Assess(input)
+	if !errors.Is(err, orderriskcontract.ErrChallengeRequired) {
+		t.Fatalf("expected challenge required, got %v", err)
+	}
+	if assessment.Score != 40 || !reflect.DeepEqual(assessment.Reasons, []string{ReasonDisposableEmail}) {
+		t.Fatalf("unexpected assessment: %+v", assessment)
+	}
+
+	input.ChallengePassed = true
+	assessment, err = svc.Assess(input)
+	if err != nil || assessment.Decision != orderriskdomain.DecisionChallenge {
+		t.Fatalf("expected passed challenge to keep decision without error, got %+v err=%v", assessment, err)
+	}
+
+	input.ChallengePassed = false
+	input.Stage = orderriskcontract.StagePayment
+	if _, err := svc.Assess(input); err != nil {
+		t.Fatalf("payment stage must not request a challenge, got %v", err)
+	}
+}
+
+func TestAssess_CombinedSignalsHoldAndBlock(t *testing.T) {
+	now := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+	signals := &signalReaderStub{
+		user:   &orderriskcontract.UserSignal{Email: "member@example.com", CreatedAt: now.Add(-time.Hour)},
+		orders: map[string]int64{"ip": 5, "identity": 1, "device": 0},
+		failed: 3,
+	}
+	svc := NewService(Options{Settings: settingReaderStub{config: scoringConfig()}, Signals: signals})
+	svc.now = func() time.Time { return now }
+
+	assessment, err := svc.Assess(orderriskcontract.AssessInput{
+		Stage:      orderriskcontract.StagePayment,
+		OrderID:    9,
+		UserID:     3,
+		RiskIP:     "1.2.3.4",
+		DeviceHash: "device-hash",
+	})
+	if err != nil {
+		t.Fatalf("expected hold without error, got %v", err)
+	}
+	wantReasons := []string{ReasonNewAccount, ReasonIPVelocity, ReasonFailedPayments}
+	if assessment.Score != 60 || assessment.Decision != orderriskdomain.DecisionHold || !reflect.DeepEqual(assessment.Reasons, wantReasons) {
+		t.Fatalf("unexpected assessment: %+v", assessment)
+	}
+	for _, scope := range signals.orderScopes {
+		if scope.ExcludeOrderID != 9 {
+			t.Fatalf("payment stage must exclude the current order, got %+v", scope)
+		}
+		if scope.Email != "" {
+			t.Fatalf("member velocity must use user id instead of email, got %+v", scope)
+		}
+	}
+
+	signals.otherEmails = 10
+	signals.orders["device"] = 5
+	cfg := scoringConfig()
+	cfg.Scoring.SuspiciousDomains = []string{"example.com"}
+	svc.settings = settingReaderStub{config: cfg}
+	assessment, err = svc.Assess(orderriskcontract.AssessInput{Stage: orderriskcontract.StageOrder, UserID: 3, RiskIP: "1.2.3.4", DeviceHash: "device-hash"})
+	if !errors.Is(err, orderriskcontract.ErrOrderBlocked) || assessment.Decision != orderriskdomain.DecisionBlock {
+		t.Fatalf("expected block, got %+v err=%v", assessment, err)
+	}
+}
+
+func TestAssess_SignalErrorsAreSkipped(t *testing.T) {
+	signals := &signalReaderStub{orderCountErr: errors.New("db down")}
+	svc := NewService(Options{Settings: settingReaderStub{config: scoringConfig()}, Signals: signals})
+	assessment, err := svc.Assess(orderriskcontract.AssessInput{Stage: orderriskcontract.StageOrder, GuestEmail: "a@example.com", RiskIP: "1.2.3.4"})
+	if err != nil || assessment.Decision != orderriskdomain.DecisionAllow {
+		t.Fatalf("signal failures must fail open, got %+v err=%v", assessment, err)
+	}
+}
+
+func TestSignalStoreCountsScopedHistory(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "risk-signals.db")), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	if err := db.AutoMigrate(&orderdomain.Order{}, &paymentdomain.Payment{}, &userdomain.User{}); err != nil {
+		t.Fatalf("migrate signal tables: %v", err)
+	}
+	now := time.Now()
+	orders := []orderdomain.Order{
+		{OrderNo: "S1", GuestEmail: "a@example.com", Status: constants.OrderStatusCanceled, Currency: "CNY", RiskIP: "1.2.3.4", DeviceHash: "dev", CreatedAt: now},
+		{OrderNo: "S2", GuestEmail: "B@example.com", Status: constants.OrderStatusPendingPayment, Currency: "CNY", RiskIP: "1.2.3.4", CreatedAt: now},
+		{OrderNo: "S3", GuestEmail: "c@example.com", Status: constants.OrderStatusPaid, Currency: "CNY", RiskIP: "1.2.3.4", CreatedAt: now.AddDate(0, 0, -40)},
+		{OrderNo: "S4", UserID: 7, Status: constants.OrderStatusPaid, Currency: "CNY", RiskIP: "5.6.7.8", CreatedAt: now},
+	}
+	for i := range orders {
+		if err := db.Create(&orders[i]).Error; err != nil {
+			t.Fatalf("seed order: %v", err)
+		}
+	}
+	if err := db.Create(&paymentdomain.Payment{OrderID: orders[0].ID, ChannelID: 1, ProviderType: "epay", ChannelType: "alipay", InteractionMode: "redirect", Currency: "CNY", Status: constants.PaymentStatusFailed, CreatedAt: now}).Error; err != nil {
+		t.Fatalf("seed payment: %v", err)
+	}
+	if err := db.Create(&userdomain.User{Email: "member@example.com", PasswordHash: "x", Status: "active", CreatedAt: now}).Error; err != nil {
+		t.Fatalf("seed user: %v", err)
+	}
+
+	store := orderriskgormstore.NewSignalStore(db)
+	since := now.Add(-time.Hour)
+	if count, err := store.CountRecentOrders(orderriskcontract.SignalScope{RiskIP: "1.2.3.4", Since: since}); err != nil || count != 2 {
+		t.Fatalf("expected 2 recent orders on ip, got %d err=%v", count, err)
+	}
+	if count, err := store.CountRecentOrders(orderriskcontract.SignalScope{RiskIP: "1.2.3.4", ExcludeOrderID: orders[1].ID, Since: since}); err != nil || count != 1 {
+		t.Fatalf("expected excluded order to be skipped, got %d err=%v", count, err)
+	}
+	if count, err := store.CountRecentOrders(orderriskcontract.SignalScope{Since: since}); err != nil || count != 0 {
+		t.Fatalf("unscoped query must not count the whole table, got %d err=%v", count, err)
+	}
+	if count, err := store.CountRecentFailedPayments(orderriskcontract.SignalScope{Email: "A@example.com", Since: since}); err != nil || count != 1 {
+		t.Fatalf("expected 1 failed payment for guest email, got %d err=%v", count, err)
+	}
+	if count, err := store.CountOtherGuestEmails(orderriskcontract.SignalScope{Email: "a@example.com", RiskIP: "1.2.3.4", Since: now.AddDate(0, 0, -30)}); err != nil || count != 1 {
+		t.Fatalf("expected 1 other guest email on ip, got %d err=%v", count, err)
+	}
+	user, err := store.GetUserSignal(1)
+	if err != nil || user == nil || user.Email != "member@example.com" {
+		t.Fatalf("unexpected user signal %+v err=%v", user, err)
+	}
+	if missing, err := store.GetUserSignal(99); err != nil || missing != nil {
+		t.Fatalf("expected nil for missing user, got %+v err=%v", missing, err)
+	}
+}
+
+type releaserStub struct {
+	released []uint
+}
+
+func (s *releaserStub) ReleaseHeldOrder(orderID uint) (*orderdomain.Order, error) {
+	s.released = append(s.released, orderID)
+	return &orderdomain.Order{ID: orderID, Status: constants.OrderStatusFulfilling}, nil
+}
+
+type refunderStub struct {
+	inputs []orderriskcontract.HeldOrderRefundInput
+}
+
+func (s *refunderStub) RefundHeldOrder(input orderriskcontract.HeldOrderRefundInput) error {
+	s.inputs = append(s.inputs, input)
+	return nil
+}
+
+type reviewNotifierStub struct {
+	inputs []notificationcontract.EnqueueInput
+}
+
+func (s *reviewNotifierStub) Enqueue(input notificationcontract.EnqueueInput) error {
+	s.inputs = append(s.inputs, input)
+	return nil
+}
+
+func openReviewTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "risk-review.db")), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	if err := db.AutoMigrate(&orderdomain.Order{}, &orderdomain.OrderItem{}, &orderriskdomain.ReviewLog{}); err != nil {
+		t.Fatalf("migrate review tables: %v", err)
+	}
+	return db
+}
+
+func TestReviewServiceApproveRejectAndAudit(t *testing.T) {
+	db := openReviewTestDB(t)
+	heldAt := time.Now().Add(-10 * time.Minute)
+	orders := []orderdomain.Order{
+		{OrderNo: "R1", UserID: 5, Status: constants.OrderStatusHeldForReview, Currency: "CNY", TotalAmount: money.FromDecimal(decimal.NewFromInt(30)), ReviewHoldReason: constants.OrderReviewHoldRiskScore, RiskScore: 80, HeldAt: &heldAt},
+		{OrderNo: "R2", GuestEmail: "g@example.com", Status: constants.OrderStatusHeldForReview, Currency: "CNY", TotalAmount: money.FromDecimal(decimal.NewFromInt(12)), RefundedAmount: money.FromDecimal(decimal.NewFromInt(2)), ReviewHoldReason: constants.OrderReviewHoldProductSetting, HeldAt: &heldAt},
+		{OrderNo: "R3", UserID: 5, Status: constants.OrderStatusPaid, Currency: "CNY"},
+	}
+	for i := range orders {
+		if err := db.Create(&orders[i]).Error; err != nil {
+			t.Fatalf("seed order: %v", err)
+		}
+	}
+	releaser := &releaserStub{}
+	refunder := &refunderStub{}
+	notifier := &reviewNotifierStub{}
+	svc := NewReviewService(ReviewOptions{
+		Store:    orderriskgormstore.NewReviewStore(db),
+		Releaser: releaser,
+		Refunder: refunder,
+		Notifier: notifier,
+	})
+
+	if err := svc.EnqueueHeldOrder(&orders[0]); err != nil {
+		t.Fatalf("enqueue held order: %v", err)
+	}
+	if len(notifier.inputs) != 1 || notifier.inputs[0].Data["alert_type_key"] != constants.NotificationAlertTypeOrderReviewPending {
+		t.Fatalf("expected pending review alert, got %+v", notifier.inputs)
+	}
+	queue, total, err := svc.ListQueue(orderriskcontract.ReviewQueueFilter{Page: 1, PageSize: 20})
+	if err != nil || total != 2 || len(queue) != 2 {
+		t.Fatalf("expected two held orders, got %d/%d err=%v", len(queue), total, err)
+	}
+
+	if _, err := svc.Approve(orderriskcontract.ReviewDecisionInput{OrderID: orders[2].ID}); !errors.Is(err, orderriskcontract.ErrReviewOrderNotHeld) {
+		t.Fatalf("expected not held error, got %v", err)
+	}
+	if _, err := svc.Reject(orderriskcontract.ReviewDecisionInput{OrderID: orders[1].ID, RefundMode: "wallet"}); !errors.Is(err, orderriskcontract.ErrReviewRefundModeInvalid) {
+		t.Fatalf("guest wallet refund must be rejected, got %v", err)
+	}
+
+	released, err := svc.Approve(orderriskcontract.ReviewDecisionInput{OrderID: orders[0].ID, OperatorAdminID: 9, OperatorUsername: "alice", Reason: "verified buyer"})
+	if err != nil || released.Status != constants.OrderStatusFulfilling || !reflect.DeepEqual(releaser.released, []uint{orders[0].ID}) {
+		t.Fatalf("unexpected approve result %+v released=%v err=%v", released, releaser.released, err)
+	}
+	if _, err := svc.Reject(orderriskcontract.ReviewDecisionInput{OrderID: orders[1].ID, OperatorAdminID: 9, Reason: "stolen card"}); err != nil {
+		t.Fatalf("reject guest order: %v", err)
+	}
+	if len(refunder.inputs) != 1 || refunder.inputs[0].Mode != orderriskdomain.ReviewRefundManual ||
+		!refunder.inputs[0].Amount.Decimal.Equal(decimal.NewFromInt(10)) || refunder.inputs[0].Remark != "stolen card" {
+		t.Fatalf("unexpected refund input %+v", refunder.inputs)
+	}
+
+	detail, err := svc.GetReview(orders[0].ID)
+	if err != nil || len(detail.Logs) != 2 {
+		t.Fatalf("expected hold and approve logs, got %+v err=%v", detail, err)
+	}
+	approveLog := detail.Logs[1]
+	if approveLog.Action != orderriskdomain.ReviewActionApprove || approveLog.OperatorAdminID != 9 || approveLog.OperatorUsername != "alice" || approveLog.Reason != "verified buyer" {
+		t.Fatalf("unexpected approve log %+v", approveLog)
+	}
+	if _, err := svc.GetReview(999); !errors.Is(err, orderriskcontract.ErrReviewOrderNotFound) {
+		t.Fatalf("expected review not found, got %v", err)
+	}
+}
+
+func TestReviewServiceAlertOverdueOnlyOncePerOrder(t *testing.T) {
+	db := openReviewTestDB(t)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	overdue := now.Add(-3 * time.Hour)
+	recent := now.Add(-10 * time.Minute)
+	orders := []orderdomain.Order{
+		{OrderNo: "O1", Status: constants.OrderStatusHeldForReview, Currency: "CNY", HeldAt: &overdue},
+		{OrderNo: "O2", Status: constants.OrderStatusHeldForReview, Currency: "CNY", HeldAt: &recent},
+	}
+	for i := range orders {
+		if err := db.Create(&orders[i]).Error; err != nil {
+			t.Fatalf("seed order: %v", err)
+		}
+	}
+	cfg := testConfig()
+	cfg.Review.SLAMinutes = 60
+	notifier := &reviewNotifierStub{}
+	svc := NewReviewService(ReviewOptions{
+		Store:    orderriskgormstore.NewReviewStore(db),
+		Settings: settingReaderStub{config: cfg},
+		Notifier: notifier,
+	})
+	svc.now = func() time.Time { return now }
+
+	alerted, err := svc.AlertOverdue()
+	if err != nil || alerted != 1 {
+		t.Fatalf("expected one overdue alert, got %d err=%v", alerted, err)
+	}
+	data := notifier.inputs[0].Data
+	if data["alert_type_key"] != constants.NotificationAlertTypeOrderReviewOverdue || data["order_no"] != "O1" || data["alert_value"] != "180" || data["alert_threshold"] != "60" {
+		t.Fatalf("unexpected overdue alert data %+v", data)
+	}
+	if alerted, err := svc.AlertOverdue(); err != nil || alerted != 0 {
+		t.Fatalf("overdue alert must not repeat, got %d err=%v", alerted, err)
+	}
+
+	cfg.Review.SLAMinutes = 0
+	disabled := NewReviewService(ReviewOptions{Store: orderriskgormstore.NewReviewStore(db), Settings: settingReaderStub{config: cfg}, Notifier: notifier})
+	if alerted, err := disabled.AlertOverdue(); err != nil || alerted != 0 {
+		t.Fatalf("zero SLA must disable alerts, got %d err=%v", alerted, err)
+	}
+}
+
+func openBlacklistTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "risk-blacklist.db")), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	if err := db.AutoMigrate(
+		&userdomain.User{}, &externalidentitydomain.Identity{}, &orderdomain.Order{},
+		&paymentdomain.Payment{}, &orderriskdomain.BlacklistEntry{},
+	); err != nil {
+		t.Fatalf("migrate blacklist tables: %v", err)
+	}
+	return db
+}
+
+func TestBlacklistServiceMatchesEveryIdentityType(t *testing.T) {
+	db := openBlacklistTestDB(t)
+	user := userdomain.User{Email: "Buyer@Example.com", PasswordHash: "x", Status: constants.UserStatusActive}
+	if err := db.Create(&user).Error; err != nil {
+		t.Fatalf("seed user: %v", err)
+	}
+	if err := db.Create(&externalidentitydomain.Identity{UserID: user.ID, Provider: constants.UserOAuthProviderTelegram, ProviderUserID: "424242"}).Error; err != nil {
+		t.Fatalf("seed identity: %v", err)
+	}
+	svc := NewBlacklistService(orderriskgormstore.NewBlacklistStore(db))
+	now := time.Now()
+	svc.now = func() time.Time { return now }
+
+	result, err := svc.Import(orderriskcontract.BlacklistImportInput{
+		Type:   orderriskdomain.BlacklistTypeIPRange,
+		Values: []string{"10.1.0.0/16", "203.0.113.7", "203.0.113.7", "not-an-ip"},
+	})
+	if err != nil || result.Created != 2 || result.Updated != 0 || !reflect.DeepEqual(result.Invalid, []string{"not-an-ip"}) {
+		t.Fatalf("unexpected import result %+v err=%v", result, err)
+	}
+	if _, err := svc.Create(orderriskcontract.BlacklistEntryInput{Type: "phone", Value: "1"}); !errors.Is(err, orderriskcontract.ErrBlacklistTypeInvalid) {
+		t.Fatalf("expected invalid type, got %v", err)
+	}
+
+	cases := []struct {
+		entryType string
+		value     string
+		subject   orderriskcontract.BlacklistSubject
+	}{
+		{orderriskdomain.BlacklistTypeIPRange, "", orderriskcontract.BlacklistSubject{ClientIP: "10.1.200.3"}},
+		{orderriskdomain.BlacklistTypeIPRange, "", orderriskcontract.BlacklistSubject{ClientIP: "203.0.113.7"}},
+		{orderriskdomain.BlacklistTypeEmailDomain, "@Spam.test", orderriskcontract.BlacklistSubject{Email: "a@spam.test"}},
+		{orderriskdomain.BlacklistTypeEmail, "buyer@example.com", orderriskcontract.BlacklistSubject{UserID: user.ID}},
+		{orderriskdomain.BlacklistTypeTelegramID, "424242", orderriskcontract.BlacklistSubject{UserID: user.ID}},
+		{orderriskdomain.BlacklistTypePayer, "PayPal:Fraud@Pay.test", orderriskcontract.BlacklistSubject{PayerIdentities: []string{"paypal:fraud@pay.test"}}},
+	}
+	for _, tc := range cases {
+		if tc.value != "" {
+			// 每个用例只保留 IP 段与当前条目，确保命中来自被测维度
+			if err := db.Where("type <> ?", orderriskdomain.BlacklistTypeIPRange).Delete(&orderriskdomain.BlacklistEntry{}).Error; err != nil {
+				t.Fatalf("reset entries: %v", err)
+			}
+			if _, err := svc.Create(orderriskcontract.BlacklistEntryInput{Type: tc.entryType, Value: tc.value}); err != nil {
+				t.Fatalf("create %s entry: %v", tc.entryType, err)
+			}
+		}
+		if err := svc.CheckCustomer(tc.subject); !errors.Is(err, orderriskcontract.ErrCustomerBlacklisted) {
+			t.Fatalf("expected %s hit for %+v, got %v", tc.entryType, tc.subject, err)
+		}
+	}
+	if err := svc.CheckCustomer(orderriskcontract.BlacklistSubject{ClientIP: "10.2.0.1", Email: "clean@example.org"}); err != nil {
+		t.Fatalf("expected clean subject to pass, got %v", err)
+	}
+
+	expired := now.Add(-time.Minute)
+	if _, err := svc.Create(orderriskcontract.BlacklistEntryInput{Type: orderriskdomain.BlacklistTypeEmail, Value: "old@example.org", ExpiresAt: &expired}); err != nil {
+		t.Fatalf("create expired entry: %v", err)
+	}
+	if err := svc.CheckCustomer(orderriskcontract.BlacklistSubject{Email: "old@example.org"}); err != nil {
+		t.Fatalf("expired entry must not match, got %v", err)
+	}
+	active, total, err := svc.List(orderriskcontract.BlacklistFilter{Page: 1, PageSize: 20, Type: orderriskdomain.BlacklistTypeEmail})
+	if err != nil || total != 0 || len(active) != 0 {
+		t.Fatalf("expired entries must be hidden by default, got %d/%d err=%v", len(active), total, err)
+	}
+}
+
+func TestBlacklistServiceBlacklistOrderCustomer(t *testing.T) {
+	db := openBlacklistTestDB(t)
+	user := userdomain.User{Email: "member@example.com", PasswordHash: "x", Status: constants.UserStatusActive}
+	if err := db.Create(&user).Error; err != nil {
+		t.Fatalf("seed user: %v", err)
+	}
+	order := orderdomain.Order{OrderNo: "B1", UserID: user.ID, ClientIP: "198.51.100.9", Status: constants.OrderStatusPaid, Currency: "CNY"}
+	if err := db.Create(&order).Error; err != nil {
+		t.Fatalf("seed order: %v", err)
+	}
+	payment := paymentdomain.Payment{
+		OrderID:         order.ID,
+		ProviderType:    constants.PaymentProviderOfficial,
+		ChannelType:     constants.PaymentChannelTypeAlipay,
+		Status:          constants.PaymentStatusSuccess,
+		Currency:        "CNY",
+		ProviderPayload: map[string]interface{}{"buyer_id": "2088000111"},
+	}
+	if err := db.Create(&payment).Error; err != nil {
+		t.Fatalf("seed payment: %v", err)
+	}
+	svc := NewBlacklistService(orderriskgormstore.NewBlacklistStore(db))
+
+	entries, err := svc.BlacklistOrderCustomer(orderriskcontract.BlacklistOrderCustomerInput{
+		OrderID:  order.ID,
+		Operator: orderriskcontract.BlacklistOperator{AdminID: 3, Username: "bob"},
+	})
+	if err != nil {
+		t.Fatalf("blacklist order customer: %v", err)
+	}
+	got := map[string]string{}
+	for _, entry := range entries {
+		if entry.SourceOrderID != order.ID || entry.CreatedByAdminID != 3 || entry.Reason != "订单 B1 拉黑" {
+			t.Fatalf("unexpected entry metadata %+v", entry)
+		}
+		got[entry.Type] = entry.Value
+	}
+	want := map[string]string{
+		orderriskdomain.BlacklistTypeUserID:  strconv.FormatUint(uint64(user.ID), 10),
+		orderriskdomain.BlacklistTypeEmail:   "member@example.com",
+		orderriskdomain.BlacklistTypeIPRange: "198.51.100.9/32",
+		orderriskdomain.BlacklistTypePayer:   "alipay:2088000111",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected blacklisted identities %v", got)
+	}
+
+	if _, err := svc.BlacklistOrderCustomer(orderriskcontract.BlacklistOrderCustomerInput{OrderID: 999}); !errors.Is(err, orderriskcontract.ErrBlacklistOrderNotFound) {
+		t.Fatalf("expected order not found, got %v", err)
+	}
+	risk := NewService(Options{Settings: settingReaderStub{config: settingssecurity.DefaultOrderRiskControlConfig()}, Blacklist: svc})
+	if _, err := risk.CheckOrderAllowed(orderriskcontract.CheckInput{IsGuest: true, GuestEmail: "member@example.com", ClientIP: "192.0.2.1"}); !errors.Is(err, orderriskcontract.ErrCustomerBlacklisted) {
+		t.Fatalf("blacklisted guest email must be rejected even with risk control disabled, got %v", err)
+	}
+}
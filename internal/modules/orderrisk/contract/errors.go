@@ -9,6 +9,16 @@ var (
 	ErrProductQuantityLimit        = errors.New("risk: product quantity limit")
 	ErrPendingProductQuantityLimit = errors.New("risk: pending product quantity limit")
 	ErrOrderRateLimited            = errors.New("risk: order rate limited")
+	ErrChallengeRequired           = errors.New("risk: challenge required")
+	ErrOrderBlocked                = errors.New("risk: order blocked")
+	ErrReviewOrderNotFound         = errors.New("risk: review order not found")
+	ErrReviewOrderNotHeld          = errors.New("risk: order is not held for review")
+	ErrReviewRefundModeInvalid     = errors.New("risk: review refund mode invalid")
+	ErrCustomerBlacklisted         = errors.New("risk: customer blacklisted")
+	ErrBlacklistTypeInvalid        = errors.New("risk: blacklist type invalid")
+	ErrBlacklistValueInvalid       = errors.New("risk: blacklist value invalid")
+	ErrBlacklistEntryNotFound      = errors.New("risk: blacklist entry not found")
+	ErrBlacklistOrderNotFound      = errors.New("risk: blacklist source order not found")
 )
 
 // RateLimitedError 携带 Retry-After 秒数。
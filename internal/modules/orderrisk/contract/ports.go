@@ -1,6 +1,13 @@
 package contract
 
-import settingssecurity "github.com/dujiao-next/internal/modules/settings/schema/security"
+import (
+	"time"
+
+	notificationcontract "github.com/dujiao-next/internal/modules/notification/contract"
+	orderdomain "github.com/dujiao-next/internal/modules/order/domain"
+	orderriskdomain "github.com/dujiao-next/internal/modules/orderrisk/domain"
+	settingssecurity "github.com/dujiao-next/internal/modules/settings/schema/security"
+)
 
 type SettingReader interface {
 	GetOrderRiskControlConfig() (settingssecurity.OrderRiskControlConfig, error)
@@ -20,8 +27,63 @@ type RateLimiter interface {
 	Check(input CheckInput, config settingssecurity.OrderRateLimitConfig) error
 }
 
+// SignalReader 读取风险评分所需的历史订单、支付与账户信号。
+type SignalReader interface {
+	GetUserSignal(userID uint) (*UserSignal, error)
+	CountRecentOrders(scope SignalScope) (int64, error)
+	CountRecentFailedPayments(scope SignalScope) (int64, error)
+	// CountOtherGuestEmails 统计同一 IP/设备下使用过的其他游客邮箱数量。
+	CountOtherGuestEmails(scope SignalScope) (int64, error)
+}
+
 // Controller 是订单上下文调用风控所需的用例端口。
 type Controller interface {
 	CheckOrderAllowed(input CheckInput) (CheckResult, error)
 	CheckPendingOrderAllowed(input CheckInput, prepared CheckResult, gate PendingOrderGate) error
+	Assess(input AssessInput) (Assessment, error)
+}
+
+// ReviewStore 读写人工复核队列与复核审计日志；订单读取均带子订单与订单项。
+type ReviewStore interface {
+	ListHeldOrders(filter ReviewQueueFilter) ([]orderdomain.Order, int64, error)
+	GetOrder(id uint) (*orderdomain.Order, error)
+	ListOverdueUnalerted(heldBefore time.Time, limit int) ([]orderdomain.Order, error)
+	MarkReviewAlerted(orderIDs []uint, at time.Time) error
+	CreateLog(log *orderriskdomain.ReviewLog) error
+	ListLogs(orderID uint) ([]orderriskdomain.ReviewLog, error)
+}
+
+// HeldOrderReleaser 放行待复核订单，并恢复自动交付、人工交付提醒与上游采购。
+type HeldOrderReleaser interface {
+	ReleaseHeldOrder(orderID uint) (*orderdomain.Order, error)
+}
+
+// HeldOrderRefunder 驳回待复核订单时经订单退款流程全额退款。
+type HeldOrderRefunder interface {
+	RefundHeldOrder(input HeldOrderRefundInput) error
+}
+
+// ReviewNotifier 通过通知中心发送复核待办与超时告警。
+type ReviewNotifier interface {
+	Enqueue(input notificationcontract.EnqueueInput) error
+}
+
+// BlacklistStore 读写客户黑名单，并读取拉黑订单客户所需的账户与支付信息。
+type BlacklistStore interface {
+	ListEntries(filter BlacklistFilter, now time.Time) ([]orderriskdomain.BlacklistEntry, int64, error)
+	GetEntry(id uint) (*orderriskdomain.BlacklistEntry, error)
+	// UpsertEntry 按 (type, value) 新增或覆盖条目，返回是否为新建。
+	UpsertEntry(entry *orderriskdomain.BlacklistEntry) (bool, error)
+	DeleteEntry(id uint) error
+	// FindActive 返回给定类型与值中尚未过期的第一条命中条目，未命中时返回 nil。
+	FindActive(entryType string, values []string, now time.Time) (*orderriskdomain.BlacklistEntry, error)
+	ListActiveByType(entryType string, now time.Time) ([]orderriskdomain.BlacklistEntry, error)
+	GetCustomerIdentity(userID uint) (*CustomerIdentity, error)
+	GetOrder(id uint) (*orderdomain.Order, error)
+	ListOrderSuccessPayments(orderID uint) ([]OrderCustomerPayment, error)
+}
+
+// CustomerBlacklist 供登录、下单与支付入口判断客户是否被拉黑；命中时返回 ErrCustomerBlacklisted。
+type CustomerBlacklist interface {
+	CheckCustomer(subject BlacklistSubject) error
 }
@@ -3,8 +3,12 @@ package contract
 import (
 	"net"
 	"strings"
+	"time"
 
+	orderdomain "github.com/dujiao-next/internal/modules/order/domain"
+	orderriskdomain "github.com/dujiao-next/internal/modules/orderrisk/domain"
 	settingssecurity "github.com/dujiao-next/internal/modules/settings/schema/security"
+	"github.com/dujiao-next/internal/shared/money"
 )
 
 // OrderItem 是风控所需的最小订单项快照。
@@ -16,6 +20,7 @@ type OrderItem struct {
 // CheckInput 是订单风控检查所需的调用上下文。
 type CheckInput struct {
 	UserID           uint
+	GuestEmail       string
 	ClientIP         string
 	RiskIP           string
 	IsGuest          bool
@@ -31,6 +36,149 @@ type CheckResult struct {
 	ConfigSnapshot       settingssecurity.OrderRiskControlConfig
 }
 
+// 风险评分阶段。
+const (
+	StageOrder   = "order"
+	StagePayment = "payment"
+)
+
+// AssessInput 是风险评分所需的订单主体快照。
+type AssessInput struct {
+	Stage           string
+	OrderID         uint // 支付阶段复评时排除当前订单自身
+	UserID          uint
+	GuestEmail      string
+	RiskIP          string
+	DeviceHash      string
+	ChallengePassed bool
+}
+
+// Assessment 是风险评分结果，会写入订单供后台复核。
+type Assessment struct {
+	Score    int
+	Decision string
+	Reasons  []string
+}
+
+// UserSignal 是评分所需的会员账户快照。
+type UserSignal struct {
+	Email     string
+	CreatedAt time.Time
+}
+
+// SignalScope 描述历史信号统计口径；仅非空主体参与过滤。
+type SignalScope struct {
+	UserID         uint
+	Email          string
+	RiskIP         string
+	DeviceHash     string
+	ExcludeOrderID uint
+	Since          time.Time
+}
+
+// ReviewQueueFilter 人工复核队列查询条件。
+type ReviewQueueFilter struct {
+	Page       int
+	PageSize   int
+	OrderNo    string
+	HeldBefore *time.Time // 仅返回早于该时间挂起的订单，用于筛选已超时项
+}
+
+// HeldOrderRefundInput 驳回复核时的全额退款请求。
+type HeldOrderRefundInput struct {
+	OrderID uint
+	Amount  money.Amount
+	Mode    string // wallet/manual
+	Remark  string
+}
+
+// ReviewDecisionInput 复核放行或驳回的操作参数。
+type ReviewDecisionInput struct {
+	OrderID          uint
+	OperatorAdminID  uint
+	OperatorUsername string
+	Reason           string
+	RefundMode       string // 仅驳回使用：wallet/manual，留空时会员退余额、游客手动退款
+}
+
+// ReviewDetail 复核详情：订单（含子订单）与完整审计记录。
+type ReviewDetail struct {
+	Order *orderdomain.Order          `json:"order"`
+	Logs  []orderriskdomain.ReviewLog `json:"logs"`
+}
+
+// BlacklistSubject 是黑名单匹配的客户身份快照；仅非空字段参与匹配，
+// 提供 UserID 时会补充该账户的邮箱与已绑定的 Telegram ID。
+type BlacklistSubject struct {
+	UserID          uint
+	Email           string
+	ClientIP        string
+	TelegramIDs     []string
+	PayerIdentities []string
+}
+
+// CustomerIdentity 是会员账户可用于黑名单匹配的身份。
+type CustomerIdentity struct {
+	Email       string
+	TelegramIDs []string
+}
+
+// BlacklistFilter 黑名单列表查询条件。
+type BlacklistFilter struct {
+	Page           int
+	PageSize       int
+	Type           string
+	Keyword        string
+	IncludeExpired bool
+}
+
+// BlacklistOperator 记录添加黑名单的管理员。
+type BlacklistOperator struct {
+	AdminID  uint
+	Username string
+}
+
+// BlacklistEntryInput 新增或更新单个黑名单条目；同类型同值的条目会被覆盖原因与有效期。
+type BlacklistEntryInput struct {
+	Type      string
+	Value     string
+	Reason    string
+	ExpiresAt *time.Time
+	Operator  BlacklistOperator
+}
+
+// BlacklistImportInput 批量导入同一类型的黑名单值。
+type BlacklistImportInput struct {
+	Type      string
+	Values    []string
+	Reason    string
+	ExpiresAt *time.Time
+	Operator  BlacklistOperator
+}
+
+// BlacklistImportResult 批量导入结果；非法值不中断导入，逐条返回。
+type BlacklistImportResult struct {
+	Created int      `json:"created"`
+	Updated int      `json:"updated"`
+	Invalid []string `json:"invalid"`
+}
+
+// BlacklistOrderCustomerInput 从订单详情拉黑下单客户；Types 为空时拉黑订单上可识别的全部身份。
+type BlacklistOrderCustomerInput struct {
+	OrderID   uint
+	Types     []string
+	Reason    string
+	ExpiresAt *time.Time
+	Operator  BlacklistOperator
+}
+
+// OrderCustomerPayment 是订单成功支付记录中可提取付款人身份的部分。
+type OrderCustomerPayment struct {
+	ProviderType string
+	ChannelType  string
+	Payload      map[string]interface{}
+}
+
 // NormalizeRiskIP 生成游客风控键：IPv4 使用完整地址，IPv6 按 /64 前缀聚合。
 func NormalizeRiskIP(raw string) string {
 	ip := net.ParseIP(strings.TrimSpace(raw))
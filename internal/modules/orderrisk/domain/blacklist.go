@@ -0,0 +1,178 @@
+package domain
+
+import (
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dujiao-next/internal/constants"
+)
+
+// 客户黑名单条目类型。
+const (
+	BlacklistTypeUserID      = "user_id"
+	BlacklistTypeEmail       = "email"
+	BlacklistTypeEmailDomain = "email_domain"
+	BlacklistTypeIPRange     = "ip_range"
+	BlacklistTypeTelegramID  = "telegram_id"
+	BlacklistTypePayer       = "payer" // 支付网关付款人身份，值形如 alipay:<buyer_id>、paypal:<email>
+)
+
+// 付款人身份的网关前缀。
+const (
+	PayerProviderAlipay = "alipay"
+	PayerProviderPaypal = "paypal"
+)
+
+// BlacklistEntry 客户黑名单条目；同一类型下规范化后的值唯一。
+type BlacklistEntry struct {
+	ID                uint       `gorm:"primarykey" json:"id"`
+	Type              string     `gorm:"type:varchar(20);not null;uniqueIndex:idx_customer_blacklist_type_value" json:"type"`
+	Value             string     `gorm:"type:varchar(255);not null;uniqueIndex:idx_customer_blacklist_type_value" json:"value"`
+	Reason            string     `gorm:"type:varchar(500);not null;default:''" json:"reason"`
+	ExpiresAt         *time.Time `gorm:"index" json:"expires_at"` // 为空表示永久生效
+	CreatedByAdminID  uint       `gorm:"index;not null;default:0" json:"created_by_admin_id"`
+	CreatedByUsername string     `gorm:"type:varchar(100);not null;default:''" json:"created_by_username"`
+	SourceOrderID     uint       `gorm:"index;not null;default:0" json:"source_order_id"` // 从订单详情拉黑时记录来源订单
+	CreatedAt         time.Time  `gorm:"index" json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+func (BlacklistEntry) TableName() string { return "customer_blacklist_entries" }
+
+// IsActive 判断条目在给定时间是否仍然生效。
+func (e BlacklistEntry) IsActive(now time.Time) bool {
+	return e.ExpiresAt == nil || e.ExpiresAt.After(now)
+}
+
+// IsBlacklistType 判断是否为受支持的黑名单类型。
+func IsBlacklistType(entryType string) bool {
+	switch entryType {
+	case BlacklistTypeUserID, BlacklistTypeEmail, BlacklistTypeEmailDomain,
+		BlacklistTypeIPRange, BlacklistTypeTelegramID, BlacklistTypePayer:
+		return true
+	default:
+		return false
+	}
+}
+
+// NormalizeBlacklistValue 按类型规范化条目值，保证存储与匹配使用同一形式；值非法时返回 false。
+// IP 条目统一保存为 CIDR，单个地址按 /32 或 /128 保存。
+func NormalizeBlacklistValue(entryType, raw string) (string, bool) {
+	value := strings.TrimSpace(raw)
+	if value == "" {
+		return "", false
+	}
+	switch entryType {
+	case BlacklistTypeUserID:
+		id, err := strconv.ParseUint(value, 10, 64)
+		if err != nil || id == 0 {
+			return "", false
+		}
+		return strconv.FormatUint(id, 10), true
+	case BlacklistTypeEmail:
+		value = strings.ToLower(value)
+		if EmailDomain(value) == "" {
+			return "", false
+		}
+		return value, true
+	case BlacklistTypeEmailDomain:
+		value = strings.TrimPrefix(strings.ToLower(value), "@")
+		if value == "" || strings.ContainsAny(value, "@ \t") || !strings.Contains(value, ".") {
+			return "", false
+		}
+		return value, true
+	case BlacklistTypeIPRange:
+		if !strings.Contains(value, "/") {
+			ip := net.ParseIP(value)
+			if ip == nil {
+				return "", false
+			}
+			if ipv4 := ip.To4(); ipv4 != nil {
+				return ipv4.String() + "/32", true
+			}
+			return ip.String() + "/128", true
+		}
+		_, network, err := net.ParseCIDR(value)
+		if err != nil {
+			return "", false
+		}
+		return network.String(), true
+	case BlacklistTypeTelegramID:
+		id, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || id == 0 {
+			return "", false
+		}
+		return strconv.FormatInt(id, 10), true
+	case BlacklistTypePayer:
+		provider, id, ok := strings.Cut(value, ":")
+		if !ok {
+			return "", false
+		}
+		identity := PayerIdentity(provider, id)
+		return identity, identity != ""
+	default:
+		return "", false
+	}
+}
+
+// PayerIdentity 组合网关前缀与付款人标识；任一为空时返回空串。
+func PayerIdentity(provider, id string) string {
+	provider = strings.ToLower(strings.TrimSpace(provider))
+	id = strings.ToLower(strings.TrimSpace(id))
+	if provider == "" || id == "" {
+		return ""
+	}
+	return provider + ":" + id
+}
+
+// PayerIdentitiesFromPayload 从已验签的网关回调原文中提取付款人身份：
+// 官方支付宝取 buyer_id/buyer_open_id，PayPal 取 payer 的邮箱与 payer_id（含 Webhook 的 event.resource.payer）。
+func PayerIdentitiesFromPayload(providerType, channelType string, payload map[string]interface{}) []string {
+	if len(payload) == 0 {
+		return nil
+	}
+	var identities []string
+	add := func(provider string, raw interface{}) {
+		value, _ := raw.(string)
+		if identity := PayerIdentity(provider, value); identity != "" {
+			identities = append(identities, identity)
+		}
+	}
+	channelType = strings.ToLower(strings.TrimSpace(channelType))
+	switch {
+	case strings.EqualFold(strings.TrimSpace(providerType), constants.PaymentProviderOfficial) && channelType == constants.PaymentChannelTypeAlipay:
+		add(PayerProviderAlipay, payload["buyer_id"])
+		add(PayerProviderAlipay, payload["buyer_open_id"])
+	case channelType == constants.PaymentChannelTypePaypal:
+		payers := []interface{}{payload["payer"]}
+		if event, ok := payload["event"].(map[string]interface{}); ok {
+			if resource, ok := event["resource"].(map[string]interface{}); ok {
+				payers = append(payers, resource["payer"])
+			}
+		}
+		for _, raw := range payers {
+			payer, ok := raw.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			add(PayerProviderPaypal, payer["email_address"])
+			add(PayerProviderPaypal, payer["payer_id"])
+		}
+	}
+	return identities
+}
+
+// IPRangeContains 判断 CIDR 条目是否包含给定地址。
+func IPRangeContains(cidr, rawIP string) bool {
+	ip := net.ParseIP(strings.TrimSpace(rawIP))
+	if ip == nil {
+		return false
+	}
+	_, network, err := net.ParseCIDR(strings.TrimSpace(cidr))
+	if err != nil {
+		return false
+	}
+	return network.Contains(ip)
+}
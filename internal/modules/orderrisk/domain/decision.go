@@ -0,0 +1,32 @@
+package domain
+
+// 风险评分处置结果，按严重程度递增。
+const (
+	DecisionAllow     = "allow"
+	DecisionChallenge = "challenge"
+	DecisionHold      = "hold"
+	DecisionBlock     = "block"
+)
+
+var decisionRank = map[string]int{
+	DecisionAllow:     0,
+	DecisionChallenge: 1,
+	DecisionHold:      2,
+	DecisionBlock:     3,
+}
+
+// DecisionRank 返回处置严重程度；未知值视为 allow。
+func DecisionRank(decision string) int {
+	return decisionRank[decision]
+}
+
+// MoreSevereDecision 返回两个处置中更严重的一个，用于支付阶段复评时只升级不降级。
+func MoreSevereDecision(current, next string) string {
+	if DecisionRank(next) > DecisionRank(current) {
+		return next
+	}
+	if current == "" {
+		return DecisionAllow
+	}
+	return current
+}
@@ -0,0 +1,72 @@
+package domain
+
+import "strings"
+
+// builtinDisposableDomains 内置的常见一次性邮箱域名，后台可追加自定义列表。
+var builtinDisposableDomains = map[string]struct{}{
+	"10minutemail.com":  {},
+	"20minutemail.com":  {},
+	"dispostable.com":   {},
+	"emailondeck.com":   {},
+	"fakeinbox.com":     {},
+	"getnada.com":       {},
+	"guerrillamail.com": {},
+	"guerrillamail.net": {},
+	"maildrop.cc":       {},
+	"mailinator.com":    {},
+	"mailnesia.com":     {},
+	"mintemail.com":     {},
+	"moakt.com":         {},
+	"sharklasers.com":   {},
+	"temp-mail.org":     {},
+	"tempmail.com":      {},
+	"tempmailo.com":     {},
+	"throwawaymail.com": {},
+	"trashmail.com":     {},
+	"yopmail.com":       {},
+}
+
+// EmailDomain 返回小写邮箱域名；无法解析时返回空串。
+func EmailDomain(email string) string {
+	email = strings.ToLower(strings.TrimSpace(email))
+	at := strings.LastIndex(email, "@")
+	if at <= 0 || at == len(email)-1 {
+		return ""
+	}
+	return email[at+1:]
+}
+
+// IsDisposableDomain 判断域名（含子域名）是否属于内置或额外配置的一次性邮箱。
+func IsDisposableDomain(domain string, extra []string) bool {
+	if domain == "" {
+		return false
+	}
+	return matchDomain(domain, extra, builtinDisposableDomains)
+}
+
+// MatchDomainList 判断域名（含子域名）是否命中配置列表。
+func MatchDomainList(domain string, list []string) bool {
+	if domain == "" {
+		return false
+	}
+	return matchDomain(domain, list, nil)
+}
+
+func matchDomain(domain string, list []string, builtin map[string]struct{}) bool {
+	for candidate := domain; candidate != ""; {
+		if _, ok := builtin[candidate]; ok {
+			return true
+		}
+		for _, entry := range list {
+			if entry == candidate {
+				return true
+			}
+		}
+		dot := strings.Index(candidate, ".")
+		if dot < 0 {
+			break
+		}
+		candidate = candidate[dot+1:]
+	}
+	return false
+}
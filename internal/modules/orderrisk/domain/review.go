@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/dujiao-next/internal/shared/jsonmap"
+)
+
+// 人工复核审计动作。
+const (
+	ReviewActionHold     = "hold"
+	ReviewActionApprove  = "approve"
+	ReviewActionReject   = "reject"
+	ReviewActionSLAAlert = "sla_alert"
+)
+
+// 驳回时的退款去向。
+const (
+	ReviewRefundWallet = "wallet"
+	ReviewRefundManual = "manual"
+)
+
+// ReviewLog 订单人工复核审计日志，记录挂起、放行、驳回与超时告警。
+type ReviewLog struct {
+	ID               uint         `gorm:"primarykey" json:"id"`
+	OrderID          uint         `gorm:"index;not null" json:"order_id"`
+	OrderNo          string       `gorm:"type:varchar(64);index;not null;default:''" json:"order_no"`
+	Action           string       `gorm:"type:varchar(20);index;not null" json:"action"`
+	OperatorAdminID  uint         `gorm:"index;not null;default:0" json:"operator_admin_id"` // 系统动作为 0
+	OperatorUsername string       `gorm:"type:varchar(100);not null;default:''" json:"operator_username"`
+	Reason           string       `gorm:"type:varchar(500);not null;default:''" json:"reason"`
+	DetailJSON       jsonmap.JSON `gorm:"type:json" json:"detail"`
+	CreatedAt        time.Time    `gorm:"index" json:"created_at"`
+}
+
+func (ReviewLog) TableName() string { return "order_review_logs" }
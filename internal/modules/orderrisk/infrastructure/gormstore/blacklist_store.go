@@ -0,0 +1,186 @@
+package gormstore
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/dujiao-next/internal/constants"
+	externalidentitydomain "github.com/dujiao-next/internal/modules/identity/externalidentity/domain"
+	userdomain "github.com/dujiao-next/internal/modules/identity/user/domain"
+	orderdomain "github.com/dujiao-next/internal/modules/order/domain"
+	orderriskcontract "github.com/dujiao-next/internal/modules/orderrisk/contract"
+	orderriskdomain "github.com/dujiao-next/internal/modules/orderrisk/domain"
+	paymentdomain "github.com/dujiao-next/internal/modules/payment/domain"
+	"github.com/dujiao-next/internal/persistence/gormutil"
+
+	"gorm.io/gorm"
+)
+
+// BlacklistStore 持久化客户黑名单，并读取拉黑订单客户所需的账户、订单与支付记录。
+type BlacklistStore struct {
+	db *gorm.DB
+}
+
+var _ orderriskcontract.BlacklistStore = (*BlacklistStore)(nil)
+
+func NewBlacklistStore(db *gorm.DB) *BlacklistStore {
+	return &BlacklistStore{db: db}
+}
+
+// ListEntries 按创建时间倒序分页返回黑名单条目，默认不含已过期条目。
+func (s *BlacklistStore) ListEntries(filter orderriskcontract.BlacklistFilter, now time.Time) ([]orderriskdomain.BlacklistEntry, int64, error) {
+	query := s.db.Model(&orderriskdomain.BlacklistEntry{})
+	if entryType := strings.TrimSpace(filter.Type); entryType != "" {
+		query = query.Where("type = ?", entryType)
+	}
+	if keyword := strings.ToLower(strings.TrimSpace(filter.Keyword)); keyword != "" {
+		like := "%" + keyword + "%"
+		query = query.Where("value LIKE ? OR reason LIKE ?", like, like)
+	}
+	if !filter.IncludeExpired {
+		query = query.Where("expires_at IS NULL OR expires_at > ?", now)
+	}
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var entries []orderriskdomain.BlacklistEntry
+	dataQuery := query.Session(&gorm.Session{}).Order("id DESC")
+	if err := gormutil.ApplyPagination(dataQuery, filter.Page, filter.PageSize).Find(&entries).Error; err != nil {
+		return nil, 0, err
+	}
+	return entries, total, nil
+}
+
+// GetEntry 读取条目；不存在时返回 nil。
+func (s *BlacklistStore) GetEntry(id uint) (*orderriskdomain.BlacklistEntry, error) {
+	var entry orderriskdomain.BlacklistEntry
+	if err := s.db.Where("id = ?", id).Take(&entry).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// UpsertEntry 同类型同值已存在时覆盖原因、有效期与操作人，保留原条目 ID 与创建时间。
+func (s *BlacklistStore) UpsertEntry(entry *orderriskdomain.BlacklistEntry) (bool, error) {
+	if entry == nil {
+		return false, nil
+	}
+	created := false
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var existing orderriskdomain.BlacklistEntry
+		err := tx.Where("type = ? AND value = ?", entry.Type, entry.Value).Take(&existing).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			created = true
+			return tx.Create(entry).Error
+		}
+		if err != nil {
+			return err
+		}
+		if err := tx.Model(&existing).Updates(map[string]interface{}{
+			"reason":              entry.Reason,
+			"expires_at":          entry.ExpiresAt,
+			"created_by_admin_id": entry.CreatedByAdminID,
+			"created_by_username": entry.CreatedByUsername,
+			"source_order_id":     entry.SourceOrderID,
+			"updated_at":          time.Now(),
+		}).Error; err != nil {
+			return err
+		}
+		entry.ID = existing.ID
+		entry.CreatedAt = existing.CreatedAt
+		return nil
+	})
+	return created, err
+}
+
+func (s *BlacklistStore) DeleteEntry(id uint) error {
+	return s.db.Where("id = ?", id).Delete(&orderriskdomain.BlacklistEntry{}).Error
+}
+
+// FindActive 返回命中的第一条未过期条目；未命中时返回 nil。
+func (s *BlacklistStore) FindActive(entryType string, values []string, now time.Time) (*orderriskdomain.BlacklistEntry, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	var entries []orderriskdomain.BlacklistEntry
+	if err := s.db.Where("type = ? AND value IN ?", entryType, values).
+		Where("expires_at IS NULL OR expires_at > ?", now).
+		Order("id ASC").Limit(1).
+		Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	return &entries[0], nil
+}
+
+// ListActiveByType 返回某类型全部未过期条目，用于 IP 段等无法按值精确查询的匹配。
+func (s *BlacklistStore) ListActiveByType(entryType string, now time.Time) ([]orderriskdomain.BlacklistEntry, error) {
+	var entries []orderriskdomain.BlacklistEntry
+	if err := s.db.Where("type = ?", entryType).
+		Where("expires_at IS NULL OR expires_at > ?", now).
+		Order("id ASC").
+		Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// GetCustomerIdentity 读取会员邮箱与已绑定的 Telegram ID；用户不存在时返回 nil。
+func (s *BlacklistStore) GetCustomerIdentity(userID uint) (*orderriskcontract.CustomerIdentity, error) {
+	if userID == 0 {
+		return nil, nil
+	}
+	var user userdomain.User
+	if err := s.db.Select("id", "email").Where("id = ?", userID).Take(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var telegramIDs []string
+	if err := s.db.Model(&externalidentitydomain.Identity{}).
+		Where("user_id = ? AND provider = ?", userID, constants.UserOAuthProviderTelegram).
+		Pluck("provider_user_id", &telegramIDs).Error; err != nil {
+		return nil, err
+	}
+	return &orderriskcontract.CustomerIdentity{Email: user.Email, TelegramIDs: telegramIDs}, nil
+}
+
+// GetOrder 读取父订单；不存在时返回 nil。
+func (s *BlacklistStore) GetOrder(id uint) (*orderdomain.Order, error) {
+	var order orderdomain.Order
+	if err := s.db.Where("orders.deleted_at IS NULL AND orders.id = ?", id).Take(&order).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &order, nil
+}
+
+// ListOrderSuccessPayments 返回订单成功支付记录的网关类型与回调原文。
+func (s *BlacklistStore) ListOrderSuccessPayments(orderID uint) ([]orderriskcontract.OrderCustomerPayment, error) {
+	var payments []paymentdomain.Payment
+	if err := s.db.Select("id", "provider_type", "channel_type", "provider_payload").
+		Where("order_id = ? AND status = ? AND deleted_at IS NULL", orderID, constants.PaymentStatusSuccess).
+		Order("id ASC").
+		Find(&payments).Error; err != nil {
+		return nil, err
+	}
+	result := make([]orderriskcontract.OrderCustomerPayment, 0, len(payments))
+	for _, payment := range payments {
+		result = append(result, orderriskcontract.OrderCustomerPayment{
+			ProviderType: payment.ProviderType,
+			ChannelType:  payment.ChannelType,
+			Payload:      payment.ProviderPayload,
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,110 @@
+package gormstore
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/dujiao-next/internal/constants"
+	orderdomain "github.com/dujiao-next/internal/modules/order/domain"
+	orderriskcontract "github.com/dujiao-next/internal/modules/orderrisk/contract"
+	orderriskdomain "github.com/dujiao-next/internal/modules/orderrisk/domain"
+	"github.com/dujiao-next/internal/persistence/gormutil"
+
+	"gorm.io/gorm"
+)
+
+// ReviewStore 读取待复核父订单并持久化复核审计日志。
+type ReviewStore struct {
+	db *gorm.DB
+}
+
+var _ orderriskcontract.ReviewStore = (*ReviewStore)(nil)
+
+func NewReviewStore(db *gorm.DB) *ReviewStore {
+	return &ReviewStore{db: db}
+}
+
+// ListHeldOrders 按挂起时间升序分页返回待复核父订单，越早挂起越靠前。
+func (s *ReviewStore) ListHeldOrders(filter orderriskcontract.ReviewQueueFilter) ([]orderdomain.Order, int64, error) {
+	query := s.heldOrders()
+	if orderNo := strings.TrimSpace(filter.OrderNo); orderNo != "" {
+		query = query.Where("orders.order_no LIKE ?", "%"+orderNo+"%")
+	}
+	if filter.HeldBefore != nil {
+		query = query.Where("orders.held_at < ?", *filter.HeldBefore)
+	}
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var orders []orderdomain.Order
+	dataQuery := query.Session(&gorm.Session{}).
+		Preload("Items").Preload("Children").Preload("Children.Items").
+		Order("orders.held_at ASC, orders.id ASC")
+	if err := gormutil.ApplyPagination(dataQuery, filter.Page, filter.PageSize).Find(&orders).Error; err != nil {
+		return nil, 0, err
+	}
+	return orders, total, nil
+}
+
+// GetOrder 读取订单及其子订单；不存在时返回 nil。
+func (s *ReviewStore) GetOrder(id uint) (*orderdomain.Order, error) {
+	var order orderdomain.Order
+	if err := s.db.Preload("Items").Preload("Children").Preload("Children.Items").
+		Where("orders.deleted_at IS NULL AND orders.id = ?", id).
+		Take(&order).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &order, nil
+}
+
+// ListOverdueUnalerted 返回挂起早于 heldBefore 且尚未发送超时告警的父订单。
+func (s *ReviewStore) ListOverdueUnalerted(heldBefore time.Time, limit int) ([]orderdomain.Order, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+	var orders []orderdomain.Order
+	if err := s.heldOrders().
+		Where("orders.held_at < ? AND orders.review_alerted_at IS NULL", heldBefore).
+		Order("orders.held_at ASC, orders.id ASC").
+		Limit(limit).
+		Find(&orders).Error; err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+// MarkReviewAlerted 记录超时告警已发送，避免每轮巡检重复告警。
+func (s *ReviewStore) MarkReviewAlerted(orderIDs []uint, at time.Time) error {
+	if len(orderIDs) == 0 {
+		return nil
+	}
+	return s.db.Model(&orderdomain.Order{}).
+		Where("id IN ?", orderIDs).
+		Update("review_alerted_at", at).Error
+}
+
+func (s *ReviewStore) CreateLog(log *orderriskdomain.ReviewLog) error {
+	if log == nil {
+		return nil
+	}
+	return s.db.Create(log).Error
+}
+
+// ListLogs 按时间顺序返回订单的复核审计记录。
+func (s *ReviewStore) ListLogs(orderID uint) ([]orderriskdomain.ReviewLog, error) {
+	var logs []orderriskdomain.ReviewLog
+	if err := s.db.Where("order_id = ?", orderID).Order("id ASC").Find(&logs).Error; err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
+
+func (s *ReviewStore) heldOrders() *gorm.DB {
+	return s.db.Model(&orderdomain.Order{}).
+		Where("orders.deleted_at IS NULL AND orders.parent_id IS NULL AND orders.status = ?", constants.OrderStatusHeldForReview)
+}
@@ -0,0 +1,123 @@
+package gormstore
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/dujiao-next/internal/constants"
+	userdomain "github.com/dujiao-next/internal/modules/identity/user/domain"
+	orderdomain "github.com/dujiao-next/internal/modules/order/domain"
+	orderriskcontract "github.com/dujiao-next/internal/modules/orderrisk/contract"
+	paymentdomain "github.com/dujiao-next/internal/modules/payment/domain"
+
+	"gorm.io/gorm"
+)
+
+// SignalStore 从订单、支付与用户表读取风险评分信号。
+type SignalStore struct {
+	db *gorm.DB
+}
+
+var _ orderriskcontract.SignalReader = (*SignalStore)(nil)
+
+func NewSignalStore(db *gorm.DB) *SignalStore {
+	return &SignalStore{db: db}
+}
+
+// GetUserSignal 读取会员邮箱与注册时间；用户不存在时返回 nil。
+func (s *SignalStore) GetUserSignal(userID uint) (*orderriskcontract.UserSignal, error) {
+	if userID == 0 {
+		return nil, nil
+	}
+	var user userdomain.User
+	if err := s.db.Select("id", "email", "created_at").Where("id = ?", userID).Take(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &orderriskcontract.UserSignal{Email: user.Email, CreatedAt: user.CreatedAt}, nil
+}
+
+// CountRecentOrders 统计窗口内命中主体的父订单数量（含已取消订单，用于识别刷单速率）。
+func (s *SignalStore) CountRecentOrders(scope orderriskcontract.SignalScope) (int64, error) {
+	query, ok := s.scopedOrders(scope)
+	if !ok {
+		return 0, nil
+	}
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// CountRecentFailedPayments 统计窗口内命中主体订单的失败支付次数。
+func (s *SignalStore) CountRecentFailedPayments(scope orderriskcontract.SignalScope) (int64, error) {
+	orders, ok := s.scopedOrders(scope)
+	if !ok {
+		return 0, nil
+	}
+	var count int64
+	if err := s.db.Model(&paymentdomain.Payment{}).
+		Where("payments.deleted_at IS NULL AND payments.status = ? AND payments.created_at >= ?", constants.PaymentStatusFailed, scope.Since).
+		Where("payments.order_id IN (?)", orders.Select("orders.id")).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// CountOtherGuestEmails 统计同一风控 IP 或设备在窗口内使用过的其他游客邮箱数量。
+func (s *SignalStore) CountOtherGuestEmails(scope orderriskcontract.SignalScope) (int64, error) {
+	email := strings.TrimSpace(scope.Email)
+	if scope.RiskIP == "" && scope.DeviceHash == "" {
+		return 0, nil
+	}
+	query := s.db.Model(&orderdomain.Order{}).
+		Where("orders.deleted_at IS NULL AND orders.parent_id IS NULL AND orders.user_id = 0 AND orders.guest_email <> ''").
+		Where("LOWER(orders.guest_email) <> ?", strings.ToLower(email)).
+		Where("orders.created_at >= ?", scope.Since)
+	if scope.RiskIP != "" {
+		query = query.Where("orders.risk_ip = ?", scope.RiskIP)
+	}
+	if scope.DeviceHash != "" {
+		query = query.Where("orders.device_hash = ?", scope.DeviceHash)
+	}
+	if scope.ExcludeOrderID > 0 {
+		query = query.Where("orders.id <> ?", scope.ExcludeOrderID)
+	}
+	var count int64
+	if err := query.Distinct("LOWER(orders.guest_email)").Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// scopedOrders 按非空主体（AND）过滤父订单；没有任何主体时返回 false，避免全表统计。
+func (s *SignalStore) scopedOrders(scope orderriskcontract.SignalScope) (*gorm.DB, bool) {
+	query := s.db.Model(&orderdomain.Order{}).
+		Where("orders.deleted_at IS NULL AND orders.parent_id IS NULL").
+		Where("orders.created_at >= ?", scope.Since)
+	filtered := false
+	if scope.UserID > 0 {
+		query = query.Where("orders.user_id = ?", scope.UserID)
+		filtered = true
+	}
+	if email := strings.TrimSpace(scope.Email); email != "" {
+		query = query.Where("orders.user_id = 0 AND LOWER(orders.guest_email) = ?", strings.ToLower(email))
+		filtered = true
+	}
+	if scope.RiskIP != "" {
+		query = query.Where("orders.risk_ip = ?", scope.RiskIP)
+		filtered = true
+	}
+	if scope.DeviceHash != "" {
+		query = query.Where("orders.device_hash = ?", scope.DeviceHash)
+		filtered = true
+	}
+	if scope.ExcludeOrderID > 0 {
+		query = query.Where("orders.id <> ?", scope.ExcludeOrderID)
+	}
+	return query, filtered
+}
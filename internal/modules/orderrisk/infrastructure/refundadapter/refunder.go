@@ -0,0 +1,81 @@
+package refundadapter
+
+import (
+	"strings"
+
+	"github.com/dujiao-next/internal/logger"
+	orderrefund "github.com/dujiao-next/internal/modules/order/application/refund"
+	orderdomain "github.com/dujiao-next/internal/modules/order/domain"
+	orderriskcontract "github.com/dujiao-next/internal/modules/orderrisk/contract"
+	orderriskdomain "github.com/dujiao-next/internal/modules/orderrisk/domain"
+	"github.com/dujiao-next/internal/queue"
+)
+
+// Refunder 将复核驳回委托给订单退款流程，并补发退款状态邮件。
+// 订单可能在复核中停留超过售后退款期限，驳回退款不受该期限限制。
+type Refunder struct {
+	refunds *orderrefund.Service
+	queue   *queue.Client
+}
+
+var _ orderriskcontract.HeldOrderRefunder = (*Refunder)(nil)
+
+func New(refunds *orderrefund.Service, queueClient *queue.Client) *Refunder {
+	return &Refunder{refunds: refunds, queue: queueClient}
+}
+
+func (r *Refunder) RefundHeldOrder(input orderriskcontract.HeldOrderRefundInput) error {
+	var (
+		order  *orderdomain.Order
+		record *orderdomain.OrderRefundRecord
+		err    error
+	)
+	switch input.Mode {
+	case orderriskdomain.ReviewRefundWallet:
+		order, _, record, err = r.refunds.AdminRefundToWallet(orderrefund.AdminRefundToWalletInput{
+			OrderID:            input.OrderID,
+			Amount:             input.Amount,
+			Remark:             input.Remark,
+			IgnoreRefundWindow: true,
+		})
+	case orderriskdomain.ReviewRefundManual:
+		order, record, err = r.refunds.AdminManualRefund(orderrefund.AdminManualRefundInput{
+			OrderID:            input.OrderID,
+			Amount:             input.Amount,
+			Remark:             input.Remark,
+			IgnoreRefundWindow: true,
+		})
+	default:
+		return orderriskcontract.ErrReviewRefundModeInvalid
+	}
+	if err != nil {
+		return err
+	}
+	r.enqueueStatusEmail(order, record)
+	return nil
+}
+
+func (r *Refunder) enqueueStatusEmail(order *orderdomain.Order, record *orderdomain.OrderRefundRecord) {
+	if r.queue == nil || order == nil || order.ID == 0 {
+		return
+	}
+	status := strings.TrimSpace(order.Status)
+	if status == "" {
+		return
+	}
+	var recordID uint
+	if record != nil {
+		recordID = record.ID
+	}
+	if err := r.queue.EnqueueOrderStatusEmail(queue.OrderStatusEmailPayload{
+		OrderID:        order.ID,
+		Status:         status,
+		RefundRecordID: recordID,
+	}); err != nil {
+		logger.Warnw("order_review_refund_enqueue_status_email_failed",
+			"order_id", order.ID,
+			"status", status,
+			"error", err,
+		)
+	}
+}
@@ -0,0 +1,109 @@
+package integrationtest
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	affiliateapp "github.com/dujiao-next/internal/modules/affiliate/application"
+	affiliatedomain "github.com/dujiao-next/internal/modules/affiliate/domain"
+	affiliategormstore "github.com/dujiao-next/internal/modules/affiliate/infrastructure/gormstore"
+	fulfillmentdomain "github.com/dujiao-next/internal/modules/fulfillment/domain"
+	userdomain "github.com/dujiao-next/internal/modules/identity/user/domain"
+	userstore "github.com/dujiao-next/internal/modules/identity/user/infrastructure/gormstore"
+	orderrefund "github.com/dujiao-next/internal/modules/order/application/refund"
+	orderdomain "github.com/dujiao-next/internal/modules/order/domain"
+	ordergormstore "github.com/dujiao-next/internal/modules/order/infrastructure/gormstore"
+	orderriskcontract "github.com/dujiao-next/internal/modules/orderrisk/contract"
+	orderriskdomain "github.com/dujiao-next/internal/modules/orderrisk/domain"
+	"github.com/dujiao-next/internal/modules/orderrisk/infrastructure/refundadapter"
+	walletapp "github.com/dujiao-next/internal/modules/wallet/application"
+	walletdomain "github.com/dujiao-next/internal/modules/wallet/domain"
+	walletgormstore "github.com/dujiao-next/internal/modules/wallet/infrastructure/gormstore"
+
+	"github.com/dujiao-next/internal/constants"
+	"github.com/dujiao-next/internal/shared/money"
+
+	"github.com/glebarez/sqlite"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+func TestRefundHeldOrderIgnoresRefundWindow(t *testing.T) {
+	dsn := fmt.Sprintf("file:orderrisk_refund_adapter_%d?mode=memory&cache=shared", time.Now().UnixNano())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite failed: %v", err)
+	}
+	if err := db.AutoMigrate(
+		&userdomain.User{},
+		&orderdomain.Order{},
+		&orderdomain.OrderItem{},
+		&orderdomain.OrderRefundRecord{},
+		&fulfillmentdomain.Fulfillment{},
+		&affiliatedomain.Profile{},
+		&affiliatedomain.Commission{},
+		&walletdomain.Account{},
+		&walletdomain.Transaction{},
+	); err != nil {
+		t.Fatalf("auto migrate failed: %v", err)
+	}
+	user := userdomain.User{Email: "held-review@example.com", PasswordHash: "hash", Status: constants.UserStatusActive}
+	if err := db.Create(&user).Error; err != nil {
+		t.Fatalf("create user failed: %v", err)
+	}
+
+	walletStore := walletgormstore.New(db)
+	wallets := walletapp.NewService(walletapp.Options{Repository: walletStore, Transactions: walletStore})
+	refunds := orderrefund.New(
+		ordergormstore.New(db, "test-guest-credential-secret-with-32-bytes"),
+		userstore.New(db),
+		affiliateapp.NewService(affiliategormstore.New(db), nil, nil, nil, nil),
+		nil,
+		wallets,
+	)
+	refunder := refundadapter.New(refunds, nil)
+
+	// 订单在复核中停留超过默认 30 天售后期限后被驳回
+	paidAt := time.Now().AddDate(0, 0, -60)
+	total := money.FromDecimal(decimal.RequireFromString("25.00"))
+	for _, mode := range []string{orderriskdomain.ReviewRefundWallet, orderriskdomain.ReviewRefundManual} {
+		order := orderdomain.Order{
+			OrderNo:        "HELD-" + mode,
+			UserID:         user.ID,
+			Status:         constants.OrderStatusHeldForReview,
+			Currency:       "CNY",
+			OriginalAmount: total,
+			TotalAmount:    total,
+			PaidAt:         &paidAt,
+			CreatedAt:      paidAt,
+			UpdatedAt:      paidAt,
+		}
+		if err := db.Create(&order).Error; err != nil {
+			t.Fatalf("create order failed: %v", err)
+		}
+		if err := refunder.RefundHeldOrder(orderriskcontract.HeldOrderRefundInput{
+			OrderID: order.ID,
+			Amount:  total,
+			Mode:    mode,
+			Remark:  "review rejected",
+		}); err != nil {
+			t.Fatalf("%s refund of held order past refund window failed: %v", mode, err)
+		}
+		var refunded orderdomain.Order
+		if err := db.First(&refunded, order.ID).Error; err != nil {
+			t.Fatalf("load order failed: %v", err)
+		}
+		if refunded.Status != constants.OrderStatusRefunded || refunded.RefundedAmount.String() != "25.00" {
+			t.Fatalf("%s refund expected refunded order, got status=%s refunded=%s", mode, refunded.Status, refunded.RefundedAmount.String())
+		}
+	}
+
+	var account walletdomain.Account
+	if err := db.Where("user_id = ?", user.ID).First(&account).Error; err != nil {
+		t.Fatalf("load wallet account failed: %v", err)
+	}
+	if account.Balance.String() != "25.00" {
+		t.Fatalf("expected wallet refund credited 25.00, got %s", account.Balance.String())
+	}
+}
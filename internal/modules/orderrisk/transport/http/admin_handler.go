@@ -0,0 +1,144 @@
+package orderriskhttp
+
+import (
+	"errors"
+	"io"
+	"strings"
+
+	orderdomain "github.com/dujiao-next/internal/modules/order/domain"
+	orderriskcontract "github.com/dujiao-next/internal/modules/orderrisk/contract"
+	"github.com/dujiao-next/internal/platform/http/ginutil"
+	"github.com/dujiao-next/internal/platform/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReviewService 是后台人工复核队列端口。
+type ReviewService interface {
+	ListQueue(filter orderriskcontract.ReviewQueueFilter) ([]orderdomain.Order, int64, error)
+	GetReview(orderID uint) (*orderriskcontract.ReviewDetail, error)
+	Approve(input orderriskcontract.ReviewDecisionInput) (*orderdomain.Order, error)
+	Reject(input orderriskcontract.ReviewDecisionInput) (*orderdomain.Order, error)
+}
+
+// AdminHandler 处理后台订单人工复核请求。
+type AdminHandler struct {
+	reviews ReviewService
+}
+
+func NewAdminHandler(reviews ReviewService) *AdminHandler {
+	if reviews == nil {
+		panic("order review admin handler: reviews is nil")
+	}
+	return &AdminHandler{reviews: reviews}
+}
+
+// ReviewDecisionRequest 复核放行/驳回请求。
+type ReviewDecisionRequest struct {
+	Reason     string `json:"reason" binding:"max=500"`
+	RefundMode string `json:"refund_mode"` // 仅驳回：wallet/manual
+}
+
+func respondReviewError(c *gin.Context, err error, fallbackKey string) {
+	switch {
+	case errors.Is(err, orderriskcontract.ErrReviewOrderNotFound):
+		ginutil.RespondError(c, response.CodeNotFound, "error.order_review_not_found", nil)
+	case errors.Is(err, orderriskcontract.ErrReviewOrderNotHeld):
+		ginutil.RespondError(c, response.CodeBadRequest, "error.order_review_not_held", nil)
+	case errors.Is(err, orderriskcontract.ErrReviewRefundModeInvalid):
+		ginutil.RespondError(c, response.CodeBadRequest, "error.order_review_refund_mode_invalid", nil)
+	default:
+		ginutil.RespondError(c, response.CodeInternal, fallbackKey, err)
+	}
+}
+
+// ListReviews 待复核订单队列
+func (h *AdminHandler) ListReviews(c *gin.Context) {
+	page, pageSize := ginutil.ParsePagination(c)
+	filter := orderriskcontract.ReviewQueueFilter{
+		Page:     page,
+		PageSize: pageSize,
+		OrderNo:  strings.TrimSpace(c.Query("order_no")),
+	}
+	heldBefore, err := ginutil.ParseTimeNullable(strings.TrimSpace(c.Query("held_before")))
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", err)
+		return
+	}
+	filter.HeldBefore = heldBefore
+
+	orders, total, err := h.reviews.ListQueue(filter)
+	if err != nil {
+		respondReviewError(c, err, "error.order_fetch_failed")
+		return
+	}
+	response.SuccessWithPage(c, orders, response.BuildPagination(page, pageSize, total))
+}
+
+// GetReview 复核详情（订单与审计记录）
+func (h *AdminHandler) GetReview(c *gin.Context) {
+	id, err := ginutil.ParseParamUint(c, "id")
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", err)
+		return
+	}
+	detail, err := h.reviews.GetReview(id)
+	if err != nil {
+		respondReviewError(c, err, "error.order_fetch_failed")
+		return
+	}
+	response.Success(c, detail)
+}
+
+// ApproveReview 复核放行，恢复交付与采购
+func (h *AdminHandler) ApproveReview(c *gin.Context) {
+	input, ok := bindReviewDecision(c)
+	if !ok {
+		return
+	}
+	order, err := h.reviews.Approve(input)
+	if err != nil {
+		respondReviewError(c, err, "error.order_update_failed")
+		return
+	}
+	response.Success(c, order)
+}
+
+// RejectReview 复核驳回并全额退款
+func (h *AdminHandler) RejectReview(c *gin.Context) {
+	input, ok := bindReviewDecision(c)
+	if !ok {
+		return
+	}
+	order, err := h.reviews.Reject(input)
+	if err != nil {
+		respondReviewError(c, err, "error.order_update_failed")
+		return
+	}
+	response.Success(c, order)
+}
+
+func bindReviewDecision(c *gin.Context) (orderriskcontract.ReviewDecisionInput, bool) {
+	id, err := ginutil.ParseParamUint(c, "id")
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", err)
+		return orderriskcontract.ReviewDecisionInput{}, false
+	}
+	adminID, ok := ginutil.GetAdminID(c)
+	if !ok {
+		return orderriskcontract.ReviewDecisionInput{}, false
+	}
+	var req ReviewDecisionRequest
+	// 放行允许空请求体
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		ginutil.RespondBindError(c, err)
+		return orderriskcontract.ReviewDecisionInput{}, false
+	}
+	return orderriskcontract.ReviewDecisionInput{
+		OrderID:          id,
+		OperatorAdminID:  adminID,
+		OperatorUsername: strings.TrimSpace(c.GetString("username")),
+		Reason:           req.Reason,
+		RefundMode:       req.RefundMode,
+	}, true
+}
@@ -0,0 +1,202 @@
+package orderriskhttp
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	orderriskcontract "github.com/dujiao-next/internal/modules/orderrisk/contract"
+	orderriskdomain "github.com/dujiao-next/internal/modules/orderrisk/domain"
+	"github.com/dujiao-next/internal/platform/http/ginutil"
+	"github.com/dujiao-next/internal/platform/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BlacklistService 是后台客户黑名单管理端口。
+type BlacklistService interface {
+	List(filter orderriskcontract.BlacklistFilter) ([]orderriskdomain.BlacklistEntry, int64, error)
+	Create(input orderriskcontract.BlacklistEntryInput) (*orderriskdomain.BlacklistEntry, error)
+	Import(input orderriskcontract.BlacklistImportInput) (*orderriskcontract.BlacklistImportResult, error)
+	Delete(id uint) error
+	BlacklistOrderCustomer(input orderriskcontract.BlacklistOrderCustomerInput) ([]orderriskdomain.BlacklistEntry, error)
+}
+
+// BlacklistHandler 处理后台客户黑名单请求。
+type BlacklistHandler struct {
+	blacklist BlacklistService
+}
+
+func NewBlacklistHandler(blacklist BlacklistService) *BlacklistHandler {
+	if blacklist == nil {
+		panic("customer blacklist admin handler: blacklist is nil")
+	}
+	return &BlacklistHandler{blacklist: blacklist}
+}
+
+// BlacklistEntryRequest 新增黑名单条目请求。
+type BlacklistEntryRequest struct {
+	Type      string     `json:"type" binding:"required"`
+	Value     string     `json:"value" binding:"required,max=255"`
+	Reason    string     `json:"reason" binding:"max=500"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// BlacklistImportRequest 批量导入请求；values 与 content（按行或逗号分隔）可同时提供。
+type BlacklistImportRequest struct {
+	Type      string     `json:"type" binding:"required"`
+	Values    []string   `json:"values"`
+	Content   string     `json:"content"`
+	Reason    string     `json:"reason" binding:"max=500"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// BlacklistOrderCustomerRequest 从订单拉黑客户请求；types 为空时拉黑全部可识别身份。
+type BlacklistOrderCustomerRequest struct {
+	Types     []string   `json:"types"`
+	Reason    string     `json:"reason" binding:"max=500"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+func respondBlacklistError(c *gin.Context, err error, fallbackKey string) {
+	switch {
+	case errors.Is(err, orderriskcontract.ErrBlacklistTypeInvalid):
+		ginutil.RespondError(c, response.CodeBadRequest, "error.customer_blacklist_type_invalid", nil)
+	case errors.Is(err, orderriskcontract.ErrBlacklistValueInvalid):
+		ginutil.RespondError(c, response.CodeBadRequest, "error.customer_blacklist_value_invalid", nil)
+	case errors.Is(err, orderriskcontract.ErrBlacklistEntryNotFound):
+		ginutil.RespondError(c, response.CodeNotFound, "error.customer_blacklist_not_found", nil)
+	case errors.Is(err, orderriskcontract.ErrBlacklistOrderNotFound):
+		ginutil.RespondError(c, response.CodeNotFound, "error.order_not_found", nil)
+	default:
+		ginutil.RespondError(c, response.CodeInternal, fallbackKey, err)
+	}
+}
+
+// ListBlacklist 黑名单列表
+func (h *BlacklistHandler) ListBlacklist(c *gin.Context) {
+	page, pageSize := ginutil.ParsePagination(c)
+	entries, total, err := h.blacklist.List(orderriskcontract.BlacklistFilter{
+		Page:           page,
+		PageSize:       pageSize,
+		Type:           strings.TrimSpace(c.Query("type")),
+		Keyword:        strings.TrimSpace(c.Query("keyword")),
+		IncludeExpired: c.Query("include_expired") == "true",
+	})
+	if err != nil {
+		respondBlacklistError(c, err, "error.customer_blacklist_fetch_failed")
+		return
+	}
+	response.SuccessWithPage(c, entries, response.BuildPagination(page, pageSize, total))
+}
+
+// CreateBlacklistEntry 新增黑名单条目，同类型同值已存在时更新原因与有效期
+func (h *BlacklistHandler) CreateBlacklistEntry(c *gin.Context) {
+	operator, ok := blacklistOperator(c)
+	if !ok {
+		return
+	}
+	var req BlacklistEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ginutil.RespondBindError(c, err)
+		return
+	}
+	entry, err := h.blacklist.Create(orderriskcontract.BlacklistEntryInput{
+		Type:      req.Type,
+		Value:     req.Value,
+		Reason:    req.Reason,
+		ExpiresAt: req.ExpiresAt,
+		Operator:  operator,
+	})
+	if err != nil {
+		respondBlacklistError(c, err, "error.customer_blacklist_save_failed")
+		return
+	}
+	response.Success(c, entry)
+}
+
+// ImportBlacklist 批量导入黑名单
+func (h *BlacklistHandler) ImportBlacklist(c *gin.Context) {
+	operator, ok := blacklistOperator(c)
+	if !ok {
+		return
+	}
+	var req BlacklistImportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ginutil.RespondBindError(c, err)
+		return
+	}
+	values := append([]string{}, req.Values...)
+	values = append(values, strings.FieldsFunc(req.Content, func(r rune) bool {
+		return r == '\n' || r == '\r' || r == ','
+	})...)
+	result, err := h.blacklist.Import(orderriskcontract.BlacklistImportInput{
+		Type:      req.Type,
+		Values:    values,
+		Reason:    req.Reason,
+		ExpiresAt: req.ExpiresAt,
+		Operator:  operator,
+	})
+	if err != nil {
+		respondBlacklistError(c, err, "error.customer_blacklist_save_failed")
+		return
+	}
+	response.Success(c, result)
+}
+
+// DeleteBlacklistEntry 移除黑名单条目
+func (h *BlacklistHandler) DeleteBlacklistEntry(c *gin.Context) {
+	id, err := ginutil.ParseParamUint(c, "id")
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", err)
+		return
+	}
+	if err := h.blacklist.Delete(id); err != nil {
+		respondBlacklistError(c, err, "error.customer_blacklist_delete_failed")
+		return
+	}
+	response.Success(c, nil)
+}
+
+// BlacklistOrderCustomer 从订单详情拉黑下单客户
+func (h *BlacklistHandler) BlacklistOrderCustomer(c *gin.Context) {
+	id, err := ginutil.ParseParamUint(c, "id")
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", err)
+		return
+	}
+	operator, ok := blacklistOperator(c)
+	if !ok {
+		return
+	}
+	var req BlacklistOrderCustomerRequest
+	// 允许空请求体，默认拉黑全部可识别身份
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		ginutil.RespondBindError(c, err)
+		return
+	}
+	entries, err := h.blacklist.BlacklistOrderCustomer(orderriskcontract.BlacklistOrderCustomerInput{
+		OrderID:   id,
+		Types:     req.Types,
+		Reason:    req.Reason,
+		ExpiresAt: req.ExpiresAt,
+		Operator:  operator,
+	})
+	if err != nil {
+		respondBlacklistError(c, err, "error.customer_blacklist_save_failed")
+		return
+	}
+	response.Success(c, entries)
+}
+
+func blacklistOperator(c *gin.Context) (orderriskcontract.BlacklistOperator, bool) {
+	adminID, ok := ginutil.GetAdminID(c)
+	if !ok {
+		return orderriskcontract.BlacklistOperator{}, false
+	}
+	return orderriskcontract.BlacklistOperator{
+		AdminID:  adminID,
+		Username: strings.TrimSpace(c.GetString("username")),
+	}, true
+}
@@ -0,0 +1,26 @@
+package orderriskhttp
+
+import "github.com/gin-gonic/gin"
+
+// RegisterAdminRoutes 注册后台订单人工复核队列路由。
+func RegisterAdminRoutes(admin gin.IRoutes, handler *AdminHandler) {
+	if admin == nil || handler == nil {
+		panic("order review admin routes: required dependency is nil")
+	}
+	admin.GET("/order-reviews", handler.ListReviews)
+	admin.GET("/order-reviews/:id", handler.GetReview)
+	admin.POST("/order-reviews/:id/approve", handler.ApproveReview)
+	admin.POST("/order-reviews/:id/reject", handler.RejectReview)
+}
+
+// RegisterAdminBlacklistRoutes 注册后台客户黑名单路由。
+func RegisterAdminBlacklistRoutes(admin gin.IRoutes, handler *BlacklistHandler) {
+	if admin == nil || handler == nil {
+		panic("customer blacklist admin routes: required dependency is nil")
+	}
+	admin.GET("/customer-blacklist", handler.ListBlacklist)
+	admin.POST("/customer-blacklist", handler.CreateBlacklistEntry)
+	admin.POST("/customer-blacklist/import", handler.ImportBlacklist)
+	admin.DELETE("/customer-blacklist/:id", handler.DeleteBlacklistEntry)
+	admin.POST("/orders/:id/blacklist-customer", handler.BlacklistOrderCustomer)
+}
@@ -386,7 +386,7 @@ func TestPatchNotificationCenterSettingPersistsInventoryAlertConfig(t *testing.T
 	setting, err := svc.PatchNotificationCenterSetting(settingsmessaging.NotificationCenterSettingPatch{
 		InventoryAlertIntervalSeconds: &interval,
 		IgnoredProductIDs:             &ignored,
-	})
+	}, 1)
 	if err != nil {
 		t.Fatalf("patch notification center setting failed: %v", err)
 	}
@@ -419,7 +419,7 @@ func TestPatchNotificationCenterSettingPersistsPaymentOrderAlertConfig(t *testin
 	setting, err := svc.PatchNotificationCenterSetting(settingsmessaging.NotificationCenterSettingPatch{
 		PaymentOrderAlertIntervalSeconds: &interval,
 		PaymentOrderAlertCheckSeconds:    &checkInterval,
-	})
+	}, 1)
 	if err != nil {
 		t.Fatalf("patch notification center setting failed: %v", err)
 	}
@@ -8,9 +8,11 @@ import (
 	productcontract "github.com/dujiao-next/internal/modules/catalog/product/contract"
 	externalidentitycontract "github.com/dujiao-next/internal/modules/identity/externalidentity/contract"
 	usercontract "github.com/dujiao-next/internal/modules/identity/user/contract"
+	memberlevelcontract "github.com/dujiao-next/internal/modules/memberlevel/contract"
 	notificationcontract "github.com/dujiao-next/internal/modules/notification/contract"
 	ordercontract "github.com/dujiao-next/internal/modules/order/contract"
 	orderdomain "github.com/dujiao-next/internal/modules/order/domain"
+	orderriskcontract "github.com/dujiao-next/internal/modules/orderrisk/contract"
 	paymentcontract "github.com/dujiao-next/internal/modules/payment/contract"
 	paymentdomain "github.com/dujiao-next/internal/modules/payment/domain"
 	resellercontract "github.com/dujiao-next/internal/modules/reseller/contract"
@@ -63,14 +65,30 @@ type PaymentService struct {
 	notificationSvc         notificationcontract.NotificationEnqueuer
 	procurementSvc          ProcurementCreator
 	downstreamCallbackSvc   DownstreamCallbackEnqueuer
+	webhookFulfillmentSvc   WebhookFulfillmentStarter
+	preorderTrigger         PreorderAllocationTrigger
 	memberLevelSvc          MemberLevelProgressor
 	paymentProviderRegistry paymentcontract.GatewayRegistry
 	resellerAccounting      resellerAccountingTransactions
+	riskAssessor            OrderRiskAssessor
+	reviewQueue             OrderReviewQueue
+	customerBlacklist       orderriskcontract.CustomerBlacklist
+}
+
+// OrderRiskAssessor 是创建支付时复评订单风险所需的最小端口。
+type OrderRiskAssessor interface {
+	Assess(input orderriskcontract.AssessInput) (orderriskcontract.Assessment, error)
+}
+
+// OrderReviewQueue 接收支付后被挂起人工复核的订单，负责审计与待复核告警。
+type OrderReviewQueue interface {
+	EnqueueHeldOrder(order *orderdomain.Order) error
 }
 
 type MemberLevelProgressor interface {
 	OnOrderPaid(userID uint, amount decimal.Decimal) error
 	OnRechargeCompleted(userID uint, amount decimal.Decimal) error
+	OnMembershipPurchased(userID, orderID uint, items []memberlevelcontract.PurchasedItem) error
 }
 
 type ProcurementCreator interface {
@@ -82,6 +100,16 @@ type DownstreamCallbackEnqueuer interface {
 	EnqueueCallback(orderID uint)
 }
 
+// WebhookFulfillmentStarter 是支付成功后触发 webhook 交付所需的最小端口。
+type WebhookFulfillmentStarter interface {
+	StartForOrder(orderID uint) error
+}
+
+// PreorderAllocationTrigger 是预售子订单支付后触发排队分配所需的最小端口。
+type PreorderAllocationTrigger interface {
+	TriggerCheck(productID uint)
+}
+
 // AffiliatePaymentLifecycle 是支付成功回调所需的推广返利用例端口。
 type AffiliatePaymentLifecycle interface {
 	HandleOrderPaid(orderID uint) error
@@ -101,11 +129,26 @@ func (s *PaymentService) SetDownstreamCallbackService(svc DownstreamCallbackEnqu
 	s.downstreamCallbackSvc = svc
 }
 
+// SetWebhookFulfillmentService 设置 webhook 交付服务（解决循环依赖）
+func (s *PaymentService) SetWebhookFulfillmentService(svc WebhookFulfillmentStarter) {
+	s.webhookFulfillmentSvc = svc
+}
+
+// SetPreorderTrigger 设置预售分配触发器（解决循环依赖）
+func (s *PaymentService) SetPreorderTrigger(trigger PreorderAllocationTrigger) {
+	s.preorderTrigger = trigger
+}
+
 // SetMemberLevelService 设置会员等级服务
 func (s *PaymentService) SetMemberLevelService(svc MemberLevelProgressor) {
 	s.memberLevelSvc = svc
 }
 
+// SetReviewQueue 设置人工复核队列（解决循环依赖）
+func (s *PaymentService) SetReviewQueue(queue OrderReviewQueue) {
+	s.reviewQueue = queue
+}
+
 // PaymentServiceOptions 支付服务构造参数
 type PaymentServiceOptions struct {
 	OrderStore              ordercontract.Store
@@ -125,6 +168,8 @@ type PaymentServiceOptions struct {
 	NotificationService     notificationcontract.NotificationEnqueuer
 	PaymentProviderRegistry paymentcontract.GatewayRegistry
 	ResellerAccounting      resellerAccountingTransactions
+	RiskAssessor            OrderRiskAssessor
+	CustomerBlacklist       orderriskcontract.CustomerBlacklist
 }
 
 // NewPaymentService 创建支付服务
@@ -147,6 +192,8 @@ func NewPaymentService(opts PaymentServiceOptions) *PaymentService {
 		notificationSvc:         opts.NotificationService,
 		paymentProviderRegistry: opts.PaymentProviderRegistry,
 		resellerAccounting:      opts.ResellerAccounting,
+		riskAssessor:            opts.RiskAssessor,
+		customerBlacklist:       opts.CustomerBlacklist,
 	}
 }
 
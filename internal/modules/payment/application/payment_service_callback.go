@@ -1,12 +1,15 @@
 package application
 
 import (
+	"errors"
 	"strings"
 	"time"
 
 	productcontract "github.com/dujiao-next/internal/modules/catalog/product/contract"
 	orderapp "github.com/dujiao-next/internal/modules/order/application"
 	orderdomain "github.com/dujiao-next/internal/modules/order/domain"
+	orderriskcontract "github.com/dujiao-next/internal/modules/orderrisk/contract"
+	orderriskdomain "github.com/dujiao-next/internal/modules/orderrisk/domain"
 	paymentcontract "github.com/dujiao-next/internal/modules/payment/contract"
 	paymentdomain "github.com/dujiao-next/internal/modules/payment/domain"
 
@@ -240,6 +243,8 @@ func (s *PaymentService) applyPaymentUpdate(payment *paymentdomain.Payment, orde
 	returnVal := payment
 	processedOrder := order
 	orderPaid := false
+	// 黑名单查询走独立连接，必须在事务外完成，避免 SQLite 单连接池下自锁
+	blacklistHold := s.resolvePayerBlacklistHold(payment, status, input.Payload)
 
 	err := s.paymentRepo.WithinTransaction(func(tx paymentcontract.Transaction) error {
 		paymentRepo := tx.Payments()
@@ -297,8 +302,8 @@ func (s *PaymentService) applyPaymentUpdate(payment *paymentdomain.Payment, orde
 			return ErrPaymentUpdateFailed
 		}
 
-		if status == constants.PaymentStatusSuccess && lockedOrder.Status != constants.OrderStatusPaid {
-			if err := s.markOrderPaid(tx, lockedOrder, now); err != nil {
+		if status == constants.PaymentStatusSuccess && lockedOrder.Status != constants.OrderStatusPaid && lockedOrder.Status != constants.OrderStatusHeldForReview {
+			if err := s.markOrderPaid(tx, lockedOrder, now, blacklistHold); err != nil {
 				return err
 			}
 			if s.resellerAccounting != nil {
@@ -338,12 +343,13 @@ func mergeProviderPayload(existing jsonmap.JSON, incoming jsonmap.JSON) jsonmap.
 	return merged
 }
 
-// markOrderPaid 在事务内将订单更新为已支付并处理库存
-func (s *PaymentService) markOrderPaid(tx paymentcontract.Transaction, order *orderdomain.Order, now time.Time) error {
+// markOrderPaid 在事务内将订单更新为已支付并处理库存；holdReason 非空时订单直接挂起待人工复核。
+func (s *PaymentService) markOrderPaid(tx paymentcontract.Transaction, order *orderdomain.Order, now time.Time, holdReason string) error {
 	if order == nil {
 		return orderapp.ErrOrderNotFound
 	}
-	if !orderapp.IsTransitionAllowed(order.Status, constants.OrderStatusPaid) {
+	// 待复核订单已完成支付，只能经复核放行，不能被重复支付回调再次置为已支付
+	if order.Status == constants.OrderStatusHeldForReview || !orderapp.IsTransitionAllowed(order.Status, constants.OrderStatusPaid) {
 		return orderapp.ErrOrderStatusInvalid
 	}
 	orderRepo := tx.Orders()
@@ -380,7 +386,12 @@ func (s *PaymentService) markOrderPaid(tx paymentcontract.Transaction, order *or
 			}); err != nil {
 				return orderapp.ErrOrderUpdateFailed
 			}
-			if err := orderapp.ConsumeManualStockByItems(productRepo, productSKURepo, child.Items); err != nil {
+			if child.IsPreorder {
+				// 预售子订单按支付时间进入排队，库存到位后再分配
+				if _, err := tx.Preorders().MarkQueued(child.ID, now); err != nil {
+					return err
+				}
+			} else if err := orderapp.ConsumeManualStockByItems(productRepo, productSKURepo, child.Items); err != nil {
 				return err
 			}
 			child.Status = childStatus
@@ -397,11 +408,117 @@ func (s *PaymentService) markOrderPaid(tx paymentcontract.Transaction, order *or
 			}
 			order.Status = parentStatus
 		}
-		return nil
+		return s.holdOrderForReviewIfNeeded(tx, order, now, holdReason)
 	}
 
 	if err := orderapp.ConsumeManualStockByItems(productRepo, productSKURepo, order.Items); err != nil {
 		return err
 	}
+	return s.holdOrderForReviewIfNeeded(tx, order, now, holdReason)
+}
+
+// holdOrderForReviewIfNeeded 在支付事务内按风险处置或商品设置将已支付订单挂起待人工复核。
+// 父子订单同时进入 held_for_review，交付与采购在复核放行前均不会触发。
+func (s *PaymentService) holdOrderForReviewIfNeeded(tx paymentcontract.Transaction, order *orderdomain.Order, now time.Time, reason string) error {
+	if reason == "" {
+		resolved, err := resolveReviewHoldReason(tx.Products(), order)
+		if err != nil {
+			return err
+		}
+		reason = resolved
+	}
+	if reason == "" {
+		return nil
+	}
+	orderRepo := tx.Orders()
+	holdUpdates := func() map[string]interface{} {
+		return map[string]interface{}{
+			"review_hold_reason": reason,
+			"held_at":            now,
+			"review_alerted_at":  nil,
+			"updated_at":         now,
+		}
+	}
+	for idx := range order.Children {
+		child := &order.Children[idx]
+		if err := orderRepo.UpdateStatus(child.ID, constants.OrderStatusHeldForReview, holdUpdates()); err != nil {
+			return orderapp.ErrOrderUpdateFailed
+		}
+		child.Status = constants.OrderStatusHeldForReview
+		child.ReviewHoldReason = reason
+		child.HeldAt = &now
+	}
+	if err := orderRepo.UpdateStatus(order.ID, constants.OrderStatusHeldForReview, holdUpdates()); err != nil {
+		return orderapp.ErrOrderUpdateFailed
+	}
+	order.Status = constants.OrderStatusHeldForReview
+	order.ReviewHoldReason = reason
+	order.HeldAt = &now
 	return nil
 }
+
+// resolvePayerBlacklistHold 支付成功回调的付款人身份命中客户黑名单时返回挂起来源；
+// 款项已到账，订单挂起待人工复核而非拒绝回调。
+func (s *PaymentService) resolvePayerBlacklistHold(payment *paymentdomain.Payment, status string, payload jsonmap.JSON) string {
+	if s.customerBlacklist == nil || payment == nil || status != constants.PaymentStatusSuccess {
+		return ""
+	}
+	identities := orderriskdomain.PayerIdentitiesFromPayload(payment.ProviderType, payment.ChannelType, payload)
+	if len(identities) == 0 {
+		return ""
+	}
+	err := s.customerBlacklist.CheckCustomer(orderriskcontract.BlacklistSubject{PayerIdentities: identities})
+	if err == nil {
+		return ""
+	}
+	if !errors.Is(err, orderriskcontract.ErrCustomerBlacklisted) {
+		// 黑名单不可用时不阻断到账处理
+		paymentLogger("payment_id", payment.ID).Warnw("payment_payer_blacklist_check_failed", "error", err)
+		return ""
+	}
+	return constants.OrderReviewHoldBlacklist
+}
+
+// resolveReviewHoldReason 返回订单需要人工复核的来源：风险评分优先，其次为商品强制复核设置。
+func resolveReviewHoldReason(productRepo productcontract.Repository, order *orderdomain.Order) (string, error) {
+	if order == nil {
+		return "", nil
+	}
+	if order.RiskDecision == orderriskdomain.DecisionHold {
+		return constants.OrderReviewHoldRiskScore, nil
+	}
+	if productRepo == nil {
+		return "", nil
+	}
+	seen := make(map[uint]struct{})
+	productIDs := make([]uint, 0)
+	collect := func(items []orderdomain.OrderItem) {
+		for _, item := range items {
+			if item.ProductID == 0 {
+				continue
+			}
+			if _, ok := seen[item.ProductID]; ok {
+				continue
+			}
+			seen[item.ProductID] = struct{}{}
+			productIDs = append(productIDs, item.ProductID)
+		}
+	}
+	collect(order.Items)
+	for _, child := range order.Children {
+		collect(child.Items)
+	}
+	if len(productIDs) == 0 {
+		return "", nil
+	}
+	products, err := productRepo.ListByIDs(productIDs)
+	if err != nil {
+		return "", ErrProductFetchFailed
+	}
+	for _, product := range products {
+		if product.RequireManualReview {
+			return constants.OrderReviewHoldProductSetting, nil
+		}
+	}
+	return "", nil
+}
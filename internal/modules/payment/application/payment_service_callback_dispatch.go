@@ -3,9 +3,11 @@ package application
 import (
 	"errors"
 	"strings"
+	"time"
 
 	paymentdomain "github.com/dujiao-next/internal/modules/payment/domain"
 
+	memberlevelcontract "github.com/dujiao-next/internal/modules/memberlevel/contract"
 	orderapp "github.com/dujiao-next/internal/modules/order/application"
 	orderdomain "github.com/dujiao-next/internal/modules/order/domain"
 	paymentcontract "github.com/dujiao-next/internal/modules/payment/contract"
@@ -32,8 +34,9 @@ func (s *PaymentService) enqueueOrderPaidAsync(order *orderdomain.Order, payment
 			)
 		}
 	}
-	if s.queue != nil && s.queue.Enabled() && !isOrderFullyAutoFulfill(order) {
-		// 完全自动交付的订单会紧接着发送含卡密内容的"已完成"邮件，跳过"已支付"邮件避免重复打扰
+	if s.queue != nil && s.queue.Enabled() && (!isOrderFullyAutoFulfill(order) || order.Status == constants.OrderStatusHeldForReview) {
+		// 完全自动交付的订单会紧接着发送含卡密内容的"已完成"邮件，跳过"已支付"邮件避免重复打扰；
+		// 待复核订单不会立即交付，仍需发送"已支付"邮件
 		if _, err := orderapp.EnqueueStatusEmailTaskIfEligible(s.orderRepo, s.queue, s.settingService, s.defaultEmailConfig, order.ID, constants.OrderStatusPaid); err != nil {
 			log.Warnw("payment_enqueue_status_email_failed",
 				"order_id", order.ID,
@@ -58,11 +61,40 @@ func (s *PaymentService) enqueueOrderPaidAsync(order *orderdomain.Order, payment
 		}
 	}
 
+	if order.Status == constants.OrderStatusHeldForReview {
+		// 待复核订单暂停自动交付、上游采购与付费等级授予，放行后再补发
+		s.enqueueReviewHoldAsync(order, log)
+		return
+	}
+	s.grantPurchasedMembership(order, log)
+	s.enqueueFulfillmentAsync(order, log)
+}
+
+// grantPurchasedMembership 订单进入交付时按付费等级方案授予等级，驳回或退款由退款流程回收。
+func (s *PaymentService) grantPurchasedMembership(order *orderdomain.Order, log *zap.SugaredLogger) {
+	if s.memberLevelSvc == nil || order == nil || order.UserID == 0 {
+		return
+	}
+	if err := s.memberLevelSvc.OnMembershipPurchased(order.UserID, order.ID, collectPurchasedItems(order)); err != nil {
+		log.Warnw("member_level_membership_purchase_failed",
+			"order_id", order.ID,
+			"user_id", order.UserID,
+			"error", err,
+		)
+	}
+}
+
+// enqueueFulfillmentAsync 为已支付订单触发人工交付提醒、自动交付、webhook 交付、文件交付、上游采购与下游回调。
+func (s *PaymentService) enqueueFulfillmentAsync(order *orderdomain.Order, log *zap.SugaredLogger) {
 	if s.queue == nil || !s.queue.Enabled() {
 		return
 	}
 	if len(order.Children) > 0 {
 		for _, child := range order.Children {
+			if child.IsPreorder {
+				s.enqueuePreorderAllocationAsync(&child)
+				continue
+			}
 			if child.Status == constants.OrderStatusFulfilling && hasManualFulfillmentItems(&child) {
 				s.enqueueManualFulfillmentPendingAsync(&child, order, log)
 			}
@@ -76,6 +108,15 @@ func (s *PaymentService) enqueueOrderPaidAsync(order *orderdomain.Order, payment
 					)
 				}
 			}
+			if child.Status == constants.OrderStatusFulfilling && hasWebhookFulfillmentItems(&child) {
+				s.enqueueWebhookFulfillmentAsync(&child, log)
+			}
+			if child.Status == constants.OrderStatusFulfilling && hasFileFulfillmentItems(&child) {
+				s.enqueueFileFulfillmentAsync(&child, log)
+			}
+			if child.Status == constants.OrderStatusFulfilling && hasLicenseFulfillmentItems(&child) {
+				s.enqueueLicenseFulfillmentAsync(&child, log)
+			}
 		}
 		// 上游采购：为包含上游交付类型的订单创建采购单
 		s.enqueueProcurementAsync(order, log)
@@ -95,19 +136,107 @@ func (s *PaymentService) enqueueOrderPaidAsync(order *orderdomain.Order, payment
 			)
 		}
 	}
+	if order.Status == constants.OrderStatusFulfilling && hasWebhookFulfillmentItems(order) {
+		s.enqueueWebhookFulfillmentAsync(order, log)
+	}
+	if order.Status == constants.OrderStatusFulfilling && hasFileFulfillmentItems(order) {
+		s.enqueueFileFulfillmentAsync(order, log)
+	}
+	if order.Status == constants.OrderStatusFulfilling && hasLicenseFulfillmentItems(order) {
+		s.enqueueLicenseFulfillmentAsync(order, log)
+	}
 	// 上游采购：为包含上游交付类型的订单创建采购单
 	s.enqueueProcurementAsync(order, log)
 	// B 侧：订单支付成功后检查是否需要回调下游
 	s.enqueueDownstreamCallbackAsync(order, log)
 }
 
+// enqueuePreorderAllocationAsync 预售子订单支付后立即尝试分配，已到货的库存无需等待下次巡检。
+func (s *PaymentService) enqueuePreorderAllocationAsync(order *orderdomain.Order) {
+	if s.preorderTrigger == nil || order == nil || len(order.Items) == 0 {
+		return
+	}
+	s.preorderTrigger.TriggerCheck(order.Items[0].ProductID)
+}
+
+// enqueueReviewHoldAsync 将挂起订单交给复核队列写审计并发送待复核告警。
+func (s *PaymentService) enqueueReviewHoldAsync(order *orderdomain.Order, log *zap.SugaredLogger) {
+	if s.reviewQueue == nil || order == nil {
+		return
+	}
+	if err := s.reviewQueue.EnqueueHeldOrder(order); err != nil {
+		log.Warnw("payment_enqueue_review_hold_failed",
+			"order_id", order.ID,
+			"order_no", order.OrderNo,
+			"error", err,
+		)
+	}
+}
+
+// ReleaseHeldOrder 复核放行：恢复挂起前的已支付/交付中状态，并补发交付、采购与下游回调。
+func (s *PaymentService) ReleaseHeldOrder(orderID uint) (*orderdomain.Order, error) {
+	if orderID == 0 {
+		return nil, orderapp.ErrOrderNotFound
+	}
+	var released *orderdomain.Order
+	err := s.paymentRepo.WithinTransaction(func(tx paymentcontract.Transaction) error {
+		orderRepo := tx.Orders()
+		order, err := orderRepo.GetByIDForUpdateWithChildren(orderID)
+		if err != nil {
+			return orderapp.ErrOrderFetchFailed
+		}
+		if order == nil {
+			return orderapp.ErrOrderNotFound
+		}
+		if order.Status != constants.OrderStatusHeldForReview {
+			return orderapp.ErrOrderStatusInvalid
+		}
+		now := time.Now()
+		parentStatus := constants.OrderStatusPaid
+		if len(order.Children) > 0 {
+			for idx := range order.Children {
+				child := &order.Children[idx]
+				if child.Status != constants.OrderStatusHeldForReview {
+					continue
+				}
+				childStatus := constants.OrderStatusPaid
+				if shouldMarkFulfilling(child) {
+					childStatus = constants.OrderStatusFulfilling
+				}
+				if err := orderRepo.UpdateStatus(child.ID, childStatus, map[string]interface{}{"updated_at": now}); err != nil {
+					return orderapp.ErrOrderUpdateFailed
+				}
+				child.Status = childStatus
+				child.UpdatedAt = now
+			}
+			if calculated := orderapp.CalcParentStatus(order.Children, constants.OrderStatusPaid); calculated != "" {
+				parentStatus = calculated
+			}
+		}
+		if err := orderRepo.UpdateStatus(order.ID, parentStatus, map[string]interface{}{"updated_at": now}); err != nil {
+			return orderapp.ErrOrderUpdateFailed
+		}
+		order.Status = parentStatus
+		order.UpdatedAt = now
+		released = order
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	log := paymentLogger("order_id", released.ID, "order_no", released.OrderNo)
+	s.grantPurchasedMembership(released, log)
+	s.enqueueFulfillmentAsync(released, log)
+	return released, nil
+}
+
 // enqueueProcurementAsync 如果订单包含上游交付类型商品，创建采购单
 func (s *PaymentService) enqueueProcurementAsync(order *orderdomain.Order, log *zap.SugaredLogger) {
 	if s.procurementSvc == nil || order == nil {
 		return
 	}
 	if err := s.procurementSvc.CreateForOrder(order.ID); err != nil {
-		if !errors.Is(err, procurementcontract.ErrExists) {
+		if !errors.Is(err, procurementcontract.ErrExists) && !errors.Is(err, procurementcontract.ErrOrderHeldForReview) {
 			log.Warnw("payment_enqueue_procurement_failed",
 				"order_id", order.ID,
 				"order_no", order.OrderNo,
@@ -125,6 +254,54 @@ func (s *PaymentService) enqueueDownstreamCallbackAsync(order *orderdomain.Order
 	s.downstreamCallbackSvc.EnqueueCallback(order.ID)
 }
 
+// enqueueWebhookFulfillmentAsync 为 webhook 交付订单创建交付记录并调度发码请求
+func (s *PaymentService) enqueueWebhookFulfillmentAsync(order *orderdomain.Order, log *zap.SugaredLogger) {
+	if s.webhookFulfillmentSvc == nil || order == nil {
+		return
+	}
+	if err := s.webhookFulfillmentSvc.StartForOrder(order.ID); err != nil {
+		log.Warnw("payment_enqueue_webhook_fulfillment_failed",
+			"order_id", order.ID,
+			"order_no", order.OrderNo,
+			"error", err,
+		)
+	}
+}
+
+// enqueueFileFulfillmentAsync 推送文件交付任务，由队列重试发放下载授权；入队失败时转人工交付提醒。
+func (s *PaymentService) enqueueFileFulfillmentAsync(order *orderdomain.Order, log *zap.SugaredLogger) {
+	if order == nil {
+		return
+	}
+	if err := s.queue.EnqueueFileFulfillment(order.ID); err != nil {
+		log.Warnw("payment_enqueue_file_fulfillment_failed",
+			"order_id", order.ID,
+			"order_no", order.OrderNo,
+			"error", err,
+		)
+		if notifyErr := s.NotifyManualFulfillmentPending(order.ID); notifyErr != nil {
+			log.Warnw("payment_file_fulfillment_fallback_failed", "order_id", order.ID, "error", notifyErr)
+		}
+	}
+}
+
+// enqueueLicenseFulfillmentAsync 推送授权码交付任务，由队列重试签发；入队失败时转人工交付提醒。
+func (s *PaymentService) enqueueLicenseFulfillmentAsync(order *orderdomain.Order, log *zap.SugaredLogger) {
+	if order == nil {
+		return
+	}
+	if err := s.queue.EnqueueLicenseFulfillment(order.ID); err != nil {
+		log.Warnw("payment_enqueue_license_fulfillment_failed",
+			"order_id", order.ID,
+			"order_no", order.OrderNo,
+			"error", err,
+		)
+		if notifyErr := s.NotifyManualFulfillmentPending(order.ID); notifyErr != nil {
+			log.Warnw("payment_license_fulfillment_fallback_failed", "order_id", order.ID, "error", notifyErr)
+		}
+	}
+}
+
 func (s *PaymentService) enqueueOrderPaidNotificationAsync(order *orderdomain.Order, payment *paymentdomain.Payment, log *zap.SugaredLogger) {
 	if s.notificationSvc == nil || order == nil {
 		return
@@ -229,6 +406,31 @@ func (s *PaymentService) enqueueWalletRechargeBotNotifyAsync(recharge *walletdom
 	}
 }
 
+// collectPurchasedItems 汇总订单（含子订单）商品行，供付费会员等级方案匹配。
+func collectPurchasedItems(order *orderdomain.Order) []memberlevelcontract.PurchasedItem {
+	if order == nil {
+		return nil
+	}
+	items := make([]memberlevelcontract.PurchasedItem, 0, len(order.Items))
+	appendItems := func(source []orderdomain.OrderItem) {
+		for _, item := range source {
+			items = append(items, memberlevelcontract.PurchasedItem{
+				ProductID: item.ProductID,
+				SKUID:     item.SKUID,
+				Quantity:  item.Quantity,
+			})
+		}
+	}
+	if len(order.Children) > 0 {
+		for i := range order.Children {
+			appendItems(order.Children[i].Items)
+		}
+		return items
+	}
+	appendItems(order.Items)
+	return items
+}
+
 // hasManualFulfillmentItems 判断订单是否包含需要人工交付的商品项。
 // upstream 类型由采购流程自动交付，不触发待人工交付提醒。
 func hasManualFulfillmentItems(order *orderdomain.Order) bool {
@@ -243,6 +445,62 @@ func hasManualFulfillmentItems(order *orderdomain.Order) bool {
 	return false
 }
 
+func hasWebhookFulfillmentItems(order *orderdomain.Order) bool {
+	if order == nil {
+		return false
+	}
+	for _, item := range order.Items {
+		if notificationformat.NormalizeFulfillmentType(item.FulfillmentType) == constants.FulfillmentTypeWebhook {
+			return true
+		}
+	}
+	return false
+}
+
+func hasFileFulfillmentItems(order *orderdomain.Order) bool {
+	if order == nil {
+		return false
+	}
+	for _, item := range order.Items {
+		if notificationformat.NormalizeFulfillmentType(item.FulfillmentType) == constants.FulfillmentTypeFile {
+			return true
+		}
+	}
+	return false
+}
+
+func hasLicenseFulfillmentItems(order *orderdomain.Order) bool {
+	if order == nil {
+		return false
+	}
+	for _, item := range order.Items {
+		if notificationformat.NormalizeFulfillmentType(item.FulfillmentType) == constants.FulfillmentTypeLicense {
+			return true
+		}
+	}
+	return false
+}
+
+// NotifyManualFulfillmentPending webhook、文件或授权码交付转入人工兜底后，发送待人工交付提醒。
+func (s *PaymentService) NotifyManualFulfillmentPending(orderID uint) error {
+	order, err := s.orderRepo.GetByID(orderID)
+	if err != nil {
+		return err
+	}
+	if order == nil {
+		return orderapp.ErrOrderNotFound
+	}
+	var parent *orderdomain.Order
+	if order.ParentID != nil && *order.ParentID > 0 {
+		parent, err = s.orderRepo.GetByID(*order.ParentID)
+		if err != nil {
+			return err
+		}
+	}
+	s.enqueueManualFulfillmentPendingAsync(order, parent, paymentLogger("order_id", order.ID))
+	return nil
+}
+
 func (s *PaymentService) enqueueManualFulfillmentPendingAsync(order *orderdomain.Order, parent *orderdomain.Order, log *zap.SugaredLogger) {
 	if s.notificationSvc == nil || order == nil {
 		return
@@ -14,7 +14,10 @@ import (
 	orderdomain "github.com/dujiao-next/internal/modules/order/domain"
 
 	"github.com/dujiao-next/internal/constants"
+	orderriskcontract "github.com/dujiao-next/internal/modules/orderrisk/contract"
+	orderriskdomain "github.com/dujiao-next/internal/modules/orderrisk/domain"
 	walletcontract "github.com/dujiao-next/internal/modules/wallet/contract"
+	"github.com/dujiao-next/internal/shared/jsonslice"
 	"github.com/dujiao-next/internal/shared/money"
 
 	"github.com/shopspring/decimal"
@@ -78,6 +81,13 @@ func (s *PaymentService) CreatePayment(input CreatePaymentInput) (*CreatePayment
 		}
 	}
 
+	if err := s.checkCustomerBlacklist(input); err != nil {
+		return nil, err
+	}
+	if err := s.reassessOrderRisk(input); err != nil {
+		return nil, err
+	}
+
 	err := s.paymentRepo.WithinTransaction(func(tx paymentcontract.Transaction) error {
 		preloaded, err := tx.Orders().GetByIDForUpdateWithChildren(input.OrderID)
 		if err != nil {
@@ -179,7 +189,7 @@ func (s *PaymentService) CreatePayment(input CreatePaymentInput) (*CreatePayment
 			if err := paymentRepo.Create(payment); err != nil {
 				return ErrPaymentCreateFailed
 			}
-			if err := s.markOrderPaid(tx, &lockedOrder, paidAt); err != nil {
+			if err := s.markOrderPaid(tx, &lockedOrder, paidAt, ""); err != nil {
 				return err
 			}
 			orderPaidByWallet = true
@@ -349,3 +359,58 @@ func (s *PaymentService) CreatePayment(input CreatePaymentInput) (*CreatePayment
 		OnlinePayAmount:  order.OnlinePaidAmount,
 	}, nil
 }
+
+// reassessOrderRisk 在发起支付前（事务外）复评订单风险：block 拒绝支付，
+// 处置升级时回写订单风险字段，hold 订单在支付成功后进入人工审核。
+func (s *PaymentService) reassessOrderRisk(input CreatePaymentInput) error {
+	if s.riskAssessor == nil || s.orderRepo == nil {
+		return nil
+	}
+	order, err := s.orderRepo.GetByID(input.OrderID)
+	if err != nil || order == nil || order.ParentID != nil || order.Status != constants.OrderStatusPendingPayment {
+		// 订单读取失败与状态校验交由事务内统一处理。
+		return nil
+	}
+	riskIP := orderriskcontract.NormalizeRiskIP(input.ClientIP)
+	if riskIP == "" {
+		riskIP = order.RiskIP
+	}
+	assessment, assessErr := s.riskAssessor.Assess(orderriskcontract.AssessInput{
+		Stage:      orderriskcontract.StagePayment,
+		OrderID:    order.ID,
+		UserID:     order.UserID,
+		GuestEmail: order.GuestEmail,
+		RiskIP:     riskIP,
+		DeviceHash: order.DeviceHash,
+	})
+	if orderriskdomain.DecisionRank(assessment.Decision) > orderriskdomain.DecisionRank(order.RiskDecision) || assessment.Score > order.RiskScore {
+		updates := map[string]interface{}{
+			"risk_decision": orderriskdomain.MoreSevereDecision(order.RiskDecision, assessment.Decision),
+		}
+		if assessment.Score > order.RiskScore {
+			updates["risk_score"] = assessment.Score
+			updates["risk_reasons"] = jsonslice.Strings(assessment.Reasons)
+		}
+		if err := s.orderRepo.UpdateFields(order.ID, updates); err != nil {
+			paymentLogger("order_id", order.ID).Warnw("payment_risk_reassess_update_failed", "error", err)
+		}
+	}
+	return assessErr
+}
+
+// checkCustomerBlacklist 在发起支付前（事务外）按订单客户与当前请求 IP 校验客户黑名单。
+func (s *PaymentService) checkCustomerBlacklist(input CreatePaymentInput) error {
+	if s.customerBlacklist == nil || s.orderRepo == nil {
+		return nil
+	}
+	order, err := s.orderRepo.GetByID(input.OrderID)
+	if err != nil || order == nil || order.ParentID != nil || order.Status != constants.OrderStatusPendingPayment {
+		// 订单读取失败与状态校验交由事务内统一处理。
+		return nil
+	}
+	return s.customerBlacklist.CheckCustomer(orderriskcontract.BlacklistSubject{
+		UserID:   order.UserID,
+		Email:    order.GuestEmail,
+		ClientIP: input.ClientIP,
+	})
+}
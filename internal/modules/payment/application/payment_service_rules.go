@@ -3,6 +3,7 @@ package application
 import (
 	"strings"
 
+	"github.com/dujiao-next/internal/i18n/locales"
 	paymentdomain "github.com/dujiao-next/internal/modules/payment/domain"
 
 	orderapp "github.com/dujiao-next/internal/modules/order/application"
@@ -34,7 +35,7 @@ func pickFirstNonEmpty(values ...string) string {
 }
 
 func shouldMarkFulfilling(order *orderdomain.Order) bool {
-	if order == nil {
+	if order == nil || order.IsPreorder {
 		return false
 	}
 	if len(order.Items) == 0 {
@@ -42,7 +43,9 @@ func shouldMarkFulfilling(order *orderdomain.Order) bool {
 	}
 	for _, item := range order.Items {
 		fulfillmentType := strings.TrimSpace(item.FulfillmentType)
-		if fulfillmentType == "" || fulfillmentType == constants.FulfillmentTypeManual || fulfillmentType == constants.FulfillmentTypeUpstream {
+		if fulfillmentType == "" || fulfillmentType == constants.FulfillmentTypeManual ||
+			fulfillmentType == constants.FulfillmentTypeUpstream || fulfillmentType == constants.FulfillmentTypeWebhook ||
+			fulfillmentType == constants.FulfillmentTypeFile || fulfillmentType == constants.FulfillmentTypeLicense {
 			return true
 		}
 	}
@@ -109,7 +112,7 @@ func isPaymentStatusValid(status string) bool {
 }
 
 func shouldAutoFulfill(order *orderdomain.Order) bool {
-	if order == nil || len(order.Items) == 0 {
+	if order == nil || order.IsPreorder || len(order.Items) == 0 {
 		return false
 	}
 	for _, item := range order.Items {
@@ -155,7 +158,7 @@ func pickOrderItemTitle(title jsonmap.JSON) string {
 	if title == nil {
 		return ""
 	}
-	for _, key := range constants.SupportedLocales {
+	for _, key := range locales.Enabled() {
 		if val, ok := title[key]; ok {
 			if str, ok := val.(string); ok && strings.TrimSpace(str) != "" {
 				return strings.TrimSpace(str)
@@ -27,6 +27,7 @@ import (
 	paymentdomain "github.com/dujiao-next/internal/modules/payment/domain"
 
 	fulfillmentdomain "github.com/dujiao-next/internal/modules/fulfillment/domain"
+	memberlevelcontract "github.com/dujiao-next/internal/modules/memberlevel/contract"
 	orderdomain "github.com/dujiao-next/internal/modules/order/domain"
 	ordergormstore "github.com/dujiao-next/internal/modules/order/infrastructure/gormstore"
 
@@ -585,8 +586,9 @@ func (r *failWalletRechargeReloadRepository) GetRechargeOrderByPaymentID(payment
 }
 
 type recordingMemberLevelProgressor struct {
-	rechargeUserID uint
-	rechargeAmount decimal.Decimal
+	rechargeUserID   uint
+	rechargeAmount   decimal.Decimal
+	purchasedOrderID []uint
 }
 
 func (r *recordingMemberLevelProgressor) OnOrderPaid(uint, decimal.Decimal) error {
@@ -599,6 +601,11 @@ func (r *recordingMemberLevelProgressor) OnRechargeCompleted(userID uint, amount
 	return nil
 }
 
+func (r *recordingMemberLevelProgressor) OnMembershipPurchased(_ uint, orderID uint, _ []memberlevelcontract.PurchasedItem) error {
+	r.purchasedOrderID = append(r.purchasedOrderID, orderID)
+	return nil
+}
+
 func TestWalletRechargeCallbackReloadFailureKeepsNotificationContext(t *testing.T) {
 	svc, db := setupPaymentServiceWalletTest(t)
 	payment, recharge := createWalletRechargeFixture(t, db, constants.PaymentStatusPending, constants.WalletRechargeStatusPending)
@@ -974,3 +981,73 @@ func assertWalletRechargeSuccessState(t *testing.T, db *gorm.DB, paymentID uint,
 func ptrTime(v time.Time) *time.Time {
 	return &v
 }
+
+type reviewQueueStub struct {
+	held []string
+}
+
+func (s *reviewQueueStub) EnqueueHeldOrder(order *orderdomain.Order) error {
+	s.held = append(s.held, order.OrderNo)
+	return nil
+}
+
+func TestCreatePaymentWalletHoldsRiskOrderUntilReleased(t *testing.T) {
+	svc, db := setupPaymentServiceWalletTest(t)
+	reviews := &reviewQueueStub{}
+	svc.SetReviewQueue(reviews)
+	progressor := &recordingMemberLevelProgressor{}
+	svc.SetMemberLevelService(progressor)
+	now := time.Now()
+
+	user := &userdomain.User{Email: "wallet_hold_user@example.com", PasswordHash: "hash", Status: constants.UserStatusActive, CreatedAt: now, UpdatedAt: now}
+	if err := db.Create(user).Error; err != nil {
+		t.Fatalf("create user failed: %v", err)
+	}
+	order := &orderdomain.Order{
+		OrderNo:        "DJTESTWALLETHOLD001",
+		UserID:         user.ID,
+		Status:         constants.OrderStatusPendingPayment,
+		Currency:       "CNY",
+		OriginalAmount: money.FromDecimal(decimal.NewFromInt(20)),
+		TotalAmount:    money.FromDecimal(decimal.NewFromInt(20)),
+		RiskScore:      75,
+		RiskDecision:   "hold",
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if err := db.Create(order).Error; err != nil {
+		t.Fatalf("create order failed: %v", err)
+	}
+	if err := db.Create(&walletdomain.Account{UserID: user.ID, Balance: money.FromDecimal(decimal.NewFromInt(100)), CreatedAt: now, UpdatedAt: now}).Error; err != nil {
+		t.Fatalf("create wallet account failed: %v", err)
+	}
+
+	result, err := svc.CreatePayment(CreatePaymentInput{OrderID: order.ID, UseBalance: true})
+	if err != nil || !result.OrderPaid {
+		t.Fatalf("create payment failed: paid=%v err=%v", result != nil && result.OrderPaid, err)
+	}
+	var held orderdomain.Order
+	if err := db.First(&held, order.ID).Error; err != nil {
+		t.Fatalf("reload order failed: %v", err)
+	}
+	if held.Status != constants.OrderStatusHeldForReview || held.ReviewHoldReason != constants.OrderReviewHoldRiskScore || held.HeldAt == nil || held.PaidAt == nil {
+		t.Fatalf("expected paid order held for review, got status=%s reason=%s held_at=%v", held.Status, held.ReviewHoldReason, held.HeldAt)
+	}
+	if len(reviews.held) != 1 || reviews.held[0] != order.OrderNo {
+		t.Fatalf("expected review queue notification, got %v", reviews.held)
+	}
+	if len(progressor.purchasedOrderID) != 0 {
+		t.Fatalf("held order must not grant purchased membership, got %v", progressor.purchasedOrderID)
+	}
+
+	released, err := svc.ReleaseHeldOrder(order.ID)
+	if err != nil || released.Status != constants.OrderStatusPaid {
+		t.Fatalf("release held order: %+v err=%v", released, err)
+	}
+	if len(progressor.purchasedOrderID) != 1 || progressor.purchasedOrderID[0] != order.ID {
+		t.Fatalf("release must grant purchased membership once, got %v", progressor.purchasedOrderID)
+	}
+	if _, err := svc.ReleaseHeldOrder(order.ID); err == nil {
+		t.Fatalf("releasing a non-held order must fail")
+	}
+}
@@ -25,6 +25,8 @@ type BotNotification struct {
 type Queue interface {
 	ordercontract.Queue
 	EnqueueOrderAutoFulfill(orderID uint) error
+	EnqueueFileFulfillment(orderID uint) error
+	EnqueueLicenseFulfillment(orderID uint) error
 	EnqueueBotNotification(input BotNotification) error
 	EnqueueWalletRechargeExpire(paymentID uint, delay time.Duration) error
 }
@@ -42,6 +42,20 @@ func (q *Queue) EnqueueOrderAutoFulfill(orderID uint) error {
 	return q.client.EnqueueOrderAutoFulfill(queue.OrderAutoFulfillPayload{OrderID: orderID}, asynq.MaxRetry(3))
 }
 
+func (q *Queue) EnqueueFileFulfillment(orderID uint) error {
+	if q == nil || q.client == nil {
+		return nil
+	}
+	return q.client.EnqueueFulfillmentFileDeliver(queue.FulfillmentOrderDeliverPayload{OrderID: orderID}, asynq.MaxRetry(5))
+}
+
+func (q *Queue) EnqueueLicenseFulfillment(orderID uint) error {
+	if q == nil || q.client == nil {
+		return nil
+	}
+	return q.client.EnqueueFulfillmentLicenseDeliver(queue.FulfillmentOrderDeliverPayload{OrderID: orderID}, asynq.MaxRetry(5))
+}
+
 func (q *Queue) EnqueueBotNotification(input paymentcontract.BotNotification) error {
 	if q == nil || q.client == nil {
 		return nil
@@ -37,6 +37,8 @@ var (
 	ErrWalletOnlyPaymentRequired           = errors.New("wallet only payment required")
 	ErrPaymentStatusInvalid                = errors.New("payment status invalid")
 	ErrPaymentAmountMismatch               = errors.New("payment amount mismatch")
+	ErrRiskOrderBlocked                    = errors.New("risk: order blocked")
+	ErrRiskCustomerBlacklisted             = errors.New("risk: customer blacklisted")
 )
 
 // CreatePaymentInput 创建支付输入。
@@ -356,6 +358,8 @@ var paymentCreateErrorRules = concatMappedErrors(
 		{target: ErrPaymentInvalid, code: response.CodeBadRequest, key: "error.payment_invalid"},
 		{target: ErrOrderNotFound, code: response.CodeNotFound, key: "error.order_not_found"},
 		{target: ErrOrderStatusInvalid, code: response.CodeBadRequest, key: "error.order_status_invalid"},
+		{target: ErrRiskOrderBlocked, code: response.CodeForbidden, key: "error.risk_order_blocked"},
+		{target: ErrRiskCustomerBlacklisted, code: response.CodeForbidden, key: "error.customer_blacklisted"},
 		{target: ErrPaymentChannelNotFound, code: response.CodeNotFound, key: "error.payment_channel_not_found"},
 		{target: ErrPaymentChannelInactive, code: response.CodeBadRequest, key: "error.payment_channel_inactive"},
 	},
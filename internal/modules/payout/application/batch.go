@@ -0,0 +1,314 @@
+package application
+
+import (
+	crand "crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	payoutcontract "github.com/dujiao-next/internal/modules/payout/contract"
+	payoutdomain "github.com/dujiao-next/internal/modules/payout/domain"
+	"github.com/dujiao-next/internal/shared/money"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	batchMaxItems      = 500
+	batchRemarkMaxLen  = 255
+	batchReferenceMax  = 128
+	batchNoPrefix      = "PO"
+	walletRemarkPrefix = "提现打款批次"
+)
+
+// ListBatches 管理端打款批次列表
+func (s *Service) ListBatches(filter payoutcontract.BatchListFilter) ([]payoutdomain.Batch, int64, error) {
+	filter.SourceType = strings.TrimSpace(filter.SourceType)
+	filter.Method = strings.TrimSpace(filter.Method)
+	filter.Status = strings.TrimSpace(filter.Status)
+	return s.store.ListBatches(filter)
+}
+
+// GetBatch 管理端打款批次详情（含明细）
+func (s *Service) GetBatch(id uint) (*payoutdomain.Batch, error) {
+	batch, err := s.store.GetBatch(id)
+	if err != nil {
+		return nil, err
+	}
+	if batch == nil {
+		return nil, payoutcontract.ErrBatchNotFound
+	}
+	return batch, nil
+}
+
+// CreateBatch 选取已审核的提现申请生成打款批次；钱包方式在同一事务内即时入账并结清。
+func (s *Service) CreateBatch(input CreateBatchInput) (*payoutdomain.Batch, error) {
+	if s.runner == nil {
+		return nil, payoutcontract.ErrUnavailable
+	}
+	source := strings.TrimSpace(input.SourceType)
+	method := strings.TrimSpace(input.Method)
+	ids := normalizeIDs(input.WithdrawIDs)
+	if !payoutdomain.IsSource(source) || !payoutdomain.IsMethod(method) || input.AdminID == 0 {
+		return nil, payoutcontract.ErrBatchInvalid
+	}
+	if len(ids) == 0 || len(ids) > batchMaxItems {
+		return nil, payoutcontract.ErrBatchInvalid
+	}
+
+	var batchID uint
+	err := s.runner.WithinPayoutTransaction(func(tx payoutcontract.Transaction) error {
+		candidates, err := tx.LockApprovedWithdraws(source, ids)
+		if err != nil {
+			return err
+		}
+		if len(candidates) != len(ids) {
+			return payoutcontract.ErrWithdrawUnavailable
+		}
+		currency := ""
+		total := decimal.Zero
+		for idx := range candidates {
+			candidates[idx].Currency = s.resolveCurrency(candidates[idx].Currency)
+			if currency == "" {
+				currency = candidates[idx].Currency
+			} else if candidates[idx].Currency != currency {
+				return payoutcontract.ErrCurrencyMismatch
+			}
+			total = total.Add(candidates[idx].Amount.Decimal.Round(2))
+		}
+		// 钱包按站点币种记账，非站点币种的提现只能走文件打款
+		if method == payoutdomain.MethodWallet && currency != s.resolveCurrency("") {
+			return payoutcontract.ErrCurrencyMismatch
+		}
+
+		now := time.Now()
+		batch := &payoutdomain.Batch{
+			BatchNo:     generateBatchNo(now),
+			SourceType:  source,
+			Method:      method,
+			Currency:    currency,
+			TotalAmount: money.FromDecimal(total.Round(2)),
+			ItemCount:   len(candidates),
+			Status:      payoutdomain.BatchStatusPending,
+			Remark:      truncateRunes(input.Remark, batchRemarkMaxLen),
+			CreatedBy:   input.AdminID,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+		if err := tx.Batches().CreateBatch(batch); err != nil {
+			return err
+		}
+		items := make([]payoutdomain.BatchItem, 0, len(candidates))
+		for _, candidate := range candidates {
+			if err := tx.AssignWithdrawBatch(source, candidate.WithdrawRequestID, batch.ID); err != nil {
+				return err
+			}
+			items = append(items, payoutdomain.BatchItem{
+				BatchID:           batch.ID,
+				SourceType:        source,
+				WithdrawRequestID: candidate.WithdrawRequestID,
+				RecipientUserID:   candidate.RecipientUserID,
+				Amount:            candidate.Amount,
+				Currency:          candidate.Currency,
+				Channel:           candidate.Channel,
+				Account:           candidate.Account,
+				Status:            payoutdomain.ItemStatusPending,
+				CreatedAt:         now,
+				UpdatedAt:         now,
+			})
+		}
+		if err := tx.Batches().CreateItems(items); err != nil {
+			return err
+		}
+		batchID = batch.ID
+		if method != payoutdomain.MethodWallet {
+			return nil
+		}
+		return settleWalletBatch(tx, batch, items, input.AdminID, now)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetBatch(batchID)
+}
+
+// MarkBatchPaid 文件打款完成后确认整批已付，逐条记录银行/链上流水号并结清提现申请。
+func (s *Service) MarkBatchPaid(input MarkBatchPaidInput) (*payoutdomain.Batch, error) {
+	if s.runner == nil {
+		return nil, payoutcontract.ErrUnavailable
+	}
+	if input.BatchID == 0 || input.AdminID == 0 {
+		return nil, payoutcontract.ErrBatchInvalid
+	}
+	err := s.runner.WithinPayoutTransaction(func(tx payoutcontract.Transaction) error {
+		batch, items, err := lockPendingBatch(tx, input.BatchID)
+		if err != nil {
+			return err
+		}
+		if !payoutdomain.IsFileMethod(batch.Method) {
+			return payoutcontract.ErrBatchStatusInvalid
+		}
+		now := time.Now()
+		for idx := range items {
+			item := &items[idx]
+			reference := strings.TrimSpace(input.ItemReferences[item.ID])
+			if reference == "" {
+				reference = strings.TrimSpace(input.Reference)
+			}
+			if reference == "" || len([]rune(reference)) > batchReferenceMax {
+				return payoutcontract.ErrReferenceRequired
+			}
+			if err := tx.SettleWithdraw(batch.SourceType, input.AdminID, item.WithdrawRequestID, batch.ID, reference); err != nil {
+				return err
+			}
+			item.Status = payoutdomain.ItemStatusPaid
+			item.TransactionRef = reference
+			item.PaidAt = &now
+			item.UpdatedAt = now
+			if err := tx.Batches().UpdateItem(item); err != nil {
+				return err
+			}
+		}
+		return markBatchPaid(tx, batch, input.AdminID, now)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetBatch(input.BatchID)
+}
+
+// CancelBatch 取消待打款批次，提现申请解除绑定并回到已审核状态。
+func (s *Service) CancelBatch(adminID, batchID uint) (*payoutdomain.Batch, error) {
+	if s.runner == nil {
+		return nil, payoutcontract.ErrUnavailable
+	}
+	if batchID == 0 || adminID == 0 {
+		return nil, payoutcontract.ErrBatchInvalid
+	}
+	err := s.runner.WithinPayoutTransaction(func(tx payoutcontract.Transaction) error {
+		batch, items, err := lockPendingBatch(tx, batchID)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		for idx := range items {
+			item := &items[idx]
+			if err := tx.AssignWithdrawBatch(batch.SourceType, item.WithdrawRequestID, 0); err != nil {
+				return err
+			}
+			item.Status = payoutdomain.ItemStatusCanceled
+			item.UpdatedAt = now
+			if err := tx.Batches().UpdateItem(item); err != nil {
+				return err
+			}
+		}
+		batch.Status = payoutdomain.BatchStatusCanceled
+		batch.CanceledBy = &adminID
+		batch.CanceledAt = &now
+		batch.UpdatedAt = now
+		return tx.Batches().UpdateBatch(batch)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetBatch(batchID)
+}
+
+// settleWalletBatch 逐条入账钱包，钱包流水引用与提现申请打款流水号一一对应。
+func settleWalletBatch(tx payoutcontract.Transaction, batch *payoutdomain.Batch, items []payoutdomain.BatchItem, adminID uint, now time.Time) error {
+	for idx := range items {
+		item := &items[idx]
+		walletTxn, err := tx.CreditWallet(payoutcontract.WalletCreditInput{
+			UserID:    item.RecipientUserID,
+			Amount:    item.Amount,
+			Currency:  item.Currency,
+			Reference: fmt.Sprintf("payout:%s:%d", item.SourceType, item.WithdrawRequestID),
+			Remark:    fmt.Sprintf("%s %s", walletRemarkPrefix, batch.BatchNo),
+		})
+		if err != nil {
+			return err
+		}
+		if walletTxn == nil {
+			return payoutcontract.ErrUnavailable
+		}
+		if err := tx.SettleWithdraw(item.SourceType, adminID, item.WithdrawRequestID, batch.ID, walletTxn.Reference); err != nil {
+			return err
+		}
+		item.Status = payoutdomain.ItemStatusPaid
+		item.TransactionRef = walletTxn.Reference
+		item.WalletTransactionID = &walletTxn.ID
+		item.PaidAt = &now
+		item.UpdatedAt = now
+		if err := tx.Batches().UpdateItem(item); err != nil {
+			return err
+		}
+	}
+	return markBatchPaid(tx, batch, adminID, now)
+}
+
+func lockPendingBatch(tx payoutcontract.Transaction, batchID uint) (*payoutdomain.Batch, []payoutdomain.BatchItem, error) {
+	batch, err := tx.Batches().GetBatchForUpdate(batchID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if batch == nil {
+		return nil, nil, payoutcontract.ErrBatchNotFound
+	}
+	if batch.Status != payoutdomain.BatchStatusPending {
+		return nil, nil, payoutcontract.ErrBatchStatusInvalid
+	}
+	items, err := tx.Batches().ListItems(batch.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return batch, items, nil
+}
+
+func markBatchPaid(tx payoutcontract.Transaction, batch *payoutdomain.Batch, adminID uint, now time.Time) error {
+	batch.Status = payoutdomain.BatchStatusPaid
+	batch.PaidBy = &adminID
+	batch.PaidAt = &now
+	batch.UpdatedAt = now
+	return tx.Batches().UpdateBatch(batch)
+}
+
+func (s *Service) resolveCurrency(currency string) string {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency == "" && s.currency != nil {
+		currency = strings.ToUpper(strings.TrimSpace(s.currency.SiteCurrency()))
+	}
+	return currency
+}
+
+func normalizeIDs(ids []uint) []uint {
+	seen := make(map[uint]struct{}, len(ids))
+	result := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if id == 0 {
+			continue
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		result = append(result, id)
+	}
+	return result
+}
+
+func truncateRunes(raw string, limit int) string {
+	value := strings.TrimSpace(raw)
+	if runes := []rune(value); len(runes) > limit {
+		return string(runes[:limit])
+	}
+	return value
+}
+
+func generateBatchNo(now time.Time) string {
+	buf := make([]byte, 4)
+	if _, err := crand.Read(buf); err != nil {
+		return fmt.Sprintf("%s%s%08d", batchNoPrefix, now.Format("20060102150405"), now.Nanosecond()%100000000)
+	}
+	return strings.ToUpper(fmt.Sprintf("%s%s%s", batchNoPrefix, now.Format("20060102150405"), hex.EncodeToString(buf)))
+}
@@ -0,0 +1,68 @@
+package application
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+
+	payoutcontract "github.com/dujiao-next/internal/modules/payout/contract"
+	payoutdomain "github.com/dujiao-next/internal/modules/payout/domain"
+)
+
+// ExportBatchFile 导出批次打款文件（CSV）；附言使用「批次号-明细ID」便于回填流水号对账。
+func (s *Service) ExportBatchFile(id uint) ([]byte, string, error) {
+	batch, err := s.GetBatch(id)
+	if err != nil {
+		return nil, "", err
+	}
+	if !payoutdomain.IsFileMethod(batch.Method) || batch.Status == payoutdomain.BatchStatusCanceled {
+		return nil, "", payoutcontract.ErrBatchStatusInvalid
+	}
+
+	builder := &strings.Builder{}
+	writer := csv.NewWriter(builder)
+	header := []string{"item_id", "withdraw_request_id", "recipient_user_id", "channel", "account", "amount", "currency", "memo"}
+	if batch.Method == payoutdomain.MethodUSDTFile {
+		header = []string{"address", "amount", "memo"}
+	}
+	if err := writer.Write(header); err != nil {
+		return nil, "", err
+	}
+	for _, item := range batch.Items {
+		if item.Status == payoutdomain.ItemStatusCanceled {
+			continue
+		}
+		memo := fmt.Sprintf("%s-%d", batch.BatchNo, item.ID)
+		record := []string{
+			strconv.FormatUint(uint64(item.ID), 10),
+			strconv.FormatUint(uint64(item.WithdrawRequestID), 10),
+			strconv.FormatUint(uint64(item.RecipientUserID), 10),
+			csvCell(item.Channel),
+			csvCell(item.Account),
+			item.Amount.String(),
+			csvCell(item.Currency),
+			memo,
+		}
+		if batch.Method == payoutdomain.MethodUSDTFile {
+			record = []string{csvCell(item.Account), item.Amount.String(), memo}
+		}
+		if err := writer.Write(record); err != nil {
+			return nil, "", err
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, "", err
+	}
+	filename := fmt.Sprintf("payout_%s_%s.csv", strings.ToLower(batch.BatchNo), batch.Method)
+	return []byte(builder.String()), filename, nil
+}
+
+// csvCell 为以公式字符开头的用户输入加单引号前缀，防止表格软件打开导出文件时执行公式。
+func csvCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
@@ -0,0 +1,28 @@
+package application
+
+import payoutcontract "github.com/dujiao-next/internal/modules/payout/contract"
+
+// Service 提现打款批次用例。
+type Service struct {
+	store    payoutcontract.Store
+	runner   payoutcontract.TransactionRunner
+	currency payoutcontract.CurrencyProvider
+}
+
+// Options 组装打款批次用例依赖。
+type Options struct {
+	Store    payoutcontract.Store
+	Runner   payoutcontract.TransactionRunner
+	Currency payoutcontract.CurrencyProvider
+}
+
+func NewService(opts Options) *Service {
+	if opts.Store == nil {
+		panic("payout service: store is nil")
+	}
+	return &Service{
+		store:    opts.Store,
+		runner:   opts.Runner,
+		currency: opts.Currency,
+	}
+}
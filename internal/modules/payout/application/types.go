@@ -0,0 +1,18 @@
+package application
+
+// CreateBatchInput 创建打款批次输入。
+type CreateBatchInput struct {
+	AdminID     uint
+	SourceType  string
+	Method      string
+	WithdrawIDs []uint
+	Remark      string
+}
+
+// MarkBatchPaidInput 确认批次打款输入；ItemReferences 按明细ID覆盖统一流水号。
+type MarkBatchPaidInput struct {
+	AdminID        uint
+	BatchID        uint
+	Reference      string
+	ItemReferences map[uint]string
+}
@@ -0,0 +1,13 @@
+package contract
+
+import "errors"
+
+var (
+	ErrUnavailable         = errors.New("payout unavailable")
+	ErrBatchInvalid        = errors.New("payout batch invalid")
+	ErrBatchNotFound       = errors.New("payout batch not found")
+	ErrBatchStatusInvalid  = errors.New("payout batch status invalid")
+	ErrWithdrawUnavailable = errors.New("payout withdraw unavailable")
+	ErrCurrencyMismatch    = errors.New("payout batch currency mismatch")
+	ErrReferenceRequired   = errors.New("payout transaction reference required")
+)
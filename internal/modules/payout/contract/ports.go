@@ -0,0 +1,66 @@
+package contract
+
+import (
+	payoutdomain "github.com/dujiao-next/internal/modules/payout/domain"
+	walletdomain "github.com/dujiao-next/internal/modules/wallet/domain"
+	"github.com/dujiao-next/internal/shared/money"
+)
+
+// BatchListFilter 打款批次列表筛选。
+type BatchListFilter struct {
+	SourceType string
+	Method     string
+	Status     string
+	Page       int
+	PageSize   int
+}
+
+// Store 是打款批次持久化端口。
+type Store interface {
+	CreateBatch(batch *payoutdomain.Batch) error
+	UpdateBatch(batch *payoutdomain.Batch) error
+	GetBatch(id uint) (*payoutdomain.Batch, error)
+	GetBatchForUpdate(id uint) (*payoutdomain.Batch, error)
+	ListBatches(filter BatchListFilter) ([]payoutdomain.Batch, int64, error)
+	CreateItems(items []payoutdomain.BatchItem) error
+	UpdateItem(item *payoutdomain.BatchItem) error
+	ListItems(batchID uint) ([]payoutdomain.BatchItem, error)
+}
+
+// WithdrawCandidate 是来源模块锁定的待出款提现申请快照。
+type WithdrawCandidate struct {
+	WithdrawRequestID uint
+	RecipientUserID   uint
+	Amount            money.Amount
+	Currency          string
+	Channel           string
+	Account           string
+}
+
+// WalletCreditInput 描述提现结算到钱包的入账事实。
+type WalletCreditInput struct {
+	UserID    uint
+	Amount    money.Amount
+	Currency  string
+	Reference string
+	Remark    string
+}
+
+// Transaction 是打款批次、来源提现申请与钱包入账共享事务内的能力集合。
+type Transaction interface {
+	Batches() Store
+	LockApprovedWithdraws(source string, ids []uint) ([]WithdrawCandidate, error)
+	AssignWithdrawBatch(source string, withdrawID, batchID uint) error
+	SettleWithdraw(source string, adminID, withdrawID, batchID uint, reference string) error
+	CreditWallet(input WalletCreditInput) (*walletdomain.Transaction, error)
+}
+
+// TransactionRunner 保证批次状态、提现状态与钱包入账原子提交或回滚。
+type TransactionRunner interface {
+	WithinPayoutTransaction(fn func(tx Transaction) error) error
+}
+
+// CurrencyProvider 是站点币种读取端口，用于补全不带币种的提现申请。
+type CurrencyProvider interface {
+	SiteCurrency() string
+}
@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/dujiao-next/internal/shared/money"
+)
+
+// 打款来源
+const (
+	SourceAffiliate = "affiliate" // 推广返利提现
+	SourceReseller  = "reseller"  // 分销收益提现
+)
+
+// 打款方式
+const (
+	MethodBankFile = "bank_file" // 导出银行批量转账文件
+	MethodUSDTFile = "usdt_file" // 导出 USDT 批量转账文件
+	MethodWallet   = "wallet"    // 即时结算到站内钱包
+)
+
+// 批次状态
+const (
+	BatchStatusPending  = "pending" // 已生成打款文件，待确认打款
+	BatchStatusPaid     = "paid"
+	BatchStatusCanceled = "canceled"
+)
+
+// 批次明细状态
+const (
+	ItemStatusPending  = "pending"
+	ItemStatusPaid     = "paid"
+	ItemStatusCanceled = "canceled"
+)
+
+// Batch 提现打款批次
+type Batch struct {
+	ID          uint         `gorm:"primarykey" json:"id"`                                      // 主键
+	BatchNo     string       `gorm:"type:varchar(48);uniqueIndex;not null" json:"batch_no"`     // 批次号
+	SourceType  string       `gorm:"type:varchar(32);not null;index" json:"source_type"`        // 打款来源
+	Method      string       `gorm:"type:varchar(32);not null;index" json:"method"`             // 打款方式
+	Currency    string       `gorm:"type:varchar(16);not null" json:"currency"`                 // 币种
+	TotalAmount money.Amount `gorm:"type:decimal(20,2);not null;default:0" json:"total_amount"` // 批次总额
+	ItemCount   int          `gorm:"not null;default:0" json:"item_count"`                      // 明细条数
+	Status      string       `gorm:"type:varchar(32);not null;index" json:"status"`             // 批次状态
+	Remark      string       `gorm:"type:varchar(255)" json:"remark,omitempty"`                 // 备注
+	CreatedBy   uint         `gorm:"not null;index" json:"created_by"`                          // 创建管理员ID
+	PaidBy      *uint        `gorm:"index" json:"paid_by,omitempty"`                            // 确认打款管理员ID
+	PaidAt      *time.Time   `gorm:"index" json:"paid_at,omitempty"`                            // 打款完成时间
+	CanceledBy  *uint        `gorm:"index" json:"canceled_by,omitempty"`                        // 取消管理员ID
+	CanceledAt  *time.Time   `json:"canceled_at,omitempty"`                                     // 取消时间
+	CreatedAt   time.Time    `gorm:"index" json:"created_at"`                                   // 创建时间
+	UpdatedAt   time.Time    `gorm:"index" json:"updated_at"`                                   // 更新时间
+
+	Items []BatchItem `gorm:"foreignKey:BatchID" json:"items,omitempty"` // 批次明细
+}
+
+// TableName 指定表名
+func (Batch) TableName() string {
+	return "payout_batches"
+}
+
+// BatchItem 打款批次明细，一条对应一笔提现申请
+type BatchItem struct {
+	ID                  uint         `gorm:"primarykey" json:"id"`                                                        // 主键
+	BatchID             uint         `gorm:"not null;index" json:"batch_id"`                                              // 批次ID
+	SourceType          string       `gorm:"type:varchar(32);not null;index:idx_payout_item_withdraw" json:"source_type"` // 打款来源
+	WithdrawRequestID   uint         `gorm:"not null;index:idx_payout_item_withdraw" json:"withdraw_request_id"`          // 来源提现申请ID
+	RecipientUserID     uint         `gorm:"not null;index" json:"recipient_user_id"`                                     // 收款用户ID
+	Amount              money.Amount `gorm:"type:decimal(20,2);not null;default:0" json:"amount"`                         // 打款金额
+	Currency            string       `gorm:"type:varchar(16);not null" json:"currency"`                                   // 币种
+	Channel             string       `gorm:"type:varchar(64)" json:"channel"`                                             // 提现渠道
+	Account             string       `gorm:"type:varchar(255)" json:"account"`                                            // 收款账号
+	Status              string       `gorm:"type:varchar(32);not null;index" json:"status"`                               // 明细状态
+	TransactionRef      string       `gorm:"type:varchar(128)" json:"transaction_ref,omitempty"`                          // 银行/链上流水号或钱包入账引用
+	WalletTransactionID *uint        `gorm:"index" json:"wallet_transaction_id,omitempty"`                                // 钱包入账流水ID
+	PaidAt              *time.Time   `json:"paid_at,omitempty"`                                                           // 打款时间
+	CreatedAt           time.Time    `gorm:"index" json:"created_at"`                                                     // 创建时间
+	UpdatedAt           time.Time    `gorm:"index" json:"updated_at"`                                                     // 更新时间
+}
+
+// TableName 指定表名
+func (BatchItem) TableName() string {
+	return "payout_batch_items"
+}
+
+// IsSource 判断打款来源是否合法
+func IsSource(source string) bool {
+	return source == SourceAffiliate || source == SourceReseller
+}
+
+// IsMethod 判断打款方式是否合法
+func IsMethod(method string) bool {
+	return method == MethodBankFile || method == MethodUSDTFile || method == MethodWallet
+}
+
+// IsFileMethod 文件打款需要导出文件后人工确认
+func IsFileMethod(method string) bool {
+	return method == MethodBankFile || method == MethodUSDTFile
+}
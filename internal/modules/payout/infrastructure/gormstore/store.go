@@ -0,0 +1,149 @@
+package gormstore
+
+import (
+	"errors"
+	"time"
+
+	payoutcontract "github.com/dujiao-next/internal/modules/payout/contract"
+	payoutdomain "github.com/dujiao-next/internal/modules/payout/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Store 是打款批次仓储端口的 GORM 实现。
+type Store struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) *Store {
+	return &Store{db: db}
+}
+
+func (r *Store) WithTx(tx *gorm.DB) *Store {
+	if tx == nil {
+		return r
+	}
+	return &Store{db: tx}
+}
+
+// Transaction 为批次写路径提供可与来源提现、钱包入账共享的事务。
+func (r *Store) Transaction(fn func(tx *gorm.DB) error) error {
+	return r.db.Transaction(fn)
+}
+
+// CreateBatch 创建打款批次
+func (r *Store) CreateBatch(batch *payoutdomain.Batch) error {
+	if batch == nil {
+		return errors.New("payout batch is nil")
+	}
+	return r.db.Omit(clause.Associations).Create(batch).Error
+}
+
+// UpdateBatch 更新打款批次
+func (r *Store) UpdateBatch(batch *payoutdomain.Batch) error {
+	if batch == nil || batch.ID == 0 {
+		return errors.New("payout batch is nil")
+	}
+	batch.UpdatedAt = time.Now()
+	return r.db.Model(&payoutdomain.Batch{}).
+		Where("id = ?", batch.ID).
+		Omit(clause.Associations).
+		Select("*").
+		Updates(batch).Error
+}
+
+// GetBatch 查询批次详情（含明细）
+func (r *Store) GetBatch(id uint) (*payoutdomain.Batch, error) {
+	if id == 0 {
+		return nil, nil
+	}
+	var row payoutdomain.Batch
+	if err := r.db.Preload("Items", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id ASC")
+	}).First(&row, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &row, nil
+}
+
+// GetBatchForUpdate 锁定查询批次（不含明细）
+func (r *Store) GetBatchForUpdate(id uint) (*payoutdomain.Batch, error) {
+	if id == 0 {
+		return nil, nil
+	}
+	var row payoutdomain.Batch
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&row, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &row, nil
+}
+
+// ListBatches 分页查询批次
+func (r *Store) ListBatches(filter payoutcontract.BatchListFilter) ([]payoutdomain.Batch, int64, error) {
+	query := r.db.Model(&payoutdomain.Batch{})
+	if filter.SourceType != "" {
+		query = query.Where("source_type = ?", filter.SourceType)
+	}
+	if filter.Method != "" {
+		query = query.Where("method = ?", filter.Method)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	page, pageSize := filter.Page, filter.PageSize
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+	rows := make([]payoutdomain.Batch, 0)
+	if err := query.Order("id DESC").Offset((page - 1) * pageSize).Limit(pageSize).Find(&rows).Error; err != nil {
+		return nil, 0, err
+	}
+	return rows, total, nil
+}
+
+// CreateItems 批量创建批次明细
+func (r *Store) CreateItems(items []payoutdomain.BatchItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+	return r.db.Create(&items).Error
+}
+
+// UpdateItem 更新批次明细
+func (r *Store) UpdateItem(item *payoutdomain.BatchItem) error {
+	if item == nil || item.ID == 0 {
+		return errors.New("payout batch item is nil")
+	}
+	return r.db.Model(&payoutdomain.BatchItem{}).
+		Where("id = ?", item.ID).
+		Select("*").
+		Updates(item).Error
+}
+
+// ListItems 查询批次明细
+func (r *Store) ListItems(batchID uint) ([]payoutdomain.BatchItem, error) {
+	rows := make([]payoutdomain.BatchItem, 0)
+	if batchID == 0 {
+		return rows, nil
+	}
+	if err := r.db.Where("batch_id = ?", batchID).Order("id ASC").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+var _ payoutcontract.Store = (*Store)(nil)
@@ -0,0 +1,327 @@
+package integrationtest
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dujiao-next/internal/constants"
+	affiliateapp "github.com/dujiao-next/internal/modules/affiliate/application"
+	affiliatedomain "github.com/dujiao-next/internal/modules/affiliate/domain"
+	affiliategormstore "github.com/dujiao-next/internal/modules/affiliate/infrastructure/gormstore"
+	userdomain "github.com/dujiao-next/internal/modules/identity/user/domain"
+	orderdomain "github.com/dujiao-next/internal/modules/order/domain"
+	payoutapp "github.com/dujiao-next/internal/modules/payout/application"
+	payoutcontract "github.com/dujiao-next/internal/modules/payout/contract"
+	payoutdomain "github.com/dujiao-next/internal/modules/payout/domain"
+	payoutgormstore "github.com/dujiao-next/internal/modules/payout/infrastructure/gormstore"
+	resellerapp "github.com/dujiao-next/internal/modules/reseller/application"
+	resellerdomain "github.com/dujiao-next/internal/modules/reseller/domain"
+	resellergormstore "github.com/dujiao-next/internal/modules/reseller/infrastructure/gormstore"
+	walletapp "github.com/dujiao-next/internal/modules/wallet/application"
+	walletdomain "github.com/dujiao-next/internal/modules/wallet/domain"
+	walletgormstore "github.com/dujiao-next/internal/modules/wallet/infrastructure/gormstore"
+	"github.com/dujiao-next/internal/shared/money"
+	payoutgormuow "github.com/dujiao-next/internal/workflows/payout/infrastructure/gormuow"
+
+	"github.com/glebarez/sqlite"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+type payoutTestCurrency struct{}
+
+func (payoutTestCurrency) SiteCurrency() string { return "CNY" }
+
+type payoutTestHarness struct {
+	db         *gorm.DB
+	payouts    *payoutapp.Service
+	affiliates *affiliateapp.Service
+	resellers  *resellerapp.AccountingWithdrawService
+}
+
+func setupPayoutTest(t *testing.T) payoutTestHarness {
+	t.Helper()
+	dsn := fmt.Sprintf("file:payout_service_test_%d?mode=memory&cache=shared", time.Now().UnixNano())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite failed: %v", err)
+	}
+	if err := db.AutoMigrate(
+		&userdomain.User{},
+		&orderdomain.Order{},
+		&affiliatedomain.Profile{},
+		&affiliatedomain.Commission{},
+		&affiliatedomain.WithdrawRequest{},
+		&resellerdomain.Profile{},
+		&resellerdomain.LedgerEntry{},
+		&resellerdomain.WithdrawRequest{},
+		&resellerdomain.BalanceAccount{},
+		&walletdomain.Account{},
+		&walletdomain.Transaction{},
+		&payoutdomain.Batch{},
+		&payoutdomain.BatchItem{},
+	); err != nil {
+		t.Fatalf("auto migrate failed: %v", err)
+	}
+
+	affiliates := affiliateapp.NewService(affiliategormstore.New(db), nil, nil, nil, nil)
+	resellers := resellerapp.NewAccountingWithdrawService(resellergormstore.New(db))
+	walletStore := walletgormstore.New(db)
+	wallet := walletapp.NewService(walletapp.Options{Repository: walletStore, Transactions: walletStore})
+	batches := payoutgormstore.New(db)
+	payouts := payoutapp.NewService(payoutapp.Options{
+		Store:    batches,
+		Runner:   payoutgormuow.New(batches, affiliates, resellers, wallet),
+		Currency: payoutTestCurrency{},
+	})
+	return payoutTestHarness{db: db, payouts: payouts, affiliates: affiliates, resellers: resellers}
+}
+
+func createPayoutTestUser(t *testing.T, db *gorm.DB, email string) userdomain.User {
+	t.Helper()
+	user := userdomain.User{Email: email, PasswordHash: "hash", Status: constants.UserStatusActive}
+	if err := db.Create(&user).Error; err != nil {
+		t.Fatalf("create user failed: %v", err)
+	}
+	return user
+}
+
+// createAffiliateWithdraw 创建一笔冻结佣金的推广提现申请，并按需审核通过
+func createAffiliateWithdraw(t *testing.T, h payoutTestHarness, email string, amount string, approve bool) (userdomain.User, affiliatedomain.WithdrawRequest) {
+	t.Helper()
+	user := createPayoutTestUser(t, h.db, email)
+	profile := affiliatedomain.Profile{UserID: user.ID, AffiliateCode: strings.ToUpper(email[:6]), Status: constants.AffiliateProfileStatusActive}
+	if err := h.db.Create(&profile).Error; err != nil {
+		t.Fatalf("create affiliate profile failed: %v", err)
+	}
+	now := time.Now()
+	req := affiliatedomain.WithdrawRequest{
+		AffiliateProfileID: profile.ID,
+		Amount:             money.FromDecimal(decimal.RequireFromString(amount)),
+		Channel:            "alipay",
+		Account:            email,
+		Status:             constants.AffiliateWithdrawStatusPendingReview,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}
+	if err := h.db.Create(&req).Error; err != nil {
+		t.Fatalf("create affiliate withdraw failed: %v", err)
+	}
+	commission := affiliatedomain.Commission{
+		AffiliateProfileID: profile.ID,
+		OrderID:            req.ID,
+		CommissionType:     constants.AffiliateCommissionTypeOrder,
+		CommissionAmount:   req.Amount,
+		Status:             constants.AffiliateCommissionStatusAvailable,
+		WithdrawRequestID:  &req.ID,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}
+	if err := h.db.Create(&commission).Error; err != nil {
+		t.Fatalf("create affiliate commission failed: %v", err)
+	}
+	if approve {
+		if _, err := h.affiliates.ReviewWithdraw(1, req.ID, constants.AffiliateWithdrawActionApprove, ""); err != nil {
+			t.Fatalf("approve affiliate withdraw failed: %v", err)
+		}
+	}
+	return user, req
+}
+
+func TestPayoutWalletBatchSettlesAffiliateWithdrawsIntoWallet(t *testing.T) {
+	h := setupPayoutTest(t)
+	userA, withdrawA := createAffiliateWithdraw(t, h, "alpha@example.test", "30.00", true)
+	_, withdrawB := createAffiliateWithdraw(t, h, "bravo@example.test", "12.50", true)
+	_, pending := createAffiliateWithdraw(t, h, "charlie@example.test", "5.00", false)
+
+	if _, err := h.payouts.CreateBatch(payoutapp.CreateBatchInput{
+		AdminID: 1, SourceType: payoutdomain.SourceAffiliate, Method: payoutdomain.MethodWallet,
+		WithdrawIDs: []uint{withdrawA.ID, pending.ID},
+	}); !errors.Is(err, payoutcontract.ErrWithdrawUnavailable) {
+		t.Fatalf("expected unapproved withdraw rejected, got %v", err)
+	}
+
+	batch, err := h.payouts.CreateBatch(payoutapp.CreateBatchInput{
+		AdminID: 1, SourceType: payoutdomain.SourceAffiliate, Method: payoutdomain.MethodWallet,
+		WithdrawIDs: []uint{withdrawA.ID, withdrawB.ID},
+	})
+	if err != nil {
+		t.Fatalf("create wallet batch failed: %v", err)
+	}
+	if batch.Status != payoutdomain.BatchStatusPaid || batch.ItemCount != 2 || batch.TotalAmount.String() != "42.50" || batch.Currency != "CNY" {
+		t.Fatalf("unexpected wallet batch: %+v", batch)
+	}
+	for _, item := range batch.Items {
+		if item.Status != payoutdomain.ItemStatusPaid || item.WalletTransactionID == nil || item.TransactionRef == "" {
+			t.Fatalf("wallet item not settled: %+v", item)
+		}
+	}
+
+	var account walletdomain.Account
+	if err := h.db.Where("user_id = ?", userA.ID).First(&account).Error; err != nil {
+		t.Fatalf("load wallet account failed: %v", err)
+	}
+	if account.Balance.String() != "30.00" {
+		t.Fatalf("expected wallet balance 30.00, got %s", account.Balance.String())
+	}
+	var txn walletdomain.Transaction
+	if err := h.db.Where("user_id = ?", userA.ID).First(&txn).Error; err != nil {
+		t.Fatalf("load wallet transaction failed: %v", err)
+	}
+	if txn.Type != constants.WalletTxnTypePayout {
+		t.Fatalf("expected payout wallet txn, got %s", txn.Type)
+	}
+
+	var paid affiliatedomain.WithdrawRequest
+	if err := h.db.First(&paid, withdrawA.ID).Error; err != nil {
+		t.Fatalf("load withdraw failed: %v", err)
+	}
+	if paid.Status != constants.AffiliateWithdrawStatusPaid || paid.PayoutBatchID == nil || *paid.PayoutBatchID != batch.ID || paid.PayoutReference != txn.Reference {
+		t.Fatalf("withdraw not linked to payout: %+v", paid)
+	}
+	var commission affiliatedomain.Commission
+	if err := h.db.Where("withdraw_request_id = ?", withdrawA.ID).First(&commission).Error; err != nil {
+		t.Fatalf("load commission failed: %v", err)
+	}
+	if commission.Status != constants.AffiliateCommissionStatusWithdrawn {
+		t.Fatalf("expected commission withdrawn, got %s", commission.Status)
+	}
+
+	if _, err := h.payouts.CreateBatch(payoutapp.CreateBatchInput{
+		AdminID: 1, SourceType: payoutdomain.SourceAffiliate, Method: payoutdomain.MethodBankFile,
+		WithdrawIDs: []uint{withdrawA.ID},
+	}); !errors.Is(err, payoutcontract.ErrWithdrawUnavailable) {
+		t.Fatalf("expected paid withdraw rejected from new batch, got %v", err)
+	}
+}
+
+func TestPayoutFileBatchExportMarkPaidAndCancel(t *testing.T) {
+	h := setupPayoutTest(t)
+	user := createPayoutTestUser(t, h.db, "reseller@example.test")
+	profile := resellerdomain.Profile{UserID: user.ID, Status: "active"}
+	if err := h.db.Create(&profile).Error; err != nil {
+		t.Fatalf("create reseller profile failed: %v", err)
+	}
+	now := time.Now()
+	withdraw := resellerdomain.WithdrawRequest{
+		ResellerID: profile.ID, Amount: money.FromDecimal(decimal.RequireFromString("88.00")), Currency: "USD",
+		Channel: "usdt-trc20", Account: "TXaddress", Status: resellerdomain.WithdrawStatusPending, CreatedAt: now, UpdatedAt: now,
+	}
+	if err := h.db.Create(&withdraw).Error; err != nil {
+		t.Fatalf("create reseller withdraw failed: %v", err)
+	}
+	ledger := resellerdomain.LedgerEntry{
+		ResellerID: profile.ID, Type: resellerdomain.LedgerTypeOrderProfit, Amount: withdraw.Amount, Currency: "USD",
+		IdempotencyKey: "profit:1", Status: resellerdomain.LedgerStatusLocked, WithdrawRequestID: &withdraw.ID, CreatedAt: now, UpdatedAt: now,
+	}
+	if err := h.db.Create(&ledger).Error; err != nil {
+		t.Fatalf("create reseller ledger failed: %v", err)
+	}
+	if _, err := h.resellers.ReviewWithdraw(1, withdraw.ID, "approve", ""); err != nil {
+		t.Fatalf("approve reseller withdraw failed: %v", err)
+	}
+
+	if _, err := h.payouts.CreateBatch(payoutapp.CreateBatchInput{
+		AdminID: 1, SourceType: payoutdomain.SourceReseller, Method: payoutdomain.MethodWallet, WithdrawIDs: []uint{withdraw.ID},
+	}); !errors.Is(err, payoutcontract.ErrCurrencyMismatch) {
+		t.Fatalf("expected non-site currency rejected for wallet, got %v", err)
+	}
+
+	canceled, err := h.payouts.CreateBatch(payoutapp.CreateBatchInput{
+		AdminID: 1, SourceType: payoutdomain.SourceReseller, Method: payoutdomain.MethodUSDTFile, WithdrawIDs: []uint{withdraw.ID},
+	})
+	if err != nil {
+		t.Fatalf("create usdt batch failed: %v", err)
+	}
+	if _, err := h.resellers.ReviewWithdraw(1, withdraw.ID, "pay", ""); err == nil {
+		t.Fatalf("expected batched withdraw to block manual pay")
+	}
+	if _, err := h.payouts.CancelBatch(1, canceled.ID); err != nil {
+		t.Fatalf("cancel batch failed: %v", err)
+	}
+	var released resellerdomain.WithdrawRequest
+	if err := h.db.First(&released, withdraw.ID).Error; err != nil {
+		t.Fatalf("load withdraw failed: %v", err)
+	}
+	if released.Status != resellerdomain.WithdrawStatusApproved || released.PayoutBatchID != nil {
+		t.Fatalf("expected withdraw released after cancel: %+v", released)
+	}
+
+	batch, err := h.payouts.CreateBatch(payoutapp.CreateBatchInput{
+		AdminID: 1, SourceType: payoutdomain.SourceReseller, Method: payoutdomain.MethodUSDTFile, WithdrawIDs: []uint{withdraw.ID},
+	})
+	if err != nil {
+		t.Fatalf("recreate usdt batch failed: %v", err)
+	}
+	content, filename, err := h.payouts.ExportBatchFile(batch.ID)
+	if err != nil {
+		t.Fatalf("export batch failed: %v", err)
+	}
+	item := batch.Items[0]
+	expectedRow := fmt.Sprintf("TXaddress,88.00,%s-%d", batch.BatchNo, item.ID)
+	if !strings.HasPrefix(string(content), "address,amount,memo\n") || !strings.Contains(string(content), expectedRow) || !strings.HasSuffix(filename, ".csv") {
+		t.Fatalf("unexpected export %s: %q", filename, string(content))
+	}
+
+	if _, err := h.payouts.MarkBatchPaid(payoutapp.MarkBatchPaidInput{AdminID: 1, BatchID: batch.ID}); !errors.Is(err, payoutcontract.ErrReferenceRequired) {
+		t.Fatalf("expected reference required, got %v", err)
+	}
+	paidBatch, err := h.payouts.MarkBatchPaid(payoutapp.MarkBatchPaidInput{
+		AdminID: 2, BatchID: batch.ID, ItemReferences: map[uint]string{item.ID: "0xabc123"},
+	})
+	if err != nil {
+		t.Fatalf("mark batch paid failed: %v", err)
+	}
+	if paidBatch.Status != payoutdomain.BatchStatusPaid || paidBatch.PaidBy == nil || paidBatch.Items[0].TransactionRef != "0xabc123" {
+		t.Fatalf("unexpected paid batch: %+v", paidBatch)
+	}
+
+	var paid resellerdomain.WithdrawRequest
+	if err := h.db.First(&paid, withdraw.ID).Error; err != nil {
+		t.Fatalf("load withdraw failed: %v", err)
+	}
+	if paid.Status != resellerdomain.WithdrawStatusPaid || paid.PayoutReference != "0xabc123" || paid.PayoutBatchID == nil || *paid.PayoutBatchID != batch.ID {
+		t.Fatalf("withdraw not linked to payout: %+v", paid)
+	}
+	var ledgerAfter resellerdomain.LedgerEntry
+	if err := h.db.First(&ledgerAfter, ledger.ID).Error; err != nil {
+		t.Fatalf("load ledger failed: %v", err)
+	}
+	if ledgerAfter.Status != resellerdomain.LedgerStatusWithdrawn {
+		t.Fatalf("expected ledger withdrawn, got %s", ledgerAfter.Status)
+	}
+	if _, err := h.payouts.CancelBatch(1, batch.ID); !errors.Is(err, payoutcontract.ErrBatchStatusInvalid) {
+		t.Fatalf("expected paid batch cannot be canceled, got %v", err)
+	}
+}
+
+func TestPayoutFileExportEscapesFormulaCells(t *testing.T) {
+	h := setupPayoutTest(t)
+	_, withdraw := createAffiliateWithdraw(t, h, "delta@example.test", "20.00", true)
+	if err := h.db.Model(&affiliatedomain.WithdrawRequest{}).Where("id = ?", withdraw.ID).
+		Updates(map[string]interface{}{"channel": "@SUM(A1)", "account": "=HYPERLINK(\"http://evil.test\")"}).Error; err != nil {
+		t.Fatalf("update withdraw account failed: %v", err)
+	}
+
+	batch, err := h.payouts.CreateBatch(payoutapp.CreateBatchInput{
+		AdminID: 1, SourceType: payoutdomain.SourceAffiliate, Method: payoutdomain.MethodBankFile, WithdrawIDs: []uint{withdraw.ID},
+	})
+	if err != nil {
+		t.Fatalf("create bank batch failed: %v", err)
+	}
+	content, _, err := h.payouts.ExportBatchFile(batch.ID)
+	if err != nil {
+		t.Fatalf("export batch failed: %v", err)
+	}
+	rows, err := csv.NewReader(strings.NewReader(string(content))).ReadAll()
+	if err != nil || len(rows) != 2 {
+		t.Fatalf("unexpected export rows %v: %q", err, string(content))
+	}
+	if rows[1][3] != "'@SUM(A1)" || rows[1][4] != "'=HYPERLINK(\"http://evil.test\")" {
+		t.Fatalf("formula cells not escaped: %q", rows[1])
+	}
+}
@@ -0,0 +1,201 @@
+package payouthttp
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	payoutapp "github.com/dujiao-next/internal/modules/payout/application"
+	payoutcontract "github.com/dujiao-next/internal/modules/payout/contract"
+	payoutdomain "github.com/dujiao-next/internal/modules/payout/domain"
+	"github.com/dujiao-next/internal/platform/http/ginutil"
+	"github.com/dujiao-next/internal/platform/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminService 是后台打款批次管理端口。
+type AdminService interface {
+	ListBatches(filter payoutcontract.BatchListFilter) ([]payoutdomain.Batch, int64, error)
+	GetBatch(id uint) (*payoutdomain.Batch, error)
+	CreateBatch(input payoutapp.CreateBatchInput) (*payoutdomain.Batch, error)
+	ExportBatchFile(id uint) ([]byte, string, error)
+	MarkBatchPaid(input payoutapp.MarkBatchPaidInput) (*payoutdomain.Batch, error)
+	CancelBatch(adminID, batchID uint) (*payoutdomain.Batch, error)
+}
+
+// AdminHandler 处理后台提现打款批次请求。
+type AdminHandler struct {
+	payouts AdminService
+}
+
+func NewAdminHandler(payouts AdminService) *AdminHandler {
+	if payouts == nil {
+		panic("payout admin handler: payouts is nil")
+	}
+	return &AdminHandler{payouts: payouts}
+}
+
+type createBatchRequest struct {
+	SourceType  string `json:"source_type" binding:"required"`
+	Method      string `json:"method" binding:"required"`
+	WithdrawIDs []uint `json:"withdraw_ids" binding:"required"`
+	Remark      string `json:"remark" binding:"max=255"`
+}
+
+type markBatchPaidItemRequest struct {
+	ItemID    uint   `json:"item_id" binding:"required"`
+	Reference string `json:"reference" binding:"required,max=128"`
+}
+
+type markBatchPaidRequest struct {
+	Reference string                     `json:"reference" binding:"max=128"`
+	Items     []markBatchPaidItemRequest `json:"items"`
+}
+
+func respondPayoutError(c *gin.Context, err error, fallbackKey string) {
+	switch {
+	case errors.Is(err, payoutcontract.ErrBatchInvalid):
+		ginutil.RespondError(c, response.CodeBadRequest, "error.payout_batch_invalid", nil)
+	case errors.Is(err, payoutcontract.ErrBatchNotFound):
+		ginutil.RespondError(c, response.CodeNotFound, "error.payout_batch_not_found", nil)
+	case errors.Is(err, payoutcontract.ErrBatchStatusInvalid):
+		ginutil.RespondError(c, response.CodeBadRequest, "error.payout_batch_status_invalid", nil)
+	case errors.Is(err, payoutcontract.ErrWithdrawUnavailable):
+		ginutil.RespondError(c, response.CodeBadRequest, "error.payout_withdraw_unavailable", nil)
+	case errors.Is(err, payoutcontract.ErrCurrencyMismatch):
+		ginutil.RespondError(c, response.CodeBadRequest, "error.payout_currency_mismatch", nil)
+	case errors.Is(err, payoutcontract.ErrReferenceRequired):
+		ginutil.RespondError(c, response.CodeBadRequest, "error.payout_reference_required", nil)
+	default:
+		ginutil.RespondError(c, response.CodeInternal, fallbackKey, err)
+	}
+}
+
+// ListBatches 打款批次列表
+func (h *AdminHandler) ListBatches(c *gin.Context) {
+	page, pageSize := ginutil.ParsePagination(c)
+	rows, total, err := h.payouts.ListBatches(payoutcontract.BatchListFilter{
+		SourceType: strings.TrimSpace(c.Query("source_type")),
+		Method:     strings.TrimSpace(c.Query("method")),
+		Status:     strings.TrimSpace(c.Query("status")),
+		Page:       page,
+		PageSize:   pageSize,
+	})
+	if err != nil {
+		respondPayoutError(c, err, "error.payout_batch_fetch_failed")
+		return
+	}
+	response.SuccessWithPage(c, rows, response.BuildPagination(page, pageSize, total))
+}
+
+// GetBatch 打款批次详情
+func (h *AdminHandler) GetBatch(c *gin.Context) {
+	id, err := ginutil.ParseParamUint(c, "id")
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", nil)
+		return
+	}
+	batch, err := h.payouts.GetBatch(id)
+	if err != nil {
+		respondPayoutError(c, err, "error.payout_batch_fetch_failed")
+		return
+	}
+	response.Success(c, batch)
+}
+
+// CreateBatch 选取已审核的提现申请生成打款批次
+func (h *AdminHandler) CreateBatch(c *gin.Context) {
+	adminID, ok := ginutil.GetAdminID(c)
+	if !ok {
+		return
+	}
+	var req createBatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ginutil.RespondBindError(c, err)
+		return
+	}
+	batch, err := h.payouts.CreateBatch(payoutapp.CreateBatchInput{
+		AdminID:     adminID,
+		SourceType:  req.SourceType,
+		Method:      req.Method,
+		WithdrawIDs: req.WithdrawIDs,
+		Remark:      req.Remark,
+	})
+	if err != nil {
+		respondPayoutError(c, err, "error.payout_batch_save_failed")
+		return
+	}
+	response.Success(c, batch)
+}
+
+// ExportBatch 下载批次打款文件
+func (h *AdminHandler) ExportBatch(c *gin.Context) {
+	id, err := ginutil.ParseParamUint(c, "id")
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", nil)
+		return
+	}
+	content, filename, err := h.payouts.ExportBatchFile(id)
+	if err != nil {
+		respondPayoutError(c, err, "error.payout_batch_fetch_failed")
+		return
+	}
+	contentType := "text/csv; charset=utf-8"
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
+	c.Data(http.StatusOK, contentType, content)
+}
+
+// MarkBatchPaid 确认整批已打款并回填流水号
+func (h *AdminHandler) MarkBatchPaid(c *gin.Context) {
+	adminID, ok := ginutil.GetAdminID(c)
+	if !ok {
+		return
+	}
+	id, err := ginutil.ParseParamUint(c, "id")
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", nil)
+		return
+	}
+	var req markBatchPaidRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ginutil.RespondBindError(c, err)
+		return
+	}
+	references := make(map[uint]string, len(req.Items))
+	for _, item := range req.Items {
+		references[item.ItemID] = item.Reference
+	}
+	batch, err := h.payouts.MarkBatchPaid(payoutapp.MarkBatchPaidInput{
+		AdminID:        adminID,
+		BatchID:        id,
+		Reference:      req.Reference,
+		ItemReferences: references,
+	})
+	if err != nil {
+		respondPayoutError(c, err, "error.payout_batch_save_failed")
+		return
+	}
+	response.Success(c, batch)
+}
+
+// CancelBatch 取消待打款批次
+func (h *AdminHandler) CancelBatch(c *gin.Context) {
+	adminID, ok := ginutil.GetAdminID(c)
+	if !ok {
+		return
+	}
+	id, err := ginutil.ParseParamUint(c, "id")
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", nil)
+		return
+	}
+	batch, err := h.payouts.CancelBatch(adminID, id)
+	if err != nil {
+		respondPayoutError(c, err, "error.payout_batch_save_failed")
+		return
+	}
+	response.Success(c, batch)
+}
@@ -0,0 +1,12 @@
+package payouthttp
+
+import "github.com/gin-gonic/gin"
+
+func RegisterAdminRoutes(admin gin.IRoutes, handler *AdminHandler) {
+	admin.GET("/payout-batches", handler.ListBatches)
+	admin.POST("/payout-batches", handler.CreateBatch)
+	admin.GET("/payout-batches/:id", handler.GetBatch)
+	admin.GET("/payout-batches/:id/export", handler.ExportBatch)
+	admin.POST("/payout-batches/:id/mark-paid", handler.MarkBatchPaid)
+	admin.POST("/payout-batches/:id/cancel", handler.CancelBatch)
+}
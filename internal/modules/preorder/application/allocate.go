@@ -0,0 +1,260 @@
+package application
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/dujiao-next/internal/constants"
+	"github.com/dujiao-next/internal/logger"
+	productdomain "github.com/dujiao-next/internal/modules/catalog/product/domain"
+	preordercontract "github.com/dujiao-next/internal/modules/preorder/contract"
+	preorderdomain "github.com/dujiao-next/internal/modules/preorder/domain"
+)
+
+// errStockShort 库存不足以分配当前排队记录，回滚事务内的部分预占。
+var errStockShort = errors.New("preorder stock short")
+
+type allocateOutcome int
+
+const (
+	allocateSkipped allocateOutcome = iota
+	allocateBlocked
+	allocateDone
+)
+
+// TriggerCheck 库存可能增加后调度商品的预售分配，失败仅记录日志。
+// 分配完成后再转交到货提醒，使剩余库存优先满足已付款的预售。
+func (s *Service) TriggerCheck(productID uint) {
+	if productID == 0 {
+		return
+	}
+	s.scheduleAllocate(productID)
+}
+
+// Allocate 按支付先后为商品的排队记录分配库存；某规格库存不足时，其后的同规格记录不再尝试，保证先到先得。
+func (s *Service) Allocate(productID uint) error {
+	if productID == 0 {
+		return nil
+	}
+	queued, err := s.store.ListQueuedByProduct(productID, preorderdomain.AllocateBatchSize)
+	if err != nil {
+		return err
+	}
+	blocked := make(map[uint]bool)
+	allocated := 0
+	for _, entry := range queued {
+		if blocked[entry.SKUID] {
+			continue
+		}
+		outcome, err := s.allocateEntry(entry.ID)
+		if err != nil {
+			logger.Warnw("preorder_allocate_entry_failed", "backorder_id", entry.ID, "error", err)
+			blocked[entry.SKUID] = true
+			continue
+		}
+		switch outcome {
+		case allocateBlocked:
+			blocked[entry.SKUID] = true
+		case allocateDone:
+			allocated++
+		}
+	}
+	if allocated > 0 {
+		logger.Infow("preorder_backorders_allocated", "product_id", productID, "count", allocated)
+	}
+	if len(queued) >= preorderdomain.AllocateBatchSize && allocated > 0 {
+		s.scheduleAllocate(productID)
+		return nil
+	}
+	if s.restockTrigger != nil {
+		s.restockTrigger.TriggerCheck(productID)
+	}
+	return nil
+}
+
+// allocateEntry 在事务内锁定排队记录与订单并预占库存；订单已取消或退款的记录随之关闭。
+func (s *Service) allocateEntry(id uint) (allocateOutcome, error) {
+	outcome := allocateSkipped
+	var allocated *preorderdomain.Backorder
+	now := s.now()
+	err := s.store.WithinTransaction(func(tx preordercontract.Transaction) error {
+		entry, err := tx.Backorders().GetForUpdate(id)
+		if err != nil {
+			return err
+		}
+		if entry == nil || entry.Status != preorderdomain.StatusQueued {
+			return nil
+		}
+		order, err := tx.Orders().GetByIDForUpdate(entry.OrderID)
+		if err != nil {
+			return err
+		}
+		closeStatus := ""
+		switch {
+		case order == nil, order.Status == constants.OrderStatusCanceled, order.Status == constants.OrderStatusRefunded:
+			closeStatus = preorderdomain.StatusCanceled
+		case order.Status == constants.OrderStatusDelivered, order.Status == constants.OrderStatusCompleted:
+			closeStatus = preorderdomain.StatusFulfilled
+		case order.Status != constants.OrderStatusPaid:
+			// 待复核或部分退款的订单暂不分配，逾期后统一退款
+			return nil
+		}
+		if closeStatus != "" {
+			return tx.Backorders().Update(entry.ID, map[string]interface{}{
+				"status":     closeStatus,
+				"closed_at":  now,
+				"updated_at": now,
+			})
+		}
+
+		tracked, err := reserveStock(tx, entry, now)
+		if err != nil {
+			return err
+		}
+		updates := map[string]interface{}{
+			"allocated_at": now,
+			"updated_at":   now,
+		}
+		if entry.PaymentMode == preorderdomain.PaymentModeDeposit && entry.BalanceAmount.Decimal.IsPositive() {
+			dueAt := now.Add(preorderdomain.BalanceWindow)
+			updates["status"] = preorderdomain.StatusAwaitingBalance
+			updates["balance_due_at"] = dueAt
+			entry.Status = preorderdomain.StatusAwaitingBalance
+			entry.BalanceDueAt = &dueAt
+		} else {
+			if entry.FulfillmentType == constants.FulfillmentTypeManual {
+				if err := startManualFulfillment(tx, entry, order.ParentID, tracked, now); err != nil {
+					return err
+				}
+			}
+			updates["status"] = preorderdomain.StatusAllocated
+			entry.Status = preorderdomain.StatusAllocated
+		}
+		entry.AllocatedAt = &now
+		if err := tx.Backorders().Update(entry.ID, updates); err != nil {
+			return err
+		}
+		allocated = entry
+		return nil
+	})
+	if errors.Is(err, errStockShort) {
+		return allocateBlocked, nil
+	}
+	if err != nil {
+		return allocateSkipped, err
+	}
+	if allocated != nil {
+		outcome = allocateDone
+		if allocated.Status == preorderdomain.StatusAllocated {
+			s.dispatchAllocated(allocated)
+		}
+	}
+	return outcome, nil
+}
+
+// reserveStock 为排队记录预占库存：自动发货锁定卡密到订单，人工交付锁定规格库存。
+// 返回人工库存是否受限（无限库存无需锁定）。
+func reserveStock(tx preordercontract.Transaction, entry *preorderdomain.Backorder, now time.Time) (bool, error) {
+	if entry.FulfillmentType == constants.FulfillmentTypeAuto {
+		secrets := tx.CardSecrets()
+		rows, err := secrets.ListAvailableByProductForUpdate(entry.ProductID, entry.SKUID, entry.Quantity)
+		if err != nil {
+			return false, err
+		}
+		if len(rows) < entry.Quantity {
+			return false, errStockShort
+		}
+		ids := make([]uint, 0, len(rows))
+		for _, row := range rows {
+			ids = append(ids, row.ID)
+		}
+		affected, err := secrets.Reserve(ids, entry.OrderID, now)
+		if err != nil {
+			return false, err
+		}
+		if int(affected) != len(ids) {
+			return false, errStockShort
+		}
+		return false, nil
+	}
+
+	tracked, err := manualStockTracked(tx, entry)
+	if err != nil || !tracked {
+		return false, err
+	}
+	affected, err := tx.ProductSKUs().ReserveManualStock(entry.SKUID, entry.Quantity, entry.OrderID)
+	if err != nil {
+		return false, err
+	}
+	if affected == 0 {
+		return false, errStockShort
+	}
+	return true, nil
+}
+
+// releaseStock 关闭已锁定库存的记录时归还库存。
+func releaseStock(tx preordercontract.Transaction, entry *preorderdomain.Backorder) error {
+	if entry.FulfillmentType == constants.FulfillmentTypeAuto {
+		_, err := tx.CardSecrets().ReleaseByOrder(entry.OrderID)
+		return err
+	}
+	tracked, err := manualStockTracked(tx, entry)
+	if err != nil || !tracked {
+		return err
+	}
+	_, err = tx.ProductSKUs().ReleaseManualStock(entry.SKUID, entry.Quantity, entry.OrderID)
+	return err
+}
+
+func manualStockTracked(tx preordercontract.Transaction, entry *preorderdomain.Backorder) (bool, error) {
+	product, err := tx.Products().GetByID(strconv.FormatUint(uint64(entry.ProductID), 10))
+	if err != nil {
+		return false, err
+	}
+	sku, err := tx.ProductSKUs().GetByID(entry.SKUID)
+	if err != nil {
+		return false, err
+	}
+	return productdomain.ShouldEnforceManualSKUStock(product, sku), nil
+}
+
+// dispatchAllocated 分配完成后触发交付：自动发货进入自动交付队列，人工交付通知后台处理。
+func (s *Service) dispatchAllocated(entry *preorderdomain.Backorder) {
+	if entry == nil {
+		return
+	}
+	if entry.FulfillmentType == constants.FulfillmentTypeAuto {
+		s.scheduleAutoFulfill(entry.OrderID)
+		return
+	}
+	if s.notifier == nil {
+		return
+	}
+	if err := s.notifier.NotifyManualPending(entry.OrderID); err != nil {
+		logger.Warnw("preorder_notify_manual_pending_failed", "order_id", entry.OrderID, "error", err)
+	}
+}
+
+func (s *Service) scheduleAllocate(productID uint) {
+	if s.queue == nil {
+		go func() {
+			if err := s.Allocate(productID); err != nil {
+				logger.Warnw("preorder_allocate_failed", "product_id", productID, "error", err)
+			}
+		}()
+		return
+	}
+	if err := s.queue.EnqueueAllocate(productID); err != nil {
+		logger.Warnw("preorder_enqueue_allocate_failed", "product_id", productID, "error", err)
+	}
+}
+
+func (s *Service) scheduleAutoFulfill(orderID uint) {
+	if s.queue == nil {
+		return
+	}
+	if err := s.queue.EnqueueAutoFulfill(orderID); err != nil {
+		logger.Warnw("preorder_enqueue_auto_fulfill_failed", "order_id", orderID, "error", err)
+	}
+}
@@ -0,0 +1,416 @@
+package application
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dujiao-next/internal/constants"
+	productdomain "github.com/dujiao-next/internal/modules/catalog/product/domain"
+	orderapp "github.com/dujiao-next/internal/modules/order/application"
+	ordercontract "github.com/dujiao-next/internal/modules/order/contract"
+	orderdomain "github.com/dujiao-next/internal/modules/order/domain"
+	preordercontract "github.com/dujiao-next/internal/modules/preorder/contract"
+	preorderdomain "github.com/dujiao-next/internal/modules/preorder/domain"
+	walletcontract "github.com/dujiao-next/internal/modules/wallet/contract"
+	"github.com/dujiao-next/internal/shared/money"
+
+	"github.com/shopspring/decimal"
+)
+
+// Options 描述预售服务依赖；Queue 为空时分配与自动交付在后台协程中执行。
+type Options struct {
+	Store    preordercontract.Store
+	Products preordercontract.ProductReader
+	Cards    preordercontract.CardStock
+	Orders   preordercontract.OrderReader
+	Wallets  preordercontract.WalletDebiter
+	Refunder preordercontract.Refunder
+	Notifier preordercontract.Notifier
+	Queue    preordercontract.Queue
+	Now      func() time.Time
+}
+
+// Service 编排预售设置、下单判定、排队分配、尾款支付与逾期退款。
+type Service struct {
+	store          preordercontract.Store
+	products       preordercontract.ProductReader
+	cards          preordercontract.CardStock
+	orders         preordercontract.OrderReader
+	wallets        preordercontract.WalletDebiter
+	refunder       preordercontract.Refunder
+	notifier       preordercontract.Notifier
+	queue          preordercontract.Queue
+	restockTrigger preordercontract.StockTrigger
+	now            func() time.Time
+}
+
+func NewService(options Options) *Service {
+	if options.Store == nil || options.Products == nil || options.Cards == nil || options.Orders == nil ||
+		options.Wallets == nil || options.Refunder == nil {
+		panic("preorder service: required dependency is nil")
+	}
+	now := options.Now
+	if now == nil {
+		now = time.Now
+	}
+	return &Service{
+		store:    options.Store,
+		products: options.Products,
+		cards:    options.Cards,
+		orders:   options.Orders,
+		wallets:  options.Wallets,
+		refunder: options.Refunder,
+		notifier: options.Notifier,
+		queue:    options.Queue,
+		now:      now,
+	}
+}
+
+// SetRestockTrigger 注入到货提醒检查（容器装配时调用），预售分配后剩余库存再通知订阅者。
+func (s *Service) SetRestockTrigger(trigger preordercontract.StockTrigger) {
+	if s == nil {
+		return
+	}
+	s.restockTrigger = trigger
+}
+
+// ResolvePreorder 规格开启预售且当前库存不足时返回预售设置，供下单判定使用。
+func (s *Service) ResolvePreorder(product *productdomain.Product, sku *productdomain.ProductSKU, quantity int) (*preorderdomain.Policy, error) {
+	if product == nil || sku == nil || quantity <= 0 {
+		return nil, nil
+	}
+	policy, err := s.store.GetPolicyBySKU(sku.ID)
+	if err != nil || policy == nil {
+		return nil, err
+	}
+	if policy.ProductID != product.ID || !policy.IsOpen(s.now()) {
+		return nil, nil
+	}
+	switch strings.TrimSpace(product.FulfillmentType) {
+	case constants.FulfillmentTypeAuto:
+		available, err := s.cards.CountAvailable(product.ID, sku.ID)
+		if err != nil {
+			return nil, err
+		}
+		if available >= int64(quantity) {
+			return nil, nil
+		}
+	case constants.FulfillmentTypeManual, "":
+		if !productdomain.ShouldEnforceManualSKUStock(product, sku) || productdomain.ManualSKUAvailable(sku) >= quantity {
+			return nil, nil
+		}
+	default:
+		return nil, nil
+	}
+	return policy, nil
+}
+
+// ListPolicies 后台查询预售设置及占用名额。
+func (s *Service) ListPolicies(productID uint) ([]preordercontract.PolicyView, error) {
+	policies, err := s.store.ListPolicies(productID)
+	if err != nil {
+		return nil, err
+	}
+	outstanding, err := s.sumOutstanding(policies)
+	if err != nil {
+		return nil, err
+	}
+	views := make([]preordercontract.PolicyView, 0, len(policies))
+	for _, policy := range policies {
+		views = append(views, preordercontract.PolicyView{Policy: policy, Outstanding: outstanding[policy.SKUID]})
+	}
+	return views, nil
+}
+
+// ListPublicPolicies 店面查询商品当前接受预售的规格。
+func (s *Service) ListPublicPolicies(productID uint) ([]preordercontract.PublicPolicy, error) {
+	if productID == 0 {
+		return nil, preordercontract.ErrPolicyInvalid
+	}
+	policies, err := s.store.ListPolicies(productID)
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	open := make([]preorderdomain.Policy, 0, len(policies))
+	for _, policy := range policies {
+		if policy.IsOpen(now) {
+			open = append(open, policy)
+		}
+	}
+	outstanding, err := s.sumOutstanding(open)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]preordercontract.PublicPolicy, 0, len(open))
+	for _, policy := range open {
+		item := preordercontract.PublicPolicy{
+			SKUID:          policy.SKUID,
+			PaymentMode:    policy.PaymentMode,
+			DepositPercent: policy.DepositPercent,
+			ExpectedAt:     policy.ExpectedAt,
+		}
+		if policy.MaxOutstanding > 0 {
+			remaining := int64(policy.MaxOutstanding) - outstanding[policy.SKUID]
+			if remaining < 0 {
+				remaining = 0
+			}
+			item.Remaining = &remaining
+		}
+		result = append(result, item)
+	}
+	return result, nil
+}
+
+// SavePolicy 后台保存规格预售设置；仅自动发货与人工交付商品支持预售。
+func (s *Service) SavePolicy(input preordercontract.SavePolicyInput) (*preorderdomain.Policy, error) {
+	mode := preorderdomain.NormalizePaymentMode(input.PaymentMode)
+	if input.ProductID == 0 || input.SKUID == 0 || mode == "" || input.MaxOutstanding < 0 || input.ExpectedAt.IsZero() {
+		return nil, preordercontract.ErrPolicyInvalid
+	}
+	depositPercent := 0
+	if mode == preorderdomain.PaymentModeDeposit {
+		if input.DepositPercent < preorderdomain.MinDepositPercent || input.DepositPercent > preorderdomain.MaxDepositPercent {
+			return nil, preordercontract.ErrPolicyInvalid
+		}
+		depositPercent = input.DepositPercent
+	}
+	now := s.now()
+	if input.Enabled && !input.ExpectedAt.After(now) {
+		return nil, preordercontract.ErrPolicyInvalid
+	}
+	product, err := s.products.GetByID(strconv.FormatUint(uint64(input.ProductID), 10))
+	if err != nil {
+		return nil, err
+	}
+	if product == nil {
+		return nil, preordercontract.ErrProductNotFound
+	}
+	fulfillmentType := strings.TrimSpace(product.FulfillmentType)
+	if fulfillmentType != constants.FulfillmentTypeAuto && fulfillmentType != constants.FulfillmentTypeManual && fulfillmentType != "" {
+		return nil, preordercontract.ErrPolicyInvalid
+	}
+	if !productHasSKU(product, input.SKUID) {
+		return nil, preordercontract.ErrPolicyInvalid
+	}
+
+	policy, err := s.store.GetPolicyBySKU(input.SKUID)
+	if err != nil {
+		return nil, err
+	}
+	if policy == nil {
+		policy = &preorderdomain.Policy{SKUID: input.SKUID, CreatedAt: now}
+	}
+	policy.ProductID = input.ProductID
+	policy.Enabled = input.Enabled
+	policy.PaymentMode = mode
+	policy.DepositPercent = depositPercent
+	policy.ExpectedAt = input.ExpectedAt
+	policy.MaxOutstanding = input.MaxOutstanding
+	policy.UpdatedAt = now
+	if err := s.store.SavePolicy(policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// ListUserBackorders 登录用户查询自己的预售进度。
+func (s *Service) ListUserBackorders(userID uint) ([]preordercontract.BackorderView, error) {
+	if userID == 0 {
+		return nil, preordercontract.ErrBackorderNotFound
+	}
+	backorders, err := s.store.ListBackordersByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.buildViews(backorders)
+}
+
+// ListGuestBackorders 游客凭订单号与查询凭据查看订单内的预售进度。
+func (s *Service) ListGuestBackorders(orderNo, email, password string) ([]preordercontract.BackorderView, error) {
+	order, err := s.orders.GetByOrderNoAndGuest(strings.TrimSpace(orderNo), email, password)
+	if err != nil {
+		return nil, err
+	}
+	if order == nil {
+		return nil, preordercontract.ErrOrderNotFound
+	}
+	parentID := order.ID
+	if order.ParentID != nil {
+		parentID = *order.ParentID
+	}
+	backorders, err := s.store.ListBackordersByParentOrder(parentID)
+	if err != nil {
+		return nil, err
+	}
+	return s.buildViews(backorders)
+}
+
+// ListBackorders 后台查询排队记录。
+func (s *Service) ListBackorders(filter preordercontract.ListFilter) ([]preordercontract.BackorderView, int64, error) {
+	filter.Status = strings.TrimSpace(filter.Status)
+	filter.OrderNo = strings.TrimSpace(filter.OrderNo)
+	backorders, total, err := s.store.ListBackorders(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	views, err := s.buildViews(backorders)
+	if err != nil {
+		return nil, 0, err
+	}
+	return views, total, nil
+}
+
+// PayBalance 登录用户用余额补齐定金预售的尾款，随后进入交付。
+func (s *Service) PayBalance(userID, backorderID uint) (*preorderdomain.Backorder, error) {
+	backorder, err := s.store.GetBackorder(backorderID)
+	if err != nil {
+		return nil, err
+	}
+	if backorder == nil || userID == 0 || backorder.UserID != userID {
+		return nil, preordercontract.ErrBackorderNotFound
+	}
+	now := s.now()
+	err = s.store.WithinTransaction(func(tx preordercontract.Transaction) error {
+		locked, err := tx.Backorders().GetForUpdate(backorder.ID)
+		if err != nil {
+			return err
+		}
+		if locked == nil || locked.Status != preorderdomain.StatusAwaitingBalance {
+			return preordercontract.ErrBackorderStateInvalid
+		}
+		if locked.BalanceDueAt != nil && now.After(*locked.BalanceDueAt) {
+			return preordercontract.ErrBalanceOverdue
+		}
+		orderStore := tx.Orders()
+		order, err := orderStore.GetByIDForUpdate(locked.OrderID)
+		if err != nil {
+			return err
+		}
+		if order == nil || order.Status != constants.OrderStatusPaid {
+			return preordercontract.ErrBackorderStateInvalid
+		}
+		balance := locked.BalanceAmount.Decimal.Round(2)
+		if balance.GreaterThan(decimal.Zero) {
+			if _, err := s.wallets.DebitInTransaction(tx.Wallets(), walletcontract.DebitInput{
+				UserID:    userID,
+				Amount:    money.FromDecimal(balance),
+				Currency:  locked.Currency,
+				Type:      constants.WalletTxnTypePreorderBalance,
+				Reference: fmt.Sprintf("preorder:%d:balance", locked.ID),
+				Remark:    "预售尾款",
+				OrderID:   &order.ID,
+			}); err != nil {
+				return err
+			}
+			if err := addBalanceToOrder(orderStore, order, balance, now); err != nil {
+				return err
+			}
+			if order.ParentID != nil {
+				parent, err := orderStore.GetByIDForUpdate(*order.ParentID)
+				if err != nil {
+					return err
+				}
+				if err := addBalanceToOrder(orderStore, parent, balance, now); err != nil {
+					return err
+				}
+			}
+		}
+		if locked.FulfillmentType == constants.FulfillmentTypeManual {
+			tracked, err := manualStockTracked(tx, locked)
+			if err != nil {
+				return err
+			}
+			if err := startManualFulfillment(tx, locked, order.ParentID, tracked, now); err != nil {
+				return err
+			}
+		}
+		if err := tx.Backorders().Update(locked.ID, map[string]interface{}{
+			"status":          preorderdomain.StatusAllocated,
+			"balance_paid_at": now,
+			"updated_at":      now,
+		}); err != nil {
+			return err
+		}
+		backorder = locked
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	backorder.Status = preorderdomain.StatusAllocated
+	backorder.BalancePaidAt = &now
+	backorder.UpdatedAt = now
+	s.dispatchAllocated(backorder)
+	return backorder, nil
+}
+
+// startManualFulfillment 人工交付预售分配完成：消耗锁定库存并转入交付中，等待后台处理。
+func startManualFulfillment(tx preordercontract.Transaction, backorder *preorderdomain.Backorder, parentID *uint, reserved bool, now time.Time) error {
+	if reserved {
+		affected, err := tx.ProductSKUs().ConsumeManualStock(backorder.SKUID, backorder.Quantity, backorder.OrderID)
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return preordercontract.ErrBackorderStateInvalid
+		}
+	}
+	orderStore := tx.Orders()
+	if err := orderStore.UpdateStatus(backorder.OrderID, constants.OrderStatusFulfilling, map[string]interface{}{"updated_at": now}); err != nil {
+		return err
+	}
+	if parentID != nil {
+		if _, err := orderapp.SyncParentStatus(orderStore, *parentID, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addBalanceToOrder 尾款计入订单实付金额，退款上限随之提高。
+func addBalanceToOrder(orderStore ordercontract.Store, order *orderdomain.Order, balance decimal.Decimal, now time.Time) error {
+	if order == nil {
+		return preordercontract.ErrOrderNotFound
+	}
+	return orderStore.UpdateFields(order.ID, map[string]interface{}{
+		"total_amount":       money.FromDecimal(order.TotalAmount.Decimal.Add(balance).Round(2)),
+		"wallet_paid_amount": money.FromDecimal(order.WalletPaidAmount.Decimal.Add(balance).Round(2)),
+		"updated_at":         now,
+	})
+}
+
+func (s *Service) sumOutstanding(policies []preorderdomain.Policy) (map[uint]int64, error) {
+	skuIDs := make([]uint, 0, len(policies))
+	for _, policy := range policies {
+		skuIDs = append(skuIDs, policy.SKUID)
+	}
+	return s.store.SumOutstandingBySKUs(skuIDs)
+}
+
+func (s *Service) buildViews(backorders []preorderdomain.Backorder) ([]preordercontract.BackorderView, error) {
+	views := make([]preordercontract.BackorderView, 0, len(backorders))
+	for _, backorder := range backorders {
+		view := preordercontract.BackorderView{Backorder: backorder}
+		if backorder.Status == preorderdomain.StatusQueued {
+			ahead, err := s.store.CountQueuedAhead(backorder)
+			if err != nil {
+				return nil, err
+			}
+			view.QueuePosition = ahead + 1
+		}
+		views = append(views, view)
+	}
+	return views, nil
+}
+
+func productHasSKU(product *productdomain.Product, skuID uint) bool {
+	for _, sku := range product.SKUs {
+		if sku.ID == skuID {
+			return true
+		}
+	}
+	return false
+}
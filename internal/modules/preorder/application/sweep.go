@@ -0,0 +1,224 @@
+package application
+
+import (
+	"github.com/dujiao-next/internal/constants"
+	"github.com/dujiao-next/internal/logger"
+	orderdomain "github.com/dujiao-next/internal/modules/order/domain"
+	preordercontract "github.com/dujiao-next/internal/modules/preorder/contract"
+	preorderdomain "github.com/dujiao-next/internal/modules/preorder/domain"
+	"github.com/dujiao-next/internal/shared/money"
+)
+
+// Sweep 定时巡检：关闭逾期记录并退款、重试失败退款、同步已分配与待原路退款记录的结果，并为仍在排队的商品补做分配。
+func (s *Service) Sweep() error {
+	now := s.now()
+	expiredQueued, err := s.store.ListQueuedExpired(now, preorderdomain.SweepBatchSize)
+	if err != nil {
+		return err
+	}
+	overdue, err := s.store.ListBalanceOverdue(now, preorderdomain.SweepBatchSize)
+	if err != nil {
+		return err
+	}
+	released := make(map[uint]bool)
+	for _, entry := range append(expiredQueued, overdue...) {
+		expired, err := s.expireEntry(entry.ID)
+		if err != nil {
+			logger.Warnw("preorder_expire_failed", "backorder_id", entry.ID, "error", err)
+			continue
+		}
+		if expired != nil && expired.HoldsStock() {
+			released[expired.ProductID] = true
+		}
+	}
+
+	refundable, err := s.store.ListExpiredRefundable(preorderdomain.SweepBatchSize)
+	if err != nil {
+		return err
+	}
+	for _, entry := range refundable {
+		if err := s.refundEntry(entry); err != nil {
+			logger.Warnw("preorder_refund_failed", "backorder_id", entry.ID, "order_id", entry.OrderID, "error", err)
+		}
+	}
+
+	if err := s.reconcileAllocated(); err != nil {
+		return err
+	}
+	if err := s.reconcileRefundReview(); err != nil {
+		return err
+	}
+
+	productIDs, err := s.store.ListQueuedProductIDs()
+	if err != nil {
+		return err
+	}
+	for _, productID := range productIDs {
+		released[productID] = true
+	}
+	for productID := range released {
+		s.scheduleAllocate(productID)
+	}
+	return nil
+}
+
+// expireEntry 关闭逾期记录并归还已锁定的库存，返回关闭前的记录；随即在事务外退款或提醒后台，失败由下次巡检重试。
+func (s *Service) expireEntry(id uint) (*preorderdomain.Backorder, error) {
+	now := s.now()
+	var expired *preorderdomain.Backorder
+	err := s.store.WithinTransaction(func(tx preordercontract.Transaction) error {
+		entry, err := tx.Backorders().GetForUpdate(id)
+		if err != nil || entry == nil {
+			return err
+		}
+		switch entry.Status {
+		case preorderdomain.StatusQueued:
+			if entry.ExpectedAt.After(now) {
+				return nil
+			}
+		case preorderdomain.StatusAwaitingBalance:
+			if entry.BalanceDueAt == nil || entry.BalanceDueAt.After(now) {
+				return nil
+			}
+			if err := releaseStock(tx, entry); err != nil {
+				return err
+			}
+		default:
+			return nil
+		}
+		if err := tx.Backorders().Update(entry.ID, map[string]interface{}{
+			"status":     preorderdomain.StatusExpired,
+			"closed_at":  now,
+			"updated_at": now,
+		}); err != nil {
+			return err
+		}
+		expired = entry
+		return nil
+	})
+	if err != nil || expired == nil {
+		return nil, err
+	}
+	logger.Infow("preorder_backorder_expired", "backorder_id", expired.ID, "order_id", expired.OrderID, "status", expired.Status)
+	closed := *expired
+	closed.Status = preorderdomain.StatusExpired
+	if err := s.refundEntry(closed); err != nil {
+		logger.Warnw("preorder_refund_failed", "backorder_id", expired.ID, "order_id", expired.OrderID, "error", err)
+	}
+	return expired, nil
+}
+
+// refundEntry 将逾期预售子订单的已付金额退回钱包；订单已无可退金额时直接标记完成，便于失败后重试。
+// 游客没有钱包，改为提醒后台按原支付渠道退款，提醒成功后转入待原路退款。
+func (s *Service) refundEntry(entry preorderdomain.Backorder) error {
+	if entry.Status != preorderdomain.StatusExpired {
+		return nil
+	}
+	order, err := s.orderByID(entry.OrderID)
+	if err != nil {
+		return err
+	}
+	refundable := order.TotalAmount.Decimal.Sub(order.RefundedAmount.Decimal).Round(2)
+	if entry.UserID == 0 {
+		if !refundable.IsPositive() || order.PaidAt == nil {
+			return s.closeEntry(entry.ID, preorderdomain.StatusExpired, preorderdomain.StatusRefunded)
+		}
+		if s.notifier == nil {
+			return nil
+		}
+		if err := s.notifier.NotifyGuestRefundRequired(order.ID); err != nil {
+			return err
+		}
+		return s.closeEntry(entry.ID, preorderdomain.StatusExpired, preorderdomain.StatusRefundReview)
+	}
+	if refundable.IsPositive() && order.PaidAt != nil {
+		if err := s.refunder.RefundToWallet(order.ID, money.FromDecimal(refundable), "预售逾期自动退款"); err != nil {
+			return err
+		}
+	}
+	return s.closeEntry(entry.ID, preorderdomain.StatusExpired, preorderdomain.StatusRefunded)
+}
+
+// reconcileAllocated 同步已分配记录的交付结果，自动交付失败时重新入队。
+func (s *Service) reconcileAllocated() error {
+	allocated, err := s.store.ListByStatus(preorderdomain.StatusAllocated, preorderdomain.SweepBatchSize)
+	if err != nil {
+		return err
+	}
+	for _, entry := range allocated {
+		order, err := s.orderByID(entry.OrderID)
+		if err != nil {
+			logger.Warnw("preorder_reconcile_fetch_order_failed", "backorder_id", entry.ID, "error", err)
+			continue
+		}
+		target := ""
+		switch order.Status {
+		case constants.OrderStatusDelivered, constants.OrderStatusCompleted:
+			target = preorderdomain.StatusFulfilled
+		case constants.OrderStatusCanceled, constants.OrderStatusRefunded:
+			target = preorderdomain.StatusCanceled
+		case constants.OrderStatusPaid:
+			if entry.FulfillmentType == constants.FulfillmentTypeAuto {
+				s.scheduleAutoFulfill(entry.OrderID)
+			}
+		}
+		if target == "" {
+			continue
+		}
+		if err := s.closeEntry(entry.ID, preorderdomain.StatusAllocated, target); err != nil {
+			logger.Warnw("preorder_reconcile_close_failed", "backorder_id", entry.ID, "error", err)
+		}
+	}
+	return nil
+}
+
+// reconcileRefundReview 后台完成游客订单原路退款后，将待原路退款记录标记为已退款。
+func (s *Service) reconcileRefundReview() error {
+	pending, err := s.store.ListByStatus(preorderdomain.StatusRefundReview, preorderdomain.SweepBatchSize)
+	if err != nil {
+		return err
+	}
+	for _, entry := range pending {
+		order, err := s.orderByID(entry.OrderID)
+		if err != nil {
+			logger.Warnw("preorder_reconcile_fetch_order_failed", "backorder_id", entry.ID, "error", err)
+			continue
+		}
+		if order.Status != constants.OrderStatusRefunded {
+			continue
+		}
+		if err := s.closeEntry(entry.ID, preorderdomain.StatusRefundReview, preorderdomain.StatusRefunded); err != nil {
+			logger.Warnw("preorder_reconcile_close_failed", "backorder_id", entry.ID, "error", err)
+		}
+	}
+	return nil
+}
+
+func (s *Service) closeEntry(id uint, from, to string) error {
+	now := s.now()
+	return s.store.WithinTransaction(func(tx preordercontract.Transaction) error {
+		entry, err := tx.Backorders().GetForUpdate(id)
+		if err != nil || entry == nil || entry.Status != from {
+			return err
+		}
+		updates := map[string]interface{}{
+			"status":     to,
+			"updated_at": now,
+		}
+		if entry.ClosedAt == nil {
+			updates["closed_at"] = now
+		}
+		return tx.Backorders().Update(id, updates)
+	})
+}
+
+func (s *Service) orderByID(orderID uint) (*orderdomain.Order, error) {
+	order, err := s.orders.GetByID(orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order == nil {
+		return nil, preordercontract.ErrOrderNotFound
+	}
+	return order, nil
+}
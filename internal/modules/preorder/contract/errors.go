@@ -0,0 +1,12 @@
+package contract
+
+import "errors"
+
+var (
+	ErrPolicyInvalid         = errors.New("preorder policy invalid")
+	ErrBackorderNotFound     = errors.New("backorder not found")
+	ErrBackorderStateInvalid = errors.New("backorder state invalid")
+	ErrBalanceOverdue        = errors.New("preorder balance overdue")
+	ErrProductNotFound       = errors.New("product not found")
+	ErrOrderNotFound         = errors.New("order not found")
+)
@@ -0,0 +1,96 @@
+package contract
+
+import (
+	"time"
+
+	productdomain "github.com/dujiao-next/internal/modules/catalog/product/domain"
+	ordercontract "github.com/dujiao-next/internal/modules/order/contract"
+	orderdomain "github.com/dujiao-next/internal/modules/order/domain"
+	preorderdomain "github.com/dujiao-next/internal/modules/preorder/domain"
+	walletcontract "github.com/dujiao-next/internal/modules/wallet/contract"
+	walletdomain "github.com/dujiao-next/internal/modules/wallet/domain"
+	"github.com/dujiao-next/internal/shared/money"
+)
+
+// Store 持久化预售设置与排队记录。
+type Store interface {
+	GetPolicyBySKU(skuID uint) (*preorderdomain.Policy, error)
+	ListPolicies(productID uint) ([]preorderdomain.Policy, error)
+	SavePolicy(policy *preorderdomain.Policy) error
+	// SumOutstandingBySKUs 按规格汇总占用预售名额的件数
+	SumOutstandingBySKUs(skuIDs []uint) (map[uint]int64, error)
+
+	GetBackorder(id uint) (*preorderdomain.Backorder, error)
+	ListBackordersByUser(userID uint) ([]preorderdomain.Backorder, error)
+	ListBackordersByParentOrder(parentOrderID uint) ([]preorderdomain.Backorder, error)
+	ListBackorders(filter ListFilter) ([]preorderdomain.Backorder, int64, error)
+	// CountQueuedAhead 统计同一规格中排在该记录之前的排队记录数
+	CountQueuedAhead(backorder preorderdomain.Backorder) (int64, error)
+	// ListQueuedByProduct 按支付先后返回商品的排队记录
+	ListQueuedByProduct(productID uint, limit int) ([]preorderdomain.Backorder, error)
+	ListQueuedProductIDs() ([]uint, error)
+	ListByStatus(status string, limit int) ([]preorderdomain.Backorder, error)
+	// ListQueuedExpired 返回预计到货时间已过仍未分配的记录
+	ListQueuedExpired(now time.Time, limit int) ([]preorderdomain.Backorder, error)
+	// ListBalanceOverdue 返回尾款逾期未付的记录
+	ListBalanceOverdue(now time.Time, limit int) ([]preorderdomain.Backorder, error)
+	// ListExpiredRefundable 返回已逾期、尚未退款或提醒后台的记录
+	ListExpiredRefundable(limit int) ([]preorderdomain.Backorder, error)
+
+	WithinTransaction(fn func(Transaction) error) error
+}
+
+// BackorderTxStore 事务内锁定并更新排队记录。
+type BackorderTxStore interface {
+	GetForUpdate(id uint) (*preorderdomain.Backorder, error)
+	Update(id uint, updates map[string]interface{}) error
+}
+
+// Transaction 复用订单工作单元的库存、订单与钱包端口，并追加排队记录。
+type Transaction interface {
+	ordercontract.Transaction
+	Backorders() BackorderTxStore
+}
+
+// ProductReader 读取商品及其规格。
+type ProductReader interface {
+	GetByID(id string) (*productdomain.Product, error)
+}
+
+// CardStock 统计自动发货规格的可用卡密。
+type CardStock interface {
+	CountAvailable(productID, skuID uint) (int64, error)
+}
+
+// OrderReader 读取订单以判断退款金额与交付结果，并校验游客查询凭据。
+type OrderReader interface {
+	GetByID(id uint) (*orderdomain.Order, error)
+	GetByOrderNoAndGuest(orderNo, email, password string) (*orderdomain.Order, error)
+}
+
+// WalletDebiter 在事务内扣减用户余额支付尾款。
+type WalletDebiter interface {
+	DebitInTransaction(tx walletcontract.Transaction, input walletcontract.DebitInput) (*walletdomain.Transaction, error)
+}
+
+// Refunder 将逾期预售订单退回用户钱包。
+type Refunder interface {
+	RefundToWallet(orderID uint, amount money.Amount, remark string) error
+}
+
+// Notifier 通知后台处理已到货的人工交付预售订单，以及需原路退款的逾期游客预售订单。
+type Notifier interface {
+	NotifyManualPending(orderID uint) error
+	NotifyGuestRefundRequired(orderID uint) error
+}
+
+// Queue 调度预售分配与分配后的自动交付。
+type Queue interface {
+	EnqueueAllocate(productID uint) error
+	EnqueueAutoFulfill(orderID uint) error
+}
+
+// StockTrigger 预售分配完成后转交剩余库存的到货检查。
+type StockTrigger interface {
+	TriggerCheck(productID uint)
+}
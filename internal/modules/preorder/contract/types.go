@@ -0,0 +1,49 @@
+package contract
+
+import (
+	"time"
+
+	preorderdomain "github.com/dujiao-next/internal/modules/preorder/domain"
+)
+
+// SavePolicyInput 后台保存规格预售设置；同一规格重复保存即更新。
+type SavePolicyInput struct {
+	ProductID      uint
+	SKUID          uint
+	Enabled        bool
+	PaymentMode    string
+	DepositPercent int
+	ExpectedAt     time.Time
+	MaxOutstanding int
+}
+
+// PolicyView 后台预售设置及当前占用名额。
+type PolicyView struct {
+	preorderdomain.Policy
+	Outstanding int64 `json:"outstanding"`
+}
+
+// PublicPolicy 店面展示的预售信息；Remaining 为空表示名额不限。
+type PublicPolicy struct {
+	SKUID          uint      `json:"sku_id"`
+	PaymentMode    string    `json:"payment_mode"`
+	DepositPercent int       `json:"deposit_percent"`
+	ExpectedAt     time.Time `json:"expected_at"`
+	Remaining      *int64    `json:"remaining,omitempty"`
+}
+
+// BackorderView 排队记录及排队位置；QueuePosition 仅排队中的记录有值，从 1 开始。
+type BackorderView struct {
+	preorderdomain.Backorder
+	QueuePosition int64 `json:"queue_position,omitempty"`
+}
+
+// ListFilter 后台排队记录筛选条件。
+type ListFilter struct {
+	ProductID uint
+	SKUID     uint
+	Status    string
+	OrderNo   string
+	Page      int
+	PageSize  int
+}
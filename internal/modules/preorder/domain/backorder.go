@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/dujiao-next/internal/shared/money"
+)
+
+const (
+	// StatusAwaitingPayment 预售子订单已创建但尚未支付
+	StatusAwaitingPayment = "awaiting_payment"
+	// StatusQueued 已支付，按支付先后排队等待库存
+	StatusQueued = "queued"
+	// StatusAwaitingBalance 定金预售已锁定库存，等待用户用余额补齐尾款
+	StatusAwaitingBalance = "awaiting_balance"
+	// StatusAllocated 库存已分配，等待自动交付或人工交付
+	StatusAllocated = "allocated"
+	StatusFulfilled = "fulfilled"
+	// StatusRefunded 逾期未到货或尾款逾期，已原路退回钱包
+	StatusRefunded = "refunded"
+	// StatusExpired 已逾期待退款：登录用户退回钱包，游客订单提醒后台原路退款，失败由巡检重试
+	StatusExpired = "expired"
+	// StatusRefundReview 游客订单已逾期并已提醒后台原路退款，订单退款完成后转为 refunded
+	StatusRefundReview = "refund_review"
+	StatusCanceled     = "canceled"
+)
+
+const (
+	// BalanceWindow 库存分配后尾款的支付期限
+	BalanceWindow = 72 * time.Hour
+	// AllocateBatchSize 单次分配扫描的排队记录数
+	AllocateBatchSize = 100
+	// SweepBatchSize 单次巡检处理的逾期记录数
+	SweepBatchSize = 100
+)
+
+// OutstandingStatuses 占用预售名额的状态。
+var OutstandingStatuses = []string{StatusAwaitingPayment, StatusQueued, StatusAwaitingBalance}
+
+// Backorder 预售排队记录，与预售子订单一一对应；ExpectedAt 为下单时的预计到货时间快照。
+type Backorder struct {
+	ID              uint         `gorm:"primarykey" json:"id"`
+	OrderID         uint         `gorm:"not null;uniqueIndex" json:"order_id"`
+	ParentOrderID   uint         `gorm:"not null;index" json:"parent_order_id"`
+	OrderNo         string       `gorm:"type:varchar(64);not null;index" json:"order_no"`
+	UserID          uint         `gorm:"not null;default:0;index" json:"user_id"`
+	ProductID       uint         `gorm:"not null;index:idx_backorder_queue,priority:1" json:"product_id"`
+	SKUID           uint         `gorm:"column:sku_id;not null;index:idx_backorder_queue,priority:2" json:"sku_id"`
+	Quantity        int          `gorm:"not null" json:"quantity"`
+	FulfillmentType string       `gorm:"type:varchar(20);not null" json:"fulfillment_type"`
+	PaymentMode     string       `gorm:"type:varchar(20);not null" json:"payment_mode"`
+	DepositAmount   money.Amount `gorm:"type:decimal(20,2);not null;default:0" json:"deposit_amount"`
+	BalanceAmount   money.Amount `gorm:"type:decimal(20,2);not null;default:0" json:"balance_amount"`
+	Currency        string       `gorm:"type:varchar(16);not null" json:"currency"`
+	Status          string       `gorm:"type:varchar(20);not null;index:idx_backorder_queue,priority:3" json:"status"`
+	ExpectedAt      time.Time    `gorm:"not null;index" json:"expected_at"`
+	QueuedAt        *time.Time   `gorm:"index:idx_backorder_queue,priority:4" json:"queued_at,omitempty"`
+	AllocatedAt     *time.Time   `json:"allocated_at,omitempty"`
+	BalanceDueAt    *time.Time   `gorm:"index" json:"balance_due_at,omitempty"`
+	BalancePaidAt   *time.Time   `json:"balance_paid_at,omitempty"`
+	ClosedAt        *time.Time   `json:"closed_at,omitempty"`
+	CreatedAt       time.Time    `json:"created_at"`
+	UpdatedAt       time.Time    `json:"updated_at"`
+}
+
+func (Backorder) TableName() string {
+	return "preorder_backorders"
+}
+
+// HoldsStock 判断记录是否已锁定（未售出）库存，关闭时需要释放。
+func (b Backorder) HoldsStock() bool {
+	return b.Status == StatusAwaitingBalance
+}
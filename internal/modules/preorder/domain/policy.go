@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	PaymentModeFull    = "full"
+	PaymentModeDeposit = "deposit"
+)
+
+const (
+	MinDepositPercent = 1
+	MaxDepositPercent = 99
+)
+
+// Policy 规格级预售设置；仅在规格缺货且预计到货时间未过时接受预售。
+// MaxOutstanding 为未完结预售件数上限，0 表示不限。
+type Policy struct {
+	ID             uint      `gorm:"primarykey" json:"id"`
+	ProductID      uint      `gorm:"not null;index" json:"product_id"`
+	SKUID          uint      `gorm:"column:sku_id;not null;uniqueIndex" json:"sku_id"`
+	Enabled        bool      `gorm:"not null;default:false" json:"enabled"`
+	PaymentMode    string    `gorm:"type:varchar(20);not null;default:'full'" json:"payment_mode"`
+	DepositPercent int       `gorm:"not null;default:0" json:"deposit_percent"`
+	ExpectedAt     time.Time `gorm:"not null" json:"expected_at"`
+	MaxOutstanding int       `gorm:"not null;default:0" json:"max_outstanding"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+func (Policy) TableName() string {
+	return "preorder_policies"
+}
+
+// IsOpen 判断当前是否接受新的预售。
+func (p Policy) IsOpen(now time.Time) bool {
+	return p.Enabled && p.ExpectedAt.After(now)
+}
+
+// IsDeposit 判断是否为定金预售。
+func (p Policy) IsDeposit() bool {
+	return strings.TrimSpace(p.PaymentMode) == PaymentModeDeposit
+}
+
+// SplitDeposit 按定金比例拆分应付金额，返回定金与尾款；定金至少 0.01。
+func (p Policy) SplitDeposit(payable decimal.Decimal) (decimal.Decimal, decimal.Decimal) {
+	payable = payable.Round(2)
+	if !p.IsDeposit() || payable.LessThanOrEqual(decimal.Zero) {
+		return payable, decimal.Zero
+	}
+	deposit := payable.Mul(decimal.NewFromInt(int64(p.DepositPercent))).Div(decimal.NewFromInt(100)).Round(2)
+	minimum := decimal.New(1, -2)
+	if deposit.LessThan(minimum) {
+		deposit = minimum
+	}
+	if deposit.GreaterThanOrEqual(payable) {
+		return payable, decimal.Zero
+	}
+	return deposit, payable.Sub(deposit).Round(2)
+}
+
+// NormalizePaymentMode 规范化付款模式，非法值返回空串。
+func NormalizePaymentMode(mode string) string {
+	switch strings.ToLower(strings.TrimSpace(mode)) {
+	case "", PaymentModeFull:
+		return PaymentModeFull
+	case PaymentModeDeposit:
+		return PaymentModeDeposit
+	default:
+		return ""
+	}
+}
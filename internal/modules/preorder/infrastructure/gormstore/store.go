@@ -0,0 +1,223 @@
+package gormstore
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	preordercontract "github.com/dujiao-next/internal/modules/preorder/contract"
+	preorderdomain "github.com/dujiao-next/internal/modules/preorder/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Store 是预售设置与排队记录的 GORM 仓储。
+type Store struct {
+	db                    *gorm.DB
+	guestCredentialSecret string
+}
+
+var _ preordercontract.Store = (*Store)(nil)
+
+func New(db *gorm.DB, guestCredentialSecret string) *Store {
+	if db == nil {
+		panic("preorder store: db is nil")
+	}
+	secret := strings.TrimSpace(guestCredentialSecret)
+	if secret == "" {
+		panic("preorder store: guest credential secret is required")
+	}
+	return &Store{db: db, guestCredentialSecret: secret}
+}
+
+func (s *Store) GetPolicyBySKU(skuID uint) (*preorderdomain.Policy, error) {
+	if skuID == 0 {
+		return nil, nil
+	}
+	var policy preorderdomain.Policy
+	if err := s.db.Where("sku_id = ?", skuID).First(&policy).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &policy, nil
+}
+
+func (s *Store) ListPolicies(productID uint) ([]preorderdomain.Policy, error) {
+	var policies []preorderdomain.Policy
+	query := s.db.Model(&preorderdomain.Policy{})
+	if productID > 0 {
+		query = query.Where("product_id = ?", productID)
+	}
+	if err := query.Order("product_id ASC, sku_id ASC").Find(&policies).Error; err != nil {
+		return nil, err
+	}
+	return policies, nil
+}
+
+func (s *Store) SavePolicy(policy *preorderdomain.Policy) error {
+	if policy.ID == 0 {
+		return s.db.Create(policy).Error
+	}
+	return s.db.Save(policy).Error
+}
+
+func (s *Store) SumOutstandingBySKUs(skuIDs []uint) (map[uint]int64, error) {
+	result := make(map[uint]int64, len(skuIDs))
+	if len(skuIDs) == 0 {
+		return result, nil
+	}
+	var rows []struct {
+		SKUID    uint `gorm:"column:sku_id"`
+		Quantity int64
+	}
+	if err := s.db.Model(&preorderdomain.Backorder{}).
+		Select("sku_id, COALESCE(SUM(quantity), 0) AS quantity").
+		Where("sku_id IN ? AND status IN ?", skuIDs, preorderdomain.OutstandingStatuses).
+		Group("sku_id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		result[row.SKUID] = row.Quantity
+	}
+	return result, nil
+}
+
+func (s *Store) GetBackorder(id uint) (*preorderdomain.Backorder, error) {
+	if id == 0 {
+		return nil, nil
+	}
+	return firstBackorder(s.db.Where("id = ?", id))
+}
+
+func (s *Store) ListBackordersByUser(userID uint) ([]preorderdomain.Backorder, error) {
+	return s.find(s.db.Where("user_id = ?", userID).Order("id DESC"), 0)
+}
+
+func (s *Store) ListBackordersByParentOrder(parentOrderID uint) ([]preorderdomain.Backorder, error) {
+	return s.find(s.db.Where("parent_order_id = ?", parentOrderID).Order("id ASC"), 0)
+}
+
+func (s *Store) ListBackorders(filter preordercontract.ListFilter) ([]preorderdomain.Backorder, int64, error) {
+	query := s.db.Model(&preorderdomain.Backorder{})
+	if filter.ProductID > 0 {
+		query = query.Where("product_id = ?", filter.ProductID)
+	}
+	if filter.SKUID > 0 {
+		query = query.Where("sku_id = ?", filter.SKUID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.OrderNo != "" {
+		query = query.Where("order_no = ?", filter.OrderNo)
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	query = paginate(query, filter.Page, filter.PageSize)
+	var backorders []preorderdomain.Backorder
+	if err := query.Order("id DESC").Find(&backorders).Error; err != nil {
+		return nil, 0, err
+	}
+	return backorders, total, nil
+}
+
+func (s *Store) CountQueuedAhead(backorder preorderdomain.Backorder) (int64, error) {
+	if backorder.QueuedAt == nil {
+		return 0, nil
+	}
+	var count int64
+	err := s.db.Model(&preorderdomain.Backorder{}).
+		Where("sku_id = ? AND status = ?", backorder.SKUID, preorderdomain.StatusQueued).
+		Where("queued_at < ? OR (queued_at = ? AND id < ?)", *backorder.QueuedAt, *backorder.QueuedAt, backorder.ID).
+		Count(&count).Error
+	return count, err
+}
+
+func (s *Store) ListQueuedByProduct(productID uint, limit int) ([]preorderdomain.Backorder, error) {
+	query := s.db.Where("product_id = ? AND status = ?", productID, preorderdomain.StatusQueued).
+		Order("queued_at ASC, id ASC")
+	return s.find(query, limit)
+}
+
+func (s *Store) ListQueuedProductIDs() ([]uint, error) {
+	var productIDs []uint
+	err := s.db.Model(&preorderdomain.Backorder{}).
+		Where("status = ?", preorderdomain.StatusQueued).
+		Distinct("product_id").
+		Order("product_id ASC").
+		Pluck("product_id", &productIDs).Error
+	return productIDs, err
+}
+
+func (s *Store) ListByStatus(status string, limit int) ([]preorderdomain.Backorder, error) {
+	return s.find(s.db.Where("status = ?", status).Order("updated_at ASC, id ASC"), limit)
+}
+
+func (s *Store) ListQueuedExpired(now time.Time, limit int) ([]preorderdomain.Backorder, error) {
+	query := s.db.Where("status = ? AND expected_at <= ?", preorderdomain.StatusQueued, now).Order("id ASC")
+	return s.find(query, limit)
+}
+
+func (s *Store) ListBalanceOverdue(now time.Time, limit int) ([]preorderdomain.Backorder, error) {
+	query := s.db.Where("status = ? AND balance_due_at <= ?", preorderdomain.StatusAwaitingBalance, now).Order("id ASC")
+	return s.find(query, limit)
+}
+
+func (s *Store) ListExpiredRefundable(limit int) ([]preorderdomain.Backorder, error) {
+	query := s.db.Where("status = ?", preorderdomain.StatusExpired).Order("id ASC")
+	return s.find(query, limit)
+}
+
+func (s *Store) find(query *gorm.DB, limit int) ([]preorderdomain.Backorder, error) {
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	var backorders []preorderdomain.Backorder
+	if err := query.Find(&backorders).Error; err != nil {
+		return nil, err
+	}
+	return backorders, nil
+}
+
+func firstBackorder(query *gorm.DB) (*preorderdomain.Backorder, error) {
+	var backorder preorderdomain.Backorder
+	if err := query.First(&backorder).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &backorder, nil
+}
+
+// backorderTxStore 事务内的排队记录端口。
+type backorderTxStore struct {
+	db *gorm.DB
+}
+
+func (s backorderTxStore) GetForUpdate(id uint) (*preorderdomain.Backorder, error) {
+	if id == 0 {
+		return nil, nil
+	}
+	return firstBackorder(s.db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id))
+}
+
+func (s backorderTxStore) Update(id uint, updates map[string]interface{}) error {
+	return s.db.Model(&preorderdomain.Backorder{}).Where("id = ?", id).Updates(updates).Error
+}
+
+func paginate(query *gorm.DB, page, pageSize int) *gorm.DB {
+	if pageSize <= 0 {
+		return query
+	}
+	if page < 1 {
+		page = 1
+	}
+	return query.Offset((page - 1) * pageSize).Limit(pageSize)
+}
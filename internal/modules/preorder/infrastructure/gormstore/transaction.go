@@ -0,0 +1,32 @@
+package gormstore
+
+import (
+	ordercontract "github.com/dujiao-next/internal/modules/order/contract"
+	ordergormstore "github.com/dujiao-next/internal/modules/order/infrastructure/gormstore"
+	preordercontract "github.com/dujiao-next/internal/modules/preorder/contract"
+
+	"gorm.io/gorm"
+)
+
+type transaction struct {
+	ordercontract.Transaction
+	db *gorm.DB
+}
+
+var _ preordercontract.Transaction = transaction{}
+
+func (tx transaction) Backorders() preordercontract.BackorderTxStore {
+	return backorderTxStore{db: tx.db}
+}
+
+func (s *Store) WithinTransaction(fn func(preordercontract.Transaction) error) error {
+	if s == nil || s.db == nil || fn == nil {
+		return nil
+	}
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		return fn(transaction{
+			Transaction: ordergormstore.UseTransaction(tx, s.guestCredentialSecret),
+			db:          tx,
+		})
+	})
+}
@@ -0,0 +1,94 @@
+package notifier
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dujiao-next/internal/constants"
+	notificationformat "github.com/dujiao-next/internal/modules/notification/application/format"
+	notificationcontract "github.com/dujiao-next/internal/modules/notification/contract"
+	orderdomain "github.com/dujiao-next/internal/modules/order/domain"
+	preordercontract "github.com/dujiao-next/internal/modules/preorder/contract"
+	"github.com/dujiao-next/internal/shared/jsonmap"
+)
+
+// OrderSource 读取分配完成的预售子订单。
+type OrderSource interface {
+	GetByID(id uint) (*orderdomain.Order, error)
+}
+
+// Notifier 复用人工交付待处理事件提醒后台处理到货的预售订单，并以异常告警提醒后台原路退款逾期游客预售。
+type Notifier struct {
+	orders        OrderSource
+	notifications notificationcontract.NotificationEnqueuer
+}
+
+var _ preordercontract.Notifier = (*Notifier)(nil)
+
+func New(orders OrderSource, notifications notificationcontract.NotificationEnqueuer) *Notifier {
+	if orders == nil || notifications == nil {
+		panic("preorder notifier: required dependency is nil")
+	}
+	return &Notifier{orders: orders, notifications: notifications}
+}
+
+func (n *Notifier) NotifyManualPending(orderID uint) error {
+	order, err := n.orders.GetByID(orderID)
+	if err != nil || order == nil {
+		return err
+	}
+	itemsSummary, fulfillmentItemsSummary, counts := notificationformat.BuildOrderItemSummaries(order.Items, constants.LocaleZhCN)
+	payload := jsonmap.JSON{
+		"order_id":                  fmt.Sprintf("%d", order.ID),
+		"order_no":                  strings.TrimSpace(order.OrderNo),
+		"user_id":                   fmt.Sprintf("%d", order.UserID),
+		"guest_email":               strings.TrimSpace(order.GuestEmail),
+		"amount":                    order.TotalAmount.String(),
+		"currency":                  strings.ToUpper(strings.TrimSpace(order.Currency)),
+		"order_status":              strings.TrimSpace(order.Status),
+		"items_summary":             itemsSummary,
+		"fulfillment_items_summary": fulfillmentItemsSummary,
+		"item_count":                fmt.Sprintf("%d", counts.Total),
+		"manual_item_count":         fmt.Sprintf("%d", counts.Manual),
+		"preorder":                  "true",
+	}
+	if order.ParentID != nil {
+		payload["parent_order_id"] = fmt.Sprintf("%d", *order.ParentID)
+	}
+	return n.notifications.Enqueue(notificationcontract.EnqueueInput{
+		EventType: constants.NotificationEventManualFulfillmentPending,
+		BizType:   constants.NotificationBizTypeOrder,
+		BizID:     order.ID,
+		Data:      payload,
+	})
+}
+
+// NotifyGuestRefundRequired 游客预售逾期无法退回钱包，告警后台按原支付渠道退款。
+func (n *Notifier) NotifyGuestRefundRequired(orderID uint) error {
+	order, err := n.orders.GetByID(orderID)
+	if err != nil || order == nil {
+		return err
+	}
+	refundable := order.TotalAmount.Decimal.Sub(order.RefundedAmount.Decimal).Round(2)
+	currency := strings.ToUpper(strings.TrimSpace(order.Currency))
+	payload := jsonmap.JSON{
+		"alert_type":  "preorder_guest_refund",
+		"alert_level": "warning",
+		"alert_value": refundable.StringFixed(2),
+		"message":     fmt.Sprintf("游客预售订单 %s 已逾期，需按原支付渠道退款 %s %s", strings.TrimSpace(order.OrderNo), refundable.StringFixed(2), currency),
+		"order_id":    fmt.Sprintf("%d", order.ID),
+		"order_no":    strings.TrimSpace(order.OrderNo),
+		"guest_email": strings.TrimSpace(order.GuestEmail),
+		"amount":      refundable.StringFixed(2),
+		"currency":    currency,
+	}
+	if order.ParentID != nil {
+		payload["parent_order_id"] = fmt.Sprintf("%d", *order.ParentID)
+	}
+	return n.notifications.Enqueue(notificationcontract.EnqueueInput{
+		EventType: constants.NotificationEventExceptionAlert,
+		BizType:   constants.NotificationBizTypeOrder,
+		BizID:     order.ID,
+		Data:      payload,
+	})
+}
@@ -0,0 +1,35 @@
+package queueadapter
+
+import (
+	"time"
+
+	preordercontract "github.com/dujiao-next/internal/modules/preorder/contract"
+	"github.com/dujiao-next/internal/queue"
+
+	"github.com/hibiken/asynq"
+)
+
+// allocateDedupWindow 分配任务在该窗口内合并，批量导入卡密时不重复扫描；被合并的触发由定时巡检兜底。
+const allocateDedupWindow = 10 * time.Second
+
+// Adapter 将预售调度端口映射到全局任务客户端。
+type Adapter struct {
+	client *queue.Client
+}
+
+var _ preordercontract.Queue = (*Adapter)(nil)
+
+func New(client *queue.Client) *Adapter {
+	if client == nil {
+		panic("preorder queue adapter: client is nil")
+	}
+	return &Adapter{client: client}
+}
+
+func (a *Adapter) EnqueueAllocate(productID uint) error {
+	return a.client.EnqueuePreorderAllocate(queue.PreorderAllocatePayload{ProductID: productID}, asynq.Unique(allocateDedupWindow))
+}
+
+func (a *Adapter) EnqueueAutoFulfill(orderID uint) error {
+	return a.client.EnqueueOrderAutoFulfill(queue.OrderAutoFulfillPayload{OrderID: orderID}, asynq.MaxRetry(3))
+}
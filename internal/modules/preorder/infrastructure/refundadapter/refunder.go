@@ -0,0 +1,66 @@
+package refundadapter
+
+import (
+	"strings"
+
+	"github.com/dujiao-next/internal/logger"
+	orderrefund "github.com/dujiao-next/internal/modules/order/application/refund"
+	orderdomain "github.com/dujiao-next/internal/modules/order/domain"
+	preordercontract "github.com/dujiao-next/internal/modules/preorder/contract"
+	"github.com/dujiao-next/internal/queue"
+	"github.com/dujiao-next/internal/shared/money"
+)
+
+// Refunder 将逾期预售委托给订单退款到余额流程，并补发退款状态邮件。
+type Refunder struct {
+	refunds *orderrefund.Service
+	queue   *queue.Client
+}
+
+var _ preordercontract.Refunder = (*Refunder)(nil)
+
+func New(refunds *orderrefund.Service, queueClient *queue.Client) *Refunder {
+	if refunds == nil {
+		panic("preorder refunder: refund service is nil")
+	}
+	return &Refunder{refunds: refunds, queue: queueClient}
+}
+
+func (r *Refunder) RefundToWallet(orderID uint, amount money.Amount, remark string) error {
+	order, _, record, err := r.refunds.AdminRefundToWallet(orderrefund.AdminRefundToWalletInput{
+		OrderID:            orderID,
+		Amount:             amount,
+		Remark:             remark,
+		IgnoreRefundWindow: true,
+	})
+	if err != nil {
+		return err
+	}
+	r.enqueueStatusEmail(order, record)
+	return nil
+}
+
+func (r *Refunder) enqueueStatusEmail(order *orderdomain.Order, record *orderdomain.OrderRefundRecord) {
+	if r.queue == nil || order == nil || order.ID == 0 {
+		return
+	}
+	status := strings.TrimSpace(order.Status)
+	if status == "" {
+		return
+	}
+	var recordID uint
+	if record != nil {
+		recordID = record.ID
+	}
+	if err := r.queue.EnqueueOrderStatusEmail(queue.OrderStatusEmailPayload{
+		OrderID:        order.ID,
+		Status:         status,
+		RefundRecordID: recordID,
+	}); err != nil {
+		logger.Warnw("preorder_refund_enqueue_status_email_failed",
+			"order_id", order.ID,
+			"status", status,
+			"error", err,
+		)
+	}
+}
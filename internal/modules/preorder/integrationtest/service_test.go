@@ -0,0 +1,380 @@
+package integrationtest
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/dujiao-next/internal/constants"
+	productdomain "github.com/dujiao-next/internal/modules/catalog/product/domain"
+	fulfillmentdomain "github.com/dujiao-next/internal/modules/fulfillment/domain"
+	orderdomain "github.com/dujiao-next/internal/modules/order/domain"
+	ordergormstore "github.com/dujiao-next/internal/modules/order/infrastructure/gormstore"
+	preorderapp "github.com/dujiao-next/internal/modules/preorder/application"
+	preordercontract "github.com/dujiao-next/internal/modules/preorder/contract"
+	preorderdomain "github.com/dujiao-next/internal/modules/preorder/domain"
+	preordergormstore "github.com/dujiao-next/internal/modules/preorder/infrastructure/gormstore"
+	stockledgerdomain "github.com/dujiao-next/internal/modules/stockledger/domain"
+	walletcontract "github.com/dujiao-next/internal/modules/wallet/contract"
+	walletdomain "github.com/dujiao-next/internal/modules/wallet/domain"
+	"github.com/dujiao-next/internal/shared/jsonmap"
+	"github.com/dujiao-next/internal/shared/money"
+
+	"github.com/glebarez/sqlite"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+const testSecret = "preorder-test-secret"
+
+type productStub struct {
+	db *gorm.DB
+}
+
+func (p productStub) GetByID(id string) (*productdomain.Product, error) {
+	productID, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	var product productdomain.Product
+	if err := p.db.Preload("SKUs").First(&product, productID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &product, nil
+}
+
+type cardStub struct {
+	available map[uint]int64
+}
+
+func (c *cardStub) CountAvailable(_, skuID uint) (int64, error) {
+	return c.available[skuID], nil
+}
+
+type walletStub struct{}
+
+func (walletStub) DebitInTransaction(walletcontract.Transaction, walletcontract.DebitInput) (*walletdomain.Transaction, error) {
+	return &walletdomain.Transaction{}, nil
+}
+
+type refundCall struct {
+	orderID uint
+	amount  string
+}
+
+type refunderStub struct {
+	calls []refundCall
+}
+
+func (r *refunderStub) RefundToWallet(orderID uint, amount money.Amount, _ string) error {
+	r.calls = append(r.calls, refundCall{orderID: orderID, amount: amount.String()})
+	return nil
+}
+
+type notifierStub struct {
+	orders       []uint
+	guestRefunds []uint
+}
+
+func (n *notifierStub) NotifyManualPending(orderID uint) error {
+	n.orders = append(n.orders, orderID)
+	return nil
+}
+
+func (n *notifierStub) NotifyGuestRefundRequired(orderID uint) error {
+	n.guestRefunds = append(n.guestRefunds, orderID)
+	return nil
+}
+
+type queueStub struct {
+	allocations  []uint
+	autoFulfills []uint
+}
+
+func (q *queueStub) EnqueueAllocate(productID uint) error {
+	q.allocations = append(q.allocations, productID)
+	return nil
+}
+
+func (q *queueStub) EnqueueAutoFulfill(orderID uint) error {
+	q.autoFulfills = append(q.autoFulfills, orderID)
+	return nil
+}
+
+type fixture struct {
+	db       *gorm.DB
+	now      time.Time
+	service  *preorderapp.Service
+	cards    *cardStub
+	refunder *refunderStub
+	notifier *notifierStub
+	queue    *queueStub
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+	dsn := fmt.Sprintf("file:preorder_%d?mode=memory&cache=shared", time.Now().UnixNano())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite failed: %v", err)
+	}
+	if err := db.AutoMigrate(
+		&stockledgerdomain.Movement{},
+		&productdomain.Product{},
+		&productdomain.ProductSKU{},
+		&orderdomain.Order{},
+		&orderdomain.OrderItem{},
+		&fulfillmentdomain.Fulfillment{},
+		&preorderdomain.Policy{},
+		&preorderdomain.Backorder{},
+	); err != nil {
+		t.Fatalf("auto migrate failed: %v", err)
+	}
+	f := &fixture{
+		db:       db,
+		now:      time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC),
+		cards:    &cardStub{available: map[uint]int64{}},
+		refunder: &refunderStub{},
+		notifier: &notifierStub{},
+		queue:    &queueStub{},
+	}
+	f.service = preorderapp.NewService(preorderapp.Options{
+		Store:    preordergormstore.New(db, testSecret),
+		Products: productStub{db: db},
+		Cards:    f.cards,
+		Orders:   ordergormstore.New(db, testSecret),
+		Wallets:  walletStub{},
+		Refunder: f.refunder,
+		Notifier: f.notifier,
+		Queue:    f.queue,
+		Now:      func() time.Time { return f.now },
+	})
+	f.seedProduct(t)
+	return f
+}
+
+// seedProduct 商品 1 为人工交付，规格 11 缺货，规格 12 有货。
+func (f *fixture) seedProduct(t *testing.T) {
+	t.Helper()
+	product := productdomain.Product{
+		ID:              1,
+		CategoryID:      1,
+		Slug:            "manual-item",
+		TitleJSON:       jsonmap.JSON{"zh-CN": "人工商品"},
+		FulfillmentType: constants.FulfillmentTypeManual,
+		IsActive:        true,
+		SKUs: []productdomain.ProductSKU{
+			{ID: 11, SKUCode: "RED", ManualStockTotal: 0, IsActive: true},
+			{ID: 12, SKUCode: "BLUE", ManualStockTotal: 5, IsActive: true},
+		},
+	}
+	if err := f.db.Create(&product).Error; err != nil {
+		t.Fatalf("create product failed: %v", err)
+	}
+}
+
+func (f *fixture) savePolicy(t *testing.T, input preordercontract.SavePolicyInput) *preorderdomain.Policy {
+	t.Helper()
+	policy, err := f.service.SavePolicy(input)
+	if err != nil {
+		t.Fatalf("save policy failed: %v", err)
+	}
+	return policy
+}
+
+// seedQueued 创建已支付的预售子订单与排队记录，paidOffset 决定排队先后。
+func (f *fixture) seedQueued(t *testing.T, userID uint, amount string, paidOffset time.Duration) preorderdomain.Backorder {
+	t.Helper()
+	paidAt := f.now.Add(paidOffset)
+	seq := paidAt.UnixNano()
+	parent := orderdomain.Order{
+		OrderNo:     fmt.Sprintf("P%d", seq),
+		UserID:      userID,
+		Status:      constants.OrderStatusPaid,
+		Currency:    "CNY",
+		TotalAmount: money.FromDecimal(decimal.RequireFromString(amount)),
+		PaidAt:      &paidAt,
+	}
+	if err := f.db.Create(&parent).Error; err != nil {
+		t.Fatalf("create parent order failed: %v", err)
+	}
+	child := orderdomain.Order{
+		OrderNo:     fmt.Sprintf("P%d-01", seq),
+		ParentID:    &parent.ID,
+		UserID:      userID,
+		Status:      constants.OrderStatusPaid,
+		Currency:    "CNY",
+		TotalAmount: money.FromDecimal(decimal.RequireFromString(amount)),
+		IsPreorder:  true,
+		PaidAt:      &paidAt,
+	}
+	if err := f.db.Create(&child).Error; err != nil {
+		t.Fatalf("create child order failed: %v", err)
+	}
+	backorder := preorderdomain.Backorder{
+		OrderID:         child.ID,
+		ParentOrderID:   parent.ID,
+		OrderNo:         child.OrderNo,
+		UserID:          userID,
+		ProductID:       1,
+		SKUID:           11,
+		Quantity:        1,
+		FulfillmentType: constants.FulfillmentTypeManual,
+		PaymentMode:     preorderdomain.PaymentModeFull,
+		Currency:        "CNY",
+		Status:          preorderdomain.StatusQueued,
+		ExpectedAt:      f.now.Add(24 * time.Hour),
+		QueuedAt:        &paidAt,
+	}
+	if err := f.db.Create(&backorder).Error; err != nil {
+		t.Fatalf("create backorder failed: %v", err)
+	}
+	return backorder
+}
+
+func (f *fixture) reload(t *testing.T, id uint) preorderdomain.Backorder {
+	t.Helper()
+	var backorder preorderdomain.Backorder
+	if err := f.db.First(&backorder, id).Error; err != nil {
+		t.Fatalf("reload backorder failed: %v", err)
+	}
+	return backorder
+}
+
+func TestSavePolicyValidatesAndResolvesOnlyWhenOutOfStock(t *testing.T) {
+	f := newFixture(t)
+	expectedAt := f.now.Add(7 * 24 * time.Hour)
+
+	invalid := []preordercontract.SavePolicyInput{
+		{ProductID: 1, SKUID: 11, Enabled: true, PaymentMode: "deposit", DepositPercent: 100, ExpectedAt: expectedAt},
+		{ProductID: 1, SKUID: 11, Enabled: true, PaymentMode: "full", ExpectedAt: f.now.Add(-time.Hour)},
+		{ProductID: 1, SKUID: 99, Enabled: true, PaymentMode: "full", ExpectedAt: expectedAt},
+		{ProductID: 1, SKUID: 11, Enabled: true, PaymentMode: "later", ExpectedAt: expectedAt},
+	}
+	for i, input := range invalid {
+		if _, err := f.service.SavePolicy(input); !errors.Is(err, preordercontract.ErrPolicyInvalid) {
+			t.Fatalf("case %d: expected invalid policy error, got %v", i, err)
+		}
+	}
+
+	first := f.savePolicy(t, preordercontract.SavePolicyInput{ProductID: 1, SKUID: 11, Enabled: true, PaymentMode: "deposit", DepositPercent: 30, ExpectedAt: expectedAt, MaxOutstanding: 2})
+	second := f.savePolicy(t, preordercontract.SavePolicyInput{ProductID: 1, SKUID: 11, Enabled: true, PaymentMode: "full", ExpectedAt: expectedAt, MaxOutstanding: 2})
+	if first.ID != second.ID || second.DepositPercent != 0 || second.PaymentMode != preorderdomain.PaymentModeFull {
+		t.Fatalf("expected upsert by sku, got %+v and %+v", first, second)
+	}
+	f.savePolicy(t, preordercontract.SavePolicyInput{ProductID: 1, SKUID: 12, Enabled: true, PaymentMode: "full", ExpectedAt: expectedAt})
+
+	product, err := productStub{db: f.db}.GetByID("1")
+	if err != nil || product == nil {
+		t.Fatalf("load product failed: %v", err)
+	}
+	policy, err := f.service.ResolvePreorder(product, &product.SKUs[0], 1)
+	if err != nil || policy == nil || policy.SKUID != 11 {
+		t.Fatalf("expected out-of-stock sku to resolve preorder, got %+v err=%v", policy, err)
+	}
+	if policy, err := f.service.ResolvePreorder(product, &product.SKUs[1], 1); err != nil || policy != nil {
+		t.Fatalf("expected in-stock sku to sell normally, got %+v err=%v", policy, err)
+	}
+
+	f.seedQueued(t, 7, "10.00", time.Minute)
+	public, err := f.service.ListPublicPolicies(1)
+	if err != nil {
+		t.Fatalf("list public policies failed: %v", err)
+	}
+	if len(public) != 2 || public[0].SKUID != 11 || public[0].Remaining == nil || *public[0].Remaining != 1 || public[1].Remaining != nil {
+		t.Fatalf("unexpected public policies: %+v", public)
+	}
+}
+
+func TestAllocateServesQueueInPaymentOrder(t *testing.T) {
+	f := newFixture(t)
+	late := f.seedQueued(t, 8, "10.00", 2*time.Minute)
+	early := f.seedQueued(t, 7, "10.00", time.Minute)
+
+	if err := f.db.Model(&productdomain.ProductSKU{}).Where("id = ?", 11).Update("manual_stock_total", 1).Error; err != nil {
+		t.Fatalf("restock failed: %v", err)
+	}
+	if err := f.service.Allocate(1); err != nil {
+		t.Fatalf("allocate failed: %v", err)
+	}
+
+	if got := f.reload(t, early.ID); got.Status != preorderdomain.StatusAllocated || got.AllocatedAt == nil {
+		t.Fatalf("expected earliest payment to be allocated, got %+v", got)
+	}
+	if got := f.reload(t, late.ID); got.Status != preorderdomain.StatusQueued {
+		t.Fatalf("expected later payment to keep waiting, got %s", got.Status)
+	}
+	var order orderdomain.Order
+	if err := f.db.First(&order, early.OrderID).Error; err != nil {
+		t.Fatalf("load order failed: %v", err)
+	}
+	if order.Status != constants.OrderStatusFulfilling {
+		t.Fatalf("expected manual preorder to enter fulfilling, got %s", order.Status)
+	}
+	var sku productdomain.ProductSKU
+	if err := f.db.First(&sku, 11).Error; err != nil {
+		t.Fatalf("load sku failed: %v", err)
+	}
+	if sku.ManualStockTotal != 0 || sku.ManualStockLocked != 0 || sku.ManualStockSold != 1 {
+		t.Fatalf("expected stock consumed once, got %+v", sku)
+	}
+	if len(f.notifier.orders) != 1 || f.notifier.orders[0] != early.OrderID {
+		t.Fatalf("expected manual pending notification, got %v", f.notifier.orders)
+	}
+
+	views, err := f.service.ListUserBackorders(8)
+	if err != nil {
+		t.Fatalf("list user backorders failed: %v", err)
+	}
+	if len(views) != 1 || views[0].QueuePosition != 1 {
+		t.Fatalf("expected remaining entry at head of queue, got %+v", views)
+	}
+}
+
+func TestSweepRefundsExpiredUserEntriesAndAlertsGuestRefunds(t *testing.T) {
+	f := newFixture(t)
+	member := f.seedQueued(t, 7, "12.50", time.Minute)
+	guest := f.seedQueued(t, 0, "8.00", 2*time.Minute)
+
+	f.now = f.now.Add(48 * time.Hour)
+	if err := f.service.Sweep(); err != nil {
+		t.Fatalf("sweep failed: %v", err)
+	}
+
+	if got := f.reload(t, member.ID); got.Status != preorderdomain.StatusRefunded || got.ClosedAt == nil {
+		t.Fatalf("expected member entry refunded, got %+v", got)
+	}
+	if got := f.reload(t, guest.ID); got.Status != preorderdomain.StatusRefundReview {
+		t.Fatalf("expected guest entry awaiting original-route refund, got %s", got.Status)
+	}
+	if len(f.refunder.calls) != 1 || f.refunder.calls[0].orderID != member.OrderID || f.refunder.calls[0].amount != "12.50" {
+		t.Fatalf("unexpected refunds: %+v", f.refunder.calls)
+	}
+	if len(f.notifier.guestRefunds) != 1 || f.notifier.guestRefunds[0] != guest.OrderID {
+		t.Fatalf("expected one guest refund alert, got %+v", f.notifier.guestRefunds)
+	}
+
+	// 重复巡检不会再次退款或告警
+	if err := f.service.Sweep(); err != nil {
+		t.Fatalf("second sweep failed: %v", err)
+	}
+	if len(f.refunder.calls) != 1 || len(f.notifier.guestRefunds) != 1 {
+		t.Fatalf("expected refund and alert to be idempotent, got refunds=%+v alerts=%+v", f.refunder.calls, f.notifier.guestRefunds)
+	}
+
+	// 后台原路退款后，巡检将游客记录标记为已退款
+	if err := f.db.Model(&orderdomain.Order{}).Where("id = ?", guest.OrderID).Update("status", constants.OrderStatusRefunded).Error; err != nil {
+		t.Fatalf("mark guest order refunded failed: %v", err)
+	}
+	if err := f.service.Sweep(); err != nil {
+		t.Fatalf("third sweep failed: %v", err)
+	}
+	if got := f.reload(t, guest.ID); got.Status != preorderdomain.StatusRefunded {
+		t.Fatalf("expected guest entry refunded after manual refund, got %s", got.Status)
+	}
+}
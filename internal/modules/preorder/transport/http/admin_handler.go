@@ -0,0 +1,109 @@
+package preorderhttp
+
+import (
+	"strings"
+	"time"
+
+	preordercontract "github.com/dujiao-next/internal/modules/preorder/contract"
+	preorderdomain "github.com/dujiao-next/internal/modules/preorder/domain"
+	"github.com/dujiao-next/internal/platform/http/ginutil"
+	"github.com/dujiao-next/internal/platform/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminService 是后台预售设置与排队查询所需的最小用例接口。
+type AdminService interface {
+	ListPolicies(productID uint) ([]preordercontract.PolicyView, error)
+	SavePolicy(input preordercontract.SavePolicyInput) (*preorderdomain.Policy, error)
+	ListBackorders(filter preordercontract.ListFilter) ([]preordercontract.BackorderView, int64, error)
+}
+
+// AdminHandler 处理后台预售设置与排队记录请求。
+type AdminHandler struct {
+	service AdminService
+}
+
+func NewAdminHandler(service AdminService) *AdminHandler {
+	if service == nil {
+		panic("preorder admin handler: required dependency is nil")
+	}
+	return &AdminHandler{service: service}
+}
+
+// SavePolicyRequest 规格预售设置请求；payment_mode 为 full 或 deposit，max_outstanding 为 0 表示不限
+type SavePolicyRequest struct {
+	ProductID      uint      `json:"product_id" binding:"required"`
+	SKUID          uint      `json:"sku_id" binding:"required"`
+	Enabled        bool      `json:"enabled"`
+	PaymentMode    string    `json:"payment_mode"`
+	DepositPercent int       `json:"deposit_percent"`
+	ExpectedAt     time.Time `json:"expected_at" binding:"required"`
+	MaxOutstanding int       `json:"max_outstanding"`
+}
+
+// ListPolicies 获取预售设置（支持 product_id 筛选）
+func (h *AdminHandler) ListPolicies(c *gin.Context) {
+	productID, err := ginutil.ParseQueryUint(c.Query("product_id"), false)
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", nil)
+		return
+	}
+	policies, err := h.service.ListPolicies(productID)
+	if err != nil {
+		ginutil.RespondError(c, response.CodeInternal, "error.preorder_fetch_failed", err)
+		return
+	}
+	response.Success(c, policies)
+}
+
+// SavePolicy 保存规格预售设置
+func (h *AdminHandler) SavePolicy(c *gin.Context) {
+	var req SavePolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ginutil.RespondBindError(c, err)
+		return
+	}
+	policy, err := h.service.SavePolicy(preordercontract.SavePolicyInput{
+		ProductID:      req.ProductID,
+		SKUID:          req.SKUID,
+		Enabled:        req.Enabled,
+		PaymentMode:    req.PaymentMode,
+		DepositPercent: req.DepositPercent,
+		ExpectedAt:     req.ExpectedAt,
+		MaxOutstanding: req.MaxOutstanding,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	response.Success(c, policy)
+}
+
+// ListBackorders 获取预售排队记录（支持 product_id、sku_id、status、order_no 筛选）
+func (h *AdminHandler) ListBackorders(c *gin.Context) {
+	productID, err := ginutil.ParseQueryUint(c.Query("product_id"), false)
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", nil)
+		return
+	}
+	skuID, err := ginutil.ParseQueryUint(c.Query("sku_id"), false)
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", nil)
+		return
+	}
+	page, pageSize := ginutil.ParsePagination(c)
+	backorders, total, err := h.service.ListBackorders(preordercontract.ListFilter{
+		ProductID: productID,
+		SKUID:     skuID,
+		Status:    strings.TrimSpace(c.Query("status")),
+		OrderNo:   strings.TrimSpace(c.Query("order_no")),
+		Page:      page,
+		PageSize:  pageSize,
+	})
+	if err != nil {
+		ginutil.RespondError(c, response.CodeInternal, "error.preorder_fetch_failed", err)
+		return
+	}
+	response.SuccessWithPage(c, backorders, response.BuildPagination(page, pageSize, total))
+}
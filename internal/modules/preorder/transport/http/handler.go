@@ -0,0 +1,125 @@
+package preorderhttp
+
+import (
+	"errors"
+	"strings"
+
+	preordercontract "github.com/dujiao-next/internal/modules/preorder/contract"
+	preorderdomain "github.com/dujiao-next/internal/modules/preorder/domain"
+	walletcontract "github.com/dujiao-next/internal/modules/wallet/contract"
+	"github.com/dujiao-next/internal/platform/http/ginutil"
+	"github.com/dujiao-next/internal/platform/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Service 是店面预售查询与尾款支付所需的最小用例接口。
+type Service interface {
+	ListPublicPolicies(productID uint) ([]preordercontract.PublicPolicy, error)
+	ListUserBackorders(userID uint) ([]preordercontract.BackorderView, error)
+	ListGuestBackorders(orderNo, email, password string) ([]preordercontract.BackorderView, error)
+	PayBalance(userID, backorderID uint) (*preorderdomain.Backorder, error)
+}
+
+// Handler 处理店面预售信息、预售进度与尾款支付请求。
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	if service == nil {
+		panic("preorder handler: required dependency is nil")
+	}
+	return &Handler{service: service}
+}
+
+// ListPublicPolicies 获取商品当前接受预售的规格
+func (h *Handler) ListPublicPolicies(c *gin.Context) {
+	productID, err := ginutil.ParseQueryUint(c.Query("product_id"), true)
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", nil)
+		return
+	}
+	policies, err := h.service.ListPublicPolicies(productID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	response.Success(c, policies)
+}
+
+// ListUserBackorders 获取当前用户的预售进度
+func (h *Handler) ListUserBackorders(c *gin.Context) {
+	userID, ok := ginutil.GetUserID(c)
+	if !ok {
+		return
+	}
+	backorders, err := h.service.ListUserBackorders(userID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	response.Success(c, backorders)
+}
+
+// ListGuestBackorders 游客获取订单内的预售进度
+func (h *Handler) ListGuestBackorders(c *gin.Context) {
+	email, password, ok := ginutil.GetGuestCredentials(c)
+	if !ok || email == "" || password == "" {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.guest_email_required", nil)
+		return
+	}
+	orderNo := strings.TrimSpace(c.Param("order_no"))
+	if orderNo == "" {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", nil)
+		return
+	}
+	backorders, err := h.service.ListGuestBackorders(orderNo, email, password)
+	if err != nil {
+		if errors.Is(err, preordercontract.ErrOrderNotFound) {
+			ginutil.RespondError(c, response.CodeNotFound, "error.guest_order_not_found", nil)
+			return
+		}
+		respondError(c, err)
+		return
+	}
+	response.Success(c, backorders)
+}
+
+// PayBalance 使用余额支付定金预售的尾款
+func (h *Handler) PayBalance(c *gin.Context) {
+	userID, ok := ginutil.GetUserID(c)
+	if !ok {
+		return
+	}
+	id, err := ginutil.ParseParamUint(c, "id")
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", nil)
+		return
+	}
+	backorder, err := h.service.PayBalance(userID, id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	response.Success(c, backorder)
+}
+
+func respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, preordercontract.ErrPolicyInvalid):
+		ginutil.RespondError(c, response.CodeBadRequest, "error.preorder_policy_invalid", nil)
+	case errors.Is(err, preordercontract.ErrProductNotFound):
+		ginutil.RespondError(c, response.CodeNotFound, "error.product_not_found", nil)
+	case errors.Is(err, preordercontract.ErrBackorderNotFound), errors.Is(err, preordercontract.ErrOrderNotFound):
+		ginutil.RespondError(c, response.CodeNotFound, "error.preorder_not_found", nil)
+	case errors.Is(err, preordercontract.ErrBackorderStateInvalid):
+		ginutil.RespondError(c, response.CodeBadRequest, "error.preorder_state_invalid", nil)
+	case errors.Is(err, preordercontract.ErrBalanceOverdue):
+		ginutil.RespondError(c, response.CodeBadRequest, "error.preorder_balance_overdue", nil)
+	case errors.Is(err, walletcontract.ErrInsufficientBalance):
+		ginutil.RespondError(c, response.CodeBadRequest, "error.wallet_insufficient_balance", nil)
+	default:
+		ginutil.RespondError(c, response.CodeInternal, "error.preorder_fetch_failed", err)
+	}
+}
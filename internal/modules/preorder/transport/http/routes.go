@@ -0,0 +1,38 @@
+package preorderhttp
+
+import "github.com/gin-gonic/gin"
+
+// RegisterPublicRoutes 注册店面预售信息查询路由。
+func RegisterPublicRoutes(public gin.IRoutes, handler *Handler) {
+	if public == nil || handler == nil {
+		panic("preorder public routes: required dependency is nil")
+	}
+	public.GET("/preorder-policies", handler.ListPublicPolicies)
+}
+
+// RegisterUserRoutes 注册登录用户预售进度与尾款支付路由。
+func RegisterUserRoutes(user gin.IRoutes, handler *Handler) {
+	if user == nil || handler == nil {
+		panic("preorder user routes: required dependency is nil")
+	}
+	user.GET("/preorders", handler.ListUserBackorders)
+	user.POST("/preorders/:id/pay-balance", handler.PayBalance)
+}
+
+// RegisterGuestRoutes 注册游客订单预售进度路由。
+func RegisterGuestRoutes(guest gin.IRoutes, handler *Handler) {
+	if guest == nil || handler == nil {
+		panic("preorder guest routes: required dependency is nil")
+	}
+	guest.GET("/orders/:order_no/preorders", handler.ListGuestBackorders)
+}
+
+// RegisterAdminRoutes 注册后台预售设置与排队查询路由。
+func RegisterAdminRoutes(authorized gin.IRoutes, handler *AdminHandler) {
+	if authorized == nil || handler == nil {
+		panic("preorder admin routes: required dependency is nil")
+	}
+	authorized.GET("/preorder-policies", handler.ListPolicies)
+	authorized.POST("/preorder-policies", handler.SavePolicy)
+	authorized.GET("/backorders", handler.ListBackorders)
+}
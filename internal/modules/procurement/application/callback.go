@@ -132,6 +132,9 @@ func (s *Service) HandleUpstreamCallback(procurementOrderID uint, upstreamStatus
 			"upstream_status", upstreamStatus,
 			"local_status", targetStatus,
 		)
+		// 回调与轮询均不携带退款金额，拉取上游订单详情后交由退款联动按策略处理
+		procOrder.Status = targetStatus
+		s.observeUpstreamRefund(procOrder)
 
 	default:
 		logger.Warnw("procurement_unknown_upstream_status",
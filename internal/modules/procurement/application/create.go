@@ -21,6 +21,10 @@ func (s *Service) CreateForOrder(orderID uint) error {
 	if order == nil {
 		return procurementcontract.ErrOrderNotFound
 	}
+	// 待人工复核订单暂不提交上游，放行后由支付服务重新触发
+	if order.Status == constants.OrderStatusHeldForReview {
+		return procurementcontract.ErrOrderHeldForReview
+	}
 
 	// 父订单有子订单：遍历子订单
 	if order.ParentID == nil && len(order.Children) > 0 {
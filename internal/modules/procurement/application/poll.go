@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/dujiao-next/internal/constants"
 	"github.com/dujiao-next/internal/logger"
 	procurementcontract "github.com/dujiao-next/internal/modules/procurement/contract"
 	procurementdomain "github.com/dujiao-next/internal/modules/procurement/domain"
@@ -190,6 +191,64 @@ func (s *Service) SyncAcceptedOrders() {
 	}
 }
 
+// refundSyncWindow 上游退款巡检回溯窗口，更早创建的采购单不再主动轮询
+const refundSyncWindow = 30 * 24 * time.Hour
+
+// refundSyncStatuses 需要巡检上游退款的采购单状态；全额退款后不再轮询
+var refundSyncStatuses = []string{
+	constants.ProcurementStatusFulfilled,
+	constants.ProcurementStatusCompleted,
+	constants.ProcurementStatusPartiallyRefunded,
+}
+
+// SyncUpstreamRefunds 定时巡检：已交付的采购单不再收到状态轮询，由此拉取上游退款交给退款联动
+// 仅巡检开启退款联动的连接，由 worker 定时任务调用（每30分钟）
+func (s *Service) SyncUpstreamRefunds() {
+	if s.refundObserver == nil {
+		return
+	}
+	since := time.Now().Add(-refundSyncWindow)
+	enabled := make(map[uint]bool)
+	for _, status := range refundSyncStatuses {
+		orders, _, err := s.procRepo.List(procurementcontract.ListFilter{
+			Status:      status,
+			CreatedFrom: &since,
+			Page:        1,
+			PageSize:    200,
+		})
+		if err != nil {
+			logger.Warnw("procurement_sync_refunds_list_failed", "status", status, "error", err)
+			continue
+		}
+		for i := range orders {
+			procOrder := &orders[i]
+			if procOrder.UpstreamOrderID == 0 {
+				continue
+			}
+			on, ok := enabled[procOrder.ConnectionID]
+			if !ok {
+				on = s.refundObserver.RefundObservationEnabled(procOrder.ConnectionID)
+				enabled[procOrder.ConnectionID] = on
+			}
+			if on {
+				s.observeUpstreamRefund(procOrder)
+			}
+		}
+	}
+}
+
+// observeUpstreamRefund 拉取上游订单详情并同步退款状态，上游有退款金额时交由退款联动处理。
+// 仅由上游回调与定时巡检调用，后台查询接口只展示退款记录，不触发本地退款。
+func (s *Service) observeUpstreamRefund(procOrder *procurementdomain.Order) {
+	if s.refundObserver == nil || procOrder == nil {
+		return
+	}
+	s.fillUpstreamRefundRecordsForProcurementOrder(procOrder)
+	if isPositiveUpstreamRefundAmount(procOrder.UpstreamRefundedAmount) {
+		s.refundObserver.ObserveUpstreamRefund(procOrder)
+	}
+}
+
 // mapProcurementUpstreamStatus 统一映射上游状态别名，便于回调与轮询使用同一分支逻辑。
 func mapProcurementUpstreamStatus(status string) string {
 	normalized := strings.ToLower(strings.TrimSpace(status))
@@ -13,6 +13,7 @@ type Options struct {
 	DownstreamCallback procurementcontract.DownstreamCallbackEnqueuer
 	BotNotifier        procurementcontract.BotFulfillmentNotifier
 	Notifications      procurementcontract.FailureNotifier
+	RefundObserver     procurementcontract.RefundObserver
 }
 
 type Service struct {
@@ -26,6 +27,7 @@ type Service struct {
 	downstreamCallback procurementcontract.DownstreamCallbackEnqueuer
 	botNotifier        procurementcontract.BotFulfillmentNotifier
 	notifications      procurementcontract.FailureNotifier
+	refundObserver     procurementcontract.RefundObserver
 }
 
 var _ procurementcontract.UseCase = (*Service)(nil)
@@ -37,5 +39,6 @@ func NewService(options Options) *Service {
 		connections: options.Connections, queue: options.Queue,
 		orderLifecycle: options.OrderLifecycle, downstreamCallback: options.DownstreamCallback,
 		botNotifier: options.BotNotifier, notifications: options.Notifications,
+		refundObserver: options.RefundObserver,
 	}
 }
@@ -8,4 +8,5 @@ var (
 	ErrStatusInvalid      = errors.New("procurement order status invalid")
 	ErrOrderNotFound      = errors.New("order not found")
 	ErrConnectionNotFound = errors.New("site connection not found")
+	ErrOrderHeldForReview = errors.New("order held for review")
 )
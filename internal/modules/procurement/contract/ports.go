@@ -54,6 +54,13 @@ type BotFulfillmentNotifier interface {
 	NotifyBotOrderFulfilled(userID, orderID uint)
 }
 
+// RefundObserver 在观测到上游退款后按连接策略联动本地订单退款，需自行保证幂等。
+// RefundObservationEnabled 供定时巡检跳过未开启退款联动的连接，避免无效的上游请求。
+type RefundObserver interface {
+	ObserveUpstreamRefund(order *procurementdomain.Order)
+	RefundObservationEnabled(connectionID uint) bool
+}
+
 type FailureNotifier interface {
 	NotifyFailure(order *procurementdomain.Order, message string) error
 }
@@ -73,6 +73,7 @@ type UseCase interface {
 	SubmitToUpstream(procurementOrderID uint) error
 	PollUpstreamStatus(procurementOrderID uint) error
 	SyncAcceptedOrders()
+	SyncUpstreamRefunds()
 	HandleUpstreamCallback(procurementOrderID uint, upstreamStatus string, fulfillment *Fulfillment) error
 	GetByID(id uint) (*procurementdomain.Order, error)
 	GetByLocalOrderNo(localOrderNo string) (*procurementdomain.Order, error)
@@ -4,12 +4,15 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	orderdomain "github.com/dujiao-next/internal/modules/order/domain"
 
 	"github.com/dujiao-next/internal/constants"
+	procurementcontract "github.com/dujiao-next/internal/modules/procurement/contract"
+	procurementdomain "github.com/dujiao-next/internal/modules/procurement/domain"
 	siteconnectionapp "github.com/dujiao-next/internal/modules/siteconnection/application"
 )
 
@@ -125,3 +128,84 @@ func TestPollUpstreamStatus_FulfilledMappedToDelivered(t *testing.T) {
 		t.Errorf("expected order status %q, got %q", constants.OrderStatusDelivered, updatedOrder.Status)
 	}
 }
+
+// ── SyncUpstreamRefunds test ──
+
+type refundObserverStub struct {
+	enabled  bool
+	observed []string
+}
+
+func (o *refundObserverStub) ObserveUpstreamRefund(order *procurementdomain.Order) {
+	o.observed = append(o.observed, order.UpstreamRefundedAmount)
+}
+
+func (o *refundObserverStub) RefundObservationEnabled(uint) bool {
+	return o.enabled
+}
+
+func TestSyncUpstreamRefunds_ObservesOnlyFromWorker(t *testing.T) {
+	db := setupProcurementTestDB(t)
+	order := createProcTestOrder(t, db, "PROC-REFUND-SYNC-001", constants.OrderStatusDelivered, constants.FulfillmentTypeUpstream)
+
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"order_id":        999,
+			"order_no":        "UP-999",
+			"status":          "partially_refunded",
+			"amount":          "50.00",
+			"refunded_amount": "10.00",
+			"currency":        "CNY",
+		})
+	}))
+	defer server.Close()
+
+	connSvc := newTestSiteConnectionService(db, "test-key", t.TempDir())
+	conn, err := connSvc.Create(siteconnectionapp.CreateInput{
+		Name:      "upstream-refund-sync",
+		BaseURL:   server.URL,
+		ApiKey:    "key",
+		ApiSecret: "secret",
+		Protocol:  constants.ConnectionProtocolDujiaoNext,
+	})
+	if err != nil {
+		t.Fatalf("create connection: %v", err)
+	}
+	proc := createTestProcurementOrder(t, db, conn.ID, order.ID, order.OrderNo, constants.ProcurementStatusFulfilled)
+	if err := db.Model(&procurementdomain.Order{}).Where("id = ?", proc.ID).Updates(map[string]interface{}{
+		"upstream_order_id": uint(999),
+		"upstream_order_no": "UP-999",
+	}).Error; err != nil {
+		t.Fatalf("set upstream order info: %v", err)
+	}
+
+	observer := &refundObserverStub{}
+	svc := newTestProcurementServiceWithObserver(db, connSvc, observer)
+
+	// 后台查询只展示上游退款，不触发退款联动
+	if _, err := svc.GetByID(proc.ID); err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if _, _, err := svc.List(procurementcontract.ListFilter{Page: 1, PageSize: 20}); err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(observer.observed) != 0 {
+		t.Fatalf("expected read paths not to observe refunds, got %v", observer.observed)
+	}
+
+	// 未开启退款联动的连接不请求上游
+	before := atomic.LoadInt32(&hits)
+	svc.SyncUpstreamRefunds()
+	if atomic.LoadInt32(&hits) != before || len(observer.observed) != 0 {
+		t.Fatalf("expected disabled connection skipped, hits=%d observed=%v", atomic.LoadInt32(&hits)-before, observer.observed)
+	}
+
+	observer.enabled = true
+	svc.SyncUpstreamRefunds()
+	if len(observer.observed) != 1 || observer.observed[0] != "10.00" {
+		t.Fatalf("expected worker to observe upstream refund 10.00, got %v", observer.observed)
+	}
+}
@@ -120,6 +120,10 @@ func createTestProcurementOrder(t *testing.T, db *gorm.DB, connID, localOrderID
 }
 
 func newTestProcurementService(db *gorm.DB, connections *siteconnectionapp.Service) *procurementapp.Service {
+	return newTestProcurementServiceWithObserver(db, connections, nil)
+}
+
+func newTestProcurementServiceWithObserver(db *gorm.DB, connections *siteconnectionapp.Service, observer procurementcontract.RefundObserver) *procurementapp.Service {
 	orders := ordergormstore.New(db, "test-guest-credential-secret-with-32-bytes")
 	return procurementapp.NewService(procurementapp.Options{
 		Repository:      procurementgormstore.New(db),
@@ -128,5 +132,6 @@ func newTestProcurementService(db *gorm.DB, connections *siteconnectionapp.Servi
 		SKUMappings:     procurementmapping.NewSKUs(mappinggormstore.NewSKUMappingStore(db)),
 		Connections:     procurementupstream.New(connections),
 		OrderLifecycle:  procurementgormstore.NewLifecycle(db, nil, nil, config.EmailConfig{}),
+		RefundObserver:  observer,
 	})
 }
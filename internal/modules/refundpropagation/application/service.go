@@ -0,0 +1,308 @@
+package application
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dujiao-next/internal/constants"
+	"github.com/dujiao-next/internal/logger"
+	procurementdomain "github.com/dujiao-next/internal/modules/procurement/domain"
+	refundpropagationcontract "github.com/dujiao-next/internal/modules/refundpropagation/contract"
+	refundpropagationdomain "github.com/dujiao-next/internal/modules/refundpropagation/domain"
+	siteconnectiondomain "github.com/dujiao-next/internal/modules/siteconnection/domain"
+	"github.com/dujiao-next/internal/shared/money"
+
+	"github.com/shopspring/decimal"
+)
+
+// Options 描述退款联动服务依赖；Notifier 为空时不发送后台提醒。
+type Options struct {
+	Store       refundpropagationcontract.Store
+	Connections refundpropagationcontract.ConnectionReader
+	Orders      refundpropagationcontract.OrderReader
+	Refunder    refundpropagationcontract.Refunder
+	Notifier    refundpropagationcontract.Notifier
+	Now         func() time.Time
+}
+
+// Service 按站点连接策略将上游退款折算为本地订单退款，支持自动执行与人工审核。
+type Service struct {
+	store       refundpropagationcontract.Store
+	connections refundpropagationcontract.ConnectionReader
+	orders      refundpropagationcontract.OrderReader
+	refunder    refundpropagationcontract.Refunder
+	notifier    refundpropagationcontract.Notifier
+	now         func() time.Time
+}
+
+func NewService(options Options) *Service {
+	if options.Store == nil || options.Connections == nil || options.Orders == nil || options.Refunder == nil {
+		panic("refund propagation service: required dependency is nil")
+	}
+	now := options.Now
+	if now == nil {
+		now = time.Now
+	}
+	return &Service{
+		store:       options.Store,
+		connections: options.Connections,
+		orders:      options.Orders,
+		refunder:    options.Refunder,
+		notifier:    options.Notifier,
+		now:         now,
+	}
+}
+
+// ObserveUpstreamRefund 接收采购侧观测到的上游累计退款金额（回调或轮询），同一累计金额只联动一次。
+func (s *Service) ObserveUpstreamRefund(order *procurementdomain.Order) {
+	if order == nil || order.ID == 0 {
+		return
+	}
+	propagation, err := s.observe(order)
+	if err != nil {
+		logger.Warnw("refund_propagation_observe_failed",
+			"procurement_order_id", order.ID,
+			"local_order_id", order.LocalOrderID,
+			"upstream_refunded_amount", order.UpstreamRefundedAmount,
+			"error", err,
+		)
+		return
+	}
+	if propagation != nil {
+		s.notify(propagation)
+	}
+}
+
+// RefundObservationEnabled 判断连接是否开启上游退款联动，供采购侧定时巡检过滤连接。
+func (s *Service) RefundObservationEnabled(connectionID uint) bool {
+	connection, err := s.connections.GetByID(connectionID)
+	if err != nil || connection == nil {
+		return false
+	}
+	return propagationEnabled(connection)
+}
+
+func propagationEnabled(connection *siteconnectiondomain.Connection) bool {
+	mode := strings.TrimSpace(connection.RefundPropagationMode)
+	return mode == constants.RefundPropagationAuto || mode == constants.RefundPropagationReview
+}
+
+func (s *Service) observe(order *procurementdomain.Order) (*refundpropagationdomain.Propagation, error) {
+	upstreamTotal, err := decimal.NewFromString(strings.TrimSpace(order.UpstreamRefundedAmount))
+	if err != nil {
+		return nil, nil
+	}
+	upstreamTotal = upstreamTotal.Round(2)
+	if upstreamTotal.LessThanOrEqual(decimal.Zero) {
+		return nil, nil
+	}
+	connection, err := s.connections.GetByID(order.ConnectionID)
+	if err != nil || connection == nil {
+		return nil, err
+	}
+	if !propagationEnabled(connection) {
+		return nil, nil
+	}
+
+	var propagation *refundpropagationdomain.Propagation
+	err = s.store.WithinTransaction(func(store refundpropagationcontract.Store) error {
+		// 锁定采购单后再汇总已折算金额，避免并发观测读到相同记录而重复退款
+		if err := store.LockProcurementOrder(order.ID); err != nil {
+			return err
+		}
+		created, err := s.record(store, order, connection, upstreamTotal)
+		propagation = created
+		return err
+	})
+	if err != nil || propagation == nil {
+		return nil, err
+	}
+	if propagation.Status == refundpropagationdomain.StatusApplying {
+		if err := s.apply(propagation); err != nil {
+			logger.Warnw("refund_propagation_auto_apply_failed",
+				"propagation_id", propagation.ID,
+				"local_order_id", propagation.LocalOrderID,
+				"error", err,
+			)
+		}
+	}
+	return propagation, nil
+}
+
+// record 在采购单锁内按上游累计退款折算本次本地退款金额并落库，已覆盖的累计金额返回 nil。
+func (s *Service) record(
+	store refundpropagationcontract.Store,
+	order *procurementdomain.Order,
+	connection *siteconnectiondomain.Connection,
+	upstreamTotal decimal.Decimal,
+) (*refundpropagationdomain.Propagation, error) {
+	mode := strings.TrimSpace(connection.RefundPropagationMode)
+	existing, err := store.ListByProcurementOrder(order.ID)
+	if err != nil {
+		return nil, err
+	}
+	covered, allocated := decimal.Zero, decimal.Zero
+	for _, item := range existing {
+		if item.UpstreamRefundedTotal.Decimal.GreaterThan(covered) {
+			covered = item.UpstreamRefundedTotal.Decimal
+		}
+		allocated = allocated.Add(item.LocalAmount.Decimal)
+	}
+	if upstreamTotal.LessThanOrEqual(covered) {
+		return nil, nil
+	}
+
+	localOrder, err := s.orders.GetByID(order.LocalOrderID)
+	if err != nil {
+		return nil, err
+	}
+	if localOrder == nil {
+		return nil, refundpropagationcontract.ErrOrderNotFound
+	}
+	// 按上游累计退款比例折算本地累计应退金额，扣除此前已折算部分，避免逐笔舍入误差累积
+	localAmount := proportionalAmount(localOrder.TotalAmount.Decimal, order.UpstreamAmount.Decimal, upstreamTotal).Sub(allocated)
+	refundable := localOrder.TotalAmount.Decimal.Sub(localOrder.RefundedAmount.Decimal).Round(2)
+	if localAmount.GreaterThan(refundable) {
+		localAmount = refundable
+	}
+	if localAmount.LessThan(decimal.Zero) {
+		localAmount = decimal.Zero
+	}
+	target := strings.TrimSpace(connection.RefundPropagationTarget)
+	if target != constants.RefundPropagationTargetOriginal && localOrder.UserID == 0 {
+		// 游客订单没有钱包，改为登记原路退款
+		target = constants.RefundPropagationTargetOriginal
+	}
+	if target != constants.RefundPropagationTargetOriginal {
+		target = constants.RefundPropagationTargetWallet
+	}
+
+	propagation := &refundpropagationdomain.Propagation{
+		ProcurementOrderID:    order.ID,
+		ConnectionID:          order.ConnectionID,
+		LocalOrderID:          localOrder.ID,
+		LocalOrderNo:          localOrder.OrderNo,
+		UpstreamOrderNo:       order.UpstreamOrderNo,
+		UpstreamAmount:        order.UpstreamAmount,
+		UpstreamRefundedTotal: money.FromDecimal(upstreamTotal),
+		UpstreamRefundDelta:   money.FromDecimal(upstreamTotal.Sub(covered)),
+		LocalAmount:           money.FromDecimal(localAmount),
+		Currency:              localOrder.Currency,
+		Mode:                  mode,
+		Target:                target,
+		Status:                refundpropagationdomain.StatusPendingReview,
+	}
+	switch {
+	case localAmount.LessThanOrEqual(decimal.Zero):
+		propagation.Status = refundpropagationdomain.StatusSkipped
+	case mode == constants.RefundPropagationAuto:
+		propagation.Status = refundpropagationdomain.StatusApplying
+	}
+	if err := store.Create(propagation); err != nil {
+		return nil, err
+	}
+	return propagation, nil
+}
+
+// List 后台查询退款联动记录。
+func (s *Service) List(filter refundpropagationcontract.ListFilter) ([]refundpropagationdomain.Propagation, int64, error) {
+	filter.Status = strings.TrimSpace(filter.Status)
+	filter.LocalOrderNo = strings.TrimSpace(filter.LocalOrderNo)
+	return s.store.List(filter)
+}
+
+// Approve 管理员确认待审核或执行失败的记录并立即执行本地退款。
+func (s *Service) Approve(id, adminID uint) (*refundpropagationdomain.Propagation, error) {
+	propagation, err := s.claim(id, refundpropagationdomain.StatusApplying)
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	propagation.ReviewedBy = adminID
+	propagation.ReviewedAt = &now
+	if err := s.apply(propagation); err != nil {
+		return propagation, fmt.Errorf("%w: %v", refundpropagationcontract.ErrApplyFailed, err)
+	}
+	return propagation, nil
+}
+
+// Reject 管理员驳回联动，本地订单不退款。
+func (s *Service) Reject(id, adminID uint, remark string) (*refundpropagationdomain.Propagation, error) {
+	propagation, err := s.claim(id, refundpropagationdomain.StatusRejected)
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	propagation.ReviewedBy = adminID
+	propagation.ReviewedAt = &now
+	propagation.Remark = strings.TrimSpace(remark)
+	if err := s.store.Update(propagation); err != nil {
+		return nil, err
+	}
+	return propagation, nil
+}
+
+func (s *Service) claim(id uint, to string) (*refundpropagationdomain.Propagation, error) {
+	propagation, err := s.store.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if propagation == nil {
+		return nil, refundpropagationcontract.ErrNotFound
+	}
+	claimed, err := s.store.Claim(id, refundpropagationdomain.ReviewableStatuses, to)
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		return nil, refundpropagationcontract.ErrStatusInvalid
+	}
+	propagation.Status = to
+	return propagation, nil
+}
+
+// apply 执行已认领记录的本地退款并落库结果，返回退款错误。
+func (s *Service) apply(propagation *refundpropagationdomain.Propagation) error {
+	remark := fmt.Sprintf("上游订单 %s 退款联动", propagation.UpstreamOrderNo)
+	var (
+		recordID  uint
+		refundErr error
+	)
+	if propagation.Target == constants.RefundPropagationTargetWallet {
+		recordID, refundErr = s.refunder.RefundToWallet(propagation.LocalOrderID, propagation.LocalAmount, remark)
+	} else {
+		recordID, refundErr = s.refunder.RefundOriginal(propagation.LocalOrderID, propagation.LocalAmount, remark)
+	}
+	if refundErr != nil {
+		propagation.Status = refundpropagationdomain.StatusFailed
+		propagation.ErrorMessage = refundErr.Error()
+	} else {
+		now := s.now()
+		propagation.Status = refundpropagationdomain.StatusApplied
+		propagation.RefundRecordID = recordID
+		propagation.AppliedAt = &now
+		propagation.ErrorMessage = ""
+	}
+	if err := s.store.Update(propagation); err != nil {
+		return err
+	}
+	return refundErr
+}
+
+func (s *Service) notify(propagation *refundpropagationdomain.Propagation) {
+	if s.notifier == nil {
+		return
+	}
+	if err := s.notifier.NotifyPropagation(propagation); err != nil {
+		logger.Warnw("refund_propagation_notify_failed", "propagation_id", propagation.ID, "error", err)
+	}
+}
+
+// proportionalAmount 按上游退款比例折算本地金额，上游金额缺失或退款超额时按全额计。
+func proportionalAmount(localTotal, upstreamAmount, upstreamRefunded decimal.Decimal) decimal.Decimal {
+	if upstreamAmount.LessThanOrEqual(decimal.Zero) || upstreamRefunded.GreaterThanOrEqual(upstreamAmount) {
+		return localTotal.Round(2)
+	}
+	return localTotal.Mul(upstreamRefunded).Div(upstreamAmount).Round(2)
+}
@@ -0,0 +1,10 @@
+package contract
+
+import "errors"
+
+var (
+	ErrNotFound      = errors.New("refund propagation not found")
+	ErrStatusInvalid = errors.New("refund propagation status invalid")
+	ErrApplyFailed   = errors.New("refund propagation apply failed")
+	ErrOrderNotFound = errors.New("local order not found")
+)
@@ -0,0 +1,43 @@
+package contract
+
+import (
+	orderdomain "github.com/dujiao-next/internal/modules/order/domain"
+	refundpropagationdomain "github.com/dujiao-next/internal/modules/refundpropagation/domain"
+	siteconnectiondomain "github.com/dujiao-next/internal/modules/siteconnection/domain"
+	"github.com/dujiao-next/internal/shared/money"
+)
+
+// Store 持久化上游退款联动记录。
+type Store interface {
+	WithinTransaction(fn func(Store) error) error
+	// LockProcurementOrder 在事务内锁定采购单行，串行化同一采购单的退款观测
+	LockProcurementOrder(procurementOrderID uint) error
+	Create(propagation *refundpropagationdomain.Propagation) error
+	Update(propagation *refundpropagationdomain.Propagation) error
+	GetByID(id uint) (*refundpropagationdomain.Propagation, error)
+	ListByProcurementOrder(procurementOrderID uint) ([]refundpropagationdomain.Propagation, error)
+	List(filter ListFilter) ([]refundpropagationdomain.Propagation, int64, error)
+	// Claim 仅当记录处于 from 中的状态时改为 to，返回是否认领成功，防止重复执行退款
+	Claim(id uint, from []string, to string) (bool, error)
+}
+
+// ConnectionReader 读取站点连接上的退款联动策略。
+type ConnectionReader interface {
+	GetByID(id uint) (*siteconnectiondomain.Connection, error)
+}
+
+// OrderReader 读取采购单关联的本地订单。
+type OrderReader interface {
+	GetByID(id uint) (*orderdomain.Order, error)
+}
+
+// Refunder 执行本地退款并返回退款记录 ID；原路退款仅登记退款记录，由管理员在支付渠道侧退回。
+type Refunder interface {
+	RefundToWallet(orderID uint, amount money.Amount, remark string) (uint, error)
+	RefundOriginal(orderID uint, amount money.Amount, remark string) (uint, error)
+}
+
+// Notifier 通知管理员上游退款联动的处理结果。
+type Notifier interface {
+	NotifyPropagation(propagation *refundpropagationdomain.Propagation) error
+}
@@ -0,0 +1,11 @@
+package contract
+
+// ListFilter 后台退款联动记录筛选条件。
+type ListFilter struct {
+	Status             string
+	ConnectionID       uint
+	ProcurementOrderID uint
+	LocalOrderNo       string
+	Page               int
+	PageSize           int
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/dujiao-next/internal/shared/money"
+)
+
+const (
+	// StatusPendingReview 审核模式下等待管理员确认
+	StatusPendingReview = "pending_review"
+	// StatusApplying 已认领，正在执行本地退款
+	StatusApplying = "applying"
+	StatusApplied  = "applied"
+	// StatusFailed 本地退款失败，可由管理员重新审核执行
+	StatusFailed   = "failed"
+	StatusRejected = "rejected"
+	// StatusSkipped 本地订单已无可退金额或按比例折算不足一分，仅记录上游退款进度
+	StatusSkipped = "skipped"
+)
+
+// ReviewableStatuses 允许管理员审核（执行或驳回）的状态。
+var ReviewableStatuses = []string{StatusPendingReview, StatusFailed}
+
+// Propagation 上游退款联动记录：采购单的上游累计退款金额每增长一次生成一条，
+// 本地退款金额按本地订单金额与上游订单金额的比例折算。
+type Propagation struct {
+	ID                    uint         `gorm:"primarykey" json:"id"`
+	ProcurementOrderID    uint         `gorm:"not null;uniqueIndex:idx_refund_propagation_step,priority:1" json:"procurement_order_id"`
+	ConnectionID          uint         `gorm:"not null;index" json:"connection_id"`
+	LocalOrderID          uint         `gorm:"not null;index" json:"local_order_id"`
+	LocalOrderNo          string       `gorm:"type:varchar(64);index" json:"local_order_no"`
+	UpstreamOrderNo       string       `gorm:"type:varchar(64)" json:"upstream_order_no"`
+	UpstreamAmount        money.Amount `gorm:"type:decimal(20,2);not null;default:0" json:"upstream_amount"`
+	UpstreamRefundedTotal money.Amount `gorm:"type:decimal(20,2);not null;default:0;uniqueIndex:idx_refund_propagation_step,priority:2" json:"upstream_refunded_total"`
+	UpstreamRefundDelta   money.Amount `gorm:"type:decimal(20,2);not null;default:0" json:"upstream_refund_delta"`
+	LocalAmount           money.Amount `gorm:"type:decimal(20,2);not null;default:0" json:"local_amount"`
+	Currency              string       `gorm:"type:varchar(10);not null;default:''" json:"currency"`
+	Mode                  string       `gorm:"type:varchar(20);not null" json:"mode"`
+	Target                string       `gorm:"type:varchar(20);not null" json:"target"`
+	Status                string       `gorm:"type:varchar(20);not null;index" json:"status"`
+	RefundRecordID        uint         `gorm:"not null;default:0" json:"refund_record_id,omitempty"`
+	ReviewedBy            uint         `gorm:"not null;default:0" json:"reviewed_by,omitempty"`
+	ReviewedAt            *time.Time   `json:"reviewed_at,omitempty"`
+	AppliedAt             *time.Time   `json:"applied_at,omitempty"`
+	ErrorMessage          string       `gorm:"type:text" json:"error_message,omitempty"`
+	Remark                string       `gorm:"type:varchar(255);not null;default:''" json:"remark,omitempty"`
+	CreatedAt             time.Time    `gorm:"index" json:"created_at"`
+	UpdatedAt             time.Time    `json:"updated_at"`
+}
+
+func (Propagation) TableName() string { return "upstream_refund_propagations" }
@@ -0,0 +1,111 @@
+package gormstore
+
+import (
+	"errors"
+	"time"
+
+	procurementdomain "github.com/dujiao-next/internal/modules/procurement/domain"
+	refundpropagationcontract "github.com/dujiao-next/internal/modules/refundpropagation/contract"
+	refundpropagationdomain "github.com/dujiao-next/internal/modules/refundpropagation/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Store 是上游退款联动记录的 GORM 仓储。
+type Store struct {
+	db *gorm.DB
+}
+
+var _ refundpropagationcontract.Store = (*Store)(nil)
+
+func New(db *gorm.DB) *Store {
+	if db == nil {
+		panic("refund propagation store: db is nil")
+	}
+	return &Store{db: db}
+}
+
+func (s *Store) WithinTransaction(fn func(refundpropagationcontract.Store) error) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		return fn(New(tx))
+	})
+}
+
+func (s *Store) LockProcurementOrder(procurementOrderID uint) error {
+	var locked procurementdomain.Order
+	return s.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		Where("id = ?", procurementOrderID).
+		Take(&locked).Error
+}
+
+func (s *Store) Create(propagation *refundpropagationdomain.Propagation) error {
+	return s.db.Create(propagation).Error
+}
+
+func (s *Store) Update(propagation *refundpropagationdomain.Propagation) error {
+	return s.db.Save(propagation).Error
+}
+
+func (s *Store) GetByID(id uint) (*refundpropagationdomain.Propagation, error) {
+	var item refundpropagationdomain.Propagation
+	if err := s.db.First(&item, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (s *Store) ListByProcurementOrder(procurementOrderID uint) ([]refundpropagationdomain.Propagation, error) {
+	var items []refundpropagationdomain.Propagation
+	if err := s.db.Where("procurement_order_id = ?", procurementOrderID).
+		Order("id ASC").Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (s *Store) List(filter refundpropagationcontract.ListFilter) ([]refundpropagationdomain.Propagation, int64, error) {
+	query := s.db.Model(&refundpropagationdomain.Propagation{})
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.ConnectionID > 0 {
+		query = query.Where("connection_id = ?", filter.ConnectionID)
+	}
+	if filter.ProcurementOrderID > 0 {
+		query = query.Where("procurement_order_id = ?", filter.ProcurementOrderID)
+	}
+	if filter.LocalOrderNo != "" {
+		query = query.Where("local_order_no = ?", filter.LocalOrderNo)
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if filter.PageSize > 0 {
+		page := filter.Page
+		if page < 1 {
+			page = 1
+		}
+		query = query.Offset((page - 1) * filter.PageSize).Limit(filter.PageSize)
+	}
+	var items []refundpropagationdomain.Propagation
+	if err := query.Order("id DESC").Find(&items).Error; err != nil {
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
+func (s *Store) Claim(id uint, from []string, to string) (bool, error) {
+	result := s.db.Model(&refundpropagationdomain.Propagation{}).
+		Where("id = ? AND status IN ?", id, from).
+		Updates(map[string]interface{}{"status": to, "updated_at": time.Now()})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
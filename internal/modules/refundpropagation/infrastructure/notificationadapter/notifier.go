@@ -0,0 +1,51 @@
+package notificationadapter
+
+import (
+	"fmt"
+
+	"github.com/dujiao-next/internal/constants"
+	"github.com/dujiao-next/internal/modules/notification/contract"
+	refundpropagationcontract "github.com/dujiao-next/internal/modules/refundpropagation/contract"
+	refundpropagationdomain "github.com/dujiao-next/internal/modules/refundpropagation/domain"
+)
+
+type Notifier struct {
+	enqueuer contract.NotificationEnqueuer
+}
+
+var _ refundpropagationcontract.Notifier = (*Notifier)(nil)
+
+func New(enqueuer contract.NotificationEnqueuer) refundpropagationcontract.Notifier {
+	if enqueuer == nil {
+		return nil
+	}
+	return &Notifier{enqueuer: enqueuer}
+}
+
+func (n *Notifier) NotifyPropagation(propagation *refundpropagationdomain.Propagation) error {
+	return n.enqueuer.Enqueue(contract.EnqueueInput{
+		EventType: constants.NotificationEventExceptionAlert,
+		BizType:   constants.NotificationBizTypeRefundPropagation,
+		BizID:     propagation.ID,
+		Data: map[string]any{
+			"message":        fmt.Sprintf("本地订单 %s 的上游订单 %s 发生退款，%s", propagation.LocalOrderNo, propagation.UpstreamOrderNo, statusSummary(propagation)),
+			"propagation_id": propagation.ID, "procurement_order_id": propagation.ProcurementOrderID,
+			"local_order_no": propagation.LocalOrderNo, "upstream_order_no": propagation.UpstreamOrderNo,
+			"upstream_refund_delta": propagation.UpstreamRefundDelta.String(), "local_amount": propagation.LocalAmount.String(),
+			"currency": propagation.Currency, "status": propagation.Status,
+		},
+	})
+}
+
+func statusSummary(propagation *refundpropagationdomain.Propagation) string {
+	switch propagation.Status {
+	case refundpropagationdomain.StatusApplied:
+		return fmt.Sprintf("已自动退款 %s %s", propagation.LocalAmount.String(), propagation.Currency)
+	case refundpropagationdomain.StatusFailed:
+		return fmt.Sprintf("自动退款失败：%s", propagation.ErrorMessage)
+	case refundpropagationdomain.StatusSkipped:
+		return "本地订单无可退金额，仅记录上游退款"
+	default:
+		return fmt.Sprintf("待审核退款 %s %s", propagation.LocalAmount.String(), propagation.Currency)
+	}
+}
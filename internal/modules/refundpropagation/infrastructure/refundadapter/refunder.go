@@ -0,0 +1,83 @@
+package refundadapter
+
+import (
+	"strings"
+
+	"github.com/dujiao-next/internal/logger"
+	orderrefund "github.com/dujiao-next/internal/modules/order/application/refund"
+	orderdomain "github.com/dujiao-next/internal/modules/order/domain"
+	refundpropagationcontract "github.com/dujiao-next/internal/modules/refundpropagation/contract"
+	"github.com/dujiao-next/internal/queue"
+	"github.com/dujiao-next/internal/shared/money"
+)
+
+// Refunder 将上游退款联动委托给订单退款流程（佣金与分销账同步冲正），并补发退款状态邮件。
+type Refunder struct {
+	refunds *orderrefund.Service
+	queue   *queue.Client
+}
+
+var _ refundpropagationcontract.Refunder = (*Refunder)(nil)
+
+func New(refunds *orderrefund.Service, queueClient *queue.Client) *Refunder {
+	if refunds == nil {
+		panic("refund propagation refunder: refund service is nil")
+	}
+	return &Refunder{refunds: refunds, queue: queueClient}
+}
+
+func (r *Refunder) RefundToWallet(orderID uint, amount money.Amount, remark string) (uint, error) {
+	order, _, record, err := r.refunds.AdminRefundToWallet(orderrefund.AdminRefundToWalletInput{
+		OrderID:            orderID,
+		Amount:             amount,
+		Remark:             remark,
+		IgnoreRefundWindow: true,
+	})
+	if err != nil {
+		return 0, err
+	}
+	r.enqueueStatusEmail(order, record)
+	return recordID(record), nil
+}
+
+func (r *Refunder) RefundOriginal(orderID uint, amount money.Amount, remark string) (uint, error) {
+	order, record, err := r.refunds.AdminManualRefund(orderrefund.AdminManualRefundInput{
+		OrderID:            orderID,
+		Amount:             amount,
+		Remark:             remark,
+		IgnoreRefundWindow: true,
+	})
+	if err != nil {
+		return 0, err
+	}
+	r.enqueueStatusEmail(order, record)
+	return recordID(record), nil
+}
+
+func recordID(record *orderdomain.OrderRefundRecord) uint {
+	if record == nil {
+		return 0
+	}
+	return record.ID
+}
+
+func (r *Refunder) enqueueStatusEmail(order *orderdomain.Order, record *orderdomain.OrderRefundRecord) {
+	if r.queue == nil || order == nil || order.ID == 0 {
+		return
+	}
+	status := strings.TrimSpace(order.Status)
+	if status == "" {
+		return
+	}
+	if err := r.queue.EnqueueOrderStatusEmail(queue.OrderStatusEmailPayload{
+		OrderID:        order.ID,
+		Status:         status,
+		RefundRecordID: recordID(record),
+	}); err != nil {
+		logger.Warnw("refund_propagation_enqueue_status_email_failed",
+			"order_id", order.ID,
+			"status", status,
+			"error", err,
+		)
+	}
+}
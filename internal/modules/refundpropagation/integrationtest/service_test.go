@@ -0,0 +1,243 @@
+package integrationtest
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dujiao-next/internal/constants"
+	orderdomain "github.com/dujiao-next/internal/modules/order/domain"
+	procurementdomain "github.com/dujiao-next/internal/modules/procurement/domain"
+	refundpropagationapp "github.com/dujiao-next/internal/modules/refundpropagation/application"
+	refundpropagationcontract "github.com/dujiao-next/internal/modules/refundpropagation/contract"
+	refundpropagationdomain "github.com/dujiao-next/internal/modules/refundpropagation/domain"
+	refundpropagationgormstore "github.com/dujiao-next/internal/modules/refundpropagation/infrastructure/gormstore"
+	siteconnectiondomain "github.com/dujiao-next/internal/modules/siteconnection/domain"
+	"github.com/dujiao-next/internal/shared/money"
+
+	"github.com/glebarez/sqlite"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+type connectionStub struct {
+	connection *siteconnectiondomain.Connection
+}
+
+func (c *connectionStub) GetByID(uint) (*siteconnectiondomain.Connection, error) {
+	return c.connection, nil
+}
+
+type orderStub struct {
+	order *orderdomain.Order
+}
+
+func (o *orderStub) GetByID(uint) (*orderdomain.Order, error) {
+	copied := *o.order
+	return &copied, nil
+}
+
+type refundCall struct {
+	target  string
+	orderID uint
+	amount  string
+}
+
+// refunderStub 模拟订单退款流程：累加本地订单已退金额，超出可退金额时报错。
+type refunderStub struct {
+	order *orderdomain.Order
+	calls []refundCall
+	err   error
+}
+
+func (r *refunderStub) refund(target string, orderID uint, amount money.Amount) (uint, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	refunded := r.order.RefundedAmount.Decimal.Add(amount.Decimal)
+	if refunded.GreaterThan(r.order.TotalAmount.Decimal) {
+		return 0, errors.New("refund exceeded")
+	}
+	r.order.RefundedAmount = money.FromDecimal(refunded)
+	r.calls = append(r.calls, refundCall{target: target, orderID: orderID, amount: amount.String()})
+	return uint(len(r.calls)), nil
+}
+
+func (r *refunderStub) RefundToWallet(orderID uint, amount money.Amount, _ string) (uint, error) {
+	return r.refund(constants.RefundPropagationTargetWallet, orderID, amount)
+}
+
+func (r *refunderStub) RefundOriginal(orderID uint, amount money.Amount, _ string) (uint, error) {
+	return r.refund(constants.RefundPropagationTargetOriginal, orderID, amount)
+}
+
+type notifierStub struct {
+	statuses []string
+}
+
+func (n *notifierStub) NotifyPropagation(propagation *refundpropagationdomain.Propagation) error {
+	n.statuses = append(n.statuses, propagation.Status)
+	return nil
+}
+
+type fixture struct {
+	connection *siteconnectiondomain.Connection
+	order      *orderdomain.Order
+	refunder   *refunderStub
+	notifier   *notifierStub
+	service    *refundpropagationapp.Service
+}
+
+func newFixture(t *testing.T, mode, target string, userID uint) *fixture {
+	t.Helper()
+	dsn := fmt.Sprintf("file:refund_propagation_%d?mode=memory&cache=shared", time.Now().UnixNano())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite failed: %v", err)
+	}
+	if err := db.AutoMigrate(&refundpropagationdomain.Propagation{}, &procurementdomain.Order{}); err != nil {
+		t.Fatalf("migrate failed: %v", err)
+	}
+	// 观测时会锁定采购单行，需要采购单真实存在
+	if err := db.Create(procurementOrder("")).Error; err != nil {
+		t.Fatalf("create procurement order failed: %v", err)
+	}
+	f := &fixture{
+		connection: &siteconnectiondomain.Connection{ID: 3, RefundPropagationMode: mode, RefundPropagationTarget: target},
+		order: &orderdomain.Order{
+			ID: 11, OrderNo: "LOCAL-11", UserID: userID, Currency: "CNY",
+			TotalAmount: money.FromDecimal(decimal.NewFromInt(150)),
+		},
+		notifier: &notifierStub{},
+	}
+	f.refunder = &refunderStub{order: f.order}
+	f.service = refundpropagationapp.NewService(refundpropagationapp.Options{
+		Store:       refundpropagationgormstore.New(db),
+		Connections: &connectionStub{connection: f.connection},
+		Orders:      &orderStub{order: f.order},
+		Refunder:    f.refunder,
+		Notifier:    f.notifier,
+	})
+	return f
+}
+
+func procurementOrder(refunded string) *procurementdomain.Order {
+	return &procurementdomain.Order{
+		ID: 7, ConnectionID: 3, LocalOrderID: 11, UpstreamOrderNo: "UP-7",
+		UpstreamAmount:         money.FromDecimal(decimal.NewFromInt(100)),
+		UpstreamRefundedAmount: refunded,
+	}
+}
+
+func (f *fixture) list(t *testing.T) []refundpropagationdomain.Propagation {
+	t.Helper()
+	items, _, err := f.service.List(refundpropagationcontract.ListFilter{})
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	return items
+}
+
+func TestAutoModeRefundsProportionallyAndOnlyOncePerStep(t *testing.T) {
+	f := newFixture(t, constants.RefundPropagationAuto, constants.RefundPropagationTargetWallet, 5)
+
+	f.service.ObserveUpstreamRefund(procurementOrder("40.00"))
+	f.service.ObserveUpstreamRefund(procurementOrder("40.00"))
+	if len(f.refunder.calls) != 1 || f.refunder.calls[0].amount != "60.00" || f.refunder.calls[0].target != constants.RefundPropagationTargetWallet {
+		t.Fatalf("expected one wallet refund of 60.00, got %+v", f.refunder.calls)
+	}
+
+	// 上游补退至全额，本地按累计比例补齐剩余金额
+	f.service.ObserveUpstreamRefund(procurementOrder("100"))
+	if len(f.refunder.calls) != 2 || f.refunder.calls[1].amount != "90.00" {
+		t.Fatalf("expected remaining refund of 90.00, got %+v", f.refunder.calls)
+	}
+	items := f.list(t)
+	if len(items) != 2 || items[0].Status != refundpropagationdomain.StatusApplied || items[0].UpstreamRefundDelta.String() != "60.00" {
+		t.Fatalf("unexpected propagations: %+v", items)
+	}
+	if len(f.notifier.statuses) != 2 {
+		t.Fatalf("expected two admin notifications, got %v", f.notifier.statuses)
+	}
+}
+
+func TestReviewModeWaitsForApproval(t *testing.T) {
+	f := newFixture(t, constants.RefundPropagationReview, constants.RefundPropagationTargetWallet, 5)
+
+	f.service.ObserveUpstreamRefund(procurementOrder("50"))
+	items := f.list(t)
+	if len(items) != 1 || items[0].Status != refundpropagationdomain.StatusPendingReview || len(f.refunder.calls) != 0 {
+		t.Fatalf("expected pending review without refund, got %+v calls=%+v", items, f.refunder.calls)
+	}
+
+	approved, err := f.service.Approve(items[0].ID, 9)
+	if err != nil {
+		t.Fatalf("approve: %v", err)
+	}
+	if approved.Status != refundpropagationdomain.StatusApplied || approved.ReviewedBy != 9 || approved.RefundRecordID == 0 {
+		t.Fatalf("unexpected approved propagation: %+v", approved)
+	}
+	if len(f.refunder.calls) != 1 || f.refunder.calls[0].amount != "75.00" {
+		t.Fatalf("expected refund of 75.00, got %+v", f.refunder.calls)
+	}
+	if _, err := f.service.Approve(items[0].ID, 9); !errors.Is(err, refundpropagationcontract.ErrStatusInvalid) {
+		t.Fatalf("second approve should be rejected, got %v", err)
+	}
+}
+
+func TestRejectKeepsLocalOrderAndLaterStepsExcludeRejectedAmount(t *testing.T) {
+	f := newFixture(t, constants.RefundPropagationReview, constants.RefundPropagationTargetWallet, 5)
+
+	f.service.ObserveUpstreamRefund(procurementOrder("20"))
+	first := f.list(t)[0]
+	rejected, err := f.service.Reject(first.ID, 9, "上游补发，无需退款")
+	if err != nil {
+		t.Fatalf("reject: %v", err)
+	}
+	if rejected.Status != refundpropagationdomain.StatusRejected || rejected.Remark == "" {
+		t.Fatalf("unexpected rejected propagation: %+v", rejected)
+	}
+
+	f.service.ObserveUpstreamRefund(procurementOrder("60"))
+	items := f.list(t)
+	if len(items) != 2 || items[0].LocalAmount.String() != "60.00" {
+		t.Fatalf("second step should only cover the new upstream delta, got %+v", items)
+	}
+	if len(f.refunder.calls) != 0 {
+		t.Fatalf("review mode should not refund before approval, got %+v", f.refunder.calls)
+	}
+}
+
+func TestGuestOrderFallsBackToOriginalRouteAndFailureCanBeRetried(t *testing.T) {
+	f := newFixture(t, constants.RefundPropagationAuto, constants.RefundPropagationTargetWallet, 0)
+	f.refunder.err = errors.New("payment channel unavailable")
+
+	f.service.ObserveUpstreamRefund(procurementOrder("100"))
+	items := f.list(t)
+	if len(items) != 1 || items[0].Status != refundpropagationdomain.StatusFailed ||
+		items[0].Target != constants.RefundPropagationTargetOriginal || items[0].ErrorMessage == "" {
+		t.Fatalf("expected failed original-route propagation, got %+v", items)
+	}
+
+	f.refunder.err = nil
+	approved, err := f.service.Approve(items[0].ID, 1)
+	if err != nil {
+		t.Fatalf("retry approve: %v", err)
+	}
+	if approved.Status != refundpropagationdomain.StatusApplied || approved.ErrorMessage != "" {
+		t.Fatalf("unexpected retried propagation: %+v", approved)
+	}
+	if len(f.refunder.calls) != 1 || f.refunder.calls[0].target != constants.RefundPropagationTargetOriginal || f.refunder.calls[0].amount != "150.00" {
+		t.Fatalf("expected original-route refund of 150.00, got %+v", f.refunder.calls)
+	}
+}
+
+func TestOffModeIgnoresUpstreamRefund(t *testing.T) {
+	f := newFixture(t, constants.RefundPropagationOff, constants.RefundPropagationTargetWallet, 5)
+
+	f.service.ObserveUpstreamRefund(procurementOrder("100"))
+	if items := f.list(t); len(items) != 0 || len(f.notifier.statuses) != 0 {
+		t.Fatalf("off mode should not record propagations, got %+v", items)
+	}
+}
@@ -0,0 +1,123 @@
+package refundpropagationhttp
+
+import (
+	"errors"
+	"strings"
+
+	refundpropagationcontract "github.com/dujiao-next/internal/modules/refundpropagation/contract"
+	refundpropagationdomain "github.com/dujiao-next/internal/modules/refundpropagation/domain"
+	"github.com/dujiao-next/internal/platform/http/ginutil"
+	"github.com/dujiao-next/internal/platform/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminService 是后台上游退款联动查询与审核所需的最小用例接口。
+type AdminService interface {
+	List(filter refundpropagationcontract.ListFilter) ([]refundpropagationdomain.Propagation, int64, error)
+	Approve(id, adminID uint) (*refundpropagationdomain.Propagation, error)
+	Reject(id, adminID uint, remark string) (*refundpropagationdomain.Propagation, error)
+}
+
+// AdminHandler 处理后台上游退款联动请求。
+type AdminHandler struct {
+	service AdminService
+}
+
+func NewAdminHandler(service AdminService) *AdminHandler {
+	if service == nil {
+		panic("refund propagation admin handler: required dependency is nil")
+	}
+	return &AdminHandler{service: service}
+}
+
+// RejectRequest 驳回退款联动请求
+type RejectRequest struct {
+	Remark string `json:"remark" binding:"max=255"`
+}
+
+// List 获取上游退款联动记录（支持 status、connection_id、procurement_order_id、local_order_no 筛选）
+func (h *AdminHandler) List(c *gin.Context) {
+	connectionID, err := ginutil.ParseQueryUint(c.Query("connection_id"), false)
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", nil)
+		return
+	}
+	procurementOrderID, err := ginutil.ParseQueryUint(c.Query("procurement_order_id"), false)
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", nil)
+		return
+	}
+	page, pageSize := ginutil.ParsePagination(c)
+	items, total, err := h.service.List(refundpropagationcontract.ListFilter{
+		Status:             strings.TrimSpace(c.Query("status")),
+		ConnectionID:       connectionID,
+		ProcurementOrderID: procurementOrderID,
+		LocalOrderNo:       strings.TrimSpace(c.Query("local_order_no")),
+		Page:               page,
+		PageSize:           pageSize,
+	})
+	if err != nil {
+		ginutil.RespondError(c, response.CodeInternal, "error.refund_propagation_fetch_failed", err)
+		return
+	}
+	response.SuccessWithPage(c, items, response.BuildPagination(page, pageSize, total))
+}
+
+// Approve 确认联动并立即执行本地退款
+func (h *AdminHandler) Approve(c *gin.Context) {
+	id, err := ginutil.ParseParamUint(c, "id")
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", nil)
+		return
+	}
+	adminID, ok := ginutil.GetAdminID(c)
+	if !ok {
+		ginutil.RespondError(c, response.CodeUnauthorized, "error.unauthorized", nil)
+		return
+	}
+	propagation, err := h.service.Approve(id, adminID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	response.Success(c, propagation)
+}
+
+// Reject 驳回联动，本地订单保持不退款
+func (h *AdminHandler) Reject(c *gin.Context) {
+	id, err := ginutil.ParseParamUint(c, "id")
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", nil)
+		return
+	}
+	adminID, ok := ginutil.GetAdminID(c)
+	if !ok {
+		ginutil.RespondError(c, response.CodeUnauthorized, "error.unauthorized", nil)
+		return
+	}
+	var req RejectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ginutil.RespondBindError(c, err)
+		return
+	}
+	propagation, err := h.service.Reject(id, adminID, req.Remark)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	response.Success(c, propagation)
+}
+
+func respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, refundpropagationcontract.ErrNotFound):
+		ginutil.RespondError(c, response.CodeNotFound, "error.refund_propagation_not_found", nil)
+	case errors.Is(err, refundpropagationcontract.ErrStatusInvalid):
+		ginutil.RespondError(c, response.CodeBadRequest, "error.refund_propagation_status_invalid", nil)
+	case errors.Is(err, refundpropagationcontract.ErrApplyFailed):
+		ginutil.RespondError(c, response.CodeBadRequest, "error.refund_propagation_apply_failed", err)
+	default:
+		ginutil.RespondError(c, response.CodeInternal, "error.refund_propagation_update_failed", err)
+	}
+}
@@ -0,0 +1,13 @@
+package refundpropagationhttp
+
+import "github.com/gin-gonic/gin"
+
+// RegisterAdminRoutes 注册后台上游退款联动查询与审核路由。
+func RegisterAdminRoutes(authorized gin.IRoutes, handler *AdminHandler) {
+	if authorized == nil || handler == nil {
+		panic("refund propagation admin routes: required dependency is nil")
+	}
+	authorized.GET("/refund-propagations", handler.List)
+	authorized.POST("/refund-propagations/:id/approve", handler.Approve)
+	authorized.POST("/refund-propagations/:id/reject", handler.Reject)
+}
@@ -152,12 +152,15 @@ func (s *AccountingWithdrawService) ApplyWithdraw(resellerID uint, input reselle
 	return s.store.GetWithdrawRequestByID(createdID)
 }
 
+// ReviewWithdraw 审核分销提现：审核通过后可单笔标记打款，或加入打款批次出款。
 func (s *AccountingWithdrawService) ReviewWithdraw(adminID uint, withdrawID uint, action string, rejectReason string) (*resellerdomain.WithdrawRequest, error) {
 	if s == nil || s.store == nil || withdrawID == 0 {
 		return nil, productcontract.ErrNotFound
 	}
 	act := strings.ToLower(strings.TrimSpace(action))
-	if act != resellercontract.WithdrawActionReject && act != resellercontract.WithdrawActionPay {
+	if act != resellercontract.WithdrawActionApprove &&
+		act != resellercontract.WithdrawActionReject &&
+		act != resellercontract.WithdrawActionPay {
 		return nil, resellercontract.ErrWithdrawStatusInvalid
 	}
 	err := s.store.WithinWithdrawTransaction(func(store resellercontract.AccountingWithdrawStore) error {
@@ -168,38 +171,144 @@ func (s *AccountingWithdrawService) ReviewWithdraw(adminID uint, withdrawID uint
 		if req == nil {
 			return productcontract.ErrNotFound
 		}
-		if req.Status != resellerdomain.WithdrawStatusPending {
+		if !withdrawReviewable(req, act) {
 			return resellercontract.ErrWithdrawStatusInvalid
 		}
 		now := time.Now()
-		req.ProcessedBy = &adminID
-		req.ProcessedAt = &now
-		req.UpdatedAt = now
-		if act == resellercontract.WithdrawActionReject {
+		switch act {
+		case resellercontract.WithdrawActionApprove:
+			req.Status = resellerdomain.WithdrawStatusApproved
+			req.ProcessedBy = &adminID
+			req.ProcessedAt = &now
+			req.UpdatedAt = now
+			return store.UpdateWithdrawRequest(req)
+		case resellercontract.WithdrawActionReject:
 			req.Status = resellerdomain.WithdrawStatusRejected
 			req.RejectReason = strings.TrimSpace(rejectReason)
+			req.ProcessedBy = &adminID
+			req.ProcessedAt = &now
+			req.UpdatedAt = now
 			if err := store.BatchUpdateLedgerEntriesByWithdrawID(withdrawID, map[string]interface{}{
 				"status":              resellerdomain.LedgerStatusAvailable,
 				"withdraw_request_id": nil,
 			}); err != nil {
 				return err
 			}
-		} else {
-			req.Status = resellerdomain.WithdrawStatusPaid
-			req.RejectReason = ""
-			if err := store.BatchUpdateLedgerEntriesByWithdrawID(withdrawID, map[string]interface{}{
-				"status": resellerdomain.LedgerStatusWithdrawn,
-			}); err != nil {
+			if err := store.UpdateWithdrawRequest(req); err != nil {
 				return err
 			}
+			return RefreshBalanceAccount(store, req.ResellerID, req.Currency, now)
+		default:
+			return markWithdrawPaid(store, req, adminID, "", now)
 		}
-		if err := store.UpdateWithdrawRequest(req); err != nil {
-			return err
-		}
-		return RefreshBalanceAccount(store, req.ResellerID, req.Currency, now)
 	})
 	if err != nil {
 		return nil, err
 	}
 	return s.store.GetWithdrawRequestByID(withdrawID)
 }
+
+// PayoutWithdraw 打款批次锁定的分销提现申请及收款用户。
+type PayoutWithdraw struct {
+	Request resellerdomain.WithdrawRequest
+	UserID  uint
+}
+
+// LockApprovedWithdrawsForPayout 在调用方事务内锁定已审核且未加入打款批次的提现申请。
+func (s *AccountingWithdrawService) LockApprovedWithdrawsForPayout(store resellercontract.AccountingWithdrawStore, ids []uint) ([]PayoutWithdraw, error) {
+	if store == nil {
+		return nil, resellercontract.ErrAccountingUnavailable
+	}
+	rows := make([]PayoutWithdraw, 0, len(ids))
+	for _, id := range ids {
+		req, err := store.GetWithdrawRequestByIDForUpdate(id)
+		if err != nil {
+			return nil, err
+		}
+		if req == nil {
+			return nil, productcontract.ErrNotFound
+		}
+		if req.Status != resellerdomain.WithdrawStatusApproved || req.PayoutBatchID != nil {
+			return nil, resellercontract.ErrWithdrawStatusInvalid
+		}
+		profile, err := store.GetProfileByID(req.ResellerID)
+		if err != nil {
+			return nil, err
+		}
+		if profile == nil {
+			return nil, productcontract.ErrNotFound
+		}
+		rows = append(rows, PayoutWithdraw{Request: *req, UserID: profile.UserID})
+	}
+	return rows, nil
+}
+
+// AssignWithdrawPayoutBatch 在调用方事务内绑定打款批次；batchID 为 0 时解除绑定。
+func (s *AccountingWithdrawService) AssignWithdrawPayoutBatch(store resellercontract.AccountingWithdrawStore, withdrawID, batchID uint) error {
+	if store == nil {
+		return resellercontract.ErrAccountingUnavailable
+	}
+	req, err := store.GetWithdrawRequestByIDForUpdate(withdrawID)
+	if err != nil {
+		return err
+	}
+	if req == nil {
+		return productcontract.ErrNotFound
+	}
+	if req.Status != resellerdomain.WithdrawStatusApproved {
+		return resellercontract.ErrWithdrawStatusInvalid
+	}
+	req.PayoutBatchID = nil
+	if batchID != 0 {
+		req.PayoutBatchID = &batchID
+	}
+	req.UpdatedAt = time.Now()
+	return store.UpdateWithdrawRequest(req)
+}
+
+// SettleWithdrawPayout 在调用方事务内按打款批次结清提现申请，锁定流水转为已提现。
+func (s *AccountingWithdrawService) SettleWithdrawPayout(store resellercontract.AccountingWithdrawStore, adminID, withdrawID, batchID uint, reference string) error {
+	if store == nil {
+		return resellercontract.ErrAccountingUnavailable
+	}
+	req, err := store.GetWithdrawRequestByIDForUpdate(withdrawID)
+	if err != nil {
+		return err
+	}
+	if req == nil {
+		return productcontract.ErrNotFound
+	}
+	if req.Status != resellerdomain.WithdrawStatusApproved || req.PayoutBatchID == nil || *req.PayoutBatchID != batchID {
+		return resellercontract.ErrWithdrawStatusInvalid
+	}
+	return markWithdrawPaid(store, req, adminID, strings.TrimSpace(reference), time.Now())
+}
+
+// withdrawReviewable 已加入打款批次的申请只能随批次结清或在批次取消后处理。
+func withdrawReviewable(req *resellerdomain.WithdrawRequest, act string) bool {
+	if act == resellercontract.WithdrawActionApprove {
+		return req.Status == resellerdomain.WithdrawStatusPending
+	}
+	if req.PayoutBatchID != nil {
+		return false
+	}
+	return req.Status == resellerdomain.WithdrawStatusPending || req.Status == resellerdomain.WithdrawStatusApproved
+}
+
+func markWithdrawPaid(store resellercontract.AccountingWithdrawStore, req *resellerdomain.WithdrawRequest, adminID uint, reference string, now time.Time) error {
+	if err := store.BatchUpdateLedgerEntriesByWithdrawID(req.ID, map[string]interface{}{
+		"status": resellerdomain.LedgerStatusWithdrawn,
+	}); err != nil {
+		return err
+	}
+	req.Status = resellerdomain.WithdrawStatusPaid
+	req.RejectReason = ""
+	req.PayoutReference = reference
+	req.ProcessedBy = &adminID
+	req.ProcessedAt = &now
+	req.UpdatedAt = now
+	if err := store.UpdateWithdrawRequest(req); err != nil {
+		return err
+	}
+	return RefreshBalanceAccount(store, req.ResellerID, req.Currency, now)
+}
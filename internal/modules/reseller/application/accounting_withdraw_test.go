@@ -39,6 +39,9 @@ type accountingWithdrawStoreStub struct{}
 func (accountingWithdrawStoreStub) WithinWithdrawTransaction(fn func(store resellercontract.AccountingWithdrawStore) error) error {
 	return fn(accountingWithdrawStoreStub{})
 }
+func (accountingWithdrawStoreStub) GetProfileByID(id uint) (*resellerdomain.Profile, error) {
+	return nil, nil
+}
 func (accountingWithdrawStoreStub) GetProfileByUserID(userID uint) (*resellerdomain.Profile, error) {
 	return nil, nil
 }
@@ -2,6 +2,10 @@ package application
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"net/http"
 	"strings"
 	"time"
 
@@ -18,8 +22,9 @@ import (
 )
 
 type ManagementService struct {
-	store resellercontract.ManagementStore
-	cfg   config.ResellerConfig
+	store    resellercontract.ManagementStore
+	cfg      config.ResellerConfig
+	verifier resellercontract.DomainVerifier
 }
 
 type ResellerApplyInput struct {
@@ -334,11 +339,16 @@ func (s *ManagementService) SubmitUserCustomDomain(userID uint, rawDomain string
 	if err != nil {
 		return nil, err
 	}
-	// 暂不启用 DNS 自动校验，自定义域名走后台人工审核。
+	// 签发校验 token：分销商配置 DNS TXT 或 HTTP 挑战后可自动校验，也可由后台人工审核。
+	token, err := generateDomainVerificationToken()
+	if err != nil {
+		return nil, err
+	}
 	row, err := s.store.UpsertDomain(resellerdomain.Domain{
 		ResellerID:         profile.ID,
 		Domain:             domain,
 		Type:               resellerdomain.DomainTypeCustom,
+		VerificationToken:  token,
 		VerificationStatus: resellerdomain.DomainVerificationPending,
 		Status:             resellerdomain.DomainStatusPendingReview,
 		IsPrimary:          false,
@@ -490,36 +500,30 @@ func (s *ManagementService) updateDomainStatus(ctx context.Context, adminID, dom
 		}
 		switch targetStatus {
 		case resellerdomain.DomainStatusActive:
-			if domain.Status != resellerdomain.DomainStatusPendingReview && domain.Status != resellerdomain.DomainStatusDisabled {
+			if domain.Status != resellerdomain.DomainStatusPendingReview && domain.Status != resellerdomain.DomainStatusDisabled &&
+				domain.Status != resellerdomain.DomainStatusSuspended {
 				return resellercontract.ErrDomainStatusInvalid
 			}
 			now := time.Now()
 			domain.Status = resellerdomain.DomainStatusActive
 			domain.VerificationStatus = resellerdomain.DomainVerificationVerified
 			domain.VerifiedAt = &now
+			domain.CheckFailureCount = 0
+			domain.LastCheckError = ""
 			if !hasActiveVerifiedPrimary(domains, domain.ID) {
 				domain.IsPrimary = true
 			}
 		case resellerdomain.DomainStatusDisabled:
-			if domain.Status != resellerdomain.DomainStatusPendingReview && domain.Status != resellerdomain.DomainStatusActive {
+			if domain.Status != resellerdomain.DomainStatusPendingReview && domain.Status != resellerdomain.DomainStatusActive &&
+				domain.Status != resellerdomain.DomainStatusSuspended {
 				return resellercontract.ErrDomainStatusInvalid
 			}
 			domain.Status = resellerdomain.DomainStatusDisabled
 			wasPrimary := domain.IsPrimary
 			domain.IsPrimary = false
 			if wasPrimary {
-				for i := range domains {
-					candidate := domains[i]
-					if candidate.ID == domain.ID || candidate.Status != resellerdomain.DomainStatusActive || candidate.VerificationStatus != resellerdomain.DomainVerificationVerified {
-						continue
-					}
-					if !candidate.IsPrimary {
-						candidate.IsPrimary = true
-						if err := repoTx.UpdateDomain(&candidate); err != nil {
-							return err
-						}
-					}
-					break
+				if err := promoteFallbackPrimary(repoTx, domains, domain.ID); err != nil {
+					return err
 				}
 			}
 		default:
@@ -642,3 +646,299 @@ func isValidResellerSubdomainLabel(label string) bool {
 	}
 	return true
 }
+
+const (
+	defaultDomainRecheckInterval = 24 * time.Hour
+	defaultDomainSuspendFailures = 3
+	domainRecheckBatchSize       = 100
+	domainAttemptListLimit       = 20
+	domainCheckDetailMaxLength   = 255
+)
+
+// DomainRecheckResult 周期复查结果。
+type DomainRecheckResult struct {
+	Checked   int `json:"checked"`
+	Verified  int `json:"verified"`
+	Failed    int `json:"failed"`
+	Suspended int `json:"suspended"`
+}
+
+// SetDomainVerifier 注入自定义域名校验器；未注入时自动校验不可用。
+func (s *ManagementService) SetDomainVerifier(verifier resellercontract.DomainVerifier) {
+	if s == nil {
+		return
+	}
+	s.verifier = verifier
+}
+
+// VerifyUserDomain 分销商在控制台手动触发自定义域名校验。
+func (s *ManagementService) VerifyUserDomain(ctx context.Context, userID, domainID uint) (*resellerdomain.Domain, []resellerdomain.DomainVerificationAttempt, error) {
+	domain, err := s.getUserDomain(userID, domainID, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.verifyDomain(ctx, domain, resellerdomain.DomainVerifyTriggerUser, time.Now())
+}
+
+// ListUserDomainVerificationAttempts 分销商查看自己域名最近的校验记录。
+func (s *ManagementService) ListUserDomainVerificationAttempts(userID, domainID uint) ([]resellerdomain.DomainVerificationAttempt, error) {
+	domain, err := s.getUserDomain(userID, domainID, false)
+	if err != nil {
+		return nil, err
+	}
+	return s.store.ListDomainVerificationAttempts(domain.ID, domainAttemptListLimit)
+}
+
+// VerifyDomain 管理员手动触发自定义域名校验。
+func (s *ManagementService) VerifyDomain(ctx context.Context, adminID, domainID uint) (*resellerdomain.Domain, error) {
+	if s == nil || s.store == nil || adminID == 0 || domainID == 0 {
+		return nil, productcontract.ErrNotFound
+	}
+	domain, err := s.store.GetDomainByID(domainID)
+	if err != nil {
+		return nil, err
+	}
+	if domain == nil {
+		return nil, productcontract.ErrNotFound
+	}
+	row, _, err := s.verifyDomain(ctx, domain, resellerdomain.DomainVerifyTriggerAdmin, time.Now())
+	return row, err
+}
+
+// ListDomainVerificationAttempts 管理端查看域名最近的校验记录。
+func (s *ManagementService) ListDomainVerificationAttempts(domainID uint) ([]resellerdomain.DomainVerificationAttempt, error) {
+	if s == nil || s.store == nil || domainID == 0 {
+		return nil, productcontract.ErrNotFound
+	}
+	return s.store.ListDomainVerificationAttempts(domainID, domainAttemptListLimit)
+}
+
+// RecheckDomains 周期复查自定义域名：待审核域名校验通过后自动启用，
+// 已启用域名连续失败达到阈值后暂停，暂停域名恢复指向后自动启用。
+func (s *ManagementService) RecheckDomains(ctx context.Context, now time.Time) (DomainRecheckResult, error) {
+	result := DomainRecheckResult{}
+	if s == nil || s.store == nil || s.verifier == nil {
+		return result, nil
+	}
+	interval := time.Duration(s.cfg.DomainVerification.RecheckIntervalHours) * time.Hour
+	if interval <= 0 {
+		interval = defaultDomainRecheckInterval
+	}
+	due, err := s.store.ListDomainsDueForCheck(now.Add(-interval), domainRecheckBatchSize)
+	if err != nil {
+		return result, err
+	}
+	for i := range due {
+		before := due[i].Status
+		row, _, err := s.verifyDomain(ctx, &due[i], resellerdomain.DomainVerifyTriggerScheduled, now)
+		if err != nil {
+			return result, err
+		}
+		result.Checked++
+		switch {
+		case row == nil:
+		case row.Status == resellerdomain.DomainStatusSuspended && before != resellerdomain.DomainStatusSuspended:
+			result.Suspended++
+		case row.VerificationStatus == resellerdomain.DomainVerificationVerified && row.CheckFailureCount == 0:
+			result.Verified++
+		default:
+			result.Failed++
+		}
+	}
+	return result, nil
+}
+
+// MatchDomainChallenge 判断请求 Host 对应域名的校验 token 是否与路径一致，供 HTTP 挑战响应使用。
+func (s *ManagementService) MatchDomainChallenge(req *http.Request, token string) (bool, error) {
+	token = strings.TrimSpace(token)
+	if s == nil || s.store == nil || token == "" {
+		return false, nil
+	}
+	host := resellercontract.NormalizeHost(resellercontract.ResolveRequestHost(req, s.cfg))
+	if host == "" {
+		return false, nil
+	}
+	domain, err := s.store.FindDomainByHost(host)
+	if err != nil || domain == nil || domain.Type != resellerdomain.DomainTypeCustom || domain.VerificationToken == "" {
+		return false, err
+	}
+	return subtle.ConstantTimeCompare([]byte(domain.VerificationToken), []byte(token)) == 1, nil
+}
+
+func (s *ManagementService) getUserDomain(userID, domainID uint, requireActive bool) (*resellerdomain.Domain, error) {
+	if s == nil || s.store == nil || userID == 0 || domainID == 0 {
+		return nil, productcontract.ErrNotFound
+	}
+	profile, err := s.store.GetProfileByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if profile == nil {
+		return nil, resellercontract.ErrNotOpened
+	}
+	if requireActive && profile.Status != resellerdomain.ProfileStatusActive {
+		return nil, resellercontract.ErrProfileInactive
+	}
+	domain, err := s.store.GetDomainByID(domainID)
+	if err != nil {
+		return nil, err
+	}
+	if domain == nil || domain.ResellerID != profile.ID {
+		return nil, productcontract.ErrNotFound
+	}
+	return domain, nil
+}
+
+// verifyDomain 在事务外完成网络校验，再在事务内按最新状态落库，避免长事务持锁。
+func (s *ManagementService) verifyDomain(
+	ctx context.Context,
+	domain *resellerdomain.Domain,
+	trigger string,
+	now time.Time,
+) (*resellerdomain.Domain, []resellerdomain.DomainVerificationAttempt, error) {
+	if s.verifier == nil || domain.Type != resellerdomain.DomainTypeCustom || domain.Status == resellerdomain.DomainStatusDisabled {
+		return nil, nil, resellercontract.ErrDomainVerificationUnavailable
+	}
+	token := domain.VerificationToken
+	if token == "" {
+		generated, err := generateDomainVerificationToken()
+		if err != nil {
+			return nil, nil, err
+		}
+		token = generated
+	}
+	checks := s.verifier.Verify(ctx, domain.Domain, token)
+	success := false
+	detail := ""
+	attempts := make([]resellerdomain.DomainVerificationAttempt, 0, len(checks))
+	for _, check := range checks {
+		if check.Success {
+			success = true
+		} else {
+			detail = truncateDomainCheckDetail(check.Detail)
+		}
+		attempts = append(attempts, resellerdomain.DomainVerificationAttempt{
+			DomainID:   domain.ID,
+			ResellerID: domain.ResellerID,
+			Method:     check.Method,
+			Trigger:    trigger,
+			Success:    check.Success,
+			Detail:     truncateDomainCheckDetail(check.Detail),
+			CreatedAt:  now,
+		})
+	}
+
+	cacheDomains := make([]string, 0, 4)
+	err := s.store.WithinManagementTransaction(func(repoTx resellercontract.ManagementStore) error {
+		row, err := repoTx.GetDomainByIDForUpdate(domain.ID)
+		if err != nil {
+			return err
+		}
+		if row == nil {
+			return productcontract.ErrNotFound
+		}
+		if row.VerificationToken == "" {
+			row.VerificationToken = token
+		} else if row.VerificationToken != token {
+			// token 已被并发重置，本次结果作废
+			attempts = nil
+			return nil
+		}
+		domains, err := repoTx.ListDomainsByResellerID(row.ResellerID)
+		if err != nil {
+			return err
+		}
+		for i := range domains {
+			if domains[i].Domain != "" {
+				cacheDomains = append(cacheDomains, domains[i].Domain)
+			}
+		}
+		row.LastCheckedAt = &now
+		if success {
+			row.CheckFailureCount = 0
+			row.LastCheckError = ""
+			if row.VerificationStatus != resellerdomain.DomainVerificationVerified {
+				row.VerificationStatus = resellerdomain.DomainVerificationVerified
+				row.VerifiedAt = &now
+			}
+			if row.Status == resellerdomain.DomainStatusPendingReview || row.Status == resellerdomain.DomainStatusSuspended {
+				row.Status = resellerdomain.DomainStatusActive
+				if !hasActiveVerifiedPrimary(domains, row.ID) {
+					row.IsPrimary = true
+				}
+			}
+		} else {
+			row.CheckFailureCount++
+			row.LastCheckError = detail
+			switch row.Status {
+			case resellerdomain.DomainStatusPendingReview:
+				row.VerificationStatus = resellerdomain.DomainVerificationFailed
+			case resellerdomain.DomainStatusActive:
+				// 只有周期复查会暂停域名，手动校验失败不影响线上站点
+				if trigger == resellerdomain.DomainVerifyTriggerScheduled && row.CheckFailureCount >= s.domainSuspendThreshold() {
+					row.Status = resellerdomain.DomainStatusSuspended
+					row.VerificationStatus = resellerdomain.DomainVerificationFailed
+					if row.IsPrimary {
+						row.IsPrimary = false
+						if err := promoteFallbackPrimary(repoTx, domains, row.ID); err != nil {
+							return err
+						}
+					}
+				}
+			}
+		}
+		if err := repoTx.UpdateDomain(row); err != nil {
+			return err
+		}
+		return repoTx.CreateDomainVerificationAttempts(attempts)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, host := range cacheDomains {
+		_ = cache.DelResellerDomain(ctx, host)
+	}
+	row, err := s.store.GetDomainByID(domain.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return row, attempts, nil
+}
+
+func (s *ManagementService) domainSuspendThreshold() int {
+	if s.cfg.DomainVerification.SuspendAfterFailures > 0 {
+		return s.cfg.DomainVerification.SuspendAfterFailures
+	}
+	return defaultDomainSuspendFailures
+}
+
+// promoteFallbackPrimary 主域名下线时，将第一个已启用且已验证的域名提升为主域名。
+func promoteFallbackPrimary(repoTx resellercontract.ManagementStore, domains []resellerdomain.Domain, excludeID uint) error {
+	for i := range domains {
+		candidate := domains[i]
+		if candidate.ID == excludeID || candidate.Status != resellerdomain.DomainStatusActive || candidate.VerificationStatus != resellerdomain.DomainVerificationVerified {
+			continue
+		}
+		if !candidate.IsPrimary {
+			candidate.IsPrimary = true
+			return repoTx.UpdateDomain(&candidate)
+		}
+		return nil
+	}
+	return nil
+}
+
+func generateDomainVerificationToken() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func truncateDomainCheckDetail(detail string) string {
+	if len(detail) <= domainCheckDetailMaxLength {
+		return detail
+	}
+	return detail[:domainCheckDetailMaxLength]
+}
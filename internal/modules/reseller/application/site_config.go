@@ -7,8 +7,12 @@ import (
 	"hash/fnv"
 	"net/mail"
 	"net/url"
+	"regexp"
+	"strconv"
 	"strings"
 
+	"github.com/dujiao-next/internal/constants"
+	"github.com/dujiao-next/internal/i18n/locales"
 	resellercontract "github.com/dujiao-next/internal/modules/reseller/contract"
 
 	resellerdomain "github.com/dujiao-next/internal/modules/reseller/domain"
@@ -16,7 +20,6 @@ import (
 	productcontract "github.com/dujiao-next/internal/modules/catalog/product/contract"
 
 	"github.com/dujiao-next/internal/cache"
-	"github.com/dujiao-next/internal/constants"
 	"github.com/dujiao-next/internal/shared/jsonmap"
 	"github.com/dujiao-next/internal/shared/mailbrand"
 )
@@ -55,24 +58,101 @@ type ResellerNavConfigInput struct {
 }
 
 type ResellerSiteConfigInput struct {
-	SiteName     string                    `json:"site_name"`
-	Logo         string                    `json:"logo"`
-	Favicon      string                    `json:"favicon"`
-	Announcement ResellerAnnouncementInput `json:"announcement"`
-	Support      ResellerSupportInput      `json:"support"`
-	SEO          ResellerSEOInput          `json:"seo"`
-	FooterLinks  []ResellerFooterLinkInput `json:"footer_links"`
-	NavConfig    ResellerNavConfigInput    `json:"nav_config"`
-}
+	SiteName     string                     `json:"site_name"`
+	Logo         string                     `json:"logo"`
+	Favicon      string                     `json:"favicon"`
+	Announcement ResellerAnnouncementInput  `json:"announcement"`
+	Support      ResellerSupportInput       `json:"support"`
+	SEO          ResellerSEOInput           `json:"seo"`
+	FooterLinks  []ResellerFooterLinkInput  `json:"footer_links"`
+	NavConfig    ResellerNavConfigInput     `json:"nav_config"`
+	Theme        ResellerThemeInput         `json:"theme"`
+	Pages        []ResellerLandingPageInput `json:"pages"`
+}
+
+type ResellerThemeColorsInput struct {
+	Primary    string `json:"primary"`
+	Accent     string `json:"accent"`
+	Background string `json:"background"`
+	Text       string `json:"text"`
+}
+
+type ResellerThemeFontsInput struct {
+	Heading string `json:"heading"`
+	Body    string `json:"body"`
+}
+
+// ResellerThemeInput 分销站点主题；各项留空表示沿用主站设置。
+type ResellerThemeInput struct {
+	Template string                   `json:"template"`
+	Layout   string                   `json:"layout"`
+	Colors   ResellerThemeColorsInput `json:"colors"`
+	Fonts    ResellerThemeFontsInput  `json:"fonts"`
+}
+
+type ResellerFAQItemInput struct {
+	Question LocalizedTextInput `json:"question"`
+	Answer   LocalizedTextInput `json:"answer"`
+}
+
+// ResellerPageBlockInput 落地页区块，按 Type 读取对应字段，其余字段忽略。
+type ResellerPageBlockInput struct {
+	Type       string                 `json:"type"`
+	Title      LocalizedTextInput     `json:"title"`
+	Subtitle   LocalizedTextInput     `json:"subtitle"`
+	Image      string                 `json:"image"`
+	ButtonText LocalizedTextInput     `json:"button_text"`
+	ButtonURL  string                 `json:"button_url"`
+	CategoryID uint                   `json:"category_id"`
+	Limit      int                    `json:"limit"`
+	Content    LocalizedTextInput     `json:"content"`
+	Items      []ResellerFAQItemInput `json:"items"`
+}
+
+type ResellerLandingPageInput struct {
+	Slug    string                   `json:"slug"`
+	Title   LocalizedTextInput       `json:"title"`
+	Enabled bool                     `json:"enabled"`
+	Blocks  []ResellerPageBlockInput `json:"blocks"`
+}
+
+const (
+	maxResellerLandingPages    = 5
+	maxResellerPageBlocks      = 12
+	maxResellerFAQItems        = 20
+	defaultResellerGridLimit   = 8
+	maxResellerGridLimit       = 24
+	maxResellerPageSlugLength  = 64
+	maxResellerRichTextLength  = 4000
+	maxResellerFAQAnswerLength = 1000
+)
+
+var (
+	resellerThemeColorPattern = regexp.MustCompile(`^#(?:[0-9a-f]{3}|[0-9a-f]{6})$`)
+	resellerPageSlugPattern   = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+	// resellerThemeFonts 前台已内置的字体族，避免加载任意外部字体。
+	resellerThemeFonts = map[string]struct{}{
+		"system": {}, "inter": {}, "roboto": {}, "noto_sans": {}, "noto_serif": {},
+	}
+)
 
 type SiteConfigService struct {
-	repo resellercontract.SiteConfigRepository
+	repo       resellercontract.SiteConfigRepository
+	categories resellercontract.SiteCategoryLookup
 }
 
 func NewSiteConfigService(repo resellercontract.SiteConfigRepository) *SiteConfigService {
 	return &SiteConfigService{repo: repo}
 }
 
+// SetCategoryLookup 注入分类查询，用于校验落地页商品区块引用的分类；未注入时仅校验 ID 非空。
+func (s *SiteConfigService) SetCategoryLookup(categories resellercontract.SiteCategoryLookup) {
+	if s == nil {
+		return
+	}
+	s.categories = categories
+}
+
 func trimLimit(raw string, max int) string {
 	value := strings.TrimSpace(raw)
 	if max <= 0 {
@@ -103,7 +183,7 @@ func newResellerFieldError(field string) error {
 
 func normalizeResellerLocalizedText(raw LocalizedTextInput, max int) jsonmap.JSON {
 	out := jsonmap.JSON{}
-	for _, lang := range []string{"zh-CN", "zh-TW", "en-US"} {
+	for _, lang := range locales.Enabled() {
 		out[lang] = trimLimit(raw[lang], max)
 	}
 	return out
@@ -236,6 +316,230 @@ func normalizeResellerNavConfig(input ResellerNavConfigInput) (jsonmap.JSON, err
 	return jsonmap.JSON{"builtin": builtin, "custom_items": custom["items"]}, nil
 }
 
+func normalizeResellerThemeColor(raw string) (string, error) {
+	value := strings.ToLower(trimLimit(raw, 16))
+	if value == "" || resellerThemeColorPattern.MatchString(value) {
+		return value, nil
+	}
+	return "", newResellerFieldError("theme")
+}
+
+func normalizeResellerThemeFont(raw string) (string, error) {
+	value := strings.ToLower(trimLimit(raw, 32))
+	if value == "" {
+		return "", nil
+	}
+	if _, ok := resellerThemeFonts[value]; !ok {
+		return "", newResellerFieldError("theme")
+	}
+	return value, nil
+}
+
+// normalizeResellerTheme 校验主题；模板留空沿用主站模板，布局默认 standard。
+// 颜色仅接受 #RGB/#RRGGBB，字体仅接受前台内置字体族。
+func normalizeResellerTheme(input ResellerThemeInput) (jsonmap.JSON, error) {
+	template := strings.ToLower(trimLimit(input.Template, 32))
+	if template != "" && template != constants.StorefrontTemplateClassic && template != constants.StorefrontTemplateVault {
+		return nil, newResellerFieldError("theme")
+	}
+	layout := strings.ToLower(trimLimit(input.Layout, 32))
+	switch layout {
+	case "":
+		layout = resellerdomain.ThemeLayoutStandard
+	case resellerdomain.ThemeLayoutStandard, resellerdomain.ThemeLayoutWide, resellerdomain.ThemeLayoutCompact:
+	default:
+		return nil, newResellerFieldError("theme")
+	}
+	colors := jsonmap.JSON{}
+	customized := template != "" || layout != resellerdomain.ThemeLayoutStandard
+	for key, raw := range map[string]string{
+		"primary":    input.Colors.Primary,
+		"accent":     input.Colors.Accent,
+		"background": input.Colors.Background,
+		"text":       input.Colors.Text,
+	} {
+		value, err := normalizeResellerThemeColor(raw)
+		if err != nil {
+			return nil, err
+		}
+		colors[key] = value
+		customized = customized || value != ""
+	}
+	heading, err := normalizeResellerThemeFont(input.Fonts.Heading)
+	if err != nil {
+		return nil, err
+	}
+	body, err := normalizeResellerThemeFont(input.Fonts.Body)
+	if err != nil {
+		return nil, err
+	}
+	if !customized && heading == "" && body == "" {
+		// 未做任何定制时不写入主题，前台完全沿用主站外观。
+		return jsonmap.JSON{}, nil
+	}
+	return jsonmap.JSON{
+		"template": template,
+		"layout":   layout,
+		"colors":   colors,
+		"fonts":    jsonmap.JSON{"heading": heading, "body": body},
+	}, nil
+}
+
+// validateResellerLinkTarget 校验按钮链接：允许站内路径或 http(s) 外链。
+func validateResellerLinkTarget(raw string) (string, error) {
+	value := trimLimit(raw, 500)
+	if value == "" {
+		return "", nil
+	}
+	if strings.HasPrefix(value, "/") && !strings.HasPrefix(value, "//") {
+		return value, nil
+	}
+	parsed, err := url.Parse(value)
+	if err != nil || parsed.Host == "" || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return "", resellercontract.ErrSiteConfigInvalid
+	}
+	return value, nil
+}
+
+func hasResellerLocalizedText(text jsonmap.JSON) bool {
+	return hasAnnouncementContent(map[string]interface{}(text))
+}
+
+func (s *SiteConfigService) normalizeResellerPageBlock(input ResellerPageBlockInput) (jsonmap.JSON, error) {
+	blockType := strings.ToLower(trimLimit(input.Type, 32))
+	switch blockType {
+	case resellerdomain.PageBlockHero:
+		title := normalizeResellerLocalizedText(input.Title, 120)
+		if !hasResellerLocalizedText(title) {
+			return nil, newResellerFieldError("page_block")
+		}
+		image, err := validateHTTPOrUploadPath(input.Image)
+		if err != nil {
+			return nil, newResellerFieldError("image")
+		}
+		buttonURL, err := validateResellerLinkTarget(input.ButtonURL)
+		if err != nil {
+			return nil, newResellerFieldError("link")
+		}
+		return jsonmap.JSON{
+			"type":        blockType,
+			"title":       title,
+			"subtitle":    normalizeResellerLocalizedText(input.Subtitle, 300),
+			"image":       image,
+			"button_text": normalizeResellerLocalizedText(input.ButtonText, 40),
+			"button_url":  buttonURL,
+		}, nil
+	case resellerdomain.PageBlockProductGrid:
+		if err := s.validateResellerPageCategory(input.CategoryID); err != nil {
+			return nil, err
+		}
+		limit := input.Limit
+		if limit <= 0 {
+			limit = defaultResellerGridLimit
+		}
+		if limit > maxResellerGridLimit {
+			limit = maxResellerGridLimit
+		}
+		return jsonmap.JSON{
+			"type":        blockType,
+			"title":       normalizeResellerLocalizedText(input.Title, 120),
+			"category_id": input.CategoryID,
+			"limit":       limit,
+		}, nil
+	case resellerdomain.PageBlockRichText:
+		// 与公告一致为富文本 HTML，前端统一经 DOMPurify 渲染。
+		content := normalizeResellerLocalizedText(input.Content, maxResellerRichTextLength)
+		if !hasResellerLocalizedText(content) {
+			return nil, newResellerFieldError("page_block")
+		}
+		return jsonmap.JSON{
+			"type":    blockType,
+			"title":   normalizeResellerLocalizedText(input.Title, 120),
+			"content": content,
+		}, nil
+	case resellerdomain.PageBlockFAQ:
+		if len(input.Items) > maxResellerFAQItems {
+			return nil, newResellerFieldError("page_block")
+		}
+		items := make([]jsonmap.JSON, 0, len(input.Items))
+		for _, item := range input.Items {
+			question := normalizeResellerLocalizedText(item.Question, 200)
+			if !hasResellerLocalizedText(question) {
+				continue
+			}
+			items = append(items, jsonmap.JSON{
+				"question": question,
+				"answer":   normalizeResellerLocalizedText(item.Answer, maxResellerFAQAnswerLength),
+			})
+		}
+		if len(items) == 0 {
+			return nil, newResellerFieldError("page_block")
+		}
+		return jsonmap.JSON{
+			"type":  blockType,
+			"title": normalizeResellerLocalizedText(input.Title, 120),
+			"items": items,
+		}, nil
+	default:
+		return nil, newResellerFieldError("page_block")
+	}
+}
+
+func (s *SiteConfigService) validateResellerPageCategory(categoryID uint) error {
+	if categoryID == 0 {
+		return newResellerFieldError("page_category")
+	}
+	if s == nil || s.categories == nil {
+		return nil
+	}
+	category, err := s.categories.GetByID(strconv.FormatUint(uint64(categoryID), 10))
+	if err != nil {
+		return err
+	}
+	if category == nil || !category.IsActive {
+		return newResellerFieldError("page_category")
+	}
+	return nil
+}
+
+// normalizeResellerPages 校验落地页：slug 唯一，区块类型与字段逐一校验，
+// 保证落库的内容都能被前台安全渲染。
+func (s *SiteConfigService) normalizeResellerPages(input []ResellerLandingPageInput) (jsonmap.JSON, error) {
+	if len(input) > maxResellerLandingPages {
+		return nil, newResellerFieldError("page_block")
+	}
+	pages := make([]jsonmap.JSON, 0, len(input))
+	seen := make(map[string]struct{}, len(input))
+	for _, page := range input {
+		slug := strings.ToLower(trimLimit(page.Slug, 0))
+		if len(slug) > maxResellerPageSlugLength || !resellerPageSlugPattern.MatchString(slug) {
+			return nil, newResellerFieldError("page_slug")
+		}
+		if _, ok := seen[slug]; ok {
+			return nil, newResellerFieldError("page_slug")
+		}
+		seen[slug] = struct{}{}
+		if len(page.Blocks) > maxResellerPageBlocks {
+			return nil, newResellerFieldError("page_block")
+		}
+		blocks := make([]jsonmap.JSON, 0, len(page.Blocks))
+		for _, block := range page.Blocks {
+			normalized, err := s.normalizeResellerPageBlock(block)
+			if err != nil {
+				return nil, err
+			}
+			blocks = append(blocks, normalized)
+		}
+		pages = append(pages, jsonmap.JSON{
+			"slug":    slug,
+			"title":   normalizeResellerLocalizedText(page.Title, 120),
+			"enabled": page.Enabled,
+			"blocks":  blocks,
+		})
+	}
+	return jsonmap.JSON{"items": pages}, nil
+}
+
 func (s *SiteConfigService) buildModel(resellerID uint, input ResellerSiteConfigInput) (*resellerdomain.SiteConfig, error) {
 	logo, err := validateHTTPOrUploadPath(input.Logo)
 	if err != nil {
@@ -261,6 +565,14 @@ func (s *SiteConfigService) buildModel(resellerID uint, input ResellerSiteConfig
 	if err != nil {
 		return nil, err
 	}
+	theme, err := normalizeResellerTheme(input.Theme)
+	if err != nil {
+		return nil, err
+	}
+	pages, err := s.normalizeResellerPages(input.Pages)
+	if err != nil {
+		return nil, err
+	}
 	return &resellerdomain.SiteConfig{
 		ResellerID:       resellerID,
 		SiteName:         trimLimit(input.SiteName, 120),
@@ -271,7 +583,8 @@ func (s *SiteConfigService) buildModel(resellerID uint, input ResellerSiteConfig
 		SEOJSON:          seo,
 		FooterLinksJSON:  footerLinks,
 		NavConfigJSON:    navConfig,
-		ThemeJSON:        jsonmap.JSON{},
+		ThemeJSON:        theme,
+		PagesJSON:        pages,
 	}, nil
 }
 
@@ -337,6 +650,41 @@ func (s *SiteConfigService) UpdateAdminSiteConfig(ctx context.Context, resellerI
 	return saved, nil
 }
 
+// PreviewUserSiteConfig 校验提交内容并生成预览，不落库。
+// 返回归一化后的配置及其叠加到前台 public config 的片段。
+func (s *SiteConfigService) PreviewUserSiteConfig(userID uint, input ResellerSiteConfigInput) (*resellerdomain.SiteConfig, map[string]interface{}, error) {
+	profile, _, _, err := s.GetUserSiteConfig(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.previewSiteConfig(profile.ID, input)
+}
+
+// PreviewAdminSiteConfig 管理端预览分销站点配置，不落库。
+func (s *SiteConfigService) PreviewAdminSiteConfig(resellerID uint, input ResellerSiteConfigInput) (*resellerdomain.SiteConfig, map[string]interface{}, error) {
+	if s == nil || s.repo == nil || resellerID == 0 {
+		return nil, nil, productcontract.ErrNotFound
+	}
+	profile, err := s.repo.GetProfileByID(resellerID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if profile == nil {
+		return nil, nil, productcontract.ErrNotFound
+	}
+	return s.previewSiteConfig(resellerID, input)
+}
+
+func (s *SiteConfigService) previewSiteConfig(resellerID uint, input ResellerSiteConfigInput) (*resellerdomain.SiteConfig, map[string]interface{}, error) {
+	row, err := s.buildModel(resellerID, input)
+	if err != nil {
+		return nil, nil, err
+	}
+	overlay := map[string]interface{}{}
+	applyResellerSiteConfigToPublicConfig(overlay, row)
+	return row, overlay, nil
+}
+
 func (s *SiteConfigService) ResetAdminSiteConfig(ctx context.Context, resellerID uint) error {
 	if resellerID == 0 {
 		return productcontract.ErrNotFound
@@ -511,6 +859,33 @@ func applyResellerSiteConfigToPublicConfig(out map[string]interface{}, cfg *rese
 	if len(cfg.NavConfigJSON) > 0 {
 		out["nav_config"] = cfg.NavConfigJSON
 	}
+	applyResellerThemeToPublicConfig(out, cfg.ThemeJSON)
+	if len(cfg.PagesJSON) > 0 {
+		out["landing_pages"] = enabledLandingPages(cfg.PagesJSON)
+	}
+}
+
+// applyResellerThemeToPublicConfig 写入主题；指定模板时覆盖主站的 storefront_template。
+func applyResellerThemeToPublicConfig(out map[string]interface{}, theme jsonmap.JSON) {
+	if len(theme) == 0 {
+		return
+	}
+	if template, ok := theme["template"].(string); ok && template != "" {
+		out[constants.SettingFieldStorefrontTemplate] = template
+	}
+	out["theme"] = theme
+}
+
+// enabledLandingPages 仅向前台暴露已启用的落地页。
+func enabledLandingPages(raw jsonmap.JSON) []interface{} {
+	items := footerItemsFromEnvelope(raw)
+	out := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		if parseOverlayBool(resellerAnnouncementLocalizedMap(item)["enabled"]) {
+			out = append(out, item)
+		}
+	}
+	return out
 }
 
 func parseOverlayBool(raw interface{}) bool {
@@ -532,7 +907,7 @@ func parseOverlayBool(raw interface{}) bool {
 }
 
 func hasAnnouncementContent(content map[string]interface{}) bool {
-	for _, lang := range constants.SupportedLocales {
+	for _, lang := range locales.Enabled() {
 		if text, ok := content[lang].(string); ok && strings.TrimSpace(text) != "" {
 			return true
 		}
@@ -543,7 +918,7 @@ func hasAnnouncementContent(content map[string]interface{}) bool {
 func announcementVersion(annType string, title, content map[string]interface{}) string {
 	hasher := fnv.New32a()
 	_, _ = hasher.Write([]byte(annType))
-	for _, lang := range constants.SupportedLocales {
+	for _, lang := range locales.Enabled() {
 		_, _ = hasher.Write([]byte{0})
 		if title != nil {
 			if text, ok := title[lang].(string); ok {
@@ -137,8 +137,9 @@ type AccountingQueryStore interface {
 }
 
 const (
-	WithdrawActionReject = "reject"
-	WithdrawActionPay    = "pay"
+	WithdrawActionApprove = "approve"
+	WithdrawActionReject  = "reject"
+	WithdrawActionPay     = "pay"
 )
 
 // BalanceAccountStore 是余额缓存刷新所需的最小持久化端口。
@@ -153,6 +154,7 @@ type BalanceAccountStore interface {
 type AccountingWithdrawStore interface {
 	BalanceAccountStore
 	WithinWithdrawTransaction(fn func(store AccountingWithdrawStore) error) error
+	GetProfileByID(id uint) (*resellerdomain.Profile, error)
 	GetProfileByUserID(userID uint) (*resellerdomain.Profile, error)
 	ListAvailableLedgerEntriesForUpdate(resellerID uint, currency string) ([]resellerdomain.LedgerEntry, error)
 	UpdateLedgerEntry(entry *resellerdomain.LedgerEntry) error
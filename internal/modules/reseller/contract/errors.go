@@ -17,6 +17,8 @@ var (
 	ErrDomainConflict = errors.New("reseller domain conflict")
 	// ErrDomainStatusInvalid 表示域名状态流转不合法。
 	ErrDomainStatusInvalid = errors.New("reseller domain status invalid")
+	// ErrDomainVerificationUnavailable 表示域名不支持或暂不能自动校验。
+	ErrDomainVerificationUnavailable = errors.New("reseller domain verification unavailable")
 	// ErrSubdomainBaseMissing 表示系统子域基址未配置。
 	ErrSubdomainBaseMissing = errors.New("reseller subdomain base missing")
 	// ErrDomainMainHostNotAllowed 表示域名命中主站 Host 保留区。
@@ -1,8 +1,10 @@
 package contract
 
 import (
+	"context"
 	"time"
 
+	categorydomain "github.com/dujiao-next/internal/modules/catalog/category/domain"
 	productdomain "github.com/dujiao-next/internal/modules/catalog/product/domain"
 	resellerdomain "github.com/dujiao-next/internal/modules/reseller/domain"
 )
@@ -58,6 +60,11 @@ type SiteConfigRepository interface {
 	DeleteSiteConfigByResellerID(resellerID uint) error
 }
 
+// SiteCategoryLookup 是落地页商品区块校验分类所需的只读端口。
+type SiteCategoryLookup interface {
+	GetByID(id string) (*categorydomain.Category, error)
+}
+
 // ManagementStore 是入驻/审批/域名管理用例所需的持久化与事务端口。
 // WithinTransaction 不得向用例暴露具体数据库连接类型。
 type ManagementStore interface {
@@ -72,6 +79,22 @@ type ManagementStore interface {
 	GetDomainByIDForUpdate(id uint) (*resellerdomain.Domain, error)
 	UpdateDomain(domain *resellerdomain.Domain) error
 	FindDomainByHost(host string) (*resellerdomain.Domain, error)
+	CreateDomainVerificationAttempts(attempts []resellerdomain.DomainVerificationAttempt) error
+	ListDomainVerificationAttempts(domainID uint, limit int) ([]resellerdomain.DomainVerificationAttempt, error)
+	ListDomainsDueForCheck(checkedBefore time.Time, limit int) ([]resellerdomain.Domain, error)
+}
+
+// DomainCheckResult 单一方式的自定义域名校验结果。
+type DomainCheckResult struct {
+	Method  string
+	Success bool
+	Detail  string
+}
+
+// DomainVerifier 是自定义域名归属校验端口：依次尝试 DNS TXT 与 HTTP 挑战，
+// 返回实际尝试过的每种方式的结果。
+type DomainVerifier interface {
+	Verify(ctx context.Context, host, token string) []DomainCheckResult
 }
 
 // ProductSettingListFilter 用户侧分销商品配置列表过滤条件。
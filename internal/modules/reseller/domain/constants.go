@@ -19,6 +19,21 @@ const (
 	DomainStatusPendingReview = "pending_review"
 	DomainStatusActive        = "active"
 	DomainStatusDisabled      = "disabled"
+	DomainStatusSuspended     = "suspended"
+
+	DomainVerifyMethodDNSTXT = "dns_txt"
+	DomainVerifyMethodHTTP   = "http"
+
+	DomainVerifyTriggerUser      = "user"
+	DomainVerifyTriggerAdmin     = "admin"
+	DomainVerifyTriggerScheduled = "scheduled"
+
+	// DomainVerificationTXTLabel DNS TXT 校验记录名前缀，完整记录名为 _dujiao-next.<domain>。
+	DomainVerificationTXTLabel = "_dujiao-next"
+	// DomainVerificationTXTValuePrefix DNS TXT 校验记录值前缀，完整值为 dujiao-next-verification=<token>。
+	DomainVerificationTXTValuePrefix = "dujiao-next-verification="
+	// DomainChallengePathPrefix HTTP 校验路径前缀，完整路径为 /.well-known/dujiao-next/<token>。
+	DomainChallengePathPrefix = "/.well-known/dujiao-next/"
 
 	PricingModeInherit       = "inherit"
 	PricingModeMarkupPercent = "markup_percent"
@@ -38,6 +53,7 @@ const (
 	LedgerStatusCanceled       = "canceled"
 
 	WithdrawStatusPending  = "pending"
+	WithdrawStatusApproved = "approved"
 	WithdrawStatusRejected = "rejected"
 	WithdrawStatusPaid     = "paid"
 
@@ -17,6 +17,9 @@ type Domain struct {
 	Status             string     `gorm:"type:varchar(24);index;not null;default:'pending_review'" json:"status"`
 	IsPrimary          bool       `gorm:"not null;default:false" json:"is_primary"`
 	VerifiedAt         *time.Time `gorm:"index" json:"verified_at,omitempty"`
+	LastCheckedAt      *time.Time `gorm:"index" json:"last_checked_at,omitempty"`
+	CheckFailureCount  int        `gorm:"not null;default:0" json:"check_failure_count"`
+	LastCheckError     string     `gorm:"type:varchar(255)" json:"last_check_error,omitempty"`
 	CreatedAt          time.Time  `gorm:"index" json:"created_at"`
 	UpdatedAt          time.Time  `gorm:"index" json:"updated_at"`
 	DeletedAt          *time.Time `gorm:"index" json:"-"`
@@ -26,6 +29,35 @@ type Domain struct {
 
 func (Domain) TableName() string { return "reseller_domains" }
 
+// DomainVerificationAttempt 自定义域名校验记录，分销商控制台可查看。
+type DomainVerificationAttempt struct {
+	ID         uint      `gorm:"primarykey" json:"id"`
+	DomainID   uint      `gorm:"not null;index" json:"domain_id"`
+	ResellerID uint      `gorm:"not null;index" json:"reseller_id"`
+	Method     string    `gorm:"type:varchar(16);not null" json:"method"`
+	Trigger    string    `gorm:"type:varchar(16);not null" json:"trigger"`
+	Success    bool      `gorm:"not null;default:false" json:"success"`
+	Detail     string    `gorm:"type:varchar(255)" json:"detail,omitempty"`
+	CreatedAt  time.Time `gorm:"index" json:"created_at"`
+}
+
+func (DomainVerificationAttempt) TableName() string { return "reseller_domain_verification_attempts" }
+
+// 分销站点主题布局变体。
+const (
+	ThemeLayoutStandard = "standard"
+	ThemeLayoutWide     = "wide"
+	ThemeLayoutCompact  = "compact"
+)
+
+// 落地页区块类型。
+const (
+	PageBlockHero        = "hero"
+	PageBlockProductGrid = "product_grid"
+	PageBlockRichText    = "rich_text"
+	PageBlockFAQ         = "faq"
+)
+
 // SiteConfig 分销站点白标配置。
 type SiteConfig struct {
 	ID               uint         `gorm:"primarykey" json:"id"`
@@ -39,6 +71,7 @@ type SiteConfig struct {
 	FooterLinksJSON  jsonmap.JSON `gorm:"type:json" json:"footer_links_json"`
 	NavConfigJSON    jsonmap.JSON `gorm:"type:json" json:"nav_config_json"`
 	ThemeJSON        jsonmap.JSON `gorm:"type:json" json:"theme_json"`
+	PagesJSON        jsonmap.JSON `gorm:"type:json" json:"pages_json"`
 	CreatedAt        time.Time    `gorm:"index" json:"created_at"`
 	UpdatedAt        time.Time    `gorm:"index" json:"updated_at"`
 	DeletedAt        *time.Time   `gorm:"index" json:"-"`
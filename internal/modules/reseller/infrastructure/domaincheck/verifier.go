@@ -0,0 +1,144 @@
+package domaincheck
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dujiao-next/internal/config"
+	resellercontract "github.com/dujiao-next/internal/modules/reseller/contract"
+	resellerdomain "github.com/dujiao-next/internal/modules/reseller/domain"
+)
+
+const (
+	defaultTimeout    = 10 * time.Second
+	maxRedirects      = 3
+	challengeBodySize = 1024
+)
+
+// TXTResolver 是 TXT 记录查询端口；*net.Resolver 即满足该接口，
+// 测试可注入指向本地 DNS 服务的解析器。
+type TXTResolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// Verifier 通过 DNS TXT 记录或 HTTP 挑战文件校验自定义域名归属。
+type Verifier struct {
+	resolver TXTResolver
+	client   *http.Client
+	timeout  time.Duration
+}
+
+var _ resellercontract.DomainVerifier = (*Verifier)(nil)
+
+// New 按配置创建校验器；配置 dns_server 时直接向该服务器查询 TXT 记录。
+func New(cfg config.ResellerDomainVerificationConfig) *Verifier {
+	timeout := time.Duration(cfg.TimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	resolver := net.DefaultResolver
+	if server := strings.TrimSpace(cfg.DNSServer); server != "" {
+		resolver = &net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				var dialer net.Dialer
+				return dialer.DialContext(ctx, network, server)
+			},
+		}
+	}
+	client := &http.Client{
+		Timeout: timeout,
+		CheckRedirect: func(_ *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return http.ErrUseLastResponse
+			}
+			return nil
+		},
+	}
+	return NewWith(resolver, client, timeout)
+}
+
+// NewWith 使用自定义解析器与 HTTP 客户端创建校验器。
+func NewWith(resolver TXTResolver, client *http.Client, timeout time.Duration) *Verifier {
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	return &Verifier{resolver: resolver, client: client, timeout: timeout}
+}
+
+// Verify 先校验 DNS TXT，失败再尝试 HTTP 挑战。
+func (v *Verifier) Verify(ctx context.Context, host, token string) []resellercontract.DomainCheckResult {
+	results := make([]resellercontract.DomainCheckResult, 0, 2)
+	dnsResult := v.CheckDNS(ctx, host, token)
+	results = append(results, dnsResult)
+	if dnsResult.Success {
+		return results
+	}
+	return append(results, v.CheckHTTP(ctx, host, token))
+}
+
+// CheckDNS 查询 _dujiao-next.<host> 的 TXT 记录。
+func (v *Verifier) CheckDNS(ctx context.Context, host, token string) resellercontract.DomainCheckResult {
+	result := resellercontract.DomainCheckResult{Method: resellerdomain.DomainVerifyMethodDNSTXT}
+	ctx, cancel := context.WithTimeout(ctx, v.timeout)
+	defer cancel()
+	records, err := v.resolver.LookupTXT(ctx, resellerdomain.DomainVerificationTXTLabel+"."+host)
+	if err != nil {
+		result.Detail = "txt lookup failed: " + err.Error()
+		return result
+	}
+	expected := resellerdomain.DomainVerificationTXTValuePrefix + token
+	for _, record := range records {
+		if subtle.ConstantTimeCompare([]byte(strings.TrimSpace(record)), []byte(expected)) == 1 {
+			result.Success = true
+			return result
+		}
+	}
+	result.Detail = fmt.Sprintf("txt record not matched (%d records)", len(records))
+	return result
+}
+
+// CheckHTTP 请求 http://<host>/.well-known/dujiao-next/<token> 并比对响应内容。
+func (v *Verifier) CheckHTTP(ctx context.Context, host, token string) resellercontract.DomainCheckResult {
+	result := resellercontract.DomainCheckResult{Method: resellerdomain.DomainVerifyMethodHTTP}
+	ctx, cancel := context.WithTimeout(ctx, v.timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+host+resellerdomain.DomainChallengePathPrefix+token, nil)
+	if err != nil {
+		result.Detail = "build request failed: " + err.Error()
+		return result
+	}
+	resp, err := v.client.Do(req)
+	if err != nil {
+		result.Detail = "http request failed: " + err.Error()
+		return result
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		result.Detail = fmt.Sprintf("unexpected http status %d", resp.StatusCode)
+		return result
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, challengeBodySize))
+	if err != nil {
+		result.Detail = "read response failed: " + err.Error()
+		return result
+	}
+	if subtle.ConstantTimeCompare([]byte(strings.TrimSpace(string(body))), []byte(token)) != 1 {
+		result.Detail = "challenge content not matched"
+		return result
+	}
+	result.Success = true
+	return result
+}
@@ -43,6 +43,9 @@ func (r *Store) UpsertDomain(input resellerdomain.Domain) (*resellerdomain.Domai
 	existing.Status = input.Status
 	existing.IsPrimary = input.IsPrimary
 	existing.VerifiedAt = input.VerifiedAt
+	existing.LastCheckedAt = input.LastCheckedAt
+	existing.CheckFailureCount = input.CheckFailureCount
+	existing.LastCheckError = input.LastCheckError
 	existing.DeletedAt = nil
 	existing.UpdatedAt = now
 	if err := r.db.Unscoped().Save(&existing).Error; err != nil {
@@ -202,6 +205,47 @@ func (r *Store) ListDomains(filter resellercontract.DomainListFilter) ([]reselle
 	return rows, total, nil
 }
 
+// CreateDomainVerificationAttempts 批量写入域名校验记录。
+func (r *Store) CreateDomainVerificationAttempts(attempts []resellerdomain.DomainVerificationAttempt) error {
+	if len(attempts) == 0 {
+		return nil
+	}
+	return r.db.Create(&attempts).Error
+}
+
+// ListDomainVerificationAttempts 按时间倒序列出域名最近的校验记录。
+func (r *Store) ListDomainVerificationAttempts(domainID uint, limit int) ([]resellerdomain.DomainVerificationAttempt, error) {
+	rows := make([]resellerdomain.DomainVerificationAttempt, 0)
+	if domainID == 0 {
+		return rows, nil
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	if err := r.db.Where("domain_id = ?", domainID).Order("id DESC").Limit(limit).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// ListDomainsDueForCheck 列出已签发校验 token 且到达复查时间的自定义域名。
+func (r *Store) ListDomainsDueForCheck(checkedBefore time.Time, limit int) ([]resellerdomain.Domain, error) {
+	rows := make([]resellerdomain.Domain, 0)
+	if limit <= 0 {
+		limit = 100
+	}
+	err := r.db.Where("deleted_at IS NULL AND type = ? AND verification_token <> ''", resellerdomain.DomainTypeCustom).
+		Where("status IN ?", []string{resellerdomain.DomainStatusPendingReview, resellerdomain.DomainStatusActive, resellerdomain.DomainStatusSuspended}).
+		Where("(last_checked_at IS NULL OR last_checked_at < ?)", checkedBefore).
+		Order("id ASC").
+		Limit(limit).
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
 func normalizeDomainForRepository(raw string) string {
 	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(raw)), ".")
 }
@@ -18,6 +18,7 @@ import (
 func resellerOperationsPaidStatuses() []string {
 	return []string{
 		constants.OrderStatusPaid,
+		constants.OrderStatusHeldForReview,
 		constants.OrderStatusFulfilling,
 		constants.OrderStatusPartiallyDelivered,
 		constants.OrderStatusPartiallyRefunded,
@@ -40,6 +40,7 @@ func (r *Store) UpsertSiteConfig(input resellerdomain.SiteConfig) (*resellerdoma
 	existing.FooterLinksJSON = input.FooterLinksJSON
 	existing.NavConfigJSON = input.NavConfigJSON
 	existing.ThemeJSON = input.ThemeJSON
+	existing.PagesJSON = input.PagesJSON
 	existing.DeletedAt = nil
 	existing.UpdatedAt = now
 	if err := r.db.Unscoped().Save(&existing).Error; err != nil {
@@ -25,6 +25,7 @@ func Migrate(db *gorm.DB) error {
 	if err := db.AutoMigrate(
 		&resellerdomain.Profile{},
 		&resellerdomain.Domain{},
+		&resellerdomain.DomainVerificationAttempt{},
 		&resellerdomain.SiteConfig{},
 		&resellerdomain.ProductSetting{},
 		&resellerdomain.OrderSnapshot{},
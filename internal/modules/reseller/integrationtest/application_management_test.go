@@ -223,7 +223,7 @@ func TestResellerManagementSubmitAndApproveCustomDomain(t *testing.T) {
 	if err != nil {
 		t.Fatalf("SubmitUserCustomDomain failed: %v", err)
 	}
-	if domain.Domain != "shop.customer.example" || domain.Type != resellerdomain.DomainTypeCustom || domain.VerificationStatus != resellerdomain.DomainVerificationPending || domain.Status != resellerdomain.DomainStatusPendingReview || domain.VerificationToken != "" {
+	if domain.Domain != "shop.customer.example" || domain.Type != resellerdomain.DomainTypeCustom || domain.VerificationStatus != resellerdomain.DomainVerificationPending || domain.Status != resellerdomain.DomainStatusPendingReview || domain.VerificationToken == "" {
 		t.Fatalf("unexpected submitted domain: %+v", domain)
 	}
 
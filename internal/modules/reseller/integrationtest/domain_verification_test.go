@@ -0,0 +1,324 @@
+package integrationtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+	"gorm.io/gorm"
+
+	"github.com/dujiao-next/internal/config"
+	resellercontract "github.com/dujiao-next/internal/modules/reseller/contract"
+	resellerdomain "github.com/dujiao-next/internal/modules/reseller/domain"
+	resellerdomaincheck "github.com/dujiao-next/internal/modules/reseller/infrastructure/domaincheck"
+	resellergormstore "github.com/dujiao-next/internal/modules/reseller/infrastructure/gormstore"
+)
+
+// fakeTXTServer 本地 UDP DNS 服务，仅应答 TXT 查询。
+type fakeTXTServer struct {
+	conn    net.PacketConn
+	mu      sync.Mutex
+	records map[string][]string
+}
+
+func startFakeTXTServer(t *testing.T) *fakeTXTServer {
+	t.Helper()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen fake dns failed: %v", err)
+	}
+	server := &fakeTXTServer{conn: conn, records: map[string][]string{}}
+	t.Cleanup(func() { _ = conn.Close() })
+	go server.serve()
+	return server
+}
+
+func (s *fakeTXTServer) set(name string, values ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records[strings.ToLower(strings.TrimSuffix(name, "."))+"."] = values
+}
+
+func (s *fakeTXTServer) serve() {
+	buf := make([]byte, 1500)
+	for {
+		n, addr, err := s.conn.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+		var parser dnsmessage.Parser
+		header, err := parser.Start(buf[:n])
+		if err != nil {
+			continue
+		}
+		question, err := parser.Question()
+		if err != nil {
+			continue
+		}
+		s.mu.Lock()
+		values, ok := s.records[strings.ToLower(question.Name.String())]
+		s.mu.Unlock()
+
+		builder := dnsmessage.NewBuilder(nil, dnsmessage.Header{ID: header.ID, Response: true, Authoritative: true})
+		builder.EnableCompression()
+		if !ok {
+			builder = dnsmessage.NewBuilder(nil, dnsmessage.Header{ID: header.ID, Response: true, Authoritative: true, RCode: dnsmessage.RCodeNameError})
+		}
+		_ = builder.StartQuestions()
+		_ = builder.Question(question)
+		_ = builder.StartAnswers()
+		if ok && question.Type == dnsmessage.TypeTXT {
+			for _, value := range values {
+				_ = builder.TXTResource(dnsmessage.ResourceHeader{Name: question.Name, Class: dnsmessage.ClassINET, TTL: 60}, dnsmessage.TXTResource{TXT: []string{value}})
+			}
+		}
+		resp, err := builder.Finish()
+		if err != nil {
+			continue
+		}
+		_, _ = s.conn.WriteTo(resp, addr)
+	}
+}
+
+// fakeChallengeSite 模拟分销商站点，所有 Host 均被路由到本地 httptest 服务。
+type fakeChallengeSite struct {
+	server *httptest.Server
+	mu     sync.Mutex
+	tokens map[string]string
+}
+
+func startFakeChallengeSite(t *testing.T) *fakeChallengeSite {
+	t.Helper()
+	site := &fakeChallengeSite{tokens: map[string]string{}}
+	site.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		site.mu.Lock()
+		token := site.tokens[strings.Split(r.Host, ":")[0]]
+		site.mu.Unlock()
+		if token == "" || r.URL.Path != resellerdomain.DomainChallengePathPrefix+token {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(token + "\n"))
+	}))
+	t.Cleanup(site.server.Close)
+	return site
+}
+
+func (s *fakeChallengeSite) set(host, token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens[host] = token
+}
+
+func (s *fakeChallengeSite) client() *http.Client {
+	addr := s.server.Listener.Addr().String()
+	return &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, network, addr)
+		},
+	}}
+}
+
+type domainVerificationHarness struct {
+	db   *gorm.DB
+	svc  *ResellerManagementService
+	dns  *fakeTXTServer
+	site *fakeChallengeSite
+}
+
+func newDomainVerificationHarness(t *testing.T) domainVerificationHarness {
+	t.Helper()
+	db := openResellerManagementServiceTestDB(t)
+	if err := db.AutoMigrate(&resellerdomain.DomainVerificationAttempt{}); err != nil {
+		t.Fatalf("migrate verification attempts failed: %v", err)
+	}
+	dns := startFakeTXTServer(t)
+	site := startFakeChallengeSite(t)
+	resolver := &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, "udp", dns.conn.LocalAddr().String())
+		},
+	}
+	svc := NewResellerManagementService(resellergormstore.New(db), config.ResellerConfig{
+		Enabled:          true,
+		SelfApplyEnabled: true,
+		SubdomainBase:    "shop.example.test",
+		MainHosts:        []string{"main.example.test"},
+		DomainVerification: config.ResellerDomainVerificationConfig{
+			RecheckIntervalHours: 1,
+			SuspendAfterFailures: 2,
+		},
+	})
+	svc.SetDomainVerifier(resellerdomaincheck.NewWith(resolver, site.client(), 2*time.Second))
+	return domainVerificationHarness{db: db, svc: svc, dns: dns, site: site}
+}
+
+func (h domainVerificationHarness) submitDomain(t *testing.T, email, host string) (uint, resellerdomain.Domain) {
+	t.Helper()
+	user := seedResellerManagementUser(t, h.db, email)
+	profile, err := h.svc.ApplyUserReseller(user.ID, ResellerApplyInput{Reason: "domain"})
+	if err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+	if _, err := h.svc.ApproveProfile(context.Background(), 9, profile.ID, ResellerApproveInput{}); err != nil {
+		t.Fatalf("approve profile failed: %v", err)
+	}
+	domain, err := h.svc.SubmitUserCustomDomain(user.ID, host)
+	if err != nil {
+		t.Fatalf("submit domain failed: %v", err)
+	}
+	return user.ID, *domain
+}
+
+func TestResellerDomainVerificationDNSTXTActivatesPendingDomain(t *testing.T) {
+	h := newDomainVerificationHarness(t)
+	userID, domain := h.submitDomain(t, "dns-verify@example.test", "dns.customer.example")
+	h.dns.set(resellerdomain.DomainVerificationTXTLabel+"."+domain.Domain, "unrelated", resellerdomain.DomainVerificationTXTValuePrefix+domain.VerificationToken)
+
+	verified, attempts, err := h.svc.VerifyUserDomain(context.Background(), userID, domain.ID)
+	if err != nil {
+		t.Fatalf("VerifyUserDomain failed: %v", err)
+	}
+	if verified.Status != resellerdomain.DomainStatusActive || verified.VerificationStatus != resellerdomain.DomainVerificationVerified || !verified.IsPrimary || verified.LastCheckedAt == nil {
+		t.Fatalf("unexpected verified domain: %+v", verified)
+	}
+	if len(attempts) != 1 || attempts[0].Method != resellerdomain.DomainVerifyMethodDNSTXT || !attempts[0].Success {
+		t.Fatalf("expected a single successful dns attempt, got %+v", attempts)
+	}
+}
+
+func TestResellerDomainVerificationFallsBackToHTTPChallenge(t *testing.T) {
+	h := newDomainVerificationHarness(t)
+	userID, domain := h.submitDomain(t, "http-verify@example.test", "http.customer.example")
+
+	failed, _, err := h.svc.VerifyUserDomain(context.Background(), userID, domain.ID)
+	if err != nil {
+		t.Fatalf("first verify failed: %v", err)
+	}
+	if failed.Status != resellerdomain.DomainStatusPendingReview || failed.VerificationStatus != resellerdomain.DomainVerificationFailed || failed.CheckFailureCount != 1 || failed.LastCheckError == "" {
+		t.Fatalf("expected failed pending domain, got %+v", failed)
+	}
+
+	h.site.set(domain.Domain, domain.VerificationToken)
+	verified, attempts, err := h.svc.VerifyUserDomain(context.Background(), userID, domain.ID)
+	if err != nil {
+		t.Fatalf("second verify failed: %v", err)
+	}
+	if verified.Status != resellerdomain.DomainStatusActive || verified.CheckFailureCount != 0 || verified.LastCheckError != "" {
+		t.Fatalf("expected http challenge to activate domain, got %+v", verified)
+	}
+	if len(attempts) != 2 || attempts[0].Success || attempts[1].Method != resellerdomain.DomainVerifyMethodHTTP || !attempts[1].Success {
+		t.Fatalf("expected dns failure followed by http success, got %+v", attempts)
+	}
+
+	history, err := h.svc.ListUserDomainVerificationAttempts(userID, domain.ID)
+	if err != nil {
+		t.Fatalf("list attempts failed: %v", err)
+	}
+	if len(history) != 4 || history[0].Method != resellerdomain.DomainVerifyMethodHTTP || !history[0].Success {
+		t.Fatalf("expected newest-first history of 4 attempts, got %+v", history)
+	}
+	if _, err := h.svc.ListUserDomainVerificationAttempts(userID+1000, domain.ID); !errors.Is(err, resellercontract.ErrNotOpened) {
+		t.Fatalf("expected other user rejected, got %v", err)
+	}
+}
+
+func TestResellerDomainRecheckSuspendsAndRestoresDomain(t *testing.T) {
+	h := newDomainVerificationHarness(t)
+	userID, domain := h.submitDomain(t, "recheck@example.test", "recheck.customer.example")
+	txtName := resellerdomain.DomainVerificationTXTLabel + "." + domain.Domain
+	h.dns.set(txtName, resellerdomain.DomainVerificationTXTValuePrefix+domain.VerificationToken)
+	if _, _, err := h.svc.VerifyUserDomain(context.Background(), userID, domain.ID); err != nil {
+		t.Fatalf("initial verify failed: %v", err)
+	}
+
+	// 记录被移除后，手动校验失败不应暂停域名
+	h.dns.set(txtName, "dujiao-next-verification=stale")
+	manual, _, err := h.svc.VerifyUserDomain(context.Background(), userID, domain.ID)
+	if err != nil {
+		t.Fatalf("manual verify failed: %v", err)
+	}
+	if manual.Status != resellerdomain.DomainStatusActive || manual.CheckFailureCount != 1 {
+		t.Fatalf("manual failure must not suspend, got %+v", manual)
+	}
+
+	now := time.Now()
+	result, err := h.svc.RecheckDomains(context.Background(), now)
+	if err != nil {
+		t.Fatalf("recheck failed: %v", err)
+	}
+	if result.Checked != 0 {
+		t.Fatalf("expected recently checked domain to be skipped, got %+v", result)
+	}
+
+	now = now.Add(2 * time.Hour)
+	result, err = h.svc.RecheckDomains(context.Background(), now)
+	if err != nil {
+		t.Fatalf("recheck failed: %v", err)
+	}
+	if result.Checked != 1 || result.Suspended != 1 {
+		t.Fatalf("expected domain suspended after threshold, got %+v", result)
+	}
+	var suspended resellerdomain.Domain
+	if err := h.db.First(&suspended, domain.ID).Error; err != nil {
+		t.Fatalf("reload domain failed: %v", err)
+	}
+	if suspended.Status != resellerdomain.DomainStatusSuspended || suspended.IsPrimary {
+		t.Fatalf("expected suspended non-primary domain, got %+v", suspended)
+	}
+	if ok, err := h.svc.MatchDomainChallenge(httptest.NewRequest(http.MethodGet, "http://recheck.customer.example/", nil), domain.VerificationToken); err != nil || !ok {
+		t.Fatalf("expected challenge to keep matching suspended domain, ok=%v err=%v", ok, err)
+	}
+
+	h.dns.set(txtName, resellerdomain.DomainVerificationTXTValuePrefix+domain.VerificationToken)
+	now = now.Add(2 * time.Hour)
+	result, err = h.svc.RecheckDomains(context.Background(), now)
+	if err != nil {
+		t.Fatalf("recheck failed: %v", err)
+	}
+	if result.Checked != 1 || result.Verified != 1 {
+		t.Fatalf("expected suspended domain restored, got %+v", result)
+	}
+	if err := h.db.First(&suspended, domain.ID).Error; err != nil {
+		t.Fatalf("reload domain failed: %v", err)
+	}
+	if suspended.Status != resellerdomain.DomainStatusActive || !suspended.IsPrimary || suspended.CheckFailureCount != 0 {
+		t.Fatalf("expected restored primary domain, got %+v", suspended)
+	}
+}
+
+func TestResellerDomainChallengeMatchesHostToken(t *testing.T) {
+	h := newDomainVerificationHarness(t)
+	_, domain := h.submitDomain(t, "challenge@example.test", "challenge.customer.example")
+
+	cases := []struct {
+		host  string
+		token string
+		want  bool
+	}{
+		{host: "challenge.customer.example", token: domain.VerificationToken, want: true},
+		{host: "challenge.customer.example", token: "wrong-token", want: false},
+		{host: "other.customer.example", token: domain.VerificationToken, want: false},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("http://%s%s%s", tc.host, resellerdomain.DomainChallengePathPrefix, tc.token), nil)
+		ok, err := h.svc.MatchDomainChallenge(req, tc.token)
+		if err != nil {
+			t.Fatalf("match challenge failed: %v", err)
+		}
+		if ok != tc.want {
+			t.Fatalf("host=%s token=%s: expected %v, got %v", tc.host, tc.token, tc.want, ok)
+		}
+	}
+}
@@ -53,6 +53,7 @@ func TestUpdateSettingsInvalidatesCallbackRoutesFromRegistryEffect(t *testing.T)
 
 	handler := settingstransport.NewAdminHandler(settingService)
 	router := gin.New()
+	router.Use(func(c *gin.Context) { c.Set("admin_id", uint(1)) })
 	settingstransport.RegisterAdminRoutes(router, handler)
 	recorder := httptest.NewRecorder()
 	request := httptest.NewRequest(http.MethodPut, "/settings", bytes.NewBufferString(`{
@@ -0,0 +1,168 @@
+package integrationtest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	categorydomain "github.com/dujiao-next/internal/modules/catalog/category/domain"
+	categorygormstore "github.com/dujiao-next/internal/modules/catalog/category/infrastructure/gormstore"
+	resellermodule "github.com/dujiao-next/internal/modules/reseller/application"
+	resellerdomain "github.com/dujiao-next/internal/modules/reseller/domain"
+	resellergormstore "github.com/dujiao-next/internal/modules/reseller/infrastructure/gormstore"
+	"github.com/dujiao-next/internal/shared/jsonmap"
+
+	"gorm.io/gorm"
+)
+
+func newResellerThemeTestService(t *testing.T, email string) (*gorm.DB, *resellermodule.SiteConfigService, resellerdomain.Profile, []categorydomain.Category) {
+	t.Helper()
+	db := openResellerManagementServiceTestDB(t)
+	if err := db.AutoMigrate(&categorydomain.Category{}); err != nil {
+		t.Fatalf("migrate categories failed: %v", err)
+	}
+	user := seedResellerManagementUser(t, db, email)
+	profile := resellerdomain.Profile{UserID: user.ID, Status: resellerdomain.ProfileStatusActive, SettlementStatus: resellerdomain.SettlementStatusNormal}
+	if err := db.Create(&profile).Error; err != nil {
+		t.Fatalf("create profile failed: %v", err)
+	}
+	categories := []categorydomain.Category{
+		{Slug: "games", NameJSON: jsonmap.JSON{"zh-CN": "游戏"}},
+		{Slug: "retired", NameJSON: jsonmap.JSON{"zh-CN": "下架"}},
+	}
+	for i := range categories {
+		if err := db.Create(&categories[i]).Error; err != nil {
+			t.Fatalf("create category failed: %v", err)
+		}
+	}
+	if err := db.Model(&categories[1]).Update("is_active", false).Error; err != nil {
+		t.Fatalf("disable category failed: %v", err)
+	}
+	svc := NewResellerSiteConfigService(resellergormstore.New(db))
+	svc.SetCategoryLookup(categorygormstore.NewCategoryStore(db))
+	return db, svc, profile, categories
+}
+
+func TestResellerSiteConfigServiceStoresThemeAndLandingPages(t *testing.T) {
+	_, svc, profile, categories := newResellerThemeTestService(t, "site-theme@example.test")
+	row, err := svc.UpdateUserSiteConfig(context.Background(), profile.UserID, ResellerSiteConfigInput{
+		SiteName: "Theme Store",
+		Theme: resellermodule.ResellerThemeInput{
+			Template: "Vault",
+			Layout:   "wide",
+			Colors:   resellermodule.ResellerThemeColorsInput{Primary: "#FF6600", Text: "#222"},
+			Fonts:    resellermodule.ResellerThemeFontsInput{Heading: "noto_serif"},
+		},
+		Pages: []resellermodule.ResellerLandingPageInput{
+			{
+				Slug:    "spring-sale",
+				Title:   LocalizedTextInput{"zh-CN": "春季促销"},
+				Enabled: true,
+				Blocks: []resellermodule.ResellerPageBlockInput{
+					{Type: "hero", Title: LocalizedTextInput{"zh-CN": "限时折扣"}, ButtonURL: "/products", Image: "/uploads/reseller/hero.png"},
+					{Type: "product_grid", CategoryID: categories[0].ID, Limit: 100},
+					{Type: "rich_text", Content: LocalizedTextInput{"en-US": "<p>Hello</p>"}},
+					{Type: "faq", Items: []resellermodule.ResellerFAQItemInput{
+						{Question: LocalizedTextInput{"zh-CN": "多久发货？"}, Answer: LocalizedTextInput{"zh-CN": "自动发货"}},
+						{Question: LocalizedTextInput{}},
+					}},
+				},
+			},
+			{Slug: "draft", Enabled: false},
+		},
+	})
+	if err != nil {
+		t.Fatalf("save config failed: %v", err)
+	}
+	if row.ThemeJSON["template"] != "vault" || row.ThemeJSON["layout"] != "wide" {
+		t.Fatalf("unexpected theme: %+v", row.ThemeJSON)
+	}
+	colors := resellerSiteConfigTestMap(row.ThemeJSON["colors"])
+	if colors["primary"] != "#ff6600" || colors["text"] != "#222" || colors["accent"] != "" {
+		t.Fatalf("unexpected theme colors: %+v", colors)
+	}
+
+	tenant := ResellerTenantContext("theme.example.test", profile.ID, profile.UserID, "theme.example.test")
+	out, err := svc.ApplyPublicConfigOverlay(context.Background(), tenant, map[string]interface{}{"storefront_template": "classic"})
+	if err != nil {
+		t.Fatalf("apply overlay failed: %v", err)
+	}
+	if out["storefront_template"] != "vault" {
+		t.Fatalf("reseller template should override main template, got %v", out["storefront_template"])
+	}
+	pages, ok := out["landing_pages"].([]interface{})
+	if !ok || len(pages) != 1 {
+		t.Fatalf("expected only the enabled landing page, got %+v", out["landing_pages"])
+	}
+	page := resellerSiteConfigTestMap(pages[0])
+	blocks, _ := page["blocks"].([]interface{})
+	if page["slug"] != "spring-sale" || len(blocks) != 4 {
+		t.Fatalf("unexpected landing page: %+v", page)
+	}
+	grid := resellerSiteConfigTestMap(blocks[1])
+	if grid["limit"] != float64(24) {
+		t.Fatalf("product grid limit should be capped, got %+v", grid)
+	}
+	faq := resellerSiteConfigTestMap(blocks[3])
+	if items, _ := faq["items"].([]interface{}); len(items) != 1 {
+		t.Fatalf("empty faq items should be dropped, got %+v", faq)
+	}
+}
+
+func TestResellerSiteConfigServiceRejectsInvalidThemeAndBlocks(t *testing.T) {
+	_, svc, profile, categories := newResellerThemeTestService(t, "site-theme-invalid@example.test")
+	cases := []struct {
+		name  string
+		input ResellerSiteConfigInput
+		field string
+	}{
+		{"template", ResellerSiteConfigInput{Theme: resellermodule.ResellerThemeInput{Template: "neon"}}, "theme"},
+		{"color", ResellerSiteConfigInput{Theme: resellermodule.ResellerThemeInput{Colors: resellermodule.ResellerThemeColorsInput{Primary: "red;}"}}}, "theme"},
+		{"font", ResellerSiteConfigInput{Theme: resellermodule.ResellerThemeInput{Fonts: resellermodule.ResellerThemeFontsInput{Body: "https://evil.test/font.woff"}}}, "theme"},
+		{"slug", ResellerSiteConfigInput{Pages: []resellermodule.ResellerLandingPageInput{{Slug: "../admin"}}}, "page_slug"},
+		{"duplicate slug", ResellerSiteConfigInput{Pages: []resellermodule.ResellerLandingPageInput{{Slug: "promo"}, {Slug: "Promo"}}}, "page_slug"},
+		{"block type", ResellerSiteConfigInput{Pages: []resellermodule.ResellerLandingPageInput{{Slug: "promo", Blocks: []resellermodule.ResellerPageBlockInput{{Type: "script"}}}}}, "page_block"},
+		{"empty hero", ResellerSiteConfigInput{Pages: []resellermodule.ResellerLandingPageInput{{Slug: "promo", Blocks: []resellermodule.ResellerPageBlockInput{{Type: "hero"}}}}}, "page_block"},
+		{"hero link", ResellerSiteConfigInput{Pages: []resellermodule.ResellerLandingPageInput{{Slug: "promo", Blocks: []resellermodule.ResellerPageBlockInput{{Type: "hero", Title: LocalizedTextInput{"zh-CN": "x"}, ButtonURL: "javascript:alert(1)"}}}}}, "link"},
+		{"missing category", ResellerSiteConfigInput{Pages: []resellermodule.ResellerLandingPageInput{{Slug: "promo", Blocks: []resellermodule.ResellerPageBlockInput{{Type: "product_grid", CategoryID: 9999}}}}}, "page_category"},
+		{"inactive category", ResellerSiteConfigInput{Pages: []resellermodule.ResellerLandingPageInput{{Slug: "promo", Blocks: []resellermodule.ResellerPageBlockInput{{Type: "product_grid", CategoryID: categories[1].ID}}}}}, "page_category"},
+	}
+	for _, tc := range cases {
+		_, err := svc.UpdateUserSiteConfig(context.Background(), profile.UserID, tc.input)
+		if !errors.Is(err, ErrResellerSiteConfigInvalid) {
+			t.Fatalf("%s: expected invalid site config error, got %v", tc.name, err)
+		}
+		var fieldErr *ResellerSiteConfigFieldError
+		if !errors.As(err, &fieldErr) || fieldErr.Field != tc.field {
+			t.Fatalf("%s: expected field %q, got %v", tc.name, tc.field, err)
+		}
+	}
+}
+
+func TestResellerSiteConfigServicePreviewDoesNotPersist(t *testing.T) {
+	_, svc, profile, _ := newResellerThemeTestService(t, "site-theme-preview@example.test")
+	row, publicConfig, err := svc.PreviewUserSiteConfig(profile.UserID, ResellerSiteConfigInput{
+		SiteName: "Preview Store",
+		Theme:    resellermodule.ResellerThemeInput{Template: "classic"},
+	})
+	if err != nil {
+		t.Fatalf("preview failed: %v", err)
+	}
+	if row.SiteName != "Preview Store" || publicConfig["storefront_template"] != "classic" {
+		t.Fatalf("unexpected preview: row=%+v public=%+v", row, publicConfig)
+	}
+	if brand := resellerSiteConfigTestMap(publicConfig["brand"]); brand["site_name"] != "Preview Store" {
+		t.Fatalf("preview should carry the brand overlay, got %+v", publicConfig)
+	}
+	_, saved, _, err := svc.GetUserSiteConfig(profile.UserID)
+	if err != nil {
+		t.Fatalf("get config failed: %v", err)
+	}
+	if saved != nil {
+		t.Fatalf("preview must not persist site config, got %+v", saved)
+	}
+
+	if _, _, err := svc.PreviewAdminSiteConfig(profile.ID, ResellerSiteConfigInput{Theme: resellermodule.ResellerThemeInput{Layout: "zigzag"}}); !errors.Is(err, ErrResellerSiteConfigInvalid) {
+		t.Fatalf("admin preview should validate theme, got %v", err)
+	}
+}
@@ -0,0 +1,237 @@
+package integrationtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	userdomain "github.com/dujiao-next/internal/modules/identity/user/domain"
+	resellerdomain "github.com/dujiao-next/internal/modules/reseller/domain"
+	staffapp "github.com/dujiao-next/internal/modules/reseller/staff/application"
+	staffcontract "github.com/dujiao-next/internal/modules/reseller/staff/contract"
+	staffdomain "github.com/dujiao-next/internal/modules/reseller/staff/domain"
+	staffgormstore "github.com/dujiao-next/internal/modules/reseller/staff/infrastructure/gormstore"
+	staffhttp "github.com/dujiao-next/internal/modules/reseller/staff/transport/http"
+	"github.com/dujiao-next/internal/platform/http/ginutil"
+
+	"github.com/gin-gonic/gin"
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+)
+
+type staffTestFixture struct {
+	db      *gorm.DB
+	svc     *staffapp.Service
+	owner   userdomain.User
+	profile resellerdomain.Profile
+}
+
+func newStaffTestFixture(t *testing.T) staffTestFixture {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(fmt.Sprintf("file:reseller_staff_%d?mode=memory&cache=shared", time.Now().UnixNano())), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open db failed: %v", err)
+	}
+	if err := db.AutoMigrate(&userdomain.User{}, &resellerdomain.Profile{}, &staffdomain.Member{}, &staffdomain.ActivityLog{}); err != nil {
+		t.Fatalf("migrate failed: %v", err)
+	}
+	owner := seedResellerManagementUser(t, db, "owner@example.test")
+	profile := resellerdomain.Profile{UserID: owner.ID, Status: resellerdomain.ProfileStatusActive}
+	if err := db.Create(&profile).Error; err != nil {
+		t.Fatalf("create profile failed: %v", err)
+	}
+	return staffTestFixture{db: db, svc: staffapp.NewService(staffgormstore.New(db)), owner: owner, profile: profile}
+}
+
+func (f staffTestFixture) inviteAndAccept(t *testing.T, email string, permissions ...string) (userdomain.User, *staffdomain.Member) {
+	t.Helper()
+	staff := seedResellerManagementUser(t, f.db, email)
+	member, err := f.svc.InviteStaff(f.owner.ID, staffapp.InviteInput{Email: email, Permissions: permissions})
+	if err != nil {
+		t.Fatalf("invite staff failed: %v", err)
+	}
+	accepted, err := f.svc.AcceptInvitation(staff.ID, member.ID)
+	if err != nil {
+		t.Fatalf("accept invitation failed: %v", err)
+	}
+	return staff, accepted
+}
+
+func TestResellerStaffInviteAcceptAndResolvePermissions(t *testing.T) {
+	f := newStaffTestFixture(t)
+	staff := seedResellerManagementUser(t, f.db, "Staff@Example.test")
+
+	if _, err := f.svc.InviteStaff(f.owner.ID, staffapp.InviteInput{Email: "staff@example.test", Permissions: []string{"bogus"}}); !errors.Is(err, staffcontract.ErrPermissionInvalid) {
+		t.Fatalf("expected ErrPermissionInvalid, got %v", err)
+	}
+	if _, err := f.svc.InviteStaff(f.owner.ID, staffapp.InviteInput{Email: "owner@example.test"}); !errors.Is(err, staffcontract.ErrInviteeInvalid) {
+		t.Fatalf("expected owner self-invite rejected, got %v", err)
+	}
+	member, err := f.svc.InviteStaff(f.owner.ID, staffapp.InviteInput{
+		Email:       "staff@example.test",
+		Permissions: []string{staffdomain.PermissionWithdrawRequest, staffdomain.PermissionOrdersView, staffdomain.PermissionOrdersView},
+	})
+	if err != nil {
+		t.Fatalf("invite failed: %v", err)
+	}
+	if member.Status != staffdomain.MemberStatusInvited || len(member.Permissions) != 2 || member.Permissions[0] != staffdomain.PermissionOrdersView {
+		t.Fatalf("unexpected invited member: %+v", member)
+	}
+	if _, err := f.svc.InviteStaff(f.owner.ID, staffapp.InviteInput{Email: "staff@example.test"}); !errors.Is(err, staffcontract.ErrMemberExists) {
+		t.Fatalf("expected ErrMemberExists, got %v", err)
+	}
+
+	// 接受邀请前不具备员工身份
+	if actor, err := f.svc.ResolveConsoleActor(staff.ID, 0, staffdomain.PermissionOrdersView); err != nil || actor != nil {
+		t.Fatalf("expected no actor before acceptance, got actor=%+v err=%v", actor, err)
+	}
+	if _, err := f.svc.AcceptInvitation(f.owner.ID, member.ID); !errors.Is(err, staffcontract.ErrMemberNotFound) {
+		t.Fatalf("expected other user unable to accept, got %v", err)
+	}
+	if _, err := f.svc.AcceptInvitation(staff.ID, member.ID); err != nil {
+		t.Fatalf("accept failed: %v", err)
+	}
+
+	actor, err := f.svc.ResolveConsoleActor(staff.ID, 0, staffdomain.PermissionOrdersView)
+	if err != nil || actor == nil || !actor.IsStaff() || actor.OwnerUserID != f.owner.ID || actor.ResellerID != f.profile.ID {
+		t.Fatalf("unexpected staff actor: %+v err=%v", actor, err)
+	}
+	if _, err := f.svc.ResolveConsoleActor(staff.ID, 0, staffdomain.PermissionSiteConfigEdit); !errors.Is(err, staffcontract.ErrPermissionDenied) {
+		t.Fatalf("expected ErrPermissionDenied, got %v", err)
+	}
+	if _, err := f.svc.ResolveConsoleActor(staff.ID, 0, ""); !errors.Is(err, staffcontract.ErrOwnerOnly) {
+		t.Fatalf("expected ErrOwnerOnly, got %v", err)
+	}
+	ownerActor, err := f.svc.ResolveConsoleActor(f.owner.ID, 0, "")
+	if err != nil || ownerActor == nil || ownerActor.IsStaff() {
+		t.Fatalf("expected owner actor, got %+v err=%v", ownerActor, err)
+	}
+
+	if _, err := f.svc.UpdateStaffPermissions(f.owner.ID, member.ID, []string{staffdomain.PermissionSiteConfigEdit}); err != nil {
+		t.Fatalf("update permissions failed: %v", err)
+	}
+	if _, err := f.svc.ResolveConsoleActor(staff.ID, 0, staffdomain.PermissionOrdersView); !errors.Is(err, staffcontract.ErrPermissionDenied) {
+		t.Fatalf("expected revoked permission denied, got %v", err)
+	}
+
+	if err := f.svc.RemoveStaff(f.owner.ID, member.ID); err != nil {
+		t.Fatalf("remove staff failed: %v", err)
+	}
+	if actor, err := f.svc.ResolveConsoleActor(staff.ID, 0, staffdomain.PermissionSiteConfigEdit); err != nil || actor != nil {
+		t.Fatalf("expected removed staff to lose access, got actor=%+v err=%v", actor, err)
+	}
+
+	logs, total, err := f.svc.ListActivityLogs(f.owner.ID, staffcontract.ActivityLogFilter{Page: 1, PageSize: 20})
+	if err != nil {
+		t.Fatalf("list activity logs failed: %v", err)
+	}
+	if total != 4 || logs[0].Action != staffdomain.ActivityStaffRemove || logs[3].Action != staffdomain.ActivityStaffInvite {
+		t.Fatalf("expected invite/accept/update/remove events, got total=%d logs=%+v", total, logs)
+	}
+}
+
+func TestResellerStaffResolveRequiresExplicitResellerWhenAmbiguous(t *testing.T) {
+	f := newStaffTestFixture(t)
+	staff, _ := f.inviteAndAccept(t, "multi@example.test", staffdomain.PermissionOrdersView)
+
+	otherOwner := seedResellerManagementUser(t, f.db, "other-owner@example.test")
+	otherProfile := resellerdomain.Profile{UserID: otherOwner.ID, Status: resellerdomain.ProfileStatusActive}
+	if err := f.db.Create(&otherProfile).Error; err != nil {
+		t.Fatalf("create other profile failed: %v", err)
+	}
+	member, err := f.svc.InviteStaff(otherOwner.ID, staffapp.InviteInput{Email: "multi@example.test", Permissions: []string{staffdomain.PermissionWithdrawRequest}})
+	if err != nil {
+		t.Fatalf("invite by other owner failed: %v", err)
+	}
+	if _, err := f.svc.AcceptInvitation(staff.ID, member.ID); err != nil {
+		t.Fatalf("accept second invitation failed: %v", err)
+	}
+
+	if _, err := f.svc.ResolveConsoleActor(staff.ID, 0, staffdomain.PermissionOrdersView); !errors.Is(err, staffcontract.ErrResellerAmbiguous) {
+		t.Fatalf("expected ErrResellerAmbiguous, got %v", err)
+	}
+	actor, err := f.svc.ResolveConsoleActor(staff.ID, otherProfile.ID, staffdomain.PermissionWithdrawRequest)
+	if err != nil || actor == nil || actor.OwnerUserID != otherOwner.ID {
+		t.Fatalf("expected explicit reseller actor, got %+v err=%v", actor, err)
+	}
+	if _, err := f.svc.ResolveConsoleActor(staff.ID, otherProfile.ID, staffdomain.PermissionOrdersView); !errors.Is(err, staffcontract.ErrPermissionDenied) {
+		t.Fatalf("expected per-reseller permission scoping, got %v", err)
+	}
+}
+
+func TestResellerStaffConsoleAccessSwapsUserAndRecordsActivity(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	f := newStaffTestFixture(t)
+	staff, member := f.inviteAndAccept(t, "console@example.test", staffdomain.PermissionOrdersView)
+	handler := staffhttp.NewHandler(f.svc)
+
+	router := gin.New()
+	console := router.Group("/api/v1/user/reseller")
+	console.Use(func(c *gin.Context) {
+		var uid uint
+		_, _ = fmt.Sscan(c.GetHeader("X-Test-User"), &uid)
+		c.Set("user_id", uid)
+		c.Next()
+	}, handler.ConsoleAccess(console.BasePath()))
+	echo := func(c *gin.Context) {
+		uid, _ := ginutil.GetUserID(c)
+		actor, _ := c.Get(staffhttp.ContextActorUserIDKey)
+		c.JSON(http.StatusOK, gin.H{"user_id": uid, "actor": actor})
+	}
+	console.GET("/orders", echo)
+	console.PUT("/site-config", echo)
+	console.POST("/apply", echo)
+	staffhttp.RegisterConsoleRoutes(console, handler)
+
+	call := func(method, path string, userID uint) map[string]interface{} {
+		req := httptest.NewRequest(method, path, bytes.NewReader(nil))
+		req.Header.Set("X-Test-User", fmt.Sprint(userID))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		var body map[string]interface{}
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("decode %s %s failed: %v (%s)", method, path, err, w.Body.String())
+		}
+		return body
+	}
+
+	orders := call(http.MethodGet, "/api/v1/user/reseller/orders", staff.ID)
+	if uint(orders["user_id"].(float64)) != f.owner.ID || uint(orders["actor"].(float64)) != staff.ID {
+		t.Fatalf("expected staff request scoped to owner, got %+v", orders)
+	}
+	denied := call(http.MethodPut, "/api/v1/user/reseller/site-config", staff.ID)
+	if _, ok := denied["user_id"]; ok {
+		t.Fatalf("expected site-config denied for staff, got %+v", denied)
+	}
+	ownerOnly := call(http.MethodPost, "/api/v1/user/reseller/apply", staff.ID)
+	if _, ok := ownerOnly["user_id"]; ok {
+		t.Fatalf("expected owner-only route denied for staff, got %+v", ownerOnly)
+	}
+	owner := call(http.MethodPut, "/api/v1/user/reseller/site-config", f.owner.ID)
+	if uint(owner["user_id"].(float64)) != f.owner.ID || owner["actor"] != nil {
+		t.Fatalf("expected owner passthrough, got %+v", owner)
+	}
+	memberships := call(http.MethodGet, "/api/v1/user/reseller/staff-memberships", staff.ID)
+	if items, ok := memberships["data"].([]interface{}); !ok || len(items) != 1 {
+		t.Fatalf("expected staff to list own membership, got %+v", memberships)
+	}
+
+	var logs []staffdomain.ActivityLog
+	if err := f.db.Where("action = ?", staffdomain.ActivityConsoleRequest).Order("id asc").Find(&logs).Error; err != nil {
+		t.Fatalf("load activity logs failed: %v", err)
+	}
+	if len(logs) != 2 {
+		t.Fatalf("expected staff read and owner write recorded, got %+v", logs)
+	}
+	if logs[0].ActorUserID != staff.ID || logs[0].StaffMemberID == nil || *logs[0].StaffMemberID != member.ID || logs[0].Path != "/api/v1/user/reseller/orders" {
+		t.Fatalf("unexpected staff activity log: %+v", logs[0])
+	}
+	if logs[1].ActorUserID != f.owner.ID || logs[1].StaffMemberID != nil || logs[1].Method != http.MethodPut {
+		t.Fatalf("unexpected owner activity log: %+v", logs[1])
+	}
+}
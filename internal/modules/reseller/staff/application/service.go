@@ -0,0 +1,323 @@
+package application
+
+import (
+	"strings"
+	"time"
+
+	"github.com/dujiao-next/internal/constants"
+	resellercontract "github.com/dujiao-next/internal/modules/reseller/contract"
+	staffcontract "github.com/dujiao-next/internal/modules/reseller/staff/contract"
+	staffdomain "github.com/dujiao-next/internal/modules/reseller/staff/domain"
+	"github.com/dujiao-next/internal/shared/jsonslice"
+)
+
+const activityDetailMaxLength = 255
+
+// Service 管理分销商员工邀请、权限与控制台访问判定。
+type Service struct {
+	store staffcontract.Store
+}
+
+// InviteInput 店主邀请员工的参数。
+type InviteInput struct {
+	Email       string
+	Permissions []string
+}
+
+func NewService(store staffcontract.Store) *Service {
+	return &Service{store: store}
+}
+
+// ListStaff 店主查看本店全部员工（含待接受邀请）。
+func (s *Service) ListStaff(ownerUserID uint) ([]staffdomain.Member, error) {
+	resellerID, err := s.ownerResellerID(ownerUserID)
+	if err != nil {
+		return nil, err
+	}
+	return s.store.ListMembersByResellerID(resellerID)
+}
+
+// InviteStaff 店主按邮箱邀请平台用户成为员工，被邀请人接受后生效。
+func (s *Service) InviteStaff(ownerUserID uint, input InviteInput) (*staffdomain.Member, error) {
+	resellerID, err := s.ownerResellerID(ownerUserID)
+	if err != nil {
+		return nil, err
+	}
+	permissions, err := normalizePermissions(input.Permissions)
+	if err != nil {
+		return nil, err
+	}
+	email := strings.ToLower(strings.TrimSpace(input.Email))
+	if email == "" {
+		return nil, staffcontract.ErrInviteeInvalid
+	}
+	user, err := s.store.FindUserByEmail(email)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || user.ID == ownerUserID || user.Status != constants.UserStatusActive {
+		return nil, staffcontract.ErrInviteeInvalid
+	}
+	existing, err := s.store.GetMember(resellerID, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, staffcontract.ErrMemberExists
+	}
+	member := &staffdomain.Member{
+		ResellerID:  resellerID,
+		UserID:      user.ID,
+		Status:      staffdomain.MemberStatusInvited,
+		Permissions: permissions,
+		InvitedBy:   ownerUserID,
+	}
+	if err := s.store.CreateMember(member); err != nil {
+		return nil, err
+	}
+	s.recordEvent(resellerID, ownerUserID, nil, staffdomain.ActivityStaffInvite, email)
+	return s.store.GetMemberByID(member.ID)
+}
+
+// UpdateStaffPermissions 店主调整员工权限，立即对后续请求生效。
+func (s *Service) UpdateStaffPermissions(ownerUserID, memberID uint, permissions []string) (*staffdomain.Member, error) {
+	member, err := s.ownerMember(ownerUserID, memberID)
+	if err != nil {
+		return nil, err
+	}
+	normalized, err := normalizePermissions(permissions)
+	if err != nil {
+		return nil, err
+	}
+	member.Permissions = normalized
+	if err := s.store.UpdateMember(member); err != nil {
+		return nil, err
+	}
+	s.recordEvent(member.ResellerID, ownerUserID, nil, staffdomain.ActivityStaffUpdate, strings.Join(normalized, ","))
+	return s.store.GetMemberByID(member.ID)
+}
+
+// RemoveStaff 店主移除员工或撤回邀请。
+func (s *Service) RemoveStaff(ownerUserID, memberID uint) error {
+	member, err := s.ownerMember(ownerUserID, memberID)
+	if err != nil {
+		return err
+	}
+	if err := s.store.DeleteMember(member.ID); err != nil {
+		return err
+	}
+	s.recordEvent(member.ResellerID, ownerUserID, nil, staffdomain.ActivityStaffRemove, memberEmail(member))
+	return nil
+}
+
+// ListMemberships 用户查看自己收到的邀请与所属分销商。
+func (s *Service) ListMemberships(userID uint) ([]staffdomain.Member, error) {
+	if s == nil || s.store == nil || userID == 0 {
+		return nil, staffcontract.ErrMemberNotFound
+	}
+	return s.store.ListMembersByUserID(userID)
+}
+
+// AcceptInvitation 被邀请人接受员工邀请。
+func (s *Service) AcceptInvitation(userID, memberID uint) (*staffdomain.Member, error) {
+	member, err := s.selfMember(userID, memberID)
+	if err != nil {
+		return nil, err
+	}
+	if member.Status != staffdomain.MemberStatusActive {
+		now := time.Now()
+		member.Status = staffdomain.MemberStatusActive
+		member.AcceptedAt = &now
+		if err := s.store.UpdateMember(member); err != nil {
+			return nil, err
+		}
+		memberID := member.ID
+		s.recordEvent(member.ResellerID, userID, &memberID, staffdomain.ActivityStaffAccept, "")
+	}
+	return s.store.GetMemberByID(member.ID)
+}
+
+// LeaveMembership 员工拒绝邀请或主动退出分销商。
+func (s *Service) LeaveMembership(userID, memberID uint) error {
+	member, err := s.selfMember(userID, memberID)
+	if err != nil {
+		return err
+	}
+	if err := s.store.DeleteMember(member.ID); err != nil {
+		return err
+	}
+	s.recordEvent(member.ResellerID, userID, nil, staffdomain.ActivityStaffLeave, "")
+	return nil
+}
+
+// ResolveConsoleActor 判定控制台请求的执行者与权限。
+// resellerID 为 0 时优先按店主身份解析，否则使用唯一的已生效员工身份；
+// permission 为空表示仅店主可访问。用户既非店主也非员工时返回 nil，交由下游用例处理。
+func (s *Service) ResolveConsoleActor(userID, resellerID uint, permission string) (*staffcontract.ConsoleActor, error) {
+	if s == nil || s.store == nil || userID == 0 {
+		return nil, nil
+	}
+	profile, err := s.store.GetProfileByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if profile != nil && (resellerID == 0 || resellerID == profile.ID) {
+		return &staffcontract.ConsoleActor{ResellerID: profile.ID, OwnerUserID: userID, ActorUserID: userID}, nil
+	}
+
+	var member *staffdomain.Member
+	if resellerID == 0 {
+		memberships, err := s.store.ListMembersByUserID(userID)
+		if err != nil {
+			return nil, err
+		}
+		for i := range memberships {
+			if memberships[i].Status != staffdomain.MemberStatusActive {
+				continue
+			}
+			if member != nil {
+				return nil, staffcontract.ErrResellerAmbiguous
+			}
+			member = &memberships[i]
+		}
+		if member == nil {
+			return nil, nil
+		}
+	} else {
+		member, err = s.store.GetMember(resellerID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if member == nil || member.Status != staffdomain.MemberStatusActive {
+			return nil, staffcontract.ErrPermissionDenied
+		}
+	}
+
+	if permission == "" {
+		return nil, staffcontract.ErrOwnerOnly
+	}
+	if !member.HasPermission(permission) {
+		return nil, staffcontract.ErrPermissionDenied
+	}
+	owner, err := s.store.GetProfileByID(member.ResellerID)
+	if err != nil {
+		return nil, err
+	}
+	if owner == nil {
+		return nil, staffcontract.ErrMemberNotFound
+	}
+	return &staffcontract.ConsoleActor{
+		ResellerID:  owner.ID,
+		OwnerUserID: owner.UserID,
+		ActorUserID: userID,
+		StaffMember: member,
+	}, nil
+}
+
+// RecordActivity 写入控制台操作记录。
+func (s *Service) RecordActivity(log *staffdomain.ActivityLog) error {
+	if s == nil || s.store == nil || log == nil || log.ResellerID == 0 {
+		return nil
+	}
+	log.Detail = truncateActivityDetail(log.Detail)
+	if log.CreatedAt.IsZero() {
+		log.CreatedAt = time.Now()
+	}
+	return s.store.CreateActivityLog(log)
+}
+
+// ListActivityLogs 店主查看本店操作记录。
+func (s *Service) ListActivityLogs(ownerUserID uint, filter staffcontract.ActivityLogFilter) ([]staffdomain.ActivityLog, int64, error) {
+	resellerID, err := s.ownerResellerID(ownerUserID)
+	if err != nil {
+		return nil, 0, err
+	}
+	filter.ResellerID = resellerID
+	return s.store.ListActivityLogs(filter)
+}
+
+func (s *Service) ownerResellerID(ownerUserID uint) (uint, error) {
+	if s == nil || s.store == nil || ownerUserID == 0 {
+		return 0, resellercontract.ErrNotOpened
+	}
+	profile, err := s.store.GetProfileByUserID(ownerUserID)
+	if err != nil {
+		return 0, err
+	}
+	if profile == nil {
+		return 0, resellercontract.ErrNotOpened
+	}
+	return profile.ID, nil
+}
+
+func (s *Service) ownerMember(ownerUserID, memberID uint) (*staffdomain.Member, error) {
+	resellerID, err := s.ownerResellerID(ownerUserID)
+	if err != nil {
+		return nil, err
+	}
+	member, err := s.store.GetMemberByID(memberID)
+	if err != nil {
+		return nil, err
+	}
+	if member == nil || member.ResellerID != resellerID {
+		return nil, staffcontract.ErrMemberNotFound
+	}
+	return member, nil
+}
+
+func (s *Service) selfMember(userID, memberID uint) (*staffdomain.Member, error) {
+	if s == nil || s.store == nil || userID == 0 || memberID == 0 {
+		return nil, staffcontract.ErrMemberNotFound
+	}
+	member, err := s.store.GetMemberByID(memberID)
+	if err != nil {
+		return nil, err
+	}
+	if member == nil || member.UserID != userID {
+		return nil, staffcontract.ErrMemberNotFound
+	}
+	return member, nil
+}
+
+// recordEvent 记录员工管理事件；写入失败不影响主流程。
+func (s *Service) recordEvent(resellerID, actorUserID uint, staffMemberID *uint, action, detail string) {
+	_ = s.RecordActivity(&staffdomain.ActivityLog{
+		ResellerID:    resellerID,
+		ActorUserID:   actorUserID,
+		StaffMemberID: staffMemberID,
+		Action:        action,
+		Detail:        detail,
+	})
+}
+
+func normalizePermissions(raw []string) (jsonslice.Strings, error) {
+	requested := make(map[string]struct{}, len(raw))
+	for _, item := range raw {
+		requested[strings.TrimSpace(item)] = struct{}{}
+	}
+	result := make(jsonslice.Strings, 0, len(requested))
+	for _, permission := range staffdomain.AllPermissions() {
+		if _, ok := requested[permission]; ok {
+			result = append(result, permission)
+			delete(requested, permission)
+		}
+	}
+	if len(requested) > 0 {
+		return nil, staffcontract.ErrPermissionInvalid
+	}
+	return result, nil
+}
+
+func memberEmail(member *staffdomain.Member) string {
+	if member == nil || member.User == nil {
+		return ""
+	}
+	return member.User.Email
+}
+
+func truncateActivityDetail(detail string) string {
+	if len(detail) <= activityDetailMaxLength {
+		return detail
+	}
+	return detail[:activityDetailMaxLength]
+}
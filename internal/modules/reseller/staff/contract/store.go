@@ -0,0 +1,67 @@
+package contract
+
+import (
+	"errors"
+
+	userdomain "github.com/dujiao-next/internal/modules/identity/user/domain"
+	resellerdomain "github.com/dujiao-next/internal/modules/reseller/domain"
+	staffdomain "github.com/dujiao-next/internal/modules/reseller/staff/domain"
+)
+
+var (
+	// ErrMemberNotFound 表示员工记录不存在或不属于当前分销商。
+	ErrMemberNotFound = errors.New("reseller staff member not found")
+	// ErrMemberExists 表示该用户已是（或已被邀请为）当前分销商员工。
+	ErrMemberExists = errors.New("reseller staff member exists")
+	// ErrInviteeInvalid 表示被邀请用户不存在、已停用或为店主本人。
+	ErrInviteeInvalid = errors.New("reseller staff invitee invalid")
+	// ErrPermissionInvalid 表示提交了未知的员工权限。
+	ErrPermissionInvalid = errors.New("reseller staff permission invalid")
+	// ErrOwnerOnly 表示该操作仅分销商店主可执行。
+	ErrOwnerOnly = errors.New("reseller staff owner only")
+	// ErrPermissionDenied 表示员工缺少访问该功能所需的权限。
+	ErrPermissionDenied = errors.New("reseller staff permission denied")
+	// ErrResellerAmbiguous 表示用户同时隶属多个分销商，需要显式指定。
+	ErrResellerAmbiguous = errors.New("reseller staff reseller ambiguous")
+)
+
+// ActivityLogFilter 分销商操作记录查询条件。
+type ActivityLogFilter struct {
+	ResellerID  uint
+	ActorUserID uint
+	Action      string
+	Page        int
+	PageSize    int
+}
+
+// Store 是员工与操作记录的持久化端口，同时提供分销商资料与用户的只读查询。
+type Store interface {
+	GetProfileByUserID(userID uint) (*resellerdomain.Profile, error)
+	GetProfileByID(id uint) (*resellerdomain.Profile, error)
+	FindUserByEmail(email string) (*userdomain.User, error)
+
+	GetMemberByID(id uint) (*staffdomain.Member, error)
+	GetMember(resellerID, userID uint) (*staffdomain.Member, error)
+	ListMembersByResellerID(resellerID uint) ([]staffdomain.Member, error)
+	ListMembersByUserID(userID uint) ([]staffdomain.Member, error)
+	CreateMember(member *staffdomain.Member) error
+	UpdateMember(member *staffdomain.Member) error
+	DeleteMember(id uint) error
+
+	CreateActivityLog(log *staffdomain.ActivityLog) error
+	ListActivityLogs(filter ActivityLogFilter) ([]staffdomain.ActivityLog, int64, error)
+}
+
+// ConsoleActor 描述一次分销商控制台请求的实际执行者。
+// OwnerUserID 为分销商店主，StaffMember 非空时表示由员工代为操作。
+type ConsoleActor struct {
+	ResellerID  uint
+	OwnerUserID uint
+	ActorUserID uint
+	StaffMember *staffdomain.Member
+}
+
+// IsStaff 判断当前请求是否由员工发起。
+func (a ConsoleActor) IsStaff() bool {
+	return a.StaffMember != nil
+}
@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"time"
+
+	userdomain "github.com/dujiao-next/internal/modules/identity/user/domain"
+	resellerdomain "github.com/dujiao-next/internal/modules/reseller/domain"
+	"github.com/dujiao-next/internal/shared/jsonslice"
+)
+
+const (
+	MemberStatusInvited = "invited"
+	MemberStatusActive  = "active"
+
+	// PermissionOrdersView 查看分销销售订单。
+	PermissionOrdersView = "orders.view"
+	// PermissionProductSettingsEdit 查看与编辑分销商品配置。
+	PermissionProductSettingsEdit = "product_settings.edit"
+	// PermissionSiteConfigEdit 管理站点配置、域名与图片上传。
+	PermissionSiteConfigEdit = "site_config.edit"
+	// PermissionWithdrawRequest 查看分销财务并发起提现。
+	PermissionWithdrawRequest = "withdraw.request"
+	// PermissionConsoleBasic 控制台基础访问（资料概览），所有已接受邀请的员工默认拥有，不可单独授予。
+	PermissionConsoleBasic = "console.basic"
+
+	ActivityConsoleRequest = "console_request"
+	ActivityStaffInvite    = "staff_invite"
+	ActivityStaffUpdate    = "staff_update"
+	ActivityStaffRemove    = "staff_remove"
+	ActivityStaffAccept    = "staff_accept"
+	ActivityStaffLeave     = "staff_leave"
+)
+
+// AllPermissions 返回员工可被授予的全部权限，顺序即前端展示顺序。
+func AllPermissions() []string {
+	return []string{
+		PermissionOrdersView,
+		PermissionProductSettingsEdit,
+		PermissionSiteConfigEdit,
+		PermissionWithdrawRequest,
+	}
+}
+
+// Member 分销商员工（子账号）。ResellerID 指向分销商资料，UserID 为被邀请的平台用户。
+type Member struct {
+	ID          uint              `gorm:"primarykey" json:"id"`
+	ResellerID  uint              `gorm:"not null;uniqueIndex:idx_reseller_staff_member" json:"reseller_id"`
+	UserID      uint              `gorm:"not null;uniqueIndex:idx_reseller_staff_member;index" json:"user_id"`
+	Status      string            `gorm:"type:varchar(20);not null;default:'invited';index" json:"status"`
+	Permissions jsonslice.Strings `gorm:"type:json" json:"permissions"`
+	InvitedBy   uint              `gorm:"not null" json:"invited_by"`
+	AcceptedAt  *time.Time        `json:"accepted_at,omitempty"`
+	CreatedAt   time.Time         `gorm:"index" json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+
+	User    *userdomain.User        `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Profile *resellerdomain.Profile `gorm:"foreignKey:ResellerID" json:"profile,omitempty"`
+}
+
+func (Member) TableName() string { return "reseller_staff_members" }
+
+// HasPermission 判断员工是否拥有指定权限。
+func (m Member) HasPermission(permission string) bool {
+	if permission == PermissionConsoleBasic {
+		return true
+	}
+	for _, granted := range m.Permissions {
+		if granted == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// ActivityLog 分销商控制台操作记录，覆盖员工的全部访问与店主的写操作。
+type ActivityLog struct {
+	ID            uint      `gorm:"primarykey" json:"id"`
+	ResellerID    uint      `gorm:"not null;index:idx_reseller_activity_reseller_created,priority:1" json:"reseller_id"`
+	ActorUserID   uint      `gorm:"not null;index" json:"actor_user_id"`
+	StaffMemberID *uint     `gorm:"index" json:"staff_member_id,omitempty"`
+	Action        string    `gorm:"type:varchar(64);not null" json:"action"`
+	Method        string    `gorm:"type:varchar(16)" json:"method,omitempty"`
+	Path          string    `gorm:"type:varchar(255)" json:"path,omitempty"`
+	ClientIP      string    `gorm:"type:varchar(64)" json:"client_ip,omitempty"`
+	Detail        string    `gorm:"type:varchar(255)" json:"detail,omitempty"`
+	CreatedAt     time.Time `gorm:"index:idx_reseller_activity_reseller_created,priority:2" json:"created_at"`
+
+	Actor *userdomain.User `gorm:"foreignKey:ActorUserID" json:"actor,omitempty"`
+}
+
+func (ActivityLog) TableName() string { return "reseller_activity_logs" }
@@ -0,0 +1,166 @@
+package gormstore
+
+import (
+	"errors"
+
+	userdomain "github.com/dujiao-next/internal/modules/identity/user/domain"
+	resellerdomain "github.com/dujiao-next/internal/modules/reseller/domain"
+	staffcontract "github.com/dujiao-next/internal/modules/reseller/staff/contract"
+	staffdomain "github.com/dujiao-next/internal/modules/reseller/staff/domain"
+
+	"gorm.io/gorm"
+)
+
+// Store 持久化分销商员工与控制台操作记录。
+type Store struct {
+	db *gorm.DB
+}
+
+var _ staffcontract.Store = (*Store)(nil)
+
+func New(db *gorm.DB) *Store {
+	return &Store{db: db}
+}
+
+// GetProfileByUserID 按店主用户 ID 获取分销商资料。
+func (r *Store) GetProfileByUserID(userID uint) (*resellerdomain.Profile, error) {
+	if userID == 0 {
+		return nil, nil
+	}
+	return r.firstProfile(r.db.Where("user_id = ? AND deleted_at IS NULL", userID))
+}
+
+// GetProfileByID 按 ID 获取分销商资料。
+func (r *Store) GetProfileByID(id uint) (*resellerdomain.Profile, error) {
+	if id == 0 {
+		return nil, nil
+	}
+	return r.firstProfile(r.db.Where("id = ? AND deleted_at IS NULL", id))
+}
+
+func (r *Store) firstProfile(query *gorm.DB) (*resellerdomain.Profile, error) {
+	var profile resellerdomain.Profile
+	if err := query.First(&profile).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &profile, nil
+}
+
+// FindUserByEmail 按邮箱（忽略大小写）查找用户。
+func (r *Store) FindUserByEmail(email string) (*userdomain.User, error) {
+	var user userdomain.User
+	if err := r.db.Where("LOWER(email) = ? AND deleted_at IS NULL", email).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+// GetMemberByID 按 ID 获取员工记录。
+func (r *Store) GetMemberByID(id uint) (*staffdomain.Member, error) {
+	if id == 0 {
+		return nil, nil
+	}
+	return r.firstMember(r.db.Where("id = ?", id))
+}
+
+// GetMember 按分销商与用户获取员工记录。
+func (r *Store) GetMember(resellerID, userID uint) (*staffdomain.Member, error) {
+	if resellerID == 0 || userID == 0 {
+		return nil, nil
+	}
+	return r.firstMember(r.db.Where("reseller_id = ? AND user_id = ?", resellerID, userID))
+}
+
+func (r *Store) firstMember(query *gorm.DB) (*staffdomain.Member, error) {
+	var member staffdomain.Member
+	if err := query.Preload("User").Preload("Profile").Preload("Profile.User").First(&member).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &member, nil
+}
+
+// ListMembersByResellerID 列出分销商全部员工。
+func (r *Store) ListMembersByResellerID(resellerID uint) ([]staffdomain.Member, error) {
+	var members []staffdomain.Member
+	err := r.db.Preload("User").
+		Where("reseller_id = ?", resellerID).
+		Order("id asc").
+		Find(&members).Error
+	return members, err
+}
+
+// ListMembersByUserID 列出用户收到的邀请与所属分销商。
+func (r *Store) ListMembersByUserID(userID uint) ([]staffdomain.Member, error) {
+	var members []staffdomain.Member
+	err := r.db.Preload("Profile").Preload("Profile.User").
+		Where("user_id = ?", userID).
+		Order("id asc").
+		Find(&members).Error
+	return members, err
+}
+
+// CreateMember 创建员工记录。
+func (r *Store) CreateMember(member *staffdomain.Member) error {
+	if member == nil {
+		return errors.New("reseller staff member is nil")
+	}
+	return r.db.Create(member).Error
+}
+
+// UpdateMember 更新员工状态与权限。
+func (r *Store) UpdateMember(member *staffdomain.Member) error {
+	if member == nil || member.ID == 0 {
+		return errors.New("invalid reseller staff member")
+	}
+	return r.db.Model(&staffdomain.Member{}).Where("id = ?", member.ID).Updates(map[string]interface{}{
+		"status":      member.Status,
+		"permissions": member.Permissions,
+		"accepted_at": member.AcceptedAt,
+	}).Error
+}
+
+// DeleteMember 删除员工记录（移除员工/撤回或拒绝邀请）。
+func (r *Store) DeleteMember(id uint) error {
+	return r.db.Delete(&staffdomain.Member{}, id).Error
+}
+
+// CreateActivityLog 写入操作记录。
+func (r *Store) CreateActivityLog(log *staffdomain.ActivityLog) error {
+	if log == nil {
+		return errors.New("reseller activity log is nil")
+	}
+	return r.db.Create(log).Error
+}
+
+// ListActivityLogs 按条件分页查询操作记录。
+func (r *Store) ListActivityLogs(filter staffcontract.ActivityLogFilter) ([]staffdomain.ActivityLog, int64, error) {
+	query := r.db.Model(&staffdomain.ActivityLog{}).Where("reseller_id = ?", filter.ResellerID)
+	if filter.ActorUserID > 0 {
+		query = query.Where("actor_user_id = ?", filter.ActorUserID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if filter.Page > 0 && filter.PageSize > 0 {
+		query = query.Offset((filter.Page - 1) * filter.PageSize).Limit(filter.PageSize)
+	}
+	var logs []staffdomain.ActivityLog
+	if err := query.Preload("Actor").Order("created_at desc, id desc").Find(&logs).Error; err != nil {
+		return nil, 0, err
+	}
+	return logs, total, nil
+}
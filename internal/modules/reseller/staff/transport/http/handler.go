@@ -0,0 +1,400 @@
+package staffhttp
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	resellercontract "github.com/dujiao-next/internal/modules/reseller/contract"
+	staffapp "github.com/dujiao-next/internal/modules/reseller/staff/application"
+	staffcontract "github.com/dujiao-next/internal/modules/reseller/staff/contract"
+	staffdomain "github.com/dujiao-next/internal/modules/reseller/staff/domain"
+	"github.com/dujiao-next/internal/platform/http/ginutil"
+	"github.com/dujiao-next/internal/platform/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// ResellerHeader 员工隶属多个分销商时，用于指定本次操作的分销商 ID。
+	ResellerHeader = "X-Reseller-ID"
+	// ContextActorUserIDKey 员工代操作时保存真实操作者用户 ID；此时 user_id 已替换为店主 ID。
+	ContextActorUserIDKey = "reseller_actor_user_id"
+	// ContextStaffMemberIDKey 员工代操作时保存员工记录 ID。
+	ContextStaffMemberIDKey = "reseller_staff_member_id"
+)
+
+// Service 是员工端点与控制台访问判定所需的最小用例接口。
+type Service interface {
+	ListStaff(ownerUserID uint) ([]staffdomain.Member, error)
+	InviteStaff(ownerUserID uint, input staffapp.InviteInput) (*staffdomain.Member, error)
+	UpdateStaffPermissions(ownerUserID, memberID uint, permissions []string) (*staffdomain.Member, error)
+	RemoveStaff(ownerUserID, memberID uint) error
+	ListMemberships(userID uint) ([]staffdomain.Member, error)
+	AcceptInvitation(userID, memberID uint) (*staffdomain.Member, error)
+	LeaveMembership(userID, memberID uint) error
+	ResolveConsoleActor(userID, resellerID uint, permission string) (*staffcontract.ConsoleActor, error)
+	RecordActivity(log *staffdomain.ActivityLog) error
+	ListActivityLogs(ownerUserID uint, filter staffcontract.ActivityLogFilter) ([]staffdomain.ActivityLog, int64, error)
+}
+
+// Handler 处理分销商员工管理、员工邀请与控制台访问控制。
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	if service == nil {
+		panic("reseller staff handler: service is nil")
+	}
+	return &Handler{service: service}
+}
+
+// InviteStaffRequest 邀请员工请求。
+type InviteStaffRequest struct {
+	Email       string   `json:"email" binding:"required,email"`
+	Permissions []string `json:"permissions"`
+}
+
+// UpdateStaffRequest 更新员工权限请求。
+type UpdateStaffRequest struct {
+	Permissions []string `json:"permissions"`
+}
+
+// StaffMemberResp 店主视角的员工信息。
+type StaffMemberResp struct {
+	ID          uint       `json:"id"`
+	UserID      uint       `json:"user_id"`
+	Email       string     `json:"email"`
+	DisplayName string     `json:"display_name"`
+	Status      string     `json:"status"`
+	Permissions []string   `json:"permissions"`
+	AcceptedAt  *time.Time `json:"accepted_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// MembershipResp 员工视角的所属分销商信息。
+type MembershipResp struct {
+	ID               uint       `json:"id"`
+	ResellerID       uint       `json:"reseller_id"`
+	OwnerDisplayName string     `json:"owner_display_name"`
+	Status           string     `json:"status"`
+	Permissions      []string   `json:"permissions"`
+	AcceptedAt       *time.Time `json:"accepted_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+// ActivityLogResp 控制台操作记录。
+type ActivityLogResp struct {
+	ID            uint      `json:"id"`
+	ActorUserID   uint      `json:"actor_user_id"`
+	ActorEmail    string    `json:"actor_email"`
+	StaffMemberID *uint     `json:"staff_member_id,omitempty"`
+	Action        string    `json:"action"`
+	Method        string    `json:"method,omitempty"`
+	Path          string    `json:"path,omitempty"`
+	ClientIP      string    `json:"client_ip,omitempty"`
+	Detail        string    `json:"detail,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// ListStaff 店主查看员工列表，同时返回可授予的权限。
+func (h *Handler) ListStaff(c *gin.Context) {
+	uid, ok := ginutil.GetUserID(c)
+	if !ok {
+		return
+	}
+	members, err := h.service.ListStaff(uid)
+	if err != nil {
+		respondStaffError(c, err, "error.reseller_staff_fetch_failed")
+		return
+	}
+	items := make([]StaffMemberResp, 0, len(members))
+	for i := range members {
+		items = append(items, newStaffMemberResp(&members[i]))
+	}
+	response.Success(c, gin.H{"items": items, "permissions": staffdomain.AllPermissions()})
+}
+
+// InviteStaff 店主按邮箱邀请员工。
+func (h *Handler) InviteStaff(c *gin.Context) {
+	uid, ok := ginutil.GetUserID(c)
+	if !ok {
+		return
+	}
+	var req InviteStaffRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ginutil.RespondBindError(c, err)
+		return
+	}
+	member, err := h.service.InviteStaff(uid, staffapp.InviteInput{Email: req.Email, Permissions: req.Permissions})
+	if err != nil {
+		respondStaffError(c, err, "error.reseller_staff_save_failed")
+		return
+	}
+	response.Success(c, newStaffMemberResp(member))
+}
+
+// UpdateStaff 店主调整员工权限。
+func (h *Handler) UpdateStaff(c *gin.Context) {
+	uid, ok := ginutil.GetUserID(c)
+	if !ok {
+		return
+	}
+	id, err := ginutil.ParseParamUint(c, "id")
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", err)
+		return
+	}
+	var req UpdateStaffRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ginutil.RespondBindError(c, err)
+		return
+	}
+	member, err := h.service.UpdateStaffPermissions(uid, id, req.Permissions)
+	if err != nil {
+		respondStaffError(c, err, "error.reseller_staff_save_failed")
+		return
+	}
+	response.Success(c, newStaffMemberResp(member))
+}
+
+// RemoveStaff 店主移除员工或撤回邀请。
+func (h *Handler) RemoveStaff(c *gin.Context) {
+	uid, ok := ginutil.GetUserID(c)
+	if !ok {
+		return
+	}
+	id, err := ginutil.ParseParamUint(c, "id")
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", err)
+		return
+	}
+	if err := h.service.RemoveStaff(uid, id); err != nil {
+		respondStaffError(c, err, "error.reseller_staff_save_failed")
+		return
+	}
+	response.Success(c, nil)
+}
+
+// ListActivityLogs 店主查看控制台操作记录。
+func (h *Handler) ListActivityLogs(c *gin.Context) {
+	uid, ok := ginutil.GetUserID(c)
+	if !ok {
+		return
+	}
+	page, pageSize := ginutil.ParsePagination(c)
+	actorUserID, err := ginutil.ParseQueryUint(c.Query("actor_user_id"), false)
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", err)
+		return
+	}
+	logs, total, err := h.service.ListActivityLogs(uid, staffcontract.ActivityLogFilter{
+		ActorUserID: actorUserID,
+		Action:      strings.TrimSpace(c.Query("action")),
+		Page:        page,
+		PageSize:    pageSize,
+	})
+	if err != nil {
+		respondStaffError(c, err, "error.reseller_staff_fetch_failed")
+		return
+	}
+	items := make([]ActivityLogResp, 0, len(logs))
+	for i := range logs {
+		items = append(items, newActivityLogResp(&logs[i]))
+	}
+	response.SuccessWithPage(c, items, response.BuildPagination(page, pageSize, total))
+}
+
+// ListMemberships 当前用户查看收到的邀请与所属分销商。
+func (h *Handler) ListMemberships(c *gin.Context) {
+	uid, ok := ginutil.GetUserID(c)
+	if !ok {
+		return
+	}
+	members, err := h.service.ListMemberships(uid)
+	if err != nil {
+		respondStaffError(c, err, "error.reseller_staff_fetch_failed")
+		return
+	}
+	items := make([]MembershipResp, 0, len(members))
+	for i := range members {
+		items = append(items, newMembershipResp(&members[i]))
+	}
+	response.Success(c, items)
+}
+
+// AcceptMembership 当前用户接受员工邀请。
+func (h *Handler) AcceptMembership(c *gin.Context) {
+	uid, ok := ginutil.GetUserID(c)
+	if !ok {
+		return
+	}
+	id, err := ginutil.ParseParamUint(c, "id")
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", err)
+		return
+	}
+	member, err := h.service.AcceptInvitation(uid, id)
+	if err != nil {
+		respondStaffError(c, err, "error.reseller_staff_save_failed")
+		return
+	}
+	response.Success(c, newMembershipResp(member))
+}
+
+// LeaveMembership 当前用户拒绝邀请或退出分销商。
+func (h *Handler) LeaveMembership(c *gin.Context) {
+	uid, ok := ginutil.GetUserID(c)
+	if !ok {
+		return
+	}
+	id, err := ginutil.ParseParamUint(c, "id")
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", err)
+		return
+	}
+	if err := h.service.LeaveMembership(uid, id); err != nil {
+		respondStaffError(c, err, "error.reseller_staff_save_failed")
+		return
+	}
+	response.Success(c, nil)
+}
+
+// ConsoleAccess 分销商控制台访问控制中间件。
+// 员工请求按路由所需权限校验，通过后将 user_id 替换为店主 ID，使下游用例天然按店主范围执行；
+// 员工的全部请求与店主的写操作都会写入操作记录。basePath 为控制台路由组前缀。
+func (h *Handler) ConsoleAccess(basePath string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := c.Request.Method + " " + strings.TrimPrefix(c.FullPath(), basePath)
+		if _, ok := selfServiceRoutes[route]; ok {
+			c.Next()
+			return
+		}
+		uid, ok := ginutil.GetUserID(c)
+		if !ok {
+			c.Abort()
+			return
+		}
+		resellerID, err := ginutil.ParseQueryUint(strings.TrimSpace(c.GetHeader(ResellerHeader)), false)
+		if err != nil {
+			ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", err)
+			c.Abort()
+			return
+		}
+		actor, err := h.service.ResolveConsoleActor(uid, resellerID, consolePermissions[route])
+		if err != nil {
+			respondStaffError(c, err, "error.forbidden")
+			c.Abort()
+			return
+		}
+		if actor == nil {
+			c.Next()
+			return
+		}
+		log := &staffdomain.ActivityLog{
+			ResellerID:  actor.ResellerID,
+			ActorUserID: uid,
+			Action:      staffdomain.ActivityConsoleRequest,
+			Method:      c.Request.Method,
+			Path:        c.FullPath(),
+			ClientIP:    c.ClientIP(),
+		}
+		if actor.IsStaff() {
+			memberID := actor.StaffMember.ID
+			log.StaffMemberID = &memberID
+			c.Set("user_id", actor.OwnerUserID)
+			c.Set(ContextActorUserIDKey, uid)
+			c.Set(ContextStaffMemberIDKey, memberID)
+		}
+		c.Next()
+		if !actor.IsStaff() && c.Request.Method == http.MethodGet {
+			return
+		}
+		if err := h.service.RecordActivity(log); err != nil {
+			ginutil.RequestLog(c).Warnw("reseller_activity_log_write_failed", "reseller_id", actor.ResellerID, "error", err)
+		}
+	}
+}
+
+var staffErrorRules = []struct {
+	target error
+	code   int
+	key    string
+}{
+	{target: resellercontract.ErrNotOpened, code: response.CodeBadRequest, key: "error.bad_request"},
+	{target: staffcontract.ErrMemberNotFound, code: response.CodeNotFound, key: "error.reseller_staff_not_found"},
+	{target: staffcontract.ErrMemberExists, code: response.CodeBadRequest, key: "error.reseller_staff_exists"},
+	{target: staffcontract.ErrInviteeInvalid, code: response.CodeBadRequest, key: "error.reseller_staff_invitee_invalid"},
+	{target: staffcontract.ErrPermissionInvalid, code: response.CodeBadRequest, key: "error.reseller_staff_permission_invalid"},
+	{target: staffcontract.ErrOwnerOnly, code: response.CodeForbidden, key: "error.reseller_staff_owner_only"},
+	{target: staffcontract.ErrPermissionDenied, code: response.CodeForbidden, key: "error.reseller_staff_permission_denied"},
+	{target: staffcontract.ErrResellerAmbiguous, code: response.CodeBadRequest, key: "error.reseller_staff_reseller_ambiguous"},
+}
+
+func respondStaffError(c *gin.Context, err error, fallbackKey string) {
+	for _, rule := range staffErrorRules {
+		if errors.Is(err, rule.target) {
+			ginutil.RespondError(c, rule.code, rule.key, nil)
+			return
+		}
+	}
+	ginutil.RespondError(c, response.CodeInternal, fallbackKey, err)
+}
+
+func newStaffMemberResp(member *staffdomain.Member) StaffMemberResp {
+	resp := StaffMemberResp{
+		ID:          member.ID,
+		UserID:      member.UserID,
+		Status:      member.Status,
+		Permissions: nonNilPermissions(member.Permissions),
+		AcceptedAt:  member.AcceptedAt,
+		CreatedAt:   member.CreatedAt,
+	}
+	if member.User != nil {
+		resp.Email = member.User.Email
+		resp.DisplayName = member.User.DisplayName
+	}
+	return resp
+}
+
+func newMembershipResp(member *staffdomain.Member) MembershipResp {
+	resp := MembershipResp{
+		ID:          member.ID,
+		ResellerID:  member.ResellerID,
+		Status:      member.Status,
+		Permissions: nonNilPermissions(member.Permissions),
+		AcceptedAt:  member.AcceptedAt,
+		CreatedAt:   member.CreatedAt,
+	}
+	if member.Profile != nil && member.Profile.User != nil {
+		resp.OwnerDisplayName = member.Profile.User.DisplayName
+	}
+	return resp
+}
+
+func newActivityLogResp(log *staffdomain.ActivityLog) ActivityLogResp {
+	resp := ActivityLogResp{
+		ID:            log.ID,
+		ActorUserID:   log.ActorUserID,
+		StaffMemberID: log.StaffMemberID,
+		Action:        log.Action,
+		Method:        log.Method,
+		Path:          log.Path,
+		ClientIP:      log.ClientIP,
+		Detail:        log.Detail,
+		CreatedAt:     log.CreatedAt,
+	}
+	if log.Actor != nil {
+		resp.ActorEmail = log.Actor.Email
+	}
+	return resp
+}
+
+func nonNilPermissions(permissions []string) []string {
+	if permissions == nil {
+		return []string{}
+	}
+	return permissions
+}
@@ -0,0 +1,59 @@
+package staffhttp
+
+import (
+	staffdomain "github.com/dujiao-next/internal/modules/reseller/staff/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// consolePermissions 控制台路由（相对控制台前缀）到员工所需权限的映射。
+// 未列出的路由仅店主可访问，新增控制台路由时需要在此显式授权给员工。
+var consolePermissions = map[string]string{
+	"GET /profile": staffdomain.PermissionConsoleBasic,
+
+	"GET /domains":                               staffdomain.PermissionSiteConfigEdit,
+	"POST /domains":                              staffdomain.PermissionSiteConfigEdit,
+	"POST /domains/:id/verify":                   staffdomain.PermissionSiteConfigEdit,
+	"GET /domains/:id/verification-attempts":     staffdomain.PermissionSiteConfigEdit,
+	"GET /site-config":                           staffdomain.PermissionSiteConfigEdit,
+	"PUT /site-config":                           staffdomain.PermissionSiteConfigEdit,
+	"POST /site-config/preview":                  staffdomain.PermissionSiteConfigEdit,
+	"POST /upload":                               staffdomain.PermissionSiteConfigEdit,
+	"GET /product-settings":                      staffdomain.PermissionProductSettingsEdit,
+	"GET /product-settings/:product_id":          staffdomain.PermissionProductSettingsEdit,
+	"POST /product-settings/:product_id/preview": staffdomain.PermissionProductSettingsEdit,
+	"PUT /product-settings/:product_id":          staffdomain.PermissionProductSettingsEdit,
+	"DELETE /product-settings/:product_id":       staffdomain.PermissionProductSettingsEdit,
+	"GET /dashboard":                             staffdomain.PermissionWithdrawRequest,
+	"GET /balance-accounts":                      staffdomain.PermissionWithdrawRequest,
+	"GET /ledger-entries":                        staffdomain.PermissionWithdrawRequest,
+	"GET /withdraws":                             staffdomain.PermissionWithdrawRequest,
+	"POST /withdraws":                            staffdomain.PermissionWithdrawRequest,
+	"GET /orders":                                staffdomain.PermissionOrdersView,
+	"GET /orders/stats":                          staffdomain.PermissionOrdersView,
+	"GET /orders/:order_no":                      staffdomain.PermissionOrdersView,
+}
+
+// selfServiceRoutes 员工处理自身邀请的路由，以当前用户身份执行，不经过控制台访问判定。
+var selfServiceRoutes = map[string]struct{}{
+	"GET /staff-memberships":             {},
+	"POST /staff-memberships/:id/accept": {},
+	"DELETE /staff-memberships/:id":      {},
+}
+
+// RegisterConsoleRoutes 注册员工管理与邀请处理路由。
+// 调用方必须在注册任何控制台路由之前为同一 RouterGroup 挂载 ConsoleAccess。
+func RegisterConsoleRoutes(console gin.IRoutes, handler *Handler) {
+	if console == nil || handler == nil {
+		panic("reseller staff routes: required dependency is nil")
+	}
+	console.GET("/staff", handler.ListStaff)
+	console.POST("/staff", handler.InviteStaff)
+	console.PUT("/staff/:id", handler.UpdateStaff)
+	console.DELETE("/staff/:id", handler.RemoveStaff)
+	console.GET("/activity-logs", handler.ListActivityLogs)
+
+	console.GET("/staff-memberships", handler.ListMemberships)
+	console.POST("/staff-memberships/:id/accept", handler.AcceptMembership)
+	console.DELETE("/staff-memberships/:id", handler.LeaveMembership)
+}
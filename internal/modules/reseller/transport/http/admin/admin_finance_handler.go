@@ -116,6 +116,25 @@ func (h *AdminFinanceHandler) ListWithdraws(c *gin.Context) {
 	response.SuccessWithPage(c, rows, response.BuildPagination(page, pageSize, total))
 }
 
+// ApproveWithdraw 审核通过分销提现申请，等待单笔打款或加入打款批次。
+func (h *AdminFinanceHandler) ApproveWithdraw(c *gin.Context) {
+	adminID, ok := ginutil.GetAdminID(c)
+	if !ok {
+		return
+	}
+	id, err := ginutil.ParseParamUint(c, "id")
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", nil)
+		return
+	}
+	row, err := h.reviewer.ReviewWithdraw(adminID, id, "approve", "")
+	if err != nil {
+		respondAdminWithdrawReviewError(c, err)
+		return
+	}
+	response.Success(c, row)
+}
+
 // RejectWithdraw 拒绝分销提现申请。
 func (h *AdminFinanceHandler) RejectWithdraw(c *gin.Context) {
 	adminID, ok := ginutil.GetAdminID(c)
@@ -29,6 +29,8 @@ type AdminManagementService interface {
 	ApproveDomain(ctx context.Context, adminID, domainID uint) (*resellerdomain.Domain, error)
 	DisableDomain(ctx context.Context, adminID, domainID uint) (*resellerdomain.Domain, error)
 	SetPrimaryDomain(ctx context.Context, adminID, domainID uint) (*resellerdomain.Domain, error)
+	VerifyDomain(ctx context.Context, adminID, domainID uint) (*resellerdomain.Domain, error)
+	ListDomainVerificationAttempts(domainID uint) ([]resellerdomain.DomainVerificationAttempt, error)
 }
 
 type AuditRecorder interface {
@@ -335,6 +337,26 @@ func (h *AdminManagementHandler) handleProfileReasonAction(
 	response.Success(c, row)
 }
 
+// VerifyDomain 立即执行自定义域名 DNS TXT / HTTP 校验。
+func (h *AdminManagementHandler) VerifyDomain(c *gin.Context) {
+	h.handleDomainAction(c, "reseller_domain_verify", "/admin/resellers/domains/:id/verify", h.management.VerifyDomain)
+}
+
+// ListDomainVerificationAttempts 查询域名最近的校验记录。
+func (h *AdminManagementHandler) ListDomainVerificationAttempts(c *gin.Context) {
+	id, err := ginutil.ParseParamUint(c, "id")
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", nil)
+		return
+	}
+	rows, err := h.management.ListDomainVerificationAttempts(id)
+	if err != nil {
+		respondAdminManagementError(c, err)
+		return
+	}
+	response.Success(c, dto.NewResellerDomainVerificationAttemptRespList(rows))
+}
+
 func (h *AdminManagementHandler) handleDomainAction(
 	c *gin.Context,
 	action string,
@@ -20,6 +20,7 @@ import (
 type AdminSiteConfigService interface {
 	UpdateAdminSiteConfig(ctx context.Context, resellerID uint, input resellermodule.ResellerSiteConfigInput) (*resellerdomain.SiteConfig, error)
 	ResetAdminSiteConfig(ctx context.Context, resellerID uint) error
+	PreviewAdminSiteConfig(resellerID uint, input resellermodule.ResellerSiteConfigInput) (*resellerdomain.SiteConfig, map[string]interface{}, error)
 }
 
 type SiteConfigDirectory interface {
@@ -42,14 +43,16 @@ func NewAdminSiteConfigHandler(siteConfig AdminSiteConfigService, directory Site
 }
 
 type adminSiteConfigRequest struct {
-	SiteName     string                                   `json:"site_name"`
-	Logo         string                                   `json:"logo"`
-	Favicon      string                                   `json:"favicon"`
-	Announcement resellermodule.ResellerAnnouncementInput `json:"announcement"`
-	Support      resellermodule.ResellerSupportInput      `json:"support"`
-	SEO          resellermodule.ResellerSEOInput          `json:"seo"`
-	FooterLinks  []resellermodule.ResellerFooterLinkInput `json:"footer_links"`
-	NavConfig    resellermodule.ResellerNavConfigInput    `json:"nav_config"`
+	SiteName     string                                    `json:"site_name"`
+	Logo         string                                    `json:"logo"`
+	Favicon      string                                    `json:"favicon"`
+	Announcement resellermodule.ResellerAnnouncementInput  `json:"announcement"`
+	Support      resellermodule.ResellerSupportInput       `json:"support"`
+	SEO          resellermodule.ResellerSEOInput           `json:"seo"`
+	FooterLinks  []resellermodule.ResellerFooterLinkInput  `json:"footer_links"`
+	NavConfig    resellermodule.ResellerNavConfigInput     `json:"nav_config"`
+	Theme        resellermodule.ResellerThemeInput         `json:"theme"`
+	Pages        []resellermodule.ResellerLandingPageInput `json:"pages"`
 }
 
 func (req adminSiteConfigRequest) toInput() resellermodule.ResellerSiteConfigInput {
@@ -62,6 +65,8 @@ func (req adminSiteConfigRequest) toInput() resellermodule.ResellerSiteConfigInp
 		SEO:          req.SEO,
 		FooterLinks:  req.FooterLinks,
 		NavConfig:    req.NavConfig,
+		Theme:        req.Theme,
+		Pages:        req.Pages,
 	}
 }
 
@@ -135,12 +140,32 @@ func (h *AdminSiteConfigHandler) UpdateSiteConfig(c *gin.Context) {
 		"reseller_id":    resellerID,
 		"config_id":      row.ID,
 		"site_name":      row.SiteName,
-		"changed_fields": []string{"site_name", "logo", "favicon", "announcement", "support", "seo", "footer_links", "nav_config"},
+		"changed_fields": []string{"site_name", "logo", "favicon", "announcement", "support", "seo", "footer_links", "nav_config", "theme", "pages"},
 		"source":         "admin",
 	})
 	response.Success(c, dto.NewAdminResellerSiteConfigResp(row))
 }
 
+// PreviewSiteConfig 管理端预览分销站点配置，仅校验不保存。
+func (h *AdminSiteConfigHandler) PreviewSiteConfig(c *gin.Context) {
+	resellerID, err := ginutil.ParseParamUint(c, "reseller_id")
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", nil)
+		return
+	}
+	var req adminSiteConfigRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ginutil.RespondBindError(c, err)
+		return
+	}
+	row, publicConfig, err := h.siteConfig.PreviewAdminSiteConfig(resellerID, req.toInput())
+	if err != nil {
+		respondAdminManagementError(c, err)
+		return
+	}
+	response.Success(c, dto.NewResellerSiteConfigPreviewResp(row, publicConfig))
+}
+
 // ResetSiteConfig 管理端重置分销站点配置。
 func (h *AdminSiteConfigHandler) ResetSiteConfig(c *gin.Context) {
 	resellerID, err := ginutil.ParseParamUint(c, "reseller_id")
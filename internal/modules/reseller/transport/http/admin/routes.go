@@ -17,6 +17,8 @@ func RegisterManagementRoutes(admin gin.IRoutes, handler *AdminManagementHandler
 	admin.POST("/resellers/domains/:id/approve", handler.ApproveDomain)
 	admin.POST("/resellers/domains/:id/disable", handler.DisableDomain)
 	admin.POST("/resellers/domains/:id/set-primary", handler.SetPrimaryDomain)
+	admin.POST("/resellers/domains/:id/verify", handler.VerifyDomain)
+	admin.GET("/resellers/domains/:id/verification-attempts", handler.ListDomainVerificationAttempts)
 }
 
 func RegisterProfileDetailRoutes(admin gin.IRoutes, handler *AdminProfileDetailHandler) {
@@ -33,6 +35,7 @@ func RegisterSiteConfigRoutes(admin gin.IRoutes, handler *AdminSiteConfigHandler
 	admin.GET("/resellers/site-configs", handler.ListSiteConfigs)
 	admin.GET("/resellers/site-configs/:reseller_id", handler.GetSiteConfig)
 	admin.PUT("/resellers/site-configs/:reseller_id", handler.UpdateSiteConfig)
+	admin.POST("/resellers/site-configs/:reseller_id/preview", handler.PreviewSiteConfig)
 	admin.POST("/resellers/site-configs/:reseller_id/reset", handler.ResetSiteConfig)
 }
 
@@ -68,6 +71,7 @@ func RegisterFinanceRoutes(admin gin.IRoutes, handler *AdminFinanceHandler) {
 	admin.GET("/resellers/ledger-entries", handler.ListLedgerEntries)
 	admin.GET("/resellers/balance-accounts", handler.ListBalanceAccounts)
 	admin.GET("/resellers/withdraws", handler.ListWithdraws)
+	admin.POST("/resellers/withdraws/:id/approve", handler.ApproveWithdraw)
 	admin.POST("/resellers/withdraws/:id/reject", handler.RejectWithdraw)
 	admin.POST("/resellers/withdraws/:id/pay", handler.PayWithdraw)
 }
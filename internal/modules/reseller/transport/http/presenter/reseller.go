@@ -81,10 +81,26 @@ type ResellerDomainResp struct {
 	Status             string     `json:"status"`
 	IsPrimary          bool       `json:"is_primary"`
 	VerifiedAt         *time.Time `json:"verified_at,omitempty"`
+	LastCheckedAt      *time.Time `json:"last_checked_at,omitempty"`
+	CheckFailureCount  int        `json:"check_failure_count"`
+	LastCheckError     string     `json:"last_check_error,omitempty"`
+	TXTRecordName      string     `json:"txt_record_name,omitempty"`
+	TXTRecordValue     string     `json:"txt_record_value,omitempty"`
+	ChallengePath      string     `json:"challenge_path,omitempty"`
 	CreatedAt          time.Time  `json:"created_at"`
 	UpdatedAt          time.Time  `json:"updated_at"`
 }
 
+type ResellerDomainVerificationAttemptResp struct {
+	ID        uint      `json:"id"`
+	DomainID  uint      `json:"domain_id"`
+	Method    string    `json:"method"`
+	Trigger   string    `json:"trigger"`
+	Success   bool      `json:"success"`
+	Detail    string    `json:"detail,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
 type ResellerManagementSnapshotResp struct {
 	Opened   bool                           `json:"opened"`
 	CanApply bool                           `json:"can_apply"`
@@ -102,9 +118,17 @@ type ResellerSiteConfigResp struct {
 	SEO          jsonmap.JSON  `json:"seo"`
 	FooterLinks  []interface{} `json:"footer_links"`
 	NavConfig    jsonmap.JSON  `json:"nav_config"`
+	Theme        jsonmap.JSON  `json:"theme"`
+	Pages        []interface{} `json:"pages"`
 	UpdatedAt    time.Time     `json:"updated_at"`
 }
 
+// ResellerSiteConfigPreviewResp 站点配置预览：归一化后的配置及前台 public config 覆盖片段。
+type ResellerSiteConfigPreviewResp struct {
+	Config       *ResellerSiteConfigResp `json:"config"`
+	PublicConfig map[string]interface{}  `json:"public_config"`
+}
+
 type ResellerSiteConfigSnapshotResp struct {
 	Opened  bool                    `json:"opened"`
 	CanEdit bool                    `json:"can_edit"`
@@ -136,6 +160,8 @@ type AdminResellerSiteConfigResp struct {
 	SEO          jsonmap.JSON                      `json:"seo"`
 	FooterLinks  []interface{}                     `json:"footer_links"`
 	NavConfig    jsonmap.JSON                      `json:"nav_config"`
+	Theme        jsonmap.JSON                      `json:"theme"`
+	Pages        []interface{}                     `json:"pages"`
 	Profile      *ResellerSiteConfigProfileRefResp `json:"profile,omitempty"`
 	CreatedAt    time.Time                         `json:"created_at"`
 	UpdatedAt    time.Time                         `json:"updated_at"`
@@ -260,7 +286,7 @@ func NewResellerDomainResp(row *resellerdomain.Domain) ResellerDomainResp {
 	if row == nil {
 		return ResellerDomainResp{}
 	}
-	return ResellerDomainResp{
+	resp := ResellerDomainResp{
 		ID:                 row.ID,
 		Domain:             row.Domain,
 		Type:               row.Type,
@@ -269,9 +295,34 @@ func NewResellerDomainResp(row *resellerdomain.Domain) ResellerDomainResp {
 		Status:             row.Status,
 		IsPrimary:          row.IsPrimary,
 		VerifiedAt:         row.VerifiedAt,
+		LastCheckedAt:      row.LastCheckedAt,
+		CheckFailureCount:  row.CheckFailureCount,
+		LastCheckError:     row.LastCheckError,
 		CreatedAt:          row.CreatedAt,
 		UpdatedAt:          row.UpdatedAt,
 	}
+	if row.Type == resellerdomain.DomainTypeCustom && row.VerificationToken != "" {
+		resp.TXTRecordName = resellerdomain.DomainVerificationTXTLabel + "." + row.Domain
+		resp.TXTRecordValue = resellerdomain.DomainVerificationTXTValuePrefix + row.VerificationToken
+		resp.ChallengePath = resellerdomain.DomainChallengePathPrefix + row.VerificationToken
+	}
+	return resp
+}
+
+func NewResellerDomainVerificationAttemptRespList(rows []resellerdomain.DomainVerificationAttempt) []ResellerDomainVerificationAttemptResp {
+	result := make([]ResellerDomainVerificationAttemptResp, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, ResellerDomainVerificationAttemptResp{
+			ID:        row.ID,
+			DomainID:  row.DomainID,
+			Method:    row.Method,
+			Trigger:   row.Trigger,
+			Success:   row.Success,
+			Detail:    row.Detail,
+			CreatedAt: row.CreatedAt,
+		})
+	}
+	return result
 }
 
 func NewResellerDomainRespList(rows []resellerdomain.Domain) []ResellerDomainResp {
@@ -306,13 +357,22 @@ func NewResellerSiteConfigResp(row *resellerdomain.SiteConfig) *ResellerSiteConf
 		Announcement: row.AnnouncementJSON,
 		Support:      row.SupportJSON,
 		SEO:          row.SEOJSON,
-		FooterLinks:  resellerFooterLinksFromEnvelope(row.FooterLinksJSON),
+		FooterLinks:  resellerItemsFromEnvelope(row.FooterLinksJSON),
 		NavConfig:    row.NavConfigJSON,
+		Theme:        row.ThemeJSON,
+		Pages:        resellerItemsFromEnvelope(row.PagesJSON),
 		UpdatedAt:    row.UpdatedAt,
 	}
 }
 
-func resellerFooterLinksFromEnvelope(raw jsonmap.JSON) []interface{} {
+func NewResellerSiteConfigPreviewResp(row *resellerdomain.SiteConfig, publicConfig map[string]interface{}) ResellerSiteConfigPreviewResp {
+	return ResellerSiteConfigPreviewResp{
+		Config:       NewResellerSiteConfigResp(row),
+		PublicConfig: publicConfig,
+	}
+}
+
+func resellerItemsFromEnvelope(raw jsonmap.JSON) []interface{} {
 	if raw == nil {
 		return make([]interface{}, 0)
 	}
@@ -339,7 +399,7 @@ func NewResellerSiteConfigSnapshotResp(profile *resellerdomain.Profile, row *res
 
 func NewAdminResellerSiteConfigResp(row *resellerdomain.SiteConfig) AdminResellerSiteConfigResp {
 	if row == nil {
-		return AdminResellerSiteConfigResp{FooterLinks: make([]interface{}, 0)}
+		return AdminResellerSiteConfigResp{FooterLinks: make([]interface{}, 0), Pages: make([]interface{}, 0)}
 	}
 	var profile *ResellerSiteConfigProfileRefResp
 	if row.Profile != nil {
@@ -366,8 +426,10 @@ func NewAdminResellerSiteConfigResp(row *resellerdomain.SiteConfig) AdminReselle
 		Announcement: row.AnnouncementJSON,
 		Support:      row.SupportJSON,
 		SEO:          row.SEOJSON,
-		FooterLinks:  resellerFooterLinksFromEnvelope(row.FooterLinksJSON),
+		FooterLinks:  resellerItemsFromEnvelope(row.FooterLinksJSON),
 		NavConfig:    row.NavConfigJSON,
+		Theme:        row.ThemeJSON,
+		Pages:        resellerItemsFromEnvelope(row.PagesJSON),
 		Profile:      profile,
 		CreatedAt:    row.CreatedAt,
 		UpdatedAt:    row.UpdatedAt,
@@ -26,6 +26,8 @@ var userManagementErrorRules = []mappedError{
 	{target: resellermodule.ErrDomainMainHostNotAllowed, code: response.CodeBadRequest, key: "error.reseller_domain_main_host_not_allowed"},
 	{target: resellermodule.ErrDomainConflict, code: response.CodeBadRequest, key: "error.reseller_domain_conflict"},
 	{target: resellermodule.ErrSiteConfigInvalid, code: response.CodeBadRequest, key: "error.reseller_site_config_invalid"},
+	{target: resellermodule.ErrDomainVerificationUnavailable, code: response.CodeBadRequest, key: "error.reseller_domain_verification_unavailable"},
+	{target: productcontract.ErrNotFound, code: response.CodeNotFound, key: "error.not_found"},
 }
 
 func respondUserManagementError(c *gin.Context, err error, fallbackKey string) {
@@ -52,6 +54,14 @@ func siteConfigFieldErrorKey(field string) string {
 		return "error.reseller_image_invalid"
 	case "link":
 		return "error.reseller_link_invalid"
+	case "theme":
+		return "error.reseller_theme_invalid"
+	case "page_slug":
+		return "error.reseller_page_slug_invalid"
+	case "page_block":
+		return "error.reseller_page_block_invalid"
+	case "page_category":
+		return "error.reseller_page_category_invalid"
 	default:
 		return "error.bad_request"
 	}
@@ -12,11 +12,22 @@ func RegisterUserConsoleRoutes(console gin.IRoutes, handler *UserHandler) {
 	console.POST("/apply", handler.ApplyProfile)
 	console.GET("/domains", handler.ListDomains)
 	console.POST("/domains", handler.SubmitCustomDomain)
+	console.POST("/domains/:id/verify", handler.VerifyDomain)
+	console.GET("/domains/:id/verification-attempts", handler.ListDomainVerificationAttempts)
 	console.GET("/site-config", handler.GetSiteConfig)
 	console.PUT("/site-config", handler.UpdateSiteConfig)
+	console.POST("/site-config/preview", handler.PreviewSiteConfig)
 	console.POST("/upload", handler.UploadImage)
 }
 
+// RegisterDomainChallengeRoutes 注册自定义域名 HTTP 校验路由，必须挂在根路由上且无需登录。
+func RegisterDomainChallengeRoutes(root gin.IRoutes, handler *UserHandler) {
+	if root == nil || handler == nil {
+		panic("reseller domain challenge routes: required dependency is nil")
+	}
+	root.GET("/.well-known/dujiao-next/:token", handler.ServeDomainChallenge)
+}
+
 // RegisterUserProductSettingRoutes 注册用户中心分销商品配置路由。
 func RegisterUserProductSettingRoutes(console gin.IRoutes, handler *UserProductSettingHandler) {
 	if console == nil || handler == nil {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"mime/multipart"
+	"net/http"
 
 	resellerdomain "github.com/dujiao-next/internal/modules/reseller/domain"
 
@@ -21,11 +22,15 @@ type ManagementService interface {
 	GetUserManagementSnapshot(userID uint) (*resellerdomain.Profile, []resellerdomain.Domain, bool, error)
 	ApplyUserReseller(userID uint, input resellermodule.ResellerApplyInput) (*resellerdomain.Profile, error)
 	SubmitUserCustomDomain(userID uint, rawDomain string) (*resellerdomain.Domain, error)
+	VerifyUserDomain(ctx context.Context, userID, domainID uint) (*resellerdomain.Domain, []resellerdomain.DomainVerificationAttempt, error)
+	ListUserDomainVerificationAttempts(userID, domainID uint) ([]resellerdomain.DomainVerificationAttempt, error)
+	MatchDomainChallenge(req *http.Request, token string) (bool, error)
 }
 
 type SiteConfigService interface {
 	GetUserSiteConfig(userID uint) (*resellerdomain.Profile, *resellerdomain.SiteConfig, bool, error)
 	UpdateUserSiteConfig(ctx context.Context, userID uint, input resellermodule.ResellerSiteConfigInput) (*resellerdomain.SiteConfig, error)
+	PreviewUserSiteConfig(userID uint, input resellermodule.ResellerSiteConfigInput) (*resellerdomain.SiteConfig, map[string]interface{}, error)
 }
 
 type UploadService interface {
@@ -54,14 +59,16 @@ type customDomainRequest struct {
 }
 
 type siteConfigRequest struct {
-	SiteName     string                                   `json:"site_name"`
-	Logo         string                                   `json:"logo"`
-	Favicon      string                                   `json:"favicon"`
-	Announcement resellermodule.ResellerAnnouncementInput `json:"announcement"`
-	Support      resellermodule.ResellerSupportInput      `json:"support"`
-	SEO          resellermodule.ResellerSEOInput          `json:"seo"`
-	FooterLinks  []resellermodule.ResellerFooterLinkInput `json:"footer_links"`
-	NavConfig    resellermodule.ResellerNavConfigInput    `json:"nav_config"`
+	SiteName     string                                    `json:"site_name"`
+	Logo         string                                    `json:"logo"`
+	Favicon      string                                    `json:"favicon"`
+	Announcement resellermodule.ResellerAnnouncementInput  `json:"announcement"`
+	Support      resellermodule.ResellerSupportInput       `json:"support"`
+	SEO          resellermodule.ResellerSEOInput           `json:"seo"`
+	FooterLinks  []resellermodule.ResellerFooterLinkInput  `json:"footer_links"`
+	NavConfig    resellermodule.ResellerNavConfigInput     `json:"nav_config"`
+	Theme        resellermodule.ResellerThemeInput         `json:"theme"`
+	Pages        []resellermodule.ResellerLandingPageInput `json:"pages"`
 }
 
 func (req siteConfigRequest) toInput() resellermodule.ResellerSiteConfigInput {
@@ -74,6 +81,8 @@ func (req siteConfigRequest) toInput() resellermodule.ResellerSiteConfigInput {
 		SEO:          req.SEO,
 		FooterLinks:  req.FooterLinks,
 		NavConfig:    req.NavConfig,
+		Theme:        req.Theme,
+		Pages:        req.Pages,
 	}
 }
 
@@ -147,6 +156,58 @@ func (h *UserHandler) SubmitCustomDomain(c *gin.Context) {
 	response.Success(c, dto.NewResellerDomainResp(row))
 }
 
+// VerifyDomain 立即校验当前用户的自定义域名（DNS TXT / HTTP 挑战）。
+func (h *UserHandler) VerifyDomain(c *gin.Context) {
+	uid, ok := ginutil.GetUserID(c)
+	if !ok {
+		return
+	}
+	id, err := ginutil.ParseParamUint(c, "id")
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", nil)
+		return
+	}
+	row, attempts, err := h.management.VerifyUserDomain(c.Request.Context(), uid, id)
+	if err != nil {
+		respondUserManagementError(c, err, "error.save_failed")
+		return
+	}
+	response.Success(c, gin.H{
+		"domain":   dto.NewResellerDomainResp(row),
+		"attempts": dto.NewResellerDomainVerificationAttemptRespList(attempts),
+	})
+}
+
+// ListDomainVerificationAttempts 查询当前用户自定义域名最近的校验记录。
+func (h *UserHandler) ListDomainVerificationAttempts(c *gin.Context) {
+	uid, ok := ginutil.GetUserID(c)
+	if !ok {
+		return
+	}
+	id, err := ginutil.ParseParamUint(c, "id")
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", nil)
+		return
+	}
+	rows, err := h.management.ListUserDomainVerificationAttempts(uid, id)
+	if err != nil {
+		respondUserManagementError(c, err, "error.user_fetch_failed")
+		return
+	}
+	response.Success(c, dto.NewResellerDomainVerificationAttemptRespList(rows))
+}
+
+// ServeDomainChallenge 响应自定义域名 HTTP 校验：Host 对应域名的 token 与路径一致时原样返回 token。
+func (h *UserHandler) ServeDomainChallenge(c *gin.Context) {
+	token := c.Param("token")
+	matched, err := h.management.MatchDomainChallenge(c.Request, token)
+	if err != nil || !matched {
+		c.String(http.StatusNotFound, "not found")
+		return
+	}
+	c.String(http.StatusOK, token)
+}
+
 // GetSiteConfig 获取当前用户的分销站点配置。
 func (h *UserHandler) GetSiteConfig(c *gin.Context) {
 	uid, ok := ginutil.GetUserID(c)
@@ -185,6 +246,30 @@ func (h *UserHandler) UpdateSiteConfig(c *gin.Context) {
 	response.Success(c, dto.NewResellerSiteConfigResp(row))
 }
 
+// PreviewSiteConfig 预览站点配置（主题与落地页），仅校验不保存。
+func (h *UserHandler) PreviewSiteConfig(c *gin.Context) {
+	uid, ok := ginutil.GetUserID(c)
+	if !ok {
+		return
+	}
+	var req siteConfigRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ginutil.RespondBindError(c, err)
+		return
+	}
+	row, publicConfig, err := h.siteConfig.PreviewUserSiteConfig(uid, req.toInput())
+	if err != nil {
+		var fieldErr *resellermodule.ResellerSiteConfigFieldError
+		if errors.As(err, &fieldErr) {
+			ginutil.RespondError(c, response.CodeBadRequest, siteConfigFieldErrorKey(fieldErr.Field), nil)
+			return
+		}
+		respondUserManagementError(c, err, "error.bad_request")
+		return
+	}
+	response.Success(c, dto.NewResellerSiteConfigPreviewResp(row, publicConfig))
+}
+
 // UploadImage 分销商上传站点图片。
 func (h *UserHandler) UploadImage(c *gin.Context) {
 	uid, ok := ginutil.GetUserID(c)
@@ -48,6 +48,18 @@ func (s managementStub) SubmitUserCustomDomain(userID uint, rawDomain string) (*
 	return &resellerdomain.Domain{Domain: rawDomain}, nil
 }
 
+func (s managementStub) VerifyUserDomain(context.Context, uint, uint) (*resellerdomain.Domain, []resellerdomain.DomainVerificationAttempt, error) {
+	return nil, nil, s.err
+}
+
+func (s managementStub) ListUserDomainVerificationAttempts(uint, uint) ([]resellerdomain.DomainVerificationAttempt, error) {
+	return nil, s.err
+}
+
+func (s managementStub) MatchDomainChallenge(*http.Request, string) (bool, error) {
+	return false, s.err
+}
+
 type siteConfigStub struct {
 	profile *resellerdomain.Profile
 	row     *resellerdomain.SiteConfig
@@ -66,6 +78,13 @@ func (s siteConfigStub) UpdateUserSiteConfig(ctx context.Context, userID uint, i
 	return &resellerdomain.SiteConfig{SiteName: input.SiteName, Logo: input.Logo}, nil
 }
 
+func (s siteConfigStub) PreviewUserSiteConfig(userID uint, input resellermodule.ResellerSiteConfigInput) (*resellerdomain.SiteConfig, map[string]interface{}, error) {
+	if s.err != nil {
+		return nil, nil, s.err
+	}
+	return &resellerdomain.SiteConfig{SiteName: input.SiteName}, map[string]interface{}{"brand": map[string]interface{}{"site_name": input.SiteName}}, nil
+}
+
 type uploadStub struct{}
 
 func (uploadStub) SaveFileWithMeta(file *multipart.FileHeader, category string) (*uploadcontract.Result, error) {
@@ -0,0 +1,370 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/dujiao-next/internal/logger"
+	restockcontract "github.com/dujiao-next/internal/modules/restock/contract"
+	restockdomain "github.com/dujiao-next/internal/modules/restock/domain"
+	"github.com/dujiao-next/internal/shared/jsonmap"
+	"github.com/dujiao-next/internal/shared/mailbrand"
+)
+
+// UnsubscribePath 退订链接路径，token 由订阅生成时随机分配。
+const UnsubscribePath = "/api/v1/public/restock-subscriptions/unsubscribe"
+
+// scanWindowFactor 单次检查扫描的待通知订阅数相对批量上限的倍数，
+// 用于跳过仍缺货的规格后继续按先后顺序挑选可通知的订阅。
+const scanWindowFactor = 4
+
+// Options 描述到货提醒服务依赖；Queue 为空时检查与发送在后台协程中执行。
+type Options struct {
+	Store     restockcontract.Store
+	Stock     restockcontract.StockReader
+	Contacts  restockcontract.ContactReader
+	Queue     restockcontract.Queue
+	Messenger restockcontract.Messenger
+	Brands    mailbrand.Resolver
+	Now       func() time.Time
+}
+
+// Service 编排到货订阅、到货检查与提醒发送。
+type Service struct {
+	store     restockcontract.Store
+	stock     restockcontract.StockReader
+	contacts  restockcontract.ContactReader
+	queue     restockcontract.Queue
+	messenger restockcontract.Messenger
+	brands    mailbrand.Resolver
+	now       func() time.Time
+}
+
+func NewService(options Options) *Service {
+	if options.Store == nil || options.Stock == nil || options.Contacts == nil || options.Messenger == nil {
+		panic("restock service: required dependency is nil")
+	}
+	now := options.Now
+	if now == nil {
+		now = time.Now
+	}
+	return &Service{
+		store:     options.Store,
+		stock:     options.Stock,
+		contacts:  options.Contacts,
+		queue:     options.Queue,
+		messenger: options.Messenger,
+		brands:    options.Brands,
+		now:       now,
+	}
+}
+
+// Subscribe 订阅缺货商品的到货提醒；同一用户或邮箱重复订阅返回已有订阅。
+func (s *Service) Subscribe(input restockcontract.SubscribeInput) (*restockdomain.Subscription, error) {
+	if input.ProductID == 0 {
+		return nil, restockcontract.ErrSubscriptionInvalid
+	}
+	email := ""
+	if input.UserID == 0 {
+		email = restockdomain.NormalizeEmail(input.Email)
+		if _, err := mail.ParseAddress(email); err != nil || email == "" {
+			return nil, restockcontract.ErrSubscriptionInvalid
+		}
+	}
+	stock, err := s.stock.GetProductStock(input.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	if stock == nil || !stock.IsActive {
+		return nil, restockcontract.ErrProductNotFound
+	}
+	if !stock.HasSKU(input.SKUID) {
+		return nil, restockcontract.ErrSubscriptionInvalid
+	}
+	if stock.Available(input.SKUID) != 0 {
+		return nil, restockcontract.ErrProductInStock
+	}
+
+	existing, err := s.store.FindPending(input.ProductID, input.SKUID, input.UserID, email)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return existing, nil
+	}
+	token, err := newToken()
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	subscription := &restockdomain.Subscription{
+		ProductID: input.ProductID,
+		SKUID:     input.SKUID,
+		UserID:    input.UserID,
+		Email:     email,
+		Locale:    strings.TrimSpace(input.Locale),
+		Token:     token,
+		Status:    restockdomain.StatusPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.store.Create(subscription); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+// ListUserSubscriptions 列出登录用户的到货订阅。
+func (s *Service) ListUserSubscriptions(userID uint) ([]restockdomain.Subscription, error) {
+	if userID == 0 {
+		return nil, restockcontract.ErrSubscriptionInvalid
+	}
+	return s.store.ListByUser(userID)
+}
+
+// CancelUserSubscription 登录用户取消自己的订阅。
+func (s *Service) CancelUserSubscription(userID, id uint) error {
+	subscription, err := s.store.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if subscription == nil || userID == 0 || subscription.UserID != userID {
+		return restockcontract.ErrSubscriptionNotFound
+	}
+	_, err = s.store.Unsubscribe(subscription.ID, s.now())
+	return err
+}
+
+// Unsubscribe 通过邮件中的退订链接取消订阅，重复退订视为成功。
+func (s *Service) Unsubscribe(token string) error {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return restockcontract.ErrSubscriptionNotFound
+	}
+	subscription, err := s.store.GetByToken(token)
+	if err != nil {
+		return err
+	}
+	if subscription == nil {
+		return restockcontract.ErrSubscriptionNotFound
+	}
+	_, err = s.store.Unsubscribe(subscription.ID, s.now())
+	return err
+}
+
+// ListSubscriptions 后台查询订阅。
+func (s *Service) ListSubscriptions(filter restockcontract.ListFilter) ([]restockdomain.Subscription, int64, error) {
+	filter.Status = strings.TrimSpace(filter.Status)
+	return s.store.List(filter)
+}
+
+// ListProductDemand 后台按待通知订阅数倒序列出商品，用于安排补货优先级。
+func (s *Service) ListProductDemand(page, pageSize int) ([]restockcontract.ProductCount, int64, error) {
+	return s.store.CountPendingByProduct(page, pageSize)
+}
+
+// TriggerCheck 库存可能增加后调度商品的到货检查，失败仅记录日志。
+func (s *Service) TriggerCheck(productID uint) {
+	if productID == 0 {
+		return
+	}
+	s.scheduleCheck(productID, 0)
+}
+
+// TriggerPendingChecks 为所有存在待通知订阅的商品调度到货检查，用于上游库存同步后。
+func (s *Service) TriggerPendingChecks() error {
+	productIDs, err := s.store.ListPendingProductIDs()
+	if err != nil {
+		return err
+	}
+	for _, productID := range productIDs {
+		s.scheduleCheck(productID, 0)
+	}
+	return nil
+}
+
+// CheckProduct 按订阅先后挑选已到货的订阅并调度提醒。
+// 有限库存时每件库存最多通知 NotifyPerUnit 个订阅；达到批量上限时间隔 NotifyInterval 继续下一批。
+func (s *Service) CheckProduct(productID uint) error {
+	stock, err := s.stock.GetProductStock(productID)
+	if err != nil {
+		return err
+	}
+	if stock == nil || !stock.IsActive {
+		return nil
+	}
+	window := restockdomain.NotifyBatchSize * scanWindowFactor
+	pending, err := s.store.ListPending(productID, window)
+	if err != nil {
+		return err
+	}
+
+	budgets := make(map[uint]int64)
+	selected := make([]uint, 0, restockdomain.NotifyBatchSize)
+	for _, subscription := range pending {
+		if len(selected) >= restockdomain.NotifyBatchSize {
+			break
+		}
+		budget, ok := budgets[subscription.SKUID]
+		if !ok {
+			budget = stock.Available(subscription.SKUID)
+			if budget > 0 {
+				budget *= restockdomain.NotifyPerUnit
+			}
+		}
+		if budget == 0 {
+			budgets[subscription.SKUID] = 0
+			continue
+		}
+		if budget > 0 {
+			budget--
+		}
+		budgets[subscription.SKUID] = budget
+		selected = append(selected, subscription.ID)
+	}
+	if len(selected) == 0 {
+		return nil
+	}
+
+	notified, err := s.store.MarkNotified(selected, s.now())
+	if err != nil {
+		return err
+	}
+	for _, id := range notified {
+		s.scheduleDeliver(id)
+	}
+	logger.Infow("restock_notifications_scheduled", "product_id", productID, "count", len(notified))
+	if len(selected) >= restockdomain.NotifyBatchSize {
+		s.scheduleCheck(productID, restockdomain.NotifyInterval)
+	}
+	return nil
+}
+
+// Deliver 发送单个订阅的到货提醒；邮件失败返回错误以便重试，Telegram 失败仅记录日志。
+func (s *Service) Deliver(ctx context.Context, subscriptionID uint) error {
+	subscription, err := s.store.GetByID(subscriptionID)
+	if err != nil {
+		return err
+	}
+	if subscription == nil || subscription.Status != restockdomain.StatusNotified {
+		return nil
+	}
+	stock, err := s.stock.GetProductStock(subscription.ProductID)
+	if err != nil {
+		return err
+	}
+	if stock == nil {
+		return nil
+	}
+
+	email := subscription.Email
+	locale := subscription.Locale
+	chatID := ""
+	if subscription.UserID != 0 {
+		contact, err := s.contacts.GetContact(subscription.UserID)
+		if err != nil {
+			return err
+		}
+		if contact == nil {
+			return nil
+		}
+		email = strings.TrimSpace(contact.Email)
+		chatID = strings.TrimSpace(contact.TelegramChatID)
+		if strings.TrimSpace(contact.Locale) != "" {
+			locale = strings.TrimSpace(contact.Locale)
+		}
+	}
+	message := s.buildMessage(ctx, subscription, stock, locale)
+
+	if chatID != "" {
+		if err := s.messenger.SendTelegram(ctx, chatID, message); err != nil {
+			logger.Warnw("restock_telegram_send_failed", "subscription_id", subscription.ID, "error", err)
+		}
+	}
+	if email != "" {
+		if err := s.messenger.SendEmail(ctx, email, message); err != nil {
+			logger.Warnw("restock_email_send_failed", "subscription_id", subscription.ID, "error", err)
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Service) buildMessage(ctx context.Context, subscription *restockdomain.Subscription, stock *restockcontract.ProductStock, locale string) restockcontract.Message {
+	var brand mailbrand.Brand
+	if s.brands != nil {
+		resolved, err := s.brands.ResolveEmailBrand(ctx, mailbrand.Scope{})
+		if err != nil {
+			logger.Warnw("restock_resolve_brand_failed", "error", err)
+		} else {
+			brand = resolved
+		}
+	}
+	siteURL := strings.TrimRight(strings.TrimSpace(brand.SiteURL), "/")
+	message := restockcontract.Message{
+		Locale:       locale,
+		ProductTitle: localizedTitle(stock.Title, locale, stock.Slug),
+		Brand:        brand,
+	}
+	if siteURL != "" {
+		message.ProductURL = siteURL + "/products/" + url.PathEscape(stock.Slug)
+		message.UnsubscribeURL = siteURL + UnsubscribePath + "?token=" + url.QueryEscape(subscription.Token)
+	}
+	return message
+}
+
+func (s *Service) scheduleCheck(productID uint, delay time.Duration) {
+	if s.queue == nil {
+		go func() {
+			if delay > 0 {
+				time.Sleep(delay)
+			}
+			if err := s.CheckProduct(productID); err != nil {
+				logger.Warnw("restock_check_failed", "product_id", productID, "error", err)
+			}
+		}()
+		return
+	}
+	if err := s.queue.EnqueueCheck(productID, delay); err != nil {
+		logger.Warnw("restock_enqueue_check_failed", "product_id", productID, "error", err)
+	}
+}
+
+func (s *Service) scheduleDeliver(subscriptionID uint) {
+	if s.queue == nil {
+		go func() {
+			if err := s.Deliver(context.Background(), subscriptionID); err != nil {
+				logger.Warnw("restock_deliver_failed", "subscription_id", subscriptionID, "error", err)
+			}
+		}()
+		return
+	}
+	if err := s.queue.EnqueueDeliver(subscriptionID); err != nil {
+		logger.Warnw("restock_enqueue_deliver_failed", "subscription_id", subscriptionID, "error", err)
+	}
+}
+
+func localizedTitle(title jsonmap.JSON, locale, fallback string) string {
+	for _, key := range []string{strings.TrimSpace(locale), "zh-CN", "en-US", "zh-TW"} {
+		if key == "" {
+			continue
+		}
+		if value, ok := title[key].(string); ok && strings.TrimSpace(value) != "" {
+			return strings.TrimSpace(value)
+		}
+	}
+	return fallback
+}
+
+func newToken() (string, error) {
+	buffer := make([]byte, 24)
+	if _, err := rand.Read(buffer); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buffer), nil
+}
@@ -0,0 +1,10 @@
+package contract
+
+import "errors"
+
+var (
+	ErrSubscriptionInvalid  = errors.New("restock subscription invalid")
+	ErrSubscriptionNotFound = errors.New("restock subscription not found")
+	ErrProductNotFound      = errors.New("product not found")
+	ErrProductInStock       = errors.New("product in stock")
+)
@@ -0,0 +1,48 @@
+package contract
+
+import (
+	"context"
+	"time"
+
+	restockdomain "github.com/dujiao-next/internal/modules/restock/domain"
+)
+
+// Store 持久化到货提醒订阅。
+type Store interface {
+	Create(subscription *restockdomain.Subscription) error
+	GetByID(id uint) (*restockdomain.Subscription, error)
+	GetByToken(token string) (*restockdomain.Subscription, error)
+	// FindPending 查找同一用户（或游客邮箱）在同一范围内尚未通知的订阅
+	FindPending(productID, skuID, userID uint, email string) (*restockdomain.Subscription, error)
+	// ListPending 按订阅先后返回商品的待通知订阅
+	ListPending(productID uint, limit int) ([]restockdomain.Subscription, error)
+	ListByUser(userID uint) ([]restockdomain.Subscription, error)
+	List(filter ListFilter) ([]restockdomain.Subscription, int64, error)
+	// MarkNotified 仅将待通知订阅置为已通知，返回实际变更的订阅 ID
+	MarkNotified(ids []uint, at time.Time) ([]uint, error)
+	Unsubscribe(id uint, at time.Time) (bool, error)
+	ListPendingProductIDs() ([]uint, error)
+	CountPendingByProduct(page, pageSize int) ([]ProductCount, int64, error)
+}
+
+// StockReader 读取商品当前库存。
+type StockReader interface {
+	GetProductStock(productID uint) (*ProductStock, error)
+}
+
+// ContactReader 解析登录用户的邮箱、语言与 Telegram 绑定。
+type ContactReader interface {
+	GetContact(userID uint) (*Contact, error)
+}
+
+// Queue 调度到货检查与单个订阅的提醒发送。
+type Queue interface {
+	EnqueueCheck(productID uint, delay time.Duration) error
+	EnqueueDeliver(subscriptionID uint) error
+}
+
+// Messenger 发送本地化的到货提醒。
+type Messenger interface {
+	SendEmail(ctx context.Context, email string, message Message) error
+	SendTelegram(ctx context.Context, chatID string, message Message) error
+}
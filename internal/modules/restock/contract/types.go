@@ -0,0 +1,83 @@
+package contract
+
+import (
+	"github.com/dujiao-next/internal/shared/jsonmap"
+	"github.com/dujiao-next/internal/shared/mailbrand"
+)
+
+// ProductStock 到货判断所需的商品库存快照；SKUs 为启用规格的可售数量，-1 表示无限库存。
+// 无启用规格的历史商品以 SKU 0 表示商品级库存。
+type ProductStock struct {
+	ProductID uint
+	Slug      string
+	Title     jsonmap.JSON
+	IsActive  bool
+	SKUs      map[uint]int64
+}
+
+// Available 返回订阅范围内的可售数量；skuID 为 0 时汇总全部规格。
+func (p ProductStock) Available(skuID uint) int64 {
+	if skuID != 0 {
+		quantity, ok := p.SKUs[skuID]
+		if !ok {
+			return 0
+		}
+		return quantity
+	}
+	var total int64
+	for _, quantity := range p.SKUs {
+		if quantity < 0 {
+			return -1
+		}
+		total += quantity
+	}
+	return total
+}
+
+// HasSKU 判断规格是否属于该商品且处于启用状态。
+func (p ProductStock) HasSKU(skuID uint) bool {
+	if skuID == 0 {
+		return true
+	}
+	_, ok := p.SKUs[skuID]
+	return ok
+}
+
+// Contact 登录用户的通知联系方式。
+type Contact struct {
+	Email          string
+	Locale         string
+	TelegramChatID string
+}
+
+// SubscribeInput 创建订阅输入；登录用户忽略 Email。
+type SubscribeInput struct {
+	ProductID uint
+	SKUID     uint
+	UserID    uint
+	Email     string
+	Locale    string
+}
+
+// ProductCount 商品维度的待通知订阅数。
+type ProductCount struct {
+	ProductID uint  `json:"product_id"`
+	Pending   int64 `json:"pending"`
+}
+
+// ListFilter 后台订阅筛选条件。
+type ListFilter struct {
+	ProductID uint
+	Status    string
+	Page      int
+	PageSize  int
+}
+
+// Message 单个订阅的到货提醒内容。
+type Message struct {
+	Locale         string
+	ProductTitle   string
+	ProductURL     string
+	UnsubscribeURL string
+	Brand          mailbrand.Brand
+}
@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	StatusPending      = "pending"
+	StatusNotified     = "notified"
+	StatusUnsubscribed = "unsubscribed"
+)
+
+const (
+	// NotifyBatchSize 单次到货检查最多通知的订阅数
+	NotifyBatchSize = 50
+	// NotifyPerUnit 有限库存时每件库存最多通知的订阅数，避免通知远多于可售数量
+	NotifyPerUnit = 3
+	// NotifyInterval 剩余订阅的下一轮检查间隔，同时作为同一商品检查的去重窗口
+	NotifyInterval = time.Minute
+)
+
+// Subscription 到货提醒订阅；SKUID 为 0 表示商品任一规格到货即提醒。
+// 登录用户的邮箱与 Telegram 在发送时按账号实时解析，游客仅保存订阅邮箱。
+type Subscription struct {
+	ID             uint       `gorm:"primarykey" json:"id"`
+	ProductID      uint       `gorm:"not null;index:idx_restock_sub_product_status,priority:1" json:"product_id"`
+	SKUID          uint       `gorm:"column:sku_id;not null;default:0" json:"sku_id"`
+	UserID         uint       `gorm:"not null;default:0;index" json:"user_id"`
+	Email          string     `gorm:"type:varchar(255);index" json:"email"`
+	Locale         string     `gorm:"type:varchar(20)" json:"locale"`
+	Token          string     `gorm:"type:varchar(64);not null;uniqueIndex" json:"-"`
+	Status         string     `gorm:"type:varchar(20);not null;default:'pending';index:idx_restock_sub_product_status,priority:2" json:"status"`
+	NotifiedAt     *time.Time `json:"notified_at,omitempty"`
+	UnsubscribedAt *time.Time `json:"unsubscribed_at,omitempty"`
+	CreatedAt      time.Time  `gorm:"index" json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+func (Subscription) TableName() string {
+	return "restock_subscriptions"
+}
+
+// NormalizeEmail 统一订阅邮箱大小写与空白。
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
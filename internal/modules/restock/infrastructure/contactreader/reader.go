@@ -0,0 +1,59 @@
+package contactreader
+
+import (
+	"strings"
+
+	"github.com/dujiao-next/internal/constants"
+	externalidentitydomain "github.com/dujiao-next/internal/modules/identity/externalidentity/domain"
+	userdomain "github.com/dujiao-next/internal/modules/identity/user/domain"
+	restockcontract "github.com/dujiao-next/internal/modules/restock/contract"
+	"github.com/dujiao-next/internal/telegramidentity"
+)
+
+// UserSource 读取用户账号。
+type UserSource interface {
+	GetByID(id uint) (*userdomain.User, error)
+}
+
+// IdentitySource 读取用户绑定的第三方身份。
+type IdentitySource interface {
+	GetByUserProvider(userID uint, provider string) (*externalidentitydomain.Identity, error)
+}
+
+// Reader 将用户邮箱、语言与 Telegram 绑定投影为通知联系方式。
+type Reader struct {
+	users      UserSource
+	identities IdentitySource
+}
+
+var _ restockcontract.ContactReader = (*Reader)(nil)
+
+func New(users UserSource, identities IdentitySource) *Reader {
+	if users == nil || identities == nil {
+		panic("restock contact reader: required dependency is nil")
+	}
+	return &Reader{users: users, identities: identities}
+}
+
+func (r *Reader) GetContact(userID uint) (*restockcontract.Contact, error) {
+	user, err := r.users.GetByID(userID)
+	if err != nil || user == nil {
+		return nil, err
+	}
+	if user.Status == constants.UserStatusDisabled {
+		return nil, nil
+	}
+	contact := &restockcontract.Contact{Locale: strings.TrimSpace(user.Locale)}
+	// Telegram 登录用户的占位邮箱不可投递
+	if email := strings.TrimSpace(user.Email); email != "" && !telegramidentity.IsPlaceholderEmail(email) {
+		contact.Email = email
+	}
+	identity, err := r.identities.GetByUserProvider(userID, constants.UserOAuthProviderTelegram)
+	if err != nil {
+		return nil, err
+	}
+	if identity != nil {
+		contact.TelegramChatID = strings.TrimSpace(identity.ProviderUserID)
+	}
+	return contact, nil
+}
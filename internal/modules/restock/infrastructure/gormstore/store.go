@@ -0,0 +1,182 @@
+package gormstore
+
+import (
+	"errors"
+	"time"
+
+	restockcontract "github.com/dujiao-next/internal/modules/restock/contract"
+	restockdomain "github.com/dujiao-next/internal/modules/restock/domain"
+
+	"gorm.io/gorm"
+)
+
+// Store 是到货提醒订阅的 GORM 仓储。
+type Store struct {
+	db *gorm.DB
+}
+
+var _ restockcontract.Store = (*Store)(nil)
+
+func New(db *gorm.DB) *Store {
+	if db == nil {
+		panic("restock store: db is nil")
+	}
+	return &Store{db: db}
+}
+
+func (s *Store) Create(subscription *restockdomain.Subscription) error {
+	return s.db.Create(subscription).Error
+}
+
+func (s *Store) GetByID(id uint) (*restockdomain.Subscription, error) {
+	if id == 0 {
+		return nil, nil
+	}
+	return s.first(s.db.Where("id = ?", id))
+}
+
+func (s *Store) GetByToken(token string) (*restockdomain.Subscription, error) {
+	if token == "" {
+		return nil, nil
+	}
+	return s.first(s.db.Where("token = ?", token))
+}
+
+func (s *Store) FindPending(productID, skuID, userID uint, email string) (*restockdomain.Subscription, error) {
+	query := s.db.Where("product_id = ? AND sku_id = ? AND status = ?", productID, skuID, restockdomain.StatusPending)
+	if userID != 0 {
+		query = query.Where("user_id = ?", userID)
+	} else {
+		query = query.Where("user_id = 0 AND email = ?", email)
+	}
+	return s.first(query)
+}
+
+func (s *Store) first(query *gorm.DB) (*restockdomain.Subscription, error) {
+	var subscription restockdomain.Subscription
+	if err := query.First(&subscription).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+func (s *Store) ListPending(productID uint, limit int) ([]restockdomain.Subscription, error) {
+	var subscriptions []restockdomain.Subscription
+	query := s.db.Where("product_id = ? AND status = ?", productID, restockdomain.StatusPending).Order("id ASC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if err := query.Find(&subscriptions).Error; err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+func (s *Store) ListByUser(userID uint) ([]restockdomain.Subscription, error) {
+	var subscriptions []restockdomain.Subscription
+	if err := s.db.Where("user_id = ?", userID).Order("id DESC").Find(&subscriptions).Error; err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+func (s *Store) List(filter restockcontract.ListFilter) ([]restockdomain.Subscription, int64, error) {
+	query := s.db.Model(&restockdomain.Subscription{})
+	if filter.ProductID > 0 {
+		query = query.Where("product_id = ?", filter.ProductID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	query = paginate(query, filter.Page, filter.PageSize)
+	var subscriptions []restockdomain.Subscription
+	if err := query.Order("id DESC").Find(&subscriptions).Error; err != nil {
+		return nil, 0, err
+	}
+	return subscriptions, total, nil
+}
+
+func (s *Store) MarkNotified(ids []uint, at time.Time) ([]uint, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var notified []uint
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		for _, id := range ids {
+			result := tx.Model(&restockdomain.Subscription{}).
+				Where("id = ? AND status = ?", id, restockdomain.StatusPending).
+				Updates(map[string]interface{}{
+					"status":      restockdomain.StatusNotified,
+					"notified_at": at,
+					"updated_at":  at,
+				})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected > 0 {
+				notified = append(notified, id)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return notified, nil
+}
+
+func (s *Store) Unsubscribe(id uint, at time.Time) (bool, error) {
+	result := s.db.Model(&restockdomain.Subscription{}).
+		Where("id = ? AND status <> ?", id, restockdomain.StatusUnsubscribed).
+		Updates(map[string]interface{}{
+			"status":          restockdomain.StatusUnsubscribed,
+			"unsubscribed_at": at,
+			"updated_at":      at,
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (s *Store) ListPendingProductIDs() ([]uint, error) {
+	var productIDs []uint
+	err := s.db.Model(&restockdomain.Subscription{}).
+		Where("status = ?", restockdomain.StatusPending).
+		Distinct("product_id").
+		Order("product_id ASC").
+		Pluck("product_id", &productIDs).Error
+	return productIDs, err
+}
+
+func (s *Store) CountPendingByProduct(page, pageSize int) ([]restockcontract.ProductCount, int64, error) {
+	base := s.db.Model(&restockdomain.Subscription{}).Where("status = ?", restockdomain.StatusPending)
+	var total int64
+	if err := base.Session(&gorm.Session{}).Distinct("product_id").Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	query := base.Session(&gorm.Session{}).
+		Select("product_id, COUNT(*) AS pending").
+		Group("product_id").
+		Order("pending DESC, product_id ASC")
+	query = paginate(query, page, pageSize)
+	var counts []restockcontract.ProductCount
+	if err := query.Scan(&counts).Error; err != nil {
+		return nil, 0, err
+	}
+	return counts, total, nil
+}
+
+func paginate(query *gorm.DB, page, pageSize int) *gorm.DB {
+	if pageSize <= 0 {
+		return query
+	}
+	if page < 1 {
+		page = 1
+	}
+	return query.Offset((page - 1) * pageSize).Limit(pageSize)
+}
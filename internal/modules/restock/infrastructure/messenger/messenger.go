@@ -0,0 +1,54 @@
+package messenger
+
+import (
+	"context"
+	"strings"
+
+	"github.com/dujiao-next/internal/i18n"
+	notificationcontract "github.com/dujiao-next/internal/modules/notification/contract"
+	restockcontract "github.com/dujiao-next/internal/modules/restock/contract"
+)
+
+// EmailSender 是邮件服务中到货提醒所需的最小能力。
+type EmailSender interface {
+	SendRestockEmail(toEmail string, input notificationcontract.RestockEmailInput, locale string) error
+}
+
+// TelegramSender 是 Telegram Bot 发送文本消息的最小能力。
+type TelegramSender interface {
+	SendMessage(ctx context.Context, chatID string, message string) error
+}
+
+// Messenger 通过邮件与 Telegram 渠道发送到货提醒。
+type Messenger struct {
+	email    EmailSender
+	telegram TelegramSender
+}
+
+var _ restockcontract.Messenger = (*Messenger)(nil)
+
+// New 创建提醒发送器；telegram 可为空，此时跳过 Telegram 渠道。
+func New(email EmailSender, telegram TelegramSender) *Messenger {
+	if email == nil {
+		panic("restock messenger: email sender is nil")
+	}
+	return &Messenger{email: email, telegram: telegram}
+}
+
+func (m *Messenger) SendEmail(_ context.Context, email string, message restockcontract.Message) error {
+	return m.email.SendRestockEmail(email, notificationcontract.RestockEmailInput{
+		ProductTitle:   message.ProductTitle,
+		ProductURL:     message.ProductURL,
+		UnsubscribeURL: message.UnsubscribeURL,
+		MailBrand:      message.Brand,
+	}, message.Locale)
+}
+
+func (m *Messenger) SendTelegram(ctx context.Context, chatID string, message restockcontract.Message) error {
+	chatID = strings.TrimSpace(chatID)
+	if m.telegram == nil || chatID == "" {
+		return nil
+	}
+	text := i18n.Sprintf(message.Locale, "telegram.restock.message", message.ProductTitle, message.ProductURL, message.UnsubscribeURL)
+	return m.telegram.SendMessage(ctx, chatID, text)
+}
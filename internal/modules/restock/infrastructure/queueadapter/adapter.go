@@ -0,0 +1,42 @@
+package queueadapter
+
+import (
+	"time"
+
+	restockcontract "github.com/dujiao-next/internal/modules/restock/contract"
+	"github.com/dujiao-next/internal/queue"
+
+	"github.com/hibiken/asynq"
+)
+
+// triggerDedupWindow 立即触发的检查在该窗口内合并，避免批量导入卡密时重复扫描。
+const triggerDedupWindow = 30 * time.Second
+
+// Adapter 将到货提醒调度端口映射到全局任务客户端。
+type Adapter struct {
+	client *queue.Client
+}
+
+var _ restockcontract.Queue = (*Adapter)(nil)
+
+func New(client *queue.Client) *Adapter {
+	if client == nil {
+		panic("restock queue adapter: client is nil")
+	}
+	return &Adapter{client: client}
+}
+
+func (a *Adapter) EnqueueCheck(productID uint, delay time.Duration) error {
+	options := make([]asynq.Option, 0, 1)
+	if delay > 0 {
+		// 续批检查由正在执行的检查发起，不能参与去重，否则会被自身的唯一锁吞掉
+		options = append(options, asynq.ProcessIn(delay))
+	} else {
+		options = append(options, asynq.Unique(triggerDedupWindow))
+	}
+	return a.client.EnqueueRestockCheck(queue.RestockCheckPayload{ProductID: productID}, options...)
+}
+
+func (a *Adapter) EnqueueDeliver(subscriptionID uint) error {
+	return a.client.EnqueueRestockDeliver(queue.RestockDeliverPayload{SubscriptionID: subscriptionID})
+}
@@ -0,0 +1,126 @@
+package stockreader
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/dujiao-next/internal/constants"
+	mappingdomain "github.com/dujiao-next/internal/modules/catalog/mapping/domain"
+	productdomain "github.com/dujiao-next/internal/modules/catalog/product/domain"
+	restockcontract "github.com/dujiao-next/internal/modules/restock/contract"
+)
+
+// ProductSource 读取商品及其启用规格。
+type ProductSource interface {
+	GetByID(id string) (*productdomain.Product, error)
+}
+
+// AutoStockCounter 为自动发货商品填充卡密库存。
+type AutoStockCounter interface {
+	ApplyAutoStockCounts(products []productdomain.Product) error
+}
+
+// SKUMappingSource 读取上游商品规格映射中的库存缓存。
+type SKUMappingSource interface {
+	GetByLocalSKUID(skuID uint) (*mappingdomain.SKUMapping, error)
+}
+
+// Reader 按商品交付类型投影与店面一致的可售数量。
+type Reader struct {
+	products    ProductSource
+	autoStock   AutoStockCounter
+	skuMappings SKUMappingSource
+}
+
+var _ restockcontract.StockReader = (*Reader)(nil)
+
+func New(products ProductSource, autoStock AutoStockCounter, skuMappings SKUMappingSource) *Reader {
+	if products == nil || autoStock == nil || skuMappings == nil {
+		panic("restock stock reader: required dependency is nil")
+	}
+	return &Reader{products: products, autoStock: autoStock, skuMappings: skuMappings}
+}
+
+func (r *Reader) GetProductStock(productID uint) (*restockcontract.ProductStock, error) {
+	if productID == 0 {
+		return nil, nil
+	}
+	product, err := r.products.GetByID(strconv.FormatUint(uint64(productID), 10))
+	if err != nil || product == nil {
+		return nil, err
+	}
+	stock := &restockcontract.ProductStock{
+		ProductID: product.ID,
+		Slug:      product.Slug,
+		Title:     product.TitleJSON,
+		IsActive:  product.IsActive,
+		SKUs:      make(map[uint]int64, len(product.SKUs)),
+	}
+
+	switch strings.TrimSpace(product.FulfillmentType) {
+	case constants.FulfillmentTypeWebhook, constants.FulfillmentTypeFile, constants.FulfillmentTypeLicense:
+		fillUnlimited(stock, product)
+	case constants.FulfillmentTypeUpstream:
+		if err := r.fillUpstream(stock, product); err != nil {
+			return nil, err
+		}
+	case constants.FulfillmentTypeAuto:
+		products := []productdomain.Product{*product}
+		if err := r.autoStock.ApplyAutoStockCounts(products); err != nil {
+			return nil, err
+		}
+		if len(products[0].SKUs) == 0 {
+			stock.SKUs[0] = products[0].AutoStockAvailable
+		}
+		for _, sku := range products[0].SKUs {
+			stock.SKUs[sku.ID] = sku.AutoStockAvailable
+		}
+	default:
+		if len(product.SKUs) == 0 {
+			stock.SKUs[0] = manualQuantity(product.ManualStockTotal)
+		}
+		for _, sku := range product.SKUs {
+			stock.SKUs[sku.ID] = manualQuantity(sku.ManualStockTotal)
+		}
+	}
+	return stock, nil
+}
+
+// fillUpstream 未映射或上游停售的规格视为缺货，与店面库存展示一致。
+func (r *Reader) fillUpstream(stock *restockcontract.ProductStock, product *productdomain.Product) error {
+	if len(product.SKUs) == 0 {
+		stock.SKUs[0] = -1
+		return nil
+	}
+	for _, sku := range product.SKUs {
+		mapping, err := r.skuMappings.GetByLocalSKUID(sku.ID)
+		if err != nil {
+			return err
+		}
+		if mapping == nil || !mapping.UpstreamIsActive {
+			stock.SKUs[sku.ID] = 0
+			continue
+		}
+		stock.SKUs[sku.ID] = manualQuantity(mapping.UpstreamStock)
+	}
+	return nil
+}
+
+func fillUnlimited(stock *restockcontract.ProductStock, product *productdomain.Product) {
+	if len(product.SKUs) == 0 {
+		stock.SKUs[0] = -1
+	}
+	for _, sku := range product.SKUs {
+		stock.SKUs[sku.ID] = -1
+	}
+}
+
+func manualQuantity(total int) int64 {
+	if total == constants.ManualStockUnlimited {
+		return -1
+	}
+	if total < 0 {
+		return 0
+	}
+	return int64(total)
+}
@@ -0,0 +1,310 @@
+package integrationtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	restockapp "github.com/dujiao-next/internal/modules/restock/application"
+	restockcontract "github.com/dujiao-next/internal/modules/restock/contract"
+	restockdomain "github.com/dujiao-next/internal/modules/restock/domain"
+	restockgormstore "github.com/dujiao-next/internal/modules/restock/infrastructure/gormstore"
+	"github.com/dujiao-next/internal/shared/jsonmap"
+	"github.com/dujiao-next/internal/shared/mailbrand"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+)
+
+type stockStub struct {
+	products map[uint]*restockcontract.ProductStock
+}
+
+func (s *stockStub) GetProductStock(productID uint) (*restockcontract.ProductStock, error) {
+	return s.products[productID], nil
+}
+
+type contactStub struct {
+	contacts map[uint]*restockcontract.Contact
+}
+
+func (s *contactStub) GetContact(userID uint) (*restockcontract.Contact, error) {
+	return s.contacts[userID], nil
+}
+
+type queueStub struct {
+	checks   []time.Duration
+	delivers []uint
+}
+
+func (q *queueStub) EnqueueCheck(_ uint, delay time.Duration) error {
+	q.checks = append(q.checks, delay)
+	return nil
+}
+
+func (q *queueStub) EnqueueDeliver(subscriptionID uint) error {
+	q.delivers = append(q.delivers, subscriptionID)
+	return nil
+}
+
+type sentMessage struct {
+	target  string
+	message restockcontract.Message
+}
+
+type messengerStub struct {
+	emails    []sentMessage
+	telegrams []sentMessage
+}
+
+func (m *messengerStub) SendEmail(_ context.Context, email string, message restockcontract.Message) error {
+	m.emails = append(m.emails, sentMessage{target: email, message: message})
+	return nil
+}
+
+func (m *messengerStub) SendTelegram(_ context.Context, chatID string, message restockcontract.Message) error {
+	m.telegrams = append(m.telegrams, sentMessage{target: chatID, message: message})
+	return nil
+}
+
+type brandStub struct{}
+
+func (brandStub) ResolveEmailBrand(context.Context, mailbrand.Scope) (mailbrand.Brand, error) {
+	return mailbrand.Brand{SiteName: "Shop", SiteURL: "https://shop.example/"}, nil
+}
+
+type fixture struct {
+	service   *restockapp.Service
+	store     *restockgormstore.Store
+	stock     *stockStub
+	contacts  *contactStub
+	queue     *queueStub
+	messenger *messengerStub
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+	dsn := fmt.Sprintf("file:restock_%d?mode=memory&cache=shared", time.Now().UnixNano())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite failed: %v", err)
+	}
+	if err := db.AutoMigrate(&restockdomain.Subscription{}); err != nil {
+		t.Fatalf("auto migrate failed: %v", err)
+	}
+	f := &fixture{
+		store: restockgormstore.New(db),
+		stock: &stockStub{products: map[uint]*restockcontract.ProductStock{
+			1: {
+				ProductID: 1,
+				Slug:      "game-key",
+				Title:     jsonmap.JSON{"zh-CN": "游戏激活码", "en-US": "Game Key"},
+				IsActive:  true,
+				SKUs:      map[uint]int64{11: 0, 12: 5},
+			},
+		}},
+		contacts:  &contactStub{contacts: map[uint]*restockcontract.Contact{}},
+		queue:     &queueStub{},
+		messenger: &messengerStub{},
+	}
+	f.service = restockapp.NewService(restockapp.Options{
+		Store:     f.store,
+		Stock:     f.stock,
+		Contacts:  f.contacts,
+		Queue:     f.queue,
+		Messenger: f.messenger,
+		Brands:    brandStub{},
+	})
+	return f
+}
+
+func (f *fixture) subscribeGuests(t *testing.T, count int) []uint {
+	t.Helper()
+	ids := make([]uint, 0, count)
+	for i := 0; i < count; i++ {
+		subscription, err := f.service.Subscribe(restockcontract.SubscribeInput{
+			ProductID: 1,
+			SKUID:     11,
+			Email:     fmt.Sprintf("guest%d@example.com", i),
+			Locale:    "en-US",
+		})
+		if err != nil {
+			t.Fatalf("subscribe failed: %v", err)
+		}
+		ids = append(ids, subscription.ID)
+	}
+	return ids
+}
+
+func TestSubscribeRejectsInStockAndDeduplicates(t *testing.T) {
+	f := newFixture(t)
+
+	_, err := f.service.Subscribe(restockcontract.SubscribeInput{ProductID: 1, SKUID: 12, Email: "a@example.com"})
+	if !errors.Is(err, restockcontract.ErrProductInStock) {
+		t.Fatalf("expected in stock error, got %v", err)
+	}
+	if _, err := f.service.Subscribe(restockcontract.SubscribeInput{ProductID: 1, SKUID: 11, Email: "not-an-email"}); !errors.Is(err, restockcontract.ErrSubscriptionInvalid) {
+		t.Fatalf("expected invalid email error, got %v", err)
+	}
+	if _, err := f.service.Subscribe(restockcontract.SubscribeInput{ProductID: 1, SKUID: 99, UserID: 7}); !errors.Is(err, restockcontract.ErrSubscriptionInvalid) {
+		t.Fatalf("expected invalid sku error, got %v", err)
+	}
+
+	first, err := f.service.Subscribe(restockcontract.SubscribeInput{ProductID: 1, SKUID: 11, Email: "A@Example.com "})
+	if err != nil {
+		t.Fatalf("subscribe failed: %v", err)
+	}
+	second, err := f.service.Subscribe(restockcontract.SubscribeInput{ProductID: 1, SKUID: 11, Email: "a@example.com"})
+	if err != nil {
+		t.Fatalf("repeat subscribe failed: %v", err)
+	}
+	if first.ID != second.ID || first.Email != "a@example.com" || first.Token == "" {
+		t.Fatalf("expected deduplicated subscription, got %+v and %+v", first, second)
+	}
+}
+
+func TestCheckProductNotifiesEarliestSubscribersWithinStockBudget(t *testing.T) {
+	f := newFixture(t)
+	ids := f.subscribeGuests(t, 5)
+
+	f.stock.products[1].SKUs[11] = 1
+	if err := f.service.CheckProduct(1); err != nil {
+		t.Fatalf("check product failed: %v", err)
+	}
+	if len(f.queue.delivers) != restockdomain.NotifyPerUnit {
+		t.Fatalf("expected %d deliveries, got %v", restockdomain.NotifyPerUnit, f.queue.delivers)
+	}
+	for i, id := range f.queue.delivers {
+		if id != ids[i] {
+			t.Fatalf("expected earliest subscribers first, got %v want prefix of %v", f.queue.delivers, ids)
+		}
+	}
+	if len(f.queue.checks) != 0 {
+		t.Fatalf("partial batch must not schedule a follow-up check, got %v", f.queue.checks)
+	}
+
+	// 再次检查不会重复通知已提醒的订阅
+	f.queue.delivers = nil
+	f.stock.products[1].SKUs[11] = 0
+	if err := f.service.CheckProduct(1); err != nil {
+		t.Fatalf("second check failed: %v", err)
+	}
+	if len(f.queue.delivers) != 0 {
+		t.Fatalf("expected no deliveries without stock, got %v", f.queue.delivers)
+	}
+}
+
+func TestCheckProductThrottlesLargeBacklogIntoBatches(t *testing.T) {
+	f := newFixture(t)
+	f.subscribeGuests(t, restockdomain.NotifyBatchSize+5)
+
+	f.stock.products[1].SKUs[11] = -1
+	if err := f.service.CheckProduct(1); err != nil {
+		t.Fatalf("check product failed: %v", err)
+	}
+	if len(f.queue.delivers) != restockdomain.NotifyBatchSize {
+		t.Fatalf("expected first batch of %d, got %d", restockdomain.NotifyBatchSize, len(f.queue.delivers))
+	}
+	if len(f.queue.checks) != 1 || f.queue.checks[0] != restockdomain.NotifyInterval {
+		t.Fatalf("expected follow-up check after interval, got %v", f.queue.checks)
+	}
+
+	if err := f.service.CheckProduct(1); err != nil {
+		t.Fatalf("follow-up check failed: %v", err)
+	}
+	if len(f.queue.delivers) != restockdomain.NotifyBatchSize+5 || len(f.queue.checks) != 1 {
+		t.Fatalf("expected remaining subscribers in second batch, got %d deliveries and %v checks", len(f.queue.delivers), f.queue.checks)
+	}
+}
+
+func TestUnsubscribeTokenStopsNotification(t *testing.T) {
+	f := newFixture(t)
+	ids := f.subscribeGuests(t, 2)
+	subscription, err := f.store.GetByID(ids[0])
+	if err != nil || subscription == nil {
+		t.Fatalf("load subscription failed: %v", err)
+	}
+	if err := f.service.Unsubscribe(subscription.Token); err != nil {
+		t.Fatalf("unsubscribe failed: %v", err)
+	}
+	if err := f.service.Unsubscribe(subscription.Token); err != nil {
+		t.Fatalf("repeat unsubscribe should succeed: %v", err)
+	}
+	if err := f.service.Unsubscribe("unknown"); !errors.Is(err, restockcontract.ErrSubscriptionNotFound) {
+		t.Fatalf("expected not found for unknown token, got %v", err)
+	}
+
+	f.stock.products[1].SKUs[11] = 10
+	if err := f.service.CheckProduct(1); err != nil {
+		t.Fatalf("check product failed: %v", err)
+	}
+	if len(f.queue.delivers) != 1 || f.queue.delivers[0] != ids[1] {
+		t.Fatalf("expected only active subscription notified, got %v", f.queue.delivers)
+	}
+}
+
+func TestDeliverSendsLocalizedEmailAndTelegram(t *testing.T) {
+	f := newFixture(t)
+	f.contacts.contacts[7] = &restockcontract.Contact{Email: "user@example.com", Locale: "zh-CN", TelegramChatID: "10086"}
+	subscription, err := f.service.Subscribe(restockcontract.SubscribeInput{ProductID: 1, SKUID: 11, UserID: 7, Locale: "en-US"})
+	if err != nil {
+		t.Fatalf("subscribe failed: %v", err)
+	}
+
+	// 尚未到货的订阅不会投递
+	if err := f.service.Deliver(context.Background(), subscription.ID); err != nil {
+		t.Fatalf("deliver pending failed: %v", err)
+	}
+	if len(f.messenger.emails) != 0 {
+		t.Fatalf("pending subscription must not be delivered")
+	}
+
+	f.stock.products[1].SKUs[11] = 2
+	if err := f.service.CheckProduct(1); err != nil {
+		t.Fatalf("check product failed: %v", err)
+	}
+	if err := f.service.Deliver(context.Background(), subscription.ID); err != nil {
+		t.Fatalf("deliver failed: %v", err)
+	}
+	if len(f.messenger.emails) != 1 || len(f.messenger.telegrams) != 1 {
+		t.Fatalf("expected email and telegram, got %d and %d", len(f.messenger.emails), len(f.messenger.telegrams))
+	}
+	email := f.messenger.emails[0]
+	if email.target != "user@example.com" || email.message.Locale != "zh-CN" || email.message.ProductTitle != "游戏激活码" {
+		t.Fatalf("unexpected email: %+v", email)
+	}
+	if email.message.ProductURL != "https://shop.example/products/game-key" {
+		t.Fatalf("unexpected product url: %s", email.message.ProductURL)
+	}
+	if !strings.HasPrefix(email.message.UnsubscribeURL, "https://shop.example"+restockapp.UnsubscribePath+"?token=") {
+		t.Fatalf("unexpected unsubscribe url: %s", email.message.UnsubscribeURL)
+	}
+	if f.messenger.telegrams[0].target != "10086" {
+		t.Fatalf("unexpected telegram target: %s", f.messenger.telegrams[0].target)
+	}
+}
+
+func TestListProductDemandOrdersByPendingCount(t *testing.T) {
+	f := newFixture(t)
+	f.stock.products[2] = &restockcontract.ProductStock{ProductID: 2, Slug: "other", IsActive: true, SKUs: map[uint]int64{0: 0}}
+	f.subscribeGuests(t, 1)
+	for i := 0; i < 3; i++ {
+		if _, err := f.service.Subscribe(restockcontract.SubscribeInput{ProductID: 2, UserID: uint(100 + i)}); err != nil {
+			t.Fatalf("subscribe failed: %v", err)
+		}
+	}
+
+	items, total, err := f.service.ListProductDemand(1, 20)
+	if err != nil {
+		t.Fatalf("list demand failed: %v", err)
+	}
+	if total != 2 || len(items) != 2 {
+		t.Fatalf("expected 2 products, got total=%d items=%v", total, items)
+	}
+	if items[0].ProductID != 2 || items[0].Pending != 3 || items[1].ProductID != 1 || items[1].Pending != 1 {
+		t.Fatalf("unexpected demand order: %+v", items)
+	}
+}
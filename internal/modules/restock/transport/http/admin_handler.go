@@ -0,0 +1,62 @@
+package restockhttp
+
+import (
+	"strings"
+
+	restockcontract "github.com/dujiao-next/internal/modules/restock/contract"
+	restockdomain "github.com/dujiao-next/internal/modules/restock/domain"
+	"github.com/dujiao-next/internal/platform/http/ginutil"
+	"github.com/dujiao-next/internal/platform/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminService 是后台到货订阅查询所需的最小用例接口。
+type AdminService interface {
+	ListSubscriptions(filter restockcontract.ListFilter) ([]restockdomain.Subscription, int64, error)
+	ListProductDemand(page, pageSize int) ([]restockcontract.ProductCount, int64, error)
+}
+
+// AdminHandler 处理后台到货订阅请求。
+type AdminHandler struct {
+	service AdminService
+}
+
+func NewAdminHandler(service AdminService) *AdminHandler {
+	if service == nil {
+		panic("restock admin handler: required dependency is nil")
+	}
+	return &AdminHandler{service: service}
+}
+
+// ListSubscriptions 获取到货订阅（支持 product_id、status 筛选）
+func (h *AdminHandler) ListSubscriptions(c *gin.Context) {
+	productID, err := ginutil.ParseQueryUint(c.Query("product_id"), false)
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", nil)
+		return
+	}
+	page, pageSize := ginutil.ParsePagination(c)
+	subscriptions, total, err := h.service.ListSubscriptions(restockcontract.ListFilter{
+		ProductID: productID,
+		Status:    strings.TrimSpace(c.Query("status")),
+		Page:      page,
+		PageSize:  pageSize,
+	})
+	if err != nil {
+		ginutil.RespondError(c, response.CodeInternal, "error.restock_fetch_failed", err)
+		return
+	}
+	response.SuccessWithPage(c, subscriptions, response.BuildPagination(page, pageSize, total))
+}
+
+// ListProductDemand 按待通知订阅数倒序获取商品
+func (h *AdminHandler) ListProductDemand(c *gin.Context) {
+	page, pageSize := ginutil.ParsePagination(c)
+	items, total, err := h.service.ListProductDemand(page, pageSize)
+	if err != nil {
+		ginutil.RespondError(c, response.CodeInternal, "error.restock_fetch_failed", err)
+		return
+	}
+	response.SuccessWithPage(c, items, response.BuildPagination(page, pageSize, total))
+}
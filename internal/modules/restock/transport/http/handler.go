@@ -0,0 +1,146 @@
+package restockhttp
+
+import (
+	"errors"
+
+	"github.com/dujiao-next/internal/i18n"
+	restockcontract "github.com/dujiao-next/internal/modules/restock/contract"
+	restockdomain "github.com/dujiao-next/internal/modules/restock/domain"
+	"github.com/dujiao-next/internal/platform/http/ginutil"
+	"github.com/dujiao-next/internal/platform/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Service 是店面到货提醒所需的最小用例接口。
+type Service interface {
+	Subscribe(input restockcontract.SubscribeInput) (*restockdomain.Subscription, error)
+	ListUserSubscriptions(userID uint) ([]restockdomain.Subscription, error)
+	CancelUserSubscription(userID, id uint) error
+	Unsubscribe(token string) error
+}
+
+// Handler 处理店面到货提醒订阅与退订请求。
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	if service == nil {
+		panic("restock handler: required dependency is nil")
+	}
+	return &Handler{service: service}
+}
+
+// SubscribeRequest 到货提醒订阅请求；sku_id 为 0 表示商品任一规格到货即提醒
+type SubscribeRequest struct {
+	ProductID uint `json:"product_id" binding:"required"`
+	SKUID     uint `json:"sku_id"`
+}
+
+// GuestSubscribeRequest 游客到货提醒订阅请求
+type GuestSubscribeRequest struct {
+	ProductID uint   `json:"product_id" binding:"required"`
+	SKUID     uint   `json:"sku_id"`
+	Email     string `json:"email" binding:"required"`
+}
+
+// SubscribeUser 登录用户订阅到货提醒
+func (h *Handler) SubscribeUser(c *gin.Context) {
+	userID, ok := ginutil.GetUserID(c)
+	if !ok {
+		return
+	}
+	var req SubscribeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ginutil.RespondBindError(c, err)
+		return
+	}
+	subscription, err := h.service.Subscribe(restockcontract.SubscribeInput{
+		ProductID: req.ProductID,
+		SKUID:     req.SKUID,
+		UserID:    userID,
+		Locale:    i18n.ResolveLocale(c),
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	response.Success(c, subscription)
+}
+
+// SubscribeGuest 游客以邮箱订阅到货提醒
+func (h *Handler) SubscribeGuest(c *gin.Context) {
+	var req GuestSubscribeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ginutil.RespondBindError(c, err)
+		return
+	}
+	subscription, err := h.service.Subscribe(restockcontract.SubscribeInput{
+		ProductID: req.ProductID,
+		SKUID:     req.SKUID,
+		Email:     req.Email,
+		Locale:    i18n.ResolveLocale(c),
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	response.Success(c, subscription)
+}
+
+// ListUserSubscriptions 获取当前用户的到货提醒订阅
+func (h *Handler) ListUserSubscriptions(c *gin.Context) {
+	userID, ok := ginutil.GetUserID(c)
+	if !ok {
+		return
+	}
+	subscriptions, err := h.service.ListUserSubscriptions(userID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	response.Success(c, subscriptions)
+}
+
+// CancelUserSubscription 取消当前用户的到货提醒订阅
+func (h *Handler) CancelUserSubscription(c *gin.Context) {
+	userID, ok := ginutil.GetUserID(c)
+	if !ok {
+		return
+	}
+	id, err := ginutil.ParseParamUint(c, "id")
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", nil)
+		return
+	}
+	if err := h.service.CancelUserSubscription(userID, id); err != nil {
+		respondError(c, err)
+		return
+	}
+	response.Success(c, nil)
+}
+
+// Unsubscribe 通过提醒消息中的退订链接取消订阅
+func (h *Handler) Unsubscribe(c *gin.Context) {
+	if err := h.service.Unsubscribe(c.Query("token")); err != nil {
+		respondError(c, err)
+		return
+	}
+	response.Success(c, nil)
+}
+
+func respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, restockcontract.ErrSubscriptionInvalid):
+		ginutil.RespondError(c, response.CodeBadRequest, "error.restock_subscription_invalid", nil)
+	case errors.Is(err, restockcontract.ErrProductNotFound):
+		ginutil.RespondError(c, response.CodeNotFound, "error.product_not_found", nil)
+	case errors.Is(err, restockcontract.ErrProductInStock):
+		ginutil.RespondError(c, response.CodeBadRequest, "error.restock_product_in_stock", nil)
+	case errors.Is(err, restockcontract.ErrSubscriptionNotFound):
+		ginutil.RespondError(c, response.CodeNotFound, "error.restock_subscription_not_found", nil)
+	default:
+		ginutil.RespondError(c, response.CodeInternal, "error.restock_fetch_failed", err)
+	}
+}
@@ -0,0 +1,38 @@
+package restockhttp
+
+import "github.com/gin-gonic/gin"
+
+// RegisterUserRoutes 注册登录用户到货提醒订阅路由。
+func RegisterUserRoutes(user gin.IRoutes, handler *Handler) {
+	if user == nil || handler == nil {
+		panic("restock user routes: required dependency is nil")
+	}
+	user.POST("/restock-subscriptions", handler.SubscribeUser)
+	user.GET("/restock-subscriptions", handler.ListUserSubscriptions)
+	user.DELETE("/restock-subscriptions/:id", handler.CancelUserSubscription)
+}
+
+// RegisterGuestRoutes 注册游客邮箱订阅路由，调用方负责限流。
+func RegisterGuestRoutes(guest gin.IRoutes, handler *Handler) {
+	if guest == nil || handler == nil {
+		panic("restock guest routes: required dependency is nil")
+	}
+	guest.POST("/restock-subscriptions", handler.SubscribeGuest)
+}
+
+// RegisterPublicRoutes 注册退订链接路由，鉴权由退订令牌完成，按 IP 限流。
+func RegisterPublicRoutes(public gin.IRoutes, handler *Handler, rateLimit gin.HandlerFunc) {
+	if public == nil || handler == nil || rateLimit == nil {
+		panic("restock public routes: required dependency is nil")
+	}
+	public.GET("/restock-subscriptions/unsubscribe", rateLimit, handler.Unsubscribe)
+}
+
+// RegisterAdminRoutes 注册后台到货订阅查询路由。
+func RegisterAdminRoutes(authorized gin.IRoutes, handler *AdminHandler) {
+	if authorized == nil || handler == nil {
+		panic("restock admin routes: required dependency is nil")
+	}
+	authorized.GET("/restock-subscriptions", handler.ListSubscriptions)
+	authorized.GET("/restock-subscriptions/demand", handler.ListProductDemand)
+}
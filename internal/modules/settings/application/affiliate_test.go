@@ -42,7 +42,7 @@ func TestUpdateAffiliateSettingNormalize(t *testing.T) {
 		ConfirmDays:       -10,
 		MinWithdrawAmount: -100.239,
 		WithdrawChannels:  []string{"  usdt  ", "USDT", "", "paypal"},
-	})
+	}, 1)
 	if err != nil {
 		t.Fatalf("update affiliate setting failed: %v", err)
 	}
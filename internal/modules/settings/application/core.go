@@ -1,14 +1,18 @@
 package settingsapp
 
 import (
+	"time"
+
 	"github.com/dujiao-next/internal/config"
 	settingscontract "github.com/dujiao-next/internal/modules/settings/contract"
+	settingsvalue "github.com/dujiao-next/internal/modules/settings/schema/value"
 	"github.com/dujiao-next/internal/shared/jsonmap"
 )
 
 // Service 是站点设置的核心用例入口：读写、Registry 归一化与声明式副作用。
 type Service struct {
 	repo                  settingscontract.Store
+	history               settingscontract.HistoryStore
 	registry              Registry
 	defaultOrderConfig    config.OrderConfig
 	hasDefaultOrderConfig bool
@@ -30,6 +34,13 @@ func (result UpdateResult) HasEffect(effect Effect) bool {
 	return false
 }
 
+// WriteMeta 描述一次设置写入的来源，用于版本历史；AdminID 为 0 表示系统写入。
+type WriteMeta struct {
+	AdminID         uint
+	Action          string
+	SourceVersionID *uint
+}
+
 // NewService 创建设置服务。可选的订单配置仅在数据库尚未覆盖设置时使用。
 func NewService(repo settingscontract.Store, defaultOrderConfig ...config.OrderConfig) *Service {
 	service := &Service{
@@ -58,9 +69,33 @@ func (s *Service) GetByKey(key string) (jsonmap.JSON, error) {
 	return value, nil
 }
 
-// Update 设置值。
+// SetHistoryStore 注入版本历史存储；未注入时写入不记录历史，保持单表行为。
+func (s *Service) SetHistoryStore(history settingscontract.HistoryStore) {
+	if s == nil {
+		return
+	}
+	s.history = history
+}
+
+// SecretPaths 返回设置键登记的密钥字段路径。
+func (s *Service) SecretPaths(key string) []string {
+	if s == nil {
+		return nil
+	}
+	return s.registry.SecretPaths(key)
+}
+
+// IsRuntimeKey 判断设置键是否为运行时状态（不记录历史、不参与快照）。
+func (s *Service) IsRuntimeKey(key string) bool {
+	if s == nil {
+		return false
+	}
+	return s.registry.IsRuntime(key)
+}
+
+// Update 以系统身份设置值。
 func (s *Service) Update(key string, value map[string]interface{}) (jsonmap.JSON, error) {
-	result, err := s.UpdateWithEffects(key, value)
+	result, err := s.UpdateWithEffects(key, value, WriteMeta{})
 	if err != nil {
 		return nil, err
 	}
@@ -68,13 +103,14 @@ func (s *Service) Update(key string, value map[string]interface{}) (jsonmap.JSON
 }
 
 // UpdateWithEffects 设置值并返回成功写入后需要处理的声明式外部影响。
-func (s *Service) UpdateWithEffects(key string, value map[string]interface{}) (UpdateResult, error) {
+// 回滚与快照导入同样经由此处，保证与普通写入走相同的归一化、历史记录与副作用。
+func (s *Service) UpdateWithEffects(key string, value map[string]interface{}, meta WriteMeta) (UpdateResult, error) {
 	if s == nil || s.repo == nil {
 		return UpdateResult{}, nil
 	}
 	normalized := s.registry.Normalize(key, jsonmap.JSON(value))
 
-	stored, err := s.repo.Upsert(key, normalized)
+	stored, err := s.persist(key, normalized, meta)
 	if err != nil {
 		return UpdateResult{}, err
 	}
@@ -83,3 +119,35 @@ func (s *Service) UpdateWithEffects(key string, value map[string]interface{}) (U
 		Effects: s.registry.Effects(key),
 	}, nil
 }
+
+// persist 写入设置值；配置了历史存储时与版本记录同事务落库，值未变化时不追加版本。
+func (s *Service) persist(key string, normalized jsonmap.JSON, meta WriteMeta) (jsonmap.JSON, error) {
+	if s.history == nil || s.registry.IsRuntime(key) {
+		return s.repo.Upsert(key, normalized)
+	}
+	previous, _, err := s.repo.GetByKey(key)
+	if err != nil {
+		return nil, err
+	}
+	diff := settingsvalue.DiffJSON(previous, normalized, s.registry.SecretPaths(key))
+	if len(diff) == 0 {
+		return s.repo.Upsert(key, normalized)
+	}
+	action := meta.Action
+	if action == "" {
+		action = settingscontract.VersionActionUpdate
+	}
+	version := settingscontract.Version{
+		Key:             key,
+		Action:          action,
+		Previous:        previous,
+		Diff:            diff,
+		SourceVersionID: meta.SourceVersionID,
+		CreatedAt:       time.Now(),
+	}
+	if meta.AdminID != 0 {
+		adminID := meta.AdminID
+		version.AdminID = &adminID
+	}
+	return s.history.UpsertVersioned(key, normalized, version)
+}
@@ -67,6 +67,8 @@ func TestUpdateDashboardSettingFallbackWhenMissing(t *testing.T) {
 	assertSettingIntValue(t, alert, "out_of_stock_products_threshold", 1)
 	assertSettingIntValue(t, alert, "pending_payment_orders_threshold", 20)
 	assertSettingIntValue(t, alert, "payments_failed_threshold", 10)
+	assertSettingIntValue(t, alert, "forecast_alert_days", 7)
+	assertSettingIntValue(t, alert, "forecast_window_days", 14)
 	assertSettingIntValue(t, ranking, "top_products_limit", 5)
 	assertSettingIntValue(t, ranking, "top_channels_limit", 5)
 }
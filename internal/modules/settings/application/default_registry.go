@@ -29,8 +29,21 @@ var defaultSettingRegistry = MustNewRegistry(
 		Effects:   []Effect{EffectInvalidatePublicConfigCache},
 	},
 	Definition{
-		Key:       constants.SettingKeyTelegramAuthConfig,
-		Normalize: settingssecurity.NormalizeTelegramAuthSettingJSON,
+		Key:         constants.SettingKeyTelegramAuthConfig,
+		Normalize:   settingssecurity.NormalizeTelegramAuthSettingJSON,
+		SecretPaths: []string{"bot_token", "client_secret"},
+	},
+	Definition{
+		Key:         constants.SettingKeySMTPConfig,
+		SecretPaths: []string{"password"},
+	},
+	Definition{
+		Key:         constants.SettingKeyCaptchaConfig,
+		SecretPaths: []string{"turnstile.secret_key"},
+	},
+	Definition{
+		Key:     constants.SettingKeyTelegramBotRuntimeStatus,
+		Runtime: true,
 	},
 	Definition{
 		Key:       constants.SettingKeyGoogleAuthConfig,
@@ -75,6 +88,11 @@ var defaultSettingRegistry = MustNewRegistry(
 		Normalize: settingsintegration.NormalizeCallbackRoutesSettingJSON,
 		Effects:   []Effect{EffectInvalidateCallbackRoutesCache},
 	},
+	Definition{
+		Key:       constants.SettingKeyLocaleConfig,
+		Normalize: settingsmessaging.NormalizeLocaleSettingJSON,
+		Effects:   []Effect{EffectInvalidateLocaleCache, EffectInvalidatePublicConfigCache},
+	},
 	Definition{
 		Key:       constants.SettingKeyHomeAnnouncement,
 		Normalize: settingsstorefront.NormalizeHomeAnnouncementJSON,
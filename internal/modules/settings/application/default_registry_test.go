@@ -12,17 +12,21 @@ func TestDefaultSettingRegistryCoversLegacyNormalizedKeys(t *testing.T) {
 	want := []string{
 		constants.SettingKeyAffiliateConfig,
 		constants.SettingKeyCallbackRoutesConfig,
+		constants.SettingKeyCaptchaConfig,
 		constants.SettingKeyDashboardConfig,
 		constants.SettingKeyGoogleAuthConfig,
 		constants.SettingKeyHomeAnnouncement,
+		constants.SettingKeyLocaleConfig,
 		constants.SettingKeyNavConfig,
 		constants.SettingKeyNotificationCenterConfig,
 		constants.SettingKeyOrderConfig,
 		constants.SettingKeyOrderRiskControlConfig,
 		constants.SettingKeyRegistrationConfig,
 		constants.SettingKeySiteConfig,
+		constants.SettingKeySMTPConfig,
 		constants.SettingKeyTelegramAuthConfig,
 		constants.SettingKeyTelegramBotConfig,
+		constants.SettingKeyTelegramBotRuntimeStatus,
 		constants.SettingKeyUpstreamSyncConfig,
 		constants.SettingKeyWalletConfig,
 	}
@@ -69,7 +73,7 @@ func TestSettingServiceUpdateWithEffectsDescribesCacheImpact(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			repo := newMockSettingRepo()
 			service := NewService(repo)
-			result, err := service.UpdateWithEffects(test.key, test.value)
+			result, err := service.UpdateWithEffects(test.key, test.value, WriteMeta{})
 			if err != nil {
 				t.Fatalf("update setting: %v", err)
 			}
@@ -81,7 +85,7 @@ func TestSettingServiceUpdateWithEffectsDescribesCacheImpact(t *testing.T) {
 
 	repo := newMockSettingRepo()
 	service := NewService(repo)
-	result, err := service.UpdateWithEffects("custom_extension_config", map[string]interface{}{"enabled": true})
+	result, err := service.UpdateWithEffects("custom_extension_config", map[string]interface{}{"enabled": true}, WriteMeta{})
 	if err != nil {
 		t.Fatalf("update unknown setting: %v", err)
 	}
@@ -38,6 +38,7 @@ func TestPatchGoogleAuthSettingPersistsNormalizedValue(t *testing.T) {
 	setting, err := svc.PatchGoogleAuthSetting(
 		config.GoogleAuthConfig{ClientID: "default.apps.googleusercontent.com"},
 		settingssecurity.GoogleAuthSettingPatch{Enabled: &enabled, ClientID: &clientID},
+		1,
 	)
 	if err != nil {
 		t.Fatalf("patch google auth setting: %v", err)
@@ -58,6 +59,7 @@ func TestPatchGoogleAuthSettingCanClearClientIDOnlyWhenDisabled(t *testing.T) {
 	if _, err := svc.PatchGoogleAuthSetting(
 		config.GoogleAuthConfig{Enabled: true, ClientID: "client.apps.googleusercontent.com"},
 		settingssecurity.GoogleAuthSettingPatch{ClientID: &empty},
+		1,
 	); !errors.Is(err, settingssecurity.ErrGoogleAuthConfigInvalid) {
 		t.Fatalf("enabled clear error = %v", err)
 	}
@@ -66,6 +68,7 @@ func TestPatchGoogleAuthSettingCanClearClientIDOnlyWhenDisabled(t *testing.T) {
 	setting, err := svc.PatchGoogleAuthSetting(
 		config.GoogleAuthConfig{Enabled: true, ClientID: "client.apps.googleusercontent.com"},
 		settingssecurity.GoogleAuthSettingPatch{Enabled: &disabled, ClientID: &empty},
+		1,
 	)
 	if err != nil {
 		t.Fatalf("disable and clear: %v", err)
@@ -18,15 +18,20 @@ type Effect string
 const (
 	EffectInvalidatePublicConfigCache   Effect = "invalidate_public_config_cache"
 	EffectInvalidateCallbackRoutesCache Effect = "invalidate_callback_routes_cache"
+	EffectInvalidateLocaleCache         Effect = "invalidate_locale_cache"
 )
 
 // Definition 描述一个设置键的写入策略。
 // Normalize 可以为空以支持只有外部影响的透传设置；定义至少需要一种能力。
 // 校验、脱敏和读取默认值仍由现有 typed setting API 负责。
+// SecretPaths 以点分路径登记密钥字段，版本历史展示与环境快照导出据此脱敏或加密；
+// Runtime 标记运行时状态键，不记录版本历史，也不参与快照导入导出。
 type Definition struct {
-	Key       string
-	Normalize Normalizer
-	Effects   []Effect
+	Key         string
+	Normalize   Normalizer
+	Effects     []Effect
+	SecretPaths []string
+	Runtime     bool
 }
 
 // Registry 是按设置键索引的不可变定义集合。
@@ -45,7 +50,7 @@ func NewRegistry(definitions ...Definition) (Registry, error) {
 		if strings.TrimSpace(definition.Key) != definition.Key {
 			return Registry{}, fmt.Errorf("settings registry: definition key %q contains surrounding whitespace", definition.Key)
 		}
-		if definition.Normalize == nil && len(definition.Effects) == 0 {
+		if definition.Normalize == nil && len(definition.Effects) == 0 && len(definition.SecretPaths) == 0 && !definition.Runtime {
 			return Registry{}, fmt.Errorf("settings registry: definition %q requires at least one capability", definition.Key)
 		}
 		if _, exists := indexed[definition.Key]; exists {
@@ -61,7 +66,13 @@ func NewRegistry(definitions ...Definition) (Registry, error) {
 			}
 			seenEffects[effect] = struct{}{}
 		}
+		for _, path := range definition.SecretPaths {
+			if strings.TrimSpace(path) == "" || strings.TrimSpace(path) != path {
+				return Registry{}, fmt.Errorf("settings registry: definition %q contains an invalid secret path %q", definition.Key, path)
+			}
+		}
 		definition.Effects = append([]Effect(nil), definition.Effects...)
+		definition.SecretPaths = append([]string(nil), definition.SecretPaths...)
 		indexed[definition.Key] = definition
 	}
 	return Registry{definitions: indexed}, nil
@@ -97,6 +108,21 @@ func (registry Registry) Effects(key string) []Effect {
 	return append([]Effect(nil), definition.Effects...)
 }
 
+// SecretPaths 返回设置键登记的密钥字段路径副本；未知 key 没有密钥字段。
+func (registry Registry) SecretPaths(key string) []string {
+	definition, exists := registry.definitions[key]
+	if !exists || len(definition.SecretPaths) == 0 {
+		return nil
+	}
+	return append([]string(nil), definition.SecretPaths...)
+}
+
+// IsRuntime 判断设置键是否为运行时状态；运行时状态不记录版本历史。
+func (registry Registry) IsRuntime(key string) bool {
+	definition, exists := registry.definitions[key]
+	return exists && definition.Runtime
+}
+
 // Keys 返回排序后的定义键副本，供覆盖检查和诊断使用。
 func (registry Registry) Keys() []string {
 	keys := make([]string, 0, len(registry.definitions))
@@ -5,10 +5,10 @@ import (
 	"strings"
 
 	"github.com/dujiao-next/internal/constants"
+	"github.com/dujiao-next/internal/i18n/locales"
 	"github.com/dujiao-next/internal/shared/jsonmap"
 )
 
-var settingSupportedLanguages = append([]string(nil), constants.SupportedLocales...)
 var settingCurrencyCodePattern = regexp.MustCompile(`^[A-Z]{3}$`)
 
 const (
@@ -172,8 +172,8 @@ func normalizeSiteLocalizedBlock(raw interface{}, fields []string) map[string]in
 }
 
 func normalizeSiteLocalizedField(raw interface{}) map[string]interface{} {
-	fieldResult := make(map[string]interface{}, len(settingSupportedLanguages))
-	for _, language := range settingSupportedLanguages {
+	fieldResult := make(map[string]interface{}, len(locales.Enabled()))
+	for _, language := range locales.Enabled() {
 		fieldResult[language] = ""
 	}
 
@@ -182,7 +182,7 @@ func normalizeSiteLocalizedField(raw interface{}) map[string]interface{} {
 		return fieldResult
 	}
 
-	for _, language := range settingSupportedLanguages {
+	for _, language := range locales.Enabled() {
 		fieldResult[language] = normalizeSettingText(fieldRaw[language])
 	}
 
@@ -199,7 +199,7 @@ func normalizeSiteLocalizedList(raw interface{}, maxItems int) []interface{} {
 	for _, item := range listRaw {
 		normalized := normalizeSiteLocalizedField(item)
 		hasText := false
-		for _, language := range settingSupportedLanguages {
+		for _, language := range locales.Enabled() {
 			text, _ := normalized[language].(string)
 			if text != "" {
 				hasText = true
@@ -274,7 +274,7 @@ func normalizeSiteLanguages(raw interface{}) []string {
 			list = append(list, normalizeSettingText(item))
 		}
 	default:
-		return append([]string(nil), settingSupportedLanguages...)
+		return locales.Enabled()
 	}
 
 	result := make([]string, 0, len(list))
@@ -291,7 +291,7 @@ func normalizeSiteLanguages(raw interface{}) []string {
 		result = append(result, lang)
 	}
 	if len(result) == 0 {
-		return append([]string(nil), settingSupportedLanguages...)
+		return locales.Enabled()
 	}
 	return result
 }
@@ -340,7 +340,7 @@ func normalizeNavConfig(value map[string]interface{}) jsonmap.JSON {
 			title := normalizeSiteLocalizedField(itemMap["title"])
 			// 跳过全空标题项
 			allEmpty := true
-			for _, lang := range settingSupportedLanguages {
+			for _, lang := range locales.Enabled() {
 				if s, _ := title[lang].(string); s != "" {
 					allEmpty = false
 					break
@@ -350,7 +350,7 @@ func normalizeNavConfig(value map[string]interface{}) jsonmap.JSON {
 				continue
 			}
 			// 截断标题长度
-			for _, lang := range settingSupportedLanguages {
+			for _, lang := range locales.Enabled() {
 				if s, _ := title[lang].(string); s != "" {
 					title[lang] = normalizeSettingTextWithRuneLimit(s, settingNavCustomItemTitleMaxRuneSize)
 				}
@@ -100,7 +100,7 @@ func TestPatchSMTPSettingKeepsPasswordWhenEmpty(t *testing.T) {
 	updated, err := svc.PatchSMTPSetting(defaultCfg, settingsmessaging.SMTPSettingPatch{
 		Host:     ptrString("smtp.custom.com"),
 		Password: ptrString(""),
-	})
+	}, 1)
 	if err != nil {
 		t.Fatalf("patch smtp setting failed: %v", err)
 	}
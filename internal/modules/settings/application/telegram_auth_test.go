@@ -48,7 +48,7 @@ func TestPatchTelegramAuthSettingKeepsTokenWhenEmpty(t *testing.T) {
 		MiniAppURL:         ptrString(" https://example.com/mini-app "),
 		LoginExpireSeconds: ptrInt(600),
 		ReplayTTLSeconds:   ptrInt(900),
-	})
+	}, 1)
 	if err != nil {
 		t.Fatalf("patch telegram auth setting failed: %v", err)
 	}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/dujiao-next/internal/config"
 	"github.com/dujiao-next/internal/constants"
+	"github.com/dujiao-next/internal/i18n/locales"
 	settingsintegration "github.com/dujiao-next/internal/modules/settings/schema/integration"
 	settingsmessaging "github.com/dujiao-next/internal/modules/settings/schema/messaging"
 	settingssecurity "github.com/dujiao-next/internal/modules/settings/schema/security"
@@ -40,6 +41,22 @@ func (s *Service) GetDashboardLowStockThreshold() int {
 	return int(setting.Alert.LowStockThreshold)
 }
 
+// GetWalletFundsSetting 获取钱包转账与提现设置（读取 wallet_config，空时回退默认）。
+func (s *Service) GetWalletFundsSetting() (settingsintegration.WalletFundsSetting, error) {
+	fallback := settingsintegration.DefaultWalletFundsSetting()
+	if s == nil {
+		return fallback, nil
+	}
+	value, err := s.GetByKey(constants.SettingKeyWalletConfig)
+	if err != nil {
+		return fallback, err
+	}
+	if value == nil {
+		return fallback, nil
+	}
+	return settingsintegration.DecodeWalletFundsSetting(value, fallback), nil
+}
+
 // GetAffiliateSetting 获取推广返利设置（优先 settings，空时回退默认）。
 func (s *Service) GetAffiliateSetting() (settingsintegration.AffiliateSetting, error) {
 	fallback := settingsintegration.DefaultAffiliateSetting()
@@ -57,12 +74,12 @@ func (s *Service) GetAffiliateSetting() (settingsintegration.AffiliateSetting, e
 }
 
 // UpdateAffiliateSetting 更新推广返利设置。
-func (s *Service) UpdateAffiliateSetting(setting settingsintegration.AffiliateSetting) (settingsintegration.AffiliateSetting, error) {
+func (s *Service) UpdateAffiliateSetting(setting settingsintegration.AffiliateSetting, adminID uint) (settingsintegration.AffiliateSetting, error) {
 	normalized := settingsintegration.NormalizeAffiliateSetting(setting)
 	if err := settingsintegration.ValidateAffiliateSetting(normalized); err != nil {
 		return settingsintegration.DefaultAffiliateSetting(), err
 	}
-	if _, err := s.Update(constants.SettingKeyAffiliateConfig, map[string]interface{}(settingsintegration.EncodeAffiliateSetting(normalized))); err != nil {
+	if _, err := s.UpdateWithEffects(constants.SettingKeyAffiliateConfig, map[string]interface{}(settingsintegration.EncodeAffiliateSetting(normalized)), WriteMeta{AdminID: adminID}); err != nil {
 		return settingsintegration.DefaultAffiliateSetting(), err
 	}
 	return normalized, nil
@@ -108,7 +125,7 @@ func (s *Service) GetNotificationCenterSetting() (settingsmessaging.Notification
 }
 
 // PatchNotificationCenterSetting 基于补丁更新通知中心配置。
-func (s *Service) PatchNotificationCenterSetting(patch settingsmessaging.NotificationCenterSettingPatch) (settingsmessaging.NotificationCenterSetting, error) {
+func (s *Service) PatchNotificationCenterSetting(patch settingsmessaging.NotificationCenterSettingPatch, adminID uint) (settingsmessaging.NotificationCenterSetting, error) {
 	current, err := s.GetNotificationCenterSetting()
 	if err != nil {
 		return settingsmessaging.NotificationCenterSetting{}, err
@@ -117,7 +134,7 @@ func (s *Service) PatchNotificationCenterSetting(patch settingsmessaging.Notific
 	if err != nil {
 		return settingsmessaging.NotificationCenterSetting{}, err
 	}
-	if _, err := s.Update(constants.SettingKeyNotificationCenterConfig, settingsmessaging.NotificationCenterSettingToMap(next)); err != nil {
+	if _, err := s.UpdateWithEffects(constants.SettingKeyNotificationCenterConfig, settingsmessaging.NotificationCenterSettingToMap(next), WriteMeta{AdminID: adminID}); err != nil {
 		return settingsmessaging.NotificationCenterSetting{}, err
 	}
 	return next, nil
@@ -140,7 +157,7 @@ func (s *Service) GetSMTPSetting(defaultCfg config.EmailConfig) (settingsmessagi
 }
 
 // PatchSMTPSetting 基于补丁更新 SMTP 设置。
-func (s *Service) PatchSMTPSetting(defaultCfg config.EmailConfig, patch settingsmessaging.SMTPSettingPatch) (settingsmessaging.SMTPSetting, error) {
+func (s *Service) PatchSMTPSetting(defaultCfg config.EmailConfig, patch settingsmessaging.SMTPSettingPatch, adminID uint) (settingsmessaging.SMTPSetting, error) {
 	current, err := s.GetSMTPSetting(defaultCfg)
 	if err != nil {
 		return settingsmessaging.SMTPSetting{}, err
@@ -149,7 +166,7 @@ func (s *Service) PatchSMTPSetting(defaultCfg config.EmailConfig, patch settings
 	if err != nil {
 		return settingsmessaging.SMTPSetting{}, err
 	}
-	if _, err := s.Update(constants.SettingKeySMTPConfig, map[string]interface{}(settingsmessaging.EncodeSMTPSetting(next))); err != nil {
+	if _, err := s.UpdateWithEffects(constants.SettingKeySMTPConfig, map[string]interface{}(settingsmessaging.EncodeSMTPSetting(next)), WriteMeta{AdminID: adminID}); err != nil {
 		return settingsmessaging.SMTPSetting{}, err
 	}
 	return next, nil
@@ -172,7 +189,7 @@ func (s *Service) GetCaptchaSetting(defaultCfg config.CaptchaConfig) (settingsse
 }
 
 // PatchCaptchaSetting 基于补丁更新验证码设置。
-func (s *Service) PatchCaptchaSetting(defaultCfg config.CaptchaConfig, patch settingssecurity.CaptchaSettingPatch) (settingssecurity.CaptchaSetting, error) {
+func (s *Service) PatchCaptchaSetting(defaultCfg config.CaptchaConfig, patch settingssecurity.CaptchaSettingPatch, adminID uint) (settingssecurity.CaptchaSetting, error) {
 	current, err := s.GetCaptchaSetting(defaultCfg)
 	if err != nil {
 		return settingssecurity.CaptchaSetting{}, err
@@ -181,7 +198,7 @@ func (s *Service) PatchCaptchaSetting(defaultCfg config.CaptchaConfig, patch set
 	if err != nil {
 		return settingssecurity.CaptchaSetting{}, err
 	}
-	if _, err := s.Update(constants.SettingKeyCaptchaConfig, map[string]interface{}(settingssecurity.EncodeCaptchaSetting(next))); err != nil {
+	if _, err := s.UpdateWithEffects(constants.SettingKeyCaptchaConfig, map[string]interface{}(settingssecurity.EncodeCaptchaSetting(next)), WriteMeta{AdminID: adminID}); err != nil {
 		return settingssecurity.CaptchaSetting{}, err
 	}
 	return next, nil
@@ -204,7 +221,7 @@ func (s *Service) GetTelegramAuthSetting(defaultCfg config.TelegramAuthConfig) (
 }
 
 // PatchTelegramAuthSetting 基于补丁更新 Telegram 登录配置。
-func (s *Service) PatchTelegramAuthSetting(defaultCfg config.TelegramAuthConfig, patch settingssecurity.TelegramAuthSettingPatch) (settingssecurity.TelegramAuthSetting, error) {
+func (s *Service) PatchTelegramAuthSetting(defaultCfg config.TelegramAuthConfig, patch settingssecurity.TelegramAuthSettingPatch, adminID uint) (settingssecurity.TelegramAuthSetting, error) {
 	current, err := s.GetTelegramAuthSetting(defaultCfg)
 	if err != nil {
 		return settingssecurity.TelegramAuthSetting{}, err
@@ -213,7 +230,7 @@ func (s *Service) PatchTelegramAuthSetting(defaultCfg config.TelegramAuthConfig,
 	if err := settingssecurity.ValidateTelegramAuthSetting(next); err != nil {
 		return settingssecurity.TelegramAuthSetting{}, err
 	}
-	if _, err := s.Update(constants.SettingKeyTelegramAuthConfig, map[string]interface{}(settingssecurity.EncodeTelegramAuthSetting(next))); err != nil {
+	if _, err := s.UpdateWithEffects(constants.SettingKeyTelegramAuthConfig, map[string]interface{}(settingssecurity.EncodeTelegramAuthSetting(next)), WriteMeta{AdminID: adminID}); err != nil {
 		return settingssecurity.TelegramAuthSetting{}, err
 	}
 	return next, nil
@@ -236,7 +253,7 @@ func (s *Service) GetGoogleAuthSetting(defaultCfg config.GoogleAuthConfig) (sett
 }
 
 // PatchGoogleAuthSetting 基于补丁更新 Google Identity Services 登录配置。
-func (s *Service) PatchGoogleAuthSetting(defaultCfg config.GoogleAuthConfig, patch settingssecurity.GoogleAuthSettingPatch) (settingssecurity.GoogleAuthSetting, error) {
+func (s *Service) PatchGoogleAuthSetting(defaultCfg config.GoogleAuthConfig, patch settingssecurity.GoogleAuthSettingPatch, adminID uint) (settingssecurity.GoogleAuthSetting, error) {
 	current, err := s.GetGoogleAuthSetting(defaultCfg)
 	if err != nil {
 		return settingssecurity.GoogleAuthSetting{}, err
@@ -245,12 +262,50 @@ func (s *Service) PatchGoogleAuthSetting(defaultCfg config.GoogleAuthConfig, pat
 	if err := settingssecurity.ValidateGoogleAuthSetting(next); err != nil {
 		return settingssecurity.GoogleAuthSetting{}, err
 	}
-	if _, err := s.Update(constants.SettingKeyGoogleAuthConfig, map[string]interface{}(settingssecurity.EncodeGoogleAuthSetting(next))); err != nil {
+	if _, err := s.UpdateWithEffects(constants.SettingKeyGoogleAuthConfig, map[string]interface{}(settingssecurity.EncodeGoogleAuthSetting(next)), WriteMeta{AdminID: adminID}); err != nil {
 		return settingssecurity.GoogleAuthSetting{}, err
 	}
 	return next, nil
 }
 
+// GetLocaleSetting 获取语言配置（禁用语言与消息覆盖）。
+func (s *Service) GetLocaleSetting() (settingsmessaging.LocaleSetting, error) {
+	if s == nil {
+		return settingsmessaging.DecodeLocaleSetting(nil), nil
+	}
+	value, err := s.GetByKey(constants.SettingKeyLocaleConfig)
+	if err != nil {
+		return settingsmessaging.DecodeLocaleSetting(nil), err
+	}
+	return settingsmessaging.DecodeLocaleSetting(value), nil
+}
+
+// PatchLocaleSetting 基于补丁更新语言配置。
+func (s *Service) PatchLocaleSetting(patch settingsmessaging.LocaleSettingPatch, adminID uint) (settingsmessaging.LocaleSetting, error) {
+	current, err := s.GetLocaleSetting()
+	if err != nil {
+		return settingsmessaging.LocaleSetting{}, err
+	}
+	next := settingsmessaging.ApplyLocaleSettingPatch(current, patch)
+	if err := settingsmessaging.ValidateLocaleSetting(next); err != nil {
+		return settingsmessaging.LocaleSetting{}, err
+	}
+	next = settingsmessaging.NormalizeLocaleSetting(next)
+	if _, err := s.UpdateWithEffects(constants.SettingKeyLocaleConfig, settingsmessaging.LocaleSettingToMap(next), WriteMeta{AdminID: adminID}); err != nil {
+		return settingsmessaging.LocaleSetting{}, err
+	}
+	return next, nil
+}
+
+// LoadLocaleOverrides 读取语言配置并转换为语言目录覆盖，供 locales 懒刷新使用。
+func (s *Service) LoadLocaleOverrides() (locales.Overrides, error) {
+	setting, err := s.GetLocaleSetting()
+	if err != nil {
+		return locales.Overrides{}, err
+	}
+	return settingsmessaging.LocaleSettingToOverrides(setting), nil
+}
+
 // GetOrderEmailTemplateSetting 获取订单邮件模板配置（优先 settings，空时回退默认）。
 func (s *Service) GetOrderEmailTemplateSetting() (settingsmessaging.OrderEmailTemplateSetting, error) {
 	fallback := settingsmessaging.DefaultOrderEmailTemplateSetting()
@@ -268,7 +323,7 @@ func (s *Service) GetOrderEmailTemplateSetting() (settingsmessaging.OrderEmailTe
 }
 
 // PatchOrderEmailTemplateSetting 基于补丁更新订单邮件模板配置。
-func (s *Service) PatchOrderEmailTemplateSetting(patch settingsmessaging.OrderEmailTemplateSettingPatch) (settingsmessaging.OrderEmailTemplateSetting, error) {
+func (s *Service) PatchOrderEmailTemplateSetting(patch settingsmessaging.OrderEmailTemplateSettingPatch, adminID uint) (settingsmessaging.OrderEmailTemplateSetting, error) {
 	current, err := s.GetOrderEmailTemplateSetting()
 	if err != nil {
 		return settingsmessaging.OrderEmailTemplateSetting{}, err
@@ -277,19 +332,19 @@ func (s *Service) PatchOrderEmailTemplateSetting(patch settingsmessaging.OrderEm
 	if err != nil {
 		return settingsmessaging.OrderEmailTemplateSetting{}, err
 	}
-	if _, err := s.Update(constants.SettingKeyOrderEmailTemplateConfig, map[string]interface{}(settingsmessaging.EncodeOrderEmailTemplateSetting(next))); err != nil {
+	if _, err := s.UpdateWithEffects(constants.SettingKeyOrderEmailTemplateConfig, map[string]interface{}(settingsmessaging.EncodeOrderEmailTemplateSetting(next)), WriteMeta{AdminID: adminID}); err != nil {
 		return settingsmessaging.OrderEmailTemplateSetting{}, err
 	}
 	return next, nil
 }
 
 // ResetOrderEmailTemplateSetting 重置订单邮件模板为默认。
-func (s *Service) ResetOrderEmailTemplateSetting() (settingsmessaging.OrderEmailTemplateSetting, error) {
+func (s *Service) ResetOrderEmailTemplateSetting(adminID uint) (settingsmessaging.OrderEmailTemplateSetting, error) {
 	defaultSetting := settingsmessaging.DefaultOrderEmailTemplateSetting()
 	if s == nil {
 		return defaultSetting, nil
 	}
-	if _, err := s.Update(constants.SettingKeyOrderEmailTemplateConfig, map[string]interface{}(settingsmessaging.EncodeOrderEmailTemplateSetting(defaultSetting))); err != nil {
+	if _, err := s.UpdateWithEffects(constants.SettingKeyOrderEmailTemplateConfig, map[string]interface{}(settingsmessaging.EncodeOrderEmailTemplateSetting(defaultSetting)), WriteMeta{AdminID: adminID}); err != nil {
 		return settingsmessaging.OrderEmailTemplateSetting{}, err
 	}
 	return defaultSetting, nil
@@ -314,7 +369,7 @@ func (s *Service) GetTelegramBotConfig() (settingsmessaging.TelegramBotConfigSet
 }
 
 // UpdateTelegramBotConfig 整对象覆盖更新 Telegram Bot 配置，自动递增 config_version。
-func (s *Service) UpdateTelegramBotConfig(cfg settingsmessaging.TelegramBotConfigSetting) (settingsmessaging.TelegramBotConfigSetting, error) {
+func (s *Service) UpdateTelegramBotConfig(cfg settingsmessaging.TelegramBotConfigSetting, adminID uint) (settingsmessaging.TelegramBotConfigSetting, error) {
 	current, err := s.GetTelegramBotConfig()
 	if err != nil {
 		return settingsmessaging.TelegramBotConfigSetting{}, err
@@ -330,7 +385,7 @@ func (s *Service) UpdateTelegramBotConfig(cfg settingsmessaging.TelegramBotConfi
 	cfg.Help.Items = settingsmessaging.NormalizeHelpItems(cfg.Help.Items)
 	cfg.Menu.Items = settingsmessaging.NormalizeTelegramBotMenuItems(cfg.Menu.Items)
 
-	if _, err := s.Update(constants.SettingKeyTelegramBotConfig, settingsmessaging.EncodeTelegramBotConfig(cfg)); err != nil {
+	if _, err := s.UpdateWithEffects(constants.SettingKeyTelegramBotConfig, settingsmessaging.EncodeTelegramBotConfig(cfg), WriteMeta{AdminID: adminID}); err != nil {
 		return settingsmessaging.TelegramBotConfigSetting{}, err
 	}
 
@@ -0,0 +1,59 @@
+package versioning
+
+import (
+	settingsapp "github.com/dujiao-next/internal/modules/settings/application"
+	settingscontract "github.com/dujiao-next/internal/modules/settings/contract"
+	settingsvalue "github.com/dujiao-next/internal/modules/settings/schema/value"
+)
+
+// ListVersions 分页查询设置版本，快照中的密钥字段已脱敏。
+func (s *Service) ListVersions(filter settingscontract.VersionFilter) ([]settingscontract.Version, int64, error) {
+	versions, total, err := s.history.ListVersions(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	for i := range versions {
+		versions[i] = s.maskVersion(versions[i])
+	}
+	return versions, total, nil
+}
+
+// GetVersion 查询单个设置版本（脱敏）。
+func (s *Service) GetVersion(id uint) (settingscontract.Version, error) {
+	version, found, err := s.history.GetVersion(id)
+	if err != nil {
+		return settingscontract.Version{}, err
+	}
+	if !found {
+		return settingscontract.Version{}, settingscontract.ErrVersionNotFound
+	}
+	return s.maskVersion(version), nil
+}
+
+// Rollback 把设置恢复为指定版本写入后的值。
+// 回滚作为一次新写入重新走归一化、版本记录与副作用声明，不会改写已有历史。
+func (s *Service) Rollback(versionID, adminID uint) (settingsapp.UpdateResult, error) {
+	version, found, err := s.history.GetVersion(versionID)
+	if err != nil {
+		return settingsapp.UpdateResult{}, err
+	}
+	if !found {
+		return settingsapp.UpdateResult{}, settingscontract.ErrVersionNotFound
+	}
+	if version.Value == nil || s.settings.IsRuntimeKey(version.Key) {
+		return settingsapp.UpdateResult{}, settingscontract.ErrVersionNotRollbackable
+	}
+	sourceID := version.ID
+	return s.settings.UpdateWithEffects(
+		version.Key,
+		map[string]interface{}(settingsvalue.CloneJSON(version.Value)),
+		settingsapp.WriteMeta{AdminID: adminID, Action: settingscontract.VersionActionRollback, SourceVersionID: &sourceID},
+	)
+}
+
+func (s *Service) maskVersion(version settingscontract.Version) settingscontract.Version {
+	secretPaths := s.settings.SecretPaths(version.Key)
+	version.Value = settingsvalue.MaskSecretPaths(version.Value, secretPaths)
+	version.Previous = settingsvalue.MaskSecretPaths(version.Previous, secretPaths)
+	return version
+}
@@ -0,0 +1,38 @@
+package versioning
+
+import (
+	settingsapp "github.com/dujiao-next/internal/modules/settings/application"
+	settingscontract "github.com/dujiao-next/internal/modules/settings/contract"
+	"github.com/dujiao-next/internal/shared/jsonmap"
+)
+
+// Settings 是版本回滚与快照导入依赖的设置写入端口；写入必须经过 Registry 归一化与副作用声明。
+type Settings interface {
+	GetByKey(key string) (jsonmap.JSON, error)
+	UpdateWithEffects(key string, value map[string]interface{}, meta settingsapp.WriteMeta) (settingsapp.UpdateResult, error)
+	SecretPaths(key string) []string
+	IsRuntimeKey(key string) bool
+	InvalidateCallbackRoutesCache()
+}
+
+// Service 提供设置版本历史、回滚与环境快照导入导出。
+type Service struct {
+	settings Settings
+	history  settingscontract.HistoryStore
+}
+
+// NewService 创建设置版本服务。
+func NewService(settings Settings, history settingscontract.HistoryStore) *Service {
+	if settings == nil {
+		panic("settings versioning: settings is nil")
+	}
+	if history == nil {
+		panic("settings versioning: history store is nil")
+	}
+	return &Service{settings: settings, history: history}
+}
+
+// InvalidateCallbackRoutesCache 透传回调路由缓存失效，供回滚/导入后处理副作用。
+func (s *Service) InvalidateCallbackRoutesCache() {
+	s.settings.InvalidateCallbackRoutesCache()
+}
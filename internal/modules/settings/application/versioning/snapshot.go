@@ -0,0 +1,260 @@
+package versioning
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dujiao-next/internal/crypto"
+	settingsapp "github.com/dujiao-next/internal/modules/settings/application"
+	settingscontract "github.com/dujiao-next/internal/modules/settings/contract"
+	settingsvalue "github.com/dujiao-next/internal/modules/settings/schema/value"
+	"github.com/dujiao-next/internal/shared/jsonmap"
+)
+
+const (
+	SnapshotFormat        = "dujiao-next/settings"
+	SnapshotSchemaVersion = 1
+
+	// SecretModeOmit 导出时去掉密钥字段，导入时保留目标环境现有密钥。
+	SecretModeOmit = "omit"
+	// SecretModeEncrypt 导出时用口令重新加密密钥字段，导入时需提供同一口令。
+	SecretModeEncrypt = "encrypt"
+
+	encryptedSecretPrefix = "enc:v1:"
+	maxSnapshotSettings   = 200
+)
+
+// Snapshot 是可在环境之间迁移的设置快照。
+type Snapshot struct {
+	Format        string                  `json:"format"`
+	SchemaVersion int                     `json:"schema_version"`
+	ExportedAt    time.Time               `json:"exported_at"`
+	SecretMode    string                  `json:"secret_mode"`
+	Settings      map[string]jsonmap.JSON `json:"settings"`
+}
+
+// ExportInput 导出参数。
+type ExportInput struct {
+	SecretMode string
+	Passphrase string
+}
+
+// ImportInput 导入参数。
+type ImportInput struct {
+	Snapshot   Snapshot
+	Passphrase string
+	AdminID    uint
+}
+
+// ImportResult 导入结果：写入的键、跳过的运行时键以及需要调用方处理的副作用。
+type ImportResult struct {
+	Applied []string
+	Skipped []string
+	Effects []settingsapp.Effect
+}
+
+// HasEffect 判断导入是否声明了指定外部影响。
+func (result ImportResult) HasEffect(effect settingsapp.Effect) bool {
+	for _, candidate := range result.Effects {
+		if candidate == effect {
+			return true
+		}
+	}
+	return false
+}
+
+// Export 导出全部设置（不含运行时状态），密钥字段按模式去除或用口令加密。
+func (s *Service) Export(input ExportInput) (Snapshot, error) {
+	mode := normalizeSecretMode(input.SecretMode)
+	if mode == "" {
+		return Snapshot{}, settingscontract.ErrSnapshotInvalid
+	}
+	var key []byte
+	if mode == SecretModeEncrypt {
+		if strings.TrimSpace(input.Passphrase) == "" {
+			return Snapshot{}, settingscontract.ErrSnapshotPassphrase
+		}
+		key = crypto.DeriveKey(input.Passphrase)
+	}
+
+	stored, err := s.history.ListSettings()
+	if err != nil {
+		return Snapshot{}, err
+	}
+	settings := make(map[string]jsonmap.JSON, len(stored))
+	for settingKey, value := range stored {
+		if s.settings.IsRuntimeKey(settingKey) {
+			continue
+		}
+		exported := settingsvalue.CloneJSON(value)
+		if exported == nil {
+			exported = jsonmap.JSON{}
+		}
+		for _, path := range s.settings.SecretPaths(settingKey) {
+			secret, ok := settingsvalue.LookupPath(exported, path)
+			if !ok {
+				continue
+			}
+			if mode == SecretModeOmit {
+				settingsvalue.DeletePath(exported, path)
+				continue
+			}
+			encrypted, err := encryptSecret(key, secret)
+			if err != nil {
+				return Snapshot{}, err
+			}
+			settingsvalue.SetPath(exported, path, encrypted)
+		}
+		settings[settingKey] = exported
+	}
+	return Snapshot{
+		Format:        SnapshotFormat,
+		SchemaVersion: SnapshotSchemaVersion,
+		ExportedAt:    time.Now(),
+		SecretMode:    mode,
+		Settings:      settings,
+	}, nil
+}
+
+// Import 导入设置快照。所有键先完成校验与解密再逐键写入，避免口令错误时只导入一半；
+// 每个键都经由 UpdateWithEffects 写入，记录为 import 版本并返回合并后的副作用。
+func (s *Service) Import(input ImportInput) (ImportResult, error) {
+	snapshot := input.Snapshot
+	if snapshot.Format != SnapshotFormat || snapshot.SchemaVersion != SnapshotSchemaVersion {
+		return ImportResult{}, settingscontract.ErrSnapshotInvalid
+	}
+	mode := normalizeSecretMode(snapshot.SecretMode)
+	if mode == "" || len(snapshot.Settings) == 0 || len(snapshot.Settings) > maxSnapshotSettings {
+		return ImportResult{}, settingscontract.ErrSnapshotInvalid
+	}
+	var key []byte
+	if mode == SecretModeEncrypt {
+		if strings.TrimSpace(input.Passphrase) == "" {
+			return ImportResult{}, settingscontract.ErrSnapshotPassphrase
+		}
+		key = crypto.DeriveKey(input.Passphrase)
+	}
+
+	keys := make([]string, 0, len(snapshot.Settings))
+	for settingKey := range snapshot.Settings {
+		if settingKey == "" || strings.TrimSpace(settingKey) != settingKey || len(settingKey) > 64 {
+			return ImportResult{}, settingscontract.ErrSnapshotInvalid
+		}
+		keys = append(keys, settingKey)
+	}
+	sort.Strings(keys)
+
+	result := ImportResult{}
+	prepared := make(map[string]jsonmap.JSON, len(keys))
+	for _, settingKey := range keys {
+		if s.settings.IsRuntimeKey(settingKey) {
+			result.Skipped = append(result.Skipped, settingKey)
+			continue
+		}
+		value, err := s.prepareImportValue(settingKey, snapshot.Settings[settingKey], mode, key)
+		if err != nil {
+			return ImportResult{}, err
+		}
+		prepared[settingKey] = value
+	}
+
+	seenEffects := map[settingsapp.Effect]struct{}{}
+	for _, settingKey := range keys {
+		value, ok := prepared[settingKey]
+		if !ok {
+			continue
+		}
+		updated, err := s.settings.UpdateWithEffects(
+			settingKey,
+			map[string]interface{}(value),
+			settingsapp.WriteMeta{AdminID: input.AdminID, Action: settingscontract.VersionActionImport},
+		)
+		if err != nil {
+			return result, err
+		}
+		result.Applied = append(result.Applied, settingKey)
+		for _, effect := range updated.Effects {
+			if _, exists := seenEffects[effect]; exists {
+				continue
+			}
+			seenEffects[effect] = struct{}{}
+			result.Effects = append(result.Effects, effect)
+		}
+	}
+	return result, nil
+}
+
+// prepareImportValue 解密密钥字段；快照中缺失的密钥沿用目标环境当前值。
+func (s *Service) prepareImportValue(settingKey string, raw jsonmap.JSON, mode string, key []byte) (jsonmap.JSON, error) {
+	value := settingsvalue.CloneJSON(raw)
+	if value == nil {
+		value = jsonmap.JSON{}
+	}
+	secretPaths := s.settings.SecretPaths(settingKey)
+	if len(secretPaths) == 0 {
+		return value, nil
+	}
+	current, err := s.settings.GetByKey(settingKey)
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range secretPaths {
+		secret, ok := settingsvalue.LookupPath(value, path)
+		if !ok {
+			if existing, found := settingsvalue.LookupPath(current, path); found {
+				settingsvalue.SetPath(value, path, existing)
+			}
+			continue
+		}
+		text, isText := secret.(string)
+		if mode != SecretModeEncrypt || !isText || !strings.HasPrefix(text, encryptedSecretPrefix) {
+			continue
+		}
+		decrypted, err := decryptSecret(key, text)
+		if err != nil {
+			return nil, err
+		}
+		settingsvalue.SetPath(value, path, decrypted)
+	}
+	return value, nil
+}
+
+func normalizeSecretMode(mode string) string {
+	switch strings.ToLower(strings.TrimSpace(mode)) {
+	case "", SecretModeOmit:
+		return SecretModeOmit
+	case SecretModeEncrypt:
+		return SecretModeEncrypt
+	default:
+		return ""
+	}
+}
+
+func encryptSecret(key []byte, secret interface{}) (interface{}, error) {
+	if text, ok := secret.(string); ok && text == "" {
+		return "", nil
+	}
+	raw, err := json.Marshal(secret)
+	if err != nil {
+		return nil, err
+	}
+	encrypted, err := crypto.Encrypt(key, string(raw))
+	if err != nil {
+		return nil, err
+	}
+	return encryptedSecretPrefix + encrypted, nil
+}
+
+func decryptSecret(key []byte, text string) (interface{}, error) {
+	plaintext, err := crypto.Decrypt(key, strings.TrimPrefix(text, encryptedSecretPrefix))
+	if err != nil {
+		return nil, settingscontract.ErrSnapshotDecryptFailed
+	}
+	var secret interface{}
+	if err := json.Unmarshal([]byte(plaintext), &secret); err != nil {
+		return nil, settingscontract.ErrSnapshotDecryptFailed
+	}
+	return secret, nil
+}
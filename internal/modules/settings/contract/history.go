@@ -0,0 +1,53 @@
+package contract
+
+import (
+	"errors"
+	"time"
+
+	"github.com/dujiao-next/internal/shared/jsonmap"
+)
+
+// 设置版本的写入来源。
+const (
+	VersionActionUpdate   = "update"
+	VersionActionRollback = "rollback"
+	VersionActionImport   = "import"
+)
+
+var (
+	ErrVersionNotFound        = errors.New("setting version not found")
+	ErrVersionNotRollbackable = errors.New("setting version cannot be rolled back")
+	ErrSnapshotInvalid        = errors.New("settings snapshot is invalid")
+	ErrSnapshotPassphrase     = errors.New("settings snapshot passphrase is required")
+	ErrSnapshotDecryptFailed  = errors.New("settings snapshot secret decrypt failed")
+)
+
+// Version 是某个设置键一次写入后的完整快照；Diff 中的密钥字段已脱敏。
+type Version struct {
+	ID              uint
+	Key             string
+	Version         int
+	Action          string
+	Value           jsonmap.JSON
+	Previous        jsonmap.JSON
+	Diff            jsonmap.JSON
+	AdminID         *uint
+	SourceVersionID *uint
+	CreatedAt       time.Time
+}
+
+// VersionFilter 是设置版本列表查询条件。
+type VersionFilter struct {
+	Key      string
+	Page     int
+	PageSize int
+}
+
+// HistoryStore 持久化设置版本历史。UpsertVersioned 在同一事务内写入设置值并追加版本，
+// 版本号按设置键自增，由存储层在事务内分配。
+type HistoryStore interface {
+	UpsertVersioned(key string, value jsonmap.JSON, version Version) (jsonmap.JSON, error)
+	ListVersions(filter VersionFilter) ([]Version, int64, error)
+	GetVersion(id uint) (Version, bool, error)
+	ListSettings() (map[string]jsonmap.JSON, error)
+}
@@ -0,0 +1,152 @@
+package settingsstore
+
+import (
+	"errors"
+	"time"
+
+	settingscontract "github.com/dujiao-next/internal/modules/settings/contract"
+	"github.com/dujiao-next/internal/shared/jsonmap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SettingVersionRecord is the persistence representation of one settings write.
+type SettingVersionRecord struct {
+	ID              uint         `gorm:"primarykey"`
+	SettingKey      string       `gorm:"type:varchar(64);not null;uniqueIndex:idx_setting_version_key_version,priority:1"`
+	Version         int          `gorm:"not null;uniqueIndex:idx_setting_version_key_version,priority:2"`
+	Action          string       `gorm:"type:varchar(16);not null"`
+	ValueJSON       jsonmap.JSON `gorm:"type:json"`
+	PreviousJSON    jsonmap.JSON `gorm:"type:json"`
+	DiffJSON        jsonmap.JSON `gorm:"type:json"`
+	AdminID         *uint        `gorm:"index"`
+	SourceVersionID *uint
+	CreatedAt       time.Time `gorm:"index"`
+}
+
+// TableName keeps setting history next to the settings table.
+func (SettingVersionRecord) TableName() string {
+	return "setting_versions"
+}
+
+// UpsertVersioned writes the setting value and appends its version atomically.
+func (store *Store) UpsertVersioned(key string, value jsonmap.JSON, version settingscontract.Version) (jsonmap.JSON, error) {
+	var stored jsonmap.JSON
+	err := store.db.Transaction(func(tx *gorm.DB) error {
+		var record SettingRecord
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("key = ?", key).First(&record).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			record = SettingRecord{Key: key, ValueJSON: value}
+			if err := tx.Create(&record).Error; err != nil {
+				return err
+			}
+		case err != nil:
+			return err
+		default:
+			record.ValueJSON = value
+			if err := tx.Save(&record).Error; err != nil {
+				return err
+			}
+		}
+
+		var latest int
+		if err := tx.Model(&SettingVersionRecord{}).
+			Where("setting_key = ?", key).
+			Select("COALESCE(MAX(version), 0)").
+			Scan(&latest).Error; err != nil {
+			return err
+		}
+		row := SettingVersionRecord{
+			SettingKey:      key,
+			Version:         latest + 1,
+			Action:          version.Action,
+			ValueJSON:       value,
+			PreviousJSON:    version.Previous,
+			DiffJSON:        version.Diff,
+			AdminID:         version.AdminID,
+			SourceVersionID: version.SourceVersionID,
+			CreatedAt:       version.CreatedAt,
+		}
+		if row.CreatedAt.IsZero() {
+			row.CreatedAt = time.Now()
+		}
+		if err := tx.Create(&row).Error; err != nil {
+			return err
+		}
+		stored = record.ValueJSON
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stored, nil
+}
+
+// ListVersions lists setting versions newest first.
+func (store *Store) ListVersions(filter settingscontract.VersionFilter) ([]settingscontract.Version, int64, error) {
+	query := store.db.Model(&SettingVersionRecord{})
+	if filter.Key != "" {
+		query = query.Where("setting_key = ?", filter.Key)
+	}
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	page, pageSize := filter.Page, filter.PageSize
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+	var rows []SettingVersionRecord
+	if err := query.Order("id DESC").Offset((page - 1) * pageSize).Limit(pageSize).Find(&rows).Error; err != nil {
+		return nil, 0, err
+	}
+	versions := make([]settingscontract.Version, 0, len(rows))
+	for i := range rows {
+		versions = append(versions, rows[i].toContract())
+	}
+	return versions, total, nil
+}
+
+// GetVersion reads a setting version by ID.
+func (store *Store) GetVersion(id uint) (settingscontract.Version, bool, error) {
+	var row SettingVersionRecord
+	if err := store.db.First(&row, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return settingscontract.Version{}, false, nil
+		}
+		return settingscontract.Version{}, false, err
+	}
+	return row.toContract(), true, nil
+}
+
+// ListSettings reads every stored setting value keyed by setting key.
+func (store *Store) ListSettings() (map[string]jsonmap.JSON, error) {
+	var records []SettingRecord
+	if err := store.db.Order("key ASC").Find(&records).Error; err != nil {
+		return nil, err
+	}
+	result := make(map[string]jsonmap.JSON, len(records))
+	for _, record := range records {
+		result[record.Key] = record.ValueJSON
+	}
+	return result, nil
+}
+
+func (row SettingVersionRecord) toContract() settingscontract.Version {
+	return settingscontract.Version{
+		ID:              row.ID,
+		Key:             row.SettingKey,
+		Version:         row.Version,
+		Action:          row.Action,
+		Value:           row.ValueJSON,
+		Previous:        row.PreviousJSON,
+		Diff:            row.DiffJSON,
+		AdminID:         row.AdminID,
+		SourceVersionID: row.SourceVersionID,
+		CreatedAt:       row.CreatedAt,
+	}
+}
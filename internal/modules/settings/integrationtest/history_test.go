@@ -0,0 +1,186 @@
+package integrationtest
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dujiao-next/internal/constants"
+	settingsapp "github.com/dujiao-next/internal/modules/settings/application"
+	settingsversioning "github.com/dujiao-next/internal/modules/settings/application/versioning"
+	settingscontract "github.com/dujiao-next/internal/modules/settings/contract"
+	settingsstore "github.com/dujiao-next/internal/modules/settings/infrastructure/gormstore"
+	settingsvalue "github.com/dujiao-next/internal/modules/settings/schema/value"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupSettingsHistoryTest(t *testing.T) (*settingsapp.Service, *settingsversioning.Service) {
+	t.Helper()
+	dsn := fmt.Sprintf("file:settings_history_test_%d?mode=memory&cache=shared", time.Now().UnixNano())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite failed: %v", err)
+	}
+	if err := db.AutoMigrate(&settingsstore.SettingRecord{}, &settingsstore.SettingVersionRecord{}); err != nil {
+		t.Fatalf("auto migrate failed: %v", err)
+	}
+	store := settingsstore.New(db)
+	settings := settingsapp.NewService(store)
+	settings.SetHistoryStore(store)
+	return settings, settingsversioning.NewService(settings, store)
+}
+
+func smtpValue(host, password string) map[string]interface{} {
+	return map[string]interface{}{
+		"enabled":  false,
+		"host":     host,
+		"port":     587,
+		"username": "mailer",
+		"password": password,
+		"from":     "notify@example.com",
+	}
+}
+
+func TestSettingsHistoryRecordsMaskedDiffAndRollsBack(t *testing.T) {
+	settings, versioning := setupSettingsHistoryTest(t)
+
+	if _, err := settings.UpdateWithEffects(constants.SettingKeySMTPConfig, smtpValue("smtp-a.example.com", "secret-a"), settingsapp.WriteMeta{AdminID: 7}); err != nil {
+		t.Fatalf("first update: %v", err)
+	}
+	if _, err := settings.UpdateWithEffects(constants.SettingKeySMTPConfig, smtpValue("smtp-b.example.com", "secret-b"), settingsapp.WriteMeta{AdminID: 8}); err != nil {
+		t.Fatalf("second update: %v", err)
+	}
+	// 值未变化时不追加版本
+	if _, err := settings.UpdateWithEffects(constants.SettingKeySMTPConfig, smtpValue("smtp-b.example.com", "secret-b"), settingsapp.WriteMeta{AdminID: 8}); err != nil {
+		t.Fatalf("noop update: %v", err)
+	}
+
+	versions, total, err := versioning.ListVersions(settingscontract.VersionFilter{Key: constants.SettingKeySMTPConfig})
+	if err != nil {
+		t.Fatalf("list versions: %v", err)
+	}
+	if total != 2 || len(versions) != 2 {
+		t.Fatalf("expected 2 versions, got total=%d len=%d", total, len(versions))
+	}
+	latest, first := versions[0], versions[1]
+	if latest.Version != 2 || latest.AdminID == nil || *latest.AdminID != 8 || latest.Action != settingscontract.VersionActionUpdate {
+		t.Fatalf("unexpected latest version: %+v", latest)
+	}
+	hostDiff, ok := latest.Diff["host"].(map[string]interface{})
+	if !ok || hostDiff["before"] != "smtp-a.example.com" || hostDiff["after"] != "smtp-b.example.com" {
+		t.Fatalf("unexpected host diff: %+v", latest.Diff["host"])
+	}
+	passwordDiff, ok := latest.Diff["password"].(map[string]interface{})
+	if !ok || passwordDiff["before"] != settingsvalue.MaskedSecret || passwordDiff["after"] != settingsvalue.MaskedSecret {
+		t.Fatalf("password diff must be masked: %+v", latest.Diff["password"])
+	}
+
+	detail, err := versioning.GetVersion(first.ID)
+	if err != nil {
+		t.Fatalf("get version: %v", err)
+	}
+	if detail.Value["password"] != settingsvalue.MaskedSecret {
+		t.Fatalf("version snapshot must mask password, got %v", detail.Value["password"])
+	}
+	if _, err := versioning.GetVersion(first.ID + 100); !errors.Is(err, settingscontract.ErrVersionNotFound) {
+		t.Fatalf("expected version not found, got %v", err)
+	}
+
+	if _, err := versioning.Rollback(first.ID, 9); err != nil {
+		t.Fatalf("rollback: %v", err)
+	}
+	current, err := settings.GetByKey(constants.SettingKeySMTPConfig)
+	if err != nil {
+		t.Fatalf("get smtp: %v", err)
+	}
+	if current["host"] != "smtp-a.example.com" || current["password"] != "secret-a" {
+		t.Fatalf("rollback should restore first value, got %+v", current)
+	}
+	versions, _, err = versioning.ListVersions(settingscontract.VersionFilter{Key: constants.SettingKeySMTPConfig})
+	if err != nil {
+		t.Fatalf("list versions after rollback: %v", err)
+	}
+	rollback := versions[0]
+	if rollback.Version != 3 || rollback.Action != settingscontract.VersionActionRollback ||
+		rollback.SourceVersionID == nil || *rollback.SourceVersionID != first.ID {
+		t.Fatalf("unexpected rollback version: %+v", rollback)
+	}
+}
+
+func TestSettingsSnapshotExportImport(t *testing.T) {
+	source, sourceVersioning := setupSettingsHistoryTest(t)
+	if _, err := source.UpdateWithEffects(constants.SettingKeySMTPConfig, smtpValue("smtp.source.example.com", "source-secret"), settingsapp.WriteMeta{AdminID: 1}); err != nil {
+		t.Fatalf("seed source smtp: %v", err)
+	}
+
+	if _, err := sourceVersioning.Export(settingsversioning.ExportInput{SecretMode: settingsversioning.SecretModeEncrypt}); !errors.Is(err, settingscontract.ErrSnapshotPassphrase) {
+		t.Fatalf("expected passphrase required, got %v", err)
+	}
+	encrypted, err := sourceVersioning.Export(settingsversioning.ExportInput{SecretMode: settingsversioning.SecretModeEncrypt, Passphrase: "move-it"})
+	if err != nil {
+		t.Fatalf("export encrypted: %v", err)
+	}
+	exportedSMTP := encrypted.Settings[constants.SettingKeySMTPConfig]
+	if password, _ := exportedSMTP["password"].(string); password == "" || password == "source-secret" {
+		t.Fatalf("encrypted export must not contain plaintext password, got %q", password)
+	}
+	omitted, err := sourceVersioning.Export(settingsversioning.ExportInput{SecretMode: settingsversioning.SecretModeOmit})
+	if err != nil {
+		t.Fatalf("export omitted: %v", err)
+	}
+	if _, exists := omitted.Settings[constants.SettingKeySMTPConfig]["password"]; exists {
+		t.Fatal("omit export must drop password")
+	}
+
+	target, targetVersioning := setupSettingsHistoryTest(t)
+	if _, err := target.UpdateWithEffects(constants.SettingKeySMTPConfig, smtpValue("smtp.target.example.com", "target-secret"), settingsapp.WriteMeta{AdminID: 2}); err != nil {
+		t.Fatalf("seed target smtp: %v", err)
+	}
+
+	if _, err := targetVersioning.Import(settingsversioning.ImportInput{Snapshot: encrypted, Passphrase: "wrong", AdminID: 3}); !errors.Is(err, settingscontract.ErrSnapshotDecryptFailed) {
+		t.Fatalf("expected decrypt failure, got %v", err)
+	}
+
+	result, err := targetVersioning.Import(settingsversioning.ImportInput{Snapshot: omitted, AdminID: 3})
+	if err != nil {
+		t.Fatalf("import omitted: %v", err)
+	}
+	if len(result.Applied) == 0 {
+		t.Fatal("expected applied keys")
+	}
+	current, err := target.GetByKey(constants.SettingKeySMTPConfig)
+	if err != nil {
+		t.Fatalf("get target smtp: %v", err)
+	}
+	if current["host"] != "smtp.source.example.com" || current["password"] != "target-secret" {
+		t.Fatalf("omitted import should keep target secret, got %+v", current)
+	}
+
+	if _, err := targetVersioning.Import(settingsversioning.ImportInput{Snapshot: encrypted, Passphrase: "move-it", AdminID: 3}); err != nil {
+		t.Fatalf("import encrypted: %v", err)
+	}
+	current, err = target.GetByKey(constants.SettingKeySMTPConfig)
+	if err != nil {
+		t.Fatalf("get target smtp after encrypted import: %v", err)
+	}
+	if current["password"] != "source-secret" {
+		t.Fatalf("encrypted import should restore source secret, got %v", current["password"])
+	}
+
+	versions, _, err := targetVersioning.ListVersions(settingscontract.VersionFilter{Key: constants.SettingKeySMTPConfig})
+	if err != nil {
+		t.Fatalf("list target versions: %v", err)
+	}
+	if len(versions) == 0 || versions[0].Action != settingscontract.VersionActionImport {
+		t.Fatalf("expected import version, got %+v", versions)
+	}
+
+	invalid := encrypted
+	invalid.Format = "other"
+	if _, err := targetVersioning.Import(settingsversioning.ImportInput{Snapshot: invalid, Passphrase: "move-it"}); !errors.Is(err, settingscontract.ErrSnapshotInvalid) {
+		t.Fatalf("expected invalid snapshot, got %v", err)
+	}
+}
@@ -26,6 +26,7 @@ var ErrAffiliateConfigInvalid = errors.New("affiliate config invalid")
 type AffiliateSetting struct {
 	Enabled           bool     `json:"enabled"`
 	CommissionRate    float64  `json:"commission_rate"`
+	SecondLevelRate   float64  `json:"second_level_rate"` // 上级推广人按订单可返利金额抽取的比例，0 表示关闭二级分成
 	ConfirmDays       int      `json:"confirm_days"`
 	MinWithdrawAmount float64  `json:"min_withdraw_amount"`
 	WithdrawChannels  []string `json:"withdraw_channels"`
@@ -47,6 +48,13 @@ func NormalizeAffiliateSetting(setting AffiliateSetting) AffiliateSetting {
 	if setting.CommissionRate > affiliateCommissionRateMax {
 		setting.CommissionRate = affiliateCommissionRateMax
 	}
+	setting.SecondLevelRate = roundAffiliateDecimal(setting.SecondLevelRate)
+	if setting.SecondLevelRate < affiliateCommissionRateMin {
+		setting.SecondLevelRate = affiliateCommissionRateMin
+	}
+	if setting.SecondLevelRate > affiliateCommissionRateMax {
+		setting.SecondLevelRate = affiliateCommissionRateMax
+	}
 	if setting.ConfirmDays < affiliateConfirmDaysMin {
 		setting.ConfirmDays = affiliateConfirmDaysMin
 	}
@@ -67,6 +75,9 @@ func ValidateAffiliateSetting(setting AffiliateSetting) error {
 	if normalized.CommissionRate < affiliateCommissionRateMin || normalized.CommissionRate > affiliateCommissionRateMax {
 		return fmt.Errorf("%w: 返利比例必须在 0-100 之间", ErrAffiliateConfigInvalid)
 	}
+	if normalized.SecondLevelRate < affiliateCommissionRateMin || normalized.SecondLevelRate > affiliateCommissionRateMax {
+		return fmt.Errorf("%w: 二级返利比例必须在 0-100 之间", ErrAffiliateConfigInvalid)
+	}
 	if normalized.ConfirmDays < affiliateConfirmDaysMin || normalized.ConfirmDays > affiliateConfirmDaysMax {
 		return fmt.Errorf("%w: 佣金确认天数必须在 0-3650 之间", ErrAffiliateConfigInvalid)
 	}
@@ -87,6 +98,11 @@ func DecodeAffiliateSetting(raw jsonmap.JSON, fallback AffiliateSetting) Affilia
 			result.CommissionRate = parsed
 		}
 	}
+	if value, exists := raw["second_level_rate"]; exists {
+		if parsed, err := settingsvalue.ParseFloat(value); err == nil {
+			result.SecondLevelRate = parsed
+		}
+	}
 	if value, exists := raw["confirm_days"]; exists {
 		if parsed, err := settingsvalue.ParseInt(value); err == nil {
 			result.ConfirmDays = parsed
@@ -109,6 +125,7 @@ func EncodeAffiliateSetting(setting AffiliateSetting) jsonmap.JSON {
 	return jsonmap.JSON{
 		"enabled":             normalized.Enabled,
 		"commission_rate":     normalized.CommissionRate,
+		"second_level_rate":   normalized.SecondLevelRate,
 		"confirm_days":        normalized.ConfirmDays,
 		"min_withdraw_amount": normalized.MinWithdrawAmount,
 		"withdraw_channels":   settingsvalue.CloneStringSlice(normalized.WithdrawChannels),
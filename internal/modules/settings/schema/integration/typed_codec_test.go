@@ -42,6 +42,8 @@ func TestDashboardSettingCodecPreservesDefaultsAndAcceptedNumberShapes(t *testin
 		"out_of_stock_products_threshold":  int64(2),
 		"pending_payment_orders_threshold": int64(20),
 		"payments_failed_threshold":        int64(10),
+		"forecast_alert_days":              int64(7),
+		"forecast_window_days":             int64(14),
 	}) {
 		t.Fatalf("dashboard encode mismatch: %#v", encoded)
 	}
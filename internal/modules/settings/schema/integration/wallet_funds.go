@@ -0,0 +1,94 @@
+package settingsintegration
+
+import (
+	settingsvalue "github.com/dujiao-next/internal/modules/settings/schema/value"
+	"github.com/dujiao-next/internal/shared/jsonmap"
+)
+
+const (
+	walletTransferFeePercentMax = 100
+	walletDailyCountMax         = 1000
+)
+
+// WalletFundsSetting 是 wallet_config 中余额转账与提现相关字段的 typed representation。
+// 金额类限额为 0 表示不限制。
+type WalletFundsSetting struct {
+	TransferEnabled    bool     `json:"transfer_enabled"`
+	TransferFeePercent float64  `json:"transfer_fee_percent"`
+	TransferMinFee     float64  `json:"transfer_min_fee"`
+	TransferMinAmount  float64  `json:"transfer_min_amount"`
+	TransferDailyLimit float64  `json:"transfer_daily_limit"`
+	TransferDailyCount int      `json:"transfer_daily_count"`
+	WithdrawEnabled    bool     `json:"withdraw_enabled"`
+	WithdrawMinAmount  float64  `json:"withdraw_min_amount"`
+	WithdrawDailyLimit float64  `json:"withdraw_daily_limit"`
+	WithdrawChannels   []string `json:"withdraw_channels"`
+}
+
+// DefaultWalletFundsSetting 默认关闭转账与提现。
+func DefaultWalletFundsSetting() WalletFundsSetting {
+	return NormalizeWalletFundsSetting(WalletFundsSetting{WithdrawChannels: []string{}})
+}
+
+// NormalizeWalletFundsSetting 归一化费率与限额范围。
+func NormalizeWalletFundsSetting(setting WalletFundsSetting) WalletFundsSetting {
+	setting.TransferFeePercent = clampWalletAmount(setting.TransferFeePercent)
+	if setting.TransferFeePercent > walletTransferFeePercentMax {
+		setting.TransferFeePercent = walletTransferFeePercentMax
+	}
+	setting.TransferMinFee = clampWalletAmount(setting.TransferMinFee)
+	setting.TransferMinAmount = clampWalletAmount(setting.TransferMinAmount)
+	setting.TransferDailyLimit = clampWalletAmount(setting.TransferDailyLimit)
+	if setting.TransferDailyCount < 0 {
+		setting.TransferDailyCount = 0
+	}
+	if setting.TransferDailyCount > walletDailyCountMax {
+		setting.TransferDailyCount = walletDailyCountMax
+	}
+	setting.WithdrawMinAmount = clampWalletAmount(setting.WithdrawMinAmount)
+	setting.WithdrawDailyLimit = clampWalletAmount(setting.WithdrawDailyLimit)
+	setting.WithdrawChannels = normalizeAffiliateWithdrawChannels(setting.WithdrawChannels)
+	return setting
+}
+
+// DecodeWalletFundsSetting 从 wallet_config 解码，其它钱包字段保持原样不受影响。
+func DecodeWalletFundsSetting(raw jsonmap.JSON, fallback WalletFundsSetting) WalletFundsSetting {
+	result := fallback
+	if value, exists := raw["transfer_enabled"]; exists {
+		result.TransferEnabled = settingsvalue.ParseBool(value)
+	}
+	if value, exists := raw["withdraw_enabled"]; exists {
+		result.WithdrawEnabled = settingsvalue.ParseBool(value)
+	}
+	for key, target := range map[string]*float64{
+		"transfer_fee_percent": &result.TransferFeePercent,
+		"transfer_min_fee":     &result.TransferMinFee,
+		"transfer_min_amount":  &result.TransferMinAmount,
+		"transfer_daily_limit": &result.TransferDailyLimit,
+		"withdraw_min_amount":  &result.WithdrawMinAmount,
+		"withdraw_daily_limit": &result.WithdrawDailyLimit,
+	} {
+		if value, exists := raw[key]; exists {
+			if parsed, err := settingsvalue.ParseFloat(value); err == nil {
+				*target = parsed
+			}
+		}
+	}
+	if value, exists := raw["transfer_daily_count"]; exists {
+		if parsed, err := settingsvalue.ParseInt(value); err == nil {
+			result.TransferDailyCount = parsed
+		}
+	}
+	if value, exists := raw["withdraw_channels"]; exists {
+		result.WithdrawChannels = settingsvalue.NormalizeStringList(value)
+	}
+	return NormalizeWalletFundsSetting(result)
+}
+
+func clampWalletAmount(value float64) float64 {
+	value = roundAffiliateDecimal(value)
+	if value < 0 {
+		return 0
+	}
+	return value
+}
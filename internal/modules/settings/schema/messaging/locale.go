@@ -0,0 +1,173 @@
+package settingsmessaging
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dujiao-next/internal/i18n/locales"
+	settingsvalue "github.com/dujiao-next/internal/modules/settings/schema/value"
+	"github.com/dujiao-next/internal/shared/jsonmap"
+)
+
+var ErrLocaleConfigInvalid = errors.New("locale config invalid")
+
+const localeOverrideMaxCount = 2000
+
+// LocaleSetting 语言配置：禁用的已安装语言与按语言覆盖的消息文本。
+type LocaleSetting struct {
+	DisabledLocales []string                     `json:"disabled_locales"`
+	Overrides       map[string]map[string]string `json:"overrides"`
+}
+
+// LocaleSettingPatch 语言配置补丁；overrides 按键合并，值为空或 null 时删除该覆盖。
+type LocaleSettingPatch struct {
+	DisabledLocales *[]string                     `json:"disabled_locales"`
+	Overrides       map[string]map[string]*string `json:"overrides"`
+}
+
+// NormalizeLocaleSetting 规范化语言标签、去重并移除空覆盖；兜底语言不可禁用。
+func NormalizeLocaleSetting(setting LocaleSetting) LocaleSetting {
+	disabled := make([]string, 0, len(setting.DisabledLocales))
+	seen := map[string]struct{}{}
+	for _, raw := range setting.DisabledLocales {
+		locale, ok := locales.Canonical(raw)
+		if !ok || locale == locales.Default {
+			continue
+		}
+		if _, exists := seen[locale]; exists {
+			continue
+		}
+		seen[locale] = struct{}{}
+		disabled = append(disabled, locale)
+	}
+	sort.Strings(disabled)
+
+	overrides := make(map[string]map[string]string, len(setting.Overrides))
+	for raw, bundle := range setting.Overrides {
+		locale, ok := locales.Canonical(raw)
+		if !ok {
+			continue
+		}
+		for key, text := range bundle {
+			key = strings.TrimSpace(key)
+			if key == "" || strings.TrimSpace(text) == "" {
+				continue
+			}
+			if overrides[locale] == nil {
+				overrides[locale] = map[string]string{}
+			}
+			overrides[locale][key] = text
+		}
+	}
+	return LocaleSetting{DisabledLocales: disabled, Overrides: overrides}
+}
+
+// ValidateLocaleSetting 校验语言均已安装、覆盖的键在语言包中存在且数量不超限。
+func ValidateLocaleSetting(setting LocaleSetting) error {
+	normalized := NormalizeLocaleSetting(setting)
+	for _, locale := range normalized.DisabledLocales {
+		if !locales.IsInstalled(locale) {
+			return fmt.Errorf("%w: 语言 %s 未安装", ErrLocaleConfigInvalid, locale)
+		}
+	}
+	total := 0
+	for locale, bundle := range normalized.Overrides {
+		if !locales.IsInstalled(locale) {
+			return fmt.Errorf("%w: 语言 %s 未安装", ErrLocaleConfigInvalid, locale)
+		}
+		for key, text := range bundle {
+			if err := locales.ValidateMessage(key, text); err != nil {
+				return fmt.Errorf("%w: 消息 %s 不合法", ErrLocaleConfigInvalid, key)
+			}
+			if !locales.HasKey(key) {
+				return fmt.Errorf("%w: 消息键 %s 不存在", ErrLocaleConfigInvalid, key)
+			}
+		}
+		total += len(bundle)
+	}
+	if total > localeOverrideMaxCount {
+		return fmt.Errorf("%w: 覆盖文本最多 %d 条", ErrLocaleConfigInvalid, localeOverrideMaxCount)
+	}
+	return nil
+}
+
+// ApplyLocaleSettingPatch 把补丁应用到当前配置（不做校验）。
+func ApplyLocaleSettingPatch(current LocaleSetting, patch LocaleSettingPatch) LocaleSetting {
+	next := NormalizeLocaleSetting(current)
+	if patch.DisabledLocales != nil {
+		next.DisabledLocales = append([]string(nil), (*patch.DisabledLocales)...)
+	}
+	for raw, bundle := range patch.Overrides {
+		locale, ok := locales.Canonical(raw)
+		if !ok {
+			locale = raw
+		}
+		for key, text := range bundle {
+			if text == nil || strings.TrimSpace(*text) == "" {
+				delete(next.Overrides[locale], key)
+				continue
+			}
+			if next.Overrides[locale] == nil {
+				next.Overrides[locale] = map[string]string{}
+			}
+			next.Overrides[locale][key] = *text
+		}
+	}
+	return next
+}
+
+// LocaleSettingToOverrides 转换为语言目录可直接应用的配置。
+func LocaleSettingToOverrides(setting LocaleSetting) locales.Overrides {
+	normalized := NormalizeLocaleSetting(setting)
+	return locales.Overrides{Disabled: normalized.DisabledLocales, Messages: normalized.Overrides}
+}
+
+// LocaleSettingToMap 转换为 settings 存储结构。
+func LocaleSettingToMap(setting LocaleSetting) map[string]interface{} {
+	normalized := NormalizeLocaleSetting(setting)
+	overrides := make(map[string]interface{}, len(normalized.Overrides))
+	for locale, bundle := range normalized.Overrides {
+		messages := make(map[string]interface{}, len(bundle))
+		for key, text := range bundle {
+			messages[key] = text
+		}
+		overrides[locale] = messages
+	}
+	return map[string]interface{}{
+		"disabled_locales": settingsvalue.CloneStringSlice(normalized.DisabledLocales),
+		"overrides":        overrides,
+	}
+}
+
+// DecodeLocaleSetting 从 settings 存储结构解析语言配置。
+func DecodeLocaleSetting(raw jsonmap.JSON) LocaleSetting {
+	setting := LocaleSetting{}
+	if raw == nil {
+		return NormalizeLocaleSetting(setting)
+	}
+	setting.DisabledLocales = settingsvalue.ReadStringList(raw, "disabled_locales", nil)
+	if overridesMap := settingsvalue.ToStringAnyMap(raw["overrides"]); overridesMap != nil {
+		setting.Overrides = make(map[string]map[string]string, len(overridesMap))
+		for locale, value := range overridesMap {
+			bundleMap := settingsvalue.ToStringAnyMap(value)
+			if bundleMap == nil {
+				continue
+			}
+			bundle := make(map[string]string, len(bundleMap))
+			for key, text := range bundleMap {
+				if str, ok := text.(string); ok {
+					bundle[key] = str
+				}
+			}
+			setting.Overrides[locale] = bundle
+		}
+	}
+	return NormalizeLocaleSetting(setting)
+}
+
+// NormalizeLocaleSettingJSON 供 Registry 归一化通用写入。
+func NormalizeLocaleSettingJSON(raw jsonmap.JSON) jsonmap.JSON {
+	return jsonmap.JSON(LocaleSettingToMap(DecodeLocaleSetting(raw)))
+}
@@ -1,6 +1,7 @@
 package settingsmessaging
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/mail"
@@ -8,18 +9,13 @@ import (
 	"strings"
 
 	"github.com/dujiao-next/internal/constants"
+	"github.com/dujiao-next/internal/i18n/locales"
 	settingsvalue "github.com/dujiao-next/internal/modules/settings/schema/value"
 	"github.com/dujiao-next/internal/shared/jsonmap"
 )
 
 var ErrNotificationConfigInvalid = errors.New("notification config invalid")
 
-var notificationSupportedLocales = map[string]struct{}{
-	constants.LocaleZhCN: {},
-	constants.LocaleZhTW: {},
-	constants.LocaleEnUS: {},
-}
-
 var telegramChatIDPattern = regexp.MustCompile(`^-?\d{5,20}$`)
 
 const (
@@ -66,6 +62,23 @@ type NotificationSceneTemplate struct {
 	ZHCN NotificationLocalizedTemplate `json:"zh-CN"`
 	ZHTW NotificationLocalizedTemplate `json:"zh-TW"`
 	ENUS NotificationLocalizedTemplate `json:"en-US"`
+	// Extra 保存语言包扩展语言的模板，序列化时与内置语言平铺在同一层。
+	Extra map[string]NotificationLocalizedTemplate `json:"-"`
+}
+
+// MarshalJSON 按存储结构平铺内置语言与扩展语言。
+func (s NotificationSceneTemplate) MarshalJSON() ([]byte, error) {
+	return json.Marshal(notificationSceneTemplateToMap(s))
+}
+
+// UnmarshalJSON 解析平铺的多语言模板。
+func (s *NotificationSceneTemplate) UnmarshalJSON(data []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*s = notificationSceneTemplateFromMap(raw, NotificationSceneTemplate{})
+	return nil
 }
 
 // NotificationTemplatesSetting 通知模板集合
@@ -132,9 +145,35 @@ type NotificationTemplatesPatch struct {
 
 // NotificationSceneTemplatePatch 单场景模板补丁
 type NotificationSceneTemplatePatch struct {
-	ZHCN *NotificationLocalizedTemplatePatch `json:"zh-CN"`
-	ZHTW *NotificationLocalizedTemplatePatch `json:"zh-TW"`
-	ENUS *NotificationLocalizedTemplatePatch `json:"en-US"`
+	ZHCN  *NotificationLocalizedTemplatePatch            `json:"zh-CN"`
+	ZHTW  *NotificationLocalizedTemplatePatch            `json:"zh-TW"`
+	ENUS  *NotificationLocalizedTemplatePatch            `json:"en-US"`
+	Extra map[string]*NotificationLocalizedTemplatePatch `json:"-"`
+}
+
+// UnmarshalJSON 内置语言写入固定字段，其余语言标签进入 Extra，由校验决定是否接受。
+func (p *NotificationSceneTemplatePatch) UnmarshalJSON(data []byte) error {
+	var raw map[string]*NotificationLocalizedTemplatePatch
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*p = NotificationSceneTemplatePatch{}
+	for tag, patch := range raw {
+		switch tag {
+		case constants.LocaleZhCN:
+			p.ZHCN = patch
+		case constants.LocaleZhTW:
+			p.ZHTW = patch
+		case constants.LocaleEnUS:
+			p.ENUS = patch
+		default:
+			if p.Extra == nil {
+				p.Extra = map[string]*NotificationLocalizedTemplatePatch{}
+			}
+			p.Extra[tag] = patch
+		}
+	}
+	return nil
 }
 
 // NotificationLocalizedTemplatePatch 多语言模板补丁
@@ -246,9 +285,21 @@ func NormalizeNotificationCenterSetting(setting NotificationCenterSetting) Notif
 // ValidateNotificationCenterSetting 校验通知中心配置
 func ValidateNotificationCenterSetting(setting NotificationCenterSetting) error {
 	normalized := NormalizeNotificationCenterSetting(setting)
-	if _, ok := notificationSupportedLocales[normalized.DefaultLocale]; !ok {
+	if !locales.IsEnabled(normalized.DefaultLocale) {
 		return fmt.Errorf("%w: 默认语言不合法", ErrNotificationConfigInvalid)
 	}
+	for _, scene := range []NotificationSceneTemplate{
+		normalized.Templates.WalletRechargeSuccess,
+		normalized.Templates.OrderPaidSuccess,
+		normalized.Templates.ManualFulfillmentPending,
+		normalized.Templates.ExceptionAlert,
+	} {
+		for locale := range scene.Extra {
+			if !locales.IsInstalled(locale) {
+				return fmt.Errorf("%w: 模板语言 %s 未安装", ErrNotificationConfigInvalid, locale)
+			}
+		}
+	}
 
 	if normalized.Channels.Email.Enabled && len(normalized.Channels.Email.Recipients) == 0 {
 		return fmt.Errorf("%w: 邮件渠道已启用但未配置收件邮箱", ErrNotificationConfigInvalid)
@@ -434,17 +485,37 @@ func (s NotificationTemplatesSetting) TemplateByEvent(eventType string) Notifica
 	}
 }
 
-// ResolveLocaleTemplate 按语言选择模板
+// ResolveLocaleTemplate 按语言选择模板；扩展语言未配置模板时沿语言包回退链选择。
 func (s NotificationSceneTemplate) ResolveLocaleTemplate(locale string) NotificationLocalizedTemplate {
 	normalized := NormalizeNotificationLocale(locale)
 	switch normalized {
+	case constants.LocaleZhCN:
+		return s.ZHCN
 	case constants.LocaleZhTW:
 		return s.ZHTW
 	case constants.LocaleEnUS:
 		return s.ENUS
-	default:
-		return s.ZHCN
 	}
+	for _, candidate := range locales.Chain(normalized) {
+		template, ok := s.localeTemplate(candidate)
+		if ok && template.Body != "" {
+			return template
+		}
+	}
+	return s.ZHCN
+}
+
+func (s NotificationSceneTemplate) localeTemplate(locale string) (NotificationLocalizedTemplate, bool) {
+	switch locale {
+	case constants.LocaleZhCN:
+		return s.ZHCN, true
+	case constants.LocaleZhTW:
+		return s.ZHTW, true
+	case constants.LocaleEnUS:
+		return s.ENUS, true
+	}
+	template, ok := s.Extra[locale]
+	return template, ok
 }
 
 func DecodeNotificationCenterSetting(raw jsonmap.JSON, fallback NotificationCenterSetting) NotificationCenterSetting {
@@ -511,6 +582,22 @@ func notificationSceneTemplateFromMap(raw map[string]interface{}, fallback Notif
 	if enUSMap := settingsvalue.ToStringAnyMap(raw[constants.LocaleEnUS]); enUSMap != nil {
 		next.ENUS = notificationLocalizedTemplateFromMap(enUSMap, next.ENUS)
 	}
+	for tag, value := range raw {
+		locale, ok := locales.Canonical(tag)
+		if !ok || isBuiltinNotificationLocale(locale) {
+			continue
+		}
+		localeMap := settingsvalue.ToStringAnyMap(value)
+		if localeMap == nil {
+			continue
+		}
+		extra := make(map[string]NotificationLocalizedTemplate, len(next.Extra)+1)
+		for key, template := range next.Extra {
+			extra[key] = template
+		}
+		extra[locale] = notificationLocalizedTemplateFromMap(localeMap, extra[locale])
+		next.Extra = extra
+	}
 	return next
 }
 
@@ -522,7 +609,7 @@ func notificationLocalizedTemplateFromMap(raw map[string]interface{}, fallback N
 }
 
 func notificationSceneTemplateToMap(template NotificationSceneTemplate) map[string]interface{} {
-	return map[string]interface{}{
+	result := map[string]interface{}{
 		constants.LocaleZhCN: map[string]interface{}{
 			"title": strings.TrimSpace(template.ZHCN.Title),
 			"body":  strings.TrimSpace(template.ZHCN.Body),
@@ -536,6 +623,13 @@ func notificationSceneTemplateToMap(template NotificationSceneTemplate) map[stri
 			"body":  strings.TrimSpace(template.ENUS.Body),
 		},
 	}
+	for locale, localized := range template.Extra {
+		result[locale] = map[string]interface{}{
+			"title": strings.TrimSpace(localized.Title),
+			"body":  strings.TrimSpace(localized.Body),
+		}
+	}
+	return result
 }
 
 func normalizeNotificationTemplates(templates NotificationTemplatesSetting) NotificationTemplatesSetting {
@@ -550,18 +644,54 @@ func normalizeNotificationSceneTemplate(template NotificationSceneTemplate) Noti
 	template.ZHCN = normalizeNotificationLocalizedTemplate(template.ZHCN)
 	template.ZHTW = normalizeNotificationLocalizedTemplate(template.ZHTW)
 	template.ENUS = normalizeNotificationLocalizedTemplate(template.ENUS)
+	if len(template.Extra) == 0 {
+		template.Extra = nil
+		return template
+	}
+	extra := make(map[string]NotificationLocalizedTemplate, len(template.Extra))
+	for tag, localized := range template.Extra {
+		locale, ok := locales.Canonical(tag)
+		if !ok || isBuiltinNotificationLocale(locale) {
+			continue
+		}
+		localized = normalizeNotificationLocalizedTemplate(localized)
+		if localized.Title == "" && localized.Body == "" {
+			continue
+		}
+		extra[locale] = localized
+	}
+	if len(extra) == 0 {
+		extra = nil
+	}
+	template.Extra = extra
 	return template
 }
 
+// ResolveBuiltinLocale 把任意语言映射到内置三种语言之一：沿语言包回退链取第一个内置语言，
+// 供只维护了内置语言文案的模板与标签使用。
+func ResolveBuiltinLocale(locale string) string {
+	for _, candidate := range locales.Chain(NormalizeNotificationLocale(locale)) {
+		if isBuiltinNotificationLocale(candidate) {
+			return candidate
+		}
+	}
+	return constants.LocaleZhCN
+}
+
+func isBuiltinNotificationLocale(locale string) bool {
+	return locale == constants.LocaleZhCN || locale == constants.LocaleZhTW || locale == constants.LocaleEnUS
+}
+
 func normalizeNotificationLocalizedTemplate(template NotificationLocalizedTemplate) NotificationLocalizedTemplate {
 	template.Title = strings.TrimSpace(template.Title)
 	template.Body = strings.TrimSpace(template.Body)
 	return template
 }
 
+// NormalizeNotificationLocale 规范化通知语言，未启用的语言回退为简体中文。
 func NormalizeNotificationLocale(locale string) string {
-	normalized := strings.TrimSpace(locale)
-	if _, ok := notificationSupportedLocales[normalized]; ok {
+	normalized, ok := locales.Canonical(locale)
+	if ok && locales.IsEnabled(normalized) {
 		return normalized
 	}
 	return constants.LocaleZhCN
@@ -662,6 +792,23 @@ func applyNotificationSceneTemplatePatch(target *NotificationSceneTemplate, patc
 	applyNotificationLocalizedTemplatePatch(&target.ZHCN, patch.ZHCN)
 	applyNotificationLocalizedTemplatePatch(&target.ZHTW, patch.ZHTW)
 	applyNotificationLocalizedTemplatePatch(&target.ENUS, patch.ENUS)
+	if len(patch.Extra) == 0 {
+		return
+	}
+	extra := make(map[string]NotificationLocalizedTemplate, len(target.Extra)+len(patch.Extra))
+	for locale, localized := range target.Extra {
+		extra[locale] = localized
+	}
+	for tag, localizedPatch := range patch.Extra {
+		locale, ok := locales.Canonical(tag)
+		if !ok {
+			locale = tag
+		}
+		localized := extra[locale]
+		applyNotificationLocalizedTemplatePatch(&localized, localizedPatch)
+		extra[locale] = localized
+	}
+	target.Extra = extra
 }
 
 func applyNotificationLocalizedTemplatePatch(target *NotificationLocalizedTemplate, patch *NotificationLocalizedTemplatePatch) {
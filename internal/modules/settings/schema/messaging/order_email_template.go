@@ -360,7 +360,7 @@ func ApplyOrderEmailTemplateSettingPatch(current OrderEmailTemplateSetting, patc
 
 // ResolveOrderEmailLocaleTemplate 按 locale 选择模板
 func ResolveOrderEmailLocaleTemplate(t OrderEmailSceneTemplate, locale string) OrderEmailLocalizedTemplate {
-	switch locale {
+	switch ResolveBuiltinLocale(locale) {
 	case constants.LocaleZhTW:
 		return t.ZHTW
 	case constants.LocaleEnUS:
@@ -372,7 +372,7 @@ func ResolveOrderEmailLocaleTemplate(t OrderEmailSceneTemplate, locale string) O
 
 // ResolveOrderEmailFulfillmentAttachmentTip 按 locale 选择交付内容附件提示
 func ResolveOrderEmailFulfillmentAttachmentTip(tip OrderEmailFulfillmentAttachmentTip, locale string) string {
-	switch locale {
+	switch ResolveBuiltinLocale(locale) {
 	case constants.LocaleZhTW:
 		return tip.ZHTW
 	case constants.LocaleEnUS:
@@ -384,7 +384,7 @@ func ResolveOrderEmailFulfillmentAttachmentTip(tip OrderEmailFulfillmentAttachme
 
 // ResolveOrderEmailGuestTip 按 locale 选择游客提示
 func ResolveOrderEmailGuestTip(tip OrderEmailGuestTip, locale string) string {
-	switch locale {
+	switch ResolveBuiltinLocale(locale) {
 	case constants.LocaleZhTW:
 		return tip.ZHTW
 	case constants.LocaleEnUS:
@@ -3,7 +3,7 @@ package settingsmessaging
 import (
 	"strings"
 
-	"github.com/dujiao-next/internal/constants"
+	"github.com/dujiao-next/internal/i18n/locales"
 	settingsvalue "github.com/dujiao-next/internal/modules/settings/schema/value"
 	"github.com/dujiao-next/internal/shared/jsonmap"
 )
@@ -645,8 +645,8 @@ func localizedTextToMap(lt LocalizedText) map[string]interface{} {
 
 // NormalizeLocalizedText 确保所有支持的语言键都存在并 trim
 func NormalizeLocalizedText(lt LocalizedText) LocalizedText {
-	result := make(LocalizedText, len(constants.SupportedLocales))
-	for _, lang := range constants.SupportedLocales {
+	result := make(LocalizedText, len(locales.Enabled()))
+	for _, lang := range locales.Enabled() {
 		result[lang] = ""
 	}
 	for k, v := range lt {
@@ -41,12 +41,21 @@ type CaptchaTurnstileSetting struct {
 	TimeoutMS int    `json:"timeout_ms"`
 }
 
+// CaptchaPoWSetting 工作量证明配置；难度单位为 SHA-256 前导零比特数。
+type CaptchaPoWSetting struct {
+	BaseDifficulty int `json:"base_difficulty"`
+	MaxDifficulty  int `json:"max_difficulty"`
+	RateStep       int `json:"rate_step"`
+	ExpireSeconds  int `json:"expire_seconds"`
+}
+
 // CaptchaSetting 验证码配置实体。
 type CaptchaSetting struct {
 	Provider  string                  `json:"provider"`
 	Scenes    CaptchaSceneSetting     `json:"scenes"`
 	Image     CaptchaImageSetting     `json:"image"`
 	Turnstile CaptchaTurnstileSetting `json:"turnstile"`
+	PoW       CaptchaPoWSetting       `json:"pow"`
 }
 
 // CaptchaScenePatch 场景配置补丁。
@@ -77,12 +86,21 @@ type CaptchaTurnstilePatch struct {
 	TimeoutMS *int    `json:"timeout_ms"`
 }
 
+// CaptchaPoWPatch 工作量证明配置补丁。
+type CaptchaPoWPatch struct {
+	BaseDifficulty *int `json:"base_difficulty"`
+	MaxDifficulty  *int `json:"max_difficulty"`
+	RateStep       *int `json:"rate_step"`
+	ExpireSeconds  *int `json:"expire_seconds"`
+}
+
 // CaptchaSettingPatch 验证码配置补丁。
 type CaptchaSettingPatch struct {
 	Provider  *string                `json:"provider"`
 	Scenes    *CaptchaScenePatch     `json:"scenes"`
 	Image     *CaptchaImagePatch     `json:"image"`
 	Turnstile *CaptchaTurnstilePatch `json:"turnstile"`
+	PoW       *CaptchaPoWPatch       `json:"pow"`
 }
 
 // DefaultCaptchaSetting 根据静态配置生成默认验证码设置。
@@ -111,6 +129,12 @@ func DefaultCaptchaSetting(cfg config.CaptchaConfig) CaptchaSetting {
 			VerifyURL: strings.TrimSpace(cfg.Turnstile.VerifyURL),
 			TimeoutMS: cfg.Turnstile.TimeoutMS,
 		},
+		PoW: CaptchaPoWSetting{
+			BaseDifficulty: cfg.PoW.BaseDifficulty,
+			MaxDifficulty:  cfg.PoW.MaxDifficulty,
+			RateStep:       cfg.PoW.RateStep,
+			ExpireSeconds:  cfg.PoW.ExpireSeconds,
+		},
 	}
 	return NormalizeCaptchaSetting(setting)
 }
@@ -119,7 +143,7 @@ func DefaultCaptchaSetting(cfg config.CaptchaConfig) CaptchaSetting {
 func NormalizeCaptchaSetting(setting CaptchaSetting) CaptchaSetting {
 	provider := strings.ToLower(strings.TrimSpace(setting.Provider))
 	switch provider {
-	case constants.CaptchaProviderImage, constants.CaptchaProviderTurnstile, constants.CaptchaProviderNone:
+	case constants.CaptchaProviderImage, constants.CaptchaProviderTurnstile, constants.CaptchaProviderPoW, constants.CaptchaProviderNone:
 		setting.Provider = provider
 	default:
 		setting.Provider = constants.CaptchaProviderNone
@@ -157,6 +181,19 @@ func NormalizeCaptchaSetting(setting CaptchaSetting) CaptchaSetting {
 		setting.Turnstile.TimeoutMS = 2000
 	}
 
+	if setting.PoW.BaseDifficulty <= 0 {
+		setting.PoW.BaseDifficulty = 18
+	}
+	if setting.PoW.MaxDifficulty <= 0 {
+		setting.PoW.MaxDifficulty = 24
+	}
+	if setting.PoW.RateStep <= 0 {
+		setting.PoW.RateStep = 5
+	}
+	if setting.PoW.ExpireSeconds <= 0 {
+		setting.PoW.ExpireSeconds = 120
+	}
+
 	return setting
 }
 
@@ -165,7 +202,7 @@ func ValidateCaptchaSetting(setting CaptchaSetting) error {
 	normalized := NormalizeCaptchaSetting(setting)
 
 	switch normalized.Provider {
-	case constants.CaptchaProviderNone, constants.CaptchaProviderImage, constants.CaptchaProviderTurnstile:
+	case constants.CaptchaProviderNone, constants.CaptchaProviderImage, constants.CaptchaProviderTurnstile, constants.CaptchaProviderPoW:
 	default:
 		return fmt.Errorf("%w: 验证码提供方无效", ErrCaptchaConfigInvalid)
 	}
@@ -195,6 +232,18 @@ func ValidateCaptchaSetting(setting CaptchaSetting) error {
 	if normalized.Turnstile.TimeoutMS < 500 || normalized.Turnstile.TimeoutMS > 10000 {
 		return fmt.Errorf("%w: Turnstile 超时时间需在 500-10000ms", ErrCaptchaConfigInvalid)
 	}
+	if normalized.PoW.BaseDifficulty < 8 || normalized.PoW.BaseDifficulty > 28 {
+		return fmt.Errorf("%w: 工作量证明基础难度需在 8-28 之间", ErrCaptchaConfigInvalid)
+	}
+	if normalized.PoW.MaxDifficulty < normalized.PoW.BaseDifficulty || normalized.PoW.MaxDifficulty > 32 {
+		return fmt.Errorf("%w: 工作量证明最大难度需在基础难度与 32 之间", ErrCaptchaConfigInvalid)
+	}
+	if normalized.PoW.RateStep > 1000 {
+		return fmt.Errorf("%w: 工作量证明难度步长需在 1-1000 之间", ErrCaptchaConfigInvalid)
+	}
+	if normalized.PoW.ExpireSeconds < 30 || normalized.PoW.ExpireSeconds > 600 {
+		return fmt.Errorf("%w: 工作量证明过期时间需在 30-600 秒", ErrCaptchaConfigInvalid)
+	}
 
 	return nil
 }
@@ -226,6 +275,12 @@ func CaptchaSettingToConfig(setting CaptchaSetting) config.CaptchaConfig {
 			VerifyURL: normalized.Turnstile.VerifyURL,
 			TimeoutMS: normalized.Turnstile.TimeoutMS,
 		},
+		PoW: config.CaptchaPoWConfig{
+			BaseDifficulty: normalized.PoW.BaseDifficulty,
+			MaxDifficulty:  normalized.PoW.MaxDifficulty,
+			RateStep:       normalized.PoW.RateStep,
+			ExpireSeconds:  normalized.PoW.ExpireSeconds,
+		},
 	}
 }
 
@@ -256,6 +311,12 @@ func EncodeCaptchaSetting(setting CaptchaSetting) jsonmap.JSON {
 			"verify_url": normalized.Turnstile.VerifyURL,
 			"timeout_ms": normalized.Turnstile.TimeoutMS,
 		},
+		"pow": map[string]interface{}{
+			"base_difficulty": normalized.PoW.BaseDifficulty,
+			"max_difficulty":  normalized.PoW.MaxDifficulty,
+			"rate_step":       normalized.PoW.RateStep,
+			"expire_seconds":  normalized.PoW.ExpireSeconds,
+		},
 	}
 }
 
@@ -287,6 +348,12 @@ func MaskCaptchaSettingForAdmin(setting CaptchaSetting) jsonmap.JSON {
 			"verify_url": normalized.Turnstile.VerifyURL,
 			"timeout_ms": normalized.Turnstile.TimeoutMS,
 		},
+		"pow": map[string]interface{}{
+			"base_difficulty": normalized.PoW.BaseDifficulty,
+			"max_difficulty":  normalized.PoW.MaxDifficulty,
+			"rate_step":       normalized.PoW.RateStep,
+			"expire_seconds":  normalized.PoW.ExpireSeconds,
+		},
 	}
 }
 
@@ -324,6 +391,9 @@ func (s CaptchaSetting) IsSceneEnabled(scene string) bool {
 		return s.Scenes.GuestCreateOrder
 	case constants.CaptchaSceneGiftCardRedeem:
 		return s.Scenes.GiftCardRedeem
+	case constants.CaptchaSceneOrderRisk:
+		// 风险评分挑战由风控规则按需触发，只要配置了验证码服务商即可使用。
+		return s.Provider != "" && s.Provider != constants.CaptchaProviderNone
 	default:
 		return false
 	}
@@ -363,6 +433,13 @@ func DecodeCaptchaSetting(raw jsonmap.JSON, fallback CaptchaSetting) CaptchaSett
 		next.Turnstile.TimeoutMS = settingsvalue.ReadInt(turnstileMap, "timeout_ms", next.Turnstile.TimeoutMS)
 	}
 
+	if powMap := settingsvalue.ToStringAnyMap(raw["pow"]); powMap != nil {
+		next.PoW.BaseDifficulty = settingsvalue.ReadInt(powMap, "base_difficulty", next.PoW.BaseDifficulty)
+		next.PoW.MaxDifficulty = settingsvalue.ReadInt(powMap, "max_difficulty", next.PoW.MaxDifficulty)
+		next.PoW.RateStep = settingsvalue.ReadInt(powMap, "rate_step", next.PoW.RateStep)
+		next.PoW.ExpireSeconds = settingsvalue.ReadInt(powMap, "expire_seconds", next.PoW.ExpireSeconds)
+	}
+
 	return next
 }
 
@@ -429,6 +506,20 @@ func ApplyCaptchaSettingPatch(current CaptchaSetting, patch CaptchaSettingPatch)
 			next.Turnstile.TimeoutMS = *patch.Turnstile.TimeoutMS
 		}
 	}
+	if patch.PoW != nil {
+		if patch.PoW.BaseDifficulty != nil {
+			next.PoW.BaseDifficulty = *patch.PoW.BaseDifficulty
+		}
+		if patch.PoW.MaxDifficulty != nil {
+			next.PoW.MaxDifficulty = *patch.PoW.MaxDifficulty
+		}
+		if patch.PoW.RateStep != nil {
+			next.PoW.RateStep = *patch.PoW.RateStep
+		}
+		if patch.PoW.ExpireSeconds != nil {
+			next.PoW.ExpireSeconds = *patch.PoW.ExpireSeconds
+		}
+	}
 
 	normalized := NormalizeCaptchaSetting(next)
 	if err := ValidateCaptchaSetting(normalized); err != nil {
@@ -42,13 +42,47 @@ type OrderRiskMemberPolicy struct {
 	RateLimit                     OrderRateLimitConfig `json:"rate_limit"`
 }
 
+// OrderRiskScoreRule 单个风险信号的加分权重与触发阈值；权重为 0 表示关闭该信号。
+type OrderRiskScoreRule struct {
+	Weight    int `json:"weight"`
+	Threshold int `json:"threshold"`
+}
+
+// OrderRiskScoringPolicy 基于信号加权打分的风控策略，分数依次对照 challenge/hold/block 阈值。
+// 阈值为 0 表示不启用对应处置；邮箱相关信号对游客取下单邮箱，对会员取账户邮箱。
+type OrderRiskScoringPolicy struct {
+	Enabled               bool               `json:"enabled"`
+	ChallengeScore        int                `json:"challenge_score"`
+	HoldScore             int                `json:"hold_score"`
+	BlockScore            int                `json:"block_score"`
+	VelocityWindowMinutes int                `json:"velocity_window_minutes"`
+	HistoryWindowDays     int                `json:"history_window_days"`
+	DisposableDomains     []string           `json:"disposable_domains"`
+	SuspiciousDomains     []string           `json:"suspicious_domains"`
+	DisposableEmail       OrderRiskScoreRule `json:"disposable_email"`
+	SuspiciousDomain      OrderRiskScoreRule `json:"suspicious_domain"`
+	NewAccount            OrderRiskScoreRule `json:"new_account"` // Threshold：账户注册小时数
+	IPVelocity            OrderRiskScoreRule `json:"ip_velocity"`
+	EmailVelocity         OrderRiskScoreRule `json:"email_velocity"`
+	DeviceVelocity        OrderRiskScoreRule `json:"device_velocity"`
+	FailedPayments        OrderRiskScoreRule `json:"failed_payments"`
+	GuestEmailMismatch    OrderRiskScoreRule `json:"guest_email_mismatch"` // Threshold：同 IP/设备使用过的其他游客邮箱数
+}
+
+// OrderRiskReviewPolicy 人工复核队列策略；SLAMinutes 为 0 表示不发送复核超时告警。
+type OrderRiskReviewPolicy struct {
+	SLAMinutes int `json:"sla_minutes"`
+}
+
 // OrderRiskControlConfig 订单风控配置。游客邮箱仅用于订单业务，不作为风控身份。
 type OrderRiskControlConfig struct {
-	Version int                   `json:"version"`
-	Enabled bool                  `json:"enabled"`
-	Common  OrderRiskCommonPolicy `json:"common"`
-	Guest   OrderRiskGuestPolicy  `json:"guest"`
-	Member  OrderRiskMemberPolicy `json:"member"`
+	Version int                    `json:"version"`
+	Enabled bool                   `json:"enabled"`
+	Common  OrderRiskCommonPolicy  `json:"common"`
+	Guest   OrderRiskGuestPolicy   `json:"guest"`
+	Member  OrderRiskMemberPolicy  `json:"member"`
+	Scoring OrderRiskScoringPolicy `json:"scoring"`
+	Review  OrderRiskReviewPolicy  `json:"review"`
 }
 
 // DefaultOrderRiskControlConfig 返回新安装推荐值；总开关默认关闭，避免静默改变订单行为。
@@ -82,6 +116,25 @@ func DefaultOrderRiskControlConfig() OrderRiskControlConfig {
 				BlockSeconds:  120,
 			},
 		},
+		Scoring: OrderRiskScoringPolicy{
+			Enabled:               false,
+			ChallengeScore:        30,
+			HoldScore:             60,
+			BlockScore:            90,
+			VelocityWindowMinutes: 60,
+			HistoryWindowDays:     30,
+			DisposableDomains:     []string{},
+			SuspiciousDomains:     []string{},
+			DisposableEmail:       OrderRiskScoreRule{Weight: 40},
+			SuspiciousDomain:      OrderRiskScoreRule{Weight: 25},
+			NewAccount:            OrderRiskScoreRule{Weight: 15, Threshold: 24},
+			IPVelocity:            OrderRiskScoreRule{Weight: 20, Threshold: 5},
+			EmailVelocity:         OrderRiskScoreRule{Weight: 20, Threshold: 5},
+			DeviceVelocity:        OrderRiskScoreRule{Weight: 20, Threshold: 5},
+			FailedPayments:        OrderRiskScoreRule{Weight: 25, Threshold: 3},
+			GuestEmailMismatch:    OrderRiskScoreRule{Weight: 20, Threshold: 3},
+		},
+		Review: OrderRiskReviewPolicy{SLAMinutes: 120},
 	}
 }
 
@@ -100,6 +153,9 @@ func NormalizeOrderRiskControlConfig(cfg OrderRiskControlConfig) OrderRiskContro
 	cfg.Member.MaxQuantityPerProductPerOrder = normalizeRiskLimit(cfg.Member.MaxQuantityPerProductPerOrder, 100000, defaults.Member.MaxQuantityPerProductPerOrder)
 	cfg.Member.RateLimit = normalizeRateLimit(cfg.Member.RateLimit, defaults.Member.RateLimit)
 
+	cfg.Scoring = normalizeScoringPolicy(cfg.Scoring, defaults.Scoring)
+	cfg.Review.SLAMinutes = normalizeRiskLimit(cfg.Review.SLAMinutes, 10080, defaults.Review.SLAMinutes)
+
 	cleanIPs := make([]string, 0, len(cfg.Common.IPBlacklist))
 	seen := make(map[string]struct{}, len(cfg.Common.IPBlacklist))
 	for _, raw := range cfg.Common.IPBlacklist {
@@ -124,6 +180,52 @@ func normalizeRiskLimit(value, maximum, fallback int) int {
 	return value
 }
 
+func normalizeScoringPolicy(cfg, fallback OrderRiskScoringPolicy) OrderRiskScoringPolicy {
+	cfg.ChallengeScore = normalizeRiskLimit(cfg.ChallengeScore, 1000, fallback.ChallengeScore)
+	cfg.HoldScore = normalizeRiskLimit(cfg.HoldScore, 1000, fallback.HoldScore)
+	cfg.BlockScore = normalizeRiskLimit(cfg.BlockScore, 1000, fallback.BlockScore)
+	if cfg.VelocityWindowMinutes < 1 || cfg.VelocityWindowMinutes > 10080 {
+		cfg.VelocityWindowMinutes = fallback.VelocityWindowMinutes
+	}
+	if cfg.HistoryWindowDays < 1 || cfg.HistoryWindowDays > 365 {
+		cfg.HistoryWindowDays = fallback.HistoryWindowDays
+	}
+	cfg.DisposableDomains = normalizeEmailDomains(cfg.DisposableDomains)
+	cfg.SuspiciousDomains = normalizeEmailDomains(cfg.SuspiciousDomains)
+	cfg.DisposableEmail = normalizeScoreRule(cfg.DisposableEmail, fallback.DisposableEmail)
+	cfg.SuspiciousDomain = normalizeScoreRule(cfg.SuspiciousDomain, fallback.SuspiciousDomain)
+	cfg.NewAccount = normalizeScoreRule(cfg.NewAccount, fallback.NewAccount)
+	cfg.IPVelocity = normalizeScoreRule(cfg.IPVelocity, fallback.IPVelocity)
+	cfg.EmailVelocity = normalizeScoreRule(cfg.EmailVelocity, fallback.EmailVelocity)
+	cfg.DeviceVelocity = normalizeScoreRule(cfg.DeviceVelocity, fallback.DeviceVelocity)
+	cfg.FailedPayments = normalizeScoreRule(cfg.FailedPayments, fallback.FailedPayments)
+	cfg.GuestEmailMismatch = normalizeScoreRule(cfg.GuestEmailMismatch, fallback.GuestEmailMismatch)
+	return cfg
+}
+
+func normalizeScoreRule(rule, fallback OrderRiskScoreRule) OrderRiskScoreRule {
+	rule.Weight = normalizeRiskLimit(rule.Weight, 1000, fallback.Weight)
+	rule.Threshold = normalizeRiskLimit(rule.Threshold, 100000, fallback.Threshold)
+	return rule
+}
+
+func normalizeEmailDomains(domains []string) []string {
+	result := make([]string, 0, len(domains))
+	seen := make(map[string]struct{}, len(domains))
+	for _, raw := range domains {
+		domain := strings.TrimPrefix(strings.ToLower(strings.TrimSpace(raw)), "@")
+		if domain == "" || strings.ContainsAny(domain, " @/") {
+			continue
+		}
+		if _, exists := seen[domain]; exists {
+			continue
+		}
+		seen[domain] = struct{}{}
+		result = append(result, domain)
+	}
+	return result
+}
+
 func normalizeRateLimit(cfg, fallback OrderRateLimitConfig) OrderRateLimitConfig {
 	if cfg.WindowSeconds < 10 || cfg.WindowSeconds > 3600 {
 		cfg.WindowSeconds = fallback.WindowSeconds
@@ -155,3 +155,38 @@ func TestIsValidIPOrCIDR(t *testing.T) {
 		}
 	}
 }
+
+func TestNormalizeOrderRiskControlConfig_ScoringPolicy(t *testing.T) {
+	cfg := NormalizeOrderRiskControlConfig(OrderRiskControlConfig{
+		Scoring: OrderRiskScoringPolicy{
+			Enabled:               true,
+			ChallengeScore:        0,
+			HoldScore:             5000,
+			BlockScore:            -1,
+			VelocityWindowMinutes: 0,
+			DisposableDomains:     []string{" @Burner.Test ", "burner.test", "bad domain", ""},
+			IPVelocity:            OrderRiskScoreRule{Weight: -5, Threshold: 200000},
+		},
+	})
+	scoring := cfg.Scoring
+	if scoring.ChallengeScore != 0 {
+		t.Fatalf("zero challenge threshold should stay disabled, got %d", scoring.ChallengeScore)
+	}
+	if scoring.HoldScore != 60 || scoring.BlockScore != 90 {
+		t.Fatalf("invalid thresholds should fall back to defaults, got hold=%d block=%d", scoring.HoldScore, scoring.BlockScore)
+	}
+	if scoring.VelocityWindowMinutes != 60 || scoring.HistoryWindowDays != 30 {
+		t.Fatalf("unexpected windows: %+v", scoring)
+	}
+	if len(scoring.DisposableDomains) != 1 || scoring.DisposableDomains[0] != "burner.test" {
+		t.Fatalf("unexpected disposable domains: %v", scoring.DisposableDomains)
+	}
+	if scoring.IPVelocity.Weight != 20 || scoring.IPVelocity.Threshold != 5 {
+		t.Fatalf("invalid rule should fall back to defaults, got %+v", scoring.IPVelocity)
+	}
+
+	decoded := DecodeOrderRiskControlConfig(EncodeOrderRiskControlConfig(cfg), DefaultOrderRiskControlConfig())
+	if !decoded.Scoring.Enabled || decoded.Scoring.DisposableDomains[0] != "burner.test" {
+		t.Fatalf("scoring policy should round-trip, got %+v", decoded.Scoring)
+	}
+}
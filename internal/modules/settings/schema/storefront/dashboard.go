@@ -11,6 +11,10 @@ type DashboardAlertSetting struct {
 	OutOfStockProductsThreshold   int64 `json:"out_of_stock_products_threshold"`
 	PendingPaymentOrdersThreshold int64 `json:"pending_payment_orders_threshold"`
 	PaymentsFailedThreshold       int64 `json:"payments_failed_threshold"`
+	// ForecastAlertDays 按近期销量预测的可售天数不高于该值时视为低库存。
+	ForecastAlertDays int64 `json:"forecast_alert_days"`
+	// ForecastWindowDays 计算销量速度所取的最近天数。
+	ForecastWindowDays int64 `json:"forecast_window_days"`
 }
 
 // DashboardRankingSetting 描述仪表盘排行数量限制。
@@ -33,6 +37,8 @@ func DefaultDashboardSetting() DashboardSetting {
 			OutOfStockProductsThreshold:   1,
 			PendingPaymentOrdersThreshold: 20,
 			PaymentsFailedThreshold:       10,
+			ForecastAlertDays:             7,
+			ForecastWindowDays:            14,
 		},
 		Ranking: DashboardRankingSetting{
 			TopProductsLimit: 5,
@@ -55,6 +61,12 @@ func NormalizeDashboardSetting(setting DashboardSetting) DashboardSetting {
 	if setting.Alert.PaymentsFailedThreshold < 1 || setting.Alert.PaymentsFailedThreshold > 100000 {
 		setting.Alert.PaymentsFailedThreshold = 10
 	}
+	if setting.Alert.ForecastAlertDays < 1 || setting.Alert.ForecastAlertDays > 365 {
+		setting.Alert.ForecastAlertDays = 7
+	}
+	if setting.Alert.ForecastWindowDays < 1 || setting.Alert.ForecastWindowDays > 180 {
+		setting.Alert.ForecastWindowDays = 14
+	}
 	if setting.Ranking.TopProductsLimit < 1 || setting.Ranking.TopProductsLimit > 20 {
 		setting.Ranking.TopProductsLimit = 5
 	}
@@ -80,6 +92,12 @@ func DecodeDashboardSetting(raw jsonmap.JSON, fallback DashboardSetting) Dashboa
 		if parsed, err := settingsvalue.ParseInt(alert["payments_failed_threshold"]); err == nil {
 			result.Alert.PaymentsFailedThreshold = int64(parsed)
 		}
+		if parsed, err := settingsvalue.ParseInt(alert["forecast_alert_days"]); err == nil {
+			result.Alert.ForecastAlertDays = int64(parsed)
+		}
+		if parsed, err := settingsvalue.ParseInt(alert["forecast_window_days"]); err == nil {
+			result.Alert.ForecastWindowDays = int64(parsed)
+		}
 	}
 	if ranking, ok := raw["ranking"].(map[string]interface{}); ok {
 		if parsed, err := settingsvalue.ParseInt(ranking["top_products_limit"]); err == nil {
@@ -101,6 +119,8 @@ func EncodeDashboardSetting(setting DashboardSetting) jsonmap.JSON {
 			"out_of_stock_products_threshold":  normalized.Alert.OutOfStockProductsThreshold,
 			"pending_payment_orders_threshold": normalized.Alert.PendingPaymentOrdersThreshold,
 			"payments_failed_threshold":        normalized.Alert.PaymentsFailedThreshold,
+			"forecast_alert_days":              normalized.Alert.ForecastAlertDays,
+			"forecast_window_days":             normalized.Alert.ForecastWindowDays,
 		},
 		"ranking": map[string]interface{}{
 			"top_products_limit": normalized.Ranking.TopProductsLimit,
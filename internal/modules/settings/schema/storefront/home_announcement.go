@@ -6,7 +6,7 @@ import (
 	"strings"
 	"time"
 
-	"github.com/dujiao-next/internal/constants"
+	"github.com/dujiao-next/internal/i18n/locales"
 	settingsvalue "github.com/dujiao-next/internal/modules/settings/schema/value"
 	"github.com/dujiao-next/internal/shared/jsonmap"
 )
@@ -48,8 +48,8 @@ func normalizeAnnouncementText(raw interface{}) string {
 }
 
 func normalizeAnnouncementLocalizedField(raw interface{}) map[string]interface{} {
-	fieldResult := make(map[string]interface{}, len(constants.SupportedLocales))
-	for _, language := range constants.SupportedLocales {
+	fieldResult := make(map[string]interface{}, len(locales.Enabled()))
+	for _, language := range locales.Enabled() {
 		fieldResult[language] = ""
 	}
 
@@ -58,7 +58,7 @@ func normalizeAnnouncementLocalizedField(raw interface{}) map[string]interface{}
 		return fieldResult
 	}
 
-	for _, language := range constants.SupportedLocales {
+	for _, language := range locales.Enabled() {
 		fieldResult[language] = normalizeAnnouncementText(fieldRaw[language])
 	}
 
@@ -82,7 +82,7 @@ func normalizeHomeAnnouncementTime(raw interface{}) string {
 func homeAnnouncementVersion(annType string, title, content map[string]interface{}) string {
 	hasher := fnv.New32a()
 	_, _ = hasher.Write([]byte(annType))
-	for _, lang := range constants.SupportedLocales {
+	for _, lang := range locales.Enabled() {
 		_, _ = hasher.Write([]byte{0})
 		if title != nil {
 			if text, ok := title[lang].(string); ok {
@@ -116,7 +116,7 @@ func isHomeAnnouncementInSchedule(startAt, endAt string, now time.Time) bool {
 
 // hasHomeAnnouncementContent 判断公告内容是否至少有一个语言非空。
 func hasHomeAnnouncementContent(content map[string]interface{}) bool {
-	for _, lang := range constants.SupportedLocales {
+	for _, lang := range locales.Enabled() {
 		if text, ok := content[lang].(string); ok && strings.TrimSpace(text) != "" {
 			return true
 		}
@@ -0,0 +1,171 @@
+package settingsvalue
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/dujiao-next/internal/shared/jsonmap"
+)
+
+// MaskedSecret 是版本历史与差异中密钥字段的占位值。
+const MaskedSecret = "******"
+
+// DiffJSON 按点分路径比较两份设置 JSON，返回 {path: {"before": x, "after": y}}。
+// 嵌套对象逐层展开，数组按整体比较；secretPaths 命中的字段只记录“已变更”不记录明文。
+func DiffJSON(before, after jsonmap.JSON, secretPaths []string) jsonmap.JSON {
+	flatBefore := map[string]interface{}{}
+	flatAfter := map[string]interface{}{}
+	flattenJSON("", canonicalJSON(before), flatBefore)
+	flattenJSON("", canonicalJSON(after), flatAfter)
+
+	paths := make([]string, 0, len(flatBefore)+len(flatAfter))
+	for path := range flatBefore {
+		paths = append(paths, path)
+	}
+	for path := range flatAfter {
+		if _, exists := flatBefore[path]; !exists {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	diff := jsonmap.JSON{}
+	for _, path := range paths {
+		previous, hadPrevious := flatBefore[path]
+		next, hasNext := flatAfter[path]
+		if hadPrevious == hasNext && reflect.DeepEqual(previous, next) {
+			continue
+		}
+		if isSecretPath(path, secretPaths) {
+			previous, next = maskIfPresent(previous, hadPrevious), maskIfPresent(next, hasNext)
+		}
+		diff[path] = map[string]interface{}{"before": previous, "after": next}
+	}
+	return diff
+}
+
+// MaskSecretPaths 返回把密钥字段替换为占位值后的副本；空密钥保持为空以便区分“未配置”。
+func MaskSecretPaths(value jsonmap.JSON, secretPaths []string) jsonmap.JSON {
+	if value == nil || len(secretPaths) == 0 {
+		return value
+	}
+	masked := jsonmap.JSON(canonicalJSON(value))
+	for _, path := range secretPaths {
+		current, ok := LookupPath(masked, path)
+		if !ok {
+			continue
+		}
+		if text, isText := current.(string); isText && text == "" {
+			continue
+		}
+		SetPath(masked, path, MaskedSecret)
+	}
+	return masked
+}
+
+// CloneJSON 深拷贝设置 JSON，返回的副本与入参不共享嵌套对象。
+func CloneJSON(value jsonmap.JSON) jsonmap.JSON {
+	if value == nil {
+		return nil
+	}
+	return jsonmap.JSON(canonicalJSON(value))
+}
+
+// LookupPath 读取点分路径上的值。
+func LookupPath(source map[string]interface{}, path string) (interface{}, bool) {
+	segments := strings.Split(path, ".")
+	current := source
+	for index, segment := range segments {
+		value, ok := current[segment]
+		if !ok {
+			return nil, false
+		}
+		if index == len(segments)-1 {
+			return value, true
+		}
+		current = ToStringAnyMap(value)
+		if current == nil {
+			return nil, false
+		}
+	}
+	return nil, false
+}
+
+// SetPath 写入点分路径上的值，缺失的中间对象会被创建。
+func SetPath(target map[string]interface{}, path string, value interface{}) {
+	segments := strings.Split(path, ".")
+	current := target
+	for _, segment := range segments[:len(segments)-1] {
+		next := ToStringAnyMap(current[segment])
+		if next == nil {
+			next = map[string]interface{}{}
+		}
+		current[segment] = next
+		current = next
+	}
+	current[segments[len(segments)-1]] = value
+}
+
+// DeletePath 删除点分路径上的值。
+func DeletePath(target map[string]interface{}, path string) {
+	segments := strings.Split(path, ".")
+	current := target
+	for _, segment := range segments[:len(segments)-1] {
+		current = ToStringAnyMap(current[segment])
+		if current == nil {
+			return
+		}
+	}
+	delete(current, segments[len(segments)-1])
+}
+
+// canonicalJSON 通过一次 JSON 往返统一数字与嵌套类型，避免 int/float64 差异被当作变更。
+func canonicalJSON(value jsonmap.JSON) map[string]interface{} {
+	if value == nil {
+		return map[string]interface{}{}
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return map[string]interface{}{}
+	}
+	result := map[string]interface{}{}
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return map[string]interface{}{}
+	}
+	return result
+}
+
+func flattenJSON(prefix string, source map[string]interface{}, target map[string]interface{}) {
+	for key, value := range source {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		if nested, ok := value.(map[string]interface{}); ok && len(nested) > 0 {
+			flattenJSON(path, nested, target)
+			continue
+		}
+		target[path] = value
+	}
+}
+
+func isSecretPath(path string, secretPaths []string) bool {
+	for _, secretPath := range secretPaths {
+		if path == secretPath || strings.HasPrefix(path, secretPath+".") {
+			return true
+		}
+	}
+	return false
+}
+
+func maskIfPresent(value interface{}, present bool) interface{} {
+	if !present {
+		return nil
+	}
+	if text, ok := value.(string); ok && text == "" {
+		return ""
+	}
+	return MaskedSecret
+}
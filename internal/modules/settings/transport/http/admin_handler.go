@@ -5,6 +5,7 @@ import (
 
 	"github.com/dujiao-next/internal/cache"
 	"github.com/dujiao-next/internal/constants"
+	"github.com/dujiao-next/internal/i18n/locales"
 	settingsapp "github.com/dujiao-next/internal/modules/settings/application"
 	ginutil "github.com/dujiao-next/internal/platform/http/ginutil"
 	"github.com/dujiao-next/internal/platform/http/response"
@@ -16,7 +17,7 @@ import (
 // AdminService 是后台通用设置端口。
 type AdminService interface {
 	GetByKey(key string) (jsonmap.JSON, error)
-	UpdateWithEffects(key string, value map[string]interface{}) (settingsapp.UpdateResult, error)
+	UpdateWithEffects(key string, value map[string]interface{}, meta settingsapp.WriteMeta) (settingsapp.UpdateResult, error)
 	InvalidateCallbackRoutesCache()
 }
 
@@ -71,7 +72,11 @@ func (h *AdminHandler) Update(c *gin.Context) {
 		return
 	}
 
-	result, err := h.settings.UpdateWithEffects(req.Key, req.Value)
+	adminID, ok := ginutil.GetAdminID(c)
+	if !ok {
+		return
+	}
+	result, err := h.settings.UpdateWithEffects(req.Key, req.Value, settingsapp.WriteMeta{AdminID: adminID})
 	if err != nil {
 		ginutil.RespondError(c, response.CodeInternal, "error.settings_save_failed", err)
 		return
@@ -83,5 +88,8 @@ func (h *AdminHandler) Update(c *gin.Context) {
 	if result.HasEffect(settingsapp.EffectInvalidateCallbackRoutesCache) {
 		h.settings.InvalidateCallbackRoutesCache()
 	}
+	if result.HasEffect(settingsapp.EffectInvalidateLocaleCache) {
+		locales.InvalidateOverrides()
+	}
 	response.Success(c, result.Value)
 }
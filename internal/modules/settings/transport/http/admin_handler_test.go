@@ -22,7 +22,7 @@ func (s *adminSettingsStub) GetByKey(string) (jsonmap.JSON, error) {
 	return nil, nil
 }
 
-func (s *adminSettingsStub) UpdateWithEffects(string, map[string]interface{}) (settingsapp.UpdateResult, error) {
+func (s *adminSettingsStub) UpdateWithEffects(string, map[string]interface{}, settingsapp.WriteMeta) (settingsapp.UpdateResult, error) {
 	s.updateCalls++
 	return settingsapp.UpdateResult{}, nil
 }
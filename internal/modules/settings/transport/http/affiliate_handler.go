@@ -14,7 +14,7 @@ import (
 // AffiliateAdminService 是后台推广返利设置端口。
 type AffiliateAdminService interface {
 	GetAffiliateSetting() (settingsintegration.AffiliateSetting, error)
-	UpdateAffiliateSetting(setting settingsintegration.AffiliateSetting) (settingsintegration.AffiliateSetting, error)
+	UpdateAffiliateSetting(setting settingsintegration.AffiliateSetting, adminID uint) (settingsintegration.AffiliateSetting, error)
 }
 
 // AffiliateHandler 处理后台推广返利设置请求。
@@ -47,7 +47,11 @@ func (h *AffiliateHandler) UpdateAffiliate(c *gin.Context) {
 		return
 	}
 
-	setting, err := h.affiliate.UpdateAffiliateSetting(req)
+	adminID, ok := ginutil.GetAdminID(c)
+	if !ok {
+		return
+	}
+	setting, err := h.affiliate.UpdateAffiliateSetting(req, adminID)
 	if err != nil {
 		if errors.Is(err, settingsintegration.ErrAffiliateConfigInvalid) {
 			ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", nil)
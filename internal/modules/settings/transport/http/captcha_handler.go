@@ -16,7 +16,7 @@ import (
 // CaptchaAdminService 是后台验证码设置端口。
 type CaptchaAdminService interface {
 	GetCaptchaSetting() (settingssecurity.CaptchaSetting, error)
-	PatchCaptchaSetting(patch settingssecurity.CaptchaSettingPatch) (settingssecurity.CaptchaSetting, error)
+	PatchCaptchaSetting(patch settingssecurity.CaptchaSettingPatch, adminID uint) (settingssecurity.CaptchaSetting, error)
 	ApplyRuntime(setting settingssecurity.CaptchaSetting)
 }
 
@@ -50,7 +50,11 @@ func (h *CaptchaHandler) UpdateCaptcha(c *gin.Context) {
 		return
 	}
 
-	setting, err := h.captcha.PatchCaptchaSetting(req)
+	adminID, ok := ginutil.GetAdminID(c)
+	if !ok {
+		return
+	}
+	setting, err := h.captcha.PatchCaptchaSetting(req, adminID)
 	if err != nil {
 		switch {
 		case errors.Is(err, captcha.ErrConfigInvalid):
@@ -14,7 +14,7 @@ import (
 // GoogleAuthAdminService 是后台 Google Identity Services 登录设置端口。
 type GoogleAuthAdminService interface {
 	GetGoogleAuthSetting() (settingssecurity.GoogleAuthSetting, error)
-	PatchGoogleAuthSetting(patch settingssecurity.GoogleAuthSettingPatch) (settingssecurity.GoogleAuthSetting, error)
+	PatchGoogleAuthSetting(patch settingssecurity.GoogleAuthSettingPatch, adminID uint) (settingssecurity.GoogleAuthSetting, error)
 	ApplyRuntime(setting settingssecurity.GoogleAuthSetting)
 }
 
@@ -48,7 +48,11 @@ func (h *GoogleAuthHandler) UpdateGoogleAuth(c *gin.Context) {
 		return
 	}
 
-	setting, err := h.googleAuth.PatchGoogleAuthSetting(req)
+	adminID, ok := ginutil.GetAdminID(c)
+	if !ok {
+		return
+	}
+	setting, err := h.googleAuth.PatchGoogleAuthSetting(req, adminID)
 	if err != nil {
 		switch {
 		case errors.Is(err, settingssecurity.ErrGoogleAuthConfigInvalid):
@@ -21,7 +21,7 @@ func (s *googleAuthAdminStub) GetGoogleAuthSetting() (settingssecurity.GoogleAut
 	return s.setting, nil
 }
 
-func (s *googleAuthAdminStub) PatchGoogleAuthSetting(patch settingssecurity.GoogleAuthSettingPatch) (settingssecurity.GoogleAuthSetting, error) {
+func (s *googleAuthAdminStub) PatchGoogleAuthSetting(patch settingssecurity.GoogleAuthSettingPatch, _ uint) (settingssecurity.GoogleAuthSetting, error) {
 	s.patch = patch
 	if patch.Enabled != nil {
 		s.setting.Enabled = *patch.Enabled
@@ -48,6 +48,7 @@ func TestGoogleAuthHandlerUpdateAppliesRuntimeSetting(t *testing.T) {
 		strings.NewReader(`{"enabled":true,"client_id":" client.apps.googleusercontent.com "}`),
 	)
 	context.Request.Header.Set("Content-Type", "application/json")
+	context.Set("admin_id", uint(1))
 
 	handler.UpdateGoogleAuth(context)
 
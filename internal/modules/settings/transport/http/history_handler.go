@@ -0,0 +1,233 @@
+package settingshttp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dujiao-next/internal/cache"
+	"github.com/dujiao-next/internal/i18n/locales"
+	settingsapp "github.com/dujiao-next/internal/modules/settings/application"
+	settingsversioning "github.com/dujiao-next/internal/modules/settings/application/versioning"
+	settingscontract "github.com/dujiao-next/internal/modules/settings/contract"
+	ginutil "github.com/dujiao-next/internal/platform/http/ginutil"
+	"github.com/dujiao-next/internal/platform/http/response"
+	"github.com/dujiao-next/internal/shared/jsonmap"
+
+	"github.com/gin-gonic/gin"
+)
+
+const maxSnapshotUploadBytes = 2 << 20
+
+// HistoryService 是设置版本历史、回滚与环境快照端口。
+type HistoryService interface {
+	ListVersions(filter settingscontract.VersionFilter) ([]settingscontract.Version, int64, error)
+	GetVersion(id uint) (settingscontract.Version, error)
+	Rollback(versionID, adminID uint) (settingsapp.UpdateResult, error)
+	Export(input settingsversioning.ExportInput) (settingsversioning.Snapshot, error)
+	Import(input settingsversioning.ImportInput) (settingsversioning.ImportResult, error)
+	InvalidateCallbackRoutesCache()
+}
+
+// HistoryHandler 处理后台设置版本历史与快照请求。
+type HistoryHandler struct {
+	history HistoryService
+}
+
+func NewHistoryHandler(history HistoryService) *HistoryHandler {
+	if history == nil {
+		panic("settings history handler: history is nil")
+	}
+	return &HistoryHandler{history: history}
+}
+
+type settingVersionResp struct {
+	ID              uint         `json:"id"`
+	Key             string       `json:"key"`
+	Version         int          `json:"version"`
+	Action          string       `json:"action"`
+	Value           jsonmap.JSON `json:"value,omitempty"`
+	Previous        jsonmap.JSON `json:"previous,omitempty"`
+	Diff            jsonmap.JSON `json:"diff"`
+	AdminID         *uint        `json:"admin_id"`
+	SourceVersionID *uint        `json:"source_version_id,omitempty"`
+	CreatedAt       time.Time    `json:"created_at"`
+}
+
+func newSettingVersionResp(version settingscontract.Version, withSnapshot bool) settingVersionResp {
+	resp := settingVersionResp{
+		ID:              version.ID,
+		Key:             version.Key,
+		Version:         version.Version,
+		Action:          version.Action,
+		Diff:            version.Diff,
+		AdminID:         version.AdminID,
+		SourceVersionID: version.SourceVersionID,
+		CreatedAt:       version.CreatedAt,
+	}
+	if withSnapshot {
+		resp.Value = version.Value
+		resp.Previous = version.Previous
+	}
+	return resp
+}
+
+type exportSnapshotRequest struct {
+	SecretMode string `json:"secret_mode"`
+	Passphrase string `json:"passphrase"`
+}
+
+func respondSettingsHistoryError(c *gin.Context, err error, fallbackKey string) {
+	switch {
+	case errors.Is(err, settingscontract.ErrVersionNotFound):
+		ginutil.RespondError(c, response.CodeNotFound, "error.settings_version_not_found", nil)
+	case errors.Is(err, settingscontract.ErrVersionNotRollbackable):
+		ginutil.RespondError(c, response.CodeBadRequest, "error.settings_version_not_rollbackable", nil)
+	case errors.Is(err, settingscontract.ErrSnapshotInvalid):
+		ginutil.RespondError(c, response.CodeBadRequest, "error.settings_snapshot_invalid", nil)
+	case errors.Is(err, settingscontract.ErrSnapshotPassphrase):
+		ginutil.RespondError(c, response.CodeBadRequest, "error.settings_snapshot_passphrase_required", nil)
+	case errors.Is(err, settingscontract.ErrSnapshotDecryptFailed):
+		ginutil.RespondError(c, response.CodeBadRequest, "error.settings_snapshot_decrypt_failed", nil)
+	default:
+		ginutil.RespondError(c, response.CodeInternal, fallbackKey, err)
+	}
+}
+
+// ListVersions 设置变更历史列表，可按 key 过滤。
+func (h *HistoryHandler) ListVersions(c *gin.Context) {
+	page, pageSize := ginutil.ParsePagination(c)
+	versions, total, err := h.history.ListVersions(settingscontract.VersionFilter{
+		Key:      strings.TrimSpace(c.Query("key")),
+		Page:     page,
+		PageSize: pageSize,
+	})
+	if err != nil {
+		respondSettingsHistoryError(c, err, "error.settings_fetch_failed")
+		return
+	}
+	items := make([]settingVersionResp, 0, len(versions))
+	for _, version := range versions {
+		items = append(items, newSettingVersionResp(version, false))
+	}
+	response.SuccessWithPage(c, items, response.BuildPagination(page, pageSize, total))
+}
+
+// GetVersion 设置版本详情（含写入前后快照，密钥已脱敏）。
+func (h *HistoryHandler) GetVersion(c *gin.Context) {
+	id, err := ginutil.ParseParamUint(c, "id")
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", nil)
+		return
+	}
+	version, err := h.history.GetVersion(id)
+	if err != nil {
+		respondSettingsHistoryError(c, err, "error.settings_fetch_failed")
+		return
+	}
+	response.Success(c, newSettingVersionResp(version, true))
+}
+
+// RollbackVersion 回滚到指定版本。
+func (h *HistoryHandler) RollbackVersion(c *gin.Context) {
+	adminID, ok := ginutil.GetAdminID(c)
+	if !ok {
+		return
+	}
+	id, err := ginutil.ParseParamUint(c, "id")
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", nil)
+		return
+	}
+	result, err := h.history.Rollback(id, adminID)
+	if err != nil {
+		respondSettingsHistoryError(c, err, "error.settings_save_failed")
+		return
+	}
+	h.applyEffects(c, result.HasEffect)
+	response.Success(c, result.Value)
+}
+
+// ExportSnapshot 导出设置快照文件；密钥字段按 secret_mode 去除或用口令加密。
+func (h *HistoryHandler) ExportSnapshot(c *gin.Context) {
+	var req exportSnapshotRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		ginutil.RespondBindError(c, err)
+		return
+	}
+	snapshot, err := h.history.Export(settingsversioning.ExportInput{
+		SecretMode: req.SecretMode,
+		Passphrase: req.Passphrase,
+	})
+	if err != nil {
+		respondSettingsHistoryError(c, err, "error.settings_fetch_failed")
+		return
+	}
+	content, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		ginutil.RespondError(c, response.CodeInternal, "error.settings_fetch_failed", err)
+		return
+	}
+	filename := fmt.Sprintf("settings-snapshot-%s.json", snapshot.ExportedAt.Format("20060102-150405"))
+	contentType := "application/json; charset=utf-8"
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
+	c.Data(http.StatusOK, contentType, content)
+}
+
+// ImportSnapshot 上传设置快照文件（multipart file）并导入，口令通过 passphrase 表单字段提供。
+func (h *HistoryHandler) ImportSnapshot(c *gin.Context) {
+	adminID, ok := ginutil.GetAdminID(c)
+	if !ok {
+		return
+	}
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", nil)
+		return
+	}
+	if fileHeader.Size > maxSnapshotUploadBytes {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.settings_snapshot_invalid", nil)
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", nil)
+		return
+	}
+	defer file.Close()
+
+	var snapshot settingsversioning.Snapshot
+	if err := json.NewDecoder(io.LimitReader(file, maxSnapshotUploadBytes)).Decode(&snapshot); err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.settings_snapshot_invalid", nil)
+		return
+	}
+	result, err := h.history.Import(settingsversioning.ImportInput{
+		Snapshot:   snapshot,
+		Passphrase: c.PostForm("passphrase"),
+		AdminID:    adminID,
+	})
+	if err != nil {
+		respondSettingsHistoryError(c, err, "error.settings_save_failed")
+		return
+	}
+	h.applyEffects(c, result.HasEffect)
+	response.Success(c, gin.H{"applied": result.Applied, "skipped": result.Skipped})
+}
+
+// applyEffects 处理回滚/导入声明的副作用，与通用设置更新保持一致。
+func (h *HistoryHandler) applyEffects(c *gin.Context, hasEffect func(settingsapp.Effect) bool) {
+	if hasEffect(settingsapp.EffectInvalidatePublicConfigCache) {
+		_ = cache.DelAllPublicConfig(c.Request.Context())
+	}
+	if hasEffect(settingsapp.EffectInvalidateCallbackRoutesCache) {
+		h.history.InvalidateCallbackRoutesCache()
+	}
+	if hasEffect(settingsapp.EffectInvalidateLocaleCache) {
+		locales.InvalidateOverrides()
+	}
+}
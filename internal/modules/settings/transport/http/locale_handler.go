@@ -0,0 +1,77 @@
+package settingshttp
+
+import (
+	"errors"
+
+	"github.com/dujiao-next/internal/cache"
+	"github.com/dujiao-next/internal/i18n/locales"
+	settingsmessaging "github.com/dujiao-next/internal/modules/settings/schema/messaging"
+	ginutil "github.com/dujiao-next/internal/platform/http/ginutil"
+	"github.com/dujiao-next/internal/platform/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LocaleAdminService 是后台语言配置端口。
+type LocaleAdminService interface {
+	GetLocaleSetting() (settingsmessaging.LocaleSetting, error)
+	PatchLocaleSetting(patch settingsmessaging.LocaleSettingPatch, adminID uint) (settingsmessaging.LocaleSetting, error)
+}
+
+// LocaleHandler 处理后台语言包查看与覆盖文本维护。
+type LocaleHandler struct {
+	locales LocaleAdminService
+}
+
+func NewLocaleHandler(service LocaleAdminService) *LocaleHandler {
+	if service == nil {
+		panic("settings locale handler: service is nil")
+	}
+	return &LocaleHandler{locales: service}
+}
+
+// GetLocales 返回已安装语言包概要与当前语言配置。
+func (h *LocaleHandler) GetLocales(c *gin.Context) {
+	setting, err := h.locales.GetLocaleSetting()
+	if err != nil {
+		ginutil.RespondError(c, response.CodeInternal, "error.settings_fetch_failed", err)
+		return
+	}
+	response.Success(c, gin.H{
+		"default": locales.Default,
+		"packs":   locales.Packs(),
+		"setting": setting,
+	})
+}
+
+// UpdateLocales 更新禁用语言与消息覆盖。
+func (h *LocaleHandler) UpdateLocales(c *gin.Context) {
+	var req settingsmessaging.LocaleSettingPatch
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ginutil.RespondBindError(c, err)
+		return
+	}
+
+	adminID, ok := ginutil.GetAdminID(c)
+	if !ok {
+		return
+	}
+	setting, err := h.locales.PatchLocaleSetting(req, adminID)
+	if err != nil {
+		switch {
+		case errors.Is(err, settingsmessaging.ErrLocaleConfigInvalid):
+			ginutil.RespondErrorWithMsg(c, response.CodeBadRequest, err.Error(), nil)
+		default:
+			ginutil.RespondError(c, response.CodeInternal, "error.settings_save_failed", err)
+		}
+		return
+	}
+
+	locales.InvalidateOverrides()
+	_ = cache.DelAllPublicConfig(c.Request.Context())
+	response.Success(c, gin.H{
+		"default": locales.Default,
+		"packs":   locales.Packs(),
+		"setting": setting,
+	})
+}
@@ -14,8 +14,8 @@ import (
 // OrderEmailTemplateAdminService 是后台订单邮件模板设置端口。
 type OrderEmailTemplateAdminService interface {
 	GetOrderEmailTemplateSetting() (settingsmessaging.OrderEmailTemplateSetting, error)
-	PatchOrderEmailTemplateSetting(patch settingsmessaging.OrderEmailTemplateSettingPatch) (settingsmessaging.OrderEmailTemplateSetting, error)
-	ResetOrderEmailTemplateSetting() (settingsmessaging.OrderEmailTemplateSetting, error)
+	PatchOrderEmailTemplateSetting(patch settingsmessaging.OrderEmailTemplateSettingPatch, adminID uint) (settingsmessaging.OrderEmailTemplateSetting, error)
+	ResetOrderEmailTemplateSetting(adminID uint) (settingsmessaging.OrderEmailTemplateSetting, error)
 }
 
 // OrderEmailTemplateHandler 处理后台订单邮件模板设置请求。
@@ -48,7 +48,11 @@ func (h *OrderEmailTemplateHandler) UpdateOrderEmailTemplate(c *gin.Context) {
 		return
 	}
 
-	setting, err := h.templates.PatchOrderEmailTemplateSetting(req)
+	adminID, ok := ginutil.GetAdminID(c)
+	if !ok {
+		return
+	}
+	setting, err := h.templates.PatchOrderEmailTemplateSetting(req, adminID)
 	if err != nil {
 		switch {
 		case errors.Is(err, settingsmessaging.ErrOrderEmailTemplateConfigInvalid):
@@ -64,7 +68,11 @@ func (h *OrderEmailTemplateHandler) UpdateOrderEmailTemplate(c *gin.Context) {
 
 // ResetOrderEmailTemplate 重置订单邮件模板为默认。
 func (h *OrderEmailTemplateHandler) ResetOrderEmailTemplate(c *gin.Context) {
-	setting, err := h.templates.ResetOrderEmailTemplateSetting()
+	adminID, ok := ginutil.GetAdminID(c)
+	if !ok {
+		return
+	}
+	setting, err := h.templates.ResetOrderEmailTemplateSetting(adminID)
 	if err != nil {
 		ginutil.RespondError(c, response.CodeInternal, "error.settings_save_failed", err)
 		return
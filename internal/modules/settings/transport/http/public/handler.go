@@ -5,6 +5,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/dujiao-next/internal/i18n/locales"
 	ginutil "github.com/dujiao-next/internal/platform/http/ginutil"
 
 	"github.com/dujiao-next/internal/constants"
@@ -126,7 +127,7 @@ func NewHandler(
 // GetConfig 获取全局配置。
 func (h *Handler) GetConfig(c *gin.Context) {
 	defaults := map[string]interface{}{
-		"languages":                              append([]string(nil), constants.SupportedLocales...),
+		"languages":                              locales.Enabled(),
 		constants.SettingFieldSiteCurrency:       constants.SiteCurrencyDefault,
 		constants.SettingFieldStorefrontTemplate: constants.StorefrontTemplateDefault,
 		"contact": map[string]interface{}{
@@ -44,3 +44,16 @@ func RegisterAdminTelegramBotRoutes(admin gin.IRoutes, handler *TelegramBotHandl
 	admin.PUT("/settings/telegram-bot", handler.UpdateTelegramBotConfig)
 	admin.GET("/settings/telegram-bot/runtime-status", handler.GetTelegramBotRuntimeStatus)
 }
+
+func RegisterAdminHistoryRoutes(admin gin.IRoutes, handler *HistoryHandler) {
+	admin.GET("/settings/versions", handler.ListVersions)
+	admin.GET("/settings/versions/:id", handler.GetVersion)
+	admin.POST("/settings/versions/:id/rollback", handler.RollbackVersion)
+	admin.POST("/settings/export", handler.ExportSnapshot)
+	admin.POST("/settings/import", handler.ImportSnapshot)
+}
+
+func RegisterAdminLocaleRoutes(admin gin.IRoutes, handler *LocaleHandler) {
+	admin.GET("/settings/locales", handler.GetLocales)
+	admin.PUT("/settings/locales", handler.UpdateLocales)
+}
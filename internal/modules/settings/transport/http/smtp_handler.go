@@ -22,7 +22,7 @@ var (
 // SMTPAdminService 是后台 SMTP 设置端口。
 type SMTPAdminService interface {
 	GetSMTPSetting() (settingsmessaging.SMTPSetting, error)
-	PatchSMTPSetting(patch settingsmessaging.SMTPSettingPatch) (settingsmessaging.SMTPSetting, error)
+	PatchSMTPSetting(patch settingsmessaging.SMTPSettingPatch, adminID uint) (settingsmessaging.SMTPSetting, error)
 	ApplyRuntime(setting settingsmessaging.SMTPSetting)
 	SendTest(setting settingsmessaging.SMTPSetting, toEmail, subject, body string) error
 }
@@ -63,7 +63,11 @@ func (h *SMTPHandler) UpdateSMTP(c *gin.Context) {
 		return
 	}
 
-	setting, err := h.smtp.PatchSMTPSetting(req)
+	adminID, ok := ginutil.GetAdminID(c)
+	if !ok {
+		return
+	}
+	setting, err := h.smtp.PatchSMTPSetting(req, adminID)
 	if err != nil {
 		switch {
 		case errors.Is(err, settingsmessaging.ErrSMTPConfigInvalid):
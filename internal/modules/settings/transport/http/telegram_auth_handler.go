@@ -15,7 +15,7 @@ import (
 // TelegramAuthAdminService 是后台 Telegram 登录设置端口。
 type TelegramAuthAdminService interface {
 	GetTelegramAuthSetting() (settingssecurity.TelegramAuthSetting, error)
-	PatchTelegramAuthSetting(patch settingssecurity.TelegramAuthSettingPatch) (settingssecurity.TelegramAuthSetting, error)
+	PatchTelegramAuthSetting(patch settingssecurity.TelegramAuthSettingPatch, adminID uint) (settingssecurity.TelegramAuthSetting, error)
 	ApplyRuntime(setting settingssecurity.TelegramAuthSetting)
 }
 
@@ -49,7 +49,11 @@ func (h *TelegramAuthHandler) UpdateTelegramAuth(c *gin.Context) {
 		return
 	}
 
-	setting, err := h.telegramAuth.PatchTelegramAuthSetting(req)
+	adminID, ok := ginutil.GetAdminID(c)
+	if !ok {
+		return
+	}
+	setting, err := h.telegramAuth.PatchTelegramAuthSetting(req, adminID)
 	if err != nil {
 		switch {
 		case errors.Is(err, settingssecurity.ErrTelegramAuthConfigInvalid):
@@ -11,7 +11,7 @@ import (
 // TelegramBotAdminService 是后台 Telegram Bot 设置端口。
 type TelegramBotAdminService interface {
 	GetTelegramBotConfig() (settingsmessaging.TelegramBotConfigSetting, error)
-	UpdateTelegramBotConfig(cfg settingsmessaging.TelegramBotConfigSetting) (settingsmessaging.TelegramBotConfigSetting, error)
+	UpdateTelegramBotConfig(cfg settingsmessaging.TelegramBotConfigSetting, adminID uint) (settingsmessaging.TelegramBotConfigSetting, error)
 	GetTelegramBotRuntimeStatus() (settingsmessaging.TelegramBotRuntimeStatusSetting, error)
 }
 
@@ -45,7 +45,11 @@ func (h *TelegramBotHandler) UpdateTelegramBotConfig(c *gin.Context) {
 		return
 	}
 
-	setting, err := h.bot.UpdateTelegramBotConfig(req)
+	adminID, ok := ginutil.GetAdminID(c)
+	if !ok {
+		return
+	}
+	setting, err := h.bot.UpdateTelegramBotConfig(req, adminID)
 	if err != nil {
 		ginutil.RespondError(c, response.CodeInternal, "error.settings_save_failed", err)
 		return
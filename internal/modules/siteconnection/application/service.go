@@ -75,6 +75,10 @@ func (s *Service) Create(input CreateInput) (*siteconnectiondomain.Connection, e
 	if roundingMode == "" {
 		roundingMode = "none"
 	}
+	refundMode, refundTarget, err := normalizeRefundPropagation(input.RefundPropagationMode, input.RefundPropagationTarget)
+	if err != nil {
+		return nil, err
+	}
 
 	conn := &siteconnectiondomain.Connection{
 		Name:               strings.TrimSpace(input.Name),
@@ -90,6 +94,9 @@ func (s *Service) Create(input CreateInput) (*siteconnectiondomain.Connection, e
 		PriceMarkupPercent: decimal.NewFromFloat(input.PriceMarkupPercent),
 		PriceRoundingMode:  roundingMode,
 		AutoSyncPrice:      input.AutoSyncPrice,
+
+		RefundPropagationMode:   refundMode,
+		RefundPropagationTarget: refundTarget,
 	}
 
 	if err := s.connRepo.Create(conn); err != nil {
@@ -157,6 +164,20 @@ func (s *Service) Update(id uint, input UpdateInput) (*siteconnectiondomain.Conn
 	if input.AutoSyncPrice != nil {
 		conn.AutoSyncPrice = *input.AutoSyncPrice
 	}
+	if input.RefundPropagationMode != nil || input.RefundPropagationTarget != nil {
+		mode, target := conn.RefundPropagationMode, conn.RefundPropagationTarget
+		if input.RefundPropagationMode != nil {
+			mode = *input.RefundPropagationMode
+		}
+		if input.RefundPropagationTarget != nil {
+			target = *input.RefundPropagationTarget
+		}
+		mode, target, err = normalizeRefundPropagation(mode, target)
+		if err != nil {
+			return nil, err
+		}
+		conn.RefundPropagationMode, conn.RefundPropagationTarget = mode, target
+	}
 
 	if err := s.connRepo.Update(conn); err != nil {
 		return nil, err
@@ -294,6 +315,27 @@ func (s *Service) DecryptSecret(encrypted string) (string, error) {
 	return crypto.Decrypt(s.encryptKey, encrypted)
 }
 
+// normalizeRefundPropagation 校验上游退款联动配置，空值分别回落为 off / wallet
+func normalizeRefundPropagation(mode, target string) (string, string, error) {
+	mode = strings.ToLower(strings.TrimSpace(mode))
+	switch mode {
+	case "":
+		mode = constants.RefundPropagationOff
+	case constants.RefundPropagationOff, constants.RefundPropagationAuto, constants.RefundPropagationReview:
+	default:
+		return "", "", siteconnectioncontract.ErrInvalid
+	}
+	target = strings.ToLower(strings.TrimSpace(target))
+	switch target {
+	case "":
+		target = constants.RefundPropagationTargetWallet
+	case constants.RefundPropagationTargetWallet, constants.RefundPropagationTargetOriginal:
+	default:
+		return "", "", siteconnectioncontract.ErrInvalid
+	}
+	return mode, target, nil
+}
+
 // normalizeExchangeRate 规范化汇率值，<=0 时返回 1
 func (s *Service) normalizeExchangeRate(rate float64) decimal.Decimal {
 	if rate <= 0 {
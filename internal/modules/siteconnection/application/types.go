@@ -14,6 +14,9 @@ type CreateInput struct {
 	PriceMarkupPercent float64 `json:"price_markup_percent"`
 	PriceRoundingMode  string  `json:"price_rounding_mode"`
 	AutoSyncPrice      bool    `json:"auto_sync_price"`
+	// 上游退款联动策略与本地退款去向，留空分别为 off / wallet
+	RefundPropagationMode   string `json:"refund_propagation_mode"`
+	RefundPropagationTarget string `json:"refund_propagation_target"`
 }
 
 // UpdateInput 更新对接连接输入。
@@ -30,6 +33,9 @@ type UpdateInput struct {
 	PriceMarkupPercent *float64 `json:"price_markup_percent"` // 指针类型，区分 0 和未传
 	PriceRoundingMode  *string  `json:"price_rounding_mode"`
 	AutoSyncPrice      *bool    `json:"auto_sync_price"`
+	// 上游退款联动策略与本地退款去向，nil 表示不修改
+	RefundPropagationMode   *string `json:"refund_propagation_mode"`
+	RefundPropagationTarget *string `json:"refund_propagation_target"`
 }
 
 // PingResult 连接测试结果。
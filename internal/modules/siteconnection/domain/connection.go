@@ -24,9 +24,12 @@ type Connection struct {
 	PriceMarkupPercent decimal.Decimal `gorm:"type:decimal(10,4);not null;default:0" json:"price_markup_percent"`   // 加价百分比，如 100 = +100%（翻倍）
 	PriceRoundingMode  string          `gorm:"type:varchar(20);not null;default:'none'" json:"price_rounding_mode"` // none / ceil_int / ceil_tenth
 	AutoSyncPrice      bool            `gorm:"not null;default:false" json:"auto_sync_price"`                       // 同步时自动更新本地价格
-	CreatedAt          time.Time       `gorm:"index" json:"created_at"`
-	UpdatedAt          time.Time       `gorm:"index" json:"updated_at"`
-	DeletedAt          *time.Time      `gorm:"index" json:"-"`
+	// 上游退款联动：off 不处理 / auto 自动按比例退款 / review 生成待审核记录
+	RefundPropagationMode   string     `gorm:"type:varchar(20);not null;default:'off'" json:"refund_propagation_mode"`
+	RefundPropagationTarget string     `gorm:"type:varchar(20);not null;default:'wallet'" json:"refund_propagation_target"` // wallet / original
+	CreatedAt               time.Time  `gorm:"index" json:"created_at"`
+	UpdatedAt               time.Time  `gorm:"index" json:"updated_at"`
+	DeletedAt               *time.Time `gorm:"index" json:"-"`
 }
 
 // TableName 指定表名
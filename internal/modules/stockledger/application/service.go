@@ -0,0 +1,191 @@
+package application
+
+import (
+	"strings"
+	"time"
+
+	"github.com/dujiao-next/internal/logger"
+	stockledgercontract "github.com/dujiao-next/internal/modules/stockledger/contract"
+	stockledgerdomain "github.com/dujiao-next/internal/modules/stockledger/domain"
+)
+
+// checkedSources 参与一致性校验的来源；上游库存为远端缓存，仅保留流水。
+var checkedSources = []string{stockledgerdomain.SourceManual, stockledgerdomain.SourceCardSecret}
+
+// Service 提供库存流水查询与计数一致性巡检。
+type Service struct {
+	store stockledgercontract.Store
+	now   func() time.Time
+}
+
+func NewService(store stockledgercontract.Store) *Service {
+	if store == nil {
+		panic("stock ledger service: store is nil")
+	}
+	return &Service{store: store, now: time.Now}
+}
+
+// ListMovements 后台查询库存流水。
+func (s *Service) ListMovements(filter stockledgercontract.MovementFilter) ([]stockledgerdomain.Movement, int64, error) {
+	filter.Source = strings.TrimSpace(filter.Source)
+	filter.Kind = strings.TrimSpace(filter.Kind)
+	return s.store.ListMovements(filter)
+}
+
+// GetSKUHistory 查询单个规格的库存流水，并附带当前计数与台账汇总便于比对。
+func (s *Service) GetSKUHistory(source string, productID, skuID uint, page, pageSize int) (*stockledgercontract.SKUHistory, error) {
+	source = strings.TrimSpace(source)
+	if productID == 0 || !validSource(source) {
+		return nil, stockledgercontract.ErrFilterInvalid
+	}
+	key := stockledgercontract.SKUKey{ProductID: productID, SKUID: skuID}
+	movements, total, err := s.store.ListMovements(stockledgercontract.MovementFilter{
+		Source:    source,
+		ProductID: productID,
+		SKUID:     skuID,
+		Page:      page,
+		PageSize:  pageSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+	ledger, err := s.store.SumMovementsBySKU(source, key)
+	if err != nil {
+		return nil, err
+	}
+	history := &stockledgercontract.SKUHistory{Ledger: ledger, Movements: movements, Total: total}
+	if source != stockledgerdomain.SourceUpstream {
+		counters, ok, err := s.store.GetCounters(source, key)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			history.Counters = &counters
+		}
+	}
+	return history, nil
+}
+
+// ListDrifts 后台查询一致性偏差。
+func (s *Service) ListDrifts(filter stockledgercontract.DriftFilter) ([]stockledgerdomain.Drift, int64, error) {
+	filter.Source = strings.TrimSpace(filter.Source)
+	return s.store.ListDrifts(filter)
+}
+
+// Check 比对库存计数与台账汇总：新发现的偏差逐个复核后记录，已恢复一致的偏差标记解决。
+// 计数与流水在同一事务提交，批量汇总与复核之间的并发写入只会造成短暂不一致，复核可排除。
+func (s *Service) Check() (*stockledgercontract.CheckResult, error) {
+	open, err := s.store.ListOpenDrifts()
+	if err != nil {
+		return nil, err
+	}
+	openByKey := make(map[string]map[stockledgercontract.SKUKey]stockledgerdomain.Drift, len(checkedSources))
+	for _, drift := range open {
+		if openByKey[drift.Source] == nil {
+			openByKey[drift.Source] = make(map[stockledgercontract.SKUKey]stockledgerdomain.Drift)
+		}
+		openByKey[drift.Source][stockledgercontract.SKUKey{ProductID: drift.ProductID, SKUID: drift.SKUID}] = drift
+	}
+
+	result := &stockledgercontract.CheckResult{}
+	for _, source := range checkedSources {
+		counters, err := s.store.ListCounters(source)
+		if err != nil {
+			return nil, err
+		}
+		ledger, err := s.store.SumMovements(source)
+		if err != nil {
+			return nil, err
+		}
+		candidates := make(map[stockledgercontract.SKUKey]bool)
+		for key, counter := range counters {
+			if !counter.Equal(ledger[key]) {
+				candidates[key] = true
+			}
+		}
+		if source == stockledgerdomain.SourceCardSecret {
+			// 卡密全部删除的规格不在计数结果中，仍需确认台账已归零
+			for key, sum := range ledger {
+				if _, ok := counters[key]; !ok && !sum.Equal(stockledgerdomain.Counters{}) {
+					candidates[key] = true
+				}
+			}
+		}
+		result.Checked += len(counters)
+		for key := range openByKey[source] {
+			candidates[key] = true
+		}
+
+		for key := range candidates {
+			drifted, err := s.recheck(source, key, openByKey[source])
+			if err != nil {
+				logger.Warnw("stock_ledger_recheck_failed", "source", source, "product_id", key.ProductID, "sku_id", key.SKUID, "error", err)
+				continue
+			}
+			switch drifted {
+			case recheckDrifted:
+				result.Drifted++
+			case recheckResolved:
+				result.Resolved++
+			}
+		}
+	}
+	if result.Drifted > 0 {
+		logger.Warnw("stock_ledger_drift_detected", "drifted", result.Drifted, "resolved", result.Resolved)
+	}
+	return result, nil
+}
+
+type recheckOutcome int
+
+const (
+	recheckConsistent recheckOutcome = iota
+	recheckDrifted
+	recheckResolved
+)
+
+func (s *Service) recheck(source string, key stockledgercontract.SKUKey, open map[stockledgercontract.SKUKey]stockledgerdomain.Drift) (recheckOutcome, error) {
+	now := s.now()
+	counter, tracked, err := s.store.GetCounters(source, key)
+	if err != nil {
+		return recheckConsistent, err
+	}
+	ledger, err := s.store.SumMovementsBySKU(source, key)
+	if err != nil {
+		return recheckConsistent, err
+	}
+	existing, hasOpen := open[key]
+	if !tracked || counter.Equal(ledger) {
+		if !hasOpen {
+			return recheckConsistent, nil
+		}
+		if err := s.store.ResolveDrift(existing.ID, now); err != nil {
+			return recheckConsistent, err
+		}
+		return recheckResolved, nil
+	}
+
+	drift := existing
+	if !hasOpen {
+		drift = stockledgerdomain.Drift{Source: source, ProductID: key.ProductID, SKUID: key.SKUID, DetectedAt: now}
+		logger.Warnw("stock_ledger_drift", "source", source, "product_id", key.ProductID, "sku_id", key.SKUID,
+			"counter_available", counter.Available, "ledger_available", ledger.Available,
+			"counter_locked", counter.Locked, "ledger_locked", ledger.Locked,
+			"counter_sold", counter.Sold, "ledger_sold", ledger.Sold)
+	}
+	drift.SetCounts(counter, ledger)
+	drift.LastCheckedAt = now
+	if err := s.store.SaveDrift(&drift); err != nil {
+		return recheckConsistent, err
+	}
+	return recheckDrifted, nil
+}
+
+func validSource(source string) bool {
+	switch source {
+	case stockledgerdomain.SourceManual, stockledgerdomain.SourceCardSecret, stockledgerdomain.SourceUpstream:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,5 @@
+package contract
+
+import "errors"
+
+var ErrFilterInvalid = errors.New("stock ledger filter invalid")
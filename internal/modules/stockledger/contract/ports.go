@@ -0,0 +1,24 @@
+package contract
+
+import (
+	"time"
+
+	stockledgerdomain "github.com/dujiao-next/internal/modules/stockledger/domain"
+)
+
+// Store 查询库存流水、当前库存计数并维护一致性偏差记录。
+type Store interface {
+	ListMovements(filter MovementFilter) ([]stockledgerdomain.Movement, int64, error)
+	// SumMovements 按规格汇总某一来源的流水增量
+	SumMovements(source string) (map[SKUKey]stockledgerdomain.Counters, error)
+	SumMovementsBySKU(source string, key SKUKey) (stockledgerdomain.Counters, error)
+	// ListCounters 返回某一来源下参与校验的规格计数（无限库存与已删除规格除外）
+	ListCounters(source string) (map[SKUKey]stockledgerdomain.Counters, error)
+	// GetCounters 读取单个规格的当前计数，规格不参与校验时返回 false
+	GetCounters(source string, key SKUKey) (stockledgerdomain.Counters, bool, error)
+
+	ListOpenDrifts() ([]stockledgerdomain.Drift, error)
+	ListDrifts(filter DriftFilter) ([]stockledgerdomain.Drift, int64, error)
+	SaveDrift(drift *stockledgerdomain.Drift) error
+	ResolveDrift(id uint, at time.Time) error
+}
@@ -0,0 +1,43 @@
+package contract
+
+import stockledgerdomain "github.com/dujiao-next/internal/modules/stockledger/domain"
+
+// SKUKey 标识一个规格的库存。
+type SKUKey struct {
+	ProductID uint
+	SKUID     uint
+}
+
+// MovementFilter 后台库存流水查询条件。
+type MovementFilter struct {
+	Source    string
+	ProductID uint
+	SKUID     uint
+	Kind      string
+	Page      int
+	PageSize  int
+}
+
+// DriftFilter 后台偏差记录查询条件；默认只返回未解决的记录。
+type DriftFilter struct {
+	Source          string
+	ProductID       uint
+	IncludeResolved bool
+	Page            int
+	PageSize        int
+}
+
+// CheckResult 一次一致性巡检的结果。
+type CheckResult struct {
+	Checked  int `json:"checked"`
+	Drifted  int `json:"drifted"`
+	Resolved int `json:"resolved"`
+}
+
+// SKUHistory 规格库存流水及当前计数与台账汇总。
+type SKUHistory struct {
+	Counters  *stockledgerdomain.Counters  `json:"counters,omitempty"`
+	Ledger    stockledgerdomain.Counters   `json:"ledger"`
+	Movements []stockledgerdomain.Movement `json:"movements"`
+	Total     int64                        `json:"total"`
+}
@@ -0,0 +1,35 @@
+package domain
+
+import "time"
+
+// Drift 一致性巡检发现的计数与台账偏差；同一规格同一来源仅保留一条未解决记录，
+// 偏差消失后由下次巡检标记解决。
+type Drift struct {
+	ID               uint       `gorm:"primarykey" json:"id"`
+	Source           string     `gorm:"type:varchar(20);not null;index:idx_stock_drift_sku,priority:1" json:"source"`
+	ProductID        uint       `gorm:"not null;index" json:"product_id"`
+	SKUID            uint       `gorm:"column:sku_id;not null;index:idx_stock_drift_sku,priority:2" json:"sku_id"`
+	CounterAvailable int64      `gorm:"not null;default:0" json:"counter_available"`
+	CounterLocked    int64      `gorm:"not null;default:0" json:"counter_locked"`
+	CounterSold      int64      `gorm:"not null;default:0" json:"counter_sold"`
+	LedgerAvailable  int64      `gorm:"not null;default:0" json:"ledger_available"`
+	LedgerLocked     int64      `gorm:"not null;default:0" json:"ledger_locked"`
+	LedgerSold       int64      `gorm:"not null;default:0" json:"ledger_sold"`
+	DetectedAt       time.Time  `gorm:"not null" json:"detected_at"`
+	LastCheckedAt    time.Time  `gorm:"not null" json:"last_checked_at"`
+	ResolvedAt       *time.Time `gorm:"index" json:"resolved_at,omitempty"`
+}
+
+func (Drift) TableName() string {
+	return "stock_drifts"
+}
+
+// SetCounts 写入巡检时的计数与台账汇总。
+func (d *Drift) SetCounts(counter, ledger Counters) {
+	d.CounterAvailable = counter.Available
+	d.CounterLocked = counter.Locked
+	d.CounterSold = counter.Sold
+	d.LedgerAvailable = ledger.Available
+	d.LedgerLocked = ledger.Locked
+	d.LedgerSold = ledger.Sold
+}
@@ -0,0 +1,91 @@
+package domain
+
+import "time"
+
+const (
+	// SourceManual 人工交付规格的手动库存计数
+	SourceManual = "manual"
+	// SourceCardSecret 自动发货规格的卡密库存（按卡密状态计数）
+	SourceCardSecret = "card_secret"
+	// SourceUpstream 对接商品缓存的上游库存，仅记录历史，不参与一致性校验
+	SourceUpstream = "upstream"
+)
+
+const (
+	// KindOpening 台账启用时写入的期初余额
+	KindOpening = "opening"
+	KindLock    = "lock"
+	KindRelease = "release"
+	KindConsume = "consume"
+	// KindAdjust 后台直接修改库存或卡密状态
+	KindAdjust       = "adjust"
+	KindImport       = "import"
+	KindDelete       = "delete"
+	KindUpstreamSync = "upstream_sync"
+)
+
+const (
+	RefTypeOrder   = "order"
+	RefTypeBatch   = "batch"
+	RefTypeAdmin   = "admin"
+	RefTypeProduct = "product"
+	// RefTypeMapping 上游商品映射
+	RefTypeMapping = "mapping"
+)
+
+// Movement 库存流水，只追加不修改。三个增量分别对应可售、占用与已售计数：
+// 手动库存对应 manual_stock_total/locked/sold，卡密库存对应 available/reserved/used 数量，
+// 上游库存仅使用可售增量。SKUID 为 0 表示无规格的历史商品级库存。
+type Movement struct {
+	ID             uint      `gorm:"primarykey" json:"id"`
+	Source         string    `gorm:"type:varchar(20);not null;index:idx_stock_movement_sku,priority:1" json:"source"`
+	ProductID      uint      `gorm:"not null;index" json:"product_id"`
+	SKUID          uint      `gorm:"column:sku_id;not null;default:0;index:idx_stock_movement_sku,priority:2" json:"sku_id"`
+	Kind           string    `gorm:"type:varchar(20);not null;index" json:"kind"`
+	AvailableDelta int       `gorm:"not null;default:0" json:"available_delta"`
+	LockedDelta    int       `gorm:"not null;default:0" json:"locked_delta"`
+	SoldDelta      int       `gorm:"not null;default:0" json:"sold_delta"`
+	RefType        string    `gorm:"type:varchar(20);index:idx_stock_movement_ref,priority:1" json:"ref_type,omitempty"`
+	RefID          uint      `gorm:"not null;default:0;index:idx_stock_movement_ref,priority:2" json:"ref_id,omitempty"`
+	Remark         string    `gorm:"type:varchar(255)" json:"remark,omitempty"`
+	CreatedAt      time.Time `gorm:"index" json:"created_at"`
+}
+
+func (Movement) TableName() string {
+	return "stock_movements"
+}
+
+// IsZero 判断流水是否没有任何计数变化。
+func (m Movement) IsZero() bool {
+	return m.AvailableDelta == 0 && m.LockedDelta == 0 && m.SoldDelta == 0
+}
+
+// Counters 一个规格在某一来源下的库存计数。
+type Counters struct {
+	Available int64 `json:"available"`
+	Locked    int64 `json:"locked"`
+	Sold      int64 `json:"sold"`
+}
+
+// Equal 判断两组计数是否一致。
+func (c Counters) Equal(other Counters) bool {
+	return c.Available == other.Available && c.Locked == other.Locked && c.Sold == other.Sold
+}
+
+const (
+	BucketAvailable = "available"
+	BucketLocked    = "locked"
+	BucketSold      = "sold"
+)
+
+// AddBucket 按计数桶累加增量，未知桶忽略。
+func (m *Movement) AddBucket(bucket string, delta int) {
+	switch bucket {
+	case BucketAvailable:
+		m.AvailableDelta += delta
+	case BucketLocked:
+		m.LockedDelta += delta
+	case BucketSold:
+		m.SoldDelta += delta
+	}
+}
@@ -0,0 +1,129 @@
+package gormstore
+
+import (
+	"time"
+
+	stockledgerdomain "github.com/dujiao-next/internal/modules/stockledger/domain"
+
+	"gorm.io/gorm"
+)
+
+// Record 在调用方的数据库句柄（通常为同一事务）中追加库存流水，忽略无计数变化的流水。
+// 库存计数的写入方在同一句柄内调用，保证计数与台账同时提交或回滚。
+func Record(db *gorm.DB, movements ...stockledgerdomain.Movement) error {
+	if db == nil || len(movements) == 0 {
+		return nil
+	}
+	now := time.Now()
+	rows := make([]stockledgerdomain.Movement, 0, len(movements))
+	for _, movement := range movements {
+		if movement.IsZero() {
+			continue
+		}
+		if movement.CreatedAt.IsZero() {
+			movement.CreatedAt = now
+		}
+		rows = append(rows, movement)
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	return db.CreateInBatches(&rows, 200).Error
+}
+
+// MovementSet 按规格聚合同一操作产生的增量，最终每个规格写入一条流水。
+type MovementSet struct {
+	base  stockledgerdomain.Movement
+	order []skuKey
+	items map[skuKey]*stockledgerdomain.Movement
+}
+
+type skuKey struct {
+	productID uint
+	skuID     uint
+}
+
+// NewMovementSet 以 base 的来源、类型与关联对象为模板聚合流水。
+func NewMovementSet(base stockledgerdomain.Movement) *MovementSet {
+	return &MovementSet{base: base, items: make(map[skuKey]*stockledgerdomain.Movement)}
+}
+
+// Add 为规格的计数桶累加增量。
+func (s *MovementSet) Add(productID, skuID uint, bucket string, delta int) {
+	key := skuKey{productID: productID, skuID: skuID}
+	movement, ok := s.items[key]
+	if !ok {
+		item := s.base
+		item.ProductID = productID
+		item.SKUID = skuID
+		movement = &item
+		s.items[key] = movement
+		s.order = append(s.order, key)
+	}
+	movement.AddBucket(bucket, delta)
+}
+
+// Record 写入聚合后的流水。
+func (s *MovementSet) Record(db *gorm.DB) error {
+	movements := make([]stockledgerdomain.Movement, 0, len(s.order))
+	for _, key := range s.order {
+		movements = append(movements, *s.items[key])
+	}
+	return Record(db, movements...)
+}
+
+// Recorder 将流水写入绑定到指定句柄，供应用层事务端口使用。
+type Recorder struct {
+	db *gorm.DB
+}
+
+func NewRecorder(db *gorm.DB) *Recorder {
+	return &Recorder{db: db}
+}
+
+// Record 追加库存流水。
+func (r *Recorder) Record(movements ...stockledgerdomain.Movement) error {
+	return Record(r.db, movements...)
+}
+
+// RecordOpeningBalances 按当前计数写入期初流水，台账启用前的历史变动以此为起点。
+func RecordOpeningBalances(db *gorm.DB) error {
+	store := New(db)
+	movements := make([]stockledgerdomain.Movement, 0)
+	for _, source := range []string{stockledgerdomain.SourceManual, stockledgerdomain.SourceCardSecret} {
+		counters, err := store.ListCounters(source)
+		if err != nil {
+			return err
+		}
+		for key, counter := range counters {
+			movements = append(movements, stockledgerdomain.Movement{
+				Source:         source,
+				ProductID:      key.ProductID,
+				SKUID:          key.SKUID,
+				Kind:           stockledgerdomain.KindOpening,
+				AvailableDelta: int(counter.Available),
+				LockedDelta:    int(counter.Locked),
+				SoldDelta:      int(counter.Sold),
+			})
+		}
+	}
+
+	var upstream []counterRow
+	if err := db.Table("sku_mappings").
+		Select("product_mappings.local_product_id AS product_id, sku_mappings.local_sku_id AS sku_id, sku_mappings.upstream_stock AS available").
+		Joins("JOIN product_mappings ON product_mappings.id = sku_mappings.product_mapping_id AND product_mappings.deleted_at IS NULL").
+		Where("sku_mappings.deleted_at IS NULL AND sku_mappings.upstream_stock > 0").
+		Scan(&upstream).Error; err != nil {
+		return err
+	}
+	for _, row := range upstream {
+		movements = append(movements, stockledgerdomain.Movement{
+			Source:         stockledgerdomain.SourceUpstream,
+			ProductID:      row.ProductID,
+			SKUID:          row.SKUID,
+			Kind:           stockledgerdomain.KindOpening,
+			AvailableDelta: int(row.Available),
+		})
+	}
+	return Record(db, movements...)
+}
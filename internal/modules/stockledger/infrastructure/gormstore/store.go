@@ -0,0 +1,217 @@
+package gormstore
+
+import (
+	"errors"
+	"time"
+
+	cardsecretdomain "github.com/dujiao-next/internal/modules/cardsecret/domain"
+	productdomain "github.com/dujiao-next/internal/modules/catalog/product/domain"
+	stockledgercontract "github.com/dujiao-next/internal/modules/stockledger/contract"
+	stockledgerdomain "github.com/dujiao-next/internal/modules/stockledger/domain"
+
+	"gorm.io/gorm"
+)
+
+// Store 是库存台账查询与偏差记录的 GORM 仓储。
+type Store struct {
+	db *gorm.DB
+}
+
+var _ stockledgercontract.Store = (*Store)(nil)
+
+func New(db *gorm.DB) *Store {
+	if db == nil {
+		panic("stock ledger store: db is nil")
+	}
+	return &Store{db: db}
+}
+
+func (s *Store) ListMovements(filter stockledgercontract.MovementFilter) ([]stockledgerdomain.Movement, int64, error) {
+	query := s.db.Model(&stockledgerdomain.Movement{})
+	if filter.Source != "" {
+		query = query.Where("source = ?", filter.Source)
+	}
+	if filter.ProductID > 0 {
+		query = query.Where("product_id = ?", filter.ProductID)
+	}
+	if filter.SKUID > 0 {
+		query = query.Where("sku_id = ?", filter.SKUID)
+	}
+	if filter.Kind != "" {
+		query = query.Where("kind = ?", filter.Kind)
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var movements []stockledgerdomain.Movement
+	if err := paginate(query.Order("id DESC"), filter.Page, filter.PageSize).Find(&movements).Error; err != nil {
+		return nil, 0, err
+	}
+	return movements, total, nil
+}
+
+type counterRow struct {
+	ProductID uint
+	SKUID     uint `gorm:"column:sku_id"`
+	Available int64
+	Locked    int64
+	Sold      int64
+}
+
+func (r counterRow) key() stockledgercontract.SKUKey {
+	return stockledgercontract.SKUKey{ProductID: r.ProductID, SKUID: r.SKUID}
+}
+
+func (r counterRow) counters() stockledgerdomain.Counters {
+	return stockledgerdomain.Counters{Available: r.Available, Locked: r.Locked, Sold: r.Sold}
+}
+
+func (s *Store) movementSums(source string) *gorm.DB {
+	return s.db.Model(&stockledgerdomain.Movement{}).
+		Select("product_id, sku_id, COALESCE(SUM(available_delta), 0) AS available, COALESCE(SUM(locked_delta), 0) AS locked, COALESCE(SUM(sold_delta), 0) AS sold").
+		Where("source = ?", source).
+		Group("product_id, sku_id")
+}
+
+func (s *Store) SumMovements(source string) (map[stockledgercontract.SKUKey]stockledgerdomain.Counters, error) {
+	var rows []counterRow
+	if err := s.movementSums(source).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return indexCounters(rows), nil
+}
+
+func (s *Store) SumMovementsBySKU(source string, key stockledgercontract.SKUKey) (stockledgerdomain.Counters, error) {
+	var rows []counterRow
+	if err := s.movementSums(source).
+		Where("product_id = ? AND sku_id = ?", key.ProductID, key.SKUID).
+		Scan(&rows).Error; err != nil {
+		return stockledgerdomain.Counters{}, err
+	}
+	if len(rows) == 0 {
+		return stockledgerdomain.Counters{}, nil
+	}
+	return rows[0].counters(), nil
+}
+
+// counterQuery 构造参与校验的规格计数查询：手动库存排除无限库存，卡密按状态计数。
+func (s *Store) counterQuery(source string) (*gorm.DB, error) {
+	switch source {
+	case stockledgerdomain.SourceManual:
+		return s.db.Model(&productdomain.ProductSKU{}).
+			Select("product_id, id AS sku_id, manual_stock_total AS available, manual_stock_locked AS locked, manual_stock_sold AS sold").
+			Where("deleted_at IS NULL AND manual_stock_total >= 0"), nil
+	case stockledgerdomain.SourceCardSecret:
+		return s.db.Model(&cardsecretdomain.Secret{}).
+			Select("product_id, sku_id, "+
+				"COALESCE(SUM(CASE WHEN status = ? THEN 1 ELSE 0 END), 0) AS available, "+
+				"COALESCE(SUM(CASE WHEN status = ? THEN 1 ELSE 0 END), 0) AS locked, "+
+				"COALESCE(SUM(CASE WHEN status = ? THEN 1 ELSE 0 END), 0) AS sold",
+				cardsecretdomain.StatusAvailable, cardsecretdomain.StatusReserved, cardsecretdomain.StatusUsed).
+			Where("deleted_at IS NULL").
+			Group("product_id, sku_id"), nil
+	default:
+		return nil, stockledgercontract.ErrFilterInvalid
+	}
+}
+
+func (s *Store) ListCounters(source string) (map[stockledgercontract.SKUKey]stockledgerdomain.Counters, error) {
+	query, err := s.counterQuery(source)
+	if err != nil {
+		return nil, err
+	}
+	var rows []counterRow
+	if err := query.Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return indexCounters(rows), nil
+}
+
+func (s *Store) GetCounters(source string, key stockledgercontract.SKUKey) (stockledgerdomain.Counters, bool, error) {
+	query, err := s.counterQuery(source)
+	if err != nil {
+		return stockledgerdomain.Counters{}, false, err
+	}
+	if source == stockledgerdomain.SourceManual {
+		query = query.Where("product_id = ? AND id = ?", key.ProductID, key.SKUID)
+	} else {
+		query = query.Where("product_id = ? AND sku_id = ?", key.ProductID, key.SKUID)
+	}
+	var rows []counterRow
+	if err := query.Scan(&rows).Error; err != nil {
+		return stockledgerdomain.Counters{}, false, err
+	}
+	if len(rows) == 0 {
+		if source == stockledgerdomain.SourceCardSecret {
+			// 卡密全部删除后计数为零，仍需与台账比对
+			return stockledgerdomain.Counters{}, true, nil
+		}
+		return stockledgerdomain.Counters{}, false, nil
+	}
+	return rows[0].counters(), true, nil
+}
+
+func (s *Store) ListOpenDrifts() ([]stockledgerdomain.Drift, error) {
+	var drifts []stockledgerdomain.Drift
+	if err := s.db.Where("resolved_at IS NULL").Order("id ASC").Find(&drifts).Error; err != nil {
+		return nil, err
+	}
+	return drifts, nil
+}
+
+func (s *Store) ListDrifts(filter stockledgercontract.DriftFilter) ([]stockledgerdomain.Drift, int64, error) {
+	query := s.db.Model(&stockledgerdomain.Drift{})
+	if !filter.IncludeResolved {
+		query = query.Where("resolved_at IS NULL")
+	}
+	if filter.Source != "" {
+		query = query.Where("source = ?", filter.Source)
+	}
+	if filter.ProductID > 0 {
+		query = query.Where("product_id = ?", filter.ProductID)
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var drifts []stockledgerdomain.Drift
+	if err := paginate(query.Order("id DESC"), filter.Page, filter.PageSize).Find(&drifts).Error; err != nil {
+		return nil, 0, err
+	}
+	return drifts, total, nil
+}
+
+func (s *Store) SaveDrift(drift *stockledgerdomain.Drift) error {
+	if drift == nil {
+		return errors.New("stock drift is nil")
+	}
+	if drift.ID == 0 {
+		return s.db.Create(drift).Error
+	}
+	return s.db.Save(drift).Error
+}
+
+func (s *Store) ResolveDrift(id uint, at time.Time) error {
+	return s.db.Model(&stockledgerdomain.Drift{}).
+		Where("id = ? AND resolved_at IS NULL", id).
+		Updates(map[string]interface{}{"resolved_at": at, "last_checked_at": at}).Error
+}
+
+func indexCounters(rows []counterRow) map[stockledgercontract.SKUKey]stockledgerdomain.Counters {
+	result := make(map[stockledgercontract.SKUKey]stockledgerdomain.Counters, len(rows))
+	for _, row := range rows {
+		result[row.key()] = row.counters()
+	}
+	return result
+}
+
+func paginate(query *gorm.DB, page, pageSize int) *gorm.DB {
+	if pageSize <= 0 {
+		return query
+	}
+	if page < 1 {
+		page = 1
+	}
+	return query.Offset((page - 1) * pageSize).Limit(pageSize)
+}
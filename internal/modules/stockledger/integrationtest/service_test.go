@@ -0,0 +1,221 @@
+package integrationtest
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	cardsecretdomain "github.com/dujiao-next/internal/modules/cardsecret/domain"
+	cardsecretgormstore "github.com/dujiao-next/internal/modules/cardsecret/infrastructure/gormstore"
+	mappingdomain "github.com/dujiao-next/internal/modules/catalog/mapping/domain"
+	productdomain "github.com/dujiao-next/internal/modules/catalog/product/domain"
+	productgormstore "github.com/dujiao-next/internal/modules/catalog/product/store/gormstore"
+	stockledgerapp "github.com/dujiao-next/internal/modules/stockledger/application"
+	stockledgercontract "github.com/dujiao-next/internal/modules/stockledger/contract"
+	stockledgerdomain "github.com/dujiao-next/internal/modules/stockledger/domain"
+	stockledgergormstore "github.com/dujiao-next/internal/modules/stockledger/infrastructure/gormstore"
+	"github.com/dujiao-next/internal/shared/money"
+
+	"github.com/glebarez/sqlite"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+type fixture struct {
+	db      *gorm.DB
+	skus    *productgormstore.SKUStore
+	secrets *cardsecretgormstore.Store
+	service *stockledgerapp.Service
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+	dsn := fmt.Sprintf("file:stock_ledger_%d?mode=memory&cache=shared", time.Now().UnixNano())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite failed: %v", err)
+	}
+	if err := db.AutoMigrate(
+		&productdomain.ProductSKU{},
+		&cardsecretdomain.Secret{},
+		&mappingdomain.Mapping{},
+		&mappingdomain.SKUMapping{},
+		&stockledgerdomain.Movement{},
+		&stockledgerdomain.Drift{},
+	); err != nil {
+		t.Fatalf("migrate failed: %v", err)
+	}
+	return &fixture{
+		db:      db,
+		skus:    productgormstore.NewSKUStore(db),
+		secrets: cardsecretgormstore.New(db),
+		service: stockledgerapp.NewService(stockledgergormstore.New(db)),
+	}
+}
+
+func (f *fixture) createSKU(t *testing.T, productID uint, code string, total int) *productdomain.ProductSKU {
+	t.Helper()
+	sku := &productdomain.ProductSKU{
+		ProductID:        productID,
+		SKUCode:          code,
+		PriceAmount:      money.FromDecimal(decimal.NewFromInt(10)),
+		ManualStockTotal: total,
+		IsActive:         true,
+	}
+	if err := f.skus.Create(sku); err != nil {
+		t.Fatalf("create sku: %v", err)
+	}
+	return sku
+}
+
+func (f *fixture) check(t *testing.T) *stockledgercontract.CheckResult {
+	t.Helper()
+	result, err := f.service.Check()
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	return result
+}
+
+func TestManualStockLedgerDriftDetectedAndResolved(t *testing.T) {
+	f := newFixture(t)
+	sku := f.createSKU(t, 1, "A", 10)
+	if err := stockledgergormstore.RecordOpeningBalances(f.db); err != nil {
+		t.Fatalf("opening balances: %v", err)
+	}
+
+	if _, err := f.skus.ReserveManualStock(sku.ID, 4, 101); err != nil {
+		t.Fatalf("reserve: %v", err)
+	}
+	if _, err := f.skus.ConsumeManualStock(sku.ID, 3, 101); err != nil {
+		t.Fatalf("consume: %v", err)
+	}
+	if _, err := f.skus.ReleaseManualStock(sku.ID, 1, 101); err != nil {
+		t.Fatalf("release: %v", err)
+	}
+	if result := f.check(t); result.Checked != 1 || result.Drifted != 0 {
+		t.Fatalf("expected consistent ledger, got %+v", result)
+	}
+
+	// 绕过仓储直接改计数，模拟未记账的写入
+	if err := f.db.Model(&productdomain.ProductSKU{}).Where("id = ?", sku.ID).
+		Update("manual_stock_total", gorm.Expr("manual_stock_total + 5")).Error; err != nil {
+		t.Fatalf("tamper counter: %v", err)
+	}
+	if result := f.check(t); result.Drifted != 1 {
+		t.Fatalf("expected one drift, got %+v", result)
+	}
+	if result := f.check(t); result.Drifted != 1 {
+		t.Fatalf("repeated check should keep one drift, got %+v", result)
+	}
+	drifts, total, err := f.service.ListDrifts(stockledgercontract.DriftFilter{})
+	if err != nil || total != 1 {
+		t.Fatalf("list drifts total=%d err=%v", total, err)
+	}
+	drift := drifts[0]
+	if drift.Source != stockledgerdomain.SourceManual || drift.SKUID != sku.ID ||
+		drift.CounterAvailable != 12 || drift.LedgerAvailable != 7 || drift.CounterSold != 3 || drift.LedgerSold != 3 {
+		t.Fatalf("unexpected drift: %+v", drift)
+	}
+
+	history, err := f.service.GetSKUHistory(stockledgerdomain.SourceManual, 1, sku.ID, 1, 20)
+	if err != nil {
+		t.Fatalf("sku history: %v", err)
+	}
+	if history.Total != 4 || history.Counters == nil || history.Counters.Available != 12 || history.Ledger.Available != 7 {
+		t.Fatalf("unexpected history: %+v", history)
+	}
+	if history.Movements[0].Kind != stockledgerdomain.KindRelease || history.Movements[0].RefID != 101 {
+		t.Fatalf("latest movement should be the release, got %+v", history.Movements[0])
+	}
+
+	if err := f.db.Model(&productdomain.ProductSKU{}).Where("id = ?", sku.ID).
+		Update("manual_stock_total", gorm.Expr("manual_stock_total - 5")).Error; err != nil {
+		t.Fatalf("restore counter: %v", err)
+	}
+	if result := f.check(t); result.Drifted != 0 || result.Resolved != 1 {
+		t.Fatalf("expected drift resolved, got %+v", result)
+	}
+	if _, total, _ := f.service.ListDrifts(stockledgercontract.DriftFilter{}); total != 0 {
+		t.Fatalf("open drifts should be empty, got %d", total)
+	}
+}
+
+func TestCardSecretLedgerFollowsStatusChanges(t *testing.T) {
+	f := newFixture(t)
+	batchID := uint(9)
+	now := time.Now()
+	items := make([]cardsecretdomain.Secret, 0, 5)
+	for i := 0; i < 5; i++ {
+		items = append(items, cardsecretdomain.Secret{
+			ProductID: 2,
+			SKUID:     3,
+			BatchID:   &batchID,
+			Secret:    fmt.Sprintf("CODE-%d", i),
+			Status:    cardsecretdomain.StatusAvailable,
+			CreatedAt: now,
+			UpdatedAt: now,
+		})
+	}
+	if err := f.secrets.CreateBatch(items); err != nil {
+		t.Fatalf("create batch: %v", err)
+	}
+	rows, err := f.secrets.ListAvailableByProduct(2, 3, 5)
+	if err != nil || len(rows) != 5 {
+		t.Fatalf("list available rows=%d err=%v", len(rows), err)
+	}
+
+	if _, err := f.secrets.Reserve([]uint{rows[0].ID, rows[1].ID}, 201, now); err != nil {
+		t.Fatalf("reserve: %v", err)
+	}
+	if _, err := f.secrets.MarkUsed([]uint{rows[0].ID}, 201, now); err != nil {
+		t.Fatalf("mark used: %v", err)
+	}
+	if _, err := f.secrets.ReleaseByOrder(201); err != nil {
+		t.Fatalf("release: %v", err)
+	}
+	if _, err := f.secrets.BatchUpdateStatus([]uint{rows[2].ID}, cardsecretdomain.StatusUsed, now, 7); err != nil {
+		t.Fatalf("batch status: %v", err)
+	}
+	if _, err := f.secrets.BatchDeleteByIDs([]uint{rows[3].ID}, 7); err != nil {
+		t.Fatalf("batch delete: %v", err)
+	}
+	if result := f.check(t); result.Checked != 1 || result.Drifted != 0 {
+		t.Fatalf("expected consistent card ledger, got %+v", result)
+	}
+
+	movements, _, err := f.service.ListMovements(stockledgercontract.MovementFilter{Source: stockledgerdomain.SourceCardSecret, SKUID: 3})
+	if err != nil {
+		t.Fatalf("list movements: %v", err)
+	}
+	wantKinds := []string{
+		stockledgerdomain.KindDelete,
+		stockledgerdomain.KindAdjust,
+		stockledgerdomain.KindRelease,
+		stockledgerdomain.KindConsume,
+		stockledgerdomain.KindLock,
+		stockledgerdomain.KindImport,
+	}
+	if len(movements) != len(wantKinds) {
+		t.Fatalf("expected %d movements, got %d", len(wantKinds), len(movements))
+	}
+	for i, kind := range wantKinds {
+		if movements[i].Kind != kind {
+			t.Fatalf("movement %d kind want %s got %s", i, kind, movements[i].Kind)
+		}
+	}
+	if movements[5].RefType != stockledgerdomain.RefTypeBatch || movements[5].RefID != batchID || movements[5].AvailableDelta != 5 {
+		t.Fatalf("unexpected import movement: %+v", movements[5])
+	}
+	if movements[0].RefType != stockledgerdomain.RefTypeAdmin || movements[0].RefID != 7 || movements[0].AvailableDelta != -1 {
+		t.Fatalf("unexpected delete movement: %+v", movements[0])
+	}
+
+	// 删除全部卡密后计数归零，台账同样应归零
+	if err := f.secrets.DeleteByProduct(2); err != nil {
+		t.Fatalf("delete by product: %v", err)
+	}
+	if result := f.check(t); result.Drifted != 0 {
+		t.Fatalf("expected consistent ledger after delete, got %+v", result)
+	}
+}
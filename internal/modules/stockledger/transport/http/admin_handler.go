@@ -0,0 +1,120 @@
+package stockledgerhttp
+
+import (
+	"errors"
+	"strings"
+
+	stockledgercontract "github.com/dujiao-next/internal/modules/stockledger/contract"
+	stockledgerdomain "github.com/dujiao-next/internal/modules/stockledger/domain"
+	"github.com/dujiao-next/internal/platform/http/ginutil"
+	"github.com/dujiao-next/internal/platform/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminService 是后台库存流水查询与巡检所需的最小用例接口。
+type AdminService interface {
+	ListMovements(filter stockledgercontract.MovementFilter) ([]stockledgerdomain.Movement, int64, error)
+	GetSKUHistory(source string, productID, skuID uint, page, pageSize int) (*stockledgercontract.SKUHistory, error)
+	ListDrifts(filter stockledgercontract.DriftFilter) ([]stockledgerdomain.Drift, int64, error)
+	Check() (*stockledgercontract.CheckResult, error)
+}
+
+// AdminHandler 处理后台库存台账请求。
+type AdminHandler struct {
+	service AdminService
+}
+
+func NewAdminHandler(service AdminService) *AdminHandler {
+	if service == nil {
+		panic("stock ledger admin handler: required dependency is nil")
+	}
+	return &AdminHandler{service: service}
+}
+
+// ListMovements 获取库存流水（支持 source、product_id、sku_id、kind 筛选）
+func (h *AdminHandler) ListMovements(c *gin.Context) {
+	productID, skuID, ok := parseSKUQuery(c)
+	if !ok {
+		return
+	}
+	page, pageSize := ginutil.ParsePagination(c)
+	movements, total, err := h.service.ListMovements(stockledgercontract.MovementFilter{
+		Source:    strings.TrimSpace(c.Query("source")),
+		ProductID: productID,
+		SKUID:     skuID,
+		Kind:      strings.TrimSpace(c.Query("kind")),
+		Page:      page,
+		PageSize:  pageSize,
+	})
+	if err != nil {
+		ginutil.RespondError(c, response.CodeInternal, "error.stock_ledger_fetch_failed", err)
+		return
+	}
+	response.SuccessWithPage(c, movements, response.BuildPagination(page, pageSize, total))
+}
+
+// GetSKUHistory 获取单个规格的库存流水与当前计数、台账汇总
+func (h *AdminHandler) GetSKUHistory(c *gin.Context) {
+	productID, skuID, ok := parseSKUQuery(c)
+	if !ok {
+		return
+	}
+	page, pageSize := ginutil.ParsePagination(c)
+	history, err := h.service.GetSKUHistory(strings.TrimSpace(c.Query("source")), productID, skuID, page, pageSize)
+	if err != nil {
+		if errors.Is(err, stockledgercontract.ErrFilterInvalid) {
+			ginutil.RespondError(c, response.CodeBadRequest, "error.stock_ledger_filter_invalid", nil)
+			return
+		}
+		ginutil.RespondError(c, response.CodeInternal, "error.stock_ledger_fetch_failed", err)
+		return
+	}
+	response.Success(c, history)
+}
+
+// ListDrifts 获取计数与台账偏差（默认仅未解决，include_resolved=true 包含已解决）
+func (h *AdminHandler) ListDrifts(c *gin.Context) {
+	productID, err := ginutil.ParseQueryUint(c.Query("product_id"), false)
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", nil)
+		return
+	}
+	page, pageSize := ginutil.ParsePagination(c)
+	drifts, total, err := h.service.ListDrifts(stockledgercontract.DriftFilter{
+		Source:          strings.TrimSpace(c.Query("source")),
+		ProductID:       productID,
+		IncludeResolved: strings.EqualFold(strings.TrimSpace(c.Query("include_resolved")), "true"),
+		Page:            page,
+		PageSize:        pageSize,
+	})
+	if err != nil {
+		ginutil.RespondError(c, response.CodeInternal, "error.stock_ledger_fetch_failed", err)
+		return
+	}
+	response.SuccessWithPage(c, drifts, response.BuildPagination(page, pageSize, total))
+}
+
+// Check 立即执行一次一致性巡检
+func (h *AdminHandler) Check(c *gin.Context) {
+	result, err := h.service.Check()
+	if err != nil {
+		ginutil.RespondError(c, response.CodeInternal, "error.stock_ledger_check_failed", err)
+		return
+	}
+	response.Success(c, result)
+}
+
+func parseSKUQuery(c *gin.Context) (uint, uint, bool) {
+	productID, err := ginutil.ParseQueryUint(c.Query("product_id"), false)
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", nil)
+		return 0, 0, false
+	}
+	skuID, err := ginutil.ParseQueryUint(c.Query("sku_id"), false)
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", nil)
+		return 0, 0, false
+	}
+	return productID, skuID, true
+}
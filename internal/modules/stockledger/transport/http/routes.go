@@ -0,0 +1,14 @@
+package stockledgerhttp
+
+import "github.com/gin-gonic/gin"
+
+// RegisterAdminRoutes 注册后台库存流水与一致性巡检路由。
+func RegisterAdminRoutes(authorized gin.IRoutes, handler *AdminHandler) {
+	if authorized == nil || handler == nil {
+		panic("stock ledger admin routes: required dependency is nil")
+	}
+	authorized.GET("/stock-movements", handler.ListMovements)
+	authorized.GET("/stock-movements/sku", handler.GetSKUHistory)
+	authorized.GET("/stock-drifts", handler.ListDrifts)
+	authorized.POST("/stock-drifts/check", handler.Check)
+}
@@ -0,0 +1,117 @@
+package application
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	stockthresholdcontract "github.com/dujiao-next/internal/modules/stockthreshold/contract"
+	stockthresholddomain "github.com/dujiao-next/internal/modules/stockthreshold/domain"
+)
+
+// setCacheTTL 店面读取阈值解析器的缓存时长，后台修改阈值时立即失效。
+const setCacheTTL = time.Minute
+
+// Service 管理分类、商品与规格级的库存告警阈值。
+type Service struct {
+	store stockthresholdcontract.Store
+
+	mu       sync.Mutex
+	set      *stockthresholddomain.Set
+	loadedAt time.Time
+}
+
+func NewService(store stockthresholdcontract.Store) *Service {
+	if store == nil {
+		panic("stock threshold service: store is nil")
+	}
+	return &Service{store: store}
+}
+
+// CurrentSet 返回缓存的阈值解析器，供店面、渠道与上游 API 计算低库存状态。
+func (s *Service) CurrentSet() (*stockthresholddomain.Set, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.set != nil && time.Since(s.loadedAt) < setCacheTTL {
+		return s.set, nil
+	}
+	items, err := s.store.ListAll()
+	if err != nil {
+		return nil, err
+	}
+	parents, err := s.store.ListCategoryParents()
+	if err != nil {
+		return nil, err
+	}
+	s.set = stockthresholddomain.NewSet(items, parents)
+	s.loadedAt = time.Now()
+	return s.set, nil
+}
+
+func (s *Service) invalidateSet() {
+	s.mu.Lock()
+	s.set = nil
+	s.mu.Unlock()
+}
+
+// List 后台查询阈值覆盖记录。
+func (s *Service) List(filter stockthresholdcontract.ListFilter) ([]stockthresholddomain.Threshold, int64, error) {
+	filter.Scope = strings.TrimSpace(filter.Scope)
+	return s.store.List(filter)
+}
+
+// Save 写入某个对象的阈值覆盖；两项均为 0 时等同于清除覆盖。
+func (s *Service) Save(input stockthresholdcontract.SaveInput) (*stockthresholddomain.Threshold, error) {
+	scope := strings.TrimSpace(input.Scope)
+	if !stockthresholddomain.ValidScope(scope) || input.TargetID == 0 ||
+		input.LowStockQuantity < 0 || input.LowStockQuantity > stockthresholddomain.MaxLowStockQuantity ||
+		input.AlertDays < 0 || input.AlertDays > stockthresholddomain.MaxAlertDays {
+		return nil, stockthresholdcontract.ErrThresholdInvalid
+	}
+	exists, err := s.store.TargetExists(scope, input.TargetID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, stockthresholdcontract.ErrTargetNotFound
+	}
+	threshold, err := s.store.GetByTarget(scope, input.TargetID)
+	if err != nil {
+		return nil, err
+	}
+	if input.LowStockQuantity == 0 && input.AlertDays == 0 {
+		if threshold != nil {
+			if err := s.store.Delete(threshold.ID); err != nil {
+				return nil, err
+			}
+			s.invalidateSet()
+		}
+		return nil, nil
+	}
+	if threshold == nil {
+		threshold = &stockthresholddomain.Threshold{Scope: scope, TargetID: input.TargetID}
+	}
+	threshold.LowStockQuantity = input.LowStockQuantity
+	threshold.AlertDays = input.AlertDays
+	if err := s.store.Save(threshold); err != nil {
+		return nil, err
+	}
+	s.invalidateSet()
+	return threshold, nil
+}
+
+// Delete 删除阈值覆盖，对象恢复继承上一级。
+func (s *Service) Delete(id uint) error {
+	threshold, err := s.store.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if threshold == nil {
+		return stockthresholdcontract.ErrThresholdNotFound
+	}
+	if err := s.store.Delete(id); err != nil {
+		return err
+	}
+	s.invalidateSet()
+	return nil
+}
@@ -0,0 +1,9 @@
+package contract
+
+import "errors"
+
+var (
+	ErrThresholdInvalid  = errors.New("stock threshold invalid")
+	ErrThresholdNotFound = errors.New("stock threshold not found")
+	ErrTargetNotFound    = errors.New("stock threshold target not found")
+)
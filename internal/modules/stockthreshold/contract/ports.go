@@ -0,0 +1,18 @@
+package contract
+
+import stockthresholddomain "github.com/dujiao-next/internal/modules/stockthreshold/domain"
+
+// Store 维护库存告警阈值覆盖记录。
+type Store interface {
+	List(filter ListFilter) ([]stockthresholddomain.Threshold, int64, error)
+	GetByID(id uint) (*stockthresholddomain.Threshold, error)
+	GetByTarget(scope string, targetID uint) (*stockthresholddomain.Threshold, error)
+	Save(threshold *stockthresholddomain.Threshold) error
+	Delete(id uint) error
+	// TargetExists 校验分类、商品或规格是否存在（已软删除的视为不存在）
+	TargetExists(scope string, targetID uint) (bool, error)
+	// ListAll 返回全部阈值覆盖，用于构建店面与上游 API 的阈值解析器
+	ListAll() ([]stockthresholddomain.Threshold, error)
+	// ListCategoryParents 返回 分类ID -> 父分类ID
+	ListCategoryParents() (map[uint]uint, error)
+}
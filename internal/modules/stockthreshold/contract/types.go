@@ -0,0 +1,17 @@
+package contract
+
+// ListFilter 后台阈值列表筛选条件。
+type ListFilter struct {
+	Scope    string
+	TargetID uint
+	Page     int
+	PageSize int
+}
+
+// SaveInput 按作用对象写入阈值，同一对象重复写入即覆盖。
+type SaveInput struct {
+	Scope            string `json:"scope" binding:"required"`
+	TargetID         uint   `json:"target_id" binding:"required"`
+	LowStockQuantity int64  `json:"low_stock_quantity"`
+	AlertDays        int    `json:"alert_days"`
+}
@@ -0,0 +1,114 @@
+package domain
+
+import "time"
+
+const (
+	ScopeCategory = "category"
+	ScopeProduct  = "product"
+	ScopeSKU      = "sku"
+)
+
+const (
+	MaxLowStockQuantity int64 = 1000000
+	MaxAlertDays              = 365
+)
+
+// Threshold 是分类、商品或规格级的库存告警阈值覆盖，字段为 0 表示继承上一级。
+type Threshold struct {
+	ID               uint      `gorm:"primarykey" json:"id"`
+	Scope            string    `gorm:"type:varchar(20);not null;uniqueIndex:idx_stock_threshold_target" json:"scope"`
+	TargetID         uint      `gorm:"not null;uniqueIndex:idx_stock_threshold_target" json:"target_id"`
+	LowStockQuantity int64     `gorm:"not null;default:0" json:"low_stock_quantity"` // 无销量数据时的低库存数量
+	AlertDays        int       `gorm:"not null;default:0" json:"alert_days"`         // 预测可售天数告警线
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+func (Threshold) TableName() string {
+	return "stock_thresholds"
+}
+
+// ValidScope 判断阈值作用范围是否受支持。
+func ValidScope(scope string) bool {
+	switch scope {
+	case ScopeCategory, ScopeProduct, ScopeSKU:
+		return true
+	default:
+		return false
+	}
+}
+
+// Values 是解析后的生效阈值。
+type Values struct {
+	LowStockQuantity int64
+	AlertDays        int
+}
+
+// Set 按 规格 > 商品 > 分类（子分类优先于父分类）> 全局默认 的顺序逐字段解析阈值。
+type Set struct {
+	categories map[uint]Values
+	products   map[uint]Values
+	skus       map[uint]Values
+	parents    map[uint]uint
+}
+
+// NewSet 基于阈值记录与分类父子关系构建解析器；categoryParents 为 分类ID -> 父分类ID。
+func NewSet(items []Threshold, categoryParents map[uint]uint) *Set {
+	set := &Set{
+		categories: make(map[uint]Values),
+		products:   make(map[uint]Values),
+		skus:       make(map[uint]Values),
+		parents:    categoryParents,
+	}
+	for _, item := range items {
+		values := Values{LowStockQuantity: item.LowStockQuantity, AlertDays: item.AlertDays}
+		switch item.Scope {
+		case ScopeCategory:
+			set.categories[item.TargetID] = values
+		case ScopeProduct:
+			set.products[item.TargetID] = values
+		case ScopeSKU:
+			set.skus[item.TargetID] = values
+		}
+	}
+	return set
+}
+
+// Resolve 返回指定规格的生效阈值；skuID 为 0 时按商品级解析。
+func (s *Set) Resolve(fallback Values, categoryID, productID, skuID uint) Values {
+	if s == nil {
+		return fallback
+	}
+	chain := make([]Values, 0, 4)
+	if skuID > 0 {
+		if values, ok := s.skus[skuID]; ok {
+			chain = append(chain, values)
+		}
+	}
+	if values, ok := s.products[productID]; ok {
+		chain = append(chain, values)
+	}
+	// 分类链深度受限于分类表，visited 防止脏数据形成环
+	visited := make(map[uint]struct{})
+	for id := categoryID; id > 0; id = s.parents[id] {
+		if _, seen := visited[id]; seen {
+			break
+		}
+		visited[id] = struct{}{}
+		if values, ok := s.categories[id]; ok {
+			chain = append(chain, values)
+		}
+	}
+	chain = append(chain, fallback)
+
+	result := Values{}
+	for _, values := range chain {
+		if result.LowStockQuantity == 0 {
+			result.LowStockQuantity = values.LowStockQuantity
+		}
+		if result.AlertDays == 0 {
+			result.AlertDays = values.AlertDays
+		}
+	}
+	return result
+}
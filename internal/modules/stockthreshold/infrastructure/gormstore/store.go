@@ -0,0 +1,121 @@
+package gormstore
+
+import (
+	"errors"
+
+	categorydomain "github.com/dujiao-next/internal/modules/catalog/category/domain"
+	productdomain "github.com/dujiao-next/internal/modules/catalog/product/domain"
+	stockthresholdcontract "github.com/dujiao-next/internal/modules/stockthreshold/contract"
+	stockthresholddomain "github.com/dujiao-next/internal/modules/stockthreshold/domain"
+
+	"gorm.io/gorm"
+)
+
+// Store 是库存告警阈值的 GORM 仓储。
+type Store struct {
+	db *gorm.DB
+}
+
+var _ stockthresholdcontract.Store = (*Store)(nil)
+
+func New(db *gorm.DB) *Store {
+	if db == nil {
+		panic("stock threshold store: db is nil")
+	}
+	return &Store{db: db}
+}
+
+func (s *Store) List(filter stockthresholdcontract.ListFilter) ([]stockthresholddomain.Threshold, int64, error) {
+	query := s.db.Model(&stockthresholddomain.Threshold{})
+	if filter.Scope != "" {
+		query = query.Where("scope = ?", filter.Scope)
+	}
+	if filter.TargetID > 0 {
+		query = query.Where("target_id = ?", filter.TargetID)
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if filter.PageSize > 0 {
+		page := filter.Page
+		if page < 1 {
+			page = 1
+		}
+		query = query.Offset((page - 1) * filter.PageSize).Limit(filter.PageSize)
+	}
+	var items []stockthresholddomain.Threshold
+	if err := query.Order("scope ASC, target_id ASC").Find(&items).Error; err != nil {
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
+func (s *Store) GetByID(id uint) (*stockthresholddomain.Threshold, error) {
+	var item stockthresholddomain.Threshold
+	if err := s.db.First(&item, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (s *Store) GetByTarget(scope string, targetID uint) (*stockthresholddomain.Threshold, error) {
+	var item stockthresholddomain.Threshold
+	if err := s.db.Where("scope = ? AND target_id = ?", scope, targetID).First(&item).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (s *Store) Save(threshold *stockthresholddomain.Threshold) error {
+	return s.db.Save(threshold).Error
+}
+
+func (s *Store) Delete(id uint) error {
+	return s.db.Delete(&stockthresholddomain.Threshold{}, id).Error
+}
+
+func (s *Store) ListAll() ([]stockthresholddomain.Threshold, error) {
+	var items []stockthresholddomain.Threshold
+	if err := s.db.Order("id ASC").Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (s *Store) ListCategoryParents() (map[uint]uint, error) {
+	var categories []categorydomain.Category
+	if err := s.db.Select("id, parent_id").Where("deleted_at IS NULL").Find(&categories).Error; err != nil {
+		return nil, err
+	}
+	parents := make(map[uint]uint, len(categories))
+	for _, category := range categories {
+		parents[category.ID] = category.ParentID
+	}
+	return parents, nil
+}
+
+func (s *Store) TargetExists(scope string, targetID uint) (bool, error) {
+	var model interface{}
+	switch scope {
+	case stockthresholddomain.ScopeCategory:
+		model = &categorydomain.Category{}
+	case stockthresholddomain.ScopeProduct:
+		model = &productdomain.Product{}
+	case stockthresholddomain.ScopeSKU:
+		model = &productdomain.ProductSKU{}
+	default:
+		return false, nil
+	}
+	var count int64
+	if err := s.db.Model(model).Where("id = ? AND deleted_at IS NULL", targetID).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
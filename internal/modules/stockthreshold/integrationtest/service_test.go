@@ -0,0 +1,88 @@
+package integrationtest
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	categorydomain "github.com/dujiao-next/internal/modules/catalog/category/domain"
+	productdomain "github.com/dujiao-next/internal/modules/catalog/product/domain"
+	stockthresholdapp "github.com/dujiao-next/internal/modules/stockthreshold/application"
+	stockthresholdcontract "github.com/dujiao-next/internal/modules/stockthreshold/contract"
+	stockthresholddomain "github.com/dujiao-next/internal/modules/stockthreshold/domain"
+	stockthresholdgormstore "github.com/dujiao-next/internal/modules/stockthreshold/infrastructure/gormstore"
+	"github.com/dujiao-next/internal/shared/jsonmap"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+)
+
+func newService(t *testing.T) (*stockthresholdapp.Service, *gorm.DB) {
+	t.Helper()
+	dsn := fmt.Sprintf("file:stock_threshold_%d?mode=memory&cache=shared", time.Now().UnixNano())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite failed: %v", err)
+	}
+	if err := db.AutoMigrate(&categorydomain.Category{}, &productdomain.Product{}, &productdomain.ProductSKU{}, &stockthresholddomain.Threshold{}); err != nil {
+		t.Fatalf("migrate failed: %v", err)
+	}
+	return stockthresholdapp.NewService(stockthresholdgormstore.New(db)), db
+}
+
+func TestSaveThresholdUpsertsAndClears(t *testing.T) {
+	service, db := newService(t)
+	category := &categorydomain.Category{Slug: "threshold", NameJSON: jsonmap.JSON{"zh-CN": "分类"}}
+	if err := db.Create(category).Error; err != nil {
+		t.Fatalf("create category: %v", err)
+	}
+
+	if _, err := service.Save(stockthresholdcontract.SaveInput{Scope: "brand", TargetID: category.ID, AlertDays: 3}); !errors.Is(err, stockthresholdcontract.ErrThresholdInvalid) {
+		t.Fatalf("unknown scope should be invalid, got %v", err)
+	}
+	if _, err := service.Save(stockthresholdcontract.SaveInput{Scope: stockthresholddomain.ScopeProduct, TargetID: 99, AlertDays: 3}); !errors.Is(err, stockthresholdcontract.ErrTargetNotFound) {
+		t.Fatalf("missing product should be rejected, got %v", err)
+	}
+
+	saved, err := service.Save(stockthresholdcontract.SaveInput{Scope: stockthresholddomain.ScopeCategory, TargetID: category.ID, AlertDays: 3})
+	if err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	updated, err := service.Save(stockthresholdcontract.SaveInput{Scope: stockthresholddomain.ScopeCategory, TargetID: category.ID, LowStockQuantity: 8, AlertDays: 5})
+	if err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if updated.ID != saved.ID || updated.AlertDays != 5 || updated.LowStockQuantity != 8 {
+		t.Fatalf("save should overwrite the same target, got %+v", updated)
+	}
+
+	if _, err := service.Save(stockthresholdcontract.SaveInput{Scope: stockthresholddomain.ScopeCategory, TargetID: category.ID}); err != nil {
+		t.Fatalf("clear: %v", err)
+	}
+	if _, total, _ := service.List(stockthresholdcontract.ListFilter{}); total != 0 {
+		t.Fatalf("zero values should clear the override, got %d", total)
+	}
+	if err := service.Delete(saved.ID); !errors.Is(err, stockthresholdcontract.ErrThresholdNotFound) {
+		t.Fatalf("delete cleared threshold should be not found, got %v", err)
+	}
+}
+
+func TestSetResolvesFieldsThroughInheritanceChain(t *testing.T) {
+	set := stockthresholddomain.NewSet([]stockthresholddomain.Threshold{
+		{Scope: stockthresholddomain.ScopeCategory, TargetID: 1, LowStockQuantity: 30, AlertDays: 10},
+		{Scope: stockthresholddomain.ScopeCategory, TargetID: 2, AlertDays: 4},
+		{Scope: stockthresholddomain.ScopeSKU, TargetID: 7, LowStockQuantity: 2},
+	}, map[uint]uint{2: 1})
+	fallback := stockthresholddomain.Values{LowStockQuantity: 5, AlertDays: 7}
+
+	if got := set.Resolve(fallback, 2, 3, 7); got.LowStockQuantity != 2 || got.AlertDays != 4 {
+		t.Fatalf("sku quantity and child category days expected, got %+v", got)
+	}
+	if got := set.Resolve(fallback, 2, 3, 0); got.LowStockQuantity != 30 || got.AlertDays != 4 {
+		t.Fatalf("parent category should fill quantity, got %+v", got)
+	}
+	if got := set.Resolve(fallback, 9, 3, 0); got != fallback {
+		t.Fatalf("unrelated category should use fallback, got %+v", got)
+	}
+}
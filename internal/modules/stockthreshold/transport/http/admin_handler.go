@@ -0,0 +1,95 @@
+package stockthresholdhttp
+
+import (
+	"errors"
+	"strings"
+
+	stockthresholdcontract "github.com/dujiao-next/internal/modules/stockthreshold/contract"
+	stockthresholddomain "github.com/dujiao-next/internal/modules/stockthreshold/domain"
+	"github.com/dujiao-next/internal/platform/http/ginutil"
+	"github.com/dujiao-next/internal/platform/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminService 是后台阈值管理所需的最小用例接口。
+type AdminService interface {
+	List(filter stockthresholdcontract.ListFilter) ([]stockthresholddomain.Threshold, int64, error)
+	Save(input stockthresholdcontract.SaveInput) (*stockthresholddomain.Threshold, error)
+	Delete(id uint) error
+}
+
+// AdminHandler 处理后台库存告警阈值请求。
+type AdminHandler struct {
+	service AdminService
+}
+
+func NewAdminHandler(service AdminService) *AdminHandler {
+	if service == nil {
+		panic("stock threshold admin handler: required dependency is nil")
+	}
+	return &AdminHandler{service: service}
+}
+
+// List 获取阈值覆盖列表（支持 scope、target_id 筛选）
+func (h *AdminHandler) List(c *gin.Context) {
+	targetID, err := ginutil.ParseQueryUint(c.Query("target_id"), false)
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", nil)
+		return
+	}
+	page, pageSize := ginutil.ParsePagination(c)
+	items, total, err := h.service.List(stockthresholdcontract.ListFilter{
+		Scope:    strings.TrimSpace(c.Query("scope")),
+		TargetID: targetID,
+		Page:     page,
+		PageSize: pageSize,
+	})
+	if err != nil {
+		ginutil.RespondError(c, response.CodeInternal, "error.stock_threshold_fetch_failed", err)
+		return
+	}
+	response.SuccessWithPage(c, items, response.BuildPagination(page, pageSize, total))
+}
+
+// Save 写入分类/商品/规格阈值，两项均为 0 时清除覆盖
+func (h *AdminHandler) Save(c *gin.Context) {
+	var req stockthresholdcontract.SaveInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ginutil.RespondBindError(c, err)
+		return
+	}
+	threshold, err := h.service.Save(req)
+	if err != nil {
+		respondThresholdError(c, err)
+		return
+	}
+	response.Success(c, threshold)
+}
+
+// Delete 删除阈值覆盖
+func (h *AdminHandler) Delete(c *gin.Context) {
+	id, err := ginutil.ParseParamUint(c, "id")
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", nil)
+		return
+	}
+	if err := h.service.Delete(id); err != nil {
+		respondThresholdError(c, err)
+		return
+	}
+	response.Success(c, nil)
+}
+
+func respondThresholdError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, stockthresholdcontract.ErrThresholdInvalid):
+		ginutil.RespondError(c, response.CodeBadRequest, "error.stock_threshold_invalid", nil)
+	case errors.Is(err, stockthresholdcontract.ErrTargetNotFound):
+		ginutil.RespondError(c, response.CodeNotFound, "error.stock_threshold_target_not_found", nil)
+	case errors.Is(err, stockthresholdcontract.ErrThresholdNotFound):
+		ginutil.RespondError(c, response.CodeNotFound, "error.stock_threshold_not_found", nil)
+	default:
+		ginutil.RespondError(c, response.CodeInternal, "error.stock_threshold_save_failed", err)
+	}
+}
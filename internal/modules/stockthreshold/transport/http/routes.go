@@ -0,0 +1,13 @@
+package stockthresholdhttp
+
+import "github.com/gin-gonic/gin"
+
+// RegisterAdminRoutes 注册后台库存告警阈值路由。
+func RegisterAdminRoutes(authorized gin.IRoutes, handler *AdminHandler) {
+	if authorized == nil || handler == nil {
+		panic("stock threshold admin routes: required dependency is nil")
+	}
+	authorized.GET("/stock-thresholds", handler.List)
+	authorized.PUT("/stock-thresholds", handler.Save)
+	authorized.DELETE("/stock-thresholds/:id", handler.Delete)
+}
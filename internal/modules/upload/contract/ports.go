@@ -25,3 +25,8 @@ type StoreInput struct {
 type Store interface {
 	Save(input StoreInput) (publicURL string, err error)
 }
+
+// Opener 读取已存储文件，供受控下载使用；key 为 scene/year/month/filename 形式的相对路径。
+type Opener interface {
+	Open(key string) (io.ReadSeekCloser, error)
+}
@@ -1,9 +1,11 @@
 package localstore
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"path"
 	"path/filepath"
 
 	"github.com/dujiao-next/internal/modules/upload/contract"
@@ -14,7 +16,10 @@ type Store struct {
 	root string
 }
 
-var _ contract.Store = (*Store)(nil)
+var (
+	_ contract.Store  = (*Store)(nil)
+	_ contract.Opener = (*Store)(nil)
+)
 
 // New 创建本地文件存储适配器。
 func New(root string) *Store {
@@ -44,3 +49,12 @@ func (s *Store) Save(input contract.StoreInput) (string, error) {
 	}
 	return fmt.Sprintf("/uploads/%s/%s/%s/%s", input.Scene, input.Year, input.Month, input.Filename), nil
 }
+
+// Open 按相对路径打开已存储文件，拒绝越出根目录的路径。
+func (s *Store) Open(key string) (io.ReadSeekCloser, error) {
+	cleaned := path.Clean("/" + filepath.ToSlash(key))
+	if cleaned == "/" {
+		return nil, errors.New("upload local store: key is empty")
+	}
+	return os.Open(filepath.Join(s.root, filepath.FromSlash(cleaned)))
+}
@@ -2,6 +2,7 @@ package localstore
 
 import (
 	"bytes"
+	"io"
 	"os"
 	"path/filepath"
 	"testing"
@@ -33,3 +34,35 @@ func TestStoreSavesFileUnderConfiguredRoot(t *testing.T) {
 		t.Fatalf("saved content got %q", data)
 	}
 }
+
+func TestStoreOpenStaysUnderRoot(t *testing.T) {
+	base := t.TempDir()
+	root := filepath.Join(base, "private")
+	store := New(root)
+	if _, err := store.Save(contract.StoreInput{
+		Source:   bytes.NewBufferString("ebook"),
+		Scene:    "fulfillment",
+		Year:     "2026",
+		Month:    "10",
+		Filename: "book.pdf",
+	}); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(base, "secret.txt"), []byte("outside"), 0o600); err != nil {
+		t.Fatalf("write outside file: %v", err)
+	}
+
+	file, err := store.Open("fulfillment/2026/10/book.pdf")
+	if err != nil {
+		t.Fatalf("open failed: %v", err)
+	}
+	data, _ := io.ReadAll(file)
+	_ = file.Close()
+	if string(data) != "ebook" {
+		t.Fatalf("opened content got %q", data)
+	}
+	if file, err := store.Open("../secret.txt"); err == nil {
+		_ = file.Close()
+		t.Fatalf("open must not escape the store root")
+	}
+}
@@ -13,4 +13,5 @@ func RegisterAuthenticatedRoutes(upstream gin.IRoutes, handler *Handler) {
 	upstream.POST("/orders", handler.CreateOrder)
 	upstream.GET("/orders/:id", handler.GetOrder)
 	upstream.POST("/orders/:id/cancel", handler.CancelOrder)
+	upstream.POST("/orders/:id/callback", handler.ResendCallback)
 }
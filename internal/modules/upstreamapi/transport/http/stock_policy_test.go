@@ -15,7 +15,7 @@ func TestComputeSKUStockUsesRemainingManualStockWithoutSubtractingLockedAgain(t
 		ManualStockLocked: 4,
 	}
 
-	status, quantity := computeSKUStock(product, sku)
+	status, quantity := computeSKUStock(product, sku, nil)
 	if quantity != 5 {
 		t.Fatalf("manual_stock_total already represents remaining stock; quantity want 5 got %d", quantity)
 	}
@@ -10,6 +10,7 @@ import (
 	"github.com/dujiao-next/internal/constants"
 	"github.com/dujiao-next/internal/logger"
 	domaincatalog "github.com/dujiao-next/internal/modules/catalog"
+	stockthresholddomain "github.com/dujiao-next/internal/modules/stockthreshold/domain"
 	"github.com/dujiao-next/internal/platform/http/ginutil"
 	"github.com/dujiao-next/internal/shared/jsonmap"
 	"github.com/dujiao-next/internal/shared/jsonslice"
@@ -133,9 +134,10 @@ func (h *Handler) ListProducts(c *gin.Context) {
 	// 批量解析映射商品的真实交付类型
 	fulfillmentTypeMap := h.resolveEffectiveFulfillmentTypes(products)
 
+	thresholds := domaincatalog.LoadStockThresholds(h.StockThresholds)
 	items := make([]upstreamProduct, 0, len(products))
 	for _, p := range products {
-		items = append(items, h.toUpstreamProductWithMemberPrice(p, memberLevelID, fulfillmentTypeMap))
+		items = append(items, h.toUpstreamProductWithMemberPrice(p, memberLevelID, fulfillmentTypeMap, thresholds))
 	}
 
 	successResponse(c, gin.H{
@@ -195,7 +197,7 @@ func (h *Handler) GetProduct(c *gin.Context) {
 
 	successResponse(c, gin.H{
 		"ok":      true,
-		"product": h.toUpstreamProductWithMemberPrice(products[0], memberLevelID, fulfillmentTypeMap),
+		"product": h.toUpstreamProductWithMemberPrice(products[0], memberLevelID, fulfillmentTypeMap, domaincatalog.LoadStockThresholds(h.StockThresholds)),
 	})
 }
 
@@ -247,13 +249,13 @@ func (h *Handler) resolveEffectiveFulfillmentTypes(products []productdomain.Prod
 	return result
 }
 
-func (h *Handler) toUpstreamProductWithMemberPrice(p productdomain.Product, memberLevelID uint, fulfillmentTypeMap map[uint]string) upstreamProduct {
+func (h *Handler) toUpstreamProductWithMemberPrice(p productdomain.Product, memberLevelID uint, fulfillmentTypeMap map[uint]string, thresholds *stockthresholddomain.Set) upstreamProduct {
 	skus := make([]upstreamSKU, 0, len(p.SKUs))
 	for _, s := range p.SKUs {
 		if !s.IsActive {
 			continue
 		}
-		stockStatus, stockQuantity := computeSKUStock(p, s)
+		stockStatus, stockQuantity := computeSKUStock(p, s, thresholds)
 		si := upstreamSKU{
 			ID:            s.ID,
 			SKUCode:       s.SKUCode,
@@ -279,6 +281,10 @@ func (h *Handler) toUpstreamProductWithMemberPrice(p productdomain.Product, memb
 	if ft, ok := fulfillmentTypeMap[p.ID]; ok {
 		effectiveFulfillmentType = ft
 	}
+	// webhook 商品对下游而言等同自动交付，内部发码细节不外露。
+	if effectiveFulfillmentType == constants.FulfillmentTypeWebhook {
+		effectiveFulfillmentType = constants.FulfillmentTypeAuto
+	}
 
 	result := upstreamProduct{
 		ID:               p.ID,
@@ -312,8 +318,8 @@ func (h *Handler) toUpstreamProductWithMemberPrice(p productdomain.Product, memb
 	return result
 }
 
-// computeSKUStock 计算 SKU 的库存状态和实际可用量
-func computeSKUStock(p productdomain.Product, s productdomain.ProductSKU) (status string, quantity int) {
+// computeSKUStock 计算 SKU 的库存状态和实际可用量，低库存阈值按分类/商品/规格覆盖解析
+func computeSKUStock(p productdomain.Product, s productdomain.ProductSKU, thresholds *stockthresholddomain.Set) (status string, quantity int) {
 	var available int64
 	switch p.FulfillmentType {
 	case constants.FulfillmentTypeManual:
@@ -321,8 +327,10 @@ func computeSKUStock(p productdomain.Product, s productdomain.ProductSKU) (statu
 		available = int64(s.ManualStockTotal)
 	case constants.FulfillmentTypeUpstream:
 		available = int64(s.UpstreamStock)
+	case constants.FulfillmentTypeWebhook, constants.FulfillmentTypeFile, constants.FulfillmentTypeLicense:
+		available = int64(constants.ManualStockUnlimited)
 	default:
 		available = s.AutoStockAvailable
 	}
-	return domaincatalog.UpstreamStockPolicy().Status(available), int(available)
+	return domaincatalog.UpstreamStockPolicy(thresholds, p.CategoryID, p.ID, s.ID).Status(available), int(available)
 }
@@ -1,6 +1,7 @@
 package upstreamhttp
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"time"
@@ -14,6 +15,7 @@ import (
 	procurementcontract "github.com/dujiao-next/internal/modules/procurement/contract"
 	procurementdomain "github.com/dujiao-next/internal/modules/procurement/domain"
 
+	domaincatalog "github.com/dujiao-next/internal/modules/catalog"
 	mappingdomain "github.com/dujiao-next/internal/modules/catalog/mapping/domain"
 
 	siteconnectiondomain "github.com/dujiao-next/internal/modules/siteconnection/domain"
@@ -45,6 +47,8 @@ var (
 	ErrSKUUnavailable        = errors.New("sku unavailable")
 	ErrInvalidOrderItem      = errors.New("invalid order item")
 	ErrManualFormInvalid     = errors.New("manual form invalid")
+	ErrCallbackURLMissing    = errors.New("callback url missing")
+	ErrCallbackTooFrequent   = errors.New("callback resend too frequent")
 )
 
 type CreateOrderItem struct {
@@ -137,6 +141,10 @@ type DownstreamOrderReferences interface {
 	GetByCredentialAndDownstreamNo(credentialID uint, downstreamOrderNo string) (*downstreamcallbackdomain.OrderRef, error)
 }
 
+type DownstreamCallbacks interface {
+	RequestResend(ctx context.Context, credentialID, orderID uint) (*downstreamcallbackdomain.OrderRef, error)
+}
+
 type SiteConnections interface {
 	GetByApiKey(apiKey string) (*siteconnectiondomain.Connection, error)
 }
@@ -160,8 +168,10 @@ type Dependencies struct {
 	Payments          Payments
 	Procurements      ProcurementOrders
 	DownstreamRefs    DownstreamOrderReferences
+	Callbacks         DownstreamCallbacks
 	Connections       SiteConnections
 	ConnectionSecrets SecretDecrypter
+	StockThresholds   domaincatalog.StockThresholdSource
 }
 
 type Handler struct {
@@ -173,8 +183,8 @@ func New(dependencies Dependencies) *Handler {
 		dependencies.ProductRepository == nil || dependencies.SKUs == nil || dependencies.ProductMappings == nil ||
 		dependencies.SKUMappings == nil || dependencies.MemberLevels == nil || dependencies.Settings == nil ||
 		dependencies.Wallet == nil || dependencies.Orders == nil || dependencies.Payments == nil ||
-		dependencies.Procurements == nil || dependencies.DownstreamRefs == nil || dependencies.Connections == nil ||
-		dependencies.ConnectionSecrets == nil {
+		dependencies.Procurements == nil || dependencies.DownstreamRefs == nil || dependencies.Callbacks == nil ||
+		dependencies.Connections == nil || dependencies.ConnectionSecrets == nil {
 		panic("upstream handler: required dependency is nil")
 	}
 	return &Handler{Dependencies: dependencies}
@@ -324,6 +324,43 @@ func (h *Handler) CancelOrder(c *gin.Context) {
 	})
 }
 
+// ResendCallback POST /api/v1/upstream/orders/:id/callback (下游请求重发本凭证订单的回调)
+func (h *Handler) ResendCallback(c *gin.Context) {
+	credentialID := getUpstreamCredentialID(c)
+	if credentialID == 0 {
+		errorResponse(c, http.StatusUnauthorized, "unauthorized", "invalid credentials")
+		return
+	}
+
+	orderID, err := ginutil.ParseParamUint(c, "id")
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "bad_request", "invalid order id")
+		return
+	}
+
+	ref, err := h.Callbacks.RequestResend(c.Request.Context(), credentialID, orderID)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrOrderNotFound):
+			errorResponse(c, http.StatusNotFound, "order_not_found", "order not found")
+		case errors.Is(err, ErrCallbackURLMissing):
+			errorResponse(c, http.StatusConflict, "callback_url_missing", "order has no callback url")
+		case errors.Is(err, ErrCallbackTooFrequent):
+			errorResponse(c, http.StatusTooManyRequests, "resend_too_frequent", "callback was sent recently, retry later")
+		default:
+			logger.Errorw("upstream_resend_callback_failed", "order_id", orderID, "credential_id", credentialID, "error", err)
+			errorResponse(c, http.StatusInternalServerError, "internal_error", "failed to resend callback")
+		}
+		return
+	}
+
+	successResponse(c, gin.H{
+		"ok":              true,
+		"order_id":        ref.OrderID,
+		"callback_status": ref.CallbackStatus,
+	})
+}
+
 func mapOrderErrorToResponse(c *gin.Context, err error) {
 	switch {
 	case errors.Is(err, ErrWalletInsufficient):
@@ -58,16 +58,14 @@ func (s *Service) CreditInTransaction(
 	}
 
 	now := time.Now()
-	account, err := ensureAccountForUpdate(repository, input.UserID, now)
+	ledger, err := s.lockLedgerAccount(repository, input.UserID, input.Currency, now)
 	if err != nil {
 		return nil, nil, err
 	}
-	before := account.Balance.Decimal.Round(2)
+	before := ledger.balance()
 	after := before.Add(amount).Round(2)
-	account.Balance = money.FromDecimal(after)
-	account.UpdatedAt = now
-	if err := repository.UpdateAccount(account); err != nil {
-		return nil, nil, walletcontract.ErrAccountUpdateFailed
+	if err := ledger.save(repository, after, now); err != nil {
+		return nil, nil, err
 	}
 
 	transaction := &walletdomain.Transaction{
@@ -81,7 +79,7 @@ func (s *Service) CreditInTransaction(
 	if err := repository.CreateTransaction(transaction); err != nil {
 		return nil, nil, walletcontract.ErrTransactionCreateFailed
 	}
-	return account, transaction, nil
+	return ledger.account, transaction, nil
 }
 
 func (s *Service) changeBalance(
@@ -162,3 +160,61 @@ func ensureAccountForUpdate(repository walletcontract.Repository, userID uint, n
 	}
 	return account, nil
 }
+
+// DebitInTransaction 在调用方事务内扣减余额；同一引用重复调用返回已有流水，余额不足时整体回滚。
+func (s *Service) DebitInTransaction(
+	tx walletcontract.Transaction,
+	input walletcontract.DebitInput,
+) (*walletdomain.Transaction, error) {
+	if tx == nil {
+		return nil, walletcontract.ErrTransactionRequired
+	}
+	if input.UserID == 0 {
+		return nil, walletcontract.ErrNotSupportedForGuest
+	}
+	amount := input.Amount.Decimal.Round(2)
+	if amount.LessThanOrEqual(decimal.Zero) {
+		return nil, walletcontract.ErrInvalidAmount
+	}
+	reference := strings.TrimSpace(input.Reference)
+	if reference == "" || strings.TrimSpace(input.Type) == "" {
+		return nil, walletcontract.ErrTransactionCreateFailed
+	}
+	repository := tx.Wallets()
+	if repository == nil {
+		return nil, walletcontract.ErrTransactionRequired
+	}
+	existing, err := repository.GetTransactionByReference(reference)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return existing, nil
+	}
+
+	now := time.Now()
+	ledger, err := s.lockLedgerAccount(repository, input.UserID, input.Currency, now)
+	if err != nil {
+		return nil, err
+	}
+	before := ledger.balance()
+	after := before.Sub(amount).Round(2)
+	if after.LessThan(decimal.Zero) {
+		return nil, walletcontract.ErrInsufficientBalance
+	}
+	if err := ledger.save(repository, after, now); err != nil {
+		return nil, err
+	}
+	transaction := &walletdomain.Transaction{
+		UserID: input.UserID, OrderID: input.OrderID,
+		Type: strings.TrimSpace(input.Type), Direction: constants.WalletTxnDirectionOut,
+		Amount: money.FromDecimal(amount), BalanceBefore: money.FromDecimal(before),
+		BalanceAfter: money.FromDecimal(after), Currency: normalizeCurrency(input.Currency),
+		Reference: reference, Remark: cleanRemark(input.Remark, "钱包扣款"),
+		CreatedAt: now, UpdatedAt: now,
+	}
+	if err := repository.CreateTransaction(transaction); err != nil {
+		return nil, walletcontract.ErrTransactionCreateFailed
+	}
+	return transaction, nil
+}
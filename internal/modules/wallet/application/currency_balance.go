@@ -0,0 +1,119 @@
+package application
+
+import (
+	"strings"
+	"time"
+
+	walletcontract "github.com/dujiao-next/internal/modules/wallet/contract"
+	walletdomain "github.com/dujiao-next/internal/modules/wallet/domain"
+	"github.com/dujiao-next/internal/shared/money"
+
+	"github.com/shopspring/decimal"
+)
+
+// ledgerAccount 是一次记账落到的余额：基准币种落在钱包主账户，其余币种落在对应币种余额上。
+type ledgerAccount struct {
+	account  *walletdomain.Account
+	currency *walletdomain.CurrencyBalance
+}
+
+func (l ledgerAccount) balance() decimal.Decimal {
+	if l.currency != nil {
+		return l.currency.Balance.Decimal.Round(2)
+	}
+	return l.account.Balance.Decimal.Round(2)
+}
+
+func (l ledgerAccount) save(repository walletcontract.Repository, balance decimal.Decimal, now time.Time) error {
+	if l.currency != nil {
+		l.currency.Balance = money.FromDecimal(balance)
+		l.currency.UpdatedAt = now
+		if err := repository.UpdateCurrencyBalance(l.currency); err != nil {
+			return walletcontract.ErrAccountUpdateFailed
+		}
+		return nil
+	}
+	l.account.Balance = money.FromDecimal(balance)
+	l.account.UpdatedAt = now
+	if err := repository.UpdateAccount(l.account); err != nil {
+		return walletcontract.ErrAccountUpdateFailed
+	}
+	return nil
+}
+
+// isBaseCurrency 未配置站点币种时视为单币种钱包，全部记在主账户。
+func (s *Service) isBaseCurrency(currency string) bool {
+	normalized := strings.ToUpper(strings.TrimSpace(currency))
+	if normalized == "" || s.currency == nil {
+		return true
+	}
+	base := strings.ToUpper(strings.TrimSpace(s.currency.SiteCurrency()))
+	return base == "" || normalized == base
+}
+
+// lockLedgerAccount 先锁主账户再锁币种余额，保证同一用户的记账按固定顺序加锁。
+func (s *Service) lockLedgerAccount(repository walletcontract.Repository, userID uint, currency string, now time.Time) (ledgerAccount, error) {
+	account, err := ensureAccountForUpdate(repository, userID, now)
+	if err != nil {
+		return ledgerAccount{}, err
+	}
+	if s.isBaseCurrency(currency) {
+		return ledgerAccount{account: account}, nil
+	}
+	balance, err := ensureCurrencyBalanceForUpdate(repository, userID, normalizeCurrency(currency), now)
+	if err != nil {
+		return ledgerAccount{}, err
+	}
+	return ledgerAccount{account: account, currency: balance}, nil
+}
+
+func ensureCurrencyBalanceForUpdate(repository walletcontract.Repository, userID uint, currency string, now time.Time) (*walletdomain.CurrencyBalance, error) {
+	balance, err := repository.GetCurrencyBalanceForUpdate(userID, currency)
+	if err != nil {
+		return nil, err
+	}
+	if balance != nil {
+		return balance, nil
+	}
+	balance = &walletdomain.CurrencyBalance{
+		UserID: userID, Currency: currency, Balance: money.FromDecimal(decimal.Zero), CreatedAt: now, UpdatedAt: now,
+	}
+	if err := repository.CreateCurrencyBalance(balance); err != nil {
+		created, queryErr := repository.GetCurrencyBalanceForUpdate(userID, currency)
+		if queryErr == nil && created != nil {
+			return created, nil
+		}
+		return nil, walletcontract.ErrAccountCreateFailed
+	}
+	return balance, nil
+}
+
+// GetAvailableBalance 查询指定币种的可用余额（基准币种取主账户余额）。
+func (s *Service) GetAvailableBalance(userID uint, currency string) (money.Amount, error) {
+	if s.isBaseCurrency(currency) {
+		account, err := s.GetAccount(userID)
+		if err != nil {
+			return money.Amount{}, err
+		}
+		return account.Balance, nil
+	}
+	if userID == 0 {
+		return money.Amount{}, walletcontract.ErrAccountNotFound
+	}
+	balance, err := s.repository.GetCurrencyBalance(userID, normalizeCurrency(currency))
+	if err != nil {
+		return money.Amount{}, err
+	}
+	if balance == nil {
+		return money.FromDecimal(decimal.Zero), nil
+	}
+	return balance.Balance, nil
+}
+
+// ListCurrencyBalances 查询用户的非基准币种余额。
+func (s *Service) ListCurrencyBalances(userID uint) ([]walletdomain.CurrencyBalance, error) {
+	if userID == 0 {
+		return nil, walletcontract.ErrAccountNotFound
+	}
+	return s.repository.ListCurrencyBalances(userID)
+}
@@ -34,11 +34,11 @@ func (s *Service) ApplyOrderBalance(tx walletcontract.Transaction, input walletc
 
 	repository := tx.Wallets()
 	now := time.Now()
-	account, err := ensureAccountForUpdate(repository, input.UserID, now)
+	ledger, err := s.lockLedgerAccount(repository, input.UserID, input.Currency, now)
 	if err != nil {
 		return money.Amount{}, err
 	}
-	available := account.Balance.Decimal.Round(2)
+	available := ledger.balance()
 	if available.LessThanOrEqual(decimal.Zero) {
 		return money.FromDecimal(decimal.Zero), nil
 	}
@@ -55,15 +55,13 @@ func (s *Service) ApplyOrderBalance(tx walletcontract.Transaction, input walletc
 		return existing.Amount, nil
 	}
 
-	before := account.Balance.Decimal.Round(2)
+	before := available
 	after := before.Sub(deduct).Round(2)
 	if after.LessThan(decimal.Zero) {
 		return money.Amount{}, walletcontract.ErrInsufficientBalance
 	}
-	account.Balance = money.FromDecimal(after)
-	account.UpdatedAt = now
-	if err := repository.UpdateAccount(account); err != nil {
-		return money.Amount{}, walletcontract.ErrAccountUpdateFailed
+	if err := ledger.save(repository, after, now); err != nil {
+		return money.Amount{}, err
 	}
 	orderID := input.OrderID
 	transaction := &walletdomain.Transaction{
@@ -116,16 +114,14 @@ func (s *Service) ReleaseOrderBalance(
 			return money.FromDecimal(decimal.Zero), nil
 		}
 	}
-	account, err := ensureAccountForUpdate(repository, input.UserID, now)
+	ledger, err := s.lockLedgerAccount(repository, input.UserID, input.Currency, now)
 	if err != nil {
 		return money.Amount{}, err
 	}
-	before := account.Balance.Decimal.Round(2)
+	before := ledger.balance()
 	after := before.Add(amount).Round(2)
-	account.Balance = money.FromDecimal(after)
-	account.UpdatedAt = now
-	if err := repository.UpdateAccount(account); err != nil {
-		return money.Amount{}, walletcontract.ErrAccountUpdateFailed
+	if err := ledger.save(repository, after, now); err != nil {
+		return money.Amount{}, err
 	}
 	orderID := input.OrderID
 	transaction := &walletdomain.Transaction{
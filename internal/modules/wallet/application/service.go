@@ -1,6 +1,8 @@
 package application
 
 import (
+	crand "crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"strings"
 	"time"
@@ -13,17 +15,34 @@ const defaultCurrency = "CNY"
 type Options struct {
 	Repository   walletcontract.Repository
 	Transactions walletcontract.UnitOfWork
+	// Recipients/TwoFactor/Settings 仅转账与提现使用，可为空（对应功能视为关闭）。
+	Recipients walletcontract.RecipientDirectory
+	TwoFactor  walletcontract.TwoFactorVerifier
+	Settings   walletcontract.FundsSettingsReader
+	// Currency 为空时钱包按单币种记账；配置后非站点币种的订单余额独立记账。
+	Currency walletcontract.CurrencyProvider
 }
 
 type Service struct {
 	repository   walletcontract.Repository
 	transactions walletcontract.UnitOfWork
+	recipients   walletcontract.RecipientDirectory
+	twoFactor    walletcontract.TwoFactorVerifier
+	settings     walletcontract.FundsSettingsReader
+	currency     walletcontract.CurrencyProvider
 }
 
 var _ walletcontract.UseCase = (*Service)(nil)
 
 func NewService(options Options) *Service {
-	return &Service{repository: options.Repository, transactions: options.Transactions}
+	return &Service{
+		repository:   options.Repository,
+		transactions: options.Transactions,
+		recipients:   options.Recipients,
+		twoFactor:    options.TwoFactor,
+		settings:     options.Settings,
+		currency:     options.Currency,
+	}
 }
 
 func normalizeCurrency(currency string) string {
@@ -57,3 +76,16 @@ func uniqueReference(prefix string, id uint) string {
 	}
 	return fmt.Sprintf("%s:%d:%d", normalized, id, time.Now().UnixNano())
 }
+
+// generateFundsNo 生成转账/提现单号：前缀 + 时间 + 随机串。
+func generateFundsNo(prefix string, now time.Time) string {
+	buf := make([]byte, 4)
+	if _, err := crand.Read(buf); err != nil {
+		return fmt.Sprintf("%s%s%08d", prefix, now.Format("20060102150405"), now.Nanosecond()%100000000)
+	}
+	return strings.ToUpper(fmt.Sprintf("%s%s%s", prefix, now.Format("20060102150405"), hex.EncodeToString(buf)))
+}
+
+func startOfDay(now time.Time) time.Time {
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+}
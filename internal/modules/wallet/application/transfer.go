@@ -0,0 +1,289 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dujiao-next/internal/constants"
+	userdomain "github.com/dujiao-next/internal/modules/identity/user/domain"
+	settingsintegration "github.com/dujiao-next/internal/modules/settings/schema/integration"
+	walletcontract "github.com/dujiao-next/internal/modules/wallet/contract"
+	walletdomain "github.com/dujiao-next/internal/modules/wallet/domain"
+	"github.com/dujiao-next/internal/shared/money"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	transferNoPrefix    = "WT"
+	fundsRemarkMaxLen   = 255
+	fundsReferenceLimit = 120
+)
+
+// Transfer 用户间余额转账：校验两步验证与每日限额后，在同一事务内扣减转出方（含手续费）并入账收款方。
+func (s *Service) Transfer(input walletcontract.TransferInput) (*walletdomain.Transfer, error) {
+	if s.transactions == nil {
+		return nil, walletcontract.ErrTransactionRequired
+	}
+	if input.FromUserID == 0 {
+		return nil, walletcontract.ErrAccountNotFound
+	}
+	setting, err := s.fundsSetting()
+	if err != nil {
+		return nil, err
+	}
+	if !setting.TransferEnabled {
+		return nil, walletcontract.ErrTransferDisabled
+	}
+	amount := input.Amount.Decimal.Round(2)
+	if amount.LessThanOrEqual(decimal.Zero) {
+		return nil, walletcontract.ErrInvalidAmount
+	}
+	if amount.LessThan(decimal.NewFromFloat(setting.TransferMinAmount)) {
+		return nil, walletcontract.ErrAmountBelowMinimum
+	}
+	recipient, err := s.resolveRecipient(input.Recipient)
+	if err != nil {
+		return nil, err
+	}
+	if recipient.ID == input.FromUserID {
+		return nil, walletcontract.ErrTransferToSelf
+	}
+	if err := s.verifyTwoFactor(input.FromUserID, input.TwoFactorCode); err != nil {
+		return nil, err
+	}
+
+	fee := transferFee(amount, setting)
+	currency := normalizeCurrency(input.Currency)
+	remark := truncateFundsText(input.Remark, fundsRemarkMaxLen)
+	now := time.Now()
+	transfer := &walletdomain.Transfer{
+		TransferNo: generateFundsNo(transferNoPrefix, now),
+		FromUserID: input.FromUserID,
+		ToUserID:   recipient.ID,
+		Amount:     money.FromDecimal(amount),
+		Fee:        money.FromDecimal(fee),
+		Currency:   currency,
+		Remark:     remark,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if err := s.transactions.WithinTransaction(func(tx walletcontract.Transaction) error {
+		repository := tx.Wallets()
+		sender, receiver, err := lockTransferAccounts(repository, input.FromUserID, recipient.ID, now)
+		if err != nil {
+			return err
+		}
+		if err := checkTransferDailyLimit(repository, input.FromUserID, amount, setting, now); err != nil {
+			return err
+		}
+		if sender.Balance.Decimal.Round(2).LessThan(amount.Add(fee)) {
+			return walletcontract.ErrInsufficientBalance
+		}
+
+		out, err := postLedgerEntry(repository, sender, ledgerEntry{
+			Type: constants.WalletTxnTypeTransferOut, Direction: constants.WalletTxnDirectionOut,
+			Amount: amount, Currency: currency,
+			Reference: transferReference(transfer.TransferNo, "out"),
+			Remark:    cleanRemark(remark, fmt.Sprintf("转账给用户 #%d", recipient.ID)),
+		}, now)
+		if err != nil {
+			return err
+		}
+		transfer.OutTransactionID = out.ID
+		if fee.GreaterThan(decimal.Zero) {
+			feeTxn, err := postLedgerEntry(repository, sender, ledgerEntry{
+				Type: constants.WalletTxnTypeTransferFee, Direction: constants.WalletTxnDirectionOut,
+				Amount: fee, Currency: currency,
+				Reference: transferReference(transfer.TransferNo, "fee"),
+				Remark:    "转账手续费",
+			}, now)
+			if err != nil {
+				return err
+			}
+			transfer.FeeTransactionID = &feeTxn.ID
+		}
+		in, err := postLedgerEntry(repository, receiver, ledgerEntry{
+			Type: constants.WalletTxnTypeTransferIn, Direction: constants.WalletTxnDirectionIn,
+			Amount: amount, Currency: currency,
+			Reference: transferReference(transfer.TransferNo, "in"),
+			Remark:    cleanRemark(remark, fmt.Sprintf("来自用户 #%d 的转账", input.FromUserID)),
+		}, now)
+		if err != nil {
+			return err
+		}
+		transfer.InTransactionID = in.ID
+		return repository.CreateTransfer(transfer)
+	}); err != nil {
+		return nil, err
+	}
+	return transfer, nil
+}
+
+// ListTransfers 查询用户作为转出方或收款方的转账记录。
+func (s *Service) ListTransfers(filter walletcontract.TransferListFilter) ([]walletdomain.Transfer, int64, error) {
+	if s.repository == nil {
+		return []walletdomain.Transfer{}, 0, nil
+	}
+	return s.repository.ListTransfers(filter)
+}
+
+func (s *Service) fundsSetting() (settingsintegration.WalletFundsSetting, error) {
+	if s.settings == nil {
+		return settingsintegration.DefaultWalletFundsSetting(), nil
+	}
+	return s.settings.GetWalletFundsSetting()
+}
+
+// resolveRecipient 纯数字按用户 ID 解析，否则按邮箱解析；停用账号不可收款。
+func (s *Service) resolveRecipient(raw string) (*userdomain.User, error) {
+	recipient := strings.TrimSpace(raw)
+	if recipient == "" || s.recipients == nil {
+		return nil, walletcontract.ErrTransferRecipientNotFound
+	}
+	var (
+		user *userdomain.User
+		err  error
+	)
+	if id, parseErr := strconv.ParseUint(recipient, 10, 64); parseErr == nil {
+		user, err = s.recipients.GetByID(uint(id))
+	} else {
+		user, err = s.recipients.GetByEmail(strings.ToLower(recipient))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || user.ID == 0 || strings.EqualFold(user.Status, constants.UserStatusDisabled) {
+		return nil, walletcontract.ErrTransferRecipientNotFound
+	}
+	return user, nil
+}
+
+func (s *Service) verifyTwoFactor(userID uint, code string) error {
+	if s.twoFactor == nil {
+		return walletcontract.ErrTwoFactorRequired
+	}
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return walletcontract.ErrTwoFactorInvalid
+	}
+	if err := s.twoFactor.VerifyTwoFactor(userID, code); err != nil {
+		if errors.Is(err, walletcontract.ErrTwoFactorRequired) || errors.Is(err, walletcontract.ErrTwoFactorInvalid) {
+			return err
+		}
+		return fmt.Errorf("verify two factor: %w", err)
+	}
+	return nil
+}
+
+// lockTransferAccounts 按用户 ID 升序加锁，避免双向转账并发时死锁。
+func lockTransferAccounts(
+	repository walletcontract.Repository,
+	fromUserID, toUserID uint,
+	now time.Time,
+) (*walletdomain.Account, *walletdomain.Account, error) {
+	firstID, secondID := fromUserID, toUserID
+	if firstID > secondID {
+		firstID, secondID = secondID, firstID
+	}
+	first, err := ensureAccountForUpdate(repository, firstID, now)
+	if err != nil {
+		return nil, nil, err
+	}
+	second, err := ensureAccountForUpdate(repository, secondID, now)
+	if err != nil {
+		return nil, nil, err
+	}
+	if first.UserID == fromUserID {
+		return first, second, nil
+	}
+	return second, first, nil
+}
+
+func checkTransferDailyLimit(
+	repository walletcontract.Repository,
+	userID uint,
+	amount decimal.Decimal,
+	setting settingsintegration.WalletFundsSetting,
+	now time.Time,
+) error {
+	if setting.TransferDailyLimit <= 0 && setting.TransferDailyCount <= 0 {
+		return nil
+	}
+	total, count, err := repository.SumTransactionsSince(userID, constants.WalletTxnTypeTransferOut, startOfDay(now))
+	if err != nil {
+		return err
+	}
+	if setting.TransferDailyCount > 0 && count+1 > int64(setting.TransferDailyCount) {
+		return walletcontract.ErrTransferLimitExceeded
+	}
+	if setting.TransferDailyLimit > 0 && total.Add(amount).GreaterThan(decimal.NewFromFloat(setting.TransferDailyLimit)) {
+		return walletcontract.ErrTransferLimitExceeded
+	}
+	return nil
+}
+
+// transferFee 按比例计算手续费，低于最低手续费时取最低值。
+func transferFee(amount decimal.Decimal, setting settingsintegration.WalletFundsSetting) decimal.Decimal {
+	fee := amount.Mul(decimal.NewFromFloat(setting.TransferFeePercent)).Div(decimal.NewFromInt(100)).Round(2)
+	if minFee := decimal.NewFromFloat(setting.TransferMinFee).Round(2); fee.LessThan(minFee) {
+		fee = minFee
+	}
+	return fee
+}
+
+type ledgerEntry struct {
+	Type      string
+	Direction string
+	Amount    decimal.Decimal
+	Currency  string
+	Reference string
+	Remark    string
+}
+
+// postLedgerEntry 变更账户可用余额并写入流水；调用方须已持有账户行锁。
+func postLedgerEntry(
+	repository walletcontract.Repository,
+	account *walletdomain.Account,
+	entry ledgerEntry,
+	now time.Time,
+) (*walletdomain.Transaction, error) {
+	before := account.Balance.Decimal.Round(2)
+	after := before.Add(entry.Amount).Round(2)
+	if entry.Direction == constants.WalletTxnDirectionOut {
+		after = before.Sub(entry.Amount).Round(2)
+	}
+	if after.LessThan(decimal.Zero) {
+		return nil, walletcontract.ErrInsufficientBalance
+	}
+	account.Balance = money.FromDecimal(after)
+	account.UpdatedAt = now
+	if err := repository.UpdateAccount(account); err != nil {
+		return nil, walletcontract.ErrAccountUpdateFailed
+	}
+	transaction := &walletdomain.Transaction{
+		UserID: account.UserID, Type: entry.Type, Direction: entry.Direction,
+		Amount: money.FromDecimal(entry.Amount), BalanceBefore: money.FromDecimal(before),
+		BalanceAfter: money.FromDecimal(after), Currency: entry.Currency,
+		Reference: entry.Reference, Remark: entry.Remark, CreatedAt: now, UpdatedAt: now,
+	}
+	if err := repository.CreateTransaction(transaction); err != nil {
+		return nil, walletcontract.ErrTransactionCreateFailed
+	}
+	return transaction, nil
+}
+
+func transferReference(transferNo, leg string) string {
+	return fmt.Sprintf("transfer:%s:%s", transferNo, leg)
+}
+
+func truncateFundsText(raw string, limit int) string {
+	value := strings.TrimSpace(raw)
+	if runes := []rune(value); len(runes) > limit {
+		return string(runes[:limit])
+	}
+	return value
+}
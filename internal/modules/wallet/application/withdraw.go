@@ -0,0 +1,233 @@
+package application
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dujiao-next/internal/constants"
+	walletcontract "github.com/dujiao-next/internal/modules/wallet/contract"
+	walletdomain "github.com/dujiao-next/internal/modules/wallet/domain"
+	"github.com/dujiao-next/internal/shared/money"
+
+	"github.com/shopspring/decimal"
+)
+
+const withdrawNoPrefix = "WW"
+
+// ApplyWithdraw 用户申请提现：可用余额转入冻结余额并记一笔冻结流水，等待后台审核。
+func (s *Service) ApplyWithdraw(input walletcontract.WithdrawApplyInput) (*walletdomain.WithdrawRequest, error) {
+	if s.transactions == nil {
+		return nil, walletcontract.ErrTransactionRequired
+	}
+	if input.UserID == 0 {
+		return nil, walletcontract.ErrAccountNotFound
+	}
+	setting, err := s.fundsSetting()
+	if err != nil {
+		return nil, err
+	}
+	if !setting.WithdrawEnabled {
+		return nil, walletcontract.ErrWithdrawDisabled
+	}
+	amount := input.Amount.Decimal.Round(2)
+	if amount.LessThanOrEqual(decimal.Zero) {
+		return nil, walletcontract.ErrInvalidAmount
+	}
+	if amount.LessThan(decimal.NewFromFloat(setting.WithdrawMinAmount)) {
+		return nil, walletcontract.ErrAmountBelowMinimum
+	}
+	channel := strings.TrimSpace(input.Channel)
+	account := truncateFundsText(input.Account, fundsRemarkMaxLen)
+	if channel == "" || account == "" || !withdrawChannelAllowed(channel, setting.WithdrawChannels) {
+		return nil, walletcontract.ErrWithdrawChannelInvalid
+	}
+
+	now := time.Now()
+	request := &walletdomain.WithdrawRequest{
+		WithdrawNo: generateFundsNo(withdrawNoPrefix, now),
+		UserID:     input.UserID,
+		Amount:     money.FromDecimal(amount),
+		Currency:   normalizeCurrency(input.Currency),
+		Channel:    channel,
+		Account:    account,
+		Status:     constants.WalletWithdrawStatusPending,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if err := s.transactions.WithinTransaction(func(tx walletcontract.Transaction) error {
+		repository := tx.Wallets()
+		wallet, err := ensureAccountForUpdate(repository, input.UserID, now)
+		if err != nil {
+			return err
+		}
+		if setting.WithdrawDailyLimit > 0 {
+			total, _, err := repository.SumTransactionsSince(input.UserID, constants.WalletTxnTypeWithdrawFreeze, startOfDay(now))
+			if err != nil {
+				return err
+			}
+			if total.Add(amount).GreaterThan(decimal.NewFromFloat(setting.WithdrawDailyLimit)) {
+				return walletcontract.ErrWithdrawLimitExceeded
+			}
+		}
+		if wallet.Balance.Decimal.Round(2).LessThan(amount) {
+			return walletcontract.ErrInsufficientBalance
+		}
+		withdrawable, err := rechargedWithdrawable(repository, wallet, request.Currency)
+		if err != nil {
+			return err
+		}
+		if withdrawable.LessThan(amount) {
+			return walletcontract.ErrWithdrawExceedsRecharged
+		}
+		wallet.LockedBalance = money.FromDecimal(wallet.LockedBalance.Decimal.Add(amount).Round(2))
+		freeze, err := postLedgerEntry(repository, wallet, ledgerEntry{
+			Type: constants.WalletTxnTypeWithdrawFreeze, Direction: constants.WalletTxnDirectionOut,
+			Amount: amount, Currency: request.Currency,
+			Reference: withdrawReference(request.WithdrawNo, "freeze"),
+			Remark:    "余额提现申请冻结",
+		}, now)
+		if err != nil {
+			return err
+		}
+		request.FreezeTransactionID = freeze.ID
+		return repository.CreateWithdrawRequest(request)
+	}); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// rechargedWithdrawable 按流水计算可提现额度：仅充值入账可提现，消费、转出等支出优先占用充值资金，
+// 礼品卡、退款、转入、后台加款等入账不计入；驳回解冻的金额回到额度中。
+func rechargedWithdrawable(repository walletcontract.Repository, wallet *walletdomain.Account, currency string) (decimal.Decimal, error) {
+	credits, err := repository.SumTransactionsByType(wallet.UserID, currency, constants.WalletTxnDirectionIn)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	debits, err := repository.SumTransactionsByType(wallet.UserID, currency, constants.WalletTxnDirectionOut)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	withdrawable := credits[constants.WalletTxnTypeRecharge].Add(credits[constants.WalletTxnTypeWithdrawRelease])
+	for _, total := range debits {
+		withdrawable = withdrawable.Sub(total)
+	}
+	if withdrawable.LessThan(decimal.Zero) {
+		return decimal.Zero, nil
+	}
+	return decimal.Min(withdrawable, wallet.Balance.Decimal).Round(2), nil
+}
+
+// ListWithdrawRequests 查询提现申请（用户端按 UserID 过滤）。
+func (s *Service) ListWithdrawRequests(filter walletcontract.WithdrawListFilter) ([]walletdomain.WithdrawRequest, int64, error) {
+	if s.repository == nil {
+		return []walletdomain.WithdrawRequest{}, 0, nil
+	}
+	filter.Status = strings.TrimSpace(filter.Status)
+	filter.WithdrawNo = strings.TrimSpace(filter.WithdrawNo)
+	return s.repository.ListWithdrawRequests(filter)
+}
+
+// RejectWithdraw 后台驳回提现：解冻金额退回可用余额并记解冻流水。
+func (s *Service) RejectWithdraw(input walletcontract.WithdrawReviewInput) (*walletdomain.WithdrawRequest, error) {
+	return s.reviewWithdraw(input, func(repository walletcontract.Repository, wallet *walletdomain.Account, request *walletdomain.WithdrawRequest, now time.Time) error {
+		release, err := postLedgerEntry(repository, wallet, ledgerEntry{
+			Type: constants.WalletTxnTypeWithdrawRelease, Direction: constants.WalletTxnDirectionIn,
+			Amount: request.Amount.Decimal.Round(2), Currency: request.Currency,
+			Reference: withdrawReference(request.WithdrawNo, "release"),
+			Remark:    "余额提现驳回退回",
+		}, now)
+		if err != nil {
+			return err
+		}
+		request.Status = constants.WalletWithdrawStatusRejected
+		request.RejectReason = truncateFundsText(input.Reason, fundsRemarkMaxLen)
+		request.ReleaseTransactionID = &release.ID
+		return nil
+	})
+}
+
+// PayWithdraw 后台确认已线下打款：扣除冻结余额并记录打款流水号。
+func (s *Service) PayWithdraw(input walletcontract.WithdrawReviewInput) (*walletdomain.WithdrawRequest, error) {
+	return s.reviewWithdraw(input, func(repository walletcontract.Repository, wallet *walletdomain.Account, request *walletdomain.WithdrawRequest, now time.Time) error {
+		wallet.UpdatedAt = now
+		if err := repository.UpdateAccount(wallet); err != nil {
+			return walletcontract.ErrAccountUpdateFailed
+		}
+		request.Status = constants.WalletWithdrawStatusPaid
+		request.PayoutReference = truncateFundsText(input.PayoutReference, fundsReferenceLimit)
+		return nil
+	})
+}
+
+// reviewWithdraw 锁定待审核申请与账户，先扣减冻结余额再执行具体审核动作。
+func (s *Service) reviewWithdraw(
+	input walletcontract.WithdrawReviewInput,
+	apply func(repository walletcontract.Repository, wallet *walletdomain.Account, request *walletdomain.WithdrawRequest, now time.Time) error,
+) (*walletdomain.WithdrawRequest, error) {
+	if s.transactions == nil {
+		return nil, walletcontract.ErrTransactionRequired
+	}
+	if input.WithdrawID == 0 {
+		return nil, walletcontract.ErrWithdrawNotFound
+	}
+	var result *walletdomain.WithdrawRequest
+	if err := s.transactions.WithinTransaction(func(tx walletcontract.Transaction) error {
+		repository := tx.Wallets()
+		request, err := repository.GetWithdrawRequestByIDForUpdate(input.WithdrawID)
+		if err != nil {
+			return err
+		}
+		if request == nil {
+			return walletcontract.ErrWithdrawNotFound
+		}
+		if request.Status != constants.WalletWithdrawStatusPending {
+			return walletcontract.ErrWithdrawStatusInvalid
+		}
+		now := time.Now()
+		wallet, err := ensureAccountForUpdate(repository, request.UserID, now)
+		if err != nil {
+			return err
+		}
+		amount := request.Amount.Decimal.Round(2)
+		locked := wallet.LockedBalance.Decimal.Round(2)
+		if locked.LessThan(amount) {
+			return fmt.Errorf("%w: locked balance %s below withdraw amount %s", walletcontract.ErrWithdrawStatusInvalid, locked.StringFixed(2), amount.StringFixed(2))
+		}
+		wallet.LockedBalance = money.FromDecimal(locked.Sub(amount).Round(2))
+		if err := apply(repository, wallet, request, now); err != nil {
+			return err
+		}
+		if input.AdminID != 0 {
+			adminID := input.AdminID
+			request.ProcessedBy = &adminID
+		}
+		request.ProcessedAt = &now
+		request.UpdatedAt = now
+		if err := repository.UpdateWithdrawRequest(request); err != nil {
+			return err
+		}
+		result = request
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func withdrawChannelAllowed(channel string, allowed []string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	for _, item := range allowed {
+		if strings.EqualFold(strings.TrimSpace(item), channel) {
+			return true
+		}
+	}
+	return false
+}
+
+func withdrawReference(withdrawNo, action string) string {
+	return fmt.Sprintf("wallet_withdraw:%s:%s", withdrawNo, action)
+}
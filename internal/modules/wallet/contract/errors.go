@@ -3,16 +3,29 @@ package contract
 import "errors"
 
 var (
-	ErrInvalidAmount           = errors.New("wallet invalid amount")
-	ErrInsufficientBalance     = errors.New("wallet insufficient balance")
-	ErrAccountNotFound         = errors.New("wallet account not found")
-	ErrAccountCreateFailed     = errors.New("wallet account create failed")
-	ErrAccountUpdateFailed     = errors.New("wallet account update failed")
-	ErrTransactionCreateFailed = errors.New("wallet transaction create failed")
-	ErrRefundExceeded          = errors.New("wallet refund exceeded")
-	ErrNotSupportedForGuest    = errors.New("wallet not supported for guest")
-	ErrRechargeNotFound        = errors.New("wallet recharge not found")
-	ErrRechargeStatusInvalid   = errors.New("wallet recharge status invalid")
-	ErrOnlyPaymentRequired     = errors.New("wallet only payment required")
-	ErrTransactionRequired     = errors.New("wallet transaction required")
+	ErrInvalidAmount             = errors.New("wallet invalid amount")
+	ErrInsufficientBalance       = errors.New("wallet insufficient balance")
+	ErrAccountNotFound           = errors.New("wallet account not found")
+	ErrAccountCreateFailed       = errors.New("wallet account create failed")
+	ErrAccountUpdateFailed       = errors.New("wallet account update failed")
+	ErrTransactionCreateFailed   = errors.New("wallet transaction create failed")
+	ErrRefundExceeded            = errors.New("wallet refund exceeded")
+	ErrNotSupportedForGuest      = errors.New("wallet not supported for guest")
+	ErrRechargeNotFound          = errors.New("wallet recharge not found")
+	ErrRechargeStatusInvalid     = errors.New("wallet recharge status invalid")
+	ErrOnlyPaymentRequired       = errors.New("wallet only payment required")
+	ErrTransactionRequired       = errors.New("wallet transaction required")
+	ErrAmountBelowMinimum        = errors.New("wallet amount below minimum")
+	ErrTransferDisabled          = errors.New("wallet transfer disabled")
+	ErrTransferRecipientNotFound = errors.New("wallet transfer recipient not found")
+	ErrTransferToSelf            = errors.New("wallet transfer to self")
+	ErrTransferLimitExceeded     = errors.New("wallet transfer daily limit exceeded")
+	ErrTwoFactorRequired         = errors.New("wallet two factor required")
+	ErrTwoFactorInvalid          = errors.New("wallet two factor code invalid")
+	ErrWithdrawDisabled          = errors.New("wallet withdraw disabled")
+	ErrWithdrawChannelInvalid    = errors.New("wallet withdraw channel invalid")
+	ErrWithdrawLimitExceeded     = errors.New("wallet withdraw daily limit exceeded")
+	ErrWithdrawNotFound          = errors.New("wallet withdraw not found")
+	ErrWithdrawStatusInvalid     = errors.New("wallet withdraw status invalid")
+	ErrWithdrawExceedsRecharged  = errors.New("wallet withdraw exceeds recharged balance")
 )
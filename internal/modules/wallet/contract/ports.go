@@ -3,8 +3,12 @@ package contract
 import (
 	"time"
 
+	userdomain "github.com/dujiao-next/internal/modules/identity/user/domain"
+	settingsintegration "github.com/dujiao-next/internal/modules/settings/schema/integration"
 	walletdomain "github.com/dujiao-next/internal/modules/wallet/domain"
 	"github.com/dujiao-next/internal/shared/money"
+
+	"github.com/shopspring/decimal"
 )
 
 // Repository owns all wallet aggregate persistence. Transactional callers
@@ -17,9 +21,25 @@ type Repository interface {
 	UpdateAccount(account *walletdomain.Account) error
 	ListAccounts(filter AccountListFilter) ([]walletdomain.Account, int64, error)
 
+	GetCurrencyBalance(userID uint, currency string) (*walletdomain.CurrencyBalance, error)
+	GetCurrencyBalanceForUpdate(userID uint, currency string) (*walletdomain.CurrencyBalance, error)
+	CreateCurrencyBalance(balance *walletdomain.CurrencyBalance) error
+	UpdateCurrencyBalance(balance *walletdomain.CurrencyBalance) error
+	ListCurrencyBalances(userID uint) ([]walletdomain.CurrencyBalance, error)
+
 	CreateTransaction(transaction *walletdomain.Transaction) error
 	GetTransactionByReference(reference string) (*walletdomain.Transaction, error)
 	ListTransactions(filter TransactionListFilter) ([]walletdomain.Transaction, int64, error)
+	SumTransactionsSince(userID uint, transactionType string, since time.Time) (decimal.Decimal, int64, error)
+	SumTransactionsByType(userID uint, currency, direction string) (map[string]decimal.Decimal, error)
+
+	CreateTransfer(transfer *walletdomain.Transfer) error
+	ListTransfers(filter TransferListFilter) ([]walletdomain.Transfer, int64, error)
+
+	CreateWithdrawRequest(request *walletdomain.WithdrawRequest) error
+	UpdateWithdrawRequest(request *walletdomain.WithdrawRequest) error
+	GetWithdrawRequestByIDForUpdate(id uint) (*walletdomain.WithdrawRequest, error)
+	ListWithdrawRequests(filter WithdrawListFilter) ([]walletdomain.WithdrawRequest, int64, error)
 
 	CreateRechargeOrder(order *walletdomain.RechargeOrder) error
 	UpdateRechargeOrder(order *walletdomain.RechargeOrder) error
@@ -42,8 +62,31 @@ type UnitOfWork interface {
 	WithinTransaction(fn func(Transaction) error) error
 }
 
+// RecipientDirectory 解析转账收款用户（按用户 ID 或邮箱）。
+type RecipientDirectory interface {
+	GetByID(id uint) (*userdomain.User, error)
+	GetByEmail(email string) (*userdomain.User, error)
+}
+
+// TwoFactorVerifier 校验用户 TOTP 动态码；未开启两步验证时返回 ErrTwoFactorRequired。
+type TwoFactorVerifier interface {
+	VerifyTwoFactor(userID uint, code string) error
+}
+
+// FundsSettingsReader 读取转账与提现的后台配置。
+type FundsSettingsReader interface {
+	GetWalletFundsSetting() (settingsintegration.WalletFundsSetting, error)
+}
+
+// CurrencyProvider 读取站点基准币种；基准币种余额记在主账户，其余币种各自独立记账。
+type CurrencyProvider interface {
+	SiteCurrency() string
+}
+
 type UseCase interface {
 	GetAccount(userID uint) (*walletdomain.Account, error)
+	GetAvailableBalance(userID uint, currency string) (money.Amount, error)
+	ListCurrencyBalances(userID uint) ([]walletdomain.CurrencyBalance, error)
 	ListTransactions(filter TransactionListFilter) ([]walletdomain.Transaction, int64, error)
 	ListRechargeOrdersAdmin(filter RechargeListFilter) ([]walletdomain.RechargeOrder, int64, error)
 	ListUserRechargeOrders(userID uint, page, pageSize int, status, rechargeNo string) ([]walletdomain.RechargeOrder, int64, error)
@@ -55,9 +98,17 @@ type UseCase interface {
 	Recharge(input RechargeInput) (*walletdomain.Account, *walletdomain.Transaction, error)
 	AdminAdjustBalance(input AdjustBalanceInput) (*walletdomain.Account, *walletdomain.Transaction, error)
 	CreditInTransaction(tx Transaction, input CreditInput) (*walletdomain.Account, *walletdomain.Transaction, error)
+	DebitInTransaction(tx Transaction, input DebitInput) (*walletdomain.Transaction, error)
 	ApplyRechargePayment(tx Transaction, recharge *walletdomain.RechargeOrder) (*walletdomain.Transaction, error)
 	ApplyOrderBalance(tx Transaction, input OrderBalanceInput) (money.Amount, error)
 	ReleaseOrderBalance(tx Transaction, input OrderReleaseInput, claim ReleaseClaim) (money.Amount, error)
+
+	Transfer(input TransferInput) (*walletdomain.Transfer, error)
+	ListTransfers(filter TransferListFilter) ([]walletdomain.Transfer, int64, error)
+	ApplyWithdraw(input WithdrawApplyInput) (*walletdomain.WithdrawRequest, error)
+	ListWithdrawRequests(filter WithdrawListFilter) ([]walletdomain.WithdrawRequest, int64, error)
+	RejectWithdraw(input WithdrawReviewInput) (*walletdomain.WithdrawRequest, error)
+	PayWithdraw(input WithdrawReviewInput) (*walletdomain.WithdrawRequest, error)
 }
 
 // ReleaseClaim atomically clears the order-side wallet allocation before the
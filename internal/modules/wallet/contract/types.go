@@ -65,6 +65,17 @@ type CreditInput struct {
 	OrderID   *uint
 }
 
+// DebitInput 在调用方事务内按幂等引用扣减余额。
+type DebitInput struct {
+	UserID    uint
+	Amount    money.Amount
+	Currency  string
+	Type      string
+	Reference string
+	Remark    string
+	OrderID   *uint
+}
+
 type OrderBalanceInput struct {
 	OrderID          uint
 	UserID           uint
@@ -83,3 +94,44 @@ type OrderReleaseInput struct {
 	TransactionType  string
 	Remark           string
 }
+
+type TransferListFilter struct {
+	Page     int
+	PageSize int
+	UserID   uint // 转出或转入任一方
+}
+
+type WithdrawListFilter struct {
+	Page        int
+	PageSize    int
+	UserID      uint
+	Status      string
+	WithdrawNo  string
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+}
+
+// TransferInput 用户间转账；Recipient 可为收款用户 ID 或邮箱。
+type TransferInput struct {
+	FromUserID    uint
+	Recipient     string
+	Amount        money.Amount
+	Currency      string
+	Remark        string
+	TwoFactorCode string
+}
+
+type WithdrawApplyInput struct {
+	UserID   uint
+	Amount   money.Amount
+	Currency string
+	Channel  string
+	Account  string
+}
+
+type WithdrawReviewInput struct {
+	WithdrawID      uint
+	AdminID         uint
+	Reason          string
+	PayoutReference string
+}
@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/dujiao-next/internal/shared/money"
+)
+
+// CurrencyBalance 用户钱包的非基准币种余额；基准币种余额仍记在 Account 上。
+type CurrencyBalance struct {
+	ID        uint         `gorm:"primarykey" json:"id"`
+	UserID    uint         `gorm:"not null;uniqueIndex:idx_wallet_currency_balance_user_currency" json:"user_id"`
+	Currency  string       `gorm:"type:varchar(16);not null;uniqueIndex:idx_wallet_currency_balance_user_currency" json:"currency"`
+	Balance   money.Amount `gorm:"type:decimal(20,2);not null;default:0" json:"balance"`
+	CreatedAt time.Time    `gorm:"index" json:"created_at"`
+	UpdatedAt time.Time    `gorm:"index" json:"updated_at"`
+}
+
+func (CurrencyBalance) TableName() string {
+	return "wallet_currency_balances"
+}
@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/dujiao-next/internal/shared/money"
+)
+
+// Transfer 用户间余额转账记录；手续费由转出方额外承担。
+type Transfer struct {
+	ID               uint         `gorm:"primarykey" json:"id"`
+	TransferNo       string       `gorm:"type:varchar(40);uniqueIndex;not null" json:"transfer_no"`
+	FromUserID       uint         `gorm:"index;not null" json:"from_user_id"`
+	ToUserID         uint         `gorm:"index;not null" json:"to_user_id"`
+	Amount           money.Amount `gorm:"type:decimal(20,2);not null" json:"amount"`
+	Fee              money.Amount `gorm:"type:decimal(20,2);not null;default:0" json:"fee"`
+	Currency         string       `gorm:"type:varchar(16);not null;default:'CNY'" json:"currency"`
+	Remark           string       `gorm:"type:varchar(255)" json:"remark"`
+	OutTransactionID uint         `gorm:"index" json:"out_transaction_id"`
+	InTransactionID  uint         `gorm:"index" json:"in_transaction_id"`
+	FeeTransactionID *uint        `gorm:"index" json:"fee_transaction_id,omitempty"`
+	CreatedAt        time.Time    `gorm:"index" json:"created_at"`
+	UpdatedAt        time.Time    `gorm:"index" json:"updated_at"`
+}
+
+func (Transfer) TableName() string {
+	return "wallet_transfers"
+}
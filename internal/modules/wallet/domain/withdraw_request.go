@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/dujiao-next/internal/shared/money"
+)
+
+// WithdrawRequest 钱包余额提现申请；审核期间金额冻结在 Account.LockedBalance。
+type WithdrawRequest struct {
+	ID                   uint         `gorm:"primarykey" json:"id"`
+	WithdrawNo           string       `gorm:"type:varchar(40);uniqueIndex;not null" json:"withdraw_no"`
+	UserID               uint         `gorm:"index;not null" json:"user_id"`
+	Amount               money.Amount `gorm:"type:decimal(20,2);not null" json:"amount"`
+	Currency             string       `gorm:"type:varchar(16);not null;default:'CNY'" json:"currency"`
+	Channel              string       `gorm:"type:varchar(32);not null" json:"channel"`
+	Account              string       `gorm:"type:varchar(255);not null" json:"account"`
+	Status               string       `gorm:"type:varchar(20);index;not null" json:"status"`
+	RejectReason         string       `gorm:"type:varchar(255)" json:"reject_reason"`
+	PayoutReference      string       `gorm:"type:varchar(120)" json:"payout_reference"`
+	FreezeTransactionID  uint         `gorm:"index" json:"freeze_transaction_id"`
+	ReleaseTransactionID *uint        `gorm:"index" json:"release_transaction_id,omitempty"`
+	ProcessedBy          *uint        `gorm:"index" json:"processed_by,omitempty"`
+	ProcessedAt          *time.Time   `gorm:"index" json:"processed_at"`
+	CreatedAt            time.Time    `gorm:"index" json:"created_at"`
+	UpdatedAt            time.Time    `gorm:"index" json:"updated_at"`
+}
+
+func (WithdrawRequest) TableName() string {
+	return "wallet_withdraw_requests"
+}
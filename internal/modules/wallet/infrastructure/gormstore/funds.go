@@ -0,0 +1,167 @@
+package gormstore
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	walletcontract "github.com/dujiao-next/internal/modules/wallet/contract"
+	walletdomain "github.com/dujiao-next/internal/modules/wallet/domain"
+	"github.com/dujiao-next/internal/persistence/gormutil"
+
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SumTransactionsSince 统计用户自 since 起指定类型流水的金额合计与笔数（用于每日限额）。
+func (s *Store) SumTransactionsSince(userID uint, transactionType string, since time.Time) (decimal.Decimal, int64, error) {
+	if userID == 0 || strings.TrimSpace(transactionType) == "" {
+		return decimal.Zero, 0, nil
+	}
+	var row struct {
+		Total decimal.Decimal `gorm:"column:total"`
+		Count int64           `gorm:"column:cnt"`
+	}
+	if err := s.db.Model(&walletdomain.Transaction{}).
+		Where("user_id = ? AND type = ? AND created_at >= ? AND deleted_at IS NULL", userID, transactionType, since).
+		Select("COALESCE(SUM(amount), 0) AS total, COUNT(*) AS cnt").
+		Scan(&row).Error; err != nil {
+		return decimal.Zero, 0, err
+	}
+	return row.Total.Round(2), row.Count, nil
+}
+
+// SumTransactionsByType 按流水类型汇总用户指定币种、方向的累计金额（用于计算可提现额度）。
+func (s *Store) SumTransactionsByType(userID uint, currency, direction string) (map[string]decimal.Decimal, error) {
+	totals := map[string]decimal.Decimal{}
+	if userID == 0 {
+		return totals, nil
+	}
+	var rows []struct {
+		Type  string          `gorm:"column:type"`
+		Total decimal.Decimal `gorm:"column:total"`
+	}
+	if err := s.db.Model(&walletdomain.Transaction{}).
+		Where("user_id = ? AND currency = ? AND direction = ? AND deleted_at IS NULL", userID, currency, direction).
+		Select("type, COALESCE(SUM(amount), 0) AS total").
+		Group("type").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		totals[row.Type] = row.Total.Round(2)
+	}
+	return totals, nil
+}
+
+func (s *Store) CreateTransfer(transfer *walletdomain.Transfer) error {
+	return s.db.Create(transfer).Error
+}
+
+func (s *Store) ListTransfers(filter walletcontract.TransferListFilter) ([]walletdomain.Transfer, int64, error) {
+	query := s.db.Model(&walletdomain.Transfer{})
+	if filter.UserID != 0 {
+		query = query.Where("from_user_id = ? OR to_user_id = ?", filter.UserID, filter.UserID)
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var transfers []walletdomain.Transfer
+	if err := gormutil.ApplyPagination(query, filter.Page, filter.PageSize).Order("id desc").Find(&transfers).Error; err != nil {
+		return nil, 0, err
+	}
+	return transfers, total, nil
+}
+
+func (s *Store) CreateWithdrawRequest(request *walletdomain.WithdrawRequest) error {
+	return s.db.Create(request).Error
+}
+
+func (s *Store) UpdateWithdrawRequest(request *walletdomain.WithdrawRequest) error {
+	return s.db.Save(request).Error
+}
+
+func (s *Store) GetWithdrawRequestByIDForUpdate(id uint) (*walletdomain.WithdrawRequest, error) {
+	if id == 0 {
+		return nil, nil
+	}
+	var request walletdomain.WithdrawRequest
+	if err := s.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&request, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &request, nil
+}
+
+func (s *Store) ListWithdrawRequests(filter walletcontract.WithdrawListFilter) ([]walletdomain.WithdrawRequest, int64, error) {
+	query := s.db.Model(&walletdomain.WithdrawRequest{})
+	if filter.UserID != 0 {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.WithdrawNo != "" {
+		query = query.Where("withdraw_no = ?", filter.WithdrawNo)
+	}
+	if filter.CreatedFrom != nil {
+		query = query.Where("created_at >= ?", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		query = query.Where("created_at <= ?", *filter.CreatedTo)
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var requests []walletdomain.WithdrawRequest
+	if err := gormutil.ApplyPagination(query, filter.Page, filter.PageSize).Order("id desc").Find(&requests).Error; err != nil {
+		return nil, 0, err
+	}
+	return requests, total, nil
+}
+
+func (s *Store) GetCurrencyBalance(userID uint, currency string) (*walletdomain.CurrencyBalance, error) {
+	return s.findCurrencyBalance(s.db, userID, currency)
+}
+
+func (s *Store) GetCurrencyBalanceForUpdate(userID uint, currency string) (*walletdomain.CurrencyBalance, error) {
+	return s.findCurrencyBalance(s.db.Clauses(clause.Locking{Strength: "UPDATE"}), userID, currency)
+}
+
+func (s *Store) findCurrencyBalance(query *gorm.DB, userID uint, currency string) (*walletdomain.CurrencyBalance, error) {
+	if userID == 0 || strings.TrimSpace(currency) == "" {
+		return nil, nil
+	}
+	var balance walletdomain.CurrencyBalance
+	if err := query.Where("user_id = ? AND currency = ?", userID, currency).First(&balance).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &balance, nil
+}
+
+func (s *Store) CreateCurrencyBalance(balance *walletdomain.CurrencyBalance) error {
+	return s.db.Create(balance).Error
+}
+
+func (s *Store) UpdateCurrencyBalance(balance *walletdomain.CurrencyBalance) error {
+	return s.db.Save(balance).Error
+}
+
+func (s *Store) ListCurrencyBalances(userID uint) ([]walletdomain.CurrencyBalance, error) {
+	if userID == 0 {
+		return []walletdomain.CurrencyBalance{}, nil
+	}
+	var balances []walletdomain.CurrencyBalance
+	if err := s.db.Where("user_id = ?", userID).Order("currency asc").Find(&balances).Error; err != nil {
+		return nil, err
+	}
+	return balances, nil
+}
@@ -0,0 +1,37 @@
+package usertotp
+
+import (
+	"errors"
+
+	totpapplication "github.com/dujiao-next/internal/modules/identity/totp/application"
+	usertotpapp "github.com/dujiao-next/internal/modules/identity/userauth/totp/application"
+	walletcontract "github.com/dujiao-next/internal/modules/wallet/contract"
+)
+
+// Verifier 将用户 TOTP 校验适配为钱包两步验证端口。
+type Verifier struct {
+	totp *usertotpapp.Service
+}
+
+var _ walletcontract.TwoFactorVerifier = Verifier{}
+
+func New(totp *usertotpapp.Service) Verifier {
+	return Verifier{totp: totp}
+}
+
+func (verifier Verifier) VerifyTwoFactor(userID uint, code string) error {
+	if verifier.totp == nil {
+		return walletcontract.ErrTwoFactorRequired
+	}
+	err := verifier.totp.VerifyChallengeCode(userID, code)
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, totpapplication.ErrNotEnabled), errors.Is(err, usertotpapp.ErrNotFound):
+		return walletcontract.ErrTwoFactorRequired
+	case errors.Is(err, totpapplication.ErrCodeInvalid):
+		return walletcontract.ErrTwoFactorInvalid
+	default:
+		return err
+	}
+}
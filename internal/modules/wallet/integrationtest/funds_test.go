@@ -0,0 +1,304 @@
+package integrationtest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dujiao-next/internal/constants"
+	userdomain "github.com/dujiao-next/internal/modules/identity/user/domain"
+	settingsintegration "github.com/dujiao-next/internal/modules/settings/schema/integration"
+	walletapp "github.com/dujiao-next/internal/modules/wallet/application"
+	walletcontract "github.com/dujiao-next/internal/modules/wallet/contract"
+	walletdomain "github.com/dujiao-next/internal/modules/wallet/domain"
+	"github.com/dujiao-next/internal/shared/money"
+
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+type fundsRecipientsStub map[uint]*userdomain.User
+
+func (stub fundsRecipientsStub) GetByID(id uint) (*userdomain.User, error) { return stub[id], nil }
+
+func (stub fundsRecipientsStub) GetByEmail(email string) (*userdomain.User, error) {
+	for _, user := range stub {
+		if user.Email == email {
+			return user, nil
+		}
+	}
+	return nil, nil
+}
+
+type fundsTwoFactorStub struct{ code string }
+
+func (stub fundsTwoFactorStub) VerifyTwoFactor(_ uint, code string) error {
+	if code != stub.code {
+		return walletcontract.ErrTwoFactorInvalid
+	}
+	return nil
+}
+
+type fundsSettingsStub struct {
+	setting settingsintegration.WalletFundsSetting
+}
+
+func (stub fundsSettingsStub) GetWalletFundsSetting() (settingsintegration.WalletFundsSetting, error) {
+	return stub.setting, nil
+}
+
+func setupWalletFundsTest(t *testing.T, setting settingsintegration.WalletFundsSetting) (*walletapp.Service, *gorm.DB) {
+	t.Helper()
+	repo, db := setupWalletRepositoryTest(t)
+	if err := db.AutoMigrate(&walletdomain.Transfer{}, &walletdomain.WithdrawRequest{}); err != nil {
+		t.Fatalf("auto migrate funds tables failed: %v", err)
+	}
+	recipients := fundsRecipientsStub{
+		1: {ID: 1, Email: "sender@example.com", Status: constants.UserStatusActive},
+		2: {ID: 2, Email: "receiver@example.com", Status: constants.UserStatusActive},
+		3: {ID: 3, Email: "disabled@example.com", Status: constants.UserStatusDisabled},
+	}
+	service := walletapp.NewService(walletapp.Options{
+		Repository: repo, Transactions: repo,
+		Recipients: recipients, TwoFactor: fundsTwoFactorStub{code: "123456"},
+		Settings: fundsSettingsStub{setting: setting},
+	})
+	if _, _, err := service.Recharge(walletcontract.RechargeInput{
+		UserID: 1, Amount: money.FromDecimal(decimal.NewFromInt(100)), Currency: "CNY",
+	}); err != nil {
+		t.Fatalf("seed balance failed: %v", err)
+	}
+	return service, db
+}
+
+func walletBalance(t *testing.T, service *walletapp.Service, userID uint) (string, string) {
+	t.Helper()
+	account, err := service.GetAccount(userID)
+	if err != nil {
+		t.Fatalf("get account %d: %v", userID, err)
+	}
+	return account.Balance.String(), account.LockedBalance.String()
+}
+
+func TestWalletTransferChargesFeeAndEnforcesDailyLimits(t *testing.T) {
+	service, db := setupWalletFundsTest(t, settingsintegration.NormalizeWalletFundsSetting(settingsintegration.WalletFundsSetting{
+		TransferEnabled:    true,
+		TransferFeePercent: 1,
+		TransferMinFee:     0.5,
+		TransferDailyLimit: 50,
+		TransferDailyCount: 2,
+	}))
+	input := walletcontract.TransferInput{
+		FromUserID: 1, Recipient: "receiver@example.com",
+		Amount: money.FromDecimal(decimal.NewFromInt(30)), Currency: "CNY", TwoFactorCode: "123456",
+	}
+
+	if _, err := service.Transfer(walletcontract.TransferInput{
+		FromUserID: 1, Recipient: "2", Amount: input.Amount, TwoFactorCode: "000000",
+	}); !errors.Is(err, walletcontract.ErrTwoFactorInvalid) {
+		t.Fatalf("wrong totp code err = %v, want ErrTwoFactorInvalid", err)
+	}
+	if _, err := service.Transfer(walletcontract.TransferInput{
+		FromUserID: 1, Recipient: "3", Amount: input.Amount, TwoFactorCode: "123456",
+	}); !errors.Is(err, walletcontract.ErrTransferRecipientNotFound) {
+		t.Fatalf("disabled recipient err = %v, want ErrTransferRecipientNotFound", err)
+	}
+	if _, err := service.Transfer(walletcontract.TransferInput{
+		FromUserID: 1, Recipient: "sender@example.com", Amount: input.Amount, TwoFactorCode: "123456",
+	}); !errors.Is(err, walletcontract.ErrTransferToSelf) {
+		t.Fatalf("self transfer err = %v, want ErrTransferToSelf", err)
+	}
+
+	transfer, err := service.Transfer(input)
+	if err != nil {
+		t.Fatalf("transfer failed: %v", err)
+	}
+	if transfer.ToUserID != 2 || transfer.Fee.String() != "0.50" || transfer.FeeTransactionID == nil {
+		t.Fatalf("unexpected transfer: to=%d fee=%s fee_txn=%v", transfer.ToUserID, transfer.Fee.String(), transfer.FeeTransactionID)
+	}
+	if balance, _ := walletBalance(t, service, 1); balance != "69.50" {
+		t.Fatalf("sender balance = %s, want 69.50", balance)
+	}
+	if balance, _ := walletBalance(t, service, 2); balance != "30.00" {
+		t.Fatalf("receiver balance = %s, want 30.00", balance)
+	}
+
+	if _, err := service.Transfer(input); !errors.Is(err, walletcontract.ErrTransferLimitExceeded) {
+		t.Fatalf("over daily amount err = %v, want ErrTransferLimitExceeded", err)
+	}
+	var count int64
+	if err := db.Model(&walletdomain.Transaction{}).Where("type IN ?", []string{
+		constants.WalletTxnTypeTransferOut, constants.WalletTxnTypeTransferIn, constants.WalletTxnTypeTransferFee,
+	}).Count(&count).Error; err != nil {
+		t.Fatalf("count transfer transactions: %v", err)
+	}
+	if count != 3 {
+		t.Fatalf("transfer transactions = %d, want 3 (failed attempts must not write ledger)", count)
+	}
+}
+
+func TestWalletWithdrawFreezesAndSettlesLockedBalance(t *testing.T) {
+	service, _ := setupWalletFundsTest(t, settingsintegration.NormalizeWalletFundsSetting(settingsintegration.WalletFundsSetting{
+		WithdrawEnabled:   true,
+		WithdrawMinAmount: 10,
+		WithdrawChannels:  []string{"alipay"},
+	}))
+	apply := func(amount int64, channel string) (*walletdomain.WithdrawRequest, error) {
+		return service.ApplyWithdraw(walletcontract.WithdrawApplyInput{
+			UserID: 1, Amount: money.FromDecimal(decimal.NewFromInt(amount)), Currency: "CNY",
+			Channel: channel, Account: "sender@alipay",
+		})
+	}
+
+	if _, err := apply(40, "bank"); !errors.Is(err, walletcontract.ErrWithdrawChannelInvalid) {
+		t.Fatalf("unknown channel err = %v, want ErrWithdrawChannelInvalid", err)
+	}
+	if _, err := apply(5, "alipay"); !errors.Is(err, walletcontract.ErrAmountBelowMinimum) {
+		t.Fatalf("below minimum err = %v, want ErrAmountBelowMinimum", err)
+	}
+	if _, err := apply(101, "alipay"); !errors.Is(err, walletcontract.ErrInsufficientBalance) {
+		t.Fatalf("over balance err = %v, want ErrInsufficientBalance", err)
+	}
+
+	rejected, err := apply(40, "alipay")
+	if err != nil {
+		t.Fatalf("apply withdraw failed: %v", err)
+	}
+	paid, err := apply(25, "alipay")
+	if err != nil {
+		t.Fatalf("apply second withdraw failed: %v", err)
+	}
+	if balance, locked := walletBalance(t, service, 1); balance != "35.00" || locked != "65.00" {
+		t.Fatalf("after apply balance=%s locked=%s, want 35.00/65.00", balance, locked)
+	}
+
+	if _, err := service.RejectWithdraw(walletcontract.WithdrawReviewInput{WithdrawID: rejected.ID, AdminID: 9, Reason: "account mismatch"}); err != nil {
+		t.Fatalf("reject withdraw failed: %v", err)
+	}
+	settled, err := service.PayWithdraw(walletcontract.WithdrawReviewInput{WithdrawID: paid.ID, AdminID: 9, PayoutReference: "ALI-001"})
+	if err != nil {
+		t.Fatalf("pay withdraw failed: %v", err)
+	}
+	if settled.Status != constants.WalletWithdrawStatusPaid || settled.PayoutReference != "ALI-001" || settled.ProcessedBy == nil {
+		t.Fatalf("unexpected paid withdraw: %+v", settled)
+	}
+	if balance, locked := walletBalance(t, service, 1); balance != "75.00" || locked != "0.00" {
+		t.Fatalf("after review balance=%s locked=%s, want 75.00/0.00", balance, locked)
+	}
+	if _, err := service.PayWithdraw(walletcontract.WithdrawReviewInput{WithdrawID: rejected.ID, AdminID: 9}); !errors.Is(err, walletcontract.ErrWithdrawStatusInvalid) {
+		t.Fatalf("pay rejected withdraw err = %v, want ErrWithdrawStatusInvalid", err)
+	}
+}
+
+func TestWalletWithdrawLimitedToRechargedFunds(t *testing.T) {
+	service, _ := setupWalletFundsTest(t, settingsintegration.NormalizeWalletFundsSetting(settingsintegration.WalletFundsSetting{
+		TransferEnabled:    true,
+		TransferFeePercent: 1,
+		TransferMinFee:     0.5,
+		WithdrawEnabled:    true,
+		WithdrawMinAmount:  1,
+		WithdrawChannels:   []string{"alipay"},
+	}))
+	if _, _, err := service.AdminAdjustBalance(walletcontract.AdjustBalanceInput{
+		UserID: 1, OperatorAdminID: 9, Delta: money.FromDecimal(decimal.NewFromInt(50)), Currency: "CNY",
+	}); err != nil {
+		t.Fatalf("admin credit failed: %v", err)
+	}
+	if _, err := service.Transfer(walletcontract.TransferInput{
+		FromUserID: 1, Recipient: "2", Amount: money.FromDecimal(decimal.NewFromInt(30)), Currency: "CNY", TwoFactorCode: "123456",
+	}); err != nil {
+		t.Fatalf("transfer failed: %v", err)
+	}
+	apply := func(userID uint, amount string) (*walletdomain.WithdrawRequest, error) {
+		return service.ApplyWithdraw(walletcontract.WithdrawApplyInput{
+			UserID: userID, Amount: money.FromDecimal(decimal.RequireFromString(amount)), Currency: "CNY",
+			Channel: "alipay", Account: "user@alipay",
+		})
+	}
+
+	// 余额 119.50 = 充值 100 + 后台加款 50 - 转出 30.50，可提现仅为充值部分扣除支出后的 69.50。
+	if _, err := apply(1, "80"); !errors.Is(err, walletcontract.ErrWithdrawExceedsRecharged) {
+		t.Fatalf("withdraw above recharged funds err = %v, want ErrWithdrawExceedsRecharged", err)
+	}
+	first, err := apply(1, "60")
+	if err != nil {
+		t.Fatalf("withdraw within recharged funds failed: %v", err)
+	}
+	if _, err := apply(1, "10"); !errors.Is(err, walletcontract.ErrWithdrawExceedsRecharged) {
+		t.Fatalf("second withdraw err = %v, want ErrWithdrawExceedsRecharged", err)
+	}
+	if _, err := service.RejectWithdraw(walletcontract.WithdrawReviewInput{WithdrawID: first.ID, AdminID: 9}); err != nil {
+		t.Fatalf("reject withdraw failed: %v", err)
+	}
+	if _, err := apply(1, "69.50"); err != nil {
+		t.Fatalf("withdraw after release failed: %v", err)
+	}
+
+	// 转入资金不可提现。
+	if _, err := apply(2, "20"); !errors.Is(err, walletcontract.ErrWithdrawExceedsRecharged) {
+		t.Fatalf("withdraw transfer-in funds err = %v, want ErrWithdrawExceedsRecharged", err)
+	}
+	if balance, locked := walletBalance(t, service, 2); balance != "30.00" || locked != "0.00" {
+		t.Fatalf("receiver balance=%s locked=%s, want 30.00/0.00", balance, locked)
+	}
+}
+
+type fundsCurrencyStub struct{}
+
+func (fundsCurrencyStub) SiteCurrency() string { return "CNY" }
+
+func TestWalletOrderBalanceKeepsForeignCurrencySeparate(t *testing.T) {
+	repo, db := setupWalletRepositoryTest(t)
+	if err := db.AutoMigrate(&walletdomain.CurrencyBalance{}); err != nil {
+		t.Fatalf("auto migrate currency balances failed: %v", err)
+	}
+	service := walletapp.NewService(walletapp.Options{Repository: repo, Transactions: repo, Currency: fundsCurrencyStub{}})
+	if _, _, err := service.Recharge(walletcontract.RechargeInput{
+		UserID: 1, Amount: money.FromDecimal(decimal.NewFromInt(100)), Currency: "CNY",
+	}); err != nil {
+		t.Fatalf("seed balance failed: %v", err)
+	}
+
+	release := func(orderID uint, amount int64) {
+		t.Helper()
+		if err := repo.WithinTransaction(func(tx walletcontract.Transaction) error {
+			_, err := service.ReleaseOrderBalance(tx, walletcontract.OrderReleaseInput{
+				OrderID: orderID, UserID: 1, WalletPaidAmount: money.FromDecimal(decimal.NewFromInt(amount)),
+				Currency: "USD", TransactionType: constants.WalletTxnTypeOrderRefund,
+			}, nil)
+			return err
+		}); err != nil {
+			t.Fatalf("release usd balance: %v", err)
+		}
+	}
+	release(1, 20)
+
+	usd, err := service.GetAvailableBalance(1, "usd")
+	if err != nil || usd.String() != "20.00" {
+		t.Fatalf("expected usd balance 20.00, got %s err=%v", usd.String(), err)
+	}
+	if balance, _ := walletBalance(t, service, 1); balance != "100.00" {
+		t.Fatalf("base balance must stay untouched, got %s", balance)
+	}
+
+	var paid money.Amount
+	if err := repo.WithinTransaction(func(tx walletcontract.Transaction) error {
+		var err error
+		paid, err = service.ApplyOrderBalance(tx, walletcontract.OrderBalanceInput{
+			OrderID: 2, UserID: 1, TotalAmount: money.FromDecimal(decimal.NewFromInt(30)),
+			Currency: "USD", UseBalance: true,
+		})
+		return err
+	}); err != nil {
+		t.Fatalf("apply usd balance: %v", err)
+	}
+	if paid.String() != "20.00" {
+		t.Fatalf("expected usd wallet pay 20.00, got %s", paid.String())
+	}
+	balances, err := service.ListCurrencyBalances(1)
+	if err != nil || len(balances) != 1 || !balances[0].Balance.Decimal.IsZero() {
+		t.Fatalf("unexpected currency balances: %+v err=%v", balances, err)
+	}
+	if balance, _ := walletBalance(t, service, 1); balance != "100.00" {
+		t.Fatalf("base balance must stay untouched after usd pay, got %s", balance)
+	}
+}
@@ -0,0 +1,338 @@
+package wallethttp
+
+import (
+	"errors"
+	"io"
+	"strings"
+
+	walletdomain "github.com/dujiao-next/internal/modules/wallet/domain"
+	walletpresenter "github.com/dujiao-next/internal/modules/wallet/transport/presenter"
+
+	"github.com/dujiao-next/internal/constants"
+	"github.com/dujiao-next/internal/platform/http/ginutil"
+	"github.com/dujiao-next/internal/platform/http/response"
+	"github.com/dujiao-next/internal/shared/money"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrAmountBelowMinimum        = errors.New("wallet amount below minimum")
+	ErrTransferDisabled          = errors.New("wallet transfer disabled")
+	ErrTransferRecipientNotFound = errors.New("wallet transfer recipient not found")
+	ErrTransferToSelf            = errors.New("wallet transfer to self")
+	ErrTransferLimitExceeded     = errors.New("wallet transfer daily limit exceeded")
+	ErrTwoFactorRequired         = errors.New("wallet two factor required")
+	ErrTwoFactorInvalid          = errors.New("wallet two factor code invalid")
+	ErrWithdrawDisabled          = errors.New("wallet withdraw disabled")
+	ErrWithdrawChannelInvalid    = errors.New("wallet withdraw channel invalid")
+	ErrWithdrawLimitExceeded     = errors.New("wallet withdraw daily limit exceeded")
+	ErrWithdrawNotFound          = errors.New("wallet withdraw not found")
+	ErrWithdrawStatusInvalid     = errors.New("wallet withdraw status invalid")
+	ErrWithdrawExceedsRecharged  = errors.New("wallet withdraw exceeds recharged balance")
+)
+
+// FundsService 是余额转账与提现所需的最小端口。
+type FundsService interface {
+	Transfer(input TransferInput) (*walletdomain.Transfer, error)
+	ListTransfers(userID uint, page, pageSize int) ([]walletdomain.Transfer, int64, error)
+	ApplyWithdraw(input WithdrawApplyInput) (*walletdomain.WithdrawRequest, error)
+	ListWithdraws(filter WithdrawListFilter) ([]walletdomain.WithdrawRequest, int64, error)
+	RejectWithdraw(input WithdrawReviewInput) (*walletdomain.WithdrawRequest, error)
+	PayWithdraw(input WithdrawReviewInput) (*walletdomain.WithdrawRequest, error)
+}
+
+// TransferInput 用户转账输入；Recipient 为收款用户 ID 或邮箱。
+type TransferInput struct {
+	FromUserID    uint
+	Recipient     string
+	Amount        money.Amount
+	Currency      string
+	Remark        string
+	TwoFactorCode string
+}
+
+// WithdrawApplyInput 用户提现申请输入。
+type WithdrawApplyInput struct {
+	UserID   uint
+	Amount   money.Amount
+	Currency string
+	Channel  string
+	Account  string
+}
+
+// WithdrawListFilter 提现申请列表过滤条件。
+type WithdrawListFilter struct {
+	Page       int
+	PageSize   int
+	UserID     uint
+	Status     string
+	WithdrawNo string
+}
+
+// WithdrawReviewInput 后台审核提现输入。
+type WithdrawReviewInput struct {
+	WithdrawID      uint
+	AdminID         uint
+	Reason          string
+	PayoutReference string
+}
+
+// FundsHandler 处理用户余额转账、提现及后台提现审核请求。
+type FundsHandler struct {
+	funds    FundsService
+	settings SiteCurrencyReader
+}
+
+func NewFundsHandler(funds FundsService, settings SiteCurrencyReader) *FundsHandler {
+	if funds == nil {
+		panic("wallet funds handler: funds is nil")
+	}
+	return &FundsHandler{funds: funds, settings: settings}
+}
+
+type walletTransferRequest struct {
+	Recipient string `json:"recipient" binding:"required"`
+	Amount    string `json:"amount" binding:"required"`
+	Remark    string `json:"remark" binding:"max=255"`
+	TOTPCode  string `json:"totp_code" binding:"required"`
+}
+
+type walletWithdrawRequest struct {
+	Amount  string `json:"amount" binding:"required"`
+	Channel string `json:"channel" binding:"required"`
+	Account string `json:"account" binding:"required,max=255"`
+}
+
+type adminWalletWithdrawRejectRequest struct {
+	Reason string `json:"reason" binding:"max=255"`
+}
+
+type adminWalletWithdrawPayRequest struct {
+	PayoutReference string `json:"payout_reference" binding:"max=120"`
+}
+
+func respondFundsError(c *gin.Context, err error, fallbackKey string) {
+	switch {
+	case errors.Is(err, ErrInvalidAmount):
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", nil)
+	case errors.Is(err, ErrInsufficientBalance):
+		ginutil.RespondError(c, response.CodeBadRequest, "error.wallet_insufficient_balance", nil)
+	case errors.Is(err, ErrAmountBelowMinimum):
+		ginutil.RespondError(c, response.CodeBadRequest, "error.wallet_amount_below_minimum", nil)
+	case errors.Is(err, ErrTransferDisabled):
+		ginutil.RespondError(c, response.CodeBadRequest, "error.wallet_transfer_disabled", nil)
+	case errors.Is(err, ErrTransferRecipientNotFound):
+		ginutil.RespondError(c, response.CodeNotFound, "error.wallet_transfer_recipient_not_found", nil)
+	case errors.Is(err, ErrTransferToSelf):
+		ginutil.RespondError(c, response.CodeBadRequest, "error.wallet_transfer_to_self", nil)
+	case errors.Is(err, ErrTransferLimitExceeded):
+		ginutil.RespondError(c, response.CodeBadRequest, "error.wallet_transfer_limit_exceeded", nil)
+	case errors.Is(err, ErrTwoFactorRequired):
+		ginutil.RespondError(c, response.CodeBadRequest, "error.wallet_two_factor_required", nil)
+	case errors.Is(err, ErrTwoFactorInvalid):
+		ginutil.RespondError(c, response.CodeBadRequest, "error.wallet_two_factor_invalid", nil)
+	case errors.Is(err, ErrWithdrawDisabled):
+		ginutil.RespondError(c, response.CodeBadRequest, "error.wallet_withdraw_disabled", nil)
+	case errors.Is(err, ErrWithdrawChannelInvalid):
+		ginutil.RespondError(c, response.CodeBadRequest, "error.wallet_withdraw_channel_invalid", nil)
+	case errors.Is(err, ErrWithdrawLimitExceeded):
+		ginutil.RespondError(c, response.CodeBadRequest, "error.wallet_withdraw_limit_exceeded", nil)
+	case errors.Is(err, ErrWithdrawNotFound):
+		ginutil.RespondError(c, response.CodeNotFound, "error.wallet_withdraw_not_found", nil)
+	case errors.Is(err, ErrWithdrawStatusInvalid):
+		ginutil.RespondError(c, response.CodeBadRequest, "error.wallet_withdraw_status_invalid", nil)
+	case errors.Is(err, ErrWithdrawExceedsRecharged):
+		ginutil.RespondError(c, response.CodeBadRequest, "error.wallet_withdraw_exceeds_recharged", nil)
+	default:
+		ginutil.RespondError(c, response.CodeInternal, fallbackKey, err)
+	}
+}
+
+func parseFundsAmount(c *gin.Context, raw string) (money.Amount, bool) {
+	amount, err := decimal.NewFromString(strings.TrimSpace(raw))
+	if err != nil || amount.LessThanOrEqual(decimal.Zero) {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", err)
+		return money.Amount{}, false
+	}
+	return money.FromDecimal(amount), true
+}
+
+func (h *FundsHandler) siteCurrency() string {
+	if h.settings == nil {
+		return ""
+	}
+	currency, err := h.settings.GetSiteCurrency(constants.SiteCurrencyDefault)
+	if err != nil {
+		return ""
+	}
+	return currency
+}
+
+// CreateTransfer 用户向其他用户转账（需 TOTP 动态码）
+func (h *FundsHandler) CreateTransfer(c *gin.Context) {
+	uid, ok := ginutil.GetUserID(c)
+	if !ok {
+		return
+	}
+	var req walletTransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ginutil.RespondBindError(c, err)
+		return
+	}
+	amount, ok := parseFundsAmount(c, req.Amount)
+	if !ok {
+		return
+	}
+	transfer, err := h.funds.Transfer(TransferInput{
+		FromUserID:    uid,
+		Recipient:     req.Recipient,
+		Amount:        amount,
+		Currency:      h.siteCurrency(),
+		Remark:        req.Remark,
+		TwoFactorCode: req.TOTPCode,
+	})
+	if err != nil {
+		respondFundsError(c, err, "error.wallet_transfer_failed")
+		return
+	}
+	response.Success(c, walletpresenter.NewWalletTransferResp(transfer, uid))
+}
+
+// ListTransfers 用户转账记录（含转出与转入）
+func (h *FundsHandler) ListTransfers(c *gin.Context) {
+	uid, ok := ginutil.GetUserID(c)
+	if !ok {
+		return
+	}
+	page, pageSize := ginutil.ParsePagination(c)
+	transfers, total, err := h.funds.ListTransfers(uid, page, pageSize)
+	if err != nil {
+		ginutil.RespondError(c, response.CodeInternal, "error.user_fetch_failed", err)
+		return
+	}
+	response.SuccessWithPage(c, walletpresenter.NewWalletTransferRespList(transfers, uid), response.BuildPagination(page, pageSize, total))
+}
+
+// ApplyWithdraw 用户申请余额提现
+func (h *FundsHandler) ApplyWithdraw(c *gin.Context) {
+	uid, ok := ginutil.GetUserID(c)
+	if !ok {
+		return
+	}
+	var req walletWithdrawRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ginutil.RespondBindError(c, err)
+		return
+	}
+	amount, ok := parseFundsAmount(c, req.Amount)
+	if !ok {
+		return
+	}
+	request, err := h.funds.ApplyWithdraw(WithdrawApplyInput{
+		UserID:   uid,
+		Amount:   amount,
+		Currency: h.siteCurrency(),
+		Channel:  req.Channel,
+		Account:  req.Account,
+	})
+	if err != nil {
+		respondFundsError(c, err, "error.wallet_withdraw_failed")
+		return
+	}
+	response.Success(c, walletpresenter.NewWalletWithdrawResp(request))
+}
+
+// ListWithdraws 用户余额提现记录
+func (h *FundsHandler) ListWithdraws(c *gin.Context) {
+	uid, ok := ginutil.GetUserID(c)
+	if !ok {
+		return
+	}
+	page, pageSize := ginutil.ParsePagination(c)
+	requests, total, err := h.funds.ListWithdraws(WithdrawListFilter{
+		Page: page, PageSize: pageSize, UserID: uid, Status: strings.TrimSpace(c.Query("status")),
+	})
+	if err != nil {
+		ginutil.RespondError(c, response.CodeInternal, "error.user_fetch_failed", err)
+		return
+	}
+	response.SuccessWithPage(c, walletpresenter.NewWalletWithdrawRespList(requests), response.BuildPagination(page, pageSize, total))
+}
+
+// AdminListWithdraws 后台余额提现申请列表
+func (h *FundsHandler) AdminListWithdraws(c *gin.Context) {
+	page, pageSize := ginutil.ParsePagination(c)
+	var userID uint
+	if raw := strings.TrimSpace(c.Query("user_id")); raw != "" {
+		parsed, err := ginutil.ParseQueryUint(raw, true)
+		if err != nil {
+			ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", err)
+			return
+		}
+		userID = parsed
+	}
+	requests, total, err := h.funds.ListWithdraws(WithdrawListFilter{
+		Page:       page,
+		PageSize:   pageSize,
+		UserID:     userID,
+		Status:     strings.TrimSpace(c.Query("status")),
+		WithdrawNo: strings.TrimSpace(c.Query("withdraw_no")),
+	})
+	if err != nil {
+		respondFundsError(c, err, "error.wallet_withdraw_fetch_failed")
+		return
+	}
+	response.SuccessWithPage(c, requests, response.BuildPagination(page, pageSize, total))
+}
+
+// AdminRejectWithdraw 后台驳回提现，冻结金额退回可用余额
+func (h *FundsHandler) AdminRejectWithdraw(c *gin.Context) {
+	id, err := ginutil.ParseParamUint(c, "id")
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", err)
+		return
+	}
+	adminID, ok := ginutil.GetAdminID(c)
+	if !ok {
+		return
+	}
+	var req adminWalletWithdrawRejectRequest
+	// 允许空请求体
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		ginutil.RespondBindError(c, err)
+		return
+	}
+	request, err := h.funds.RejectWithdraw(WithdrawReviewInput{WithdrawID: id, AdminID: adminID, Reason: req.Reason})
+	if err != nil {
+		respondFundsError(c, err, "error.wallet_withdraw_review_failed")
+		return
+	}
+	response.Success(c, request)
+}
+
+// AdminPayWithdraw 后台确认提现已打款
+func (h *FundsHandler) AdminPayWithdraw(c *gin.Context) {
+	id, err := ginutil.ParseParamUint(c, "id")
+	if err != nil {
+		ginutil.RespondError(c, response.CodeBadRequest, "error.bad_request", err)
+		return
+	}
+	adminID, ok := ginutil.GetAdminID(c)
+	if !ok {
+		return
+	}
+	var req adminWalletWithdrawPayRequest
+	// 允许空请求体
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		ginutil.RespondBindError(c, err)
+		return
+	}
+	request, err := h.funds.PayWithdraw(WithdrawReviewInput{WithdrawID: id, AdminID: adminID, PayoutReference: req.PayoutReference})
+	if err != nil {
+		respondFundsError(c, err, "error.wallet_withdraw_review_failed")
+		return
+	}
+	response.Success(c, request)
+}
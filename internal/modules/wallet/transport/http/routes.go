@@ -28,6 +28,27 @@ func RegisterAdminRoutes(paymentProtected gin.IRoutes, handler *AdminHandler) {
 	paymentProtected.GET("/wallet/recharges", handler.GetRecharges)
 }
 
+// RegisterUserFundsRoutes 注册用户余额转账与提现端点。
+func RegisterUserFundsRoutes(user gin.IRoutes, handler *FundsHandler) {
+	if user == nil || handler == nil {
+		panic("wallet user funds routes: required dependency is nil")
+	}
+	user.POST("/wallet/transfers", handler.CreateTransfer)
+	user.GET("/wallet/transfers", handler.ListTransfers)
+	user.POST("/wallet/withdraws", handler.ApplyWithdraw)
+	user.GET("/wallet/withdraws", handler.ListWithdraws)
+}
+
+// RegisterAdminFundsRoutes 注册后台余额提现审核端点（须挂在 paymentProtected 分组）。
+func RegisterAdminFundsRoutes(paymentProtected gin.IRoutes, handler *FundsHandler) {
+	if paymentProtected == nil || handler == nil {
+		panic("wallet admin funds routes: required dependency is nil")
+	}
+	paymentProtected.GET("/wallet/withdraws", handler.AdminListWithdraws)
+	paymentProtected.POST("/wallet/withdraws/:id/reject", handler.AdminRejectWithdraw)
+	paymentProtected.POST("/wallet/withdraws/:id/pay", handler.AdminPayWithdraw)
+}
+
 // RegisterChannelRoutes 注册渠道钱包端点。
 func RegisterChannelRoutes(channel gin.IRoutes, handler *ChannelHandler) {
 	if channel == nil || handler == nil {
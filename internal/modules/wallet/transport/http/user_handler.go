@@ -45,6 +45,7 @@ var (
 // WalletService 是用户钱包查询所需的最小端口。
 type WalletService interface {
 	GetAccount(userID uint) (*walletdomain.Account, error)
+	ListCurrencyBalances(userID uint) ([]walletdomain.CurrencyBalance, error)
 	ListTransactions(userID uint, page, pageSize int) ([]walletdomain.Transaction, int64, error)
 	ListUserRechargeOrders(userID uint, page, pageSize int, status, rechargeNo string) ([]walletdomain.RechargeOrder, int64, error)
 	StatsUserRechargeOrders(userID uint, rechargeNo string) (map[string]int64, error)
@@ -155,7 +156,14 @@ func (h *UserHandler) GetWallet(c *gin.Context) {
 		ginutil.RespondError(c, response.CodeInternal, "error.user_fetch_failed", err)
 		return
 	}
-	response.Success(c, walletpresenter.NewWalletAccountResp(account))
+	balances, err := h.wallets.ListCurrencyBalances(uid)
+	if err != nil {
+		ginutil.RespondError(c, response.CodeInternal, "error.user_fetch_failed", err)
+		return
+	}
+	resp := walletpresenter.NewWalletAccountResp(account)
+	resp.CurrencyBalances = walletpresenter.NewWalletCurrencyBalanceList(balances)
+	response.Success(c, resp)
 }
 
 func (h *UserHandler) GetTransactions(c *gin.Context) {
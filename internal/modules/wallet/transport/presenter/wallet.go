@@ -13,16 +13,37 @@ import (
 
 // WalletAccountResp 钱包账户响应
 type WalletAccountResp struct {
-	Balance money.Amount `json:"balance"`
+	Balance          money.Amount                `json:"balance"`
+	LockedBalance    money.Amount                `json:"locked_balance"`
+	CurrencyBalances []WalletCurrencyBalanceResp `json:"currency_balances,omitempty"`
+}
+
+// WalletCurrencyBalanceResp 非站点币种余额响应
+type WalletCurrencyBalanceResp struct {
+	Currency string       `json:"currency"`
+	Balance  money.Amount `json:"balance"`
 }
 
 // NewWalletAccountResp 从 walletdomain.Account 构造响应
 func NewWalletAccountResp(a *walletdomain.Account) WalletAccountResp {
 	return WalletAccountResp{
-		Balance: a.Balance,
+		Balance:       a.Balance,
+		LockedBalance: a.LockedBalance,
 	}
 }
 
+// NewWalletCurrencyBalanceList 构造币种余额列表，零余额不返回
+func NewWalletCurrencyBalanceList(balances []walletdomain.CurrencyBalance) []WalletCurrencyBalanceResp {
+	result := make([]WalletCurrencyBalanceResp, 0, len(balances))
+	for _, balance := range balances {
+		if balance.Balance.Decimal.IsZero() {
+			continue
+		}
+		result = append(result, WalletCurrencyBalanceResp{Currency: balance.Currency, Balance: balance.Balance})
+	}
+	return result
+}
+
 // WalletTransactionResp 钱包流水响应
 type WalletTransactionResp struct {
 	ID           uint         `json:"id"`
@@ -155,3 +176,82 @@ func NewWalletRechargePaymentPayload(recharge *walletdomain.RechargeOrder, payme
 	// 排除 Payment 的：OrderID、ChannelID、Amount、FeeRate、FixedFee、FeeAmount、Currency、
 	// ProviderRef、GatewayOrderNo、ProviderPayload、CreatedAt、UpdatedAt、PaidAt、CallbackAt
 }
+
+// WalletTransferResp 用户视角的转账记录响应
+type WalletTransferResp struct {
+	TransferNo         string       `json:"transfer_no"`
+	Direction          string       `json:"direction"` // out/in，相对当前用户
+	CounterpartyUserID uint         `json:"counterparty_user_id"`
+	Amount             money.Amount `json:"amount"`
+	Fee                money.Amount `json:"fee"`
+	Currency           string       `json:"currency"`
+	Remark             string       `json:"remark"`
+	CreatedAt          time.Time    `json:"created_at"`
+}
+
+// NewWalletTransferResp 从 walletdomain.Transfer 构造当前用户视角的响应；收款方不展示手续费
+func NewWalletTransferResp(t *walletdomain.Transfer, viewerUserID uint) WalletTransferResp {
+	resp := WalletTransferResp{
+		TransferNo:         t.TransferNo,
+		Direction:          "out",
+		CounterpartyUserID: t.ToUserID,
+		Amount:             t.Amount,
+		Fee:                t.Fee,
+		Currency:           t.Currency,
+		Remark:             t.Remark,
+		CreatedAt:          t.CreatedAt,
+	}
+	if t.FromUserID != viewerUserID {
+		resp.Direction = "in"
+		resp.CounterpartyUserID = t.FromUserID
+		resp.Fee = money.Amount{}
+	}
+	return resp
+}
+
+// NewWalletTransferRespList 批量转换转账记录
+func NewWalletTransferRespList(transfers []walletdomain.Transfer, viewerUserID uint) []WalletTransferResp {
+	result := make([]WalletTransferResp, 0, len(transfers))
+	for i := range transfers {
+		result = append(result, NewWalletTransferResp(&transfers[i], viewerUserID))
+	}
+	return result
+}
+
+// WalletWithdrawResp 用户余额提现申请响应
+type WalletWithdrawResp struct {
+	WithdrawNo   string       `json:"withdraw_no"`
+	Amount       money.Amount `json:"amount"`
+	Currency     string       `json:"currency"`
+	Channel      string       `json:"channel"`
+	Account      string       `json:"account"`
+	Status       string       `json:"status"`
+	RejectReason string       `json:"reject_reason"`
+	ProcessedAt  *time.Time   `json:"processed_at"`
+	CreatedAt    time.Time    `json:"created_at"`
+}
+
+// NewWalletWithdrawResp 从 walletdomain.WithdrawRequest 构造响应
+func NewWalletWithdrawResp(r *walletdomain.WithdrawRequest) WalletWithdrawResp {
+	return WalletWithdrawResp{
+		WithdrawNo:   r.WithdrawNo,
+		Amount:       r.Amount,
+		Currency:     r.Currency,
+		Channel:      r.Channel,
+		Account:      r.Account,
+		Status:       r.Status,
+		RejectReason: r.RejectReason,
+		ProcessedAt:  r.ProcessedAt,
+		CreatedAt:    r.CreatedAt,
+	}
+	// 排除：UserID、流水 ID、PayoutReference、ProcessedBy
+}
+
+// NewWalletWithdrawRespList 批量转换提现申请
+func NewWalletWithdrawRespList(requests []walletdomain.WithdrawRequest) []WalletWithdrawResp {
+	result := make([]WalletWithdrawResp, 0, len(requests))
+	for i := range requests {
+		result = append(result, NewWalletWithdrawResp(&requests[i]))
+	}
+	return result
+}
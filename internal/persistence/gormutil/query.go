@@ -4,13 +4,11 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/dujiao-next/internal/constants"
+	"github.com/dujiao-next/internal/i18n/locales"
 
 	"gorm.io/gorm"
 )
 
-var localizedJSONSearchKeys = append([]string(nil), constants.SupportedLocales...)
-
 func DialectName(db *gorm.DB) string {
 	if db == nil || db.Dialector == nil {
 		return "sqlite"
@@ -54,7 +52,7 @@ func BuildLocalizedLikeCondition(db *gorm.DB, plainColumns, jsonColumns []string
 }
 
 func BuildLocalizedLikeConditionByDialect(dialect string, plainColumns, jsonColumns []string) (string, int) {
-	parts := make([]string, 0, len(plainColumns)+len(jsonColumns)*len(localizedJSONSearchKeys))
+	parts := make([]string, 0, len(plainColumns)+len(jsonColumns)*len(locales.Enabled()))
 	argCount := 0
 	operator := "LIKE"
 	if normalized := strings.ToLower(strings.TrimSpace(dialect)); normalized == "postgres" || normalized == "postgresql" {
@@ -75,7 +73,7 @@ func BuildLocalizedLikeConditionByDialect(dialect string, plainColumns, jsonColu
 		if trimmed == "" {
 			continue
 		}
-		for _, key := range localizedJSONSearchKeys {
+		for _, key := range locales.Enabled() {
 			parts = append(parts, fmt.Sprintf("%s %s ?", JSONTextExprByDialect(dialect, trimmed, key), operator))
 			argCount++
 		}
@@ -26,6 +26,16 @@ func IsSuperAdmin(c *gin.Context) bool {
 	return b
 }
 
+// GetRateLimitCount 读取限流中间件注入的当前窗口请求计数；未经过限流中间件时返回 0。
+func GetRateLimitCount(c *gin.Context) int64 {
+	v, ok := c.Get("rate_limit_count")
+	if !ok {
+		return 0
+	}
+	count, _ := v.(int64)
+	return count
+}
+
 // GetContextUintWithKeys 从上下文读取 uint 值并统一处理错误响应。
 func GetContextUintWithKeys(c *gin.Context, key, invalidKey, typeInvalidKey string) (uint, bool) {
 	value, exists := c.Get(key)
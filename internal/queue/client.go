@@ -1,6 +1,7 @@
 package queue
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -178,6 +179,96 @@ func (c *Client) EnqueueDownstreamCallback(payload DownstreamCallbackPayload, op
 	return err
 }
 
+// EnqueueFulfillmentWebhookDispatch 推送 webhook 交付任务
+func (c *Client) EnqueueFulfillmentWebhookDispatch(payload FulfillmentWebhookDispatchPayload, opts ...asynq.Option) error {
+	if !c.Enabled() {
+		return nil
+	}
+	task, err := NewFulfillmentWebhookDispatchTask(payload)
+	if err != nil {
+		return err
+	}
+	options := append([]asynq.Option{asynq.Queue(c.defaultQueue)}, opts...)
+	_, err = c.client.Enqueue(task, options...)
+	return err
+}
+
+// EnqueueFulfillmentFileDeliver 推送文件交付任务
+func (c *Client) EnqueueFulfillmentFileDeliver(payload FulfillmentOrderDeliverPayload, opts ...asynq.Option) error {
+	if !c.Enabled() {
+		return nil
+	}
+	task, err := NewFulfillmentFileDeliverTask(payload)
+	if err != nil {
+		return err
+	}
+	options := append([]asynq.Option{asynq.Queue(c.defaultQueue)}, opts...)
+	_, err = c.client.Enqueue(task, options...)
+	return err
+}
+
+// EnqueueFulfillmentLicenseDeliver 推送授权码交付任务
+func (c *Client) EnqueueFulfillmentLicenseDeliver(payload FulfillmentOrderDeliverPayload, opts ...asynq.Option) error {
+	if !c.Enabled() {
+		return nil
+	}
+	task, err := NewFulfillmentLicenseDeliverTask(payload)
+	if err != nil {
+		return err
+	}
+	options := append([]asynq.Option{asynq.Queue(c.defaultQueue)}, opts...)
+	_, err = c.client.Enqueue(task, options...)
+	return err
+}
+
+// EnqueueRestockCheck 入队到货检查任务；同一商品在窗口期内只保留一个待执行任务
+func (c *Client) EnqueueRestockCheck(payload RestockCheckPayload, opts ...asynq.Option) error {
+	if !c.Enabled() {
+		return nil
+	}
+	task, err := NewRestockCheckTask(payload)
+	if err != nil {
+		return err
+	}
+	options := append([]asynq.Option{asynq.Queue(c.defaultQueue)}, opts...)
+	_, err = c.client.Enqueue(task, options...)
+	if errors.Is(err, asynq.ErrDuplicateTask) || errors.Is(err, asynq.ErrTaskIDConflict) {
+		return nil
+	}
+	return err
+}
+
+// EnqueueRestockDeliver 入队到货通知投递任务
+func (c *Client) EnqueueRestockDeliver(payload RestockDeliverPayload, opts ...asynq.Option) error {
+	if !c.Enabled() {
+		return nil
+	}
+	task, err := NewRestockDeliverTask(payload)
+	if err != nil {
+		return err
+	}
+	options := append([]asynq.Option{asynq.Queue(c.defaultQueue), asynq.MaxRetry(5)}, opts...)
+	_, err = c.client.Enqueue(task, options...)
+	return err
+}
+
+// EnqueuePreorderAllocate 入队预售排队分配任务；同一商品在窗口期内只保留一个待执行任务
+func (c *Client) EnqueuePreorderAllocate(payload PreorderAllocatePayload, opts ...asynq.Option) error {
+	if !c.Enabled() {
+		return nil
+	}
+	task, err := NewPreorderAllocateTask(payload)
+	if err != nil {
+		return err
+	}
+	options := append([]asynq.Option{asynq.Queue(c.defaultQueue)}, opts...)
+	_, err = c.client.Enqueue(task, options...)
+	if errors.Is(err, asynq.ErrDuplicateTask) || errors.Is(err, asynq.ErrTaskIDConflict) {
+		return nil
+	}
+	return err
+}
+
 // EnqueueReconciliationRun 入队对账执行任务
 func (c *Client) EnqueueReconciliationRun(payload ReconciliationRunPayload, opts ...asynq.Option) error {
 	if !c.Enabled() {
@@ -23,6 +23,12 @@ const (
 	TaskAffiliateConfirmCommissions = constants.TaskAffiliateConfirmCommissions
 	// TaskResellerConfirmLedger 分销商账务到期确认任务
 	TaskResellerConfirmLedger = constants.TaskResellerConfirmLedger
+	// TaskOrderReviewSLACheck 人工复核超时巡检任务
+	TaskOrderReviewSLACheck = constants.TaskOrderReviewSLACheck
+	// TaskMemberLevelEvaluate 会员等级到期与窗口评估任务
+	TaskMemberLevelEvaluate = constants.TaskMemberLevelEvaluate
+	// TaskResellerDomainRecheck 分销自定义域名周期复查任务
+	TaskResellerDomainRecheck = constants.TaskResellerDomainRecheck
 	// TaskUpstreamSyncStock 上游库存同步任务
 	TaskUpstreamSyncStock = constants.TaskUpstreamSyncStock
 	// TaskProcurementSubmit 采购提交任务
@@ -31,8 +37,28 @@ const (
 	TaskProcurementPollStatus = constants.TaskProcurementPollStatus
 	// TaskProcurementSyncAccepted 采购单定时巡检任务
 	TaskProcurementSyncAccepted = constants.TaskProcurementSyncAccepted
+	// TaskProcurementSyncRefunds 已交付采购单上游退款巡检任务
+	TaskProcurementSyncRefunds = constants.TaskProcurementSyncRefunds
 	// TaskDownstreamCallback 下游回调通知任务
 	TaskDownstreamCallback = constants.TaskDownstreamCallback
+	// TaskFulfillmentWebhookDispatch webhook 交付推送任务
+	TaskFulfillmentWebhookDispatch = constants.TaskFulfillmentWebhookDispatch
+	// TaskFulfillmentFileDeliver 文件交付任务
+	TaskFulfillmentFileDeliver = constants.TaskFulfillmentFileDeliver
+	// TaskFulfillmentLicenseDeliver 授权码交付任务
+	TaskFulfillmentLicenseDeliver = constants.TaskFulfillmentLicenseDeliver
+	// TaskRestockCheck 到货检查任务
+	TaskRestockCheck = constants.TaskRestockCheck
+	// TaskRestockDeliver 到货通知投递任务
+	TaskRestockDeliver = constants.TaskRestockDeliver
+	// TaskPreorderAllocate 预售排队分配任务
+	TaskPreorderAllocate = constants.TaskPreorderAllocate
+	// TaskPreorderSweep 预售逾期巡检任务
+	TaskPreorderSweep = constants.TaskPreorderSweep
+	// TaskStockLedgerCheck 库存台账一致性巡检任务
+	TaskStockLedgerCheck = constants.TaskStockLedgerCheck
+	// TaskProductChangeSetRun 商品变更集到期生效与回滚任务
+	TaskProductChangeSetRun = constants.TaskProductChangeSetRun
 	// TaskReconciliationRun 对账执行任务
 	TaskReconciliationRun = constants.TaskReconciliationRun
 	// TaskBotNotify Bot 交付通知任务
@@ -140,6 +166,21 @@ func NewResellerConfirmLedgerTask() *asynq.Task {
 	return asynq.NewTask(TaskResellerConfirmLedger, nil)
 }
 
+// NewOrderReviewSLACheckTask 创建人工复核超时巡检任务
+func NewOrderReviewSLACheckTask() *asynq.Task {
+	return asynq.NewTask(TaskOrderReviewSLACheck, nil)
+}
+
+// NewMemberLevelEvaluateTask 创建会员等级到期与窗口评估任务
+func NewMemberLevelEvaluateTask() *asynq.Task {
+	return asynq.NewTask(TaskMemberLevelEvaluate, nil)
+}
+
+// NewResellerDomainRecheckTask 创建分销自定义域名周期复查任务
+func NewResellerDomainRecheckTask() *asynq.Task {
+	return asynq.NewTask(TaskResellerDomainRecheck, nil)
+}
+
 // NewUpstreamSyncStockTask 创建上游库存同步任务
 func NewUpstreamSyncStockTask() *asynq.Task {
 	return asynq.NewTask(TaskUpstreamSyncStock, nil)
@@ -150,6 +191,11 @@ func NewProcurementSyncAcceptedTask() *asynq.Task {
 	return asynq.NewTask(TaskProcurementSyncAccepted, nil)
 }
 
+// NewProcurementSyncRefundsTask 创建已交付采购单上游退款巡检任务
+func NewProcurementSyncRefundsTask() *asynq.Task {
+	return asynq.NewTask(TaskProcurementSyncRefunds, nil)
+}
+
 // ProcurementSubmitPayload 采购提交任务载荷
 type ProcurementSubmitPayload struct {
 	ProcurementOrderID uint `json:"procurement_order_id"`
@@ -194,7 +240,8 @@ func NewReconciliationRunTask(payload ReconciliationRunPayload) (*asynq.Task, er
 
 // DownstreamCallbackPayload 下游回调通知任务载荷
 type DownstreamCallbackPayload struct {
-	DownstreamOrderRefID uint `json:"downstream_order_ref_id"`
+	DownstreamOrderRefID uint   `json:"downstream_order_ref_id"`
+	Trigger              string `json:"trigger,omitempty"`
 }
 
 // NewDownstreamCallbackTask 创建下游回调通知任务
@@ -206,6 +253,100 @@ func NewDownstreamCallbackTask(payload DownstreamCallbackPayload) (*asynq.Task,
 	return asynq.NewTask(TaskDownstreamCallback, body), nil
 }
 
+// FulfillmentWebhookDispatchPayload webhook 交付推送任务载荷
+type FulfillmentWebhookDispatchPayload struct {
+	DeliveryID uint `json:"delivery_id"`
+}
+
+// NewFulfillmentWebhookDispatchTask 创建 webhook 交付推送任务
+func NewFulfillmentWebhookDispatchTask(payload FulfillmentWebhookDispatchPayload) (*asynq.Task, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	return asynq.NewTask(TaskFulfillmentWebhookDispatch, body), nil
+}
+
+// FulfillmentOrderDeliverPayload 文件/授权码交付任务载荷
+type FulfillmentOrderDeliverPayload struct {
+	OrderID uint `json:"order_id"`
+}
+
+// NewFulfillmentFileDeliverTask 创建文件交付任务
+func NewFulfillmentFileDeliverTask(payload FulfillmentOrderDeliverPayload) (*asynq.Task, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	return asynq.NewTask(TaskFulfillmentFileDeliver, body), nil
+}
+
+// NewFulfillmentLicenseDeliverTask 创建授权码交付任务
+func NewFulfillmentLicenseDeliverTask(payload FulfillmentOrderDeliverPayload) (*asynq.Task, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	return asynq.NewTask(TaskFulfillmentLicenseDeliver, body), nil
+}
+
+// RestockCheckPayload 到货检查任务载荷
+type RestockCheckPayload struct {
+	ProductID uint `json:"product_id"`
+}
+
+// NewRestockCheckTask 创建到货检查任务
+func NewRestockCheckTask(payload RestockCheckPayload) (*asynq.Task, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	return asynq.NewTask(TaskRestockCheck, body), nil
+}
+
+// RestockDeliverPayload 到货通知投递任务载荷
+type RestockDeliverPayload struct {
+	SubscriptionID uint `json:"subscription_id"`
+}
+
+// NewRestockDeliverTask 创建到货通知投递任务
+func NewRestockDeliverTask(payload RestockDeliverPayload) (*asynq.Task, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	return asynq.NewTask(TaskRestockDeliver, body), nil
+}
+
+// PreorderAllocatePayload 预售排队分配任务载荷
+type PreorderAllocatePayload struct {
+	ProductID uint `json:"product_id"`
+}
+
+// NewPreorderAllocateTask 创建预售排队分配任务
+func NewPreorderAllocateTask(payload PreorderAllocatePayload) (*asynq.Task, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	return asynq.NewTask(TaskPreorderAllocate, body), nil
+}
+
+// NewPreorderSweepTask 创建预售逾期巡检任务
+func NewPreorderSweepTask() *asynq.Task {
+	return asynq.NewTask(TaskPreorderSweep, nil)
+}
+
+// NewStockLedgerCheckTask 创建库存台账一致性巡检任务
+func NewStockLedgerCheckTask() *asynq.Task {
+	return asynq.NewTask(TaskStockLedgerCheck, nil)
+}
+
+// NewProductChangeSetRunTask 创建商品变更集到期执行任务
+func NewProductChangeSetRunTask() *asynq.Task {
+	return asynq.NewTask(TaskProductChangeSetRun, nil)
+}
+
 // BotNotifyPayload Bot 交付通知任务载荷
 type BotNotifyPayload struct {
 	EventType      string `json:"event_type,omitempty"`
@@ -0,0 +1,156 @@
+package gormuow
+
+import (
+	"errors"
+
+	"github.com/dujiao-next/internal/constants"
+	affiliateapp "github.com/dujiao-next/internal/modules/affiliate/application"
+	affiliategormstore "github.com/dujiao-next/internal/modules/affiliate/infrastructure/gormstore"
+	productcontract "github.com/dujiao-next/internal/modules/catalog/product/contract"
+	payoutcontract "github.com/dujiao-next/internal/modules/payout/contract"
+	payoutdomain "github.com/dujiao-next/internal/modules/payout/domain"
+	"github.com/dujiao-next/internal/modules/payout/infrastructure/gormstore"
+	resellerapp "github.com/dujiao-next/internal/modules/reseller/application"
+	resellercontract "github.com/dujiao-next/internal/modules/reseller/contract"
+	resellergormstore "github.com/dujiao-next/internal/modules/reseller/infrastructure/gormstore"
+	walletapp "github.com/dujiao-next/internal/modules/wallet/application"
+	walletcontract "github.com/dujiao-next/internal/modules/wallet/contract"
+	walletdomain "github.com/dujiao-next/internal/modules/wallet/domain"
+	walletgormstore "github.com/dujiao-next/internal/modules/wallet/infrastructure/gormstore"
+
+	"gorm.io/gorm"
+)
+
+// Runner 将打款批次、推广/分销提现申请与钱包入账绑定到同一个 GORM 事务。
+type Runner struct {
+	batches    *gormstore.Store
+	affiliates *affiliateapp.Service
+	resellers  *resellerapp.AccountingWithdrawService
+	wallet     *walletapp.Service
+}
+
+func New(
+	batches *gormstore.Store,
+	affiliates *affiliateapp.Service,
+	resellers *resellerapp.AccountingWithdrawService,
+	wallet *walletapp.Service,
+) *Runner {
+	return &Runner{batches: batches, affiliates: affiliates, resellers: resellers, wallet: wallet}
+}
+
+func (r *Runner) WithinPayoutTransaction(fn func(tx payoutcontract.Transaction) error) error {
+	if r == nil || r.batches == nil || r.affiliates == nil || r.resellers == nil || r.wallet == nil {
+		return payoutcontract.ErrUnavailable
+	}
+	return r.batches.Transaction(func(tx *gorm.DB) error {
+		return fn(&transaction{
+			db:         tx,
+			batches:    r.batches.WithTx(tx),
+			affiliates: r.affiliates,
+			resellers:  r.resellers,
+			wallet:     r.wallet,
+		})
+	})
+}
+
+type transaction struct {
+	db         *gorm.DB
+	batches    *gormstore.Store
+	affiliates *affiliateapp.Service
+	resellers  *resellerapp.AccountingWithdrawService
+	wallet     *walletapp.Service
+}
+
+func (tx *transaction) Batches() payoutcontract.Store {
+	return tx.batches
+}
+
+func (tx *transaction) LockApprovedWithdraws(source string, ids []uint) ([]payoutcontract.WithdrawCandidate, error) {
+	candidates := make([]payoutcontract.WithdrawCandidate, 0, len(ids))
+	switch source {
+	case payoutdomain.SourceAffiliate:
+		rows, err := tx.affiliates.LockApprovedWithdrawsForPayout(affiliategormstore.New(tx.db), ids)
+		if err != nil {
+			return nil, mapWithdrawError(err)
+		}
+		for _, row := range rows {
+			candidates = append(candidates, payoutcontract.WithdrawCandidate{
+				WithdrawRequestID: row.Request.ID,
+				RecipientUserID:   row.UserID,
+				Amount:            row.Request.Amount,
+				Channel:           row.Request.Channel,
+				Account:           row.Request.Account,
+			})
+		}
+	case payoutdomain.SourceReseller:
+		rows, err := tx.resellers.LockApprovedWithdrawsForPayout(resellergormstore.New(tx.db), ids)
+		if err != nil {
+			return nil, mapWithdrawError(err)
+		}
+		for _, row := range rows {
+			candidates = append(candidates, payoutcontract.WithdrawCandidate{
+				WithdrawRequestID: row.Request.ID,
+				RecipientUserID:   row.UserID,
+				Amount:            row.Request.Amount,
+				Currency:          row.Request.Currency,
+				Channel:           row.Request.Channel,
+				Account:           row.Request.Account,
+			})
+		}
+	default:
+		return nil, payoutcontract.ErrBatchInvalid
+	}
+	return candidates, nil
+}
+
+func (tx *transaction) AssignWithdrawBatch(source string, withdrawID, batchID uint) error {
+	switch source {
+	case payoutdomain.SourceAffiliate:
+		return mapWithdrawError(tx.affiliates.AssignWithdrawPayoutBatch(affiliategormstore.New(tx.db), withdrawID, batchID))
+	case payoutdomain.SourceReseller:
+		return mapWithdrawError(tx.resellers.AssignWithdrawPayoutBatch(resellergormstore.New(tx.db), withdrawID, batchID))
+	default:
+		return payoutcontract.ErrBatchInvalid
+	}
+}
+
+func (tx *transaction) SettleWithdraw(source string, adminID, withdrawID, batchID uint, reference string) error {
+	switch source {
+	case payoutdomain.SourceAffiliate:
+		return mapWithdrawError(tx.affiliates.SettleWithdrawPayout(affiliategormstore.New(tx.db), adminID, withdrawID, batchID, reference))
+	case payoutdomain.SourceReseller:
+		return mapWithdrawError(tx.resellers.SettleWithdrawPayout(resellergormstore.New(tx.db), adminID, withdrawID, batchID, reference))
+	default:
+		return payoutcontract.ErrBatchInvalid
+	}
+}
+
+func (tx *transaction) CreditWallet(input payoutcontract.WalletCreditInput) (*walletdomain.Transaction, error) {
+	_, txn, err := tx.wallet.CreditInTransaction(walletgormstore.UseTransaction(tx.db), walletcontract.CreditInput{
+		UserID:    input.UserID,
+		Amount:    input.Amount,
+		Currency:  input.Currency,
+		Type:      constants.WalletTxnTypePayout,
+		Reference: input.Reference,
+		Remark:    input.Remark,
+	})
+	return txn, err
+}
+
+// mapWithdrawError 将来源模块的状态错误统一为打款端口错误。
+func mapWithdrawError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, affiliateapp.ErrNotFound),
+		errors.Is(err, affiliateapp.ErrWithdrawStatusInvalid),
+		errors.Is(err, productcontract.ErrNotFound),
+		errors.Is(err, resellercontract.ErrWithdrawStatusInvalid):
+		return payoutcontract.ErrWithdrawUnavailable
+	default:
+		return err
+	}
+}
+
+var _ payoutcontract.TransactionRunner = (*Runner)(nil)
+var _ payoutcontract.Transaction = (*transaction)(nil)